@@ -121,6 +121,9 @@ type serializedKnownAddress struct {
 	TimeStamp   int64
 	LastAttempt int64
 	LastSuccess int64
+
+	// Services was added in version 2.
+	Services wire.ServiceFlag
 }
 
 // serializedAddrManager is used to represent the serializable state of an
@@ -230,7 +233,10 @@ const (
 	getKnownAddressPercentage = 23
 
 	// serialisationVersion is the current version of the on-disk format.
-	serialisationVersion = 1
+	//
+	// Version 2 adds the services of each address and restores the last time
+	// each address was seen from the serialized timestamp.
+	serialisationVersion = 2
 )
 
 // addOrUpdateAddress is a helper function to either update an address already known
@@ -442,6 +448,12 @@ func (a *AddrManager) savePeers() {
 		return
 	}
 
+	// Compact the new buckets by removing stale addresses prior to saving so
+	// they do not accumulate in the peers file.
+	if numRemoved := a.compactNew(); numRemoved > 0 {
+		log.Debugf("Removed %d stale addresses prior to saving", numRemoved)
+	}
+
 	// First we make a serialisable data structure so we can encode it to JSON.
 	sam := new(serializedAddrManager)
 	sam.Version = serialisationVersion
@@ -457,6 +469,7 @@ func (a *AddrManager) savePeers() {
 		ska.Attempts = v.attempts
 		ska.LastAttempt = v.lastattempt.Unix()
 		ska.LastSuccess = v.lastsuccess.Unix()
+		ska.Services = v.na.Services
 		// Tried and refs are implicit in the rest of the structure
 		// and will be worked out from context on unserialisation.
 		sam.Addresses[i] = ska
@@ -502,8 +515,36 @@ func (a *AddrManager) savePeers() {
 	a.addrChanged = false
 }
 
-// loadPeers loads the known addresses from a saved file.  If the file is empty,
-// missing, or malformed then no known addresses will be added to the address
+// compactNew removes all addresses in the new buckets that are considered bad
+// and returns the number of addresses that were removed from the address
+// manager as a result.
+//
+// This function MUST be called with the address manager lock held (for writes).
+func (a *AddrManager) compactNew() int {
+	var numRemoved int
+	for bucket := range a.addrNew {
+		for k, v := range a.addrNew[bucket] {
+			if !v.isBad() {
+				continue
+			}
+
+			delete(a.addrNew[bucket], k)
+			a.addrChanged = true
+			v.refs--
+			if v.refs == 0 {
+				a.nNew--
+				delete(a.addrIndex, k)
+				numRemoved++
+			}
+		}
+	}
+	return numRemoved
+}
+
+// loadPeers loads the known addresses from a saved file.  Valid entries are
+// salvaged from files that are only partially readable or contain invalid
+// entries.  If the file is empty, missing, of an unknown version, or contains
+// no salvageable entries then no known addresses will be added to the address
 // manager from a call to this method.
 func (a *AddrManager) loadPeers() {
 	a.mtx.Lock()
@@ -524,6 +565,115 @@ func (a *AddrManager) loadPeers() {
 	log.Infof("Loaded %d addresses from file '%s'", a.numAddresses(), a.peersFile)
 }
 
+// serializedMigrations houses the functions that migrate the serialized state
+// of an address manager to the next version.  The function at each index
+// migrates from the version one more than the index.
+var serializedMigrations = []func(sam *serializedAddrManager){
+	// Version 1 to 2.
+	func(sam *serializedAddrManager) {
+		// Version 1 did not store the services of each address and they
+		// were assumed to be full nodes.
+		for _, v := range sam.Addresses {
+			v.Services = wire.SFNodeNetwork
+		}
+	},
+}
+
+// migrateSerialized migrates the provided serialized state of an address
+// manager from its version to the current serialization version.
+func migrateSerialized(sam *serializedAddrManager) error {
+	if sam.Version < 1 || sam.Version > serialisationVersion {
+		return fmt.Errorf("unknown version %v in serialized "+
+			"addrmanager", sam.Version)
+	}
+	for sam.Version < serialisationVersion {
+		log.Infof("Migrating peers file from version %d to %d",
+			sam.Version, sam.Version+1)
+		serializedMigrations[sam.Version-1](sam)
+		sam.Version++
+	}
+	return nil
+}
+
+// expectJSONDelim reads the next token from the provided decoder and returns an
+// error if it is not the given delimiter.
+func expectJSONDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("unexpected token %v (expected %v)", tok, delim)
+	}
+	return nil
+}
+
+// decodeSerializedAddrManager decodes the serialized state of an address
+// manager from the provided reader.  The addresses are decoded individually so
+// that the returned state contains all entries that precede any corruption
+// along with the error that describes the corruption.
+func decodeSerializedAddrManager(r io.Reader) (*serializedAddrManager, error) {
+	var sam serializedAddrManager
+	dec := json.NewDecoder(r)
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		return &sam, err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return &sam, err
+		}
+		field, ok := tok.(string)
+		if !ok {
+			return &sam, fmt.Errorf("unexpected token %v", tok)
+		}
+
+		switch field {
+		case "Version":
+			err = dec.Decode(&sam.Version)
+
+		case "Key":
+			err = dec.Decode(&sam.Key)
+
+		case "Addresses":
+			if err := expectJSONDelim(dec, '['); err != nil {
+				return &sam, err
+			}
+			for dec.More() {
+				var ska serializedKnownAddress
+				if err := dec.Decode(&ska); err != nil {
+					return &sam, err
+				}
+				sam.Addresses = append(sam.Addresses, &ska)
+			}
+			err = expectJSONDelim(dec, ']')
+
+		case "NewBuckets":
+			err = dec.Decode(&sam.NewBuckets)
+
+		case "TriedBuckets":
+			err = dec.Decode(&sam.TriedBuckets)
+
+		default:
+			var unknown json.RawMessage
+			err = dec.Decode(&unknown)
+		}
+		if err != nil {
+			return &sam, err
+		}
+	}
+	return &sam, expectJSONDelim(dec, '}')
+}
+
+// deserializePeers loads the known addresses from the provided peers file.
+//
+// Entries that are invalid or inconsistent with the rest of the file are
+// skipped and all valid entries that precede any corruption in the file are
+// salvaged.  Salvaged addresses that are no longer referenced by any bucket are
+// added to the new buckets.  An error is only returned when the file can't be
+// read or is of an unknown version.
+//
+// This function MUST be called with the address manager lock held (for writes).
 func (a *AddrManager) deserializePeers(filePath string) error {
 	_, err := os.Stat(filePath)
 	if os.IsNotExist(err) {
@@ -535,30 +685,43 @@ func (a *AddrManager) deserializePeers(filePath string) error {
 	}
 	defer r.Close()
 
-	var sam serializedAddrManager
-	dec := json.NewDecoder(r)
-	err = dec.Decode(&sam)
-	if err != nil {
-		return fmt.Errorf("error reading %s: %v", filePath, err)
+	sam, decodeErr := decodeSerializedAddrManager(r)
+	if decodeErr != nil && len(sam.Addresses) == 0 {
+		return fmt.Errorf("error reading %s: %v", filePath, decodeErr)
+	}
+	if decodeErr != nil && sam.Version == 0 {
+		// The version precedes the addresses in files written by the
+		// address manager, so assume the file is the current version when
+		// addresses were salvaged without one.
+		sam.Version = serialisationVersion
+	}
+	if err := migrateSerialized(sam); err != nil {
+		return err
 	}
 
-	if sam.Version != serialisationVersion {
-		return fmt.Errorf("unknown version %v in serialized "+
-			"addrmanager", sam.Version)
+	// Only use the serialized key when it was decoded since the buckets
+	// addresses are assigned to are derived from it.
+	if sam.Key != [32]byte{} {
+		copy(a.key[:], sam.Key[:])
 	}
-	copy(a.key[:], sam.Key[:])
 
+	var numSkipped int
 	for _, v := range sam.Addresses {
 		netAddr, err := a.newAddressFromString(v.Addr)
 		if err != nil {
-			return fmt.Errorf("failed to deserialize netaddress "+
-				"%s: %v", v.Addr, err)
+			log.Debugf("Skipping invalid netaddress %s: %v", v.Addr, err)
+			numSkipped++
+			continue
 		}
 		srcAddr, err := a.newAddressFromString(v.Src)
 		if err != nil {
-			return fmt.Errorf("failed to deserialize netaddress "+
-				"%s: %v", v.Src, err)
+			log.Debugf("Skipping invalid source netaddress %s: %v", v.Src,
+				err)
+			numSkipped++
+			continue
 		}
+		netAddr.Timestamp = time.Unix(v.TimeStamp, 0)
+		netAddr.Services = v.Services
 
 		ka := &KnownAddress{
 			na:          netAddr,
@@ -570,12 +733,32 @@ func (a *AddrManager) deserializePeers(filePath string) error {
 		a.addrIndex[ka.na.Key()] = ka
 	}
 
+	// Restore the tried buckets prior to the new buckets since addresses
+	// must not be in both and tried addresses are preferred.
+	for i := range sam.TriedBuckets {
+		for _, val := range sam.TriedBuckets[i] {
+			ka, ok := a.addrIndex[val]
+			if !ok || ka.tried || len(a.addrTried[i]) >= a.triedBucketSize {
+				log.Debugf("Skipping tried bucket entry %s", val)
+				numSkipped++
+				continue
+			}
+
+			ka.tried = true
+			a.nTried++
+			a.addrTried[i] = append(a.addrTried[i], ka)
+		}
+	}
 	for i := range sam.NewBuckets {
 		for _, val := range sam.NewBuckets[i] {
 			ka, ok := a.addrIndex[val]
-			if !ok {
-				return fmt.Errorf("new buckets contains %s but "+
-					"none in address list", val)
+			if !ok || ka.tried || ka.refs >= newBucketsPerAddress {
+				log.Debugf("Skipping new bucket entry %s", val)
+				numSkipped++
+				continue
+			}
+			if _, ok := a.addrNew[i][val]; ok {
+				continue
 			}
 
 			if ka.refs == 0 {
@@ -585,33 +768,32 @@ func (a *AddrManager) deserializePeers(filePath string) error {
 			a.addrNew[i][val] = ka
 		}
 	}
-	for i := range sam.TriedBuckets {
-		for _, val := range sam.TriedBuckets[i] {
-			ka, ok := a.addrIndex[val]
-			if !ok {
-				return fmt.Errorf("tried buckets contains %s but "+
-					"none in address list", val)
-			}
-
-			ka.tried = true
-			a.nTried++
-			a.addrTried[i] = append(a.addrTried[i], ka)
-		}
-	}
 
-	// Sanity checking.
-	for k, v := range a.addrIndex {
-		if v.refs == 0 && !v.tried {
-			return fmt.Errorf("address %s after serialisation "+
-				"with no references", k)
+	// Add any salvaged addresses that are not referenced by a bucket to the
+	// new buckets and remove those that don't fit.
+	for k, ka := range a.addrIndex {
+		if ka.refs > 0 || ka.tried {
+			continue
 		}
 
-		if v.refs > 0 && v.tried {
-			return fmt.Errorf("address %s after serialisation "+
-				"which is both new and tried", k)
+		bucket := a.getNewBucket(ka.na, ka.srcAddr)
+		if len(a.addrNew[bucket]) >= newBucketSize {
+			delete(a.addrIndex, k)
+			numSkipped++
+			continue
 		}
+		ka.refs++
+		a.nNew++
+		a.addrNew[bucket][k] = ka
 	}
 
+	if decodeErr != nil {
+		log.Warnf("Peers file %s is corrupt: %v", filePath, decodeErr)
+	}
+	if decodeErr != nil || numSkipped > 0 {
+		log.Warnf("Salvaged %d addresses from peers file %s (skipped %d "+
+			"invalid entries)", len(a.addrIndex), filePath, numSkipped)
+	}
 	return nil
 }
 
@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -603,6 +604,156 @@ func TestCorruptPeersFile(t *testing.T) {
 	}
 }
 
+// TestPeersFileMigration ensures that peers files written with version 1 of
+// the serialization format are migrated and that unknown versions are
+// rejected.
+func TestPeersFileMigration(t *testing.T) {
+	addr := net.JoinHostPort(someIP, "8333")
+	now := time.Now().Unix()
+	v1File := fmt.Sprintf(`{"Version":1,"Key":[%s1],"Addresses":[`+
+		`{"Addr":%q,"Src":%q,"Attempts":1,"TimeStamp":%d,`+
+		`"LastAttempt":%d,"LastSuccess":0}],"NewBuckets":[[%q]],`+
+		`"TriedBuckets":[]}`, strings.Repeat("0,", 31), addr, addr, now,
+		now, addr)
+
+	dir := t.TempDir()
+	peersFile := filepath.Join(dir, peersFilename)
+	if err := os.WriteFile(peersFile, []byte(v1File), 0644); err != nil {
+		t.Fatalf("unable to write peers file: %v", err)
+	}
+
+	amgr := New(dir, nil)
+	if err := amgr.deserializePeers(peersFile); err != nil {
+		t.Fatalf("unexpected error loading version 1 peers file: %v", err)
+	}
+	ka := amgr.addrIndex[addr]
+	if ka == nil {
+		t.Fatalf("migrated peers file does not contain %s", addr)
+	}
+	if ka.na.Services != wire.SFNodeNetwork {
+		t.Fatalf("unexpected migrated services - got %v, want %v",
+			ka.na.Services, wire.SFNodeNetwork)
+	}
+	if ka.na.Timestamp.Unix() != now {
+		t.Fatalf("unexpected timestamp - got %v, want %v",
+			ka.na.Timestamp.Unix(), now)
+	}
+	if amgr.key[31] != 1 {
+		t.Fatal("key was not loaded from migrated peers file")
+	}
+
+	// Ensure unknown versions are rejected.
+	for _, version := range []int{0, serialisationVersion + 1} {
+		contents := fmt.Sprintf(`{"Version":%d}`, version)
+		err := os.WriteFile(peersFile, []byte(contents), 0644)
+		if err != nil {
+			t.Fatalf("unable to write peers file: %v", err)
+		}
+		amgr := New(dir, nil)
+		if err := amgr.deserializePeers(peersFile); err == nil {
+			t.Fatalf("did not receive error for version %d", version)
+		}
+	}
+}
+
+// TestPeersFileRoundTrip ensures the services and timestamps of addresses
+// survive saving and loading the peers file and that stale addresses are
+// removed when saving.
+func TestPeersFileRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	amgr := New(dir, nil)
+
+	const staleIP = "173.194.115.67"
+	services := wire.SFNodeNetwork | wire.SFNodeCF
+	timestamp := time.Unix(time.Now().Add(-time.Hour).Unix(), 0)
+	na := NewNetAddressIPPort(net.ParseIP(someIP), 8333, services)
+	na.Timestamp = timestamp
+	amgr.addOrUpdateAddress(na, na)
+	amgr.addAddressByIP(staleIP, 8333)
+	staleKey := net.JoinHostPort(staleIP, "8333")
+	amgr.addrIndex[staleKey].na.Timestamp = time.Now().Add(-24 * time.Hour *
+		(numMissingDays + 1))
+	amgr.savePeers()
+
+	amgr = New(dir, nil)
+	amgr.loadPeers()
+	if amgr.numAddresses() != 1 {
+		t.Fatalf("unexpected number of addresses - got %d, want 1",
+			amgr.numAddresses())
+	}
+	if _, ok := amgr.addrIndex[staleKey]; ok {
+		t.Fatalf("stale address %s was not removed", staleKey)
+	}
+	ka := amgr.addrIndex[net.JoinHostPort(someIP, "8333")]
+	if ka == nil {
+		t.Fatal("peers file does not contain expected address")
+	}
+	if ka.na.Services != services {
+		t.Fatalf("unexpected services - got %v, want %v", ka.na.Services,
+			services)
+	}
+	if !ka.na.Timestamp.Equal(timestamp) {
+		t.Fatalf("unexpected timestamp - got %v, want %v",
+			ka.na.Timestamp, timestamp)
+	}
+}
+
+// TestSalvagePeersFile ensures the valid entries of truncated and inconsistent
+// peers files are salvaged.
+func TestSalvagePeersFile(t *testing.T) {
+	dir := t.TempDir()
+	peersFile := filepath.Join(dir, peersFilename)
+	amgr := New(dir, nil)
+	for i := 0; i < 10; i++ {
+		amgr.addAddressByIP(fmt.Sprintf("173.194.115.%d", i+1), 8333)
+	}
+	amgr.savePeers()
+
+	contents, err := os.ReadFile(peersFile)
+	if err != nil {
+		t.Fatalf("unable to read peers file: %v", err)
+	}
+
+	// Truncate the file in the middle of the addresses such that neither the
+	// final address nor the buckets are present.
+	addrsEnd := strings.Index(string(contents), `"NewBuckets"`)
+	lastAddr := strings.LastIndex(string(contents[:addrsEnd]), `{"Addr"`)
+	truncated := contents[:lastAddr+10]
+	if err := os.WriteFile(peersFile, truncated, 0644); err != nil {
+		t.Fatalf("unable to write peers file: %v", err)
+	}
+
+	amgr = New(dir, nil)
+	amgr.loadPeers()
+	if got := amgr.numAddresses(); got != 9 {
+		t.Fatalf("unexpected number of salvaged addresses - got %d, want 9",
+			got)
+	}
+	if amgr.GetAddress() == nil {
+		t.Fatal("salvaged address manager should return an address")
+	}
+
+	// Ensure invalid addresses and bucket entries that reference unknown
+	// addresses are skipped.
+	addr := net.JoinHostPort(someIP, "8333")
+	contents = []byte(fmt.Sprintf(`{"Version":%d,"Addresses":[`+
+		`{"Addr":"invalid","Src":"invalid"},{"Addr":%q,"Src":%q}],`+
+		`"NewBuckets":[["invalid","unknown"]],"TriedBuckets":[["unknown"]]}`,
+		serialisationVersion, addr, addr))
+	if err := os.WriteFile(peersFile, contents, 0644); err != nil {
+		t.Fatalf("unable to write peers file: %v", err)
+	}
+	amgr = New(dir, nil)
+	amgr.loadPeers()
+	if got := amgr.numAddresses(); got != 1 {
+		t.Fatalf("unexpected number of salvaged addresses - got %d, want 1",
+			got)
+	}
+	if _, ok := amgr.addrIndex[addr]; !ok {
+		t.Fatalf("salvaged address manager does not contain %s", addr)
+	}
+}
+
 // TestValidatePeerNa tests whether a remote address is considered reachable
 // from a local address.
 func TestValidatePeerNa(t *testing.T) {
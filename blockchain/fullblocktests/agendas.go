@@ -0,0 +1,620 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package fullblocktests
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/decred/dcrd/blockchain/v5/chaingen"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+const (
+	// Intentionally defined here rather than using constants from codebase
+	// to ensure consensus changes are detected.
+	voteIDTreasury            = "treasury"
+	voteIDAutoRevocations     = "autorevocations"
+	revocationTxVersionAuto   = 2
+	treasuryVoteYes           = 0x01
+	treasuryVoteNo            = 0x02
+	treasuryVoteMarkerLen     = 2
+	treasurybaseExtraNonceLen = 12
+)
+
+var (
+	// piPrivKey and piPrivKey2 are the private keys that correspond to the Pi
+	// keys defined in the parameters used by the generated tests.
+	piPrivKey  = fromHex("68ab7efdac0eb99b1edf83b23374cc7a9c8d0a4183a2627afc8ea0437b20589e")
+	piPrivKey2 = fromHex("2527f13f61024c9b9f4b30186f16e0b0af35b08c54ed2ed67def863b447ea11b")
+)
+
+// AgendaTestParams returns the network parameters that the tests generated by
+// GenerateTreasury and GenerateAutoRevocations are created for.  They are the
+// same as the parameters used by Generate with modifications that allow for
+// much quicker vote activation.
+//
+// Callers must use these parameters to create the chain instance that
+// processes the generated tests.
+func AgendaTestParams() *chaincfg.Params {
+	params := *regNetParams
+	params.WorkDiffWindowSize = 200000
+	params.WorkDiffWindows = 1
+	params.TargetTimespan = params.TargetTimePerBlock *
+		time.Duration(params.WorkDiffWindowSize)
+	params.CoinbaseMaturity = 2
+	params.BlockEnforceNumRequired = 5
+	params.BlockRejectNumRequired = 7
+	params.BlockUpgradeNumToCheck = 10
+	params.TicketMaturity = 2
+	params.TicketPoolSize = 4
+	params.TicketExpiry = 6 * uint32(params.TicketPoolSize)
+	params.StakeEnabledHeight = int64(params.CoinbaseMaturity) +
+		int64(params.TicketMaturity)
+	params.StakeValidationHeight = int64(params.CoinbaseMaturity) +
+		int64(params.TicketPoolSize)*2
+	params.StakeVersionInterval = 10
+	params.RuleChangeActivationInterval = uint32(params.TicketPoolSize) *
+		uint32(params.TicketsPerBlock)
+	params.RuleChangeActivationQuorum = params.RuleChangeActivationInterval *
+		uint32(params.TicketsPerBlock*100) / 1000
+	return &params
+}
+
+// agendaGenerator houses a generator along with the tests produced by it and
+// provides convenience functions for producing tests which exercise agendas
+// that require activation via stake votes.
+type agendaGenerator struct {
+	*chaingen.Generator
+	tests [][]TestInstance
+}
+
+// generateAgendaTests creates a generator for the agenda test parameters,
+// invokes the provided function with it, and returns the tests it produced.
+//
+// In order to simplify the generation code which really should never fail
+// unless the test code itself is broken, panics are used internally.  Any
+// panics are converted to the returned error.
+func generateAgendaTests(f func(g *agendaGenerator)) (tests [][]TestInstance, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			tests = nil
+
+			switch rt := r.(type) {
+			case string:
+				err = errors.New(rt)
+			case error:
+				err = rt
+			default:
+				err = errors.New("unknown panic")
+			}
+		}
+	}()
+
+	gen, err := chaingen.MakeGenerator(AgendaTestParams())
+	if err != nil {
+		return nil, err
+	}
+	g := &agendaGenerator{Generator: &gen}
+	f(g)
+	return g.tests, nil
+}
+
+// accepted appends a test instance that expects the current tip to be accepted
+// to the main chain.
+func (g *agendaGenerator) accepted() {
+	g.tests = append(g.tests, []TestInstance{
+		AcceptedBlock{g.TipName(), g.Tip(), true, false},
+	})
+}
+
+// rejected appends a test instance that expects the current tip to be rejected
+// with the provided error kind.
+func (g *agendaGenerator) rejected(kind ErrorKind) {
+	g.tests = append(g.tests, []TestInstance{
+		RejectedBlock{g.TipName(), g.Tip(), kind},
+	})
+}
+
+// findDeployment returns the deployment version and yes vote bits for the
+// provided vote ID.
+func findDeployment(params *chaincfg.Params, voteID string) (uint32, uint16) {
+	for version, deployments := range params.Deployments {
+		for _, deployment := range deployments {
+			if deployment.Vote.Id != voteID {
+				continue
+			}
+			for _, choice := range deployment.Vote.Choices {
+				if choice.Id == "yes" {
+					return version, choice.Bits
+				}
+			}
+		}
+	}
+	panic(fmt.Sprintf("unable to find yes choice for deployment %q", voteID))
+}
+
+// advanceToStakeValidationHeight generates and accepts enough blocks to reach
+// stake validation height while purchasing tickets until the target ticket
+// pool size is reached.
+func (g *agendaGenerator) advanceToStakeValidationHeight() {
+	// Shorter versions of useful params for convenience.
+	params := g.Params()
+	coinbaseMaturity := uint32(params.CoinbaseMaturity)
+	stakeEnabledHeight := uint32(params.StakeEnabledHeight)
+	stakeValidationHeight := uint32(params.StakeValidationHeight)
+	ticketsPerBlock := uint32(params.TicketsPerBlock)
+	targetPoolSize := uint32(params.TicketPoolSize) * ticketsPerBlock
+
+	// Add the required first block.
+	//
+	//   genesis -> bfb
+	g.CreateBlockOne("bfb", 0)
+	g.accepted()
+
+	// Generate enough blocks to have mature coinbase outputs to work with.
+	//
+	//   genesis -> bfb -> bm0 -> bm1 -> ... -> bm#
+	for i := uint32(0); i < coinbaseMaturity; i++ {
+		g.NextBlock(fmt.Sprintf("bm%d", i), nil, nil)
+		g.SaveTipCoinbaseOuts()
+		g.accepted()
+	}
+
+	// Generate enough blocks to reach stake validation height while creating
+	// ticket purchases that spend from the coinbases matured above until the
+	// target ticket pool size is reached.
+	//
+	//   ... -> bm# -> bse0 -> ... -> bse# -> bsv0 -> ... -> bsv#
+	var ticketsPurchased uint32
+	firstHeight := coinbaseMaturity + 2
+	for height := firstHeight; height <= stakeValidationHeight; height++ {
+		ticketsNeeded := targetPoolSize - ticketsPurchased
+		if ticketsNeeded > ticketsPerBlock {
+			ticketsNeeded = ticketsPerBlock
+		}
+		outs := g.OldestCoinbaseOuts()
+		ticketsPurchased += ticketsNeeded
+
+		blockName := fmt.Sprintf("bse%d", height-firstHeight)
+		if height > stakeEnabledHeight {
+			blockName = fmt.Sprintf("bsv%d", height-stakeEnabledHeight-1)
+		}
+		g.NextBlock(blockName, nil, outs[1:ticketsNeeded+1])
+		g.SaveTipCoinbaseOuts()
+		g.accepted()
+	}
+	g.AssertTipHeight(stakeValidationHeight)
+}
+
+// advanceFromSVHToActiveAgenda generates and accepts enough blocks with the
+// appropriate versions and vote bits set to reach one block prior to the
+// agenda with the provided vote ID becoming active.  It returns the deployment
+// version of the agenda.
+//
+// It must only be called when the tip is at stake validation height.
+func (g *agendaGenerator) advanceFromSVHToActiveAgenda(voteID string) uint32 {
+	// Shorter versions of useful params for convenience.
+	params := g.Params()
+	stakeValidationHeight := params.StakeValidationHeight
+	stakeVerInterval := params.StakeVersionInterval
+	ruleChangeInterval := int64(params.RuleChangeActivationInterval)
+	deploymentVer, yesBits := findDeployment(params, voteID)
+
+	// Generate enough blocks to reach one block before the next two stake
+	// version intervals with block and vote versions for the agenda.  This
+	// results in the stake version being upgraded to the deployment version
+	// and the agenda moving to the started state.
+	//
+	//   ... -> bsv# -> bvu0 -> bvu1 -> ... -> bvu#
+	blocksNeeded := stakeValidationHeight + stakeVerInterval*2 - 1 -
+		int64(g.Tip().Header.Height)
+	for i := int64(0); i < blocksNeeded; i++ {
+		outs := g.OldestCoinbaseOuts()
+		g.NextBlock(fmt.Sprintf("bvu%d", i), nil, outs[1:],
+			chaingen.ReplaceBlockVersion(int32(deploymentVer)),
+			chaingen.ReplaceVoteVersions(deploymentVer))
+		g.SaveTipCoinbaseOuts()
+		g.accepted()
+	}
+
+	// Generate enough blocks to reach the next rule change interval with yes
+	// votes for the agenda in order to move it to the locked in state.
+	//
+	//   ... -> bvu# -> bvli0 -> bvli1 -> ... -> bvli#
+	blocksNeeded = stakeValidationHeight + ruleChangeInterval*2 - 1 -
+		int64(g.Tip().Header.Height)
+	for i := int64(0); i < blocksNeeded; i++ {
+		outs := g.OldestCoinbaseOuts()
+		g.NextBlock(fmt.Sprintf("bvli%d", i), nil, outs[1:],
+			chaingen.ReplaceBlockVersion(int32(deploymentVer)),
+			chaingen.ReplaceStakeVersion(deploymentVer),
+			chaingen.ReplaceVotes(voteBitYes|yesBits, deploymentVer))
+		g.SaveTipCoinbaseOuts()
+		g.accepted()
+	}
+
+	// Generate enough blocks to reach the next rule change interval in order
+	// to move the agenda to the active state.
+	//
+	//   ... -> bvli# -> bva0 -> bva1 -> ... -> bva#
+	blocksNeeded = stakeValidationHeight + ruleChangeInterval*3 - 1 -
+		int64(g.Tip().Header.Height)
+	for i := int64(0); i < blocksNeeded; i++ {
+		outs := g.OldestCoinbaseOuts()
+		g.NextBlock(fmt.Sprintf("bva%d", i), nil, outs[1:],
+			chaingen.ReplaceBlockVersion(int32(deploymentVer)),
+			chaingen.ReplaceStakeVersion(deploymentVer),
+			chaingen.ReplaceVoteVersions(deploymentVer))
+		g.SaveTipCoinbaseOuts()
+		g.accepted()
+	}
+	g.AssertTipHeight(uint32(stakeValidationHeight + ruleChangeInterval*3 - 1))
+	g.AssertBlockVersion(int32(deploymentVer))
+	g.AssertStakeVersion(deploymentVer)
+
+	return deploymentVer
+}
+
+// calcTSpendExpiry returns the only valid treasury spend expiry for a
+// transaction that is first included in the provided block height given the
+// treasury vote interval and multiplier.
+func calcTSpendExpiry(nextBlockHeight uint32, tvi, multiplier uint64) uint32 {
+	nbh := uint64(nextBlockHeight)
+	nextTVI := nbh + (tvi - (nbh % tvi))
+	return uint32(nextTVI + tvi*multiplier + 2)
+}
+
+// replaceWithTreasurybase is a munge function which modifies the provided
+// block by removing the treasury payout from the coinbase and moving it into
+// a treasurybase transaction at the start of the stake tree as required once
+// the treasury agenda is active.
+func replaceWithTreasurybase(b *wire.MsgBlock) {
+	coinbaseTx := b.Transactions[0]
+	devSubsidy := coinbaseTx.TxOut[0].Value
+	coinbaseTx.TxOut = coinbaseTx.TxOut[1:]
+	coinbaseTx.Version = wire.TxVersionTreasury
+	coinbaseTx.TxIn[0].ValueIn -= devSubsidy
+
+	// The treasurybase commits to the block height and a random extra nonce
+	// via a provably pruneable output.
+	extraNonce, err := wire.RandomUint64()
+	if err != nil {
+		panic(err)
+	}
+	var enData [treasurybaseExtraNonceLen]byte
+	binary.LittleEndian.PutUint32(enData[0:4], b.Header.Height)
+	binary.LittleEndian.PutUint64(enData[4:12], extraNonce)
+
+	treasurybaseTx := wire.NewMsgTx()
+	treasurybaseTx.Version = wire.TxVersionTreasury
+	treasurybaseTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: *wire.NewOutPoint(&chainhash.Hash{},
+			wire.MaxPrevOutIndex, wire.TxTreeRegular),
+		Sequence:    wire.MaxTxInSequenceNum,
+		ValueIn:     devSubsidy,
+		BlockHeight: wire.NullBlockHeight,
+		BlockIndex:  wire.NullBlockIndex,
+	})
+	treasurybaseTx.AddTxOut(wire.NewTxOut(devSubsidy,
+		[]byte{txscript.OP_TADD}))
+	treasurybaseTx.AddTxOut(wire.NewTxOut(0, opReturnScript(enData[:])))
+	b.STransactions = append([]*wire.MsgTx{treasurybaseTx},
+		b.STransactions...)
+}
+
+// isVoteTx returns whether or not the passed transaction is a stake vote.
+//
+// NOTE: Like many other functions in this test code, this function
+// intentionally does not use the blockchain/stake package code since the
+// intent is to be able to generate known good tests which exercise that code.
+func isVoteTx(tx *wire.MsgTx) bool {
+	return len(tx.TxIn) == 2 && len(tx.TxOut) >= 3 &&
+		len(tx.TxOut[2].PkScript) > 0 &&
+		tx.TxOut[2].PkScript[0] == txscript.OP_SSGEN
+}
+
+// isRevocationTx returns whether or not the passed transaction is a stake
+// ticket revocation.
+//
+// NOTE: Like many other functions in this test code, this function
+// intentionally does not use the blockchain/stake package code since the
+// intent is to be able to generate known good tests which exercise that code.
+func isRevocationTx(tx *wire.MsgTx) bool {
+	return len(tx.TxOut) > 0 && len(tx.TxOut[0].PkScript) > 0 &&
+		tx.TxOut[0].PkScript[0] == txscript.OP_SSRTX
+}
+
+// addTSpendVotes returns a munge function which modifies the provided block by
+// adding the provided treasury spend vote for the treasury spend with the
+// provided hash to all votes in the block.
+func addTSpendVotes(tspendHash chainhash.Hash, vote byte) func(*wire.MsgBlock) {
+	return func(b *wire.MsgBlock) {
+		// OP_RETURN OP_DATA <TV> <tspend hash> <vote bits>
+		data := make([]byte, 0, treasuryVoteMarkerLen+chainhash.HashSize+1)
+		data = append(data, 'T', 'V')
+		data = append(data, tspendHash[:]...)
+		data = append(data, vote)
+		script := opReturnScript(data)
+		for _, stx := range b.STransactions {
+			if !isVoteTx(stx) {
+				continue
+			}
+			stx.TxOut = append(stx.TxOut, wire.NewTxOut(0, script))
+			stx.Version = wire.TxVersionTreasury
+		}
+	}
+}
+
+// GenerateTreasury returns a slice of tests that can be used to exercise the
+// consensus validation rules of the decentralized treasury agenda defined by
+// DCP0006.  The tests are intended to be processed by a chain instance created
+// with the parameters returned by AgendaTestParams.
+//
+// The tests activate the agenda via stake votes and then exercise treasury
+// adds, treasury spends, and the treasury spend voting window rules.
+func GenerateTreasury() (tests [][]TestInstance, err error) {
+	return generateAgendaTests(func(g *agendaGenerator) {
+		// Shorter versions of useful params for convenience.
+		params := g.Params()
+		tvi := params.TreasuryVoteInterval
+		mul := params.TreasuryVoteIntervalMultiplier
+
+		// -----------------------------------------------------------------
+		// Generate and accept enough blocks with the appropriate vote bits
+		// set to reach one block prior to the treasury agenda becoming
+		// active.
+		// -----------------------------------------------------------------
+
+		g.advanceToStakeValidationHeight()
+		tVersion := g.advanceFromSVHToActiveAgenda(voteIDTreasury)
+
+		// treasuryBlock creates a new block that builds on the current tip
+		// with the versions of the treasury deployment and a treasurybase
+		// followed by any additional provided munge functions.  The block
+		// purchases tickets with the provided outputs.
+		//
+		// acceptedTreasuryBlock creates a block via treasuryBlock, expects it
+		// to be accepted to the main chain, and updates the outputs available
+		// for spending by the next block accordingly.
+		treasuryBlock := func(blockName string, ticketOuts []chaingen.SpendableOut, mungers ...func(*wire.MsgBlock)) {
+			mungers = append([]func(*wire.MsgBlock){
+				chaingen.ReplaceBlockVersion(int32(tVersion)),
+				chaingen.ReplaceStakeVersion(tVersion),
+				chaingen.ReplaceVoteVersions(tVersion),
+				replaceWithTreasurybase,
+			}, mungers...)
+			g.NextBlock(blockName, nil, ticketOuts, mungers...)
+		}
+		outs := g.OldestCoinbaseOuts()
+		acceptedTreasuryBlock := func(blockName string, mungers ...func(*wire.MsgBlock)) {
+			treasuryBlock(blockName, outs[1:], mungers...)
+			g.SaveTipCoinbaseOutsWithTreasury()
+			g.accepted()
+			outs = g.OldestCoinbaseOuts()
+		}
+
+		// Create a treasury spend that is only valid within the voting window
+		// that starts at the next TVI as well as a treasury spend for a
+		// voting window that is far in the future.
+		nextHeight := g.Tip().Header.Height + 1
+		expiry := calcTSpendExpiry(nextHeight, tvi, mul)
+		windowStart := expiry - uint32(tvi*mul) - 2
+		payouts := []chaingen.AddressAmountTuple{{Amount: 1e8}}
+		tspend := g.CreateTreasuryTSpend(piPrivKey, payouts, lowFee, expiry)
+		tspendHash := tspend.TxHash()
+		futureExpiry := calcTSpendExpiry(nextHeight+uint32(tvi*mul*4), tvi,
+			mul)
+		futureTSpend := g.CreateTreasuryTSpend(piPrivKey2, payouts, lowFee,
+			futureExpiry)
+		addTSpend := func(tx *wire.MsgTx) func(*wire.MsgBlock) {
+			return func(b *wire.MsgBlock) {
+				b.AddSTransaction(tx)
+			}
+		}
+
+		// Attempt to add a treasury spend in a block that is not on a TVI.
+		//
+		//   ... -> bva#
+		//              \-> btsnottvi
+		if nextHeight%uint32(tvi) == 0 {
+			panic(fmt.Sprintf("expected height %d to not be a TVI",
+				nextHeight))
+		}
+		startTip := g.TipName()
+		treasuryBlock("btsnottvi", outs[1:], addTSpend(tspend))
+		g.rejected(ErrNotTVI)
+
+		// Generate enough blocks to reach the start of the voting window with
+		// yes votes for the treasury spend.  The votes are not counted since
+		// they are prior to the voting window.
+		//
+		//   ... -> bva# -> btpre0 -> ... -> btpre#
+		g.SetTip(startTip)
+		for i := uint32(0); i < windowStart-nextHeight; i++ {
+			acceptedTreasuryBlock(fmt.Sprintf("btpre%d", i),
+				addTSpendVotes(tspendHash, treasuryVoteYes))
+		}
+
+		// Attempt to add the treasury spend at the start of the voting window
+		// without any votes in the window.
+		//
+		//   ... -> btpre#
+		//                \-> btsnovotes
+		startTip = g.TipName()
+		treasuryBlock("btsnovotes", outs[1:], addTSpend(tspend))
+		g.rejected(ErrNotEnoughTSpendVotes)
+
+		// Create a block that adds funds to the treasury via a treasury add
+		// and votes yes on the treasury spend.
+		//
+		//   ... -> btpre# -> btadd
+		g.SetTip(startTip)
+		tadd := g.CreateTreasuryTAdd(&outs[0], dcrutil.Amount(1e8), lowFee)
+		tadd.Version = wire.TxVersionTreasury
+		acceptedTreasuryBlock("btadd", addTSpendVotes(tspendHash,
+			treasuryVoteYes), addTSpend(tadd))
+
+		// Generate the remainder of a TVI with yes votes for the treasury
+		// spend and attempt to add it.  There are not yet enough yes votes
+		// since only a single TVI of the voting window has elapsed.
+		//
+		//   ... -> btadd -> btyes0 -> ... -> btyes#
+		//                                          \-> btsnotenough
+		for i := uint64(0); i < tvi-1; i++ {
+			acceptedTreasuryBlock(fmt.Sprintf("btyes%d", i),
+				addTSpendVotes(tspendHash, treasuryVoteYes))
+		}
+		startTip = g.TipName()
+		treasuryBlock("btsnotenough", outs[1:], addTSpend(tspend))
+		g.rejected(ErrNotEnoughTSpendVotes)
+
+		// Generate another TVI with yes votes for the treasury spend so there
+		// are enough yes votes to approve it.
+		//
+		//   ... -> btyes# -> ... -> btyes#
+		g.SetTip(startTip)
+		for i := tvi - 1; i < tvi*2-1; i++ {
+			acceptedTreasuryBlock(fmt.Sprintf("btyes%d", i),
+				addTSpendVotes(tspendHash, treasuryVoteYes))
+		}
+
+		// Attempt to add the treasury spend for the voting window that is far
+		// in the future.
+		//
+		//   ... -> btyes#
+		//                \-> btsfuture
+		startTip = g.TipName()
+		treasuryBlock("btsfuture", outs[1:], addTSpend(futureTSpend))
+		g.rejected(ErrInvalidTSpendWindow)
+
+		// Add the approved treasury spend.
+		//
+		//   ... -> btyes# -> btspend
+		g.SetTip(startTip)
+		acceptedTreasuryBlock("btspend", addTSpend(tspend))
+
+		// Ensure a treasury spend vote of no is also accepted after the
+		// treasury spend is included.
+		//
+		//   ... -> btspend -> btno
+		acceptedTreasuryBlock("btno", addTSpendVotes(futureTSpend.TxHash(),
+			treasuryVoteNo))
+	})
+}
+
+// GenerateAutoRevocations returns a slice of tests that can be used to exercise
+// the consensus validation rules of the automatic ticket revocations agenda
+// defined by DCP0009.  The tests are intended to be processed by a chain
+// instance created with the parameters returned by AgendaTestParams.
+//
+// The tests activate the agenda via stake votes and then exercise the rules
+// that require revocations for missed tickets to be included in the block that
+// misses them.
+func GenerateAutoRevocations() (tests [][]TestInstance, err error) {
+	return generateAgendaTests(func(g *agendaGenerator) {
+		// Shorter versions of useful params for convenience.
+		coinbaseMaturity := g.Params().CoinbaseMaturity
+
+		// -----------------------------------------------------------------
+		// Generate and accept enough blocks with the appropriate vote bits
+		// set to reach one block prior to the automatic ticket revocations
+		// agenda becoming active.
+		// -----------------------------------------------------------------
+
+		g.advanceToStakeValidationHeight()
+		version := g.advanceFromSVHToActiveAgenda(voteIDAutoRevocations)
+
+		// replaceAutoRevocationsVersions is a munge function which modifies
+		// the provided block by replacing the block, stake, vote, and
+		// revocation transaction versions with the versions associated with
+		// the automatic ticket revocations deployment.
+		replaceAutoRevocationsVersions := func(b *wire.MsgBlock) {
+			chaingen.ReplaceBlockVersion(int32(version))(b)
+			chaingen.ReplaceStakeVersion(version)(b)
+			chaingen.ReplaceVoteVersions(version)(b)
+			chaingen.ReplaceRevocationVersions(revocationTxVersionAuto)(b)
+		}
+
+		// Generate enough blocks to have a known distance to the first mature
+		// coinbase outputs for all tests that follow.  These blocks continue
+		// to purchase tickets to avoid running out of votes.
+		//
+		//   ... -> bva# -> bbm0 -> bbm1 -> ... -> bbm#
+		for i := uint16(0); i < coinbaseMaturity; i++ {
+			outs := g.OldestCoinbaseOuts()
+			g.NextBlock(fmt.Sprintf("bbm%d", i), nil, outs[1:],
+				replaceAutoRevocationsVersions)
+			g.SaveTipCoinbaseOuts()
+			g.accepted()
+		}
+		outs := g.OldestCoinbaseOuts()
+
+		// Create a block that misses a vote and does not contain a revocation
+		// for that missed vote.
+		//
+		//   ... -> bbm#
+		//              \-> bar1(0)
+		startTip := g.TipName()
+		g.NextBlock("bar1", &outs[0], outs[1:], g.ReplaceWithNVotes(4),
+			replaceAutoRevocationsVersions)
+		g.AssertTipNumRevocations(0)
+		g.rejected(ErrNoMissedTicketRevocation)
+
+		// Create a block that misses a vote and contains a version 1
+		// revocation transaction.
+		//
+		//   ... -> bbm#
+		//              \-> bar2(0)
+		g.SetTip(startTip)
+		g.NextBlock("bar2", &outs[0], outs[1:], g.ReplaceWithNVotes(4),
+			g.CreateRevocationsForMissedTickets(),
+			replaceAutoRevocationsVersions,
+			chaingen.ReplaceRevocationVersions(1))
+		g.AssertTipNumRevocations(1)
+		g.rejected(ErrInvalidRevocationTxVersion)
+
+		// Create a block that misses a vote and contains a revocation with a
+		// non-zero fee.
+		//
+		// Note that this fails with ErrRegTxCreateStakeOut since a revocation
+		// with a non-zero fee is not identified as a revocation once the
+		// agenda is active.
+		//
+		//   ... -> bbm#
+		//              \-> bar3(0)
+		g.SetTip(startTip)
+		g.NextBlock("bar3", &outs[0], outs[1:], g.ReplaceWithNVotes(4),
+			g.CreateRevocationsForMissedTickets(),
+			replaceAutoRevocationsVersions,
+			func(b *wire.MsgBlock) {
+				for _, stx := range b.STransactions {
+					if isRevocationTx(stx) {
+						stx.TxOut[0].Value--
+						return
+					}
+				}
+			})
+		g.AssertTipNumRevocations(1)
+		g.rejected(ErrRegTxCreateStakeOut)
+
+		// Create a valid block that misses multiple votes and contains
+		// revocation transactions for those votes.
+		//
+		//   ... -> bbm# -> bar4(0)
+		g.SetTip(startTip)
+		g.NextBlock("bar4", &outs[0], outs[1:], g.ReplaceWithNVotes(3),
+			g.CreateRevocationsForMissedTickets(),
+			replaceAutoRevocationsVersions)
+		g.AssertTipNumRevocations(2)
+		g.accepted()
+	})
+}
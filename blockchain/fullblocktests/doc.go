@@ -16,5 +16,11 @@ independent versions over the peer-to-peer network.
 This package has intentionally been designed so it can be used as a standalone
 package for any projects needing to test their implementation against a full set
 of blocks that exercise the consensus validation rules.
+
+In addition to the tests produced by Generate, GenerateTreasury and
+GenerateAutoRevocations produce tests that activate the respective agendas via
+stake votes and then exercise the consensus rules they introduce.  Those tests
+must be processed by a chain instance created with the parameters returned by
+AgendaTestParams.
 */
 package fullblocktests
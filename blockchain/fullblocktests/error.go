@@ -277,6 +277,26 @@ const (
 	// ErrInvalidEarlyFinalState indicates that a block before stake validation
 	// height had a non-zero final state.
 	ErrInvalidEarlyFinalState = ErrorKind("ErrInvalidEarlyFinalState")
+
+	// ErrNotTVI indicates that a treasury spend transaction appeared in a
+	// block that is not at a TVI interval.
+	ErrNotTVI = ErrorKind("ErrNotTVI")
+
+	// ErrInvalidTSpendWindow indicates that this treasury spend transaction
+	// is outside of the allowed window.
+	ErrInvalidTSpendWindow = ErrorKind("ErrInvalidTSpendWindow")
+
+	// ErrNotEnoughTSpendVotes indicates that a treasury spend transaction
+	// does not have enough votes to be included in block.
+	ErrNotEnoughTSpendVotes = ErrorKind("ErrNotEnoughTSpendVotes")
+
+	// ErrInvalidRevocationTxVersion indicates that the revocation is the wrong
+	// transaction version.
+	ErrInvalidRevocationTxVersion = ErrorKind("ErrInvalidRevocationTxVersion")
+
+	// ErrNoMissedTicketRevocation indicates that the block does not contain a
+	// revocation for a ticket that is becoming missed as of that block.
+	ErrNoMissedTicketRevocation = ErrorKind("ErrNoMissedTicketRevocation")
 )
 
 // Error satisfies the error interface and prints human-readable errors.
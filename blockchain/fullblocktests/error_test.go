@@ -80,6 +80,11 @@ func TestErrorKindStringer(t *testing.T) {
 		{ErrFraudBlockIndex, "ErrFraudBlockIndex"},
 		{ErrInvalidEarlyVoteBits, "ErrInvalidEarlyVoteBits"},
 		{ErrInvalidEarlyFinalState, "ErrInvalidEarlyFinalState"},
+		{ErrNotTVI, "ErrNotTVI"},
+		{ErrInvalidTSpendWindow, "ErrInvalidTSpendWindow"},
+		{ErrNotEnoughTSpendVotes, "ErrNotEnoughTSpendVotes"},
+		{ErrInvalidRevocationTxVersion, "ErrInvalidRevocationTxVersion"},
+		{ErrNoMissedTicketRevocation, "ErrNoMissedTicketRevocation"},
 	}
 
 	for i, test := range tests {
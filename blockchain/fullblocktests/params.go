@@ -492,4 +492,23 @@ var regNetParams = &chaincfg.Params{
 		Script:        fromHex("76a91469de627d3231b14228653dd09cba75eeb872754288ac"),
 		Amount:        100000 * 1e8,
 	}},
+
+	// Treasury related parameters.
+	//
+	// The Pi keys correspond to the private keys piPrivKey and piPrivKey2.
+	PiKeys: [][]byte{
+		fromHex("03b459ccf3ce4935a676414fd9ec93ecf7c9dad081a52ed6993bf073c627499388"),
+		fromHex("02e3af1209f4d39dd8b448ef0a5375befa85bbc50be0aa0936379d67444184a2c3"),
+	},
+	TreasuryVoteInterval:           4, // every 4 blocks
+	TreasuryVoteIntervalMultiplier: 3, // 3 * 4 block Expiry.
+
+	TreasuryExpenditureWindow:    4,         // 4 * 2 * 4 blocks for policy check
+	TreasuryExpenditurePolicy:    3,         // Avg of 3*4*2*4 blocks for policy check
+	TreasuryExpenditureBootstrap: 100 * 1e8, // 100 dcr/tew as expense bootstrap
+
+	TreasuryVoteQuorumMultiplier:   1, // 20% quorum required
+	TreasuryVoteQuorumDivisor:      5,
+	TreasuryVoteRequiredMultiplier: 3, // 60% yes votes required
+	TreasuryVoteRequiredDivisor:    5,
 }
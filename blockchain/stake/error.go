@@ -304,6 +304,22 @@ const (
 	// ErrTreasuryBaseInvalid indicates that this transaction contains
 	// invalid treasurybase TxIn constants.
 	ErrTreasuryBaseInvalid = ErrorKind("ErrTreasuryBaseInvalid")
+
+	// ErrPoolFeeInvalidRate indicates that a stake pool fee rate is outside
+	// of the allowed range.
+	ErrPoolFeeInvalidRate = ErrorKind("ErrPoolFeeInvalidRate")
+
+	// ErrPoolFeeNoCommitment indicates that a ticket does not have the
+	// commitment outputs required for it to pay a stake pool fee.
+	ErrPoolFeeNoCommitment = ErrorKind("ErrPoolFeeNoCommitment")
+
+	// ErrPoolFeeTooLow indicates that a ticket commits less than the
+	// required stake pool fee to the stake pool.
+	ErrPoolFeeTooLow = ErrorKind("ErrPoolFeeTooLow")
+
+	// ErrPoolFeeBadSpend indicates that a vote or revocation does not spend
+	// the expected ticket or does not pay the stake pool its commitment.
+	ErrPoolFeeBadSpend = ErrorKind("ErrPoolFeeBadSpend")
 )
 
 // Error satisfies the error interface and prints human-readable errors.
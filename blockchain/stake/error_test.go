@@ -89,6 +89,10 @@ func TestErrorKindStringer(t *testing.T) {
 		{ErrTreasuryBaseInvalidOpcode0, "ErrTreasuryBaseInvalidOpcode0"},
 		{ErrTreasuryBaseInvalidOpcode1, "ErrTreasuryBaseInvalidOpcode1"},
 		{ErrTreasuryBaseInvalid, "ErrTreasuryBaseInvalid"},
+		{ErrPoolFeeInvalidRate, "ErrPoolFeeInvalidRate"},
+		{ErrPoolFeeNoCommitment, "ErrPoolFeeNoCommitment"},
+		{ErrPoolFeeTooLow, "ErrPoolFeeTooLow"},
+		{ErrPoolFeeBadSpend, "ErrPoolFeeBadSpend"},
 	}
 
 	for i, test := range tests {
@@ -0,0 +1,144 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package stake
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/txscript/v4/stdscript"
+	"github.com/decred/dcrd/wire"
+)
+
+// MaxPoolFeeRate is the maximum stake pool fee rate in hundredths of a
+// percent.  In other words, it represents a pool fee of 100.00%.
+const MaxPoolFeeRate = 10000
+
+// CalcPoolFee returns the fee in atoms a stake pool is owed for a ticket
+// purchased at the provided price which pays the provided relay fee, given the
+// expected vote subsidy and the pool fee rate in hundredths of a percent (for
+// example, a rate of 150 is a 1.50% pool fee).
+//
+// The pool fee is calculated with the standard stake pool fee formula:
+//
+//	fee = p * s * (v + z) / (s + v)
+//
+// where p is the pool fee rate, s is the vote subsidy, v is the ticket price,
+// and z is the relay fee.  In other words, the pool is paid its rate of the
+// portion of the total amount returned by a vote that is attributable to the
+// subsidy, scaled by the ticket price plus the fee.
+//
+// The calculation is done entirely with integer math so that all software
+// that implements it produces identical results.  Zero is returned when the
+// rate is not in the range [1, MaxPoolFeeRate] or any of the amounts are
+// negative.
+func CalcPoolFee(ticketPrice, relayFee, voteSubsidy int64, poolFeeRate uint16) int64 {
+	if poolFeeRate == 0 || poolFeeRate > MaxPoolFeeRate || ticketPrice < 0 ||
+		relayFee < 0 || voteSubsidy < 0 || voteSubsidy+ticketPrice == 0 {
+
+		return 0
+	}
+
+	// The numerator is p * s * (v + z) and the denominator is
+	// MaxPoolFeeRate * (s + v) since the rate is in hundredths of a percent.
+	s := big.NewInt(voteSubsidy)
+	v := big.NewInt(ticketPrice)
+	num := new(big.Int).Add(v, big.NewInt(relayFee))
+	num.Mul(num, s)
+	num.Mul(num, big.NewInt(int64(poolFeeRate)))
+	den := new(big.Int).Add(s, v)
+	den.Mul(den, big.NewInt(MaxPoolFeeRate))
+	return num.Div(num, den).Int64()
+}
+
+// CheckPoolFee ensures the provided ticket commits at least the stake pool fee
+// calculated by CalcPoolFee with the provided parameters to the stake pool and,
+// when a vote or revocation that spends the ticket is provided, that it pays
+// the stake pool its commitment.
+//
+// Stake pool tickets commit the pool fee to the first commitment output and the
+// remainder of the ticket purchase to the user via the second commitment
+// output.  The ticket price is the value of the first output of the ticket.
+//
+// The spend may be nil to only check the ticket.  The amounts paid by the spend
+// are not checked since they are dictated by the commitments and enforced by
+// consensus.
+func CheckPoolFee(ticket, spend *wire.MsgTx, relayFee, voteSubsidy int64, poolFeeRate uint16) error {
+	if poolFeeRate == 0 || poolFeeRate > MaxPoolFeeRate {
+		str := fmt.Sprintf("pool fee rate %d is not in the range [1, %d]",
+			poolFeeRate, MaxPoolFeeRate)
+		return stakeRuleError(ErrPoolFeeInvalidRate, str)
+	}
+
+	commitments, err := ExtractTicketCommitments(ticket)
+	if err != nil {
+		return err
+	}
+	if len(commitments) < 2 {
+		str := fmt.Sprintf("ticket has %d commitment outputs, but pool "+
+			"tickets require at least 2", len(commitments))
+		return stakeRuleError(ErrPoolFeeNoCommitment, str)
+	}
+
+	// Ensure the ticket commits at least the required pool fee to the pool.
+	poolCommitment := &commitments[0]
+	ticketPrice := ticket.TxOut[0].Value
+	wantFee := CalcPoolFee(ticketPrice, relayFee, voteSubsidy, poolFeeRate)
+	if poolCommitment.Amount < wantFee {
+		str := fmt.Sprintf("ticket commits %d atoms to the pool, but the "+
+			"pool fee is %d atoms", poolCommitment.Amount, wantFee)
+		return stakeRuleError(ErrPoolFeeTooLow, str)
+	}
+	if spend == nil {
+		return nil
+	}
+
+	// Determine the input that references the ticket and the output that
+	// pays the pool commitment based on whether the spend is a vote or a
+	// revocation.
+	var ticketInIdx, payoutIdx int
+	var extractHash func([]byte) []byte
+	switch {
+	case IsSSGen(spend):
+		ticketInIdx, payoutIdx = 1, 2
+		extractHash = stdscript.ExtractStakeGenPubKeyHashV0
+		if poolCommitment.IsScriptHash {
+			extractHash = stdscript.ExtractStakeGenScriptHashV0
+		}
+
+	case IsSSRtx(spend):
+		ticketInIdx, payoutIdx = 0, 0
+		extractHash = stdscript.ExtractStakeRevocationPubKeyHashV0
+		if poolCommitment.IsScriptHash {
+			extractHash = stdscript.ExtractStakeRevocationScriptHashV0
+		}
+
+	default:
+		str := "spend is neither a vote nor a revocation"
+		return stakeRuleError(ErrPoolFeeBadSpend, str)
+	}
+
+	ticketHash := ticket.TxHash()
+	if spend.TxIn[ticketInIdx].PreviousOutPoint.Hash != ticketHash {
+		str := fmt.Sprintf("spend does not reference ticket %v", ticketHash)
+		return stakeRuleError(ErrPoolFeeBadSpend, str)
+	}
+	if payoutIdx >= len(spend.TxOut) {
+		str := fmt.Sprintf("spend does not have an output at index %d to "+
+			"pay the pool", payoutIdx)
+		return stakeRuleError(ErrPoolFeeBadSpend, str)
+	}
+	payout := spend.TxOut[payoutIdx]
+	if payout.Version != 0 || !bytes.Equal(extractHash(payout.PkScript),
+		poolCommitment.Hash[:]) {
+
+		str := fmt.Sprintf("spend output %d does not pay the pool "+
+			"commitment", payoutIdx)
+		return stakeRuleError(ErrPoolFeeBadSpend, str)
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package stake
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+// TestCalcPoolFee ensures the stake pool fee calculation produces the expected
+// results.
+func TestCalcPoolFee(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string // test description
+		ticketPrice int64  // ticket price in atoms
+		relayFee    int64  // relay fee in atoms
+		voteSubsidy int64  // vote subsidy in atoms
+		rate        uint16 // pool fee rate in hundredths of a percent
+		want        int64  // expected pool fee
+	}{{
+		name:        "max rate, no relay fee",
+		ticketPrice: 100e8,
+		voteSubsidy: 1e8,
+		rate:        MaxPoolFeeRate,
+		want:        99009900,
+	}, {
+		name:        "1.50% rate, no relay fee",
+		ticketPrice: 100e8,
+		voteSubsidy: 1e8,
+		rate:        150,
+		want:        1485148,
+	}, {
+		name:        "1.50% rate with relay fee",
+		ticketPrice: 100e8,
+		relayFee:    30000,
+		voteSubsidy: 1e8,
+		rate:        150,
+		want:        1485152,
+	}, {
+		name:        "zero rate",
+		ticketPrice: 100e8,
+		voteSubsidy: 1e8,
+		rate:        0,
+		want:        0,
+	}, {
+		name:        "rate over max",
+		ticketPrice: 100e8,
+		voteSubsidy: 1e8,
+		rate:        MaxPoolFeeRate + 1,
+		want:        0,
+	}, {
+		name:        "negative ticket price",
+		ticketPrice: -1,
+		voteSubsidy: 1e8,
+		rate:        150,
+		want:        0,
+	}, {
+		name: "zero ticket price and subsidy",
+		rate: 150,
+		want: 0,
+	}}
+
+	for _, test := range tests {
+		got := CalcPoolFee(test.ticketPrice, test.relayFee, test.voteSubsidy,
+			test.rate)
+		if got != test.want {
+			t.Errorf("%q: unexpected pool fee -- got %d, want %d", test.name,
+				got, test.want)
+		}
+	}
+}
+
+// TestCheckPoolFee ensures tickets and the votes and revocations that spend
+// them are validated against the stake pool fee as expected.
+func TestCheckPoolFee(t *testing.T) {
+	t.Parallel()
+
+	// The test ticket commits 556000000 atoms to the pool via its first
+	// commitment and has a ticket price of the same amount.  Since the pool
+	// fee approaches the ticket price plus the relay fee as the vote subsidy
+	// grows with the max rate, a large subsidy and relay fee result in a pool
+	// fee that exceeds the commitment.
+	ticket := sstxMsgTx
+	ticketHash := ticket.TxHash()
+	const voteSubsidy = 1e8
+	const tooHighSubsidy = 1e12
+	const tooHighRelayFee = 1e8
+
+	// Create a vote that spends the ticket and pays the pool commitment.
+	poolPayoutScript := []byte{
+		txscript.OP_SSGEN, txscript.OP_DUP, txscript.OP_HASH160,
+		txscript.OP_DATA_20,
+		0x94, 0x8c, 0x76, 0x5a, 0x69, 0x14, 0xd4, 0x3f, 0x2a, 0x7a,
+		0xc1, 0x77, 0xda, 0x2c, 0x2f, 0x6b, 0x52, 0xde, 0x3d, 0x7c,
+		txscript.OP_EQUALVERIFY, txscript.OP_CHECKSIG,
+	}
+	vote := ssgenMsgTx.Copy()
+	vote.TxIn[1].PreviousOutPoint.Hash = ticketHash
+	vote.TxOut[2].PkScript = poolPayoutScript
+
+	// Create a vote that does not spend the ticket and one that does not pay
+	// the pool commitment.
+	otherVote := vote.Copy()
+	otherVote.TxIn[1].PreviousOutPoint.Hash[0] ^= 0xff
+	badPayoutVote := ssgenMsgTx.Copy()
+	badPayoutVote.TxIn[1].PreviousOutPoint.Hash = ticketHash
+
+	// Create a revocation that spends the ticket.
+	revocation, err := CreateRevocationFromTicket(&ticketHash,
+		ConvertToMinimalOutputs(ticket), 0, TxVersionAutoRevocations,
+		chaincfg.MainNetParams(), nil, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating revocation: %v", err)
+	}
+
+	// Create a ticket with a single commitment.
+	singleCommitmentTicket := &wire.MsgTx{
+		SerType: wire.TxSerializeFull,
+		Version: 1,
+		TxIn:    []*wire.TxIn{&sstxTxIn},
+		TxOut:   []*wire.TxOut{&sstxTxOut0, &sstxTxOut1, &sstxTxOut2},
+	}
+
+	tests := []struct {
+		name        string      // test description
+		ticket      *wire.MsgTx // ticket to check
+		spend       *wire.MsgTx // optional spend to check
+		relayFee    int64       // relay fee in atoms
+		voteSubsidy int64       // vote subsidy in atoms
+		rate        uint16      // pool fee rate in hundredths of a percent
+		err         error       // expected error
+	}{{
+		name:        "ticket only",
+		ticket:      ticket,
+		voteSubsidy: voteSubsidy,
+		rate:        MaxPoolFeeRate,
+	}, {
+		name:        "ticket with vote",
+		ticket:      ticket,
+		spend:       vote,
+		voteSubsidy: voteSubsidy,
+		rate:        150,
+	}, {
+		name:        "ticket with revocation",
+		ticket:      ticket,
+		spend:       revocation,
+		voteSubsidy: voteSubsidy,
+		rate:        150,
+	}, {
+		name:        "invalid rate",
+		ticket:      ticket,
+		voteSubsidy: voteSubsidy,
+		rate:        MaxPoolFeeRate + 1,
+		err:         ErrPoolFeeInvalidRate,
+	}, {
+		name:        "not a ticket",
+		ticket:      vote,
+		voteSubsidy: voteSubsidy,
+		rate:        150,
+		err:         ErrSStxInvalidOutputs,
+	}, {
+		name:        "single commitment",
+		ticket:      singleCommitmentTicket,
+		voteSubsidy: voteSubsidy,
+		rate:        150,
+		err:         ErrPoolFeeNoCommitment,
+	}, {
+		name:        "pool commitment too low",
+		ticket:      ticket,
+		relayFee:    tooHighRelayFee,
+		voteSubsidy: tooHighSubsidy,
+		rate:        MaxPoolFeeRate,
+		err:         ErrPoolFeeTooLow,
+	}, {
+		name:        "spend is not a vote or revocation",
+		ticket:      ticket,
+		spend:       ticket,
+		voteSubsidy: voteSubsidy,
+		rate:        150,
+		err:         ErrPoolFeeBadSpend,
+	}, {
+		name:        "vote spends another ticket",
+		ticket:      ticket,
+		spend:       otherVote,
+		voteSubsidy: voteSubsidy,
+		rate:        150,
+		err:         ErrPoolFeeBadSpend,
+	}, {
+		name:        "vote does not pay pool commitment",
+		ticket:      ticket,
+		spend:       badPayoutVote,
+		voteSubsidy: voteSubsidy,
+		rate:        150,
+		err:         ErrPoolFeeBadSpend,
+	}}
+
+	for _, test := range tests {
+		err := CheckPoolFee(test.ticket, test.spend, test.relayFee,
+			test.voteSubsidy, test.rate)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%q: unexpected error -- got %v, want %v", test.name,
+				err, test.err)
+		}
+	}
+}
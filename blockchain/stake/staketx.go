@@ -460,6 +460,108 @@ func AmountFromSStxPkScrCommitment(pkScript []byte) (dcrutil.Amount, error) {
 	return dcrutil.Amount(binary.LittleEndian.Uint64(amtEncoded)), nil
 }
 
+// TicketCommitment houses the details of a reward commitment output of a
+// ticket purchase transaction.
+type TicketCommitment struct {
+	// OutputIndex is the index of the commitment output in the ticket.
+	OutputIndex uint32
+
+	// Hash is the hash of the public key or script the original funds locked
+	// to purchase the ticket plus any rewards are committed to.
+	Hash [20]byte
+
+	// IsScriptHash specifies whether the hash is a script hash as opposed to
+	// a public key hash.
+	IsScriptHash bool
+
+	// Amount is the amount contributed to the ticket purchase by the
+	// commitment.
+	Amount int64
+
+	// HasVoteFeeLimit specifies whether or not a vote is allowed to pay fees
+	// from the amount returned to the commitment.
+	HasVoteFeeLimit bool
+
+	// VoteFeeLimit is the maximum number of atoms a vote may pay as fees from
+	// the amount returned to the commitment when HasVoteFeeLimit is set.  It
+	// is math.MaxInt64 when the entire amount may be used.
+	VoteFeeLimit int64
+
+	// HasRevocationFeeLimit specifies whether or not a revocation is allowed
+	// to pay fees from the amount returned to the commitment.
+	HasRevocationFeeLimit bool
+
+	// RevocationFeeLimit is the maximum number of atoms a revocation may pay
+	// as fees from the amount returned to the commitment when
+	// HasRevocationFeeLimit is set.  It is math.MaxInt64 when the entire
+	// amount may be used.
+	RevocationFeeLimit int64
+}
+
+// Address returns the stake address the commitment pays to for the provided
+// network parameters.
+func (c *TicketCommitment) Address(params stdaddr.AddressParams) (stdaddr.StakeAddress, error) {
+	if c.IsScriptHash {
+		return stdaddr.NewAddressScriptHashV0FromHash(c.Hash[:], params)
+	}
+	return stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(c.Hash[:], params)
+}
+
+// feeLimitFromLog2 converts the provided log2 encoded fee limit from a ticket
+// commitment to atoms.  Since amounts are int64, anything greater than or equal
+// to 63 means the entire amount may be used as a fee.
+func feeLimitFromLog2(feeLimitLog2 uint16) int64 {
+	if feeLimitLog2 >= 63 {
+		return math.MaxInt64
+	}
+	return 1 << feeLimitLog2
+}
+
+// ExtractTicketCommitments returns the details of all reward commitment outputs
+// of the provided ticket purchase transaction in output order.  An error is
+// returned when the transaction is not a valid ticket purchase per CheckSStx.
+func ExtractTicketCommitments(tx *wire.MsgTx) ([]TicketCommitment, error) {
+	if err := CheckSStx(tx); err != nil {
+		return nil, err
+	}
+
+	// The commitment outputs are all of the odd numbered outputs and the
+	// checks above ensure they are at least the minimum commitment size.
+	commitments := make([]TicketCommitment, 0, len(tx.TxOut)/2)
+	for outIdx := 1; outIdx < len(tx.TxOut); outIdx += 2 {
+		pkScript := tx.TxOut[outIdx].PkScript
+
+		// The MSB of the encoded amount specifies if the commitment is for a
+		// script hash.
+		amtEncoded := binary.LittleEndian.Uint64(pkScript[22:30])
+		isP2SH := amtEncoded&(1<<63) != 0
+		amount := int64(amtEncoded &^ (1 << 63))
+
+		feeLimits := binary.LittleEndian.Uint16(pkScript[30:32])
+		voteLimitLog2 := feeLimits & SStxVoteReturnFractionMask
+		revLimitLog2 := (feeLimits & SStxRevReturnFractionMask) >>
+			SStxRevReturnFractionShift
+
+		commitment := TicketCommitment{
+			OutputIndex:           uint32(outIdx),
+			IsScriptHash:          isP2SH,
+			Amount:                amount,
+			HasVoteFeeLimit:       feeLimits&SStxVoteFractionFlag != 0,
+			HasRevocationFeeLimit: feeLimits&SStxRevFractionFlag != 0,
+		}
+		copy(commitment.Hash[:], pkScript[2:22])
+		if commitment.HasVoteFeeLimit {
+			commitment.VoteFeeLimit = feeLimitFromLog2(voteLimitLog2)
+		}
+		if commitment.HasRevocationFeeLimit {
+			commitment.RevocationFeeLimit = feeLimitFromLog2(revLimitLog2)
+		}
+		commitments = append(commitments, commitment)
+	}
+
+	return commitments, nil
+}
+
 // SSGenBlockVotedOn takes an SSGen tx and returns the block voted on in the
 // first OP_RETURN by hash and height.
 //
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"errors"
+	"math"
 	"math/rand"
 	"reflect"
 	"testing"
@@ -1101,6 +1102,68 @@ func TestGetSStxStakeOutputInfo(t *testing.T) {
 	}
 }
 
+// TestExtractTicketCommitments ensures the ticket commitment details are
+// extracted from all commitment outputs of a ticket and that invalid tickets
+// are rejected.
+func TestExtractTicketCommitments(t *testing.T) {
+	commitments, err := ExtractTicketCommitments(sstxMsgTx)
+	if err != nil {
+		t.Fatalf("unexpected error extracting ticket commitments: %v", err)
+	}
+
+	hash := [20]byte{0x94, 0x8c, 0x76, 0x5a, 0x69, 0x14, 0xd4, 0x3f, 0x2a,
+		0x7a, 0xc1, 0x77, 0xda, 0x2c, 0x2f, 0x6b, 0x52, 0xde, 0x3d, 0x7c}
+	want := []TicketCommitment{{
+		OutputIndex:     1,
+		Hash:            hash,
+		Amount:          0x2123e300,
+		HasVoteFeeLimit: true,
+		VoteFeeLimit:    16,
+	}, {
+		OutputIndex:     3,
+		Hash:            hash,
+		Amount:          0x2123e300,
+		HasVoteFeeLimit: true,
+		VoteFeeLimit:    16,
+	}, {
+		OutputIndex:     5,
+		Hash:            hash,
+		IsScriptHash:    true,
+		Amount:          0x2123e300,
+		HasVoteFeeLimit: true,
+		VoteFeeLimit:    16,
+	}}
+	if !reflect.DeepEqual(commitments, want) {
+		t.Fatalf("unexpected commitments -- got %+v, want %+v", commitments,
+			want)
+	}
+
+	// Ensure the commitment addresses are of the expected type.
+	params := chaincfg.MainNetParams()
+	for _, commitment := range commitments {
+		addr, err := commitment.Address(params)
+		if err != nil {
+			t.Fatalf("unexpected error creating commitment address: %v", err)
+		}
+		_, isP2SH := addr.(*stdaddr.AddressScriptHashV0)
+		if isP2SH != commitment.IsScriptHash {
+			t.Fatalf("unexpected address type %T for output %d", addr,
+				commitment.OutputIndex)
+		}
+	}
+
+	// Ensure the largest fee limits are treated as the entire amount.
+	if got := feeLimitFromLog2(SStxVoteReturnFractionMask); got != math.MaxInt64 {
+		t.Fatalf("unexpected max fee limit -- got %d, want %d", got,
+			int64(math.MaxInt64))
+	}
+
+	// Ensure transactions that are not tickets are rejected.
+	if _, err := ExtractTicketCommitments(ssgenMsgTx); err == nil {
+		t.Fatal("extracting commitments from a vote did not error")
+	}
+}
+
 func TestGetSSGenVoteBits(t *testing.T) {
 	var ssgen = dcrutil.NewTx(ssgenMsgTx)
 	ssgen.SetTree(wire.TxTreeStake)
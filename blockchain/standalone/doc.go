@@ -24,6 +24,7 @@ The provided functions fall into the following categories:
   - Proof-of-work
   - Merkle root calculation
   - Subsidy calculation
+  - Header-based calculations
   - Coinbase transaction identification
   - Merkle tree inclusion proofs
   - Transaction sanity checking
@@ -46,6 +47,12 @@ The provided functions fall into the following categories:
   - Stake vote subsidy for a given height
   - Treasury subsidy for a given height and number of votes
 
+# Header-based calculations
+
+  - Next required proof-of-work difficulty for a chain of headers
+  - Next required stake difficulty for a chain of headers (DCP0001 algorithm)
+  - Past median time for a chain of headers
+
 # Merkle tree inclusion proofs
 
   - Generate an inclusion proof for a given tree and leaf index
@@ -43,6 +43,16 @@ const (
 	// ErrDuplicateTxInputs indicates a transaction references the same
 	// input more than once.
 	ErrDuplicateTxInputs = ErrorKind("ErrDuplicateTxInputs")
+
+	// ErrHeaderChainDisconnected indicates a header provided to one of the
+	// header-based calculation functions does not connect to the header before
+	// it.
+	ErrHeaderChainDisconnected = ErrorKind("ErrHeaderChainDisconnected")
+
+	// ErrInsufficientHeaders indicates that not enough headers were provided to
+	// one of the header-based calculation functions to perform the requested
+	// calculation.
+	ErrInsufficientHeaders = ErrorKind("ErrInsufficientHeaders")
 )
 
 // Error satisfies the error interface and prints human-readable errors.
@@ -24,6 +24,8 @@ func TestErrorKindStringer(t *testing.T) {
 		{ErrTxTooBig, "ErrTxTooBig"},
 		{ErrBadTxOutValue, "ErrBadTxOutValue"},
 		{ErrDuplicateTxInputs, "ErrDuplicateTxInputs"},
+		{ErrHeaderChainDisconnected, "ErrHeaderChainDisconnected"},
+		{ErrInsufficientHeaders, "ErrInsufficientHeaders"},
 	}
 
 	t.Logf("Running %d tests", len(tests))
@@ -0,0 +1,530 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package standalone
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/decred/dcrd/wire"
+)
+
+const (
+	// medianTimeBlocks is the number of previous blocks which should be
+	// used to calculate the median time used to validate block timestamps.
+	medianTimeBlocks = 11
+
+	// testNet3MaxDiffActivationHeight is the height that enforcement of the
+	// maximum difficulty rules starts on version 3 of the test network.
+	testNet3MaxDiffActivationHeight = 962928
+
+	// testNet3MaxDiffShift is the number of bits the proof of work limit is
+	// shifted to determine the maximum difficulty on version 3 of the test
+	// network.  This equates to a maximum difficulty of 2^6 = 64.
+	testNet3MaxDiffShift = 6
+)
+
+// HeaderCalcParams defines an interface that is used to provide the parameters
+// required when calculating the required difficulties and median time from a
+// chain of block headers.  These values are typically well-defined and unique
+// per network.
+//
+// The subsidy parameters are required since the stake difficulty algorithm
+// limits the maximum stake difficulty based on the estimated coin supply.
+type HeaderCalcParams interface {
+	SubsidyParams
+
+	// CurrencyNetwork returns the network the parameters define.  It is used
+	// to enforce rules that only apply to specific networks.
+	CurrencyNetwork() wire.CurrencyNet
+
+	// PowLimitTarget returns the highest allowed proof of work value for a
+	// block.
+	PowLimitTarget() *big.Int
+
+	// PowLimitCompact returns the highest allowed proof of work value for a
+	// block in compact form.
+	PowLimitCompact() uint32
+
+	// MinDifficultyReduction returns whether or not the network reduces the
+	// required difficulty to the minimum once the returned amount of time has
+	// elapsed without a block being mined.
+	MinDifficultyReduction() (bool, time.Duration)
+
+	// WorkDiffWindowSizeBlocks returns the number of blocks in each interval
+	// used to calculate the required proof of work difficulty.
+	WorkDiffWindowSizeBlocks() int64
+
+	// WorkDiffWindowCount returns the number of intervals used to calculate
+	// the required proof of work difficulty.
+	WorkDiffWindowCount() int64
+
+	// WorkDiffSmoothingAlpha returns the smoothing factor applied to the
+	// intervals used to calculate the required proof of work difficulty.
+	WorkDiffSmoothingAlpha() int64
+
+	// RetargetTimespan returns the desired amount of time that should elapse
+	// for each interval used to calculate the required proof of work
+	// difficulty.
+	RetargetTimespan() time.Duration
+
+	// RetargetAdjustmentLimit returns the factor used to limit the minimum
+	// and maximum amount of adjustment that can occur between proof of work
+	// difficulty retargets.
+	RetargetAdjustmentLimit() int64
+
+	// MinimumStakeDifficulty returns the minimum amount of atoms required to
+	// purchase a ticket.
+	MinimumStakeDifficulty() int64
+
+	// StakeDiffWindowSizeBlocks returns the number of blocks between each
+	// stake difficulty retarget.
+	StakeDiffWindowSizeBlocks() int64
+
+	// TicketPoolSizeBlocks returns the target size of the ticket pool in
+	// multiples of the number of votes per block.
+	TicketPoolSizeBlocks() uint16
+
+	// TicketMaturityBlocks returns the number of blocks required for tickets
+	// to mature.
+	TicketMaturityBlocks() uint16
+
+	// CoinbaseMaturityBlocks returns the number of blocks required before
+	// newly mined coins can be spent.
+	CoinbaseMaturityBlocks() uint16
+}
+
+// HeaderCalculator provides stateless calculation of the next required
+// difficulty, next required stake difficulty, and past median time for a
+// contiguous chain of block headers without requiring access to a full chain
+// instance.
+//
+// The results are identical to those that a full node produces for the same
+// blocks provided enough headers to cover the calculation window are supplied.
+// The required number of headers for each calculation is exposed by the
+// corresponding Required*Headers method.
+//
+// IMPORTANT: The stake difficulty is only calculated according to the
+// algorithm defined in DCP0001.  The results are therefore only correct for
+// blocks after the agenda that activates it (sdiffalgorithm) is active on the
+// network.  Callers are responsible for determining the activation state since
+// it can't be determined from the headers alone.
+type HeaderCalculator struct {
+	params HeaderCalcParams
+
+	// minTestNetTarget is the maximum difficulty target imposed by version 3
+	// of the test network.  It is nil for all other networks.
+	minTestNetTarget *big.Int
+}
+
+// NewHeaderCalculator returns a header calculator for the provided network
+// parameters.
+func NewHeaderCalculator(params HeaderCalcParams) *HeaderCalculator {
+	var minTestNetTarget *big.Int
+	if params.CurrencyNetwork() == wire.TestNet3 {
+		minTestNetTarget = new(big.Int).Rsh(params.PowLimitTarget(),
+			testNet3MaxDiffShift)
+	}
+	return &HeaderCalculator{
+		params:           params,
+		minTestNetTarget: minTestNetTarget,
+	}
+}
+
+// checkHeaders returns an error when the provided headers do not form a
+// contiguous chain ordered from oldest to newest or there are fewer than the
+// required number of them unless the chain they form starts at the genesis
+// block, in which case all available history is present.
+func checkHeaders(headers []wire.BlockHeader, required int64) error {
+	if len(headers) == 0 {
+		str := "no headers provided"
+		return ruleError(ErrInsufficientHeaders, str)
+	}
+
+	for i := 1; i < len(headers); i++ {
+		prev, header := &headers[i-1], &headers[i]
+		if header.Height != prev.Height+1 ||
+			header.PrevBlock != prev.BlockHash() {
+
+			str := fmt.Sprintf("header %s (height %d) does not connect to "+
+				"header %s (height %d)", header.BlockHash(), header.Height,
+				prev.BlockHash(), prev.Height)
+			return ruleError(ErrHeaderChainDisconnected, str)
+		}
+	}
+
+	if int64(len(headers)) >= required || headers[0].Height == 0 {
+		return nil
+	}
+	str := fmt.Sprintf("calculation requires at least %d headers or a "+
+		"header chain starting at the genesis block, but %d headers "+
+		"starting at height %d were provided", required, len(headers),
+		headers[0].Height)
+	return ruleError(ErrInsufficientHeaders, str)
+}
+
+// RequiredMedianTimeHeaders returns the number of headers required to
+// calculate the past median time.
+func (c *HeaderCalculator) RequiredMedianTimeHeaders() int64 {
+	return medianTimeBlocks
+}
+
+// RequiredDifficultyHeaders returns the number of headers required to
+// calculate the next required difficulty.
+func (c *HeaderCalculator) RequiredDifficultyHeaders() int64 {
+	return c.params.WorkDiffWindowSizeBlocks()*c.params.WorkDiffWindowCount() + 1
+}
+
+// RequiredStakeDifficultyHeaders returns the number of headers required to
+// calculate the next required stake difficulty.
+func (c *HeaderCalculator) RequiredStakeDifficultyHeaders() int64 {
+	return c.params.StakeDiffWindowSizeBlocks() +
+		int64(c.params.TicketMaturityBlocks())
+}
+
+// CalcPastMedianTime calculates the median time of the previous few blocks
+// ending with the final provided header per the same rules used to validate
+// block timestamps.
+//
+// The headers must form a contiguous chain ordered from oldest to newest and
+// either contain at least the number of headers returned by
+// RequiredMedianTimeHeaders or start at the genesis block.
+//
+// This function is safe for concurrent access.
+func (c *HeaderCalculator) CalcPastMedianTime(headers []wire.BlockHeader) (time.Time, error) {
+	if err := checkHeaders(headers, c.RequiredMedianTimeHeaders()); err != nil {
+		return time.Time{}, err
+	}
+
+	// Collect the timestamps of the previous few blocks, which will be fewer
+	// than desired near the beginning of the block chain, and sort them.
+	numTimestamps := len(headers)
+	if numTimestamps > medianTimeBlocks {
+		numTimestamps = medianTimeBlocks
+	}
+	timestamps := make([]int64, 0, numTimestamps)
+	for i := len(headers) - 1; i >= len(headers)-numTimestamps; i-- {
+		timestamps = append(timestamps, headers[i].Timestamp.Unix())
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i] < timestamps[j]
+	})
+
+	// NOTE: The consensus rules incorrectly calculate the median for even
+	// numbers of blocks.  This only affects a few blocks near the beginning of
+	// the chain since the number of blocks used is odd, however, the same
+	// calculation must be used to match the consensus rules.
+	return time.Unix(timestamps[numTimestamps/2], 0), nil
+}
+
+// findPrevTestNetDifficulty returns the difficulty of the header at the given
+// index or the most recent one before it which did not have the special
+// testnet minimum difficulty rule applied.
+func (c *HeaderCalculator) findPrevTestNetDifficulty(headers []wire.BlockHeader, startIdx int) uint32 {
+	// Search backwards through the chain for the last block without the
+	// special rule applied.
+	blocksPerRetarget := c.params.WorkDiffWindowSizeBlocks() *
+		c.params.WorkDiffWindowCount()
+	powLimitBits := c.params.PowLimitCompact()
+	idx := startIdx
+	for idx >= 0 && int64(headers[idx].Height)%blocksPerRetarget != 0 &&
+		headers[idx].Bits == powLimitBits {
+
+		idx--
+	}
+
+	// Return the found difficulty or the minimum difficulty if no appropriate
+	// block was found.
+	if idx < 0 {
+		return powLimitBits
+	}
+	return headers[idx].Bits
+}
+
+// CalcNextRequiredDifficulty calculates the required difficulty for the block
+// after the final provided header based on the difficulty retarget rules when
+// the new block has the provided timestamp.
+//
+// The headers must form a contiguous chain ordered from oldest to newest and
+// either contain at least the number of headers returned by
+// RequiredDifficultyHeaders or start at the genesis block.
+//
+// This function is safe for concurrent access.
+func (c *HeaderCalculator) CalcNextRequiredDifficulty(headers []wire.BlockHeader, newBlockTime time.Time) (uint32, error) {
+	if err := checkHeaders(headers, c.RequiredDifficultyHeaders()); err != nil {
+		return 0, err
+	}
+
+	// Get the old difficulty and return it when the next block is not at a
+	// retarget interval.
+	params := c.params
+	tipIdx := len(headers) - 1
+	tip := &headers[tipIdx]
+	oldDiff := tip.Bits
+	oldDiffBig := CompactToBig(oldDiff)
+	isTestNet3 := params.CurrencyNetwork() == wire.TestNet3
+	nextHeight := int64(tip.Height) + 1
+	windowSize := params.WorkDiffWindowSizeBlocks()
+	if nextHeight%windowSize != 0 {
+		// For networks that support it, allow special reduction of the
+		// required difficulty once too much time has elapsed without mining a
+		// block.
+		//
+		// Note that this behavior is deprecated and thus is only supported on
+		// testnet v3 prior to the max diff activation height.
+		reduceMinDiff, reductionTime := params.MinDifficultyReduction()
+		if reduceMinDiff && (!isTestNet3 || nextHeight <
+			testNet3MaxDiffActivationHeight) {
+
+			// Return minimum difficulty when more than the desired amount of
+			// time has elapsed without mining a block.
+			allowMinTime := tip.Timestamp.Unix() +
+				int64(reductionTime/time.Second)
+			if newBlockTime.Unix() > allowMinTime {
+				return params.PowLimitCompact(), nil
+			}
+
+			// The block was mined within the desired timeframe, so return the
+			// difficulty for the last block which did not have the special
+			// minimum difficulty rule applied.
+			return c.findPrevTestNetDifficulty(headers, tipIdx), nil
+		}
+
+		return oldDiff, nil
+	}
+
+	// Calculate the limits of the adjustment.
+	adjustmentFactor := big.NewInt(params.RetargetAdjustmentLimit())
+	nextDiffBigMin := CompactToBig(oldDiff)
+	nextDiffBigMin.Div(nextDiffBigMin, adjustmentFactor)
+	nextDiffBigMax := CompactToBig(oldDiff)
+	nextDiffBigMax.Mul(nextDiffBigMax, adjustmentFactor)
+
+	// Regress through all of the previous blocks and store the percent changes
+	// per window period while staying at the first header as needed.  Use
+	// big integers to emulate 64.32 bit fixed point.
+	alpha := params.WorkDiffSmoothingAlpha()
+	numWindows := params.WorkDiffWindowCount()
+	nodesToTraverse := windowSize * numWindows
+	targetTimespan := int64(params.RetargetTimespan() / time.Second)
+	windowChanges := make([]*big.Int, numWindows)
+	var windowPeriod int64
+	var weights uint64
+	oldIdx := tipIdx
+	recentTime := tip.Timestamp.Unix()
+	for i := int64(0); ; i++ {
+		// Store and reset after reaching the end of every window period.
+		if i%windowSize == 0 && i != 0 {
+			olderTime := headers[oldIdx].Timestamp.Unix()
+			timeDifference := recentTime - olderTime
+
+			// Just assume the target was hit (no change) when the genesis
+			// block was reached.
+			if headers[oldIdx].Height == 0 {
+				timeDifference = targetTimespan
+			}
+
+			timeDifBig := big.NewInt(timeDifference)
+			timeDifBig.Lsh(timeDifBig, 32) // Add padding
+			targetTemp := big.NewInt(targetTimespan)
+			windowAdjusted := targetTemp.Div(timeDifBig, targetTemp)
+
+			// Weight it exponentially.
+			windowAdjusted = windowAdjusted.Lsh(windowAdjusted,
+				uint((numWindows-windowPeriod)*alpha))
+			weights += 1 << uint64((numWindows-windowPeriod)*alpha)
+			windowChanges[windowPeriod] = windowAdjusted
+
+			windowPeriod++
+			recentTime = olderTime
+		}
+
+		if i == nodesToTraverse {
+			break
+		}
+		if oldIdx > 0 {
+			oldIdx--
+		}
+	}
+
+	// Sum up the weighted window periods, divide by the sum of all weights,
+	// multiply by the old difficulty, and restore the original padding.
+	weightedSum := big.NewInt(0)
+	for i := int64(0); i < numWindows; i++ {
+		weightedSum.Add(weightedSum, windowChanges[i])
+	}
+	weightsBig := big.NewInt(int64(weights))
+	weightedSumDiv := weightedSum.Div(weightedSum, weightsBig)
+	nextDiffBig := weightedSumDiv.Mul(weightedSumDiv, oldDiffBig)
+	nextDiffBig = nextDiffBig.Rsh(nextDiffBig, 32)
+
+	// Limit the result to the maximum allowable retarget except in the case
+	// the old difficulty is zero.
+	powLimit := params.PowLimitTarget()
+	switch {
+	case oldDiffBig.Sign() == 0:
+	case nextDiffBig.Sign() == 0:
+		nextDiffBig.Set(powLimit)
+	case nextDiffBig.Cmp(nextDiffBigMax) > 0:
+		nextDiffBig.Set(nextDiffBigMax)
+	case nextDiffBig.Cmp(nextDiffBigMin) < 0:
+		nextDiffBig.Set(nextDiffBigMin)
+	}
+
+	// Prevent the difficulty from going lower than the minimum allowed
+	// difficulty.
+	if nextDiffBig.Cmp(powLimit) > 0 {
+		nextDiffBig.Set(powLimit)
+	}
+
+	// Prevent the difficulty from going higher than the maximum allowed
+	// difficulty on version 3 of the test network once the max diff
+	// activation height has been reached.
+	if c.minTestNetTarget != nil && nextDiffBig.Cmp(c.minTestNetTarget) < 0 &&
+		nextHeight >= testNet3MaxDiffActivationHeight {
+
+		nextDiffBig = c.minTestNetTarget
+	}
+
+	return BigToCompact(nextDiffBig), nil
+}
+
+// sumPurchasedTickets returns the sum of the number of tickets purchased in the
+// specified number of headers ending with the header at the provided index.
+func sumPurchasedTickets(headers []wire.BlockHeader, endIdx int, numToSum int64) int64 {
+	var numPurchased int64
+	for idx, numTraversed := endIdx, int64(0); idx >= 0 &&
+		numTraversed < numToSum; numTraversed++ {
+
+		numPurchased += int64(headers[idx].FreshStake)
+		idx--
+	}
+	return numPurchased
+}
+
+// estimateSupply returns an estimate of the coin supply for the provided block
+// height per the same method used by the stake difficulty algorithm.
+func estimateSupply(params SubsidyParams, height int64) int64 {
+	if height <= 0 {
+		return 0
+	}
+
+	// Estimate the supply by calculating the full block subsidy for each
+	// reduction interval and multiplying it the number of blocks in the
+	// interval then adding the subsidy produced by number of blocks in the
+	// current interval.
+	supply := params.BlockOneSubsidy()
+	reductionInterval := params.SubsidyReductionIntervalBlocks()
+	reductions := height / reductionInterval
+	subsidy := params.BaseSubsidyValue()
+	for i := int64(0); i < reductions; i++ {
+		supply += reductionInterval * subsidy
+
+		subsidy *= params.SubsidyReductionMultiplier()
+		subsidy /= params.SubsidyReductionDivisor()
+	}
+	supply += (1 + height%reductionInterval) * subsidy
+
+	// Blocks 0 and 1 have special subsidy amounts that have already been
+	// added above, so remove what their subsidies would have normally been
+	// which were also added above.
+	supply -= params.BaseSubsidyValue() * 2
+
+	return supply
+}
+
+// CalcNextRequiredStakeDifficulty calculates the required stake difficulty for
+// the block after the final provided header based on the algorithm defined in
+// DCP0001.
+//
+// IMPORTANT: The result is only correct when the DCP0001 stake difficulty
+// algorithm is active for the block after the final provided header.  Prior
+// to its activation, the network used a different algorithm that is not
+// implemented here, so this function silently returns incorrect values for
+// those blocks.
+//
+// The headers must form a contiguous chain ordered from oldest to newest and
+// either contain at least the number of headers returned by
+// RequiredStakeDifficultyHeaders or start at the genesis block.
+//
+// This function is safe for concurrent access.
+func (c *HeaderCalculator) CalcNextRequiredStakeDifficulty(headers []wire.BlockHeader) (int64, error) {
+	err := checkHeaders(headers, c.RequiredStakeDifficultyHeaders())
+	if err != nil {
+		return 0, err
+	}
+
+	// Stake difficulty before any tickets could possibly be purchased is the
+	// minimum value.
+	params := c.params
+	tipIdx := len(headers) - 1
+	tip := &headers[tipIdx]
+	nextHeight := int64(tip.Height) + 1
+	stakeDiffStartHeight := int64(params.CoinbaseMaturityBlocks()) + 1
+	if nextHeight < stakeDiffStartHeight {
+		return params.MinimumStakeDifficulty(), nil
+	}
+
+	// Return the previous block's difficulty requirements if the next block
+	// is not at a difficulty retarget interval.
+	intervalSize := params.StakeDiffWindowSizeBlocks()
+	curDiff := tip.SBits
+	if nextHeight%intervalSize != 0 {
+		return curDiff, nil
+	}
+
+	// Get the pool size and number of tickets that were immature at the
+	// previous retarget interval relative to the block just before it to
+	// coincide with how it was originally calculated.
+	var prevPoolSize, prevImmatureTickets int64
+	ticketMaturity := int64(params.TicketMaturityBlocks())
+	prevRetargetHeight := nextHeight - intervalSize - 1
+	prevRetargetIdx := int(prevRetargetHeight - int64(headers[0].Height))
+	if prevRetargetHeight >= 0 && prevRetargetIdx >= 0 {
+		prevPoolSize = int64(headers[prevRetargetIdx].PoolSize)
+		prevImmatureTickets = sumPurchasedTickets(headers, prevRetargetIdx,
+			ticketMaturity)
+	}
+
+	// Return the existing ticket price for the first few intervals to avoid
+	// division by zero and encourage initial pool population.
+	prevPoolSizeAll := prevPoolSize + prevImmatureTickets
+	if prevPoolSizeAll == 0 {
+		return curDiff, nil
+	}
+
+	// Count the number of currently immature tickets.
+	immatureTickets := sumPurchasedTickets(headers, tipIdx, ticketMaturity)
+	curPoolSizeAll := int64(tip.PoolSize) + immatureTickets
+
+	// Calculate the difficulty per DCP0001 using integer math:
+	//
+	//                   curDiff * curPoolSizeAll^2
+	//   nextDiff = -----------------------------------
+	//              prevPoolSizeAll * targetPoolSizeAll
+	votesPerBlock := int64(params.VotesPerBlock())
+	ticketPoolSize := int64(params.TicketPoolSizeBlocks())
+	targetPoolSizeAll := votesPerBlock * (ticketPoolSize + ticketMaturity)
+	curPoolSizeAllBig := big.NewInt(curPoolSizeAll)
+	nextDiffBig := big.NewInt(curDiff)
+	nextDiffBig.Mul(nextDiffBig, curPoolSizeAllBig)
+	nextDiffBig.Mul(nextDiffBig, curPoolSizeAllBig)
+	nextDiffBig.Div(nextDiffBig, big.NewInt(prevPoolSizeAll))
+	nextDiffBig.Div(nextDiffBig, big.NewInt(targetPoolSizeAll))
+
+	// Limit the new stake difficulty between the minimum allowed stake
+	// difficulty and a maximum value that is relative to the total supply.
+	nextDiff := nextDiffBig.Int64()
+	maximumStakeDiff := estimateSupply(params, nextHeight) / ticketPoolSize
+	if nextDiff > maximumStakeDiff {
+		nextDiff = maximumStakeDiff
+	}
+	if minStakeDiff := params.MinimumStakeDifficulty(); nextDiff < minStakeDiff {
+		nextDiff = minStakeDiff
+	}
+	return nextDiff, nil
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package standalone
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/wire"
+)
+
+// mockHeaderCalcParams implements the HeaderCalcParams interface and is used
+// throughout the tests to mock networks.
+type mockHeaderCalcParams struct {
+	*mockSubsidyParams
+	net                    wire.CurrencyNet
+	powLimit               *big.Int
+	powLimitBits           uint32
+	reduceMinDifficulty    bool
+	minDiffReductionTime   time.Duration
+	workDiffWindowSize     int64
+	workDiffWindows        int64
+	workDiffAlpha          int64
+	targetTimespan         time.Duration
+	retargetAdjustmentFact int64
+	minimumStakeDiff       int64
+	stakeDiffWindowSize    int64
+	ticketPoolSize         uint16
+	ticketMaturity         uint16
+	coinbaseMaturity       uint16
+}
+
+// Ensure the mock header calc params satisfy the HeaderCalcParams interface.
+var _ HeaderCalcParams = (*mockHeaderCalcParams)(nil)
+
+// CurrencyNetwork returns the value associated with the mock params for the
+// network the parameters define.
+//
+// This is part of the HeaderCalcParams interface.
+func (p *mockHeaderCalcParams) CurrencyNetwork() wire.CurrencyNet {
+	return p.net
+}
+
+// PowLimitTarget returns the value associated with the mock params for the
+// highest allowed proof of work value.
+//
+// This is part of the HeaderCalcParams interface.
+func (p *mockHeaderCalcParams) PowLimitTarget() *big.Int {
+	return p.powLimit
+}
+
+// PowLimitCompact returns the value associated with the mock params for the
+// highest allowed proof of work value in compact form.
+//
+// This is part of the HeaderCalcParams interface.
+func (p *mockHeaderCalcParams) PowLimitCompact() uint32 {
+	return p.powLimitBits
+}
+
+// MinDifficultyReduction returns the values associated with the mock params
+// for the minimum difficulty reduction rules.
+//
+// This is part of the HeaderCalcParams interface.
+func (p *mockHeaderCalcParams) MinDifficultyReduction() (bool, time.Duration) {
+	return p.reduceMinDifficulty, p.minDiffReductionTime
+}
+
+// WorkDiffWindowSizeBlocks returns the value associated with the mock params
+// for the number of blocks in each proof of work difficulty interval.
+//
+// This is part of the HeaderCalcParams interface.
+func (p *mockHeaderCalcParams) WorkDiffWindowSizeBlocks() int64 {
+	return p.workDiffWindowSize
+}
+
+// WorkDiffWindowCount returns the value associated with the mock params for
+// the number of proof of work difficulty intervals.
+//
+// This is part of the HeaderCalcParams interface.
+func (p *mockHeaderCalcParams) WorkDiffWindowCount() int64 {
+	return p.workDiffWindows
+}
+
+// WorkDiffSmoothingAlpha returns the value associated with the mock params for
+// the proof of work difficulty smoothing factor.
+//
+// This is part of the HeaderCalcParams interface.
+func (p *mockHeaderCalcParams) WorkDiffSmoothingAlpha() int64 {
+	return p.workDiffAlpha
+}
+
+// RetargetTimespan returns the value associated with the mock params for the
+// desired amount of time for each proof of work difficulty interval.
+//
+// This is part of the HeaderCalcParams interface.
+func (p *mockHeaderCalcParams) RetargetTimespan() time.Duration {
+	return p.targetTimespan
+}
+
+// RetargetAdjustmentLimit returns the value associated with the mock params
+// for the proof of work difficulty adjustment limit.
+//
+// This is part of the HeaderCalcParams interface.
+func (p *mockHeaderCalcParams) RetargetAdjustmentLimit() int64 {
+	return p.retargetAdjustmentFact
+}
+
+// MinimumStakeDifficulty returns the value associated with the mock params for
+// the minimum stake difficulty.
+//
+// This is part of the HeaderCalcParams interface.
+func (p *mockHeaderCalcParams) MinimumStakeDifficulty() int64 {
+	return p.minimumStakeDiff
+}
+
+// StakeDiffWindowSizeBlocks returns the value associated with the mock params
+// for the number of blocks between stake difficulty retargets.
+//
+// This is part of the HeaderCalcParams interface.
+func (p *mockHeaderCalcParams) StakeDiffWindowSizeBlocks() int64 {
+	return p.stakeDiffWindowSize
+}
+
+// TicketPoolSizeBlocks returns the value associated with the mock params for
+// the target ticket pool size.
+//
+// This is part of the HeaderCalcParams interface.
+func (p *mockHeaderCalcParams) TicketPoolSizeBlocks() uint16 {
+	return p.ticketPoolSize
+}
+
+// TicketMaturityBlocks returns the value associated with the mock params for
+// the ticket maturity.
+//
+// This is part of the HeaderCalcParams interface.
+func (p *mockHeaderCalcParams) TicketMaturityBlocks() uint16 {
+	return p.ticketMaturity
+}
+
+// CoinbaseMaturityBlocks returns the value associated with the mock params for
+// the coinbase maturity.
+//
+// This is part of the HeaderCalcParams interface.
+func (p *mockHeaderCalcParams) CoinbaseMaturityBlocks() uint16 {
+	return p.coinbaseMaturity
+}
+
+// mockMainNetHeaderCalcParams returns mock header calculation parameters with
+// the values used by the main network.
+func mockMainNetHeaderCalcParams() *mockHeaderCalcParams {
+	return &mockHeaderCalcParams{
+		mockSubsidyParams:      mockMainNetParams(),
+		net:                    wire.MainNet,
+		powLimit:               CompactToBig(0x1d00ffff),
+		powLimitBits:           0x1d00ffff,
+		workDiffWindowSize:     144,
+		workDiffWindows:        20,
+		workDiffAlpha:          1,
+		targetTimespan:         time.Minute * 5 * 144,
+		retargetAdjustmentFact: 4,
+		minimumStakeDiff:       2 * 1e8,
+		stakeDiffWindowSize:    144,
+		ticketPoolSize:         8192,
+		ticketMaturity:         256,
+		coinbaseMaturity:       256,
+	}
+}
+
+// TestHeaderCalculatorErrors ensures the stateless header calculator returns
+// the expected errors when provided with invalid header chains.
+func TestHeaderCalculatorErrors(t *testing.T) {
+	calc := NewHeaderCalculator(mockMainNetHeaderCalcParams())
+
+	// Create a chain of headers that does not start at the genesis block and
+	// is one short of the number of headers required to calculate the median
+	// time.
+	numHeaders := calc.RequiredMedianTimeHeaders() - 1
+	headers := make([]wire.BlockHeader, 0, numHeaders)
+	var prevHash chainhash.Hash
+	for i := int64(0); i < numHeaders; i++ {
+		header := wire.BlockHeader{
+			PrevBlock: prevHash,
+			Height:    uint32(i + 1),
+			Timestamp: time.Unix(int64(i+1)*300, 0),
+		}
+		headers = append(headers, header)
+		prevHash = header.BlockHash()
+	}
+
+	// Disconnect the final header from the one before it.
+	disconnected := make([]wire.BlockHeader, len(headers))
+	copy(disconnected, headers)
+	disconnected[len(disconnected)-1].PrevBlock = chainhash.Hash{}
+
+	tests := []struct {
+		name    string
+		headers []wire.BlockHeader
+		wantErr error
+	}{{
+		name:    "no headers",
+		headers: nil,
+		wantErr: ErrInsufficientHeaders,
+	}, {
+		name:    "too few headers",
+		headers: headers,
+		wantErr: ErrInsufficientHeaders,
+	}, {
+		name:    "disconnected headers",
+		headers: disconnected,
+		wantErr: ErrHeaderChainDisconnected,
+	}}
+
+	for _, test := range tests {
+		_, err := calc.CalcPastMedianTime(test.headers)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%q: mismatched median time err -- got %v, want %v",
+				test.name, err, test.wantErr)
+		}
+		_, err = calc.CalcNextRequiredDifficulty(test.headers, time.Now())
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%q: mismatched difficulty err -- got %v, want %v",
+				test.name, err, test.wantErr)
+		}
+		_, err = calc.CalcNextRequiredStakeDifficulty(test.headers)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%q: mismatched stake difficulty err -- got %v, want %v",
+				test.name, err, test.wantErr)
+		}
+	}
+}
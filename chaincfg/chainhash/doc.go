@@ -2,4 +2,9 @@
 //
 // This package provides a generic hash type and associated functions that
 // allows the specific hash algorithm to be abstracted.
+//
+// Block header hashes are calculated by the function registered for the header
+// version via RegisterHeaderHashFunc and default to BLAKE-256 for versions that
+// do not have a registered function.  Registration must happen in an init
+// function since the registered functions are frozen once headers are hashed.
 package chainhash
@@ -0,0 +1,103 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chainhash
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// HeaderHashFunc defines the signature of a function that calculates the hash
+// of a serialized block header.
+type HeaderHashFunc func(serializedHeader []byte) Hash
+
+var (
+	// registerHeaderHashMtx serializes registration of header hash functions.
+	// It is not used when looking up the functions.
+	registerHeaderHashMtx sync.Mutex
+
+	// headerHashFuncs houses an immutable map of the registered header hash
+	// functions keyed by the block header version they apply to.  Header
+	// versions that do not have a registered function are hashed with HashH.
+	// Registration replaces the map with a new one so lookups are lock free.
+	headerHashFuncs atomic.Value
+
+	// headerHashFuncsFrozen is set atomically once the first header hash
+	// function lookup is performed and prevents any further registrations so
+	// the function used for a given header version never changes after
+	// headers have been hashed.
+	headerHashFuncsFrozen uint32
+)
+
+// RegisterHeaderHashFunc registers the provided function to be used to
+// calculate the hash of block headers with the provided version.
+//
+// This is intended to allow research forks and any potential future change to
+// the header hashing algorithm to be implemented without modifying all of the
+// callers that hash block headers.  Registration MUST be done in an init
+// function since the registered functions are frozen as soon as the first
+// header hash function lookup is performed.
+//
+// An error is returned when the provided function is nil, a function is
+// already registered for the version, or the registered functions are already
+// frozen.  Header versions without a registered function are hashed with
+// HashH, which is BLAKE-256.
+//
+// This function is safe for concurrent access.
+func RegisterHeaderHashFunc(version int32, fn HeaderHashFunc) error {
+	if fn == nil {
+		return errors.New("header hash function must not be nil")
+	}
+
+	registerHeaderHashMtx.Lock()
+	defer registerHeaderHashMtx.Unlock()
+
+	if atomic.LoadUint32(&headerHashFuncsFrozen) != 0 {
+		return errors.New("header hash functions must be registered before " +
+			"any headers are hashed")
+	}
+	funcs, _ := headerHashFuncs.Load().(map[int32]HeaderHashFunc)
+	if _, ok := funcs[version]; ok {
+		return fmt.Errorf("a header hash function is already registered "+
+			"for header version %d", version)
+	}
+	newFuncs := make(map[int32]HeaderHashFunc, len(funcs)+1)
+	for v, f := range funcs {
+		newFuncs[v] = f
+	}
+	newFuncs[version] = fn
+	headerHashFuncs.Store(newFuncs)
+	return nil
+}
+
+// HeaderHashFuncForVersion returns the function used to calculate the hash of
+// block headers with the provided version.  HashH is returned for header
+// versions that do not have a registered function.
+//
+// The registered functions are frozen once this is called, so any further
+// attempts to register functions will fail.
+//
+// This function is safe for concurrent access.
+func HeaderHashFuncForVersion(version int32) HeaderHashFunc {
+	if atomic.LoadUint32(&headerHashFuncsFrozen) == 0 {
+		atomic.StoreUint32(&headerHashFuncsFrozen, 1)
+	}
+	funcs, _ := headerHashFuncs.Load().(map[int32]HeaderHashFunc)
+	if fn, ok := funcs[version]; ok {
+		return fn
+	}
+	return HashH
+}
+
+// HeaderHash calculates the hash of the provided serialized block header using
+// the hash function registered for the provided header version, or HashH when
+// there is no registered function for it.
+//
+// This function is safe for concurrent access.
+func HeaderHash(version int32, serializedHeader []byte) Hash {
+	return HeaderHashFuncForVersion(version)(serializedHeader)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chainhash
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+// TestHeaderHash ensures header hashes are calculated with BLAKE-256 for
+// header versions without a registered function, with the registered function
+// otherwise, and that invalid registrations, including those after headers
+// have been hashed, are rejected.
+func TestHeaderHash(t *testing.T) {
+	// Reset the registered functions so registration is possible and restore
+	// them once the test completes.
+	origFuncs := headerHashFuncs.Load()
+	origFrozen := atomic.LoadUint32(&headerHashFuncsFrozen)
+	headerHashFuncs.Store(map[int32]HeaderHashFunc(nil))
+	atomic.StoreUint32(&headerHashFuncsFrozen, 0)
+	defer func() {
+		if origFuncs != nil {
+			headerHashFuncs.Store(origFuncs)
+		} else {
+			headerHashFuncs.Store(map[int32]HeaderHashFunc(nil))
+		}
+		atomic.StoreUint32(&headerHashFuncsFrozen, origFrozen)
+	}()
+
+	// Ensure nil functions are rejected.
+	const version = 0x7ffffff0
+	if err := RegisterHeaderHashFunc(version, nil); err == nil {
+		t.Fatal("registering nil header hash function did not error")
+	}
+
+	// Register a function that produces a constant hash and ensure duplicate
+	// registrations are rejected.
+	want := Hash{0x01, 0x02, 0x03}
+	fn := func([]byte) Hash { return want }
+	if err := RegisterHeaderHashFunc(version, fn); err != nil {
+		t.Fatalf("unexpected error registering header hash function: %v", err)
+	}
+	if err := RegisterHeaderHashFunc(version, fn); err == nil {
+		t.Fatal("duplicate header hash function registration did not error")
+	}
+
+	// Ensure the default hash function is used for versions without a
+	// registered function.
+	for _, test := range hashTests {
+		hash := HeaderHash(version-1, []byte(test.in))
+		if h := fmt.Sprintf("%x", hash[:]); h != test.out {
+			t.Fatalf("HeaderHash(%q) = %s, want %s", test.in, h, test.out)
+		}
+	}
+
+	// Ensure the registered function is used for the registered version.
+	if got := HeaderHash(version, []byte("abc")); got != want {
+		t.Fatalf("unexpected registered header hash -- got %v, want %v", got,
+			want)
+	}
+
+	// Ensure registrations are rejected once headers have been hashed.
+	if err := RegisterHeaderHashFunc(version+1, fn); err == nil {
+		t.Fatal("header hash function registration after hashing did not " +
+			"error")
+	}
+}
@@ -701,6 +701,90 @@ func (p *Params) TicketExpiryBlocks() uint32 {
 	return p.TicketExpiry
 }
 
+// CurrencyNetwork returns the network the parameters define.
+func (p *Params) CurrencyNetwork() wire.CurrencyNet {
+	return p.Net
+}
+
+// PowLimitTarget returns the highest allowed proof of work value for a block.
+func (p *Params) PowLimitTarget() *big.Int {
+	return p.PowLimit
+}
+
+// PowLimitCompact returns the highest allowed proof of work value for a block
+// in compact form.
+func (p *Params) PowLimitCompact() uint32 {
+	return p.PowLimitBits
+}
+
+// MinDifficultyReduction returns whether or not the network reduces the
+// required difficulty to the minimum once the returned amount of time has
+// elapsed without a block being mined.
+func (p *Params) MinDifficultyReduction() (bool, time.Duration) {
+	return p.ReduceMinDifficulty, p.MinDiffReductionTime
+}
+
+// WorkDiffWindowSizeBlocks returns the number of blocks in each interval used
+// to calculate the required proof of work difficulty.
+func (p *Params) WorkDiffWindowSizeBlocks() int64 {
+	return p.WorkDiffWindowSize
+}
+
+// WorkDiffWindowCount returns the number of intervals used to calculate the
+// required proof of work difficulty.
+func (p *Params) WorkDiffWindowCount() int64 {
+	return p.WorkDiffWindows
+}
+
+// WorkDiffSmoothingAlpha returns the smoothing factor applied to the intervals
+// used to calculate the required proof of work difficulty.
+func (p *Params) WorkDiffSmoothingAlpha() int64 {
+	return p.WorkDiffAlpha
+}
+
+// RetargetTimespan returns the desired amount of time that should elapse for
+// each interval used to calculate the required proof of work difficulty.
+func (p *Params) RetargetTimespan() time.Duration {
+	return p.TargetTimespan
+}
+
+// RetargetAdjustmentLimit returns the factor used to limit the minimum and
+// maximum amount of adjustment that can occur between proof of work difficulty
+// retargets.
+func (p *Params) RetargetAdjustmentLimit() int64 {
+	return p.RetargetAdjustmentFactor
+}
+
+// MinimumStakeDifficulty returns the minimum amount of atoms required to
+// purchase a ticket.
+func (p *Params) MinimumStakeDifficulty() int64 {
+	return p.MinimumStakeDiff
+}
+
+// StakeDiffWindowSizeBlocks returns the number of blocks between each stake
+// difficulty retarget.
+func (p *Params) StakeDiffWindowSizeBlocks() int64 {
+	return p.StakeDiffWindowSize
+}
+
+// TicketPoolSizeBlocks returns the target size of the ticket pool in multiples
+// of the number of votes per block.
+func (p *Params) TicketPoolSizeBlocks() uint16 {
+	return p.TicketPoolSize
+}
+
+// TicketMaturityBlocks returns the number of blocks required for tickets to
+// mature.
+func (p *Params) TicketMaturityBlocks() uint16 {
+	return p.TicketMaturity
+}
+
+// CoinbaseMaturityBlocks returns the number of blocks required before newly
+// mined coins can be spent.
+func (p *Params) CoinbaseMaturityBlocks() uint16 {
+	return p.CoinbaseMaturity
+}
+
 // newHashFromStr converts the passed big-endian hex string into a
 // chainhash.Hash.  It only differs from the one available in chainhash in that
 // it panics on an error since it will only (and must only) be called with
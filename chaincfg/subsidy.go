@@ -1,4 +1,4 @@
-// Copyright (c) 2019 The Decred developers
+// Copyright (c) 2019-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -25,3 +25,196 @@ func tokenPayouts(scriptsHex string, payouts []blockOnePayout) []TokenPayout {
 	}
 	return tokenPayouts
 }
+
+// SubsidySplit houses the portions of the subsidy generated by a block that
+// are allocated to proof-of-work, proof-of-stake, and the treasury.
+type SubsidySplit struct {
+	// Work is the subsidy paid to the creator of the block.
+	Work int64
+
+	// Stake is the total subsidy paid to all of the votes in the block.
+	Stake int64
+
+	// Treasury is the subsidy allocated to the project treasury.
+	Treasury int64
+}
+
+// Total returns the total subsidy of all portions of the split.
+func (s *SubsidySplit) Total() int64 {
+	return s.Work + s.Stake + s.Treasury
+}
+
+// BlockSubsidy returns the max potential subsidy for a block at the provided
+// height prior to it being split between proof-of-work, proof-of-stake, and the
+// treasury.
+//
+// The base subsidy is reduced exponentially every reduction interval as
+// follows:
+//
+//	subsidy := BaseSubsidy
+//	for i := 0; i < (height / SubsidyReductionInterval); i++ {
+//	  subsidy *= MulSubsidy
+//	  subsidy /= DivSubsidy
+//	}
+//
+// Block 1 is special since it houses the initial token distribution and the
+// genesis block does not produce any subsidy.
+func (p *Params) BlockSubsidy(height int64) int64 {
+	switch {
+	case height <= 0:
+		return 0
+	case height == 1:
+		return p.BlockOneSubsidy()
+	}
+
+	subsidy := p.BaseSubsidy
+	numReductions := height / p.SubsidyReductionInterval
+	for i := int64(0); i < numReductions && subsidy != 0; i++ {
+		subsidy *= p.MulSubsidy
+		subsidy /= p.DivSubsidy
+	}
+	return subsidy
+}
+
+// calcSubsidySplit returns the subsidy split for a block at the provided
+// height with the provided number of votes given the max potential subsidy
+// for the block and its parent.  This is the primary implementation logic used
+// by CalcSubsidySplit and TotalSupply.
+//
+// See the comments of CalcSubsidySplit for further details.
+func (p *Params) calcSubsidySplit(height int64, voters uint16, useDCP0010,
+	isTreasuryEnabled bool, subsidy, parentSubsidy int64) SubsidySplit {
+
+	// The genesis block does not produce any subsidy and the first block
+	// houses the initial token distribution.
+	switch {
+	case height <= 0:
+		return SubsidySplit{}
+	case height == 1:
+		return SubsidySplit{Work: p.BlockOneSubsidy()}
+	}
+
+	// There is no subsidy if there are not enough voters once voting begins
+	// since a block without enough voters is invalid.
+	votesPerBlock := int64(p.TicketsPerBlock)
+	if height >= p.StakeValidationHeight && voters < p.TicketsPerBlock/2+1 {
+		return SubsidySplit{}
+	}
+
+	// Determine the proportions of the subsidy allocated to proof-of-work and
+	// proof-of-stake.  The modified split defined in DCP0010 is 10% PoW and
+	// 80% PoS.
+	workProportion := int64(p.WorkRewardProportion)
+	stakeProportion := int64(p.StakeRewardProportion)
+	totalProportions := int64(p.TotalSubsidyProportions())
+	splitProportions := totalProportions
+	if useDCP0010 {
+		workProportion, stakeProportion, splitProportions = 1, 8, 10
+	}
+
+	split := SubsidySplit{
+		Work:     subsidy * workProportion / splitProportions,
+		Treasury: subsidy * int64(p.BlockTaxProportion) / totalProportions,
+	}
+
+	// Ignore any potential subsidy reductions due to the number of votes prior
+	// to the point voting begins.  Note that votes do not produce any subsidy
+	// prior to that point either.
+	if height < p.StakeValidationHeight {
+		return split
+	}
+
+	// Adjust the work subsidy and, prior to the decentralized treasury agenda,
+	// the treasury subsidy for the number of voters.
+	split.Work = int64(voters) * split.Work / votesPerBlock
+	if !isTreasuryEnabled {
+		split.Treasury = int64(voters) * split.Treasury / votesPerBlock
+	}
+
+	// The subsidy for each vote is based on the height being voted on, which
+	// is the parent of the block that includes it, and it is not reduced when
+	// the block contains less than the maximum number of votes.
+	voteSubsidy := parentSubsidy * stakeProportion /
+		(splitProportions * votesPerBlock)
+	split.Stake = int64(voters) * voteSubsidy
+	return split
+}
+
+// CalcSubsidySplit returns the subsidy for a block at the provided height that
+// contains the provided number of votes split between proof-of-work,
+// proof-of-stake, and the treasury.  The provided flags specify whether or not
+// the modified subsidy split defined in DCP0010 and the decentralized treasury
+// agenda defined in DCP0006 are active for the block.
+//
+// Note that passing a number of voters fewer than the minimum required for a
+// block to be valid by consensus along with a height greater than or equal to
+// the height at which voting begins will return a split with zero subsidy.
+func (p *Params) CalcSubsidySplit(height int64, voters uint16, useDCP0010, isTreasuryEnabled bool) SubsidySplit {
+	return p.calcSubsidySplit(height, voters, useDCP0010, isTreasuryEnabled,
+		p.BlockSubsidy(height), p.BlockSubsidy(height-1))
+}
+
+// TotalSupply returns the cumulative subsidy issued by all blocks up to and
+// including the provided height assuming every block once voting begins
+// contains the maximum number of votes and is approved by stakeholders.  In
+// other words, it is the maximum supply permitted by the subsidy schedule as
+// of the provided height.
+//
+// The modified subsidy split defined in DCP0010 is applied to all blocks at or
+// after the provided activation height.  A negative activation height indicates
+// the modified split is not active.
+func (p *Params) TotalSupply(height, dcp0010Height int64) int64 {
+	if height < 1 {
+		return 0
+	}
+
+	// addRun adds the subsidy for the blocks in the provided inclusive range
+	// that all share the same max potential subsidy as well as the same max
+	// potential subsidy for their parents.
+	supply := p.BlockOneSubsidy()
+	addRun := func(start, end, subsidy, parentSubsidy int64) {
+		for start <= end {
+			// The split only changes at the heights voting begins and the
+			// modified split takes effect.
+			next := end + 1
+			for _, boundary := range [...]int64{p.StakeValidationHeight,
+				dcp0010Height} {
+
+				if boundary > start && boundary < next {
+					next = boundary
+				}
+			}
+
+			useDCP0010 := dcp0010Height >= 0 && start >= dcp0010Height
+			split := p.calcSubsidySplit(start, p.TicketsPerBlock, useDCP0010,
+				true, subsidy, parentSubsidy)
+			supply += split.Total() * (next - start)
+			start = next
+		}
+	}
+
+	// Add the subsidy for each reduction interval while taking care to handle
+	// the first block of each interval separately since the subsidy for the
+	// votes it contains is based on its parent in the previous interval.
+	interval := p.SubsidyReductionInterval
+	subsidy, parentSubsidy := p.BaseSubsidy, p.BlockOneSubsidy()
+	for start := int64(2); start <= height; {
+		end := start - start%interval + interval - 1
+		if end > height {
+			end = height
+		}
+		addRun(start, start, subsidy, parentSubsidy)
+		addRun(start+1, end, subsidy, subsidy)
+
+		// Stop once there is no more subsidy to issue.
+		parentSubsidy = subsidy
+		subsidy *= p.MulSubsidy
+		subsidy /= p.DivSubsidy
+		if subsidy == 0 && parentSubsidy == 0 {
+			break
+		}
+		start = end + 1
+	}
+
+	return supply
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"testing"
+)
+
+// TestCalcSubsidySplit ensures the subsidy split calculated for blocks on the
+// main network is correct for various heights, numbers of votes, and agendas.
+func TestCalcSubsidySplit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string       // test description
+		height     int64        // height to calculate the split for
+		voters     uint16       // number of votes in the block
+		useDCP0010 bool         // use the modified subsidy split
+		isTrsyOn   bool         // decentralized treasury agenda is active
+		subsidy    int64        // expected max potential block subsidy
+		want       SubsidySplit // expected subsidy split
+	}{{
+		name:   "genesis block",
+		height: 0,
+		want:   SubsidySplit{},
+	}, {
+		name:    "block one",
+		height:  1,
+		subsidy: 168000000000000,
+		want:    SubsidySplit{Work: 168000000000000},
+	}, {
+		name:    "first block with regular subsidy",
+		height:  2,
+		subsidy: 3119582664,
+		want:    SubsidySplit{Work: 1871749598, Treasury: 311958266},
+	}, {
+		name:    "last block prior to voting",
+		height:  4095,
+		subsidy: 3119582664,
+		want:    SubsidySplit{Work: 1871749598, Treasury: 311958266},
+	}, {
+		name:    "first block with votes, max votes",
+		height:  4096,
+		voters:  5,
+		subsidy: 3119582664,
+		want: SubsidySplit{
+			Work:     1871749598,
+			Stake:    935874795,
+			Treasury: 311958266,
+		},
+	}, {
+		name:    "first block with votes, min votes",
+		height:  4096,
+		voters:  3,
+		subsidy: 3119582664,
+		want: SubsidySplit{
+			Work:     1123049758,
+			Stake:    561524877,
+			Treasury: 187174959,
+		},
+	}, {
+		name:    "first block with votes, not enough votes",
+		height:  4096,
+		voters:  2,
+		subsidy: 3119582664,
+		want:    SubsidySplit{},
+	}, {
+		name:    "first reduction, votes for prior interval",
+		height:  6144,
+		voters:  5,
+		subsidy: 3088695706,
+		want: SubsidySplit{
+			Work:     1853217423,
+			Stake:    935874795,
+			Treasury: 308869570,
+		},
+	}, {
+		name:     "first reduction, treasury agenda, 4 votes",
+		height:   6144,
+		voters:   4,
+		isTrsyOn: true,
+		subsidy:  3088695706,
+		want: SubsidySplit{
+			Work:     1482573938,
+			Stake:    748699836,
+			Treasury: 308869570,
+		},
+	}, {
+		name:     "original split at reduction interval 104",
+		height:   638976,
+		voters:   5,
+		isTrsyOn: true,
+		subsidy:  1108341543,
+		want: SubsidySplit{
+			Work:     665004925,
+			Stake:    335827485,
+			Treasury: 110834154,
+		},
+	}, {
+		name:       "modified split at reduction interval 104",
+		height:     638976,
+		voters:     5,
+		useDCP0010: true,
+		isTrsyOn:   true,
+		subsidy:    1108341543,
+		want: SubsidySplit{
+			Work:     110834154,
+			Stake:    895539965,
+			Treasury: 110834154,
+		},
+	}, {
+		name:       "modified split after reduction interval 104, 4 votes",
+		height:     638977,
+		voters:     4,
+		useDCP0010: true,
+		isTrsyOn:   true,
+		subsidy:    1108341543,
+		want: SubsidySplit{
+			Work:     88667323,
+			Stake:    709338584,
+			Treasury: 110834154,
+		},
+	}}
+
+	params := MainNetParams()
+	for _, test := range tests {
+		subsidy := params.BlockSubsidy(test.height)
+		if subsidy != test.subsidy {
+			t.Errorf("%q: unexpected block subsidy -- got %d, want %d",
+				test.name, subsidy, test.subsidy)
+			continue
+		}
+
+		split := params.CalcSubsidySplit(test.height, test.voters,
+			test.useDCP0010, test.isTrsyOn)
+		if split != test.want {
+			t.Errorf("%q: unexpected split -- got %+v, want %+v", test.name,
+				split, test.want)
+			continue
+		}
+	}
+}
+
+// TestTotalSupply ensures the total supply calculated for the main network is
+// correct for various heights and activation heights of the modified subsidy
+// split.  It also ensures the result matches the sum of the subsidy splits of
+// the individual blocks.
+func TestTotalSupply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string // test description
+		height        int64  // height to calculate the supply for
+		dcp0010Height int64  // activation height of the modified split
+		want          int64  // expected total supply
+	}{{
+		name:          "genesis block",
+		height:        0,
+		dcp0010Height: -1,
+		want:          0,
+	}, {
+		name:          "block one",
+		height:        1,
+		dcp0010Height: -1,
+		want:          168000000000000,
+	}, {
+		name:          "last block prior to voting",
+		height:        4095,
+		dcp0010Height: -1,
+		want:          176940099995216,
+	}, {
+		name:          "first block with votes",
+		height:        4096,
+		dcp0010Height: -1,
+		want:          176943219577875,
+	}, {
+		name:          "reduction interval 104, original split",
+		height:        638976,
+		dcp0010Height: -1,
+		want:          1412227576079522,
+	}, {
+		name:          "reduction interval 104, modified split",
+		height:        638976,
+		dcp0010Height: 638976,
+		want:          1412227581621231,
+	}, {
+		name:          "all subsidy issued, original split",
+		height:        1 << 40,
+		dcp0010Height: -1,
+		want:          2100000935675707,
+	}, {
+		name:          "all subsidy issued, modified split",
+		height:        1 << 40,
+		dcp0010Height: 638976,
+		want:          2100001495603227,
+	}}
+
+	params := MainNetParams()
+	for _, test := range tests {
+		supply := params.TotalSupply(test.height, test.dcp0010Height)
+		if supply != test.want {
+			t.Errorf("%q: unexpected total supply -- got %d, want %d",
+				test.name, supply, test.want)
+			continue
+		}
+	}
+
+	// Ensure the total supply matches the sum of the individual subsidy splits
+	// of every block with the max number of votes across several reduction
+	// intervals and the activation of the modified split.
+	const dcp0010Height = 6144*3 + 7
+	const maxHeight = 6144 * 5
+	var sum int64
+	for height := int64(0); height <= maxHeight; height++ {
+		split := params.CalcSubsidySplit(height, params.TicketsPerBlock,
+			height >= dcp0010Height, true)
+		sum += split.Total()
+		if height%1000 != 0 && height%6144 > 1 && height != maxHeight {
+			continue
+		}
+		supply := params.TotalSupply(height, dcp0010Height)
+		if supply != sum {
+			t.Fatalf("height %d: unexpected total supply -- got %d, want %d",
+				height, supply, sum)
+		}
+	}
+}
@@ -351,7 +351,8 @@ func newBlockImporter(ctx context.Context, db database.DB, utxoDb *leveldb.DB, r
 	}
 	if !cfg.NoExistsAddrIndex {
 		log.Info("Exists address index is enabled")
-		existsAddrIndex, err = indexers.NewExistsAddrIndex(subber, db, queryer)
+		existsAddrIndex, err = indexers.NewExistsAddrIndex(subber, db, queryer,
+			indexers.DefaultExistsAddrFPRate)
 		if err != nil {
 			return nil, err
 		}
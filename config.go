@@ -10,6 +10,7 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
@@ -27,11 +28,13 @@ import (
 	"github.com/decred/dcrd/database/v3"
 	_ "github.com/decred/dcrd/database/v3/ffldb"
 	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/internal/blockchain/indexers"
 	"github.com/decred/dcrd/internal/mempool"
 	"github.com/decred/dcrd/internal/version"
 	"github.com/decred/dcrd/rpc/jsonrpc/types/v4"
 	"github.com/decred/dcrd/sampleconfig"
 	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
 	"github.com/decred/go-socks/socks"
 	"github.com/decred/slog"
 	flags "github.com/jessevdk/go-flags"
@@ -39,23 +42,27 @@ import (
 
 const (
 	// Defaults for general application behavior options.
-	defaultConfigFilename   = "dcrd.conf"
-	defaultDataDirname      = "data"
-	defaultLogDirname       = "logs"
-	defaultLogFilename      = "dcrd.log"
-	defaultLogSize          = "10M"
-	defaultDbType           = "ffldb"
-	defaultLogLevel         = "info"
-	defaultSigCacheMaxSize  = 100000
-	defaultUtxoCacheMaxSize = 150
-	minUtxoCacheMaxSize     = 25
-	maxUtxoCacheMaxSize     = 32768 // 32 GiB
+	defaultConfigFilename     = "dcrd.conf"
+	defaultDataDirname        = "data"
+	defaultLogDirname         = "logs"
+	defaultLogFilename        = "dcrd.log"
+	defaultLogSize            = "10M"
+	defaultDbType             = "ffldb"
+	defaultLogLevel           = "info"
+	defaultSigCacheMaxSize    = 100000
+	defaultScriptCacheMaxSize = 100000
+	defaultUtxoCacheMaxSize   = 150
+	minUtxoCacheMaxSize       = 25
+	maxUtxoCacheMaxSize       = 32768 // 32 GiB
 
 	// Defaults for RPC server options and policy.
 	defaultTLSCurve             = "P-256"
 	defaultMaxRPCClients        = 10
 	defaultMaxRPCWebsockets     = 25
 	defaultMaxRPCConcurrentReqs = 20
+	defaultMaxRPCWSQueueBytes   = 64 * 1024 * 1024
+	defaultRPCCacheBytes        = 32 * 1024 * 1024
+	defaultRPCAuthLockout       = time.Minute
 
 	// Defaults for P2P network options.
 	defaultMaxSameIP       = 5
@@ -63,6 +70,11 @@ const (
 	defaultDialTimeout     = time.Second * 30
 	defaultPeerIdleTimeout = time.Second * 120
 
+	// Defaults for inventory trickling options.
+	defaultTrickleInterval        = time.Millisecond * 500
+	defaultInboundTrickleInterval = time.Second
+	minTrickleInterval            = time.Millisecond * 10
+
 	// Defaults for banning options.
 	defaultBanDuration  = time.Hour * 24
 	defaultBanThreshold = 100
@@ -70,6 +82,7 @@ const (
 	// Defaults for relay and mempool policy options.
 	defaultMaxOrphanTransactions = 100
 	defaultAllowOldVotes         = false
+	defaultMempoolJournalSize    = 1000
 
 	// Defaults for mining options and policy.
 	defaultGenerate            = false
@@ -121,41 +134,54 @@ func minUint32(a, b uint32) uint32 {
 // See loadConfig for details on the configuration load process.
 type config struct {
 	// General application behavior.
-	ShowVersion      bool   `short:"V" long:"version" description:"Display version information and exit"`
-	HomeDir          string `short:"A" long:"appdata" description:"Path to application home directory"`
-	ConfigFile       string `short:"C" long:"configfile" description:"Path to configuration file"`
-	DataDir          string `short:"b" long:"datadir" description:"Directory to store data"`
-	LogDir           string `long:"logdir" description:"Directory to log output"`
-	LogSize          string `long:"logsize" description:"Maximum size of log file before it is rotated"`
-	NoFileLogging    bool   `long:"nofilelogging" description:"Disable file logging"`
-	DbType           string `long:"dbtype" description:"Database backend to use for the block chain"`
-	Profile          string `long:"profile" description:"Enable HTTP profiling on given [addr:]port -- NOTE port must be between 1024 and 65536"`
-	CPUProfile       string `long:"cpuprofile" description:"Write CPU profile to the specified file"`
-	MemProfile       string `long:"memprofile" description:"Write mem profile to the specified file"`
-	TestNet          bool   `long:"testnet" description:"Use the test network"`
-	SimNet           bool   `long:"simnet" description:"Use the simulation test network"`
-	RegNet           bool   `long:"regnet" description:"Use the regression test network"`
-	DebugLevel       string `short:"d" long:"debuglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
-	SigCacheMaxSize  uint   `long:"sigcachemaxsize" description:"The maximum number of entries in the signature verification cache"`
-	UtxoCacheMaxSize uint   `long:"utxocachemaxsize" description:"The maximum size in MiB of the utxo cache; (min: 25, max: 32768)"`
+	ShowVersion        bool   `short:"V" long:"version" description:"Display version information and exit"`
+	HomeDir            string `short:"A" long:"appdata" description:"Path to application home directory"`
+	ConfigFile         string `short:"C" long:"configfile" description:"Path to configuration file"`
+	DataDir            string `short:"b" long:"datadir" description:"Directory to store data"`
+	LogDir             string `long:"logdir" description:"Directory to log output"`
+	LogSize            string `long:"logsize" description:"Maximum size of log file before it is rotated"`
+	NoFileLogging      bool   `long:"nofilelogging" description:"Disable file logging"`
+	LogFormat          string `long:"logformat" description:"The format of log entries {text, json} -- json writes each entry as a JSON object with the subsystem, level, message, and context such as block hashes, transaction hashes, and peer addresses"`
+	DbType             string `long:"dbtype" description:"Database backend to use for the block chain"`
+	Profile            string `long:"profile" description:"Enable HTTP profiling on given [addr:]port -- NOTE port must be between 1024 and 65536"`
+	CPUProfile         string `long:"cpuprofile" description:"Write CPU profile to the specified file"`
+	MemProfile         string `long:"memprofile" description:"Write mem profile to the specified file"`
+	TestNet            bool   `long:"testnet" description:"Use the test network"`
+	SimNet             bool   `long:"simnet" description:"Use the simulation test network"`
+	RegNet             bool   `long:"regnet" description:"Use the regression test network"`
+	DebugLevel         string `short:"d" long:"debuglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
+	SigCacheMaxSize    uint   `long:"sigcachemaxsize" description:"The maximum number of entries in the signature verification cache"`
+	ScriptCacheMaxSize uint   `long:"scriptcachemaxsize" description:"The maximum number of entries in the script execution cache"`
+	UtxoCacheMaxSize   uint   `long:"utxocachemaxsize" description:"The maximum size in MiB of the utxo cache; (min: 25, max: 32768)"`
+	UtxoPrefetch       bool   `long:"utxoprefetch" description:"Prefetch the utxos spent by the next block into the utxo cache while connecting blocks during reorganizations"`
 
 	// RPC server options and policy.
-	DisableRPC           bool     `long:"norpc" description:"Disable built-in RPC server -- NOTE: The RPC server is disabled by default if no rpcuser/rpcpass or rpclimituser/rpclimitpass is specified"`
-	RPCListeners         []string `long:"rpclisten" description:"Add an interface/port to listen for RPC connections (default port: 9109, testnet: 19109)"`
-	RPCUser              string   `short:"u" long:"rpcuser" description:"Username for RPC connections"`
-	RPCPass              string   `short:"P" long:"rpcpass" default-mask:"-" description:"Password for RPC connections"`
-	RPCAuthType          string   `long:"authtype" description:"Method for RPC client authentication (basic or clientcert)"`
-	RPCClientCAs         string   `long:"clientcafile" description:"File containing Certificate Authorities to verify TLS client certificates; requires authtype=clientcert"`
-	RPCLimitUser         string   `long:"rpclimituser" description:"Username for limited RPC connections"`
-	RPCLimitPass         string   `long:"rpclimitpass" default-mask:"-" description:"Password for limited RPC connections"`
-	RPCCert              string   `long:"rpccert" description:"File containing the certificate file"`
-	RPCKey               string   `long:"rpckey" description:"File containing the certificate key"`
-	TLSCurve             string   `long:"tlscurve" description:"Curve to use when generating TLS keypairs"`
-	AltDNSNames          []string `long:"altdnsnames" description:"Specify additional DNS names to use when generating the RPC server certificate" env:"DCRD_ALT_DNSNAMES" env-delim:","`
-	DisableTLS           bool     `long:"notls" description:"Disable TLS for the RPC server -- NOTE: This is only allowed if the RPC server is bound to localhost"`
-	RPCMaxClients        int      `long:"rpcmaxclients" description:"Max number of RPC clients for standard connections"`
-	RPCMaxWebsockets     int      `long:"rpcmaxwebsockets" description:"Max number of RPC websocket connections"`
-	RPCMaxConcurrentReqs int      `long:"rpcmaxconcurrentreqs" description:"Max number of concurrent RPC requests that may be processed concurrently"`
+	DisableRPC            bool          `long:"norpc" description:"Disable built-in RPC server -- NOTE: The RPC server is disabled by default if no rpcuser/rpcpass or rpclimituser/rpclimitpass is specified"`
+	RPCListeners          []string      `long:"rpclisten" description:"Add an interface/port to listen for RPC connections (default port: 9109, testnet: 19109)"`
+	RPCUser               string        `short:"u" long:"rpcuser" description:"Username for RPC connections"`
+	RPCPass               string        `short:"P" long:"rpcpass" default-mask:"-" description:"Password for RPC connections"`
+	RPCAuthType           string        `long:"authtype" description:"Method for RPC client authentication (basic or clientcert)"`
+	RPCClientCAs          string        `long:"clientcafile" description:"File containing Certificate Authorities to verify TLS client certificates; requires authtype=clientcert"`
+	RPCLimitUser          string        `long:"rpclimituser" description:"Username for limited RPC connections"`
+	RPCLimitPass          string        `long:"rpclimitpass" default-mask:"-" description:"Password for limited RPC connections"`
+	RPCCert               string        `long:"rpccert" description:"File containing the certificate file"`
+	RPCKey                string        `long:"rpckey" description:"File containing the certificate key"`
+	TLSCurve              string        `long:"tlscurve" description:"Curve to use when generating TLS keypairs"`
+	AltDNSNames           []string      `long:"altdnsnames" description:"Specify additional DNS names to use when generating the RPC server certificate" env:"DCRD_ALT_DNSNAMES" env-delim:","`
+	DisableTLS            bool          `long:"notls" description:"Disable TLS for the RPC server -- NOTE: This is only allowed if the RPC server is bound to localhost"`
+	RPCListenUnix         string        `long:"rpclistenunix" description:"Path of a Unix domain socket to listen for RPC connections on -- TLS is not used for connections over the socket and only the user running the server is permitted to access it"`
+	RPCUnixNoAuth         bool          `long:"rpcunixnoauth" description:"Do not require RPC authentication for connections over the Unix domain socket specified by --rpclistenunix since access is controlled by its file permissions -- The RPC server only listens on the socket when no rpcuser/rpcpass or rpclimituser/rpclimitpass is specified"`
+	RPCMaxClients         int           `long:"rpcmaxclients" description:"Max number of RPC clients for standard connections"`
+	RPCMaxWebsockets      int           `long:"rpcmaxwebsockets" description:"Max number of RPC websocket connections"`
+	RPCMaxConcurrentReqs  int           `long:"rpcmaxconcurrentreqs" description:"Max number of concurrent RPC requests that may be processed concurrently"`
+	RPCMaxWebsocketsPerIP int           `long:"rpcmaxwebsocketsperip" description:"Max number of RPC websocket connections per IP address -- 0 to disable"`
+	RPCMaxWSQueueBytes    int64         `long:"rpcmaxwsqueuebytes" description:"Max number of bytes of notifications that may be queued for a RPC websocket client before they are dropped and the client is disconnected -- 0 to disable"`
+	RPCCacheBytes         int64         `long:"rpccachebytes" description:"Max number of bytes of immutable RPC responses, such as those for deeply confirmed blocks and transactions, to cache in memory -- 0 to disable"`
+	RPCMaxAuthFailures    int           `long:"rpcmaxauthfailures" description:"Number of consecutive RPC authentication failures from an IP address before it is locked out from authenticating -- Each additional failure doubles the lockout duration up to 1 hour; 0 to disable"`
+	RPCAuthLockout        time.Duration `long:"rpcauthlockout" description:"Initial duration an IP address is locked out from authenticating after reaching --rpcmaxauthfailures.  Valid time units are {s, m, h}"`
+	RPCAuditLog           string        `long:"rpcauditlog" description:"File to append a JSON line to for each invocation of a privileged RPC (disabled when empty)"`
+	RPCAuditRedact        []string      `long:"rpcauditredact" description:"Redact RPC parameters from the audit log -- Specify method to redact all parameters of a method or method.param to redact a single parameter; may be specified multiple times"`
+	DebugRPC              bool          `long:"debugrpc" description:"Enable RPCs intended for testing such as setmocktime and forceprune -- Only allowed on simnet and regnet"`
 
 	// P2P proxy and Tor settings.
 	Proxy          string `long:"proxy" description:"Connect via SOCKS5 proxy (eg. 127.0.0.1:9050)"`
@@ -172,10 +198,21 @@ type config struct {
 	ConnectPeers    []string      `long:"connect" description:"Connect only to the specified peers at startup"`
 	DisableListen   bool          `long:"nolisten" description:"Disable listening for incoming connections -- NOTE: Listening is automatically disabled if the --connect or --proxy options are used without also specifying listen interfaces via --listen"`
 	Listeners       []string      `long:"listen" description:"Add an interface/port to listen for connections (default all interfaces port: 9108, testnet: 19108)"`
+	ListenPolicies  []string      `long:"listenpolicy" description:"Apply policies to peers accepted via a listen interface/port in the form <interface/port>=<policy>[,<policy>...] -- Supported policies: onion (connections are from a local Tor hidden service so they are never whitelisted and the address is not advertised), norelay (do not relay transactions to or from the peers)"`
 	MaxSameIP       int           `long:"maxsameip" description:"Max number of connections with the same IP -- 0 to disable"`
 	MaxPeers        int           `long:"maxpeers" description:"Max number of inbound and outbound peers"`
 	DialTimeout     time.Duration `long:"dialtimeout" description:"How long to wait for TCP connection completion.  Valid time units are {s, m, h}.  Minimum 1 second"`
 	PeerIdleTimeout time.Duration `long:"peeridletimeout" description:"The duration of inactivity before a peer is timed out. Valid time units are {s,m,h}. Minimum 15 seconds"`
+	NoCompression   bool          `long:"nocompression" description:"Disable negotiating compression of large block and filter messages with peers"`
+
+	// P2P inventory trickling options.
+	TrickleInterval        time.Duration `long:"trickleinterval" description:"The average duration between inventory announcements to outbound peers -- Whitelisted peers are sent announcements at a quarter of the interval.  Valid time units are {ms,s,m}.  Minimum 10 milliseconds"`
+	InboundTrickleInterval time.Duration `long:"inboundtrickleinterval" description:"The average duration between inventory announcements to inbound peers.  Valid time units are {ms,s,m}.  Minimum 10 milliseconds"`
+
+	// P2P user agent options.
+	UserAgentComments  []string `long:"uacomment" description:"Add a comment to the user agent advertised to peers -- Comments may not contain the '/', ':', '(', or ')' characters"`
+	RejectAgents       []string `long:"rejectagent" description:"Reject peers with a user agent that matches the regular expression (eg. '/dcrd:1.[0-6].')"`
+	DeprioritizeAgents []string `long:"deprioritizeagent" description:"Evict inbound peers with a user agent that matches the regular expression to make room for other peers once the max number of peers is reached"`
 
 	// P2P network discovery options.
 	DisableSeeders bool     `long:"noseeders" description:"Disable seeding for peer discovery"`
@@ -191,9 +228,12 @@ type config struct {
 	Whitelists     []string      `long:"whitelist" description:"Add an IP network or IP that will not be banned. (eg. 192.168.1.0/24 or ::1)"`
 
 	// Chain related options.
-	AllowOldForks  bool   `long:"allowoldforks" description:"Process forks deep in history.  Don't do this unless you know what you're doing"`
-	DumpBlockchain string `long:"dumpblockchain" description:"Write blockchain as a flat file of blocks for use with addblock, to the specified filename"`
-	AssumeValid    string `long:"assumevalid" description:"Hash of an assumed valid block.  Defaults to the hard-coded assumed valid block that is updated periodically with new releases.  Don't use a different hash unless you understand the implications.  Set to 0 to disable"`
+	AllowOldForks      bool   `long:"allowoldforks" description:"Process forks deep in history.  Don't do this unless you know what you're doing"`
+	DumpBlockchain     string `long:"dumpblockchain" description:"Write blockchain as a flat file of blocks for use with addblock, to the specified filename"`
+	AssumeValid        string `long:"assumevalid" description:"Hash of an assumed valid block.  Defaults to the hard-coded assumed valid block that is updated periodically with new releases.  Don't use a different hash unless you understand the implications.  Set to 0 to disable"`
+	MaxReorgDepth      uint32 `long:"maxreorgdepth" description:"Maximum number of blocks an automatic chain reorganization may disconnect.  Deeper reorganizations are paused until approved with the approvedeepreorg RPC.  Set to 0 to disable"`
+	MaxSideChainBlocks uint32 `long:"maxsidechainblocks" description:"Maximum number of side chain blocks to keep available for reorganizations.  Blocks on the most stale side chains are evicted first.  Set to 0 to disable"`
+	AuditAssumeValid   bool   `long:"auditassumevalid" description:"Record the blocks whose scripts are not executed during the initial chain sync due to the assumed valid block and execute them in the background once the chain is synced"`
 
 	// Relay and mempool policy.
 	MinRelayTxFee    float64 `long:"minrelaytxfee" description:"The minimum transaction fee in DCR/kB to be considered a non-zero fee"`
@@ -205,6 +245,9 @@ type config struct {
 	RejectNonStd     bool    `long:"rejectnonstd" description:"Reject non-standard transactions regardless of the default settings for the active network"`
 	AllowOldVotes    bool    `long:"allowoldvotes" description:"Enable the addition of very old votes to the mempool"`
 
+	MempoolJournalSize    int  `long:"mempooljournalsize" description:"Max number of recent mempool events, such as transactions being accepted, rejected, evicted, or mined, to keep in memory for the getmempooljournal RPC -- Set to 0 to disable"`
+	PersistMempoolJournal bool `long:"persistmempooljournal" description:"Save the mempool event journal to the data directory on shutdown and restore it on startup"`
+
 	// Mining options and policy.
 	Generate            bool     `long:"generate" description:"Generate (mine) coins using the CPU"`
 	MiningAddrs         []string `long:"miningaddr" description:"Add the specified payment address to the list of addresses to use for generated blocks -- At least one address is required if the generate option is set"`
@@ -217,10 +260,20 @@ type config struct {
 	AllowUnsyncedMining bool     `long:"allowunsyncedmining" description:"Allow block templates to be generated even when the chain is not considered synced on networks other than the main network.  This is automatically enabled when the simnet option is set.  Don't do this unless you know what you're doing"`
 
 	// Indexing options.
-	TxIndex             bool `long:"txindex" description:"Maintain a full hash-based transaction index which makes all transactions available via the getrawtransaction RPC"`
-	DropTxIndex         bool `long:"droptxindex" description:"Deletes the hash-based transaction index from the database on start up and then exits"`
-	NoExistsAddrIndex   bool `long:"noexistsaddrindex" description:"Disable the exists address index, which tracks whether or not an address has even been used"`
-	DropExistsAddrIndex bool `long:"dropexistsaddrindex" description:"Deletes the exists address index from the database on start up and then exits"`
+	TxIndex              bool     `long:"txindex" description:"Maintain a full hash-based transaction index which makes all transactions available via the getrawtransaction RPC"`
+	DropTxIndex          bool     `long:"droptxindex" description:"Deletes the hash-based transaction index from the database on start up and then exits"`
+	NoExistsAddrIndex    bool     `long:"noexistsaddrindex" description:"Disable the exists address index, which tracks whether or not an address has even been used"`
+	ExistsAddrFPRate     float64  `long:"existsaddrfprate" description:"Maximum false positive rate of the in-memory filter the exists address index uses to avoid database lookups for unused addresses -- Lower rates use more memory"`
+	DropExistsAddrIndex  bool     `long:"dropexistsaddrindex" description:"Deletes the exists address index from the database on start up and then exits"`
+	NullDataIndex        bool     `long:"nulldataindex" description:"Maintain an index of null data (OP_RETURN) payloads that start with one of the prefixes specified by --nulldataprefix which makes them available via the getnulldata RPC"`
+	NullDataPrefixes     []string `long:"nulldataprefix" description:"Add the specified hex-encoded prefix to the set of prefixes null data payloads must start with in order to be indexed by the null data index -- Changing the set of prefixes rebuilds the index"`
+	DropNullDataIndex    bool     `long:"dropnulldataindex" description:"Deletes the null data index from the database on start up and then exits"`
+	UtxoHistoryIndex     bool     `long:"utxohistoryindex" description:"Maintain an archival index of all transaction outputs and the history of their spends in the main chain which makes their state as of any height available via the gettxouthistory RPC"`
+	DropUtxoHistoryIndex bool     `long:"droputxohistoryindex" description:"Deletes the utxo history index from the database on start up and then exits"`
+
+	// UTXO set snapshot options.
+	ExportUtxoSet string `long:"exportutxoset" description:"Write a snapshot of the UTXO set as of the current best block to the specified file on start up and then exits"`
+	ImportUtxoSet string `long:"importutxoset" description:"Import the UTXO set from the specified snapshot file on start up -- The UTXO database must be empty and the snapshot block must be in the main chain of the block database"`
 
 	// IPC options.
 	PipeRx         uint `long:"piperx" description:"File descriptor of read end pipe to enable parent -> child process communication"`
@@ -228,17 +281,23 @@ type config struct {
 	LifetimeEvents bool `long:"lifetimeevents" description:"Send lifetime notifications over the TX pipe"`
 
 	// Cooked options ready for use.
-	onionlookup   func(string) ([]net.IP, error)
-	lookup        func(string) ([]net.IP, error)
-	oniondial     func(context.Context, string, string) (net.Conn, error)
-	dial          func(context.Context, string, string) (net.Conn, error)
-	miningAddrs   []stdaddr.Address
-	minRelayTxFee dcrutil.Amount
-	whitelists    []*net.IPNet
-	ipv4NetInfo   types.NetworksResult
-	ipv6NetInfo   types.NetworksResult
-	onionNetInfo  types.NetworksResult
-	params        *params
+	onionlookup      func(string) ([]net.IP, error)
+	lookup           func(string) ([]net.IP, error)
+	oniondial        func(context.Context, string, string) (net.Conn, error)
+	dial             func(context.Context, string, string) (net.Conn, error)
+	routedDial       connmgr.DialFunc
+	miningAddrs      []stdaddr.Address
+	nullDataPrefixes [][]byte
+	minRelayTxFee    dcrutil.Amount
+	whitelists       []*net.IPNet
+	rejectAgents     []*regexp.Regexp
+	deprioAgents     []*regexp.Regexp
+	listenPolicies   map[string]listenPolicy
+	ipv4NetInfo      types.NetworksResult
+	ipv6NetInfo      types.NetworksResult
+	onionNetInfo     types.NetworksResult
+	rpcUnixOnly      bool
+	params           *params
 }
 
 // serviceOptions defines the configuration options for the daemon as a service on
@@ -341,7 +400,9 @@ func parseAndSetDebugLevels(debugLevel string) error {
 	}
 
 	// Split the specified string into subsystem/level pairs while detecting
-	// issues and update the log levels accordingly.
+	// issues and update the log levels accordingly once they are all known to
+	// be valid.
+	levels := make(map[string]string)
 	for _, logLevelPair := range strings.Split(debugLevel, ",") {
 		if !strings.Contains(logLevelPair, "=") {
 			str := "the specified debug level contains an invalid " +
@@ -366,12 +427,93 @@ func parseAndSetDebugLevels(debugLevel string) error {
 			return fmt.Errorf(str, logLevel)
 		}
 
+		levels[subsysID] = logLevel
+	}
+	for subsysID, logLevel := range levels {
 		setLogLevel(subsysID, logLevel)
 	}
 
 	return nil
 }
 
+// validateUserAgentComments returns an error if any of the provided user agent
+// comments contain characters that are not allowed or they would cause the
+// advertised user agent to exceed the maximum allowed length.
+func validateUserAgentComments(comments []string) error {
+	for _, comment := range comments {
+		if strings.ContainsAny(comment, "/:()") {
+			str := "the uacomment value of '%s' contains one of the " +
+				"disallowed characters '/', ':', '(', or ')'"
+			return fmt.Errorf(str, comment)
+		}
+	}
+
+	// Ensure the resulting user agent is valid when combined with the
+	// comments that are always added.
+	if version.PreRelease != "" {
+		comments = append([]string{version.PreRelease}, comments...)
+	}
+	var msg wire.MsgVersion
+	err := msg.AddUserAgent(userAgentName, userAgentVersion, comments...)
+	if err != nil {
+		return fmt.Errorf("the uacomment values are invalid: %w", err)
+	}
+	return nil
+}
+
+// parseAgentPatterns parses the provided user agent regular expressions for
+// the named option.  An appropriate error is returned if any of them are
+// invalid.
+func parseAgentPatterns(option string, patterns []string) ([]*regexp.Regexp, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	regexps := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			str := "the %s value of '%s' is not a valid regular expression: %w"
+			return nil, fmt.Errorf(str, option, pattern, err)
+		}
+		regexps = append(regexps, re)
+	}
+	return regexps, nil
+}
+
+// parseWhitelists parses the provided whitelisted IP addresses and networks.
+// An appropriate error is returned if any of them are invalid.
+func parseWhitelists(whitelists []string) ([]*net.IPNet, error) {
+	if len(whitelists) == 0 {
+		return nil, nil
+	}
+
+	ipnets := make([]*net.IPNet, 0, len(whitelists))
+	for _, addr := range whitelists {
+		_, ipnet, err := net.ParseCIDR(addr)
+		if err != nil {
+			ip := net.ParseIP(addr)
+			if ip == nil {
+				str := "the whitelist value of '%s' is invalid"
+				return nil, fmt.Errorf(str, addr)
+			}
+			var bits int
+			if ip.To4() == nil {
+				// IPv6
+				bits = 128
+			} else {
+				bits = 32
+			}
+			ipnet = &net.IPNet{
+				IP:   ip,
+				Mask: net.CIDRMask(bits, bits),
+			}
+		}
+		ipnets = append(ipnets, ipnet)
+	}
+	return ipnets, nil
+}
+
 // validDbType returns whether or not dbType is a supported database type.
 func validDbType(dbType string) bool {
 	for _, knownType := range knownDbTypes {
@@ -402,6 +544,72 @@ const (
 	normalizeInterfaceFirstAddr
 )
 
+// listenPolicy houses the policies that apply to peers accepted via a specific
+// listen address.
+type listenPolicy struct {
+	// onion indicates the connections accepted via the address are from a
+	// local Tor hidden service.  The peers are never whitelisted since their
+	// remote addresses are always local and the address is not advertised.
+	onion bool
+
+	// noRelay indicates transactions are not relayed to or from the peers.
+	noRelay bool
+}
+
+// parseListenPolicies parses the provided listen policies, which are in the
+// form <interface/port>=<policy>[,<policy>...], and returns a map of the
+// policies keyed by the normalized listen addresses they apply to.  An error is
+// returned when a policy is not supported or refers to an address that is not
+// one of the provided normalized listen addresses.
+func parseListenPolicies(policies, listeners []string, defaultPort string) (map[string]listenPolicy, error) {
+	if len(policies) == 0 {
+		return nil, nil
+	}
+
+	isListener := make(map[string]struct{}, len(listeners))
+	for _, addr := range listeners {
+		isListener[addr] = struct{}{}
+	}
+
+	result := make(map[string]listenPolicy, len(policies))
+	for _, entry := range policies {
+		idx := strings.LastIndex(entry, "=")
+		if idx <= 0 || idx == len(entry)-1 {
+			return nil, fmt.Errorf("listen policy %q is not in the form "+
+				"<interface/port>=<policy>[,<policy>...]", entry)
+		}
+
+		var parsed listenPolicy
+		for _, name := range strings.Split(entry[idx+1:], ",") {
+			switch strings.TrimSpace(name) {
+			case "onion":
+				parsed.onion = true
+			case "norelay":
+				parsed.noRelay = true
+			default:
+				return nil, fmt.Errorf("listen policy %q specifies "+
+					"unsupported policy %q", entry, name)
+			}
+		}
+
+		// The address is normalized the same way as the listen addresses
+		// which means an interface name may expand to multiple addresses.
+		addrs := normalizeAddresses([]string{entry[:idx]}, defaultPort,
+			normalizeInterfaceAddrs)
+		for _, addr := range addrs {
+			if _, ok := isListener[addr]; !ok {
+				return nil, fmt.Errorf("listen policy %q refers to address "+
+					"%s which is not a listen address", entry, addr)
+			}
+			policy := result[addr]
+			policy.onion = policy.onion || parsed.onion
+			policy.noRelay = policy.noRelay || parsed.noRelay
+			result[addr] = policy
+		}
+	}
+	return result, nil
+}
+
 // normalizeAddresses returns a new slice with all the passed peer addresses
 // normalized with the given default port, and all duplicates removed.
 //
@@ -605,15 +813,17 @@ func loadConfig(appName string) (*config, []string, error) {
 	// Default config.
 	cfg := config{
 		// General application behavior.
-		HomeDir:          defaultHomeDir,
-		ConfigFile:       defaultConfigFile,
-		DataDir:          defaultDataDir,
-		LogDir:           defaultLogDir,
-		LogSize:          defaultLogSize,
-		DbType:           defaultDbType,
-		DebugLevel:       defaultLogLevel,
-		SigCacheMaxSize:  defaultSigCacheMaxSize,
-		UtxoCacheMaxSize: defaultUtxoCacheMaxSize,
+		HomeDir:            defaultHomeDir,
+		ConfigFile:         defaultConfigFile,
+		DataDir:            defaultDataDir,
+		LogDir:             defaultLogDir,
+		LogSize:            defaultLogSize,
+		LogFormat:          logFormatText,
+		DbType:             defaultDbType,
+		DebugLevel:         defaultLogLevel,
+		SigCacheMaxSize:    defaultSigCacheMaxSize,
+		ScriptCacheMaxSize: defaultScriptCacheMaxSize,
+		UtxoCacheMaxSize:   defaultUtxoCacheMaxSize,
 
 		// RPC server options and policy.
 		RPCCert:              defaultRPCCertFile,
@@ -624,6 +834,9 @@ func loadConfig(appName string) (*config, []string, error) {
 		RPCMaxClients:        defaultMaxRPCClients,
 		RPCMaxWebsockets:     defaultMaxRPCWebsockets,
 		RPCMaxConcurrentReqs: defaultMaxRPCConcurrentReqs,
+		RPCMaxWSQueueBytes:   defaultMaxRPCWSQueueBytes,
+		RPCCacheBytes:        defaultRPCCacheBytes,
+		RPCAuthLockout:       defaultRPCAuthLockout,
 
 		// P2P network options.
 		MaxSameIP:       defaultMaxSameIP,
@@ -631,14 +844,19 @@ func loadConfig(appName string) (*config, []string, error) {
 		DialTimeout:     defaultDialTimeout,
 		PeerIdleTimeout: defaultPeerIdleTimeout,
 
+		// P2P inventory trickling options.
+		TrickleInterval:        defaultTrickleInterval,
+		InboundTrickleInterval: defaultInboundTrickleInterval,
+
 		// Banning options.
 		BanDuration:  defaultBanDuration,
 		BanThreshold: defaultBanThreshold,
 
 		// Relay and mempool policy.
-		MinRelayTxFee: mempool.DefaultMinRelayTxFee.ToCoin(),
-		MaxOrphanTxs:  defaultMaxOrphanTransactions,
-		AllowOldVotes: defaultAllowOldVotes,
+		MinRelayTxFee:      mempool.DefaultMinRelayTxFee.ToCoin(),
+		MaxOrphanTxs:       defaultMaxOrphanTransactions,
+		AllowOldVotes:      defaultAllowOldVotes,
+		MempoolJournalSize: defaultMempoolJournalSize,
 
 		// Mining options and policy.
 		Generate:            defaultGenerate,
@@ -651,6 +869,7 @@ func loadConfig(appName string) (*config, []string, error) {
 		// Indexing options.
 		TxIndex:           defaultTxIndex,
 		NoExistsAddrIndex: defaultNoExistsAddrIndex,
+		ExistsAddrFPRate:  indexers.DefaultExistsAddrFPRate,
 
 		// Cooked options ready for use.
 		ipv4NetInfo:  types.NetworksResult{Name: "IPV4"},
@@ -876,6 +1095,20 @@ func loadConfig(appName string) (*config, []string, error) {
 	oldTestNets = append(oldTestNets, filepath.Join(cfg.DataDir, "testnet"))
 	oldTestNets = append(oldTestNets, filepath.Join(cfg.DataDir, "testnet2"))
 	cfg.DataDir = filepath.Join(cfg.DataDir, cfg.params.Name)
+	// Validate the log format and enable JSON logging if requested.  This must
+	// be done before any logging takes place.
+	switch cfg.LogFormat {
+	case logFormatText:
+	case logFormatJSON:
+		jsonLogging = true
+	default:
+		str := "%s: the specified log format [%v] is invalid -- " +
+			"supported formats %v"
+		err := fmt.Errorf(str, funcName, cfg.LogFormat,
+			[]string{logFormatText, logFormatJSON})
+		return nil, nil, err
+	}
+
 	logRotator = nil
 	if !cfg.NoFileLogging {
 		// Append the network type to the log directory so it is "namespaced"
@@ -991,34 +1224,44 @@ func loadConfig(appName string) (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// Don't allow inventory trickle intervals that are too short.
+	if cfg.TrickleInterval < minTrickleInterval {
+		str := "%s: the trickleinterval option may not be less than %v " +
+			"-- parsed [%v]"
+		err := fmt.Errorf(str, funcName, minTrickleInterval,
+			cfg.TrickleInterval)
+		return nil, nil, err
+	}
+	if cfg.InboundTrickleInterval < minTrickleInterval {
+		str := "%s: the inboundtrickleinterval option may not be less than " +
+			"%v -- parsed [%v]"
+		err := fmt.Errorf(str, funcName, minTrickleInterval,
+			cfg.InboundTrickleInterval)
+		return nil, nil, err
+	}
+
 	// Validate any given whitelisted IP addresses and networks.
-	if len(cfg.Whitelists) > 0 {
-		var ip net.IP
-		cfg.whitelists = make([]*net.IPNet, 0, len(cfg.Whitelists))
+	cfg.whitelists, err = parseWhitelists(cfg.Whitelists)
+	if err != nil {
+		err := fmt.Errorf("%s: %w", funcName, err)
+		return nil, nil, err
+	}
 
-		for _, addr := range cfg.Whitelists {
-			_, ipnet, err := net.ParseCIDR(addr)
-			if err != nil {
-				ip = net.ParseIP(addr)
-				if ip == nil {
-					str := "%s: the whitelist value of '%s' is invalid"
-					err = fmt.Errorf(str, funcName, addr)
-					return nil, nil, err
-				}
-				var bits int
-				if ip.To4() == nil {
-					// IPv6
-					bits = 128
-				} else {
-					bits = 32
-				}
-				ipnet = &net.IPNet{
-					IP:   ip,
-					Mask: net.CIDRMask(bits, bits),
-				}
-			}
-			cfg.whitelists = append(cfg.whitelists, ipnet)
-		}
+	// Validate the user agent comments and patterns.
+	if err := validateUserAgentComments(cfg.UserAgentComments); err != nil {
+		err := fmt.Errorf("%s: %w", funcName, err)
+		return nil, nil, err
+	}
+	cfg.rejectAgents, err = parseAgentPatterns("rejectagent", cfg.RejectAgents)
+	if err != nil {
+		err := fmt.Errorf("%s: %w", funcName, err)
+		return nil, nil, err
+	}
+	cfg.deprioAgents, err = parseAgentPatterns("deprioritizeagent",
+		cfg.DeprioritizeAgents)
+	if err != nil {
+		err := fmt.Errorf("%s: %w", funcName, err)
+		return nil, nil, err
 	}
 
 	// --addPeer and --connect do not mix.
@@ -1065,12 +1308,50 @@ func loadConfig(appName string) (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// --rpcunixnoauth requires --rpclistenunix.
+	if cfg.RPCUnixNoAuth && cfg.RPCListenUnix == "" {
+		str := "%s: the --rpcunixnoauth option requires the " +
+			"--rpclistenunix option"
+		err := fmt.Errorf(str, funcName)
+		return nil, nil, err
+	}
+	if cfg.RPCListenUnix != "" {
+		cfg.RPCListenUnix = cleanAndExpandPath(cfg.RPCListenUnix)
+	}
+
+	// TLS client certificates can't be used to authenticate connections over
+	// the Unix domain socket, so require authentication for it to be
+	// explicitly disabled in that case.
+	if cfg.RPCAuthType == authTypeClientCert && cfg.RPCListenUnix != "" &&
+		!cfg.RPCUnixNoAuth {
+
+		str := "%s: the --rpclistenunix option requires the " +
+			"--rpcunixnoauth option with --authtype=clientcert"
+		err := fmt.Errorf(str, funcName)
+		return nil, nil, err
+	}
+
 	// The RPC server is disabled if no username or password is provided
-	// under basic user/pass authentication.
+	// under basic user/pass authentication unless authentication is not
+	// required for the Unix domain socket, in which case the RPC server only
+	// listens on the socket.
 	if cfg.RPCAuthType == authTypeBasic &&
 		(cfg.RPCUser == "" || cfg.RPCPass == "") &&
 		(cfg.RPCLimitUser == "" || cfg.RPCLimitPass == "") {
-		cfg.DisableRPC = true
+
+		switch {
+		case cfg.RPCUnixNoAuth && len(cfg.RPCListeners) > 0:
+			str := "%s: the --rpclisten option requires an RPC username " +
+				"and password when used with the --rpcunixnoauth option"
+			err := fmt.Errorf(str, funcName)
+			return nil, nil, err
+
+		case cfg.RPCUnixNoAuth:
+			cfg.rpcUnixOnly = true
+
+		default:
+			cfg.DisableRPC = true
+		}
 	}
 
 	// Check to make sure RPC usernames and passwords are not provided under
@@ -1087,7 +1368,7 @@ func loadConfig(appName string) (*config, []string, error) {
 	}
 
 	// Default RPC to listen on localhost only.
-	if !cfg.DisableRPC && len(cfg.RPCListeners) == 0 {
+	if !cfg.DisableRPC && !cfg.rpcUnixOnly && len(cfg.RPCListeners) == 0 {
 		addrs, err := net.LookupHost("localhost")
 		if err != nil {
 			return nil, nil, err
@@ -1105,6 +1386,36 @@ func loadConfig(appName string) (*config, []string, error) {
 		err := fmt.Errorf(str, funcName, cfg.RPCMaxConcurrentReqs)
 		return nil, nil, err
 	}
+	if cfg.RPCMaxWebsocketsPerIP < 0 {
+		str := "%s: the rpcmaxwebsocketsperip option may not be less " +
+			"than 0 -- parsed [%d]"
+		err := fmt.Errorf(str, funcName, cfg.RPCMaxWebsocketsPerIP)
+		return nil, nil, err
+	}
+	if cfg.RPCMaxWSQueueBytes < 0 {
+		str := "%s: the rpcmaxwsqueuebytes option may not be less than 0 " +
+			"-- parsed [%d]"
+		err := fmt.Errorf(str, funcName, cfg.RPCMaxWSQueueBytes)
+		return nil, nil, err
+	}
+	if cfg.RPCCacheBytes < 0 {
+		str := "%s: the rpccachebytes option may not be less than 0 " +
+			"-- parsed [%d]"
+		err := fmt.Errorf(str, funcName, cfg.RPCCacheBytes)
+		return nil, nil, err
+	}
+	if cfg.RPCMaxAuthFailures < 0 {
+		str := "%s: the rpcmaxauthfailures option may not be less than 0 " +
+			"-- parsed [%d]"
+		err := fmt.Errorf(str, funcName, cfg.RPCMaxAuthFailures)
+		return nil, nil, err
+	}
+	if cfg.RPCAuthLockout < time.Second {
+		str := "%s: the rpcauthlockout option may not be less than 1s " +
+			"-- parsed [%v]"
+		err := fmt.Errorf(str, funcName, cfg.RPCAuthLockout)
+		return nil, nil, err
+	}
 
 	// Validate the minrelaytxfee.
 	cfg.minRelayTxFee, err = dcrutil.NewAmount(cfg.MinRelayTxFee)
@@ -1135,6 +1446,14 @@ func loadConfig(appName string) (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// Ensure the mempool event journal size is not negative.
+	if cfg.MempoolJournalSize < 0 {
+		str := "%s: the mempooljournalsize option may not be less than 0 " +
+			"-- parsed [%d]"
+		err := fmt.Errorf(str, funcName, cfg.MempoolJournalSize)
+		return nil, nil, err
+	}
+
 	// Limit the block priority and minimum block sizes to max block size.
 	cfg.BlockPrioritySize = minUint32(cfg.BlockPrioritySize, cfg.BlockMaxSize)
 	cfg.BlockMinSize = minUint32(cfg.BlockMinSize, cfg.BlockMaxSize)
@@ -1154,6 +1473,79 @@ func loadConfig(appName string) (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// The exists address filter false positive rate must be in the range
+	// (0, 1).
+	if cfg.ExistsAddrFPRate <= 0 || cfg.ExistsAddrFPRate >= 1 {
+		err := fmt.Errorf("%s: the existsaddrfprate option must be greater "+
+			"than 0 and less than 1 -- parsed [%v]", funcName,
+			cfg.ExistsAddrFPRate)
+		return nil, nil, err
+	}
+
+	// --nulldataindex and --dropnulldataindex do not mix.
+	if cfg.NullDataIndex && cfg.DropNullDataIndex {
+		err := fmt.Errorf("%s: the --nulldataindex and --dropnulldataindex "+
+			"options may not be activated at the same time", funcName)
+		return nil, nil, err
+	}
+
+	// --utxohistoryindex and --droputxohistoryindex do not mix.
+	if cfg.UtxoHistoryIndex && cfg.DropUtxoHistoryIndex {
+		err := fmt.Errorf("%s: the --utxohistoryindex and "+
+			"--droputxohistoryindex options may not be activated at the same "+
+			"time", funcName)
+		return nil, nil, err
+	}
+
+	// Check the null data index prefixes are valid and save parsed versions.
+	cfg.nullDataPrefixes = make([][]byte, 0, len(cfg.NullDataPrefixes))
+	for _, strPrefix := range cfg.NullDataPrefixes {
+		prefix, err := hex.DecodeString(strPrefix)
+		if err != nil {
+			str := "%s: null data prefix '%s' failed to decode: %w"
+			err := fmt.Errorf(str, funcName, strPrefix, err)
+			return nil, nil, err
+		}
+		if len(prefix) == 0 || len(prefix) > indexers.MaxNullDataPrefixLen {
+			str := "%s: null data prefix '%s' must be between 1 and %d bytes"
+			err := fmt.Errorf(str, funcName, strPrefix,
+				indexers.MaxNullDataPrefixLen)
+			return nil, nil, err
+		}
+		cfg.nullDataPrefixes = append(cfg.nullDataPrefixes, prefix)
+	}
+
+	// Ensure there is at least one prefix when the null data index is enabled.
+	if cfg.NullDataIndex && len(cfg.nullDataPrefixes) == 0 {
+		str := "%s: the --nulldataindex option requires at least one prefix " +
+			"specified with --nulldataprefix"
+		err := fmt.Errorf(str, funcName)
+		return nil, nil, err
+	}
+
+	// --exportutxoset and --importutxoset do not mix.
+	if cfg.ExportUtxoSet != "" && cfg.ImportUtxoSet != "" {
+		err := fmt.Errorf("%s: the --exportutxoset and --importutxoset "+
+			"options may not be activated at the same time", funcName)
+		return nil, nil, err
+	}
+	// --rpcauditredact requires --rpcauditlog.
+	if len(cfg.RPCAuditRedact) > 0 && cfg.RPCAuditLog == "" {
+		err := fmt.Errorf("%s: the --rpcauditredact option requires the "+
+			"--rpcauditlog option", funcName)
+		return nil, nil, err
+	}
+	if cfg.RPCAuditLog != "" {
+		cfg.RPCAuditLog = cleanAndExpandPath(cfg.RPCAuditLog)
+	}
+
+	if cfg.ExportUtxoSet != "" {
+		cfg.ExportUtxoSet = cleanAndExpandPath(cfg.ExportUtxoSet)
+	}
+	if cfg.ImportUtxoSet != "" {
+		cfg.ImportUtxoSet = cleanAndExpandPath(cfg.ImportUtxoSet)
+	}
+
 	// Check mining addresses are valid and saved parsed versions.
 	cfg.miningAddrs = make([]stdaddr.Address, 0, len(cfg.MiningAddrs))
 	for _, strAddr := range cfg.MiningAddrs {
@@ -1175,6 +1567,15 @@ func loadConfig(appName string) (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// Don't allow CPU mining on networks where it is extremely unlikely to
+	// ever find a block such as mainnet.
+	if cfg.Generate && !cfg.params.GenerateSupported {
+		str := "%s: the generate flag is not supported on %s since it is " +
+			"extremely unlikely to be possible to mine a block with the CPU"
+		err := fmt.Errorf(str, funcName, cfg.params.Name)
+		return nil, nil, err
+	}
+
 	// Don't allow unsynchronized mining on mainnet.
 	if cfg.AllowUnsyncedMining && cfg.params == &mainNetParams {
 		str := "%s: allowunsyncedmining cannot be activated on mainnet"
@@ -1182,6 +1583,13 @@ func loadConfig(appName string) (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// Only allow the debug RPCs on simnet and regnet.
+	if cfg.DebugRPC && !(cfg.SimNet || cfg.RegNet) {
+		str := "%s: debugrpc may only be used with simnet or regnet"
+		err := fmt.Errorf(str, funcName)
+		return nil, nil, err
+	}
+
 	// Always allow unsynchronized mining on simnet and regnet.
 	if cfg.SimNet || cfg.RegNet {
 		cfg.AllowUnsyncedMining = true
@@ -1197,6 +1605,14 @@ func loadConfig(appName string) (*config, []string, error) {
 	cfg.RPCListeners = normalizeAddresses(cfg.RPCListeners,
 		cfg.params.rpcPort, normalizeInterfaceAddrs)
 
+	// Parse the policies for the listen addresses.
+	cfg.listenPolicies, err = parseListenPolicies(cfg.ListenPolicies,
+		cfg.Listeners, cfg.params.DefaultPort)
+	if err != nil {
+		err := fmt.Errorf("%s: %w", funcName, err)
+		return nil, nil, err
+	}
+
 	// The authtype config must be one of "basic" or "clientcert".
 	switch cfg.RPCAuthType {
 	case authTypeBasic, authTypeClientCert:
@@ -1334,17 +1750,47 @@ func loadConfig(appName string) (*config, []string, error) {
 		cfg.onionlookup = cfg.lookup
 	}
 
-	// Specifying --noonion means the onion address dial and DNS resolution
-	// (lookup) functions result in an error.
+	// Specifying --noonion means the onion address DNS resolution (lookup)
+	// function results in an error.
 	if cfg.NoOnion {
-		cfg.oniondial = func(ctx context.Context, a, b string) (net.Conn, error) {
-			return nil, errors.New("tor has been disabled")
-		}
 		cfg.onionlookup = func(a string) ([]net.IP, error) {
 			return nil, errors.New("tor has been disabled")
 		}
 	}
 
+	// Setup the dial function used for all outbound connections to route
+	// .onion addresses through the onion address dial function and all other
+	// addresses through the normal dial function.  Specifying --noonion means
+	// there is no route for .onion addresses, so dialing them results in an
+	// error.
+	var routes []connmgr.DialRoute
+	if !cfg.NoOnion {
+		var onionRouteName string
+		switch {
+		case cfg.OnionProxy != "":
+			onionRouteName = "onion proxy " + cfg.OnionProxy
+		case cfg.Proxy != "":
+			onionRouteName = "proxy " + cfg.Proxy
+		}
+		routes = append(routes, connmgr.DialRoute{
+			Name:  onionRouteName,
+			Match: connmgr.IsOnionAddr,
+			Dial:  cfg.oniondial,
+		})
+	}
+	var routeName string
+	if cfg.Proxy != "" {
+		routeName = "proxy " + cfg.Proxy
+	}
+	routes = append(routes, connmgr.DialRoute{
+		Name: routeName,
+		Match: func(network, addr string) bool {
+			return !connmgr.IsOnionAddr(network, addr)
+		},
+		Dial: cfg.dial,
+	})
+	cfg.routedDial = connmgr.NewRoutedDialer(routes...)
+
 	// Warn if old testnet directory is present.
 	for _, oldDir := range oldTestNets {
 		if fileExists(oldDir) {
@@ -1375,16 +1821,150 @@ func loadConfig(appName string) (*config, []string, error) {
 	return &cfg, remainingArgs, nil
 }
 
+// reloadableConfig houses the subset of the configuration options that can
+// safely be changed while the process is running.
+type reloadableConfig struct {
+	debugLevel     string
+	disableBanning bool
+	banDuration    time.Duration
+	banThreshold   uint32
+	whitelists     []*net.IPNet
+	minRelayTxFee  dcrutil.Amount
+
+	// rejectAgents and deprioAgents are the user agent patterns of peers
+	// to reject and evict first, respectively.
+	rejectAgents []*regexp.Regexp
+	deprioAgents []*regexp.Regexp
+
+	// permanentPeers are the normalized addresses of the peers specified via
+	// either --connect or --addpeer and connectOnly indicates which of the
+	// options they were specified by.
+	permanentPeers []string
+	connectOnly    bool
+}
+
+// reloadable returns the options that can be reloaded at runtime from the
+// config.
+func (cfg *config) reloadable() *reloadableConfig {
+	rcfg := reloadableConfig{
+		debugLevel:     cfg.DebugLevel,
+		disableBanning: cfg.DisableBanning,
+		banDuration:    cfg.BanDuration,
+		banThreshold:   cfg.BanThreshold,
+		whitelists:     cfg.whitelists,
+		minRelayTxFee:  cfg.minRelayTxFee,
+		permanentPeers: cfg.AddPeers,
+		connectOnly:    len(cfg.ConnectPeers) > 0,
+
+		rejectAgents: cfg.rejectAgents,
+		deprioAgents: cfg.deprioAgents,
+	}
+	if rcfg.connectOnly {
+		rcfg.permanentPeers = cfg.ConnectPeers
+	}
+	return &rcfg
+}
+
+// loadReloadableConfig parses the config file and command line options that
+// were used to start the process again and returns the validated options that
+// can be reloaded at runtime.  The command line options continue to take
+// precedence over the config file.  All other options are ignored.
+//
+// An error is returned when any of the reloadable options are invalid or the
+// reloaded options change between the --connect and --addpeer modes since
+// peer discovery and listening are configured at startup based on them.
+func loadReloadableConfig() (*reloadableConfig, error) {
+	// Start with the defaults for the reloadable options along with the
+	// values of the options that determine which config file to parse.
+	newCfg := config{
+		HomeDir:       cfg.HomeDir,
+		ConfigFile:    cfg.ConfigFile,
+		DebugLevel:    defaultLogLevel,
+		BanDuration:   defaultBanDuration,
+		BanThreshold:  defaultBanThreshold,
+		MinRelayTxFee: mempool.DefaultMinRelayTxFee.ToCoin(),
+	}
+
+	// Load the config file when it was loaded at startup.  The config file
+	// may have been removed since then, so only the command line options
+	// apply in that case.
+	serviceOpts := serviceOptions{}
+	parser := newConfigParser(&newCfg, &serviceOpts, flags.PassDoubleDash)
+	if !(cfg.SimNet || cfg.RegNet) || cfg.ConfigFile != defaultConfigFile {
+		err := flags.NewIniParser(parser).ParseFile(cfg.ConfigFile)
+		if err != nil {
+			var e *os.PathError
+			if !errors.As(err, &e) {
+				return nil, fmt.Errorf("error parsing config file: %w", err)
+			}
+		}
+	}
+
+	// Don't add peers from the config file when in regression test mode.
+	if cfg.RegNet {
+		newCfg.AddPeers = nil
+	}
+
+	// Parse command line options again to ensure they take precedence.
+	if _, err := parser.Parse(); err != nil {
+		return nil, err
+	}
+
+	// Validate the reloadable options.
+	if newCfg.DebugLevel == "show" {
+		return nil, errors.New("the debuglevel option may not be show when " +
+			"reloading the config")
+	}
+	if newCfg.BanDuration < time.Second {
+		str := "the banduration option may not be less than 1s -- parsed [%v]"
+		return nil, fmt.Errorf(str, newCfg.BanDuration)
+	}
+	var err error
+	newCfg.whitelists, err = parseWhitelists(newCfg.Whitelists)
+	if err != nil {
+		return nil, err
+	}
+	newCfg.minRelayTxFee, err = dcrutil.NewAmount(newCfg.MinRelayTxFee)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minrelaytxfee: %w", err)
+	}
+	newCfg.rejectAgents, err = parseAgentPatterns("rejectagent",
+		newCfg.RejectAgents)
+	if err != nil {
+		return nil, err
+	}
+	newCfg.deprioAgents, err = parseAgentPatterns("deprioritizeagent",
+		newCfg.DeprioritizeAgents)
+	if err != nil {
+		return nil, err
+	}
+	if len(newCfg.AddPeers) > 0 && len(newCfg.ConnectPeers) > 0 {
+		return nil, errors.New("the --addpeer and --connect options can " +
+			"not be mixed")
+	}
+	newCfg.AddPeers = normalizeAddresses(newCfg.AddPeers,
+		cfg.params.DefaultPort, normalizeInterfaceFirstAddr)
+	newCfg.ConnectPeers = normalizeAddresses(newCfg.ConnectPeers,
+		cfg.params.DefaultPort, normalizeInterfaceFirstAddr)
+	connectOnly := len(cfg.ConnectPeers) > 0
+	if (len(newCfg.ConnectPeers) > 0) != connectOnly {
+		return nil, errors.New("switching between the --connect and " +
+			"--addpeer options requires a restart")
+	}
+
+	return newCfg.reloadable(), nil
+}
+
 // dcrdDial connects to the address on the named network using the appropriate
 // dial function depending on the address and configuration options.  For
 // example, .onion addresses will be dialed using the onion specific proxy if
 // one was specified, but will otherwise use the normal dial function (which
 // could itself use a proxy or not).
+//
+// Errors for failed connection attempts have one of the connmgr dial error
+// kinds which describes the reason for the failure.
 func dcrdDial(ctx context.Context, network, addr string) (net.Conn, error) {
-	if strings.Contains(addr, ".onion:") {
-		return cfg.oniondial(ctx, network, addr)
-	}
-	return cfg.dial(ctx, network, addr)
+	return cfg.routedDial(ctx, network, addr)
 }
 
 // dcrdLookup returns the correct DNS lookup function to use depending on the
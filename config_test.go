@@ -1,4 +1,4 @@
-// Copyright (c) 2018 The Decred developers
+// Copyright (c) 2018-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -7,8 +7,11 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/decred/dcrd/wire"
 )
 
 // In order to test command line arguments and environment variables, append
@@ -81,8 +84,267 @@ func TestAltDNSNamesWithArg(t *testing.T) {
 	os.Args = old
 }
 
+// TestDebugRPCNetwork ensures the debugrpc configuration option is only
+// allowed on networks intended for testing.
+func TestDebugRPCNetwork(t *testing.T) {
+	appName := filepath.Base(os.Args[0])
+	appName = strings.TrimSuffix(appName, filepath.Ext(appName))
+	old := os.Args
+	defer func() { os.Args = old }()
+
+	os.Args = append(old[:len(old):len(old)], "--debugrpc")
+	if _, _, err := loadConfig(appName); err == nil {
+		t.Fatal("debugrpc was allowed on mainnet")
+	}
+
+	os.Args = append(old[:len(old):len(old)], "--debugrpc", "--regnet")
+	cfg, _, err := loadConfig(appName)
+	if err != nil {
+		t.Fatalf("Failed to load dcrd config: %s", err)
+	}
+	if !cfg.DebugRPC {
+		t.Fatal("debugrpc was not enabled on regnet")
+	}
+}
+
 // init parses the -test.* flags from the command line arguments list and then
 // removes them to allow go-flags tests to succeed.
 func init() {
 	os.Args = os.Args[:1]
 }
+
+// TestParseListenPolicies ensures listen policies are parsed and associated
+// with the expected normalized listen addresses.
+func TestParseListenPolicies(t *testing.T) {
+	listeners := []string{"127.0.0.1:9108", "127.0.0.1:9200", "[::1]:9108"}
+
+	tests := []struct {
+		name     string
+		policies []string
+		want     map[string]listenPolicy
+		wantErr  bool
+	}{{
+		name:     "no policies",
+		policies: nil,
+		want:     nil,
+	}, {
+		name:     "single policy with default port",
+		policies: []string{"127.0.0.1=norelay"},
+		want: map[string]listenPolicy{
+			"127.0.0.1:9108": {noRelay: true},
+		},
+	}, {
+		name:     "multiple policies for multiple addresses",
+		policies: []string{"127.0.0.1:9200=onion,norelay", "[::1]:9108=onion"},
+		want: map[string]listenPolicy{
+			"127.0.0.1:9200": {onion: true, noRelay: true},
+			"[::1]:9108":     {onion: true},
+		},
+	}, {
+		name:     "policies for same address are combined",
+		policies: []string{"127.0.0.1:9200=onion", "127.0.0.1:9200=norelay"},
+		want: map[string]listenPolicy{
+			"127.0.0.1:9200": {onion: true, noRelay: true},
+		},
+	}, {
+		name:     "missing policy",
+		policies: []string{"127.0.0.1:9108="},
+		wantErr:  true,
+	}, {
+		name:     "missing address",
+		policies: []string{"=onion"},
+		wantErr:  true,
+	}, {
+		name:     "unsupported policy",
+		policies: []string{"127.0.0.1:9108=bogus"},
+		wantErr:  true,
+	}, {
+		name:     "address is not a listener",
+		policies: []string{"127.0.0.1:9300=onion"},
+		wantErr:  true,
+	}}
+
+	for _, test := range tests {
+		got, err := parseListenPolicies(test.policies, listeners, "9108")
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%q: did not receive expected error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: mismatched policies -- got %+v, want %+v",
+				test.name, got, test.want)
+		}
+	}
+}
+
+// TestParseWhitelists ensures whitelisted IP addresses and networks are parsed
+// into the expected networks.
+func TestParseWhitelists(t *testing.T) {
+	tests := []struct {
+		name       string
+		whitelists []string
+		want       []string
+		wantErr    bool
+	}{{
+		name:       "no whitelists",
+		whitelists: nil,
+		want:       nil,
+	}, {
+		name:       "networks and addresses",
+		whitelists: []string{"192.168.1.0/24", "10.0.0.1", "::1"},
+		want:       []string{"192.168.1.0/24", "10.0.0.1/32", "::1/128"},
+	}, {
+		name:       "invalid address",
+		whitelists: []string{"10.0.0.1", "bogus"},
+		wantErr:    true,
+	}}
+
+	for _, test := range tests {
+		got, err := parseWhitelists(test.whitelists)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%q: did not receive expected error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.name, err)
+			continue
+		}
+		var gotStrs []string
+		for _, ipnet := range got {
+			gotStrs = append(gotStrs, ipnet.String())
+		}
+		if !reflect.DeepEqual(gotStrs, test.want) {
+			t.Errorf("%q: mismatched whitelists -- got %v, want %v",
+				test.name, gotStrs, test.want)
+		}
+	}
+}
+
+// TestValidateUserAgentComments ensures user agent comments with disallowed
+// characters or that would result in an invalid user agent are rejected.
+func TestValidateUserAgentComments(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []string
+		wantErr  bool
+	}{{
+		name:     "no comments",
+		comments: nil,
+	}, {
+		name:     "valid comments",
+		comments: []string{"upgrade-ready", "pool 1"},
+	}, {
+		name:     "comment with slash",
+		comments: []string{"valid", "in/valid"},
+		wantErr:  true,
+	}, {
+		name:     "comment with colon",
+		comments: []string{"in:valid"},
+		wantErr:  true,
+	}, {
+		name:     "comment with parens",
+		comments: []string{"(invalid)"},
+		wantErr:  true,
+	}, {
+		name:     "comment with non-ascii",
+		comments: []string{"inválid"},
+		wantErr:  true,
+	}, {
+		name:     "user agent too long",
+		comments: []string{strings.Repeat("a", wire.MaxUserAgentLen)},
+		wantErr:  true,
+	}}
+
+	for _, test := range tests {
+		err := validateUserAgentComments(test.comments)
+		if test.wantErr != (err != nil) {
+			t.Errorf("%q: unexpected error result -- got %v, want error %v",
+				test.name, err, test.wantErr)
+		}
+	}
+}
+
+// TestParseAgentPatterns ensures user agent patterns are parsed into regular
+// expressions that match the expected user agents.
+func TestParseAgentPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		agent    string
+		want     string
+		wantErr  bool
+	}{{
+		name:     "no patterns",
+		patterns: nil,
+		agent:    "/dcrwire:1.0.0/dcrd:1.7.0/",
+		want:     "",
+	}, {
+		name:     "first matching pattern",
+		patterns: []string{`^/dcrwire:0\.`, `/dcrd:1\.[0-6]\.`, `dcrd`},
+		agent:    "/dcrwire:1.0.0/dcrd:1.6.2/",
+		want:     `/dcrd:1\.[0-6]\.`,
+	}, {
+		name:     "no matching patterns",
+		patterns: []string{`/dcrd:1\.[0-6]\.`},
+		agent:    "/dcrwire:1.0.0/dcrd:1.7.0/",
+		want:     "",
+	}, {
+		name:     "invalid pattern",
+		patterns: []string{`dcrd`, `dcrd:(`},
+		wantErr:  true,
+	}}
+
+	for _, test := range tests {
+		got, err := parseAgentPatterns("rejectagent", test.patterns)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%q: did not receive expected error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.name, err)
+			continue
+		}
+		var gotMatch string
+		if re := matchUserAgent(got, test.agent); re != nil {
+			gotMatch = re.String()
+		}
+		if gotMatch != test.want {
+			t.Errorf("%q: mismatched matching pattern -- got %q, want %q",
+				test.name, gotMatch, test.want)
+		}
+	}
+}
+
+// TestLoadReloadableConfig ensures reloading the config without any changes to
+// the config file or command line produces the same reloadable options that
+// were loaded at startup.
+func TestLoadReloadableConfig(t *testing.T) {
+	appName := filepath.Base(os.Args[0])
+	appName = strings.TrimSuffix(appName, filepath.Ext(appName))
+	loadedCfg, _, err := loadConfig(appName)
+	if err != nil {
+		t.Fatalf("Failed to load dcrd config: %v", err)
+	}
+	origCfg := cfg
+	cfg = loadedCfg
+	defer func() { cfg = origCfg }()
+
+	got, err := loadReloadableConfig()
+	if err != nil {
+		t.Fatalf("Failed to reload dcrd config: %v", err)
+	}
+	if want := loadedCfg.reloadable(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("mismatched reloadable config -- got %+v, want %+v", got,
+			want)
+	}
+}
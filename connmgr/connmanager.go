@@ -149,6 +149,13 @@ type Config struct {
 	// connection is disconnected.
 	OnDisconnection func(*ConnReq)
 
+	// OnConnectionFailed is a callback that is fired when an attempt to
+	// establish an outbound connection to the address of a connection request
+	// fails.  The error has one of the dial error kinds, such as
+	// ErrDialTimeout and ErrDialRefused, which describes the reason for the
+	// failure.  See DialErrorKind for more details.
+	OnConnectionFailed func(*ConnReq, error)
+
 	// GetNewAddress is a way to get an address to make a network connection
 	// to.  If nil, no new connections will be made automatically.
 	GetNewAddress func() (net.Addr, error)
@@ -162,8 +169,9 @@ type Config struct {
 	// to be specified (but not both).
 	DialAddr func(context.Context, net.Addr) (net.Conn, error)
 
-	// Timeout specifies the amount of time to wait for a connection
-	// to complete before giving up.
+	// Timeout specifies the amount of time to wait for each connection
+	// attempt to complete before giving up.  Dial functions created by
+	// NewRoutedDialer may impose a separate timeout per route.
 	Timeout time.Duration
 }
 
@@ -188,10 +196,13 @@ type handleDisconnected struct {
 	retry bool
 }
 
-// handleFailed is used to remove a pending connection.
+// handleFailed is used to remove a pending connection.  The dialed flag
+// indicates the failure occurred while dialing the address of the connection
+// request as opposed to while obtaining an address for it.
 type handleFailed struct {
-	c   *ConnReq
-	err error
+	c      *ConnReq
+	err    error
+	dialed bool
 }
 
 // handleCancelPending is used to remove failing connections from retries.
@@ -403,6 +414,9 @@ out:
 				connReq.updateState(ConnFailed)
 				log.Debugf("Failed to connect to %v: %v",
 					connReq, msg.err)
+				if msg.dialed && cm.cfg.OnConnectionFailed != nil {
+					go cm.cfg.OnConnectionFailed(connReq, msg.err)
+				}
 				cm.handleFailedConn(ctx, connReq)
 
 			case handleCancelPending:
@@ -490,7 +504,7 @@ func (cm *ConnManager) newConnReq(ctx context.Context) {
 	addr, err := cm.cfg.GetNewAddress()
 	if err != nil {
 		select {
-		case cm.requests <- handleFailed{c, err}:
+		case cm.requests <- handleFailed{c, err, false}:
 		case <-cm.quit:
 		}
 		return
@@ -568,8 +582,9 @@ func (cm *ConnManager) Connect(ctx context.Context, c *ConnReq) {
 		conn, err = cm.cfg.DialAddr(ctx, c.Addr)
 	}
 	if err != nil {
+		err = makeDialError(ctx, c.Addr.String(), err)
 		select {
-		case cm.requests <- handleFailed{c, err}:
+		case cm.requests <- handleFailed{c, err, true}:
 		case <-cm.quit:
 		}
 		return
@@ -13,6 +13,7 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -752,6 +753,80 @@ func TestDialTimeout(t *testing.T) {
 	wg.Wait()
 }
 
+// TestConnectionFailedCallback ensures the connection failed callback is
+// invoked with an error that describes the reason for failed dial attempts.
+func TestConnectionFailedCallback(t *testing.T) {
+	type failure struct {
+		c   *ConnReq
+		err error
+	}
+	failed := make(chan failure)
+	const dialTimeout = time.Millisecond * 20
+	refusedAddr := "127.0.0.1:18555"
+	cmgr, err := New(&Config{
+		Dial: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if addr == refusedAddr {
+				return nil, &net.OpError{Op: "dial", Net: network,
+					Err: syscall.ECONNREFUSED}
+			}
+			<-ctx.Done()
+			return nil, errors.New("dial aborted")
+		},
+		Timeout: dialTimeout,
+		OnConnectionFailed: func(c *ConnReq, err error) {
+			failed <- failure{c, err}
+		},
+	})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	ctx, shutdown, wg := runConnMgrAsync(context.Background(), cmgr)
+
+	tests := []struct {
+		name string
+		addr string
+		want ErrorKind
+	}{{
+		name: "connection refused",
+		addr: refusedAddr,
+		want: ErrDialRefused,
+	}, {
+		name: "per attempt timeout",
+		addr: "127.0.0.1:18556",
+		want: ErrDialTimeout,
+	}}
+	for _, test := range tests {
+		addr, err := net.ResolveTCPAddr("tcp", test.addr)
+		if err != nil {
+			t.Fatalf("%s: unexpected error resolving addr: %v", test.name,
+				err)
+		}
+		cr := &ConnReq{Addr: addr}
+		go cmgr.Connect(ctx, cr)
+
+		select {
+		case f := <-failed:
+			if f.c != cr {
+				t.Fatalf("%s: unexpected conn req %v", test.name, f.c)
+			}
+			if !errors.Is(f.err, test.want) {
+				t.Fatalf("%s: unexpected error -- got %v, want %v",
+					test.name, f.err, test.want)
+			}
+			if got := DialErrorKind(f.err); got != test.want {
+				t.Fatalf("%s: unexpected dial error kind -- got %v, want %v",
+					test.name, got, test.want)
+			}
+		case <-time.After(dialTimeout * 10):
+			t.Fatalf("%s: timeout waiting for failure callback", test.name)
+		}
+	}
+
+	// Ensure clean shutdown of connection manager.
+	shutdown()
+	wg.Wait()
+}
+
 // TestConnectContext ensures the Connect method works as intended when provided
 // with a context that times out before a dial attempt succeeds.
 func TestConnectContext(t *testing.T) {
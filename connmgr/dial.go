@@ -0,0 +1,152 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package connmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// DialRoute describes a route a routed dialer may use to connect to addresses
+// such as a specific proxy or a direct connection.
+type DialRoute struct {
+	// Name is a human-readable name for the route that is included in the
+	// errors for failed connection attempts made through it.  For example,
+	// "onion proxy".
+	Name string
+
+	// Match returns whether or not the route handles the provided address on
+	// the named network.  A nil function matches all addresses.
+	Match func(network, addr string) bool
+
+	// Dial connects to the address on the named network via the route.
+	Dial DialFunc
+
+	// Timeout specifies the maximum amount of time to wait for each
+	// connection attempt made through the route to complete.  This is in
+	// addition to any deadline of the context provided to the dial function.
+	// Zero means no additional limit is imposed.
+	Timeout time.Duration
+}
+
+// IsOnionAddr returns whether or not the provided address in the form of
+// 'host:port' refers to a Tor onion service.  It is primarily intended for use
+// as the Match function of a DialRoute.
+func IsOnionAddr(network, addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	return strings.HasSuffix(host, ".onion")
+}
+
+// NewRoutedDialer returns a dial function that connects to each address using
+// the first of the provided routes that matches it.  This allows a chain of
+// routes to be configured according to policy, such as routing onion addresses
+// through Tor while connecting directly otherwise.
+//
+// Connection attempts for addresses that no route matches fail with an error
+// of kind ErrDialNoRoute.  All other errors returned by the dial function are
+// classified by the reason for the failure.  See DialErrorKind for more
+// details.
+func NewRoutedDialer(routes ...DialRoute) DialFunc {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		for i := range routes {
+			route := &routes[i]
+			if route.Match != nil && !route.Match(network, addr) {
+				continue
+			}
+
+			if route.Timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, route.Timeout)
+				defer cancel()
+			}
+			conn, err := route.Dial(ctx, network, addr)
+			if err != nil {
+				addrDesc := addr
+				if route.Name != "" {
+					addrDesc = fmt.Sprintf("%s via %s", addr, route.Name)
+				}
+				return nil, makeDialError(ctx, addrDesc, err)
+			}
+			return conn, nil
+		}
+
+		str := fmt.Sprintf("no dial route for %s", addr)
+		return nil, MakeError(ErrDialNoRoute, str)
+	}
+}
+
+// dialErrorKinds houses the error kinds that describe the reason a connection
+// attempt failed.
+var dialErrorKinds = []ErrorKind{ErrDialTimeout, ErrDialCanceled,
+	ErrDialRefused, ErrDialUnreachable, ErrDialNoRoute, ErrDialFailed}
+
+// dialErrorKind returns the dial error kind of the provided error along with
+// whether or not it is one of the dial error kinds.
+func dialErrorKind(err error) (ErrorKind, bool) {
+	for _, kind := range dialErrorKinds {
+		if errors.Is(err, kind) {
+			return kind, true
+		}
+	}
+	return ErrDialFailed, false
+}
+
+// DialErrorKind returns the kind of error that describes the reason the
+// provided error from a connection attempt occurred.  It returns ErrDialFailed
+// for errors that are not one of the more specific dial error kinds.
+func DialErrorKind(err error) ErrorKind {
+	kind, _ := dialErrorKind(err)
+	return kind
+}
+
+// classifyDialError returns the kind of error that describes the reason for the
+// provided error returned by a dial function invoked with the given context.
+func classifyDialError(ctx context.Context, err error) ErrorKind {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrDialCanceled
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return ErrDialTimeout
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return ErrDialRefused
+	case errors.Is(err, syscall.ENETUNREACH),
+		errors.Is(err, syscall.EHOSTUNREACH):
+		return ErrDialUnreachable
+	}
+
+	// Dial functions do not always return context errors when the context is
+	// done, so fall back to the context itself.
+	switch ctx.Err() {
+	case context.Canceled:
+		return ErrDialCanceled
+	case context.DeadlineExceeded:
+		return ErrDialTimeout
+	}
+	return ErrDialFailed
+}
+
+// makeDialError returns an error of the kind that describes the reason for the
+// provided error returned by a dial function invoked with the given context to
+// connect to the described address.  Errors that already are one of the dial
+// error kinds are returned unmodified.
+func makeDialError(ctx context.Context, addrDesc string, err error) error {
+	if _, ok := dialErrorKind(err); ok {
+		return err
+	}
+
+	kind := classifyDialError(ctx, err)
+	str := fmt.Sprintf("failed to connect to %s: %v", addrDesc, err)
+	return MakeError(kind, str)
+}
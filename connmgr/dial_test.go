@@ -0,0 +1,161 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package connmgr
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestIsOnionAddr ensures onion addresses are detected as expected.
+func TestIsOnionAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"abcdefghijklmnop.onion:9108", true},
+		{"abcdefghijklmnop.onion", true},
+		{"127.0.0.1:9108", false},
+		{"onion.example.com:9108", false},
+		{"[::1]:9108", false},
+	}
+
+	for _, test := range tests {
+		if got := IsOnionAddr("tcp", test.addr); got != test.want {
+			t.Errorf("%q: unexpected result -- got %v, want %v", test.addr,
+				got, test.want)
+		}
+	}
+}
+
+// TestRoutedDialer ensures the dial functions returned by NewRoutedDialer
+// select the expected route, apply per route timeouts, and return errors that
+// describe the reason for failures.
+func TestRoutedDialer(t *testing.T) {
+	const routeTimeout = time.Millisecond * 20
+
+	// recordingDialer returns a dial function that records the name of the
+	// route it is associated with and then either connects or fails with
+	// the provided error.
+	var lastRoute string
+	recordingDialer := func(name string, dialErr error) DialFunc {
+		return func(ctx context.Context, network, addr string) (net.Conn, error) {
+			lastRoute = name
+			if dialErr != nil {
+				return nil, dialErr
+			}
+			return mockDialer(ctx, network, addr)
+		}
+	}
+	blockingDialer := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		lastRoute = "slow"
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	refusedErr := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	isSlowAddr := func(network, addr string) bool {
+		return strings.HasPrefix(addr, "10.0.0.1:")
+	}
+	isRefusedAddr := func(network, addr string) bool {
+		return strings.HasPrefix(addr, "10.0.0.2:")
+	}
+	isDirectAddr := func(network, addr string) bool {
+		return !IsOnionAddr(network, addr)
+	}
+	dial := NewRoutedDialer(DialRoute{
+		Name:  "onion proxy",
+		Match: IsOnionAddr,
+		Dial:  recordingDialer("onion", nil),
+	}, DialRoute{
+		Name:    "slow proxy",
+		Match:   isSlowAddr,
+		Dial:    blockingDialer,
+		Timeout: routeTimeout,
+	}, DialRoute{
+		Name:  "refusing proxy",
+		Match: isRefusedAddr,
+		Dial:  recordingDialer("refused", refusedErr),
+	}, DialRoute{
+		Match: isDirectAddr,
+		Dial:  recordingDialer("direct", nil),
+	})
+
+	tests := []struct {
+		name      string    // test description
+		addr      string    // address to dial
+		wantRoute string    // expected route
+		wantErr   ErrorKind // expected error kind, empty for no error
+	}{{
+		name:      "onion address",
+		addr:      "abcdefghijklmnop.onion:9108",
+		wantRoute: "onion",
+	}, {
+		name:      "direct address",
+		addr:      "127.0.0.1:9108",
+		wantRoute: "direct",
+	}, {
+		name:      "route timeout",
+		addr:      "10.0.0.1:9108",
+		wantRoute: "slow",
+		wantErr:   ErrDialTimeout,
+	}, {
+		name:      "connection refused",
+		addr:      "10.0.0.2:9108",
+		wantRoute: "refused",
+		wantErr:   ErrDialRefused,
+	}}
+
+	for _, test := range tests {
+		lastRoute = ""
+		start := time.Now()
+		conn, err := dial(context.Background(), "tcp", test.addr)
+		if lastRoute != test.wantRoute {
+			t.Errorf("%s: unexpected route -- got %q, want %q", test.name,
+				lastRoute, test.wantRoute)
+			continue
+		}
+		if test.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", test.name, err)
+				continue
+			}
+			conn.Close()
+			continue
+		}
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%s: unexpected error -- got %v, want %v", test.name,
+				err, test.wantErr)
+			continue
+		}
+		if test.wantErr == ErrDialTimeout && time.Since(start) > routeTimeout*10 {
+			t.Errorf("%s: route timeout not applied", test.name)
+		}
+	}
+
+	// Ensure addresses that no route matches fail with the expected error when
+	// the onion route is not available.
+	dial = NewRoutedDialer(DialRoute{
+		Match: isDirectAddr,
+		Dial:  recordingDialer("direct", nil),
+	})
+	_, err := dial(context.Background(), "tcp", "abcdefghijklmnop.onion:9108")
+	if !errors.Is(err, ErrDialNoRoute) {
+		t.Fatalf("unexpected error -- got %v, want %v", err, ErrDialNoRoute)
+	}
+
+	// Ensure errors from canceled contexts are classified as such.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	dial = NewRoutedDialer(DialRoute{Dial: blockingDialer})
+	_, err = dial(ctx, "tcp", "127.0.0.1:9108")
+	if !errors.Is(err, ErrDialCanceled) {
+		t.Fatalf("unexpected error -- got %v, want %v", err, ErrDialCanceled)
+	}
+}
@@ -11,5 +11,18 @@ Package connmgr implements a generic Decred network connection manager.
 Connection manager handles all the general connection concerns such as
 maintaining a set number of outbound connections, sourcing peers, banning,
 limiting max connections, tor lookup, etc.
+
+# Dialing
+
+The dial function NewRoutedDialer returns may be used to route connections to
+addresses according to policy through a chain of routes, such as connecting
+to onion addresses through Tor while connecting directly otherwise, with
+optional per route timeouts.
+
+Failed connection attempts are reported to the OnConnectionFailed callback with
+an error that has one of the dial error kinds, such as ErrDialTimeout and
+ErrDialRefused, which describes the reason for the failure.  This allows
+callers to decide how each failure is accounted for, for example, in an address
+manager.
 */
 package connmgr
@@ -53,6 +53,29 @@ const (
 
 	// ErrTorAddrNotSupported indicates the tor address type is not supported.
 	ErrTorAddrNotSupported = ErrorKind("ErrTorAddrNotSupported")
+
+	// ErrDialTimeout indicates a connection attempt did not complete before
+	// its timeout elapsed.
+	ErrDialTimeout = ErrorKind("ErrDialTimeout")
+
+	// ErrDialCanceled indicates a connection attempt was canceled before it
+	// completed.
+	ErrDialCanceled = ErrorKind("ErrDialCanceled")
+
+	// ErrDialRefused indicates the remote host refused a connection attempt.
+	ErrDialRefused = ErrorKind("ErrDialRefused")
+
+	// ErrDialUnreachable indicates the network or remote host of a
+	// connection attempt is unreachable.
+	ErrDialUnreachable = ErrorKind("ErrDialUnreachable")
+
+	// ErrDialNoRoute indicates there is no dial route that is allowed to
+	// handle the address of a connection attempt.
+	ErrDialNoRoute = ErrorKind("ErrDialNoRoute")
+
+	// ErrDialFailed indicates a connection attempt failed for a reason that
+	// is not covered by one of the more specific dial error kinds.
+	ErrDialFailed = ErrorKind("ErrDialFailed")
 )
 
 // Error satisfies the error interface and prints human-readable errors.
@@ -29,6 +29,12 @@ func TestErrorKindStringer(t *testing.T) {
 		{ErrTorTTLExpired, "ErrTorTTLExpired"},
 		{ErrTorCmdNotSupported, "ErrTorCmdNotSupported"},
 		{ErrTorAddrNotSupported, "ErrTorAddrNotSupported"},
+		{ErrDialTimeout, "ErrDialTimeout"},
+		{ErrDialCanceled, "ErrDialCanceled"},
+		{ErrDialRefused, "ErrDialRefused"},
+		{ErrDialUnreachable, "ErrDialUnreachable"},
+		{ErrDialNoRoute, "ErrDialNoRoute"},
+		{ErrDialFailed, "ErrDialFailed"},
 	}
 
 	for i, test := range tests {
@@ -498,6 +498,34 @@ func (s *blockStore) writeBlock(rawBlock []byte) (blockLocation, error) {
 	return loc, nil
 }
 
+// releaseBlock releases the space occupied by the block record at the provided
+// location in its flat file without changing the size of the file or the
+// location of any other block records.  The space is only released on
+// platforms and filesystems that support deallocating file ranges.
+//
+// The block record MUST no longer be referenced by the block index since the
+// data it contains is no longer valid afterwards.
+//
+// Returns ErrDriverSpecific if the space fails to be released for any reason.
+func (s *blockStore) releaseBlock(loc blockLocation) error {
+	filePath := blockFilePath(s.basePath, loc.blockFileNum)
+	file, err := os.OpenFile(filePath, os.O_RDWR, 0)
+	if err != nil {
+		str := fmt.Sprintf("failed to open file %q: %v", filePath, err)
+		return makeDbErr(database.ErrDriverSpecific, str)
+	}
+	defer file.Close()
+
+	err = deallocateFileRange(file, int64(loc.fileOffset), int64(loc.blockLen))
+	if err != nil {
+		str := fmt.Sprintf("failed to release %d bytes at offset %d in file "+
+			"%q: %v", loc.blockLen, loc.fileOffset, filePath, err)
+		return makeDbErr(database.ErrDriverSpecific, str)
+	}
+
+	return nil
+}
+
 // readBlock reads the specified block record and returns the serialized block.
 // It ensures the integrity of the block data by checking that the serialized
 // network matches the current network associated with the block store and
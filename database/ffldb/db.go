@@ -973,6 +973,10 @@ type transaction struct {
 	pendingBlocks    map[chainhash.Hash]int
 	pendingBlockData []pendingBlock
 
+	// Locations of the blocks that were removed from the block index and
+	// need their space released on commit.
+	pendingRemoveBlocks []blockLocation
+
 	// Keys that need to be stored or deleted on commit.
 	pendingKeys   *treap.Mutable
 	pendingRemove *treap.Mutable
@@ -1196,6 +1200,61 @@ func (tx *transaction) StoreBlock(block database.BlockSerializer) error {
 	return nil
 }
 
+// RemoveBlock removes the block with the provided hash from the database.  The
+// block is removed from the block index and the space it occupied in the flat
+// files is released once the transaction is committed on platforms and
+// filesystems that support it.
+//
+// Note that this is not part of the database.Tx interface.  Callers may
+// determine whether or not a transaction supports removing blocks by asserting
+// it implements an interface with this method.
+//
+// Returns the following errors:
+//   - ErrBlockNotFound if the requested block hash does not exist
+//   - ErrTxNotWritable if attempted against a read-only transaction
+//   - ErrTxClosed if the transaction has already been closed
+func (tx *transaction) RemoveBlock(hash *chainhash.Hash) error {
+	// Ensure transaction state is valid.
+	if err := tx.checkClosed(); err != nil {
+		return err
+	}
+
+	// Ensure the transaction is writable.
+	if !tx.writable {
+		str := "remove block requires a writable database transaction"
+		return makeDbErr(database.ErrTxNotWritable, str)
+	}
+
+	// Remove the block from the pending blocks when it has not been stored
+	// yet.
+	if idx, exists := tx.pendingBlocks[*hash]; exists {
+		delete(tx.pendingBlocks, *hash)
+		copy(tx.pendingBlockData[idx:], tx.pendingBlockData[idx+1:])
+		tx.pendingBlockData = tx.pendingBlockData[:len(tx.pendingBlockData)-1]
+		for blockHash, pendingIdx := range tx.pendingBlocks {
+			if pendingIdx > idx {
+				tx.pendingBlocks[blockHash] = pendingIdx - 1
+			}
+		}
+		return nil
+	}
+
+	// Remove the block from the block index and keep track of its location so
+	// the space it occupies can be released on commit.
+	blockRow, err := tx.fetchBlockRow(hash)
+	if err != nil {
+		return err
+	}
+	location := deserializeBlockLoc(blockRow)
+	if err := tx.blockIdxBucket.Delete(hash[:]); err != nil {
+		return err
+	}
+	tx.pendingRemoveBlocks = append(tx.pendingRemoveBlocks, location)
+	log.Tracef("Added block %s to pending removed blocks", hash)
+
+	return nil
+}
+
 // HasBlock returns whether or not a block with the given hash exists in the
 // database.
 //
@@ -1642,6 +1701,7 @@ func (tx *transaction) close() {
 	// Clear pending blocks that would have been written on commit.
 	tx.pendingBlocks = nil
 	tx.pendingBlockData = nil
+	tx.pendingRemoveBlocks = nil
 
 	// Clear pending keys that would have been written or deleted on commit.
 	tx.pendingKeys = nil
@@ -1730,7 +1790,27 @@ func (tx *transaction) writePendingAndCommit() error {
 
 	// Atomically update the database cache.  The cache automatically
 	// handles flushing to the underlying persistent storage database.
-	return tx.db.cache.commitTx(tx)
+	if err := tx.db.cache.commitTx(tx); err != nil {
+		return err
+	}
+
+	// Release the space occupied by any removed blocks.  The cache is flushed
+	// first to ensure the block index no longer references them in the
+	// persistent storage database in unexpected shutdown scenarios.  Failure
+	// to release the space is not fatal since the blocks are no longer
+	// referenced either way.
+	if len(tx.pendingRemoveBlocks) == 0 {
+		return nil
+	}
+	if err := tx.db.cache.flush(); err != nil {
+		return err
+	}
+	for _, location := range tx.pendingRemoveBlocks {
+		if err := tx.db.store.releaseBlock(location); err != nil {
+			log.Warnf("Unable to release space of removed block: %v", err)
+		}
+	}
+	return nil
 }
 
 // Commit commits all changes that have been made to the root metadata bucket
@@ -0,0 +1,33 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package ffldb
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+const (
+	// fallocFlKeepSize and fallocFlPunchHole are the fallocate flags which
+	// deallocate a range of a file without changing its size.
+	fallocFlKeepSize  = 0x01
+	fallocFlPunchHole = 0x02
+)
+
+// deallocateFileRange releases the disk space occupied by the provided range of
+// the passed file by punching a hole in it.  Reads of the range return zeros
+// afterwards.  Filesystems that do not support punching holes are ignored.
+func deallocateFileRange(file *os.File, offset, length int64) error {
+	const mode = fallocFlKeepSize | fallocFlPunchHole
+	err := syscall.Fallocate(int(file.Fd()), mode, offset, length)
+	if errors.Is(err, syscall.EOPNOTSUPP) {
+		return nil
+	}
+	return err
+}
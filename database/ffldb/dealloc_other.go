@@ -0,0 +1,16 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package ffldb
+
+import "os"
+
+// deallocateFileRange is a no-op on platforms that do not support releasing the
+// disk space occupied by a range of a file.
+func deallocateFileRange(file *os.File, offset, length int64) error {
+	return nil
+}
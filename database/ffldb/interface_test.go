@@ -1650,6 +1650,125 @@ func testBlockIOTxInterface(tc *testContext) bool {
 	return true
 }
 
+// blockRemover is implemented by database transactions that support removing
+// blocks.
+type blockRemover interface {
+	RemoveBlock(hash *chainhash.Hash) error
+}
+
+// removeBlock removes the block with the provided hash via the passed database
+// transaction, which must support removing blocks.
+func removeBlock(tx database.Tx, hash *chainhash.Hash) error {
+	return tx.(blockRemover).RemoveBlock(hash)
+}
+
+// testRemoveBlockTxInterface ensures that removing blocks works as expected for
+// both blocks that are pending in the same transaction and blocks that were
+// previously committed.  This function removes the last stored block from the
+// database.
+func testRemoveBlockTxInterface(tc *testContext) bool {
+	lastBlock := tc.blocks[len(tc.blocks)-1]
+	lastBlockHash := lastBlock.Hash()
+
+	// Ensure attempting to remove a block with a read-only transaction fails
+	// with the expected error.
+	err := tc.db.View(func(tx database.Tx) error {
+		testName := "RemoveBlock on ro tx"
+		err := removeBlock(tx, lastBlockHash)
+		if !checkDbError(tc.t, testName, err, database.ErrTxNotWritable) {
+			return errSubTestFail
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, errSubTestFail) {
+			tc.t.Errorf("%v", err)
+		}
+		return false
+	}
+
+	// Remove the last block and ensure it no longer exists from the point of
+	// view of the transaction while the other blocks remain available.  Also,
+	// ensure removing it again returns the expected error.
+	err = tc.db.Update(func(tx database.Tx) error {
+		if err := removeBlock(tx, lastBlockHash); err != nil {
+			tc.t.Errorf("RemoveBlock: unexpected error: %v", err)
+			return errSubTestFail
+		}
+		if hasBlock, _ := tx.HasBlock(lastBlockHash); hasBlock {
+			tc.t.Errorf("HasBlock: removed block still exists")
+			return errSubTestFail
+		}
+		testName := "RemoveBlock on removed block"
+		err := removeBlock(tx, lastBlockHash)
+		if !checkDbError(tc.t, testName, err, database.ErrBlockNotFound) {
+			return errSubTestFail
+		}
+		if _, err := tx.FetchBlock(tc.blocks[0].Hash()); err != nil {
+			tc.t.Errorf("FetchBlock: unexpected error: %v", err)
+			return errSubTestFail
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, errSubTestFail) {
+			tc.t.Errorf("%v", err)
+		}
+		return false
+	}
+
+	// Ensure the removed block does not exist after the commit.
+	err = tc.db.View(func(tx database.Tx) error {
+		testName := "FetchBlock on removed block"
+		_, err := tx.FetchBlock(lastBlockHash)
+		if !checkDbError(tc.t, testName, err, database.ErrBlockNotFound) {
+			return errSubTestFail
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, errSubTestFail) {
+			tc.t.Errorf("%v", err)
+		}
+		return false
+	}
+
+	// Ensure removing a block that is pending in the same transaction prevents
+	// it from being stored.
+	err = tc.db.Update(func(tx database.Tx) error {
+		if err := tx.StoreBlock(lastBlock); err != nil {
+			tc.t.Errorf("StoreBlock: unexpected error: %v", err)
+			return errSubTestFail
+		}
+		if err := removeBlock(tx, lastBlockHash); err != nil {
+			tc.t.Errorf("RemoveBlock (pending): unexpected error: %v", err)
+			return errSubTestFail
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, errSubTestFail) {
+			tc.t.Errorf("%v", err)
+		}
+		return false
+	}
+	err = tc.db.View(func(tx database.Tx) error {
+		if hasBlock, _ := tx.HasBlock(lastBlockHash); hasBlock {
+			tc.t.Errorf("HasBlock: removed pending block was stored")
+			return errSubTestFail
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, errSubTestFail) {
+			tc.t.Errorf("%v", err)
+		}
+		return false
+	}
+
+	return true
+}
+
 // testClosedTxInterface ensures that both the metadata and block IO API
 // functions behave as expected when attempted against a closed transaction.
 func testClosedTxInterface(tc *testContext, tx database.Tx) bool {
@@ -1825,6 +1944,13 @@ func testClosedTxInterface(tc *testContext, tx database.Tx) bool {
 			return false
 		}
 
+		// Ensure RemoveBlock returns expected error.
+		testName = fmt.Sprintf("RemoveBlock #%d on closed tx", i)
+		err = removeBlock(tx, blockHash)
+		if !checkDbError(tc.t, testName, err, wantErrKind) {
+			return false
+		}
+
 		// Ensure FetchBlock returns expected error.
 		testName = fmt.Sprintf("FetchBlock #%d on closed tx", i)
 		_, err = tx.FetchBlock(blockHash)
@@ -2261,6 +2387,12 @@ func testInterface(t *testing.T, db database.DB) {
 		return
 	}
 
+	// Test removing blocks works as expected.  This function removes the last
+	// stored block, so it must be run after all tests that rely on it.
+	if !testRemoveBlockTxInterface(&context) {
+		return
+	}
+
 	// Test that closing the database with open transactions blocks until
 	// the transactions are finished.
 	//
@@ -6,6 +6,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -17,10 +18,12 @@ import (
 	"runtime/pprof"
 	"strings"
 
+	"github.com/decred/dcrd/database/v3"
 	"github.com/decred/dcrd/internal/blockchain"
 	"github.com/decred/dcrd/internal/blockchain/indexers"
 	"github.com/decred/dcrd/internal/limits"
 	"github.com/decred/dcrd/internal/version"
+	"github.com/syndtr/goleveldb/leveldb"
 )
 
 var cfg *config
@@ -35,6 +38,50 @@ var winServiceMain func() (bool, error)
 // service is not running.
 var serviceStartOfDayChan = make(chan *config, 1)
 
+// importUtxoSet imports the UTXO set from the snapshot file specified by the
+// importutxoset option into the provided UTXO database, which must be empty.
+func importUtxoSet(ctx context.Context, db database.DB, utxoDb *leveldb.DB) error {
+	f, err := os.Open(cfg.ImportUtxoSet)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dcrdLog.Infof("Importing UTXO set from %s...", cfg.ImportUtxoSet)
+	backend := blockchain.NewLevelDbUtxoBackend(utxoDb)
+	info, err := blockchain.ImportUtxoSnapshot(ctx, f, cfg.params.Params, db,
+		backend)
+	if err != nil {
+		return err
+	}
+	dcrdLog.Infof("Imported %d utxos as of block %v (height %d, integrity "+
+		"hash %v)", info.NumUtxos, info.Hash, info.Height, info.IntegrityHash)
+	return nil
+}
+
+// exportUtxoSet writes a snapshot of the UTXO set as of the current best block
+// of the provided chain to the file specified by the exportutxoset option.
+func exportUtxoSet(ctx context.Context, chain *blockchain.BlockChain) error {
+	f, err := os.Create(cfg.ExportUtxoSet)
+	if err != nil {
+		return err
+	}
+
+	dcrdLog.Infof("Exporting UTXO set to %s...", cfg.ExportUtxoSet)
+	info, err := chain.ExportUtxoSnapshot(ctx, f)
+	if err != nil {
+		f.Close()
+		os.Remove(cfg.ExportUtxoSet)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	dcrdLog.Infof("Exported %d utxos as of block %v (height %d, integrity "+
+		"hash %v)", info.NumUtxos, info.Hash, info.Height, info.IntegrityHash)
+	return nil
+}
+
 // dcrdMain is the real main function for dcrd.  It is necessary to work around
 // the fact that deferred functions do not run when os.Exit() is called.
 func dcrdMain() error {
@@ -192,6 +239,22 @@ func dcrdMain() error {
 
 		return nil
 	}
+	if cfg.DropNullDataIndex {
+		if err := indexers.DropNullDataIndex(ctx, db); err != nil {
+			dcrdLog.Errorf("%v", err)
+			return err
+		}
+
+		return nil
+	}
+	if cfg.DropUtxoHistoryIndex {
+		if err := indexers.DropUtxoHistoryIndex(ctx, db); err != nil {
+			dcrdLog.Errorf("%v", err)
+			return err
+		}
+
+		return nil
+	}
 
 	// Drop the legacy v1 committed filter index if needed.
 	if err := indexers.DropCfIndex(ctx, db); err != nil {
@@ -199,6 +262,16 @@ func dcrdMain() error {
 		return err
 	}
 
+	// Import the UTXO set from a snapshot file if requested.  This must happen
+	// before the server is created since the chain initializes its view of the
+	// UTXO set from the database when it is created.
+	if cfg.ImportUtxoSet != "" {
+		if err := importUtxoSet(ctx, db, utxoDb); err != nil {
+			dcrdLog.Errorf("Unable to import UTXO set: %v", err)
+			return err
+		}
+	}
+
 	// Create server.
 	lifetimeNotifier.notifyStartupEvent(lifetimeEventP2PServer)
 	svr, err := newServer(ctx, cfg.Listeners, db, utxoDb, cfg.params.Params,
@@ -212,12 +285,26 @@ func dcrdMain() error {
 		return nil
 	}
 
+	// Export the UTXO set to a snapshot file and exit if requested.
+	if cfg.ExportUtxoSet != "" {
+		if err := exportUtxoSet(ctx, svr.chain); err != nil {
+			dcrdLog.Errorf("Unable to export UTXO set: %v", err)
+			return err
+		}
+
+		return nil
+	}
+
 	lifetimeNotifier.notifyStartupComplete()
 	defer lifetimeNotifier.notifyShutdownEvent(lifetimeEventP2PServer)
 
 	// Signal the Windows service (if running) that startup has completed.
 	serviceStartOfDayChan <- cfg
 
+	// Reload the config options that can safely be changed at runtime when
+	// a reload signal such as SIGHUP is received.
+	go reloadListener(ctx, svr)
+
 	// Run the server.  This will block until the context is cancelled which
 	// happens when the interrupt signal is received from an OS signal or
 	// shutdown is requested through one of the subsystems such as the RPC
@@ -6,6 +6,7 @@ package edwards
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"crypto/hmac"
 	"crypto/sha256"
 	"crypto/sha512"
@@ -14,7 +15,6 @@ import (
 	"io"
 	"math/big"
 
-	"github.com/agl/ed25519"
 	"github.com/agl/ed25519/edwards25519"
 )
 
@@ -22,9 +22,11 @@ import (
 // Memory management is kind of sloppy and whether or not your keys or
 // nonces can be found in memory later is likely a product of when the
 // garbage collector runs.
-// Signing/EC mult is also not constant side, so don't use this in any
-// application where you think you might be vulnerable to side channel
-// attacks.
+// Key generation, signing from standard private secrets, and verification
+// are backed by the constant time implementation in the standard library,
+// however, signing from non-standard scalars and the general EC math is not
+// constant time, so don't use those in any application where you think you
+// might be vulnerable to side channel attacks.
 
 var (
 	// oneInitializer is used to fill a byte slice with byte 0x01.  It is provided
@@ -39,15 +41,13 @@ var (
 // the private scalar and the corresponding public key points from a
 // random secret.
 func GenerateKey(rand io.Reader) (priv []byte, x, y *big.Int, err error) {
-	var pub *[PubKeyBytesLen]byte
-	var privArray *[PrivKeyBytesLen]byte
-	pub, privArray, err = ed25519.GenerateKey(rand)
+	pub, privKey, err := ed25519.GenerateKey(rand)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	priv = privArray[:]
+	priv = privKey
 
-	x, y, err = Edwards().encodedBytesToBigIntPoint(pub)
+	x, y, err = Edwards().encodedBytesToBigIntPoint(copyBytes(pub))
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -68,7 +68,7 @@ func SignFromSecret(rand io.Reader, priv *PrivateKey, hash []byte) (r, s *big.In
 func SignFromSecretNoReader(priv *PrivateKey, hash []byte) (r, s *big.Int, err error) {
 	privBytes := priv.SerializeSecret()
 	privArray := copyBytes64(privBytes)
-	sig := ed25519.Sign(privArray, hash)
+	sig := ed25519.Sign(privArray[:], hash)
 
 	// The signatures are encoded as
 	//   sig[0:32]  R, a point encoded as little endian
@@ -358,10 +358,31 @@ func Verify(pub *PublicKey, hash []byte, r, s *big.Int) bool {
 		return false
 	}
 
-	pubBytes := pub.Serialize()
-	sig := &Signature{r, s}
-	sigBytes := sig.Serialize()
-	pubArray := copyBytes(pubBytes)
-	sigArray := copyBytes64(sigBytes)
-	return ed25519.Verify(pubArray, hash, sigArray)
+	return verify(pub, hash, &Signature{r, s})
+}
+
+// verify verifies a message 'hash' using the given public key and signature
+// via the constant time implementation in the standard library.
+//
+// The standard library implementation rejects signatures with an encoded S
+// that is not fully reduced modulo the group order, while the historical
+// implementation only rejected signatures with any of the 3 most significant
+// bits of S set.  Since scalar multiplication of the base point by S is
+// equivalent to multiplication by S reduced modulo the group order, S is
+// reduced accordingly prior to verification in order to retain identical
+// verification semantics.  Note that ParseSignature rejects signatures that
+// are not fully reduced, so this only applies to signatures constructed
+// directly from their components.
+func verify(pub *PublicKey, hash []byte, sig *Signature) bool {
+	pubArray := copyBytes(pub.Serialize())
+	sigArray := copyBytes64(sig.Serialize())
+	if sigArray[63]&0xe0 != 0 {
+		return false
+	}
+	sBytes := copyBytes(sigArray[32:])
+	if s := encodedBytesToBigInt(sBytes); s.Cmp(Edwards().N) >= 0 {
+		s.Mod(s, Edwards().N)
+		copy(sigArray[32:], bigIntToEncodedBytes(s)[:])
+	}
+	return ed25519.Verify(pubArray[:], hash, sigArray[:])
 }
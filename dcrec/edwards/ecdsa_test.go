@@ -16,6 +16,8 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	refed25519 "github.com/agl/ed25519"
 )
 
 func TestGolden(t *testing.T) {
@@ -470,3 +472,100 @@ func benchmarkVerification(b *testing.B) {
 }
 
 func BenchmarkVerification(b *testing.B) { benchmarkVerification(b) }
+
+// TestReferenceCrossCheck ensures key generation, signing, and verification
+// produce identical results to the reference implementation that previously
+// backed them for randomly-generated keys and messages as well as valid,
+// malformed, and non-canonical signatures.
+func TestReferenceCrossCheck(t *testing.T) {
+	tRand := rand.New(rand.NewSource(12345))
+	curveN := Edwards().N
+
+	// refVerify verifies the provided signature components using the reference
+	// implementation.
+	refVerify := func(pub *PublicKey, msg []byte, r, s *big.Int) bool {
+		pubArray := copyBytes(pub.Serialize())
+		sigArray := copyBytes64(NewSignature(r, s).Serialize())
+		return refed25519.Verify(pubArray, msg, sigArray)
+	}
+
+	for i := 0; i < 500; i++ {
+		// Ensure generating a key from the same source of randomness produces
+		// the same key as the reference implementation.
+		var seed [32]byte
+		tRand.Read(seed[:])
+		priv, x, y, err := GenerateKey(bytes.NewReader(seed[:]))
+		if err != nil {
+			t.Fatalf("unexpected error generating key: %v", err)
+		}
+		refPub, refPriv, err := refed25519.GenerateKey(bytes.NewReader(seed[:]))
+		if err != nil {
+			t.Fatalf("unexpected error generating reference key: %v", err)
+		}
+		if !bytes.Equal(priv, refPriv[:]) {
+			t.Fatalf("mismatched private key -- got %x, want %x", priv,
+				refPriv[:])
+		}
+		pub := NewPublicKey(x, y)
+		if !bytes.Equal(pub.Serialize(), refPub[:]) {
+			t.Fatalf("mismatched public key -- got %x, want %x",
+				pub.Serialize(), refPub[:])
+		}
+
+		// Ensure signing a random message produces the same signature as the
+		// reference implementation.
+		msg := make([]byte, tRand.Intn(128))
+		tRand.Read(msg)
+		privKey, _ := PrivKeyFromSecret(seed[:])
+		r, s, err := Sign(privKey, msg)
+		if err != nil {
+			t.Fatalf("unexpected error signing: %v", err)
+		}
+		sig := NewSignature(r, s).Serialize()
+		refSig := refed25519.Sign(refPriv, msg)
+		if !bytes.Equal(sig, refSig[:]) {
+			t.Fatalf("mismatched signature -- got %x, want %x", sig,
+				refSig[:])
+		}
+
+		// Create several valid, malformed, and non-canonical variations of the
+		// signature and ensure verification results match the reference
+		// implementation.
+		flipBit := func(v *big.Int) *big.Int {
+			return new(big.Int).Xor(v, new(big.Int).Lsh(one,
+				uint(tRand.Intn(256))))
+		}
+		otherMsg := append([]byte{0x01}, msg...)
+		tests := []struct {
+			name string
+			msg  []byte
+			r, s *big.Int
+		}{
+			{name: "valid", msg: msg, r: r, s: s},
+			{name: "wrong message", msg: otherMsg, r: r, s: s},
+			{name: "flipped R bit", msg: msg, r: flipBit(r), s: s},
+			{name: "flipped S bit", msg: msg, r: r, s: flipBit(s)},
+			{name: "S + N", msg: msg, r: r, s: new(big.Int).Add(s, curveN)},
+			{name: "S + 2N", msg: msg, r: r,
+				s: new(big.Int).Add(s, new(big.Int).Lsh(curveN, 1))},
+			{name: "S - N", msg: msg, r: r, s: new(big.Int).Sub(s, curveN)},
+			{name: "zero S", msg: msg, r: r, s: new(big.Int)},
+			{name: "N as S", msg: msg, r: r, s: curveN},
+		}
+		for _, test := range tests {
+			want := refVerify(pub, test.msg, test.r, test.s)
+			if got := Verify(pub, test.msg, test.r, test.s); got != want {
+				t.Fatalf("%q: mismatched verify result -- got %v, want %v",
+					test.name, got, want)
+			}
+			sig := NewSignature(test.r, test.s)
+			if got := sig.Verify(test.msg, pub); got != want {
+				t.Fatalf("%q: mismatched signature verify result -- got %v, "+
+					"want %v", test.name, got, want)
+			}
+			if test.name == "valid" && !want {
+				t.Fatalf("%q: reference rejected valid signature", test.name)
+			}
+		}
+	}
+}
@@ -6,14 +6,12 @@
 package edwards
 
 import (
-	"bytes"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/sha512"
 	"fmt"
 	"math/big"
-
-	"github.com/agl/ed25519"
 )
 
 // These constants define the lengths of serialized private keys.
@@ -105,15 +103,10 @@ func PrivKeyFromSecret(s []byte) (*PrivateKey, *PublicKey) {
 		return nil, nil
 	}
 
-	// Instead of using rand to generate our scalar, use the scalar
-	// itself as a reader.
-	sReader := bytes.NewReader(s)
-	_, pk, err := ed25519.GenerateKey(sReader)
-	if err != nil {
-		return nil, nil
-	}
-
-	return PrivKeyFromBytes(pk[:])
+	// Derive the full private key from the secret, which is the seed in
+	// the terminology used by RFC 8032.
+	pk := ed25519.NewKeyFromSeed(s)
+	return PrivKeyFromBytes(pk)
 }
 
 // PrivKeyFromScalar returns a private and public key for `curve' based on the
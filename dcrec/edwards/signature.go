@@ -8,8 +8,6 @@ package edwards
 import (
 	"fmt"
 	"math/big"
-
-	"github.com/agl/ed25519"
 )
 
 // Signature is a type representing an ecdsa signature.
@@ -56,11 +54,7 @@ func (sig *Signature) Verify(hash []byte, pubKey *PublicKey) bool {
 		return false
 	}
 
-	pubBytes := pubKey.Serialize()
-	sigBytes := sig.Serialize()
-	pubArray := copyBytes(pubBytes)
-	sigArray := copyBytes64(sigBytes)
-	return ed25519.Verify(pubArray, hash, sigArray)
+	return verify(pubKey, hash, sig)
 }
 
 // parseSig is the default method of parsing a serialized Ed25519 signature.
@@ -65,6 +65,33 @@ func BenchmarkScalarBaseMultNonConst(b *testing.B) {
 	}
 }
 
+// BenchmarkScalarBaseMultPrecomps benchmarks multiplying a scalar by the base
+// point of the curve with both the large and small pre-computed tables.
+func BenchmarkScalarBaseMultPrecomps(b *testing.B) {
+	k := hexToModNScalar("d74bf844b0862475103d96a611cf2d898447e288d34b360bc885cb8ce7c00575")
+
+	benches := []struct {
+		name string
+		fn   func(*ModNScalar, *JacobianPoint)
+	}{
+		{name: "large", fn: scalarBaseMultNonConstLarge},
+		{name: "small", fn: scalarBaseMultNonConstSmall},
+	}
+	for _, bench := range benches {
+		b.Run(bench.name, func(b *testing.B) {
+			// Ensure the table is loaded prior to timing.
+			var result JacobianPoint
+			bench.fn(k, &result)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				bench.fn(k, &result)
+			}
+		})
+	}
+}
+
 // BenchmarkSplitK benchmarks decomposing scalars into a balanced length-two
 // representation.
 func BenchmarkSplitK(b *testing.B) {
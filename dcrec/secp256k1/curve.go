@@ -1219,8 +1219,27 @@ func ScalarMultNonConst(k *ModNScalar, point, result *JacobianPoint) {
 // order and G is the base point of the group and stores the result in the
 // provided Jacobian point.
 //
+// The pre-computed table used to accelerate the multiplication is selected at
+// build time.  By default, a larger table that provides the best performance is
+// used.  Building with the secp256k1_smallprecomps build tag selects a table
+// that requires roughly an eighth of the memory in exchange for performing
+// twice as many point additions.
+//
 // NOTE: The resulting point will be normalized.
 func ScalarBaseMultNonConst(k *ModNScalar, result *JacobianPoint) {
+	if useSmallPrecomps {
+		scalarBaseMultNonConstSmall(k, result)
+		return
+	}
+	scalarBaseMultNonConstLarge(k, result)
+}
+
+// scalarBaseMultNonConstLarge multiplies k*G where k is a scalar modulo the
+// curve order and G is the base point of the group using the large pre-computed
+// table of 8-bit windows and stores the result in the provided Jacobian point.
+//
+// NOTE: The resulting point will be normalized.
+func scalarBaseMultNonConstLarge(k *ModNScalar, result *JacobianPoint) {
 	bytePoints := s256BytePoints()
 
 	// Start with the point at infinity.
@@ -1239,6 +1258,30 @@ func ScalarBaseMultNonConst(k *ModNScalar, result *JacobianPoint) {
 	}
 }
 
+// scalarBaseMultNonConstSmall multiplies k*G where k is a scalar modulo the
+// curve order and G is the base point of the group using the small pre-computed
+// table of 4-bit windows and stores the result in the provided Jacobian point.
+//
+// NOTE: The resulting point will be normalized.
+func scalarBaseMultNonConstSmall(k *ModNScalar, result *JacobianPoint) {
+	nibblePoints := s256NibblePoints()
+
+	// Start with the point at infinity.
+	result.X.Zero()
+	result.Y.Zero()
+	result.Z.Zero()
+
+	// nibblePoints has all 16 nibble points for each 4-bit window.  The
+	// strategy is the same as the large table variant except each byte of k in
+	// base-256 is split into its high and low base-16 digits which are then
+	// looked up using nibblePoints and added together.
+	kb := k.Bytes()
+	for i := 0; i < len(kb); i++ {
+		AddNonConst(result, &nibblePoints[i*2][kb[i]>>4], result)
+		AddNonConst(result, &nibblePoints[i*2+1][kb[i]&0x0f], result)
+	}
+}
+
 // isOnCurve returns whether or not the affine point (x,y) is on the curve.
 func isOnCurve(fx, fy *FieldVal) bool {
 	// Elliptic curve equation for secp256k1 is: y^2 = x^3 + 7
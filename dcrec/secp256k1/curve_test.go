@@ -564,9 +564,11 @@ func TestScalarBaseMultJacobian(t *testing.T) {
 		}
 
 		// Ensure the result matches the expected value in Jacobian coordinates.
+		// Note that the Jacobian coordinates depend on the pre-computed table
+		// in use, so they are only checked for the default table.
 		var r JacobianPoint
 		ScalarBaseMultNonConst(k, &r)
-		if !r.IsStrictlyEqual(&want) {
+		if !useSmallPrecomps && !r.IsStrictlyEqual(&want) {
 			t.Errorf("%q: wrong result:\ngot: (%s, %s, %s)\nwant: (%s, %s, %s)",
 				test.name, r.X, r.Y, r.Z, want.X, want.Y, want.Z)
 			continue
@@ -582,6 +584,44 @@ func TestScalarBaseMultJacobian(t *testing.T) {
 	}
 }
 
+// TestScalarBaseMultPrecompsRandom ensures scalar base point multiplication
+// produces the same results with both the large and small pre-computed tables
+// for some edge cases and randomly-generated scalars.
+func TestScalarBaseMultPrecompsRandom(t *testing.T) {
+	// Use a unique random seed each test instance and log it if the tests fail.
+	seed := time.Now().Unix()
+	rng := mrand.New(mrand.NewSource(seed))
+	defer func(t *testing.T, seed int64) {
+		if t.Failed() {
+			t.Logf("random seed: %d", seed)
+		}
+	}(t, seed)
+
+	// Start with the edge cases of zero, one, and the group order minus one
+	// followed by random scalars.
+	scalars := []*ModNScalar{
+		new(ModNScalar),
+		new(ModNScalar).SetInt(1),
+		new(ModNScalar).SetInt(1).Negate(),
+	}
+	for i := 0; i < 100; i++ {
+		scalars = append(scalars, randModNScalar(t, rng))
+	}
+
+	for _, k := range scalars {
+		// Ensure the affine results from both tables match.
+		var large, small JacobianPoint
+		scalarBaseMultNonConstLarge(k, &large)
+		scalarBaseMultNonConstSmall(k, &small)
+		large.ToAffine()
+		small.ToAffine()
+		if !large.IsStrictlyEqual(&small) {
+			t.Fatalf("mismatched results for scalar %v\nlarge: (%v, %v)\n"+
+				"small: (%v, %v)", k, large.X, large.Y, small.X, small.Y)
+		}
+	}
+}
+
 // modNBitLen returns the minimum number of bits required to represent the mod n
 // scalar.  The result is 0 when the value is 0.
 func modNBitLen(s *ModNScalar) uint16 {
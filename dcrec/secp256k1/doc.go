@@ -32,6 +32,8 @@ An overview of the features provided by this package are as follows:
   - Scalar multiplication with an arbitrary point
   - Scalar multiplication with the base point (group generator)
   - Point decompression from a given x coordinate
+  - Shared secret derivation via ECDH with optional hashing of the shared point
+    and ephemeral key generation
   - Nonce generation via RFC6979 with support for extra data and version
     information that can be used to prevent nonce reuse between signing
     algorithms
@@ -50,6 +52,15 @@ use optimized secp256k1 elliptic curve cryptography.
 Finally, a comprehensive suite of tests is provided to provide a high level of
 quality assurance.
 
+# Pre-computed Table Size
+
+Scalar multiplication with the base point is accelerated by a table of
+pre-computed points that is loaded on first use.  By default, a table that
+requires roughly 1 MiB of memory is used since it provides the best performance.
+Memory-constrained devices may instead build with the secp256k1_smallprecomps
+build tag to select a table that requires roughly 120 KiB of memory in exchange
+for scalar base multiplication taking nearly twice as long.
+
 # Use of secp256k1 in Decred
 
 At the time of this writing, the primary public key cryptography in widespread
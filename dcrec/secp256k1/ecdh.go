@@ -5,6 +5,12 @@
 
 package secp256k1
 
+import "crypto/sha256"
+
+// SharedSecretSize is the size in bytes of the hashed shared secrets produced
+// by HashedSharedSecret and GenerateEphemeralSharedSecret.
+const SharedSecretSize = sha256.Size
+
 // GenerateSharedSecret generates a shared secret based on a private key and a
 // public key using Diffie-Hellman key exchange (ECDH) (RFC 5903).
 // RFC5903 Section 9 states we should only return x.
@@ -19,3 +25,55 @@ func GenerateSharedSecret(privkey *PrivateKey, pubkey *PublicKey) []byte {
 	xBytes := result.X.Bytes()
 	return xBytes[:]
 }
+
+// HashedSharedSecret generates a shared secret based on a private key and a
+// public key using Diffie-Hellman key exchange (ECDH) and returns the SHA-256
+// hash of the compressed serialization of the resulting shared point.  This is
+// the same derivation as the default hash function of the ECDH module in
+// libsecp256k1, so the results are interoperable with it.
+//
+// Unlike GenerateSharedSecret, which returns the raw x coordinate of the shared
+// point, the result is suitable for direct use as a symmetric key.  Further,
+// an error is returned when the private key is zero or the public key is not on
+// the curve rather than deriving a secret from them since either case can
+// result in a secret that is known to an attacker.
+func HashedSharedSecret(privKey *PrivateKey, pubKey *PublicKey) ([SharedSecretSize]byte, error) {
+	if privKey.Key.IsZero() {
+		str := "private key is zero"
+		return [SharedSecretSize]byte{}, makeError(ErrPrivKeyZero, str)
+	}
+	if !pubKey.IsOnCurve() {
+		str := "public key is not on the secp256k1 curve"
+		return [SharedSecretSize]byte{}, makeError(ErrPubKeyNotOnCurve, str)
+	}
+
+	var point, result JacobianPoint
+	pubKey.AsJacobian(&point)
+	ScalarMultNonConst(&privKey.Key, &point, &result)
+	result.ToAffine()
+	sharedPubKey := NewPublicKey(&result.X, &result.Y)
+	return sha256.Sum256(sharedPubKey.SerializeCompressed()), nil
+}
+
+// GenerateEphemeralSharedSecret generates a new ephemeral private key and uses
+// it to derive a shared secret with the provided public key per
+// HashedSharedSecret.  It returns the public key that corresponds to the
+// ephemeral private key along with the shared secret.
+//
+// The returned ephemeral public key must be provided to the owner of the
+// private key for the provided public key so they are able to derive the same
+// shared secret via HashedSharedSecret.  The ephemeral private key is zeroed
+// before returning since it is not needed after deriving the secret.
+func GenerateEphemeralSharedSecret(pubKey *PublicKey) (*PublicKey, [SharedSecretSize]byte, error) {
+	ephemeralPrivKey, err := GeneratePrivateKey()
+	if err != nil {
+		return nil, [SharedSecretSize]byte{}, err
+	}
+	defer ephemeralPrivKey.Zero()
+
+	secret, err := HashedSharedSecret(ephemeralPrivKey, pubKey)
+	if err != nil {
+		return nil, [SharedSecretSize]byte{}, err
+	}
+	return ephemeralPrivKey.PubKey(), secret, nil
+}
@@ -7,6 +7,8 @@ package secp256k1
 
 import (
 	"bytes"
+	"encoding/hex"
+	"errors"
 	"testing"
 )
 
@@ -31,3 +33,89 @@ func TestGenerateSharedSecret(t *testing.T) {
 			secret1, secret2)
 	}
 }
+
+// TestHashedSharedSecret ensures hashed shared secrets are derived as expected
+// for known test vectors, that both parties derive the same secret, and that
+// invalid keys are rejected.
+func TestHashedSharedSecret(t *testing.T) {
+	tests := []struct {
+		name    string // test description
+		privKey string // hex encoded private key
+		pubKey  string // hex encoded public key of the other party
+		want    string // expected hex encoded shared secret
+	}{{
+		name:    "private key one with generator",
+		privKey: "0000000000000000000000000000000000000000000000000000000000000001",
+		pubKey:  "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+		want:    "0f715baf5d4c2ed329785cef29e562f73488c8a2bb9dbc5700b361d54b9b0554",
+	}, {
+		name:    "small private key",
+		privKey: "0000000000000000000000000000000000000000000000000000a2f3d9c1e0b2",
+		pubKey:  "03f973a0b87062c389d125d8199e803b832b6ac6bf7867a4f6cd87506060fc4c58",
+		want:    "1fa7799adb4891379dd0596b11fa4fc971341a2d9b736bbca7897885286bba73",
+	}, {
+		name:    "large private key with negated generator",
+		privKey: "eb7fa1b3c5d7e9f10213243546576879808a9bacbdcedf0f1e2d3c4b5a69788f",
+		pubKey:  "0379be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+		want:    "2fd867160eef21a6e42783e15b309fcda46281bc097dc89f35166c2d40858c7c",
+	}}
+
+	for _, test := range tests {
+		privKey := PrivKeyFromBytes(hexToBytes(test.privKey))
+		pubKey, err := ParsePubKey(hexToBytes(test.pubKey))
+		if err != nil {
+			t.Errorf("%q: unexpected error parsing public key: %v", test.name,
+				err)
+			continue
+		}
+		secret, err := HashedSharedSecret(privKey, pubKey)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.name, err)
+			continue
+		}
+		if got := hex.EncodeToString(secret[:]); got != test.want {
+			t.Errorf("%q: mismatched secret -- got %s, want %s", test.name,
+				got, test.want)
+		}
+	}
+
+	// Ensure both parties derive the same secret when using an ephemeral key.
+	privKey, err := GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("private key generation error: %v", err)
+	}
+	ephemeralPubKey, secret1, err := GenerateEphemeralSharedSecret(privKey.PubKey())
+	if err != nil {
+		t.Fatalf("unexpected error generating ephemeral secret: %v", err)
+	}
+	secret2, err := HashedSharedSecret(privKey, ephemeralPubKey)
+	if err != nil {
+		t.Fatalf("unexpected error deriving secret: %v", err)
+	}
+	if secret1 != secret2 {
+		t.Fatalf("ECDH failed, secrets mismatch - first: %x, second: %x",
+			secret1, secret2)
+	}
+
+	// Ensure a zero private key is rejected.
+	var zeroPrivKey PrivateKey
+	_, err = HashedSharedSecret(&zeroPrivKey, privKey.PubKey())
+	if !errors.Is(err, ErrPrivKeyZero) {
+		t.Fatalf("unexpected error -- got %v, want %v", err, ErrPrivKeyZero)
+	}
+
+	// Ensure a public key that is not on the curve is rejected.
+	var x, y FieldVal
+	x.SetInt(1)
+	y.SetInt(1)
+	_, err = HashedSharedSecret(privKey, NewPublicKey(&x, &y))
+	if !errors.Is(err, ErrPubKeyNotOnCurve) {
+		t.Fatalf("unexpected error -- got %v, want %v", err,
+			ErrPubKeyNotOnCurve)
+	}
+	_, _, err = GenerateEphemeralSharedSecret(NewPublicKey(&x, &y))
+	if !errors.Is(err, ErrPubKeyNotOnCurve) {
+		t.Fatalf("unexpected error -- got %v, want %v", err,
+			ErrPubKeyNotOnCurve)
+	}
+}
@@ -658,8 +658,9 @@ func sign(privKey, nonce *secp256k1.ModNScalar, hash []byte) (*Signature, byte,
 
 // signRFC6979 generates a deterministic ECDSA signature according to RFC 6979
 // and BIP0062 and returns it along with an additional public key recovery code
-// for efficiently recovering the public key from the signature.
-func signRFC6979(privKey *secp256k1.PrivateKey, hash []byte) (*Signature, byte) {
+// for efficiently recovering the public key from the signature.  The optional
+// extra data is mixed into the nonce generation when it is 32 bytes.
+func signRFC6979(privKey *secp256k1.PrivateKey, hash, extraData []byte) (*Signature, byte) {
 	// The algorithm for producing an ECDSA signature is given as algorithm 4.29
 	// in [GECC].
 	//
@@ -685,7 +686,8 @@ func signRFC6979(privKey *secp256k1.PrivateKey, hash []byte) (*Signature, byte)
 	//
 	// A. Instead of selecting a random nonce in step 1, use RFC6979 to generate
 	//    a deterministic nonce in [1, N-1] parameterized by the private key,
-	//    message being signed, and an iteration count for the repeat cases
+	//    message being signed, optional extra data, and an iteration count for
+	//    the repeat cases
 	// B. Negate s calculated in step 5 if it is > N/2
 	//    This is done because both s and its negation are valid signatures
 	//    modulo the curve order N, so it forces a consistent choice to reduce
@@ -699,8 +701,9 @@ func signRFC6979(privKey *secp256k1.PrivateKey, hash []byte) (*Signature, byte)
 		// Step 1 with modification A.
 		//
 		// Generate a deterministic nonce in [1, N-1] parameterized by the
-		// private key, message being signed, and iteration count.
-		k := secp256k1.NonceRFC6979(privKeyBytes[:], hash, nil, nil, iteration)
+		// private key, message being signed, extra data, and iteration count.
+		k := secp256k1.NonceRFC6979(privKeyBytes[:], hash, extraData, nil,
+			iteration)
 
 		// Steps 2-6.
 		sig, pubKeyRecoveryCode, success := sign(privKeyScalar, k, hash)
@@ -719,7 +722,23 @@ func signRFC6979(privKey *secp256k1.PrivateKey, hash []byte) (*Signature, byte)
 // key yield the same signature) and canonical in accordance with RFC6979 and
 // BIP0062.
 func Sign(key *secp256k1.PrivateKey, hash []byte) *Signature {
-	signature, _ := signRFC6979(key, hash)
+	signature, _ := signRFC6979(key, hash, nil)
+	return signature
+}
+
+// SignWithExtraEntropy generates an ECDSA signature over the secp256k1 curve
+// for the provided hash (which should be the result of hashing a larger
+// message) using the given private key in the same way as Sign with the
+// addition of mixing the provided extra entropy into the RFC6979 nonce
+// generation as described by section 3.6 of the RFC.
+//
+// The extra entropy must be 32 bytes or it is ignored, in which case the
+// produced signature is identical to the one produced by Sign.  Otherwise, the
+// produced signature is still deterministic for a given key, message, and
+// extra entropy and canonical in accordance with BIP0062, but differs from the
+// one produced by Sign.
+func SignWithExtraEntropy(key *secp256k1.PrivateKey, hash, extraEntropy []byte) *Signature {
+	signature, _ := signRFC6979(key, hash, extraEntropy)
 	return signature
 }
 
@@ -765,7 +784,7 @@ const (
 func SignCompact(key *secp256k1.PrivateKey, hash []byte, isCompressedKey bool) []byte {
 	// Create the signature and associated pubkey recovery code and calculate
 	// the compact signature recovery code.
-	sig, pubKeyRecoveryCode := signRFC6979(key, hash)
+	sig, pubKeyRecoveryCode := signRFC6979(key, hash, nil)
 	compactSigRecoveryCode := compactSigMagicOffset + pubKeyRecoveryCode
 	if isCompressedKey {
 		compactSigRecoveryCode += compactSigCompPubKey
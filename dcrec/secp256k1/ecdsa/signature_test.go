@@ -647,6 +647,84 @@ func TestSignAndVerifyRandom(t *testing.T) {
 	}
 }
 
+// TestSignWithExtraEntropy ensures signing with extra entropy mixes the entropy
+// into the RFC6979 nonce when it is 32 bytes and otherwise produces the same
+// signature as signing without it.
+func TestSignWithExtraEntropy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string // test description
+		key      string // hex encoded private key
+		hash     string // hex encoded hash of the message to sign
+		extra    string // hex encoded extra entropy
+		wantSign bool   // whether the signature is the same as from Sign
+	}{{
+		name:     "no extra entropy",
+		key:      "0000000000000000000000000000000000000000000000000000000000000001",
+		hash:     "c301ba9de5d6053caad9f5eb46523f007702add2c62fa39de03146a36b8026b7",
+		extra:    "",
+		wantSign: true,
+	}, {
+		name:     "extra entropy with invalid length is ignored",
+		key:      "0000000000000000000000000000000000000000000000000000000000000001",
+		hash:     "c301ba9de5d6053caad9f5eb46523f007702add2c62fa39de03146a36b8026b7",
+		extra:    "0102030405060708090a0b0c0d0e0f10",
+		wantSign: true,
+	}, {
+		name:     "32-byte extra entropy",
+		key:      "0000000000000000000000000000000000000000000000000000000000000001",
+		hash:     "c301ba9de5d6053caad9f5eb46523f007702add2c62fa39de03146a36b8026b7",
+		extra:    "0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20",
+		wantSign: false,
+	}, {
+		name:     "32-byte extra entropy with another key",
+		key:      "a8d3f21c4bd5e4c2b6b7c2d6fe6e7c9c8f0b2f1e8de4b1cd4ac3ccf1d17b3b11",
+		hash:     "393bec84f1a04037751c0d6c2817f37953eaa204ac0898de7adb038c33a20438",
+		extra:    "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		wantSign: false,
+	}}
+
+	for _, test := range tests {
+		privKey := secp256k1.NewPrivateKey(hexToModNScalar(test.key))
+		hash := hexToBytes(test.hash)
+		extra := hexToBytes(test.extra)
+
+		// Determine the expected signature by generating the nonce with the
+		// extra entropy mixed in directly.
+		var privKeyBytes [32]byte
+		privKey.Key.PutBytes(&privKeyBytes)
+		nonce := secp256k1.NonceRFC6979(privKeyBytes[:], hash, extra, nil, 0)
+		wantSig, _, success := sign(&privKey.Key, nonce, hash)
+		if !success {
+			t.Errorf("%s: unexpected error when signing", test.name)
+			continue
+		}
+
+		// Ensure the signature is the expected value and verifies.
+		gotSig := SignWithExtraEntropy(privKey, hash, extra)
+		if !gotSig.IsEqual(wantSig) {
+			t.Errorf("%s: unexpected signature -- got %x, want %x", test.name,
+				gotSig.Serialize(), wantSig.Serialize())
+			continue
+		}
+		if !gotSig.Verify(hash, privKey.PubKey()) {
+			t.Errorf("%s: signature failed to verify", test.name)
+			continue
+		}
+
+		// Ensure the signature only matches the one produced without extra
+		// entropy when expected.
+		isSameAsSign := gotSig.IsEqual(Sign(privKey, hash))
+		if isSameAsSign != test.wantSign {
+			t.Errorf("%s: unexpected match with signature without extra "+
+				"entropy -- got %v, want %v", test.name, isSameAsSign,
+				test.wantSign)
+			continue
+		}
+	}
+}
+
 // TestSignFailures ensures the internal ECDSA signing function returns an
 // unsuccessful result when particular combinations of values are unable to
 // produce a valid signature.
@@ -35,6 +35,10 @@ const (
 	// an oddness of the y coordinate that does not match the actual oddness of
 	// the provided y coordinate.
 	ErrPubKeyMismatchedOddness = ErrorKind("ErrPubKeyMismatchedOddness")
+
+	// ErrPrivKeyZero indicates an attempt was made to derive a shared secret
+	// with a private key that is zero.
+	ErrPrivKeyZero = ErrorKind("ErrPrivKeyZero")
 )
 
 // Error satisfies the error interface and prints human-readable errors.
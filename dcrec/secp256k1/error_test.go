@@ -21,6 +21,7 @@ func TestErrorKindStringer(t *testing.T) {
 		{ErrPubKeyYTooBig, "ErrPubKeyYTooBig"},
 		{ErrPubKeyNotOnCurve, "ErrPubKeyNotOnCurve"},
 		{ErrPubKeyMismatchedOddness, "ErrPubKeyMismatchedOddness"},
+		{ErrPrivKeyZero, "ErrPrivKeyZero"},
 	}
 
 	for i, test := range tests {
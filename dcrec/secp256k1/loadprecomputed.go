@@ -24,40 +24,58 @@ type bytePointTable [32][256]JacobianPoint
 // multiplication.
 var compressedBytePointsFn func() string
 
+// nibblePointTable describes a smaller table used to house pre-computed values
+// for accelerating scalar base multiplication at the cost of performing twice
+// as many point additions as when using a bytePointTable.
+type nibblePointTable [64][16]JacobianPoint
+
+// mustDecompressBytePoints decompresses and returns the serialized pre-computed
+// byte points used to accelerate scalar base multiplication for the secp256k1
+// curve.  The serialized data consists of the 32-byte big-endian X and Y
+// coordinates of all 256 affine points for each of the 32 8-bit windows.
+//
+// This approach is used since it allows the compile to use significantly less
+// ram and be performed much faster than it is with hard-coding the final
+// in-memory data structure.  At the same time, it is quite fast to generate the
+// in-memory data structure on first use with this approach versus computing
+// the table.
+//
+// It returns nil when there are no byte points to load, which is the case when
+// generating them.  It will panic on any errors because the data is hard coded
+// and thus any errors means something is wrong in the source code.
+func mustDecompressBytePoints() []byte {
+	// There will be no byte points to load when generating them.
+	if compressedBytePointsFn == nil {
+		return nil
+	}
+	bp := compressedBytePointsFn()
+
+	// Decompress the pre-computed table used to accelerate scalar base
+	// multiplication.
+	decoder := base64.NewDecoder(base64.StdEncoding, strings.NewReader(bp))
+	r, err := zlib.NewReader(decoder)
+	if err != nil {
+		panic(err)
+	}
+	serialized, err := io.ReadAll(r)
+	if err != nil {
+		panic(err)
+	}
+	return serialized
+}
+
 // s256BytePoints houses pre-computed values used to accelerate scalar base
 // multiplication such that they are only loaded on first use.
 var s256BytePoints = func() func() *bytePointTable {
 	// mustLoadBytePoints decompresses and deserializes the pre-computed byte
 	// points used to accelerate scalar base multiplication for the secp256k1
 	// curve.
-	//
-	// This approach is used since it allows the compile to use significantly
-	// less ram and be performed much faster than it is with hard-coding the
-	// final in-memory data structure.  At the same time, it is quite fast to
-	// generate the in-memory data structure on first use with this approach
-	// versus computing the table.
-	//
-	// It will panic on any errors because the data is hard coded and thus any
-	// errors means something is wrong in the source code.
 	var data *bytePointTable
 	mustLoadBytePoints := func() {
-		// There will be no byte points to load when generating them.
-		if compressedBytePointsFn == nil {
+		serialized := mustDecompressBytePoints()
+		if serialized == nil {
 			return
 		}
-		bp := compressedBytePointsFn()
-
-		// Decompress the pre-computed table used to accelerate scalar base
-		// multiplication.
-		decoder := base64.NewDecoder(base64.StdEncoding, strings.NewReader(bp))
-		r, err := zlib.NewReader(decoder)
-		if err != nil {
-			panic(err)
-		}
-		serialized, err := io.ReadAll(r)
-		if err != nil {
-			panic(err)
-		}
 
 		// Deserialize the precomputed byte points and set the memory table to
 		// them.
@@ -89,3 +107,58 @@ var s256BytePoints = func() func() *bytePointTable {
 		return data
 	}
 }()
+
+// s256NibblePoints houses pre-computed values used to accelerate scalar base
+// multiplication when the small pre-computed table mode is selected such that
+// they are only loaded on first use.
+//
+// The table requires roughly an eighth of the memory of the byte points table
+// since it only houses the 16 points for each 4-bit window.
+var s256NibblePoints = func() func() *nibblePointTable {
+	// mustLoadNibblePoints derives the pre-computed nibble points used to
+	// accelerate scalar base multiplication for the secp256k1 curve from the
+	// serialized byte points.
+	//
+	// Each 8-bit window of the byte points contains all multiples of the
+	// window base point by the values 0-255.  Thus, the points for the low
+	// 4-bit window of each byte are the first 16 points of the window, while
+	// the points for the high 4-bit window are every 16th point.
+	var data *nibblePointTable
+	mustLoadNibblePoints := func() {
+		serialized := mustDecompressBytePoints()
+		if serialized == nil {
+			return
+		}
+
+		// Deserialize the relevant precomputed byte points and set the memory
+		// table to them.  Note that the windows are in big-endian order to
+		// match the byte points, so the high nibble window of each byte comes
+		// first.
+		const pointSize = 64
+		const windowSize = 256 * pointSize
+		var nibblePoints nibblePointTable
+		deserializePoint := func(offset int, p *JacobianPoint) {
+			p.X.SetByteSlice(serialized[offset : offset+32])
+			p.Y.SetByteSlice(serialized[offset+32 : offset+64])
+			p.Z.SetInt(1)
+		}
+		for byteNum := 0; byteNum < len(nibblePoints)/2; byteNum++ {
+			windowOffset := byteNum * windowSize
+			highPoints := &nibblePoints[byteNum*2]
+			lowPoints := &nibblePoints[byteNum*2+1]
+			for i := 0; i < len(highPoints); i++ {
+				deserializePoint(windowOffset+(i<<4)*pointSize, &highPoints[i])
+				deserializePoint(windowOffset+i*pointSize, &lowPoints[i])
+			}
+		}
+		data = &nibblePoints
+	}
+
+	// Return a closure that initializes the data on first access for the same
+	// reasons as the byte points.
+	var loadNibblePointsOnce sync.Once
+	return func() *nibblePointTable {
+		loadNibblePointsOnce.Do(mustLoadNibblePoints)
+		return data
+	}
+}()
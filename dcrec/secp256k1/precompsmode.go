@@ -0,0 +1,13 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+//go:build !secp256k1_smallprecomps
+// +build !secp256k1_smallprecomps
+
+package secp256k1
+
+// useSmallPrecomps indicates whether or not scalar base multiplication uses the
+// small pre-computed table that trades performance for a reduced memory
+// footprint.  It is selected via the secp256k1_smallprecomps build tag.
+const useSmallPrecomps = false
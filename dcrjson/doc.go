@@ -126,6 +126,16 @@ The specific reason for the error can be detected by type asserting it to a
 
 The second category of errors (type RPCError), on the other hand, are useful for
 returning errors to RPC clients.  Consequently, they are used in the previously
-described Response type.
+described Response type.  They may optionally carry additional machine-readable
+details about the error via the Data field so clients do not need to rely on
+the human-readable message.
+
+The RPC error codes (type RPCErrorCode) defined by this package are guaranteed
+to remain stable across releases, so clients may reliably switch on the Code
+field of an RPCError.  Several command-specific codes share the value of the
+more general code they are a subset of, such as ErrRPCBlockNotFound and
+ErrRPCInvalidAddressOrKey.  The String method of a code returns the name of its
+general category and the IsKnown method reports whether or not it is one of the
+stable codes.
 */
 package dcrjson
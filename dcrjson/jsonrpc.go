@@ -18,9 +18,13 @@ type RPCErrorCode int
 
 // RPCError represents an error that is used as a part of a JSON-RPC Response
 // object.
+//
+// The optional Data field may be used to provide additional machine-readable
+// information about the error and is omitted when nil.
 type RPCError struct {
 	Code    RPCErrorCode `json:"code,omitempty"`
 	Message string       `json:"message,omitempty"`
+	Data    interface{}  `json:"data,omitempty"`
 }
 
 // Guarantee RPCError satisfies the builtin error interface.
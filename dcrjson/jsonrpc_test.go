@@ -1,5 +1,5 @@
 // Copyright (c) 2014 The btcsuite developers
-// Copyright (c) 2015-2020 The Decred developers
+// Copyright (c) 2015-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -76,6 +76,16 @@ func TestMarshalResponse(t *testing.T) {
 			}(),
 			expected: []byte(`{"jsonrpc":"1.0","result":null,"error":{"code":-5,"message":"123 not found"},"id":1}`),
 		},
+		{
+			name:   "result with error and data",
+			result: nil,
+			jsonErr: func() *RPCError {
+				err := NewRPCError(ErrRPCMisc, "rejected")
+				err.Data = map[string]string{"rejectcode": "dust"}
+				return err
+			}(),
+			expected: []byte(`{"jsonrpc":"1.0","result":null,"error":{"code":-1,"message":"rejected","data":{"rejectcode":"dust"}},"id":1}`),
+		},
 	}
 
 	t.Logf("Running %d tests", len(tests))
@@ -155,3 +165,65 @@ func TestRPCError(t *testing.T) {
 		}
 	}
 }
+
+// TestRPCErrorCodeStringer tests the stringized output for the RPCErrorCode
+// type along with whether or not the codes are part of the registry of stable
+// codes.
+func TestRPCErrorCodeStringer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in    RPCErrorCode
+		want  string
+		known bool
+	}{
+		{ErrRPCInvalidRequest.Code, "ErrRPCInvalidRequest", true},
+		{ErrRPCMethodNotFound.Code, "ErrRPCMethodNotFound", true},
+		{ErrRPCInvalidParams.Code, "ErrRPCInvalidParams", true},
+		{ErrRPCInternal.Code, "ErrRPCInternal", true},
+		{ErrRPCParse.Code, "ErrRPCParse", true},
+		{ErrRPCMisc, "ErrRPCMisc", true},
+		{ErrRPCForbiddenBySafeMode, "ErrRPCForbiddenBySafeMode", true},
+		{ErrRPCType, "ErrRPCType", true},
+		{ErrRPCInvalidAddressOrKey, "ErrRPCInvalidAddressOrKey", true},
+		{ErrRPCOutOfMemory, "ErrRPCOutOfMemory", true},
+		{ErrRPCInvalidParameter, "ErrRPCInvalidParameter", true},
+		{ErrRPCDatabase, "ErrRPCDatabase", true},
+		{ErrRPCDeserialization, "ErrRPCDeserialization", true},
+		{ErrRPCVerify, "ErrRPCVerify", true},
+		{ErrRPCClientNotConnected, "ErrRPCClientNotConnected", true},
+		{ErrRPCClientInInitialDownload, "ErrRPCClientInInitialDownload", true},
+		{ErrRPCWallet, "ErrRPCWallet", true},
+		{ErrRPCWalletInsufficientFunds, "ErrRPCWalletInsufficientFunds", true},
+		{ErrRPCWalletInvalidAccountName, "ErrRPCWalletInvalidAccountName", true},
+		{ErrRPCWalletKeypoolRanOut, "ErrRPCWalletKeypoolRanOut", true},
+		{ErrRPCWalletUnlockNeeded, "ErrRPCWalletUnlockNeeded", true},
+		{ErrRPCWalletPassphraseIncorrect, "ErrRPCWalletPassphraseIncorrect", true},
+		{ErrRPCWalletWrongEncState, "ErrRPCWalletWrongEncState", true},
+		{ErrRPCWalletEncryptionFailed, "ErrRPCWalletEncryptionFailed", true},
+		{ErrRPCWalletAlreadyUnlocked, "ErrRPCWalletAlreadyUnlocked", true},
+		{ErrRPCDuplicateTx, "ErrRPCDuplicateTx", true},
+		{ErrRPCReconsiderFailure, "ErrRPCReconsiderFailure", true},
+
+		// Command-specific codes are reported as their general category.
+		{ErrRPCBlockNotFound, "ErrRPCInvalidAddressOrKey", true},
+		{ErrRPCOutOfRange, "ErrRPCMisc", true},
+		{ErrRPCRawTxString, "ErrRPCInvalidParams", true},
+		{ErrRPCDecodeHexString, "ErrRPCDeserialization", true},
+		{ErrRPCUnimplemented, "ErrRPCMisc", true},
+
+		{-0xdcd, "Unknown RPCErrorCode (-3533)", false},
+	}
+
+	for i, test := range tests {
+		result := test.in.String()
+		if result != test.want {
+			t.Errorf("%d: got: %s want: %s", i, result, test.want)
+			continue
+		}
+		if known := test.in.IsKnown(); known != test.known {
+			t.Errorf("%d: got known: %v want: %v", i, known, test.known)
+			continue
+		}
+	}
+}
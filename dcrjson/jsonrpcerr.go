@@ -1,10 +1,18 @@
 // Copyright (c) 2014 The btcsuite developers
-// Copyright (c) 2015-2020 The Decred developers
+// Copyright (c) 2015-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
 package dcrjson
 
+import "fmt"
+
+// The RPC error codes defined in this file are guaranteed to remain stable
+// across releases so clients are able to reliably switch on them.  New codes
+// may be added, but existing codes will never be changed or reused for a
+// different purpose.  Several of the command-specific codes intentionally share
+// the value of the more general code they are a subset of.
+
 // Standard JSON-RPC 2.0 errors.
 var (
 	ErrRPCInvalidRequest = &RPCError{
@@ -85,3 +93,58 @@ const (
 	ErrRPCNoWallet      RPCErrorCode = -1
 	ErrRPCUnimplemented RPCErrorCode = -1
 )
+
+// rpcErrorCodeStrings is a registry of all stable RPC error codes mapped to the
+// name of the constant that defines the general category they belong to for
+// pretty printing.  Command-specific codes that share the value of a general
+// code are reported as the general code.
+var rpcErrorCodeStrings = map[RPCErrorCode]string{
+	ErrRPCInvalidRequest.Code: "ErrRPCInvalidRequest",
+	ErrRPCMethodNotFound.Code: "ErrRPCMethodNotFound",
+	ErrRPCInvalidParams.Code:  "ErrRPCInvalidParams",
+	ErrRPCInternal.Code:       "ErrRPCInternal",
+	ErrRPCParse.Code:          "ErrRPCParse",
+
+	ErrRPCMisc:                "ErrRPCMisc",
+	ErrRPCForbiddenBySafeMode: "ErrRPCForbiddenBySafeMode",
+	ErrRPCType:                "ErrRPCType",
+	ErrRPCInvalidAddressOrKey: "ErrRPCInvalidAddressOrKey",
+	ErrRPCOutOfMemory:         "ErrRPCOutOfMemory",
+	ErrRPCInvalidParameter:    "ErrRPCInvalidParameter",
+	ErrRPCDatabase:            "ErrRPCDatabase",
+	ErrRPCDeserialization:     "ErrRPCDeserialization",
+	ErrRPCVerify:              "ErrRPCVerify",
+
+	ErrRPCClientNotConnected:      "ErrRPCClientNotConnected",
+	ErrRPCClientInInitialDownload: "ErrRPCClientInInitialDownload",
+
+	ErrRPCWallet:                    "ErrRPCWallet",
+	ErrRPCWalletInsufficientFunds:   "ErrRPCWalletInsufficientFunds",
+	ErrRPCWalletInvalidAccountName:  "ErrRPCWalletInvalidAccountName",
+	ErrRPCWalletKeypoolRanOut:       "ErrRPCWalletKeypoolRanOut",
+	ErrRPCWalletUnlockNeeded:        "ErrRPCWalletUnlockNeeded",
+	ErrRPCWalletPassphraseIncorrect: "ErrRPCWalletPassphraseIncorrect",
+	ErrRPCWalletWrongEncState:       "ErrRPCWalletWrongEncState",
+	ErrRPCWalletEncryptionFailed:    "ErrRPCWalletEncryptionFailed",
+	ErrRPCWalletAlreadyUnlocked:     "ErrRPCWalletAlreadyUnlocked",
+
+	ErrRPCDuplicateTx:       "ErrRPCDuplicateTx",
+	ErrRPCReconsiderFailure: "ErrRPCReconsiderFailure",
+}
+
+// String returns the name of the constant that defines the general category
+// of the RPC error code.  Codes that are not part of the registry of stable
+// codes are reported as unknown along with their numeric value.
+func (e RPCErrorCode) String() string {
+	if s := rpcErrorCodeStrings[e]; s != "" {
+		return s
+	}
+	return fmt.Sprintf("Unknown RPCErrorCode (%d)", int(e))
+}
+
+// IsKnown returns whether or not the RPC error code is part of the registry of
+// stable codes.
+func (e RPCErrorCode) IsKnown() bool {
+	_, ok := rpcErrorCodeStrings[e]
+	return ok
+}
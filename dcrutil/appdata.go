@@ -6,6 +6,8 @@
 package dcrutil
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -14,6 +16,25 @@ import (
 	"unicode"
 )
 
+// userHomeDir returns the home directory of the current user or an empty
+// string when it can't be determined.
+func userHomeDir() string {
+	// Get the OS specific home directory via the Go standard lib.
+	var homeDir string
+	usr, err := user.Current()
+	if err == nil {
+		homeDir = usr.HomeDir
+	}
+
+	// Fall back to standard HOME environment variable that works
+	// for most POSIX OSes if the directory from the Go standard
+	// lib failed.
+	if err != nil || homeDir == "" {
+		homeDir = os.Getenv("HOME")
+	}
+	return homeDir
+}
+
 // appDataDir returns an operating system specific directory to be used for
 // storing application data for an application.  See AppDataDir for more
 // details.  This unexported version takes an operating system argument
@@ -30,20 +51,7 @@ func appDataDir(goos, appName string, roaming bool) string {
 	appNameUpper := string(unicode.ToUpper(rune(appName[0]))) + appName[1:]
 	appNameLower := string(unicode.ToLower(rune(appName[0]))) + appName[1:]
 
-	// Get the OS specific home directory via the Go standard lib.
-	var homeDir string
-	usr, err := user.Current()
-	if err == nil {
-		homeDir = usr.HomeDir
-	}
-
-	// Fall back to standard HOME environment variable that works
-	// for most POSIX OSes if the directory from the Go standard
-	// lib failed.
-	if err != nil || homeDir == "" {
-		homeDir = os.Getenv("HOME")
-	}
-
+	homeDir := userHomeDir()
 	switch goos {
 	// Attempt to use the LOCALAPPDATA or APPDATA environment variable on
 	// Windows.
@@ -105,3 +113,184 @@ func appDataDir(goos, appName string, roaming bool) string {
 func AppDataDir(appName string, roaming bool) string {
 	return appDataDir(runtime.GOOS, appName, roaming)
 }
+
+// AppDirs houses the directories to be used for storing the configuration and
+// data for an application.
+type AppDirs struct {
+	// Config is the directory for the application configuration file.
+	Config string
+
+	// Data is the directory for the application data such as the databases
+	// and logs.
+	Data string
+}
+
+// usesXDGDirs returns whether or not the XDG base directory specification
+// applies to the provided operating system.
+func usesXDGDirs(goos string) bool {
+	switch goos {
+	case "windows", "darwin", "plan9", "ios", "android":
+		return false
+	}
+	return true
+}
+
+// xdgBaseDir returns the base directory specified by the provided XDG
+// environment variable or the given default relative to the home directory
+// when it is not set.  Per the specification, relative paths in the
+// environment variables are invalid and therefore ignored.
+func xdgBaseDir(getenv func(string) string, envVar, homeDir, defaultDir string) string {
+	if dir := getenv(envVar); dir != "" && filepath.IsAbs(dir) {
+		return dir
+	}
+	if homeDir == "" {
+		return ""
+	}
+	return filepath.Join(homeDir, defaultDir)
+}
+
+// xdgAppDirs returns the operating system specific directories to be used for
+// storing the configuration and data for an application.  See XDGAppDirs for
+// more details.  This unexported version takes an operating system argument
+// and a function to look up environment variables primarily to enable the
+// testing package to properly test the function.
+func xdgAppDirs(goos string, getenv func(string) string, appName string, roaming bool) AppDirs {
+	if appName == "" || appName == "." || !usesXDGDirs(goos) {
+		dir := appDataDir(goos, appName, roaming)
+		return AppDirs{Config: dir, Data: dir}
+	}
+
+	appName = strings.TrimPrefix(appName, ".")
+	appNameLower := string(unicode.ToLower(rune(appName[0]))) + appName[1:]
+
+	homeDir := userHomeDir()
+	configHome := xdgBaseDir(getenv, "XDG_CONFIG_HOME", homeDir, ".config")
+	dataHome := xdgBaseDir(getenv, "XDG_DATA_HOME", homeDir,
+		filepath.Join(".local", "share"))
+
+	// Fall back to the current directory if all else fails.
+	dirs := AppDirs{Config: ".", Data: "."}
+	if configHome != "" {
+		dirs.Config = filepath.Join(configHome, appNameLower)
+	}
+	if dataHome != "" {
+		dirs.Data = filepath.Join(dataHome, appNameLower)
+	}
+	return dirs
+}
+
+// XDGAppDirs returns the operating system specific directories to be used for
+// storing the configuration and data for an application.
+//
+// On POSIX style operating systems other than Mac OS, the directories follow
+// the XDG base directory specification.  That is to say the application name
+// is joined to $XDG_CONFIG_HOME and $XDG_DATA_HOME, which default to
+// $HOME/.config and $HOME/.local/share, respectively, when they are not set to
+// absolute paths.  The first character of appName is made lowercase and any
+// leading period is stripped.
+//
+// All other operating systems, as well as an empty appName or one with a
+// single dot, use the same directory returned by AppDataDir for both the
+// configuration and data.
+//
+// Example results:
+//
+//	dirs := XDGAppDirs("myapp", false)
+//	 POSIX (Linux/BSD): Config: ~/.config/myapp
+//	                    Data:   ~/.local/share/myapp
+//	 Mac OS: $HOME/Library/Application Support/Myapp
+//	 Windows: %LOCALAPPDATA%\Myapp
+//	 Plan 9: $home/myapp
+//
+// See MigrateLegacyAppDataDir for moving existing data from the directory
+// returned by AppDataDir.
+func XDGAppDirs(appName string, roaming bool) AppDirs {
+	return xdgAppDirs(runtime.GOOS, os.Getenv, appName, roaming)
+}
+
+// fileExists reports whether the named file or directory exists.
+func fileExists(name string) (bool, error) {
+	_, err := os.Stat(name)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return false, err
+}
+
+// migrateAppDataDir moves the provided legacy application directory to the
+// given directories as described by MigrateLegacyAppDataDir.
+func migrateAppDataDir(legacyDir string, dirs AppDirs, configFileName string) (bool, error) {
+	if legacyDir == dirs.Data {
+		return false, nil
+	}
+
+	// Nothing to do when there is no legacy directory or the new data
+	// directory already exists since it must not be overwritten.
+	legacyExists, err := fileExists(legacyDir)
+	if err != nil || !legacyExists {
+		return false, err
+	}
+	dataExists, err := fileExists(dirs.Data)
+	if err != nil || dataExists {
+		return false, err
+	}
+
+	// Move the legacy directory to the new data directory.
+	if err := os.MkdirAll(filepath.Dir(dirs.Data), 0700); err != nil {
+		return false, err
+	}
+	if err := os.Rename(legacyDir, dirs.Data); err != nil {
+		return false, fmt.Errorf("unable to move %s to %s: %w", legacyDir,
+			dirs.Data, err)
+	}
+
+	// Move the configuration file to the new configuration directory when
+	// it is separate and does not already contain one.
+	if configFileName == "" || dirs.Config == dirs.Data {
+		return true, nil
+	}
+	oldConfigFile := filepath.Join(dirs.Data, configFileName)
+	newConfigFile := filepath.Join(dirs.Config, configFileName)
+	oldConfigExists, err := fileExists(oldConfigFile)
+	if err != nil || !oldConfigExists {
+		return true, err
+	}
+	newConfigExists, err := fileExists(newConfigFile)
+	if err != nil || newConfigExists {
+		return true, err
+	}
+	if err := os.MkdirAll(dirs.Config, 0700); err != nil {
+		return true, err
+	}
+	if err := os.Rename(oldConfigFile, newConfigFile); err != nil {
+		return true, fmt.Errorf("unable to move %s to %s: %w",
+			oldConfigFile, newConfigFile, err)
+	}
+	return true, nil
+}
+
+// MigrateLegacyAppDataDir moves the legacy application directory returned by
+// AppDataDir to the directories returned by XDGAppDirs so that applications
+// can transparently adopt the XDG base directory layout.
+//
+// The entire legacy directory is moved to the new data directory and then the
+// configuration file with the provided name, if any, is moved from it to the
+// new configuration directory.  An empty configFileName leaves all files in
+// the data directory.
+//
+// No changes are made when the directories are the same, which is the case on
+// operating systems that do not use the XDG layout, when the legacy directory
+// does not exist, or when the new data directory already exists.  This makes
+// it safe to call on every startup.
+//
+// The returned directories are the ones that should be used by the caller and
+// the returned flag indicates whether or not a migration took place.
+func MigrateLegacyAppDataDir(appName string, roaming bool, configFileName string) (AppDirs, bool, error) {
+	legacyDir := AppDataDir(appName, roaming)
+	dirs := XDGAppDirs(appName, roaming)
+	migrated, err := migrateAppDataDir(legacyDir, dirs, configFileName)
+	return dirs, migrated, err
+}
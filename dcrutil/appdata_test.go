@@ -130,3 +130,156 @@ func TestAppDataDir(t *testing.T) {
 		}
 	}
 }
+
+// TestXDGAppDirs ensures the XDG application directories are the expected
+// values for various operating systems and environment variables.
+func TestXDGAppDirs(t *testing.T) {
+	usr, err := user.Current()
+	if err != nil {
+		t.Fatalf("user.Current: %v", err)
+	}
+	homeDir := usr.HomeDir
+	absConfig := filepath.Join(homeDir, "xdgconfig")
+	absData := filepath.Join(homeDir, "xdgdata")
+	defaultConfig := filepath.Join(homeDir, ".config", "myapp")
+	defaultData := filepath.Join(homeDir, ".local", "share", "myapp")
+	macDir := appDataDir("darwin", "myapp", false)
+
+	tests := []struct {
+		name    string
+		goos    string
+		env     map[string]string
+		appName string
+		want    AppDirs
+	}{{
+		name:    "linux defaults",
+		goos:    "linux",
+		appName: "myapp",
+		want:    AppDirs{Config: defaultConfig, Data: defaultData},
+	}, {
+		name:    "freebsd defaults with uppercase and leading period",
+		goos:    "freebsd",
+		appName: ".Myapp",
+		want:    AppDirs{Config: defaultConfig, Data: defaultData},
+	}, {
+		name: "linux with absolute environment variables",
+		goos: "linux",
+		env: map[string]string{
+			"XDG_CONFIG_HOME": absConfig,
+			"XDG_DATA_HOME":   absData,
+		},
+		appName: "myapp",
+		want: AppDirs{
+			Config: filepath.Join(absConfig, "myapp"),
+			Data:   filepath.Join(absData, "myapp"),
+		},
+	}, {
+		name: "linux ignores relative environment variables",
+		goos: "linux",
+		env: map[string]string{
+			"XDG_CONFIG_HOME": "relconfig",
+			"XDG_DATA_HOME":   "reldata",
+		},
+		appName: "myapp",
+		want:    AppDirs{Config: defaultConfig, Data: defaultData},
+	}, {
+		name: "darwin ignores environment variables",
+		goos: "darwin",
+		env: map[string]string{
+			"XDG_CONFIG_HOME": absConfig,
+			"XDG_DATA_HOME":   absData,
+		},
+		appName: "myapp",
+		want:    AppDirs{Config: macDir, Data: macDir},
+	}, {
+		name:    "plan9 uses legacy directory",
+		goos:    "plan9",
+		appName: "myapp",
+		want: AppDirs{
+			Config: filepath.Join(homeDir, "myapp"),
+			Data:   filepath.Join(homeDir, "myapp"),
+		},
+	}, {
+		name:    "linux without application name",
+		goos:    "linux",
+		appName: "",
+		want:    AppDirs{Config: ".", Data: "."},
+	}}
+
+	for _, test := range tests {
+		getenv := func(key string) string { return test.env[key] }
+		got := xdgAppDirs(test.goos, getenv, test.appName, false)
+		if got != test.want {
+			t.Errorf("%q: mismatched dirs -- got %+v, want %+v", test.name,
+				got, test.want)
+		}
+	}
+}
+
+// TestMigrateAppDataDir ensures migrating a legacy application directory to
+// the XDG layout moves the data and configuration file as expected and never
+// overwrites existing directories.
+func TestMigrateAppDataDir(t *testing.T) {
+	root := t.TempDir()
+	legacyDir := filepath.Join(root, ".myapp")
+	dirs := AppDirs{
+		Config: filepath.Join(root, ".config", "myapp"),
+		Data:   filepath.Join(root, ".local", "share", "myapp"),
+	}
+	writeFile := func(name string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+			t.Fatalf("unable to create dir: %v", err)
+		}
+		if err := os.WriteFile(name, []byte(name), 0600); err != nil {
+			t.Fatalf("unable to write file: %v", err)
+		}
+	}
+	assertExists := func(name string, want bool) {
+		t.Helper()
+		exists, err := fileExists(name)
+		if err != nil {
+			t.Fatalf("unable to stat %s: %v", name, err)
+		}
+		if exists != want {
+			t.Fatalf("%s: mismatched existence -- got %v, want %v", name,
+				exists, want)
+		}
+	}
+
+	// No migration when the legacy directory does not exist.
+	migrated, err := migrateAppDataDir(legacyDir, dirs, "myapp.conf")
+	if err != nil || migrated {
+		t.Fatalf("unexpected migration without legacy dir: %v, %v",
+			migrated, err)
+	}
+
+	// Migrate a legacy directory with a config file and data.
+	writeFile(filepath.Join(legacyDir, "myapp.conf"))
+	writeFile(filepath.Join(legacyDir, "data", "db"))
+	migrated, err = migrateAppDataDir(legacyDir, dirs, "myapp.conf")
+	if err != nil || !migrated {
+		t.Fatalf("unexpected migration result: %v, %v", migrated, err)
+	}
+	assertExists(legacyDir, false)
+	assertExists(filepath.Join(dirs.Data, "data", "db"), true)
+	assertExists(filepath.Join(dirs.Data, "myapp.conf"), false)
+	assertExists(filepath.Join(dirs.Config, "myapp.conf"), true)
+
+	// No migration when the new data directory already exists.
+	writeFile(filepath.Join(legacyDir, "myapp.conf"))
+	migrated, err = migrateAppDataDir(legacyDir, dirs, "myapp.conf")
+	if err != nil || migrated {
+		t.Fatalf("unexpected migration with existing data dir: %v, %v",
+			migrated, err)
+	}
+	assertExists(filepath.Join(legacyDir, "myapp.conf"), true)
+
+	// No migration when the directories are the same.
+	sameDirs := AppDirs{Config: legacyDir, Data: legacyDir}
+	migrated, err = migrateAppDataDir(legacyDir, sameDirs, "myapp.conf")
+	if err != nil || migrated {
+		t.Fatalf("unexpected migration with same dirs: %v, %v", migrated,
+			err)
+	}
+}
@@ -1,5 +1,5 @@
 // Copyright (c) 2013-2016 The btcsuite developers
-// Copyright (c) 2015-2019 The Decred developers
+// Copyright (c) 2015-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sync/atomic"
 
 	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/wire"
@@ -37,6 +38,13 @@ func (e OutOfRangeError) Error() string {
 // transactions on their first access so subsequent accesses don't have to
 // repeat the relatively expensive hashing operations.
 type Block struct {
+	// serializeSize houses the cached serialize size of the block.  It is
+	// zero when the size has not been calculated yet.
+	//
+	// It must be accessed atomically and remain the first field in the struct
+	// in order to ensure it is 64-bit aligned on 32-bit platforms.
+	serializeSize uint64
+
 	msgBlock        *wire.MsgBlock // Underlying MsgBlock
 	serializedBlock []byte         // Serialized bytes for the block
 	hash            chainhash.Hash // Cached block hash
@@ -75,6 +83,30 @@ func (b *Block) Bytes() ([]byte, error) {
 	return serializedBlock, nil
 }
 
+// SerializeSize returns the number of bytes it would take to serialize the
+// block.  This is equivalent to calling SerializeSize on the underlying
+// wire.MsgBlock, however it caches the result so subsequent calls are more
+// efficient.
+//
+// This function is safe for concurrent access.
+func (b *Block) SerializeSize() int {
+	if cached := atomic.LoadUint64(&b.serializeSize); cached != 0 {
+		if assertBlockImmutability {
+			size := b.msgBlock.SerializeSize()
+			if size != int(cached) {
+				str := fmt.Sprintf("ASSERT: mutated util.block detected, old "+
+					"size %d, new size %d", cached, size)
+				panic(str)
+			}
+		}
+		return int(cached)
+	}
+
+	size := b.msgBlock.SerializeSize()
+	atomic.StoreUint64(&b.serializeSize, uint64(size))
+	return size
+}
+
 // BlockHeaderBytes returns the serialized bytes for the Block's header.  This
 // is equivalent to calling Serialize on the underlying wire.MsgBlock.Header,
 // but it returns a byte slice.
@@ -374,6 +406,7 @@ func NewBlockFromBytes(serializedBlock []byte) (*Block, error) {
 		return nil, err
 	}
 	b.serializedBlock = serializedBlock
+	b.serializeSize = uint64(len(serializedBlock))
 	return b, nil
 }
 
@@ -398,6 +431,7 @@ func NewBlockFromReader(r io.Reader) (*Block, error) {
 // an underlying wire.MsgBlock and the serialized bytes for it.  See Block.
 func NewBlockFromBlockAndBytes(msgBlock *wire.MsgBlock, serializedBlock []byte) *Block {
 	return &Block{
+		serializeSize:   uint64(len(serializedBlock)),
 		hash:            msgBlock.BlockHash(),
 		msgBlock:        msgBlock,
 		serializedBlock: serializedBlock,
@@ -222,6 +222,12 @@ func TestNewBlockFromBytes(t *testing.T) {
 		t.Errorf("MsgBlock: mismatched MsgBlock - got %v, want %v",
 			spew.Sdump(msgBlock), spew.Sdump(&Block100000))
 	}
+
+	// Ensure the serialize size is the size of the serialized bytes.
+	if size := b.SerializeSize(); size != len(block100000Bytes) {
+		t.Errorf("SerializeSize: mismatched size - got %d, want %d", size,
+			len(block100000Bytes))
+	}
 }
 
 // TestNewBlockFromBlockAndBytes tests creation of a Block from a MsgBlock and
@@ -1,5 +1,5 @@
 // Copyright (c) 2013-2016 The btcsuite developers
-// Copyright (c) 2015-2021 The Decred developers
+// Copyright (c) 2015-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sync/atomic"
 
 	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/wire"
@@ -26,8 +27,18 @@ const TxIndexUnknown = -1
 // Tx defines a transaction that provides easier and more efficient manipulation
 // of raw transactions.  It also memoizes the hash for the transaction on its
 // first access so subsequent accesses don't have to repeat the relatively
-// expensive hashing operations.
+// expensive hashing operations.  Similarly, the serialize size is memoized on
+// its first access.
 type Tx struct {
+	// serializeSize houses the cached serialize size of the transaction in the
+	// upper bits along with the serialization type it was calculated for in
+	// the lower 16 bits.  It is zero when the size has not been calculated
+	// yet.
+	//
+	// It must be accessed atomically and remain the first field in the struct
+	// in order to ensure it is 64-bit aligned on 32-bit platforms.
+	serializeSize uint64
+
 	hash    chainhash.Hash // Cached transaction hash
 	msgTx   *wire.MsgTx    // Underlying MsgTx
 	txTree  int8           // Indicates which tx tree the tx is found in
@@ -57,6 +68,38 @@ func (t *Tx) Hash() *chainhash.Hash {
 	return &t.hash
 }
 
+// SerializeSize returns the number of bytes it would take to serialize the
+// transaction with its current serialization type.  This is equivalent to
+// calling SerializeSize on the underlying wire.MsgTx, however it caches the
+// result so subsequent calls are more efficient.
+//
+// The cached size is only used when the serialization type of the underlying
+// transaction matches the one it was calculated for and it is invalidated by
+// the methods that mutate the transaction, such as SetVersion.
+//
+// This function is safe for concurrent access.
+func (t *Tx) SerializeSize() int {
+	serType := t.msgTx.SerType
+	cached := atomic.LoadUint64(&t.serializeSize)
+	if cached != 0 && wire.TxSerializeType(cached&0xffff) == serType {
+		if !assertTransactionImmutability {
+			return int(cached >> 16)
+		}
+
+		size := t.msgTx.SerializeSize()
+		if size != int(cached>>16) {
+			str := fmt.Sprintf("ASSERT: mutated util.tx detected, old size "+
+				"%d, new size %d", cached>>16, size)
+			panic(str)
+		}
+		return size
+	}
+
+	size := t.msgTx.SerializeSize()
+	atomic.StoreUint64(&t.serializeSize, uint64(size)<<16|uint64(serType))
+	return size
+}
+
 // Index returns the saved index of the transaction within a block.  This value
 // will be TxIndexUnknown if it hasn't already explicitly been set.
 func (t *Tx) Index() int {
@@ -84,9 +127,11 @@ func (t *Tx) Version() uint16 {
 	return t.msgTx.Version
 }
 
-// SetVersion sets the version of the transaction within a block.
+// SetVersion sets the version of the transaction within a block.  It also
+// invalidates the cached serialize size.
 func (t *Tx) SetVersion(version uint16) {
 	t.msgTx.Version = version
+	atomic.StoreUint64(&t.serializeSize, 0)
 }
 
 // NewTx returns a new instance of a transaction given an underlying
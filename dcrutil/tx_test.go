@@ -1,5 +1,5 @@
 // Copyright (c) 2013-2016 The btcsuite developers
-// Copyright (c) 2015-2021 The Decred developers
+// Copyright (c) 2015-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -77,6 +77,46 @@ func TestTx(t *testing.T) {
 	}
 }
 
+// TestTxSerializeSize ensures the cached serialize size of a Tx matches the
+// underlying transaction and accounts for its serialization type.
+func TestTxSerializeSize(t *testing.T) {
+	testTx := NewTxDeep(Block100000.Transactions[1]).MsgTx()
+	tx := NewTx(testTx)
+
+	// Request the size multiple times to test generation and caching.
+	wantSize := testTx.SerializeSize()
+	for i := 0; i < 2; i++ {
+		if size := tx.SerializeSize(); size != wantSize {
+			t.Errorf("SerializeSize #%d: mismatched size - got %d, want %d",
+				i, size, wantSize)
+		}
+	}
+
+	// Ensure the size is recalculated when the serialization type changes.
+	for _, serType := range []wire.TxSerializeType{wire.TxSerializeNoWitness,
+		wire.TxSerializeOnlyWitness, wire.TxSerializeFull} {
+
+		testTx.SerType = serType
+		wantSize := testTx.SerializeSize()
+		if size := tx.SerializeSize(); size != wantSize {
+			t.Errorf("SerializeSize (type %d): mismatched size - got %d, "+
+				"want %d", serType, size, wantSize)
+		}
+	}
+
+	// Ensure mutating the transaction via the wrapper invalidates the cached
+	// size.
+	tx.SerializeSize()
+	tx.SetVersion(tx.Version() + 1)
+	if cached := tx.serializeSize; cached != 0 {
+		t.Errorf("SetVersion: cached size %d not invalidated", cached>>16)
+	}
+	if size := tx.SerializeSize(); size != wantSize {
+		t.Errorf("SerializeSize: mismatched size - got %d, want %d", size,
+			wantSize)
+	}
+}
+
 // TestNewTxFromBytes tests creation of a Tx from serialized bytes.
 func TestNewTxFromBytes(t *testing.T) {
 	// Serialize the test transaction.
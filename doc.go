@@ -32,6 +32,11 @@ Application Options:
 	    --logsize=               Maximum size of log file before it is rotated
 	                             (default: 10 MiB)
 	    --nofilelogging          Disable file logging
+	    --logformat=             The format of log entries {text, json} -- json
+	                             writes each entry as a JSON object with the
+	                             subsystem, level, message, and context such as
+	                             block hashes, transaction hashes, and peer
+	                             addresses (default: text)
 	    --dbtype=                Database backend to use for the block chain
 	                             (default: ffldb)
 	    --profile=               Enable HTTP profiling on given [addr:]port --
@@ -49,8 +54,13 @@ Application Options:
 	                             Use show to list available subsystems (info)
 	    --sigcachemaxsize=       The maximum number of entries in the signature
 	                             verification cache (default: 100000)
+	    --scriptcachemaxsize=    The maximum number of entries in the script
+	                             execution cache (default: 100000)
 	    --utxocachemaxsize=      The maximum size in MiB of the utxo cache
 	                             (default: 150, minimum: 25, maximum: 32768)
+	    --utxoprefetch           Prefetch the utxos spent by the next block into
+	                             the utxo cache while connecting blocks during
+	                             reorganizations
 	    --norpc                  Disable built-in RPC server -- NOTE: The RPC
 	                             server is disabled by default if no
 	                             rpcuser/rpcpass or rpclimituser/rpclimitpass is
@@ -76,12 +86,52 @@ Application Options:
 	    --notls                  Disable TLS for the RPC server -- NOTE: This is
 	                             only allowed if the RPC server is bound to
 	                             localhost
+	    --rpclistenunix=         Path of a Unix domain socket to listen for RPC
+	                             connections on -- TLS is not used for
+	                             connections over the socket and only the user
+	                             running the server is permitted to access it
+	    --rpcunixnoauth          Do not require RPC authentication for
+	                             connections over the Unix domain socket
+	                             specified by --rpclistenunix since access is
+	                             controlled by its file permissions -- The RPC
+	                             server only listens on the socket when no
+	                             rpcuser/rpcpass or rpclimituser/rpclimitpass is
+	                             specified
 	    --rpcmaxclients=         Max number of RPC clients for standard
 	                             connections (default: 10)
 	    --rpcmaxwebsockets=      Max number of RPC websocket connections
 	                             (default: 25)
 	    --rpcmaxconcurrentreqs=  Max number of concurrent RPC requests that may
 	                             be processed concurrently (default: 20)
+	    --rpcmaxwebsocketsperip= Max number of RPC websocket connections per IP
+	                             address -- 0 to disable (default: 0)
+	    --rpcmaxwsqueuebytes=    Max number of bytes of notifications that may be
+	                             queued for a RPC websocket client before they
+	                             are dropped and the client is disconnected -- 0
+	                             to disable (default: 67108864)
+	    --rpccachebytes=         Max number of bytes of immutable RPC responses,
+	                             such as those for deeply confirmed blocks and
+	                             transactions, to cache in memory -- 0 to
+	                             disable (default: 33554432)
+	    --rpcmaxauthfailures=    Number of consecutive RPC authentication
+	                             failures from an IP address before it is locked
+	                             out from authenticating -- Each additional
+	                             failure doubles the lockout duration up to 1
+	                             hour; 0 to disable (default: 0)
+	    --rpcauthlockout=        Initial duration an IP address is locked out
+	                             from authenticating after reaching
+	                             --rpcmaxauthfailures.  Valid time units are
+	                             {s, m, h} (default: 1m)
+	    --rpcauditlog=           File to append a JSON line to for each
+	                             invocation of a privileged RPC (disabled when
+	                             empty)
+	    --rpcauditredact=        Redact RPC parameters from the audit log --
+	                             Specify method to redact all parameters of a
+	                             method or method.param to redact a single
+	                             parameter; may be specified multiple times
+	    --debugrpc               Enable RPCs intended for testing such as
+	                             setmocktime and forceprune -- Only allowed on
+	                             simnet and regnet
 	    --proxy=                 Connect via SOCKS5 proxy (eg. 127.0.0.1:9050)
 	    --proxyuser=             Username for proxy server
 	    --proxypass=             Password for proxy server
@@ -102,6 +152,14 @@ Application Options:
 	    --listen=                Add an interface/port to listen for connections
 	                             (default all interfaces port: 9108, testnet:
 	                             19108)
+	    --listenpolicy=          Apply policies to peers accepted via a listen
+	                             interface/port in the form
+	                             <interface/port>=<policy>[,<policy>...] --
+	                             Supported policies: onion (connections are from
+	                             a local Tor hidden service so they are never
+	                             whitelisted and the address is not advertised),
+	                             norelay (do not relay transactions to or from
+	                             the peers)
 	    --maxsameip=             Max number of connections with the same IP -- 0
 	                             to disable (default: 5)
 	    --maxpeers=              Max number of inbound and outbound peers
@@ -112,6 +170,27 @@ Application Options:
 	    --peeridletimeout        The duration of inactivity before a peer is
 	                             timed out. Valid time units are {s,m,h}.
 	                             Minimum 15 seconds (default: 2m0s)
+	    --nocompression          Disable negotiating compression of large block
+	                             and filter messages with peers
+	    --trickleinterval=       The average duration between inventory
+	                             announcements to outbound peers -- Whitelisted
+	                             peers are sent announcements at a quarter of
+	                             the interval.  Valid time units are {ms,s,m}.
+	                             Minimum 10 milliseconds (default: 500ms)
+	    --inboundtrickleinterval=
+	                             The average duration between inventory
+	                             announcements to inbound peers.  Valid time
+	                             units are {ms,s,m}.  Minimum 10 milliseconds
+	                             (default: 1s)
+	    --uacomment=             Add a comment to the user agent advertised to
+	                             peers -- Comments may not contain the '/', ':',
+	                             '(', or ')' characters
+	    --rejectagent=           Reject peers with a user agent that matches the
+	                             regular expression (eg. '/dcrd:1.[0-6].')
+	    --deprioritizeagent=     Evict inbound peers with a user agent that
+	                             matches the regular expression to make room for
+	                             other peers once the max number of peers is
+	                             reached
 	    --noseeders              Disable seeding for peer discovery
 	    --nodnsseed              DEPRECATED: use --noseeders
 	    --externalip=            Add a public-facing IP to the list of local
@@ -137,6 +216,18 @@ Application Options:
 	                             periodically with new releases. Don't use a
 	                             different hash unless you understand the
 	                             implications. Set to 0 to disable
+	    --maxreorgdepth=         Maximum number of blocks an automatic chain
+	                             reorganization may disconnect. Deeper
+	                             reorganizations are paused until approved with
+	                             the approvedeepreorg RPC. Set to 0 to disable
+	    --maxsidechainblocks=    Maximum number of side chain blocks to keep
+	                             available for reorganizations. Blocks on the
+	                             most stale side chains are evicted first. Set
+	                             to 0 to disable
+	    --auditassumevalid       Record the blocks whose scripts are not
+	                             executed during the initial chain sync due to
+	                             the assumed valid block and execute them in the
+	                             background once the chain is synced
 	    --minrelaytxfee=         The minimum transaction fee in DCR/kB to be
 	                             considered a non-zero fee (default: 0.0001)
 	    --limitfreerelay=        DEPRECATED: This behavior is no longer available
@@ -155,6 +246,13 @@ Application Options:
 	                             the default settings for the active network
 	    --allowoldvotes          Enable the addition of very old votes to the
 	                             mempool
+	    --mempooljournalsize=    Max number of recent mempool events, such as
+	                             transactions being accepted, rejected, evicted,
+	                             or mined, to keep in memory for the
+	                             getmempooljournal RPC -- Set to 0 to disable
+	                             (default: 1000)
+	    --persistmempooljournal  Save the mempool event journal to the data
+	                             directory on shutdown and restore it on startup
 	    --generate               Generate (mine) coins using the CPU
 	    --miningaddr=            Add the specified payment address to the list
 	                             of addresses to use for generated blocks -- At
@@ -186,8 +284,36 @@ Application Options:
 	                             the database on start up and then exits
 	    --noexistsaddrindex      Disable the exists address index, which tracks
 	                             whether or not an address has even been used
+	    --existsaddrfprate=      Maximum false positive rate of the in-memory
+	                             filter the exists address index uses to avoid
+	                             database lookups for unused addresses -- Lower
+	                             rates use more memory (default: 0.0001)
 	    --dropexistsaddrindex    Deletes the exists address index from the
 	                             database on start up and then exits
+	    --nulldataindex          Maintain an index of null data (OP_RETURN)
+	                             payloads that start with one of the prefixes
+	                             specified by --nulldataprefix which makes them
+	                             available via the getnulldata RPC
+	    --nulldataprefix=        Add the specified hex-encoded prefix to the set
+	                             of prefixes null data payloads must start with
+	                             in order to be indexed by the null data index
+	                             -- Changing the set of prefixes rebuilds the
+	                             index
+	    --dropnulldataindex      Deletes the null data index from the database on
+	                             start up and then exits
+	    --utxohistoryindex       Maintain an archival index of all transaction
+	                             outputs and the history of their spends in the
+	                             main chain which makes their state as of any
+	                             height available via the gettxouthistory RPC
+	    --droputxohistoryindex   Deletes the utxo history index from the database
+	                             on start up and then exits
+	    --exportutxoset=         Write a snapshot of the UTXO set as of the
+	                             current best block to the specified file on
+	                             start up and then exits
+	    --importutxoset=         Import the UTXO set from the specified snapshot
+	                             file on start up -- The UTXO database must be
+	                             empty and the snapshot block must be in the
+	                             main chain of the block database
 	    --piperx=                File descriptor of read end pipe to enable
 	                             parent -> child process communication
 	    --pipetx=                File descriptor of write end pipe to enable
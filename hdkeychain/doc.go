@@ -92,5 +92,17 @@ bytes which tie them to a specific network.  The network that an extended key is
 associated with is specified when creating and decoding the key.  In the case of
 decoding, an error will be returned if a given encoded extended key is not for
 the specified network.
+
+# Alternate Version Bytes
+
+Some software encodes extended keys with alternate version bytes, such as those
+registered by SLIP-0132, to convey additional information about how the keys are
+intended to be used.  A VersionRegistry created with NewVersionRegistry accepts
+the version bytes of a network along with any alternate version bytes that are
+registered with it.  Its NewKeyFromString method decodes extended keys encoded
+with any of the registered version bytes and the ConvertKey and
+ConvertKeyString methods convert extended keys between them.  Conversion is
+refused for extended keys with version bytes that are not registered, so keys
+can not be accidentally converted to the version bytes of another network.
 */
 package hdkeychain
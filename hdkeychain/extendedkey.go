@@ -1,5 +1,5 @@
 // Copyright (c) 2014-2016 The btcsuite developers
-// Copyright (c) 2015-2021 The Decred developers
+// Copyright (c) 2015-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -536,6 +536,24 @@ func NewMaster(seed []byte, net NetworkParams) (*ExtendedKey, error) {
 // NewKeyFromString returns a new extended key instance from a base58-encoded
 // extended key which is required to be for the provided network.
 func NewKeyFromString(key string, net NetworkParams) (*ExtendedKey, error) {
+	return decodeKeyString(key, func(version []byte) NetworkParams {
+		privVersion := net.HDPrivKeyVersion()
+		pubVersion := net.HDPubKeyVersion()
+		if !bytes.Equal(version, privVersion[:]) &&
+			!bytes.Equal(version, pubVersion[:]) {
+
+			return nil
+		}
+		return net
+	})
+}
+
+// decodeKeyString returns a new extended key instance from a base58-encoded
+// extended key.  The provided function is used to look up the version bytes
+// encoded in the key and must return nil when they are not acceptable in which
+// case ErrWrongNetwork is returned.  Otherwise, the returned extended key is
+// associated with the version bytes returned by the function.
+func decodeKeyString(key string, lookupVersion func(version []byte) NetworkParams) (*ExtendedKey, error) {
 	// The provided encoded extended key must not be larger than the maximum
 	// possible encoded size.  The base58-decoded extended key consists of the
 	// serialized payload plus an additional 4 bytes for the checksum.
@@ -573,13 +591,9 @@ func NewKeyFromString(key string, net NetworkParams) (*ExtendedKey, error) {
 		return nil, ErrBadChecksum
 	}
 
-	// Ensure the version encoded in the payload matches the provided network.
-	privVersion := net.HDPrivKeyVersion()
-	pubVersion := net.HDPubKeyVersion()
-	version := payload[:4]
-	if !bytes.Equal(version, privVersion[:]) &&
-		!bytes.Equal(version, pubVersion[:]) {
-
+	// Ensure the version encoded in the payload is acceptable.
+	net := lookupVersion(payload[:4])
+	if net == nil {
 		return nil, ErrWrongNetwork
 	}
 
@@ -610,8 +624,8 @@ func NewKeyFromString(key string, net NetworkParams) (*ExtendedKey, error) {
 		}
 	}
 
-	return newExtendedKey(privVersion, pubVersion, keyData, chainCode, parentFP,
-		depth, childNum, isPrivate), nil
+	return newExtendedKey(net.HDPrivKeyVersion(), net.HDPubKeyVersion(),
+		keyData, chainCode, parentFP, depth, childNum, isPrivate), nil
 }
 
 // GenerateSeed returns a cryptographically secure random seed that can be used
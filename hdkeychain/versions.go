@@ -0,0 +1,242 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain
+
+// References:
+//   [SLIP132]: SLIP-0132 - Registered HD version bytes for BIP-0032
+//   https://github.com/satoshilabs/slips/blob/master/slip-0132.md
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// DefaultVersionsName is the name the version bytes of the network a
+// VersionRegistry is created for are registered under.
+const DefaultVersionsName = "default"
+
+var (
+	// ErrDuplicateVersion describes an error in which the caller attempted
+	// to register extended key version bytes, or a name for them, that are
+	// already registered or that are the same for both private and public
+	// extended keys.
+	ErrDuplicateVersion = errors.New("the extended key version bytes are " +
+		"already registered")
+
+	// ErrUnknownVersions describes an error in which the caller requested
+	// extended key version bytes by a name that is not registered.
+	ErrUnknownVersions = errors.New("no extended key version bytes are " +
+		"registered with the provided name")
+)
+
+// KeyVersions houses a named pair of hierarchical deterministic extended
+// private and public key magic version bytes.  Alternate version bytes, such
+// as those registered by [SLIP132], are commonly used by other software to
+// convey additional information about how an extended key is intended to be
+// used.
+//
+// KeyVersions implements the NetworkParams interface so it may be provided to
+// any function that accepts network parameters.
+type KeyVersions struct {
+	// Name is a human-readable identifier for the version bytes.
+	Name string
+
+	// PrivVersion and PubVersion are the magic version bytes for extended
+	// private and public keys, respectively.
+	PrivVersion [4]byte
+	PubVersion  [4]byte
+}
+
+// HDPrivKeyVersion returns the extended private key magic version bytes.
+//
+// This is part of the NetworkParams interface.
+func (v *KeyVersions) HDPrivKeyVersion() [4]byte {
+	return v.PrivVersion
+}
+
+// HDPubKeyVersion returns the extended public key magic version bytes.
+//
+// This is part of the NetworkParams interface.
+func (v *KeyVersions) HDPubKeyVersion() [4]byte {
+	return v.PubVersion
+}
+
+// VersionRegistry houses the extended key version bytes that are accepted for
+// a single network.  It always contains the version bytes defined by the
+// network parameters it was created with, registered under
+// DefaultVersionsName, along with any alternate version bytes that are
+// registered with it.
+//
+// Since all version bytes in a registry are considered to be for the same
+// network, callers must take care to only register alternate version bytes
+// that are intended for that network.  Keys may only be converted between
+// version bytes in the same registry which prevents accidentally converting an
+// extended key to the version bytes of another network.
+//
+// Registering version bytes is not safe for concurrent access with any other
+// methods of the registry.
+type VersionRegistry struct {
+	versions []KeyVersions
+}
+
+// NewVersionRegistry returns a new registry for the provided network that also
+// accepts the provided alternate version bytes.
+//
+// ErrDuplicateVersion is returned when any of the alternate version bytes, or
+// their names, conflict with each other or with the network version bytes.
+func NewVersionRegistry(net NetworkParams, alternates ...KeyVersions) (*VersionRegistry, error) {
+	r := &VersionRegistry{
+		versions: make([]KeyVersions, 0, len(alternates)+1),
+	}
+	err := r.Register(KeyVersions{
+		Name:        DefaultVersionsName,
+		PrivVersion: net.HDPrivKeyVersion(),
+		PubVersion:  net.HDPubKeyVersion(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range alternates {
+		if err := r.Register(v); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds the provided version bytes to the registry.
+//
+// ErrDuplicateVersion is returned when the private and public version bytes
+// are the same or when either of them, or the name, is already registered.
+func (r *VersionRegistry) Register(v KeyVersions) error {
+	if v.PrivVersion == v.PubVersion {
+		return fmt.Errorf("%w: private and public version bytes %x for %q "+
+			"must differ", ErrDuplicateVersion, v.PrivVersion, v.Name)
+	}
+	for i := range r.versions {
+		existing := &r.versions[i]
+		if existing.Name == v.Name {
+			return fmt.Errorf("%w: name %q is already registered",
+				ErrDuplicateVersion, v.Name)
+		}
+		for _, version := range [2][4]byte{v.PrivVersion, v.PubVersion} {
+			if version == existing.PrivVersion ||
+				version == existing.PubVersion {
+
+				return fmt.Errorf("%w: version bytes %x are already "+
+					"registered for %q", ErrDuplicateVersion, version,
+					existing.Name)
+			}
+		}
+	}
+
+	r.versions = append(r.versions, v)
+	return nil
+}
+
+// lookup returns the registered version bytes that contain the provided version
+// bytes for either extended private or public keys or nil when they are not
+// registered.
+func (r *VersionRegistry) lookup(version []byte) *KeyVersions {
+	for i := range r.versions {
+		v := &r.versions[i]
+		if bytes.Equal(version, v.PrivVersion[:]) ||
+			bytes.Equal(version, v.PubVersion[:]) {
+
+			return v
+		}
+	}
+	return nil
+}
+
+// Lookup returns the registered version bytes that contain the provided version
+// bytes for either extended private or public keys and whether or not they are
+// registered.
+func (r *VersionRegistry) Lookup(version [4]byte) (KeyVersions, bool) {
+	v := r.lookup(version[:])
+	if v == nil {
+		return KeyVersions{}, false
+	}
+	return *v, true
+}
+
+// Versions returns the version bytes registered under the provided name and
+// whether or not they are registered.
+func (r *VersionRegistry) Versions(name string) (KeyVersions, bool) {
+	for i := range r.versions {
+		if r.versions[i].Name == name {
+			return r.versions[i], true
+		}
+	}
+	return KeyVersions{}, false
+}
+
+// NewKeyFromString returns a new extended key instance from a base58-encoded
+// extended key which is required to be encoded with any of the version bytes
+// in the registry along with the registered version bytes it is encoded with.
+// The returned extended key retains the version bytes so that it serializes to
+// the same encoding.
+//
+// ErrWrongNetwork is returned when the version bytes of the encoded key are not
+// registered.
+func (r *VersionRegistry) NewKeyFromString(key string) (*ExtendedKey, KeyVersions, error) {
+	var versions *KeyVersions
+	k, err := decodeKeyString(key, func(version []byte) NetworkParams {
+		versions = r.lookup(version)
+		if versions == nil {
+			return nil
+		}
+		return versions
+	})
+	if err != nil {
+		return nil, KeyVersions{}, err
+	}
+	return k, *versions, nil
+}
+
+// ConvertKey returns a new instance of the provided extended key that is
+// encoded with the version bytes registered under the provided name.  The
+// original extended key is not modified.
+//
+// ErrWrongNetwork is returned when the version bytes of the provided extended
+// key are not registered, since that indicates it is for another network, and
+// ErrUnknownVersions is returned when there are no version bytes registered
+// under the provided name.
+func (r *VersionRegistry) ConvertKey(k *ExtendedKey, name string) (*ExtendedKey, error) {
+	from := r.lookup(k.privVer[:])
+	if from == nil || from.PubVersion != k.pubVer {
+		return nil, ErrWrongNetwork
+	}
+	to, ok := r.Versions(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownVersions, name)
+	}
+
+	// Copy the key material so that zeroing either key does not affect the
+	// other one.
+	key := append([]byte(nil), k.key...)
+	chainCode := append([]byte(nil), k.chainCode...)
+	parentFP := append([]byte(nil), k.parentFP...)
+	return newExtendedKey(to.PrivVersion, to.PubVersion, key, chainCode,
+		parentFP, k.depth, k.childNum, k.isPrivate), nil
+}
+
+// ConvertKeyString converts the provided base58-encoded extended key, which is
+// required to be encoded with any of the version bytes in the registry, to the
+// base58 encoding with the version bytes registered under the provided name.
+//
+// See NewKeyFromString and ConvertKey for the errors that may be returned.
+func (r *VersionRegistry) ConvertKeyString(key, name string) (string, error) {
+	k, _, err := r.NewKeyFromString(key)
+	if err != nil {
+		return "", err
+	}
+	converted, err := r.ConvertKey(k, name)
+	if err != nil {
+		return "", err
+	}
+	return converted.String(), nil
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain
+
+import (
+	"errors"
+	"testing"
+)
+
+// mockAltVersions returns mock alternate version bytes to register for the
+// mock mainnet params throughout the tests.
+func mockAltVersions() KeyVersions {
+	return KeyVersions{
+		Name:        "alt",
+		PrivVersion: [4]byte{0x02, 0xfd, 0xa4, 0xf0},
+		PubVersion:  [4]byte{0x02, 0xfd, 0xa9, 0x30},
+	}
+}
+
+// TestVersionRegistry ensures registering and looking up extended key version
+// bytes works as intended.
+func TestVersionRegistry(t *testing.T) {
+	net := mockMainNetParams()
+	alt := mockAltVersions()
+	r, err := NewVersionRegistry(net, alt)
+	if err != nil {
+		t.Fatalf("NewVersionRegistry: unexpected error: %v", err)
+	}
+
+	// Ensure the network and alternate version bytes can be looked up.
+	tests := []struct {
+		name    string
+		version [4]byte
+		want    string
+		found   bool
+	}{{
+		name:    "network private version",
+		version: net.HDPrivKeyVersion(),
+		want:    DefaultVersionsName,
+		found:   true,
+	}, {
+		name:    "network public version",
+		version: net.HDPubKeyVersion(),
+		want:    DefaultVersionsName,
+		found:   true,
+	}, {
+		name:    "alternate private version",
+		version: alt.PrivVersion,
+		want:    alt.Name,
+		found:   true,
+	}, {
+		name:    "alternate public version",
+		version: alt.PubVersion,
+		want:    alt.Name,
+		found:   true,
+	}, {
+		name:    "other network version",
+		version: mockTestNetParams().HDPubKeyVersion(),
+		found:   false,
+	}}
+	for _, test := range tests {
+		v, found := r.Lookup(test.version)
+		if found != test.found {
+			t.Errorf("%s: mismatched found -- got %v, want %v", test.name,
+				found, test.found)
+			continue
+		}
+		if v.Name != test.want {
+			t.Errorf("%s: mismatched name -- got %q, want %q", test.name,
+				v.Name, test.want)
+		}
+	}
+	if v, ok := r.Versions(alt.Name); !ok || v != alt {
+		t.Errorf("Versions: mismatched versions -- got %+v (found %v), want "+
+			"%+v", v, ok, alt)
+	}
+	if _, ok := r.Versions("unknown"); ok {
+		t.Error("Versions: found unregistered versions")
+	}
+
+	// Ensure conflicting version bytes are rejected.
+	conflictTests := []struct {
+		name     string
+		versions KeyVersions
+	}{{
+		name: "same private and public versions",
+		versions: KeyVersions{
+			Name:        "same",
+			PrivVersion: [4]byte{0x01, 0x02, 0x03, 0x04},
+			PubVersion:  [4]byte{0x01, 0x02, 0x03, 0x04},
+		},
+	}, {
+		name: "duplicate name",
+		versions: KeyVersions{
+			Name:        alt.Name,
+			PrivVersion: [4]byte{0x01, 0x02, 0x03, 0x04},
+			PubVersion:  [4]byte{0x01, 0x02, 0x03, 0x05},
+		},
+	}, {
+		name: "network private version",
+		versions: KeyVersions{
+			Name:        "dup",
+			PrivVersion: net.HDPrivKeyVersion(),
+			PubVersion:  [4]byte{0x01, 0x02, 0x03, 0x05},
+		},
+	}, {
+		name: "alternate private version as public version",
+		versions: KeyVersions{
+			Name:        "dup",
+			PrivVersion: [4]byte{0x01, 0x02, 0x03, 0x04},
+			PubVersion:  alt.PrivVersion,
+		},
+	}}
+	for _, test := range conflictTests {
+		err := r.Register(test.versions)
+		if !errors.Is(err, ErrDuplicateVersion) {
+			t.Errorf("%s: mismatched error -- got %v, want %v", test.name,
+				err, ErrDuplicateVersion)
+		}
+		_, err = NewVersionRegistry(net, test.versions, alt)
+		if !errors.Is(err, ErrDuplicateVersion) {
+			t.Errorf("%s: mismatched error -- got %v, want %v", test.name,
+				err, ErrDuplicateVersion)
+		}
+	}
+}
+
+// TestVersionRegistryConvert ensures converting extended keys between the
+// version bytes in a registry works as intended and refuses to convert keys
+// for other networks.
+func TestVersionRegistryConvert(t *testing.T) {
+	net := mockMainNetParams()
+	alt := mockAltVersions()
+	r, err := NewVersionRegistry(net, alt)
+	if err != nil {
+		t.Fatalf("NewVersionRegistry: unexpected error: %v", err)
+	}
+
+	// The private and public extended keys for the master node of test vector 1
+	// from [BIP32].
+	const privKeyStr = "dprv3hCznBesA6jBtmoyVFPfyMSZ1qYZ3WdjdebquvkEfmRfxC9VFEFi2YDaJqHnx7uGe75eGSa3Mn3oHK11hBW7KZUrPxwbCPBmuCi1nwm182s"
+	const pubKeyStr = "dpubZ9169KDAEUnyoBhjjmT2VaEodr6pUTDoqCEAeqgbfr2JfkB88BbK77jbTYbcYXb2FVz7DKBdW4P618yd51MwF8DjKVopSbS7Lkgi6bowX5w"
+
+	for _, keyStr := range []string{privKeyStr, pubKeyStr} {
+		// Convert the key to the alternate version bytes.
+		altKeyStr, err := r.ConvertKeyString(keyStr, alt.Name)
+		if err != nil {
+			t.Fatalf("ConvertKeyString: unexpected error: %v", err)
+		}
+		if altKeyStr == keyStr {
+			t.Fatalf("ConvertKeyString: key %s was not converted", keyStr)
+		}
+
+		// Ensure the converted key is rejected without the registry.
+		_, err = NewKeyFromString(altKeyStr, net)
+		if !errors.Is(err, ErrWrongNetwork) {
+			t.Fatalf("NewKeyFromString: mismatched error -- got %v, want %v",
+				err, ErrWrongNetwork)
+		}
+
+		// Ensure the converted key decodes with the registry, reports the
+		// alternate version bytes, and reserializes to the same encoding.
+		altKey, versions, err := r.NewKeyFromString(altKeyStr)
+		if err != nil {
+			t.Fatalf("NewKeyFromString: unexpected error: %v", err)
+		}
+		if versions != alt {
+			t.Fatalf("NewKeyFromString: mismatched versions -- got %+v, "+
+				"want %+v", versions, alt)
+		}
+		if altKey.String() != altKeyStr {
+			t.Fatalf("String: mismatched key -- got %s, want %s",
+				altKey.String(), altKeyStr)
+		}
+
+		// Ensure converting back to the network version bytes produces the
+		// original key and does not modify the converted key.
+		key, err := r.ConvertKey(altKey, DefaultVersionsName)
+		if err != nil {
+			t.Fatalf("ConvertKey: unexpected error: %v", err)
+		}
+		if key.String() != keyStr {
+			t.Fatalf("ConvertKey: mismatched key -- got %s, want %s",
+				key.String(), keyStr)
+		}
+		key.Zero()
+		if altKey.String() != altKeyStr {
+			t.Fatalf("ConvertKey: converted key modified -- got %s, want %s",
+				altKey.String(), altKeyStr)
+		}
+	}
+
+	// Ensure converting to unregistered version bytes is rejected.
+	_, err = r.ConvertKeyString(privKeyStr, "unknown")
+	if !errors.Is(err, ErrUnknownVersions) {
+		t.Fatalf("ConvertKeyString: mismatched error -- got %v, want %v", err,
+			ErrUnknownVersions)
+	}
+
+	// Ensure keys for another network are rejected.
+	seed := make([]byte, RecommendedSeedLen)
+	testNetKey, err := NewMaster(seed, mockTestNetParams())
+	if err != nil {
+		t.Fatalf("NewMaster: unexpected error: %v", err)
+	}
+	_, err = r.ConvertKey(testNetKey, alt.Name)
+	if !errors.Is(err, ErrWrongNetwork) {
+		t.Fatalf("ConvertKey: mismatched error -- got %v, want %v", err,
+			ErrWrongNetwork)
+	}
+	_, err = r.ConvertKeyString(testNetKey.String(), alt.Name)
+	if !errors.Is(err, ErrWrongNetwork) {
+		t.Fatalf("ConvertKeyString: mismatched error -- got %v, want %v", err,
+			ErrWrongNetwork)
+	}
+}
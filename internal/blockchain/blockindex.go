@@ -1350,6 +1350,46 @@ func (bi *blockIndex) AcceptBlockData(node, tip *blockNode) []*blockNode {
 	return linkedBlocks
 }
 
+// RemoveBlockData updates the block index state to account for the full data
+// for a block no longer being available.  The block, along with all of its
+// descendants, are no longer eligible for validation until the data for the
+// block becomes available again.  Descendants that have their data available
+// are tracked as unlinked blocks accordingly so they are linked again once that
+// happens.
+//
+// NOTE: It is up to the caller to ensure the block is not part of the main
+// chain.
+//
+// This function is safe for concurrent access.
+func (bi *blockIndex) RemoveBlockData(node *blockNode) {
+	bi.Lock()
+	bi.unsetStatusFlags(node, statusDataStored)
+	bi.removeBestChainCandidate(node)
+	node.isFullyLinked = false
+
+	// Unlink all descendants of the block by walking backwards from all chain
+	// tips that descend from it.  Note that blocks that are not fully linked
+	// are already tracked as unlinked when they have their data available.
+	bi.forEachChainTipAfterHeight(node, func(tip *blockNode) error {
+		if !node.IsAncestorOf(tip) {
+			return nil
+		}
+		for n := tip; n != node; n = n.parent {
+			if !n.isFullyLinked {
+				continue
+			}
+			n.isFullyLinked = false
+			bi.removeBestChainCandidate(n)
+			if n.status.HaveData() && !n.status.KnownInvalid() {
+				unlinkedChildren := bi.unlinkedChildrenOf[n.parent]
+				bi.unlinkedChildrenOf[n.parent] = append(unlinkedChildren, n)
+			}
+		}
+		return nil
+	})
+	bi.Unlock()
+}
+
 // FindBestChainCandidate searches the block index for the best potentially
 // valid chain that contains the most cumulative work and returns its tip.  In
 // order to be potentially valid, all of the block data leading up to a block
@@ -11,6 +11,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"sync"
 	"time"
@@ -25,6 +26,7 @@ import (
 	"github.com/decred/dcrd/gcs/v4/blockcf2"
 	"github.com/decred/dcrd/internal/blockchain/indexers"
 	"github.com/decred/dcrd/internal/blockchain/spendpruner"
+	"github.com/decred/dcrd/internal/staging/primitives"
 	"github.com/decred/dcrd/lru"
 	"github.com/decred/dcrd/math/uint256"
 	"github.com/decred/dcrd/txscript/v4"
@@ -101,6 +103,12 @@ type BestState struct {
 	NextWinningTickets  []chainhash.Hash // The eligible tickets to vote on the next block.
 	MissedTickets       []chainhash.Hash // The missed tickets set to be revoked.
 	NextFinalState      [6]byte          // The calculated state of the lottery for the next block.
+
+	// Sequence is a number that increases each time the best state changes.
+	// It is not persisted and therefore starts over at zero each time the
+	// chain is loaded, so it is only meaningful for comparisons against other
+	// snapshots from the same chain instance.
+	Sequence uint64
 }
 
 // newBestState returns a new best stats instance for the given parameters.
@@ -141,6 +149,8 @@ type BlockChain struct {
 	// separate mutex.
 	assumeValid              chainhash.Hash
 	allowOldForks            bool
+	maxReorgDepth            int64
+	maxSideChainBlocks       int64
 	expectedBlocksInTwoWeeks int64
 	deploymentVers           map[string]uint32
 	minKnownWork             *uint256.Uint256
@@ -151,9 +161,16 @@ type BlockChain struct {
 	timeSource               MedianTimeSource
 	notifications            NotificationCallback
 	sigCache                 *txscript.SigCache
+	scriptCache              *ScriptCache
 	indexSubscriber          *indexers.IndexSubscriber
 	interrupt                <-chan struct{}
 	utxoCache                UtxoCacher
+	utxoBackend              UtxoBackend
+	prefetchUtxos            bool
+
+	// prefetchWg tracks the goroutines that are prefetching utxos into the
+	// utxo cache so they can be waited on during shutdown.
+	prefetchWg sync.WaitGroup
 
 	// subsidyCache is the cache that provides quick lookup of subsidy
 	// values.
@@ -218,11 +235,42 @@ type BlockChain struct {
 	// It is protected by the chain lock.
 	rejectForksCheckpoint *blockNode
 
+	// approvedDeepReorg tracks the block the caller most recently approved
+	// as part of a branch that the chain is allowed to reorganize to even
+	// though doing so exceeds the maximum automatic reorganization depth.
+	//
+	// pausedDeepReorg tracks the target of the most recent reorganization that
+	// was paused due to exceeding the maximum automatic reorganization depth.
+	// It is used to avoid repeatedly notifying the caller about the same paused
+	// reorganization.
+	//
+	// These fields are protected by the chain lock.
+	approvedDeepReorg *blockNode
+	pausedDeepReorg   *blockNode
+
 	// assumeValidNode tracks the assumed valid block.  It will be nil when a
 	// block header with the assumed valid block hash has not been discovered or
 	// when assume valid is disabled.  It is protected by the chain lock.
 	assumeValidNode *blockNode
 
+	// numSideChainBlocks tracks the number of blocks that are not part of the
+	// main chain and have their data available.  It is updated as blocks are
+	// stored, connected, disconnected, and have their data removed so that
+	// enforcing the maximum number of side chain blocks does not require
+	// walking all of the chain tips every time a block is processed.  It is
+	// protected by the chain lock.
+	numSideChainBlocks int64
+
+	// auditSkippedScripts indicates whether or not the blocks that are
+	// connected to the main chain without executing their transaction scripts
+	// are recorded for later verification.
+	//
+	// scriptAudit tracks the recorded blocks along with the results of
+	// verifying them.  It is protected by the script audit mutex.
+	auditSkippedScripts bool
+	scriptAuditMtx      sync.Mutex
+	scriptAudit         scriptAuditTrail
+
 	// The state is used as a fairly efficient way to cache information
 	// about the current best chain state that is returned to callers when
 	// requested.  It operates on the principle of MVCC such that any time a
@@ -237,6 +285,11 @@ type BlockChain struct {
 	stateLock     sync.RWMutex
 	stateSnapshot *BestState
 
+	// stateChanged is closed and replaced each time the state snapshot is
+	// replaced in order to notify callers that are waiting for a newer
+	// state.  It is protected by the state lock.
+	stateChanged chan struct{}
+
 	// The following caches are used to efficiently keep track of the
 	// current deployment threshold state of each rule change deployment.
 	//
@@ -683,14 +736,11 @@ func (b *BlockChain) connectBlock(node *blockNode, block, parent *dcrutil.Block,
 	// the header commitment merkle tree depending on the active agendas.  These
 	// are stored in the database below so that inclusion proofs can be
 	// generated for each commitment.
-	var hdrCommitmentLeaves []chainhash.Hash
-	hdrCommitmentsActive, err := b.isHeaderCommitmentsAgendaActive(node.parent)
+	hdrCommitmentVersion, err := b.headerCommitmentVersion(node.parent)
 	if err != nil {
 		return err
 	}
-	if hdrCommitmentsActive {
-		hdrCommitmentLeaves = hdrCommitments.v1Leaves()
-	}
+	hdrCommitmentLeaves := hdrCommitments.leaves(hdrCommitmentVersion)
 
 	// Generate a new best state snapshot that will be used to update the
 	// database and later memory if all database updates are successful.
@@ -788,6 +838,7 @@ func (b *BlockChain) connectBlock(node *blockNode, block, parent *dcrutil.Block,
 
 	// This node is now the end of the best chain.
 	b.bestChain.SetTip(node)
+	b.numSideChainBlocks--
 	b.index.MaybePruneCachedTips(node)
 
 	// Update the state for the best block.  Notice how this replaces the
@@ -795,9 +846,7 @@ func (b *BlockChain) connectBlock(node *blockNode, block, parent *dcrutil.Block,
 	// allows the old version to act as a snapshot which callers can use
 	// freely without needing to hold a lock for the duration.  See the
 	// comments on the state variable for more details.
-	b.stateLock.Lock()
-	b.stateSnapshot = state
-	b.stateLock.Unlock()
+	b.setBestState(state)
 
 	// Conditionally log target difficulty changes at retarget intervals.  Only
 	// log when the chain believes it is current since it is very noisy during
@@ -831,14 +880,29 @@ func (b *BlockChain) connectBlock(node *blockNode, block, parent *dcrutil.Block,
 			return err
 		}
 
-		// Notify of new tickets.
-		b.sendNotification(NTNewTickets,
-			&TicketNotificationsData{
-				Hash:            node.hash,
-				Height:          node.height,
-				StakeDifficulty: nextStakeDiff,
-				TicketsNew:      node.stakeNode.NewTickets(),
-			})
+		// Notify of new tickets along with the tickets that were voted,
+		// missed, expired, and revoked by the block.
+		tnd := &TicketNotificationsData{
+			Hash:            node.hash,
+			Height:          node.height,
+			StakeDifficulty: nextStakeDiff,
+			TicketsNew:      node.stakeNode.NewTickets(),
+		}
+		for _, undo := range node.stakeNode.UndoData() {
+			switch {
+			case undo.Spent:
+				tnd.TicketsVoted = append(tnd.TicketsVoted, undo.TicketHash)
+			case undo.Revoked:
+				tnd.TicketsRevoked = append(tnd.TicketsRevoked,
+					undo.TicketHash)
+			case undo.Expired:
+				tnd.TicketsExpired = append(tnd.TicketsExpired,
+					undo.TicketHash)
+			case undo.Missed:
+				tnd.TicketsMissed = append(tnd.TicketsMissed, undo.TicketHash)
+			}
+		}
+		b.sendNotification(NTNewTickets, tnd)
 	}
 
 	// Optimization:  Immediately prune the parent's stake node when it is no
@@ -969,15 +1033,14 @@ func (b *BlockChain) disconnectBlock(node *blockNode, block, parent *dcrutil.Blo
 
 	// This node's parent is now the end of the best chain.
 	b.bestChain.SetTip(node.parent)
+	b.numSideChainBlocks++
 
 	// Update the state for the best block.  Notice how this replaces the
 	// entire struct instead of updating the existing one.  This effectively
 	// allows the old version to act as a snapshot which callers can use
 	// freely without needing to hold a lock for the duration.  See the
 	// comments on the state variable for more details.
-	b.stateLock.Lock()
-	b.stateSnapshot = state
-	b.stateLock.Unlock()
+	b.setBestState(state)
 
 	// Notify the caller that the block was disconnected from the main
 	// chain.  The caller would typically want to react with actions such as
@@ -1197,7 +1260,7 @@ func (b *BlockChain) reorganizeChainInternal(target *blockNode) error {
 	// chain.  This entails performing several checks to verify each block can
 	// be connected without violating any consensus rules and updating the
 	// relevant information related to the current chain state.
-	var prevBlockAttached *dcrutil.Block
+	var prevBlockAttached, nextBlockToAttach *dcrutil.Block
 	for i, n := range attachNodes {
 		select {
 		case <-b.interrupt:
@@ -1208,11 +1271,17 @@ func (b *BlockChain) reorganizeChainInternal(target *blockNode) error {
 		// Grab the block to attach based on the node.  Use the fact that the
 		// parent of the block is either the fork point for the first node being
 		// attached or the previous one that was attached for subsequent blocks
-		// to optimize.
-		block, err := b.fetchBlockByNode(n)
-		if err != nil {
-			return err
+		// to optimize.  The block will already be loaded when it was loaded
+		// in order to prefetch its utxos.
+		block := nextBlockToAttach
+		if block == nil {
+			var err error
+			block, err = b.fetchBlockByNode(n)
+			if err != nil {
+				return err
+			}
 		}
+		nextBlockToAttach = nil
 		parent := forkBlock
 		if i > 0 {
 			parent = prevBlockAttached
@@ -1232,6 +1301,21 @@ func (b *BlockChain) reorganizeChainInternal(target *blockNode) error {
 			return err
 		}
 
+		// Load the utxos referenced by the next block to attach into the utxo
+		// cache concurrently with connecting this one when enabled.
+		if b.prefetchUtxos && i+1 < len(attachNodes) {
+			nextNode := attachNodes[i+1]
+			nextBlockToAttach, err = b.fetchBlockByNode(nextNode)
+			if err != nil {
+				return err
+			}
+			isNextTreasuryEnabled, err := b.isTreasuryAgendaActive(n)
+			if err != nil {
+				return err
+			}
+			b.prefetchInputUtxos(nextBlockToAttach, isNextTreasuryEnabled)
+		}
+
 		// Skip validation if the block has already been validated.  However,
 		// the utxo view still needs to be updated and the stxos and header
 		// commitment data are still needed.
@@ -1284,6 +1368,7 @@ func (b *BlockChain) reorganizeChainInternal(target *blockNode) error {
 				return err
 			}
 			b.index.SetStatusFlags(n, statusValidated)
+			b.maybeRecordSkippedScripts(n)
 		}
 
 		// Update the database and chain state.
@@ -1650,6 +1735,100 @@ func (b *BlockChain) IsCurrent() bool {
 	return isCurrent
 }
 
+// calcVerificationProgress returns an estimate of the verification progress of
+// the provided chain tip in the range [0, 1] based on the cumulative work of the
+// tip relative to the estimated total cumulative work of the network as of the
+// provided time.
+//
+// The estimated total work is the cumulative work of the provided best known
+// header plus the work expected to have been produced since its timestamp
+// assuming blocks are found at the target rate with the same difficulty.
+func calcVerificationProgress(params *chaincfg.Params, tip, bestHeader *blockNode, now time.Time) float64 {
+	estimatedWork := bestHeader.workSum
+	targetSecs := int64(params.TargetTimePerBlock / time.Second)
+	elapsedSecs := now.Unix() - bestHeader.timestamp
+	if targetSecs > 0 && elapsedSecs > targetSecs {
+		expectedBlocks := uint64(elapsedSecs / targetSecs)
+		remainingWork := primitives.CalcWork(bestHeader.bits)
+		remainingWork.MulUint64(expectedBlocks)
+		estimatedWork.Add(&remainingWork)
+	}
+	if estimatedWork.IsZero() {
+		return 0
+	}
+
+	tipWork := new(big.Float).SetInt(tip.workSum.ToBig())
+	totalWork := new(big.Float).SetInt(estimatedWork.ToBig())
+	progress, _ := tipWork.Quo(tipWork, totalWork).Float64()
+	return math.Min(progress, 1.0)
+}
+
+// VerificationProgress returns an estimate of how far along the chain is in
+// verifying the entire network's chain as a value in the range [0, 1].  It is
+// based on the cumulative work of the current best chain tip relative to the
+// cumulative work of the best known header plus the work expected to have been
+// produced since the timestamp of that header.
+//
+// Unlike estimates based solely on block heights, this accounts for blocks
+// that have not yet been announced and the fact that later blocks typically
+// require significantly more work to produce.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) VerificationProgress() float64 {
+	b.chainLock.RLock()
+	tip := b.bestChain.Tip()
+	b.chainLock.RUnlock()
+	bestHeader := b.index.BestHeader()
+	now := b.timeSource.AdjustedTime()
+	return calcVerificationProgress(b.chainParams, tip, bestHeader, now)
+}
+
+// setBestState replaces the current best state snapshot with the provided
+// state after assigning it the next sequence number and notifies any callers
+// that are waiting for a newer state.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) setBestState(state *BestState) {
+	b.stateLock.Lock()
+	if b.stateSnapshot != nil {
+		state.Sequence = b.stateSnapshot.Sequence + 1
+	}
+	b.stateSnapshot = state
+	if b.stateChanged != nil {
+		close(b.stateChanged)
+	}
+	b.stateChanged = make(chan struct{})
+	b.stateLock.Unlock()
+}
+
+// WaitForNewerBestState blocks until the sequence number of the current best
+// state snapshot is greater than the provided sequence number, or the provided
+// context is canceled, and returns the snapshot.  It returns immediately when
+// the current snapshot is already newer.
+//
+// This allows callers to cheaply determine when the best chain has changed
+// since they last observed it by passing the Sequence field of the snapshot
+// they observed.  The returned instance must be treated as immutable since it
+// is shared by all callers.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) WaitForNewerBestState(ctx context.Context, sequence uint64) (*BestState, error) {
+	for {
+		b.stateLock.RLock()
+		snapshot, changed := b.stateSnapshot, b.stateChanged
+		b.stateLock.RUnlock()
+		if snapshot.Sequence > sequence {
+			return snapshot, nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
 // BestSnapshot returns information about the current best chain block and
 // related state as of the current point in time.  The returned instance must be
 // treated as immutable since it is shared by all callers.
@@ -2259,6 +2438,28 @@ type Config struct {
 	// due to the old fork rejection semantics.
 	AllowOldForks bool
 
+	// MaxReorgDepth is the maximum number of blocks that may be disconnected
+	// from the main chain by an automatic chain reorganization.  Deeper
+	// reorganizations are paused, and a NTDeepReorgPaused notification is
+	// sent, until they are approved via ApproveDeepReorg.
+	//
+	// Manual operations such as invalidating and reconsidering blocks are not
+	// subject to the limit.
+	//
+	// This field may be zero to impose no limit.
+	MaxReorgDepth int64
+
+	// MaxSideChainBlocks is the maximum number of blocks that are not part of
+	// the main chain that may have their data available.  When the limit is
+	// exceeded, side chain blocks are evicted starting with the branches that
+	// fork from the main chain the furthest before its tip.  Evicted blocks are
+	// no longer considered for reorganization unless their data is received
+	// again.  Blocks that are part of a branch with more work than the main
+	// chain are never evicted.
+	//
+	// This field may be zero to impose no limit.
+	MaxSideChainBlocks int64
+
 	// AssumeValid is the hash of a block that has been externally verified to
 	// be valid.  It allows several validation checks to be skipped for blocks
 	// that are both an ancestor of the assumed valid block and an ancestor of
@@ -2267,6 +2468,12 @@ type Config struct {
 	// This field may not be set for networks that do not require it.
 	AssumeValid chainhash.Hash
 
+	// AuditSkippedScripts enables recording an audit entry for every block
+	// that is connected to the main chain without executing its transaction
+	// scripts due to being an ancestor of the assumed valid block so that
+	// the scripts may later be verified via AuditSkippedScripts.
+	AuditSkippedScripts bool
+
 	// TimeSource defines the median time source to use for things such as
 	// block processing and determining whether or not the chain is current.
 	//
@@ -2293,6 +2500,16 @@ type Config struct {
 	// signature cache.
 	SigCache *txscript.SigCache
 
+	// ScriptCache defines a script execution cache to use when validating
+	// transaction scripts.  Much like the signature cache, this is typically
+	// most useful when individual transactions are already being validated
+	// prior to their inclusion in a block, in which case the scripts for
+	// transactions that were already validated are not executed again.
+	//
+	// This field can be nil if the caller is not interested in using a
+	// script cache.
+	ScriptCache *ScriptCache
+
 	// SubsidyCache defines a subsidy cache to use when calculating and
 	// validating block and vote subsidies.
 	//
@@ -2310,6 +2527,13 @@ type Config struct {
 	//
 	// This field is required.
 	UtxoCache UtxoCacher
+
+	// PrefetchUtxos enables loading the utxos referenced by the next block to
+	// connect into the utxo cache concurrently with the validation of the
+	// current block when connecting multiple blocks, such as during initial
+	// block download.  This reduces the time spent waiting on random reads
+	// from the utxo database.
+	PrefetchUtxos bool
 }
 
 // New returns a BlockChain instance using the provided configuration details.
@@ -2373,7 +2597,11 @@ func New(ctx context.Context, config *Config) (*BlockChain, error) {
 
 	b := BlockChain{
 		assumeValid:                   config.AssumeValid,
+		auditSkippedScripts:           config.AuditSkippedScripts,
 		allowOldForks:                 allowOldForks,
+		maxReorgDepth:                 config.MaxReorgDepth,
+		maxSideChainBlocks:            config.MaxSideChainBlocks,
+		prefetchUtxos:                 config.PrefetchUtxos,
 		expectedBlocksInTwoWeeks:      expectedBlksInTwoWeeks,
 		deploymentVers:                deploymentVers,
 		minKnownWork:                  minKnownWork,
@@ -2383,6 +2611,7 @@ func New(ctx context.Context, config *Config) (*BlockChain, error) {
 		timeSource:                    config.TimeSource,
 		notifications:                 config.Notifications,
 		sigCache:                      config.SigCache,
+		scriptCache:                   config.ScriptCache,
 		interrupt:                     ctx.Done(),
 		indexSubscriber:               config.IndexSubscriber,
 		subsidyCache:                  subsidyCache,
@@ -2397,6 +2626,7 @@ func New(ctx context.Context, config *Config) (*BlockChain, error) {
 		calcVoterVersionIntervalCache: make(map[[chainhash.HashSize]byte]uint32),
 		calcStakeVersionCache:         make(map[[chainhash.HashSize]byte]uint32),
 		utxoCache:                     config.UtxoCache,
+		utxoBackend:                   config.UtxoBackend,
 	}
 	b.pruner = newChainPruner(&b)
 
@@ -2407,6 +2637,10 @@ func New(ctx context.Context, config *Config) (*BlockChain, error) {
 		return nil, err
 	}
 
+	// Count the blocks that are not part of the main chain and have their
+	// data available so the count can be updated incrementally from here on.
+	b.numSideChainBlocks = int64(len(b.sideChainNodes()))
+
 	// Initialize the UTXO state.  This entails running any database migrations
 	// as necessary as well as initializing the UTXO cache.
 	if err := b.utxoCache.Initialize(ctx, &b, b.bestChain.tip()); err != nil {
@@ -2462,6 +2696,15 @@ func New(ctx context.Context, config *Config) (*BlockChain, error) {
 		b.notifications = curNtfnCallback
 	}
 
+	// Rebuild the blocks that are pending a script audit from the main chain
+	// ancestors of the assumed valid block that have not been audited yet
+	// since they are not otherwise tracked across restarts.
+	if b.auditSkippedScripts {
+		if err := b.loadScriptAuditTrail(); err != nil {
+			return nil, err
+		}
+	}
+
 	bestHdr := b.index.BestHeader()
 	log.Infof("Best known header: height %d, hash %v", bestHdr.height,
 		bestHdr.hash)
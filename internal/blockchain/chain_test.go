@@ -8,6 +8,7 @@ package blockchain
 import (
 	"bytes"
 	"compress/bzip2"
+	"context"
 	"encoding/gob"
 	"errors"
 	"fmt"
@@ -799,3 +800,257 @@ func TestLocateInventory(t *testing.T) {
 		}
 	}
 }
+
+// TestHeaderIteration ensures that iterating headers via the ForEachHeader and
+// FilterHeaders functions behaves as expected.
+func TestHeaderIteration(t *testing.T) {
+	// Construct a synthetic block chain with a block index consisting of
+	// the following structure.
+	// 	genesis -> 1 -> 2 -> ... -> 8 -> 9  -> 10
+	// 	                             \-> 9a -> 10a
+	tip := branchTip
+	chain := newFakeChain(chaincfg.MainNetParams())
+	branch0Nodes := chainedFakeNodes(chain.bestChain.Genesis(), 10)
+	branch1Nodes := chainedFakeNodes(branch0Nodes[7], 2)
+	for _, node := range branch0Nodes {
+		chain.index.AddNode(node)
+	}
+	for _, node := range branch1Nodes {
+		chain.index.AddNode(node)
+	}
+	chain.bestChain.SetTip(tip(branch0Nodes))
+	genesis := chain.bestChain.Genesis()
+	oddHeight := func(header *wire.BlockHeader) bool {
+		return header.Height%2 == 1
+	}
+
+	tests := []struct {
+		name       string
+		start      *blockNode          // block to start from
+		dir        HeaderIterDirection // direction to iterate
+		maxHeaders uint32              // max headers, 0 = no limit
+		filter     HeaderFilterFunc    // filter to apply
+		headers    []wire.BlockHeader  // expected headers
+		err        error               // expected error
+	}{{
+		name:    "backward from main chain tip",
+		start:   tip(branch0Nodes),
+		dir:     HeaderIterBackward,
+		headers: append(nodeHeaders(branch0Nodes, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0), genesis.Header()),
+	}, {
+		name:       "backward from side chain tip with limit",
+		start:      tip(branch1Nodes),
+		dir:        HeaderIterBackward,
+		maxHeaders: 4,
+		headers: []wire.BlockHeader{branch1Nodes[1].Header(),
+			branch1Nodes[0].Header(), branch0Nodes[7].Header(),
+			branch0Nodes[6].Header()},
+	}, {
+		name:       "backward with filter and limit",
+		start:      tip(branch0Nodes),
+		dir:        HeaderIterBackward,
+		maxHeaders: 3,
+		filter:     oddHeight,
+		headers:    nodeHeaders(branch0Nodes, 8, 6, 4),
+	}, {
+		name:    "forward from genesis with filter",
+		start:   genesis,
+		dir:     HeaderIterForward,
+		filter:  oddHeight,
+		headers: nodeHeaders(branch0Nodes, 0, 2, 4, 6, 8),
+	}, {
+		name:       "forward from main chain block with limit",
+		start:      branch0Nodes[5],
+		dir:        HeaderIterForward,
+		maxHeaders: 3,
+		headers:    nodeHeaders(branch0Nodes, 5, 6, 7),
+	}, {
+		name:    "forward from main chain tip",
+		start:   tip(branch0Nodes),
+		dir:     HeaderIterForward,
+		headers: nodeHeaders(branch0Nodes, 9),
+	}, {
+		name:  "forward from side chain block",
+		start: branch1Nodes[0],
+		dir:   HeaderIterForward,
+		err:   errNotInMainChain(""),
+	}, {
+		name:  "unknown block",
+		start: chainedFakeNodes(nil, 1)[0],
+		dir:   HeaderIterBackward,
+		err:   ErrUnknownBlock,
+	}}
+
+	for _, test := range tests {
+		headers, err := chain.FilterHeaders(&test.start.hash, test.dir,
+			test.maxHeaders, test.filter)
+		switch {
+		case test.err == nil && err != nil:
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		case isNotInMainChainErr(test.err) && !isNotInMainChainErr(err):
+			t.Errorf("%s: mismatched err -- got %v, want %T", test.name,
+				err, test.err)
+			continue
+		case errors.Is(test.err, ErrUnknownBlock) &&
+			!errors.Is(err, ErrUnknownBlock):
+
+			t.Errorf("%s: mismatched err -- got %v, want %v", test.name,
+				err, test.err)
+			continue
+		}
+		if !reflect.DeepEqual(headers, test.headers) {
+			t.Errorf("%s: unexpected headers -- got %v, want %v",
+				test.name, headers, test.headers)
+			continue
+		}
+	}
+
+	// Ensure iteration stops as soon as the provided function returns false.
+	var numIterated int
+	err := chain.ForEachHeader(&tip(branch0Nodes).hash, HeaderIterBackward,
+		func(header *wire.BlockHeader) bool {
+			numIterated++
+			return header.Height > 7
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if numIterated != 4 {
+		t.Fatalf("unexpected number of iterated headers -- got %d, want %d",
+			numIterated, 4)
+	}
+}
+
+// TestCalcVerificationProgress ensures the verification progress estimate based
+// on cumulative work and the time since the best known header is calculated as
+// expected.
+func TestCalcVerificationProgress(t *testing.T) {
+	// Construct a synthetic chain of nodes that all have the same difficulty as
+	// the genesis block so each block contributes the same amount of work.
+	params := chaincfg.RegNetParams()
+	targetTime := params.TargetTimePerBlock
+	genesis := newBlockNode(&params.GenesisBlock.Header, nil)
+	nodes := []*blockNode{genesis}
+	tip := genesis
+	for i := 1; i < 100; i++ {
+		blockTime := time.Unix(tip.timestamp, 0).Add(targetTime)
+		tip = newFakeNode(tip, 1, 1, genesis.bits, blockTime)
+		nodes = append(nodes, tip)
+	}
+	bestHeader := nodes[len(nodes)-1]
+	headerTime := time.Unix(bestHeader.timestamp, 0)
+
+	tests := []struct {
+		name     string     // test description
+		tip      *blockNode // current chain tip
+		now      time.Time  // current time
+		expected float64    // expected progress
+	}{{
+		name:     "fully synced to best header at header time",
+		tip:      bestHeader,
+		now:      headerTime,
+		expected: 1,
+	}, {
+		name:     "fully synced to best header less than a block later",
+		tip:      bestHeader,
+		now:      headerTime.Add(targetTime - time.Second),
+		expected: 1,
+	}, {
+		name:     "half synced to best header at header time",
+		tip:      nodes[49],
+		now:      headerTime,
+		expected: 0.5,
+	}, {
+		name:     "fully synced to best header with blocks expected since",
+		tip:      bestHeader,
+		now:      headerTime.Add(targetTime * 100),
+		expected: 0.5,
+	}, {
+		name:     "half synced to best header with blocks expected since",
+		tip:      nodes[49],
+		now:      headerTime.Add(targetTime * 100),
+		expected: 0.25,
+	}, {
+		name:     "time before best header",
+		tip:      nodes[49],
+		now:      headerTime.Add(-targetTime * 10),
+		expected: 0.5,
+	}}
+
+	for _, test := range tests {
+		got := calcVerificationProgress(params, test.tip, bestHeader, test.now)
+		if got != test.expected {
+			t.Errorf("%q: unexpected progress -- got %v, want %v", test.name,
+				got, test.expected)
+		}
+	}
+}
+
+// TestWaitForNewerBestState ensures the best state sequence number increases
+// as the best chain changes and that waiting for a newer best state behaves as
+// expected.
+func TestWaitForNewerBestState(t *testing.T) {
+	// Create a test harness initialized with the genesis block as the tip.
+	params := chaincfg.RegNetParams()
+	g := newChaingenHarness(t, params)
+
+	// Start waiting for a newer state prior to connecting any blocks.
+	initialSeq := g.chain.BestSnapshot().Sequence
+	type waitResult struct {
+		snapshot *BestState
+		err      error
+	}
+	resultChan := make(chan waitResult, 1)
+	go func() {
+		snapshot, err := g.chain.WaitForNewerBestState(context.Background(),
+			initialSeq)
+		resultChan <- waitResult{snapshot, err}
+	}()
+
+	// Connect some blocks and ensure the waiter is notified with a newer state
+	// and that the sequence number increased by one for each block.
+	const numBlocks = 2
+	g.AdvanceToHeight(numBlocks, 0)
+	select {
+	case result := <-resultChan:
+		if result.err != nil {
+			t.Fatalf("unexpected error waiting for newer state: %v",
+				result.err)
+		}
+		if result.snapshot.Sequence <= initialSeq {
+			t.Fatalf("unexpected sequence -- got %d, want > %d",
+				result.snapshot.Sequence, initialSeq)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("timeout waiting for newer state")
+	}
+	snapshot := g.chain.BestSnapshot()
+	if snapshot.Sequence != initialSeq+numBlocks {
+		t.Fatalf("unexpected sequence -- got %d, want %d", snapshot.Sequence,
+			initialSeq+numBlocks)
+	}
+
+	// Ensure waiting for a state newer than an older sequence returns the
+	// current state immediately.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	got, err := g.chain.WaitForNewerBestState(ctx, initialSeq)
+	if err != nil {
+		t.Fatalf("unexpected error waiting for newer state: %v", err)
+	}
+	if got != snapshot {
+		t.Fatalf("unexpected snapshot -- got sequence %d, want %d",
+			got.Sequence, snapshot.Sequence)
+	}
+
+	// Ensure waiting for a state newer than the current one returns an error
+	// once the context is done.
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err = g.chain.WaitForNewerBestState(ctx, snapshot.Sequence)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("unexpected error -- got %v, want %v", err,
+			context.DeadlineExceeded)
+	}
+}
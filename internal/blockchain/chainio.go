@@ -83,6 +83,11 @@ var (
 	// deployment version.
 	deploymentVerKeyName = []byte("deploymentver")
 
+	// scriptAuditKeyName is the name of the db key used to store the height
+	// of the last main chain block that was audited after being connected
+	// without executing its transaction scripts.
+	scriptAuditKeyName = []byte("scriptaudit")
+
 	// spendJournalBucketName is the name of the db bucket used to house
 	// transactions outputs that are spent in each block.
 	spendJournalBucketName = []byte("spendjournalv3")
@@ -745,17 +750,11 @@ func serializeSpendJournalEntry(stxos []spentTxOut) ([]byte, error) {
 	return serialized, nil
 }
 
-// dbFetchSpendJournalEntry fetches the spend journal entry for the passed
-// block and deserializes it into a slice of spent txout entries.  The provided
-// view MUST have the utxos referenced by all of the transactions available for
-// the passed block since that information is required to reconstruct the spent
-// txouts.
-func dbFetchSpendJournalEntry(dbTx database.Tx, block *dcrutil.Block, isTreasuryEnabled bool) ([]spentTxOut, error) {
+// spendJournalTxns returns the transactions of the passed block that are able
+// to spend outputs and therefore have their spent txouts recorded in the spend
+// journal entry for the block in the order they are recorded.
+func spendJournalTxns(msgBlock *wire.MsgBlock, isTreasuryEnabled bool) []*wire.MsgTx {
 	// Exclude the coinbase transaction since it can't spend anything.
-	spendBucket := dbTx.Metadata().Bucket(spendJournalBucketName)
-	serialized := spendBucket.Get(block.Hash()[:])
-	msgBlock := block.MsgBlock()
-
 	blockTxns := make([]*wire.MsgTx, 0, len(msgBlock.STransactions)+
 		len(msgBlock.Transactions[1:]))
 	if len(msgBlock.STransactions) > 0 && isTreasuryEnabled {
@@ -770,6 +769,18 @@ func dbFetchSpendJournalEntry(dbTx database.Tx, block *dcrutil.Block, isTreasury
 		blockTxns = append(blockTxns, msgBlock.STransactions...)
 	}
 	blockTxns = append(blockTxns, msgBlock.Transactions[1:]...)
+	return blockTxns
+}
+
+// dbFetchSpendJournalEntry fetches the spend journal entry for the passed
+// block and deserializes it into a slice of spent txout entries.  The provided
+// view MUST have the utxos referenced by all of the transactions available for
+// the passed block since that information is required to reconstruct the spent
+// txouts.
+func dbFetchSpendJournalEntry(dbTx database.Tx, block *dcrutil.Block, isTreasuryEnabled bool) ([]spentTxOut, error) {
+	spendBucket := dbTx.Metadata().Bucket(spendJournalBucketName)
+	serialized := spendBucket.Get(block.Hash()[:])
+	blockTxns := spendJournalTxns(block.MsgBlock(), isTreasuryEnabled)
 	if len(blockTxns) > 0 && len(serialized) == 0 {
 		panicf("missing spend journal data for %s", block.Hash())
 	}
@@ -1262,6 +1273,27 @@ func dbFetchDeploymentVer(dbTx database.Tx) uint32 {
 	return byteOrder.Uint32(serializedData)
 }
 
+// dbPutScriptAuditHeight uses an existing database transaction to update the
+// height of the last audited block that was connected without executing its
+// transaction scripts.
+func dbPutScriptAuditHeight(dbTx database.Tx, height int64) error {
+	serializedData := make([]byte, 4)
+	byteOrder.PutUint32(serializedData, uint32(height))
+	return dbTx.Metadata().Put(scriptAuditKeyName, serializedData)
+}
+
+// dbFetchScriptAuditHeight uses an existing database transaction to fetch the
+// height of the last audited block that was connected without executing its
+// transaction scripts.  Zero is returned when no blocks have been audited.
+func dbFetchScriptAuditHeight(dbTx database.Tx) int64 {
+	meta := dbTx.Metadata()
+	serializedData := meta.Get(scriptAuditKeyName)
+	if len(serializedData) == 0 {
+		return 0
+	}
+	return int64(byteOrder.Uint32(serializedData))
+}
+
 // createChainState initializes both the database and the chain state to the
 // genesis block.  This includes creating the necessary buckets and inserting
 // the genesis block, so it must only be called on an uninitialized database.
@@ -1738,11 +1770,11 @@ func (b *BlockChain) initChainState(ctx context.Context,
 			}
 		}
 
-		b.stateSnapshot = newBestState(tip, blockSize, numTxns,
+		b.setBestState(newBestState(tip, blockSize, numTxns,
 			state.totalTxns, tip.CalcPastMedianTime(),
 			state.totalSubsidy, uint32(tip.stakeNode.PoolSize()),
 			nextStakeDiff, tip.stakeNode.ExpiringNextBlock(), tip.stakeNode.Winners(),
-			tip.stakeNode.MissedTickets(), tip.stakeNode.FinalState())
+			tip.stakeNode.MissedTickets(), tip.stakeNode.FinalState()))
 
 		return nil
 	})
@@ -6,10 +6,12 @@ package blockchain
 
 import (
 	"bytes"
+	"fmt"
 	"math"
 	"sort"
 
 	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/wire"
 )
 
 // nodeHeightSorter implements sort.Interface to allow a slice of nodes to
@@ -269,3 +271,87 @@ func (b *BlockChain) IsKnownInvalidBlock(hash *chainhash.Hash) bool {
 
 	return b.index.NodeStatus(node).KnownInvalid()
 }
+
+// HeaderIterDirection specifies the direction in which block headers are
+// iterated.
+type HeaderIterDirection uint8
+
+const (
+	// HeaderIterBackward iterates headers from a block toward the genesis
+	// block by following the previous block of each header.  It may start
+	// from blocks in both the main chain and side chains.
+	HeaderIterBackward HeaderIterDirection = iota
+
+	// HeaderIterForward iterates headers from a block in the main chain
+	// toward the tip of the main chain.
+	HeaderIterForward
+)
+
+// HeaderFilterFunc defines a function that is used to filter block headers
+// while iterating them.  It returns whether or not the header should be
+// included.
+type HeaderFilterFunc func(header *wire.BlockHeader) bool
+
+// ForEachHeader invokes the provided function with the header of the block
+// identified by the given hash followed by the headers of each subsequent block
+// in the given direction until either the function returns false or there are
+// no more blocks.
+//
+// The headers are loaded from the block index, so no block data is read from
+// the database and the headers of blocks for which the data is not yet
+// available are included.
+//
+// Iterating forward requires the block to be in the main chain and stops early
+// if the main chain is reorganized such that the most recently iterated block
+// is no longer part of it.
+//
+// This function is safe for concurrent access.  However, the chain state lock
+// is not held while the provided function is invoked, so it may safely query
+// the chain.
+func (b *BlockChain) ForEachHeader(hash *chainhash.Hash, dir HeaderIterDirection, fn func(header *wire.BlockHeader) bool) error {
+	node := b.index.LookupNode(hash)
+	if node == nil {
+		return unknownBlockError(hash)
+	}
+	if dir == HeaderIterForward && !b.bestChain.Contains(node) {
+		str := fmt.Sprintf("block %s is not in the main chain", hash)
+		return errNotInMainChain(str)
+	}
+
+	for node != nil {
+		header := node.Header()
+		if !fn(&header) {
+			break
+		}
+
+		switch dir {
+		case HeaderIterForward:
+			node = b.bestChain.Next(node)
+		default:
+			node = node.parent
+		}
+	}
+	return nil
+}
+
+// FilterHeaders returns up to the provided maximum number of headers that
+// satisfy the provided filter starting with the header of the block identified
+// by the given hash and iterating in the given direction.  A nil filter
+// includes all headers and a maximum of zero imposes no limit.
+//
+// See ForEachHeader for more details on the iteration.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) FilterHeaders(hash *chainhash.Hash, dir HeaderIterDirection, maxHeaders uint32, filter HeaderFilterFunc) ([]wire.BlockHeader, error) {
+	var headers []wire.BlockHeader
+	err := b.ForEachHeader(hash, dir, func(header *wire.BlockHeader) bool {
+		if filter == nil || filter(header) {
+			headers = append(headers, *header)
+		}
+		return maxHeaders == 0 || uint32(len(headers)) < maxHeaders
+	})
+	if err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
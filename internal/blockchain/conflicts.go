@@ -0,0 +1,116 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+// UnminedTxSource provides access to a set of unconfirmed transactions, such as
+// those in a transaction pool, for the purposes of determining how they would
+// be affected by a block.
+type UnminedTxSource interface {
+	// HaveTransaction returns whether or not the passed transaction hash
+	// exists in the source.
+	HaveTransaction(hash *chainhash.Hash) bool
+
+	// SpendingTx returns the transaction in the source that spends the
+	// provided outpoint or nil when no transaction in the source spends it.
+	SpendingTx(outpoint wire.OutPoint) *dcrutil.Tx
+}
+
+// BlockConflicts houses the results of checking a block for conflicts with a
+// set of unconfirmed transactions.  All hashes are in the order they were
+// discovered, which is deterministic for a given block and source.
+type BlockConflicts struct {
+	// Mined houses the hashes of the transactions in the source that are
+	// also included in the block.
+	Mined []chainhash.Hash
+
+	// DoubleSpent houses the hashes of the transactions in the source that
+	// spend at least one output which is also spent by a different
+	// transaction in the block.
+	DoubleSpent []chainhash.Hash
+
+	// Evicted houses the hashes of all transactions in the source that would
+	// no longer be valid once the block is connected.  That is, all of the
+	// double spent transactions along with all transactions that depend on
+	// them, recursively.
+	Evicted []chainhash.Hash
+}
+
+// CheckBlockConflicts determines how the unconfirmed transactions in the
+// provided source would be affected by connecting the passed block without
+// actually connecting it.  That is, it reports which of the transactions would
+// be removed due to being included in the block, which are double spent by
+// transactions in the block, and which would be evicted as a result.
+//
+// Note that transactions that depend on the transactions included in the block
+// remain valid and thus are not reported.  Inputs with null outpoints, such as
+// those of coinbases, stakebases, and treasury spends, never conflict.  Also, no validation of the block
+// itself is performed, so callers that need to ensure the block is valid, such
+// as miners validating externally produced blocks, must do so separately, for
+// example via CheckConnectBlockTemplate.
+func CheckBlockConflicts(block *dcrutil.Block, source UnminedTxSource) *BlockConflicts {
+	var conflicts BlockConflicts
+	doubleSpent := make(map[chainhash.Hash]struct{})
+	evicted := make(map[chainhash.Hash]struct{})
+
+	// evict marks the provided transaction along with all transactions in the
+	// source that depend on it, recursively, as evicted.  Only the regular
+	// tree is considered when looking for dependents since that is the only
+	// tree that unconfirmed transactions are able to spend from.
+	var evict func(tx *dcrutil.Tx)
+	evict = func(tx *dcrutil.Tx) {
+		if _, ok := evicted[*tx.Hash()]; ok {
+			return
+		}
+		evicted[*tx.Hash()] = struct{}{}
+		conflicts.Evicted = append(conflicts.Evicted, *tx.Hash())
+
+		outpoint := wire.OutPoint{Hash: *tx.Hash(), Tree: wire.TxTreeRegular}
+		for i := range tx.MsgTx().TxOut {
+			outpoint.Index = uint32(i)
+			if redeemer := source.SpendingTx(outpoint); redeemer != nil {
+				evict(redeemer)
+			}
+		}
+	}
+
+	checkTxns := func(txns []*dcrutil.Tx) {
+		for _, tx := range txns {
+			if source.HaveTransaction(tx.Hash()) {
+				conflicts.Mined = append(conflicts.Mined, *tx.Hash())
+			}
+
+			for _, txIn := range tx.MsgTx().TxIn {
+				// Null outpoints, such as those of coinbases, treasurybases,
+				// stakebases, and treasury spends, do not reference any
+				// outputs and therefore never conflict.
+				if isNullOutpoint(&txIn.PreviousOutPoint) {
+					continue
+				}
+
+				spender := source.SpendingTx(txIn.PreviousOutPoint)
+				if spender == nil || spender.Hash().IsEqual(tx.Hash()) {
+					continue
+				}
+				if _, ok := doubleSpent[*spender.Hash()]; ok {
+					continue
+				}
+				doubleSpent[*spender.Hash()] = struct{}{}
+				conflicts.DoubleSpent = append(conflicts.DoubleSpent,
+					*spender.Hash())
+				evict(spender)
+			}
+		}
+	}
+	checkTxns(block.Transactions())
+	checkTxns(block.STransactions())
+
+	return &conflicts
+}
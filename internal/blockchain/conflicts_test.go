@@ -0,0 +1,129 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+// fakeUnminedTxSource provides a simple implementation of the UnminedTxSource
+// interface backed by a set of transactions for use in tests.
+type fakeUnminedTxSource struct {
+	txns      map[chainhash.Hash]*dcrutil.Tx
+	outpoints map[wire.OutPoint]*dcrutil.Tx
+}
+
+// newFakeUnminedTxSource returns a fake unmined transaction source that houses
+// the provided transactions.
+func newFakeUnminedTxSource(txns ...*dcrutil.Tx) *fakeUnminedTxSource {
+	source := &fakeUnminedTxSource{
+		txns:      make(map[chainhash.Hash]*dcrutil.Tx),
+		outpoints: make(map[wire.OutPoint]*dcrutil.Tx),
+	}
+	for _, tx := range txns {
+		source.txns[*tx.Hash()] = tx
+		for _, txIn := range tx.MsgTx().TxIn {
+			source.outpoints[txIn.PreviousOutPoint] = tx
+		}
+	}
+	return source
+}
+
+// HaveTransaction returns whether or not the passed transaction hash exists in
+// the fake source.
+func (s *fakeUnminedTxSource) HaveTransaction(hash *chainhash.Hash) bool {
+	_, ok := s.txns[*hash]
+	return ok
+}
+
+// SpendingTx returns the transaction in the fake source that spends the
+// provided outpoint, if any.
+func (s *fakeUnminedTxSource) SpendingTx(outpoint wire.OutPoint) *dcrutil.Tx {
+	return s.outpoints[outpoint]
+}
+
+// TestCheckBlockConflicts ensures that checking a block for conflicts with a
+// set of unconfirmed transactions reports the expected mined, double spent,
+// and evicted transactions.
+func TestCheckBlockConflicts(t *testing.T) {
+	t.Parallel()
+
+	// spendTx returns a transaction that spends the provided outpoints and
+	// has the given number of outputs.  The value of the output is used to
+	// ensure transactions that spend the same outpoints have unique hashes.
+	spendTx := func(value int64, numOutputs int, prevOuts ...wire.OutPoint) *dcrutil.Tx {
+		tx := wire.NewMsgTx()
+		for i := range prevOuts {
+			tx.AddTxIn(wire.NewTxIn(&prevOuts[i], 0, nil))
+		}
+		for i := 0; i < numOutputs; i++ {
+			tx.AddTxOut(wire.NewTxOut(value, nil))
+		}
+		return dcrutil.NewTx(tx)
+	}
+	outpoint := func(tx *dcrutil.Tx, index uint32) wire.OutPoint {
+		return wire.OutPoint{Hash: *tx.Hash(), Index: index}
+	}
+	confirmed1 := wire.OutPoint{Hash: chainhash.Hash{0x01}}
+	confirmed2 := wire.OutPoint{Hash: chainhash.Hash{0x02}}
+	confirmed3 := wire.OutPoint{Hash: chainhash.Hash{0x03}}
+
+	// Create unconfirmed transactions such that:
+	//
+	// - minedTx is included in the block and has a dependent which remains
+	//   valid
+	// - conflictTx double spends a transaction in the block and has a chain of
+	//   dependents that are evicted as a result
+	// - unrelatedTx is not affected by the block
+	// - voteTx has a null stakebase input like the coinbase of the block and
+	//   is not affected by the block
+	minedTx := spendTx(1, 1, confirmed1)
+	minedChildTx := spendTx(1, 1, outpoint(minedTx, 0))
+	conflictTx := spendTx(1, 2, confirmed2)
+	conflictChildTx := spendTx(1, 1, outpoint(conflictTx, 1))
+	conflictGrandchildTx := spendTx(1, 1, outpoint(conflictChildTx, 0))
+	unrelatedTx := spendTx(1, 1, confirmed3)
+	nullOutpoint := wire.OutPoint{Index: wire.MaxPrevOutIndex}
+	confirmedTicket := wire.OutPoint{Hash: chainhash.Hash{0x04},
+		Tree: wire.TxTreeStake}
+	voteTx := spendTx(1, 1, nullOutpoint, confirmedTicket)
+	source := newFakeUnminedTxSource(minedTx, minedChildTx, conflictTx,
+		conflictChildTx, conflictGrandchildTx, unrelatedTx, voteTx)
+
+	// Create a block with a coinbase, the mined transaction, and a
+	// transaction that double spends the conflicting transaction.
+	coinbase := spendTx(1, 1, nullOutpoint)
+	blockConflictTx := spendTx(2, 1, confirmed2)
+	block := dcrutil.NewBlock(&wire.MsgBlock{
+		Transactions: []*wire.MsgTx{coinbase.MsgTx(), minedTx.MsgTx(),
+			blockConflictTx.MsgTx()},
+	})
+
+	got := CheckBlockConflicts(block, source)
+	want := &BlockConflicts{
+		Mined:       []chainhash.Hash{*minedTx.Hash()},
+		DoubleSpent: []chainhash.Hash{*conflictTx.Hash()},
+		Evicted: []chainhash.Hash{*conflictTx.Hash(),
+			*conflictChildTx.Hash(), *conflictGrandchildTx.Hash()},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected conflicts -- got %+v, want %+v", got, want)
+	}
+
+	// Ensure a block with no transactions in common with the source reports
+	// no conflicts.
+	block = dcrutil.NewBlock(&wire.MsgBlock{
+		Transactions: []*wire.MsgTx{coinbase.MsgTx()},
+	})
+	got = CheckBlockConflicts(block, source)
+	if !reflect.DeepEqual(got, &BlockConflicts{}) {
+		t.Fatalf("unexpected conflicts -- got %+v, want none", got)
+	}
+}
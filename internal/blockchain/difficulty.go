@@ -1229,3 +1229,182 @@ func (b *BlockChain) EstimateNextStakeDifficulty(hash *chainhash.Hash, newTicket
 	b.chainLock.Unlock()
 	return estimate, err
 }
+
+// StakeDiffProjection houses the projected stake difficulty and ticket pool
+// size for the first block of a future stake difficulty retarget interval.
+type StakeDiffProjection struct {
+	Height    int64
+	StakeDiff int64
+	PoolSize  uint32
+}
+
+// StakeDiffForecast houses the results of simulating the stake difficulty
+// algorithm forward for a number of retarget intervals.
+type StakeDiffForecast struct {
+	// TicketsPerInterval is the number of tickets assumed to be purchased
+	// during each retarget interval of the simulation.
+	TicketsPerInterval int64
+
+	// Projections houses the projected stake difficulty for each simulated
+	// retarget interval in order of height.
+	Projections []StakeDiffProjection
+}
+
+// forecastStakeDifficulty simulates the stake difficulty algorithm forward from
+// the passed node for the provided number of retarget intervals by pretending
+// the provided number of tickets will be purchased in every interval, spread as
+// evenly as possible across the blocks of each interval.  A negative number of
+// tickets signifies the number of tickets that were purchased in the most
+// recent complete interval.
+//
+// The simulation assumes every block includes the maximum number of votes and
+// does not account for tickets that expire or are missed, so the projected
+// ticket pool sizes, and therefore stake difficulties, become less accurate the
+// further into the future they are.
+//
+// The stake difficulty algorithm is selected based on the rules active as of
+// the passed node.
+//
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) forecastStakeDifficulty(curNode *blockNode, numIntervals, ticketsPerInterval int64) (*StakeDiffForecast, error) {
+	if numIntervals < 0 {
+		return nil, fmt.Errorf("number of intervals must not be less than "+
+			"zero - got %d", numIntervals)
+	}
+
+	// Default to the number of tickets purchased in the most recent complete
+	// interval when requested.
+	intervalSize := b.chainParams.StakeDiffWindowSize
+	curHeight := curNode.height
+	if ticketsPerInterval < 0 {
+		ticketsPerInterval = 0
+		lastIntervalEnd := curHeight - (curHeight+1)%intervalSize
+		if lastIntervalEnd >= intervalSize-1 {
+			ticketsPerInterval = b.sumPurchasedTickets(
+				curNode.Ancestor(lastIntervalEnd), intervalSize)
+		}
+	}
+
+	// Ensure the specified number of tickets is possible.
+	maxTicketsPerBlock := int64(b.chainParams.MaxFreshStakePerBlock)
+	maxTicketsPerInterval := intervalSize * maxTicketsPerBlock
+	if ticketsPerInterval > maxTicketsPerInterval {
+		return nil, fmt.Errorf("unable to forecast the stake difficulty "+
+			"with %d tickets per interval since it is more than the "+
+			"maximum of %d", ticketsPerInterval, maxTicketsPerInterval)
+	}
+
+	forecast := &StakeDiffForecast{
+		TicketsPerInterval: ticketsPerInterval,
+		Projections:        make([]StakeDiffProjection, 0, numIntervals),
+	}
+	if numIntervals == 0 {
+		return forecast, nil
+	}
+
+	// Determine which stake difficulty algorithm is in effect.  Note that the
+	// agenda state can't change for the simulated blocks since it is only
+	// possible for it to become active via votes.
+	calcStakeDiff := func(node *blockNode) (int64, error) {
+		return b.calcNextRequiredStakeDifficultyV2(node), nil
+	}
+	const deploymentID = chaincfg.VoteIDSDiffAlgorithm
+	if deploymentVer, ok := b.deploymentVers[deploymentID]; ok {
+		state, err := b.deploymentState(curNode, deploymentVer, deploymentID)
+		if err != nil {
+			return nil, err
+		}
+		if state.State != ThresholdActive {
+			calcStakeDiff = b.calcNextRequiredStakeDifficultyV1
+		}
+	}
+
+	// Create fake nodes on top of the passed node with the assumed ticket
+	// purchases and resulting ticket pool sizes, calculating the required
+	// stake difficulty for each one, until the final requested retarget
+	// interval.
+	//
+	// NOTE: The pool size in the block headers does not include the tickets
+	// maturing at the height in which they mature, nor does it account for
+	// the votes in the block itself, so they are only reflected starting
+	// with the next block.
+	ticketMaturity := int64(b.chainParams.TicketMaturity)
+	stakeDiffStartHeight := int64(b.chainParams.CoinbaseMaturity) + 1
+	stakeValidationHeight := b.chainParams.StakeValidationHeight
+	votesPerBlock := uint32(b.chainParams.TicketsPerBlock)
+	baseTicketsPerBlock := ticketsPerInterval / intervalSize
+	extraTicketBlocks := ticketsPerInterval % intervalSize
+	finalHeight := (curHeight/intervalSize + numIntervals) * intervalSize
+	topNode := curNode
+	for height := curHeight + 1; height <= finalHeight; height++ {
+		stakeDiff, err := calcStakeDiff(topNode)
+		if err != nil {
+			return nil, err
+		}
+
+		poolSize := topNode.poolSize
+		maturingNode := topNode.Ancestor(topNode.height - ticketMaturity)
+		if maturingNode != nil {
+			poolSize += uint32(maturingNode.freshStake)
+		}
+		if topNode.height >= stakeValidationHeight {
+			if poolSize < votesPerBlock {
+				poolSize = 0
+			} else {
+				poolSize -= votesPerBlock
+			}
+		}
+
+		var freshStake int64
+		if height >= stakeDiffStartHeight {
+			freshStake = baseTicketsPerBlock
+			if height%intervalSize < extraTicketBlocks {
+				freshStake++
+			}
+		}
+
+		header := wire.BlockHeader{
+			PrevBlock:  topNode.hash,
+			FreshStake: uint8(freshStake),
+			PoolSize:   poolSize,
+			SBits:      stakeDiff,
+			Height:     uint32(height),
+		}
+		topNode = newBlockNode(&header, topNode)
+
+		if height%intervalSize == 0 {
+			forecast.Projections = append(forecast.Projections,
+				StakeDiffProjection{
+					Height:    height,
+					StakeDiff: stakeDiff,
+					PoolSize:  poolSize,
+				})
+		}
+	}
+
+	return forecast, nil
+}
+
+// ForecastStakeDifficulty simulates the stake difficulty algorithm forward from
+// the block with the provided hash for the provided number of retarget
+// intervals by pretending the provided number of tickets will be purchased in
+// every interval and returns the projected stake difficulty for the first block
+// of each interval.  A negative number of tickets signifies the number of
+// tickets that were purchased in the most recent complete interval.
+//
+// The simulation assumes every block includes the maximum number of votes and
+// does not account for tickets that expire or are missed.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) ForecastStakeDifficulty(hash *chainhash.Hash, numIntervals, ticketsPerInterval int64) (*StakeDiffForecast, error) {
+	node := b.index.LookupNode(hash)
+	if node == nil || !b.index.CanValidate(node) {
+		return nil, unknownBlockError(hash)
+	}
+
+	b.chainLock.Lock()
+	forecast, err := b.forecastStakeDifficulty(node, numIntervals,
+		ticketsPerInterval)
+	b.chainLock.Unlock()
+	return forecast, err
+}
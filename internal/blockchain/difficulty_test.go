@@ -1132,3 +1132,124 @@ func TestMinDifficultyReduction(t *testing.T) {
 		}
 	}
 }
+
+// TestForecastStakeDifficulty ensures simulating the stake difficulty algorithm
+// forward for multiple retarget intervals produces the expected results.
+func TestForecastStakeDifficulty(t *testing.T) {
+	t.Parallel()
+
+	params := chaincfg.SimNetParams()
+	intervalSize := params.StakeDiffWindowSize
+	ticketMaturity := uint32(params.TicketMaturity)
+	ticketsPerBlock := uint32(params.TicketsPerBlock)
+	maxTicketsPerInterval := intervalSize * int64(params.MaxFreshStakePerBlock)
+
+	// Create a fake chain a few blocks into a retarget interval past stake
+	// validation height where every block after the stake difficulty start
+	// height purchases a fixed number of tickets.
+	const newTickets = 5
+	bc := newFakeChain(params)
+	tip := bc.bestChain.Tip()
+	immatureTickets := make(map[uint32]uint8)
+	var poolSize uint32
+	stakeDiffStartHeight := uint32(params.CoinbaseMaturity) + 1
+	finalHeight := uint32(params.StakeValidationHeight + intervalSize*4 + 3)
+	for nextHeight := uint32(1); nextHeight <= finalHeight; nextHeight++ {
+		var freshStake uint8
+		if nextHeight >= stakeDiffStartHeight {
+			freshStake = newTickets
+		}
+		header := &wire.BlockHeader{
+			Version:    4,
+			SBits:      bc.calcNextRequiredStakeDifficultyV2(tip),
+			Height:     nextHeight,
+			FreshStake: freshStake,
+			PoolSize:   poolSize,
+		}
+		tip = newBlockNode(header, tip)
+
+		poolSize += uint32(immatureTickets[nextHeight])
+		delete(immatureTickets, nextHeight)
+		if int64(nextHeight) >= params.StakeValidationHeight {
+			poolSize -= ticketsPerBlock
+		}
+		immatureTickets[nextHeight+ticketMaturity] = freshStake
+		bc.bestChain.SetTip(tip)
+	}
+
+	// Ensure the projections are for the expected retarget heights and that
+	// the default number of tickets is the number purchased in the most
+	// recent complete interval.
+	const numIntervals = 5
+	forecast, err := bc.forecastStakeDifficulty(tip, numIntervals, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if forecast.TicketsPerInterval != newTickets*intervalSize {
+		t.Fatalf("unexpected default tickets per interval -- got %d, want %d",
+			forecast.TicketsPerInterval, newTickets*intervalSize)
+	}
+	if len(forecast.Projections) != numIntervals {
+		t.Fatalf("unexpected number of projections -- got %d, want %d",
+			len(forecast.Projections), numIntervals)
+	}
+	nextRetargetHeight := (tip.height/intervalSize + 1) * intervalSize
+	for i, projection := range forecast.Projections {
+		wantHeight := nextRetargetHeight + int64(i)*intervalSize
+		if projection.Height != wantHeight {
+			t.Fatalf("projection %d: unexpected height -- got %d, want %d", i,
+				projection.Height, wantHeight)
+		}
+	}
+
+	// Ensure the first projection when purchasing the maximum number of
+	// tickets matches the estimate for the next interval under the same
+	// conditions.
+	maxForecast, err := bc.forecastStakeDifficulty(tip, numIntervals,
+		maxTicketsPerInterval)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantDiff, err := bc.estimateNextStakeDifficultyV2(tip, 0, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if maxForecast.Projections[0].StakeDiff != wantDiff {
+		t.Fatalf("unexpected first projection -- got %d, want %d",
+			maxForecast.Projections[0].StakeDiff, wantDiff)
+	}
+
+	// Ensure purchasing more tickets never results in a lower projected stake
+	// difficulty than purchasing no tickets.
+	minForecast, err := bc.forecastStakeDifficulty(tip, numIntervals, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range minForecast.Projections {
+		minDiff := minForecast.Projections[i].StakeDiff
+		maxDiff := maxForecast.Projections[i].StakeDiff
+		if maxDiff < minDiff {
+			t.Fatalf("projection %d: max tickets diff %d is less than no "+
+				"tickets diff %d", i, maxDiff, minDiff)
+		}
+	}
+	lastIdx := numIntervals - 1
+	if maxForecast.Projections[lastIdx].StakeDiff <=
+		minForecast.Projections[lastIdx].StakeDiff {
+
+		t.Fatalf("final projection with max tickets %d is not greater than "+
+			"with no tickets %d", maxForecast.Projections[lastIdx].StakeDiff,
+			minForecast.Projections[lastIdx].StakeDiff)
+	}
+
+	// Ensure invalid parameters are rejected.
+	_, err = bc.forecastStakeDifficulty(tip, numIntervals,
+		maxTicketsPerInterval+1)
+	if err == nil {
+		t.Fatal("expected error for too many tickets per interval")
+	}
+	_, err = bc.forecastStakeDifficulty(tip, -1, 0)
+	if err == nil {
+		t.Fatal("expected error for negative number of intervals")
+	}
+}
@@ -589,6 +589,14 @@ const (
 	// ErrNoFilter indicates a filter for a given block hash does not exist.
 	ErrNoFilter = ErrorKind("ErrNoFilter")
 
+	// ErrInvalidHeightRange indicates a requested range of block heights is
+	// not valid for the main chain.
+	ErrInvalidHeightRange = ErrorKind("ErrInvalidHeightRange")
+
+	// ErrNoHeaderCommitment indicates a requested header commitment for a
+	// given block hash does not exist.
+	ErrNoHeaderCommitment = ErrorKind("ErrNoHeaderCommitment")
+
 	// ErrNoTreasuryBalance indicates the treasury balance for a given block
 	// hash does not exist.
 	ErrNoTreasuryBalance = ErrorKind("ErrNoTreasuryBalance")
@@ -600,6 +608,17 @@ const (
 	// ErrSerializeHeader indicates an attempt to serialize a block header failed.
 	ErrSerializeHeader = ErrorKind("ErrSerializeHeader")
 
+	// ErrDeepReorgNotApproved indicates an attempt to approve a chain
+	// reorganization that exceeds the maximum automatic reorganization depth
+	// specified a block that is not part of the branch the chain would
+	// reorganize to.
+	ErrDeepReorgNotApproved = ErrorKind("ErrDeepReorgNotApproved")
+
+	// ErrScriptAuditFailed indicates the transaction scripts of a block that
+	// was connected to the main chain without executing them failed
+	// validation when they were later audited.
+	ErrScriptAuditFailed = ErrorKind("ErrScriptAuditFailed")
+
 	// ------------------------------------------
 	// Errors related to the UTXO backend.
 	// ------------------------------------------
@@ -621,6 +640,14 @@ const (
 	// performed.
 	ErrUtxoBackendTxClosed = ErrorKind("ErrUtxoBackendTxClosed")
 
+	// ErrUtxoBackendNotEmpty indicates an attempt was made to import a UTXO set
+	// snapshot into a UTXO backend that already contains a UTXO set.
+	ErrUtxoBackendNotEmpty = ErrorKind("ErrUtxoBackendNotEmpty")
+
+	// ErrInvalidUtxoSnapshot indicates a UTXO set snapshot is malformed,
+	// corrupt, or otherwise can't be imported.
+	ErrInvalidUtxoSnapshot = ErrorKind("ErrInvalidUtxoSnapshot")
+
 	// -----------------------------------------------------------------
 	// Errors related to the automatic ticket revocations agenda.
 	// -----------------------------------------------------------------
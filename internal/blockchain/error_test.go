@@ -150,13 +150,19 @@ func TestErrorKindStringer(t *testing.T) {
 		{ErrDuplicateDeployment, "ErrDuplicateDeployment"},
 		{ErrUnknownBlock, "ErrUnknownBlock"},
 		{ErrNoFilter, "ErrNoFilter"},
+		{ErrInvalidHeightRange, "ErrInvalidHeightRange"},
+		{ErrNoHeaderCommitment, "ErrNoHeaderCommitment"},
 		{ErrNoTreasuryBalance, "ErrNoTreasuryBalance"},
 		{ErrInvalidateGenesisBlock, "ErrInvalidateGenesisBlock"},
 		{ErrSerializeHeader, "ErrSerializeHeader"},
+		{ErrDeepReorgNotApproved, "ErrDeepReorgNotApproved"},
+		{ErrScriptAuditFailed, "ErrScriptAuditFailed"},
 		{ErrUtxoBackend, "ErrUtxoBackend"},
 		{ErrUtxoBackendCorruption, "ErrUtxoBackendCorruption"},
 		{ErrUtxoBackendNotOpen, "ErrUtxoBackendNotOpen"},
 		{ErrUtxoBackendTxClosed, "ErrUtxoBackendTxClosed"},
+		{ErrUtxoBackendNotEmpty, "ErrUtxoBackendNotEmpty"},
+		{ErrInvalidUtxoSnapshot, "ErrInvalidUtxoSnapshot"},
 		{ErrInvalidRevocationTxVersion, "ErrInvalidRevocationTxVersion"},
 		{ErrNoExpiredTicketRevocation, "ErrNoExpiredTicketRevocation"},
 		{ErrNoMissedTicketRevocation, "ErrNoMissedTicketRevocation"},
@@ -151,6 +151,16 @@ func fullBlockTestErrToLocalErr(t *testing.T, kind fullblocktests.ErrorKind) Err
 		return ErrInvalidEarlyVoteBits
 	case fullblocktests.ErrInvalidEarlyFinalState:
 		return ErrInvalidEarlyFinalState
+	case fullblocktests.ErrNotTVI:
+		return ErrNotTVI
+	case fullblocktests.ErrInvalidTSpendWindow:
+		return ErrInvalidTSpendWindow
+	case fullblocktests.ErrNotEnoughTSpendVotes:
+		return ErrNotEnoughTSpendVotes
+	case fullblocktests.ErrInvalidRevocationTxVersion:
+		return ErrInvalidRevocationTxVersion
+	case fullblocktests.ErrNoMissedTicketRevocation:
+		return ErrNoMissedTicketRevocation
 	default:
 		t.Fatalf("unconverted fullblocktest error kind %v", kind)
 	}
@@ -158,16 +168,14 @@ func fullBlockTestErrToLocalErr(t *testing.T, kind fullblocktests.ErrorKind) Err
 	panic("unreachable")
 }
 
-// TestFullBlocks ensures all tests generated by the fullblocktests package
-// have the expected result when processed via ProcessBlock.
-func TestFullBlocks(t *testing.T) {
-	tests, err := fullblocktests.Generate(false)
-	if err != nil {
-		t.Fatalf("failed to generate tests: %v", err)
-	}
+// runFullBlockTests ensures the provided tests generated by the fullblocktests
+// package have the expected result when processed via ProcessBlock by a new
+// chain instance created with the provided parameters.
+func runFullBlockTests(t *testing.T, params *chaincfg.Params, tests [][]fullblocktests.TestInstance) {
+	t.Helper()
 
 	// Create a new database and chain instance to run tests against.
-	chain, err := chainSetup(t, chaincfg.RegNetParams())
+	chain, err := chainSetup(t, params)
 	if err != nil {
 		t.Fatalf("Failed to setup chain instance: %v", err)
 	}
@@ -330,3 +338,35 @@ func TestFullBlocks(t *testing.T) {
 		}
 	}
 }
+
+// TestFullBlocks ensures all tests generated by the fullblocktests package
+// have the expected result when processed via ProcessBlock.
+func TestFullBlocks(t *testing.T) {
+	tests, err := fullblocktests.Generate(false)
+	if err != nil {
+		t.Fatalf("failed to generate tests: %v", err)
+	}
+	runFullBlockTests(t, chaincfg.RegNetParams(), tests)
+}
+
+// TestFullBlocksTreasury ensures all treasury agenda tests generated by the
+// fullblocktests package have the expected result when processed via
+// ProcessBlock.
+func TestFullBlocksTreasury(t *testing.T) {
+	tests, err := fullblocktests.GenerateTreasury()
+	if err != nil {
+		t.Fatalf("failed to generate tests: %v", err)
+	}
+	runFullBlockTests(t, fullblocktests.AgendaTestParams(), tests)
+}
+
+// TestFullBlocksAutoRevocations ensures all automatic ticket revocations agenda
+// tests generated by the fullblocktests package have the expected result when
+// processed via ProcessBlock.
+func TestFullBlocksAutoRevocations(t *testing.T) {
+	tests, err := fullblocktests.GenerateAutoRevocations()
+	if err != nil {
+		t.Fatalf("failed to generate tests: %v", err)
+	}
+	runFullBlockTests(t, fullblocktests.AgendaTestParams(), tests)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	mrand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/blockchain/standalone/v2"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/wire"
+)
+
+// TestHeaderCalculator ensures the stateless header calculator provided by the
+// standalone module produces the same results as the chain for the median
+// time, required difficulty, and required stake difficulty when provided with
+// only the minimum required number of trailing headers.
+func TestHeaderCalculator(t *testing.T) {
+	params := chaincfg.RegNetParams()
+	bc := newFakeChain(params)
+	calc := standalone.NewHeaderCalculator(params)
+
+	// Determine the maximum number of headers required by any of the
+	// calculations so the generated chain is long enough to exercise both the
+	// truncated and full window cases multiple times.
+	maxRequired := calc.RequiredDifficultyHeaders()
+	if n := calc.RequiredStakeDifficultyHeaders(); n > maxRequired {
+		maxRequired = n
+	}
+	numBlocks := maxRequired * 4
+
+	prng := mrand.New(mrand.NewSource(0))
+	headers := []wire.BlockHeader{params.GenesisBlock.Header}
+	tip := bc.bestChain.Tip()
+	for i := int64(0); i < numBlocks; i++ {
+		// Use a timestamp that randomly varies around the target time per
+		// block so the difficulty is adjusted in both directions.
+		targetSecs := int64(params.TargetTimePerBlock / time.Second)
+		offset := time.Duration(prng.Int63n(targetSecs*2)+1) * time.Second
+		blockTime := time.Unix(tip.timestamp, 0).Add(offset)
+
+		// Ensure the results of the calculator match those of the chain
+		// when only the required trailing headers are provided.
+		trailing := func(required int64) []wire.BlockHeader {
+			if int64(len(headers)) <= required {
+				return headers
+			}
+			return headers[int64(len(headers))-required:]
+		}
+		wantMedian := tip.CalcPastMedianTime()
+		gotMedian, err := calc.CalcPastMedianTime(trailing(
+			calc.RequiredMedianTimeHeaders()))
+		if err != nil {
+			t.Fatalf("CalcPastMedianTime (height %d): unexpected error: %v",
+				tip.height, err)
+		}
+		if !gotMedian.Equal(wantMedian) {
+			t.Fatalf("CalcPastMedianTime (height %d): mismatched median "+
+				"time -- got %v, want %v", tip.height, gotMedian, wantMedian)
+		}
+
+		wantDiff := bc.calcNextRequiredDifficulty(tip, blockTime)
+		gotDiff, err := calc.CalcNextRequiredDifficulty(trailing(
+			calc.RequiredDifficultyHeaders()), blockTime)
+		if err != nil {
+			t.Fatalf("CalcNextRequiredDifficulty (height %d): unexpected "+
+				"error: %v", tip.height, err)
+		}
+		if gotDiff != wantDiff {
+			t.Fatalf("CalcNextRequiredDifficulty (height %d): mismatched "+
+				"difficulty -- got %d, want %d", tip.height, gotDiff, wantDiff)
+		}
+
+		wantSDiff, err := bc.calcNextRequiredStakeDifficulty(tip)
+		if err != nil {
+			t.Fatalf("calcNextRequiredStakeDifficulty (height %d): "+
+				"unexpected error: %v", tip.height, err)
+		}
+		gotSDiff, err := calc.CalcNextRequiredStakeDifficulty(trailing(
+			calc.RequiredStakeDifficultyHeaders()))
+		if err != nil {
+			t.Fatalf("CalcNextRequiredStakeDifficulty (height %d): "+
+				"unexpected error: %v", tip.height, err)
+		}
+		if gotSDiff != wantSDiff {
+			t.Fatalf("CalcNextRequiredStakeDifficulty (height %d): "+
+				"mismatched stake difficulty -- got %d, want %d", tip.height,
+				gotSDiff, wantSDiff)
+		}
+
+		// Extend the chain with a header that commits to the calculated
+		// difficulties along with a random number of new tickets and pool
+		// size so the stake difficulty changes as well.
+		header := wire.BlockHeader{
+			Version:    1,
+			PrevBlock:  tip.hash,
+			VoteBits:   0x01,
+			FreshStake: uint8(prng.Intn(int(params.MaxFreshStakePerBlock) + 1)),
+			PoolSize:   uint32(prng.Intn(int(params.TicketPoolSize) * 2)),
+			Bits:       wantDiff,
+			SBits:      wantSDiff,
+			Height:     uint32(tip.height + 1),
+			Timestamp:  blockTime,
+			Nonce:      prng.Uint32(),
+		}
+		headers = append(headers, header)
+		tip = newBlockNode(&header, tip)
+		bc.index.AddNode(tip)
+		bc.bestChain.SetTip(tip)
+	}
+}
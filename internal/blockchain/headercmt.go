@@ -20,6 +20,35 @@ const (
 	HeaderCmtFilterIndex = 0
 )
 
+const (
+	// HeaderCmtVersionNone indicates a block header does not commit to any
+	// data via the commitment root.  This is the case for all blocks prior
+	// to the activation of the header commitments agenda defined in DCP0005.
+	HeaderCmtVersionNone = 0
+
+	// HeaderCmtVersion1 is the header commitment version defined in DCP0005.
+	// It commits to the version 2 GCS filter of the block.
+	HeaderCmtVersion1 = 1
+)
+
+const (
+	// HeaderCmtFilterName is the name of the version 2 GCS filter header
+	// commitment.
+	HeaderCmtFilterName = "cfilterv2"
+)
+
+// headerCmtNames houses the names of the individual commitments that comprise
+// the leaves of the header commitment merkle tree for each header commitment
+// version.  The position of each name is the proof index of the associated
+// commitment.
+//
+// New header commitment versions must be added here and in the leaves method of
+// headerCommitmentData along with the agenda that activates them in
+// headerCommitmentVersion.
+var headerCmtNames = map[uint32][]string{
+	HeaderCmtVersion1: {HeaderCmtFilterIndex: HeaderCmtFilterName},
+}
+
 // headerCommitmentData houses information the block header commits to via the
 // commitment root.
 type headerCommitmentData struct {
@@ -33,6 +62,42 @@ func (c *headerCommitmentData) v1Leaves() []chainhash.Hash {
 	return []chainhash.Hash{c.filterHash}
 }
 
+// leaves returns the individual commitment hashes that comprise the leaves of
+// the merkle tree for the provided header commitment version.  Nil is returned
+// for HeaderCmtVersionNone and unknown versions.
+func (c *headerCommitmentData) leaves(version uint32) []chainhash.Hash {
+	switch version {
+	case HeaderCmtVersion1:
+		return c.v1Leaves()
+	}
+	return nil
+}
+
+// calcCommitmentRoot calculates and returns the commitment root for the
+// provided header commitment version from the data it commits to.
+func calcCommitmentRoot(version uint32, c *headerCommitmentData) chainhash.Hash {
+	switch version {
+	case HeaderCmtVersion1:
+		return CalcCommitmentRootV1(c.filterHash)
+	}
+	return standalone.CalcMerkleRoot(c.leaves(version))
+}
+
+// headerCommitmentVersion returns the header commitment version that applies
+// to the block AFTER the passed node depending on the active agendas.
+//
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) headerCommitmentVersion(prevNode *blockNode) (uint32, error) {
+	hdrCommitmentsActive, err := b.isHeaderCommitmentsAgendaActive(prevNode)
+	if err != nil {
+		return HeaderCmtVersionNone, err
+	}
+	if hdrCommitmentsActive {
+		return HeaderCmtVersion1, nil
+	}
+	return HeaderCmtVersionNone, nil
+}
+
 // CalcCommitmentRootV1 calculates and returns the required v1 block commitment
 // root from the filter hash it commits to.
 //
@@ -191,3 +256,112 @@ func (b *BlockChain) FilterByBlockHash(hash *chainhash.Hash) (*gcs.FilterV2, *He
 	}
 	return filter, headerProof, nil
 }
+
+// HeaderCommitment describes an individual commitment that is a leaf of the
+// header commitment merkle tree.
+type HeaderCommitment struct {
+	// Name identifies the data the commitment commits to.
+	Name string
+
+	// Hash is the commitment hash of the data.
+	Hash chainhash.Hash
+}
+
+// HeaderCommitments houses the individual commitments that the commitment root
+// of a block header commits to along with the header commitment version that
+// defines them.
+type HeaderCommitments struct {
+	// Version is the header commitment version of the block.
+	Version uint32
+
+	// Root is the commitment root from the block header.
+	Root chainhash.Hash
+
+	// Commitments are the individual commitments in proof index order.
+	Commitments []HeaderCommitment
+}
+
+// InclusionProof returns a header commitment inclusion proof for the
+// commitment at the given proof index.
+//
+// An error that wraps ErrNoHeaderCommitment will be returned when there is no
+// commitment at the given index.
+func (c *HeaderCommitments) InclusionProof(proofIndex uint32) (*HeaderProof, error) {
+	if proofIndex >= uint32(len(c.Commitments)) {
+		str := fmt.Sprintf("no header commitment at proof index %d (version "+
+			"%d commits to %d items)", proofIndex, c.Version,
+			len(c.Commitments))
+		return nil, contextError(ErrNoHeaderCommitment, str)
+	}
+
+	leaves := make([]chainhash.Hash, 0, len(c.Commitments))
+	for i := range c.Commitments {
+		leaves = append(leaves, c.Commitments[i].Hash)
+	}
+	proof := standalone.GenerateInclusionProof(leaves, proofIndex)
+	return &HeaderProof{ProofIndex: proofIndex, ProofHashes: proof}, nil
+}
+
+// HeaderCommitments returns the individual commitments that the commitment
+// root of the header for the given block hash commits to.  This function
+// returns the commitments regardless of whether or not their associated block
+// is part of the main chain.
+//
+// An error that wraps ErrNoHeaderCommitment will be returned when the block
+// does not commit to any data or the commitments are not available.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) HeaderCommitments(hash *chainhash.Hash) (*HeaderCommitments, error) {
+	node := b.index.LookupNode(hash)
+	if node == nil || !b.index.CanValidate(node) {
+		return nil, unknownBlockError(hash)
+	}
+
+	// The genesis block does not commit to any data.
+	if node.parent == nil {
+		str := fmt.Sprintf("block %s does not have header commitments", hash)
+		return nil, contextError(ErrNoHeaderCommitment, str)
+	}
+
+	// Determine the header commitment version for the block.
+	b.chainLock.Lock()
+	version, err := b.headerCommitmentVersion(node.parent)
+	b.chainLock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	names := headerCmtNames[version]
+	if len(names) == 0 {
+		str := fmt.Sprintf("block %s does not have header commitments", hash)
+		return nil, contextError(ErrNoHeaderCommitment, str)
+	}
+
+	// Load the header commitments from the database.  They will not be
+	// available when the block has not been connected yet.
+	var leaves []chainhash.Hash
+	err = b.db.View(func(dbTx database.Tx) error {
+		leaves, err = dbFetchHeaderCommitments(dbTx, hash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(leaves) != len(names) {
+		str := fmt.Sprintf("header commitments for block %s are not "+
+			"available", hash)
+		return nil, contextError(ErrNoHeaderCommitment, str)
+	}
+
+	commitments := make([]HeaderCommitment, 0, len(leaves))
+	for i := range leaves {
+		commitments = append(commitments, HeaderCommitment{
+			Name: names[i],
+			Hash: leaves[i],
+		})
+	}
+	return &HeaderCommitments{
+		Version:     version,
+		Root:        node.stakeRoot,
+		Commitments: commitments,
+	}, nil
+}
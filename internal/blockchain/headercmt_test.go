@@ -1,12 +1,14 @@
-// Copyright (c) 2019 The Decred developers
+// Copyright (c) 2019-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
 package blockchain
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/decred/dcrd/blockchain/standalone/v2"
 	"github.com/decred/dcrd/chaincfg/chainhash"
 )
 
@@ -54,3 +56,72 @@ func TestCalcCommitmentRootV1(t *testing.T) {
 		}
 	}
 }
+
+// TestCalcCommitmentRoot ensures the commitment root calculated for each
+// header commitment version matches the merkle root of the leaves for that
+// version and that every version has a name for each of its leaves.
+func TestCalcCommitmentRoot(t *testing.T) {
+	filterHash := chainhash.HashH([]byte("filter"))
+	data := &headerCommitmentData{filterHash: filterHash}
+	for version, names := range headerCmtNames {
+		leaves := data.leaves(version)
+		if len(leaves) != len(names) {
+			t.Errorf("version %d: mismatched number of leaves -- got %d, "+
+				"want %d", version, len(leaves), len(names))
+			continue
+		}
+
+		got := calcCommitmentRoot(version, data)
+		want := standalone.CalcMerkleRoot(leaves)
+		if got != want {
+			t.Errorf("version %d: mismatched commitment root -- got %v, "+
+				"want %v", version, got, want)
+		}
+	}
+
+	// Ensure there are no leaves when header commitments are not active.
+	if leaves := data.leaves(HeaderCmtVersionNone); leaves != nil {
+		t.Errorf("unexpected leaves for no header commitments: %v", leaves)
+	}
+}
+
+// TestHeaderCommitmentsInclusionProof ensures the header commitment inclusion
+// proofs generated for each commitment prove the commitment is a leaf of the
+// commitment root and that requesting a proof for a commitment that does not
+// exist is rejected.
+func TestHeaderCommitmentsInclusionProof(t *testing.T) {
+	cmts := &HeaderCommitments{Version: HeaderCmtVersion1}
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		cmts.Commitments = append(cmts.Commitments, HeaderCommitment{
+			Name: name,
+			Hash: chainhash.HashH([]byte(name)),
+		})
+	}
+	leaves := make([]chainhash.Hash, 0, len(cmts.Commitments))
+	for _, cmt := range cmts.Commitments {
+		leaves = append(leaves, cmt.Hash)
+	}
+	cmts.Root = standalone.CalcMerkleRoot(leaves)
+
+	for i := range cmts.Commitments {
+		proof, err := cmts.InclusionProof(uint32(i))
+		if err != nil {
+			t.Fatalf("proof index %d: unexpected error: %v", i, err)
+		}
+		if proof.ProofIndex != uint32(i) {
+			t.Fatalf("proof index %d: mismatched proof index %d", i,
+				proof.ProofIndex)
+		}
+		if !standalone.VerifyInclusionProof(&cmts.Root,
+			&cmts.Commitments[i].Hash, proof.ProofIndex, proof.ProofHashes) {
+
+			t.Fatalf("proof index %d: inclusion proof does not verify", i)
+		}
+	}
+
+	_, err := cmts.InclusionProof(uint32(len(cmts.Commitments)))
+	if !errors.Is(err, ErrNoHeaderCommitment) {
+		t.Fatalf("mismatched error for out of range proof index -- got %v, "+
+			"want %v", err, ErrNoHeaderCommitment)
+	}
+}
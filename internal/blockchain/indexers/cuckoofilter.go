@@ -0,0 +1,242 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"math"
+	"math/bits"
+
+	"github.com/dchest/siphash"
+)
+
+const (
+	// cuckooBucketSize is the number of fingerprints each bucket of a cuckoo
+	// filter houses.
+	cuckooBucketSize = 4
+
+	// cuckooMaxLoadFactor is the target maximum ratio of occupied entries to
+	// total entries a cuckoo filter is sized for.  Cuckoo filters with 4
+	// entries per bucket are able to achieve load factors of around 95% before
+	// insertions start failing.
+	cuckooMaxLoadFactor = 0.9
+
+	// cuckooMaxKicks is the maximum number of times an existing fingerprint is
+	// relocated while attempting to make room for a new one before the filter
+	// is considered full.
+	cuckooMaxKicks = 500
+)
+
+// cuckooFilter implements a probabilistic data structure that supports fast
+// set membership queries with a tunable false positive rate and no false
+// negatives.
+//
+// It is based on the design in "Cuckoo Filter: Practically Better Than Bloom"
+// by Fan et al.  In short, each item is reduced to a short fingerprint which is
+// stored in one of two candidate buckets.  The second candidate bucket is
+// derived from the first one and the fingerprint, which allows fingerprints to
+// be relocated between their candidate buckets to make room for new items
+// without needing the original item.
+//
+// The fingerprints are stored using a whole number of bytes in order to
+// provide a compact representation with simple accesses.
+//
+// This type is NOT safe for concurrent access.
+type cuckooFilter struct {
+	// key0 and key1 are used to seed the hash function in order to ensure
+	// attackers are not able to intentionally grind items that map to the
+	// same buckets and fingerprints.
+	key0, key1 uint64
+
+	// fpBytes is the number of bytes used to store each fingerprint.
+	fpBytes int
+
+	// fpMask is the mask used to reduce hashes to fingerprints.
+	fpMask uint64
+
+	// bucketMask is the mask used to reduce hashes to bucket indices.  The
+	// number of buckets is always a power of two.
+	bucketMask uint64
+
+	// numItems is the number of items that have been added to the filter.
+	numItems uint64
+
+	// kickIdx is used to cycle through the entries of a bucket when
+	// relocating fingerprints.
+	kickIdx int
+
+	// data houses the fingerprints of all buckets.  An entry of zero denotes
+	// an empty entry.
+	data []byte
+}
+
+// cuckooFingerprintBytes returns the number of bytes needed per fingerprint in
+// order to achieve the provided false positive rate.
+func cuckooFingerprintBytes(fpRate float64) int {
+	// The false positive rate of a cuckoo filter is upper bounded by 2b/2^f
+	// where b is the number of entries per bucket and f is the number of bits
+	// in each fingerprint.
+	fpBits := math.Ceil(math.Log2(2 * cuckooBucketSize / fpRate))
+	fpBytes := int(math.Ceil(fpBits / 8))
+	switch {
+	case fpBytes < 1:
+		fpBytes = 1
+	case fpBytes > 4:
+		fpBytes = 4
+	}
+	return fpBytes
+}
+
+// newCuckooFilter returns a cuckoo filter that is able to house at least the
+// provided number of items with a false positive rate that is no higher than
+// the provided rate.  The rate is limited to the range that can be achieved
+// with fingerprints of 1 to 4 bytes.
+func newCuckooFilter(capacity uint64, fpRate float64) *cuckooFilter {
+	var keys [16]byte
+	rand.Read(keys[:])
+
+	numBuckets := uint64(math.Ceil(float64(capacity) /
+		(cuckooBucketSize * cuckooMaxLoadFactor)))
+	if numBuckets < 1 {
+		numBuckets = 1
+	}
+	numBuckets = 1 << bits.Len64(numBuckets-1)
+
+	fpBytes := cuckooFingerprintBytes(fpRate)
+	return &cuckooFilter{
+		key0:       binary.LittleEndian.Uint64(keys[0:8]),
+		key1:       binary.LittleEndian.Uint64(keys[8:16]),
+		fpBytes:    fpBytes,
+		fpMask:     1<<(8*uint(fpBytes)) - 1,
+		bucketMask: numBuckets - 1,
+		data:       make([]byte, numBuckets*cuckooBucketSize*uint64(fpBytes)),
+	}
+}
+
+// FPRate returns the maximum false positive rate of the filter when it is
+// filled to its capacity.
+func (f *cuckooFilter) FPRate() float64 {
+	return 2 * cuckooBucketSize / math.Pow(2, float64(8*f.fpBytes))
+}
+
+// Capacity returns the number of items the filter is sized to house.
+func (f *cuckooFilter) Capacity() uint64 {
+	numEntries := (f.bucketMask + 1) * cuckooBucketSize
+	return uint64(float64(numEntries) * cuckooMaxLoadFactor)
+}
+
+// Count returns the number of items that have been added to the filter.
+func (f *cuckooFilter) Count() uint64 {
+	return f.numItems
+}
+
+// Size returns the number of bytes used to house the fingerprints.
+func (f *cuckooFilter) Size() int {
+	return len(f.data)
+}
+
+// indexAndFingerprint returns the first candidate bucket index and the
+// fingerprint for the provided item.  The fingerprint is never zero since that
+// denotes an empty entry.
+func (f *cuckooFilter) indexAndFingerprint(item []byte) (uint64, uint64) {
+	hash1, hash2 := siphash.Hash128(f.key0, f.key1, item)
+	fp := hash2 & f.fpMask
+	if fp == 0 {
+		fp = 1
+	}
+	return hash1 & f.bucketMask, fp
+}
+
+// altIndex returns the alternate candidate bucket index for the provided
+// bucket index and fingerprint.  It is symmetric, meaning the alternate index
+// of the alternate index is the original index.
+func (f *cuckooFilter) altIndex(index, fp uint64) uint64 {
+	// Mix the fingerprint using the multiplier from MurmurHash2 so that
+	// fingerprints that only differ slightly map to distant buckets.
+	return (index ^ (fp * 0x5bd1e995)) & f.bucketMask
+}
+
+// entry returns the fingerprint at the provided entry of the provided bucket.
+func (f *cuckooFilter) entry(index uint64, entry int) uint64 {
+	offset := (index*cuckooBucketSize + uint64(entry)) * uint64(f.fpBytes)
+	var fp uint64
+	for i := 0; i < f.fpBytes; i++ {
+		fp |= uint64(f.data[offset+uint64(i)]) << (8 * uint(i))
+	}
+	return fp
+}
+
+// setEntry sets the provided entry of the provided bucket to the provided
+// fingerprint.
+func (f *cuckooFilter) setEntry(index uint64, entry int, fp uint64) {
+	offset := (index*cuckooBucketSize + uint64(entry)) * uint64(f.fpBytes)
+	for i := 0; i < f.fpBytes; i++ {
+		f.data[offset+uint64(i)] = byte(fp >> (8 * uint(i)))
+	}
+}
+
+// bucketContains returns whether or not the provided bucket houses the
+// provided fingerprint.
+func (f *cuckooFilter) bucketContains(index, fp uint64) bool {
+	for i := 0; i < cuckooBucketSize; i++ {
+		if f.entry(index, i) == fp {
+			return true
+		}
+	}
+	return false
+}
+
+// tryInsert attempts to insert the provided fingerprint into an empty entry of
+// the provided bucket and returns whether or not it was successful.
+func (f *cuckooFilter) tryInsert(index, fp uint64) bool {
+	for i := 0; i < cuckooBucketSize; i++ {
+		if f.entry(index, i) == 0 {
+			f.setEntry(index, i, fp)
+			return true
+		}
+	}
+	return false
+}
+
+// Add inserts the provided item into the filter and returns whether or not it
+// was successful.  Adding an item fails when the filter is too full to make
+// room for it, in which case some previously added item may no longer be
+// present in the filter, so the filter must be rebuilt with a larger capacity.
+func (f *cuckooFilter) Add(item []byte) bool {
+	index1, fp := f.indexAndFingerprint(item)
+	index2 := f.altIndex(index1, fp)
+	if f.tryInsert(index1, fp) || f.tryInsert(index2, fp) {
+		f.numItems++
+		return true
+	}
+
+	// Both candidate buckets are full, so relocate existing fingerprints to
+	// their alternate buckets until an empty entry is found.
+	index := index1
+	for kick := 0; kick < cuckooMaxKicks; kick++ {
+		f.kickIdx = (f.kickIdx + 1) % cuckooBucketSize
+		victim := f.entry(index, f.kickIdx)
+		f.setEntry(index, f.kickIdx, fp)
+		fp = victim
+		index = f.altIndex(index, fp)
+		if f.tryInsert(index, fp) {
+			f.numItems++
+			return true
+		}
+	}
+	return false
+}
+
+// Contains returns whether or not the provided item was added to the filter.
+// False positives are possible at a rate determined by the filter parameters,
+// but false negatives are not.
+func (f *cuckooFilter) Contains(item []byte) bool {
+	index1, fp := f.indexAndFingerprint(item)
+	if f.bucketContains(index1, fp) {
+		return true
+	}
+	return f.bucketContains(f.altIndex(index1, fp), fp)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// TestCuckooFingerprintBytes ensures the number of bytes used per fingerprint
+// is calculated as expected for various false positive rates.
+func TestCuckooFingerprintBytes(t *testing.T) {
+	tests := []struct {
+		fpRate float64 // target false positive rate
+		want   int     // expected bytes per fingerprint
+	}{
+		{fpRate: 0.5, want: 1},
+		{fpRate: 0.03125, want: 1},
+		{fpRate: 0.03, want: 2},
+		{fpRate: 0.0001, want: 3},
+		{fpRate: 1e-7, want: 4},
+		{fpRate: 1e-20, want: 4},
+	}
+
+	for _, test := range tests {
+		got := cuckooFingerprintBytes(test.fpRate)
+		if got != test.want {
+			t.Errorf("fp rate %v: unexpected bytes -- got %d, want %d",
+				test.fpRate, got, test.want)
+		}
+	}
+}
+
+// TestCuckooFilter ensures the cuckoo filter contains all added items, has
+// a false positive rate within the expected bounds, and reports when it is
+// full.
+func TestCuckooFilter(t *testing.T) {
+	t.Parallel()
+
+	// item returns a unique item for the provided index.
+	item := func(i uint64) []byte {
+		var b [addrKeySize]byte
+		binary.LittleEndian.PutUint64(b[1:], i)
+		return b[:]
+	}
+
+	// Ensure the filter is sized as expected.
+	const numItems = 10000
+	const fpRate = 0.01
+	f := newCuckooFilter(numItems, fpRate)
+	if f.Capacity() < numItems {
+		t.Fatalf("unexpected capacity -- got %d, want at least %d",
+			f.Capacity(), numItems)
+	}
+	if f.FPRate() > fpRate {
+		t.Fatalf("unexpected max false positive rate -- got %v, want at "+
+			"most %v", f.FPRate(), fpRate)
+	}
+
+	// Ensure all added items are contained in the filter.
+	for i := uint64(0); i < numItems; i++ {
+		if !f.Add(item(i)) {
+			t.Fatalf("failed to add item %d", i)
+		}
+	}
+	if f.Count() != numItems {
+		t.Fatalf("unexpected count -- got %d, want %d", f.Count(), numItems)
+	}
+	for i := uint64(0); i < numItems; i++ {
+		if !f.Contains(item(i)) {
+			t.Fatalf("filter does not contain added item %d", i)
+		}
+	}
+
+	// Ensure the observed false positive rate of items that were not added is
+	// within the expected bounds.
+	const numQueries = 100000
+	var falsePositives int
+	for i := uint64(numItems); i < numItems+numQueries; i++ {
+		if f.Contains(item(i)) {
+			falsePositives++
+		}
+	}
+	if observed := float64(falsePositives) / numQueries; observed > fpRate {
+		t.Fatalf("unexpected false positive rate -- got %v, want at most %v",
+			observed, fpRate)
+	}
+
+	// Ensure adding items eventually fails once the filter is full.
+	f = newCuckooFilter(1, fpRate)
+	var full bool
+	for i := uint64(0); i < 100; i++ {
+		if !f.Add(item(i)) {
+			full = true
+			break
+		}
+	}
+	if !full {
+		t.Fatal("adding items to a full filter did not fail")
+	}
+}
@@ -42,6 +42,10 @@ const (
 	// ErrBlockNotOnMainChain indicates the provided block is not on the
 	// main chain.
 	ErrBlockNotOnMainChain = ErrorKind("ErrBlockNotOnMainChain")
+
+	// ErrInvalidFPRate indicates a false positive rate that is not in the
+	// range (0, 1).
+	ErrInvalidFPRate = ErrorKind("ErrInvalidFPRate")
 )
 
 // Error satisfies the error interface and prints human-readable errors.
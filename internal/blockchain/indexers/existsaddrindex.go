@@ -5,8 +5,11 @@
 package indexers
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/decred/dcrd/blockchain/stake/v5"
@@ -53,12 +56,25 @@ const (
 	// represents a pay-to-script-hash address.  This is necessary because the
 	// hash of a pubkey address might be the same as that of a script hash.
 	addrKeyTypeScriptHash = 3
+
+	// DefaultExistsAddrFPRate is the default maximum false positive rate of
+	// the in-memory filter that is used to avoid database lookups for
+	// addresses that have never been seen.
+	DefaultExistsAddrFPRate = 0.0001
+
+	// minExistsAddrFilterCapacity is the minimum number of addresses the
+	// in-memory filter is sized to house.
+	minExistsAddrFilterCapacity = 1 << 16
 )
 
 var (
 	// existsAddrIndexKey is the key of the ever seen address index and
 	// the db bucket used to house it.
 	existsAddrIndexKey = []byte("existsaddridx")
+
+	// errFilterFull is used internally to stop loading addresses into the
+	// in-memory filter when it is full.
+	errFilterFull = errors.New("filter is full")
 )
 
 // addrToKey converts known address types to an addrindex key.  An error is
@@ -108,13 +124,29 @@ func addrToKey(addr stdaddr.Address) ([addrKeySize]byte, error) {
 // In addition, support is provided for a memory-only index of unconfirmed
 // transactions such as those which are kept in the memory pool before inclusion
 // in a block.
+//
+// The addresses in the database are also tracked by an in-memory cuckoo filter
+// with a configurable false positive rate.  Since the vast majority of queries,
+// such as those made during wallet address discovery, are for addresses that
+// have never been seen, this allows most queries to be answered without
+// accessing the database at all.  Addresses that match the filter are always
+// confirmed against the database, so false positives only affect performance
+// and never the results.
 type ExistsAddrIndex struct {
 	// The following fields are set when the instance is created and can't
 	// be changed afterwards, so there is no need to protect them with a
 	// separate mutex.
-	db    database.DB
-	chain ChainQueryer
-	sub   *IndexSubscription
+	db     database.DB
+	chain  ChainQueryer
+	sub    *IndexSubscription
+	fpRate float64
+
+	// filter tracks all addresses in the database to avoid database lookups
+	// for addresses that have never been seen.  It may also contain addresses
+	// from database transactions that were not committed, which only results
+	// in additional database lookups.  It is protected by the filter lock.
+	filterLock sync.RWMutex
+	filter     *cuckooFilter
 
 	// The following fields are used to quickly link transactions and
 	// addresses that have not been included into a block yet when an
@@ -138,11 +170,21 @@ type ExistsAddrIndex struct {
 }
 
 // NewExistsAddrIndex returns a new instance of an indexer that is used to
-// create a mapping of all addresses ever seen.
-func NewExistsAddrIndex(subscriber *IndexSubscriber, db database.DB, chain ChainQueryer) (*ExistsAddrIndex, error) {
+// create a mapping of all addresses ever seen.  The false positive rate
+// specifies the maximum rate of the in-memory filter that is used to avoid
+// database lookups for addresses that have never been seen.  Lower rates
+// require more memory.
+func NewExistsAddrIndex(subscriber *IndexSubscriber, db database.DB, chain ChainQueryer, fpRate float64) (*ExistsAddrIndex, error) {
+	if fpRate <= 0 || fpRate >= 1 {
+		msg := fmt.Sprintf("%s: false positive rate %v is not in the range "+
+			"(0, 1)", existsAddressIndexName, fpRate)
+		return nil, indexerError(ErrInvalidFPRate, msg)
+	}
+
 	idx := &ExistsAddrIndex{
 		db:           db,
 		chain:        chain,
+		fpRate:       fpRate,
 		mpExistsAddr: make(map[[addrKeySize]byte]struct{}),
 		subscribers:  make(map[chan bool]struct{}),
 		cancel:       subscriber.cancel,
@@ -197,9 +239,69 @@ func (idx *ExistsAddrIndex) Init(ctx context.Context, chainParams *chaincfg.Para
 		return err
 	}
 
+	// Load all addresses in the index into the in-memory filter.
+	err := idx.db.View(func(dbTx database.Tx) error {
+		return idx.rebuildFilter(ctx, dbTx, minExistsAddrFilterCapacity)
+	})
+	if err != nil {
+		return err
+	}
+	idx.filterLock.RLock()
+	log.Infof("Loaded %d addresses into the %s filter (%d bytes, max false "+
+		"positive rate %v)", idx.filter.Count(), idx.Name(), idx.filter.Size(),
+		idx.filter.FPRate())
+	idx.filterLock.RUnlock()
+
 	return nil
 }
 
+// rebuildFilter replaces the in-memory filter with one that contains all
+// addresses in the index and is sized to house at least the provided number of
+// addresses.
+//
+// This function MUST be called with the filter lock held (for writes) when
+// the filter has been initialized.
+func (idx *ExistsAddrIndex) rebuildFilter(ctx context.Context, dbTx database.Tx, minCapacity uint64) error {
+	bucket := dbTx.Metadata().Bucket(existsAddrIndexKey)
+	var numAddrs uint64
+	err := bucket.ForEach(func(k, _ []byte) error {
+		numAddrs++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Size the filter to allow the index to grow considerably before it needs
+	// to be rebuilt again.
+	capacity := numAddrs * 2
+	if capacity < minCapacity {
+		capacity = minCapacity
+	}
+	for {
+		filter := newCuckooFilter(capacity, idx.fpRate)
+		err := bucket.ForEach(func(k, _ []byte) error {
+			if interruptRequested(ctx) {
+				return indexerError(ErrInterruptRequested, interruptMsg)
+			}
+			if !filter.Add(k) {
+				return errFilterFull
+			}
+			return nil
+		})
+		if errors.Is(err, errFilterFull) {
+			capacity *= 2
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		idx.filter = filter
+		return nil
+	}
+}
+
 // Key returns the database key to use for the index as a byte slice.
 //
 // This is part of the Indexer interface.
@@ -296,10 +398,21 @@ func dbPutExistsAddr(bucket internalBucket, addrKey [addrKeySize]byte) error {
 	return bucket.Put(addrKey[:], nil)
 }
 
+// filterContains returns whether or not the provided address key might exist
+// in the database according to the in-memory filter.
+//
+// This function is safe for concurrent access.
+func (idx *ExistsAddrIndex) filterContains(k [addrKeySize]byte) bool {
+	idx.filterLock.RLock()
+	contains := idx.filter.Contains(k[:])
+	idx.filterLock.RUnlock()
+	return contains
+}
+
 // existsAddress takes a bucket and key for an address and responds with
 // whether or not the key exists in the database.
 func (idx *ExistsAddrIndex) existsAddress(bucket internalBucket, k [addrKeySize]byte) bool {
-	if bucket.Get(k[:]) != nil {
+	if idx.filterContains(k) && bucket.Get(k[:]) != nil {
 		return true
 	}
 
@@ -318,16 +431,19 @@ func (idx *ExistsAddrIndex) ExistsAddress(addr stdaddr.Address) (bool, error) {
 		return false, err
 	}
 
+	// Only check the database when the address might exist in it.
 	var exists bool
-	err = idx.db.View(func(dbTx database.Tx) error {
-		meta := dbTx.Metadata()
-		existsAddrIndex := meta.Bucket(existsAddrIndexKey)
-		exists = existsAddrIndex.Get(k[:]) != nil
+	if idx.filterContains(k) {
+		err = idx.db.View(func(dbTx database.Tx) error {
+			meta := dbTx.Metadata()
+			existsAddrIndex := meta.Bucket(existsAddrIndexKey)
+			exists = existsAddrIndex.Get(k[:]) != nil
 
-		return nil
-	})
-	if err != nil {
-		return false, err
+			return nil
+		})
+		if err != nil {
+			return false, err
+		}
 	}
 
 	// Only check the in memory map if needed.
@@ -342,6 +458,10 @@ func (idx *ExistsAddrIndex) ExistsAddress(addr stdaddr.Address) (bool, error) {
 
 // ExistsAddresses is the concurrency safe, exported function that returns
 // whether or not each address in a slice of addresses has been seen before.
+//
+// It is optimized for large batches of addresses, such as those queried during
+// wallet address discovery, by only querying the database for the addresses
+// that match the in-memory filter and doing so in key order.
 func (idx *ExistsAddrIndex) ExistsAddresses(addrs []stdaddr.Address) ([]bool, error) {
 	exists := make([]bool, len(addrs))
 	addrKeys := make([][addrKeySize]byte, len(addrs))
@@ -353,18 +473,35 @@ func (idx *ExistsAddrIndex) ExistsAddresses(addrs []stdaddr.Address) ([]bool, er
 		}
 	}
 
-	err := idx.db.View(func(dbTx database.Tx) error {
-		for i := range addrKeys {
+	// Determine which addresses might exist in the database according to the
+	// filter and sort them by their keys to improve the locality of the
+	// database lookups.
+	var dbLookups []int
+	idx.filterLock.RLock()
+	for i := range addrKeys {
+		if idx.filter.Contains(addrKeys[i][:]) {
+			dbLookups = append(dbLookups, i)
+		}
+	}
+	idx.filterLock.RUnlock()
+	sort.Slice(dbLookups, func(i, j int) bool {
+		return bytes.Compare(addrKeys[dbLookups[i]][:],
+			addrKeys[dbLookups[j]][:]) < 0
+	})
+
+	if len(dbLookups) > 0 {
+		err := idx.db.View(func(dbTx database.Tx) error {
 			meta := dbTx.Metadata()
 			existsAddrIndex := meta.Bucket(existsAddrIndexKey)
+			for _, i := range dbLookups {
+				exists[i] = existsAddrIndex.Get(addrKeys[i][:]) != nil
+			}
 
-			exists[i] = existsAddrIndex.Get(addrKeys[i][:]) != nil
+			return nil
+		})
+		if err != nil {
+			return nil, err
 		}
-
-		return nil
-	})
-	if err != nil {
-		return nil, err
 	}
 
 	idx.unconfirmedLock.RLock()
@@ -485,6 +622,26 @@ func (idx *ExistsAddrIndex) connectBlock(dbTx database.Tx, block *dcrutil.Block)
 		}
 	}
 
+	// Add the newly used addresses to the in-memory filter and rebuild it with
+	// a larger capacity from the addresses in the database, which includes the
+	// newly used ones, when it is full.
+	idx.filterLock.Lock()
+	defer idx.filterLock.Unlock()
+	for addrKey := range newUsedAddrs {
+		addrKey := addrKey
+		if idx.filter.Add(addrKey[:]) {
+			continue
+		}
+
+		log.Debugf("Rebuilding the %s filter", idx.Name())
+		minCapacity := idx.filter.Capacity() * 2
+		err := idx.rebuildFilter(context.Background(), dbTx, minCapacity)
+		if err != nil {
+			return err
+		}
+		break
+	}
+
 	// Update the current index tip.
 	return dbPutIndexerTip(dbTx, idx.Key(), block.Hash(), int32(block.Height()))
 }
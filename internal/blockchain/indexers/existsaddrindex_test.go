@@ -6,11 +6,13 @@ package indexers
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/decred/dcrd/blockchain/v5/chaingen"
 	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
 	"github.com/decred/dcrd/txscript/v4/stdscript"
 )
 
@@ -41,7 +43,7 @@ func TestExistsAddrIndexAsync(t *testing.T) {
 	subber := NewIndexSubscriber(ctx)
 	go subber.Run(ctx)
 
-	idx, err := NewExistsAddrIndex(subber, db, chain)
+	idx, err := NewExistsAddrIndex(subber, db, chain, DefaultExistsAddrFPRate)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -116,6 +118,21 @@ func TestExistsAddrIndexAsync(t *testing.T) {
 		t.Fatalf("expected %s to be indexed", addrs[0])
 	}
 
+	// Ensure a batch query reports the indexed address along with an address
+	// that was never seen.
+	unusedAddr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(
+		make([]byte, 20), idx.chain.ChainParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	exists, err := idx.ExistsAddresses([]stdaddr.Address{unusedAddr, addrs[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exists) != 2 || exists[0] || !exists[1] {
+		t.Fatalf("unexpected batch query result %v", exists)
+	}
+
 	// Simulate a reorg by setting bk4 as the main chain tip. bk5 is now
 	// an orphan block.
 	g.SetTip("bk4")
@@ -135,7 +152,7 @@ func TestExistsAddrIndexAsync(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	idx, err = NewExistsAddrIndex(subber, db, chain)
+	idx, err = NewExistsAddrIndex(subber, db, chain, DefaultExistsAddrFPRate)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -232,7 +249,7 @@ func TestExistsAddrIndexAsync(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	idx, err = NewExistsAddrIndex(subber, db, chain)
+	idx, err = NewExistsAddrIndex(subber, db, chain, DefaultExistsAddrFPRate)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -317,3 +334,15 @@ func TestExistsAddrIndexAsync(t *testing.T) {
 		t.Fatalf("expected tip hash to be %s, got %s", bk4a.Hash(), tipHash)
 	}
 }
+
+// TestExistsAddrIndexInvalidFPRate ensures creating an exists address index
+// with a false positive rate that is out of range is rejected.
+func TestExistsAddrIndexInvalidFPRate(t *testing.T) {
+	for _, fpRate := range []float64{-0.1, 0, 1, 1.5} {
+		_, err := NewExistsAddrIndex(nil, nil, nil, fpRate)
+		if !errors.Is(err, ErrInvalidFPRate) {
+			t.Errorf("fp rate %v: unexpected error -- got %v, want %v",
+				fpRate, err, ErrInvalidFPRate)
+		}
+	}
+}
@@ -44,7 +44,7 @@ func TestIndexSubscriberAsync(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	existsAddrIdx, err := NewExistsAddrIndex(subber, db, chain)
+	existsAddrIdx, err := NewExistsAddrIndex(subber, db, chain, DefaultExistsAddrFPRate)
 	if err != nil {
 		t.Fatal(err)
 	}
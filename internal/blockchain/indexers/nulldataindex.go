@@ -0,0 +1,644 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/database/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
+	"github.com/decred/dcrd/wire"
+)
+
+const (
+	// nullDataIndexName is the human-readable name for the index.
+	nullDataIndexName = "null data index"
+
+	// nullDataIndexVersion is the current version of the null data index.
+	nullDataIndexVersion = 1
+
+	// MaxNullDataPrefixLen is the maximum allowed length of a prefix used to
+	// filter the payloads stored in the null data index.
+	MaxNullDataPrefixLen = 32
+
+	// nullDataLocSize is the size of the location portion of a null data
+	// index key.  It consists of 4 bytes block height + 1 byte tree + 4 bytes
+	// transaction index + 4 bytes output index.
+	nullDataLocSize = 4 + 1 + 4 + 4
+)
+
+var (
+	// nullDataHeightOrder is the byte order used for serializing the
+	// location fields of null data index keys.  Big endian is used so the
+	// keys sort by their location in the chain.
+	nullDataHeightOrder = binary.BigEndian
+
+	// nullDataIndexKey is the key of the null data index and the db bucket
+	// used to house it.
+	nullDataIndexKey = []byte("nulldataidx")
+
+	// nullDataPrefixesBucketName is the name of the db bucket used to house
+	// the set of prefixes the null data index was built with.
+	nullDataPrefixesBucketName = []byte("nulldataidxprefixes")
+)
+
+// -----------------------------------------------------------------------------
+// The null data index consists of an entry for every null data (OP_RETURN)
+// output in the main chain whose payload starts with one of a configured set
+// of prefixes.  Entries are keyed by the matching prefix followed by the
+// location of the output, with the block height first, so that all payloads
+// for a given prefix within a range of heights may be efficiently iterated in
+// order with a cursor.
+//
+// A separate bucket stores the set of prefixes the index was built with so
+// that the index can be rebuilt when the configured prefixes change.
+//
+// The serialized format for the keys and values in the null data index bucket
+// is:
+//
+//   <prefix len><prefix><height><tree><tx index><output index> =
+//     <tx hash><payload>
+//
+//   Field           Type              Size
+//   prefix len      uint8             1 byte
+//   prefix          []byte            variable
+//   height          uint32            4 bytes (big endian)
+//   tree            int8              1 byte
+//   tx index        uint32            4 bytes (big endian)
+//   output index    uint32            4 bytes (big endian)
+//   tx hash         chainhash.Hash    32 bytes
+//   payload         []byte            variable
+//
+// The serialized format for the keys and values in the prefixes bucket is:
+//
+//   <prefix> = <nil>
+// -----------------------------------------------------------------------------
+
+// NullDataEntry houses information about a null data output stored in the
+// null data index.
+type NullDataEntry struct {
+	// Height is the height of the block that contains the transaction.
+	Height int64
+
+	// TxHash is the hash of the transaction that contains the output.
+	TxHash chainhash.Hash
+
+	// Tree is the transaction tree of the transaction.
+	Tree int8
+
+	// TxIndex is the index of the transaction within the array of
+	// transactions that comprise the tree of the block.
+	TxIndex uint32
+
+	// OutputIndex is the index of the null data output in the transaction.
+	OutputIndex uint32
+
+	// Payload is the data pushed by the null data output.
+	Payload []byte
+}
+
+// extractNullDataPayload returns the data pushed by the passed script if it is
+// a standard null data script.  The returned boolean is false when the script
+// is not a null data script.
+func extractNullDataPayload(scriptVersion uint16, script []byte) ([]byte, bool) {
+	if !stdscript.IsNullDataScript(scriptVersion, script) {
+		return nil, false
+	}
+
+	// A standard null data script is either a single OP_RETURN or an OP_RETURN
+	// followed by a single canonical data push.
+	if len(script) == 1 {
+		return nil, true
+	}
+	tokenizer := txscript.MakeScriptTokenizer(scriptVersion, script[1:])
+	if !tokenizer.Next() {
+		return nil, false
+	}
+	return tokenizer.Data(), true
+}
+
+// nullDataPrefixKey returns the portion of a null data index key that is
+// common to all entries for the passed prefix.
+func nullDataPrefixKey(prefix []byte) []byte {
+	key := make([]byte, 1+len(prefix))
+	key[0] = uint8(len(prefix))
+	copy(key[1:], prefix)
+	return key
+}
+
+// nullDataEntryKey returns the null data index key for the passed prefix and
+// output location.
+func nullDataEntryKey(prefix []byte, height uint32, tree int8, txIndex, outputIndex uint32) []byte {
+	prefixKeyLen := 1 + len(prefix)
+	key := make([]byte, prefixKeyLen+nullDataLocSize)
+	key[0] = uint8(len(prefix))
+	copy(key[1:], prefix)
+	loc := key[prefixKeyLen:]
+	nullDataHeightOrder.PutUint32(loc, height)
+	loc[4] = uint8(tree)
+	nullDataHeightOrder.PutUint32(loc[5:], txIndex)
+	nullDataHeightOrder.PutUint32(loc[9:], outputIndex)
+	return key
+}
+
+// matchNullDataPrefixes returns all prefixes in the passed set that the
+// provided payload starts with.
+func matchNullDataPrefixes(prefixes [][]byte, payload []byte) [][]byte {
+	var matches [][]byte
+	for _, prefix := range prefixes {
+		if bytes.HasPrefix(payload, prefix) {
+			matches = append(matches, prefix)
+		}
+	}
+	return matches
+}
+
+// NullDataIndex implements an index over the payloads of null data (OP_RETURN)
+// outputs that start with any of a configured set of prefixes.  This allows
+// protocols that anchor data in transactions to efficiently query all of
+// their payloads within a range of blocks.
+type NullDataIndex struct {
+	// prefixes is the sorted set of prefixes payloads must start with in
+	// order to be indexed.
+	prefixes [][]byte
+
+	// These fields provide access to the chain queryer and the
+	// database of the index.
+	db    database.DB
+	chain ChainQueryer
+
+	// These fields track the notification subscription for the index
+	// and its subscribers.
+	sub         *IndexSubscription
+	subscribers map[chan bool]struct{}
+
+	mtx    sync.Mutex
+	cancel context.CancelFunc
+}
+
+// Ensure the NullDataIndex type implements the Indexer interface.
+var _ Indexer = (*NullDataIndex)(nil)
+
+// NewNullDataIndex returns a new instance of an indexer that is used to create
+// a mapping of the payloads of all null data outputs in the blockchain that
+// start with any of the provided prefixes to their location.
+//
+// The index is automatically rebuilt when the provided prefixes differ from
+// the ones it was previously built with.
+func NewNullDataIndex(subscriber *IndexSubscriber, db database.DB, chain ChainQueryer, prefixes [][]byte) (*NullDataIndex, error) {
+	if len(prefixes) == 0 {
+		return nil, errors.New("the null data index requires at least " +
+			"one prefix")
+	}
+
+	// Deduplicate and sort the prefixes so the stored set is deterministic.
+	seen := make(map[string]struct{}, len(prefixes))
+	uniquePrefixes := make([][]byte, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		if len(prefix) == 0 || len(prefix) > MaxNullDataPrefixLen {
+			return nil, fmt.Errorf("null data index prefix %x must be "+
+				"between 1 and %d bytes", prefix, MaxNullDataPrefixLen)
+		}
+		if _, ok := seen[string(prefix)]; ok {
+			continue
+		}
+		seen[string(prefix)] = struct{}{}
+		uniquePrefixes = append(uniquePrefixes, prefix)
+	}
+	sort.Slice(uniquePrefixes, func(i, j int) bool {
+		return bytes.Compare(uniquePrefixes[i], uniquePrefixes[j]) < 0
+	})
+
+	idx := &NullDataIndex{
+		prefixes:    uniquePrefixes,
+		db:          db,
+		chain:       chain,
+		subscribers: make(map[chan bool]struct{}),
+		cancel:      subscriber.cancel,
+	}
+
+	// The null data index is an optional index.  It has no prerequisite and
+	// is updated asynchronously.
+	sub, err := subscriber.Subscribe(idx, noPrereqs)
+	if err != nil {
+		return nil, err
+	}
+
+	idx.sub = sub
+
+	err = idx.Init(subscriber.ctx, chain.ChainParams())
+	if err != nil {
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+// storedPrefixesMatch returns whether or not the set of prefixes the existing
+// index was built with matches the prefixes configured for the index.  It
+// returns true when the index does not exist yet.
+func (idx *NullDataIndex) storedPrefixesMatch() (bool, error) {
+	match := true
+	err := idx.db.View(func(dbTx database.Tx) error {
+		bucket := dbTx.Metadata().Bucket(nullDataPrefixesBucketName)
+		if bucket == nil {
+			return nil
+		}
+
+		var stored [][]byte
+		err := bucket.ForEach(func(k, _ []byte) error {
+			stored = append(stored, k)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// The keys are iterated in byte-wise order which matches the order
+		// of the configured prefixes.
+		if len(stored) != len(idx.prefixes) {
+			match = false
+			return nil
+		}
+		for i := range stored {
+			if !bytes.Equal(stored[i], idx.prefixes[i]) {
+				match = false
+				return nil
+			}
+		}
+		return nil
+	})
+	return match, err
+}
+
+// Init initializes the null data index.  In particular, it rebuilds the index
+// when the configured prefixes differ from the ones it was built with.
+//
+// This is part of the Indexer interface.
+func (idx *NullDataIndex) Init(ctx context.Context, chainParams *chaincfg.Params) error {
+	if interruptRequested(ctx) {
+		return indexerError(ErrInterruptRequested, interruptMsg)
+	}
+
+	// Finish any drops that were previously interrupted.
+	if err := finishDrop(ctx, idx); err != nil {
+		return err
+	}
+
+	// Drop the index when it was built with a different set of prefixes so
+	// it is rebuilt with the configured ones.
+	match, err := idx.storedPrefixesMatch()
+	if err != nil {
+		return err
+	}
+	if !match {
+		log.Infof("Rebuilding %s due to changed prefixes", idx.Name())
+		if err := idx.DropIndex(ctx, idx.db); err != nil {
+			return err
+		}
+	}
+
+	// Create the initial state for the index as needed.
+	if err := createIndex(idx, &chainParams.GenesisHash); err != nil {
+		return err
+	}
+
+	// Upgrade the index as needed.
+	if err := upgradeIndex(ctx, idx, &chainParams.GenesisHash); err != nil {
+		return err
+	}
+
+	// Recover the null data index and its dependents to the main chain if
+	// needed.
+	return recoverIndex(ctx, idx)
+}
+
+// Key returns the database key to use for the index as a byte slice.
+//
+// This is part of the Indexer interface.
+func (idx *NullDataIndex) Key() []byte {
+	return nullDataIndexKey
+}
+
+// Name returns the human-readable name of the index.
+//
+// This is part of the Indexer interface.
+func (idx *NullDataIndex) Name() string {
+	return nullDataIndexName
+}
+
+// Version returns the current version of the index.
+//
+// This is part of the Indexer interface.
+func (idx *NullDataIndex) Version() uint32 {
+	return nullDataIndexVersion
+}
+
+// DB returns the database of the index.
+//
+// This is part of the Indexer interface.
+func (idx *NullDataIndex) DB() database.DB {
+	return idx.db
+}
+
+// Queryer returns the chain queryer.
+//
+// This is part of the Indexer interface.
+func (idx *NullDataIndex) Queryer() ChainQueryer {
+	return idx.chain
+}
+
+// Tip returns the current tip of the index.
+//
+// This is part of the Indexer interface.
+func (idx *NullDataIndex) Tip() (int64, *chainhash.Hash, error) {
+	return tip(idx.db, idx.Key())
+}
+
+// Prefixes returns the sorted set of prefixes payloads must start with in
+// order to be indexed.  The returned prefixes must be treated as read only.
+func (idx *NullDataIndex) Prefixes() [][]byte {
+	return idx.prefixes
+}
+
+// IndexSubscription returns the subscription for index updates.
+//
+// This is part of the Indexer interface.
+func (idx *NullDataIndex) IndexSubscription() *IndexSubscription {
+	return idx.sub
+}
+
+// Subscribers returns all client channels waiting for the next index update.
+//
+// This is part of the Indexer interface.
+// Deprecated: This will be removed in the next major version bump.
+func (idx *NullDataIndex) Subscribers() map[chan bool]struct{} {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+	return idx.subscribers
+}
+
+// NotifySyncSubscribers signals subscribers of an index sync update.
+//
+// This is part of the Indexer interface.
+func (idx *NullDataIndex) NotifySyncSubscribers() {
+	idx.mtx.Lock()
+	notifySyncSubscribers(idx.subscribers)
+	idx.mtx.Unlock()
+}
+
+// WaitForSync subscribes clients for the next index sync update.
+//
+// This is part of the Indexer interface.
+func (idx *NullDataIndex) WaitForSync() chan bool {
+	c := make(chan bool)
+
+	idx.mtx.Lock()
+	idx.subscribers[c] = struct{}{}
+	idx.mtx.Unlock()
+
+	return c
+}
+
+// Create is invoked when the index is created for the first time.  It creates
+// the buckets for the null data index and stores the configured prefixes.
+//
+// This is part of the Indexer interface.
+func (idx *NullDataIndex) Create(dbTx database.Tx) error {
+	meta := dbTx.Metadata()
+	if _, err := meta.CreateBucket(nullDataIndexKey); err != nil {
+		return err
+	}
+	prefixesBucket, err := meta.CreateBucketIfNotExists(
+		nullDataPrefixesBucketName)
+	if err != nil {
+		return err
+	}
+	for _, prefix := range idx.prefixes {
+		if err := prefixesBucket.Put(prefix, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachNullDataMatch invokes the provided function with the index key and
+// serialized value for every null data output in the passed block with a
+// payload that starts with one of the configured prefixes.
+func (idx *NullDataIndex) forEachNullDataMatch(block *dcrutil.Block, f func(key, value []byte) error) error {
+	height := uint32(block.Height())
+	processTxns := func(txns []*wire.MsgTx, tree int8) error {
+		for txIdx, tx := range txns {
+			var txHash *chainhash.Hash
+			for txOutIdx, txOut := range tx.TxOut {
+				payload, ok := extractNullDataPayload(txOut.Version,
+					txOut.PkScript)
+				if !ok {
+					continue
+				}
+				matches := matchNullDataPrefixes(idx.prefixes, payload)
+				if len(matches) == 0 {
+					continue
+				}
+
+				// Only calculate the transaction hash when it is needed.
+				if txHash == nil {
+					hash := tx.TxHash()
+					txHash = &hash
+				}
+				value := make([]byte, chainhash.HashSize+len(payload))
+				copy(value, txHash[:])
+				copy(value[chainhash.HashSize:], payload)
+				for _, prefix := range matches {
+					key := nullDataEntryKey(prefix, height, tree,
+						uint32(txIdx), uint32(txOutIdx))
+					if err := f(key, value); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	msgBlock := block.MsgBlock()
+	err := processTxns(msgBlock.Transactions, wire.TxTreeRegular)
+	if err != nil {
+		return err
+	}
+	return processTxns(msgBlock.STransactions, wire.TxTreeStake)
+}
+
+// connectBlock adds an entry for every null data output in the passed block
+// with a payload that starts with one of the configured prefixes.
+func (idx *NullDataIndex) connectBlock(dbTx database.Tx, block *dcrutil.Block) error {
+	// NOTE: The fact that the block can disapprove the regular tree of the
+	// previous block is ignored for this index because even though the
+	// disapproved transactions no longer apply spend semantics, the data they
+	// anchor was still published in the block.
+
+	bucket := dbTx.Metadata().Bucket(nullDataIndexKey)
+	err := idx.forEachNullDataMatch(block, func(key, value []byte) error {
+		return bucket.Put(key, value)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Update the current index tip.
+	return dbPutIndexerTip(dbTx, idx.Key(), block.Hash(), int32(block.Height()))
+}
+
+// disconnectBlock removes the entries for every null data output in the passed
+// block with a payload that starts with one of the configured prefixes.
+func (idx *NullDataIndex) disconnectBlock(dbTx database.Tx, block *dcrutil.Block) error {
+	bucket := dbTx.Metadata().Bucket(nullDataIndexKey)
+	err := idx.forEachNullDataMatch(block, func(key, _ []byte) error {
+		return bucket.Delete(key)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Update the current index tip.
+	return dbPutIndexerTip(dbTx, idx.Key(), &block.MsgBlock().Header.PrevBlock,
+		int32(block.Height()-1))
+}
+
+// Entries returns the entries in the null data index for the provided prefix
+// within the provided inclusive range of block heights ordered by their
+// location in the chain.  At most maxEntries entries are returned when it is
+// greater than zero.
+//
+// The prefix must be one of the configured prefixes, since payloads are only
+// indexed by those prefixes.
+//
+// This function is safe for concurrent access.
+func (idx *NullDataIndex) Entries(prefix []byte, startHeight, endHeight int64, maxEntries int) ([]NullDataEntry, error) {
+	var configured bool
+	for _, p := range idx.prefixes {
+		if bytes.Equal(p, prefix) {
+			configured = true
+			break
+		}
+	}
+	if !configured {
+		return nil, fmt.Errorf("prefix %x is not indexed by the %s", prefix,
+			idx.Name())
+	}
+	if startHeight < 0 || startHeight > endHeight {
+		return nil, fmt.Errorf("invalid height range [%d, %d]", startHeight,
+			endHeight)
+	}
+
+	prefixKey := nullDataPrefixKey(prefix)
+	startKey := nullDataEntryKey(prefix, uint32(startHeight), 0, 0, 0)
+	var entries []NullDataEntry
+	err := idx.db.View(func(dbTx database.Tx) error {
+		cursor := dbTx.Metadata().Bucket(nullDataIndexKey).Cursor()
+		for ok := cursor.Seek(startKey); ok; ok = cursor.Next() {
+			key := cursor.Key()
+			if !bytes.HasPrefix(key, prefixKey) {
+				break
+			}
+			loc := key[len(prefixKey):]
+			value := cursor.Value()
+			if len(loc) != nullDataLocSize || len(value) < chainhash.HashSize {
+				str := fmt.Sprintf("corrupt null data index entry for "+
+					"prefix %x", prefix)
+				return makeDbErr(database.ErrCorruption, str)
+			}
+
+			height := int64(nullDataHeightOrder.Uint32(loc))
+			if height > endHeight {
+				break
+			}
+			entry := NullDataEntry{
+				Height:      height,
+				Tree:        int8(loc[4]),
+				TxIndex:     nullDataHeightOrder.Uint32(loc[5:]),
+				OutputIndex: nullDataHeightOrder.Uint32(loc[9:]),
+				Payload:     append([]byte(nil), value[chainhash.HashSize:]...),
+			}
+			copy(entry.TxHash[:], value)
+			entries = append(entries, entry)
+			if maxEntries > 0 && len(entries) >= maxEntries {
+				break
+			}
+		}
+		return nil
+	})
+	return entries, err
+}
+
+// dropNullDataPrefixes drops the bucket that houses the set of prefixes the
+// null data index was built with.
+func dropNullDataPrefixes(db database.DB) error {
+	return db.Update(func(dbTx database.Tx) error {
+		err := dbTx.Metadata().DeleteBucket(nullDataPrefixesBucketName)
+		if err != nil && !errors.Is(err, database.ErrBucketNotFound) {
+			return err
+		}
+		return nil
+	})
+}
+
+// DropNullDataIndex drops the null data index from the provided database if it
+// exists.
+func DropNullDataIndex(ctx context.Context, db database.DB) error {
+	err := dropFlatIndex(ctx, db, nullDataIndexKey, nullDataIndexName)
+	if err != nil {
+		return err
+	}
+
+	// Call extra index specific deinitialization for the null data index.
+	return dropNullDataPrefixes(db)
+}
+
+// DropIndex drops the null data index from the provided database if it exists.
+func (*NullDataIndex) DropIndex(ctx context.Context, db database.DB) error {
+	return DropNullDataIndex(ctx, db)
+}
+
+// ProcessNotification indexes the provided notification based on its
+// notification type.
+//
+// This is part of the Indexer interface.
+func (idx *NullDataIndex) ProcessNotification(dbTx database.Tx, ntfn *IndexNtfn) error {
+	switch ntfn.NtfnType {
+	case ConnectNtfn:
+		err := idx.connectBlock(dbTx, ntfn.Block)
+		if err != nil {
+			msg := fmt.Sprintf("%s: unable to connect block: %v",
+				idx.Name(), err)
+			return indexerError(ErrConnectBlock, msg)
+		}
+
+	case DisconnectNtfn:
+		err := idx.disconnectBlock(dbTx, ntfn.Block)
+		if err != nil {
+			msg := fmt.Sprintf("%s: unable to disconnect block: %v",
+				idx.Name(), err)
+			return indexerError(ErrDisconnectBlock, msg)
+		}
+
+	default:
+		msg := fmt.Sprintf("%s: unknown notification type received: %d",
+			idx.Name(), ntfn.NtfnType)
+		return indexerError(ErrInvalidNotificationType, msg)
+	}
+
+	return nil
+}
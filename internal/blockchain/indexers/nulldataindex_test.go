@@ -0,0 +1,272 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/decred/dcrd/blockchain/v5/chaingen"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/database/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+// addNullDataBlock extends the provided chain with a generated block whose
+// coinbase includes a null data output for each of the provided payloads.
+func addNullDataBlock(t *testing.T, chain *testChain, gen *chaingen.Generator, name string, payloads ...[]byte) *dcrutil.Block {
+	t.Helper()
+
+	msgBlk := gen.NextBlock(name, nil, nil, func(b *wire.MsgBlock) {
+		for _, payload := range payloads {
+			script, err := txscript.NewScriptBuilder().
+				AddOp(txscript.OP_RETURN).AddData(payload).Script()
+			if err != nil {
+				t.Fatalf("unexpected error creating script: %v", err)
+			}
+			b.Transactions[0].AddTxOut(wire.NewTxOut(0, script))
+		}
+	})
+	gen.SaveTipCoinbaseOuts()
+
+	blk := dcrutil.NewBlock(msgBlk)
+	if err := chain.AddBlock(blk); err != nil {
+		t.Fatal(err)
+	}
+
+	return blk
+}
+
+// TestNullDataIndexAsync ensures the null data index behaves as expected when
+// receiving updates asynchronously.
+func TestNullDataIndexAsync(t *testing.T) {
+	db := setupDB(t)
+
+	chain, err := newTestChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := chaingen.MakeGenerator(chaincfg.SimNetParams())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// Add blocks that contain payloads with and without the indexed prefixes.
+	prefixA, prefixB := []byte("DCRA"), []byte("DCRB")
+	addBlock(t, chain, &g, "bk1")
+	bk2 := addNullDataBlock(t, chain, &g, "bk2", []byte("DCRAhello"),
+		[]byte("other"))
+	bk3 := addNullDataBlock(t, chain, &g, "bk3", []byte("DCRBworld"))
+
+	// Initialize the null data index.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subber := NewIndexSubscriber(ctx)
+	go subber.Run(ctx)
+
+	idx, err := NewNullDataIndex(subber, db, chain, [][]byte{prefixB, prefixA,
+		prefixA})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = subber.CatchUp(ctx, db, chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Ensure the configured prefixes were deduplicated and sorted.
+	prefixes := idx.Prefixes()
+	if len(prefixes) != 2 || !bytes.Equal(prefixes[0], prefixA) ||
+		!bytes.Equal(prefixes[1], prefixB) {
+
+		t.Fatalf("unexpected prefixes %q", prefixes)
+	}
+
+	// Ensure the index got synced to bk3 on initialization.
+	tipHeight, tipHash, err := idx.Tip()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tipHeight != bk3.Height() || *tipHash != *bk3.Hash() {
+		t.Fatalf("expected tip to be %s (height %d), got %s (height %d)",
+			bk3.Hash(), bk3.Height(), tipHash, tipHeight)
+	}
+
+	// Connect another block with a matching payload.
+	bk4 := addNullDataBlock(t, chain, &g, "bk4", []byte("DCRAagain"))
+	notifyAndWait(t, subber, &IndexNtfn{
+		NtfnType: ConnectNtfn,
+		Block:    bk4,
+		Parent:   bk3,
+	})
+
+	// Ensure the entries for each prefix are returned in order for various
+	// height ranges and limits.
+	coinbaseOuts := uint32(len(bk2.MsgBlock().Transactions[0].TxOut))
+	tests := []struct {
+		name       string
+		prefix     []byte
+		start, end int64
+		max        int
+		want       []string
+		wantHeight []int64
+	}{{
+		name:       "all entries for prefix A",
+		prefix:     prefixA,
+		start:      0,
+		end:        100,
+		want:       []string{"DCRAhello", "DCRAagain"},
+		wantHeight: []int64{bk2.Height(), bk4.Height()},
+	}, {
+		name:       "all entries for prefix B",
+		prefix:     prefixB,
+		start:      0,
+		end:        100,
+		want:       []string{"DCRBworld"},
+		wantHeight: []int64{bk3.Height()},
+	}, {
+		name:       "prefix A limited height range",
+		prefix:     prefixA,
+		start:      bk3.Height(),
+		end:        bk4.Height(),
+		want:       []string{"DCRAagain"},
+		wantHeight: []int64{bk4.Height()},
+	}, {
+		name:   "prefix A range without entries",
+		prefix: prefixA,
+		start:  bk3.Height(),
+		end:    bk3.Height(),
+	}, {
+		name:       "prefix A max entries",
+		prefix:     prefixA,
+		start:      0,
+		end:        100,
+		max:        1,
+		want:       []string{"DCRAhello"},
+		wantHeight: []int64{bk2.Height()},
+	}}
+	for _, test := range tests {
+		entries, err := idx.Entries(test.prefix, test.start, test.end,
+			test.max)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", test.name, err)
+		}
+		if len(entries) != len(test.want) {
+			t.Fatalf("%q: unexpected number of entries -- got %d, want %d",
+				test.name, len(entries), len(test.want))
+		}
+		for i, entry := range entries {
+			if string(entry.Payload) != test.want[i] {
+				t.Fatalf("%q: unexpected payload -- got %q, want %q",
+					test.name, entry.Payload, test.want[i])
+			}
+			if entry.Height != test.wantHeight[i] {
+				t.Fatalf("%q: unexpected height -- got %d, want %d",
+					test.name, entry.Height, test.wantHeight[i])
+			}
+		}
+	}
+
+	// Ensure the location details of an entry are correct.
+	entries, err := idx.Entries(prefixA, bk2.Height(), bk2.Height(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantHash := bk2.MsgBlock().Transactions[0].TxHash()
+	if entries[0].TxHash != wantHash || entries[0].Tree != wire.TxTreeRegular ||
+		entries[0].TxIndex != 0 || entries[0].OutputIndex != coinbaseOuts-2 {
+
+		t.Fatalf("unexpected entry %+v", entries[0])
+	}
+
+	// Ensure querying a prefix that is not indexed and an invalid height range
+	// are rejected.
+	if _, err := idx.Entries([]byte("DCRC"), 0, 100, 0); err == nil {
+		t.Fatal("expected error for unindexed prefix")
+	}
+	if _, err := idx.Entries(prefixA, 10, 5, 0); err == nil {
+		t.Fatal("expected error for invalid height range")
+	}
+
+	// Ensure the entries are removed when blocks are disconnected.
+	err = chain.RemoveBlock(bk4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.SetTip("bk3")
+	notifyAndWait(t, subber, &IndexNtfn{
+		NtfnType: DisconnectNtfn,
+		Block:    bk4,
+		Parent:   bk3,
+	})
+	entries, err = idx.Entries(prefixA, 0, 100, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || string(entries[0].Payload) != "DCRAhello" {
+		t.Fatalf("unexpected entries after disconnect: %+v", entries)
+	}
+
+	// Resubscribe the index with a different set of prefixes and ensure it is
+	// rebuilt accordingly.
+	subber.mtx.Lock()
+	err = idx.sub.stop()
+	subber.mtx.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idx, err = NewNullDataIndex(subber, db, chain, [][]byte{prefixB})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = subber.CatchUp(ctx, db, chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := idx.Entries(prefixA, 0, 100, 0); err == nil {
+		t.Fatal("expected error for prefix removed from the index")
+	}
+	entries, err = idx.Entries(prefixB, 0, 100, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || string(entries[0].Payload) != "DCRBworld" {
+		t.Fatalf("unexpected entries after rebuild: %+v", entries)
+	}
+	err = db.View(func(dbTx database.Tx) error {
+		if dbTx.Metadata().Bucket(nullDataIndexKey).Get(nullDataEntryKey(
+			prefixA, uint32(bk2.Height()), wire.TxTreeRegular, 0,
+			coinbaseOuts-2)) != nil {
+
+			t.Fatal("stale entry for removed prefix after rebuild")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Drop the index and ensure the stored prefixes are removed as well.
+	err = idx.DropIndex(ctx, idx.db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.View(func(dbTx database.Tx) error {
+		if dbTx.Metadata().Bucket(nullDataPrefixesBucketName) != nil {
+			t.Fatal("prefixes bucket still exists after drop")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,805 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/database/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+const (
+	// utxoHistoryIndexName is the human-readable name for the index.
+	utxoHistoryIndexName = "utxo history index"
+
+	// utxoHistoryIndexVersion is the current version of the utxo history
+	// index.
+	utxoHistoryIndexVersion = 1
+
+	// utxoHistoryKeySize is the size of a utxo history index key.  It
+	// consists of the 32 byte transaction hash + 4 byte output index.
+	utxoHistoryKeySize = chainhash.HashSize + 4
+
+	// utxoHistoryHeaderSize is the size of the fixed portion of a serialized
+	// utxo history entry.  It consists of 4 bytes block height + 1 byte tree
+	// + 1 byte flags + 8 bytes amount + 2 bytes script version + 4 bytes
+	// script length.
+	utxoHistoryHeaderSize = 4 + 1 + 1 + 8 + 2 + 4
+
+	// utxoHistoryEventSize is the size of a serialized utxo history event.
+	// It consists of 4 bytes block height + 1 byte kind + 32 bytes spending
+	// transaction hash + 4 bytes input index.
+	utxoHistoryEventSize = 4 + 1 + chainhash.HashSize + 4
+
+	// utxoHistoryFlagCoinbase is the flag that indicates the output was
+	// created by a coinbase transaction.
+	utxoHistoryFlagCoinbase = 0x01
+)
+
+var (
+	// utxoHistoryIndexKey is the key of the utxo history index and the db
+	// bucket used to house it.
+	utxoHistoryIndexKey = []byte("utxohistoryidx")
+)
+
+// -----------------------------------------------------------------------------
+// The utxo history index consists of an entry for every transaction output in
+// the main chain, including those that have since been spent.  Each entry
+// houses the output itself along with the ordered list of events that changed
+// its state in the main chain, which allows the state of the output as of any
+// historical height to be determined.
+//
+// Outputs are created by the block that contains their transaction.  The
+// events that change the state of an output afterwards are:
+//
+// - The output is spent by a transaction
+// - A spend of the output is reverted due to the regular transaction tree that
+//   contains the spending transaction being disapproved by stakeholders
+// - The output is removed due to the regular transaction tree that created it
+//   being disapproved by stakeholders
+// - The output is created again due to the transaction that created it being
+//   included in a later block after it was removed
+//
+// The serialized format for the keys and values in the utxo history index
+// bucket is:
+//
+//   <tx hash><output index> = <height><tree><flags><amount><script version>
+//     <script len><script>[<event>...]
+//
+//   Field           Type              Size
+//   tx hash         chainhash.Hash    32 bytes
+//   output index    uint32            4 bytes
+//   height          uint32            4 bytes
+//   tree            int8              1 byte
+//   flags           uint8             1 byte
+//   amount          int64             8 bytes
+//   script version  uint16            2 bytes
+//   script len      uint32            4 bytes
+//   script          []byte            variable
+//
+// Each event is serialized as:
+//
+//   Field           Type              Size
+//   height          uint32            4 bytes
+//   kind            uint8             1 byte
+//   spender hash    chainhash.Hash    32 bytes
+//   input index     uint32            4 bytes
+// -----------------------------------------------------------------------------
+
+// UtxoEventKind identifies the kind of a utxo history event.
+type UtxoEventKind uint8
+
+// These constants define the kinds of utxo history events.
+const (
+	// UtxoEventSpent indicates the output was spent by a transaction.
+	UtxoEventSpent UtxoEventKind = 1
+
+	// UtxoEventUnspent indicates a spend of the output was reverted because
+	// the regular transaction tree that contains the spending transaction
+	// was disapproved.
+	UtxoEventUnspent UtxoEventKind = 2
+
+	// UtxoEventRemoved indicates the output was removed because the regular
+	// transaction tree that created it was disapproved.
+	UtxoEventRemoved UtxoEventKind = 3
+
+	// UtxoEventRecreated indicates the output was created again because the
+	// transaction that created it was included in a later block after the
+	// output was removed.
+	UtxoEventRecreated UtxoEventKind = 4
+)
+
+// utxoEventKindStrings is a map of utxo history event kinds back to their
+// constant names for pretty printing.
+var utxoEventKindStrings = map[UtxoEventKind]string{
+	UtxoEventSpent:     "spent",
+	UtxoEventUnspent:   "unspent",
+	UtxoEventRemoved:   "removed",
+	UtxoEventRecreated: "recreated",
+}
+
+// String returns the UtxoEventKind in human-readable form.
+func (k UtxoEventKind) String() string {
+	if s, ok := utxoEventKindStrings[k]; ok {
+		return s
+	}
+	return fmt.Sprintf("Unknown UtxoEventKind (%d)", uint8(k))
+}
+
+// UtxoState identifies the state of an output as of a given height.
+type UtxoState uint8
+
+// These constants define the possible states of an output.
+const (
+	// UtxoStateNotCreated indicates the output had not been created yet.
+	UtxoStateNotCreated UtxoState = iota
+
+	// UtxoStateUnspent indicates the output was unspent.
+	UtxoStateUnspent
+
+	// UtxoStateSpent indicates the output was spent.
+	UtxoStateSpent
+
+	// UtxoStateRemoved indicates the output was removed due to the regular
+	// transaction tree that created it being disapproved.
+	UtxoStateRemoved
+)
+
+// utxoStateStrings is a map of output states back to their constant names for
+// pretty printing.
+var utxoStateStrings = map[UtxoState]string{
+	UtxoStateNotCreated: "notcreated",
+	UtxoStateUnspent:    "unspent",
+	UtxoStateSpent:      "spent",
+	UtxoStateRemoved:    "removed",
+}
+
+// String returns the UtxoState in human-readable form.
+func (s UtxoState) String() string {
+	if str, ok := utxoStateStrings[s]; ok {
+		return str
+	}
+	return fmt.Sprintf("Unknown UtxoState (%d)", uint8(s))
+}
+
+// UtxoEvent houses information about an event that changed the state of an
+// output in the main chain.
+type UtxoEvent struct {
+	// Height is the height of the block that caused the event.
+	Height int64
+
+	// Kind is the kind of event.
+	Kind UtxoEventKind
+
+	// SpenderHash and SpenderIndex identify the transaction input that spent
+	// the output or had its spend of the output reverted.  They are zero
+	// for removal and recreation events.
+	SpenderHash  chainhash.Hash
+	SpenderIndex uint32
+}
+
+// UtxoHistoryEntry houses information about a transaction output and its
+// history stored in the utxo history index.
+type UtxoHistoryEntry struct {
+	// Height is the height of the block that first created the output.
+	Height int64
+
+	// Tree is the transaction tree of the transaction that created the
+	// output.
+	Tree int8
+
+	// Coinbase indicates the output was created by a coinbase transaction.
+	Coinbase bool
+
+	// Amount, ScriptVersion, and PkScript are the details of the output.
+	Amount        int64
+	ScriptVersion uint16
+	PkScript      []byte
+
+	// Events are the events that changed the state of the output ordered by
+	// height.
+	Events []UtxoEvent
+}
+
+// StateAt returns the state of the output as of the block at the provided
+// height along with the most recent event that affected it as of that height,
+// if any.
+func (e *UtxoHistoryEntry) StateAt(height int64) (UtxoState, *UtxoEvent) {
+	if height < e.Height {
+		return UtxoStateNotCreated, nil
+	}
+
+	var event *UtxoEvent
+	for i := range e.Events {
+		if e.Events[i].Height > height {
+			break
+		}
+		event = &e.Events[i]
+	}
+	if event == nil {
+		return UtxoStateUnspent, nil
+	}
+	switch event.Kind {
+	case UtxoEventSpent:
+		return UtxoStateSpent, event
+	case UtxoEventRemoved:
+		return UtxoStateRemoved, event
+	}
+	return UtxoStateUnspent, event
+}
+
+// utxoHistoryKey returns the utxo history index key for the provided output.
+func utxoHistoryKey(hash *chainhash.Hash, index uint32) []byte {
+	key := make([]byte, utxoHistoryKeySize)
+	copy(key, hash[:])
+	byteOrder.PutUint32(key[chainhash.HashSize:], index)
+	return key
+}
+
+// serializeUtxoHistoryEntry returns the serialized utxo history entry for the
+// provided output that was created at the provided height without any events.
+func serializeUtxoHistoryEntry(height int64, tree int8, coinbase bool, txOut *wire.TxOut) []byte {
+	serialized := make([]byte, utxoHistoryHeaderSize+len(txOut.PkScript))
+	byteOrder.PutUint32(serialized, uint32(height))
+	serialized[4] = uint8(tree)
+	if coinbase {
+		serialized[5] |= utxoHistoryFlagCoinbase
+	}
+	byteOrder.PutUint64(serialized[6:], uint64(txOut.Value))
+	byteOrder.PutUint16(serialized[14:], txOut.Version)
+	byteOrder.PutUint32(serialized[16:], uint32(len(txOut.PkScript)))
+	copy(serialized[utxoHistoryHeaderSize:], txOut.PkScript)
+	return serialized
+}
+
+// deserializeUtxoHistoryEntry decodes the passed serialized utxo history entry
+// into a new entry.
+func deserializeUtxoHistoryEntry(serialized []byte) (*UtxoHistoryEntry, error) {
+	if len(serialized) < utxoHistoryHeaderSize {
+		str := "corrupt utxo history entry: short header"
+		return nil, makeDbErr(database.ErrCorruption, str)
+	}
+	scriptLen := int(byteOrder.Uint32(serialized[16:]))
+	eventsData := serialized[utxoHistoryHeaderSize:]
+	if scriptLen > len(eventsData) ||
+		(len(eventsData)-scriptLen)%utxoHistoryEventSize != 0 {
+
+		str := "corrupt utxo history entry: invalid length"
+		return nil, makeDbErr(database.ErrCorruption, str)
+	}
+	entry := &UtxoHistoryEntry{
+		Height:        int64(byteOrder.Uint32(serialized)),
+		Tree:          int8(serialized[4]),
+		Coinbase:      serialized[5]&utxoHistoryFlagCoinbase != 0,
+		Amount:        int64(byteOrder.Uint64(serialized[6:])),
+		ScriptVersion: byteOrder.Uint16(serialized[14:]),
+		PkScript:      append([]byte(nil), eventsData[:scriptLen]...),
+	}
+	eventsData = eventsData[scriptLen:]
+	numEvents := len(eventsData) / utxoHistoryEventSize
+	if numEvents > 0 {
+		entry.Events = make([]UtxoEvent, numEvents)
+	}
+	for i := range entry.Events {
+		data := eventsData[i*utxoHistoryEventSize:]
+		event := &entry.Events[i]
+		event.Height = int64(byteOrder.Uint32(data))
+		event.Kind = UtxoEventKind(data[4])
+		copy(event.SpenderHash[:], data[5:])
+		event.SpenderIndex = byteOrder.Uint32(data[5+chainhash.HashSize:])
+	}
+	return entry, nil
+}
+
+// UtxoHistoryIndex implements an archival index of every transaction output in
+// the main chain along with the history of its state.  This allows queries
+// about the state of outputs as of historical heights, such as those needed by
+// forensic and accounting tools.
+type UtxoHistoryIndex struct {
+	// These fields provide access to the chain queryer and the
+	// database of the index.
+	db    database.DB
+	chain ChainQueryer
+
+	// These fields track the notification subscription for the index
+	// and its subscribers.
+	sub         *IndexSubscription
+	subscribers map[chan bool]struct{}
+
+	mtx    sync.Mutex
+	cancel context.CancelFunc
+}
+
+// Ensure the UtxoHistoryIndex type implements the Indexer interface.
+var _ Indexer = (*UtxoHistoryIndex)(nil)
+
+// NewUtxoHistoryIndex returns a new instance of an indexer that is used to
+// create an archive of all transaction outputs in the blockchain along with
+// the history of their state.
+func NewUtxoHistoryIndex(subscriber *IndexSubscriber, db database.DB, chain ChainQueryer) (*UtxoHistoryIndex, error) {
+	idx := &UtxoHistoryIndex{
+		db:          db,
+		chain:       chain,
+		subscribers: make(map[chan bool]struct{}),
+		cancel:      subscriber.cancel,
+	}
+
+	// The utxo history index is an optional index.  It has no prerequisite
+	// and is updated asynchronously.
+	sub, err := subscriber.Subscribe(idx, noPrereqs)
+	if err != nil {
+		return nil, err
+	}
+
+	idx.sub = sub
+
+	err = idx.Init(subscriber.ctx, chain.ChainParams())
+	if err != nil {
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+// Init initializes the utxo history index.
+//
+// This is part of the Indexer interface.
+func (idx *UtxoHistoryIndex) Init(ctx context.Context, chainParams *chaincfg.Params) error {
+	if interruptRequested(ctx) {
+		return indexerError(ErrInterruptRequested, interruptMsg)
+	}
+
+	// Finish any drops that were previously interrupted.
+	if err := finishDrop(ctx, idx); err != nil {
+		return err
+	}
+
+	// Create the initial state for the index as needed.
+	if err := createIndex(idx, &chainParams.GenesisHash); err != nil {
+		return err
+	}
+
+	// Upgrade the index as needed.
+	if err := upgradeIndex(ctx, idx, &chainParams.GenesisHash); err != nil {
+		return err
+	}
+
+	// Recover the utxo history index and its dependents to the main chain if
+	// needed.
+	return recoverIndex(ctx, idx)
+}
+
+// Key returns the database key to use for the index as a byte slice.
+//
+// This is part of the Indexer interface.
+func (idx *UtxoHistoryIndex) Key() []byte {
+	return utxoHistoryIndexKey
+}
+
+// Name returns the human-readable name of the index.
+//
+// This is part of the Indexer interface.
+func (idx *UtxoHistoryIndex) Name() string {
+	return utxoHistoryIndexName
+}
+
+// Version returns the current version of the index.
+//
+// This is part of the Indexer interface.
+func (idx *UtxoHistoryIndex) Version() uint32 {
+	return utxoHistoryIndexVersion
+}
+
+// DB returns the database of the index.
+//
+// This is part of the Indexer interface.
+func (idx *UtxoHistoryIndex) DB() database.DB {
+	return idx.db
+}
+
+// Queryer returns the chain queryer.
+//
+// This is part of the Indexer interface.
+func (idx *UtxoHistoryIndex) Queryer() ChainQueryer {
+	return idx.chain
+}
+
+// Tip returns the current tip of the index.
+//
+// This is part of the Indexer interface.
+func (idx *UtxoHistoryIndex) Tip() (int64, *chainhash.Hash, error) {
+	return tip(idx.db, idx.Key())
+}
+
+// IndexSubscription returns the subscription for index updates.
+//
+// This is part of the Indexer interface.
+func (idx *UtxoHistoryIndex) IndexSubscription() *IndexSubscription {
+	return idx.sub
+}
+
+// Subscribers returns all client channels waiting for the next index update.
+//
+// This is part of the Indexer interface.
+// Deprecated: This will be removed in the next major version bump.
+func (idx *UtxoHistoryIndex) Subscribers() map[chan bool]struct{} {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+	return idx.subscribers
+}
+
+// NotifySyncSubscribers signals subscribers of an index sync update.
+//
+// This is part of the Indexer interface.
+func (idx *UtxoHistoryIndex) NotifySyncSubscribers() {
+	idx.mtx.Lock()
+	notifySyncSubscribers(idx.subscribers)
+	idx.mtx.Unlock()
+}
+
+// WaitForSync subscribes clients for the next index sync update.
+//
+// This is part of the Indexer interface.
+func (idx *UtxoHistoryIndex) WaitForSync() chan bool {
+	c := make(chan bool)
+
+	idx.mtx.Lock()
+	idx.subscribers[c] = struct{}{}
+	idx.mtx.Unlock()
+
+	return c
+}
+
+// Create is invoked when the index is created for the first time.  It creates
+// the bucket for the utxo history index.
+//
+// This is part of the Indexer interface.
+func (idx *UtxoHistoryIndex) Create(dbTx database.Tx) error {
+	_, err := dbTx.Metadata().CreateBucket(utxoHistoryIndexKey)
+	return err
+}
+
+// isNullOutPoint returns whether or not the passed outpoint does not reference
+// an actual output, such as the inputs of coinbase, stakebase, treasurybase,
+// and treasury spend transactions.
+func isNullOutPoint(outpoint *wire.OutPoint) bool {
+	return outpoint.Hash == chainhash.Hash{}
+}
+
+// forEachSpend invokes the provided function with the outpoint spent by every
+// input of the passed transactions along with the hash of the spending
+// transaction and the index of the input.
+func forEachSpend(txns []*wire.MsgTx, f func(prevOut *wire.OutPoint, spender *chainhash.Hash, inputIdx uint32) error) error {
+	for _, tx := range txns {
+		var txHash *chainhash.Hash
+		for txInIdx, txIn := range tx.TxIn {
+			prevOut := &txIn.PreviousOutPoint
+			if isNullOutPoint(prevOut) {
+				continue
+			}
+
+			// Only calculate the transaction hash when it is needed.
+			if txHash == nil {
+				hash := tx.TxHash()
+				txHash = &hash
+			}
+			if err := f(prevOut, txHash, uint32(txInIdx)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendUtxoEvent appends the provided event to the entry for the provided
+// outpoint in the passed bucket.
+func appendUtxoEvent(bucket database.Bucket, hash *chainhash.Hash, index uint32, event *UtxoEvent) error {
+	key := utxoHistoryKey(hash, index)
+	existing := bucket.Get(key)
+	if existing == nil {
+		str := fmt.Sprintf("utxo history entry for %v:%d does not exist",
+			hash, index)
+		return makeDbErr(database.ErrCorruption, str)
+	}
+
+	serialized := make([]byte, len(existing)+utxoHistoryEventSize)
+	copy(serialized, existing)
+	data := serialized[len(existing):]
+	byteOrder.PutUint32(data, uint32(event.Height))
+	data[4] = uint8(event.Kind)
+	copy(data[5:], event.SpenderHash[:])
+	byteOrder.PutUint32(data[5+chainhash.HashSize:], event.SpenderIndex)
+	return bucket.Put(key, serialized)
+}
+
+// removeUtxoEvents removes all events at the provided height from the entry for
+// the provided outpoint in the passed bucket.
+func removeUtxoEvents(bucket database.Bucket, hash *chainhash.Hash, index uint32, height int64) error {
+	key := utxoHistoryKey(hash, index)
+	existing := bucket.Get(key)
+	if existing == nil {
+		return nil
+	}
+	entry, err := deserializeUtxoHistoryEntry(existing)
+	if err != nil {
+		return err
+	}
+
+	// Events are ordered by height, so the events to remove are the final
+	// ones.
+	end := len(existing)
+	for i := len(entry.Events) - 1; i >= 0; i-- {
+		if entry.Events[i].Height != height {
+			break
+		}
+		end -= utxoHistoryEventSize
+	}
+	if end == len(existing) {
+		return nil
+	}
+	return bucket.Put(key, append([]byte(nil), existing[:end]...))
+}
+
+// forEachDisapproval invokes the provided functions for the changes to the
+// outputs that are caused by the passed block disapproving the regular tree of
+// its parent, if it does.  The spend function is invoked for every spend that
+// is reverted and the output function is invoked for every output that is
+// removed.
+func forEachDisapproval(block, parent *dcrutil.Block, spend func(prevOut *wire.OutPoint, spender *chainhash.Hash, inputIdx uint32) error, output func(hash *chainhash.Hash, index uint32) error) error {
+	// Nothing to do when the regular tree of the parent is approved.  Note
+	// that the outputs of the genesis block are not indexed since they are
+	// not spendable.
+	if parent == nil || parent.Height() == 0 ||
+		headerApprovesParent(&block.MsgBlock().Header) {
+
+		return nil
+	}
+
+	// Revert the spends prior to removing the outputs since transactions in
+	// the tree are able to spend outputs created earlier in the same tree.
+	regularTxns := parent.MsgBlock().Transactions
+	if err := forEachSpend(regularTxns, spend); err != nil {
+		return err
+	}
+	for _, tx := range regularTxns {
+		txHash := tx.TxHash()
+		for txOutIdx := range tx.TxOut {
+			if err := output(&txHash, uint32(txOutIdx)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// headerApprovesParent returns whether or not the vote bits in the passed
+// header indicate the regular transaction tree of the parent block should be
+// considered valid.
+func headerApprovesParent(header *wire.BlockHeader) bool {
+	return dcrutil.IsFlagSet16(header.VoteBits, dcrutil.BlockValid)
+}
+
+// connectBlock adds an entry for every output created by the passed block and
+// adds events for every output that the block spends or that changes state due
+// to the block disapproving the regular tree of its parent.
+func (idx *UtxoHistoryIndex) connectBlock(dbTx database.Tx, block, parent *dcrutil.Block) error {
+	bucket := dbTx.Metadata().Bucket(utxoHistoryIndexKey)
+	height := block.Height()
+
+	// Apply the effects of disapproving the regular tree of the parent.
+	err := forEachDisapproval(block, parent,
+		func(prevOut *wire.OutPoint, spender *chainhash.Hash, inputIdx uint32) error {
+			return appendUtxoEvent(bucket, &prevOut.Hash, prevOut.Index,
+				&UtxoEvent{
+					Height:       height,
+					Kind:         UtxoEventUnspent,
+					SpenderHash:  *spender,
+					SpenderIndex: inputIdx,
+				})
+		},
+		func(hash *chainhash.Hash, index uint32) error {
+			return appendUtxoEvent(bucket, hash, index, &UtxoEvent{
+				Height: height,
+				Kind:   UtxoEventRemoved,
+			})
+		})
+	if err != nil {
+		return err
+	}
+
+	// Add entries for all outputs created by the block prior to adding the
+	// spends since transactions are able to spend outputs created earlier in
+	// the same block.
+	msgBlock := block.MsgBlock()
+	addOutputs := func(txns []*wire.MsgTx, tree int8) error {
+		for txIdx, tx := range txns {
+			txHash := tx.TxHash()
+			coinbase := tree == wire.TxTreeRegular && txIdx == 0
+			for txOutIdx, txOut := range tx.TxOut {
+				// Transactions in a disapproved regular tree are commonly
+				// included again in a later block, in which case their
+				// outputs already have an entry.
+				key := utxoHistoryKey(&txHash, uint32(txOutIdx))
+				if bucket.Get(key) != nil {
+					err := appendUtxoEvent(bucket, &txHash, uint32(txOutIdx),
+						&UtxoEvent{Height: height, Kind: UtxoEventRecreated})
+					if err != nil {
+						return err
+					}
+					continue
+				}
+
+				value := serializeUtxoHistoryEntry(height, tree, coinbase,
+					txOut)
+				if err := bucket.Put(key, value); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := addOutputs(msgBlock.STransactions, wire.TxTreeStake); err != nil {
+		return err
+	}
+	if err := addOutputs(msgBlock.Transactions, wire.TxTreeRegular); err != nil {
+		return err
+	}
+
+	// Add spend events for all outputs spent by the block.
+	addSpend := func(prevOut *wire.OutPoint, spender *chainhash.Hash, inputIdx uint32) error {
+		return appendUtxoEvent(bucket, &prevOut.Hash, prevOut.Index,
+			&UtxoEvent{
+				Height:       height,
+				Kind:         UtxoEventSpent,
+				SpenderHash:  *spender,
+				SpenderIndex: inputIdx,
+			})
+	}
+	if err := forEachSpend(msgBlock.STransactions, addSpend); err != nil {
+		return err
+	}
+	if err := forEachSpend(msgBlock.Transactions, addSpend); err != nil {
+		return err
+	}
+
+	// Update the current index tip.
+	return dbPutIndexerTip(dbTx, idx.Key(), block.Hash(), int32(height))
+}
+
+// disconnectBlock removes the entries for every output created by the passed
+// block and removes the events the block added to other outputs.
+func (idx *UtxoHistoryIndex) disconnectBlock(dbTx database.Tx, block, parent *dcrutil.Block) error {
+	bucket := dbTx.Metadata().Bucket(utxoHistoryIndexKey)
+	height := block.Height()
+
+	// Remove the events for all outputs spent by the block.
+	removeSpend := func(prevOut *wire.OutPoint, _ *chainhash.Hash, _ uint32) error {
+		return removeUtxoEvents(bucket, &prevOut.Hash, prevOut.Index, height)
+	}
+	msgBlock := block.MsgBlock()
+	if err := forEachSpend(msgBlock.Transactions, removeSpend); err != nil {
+		return err
+	}
+	if err := forEachSpend(msgBlock.STransactions, removeSpend); err != nil {
+		return err
+	}
+
+	// Remove the entries for all outputs created by the block.  Outputs that
+	// were created again by the block only need the events at the height of
+	// the block removed.
+	removeOutputs := func(txns []*wire.MsgTx) error {
+		for _, tx := range txns {
+			txHash := tx.TxHash()
+			for txOutIdx := range tx.TxOut {
+				key := utxoHistoryKey(&txHash, uint32(txOutIdx))
+				existing := bucket.Get(key)
+				if len(existing) >= 4 &&
+					int64(byteOrder.Uint32(existing)) != height {
+
+					err := removeUtxoEvents(bucket, &txHash,
+						uint32(txOutIdx), height)
+					if err != nil {
+						return err
+					}
+					continue
+				}
+				if err := bucket.Delete(key); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := removeOutputs(msgBlock.Transactions); err != nil {
+		return err
+	}
+	if err := removeOutputs(msgBlock.STransactions); err != nil {
+		return err
+	}
+
+	// Remove the events caused by disapproving the regular tree of the
+	// parent.
+	err := forEachDisapproval(block, parent, removeSpend,
+		func(hash *chainhash.Hash, index uint32) error {
+			return removeUtxoEvents(bucket, hash, index, height)
+		})
+	if err != nil {
+		return err
+	}
+
+	// Update the current index tip.
+	return dbPutIndexerTip(dbTx, idx.Key(), &msgBlock.Header.PrevBlock,
+		int32(height-1))
+}
+
+// Entry returns the entry in the utxo history index for the provided output.
+// When there is no entry for the provided output, nil is returned for both the
+// entry and the error.
+//
+// This function is safe for concurrent access.
+func (idx *UtxoHistoryIndex) Entry(hash *chainhash.Hash, index uint32) (*UtxoHistoryEntry, error) {
+	var entry *UtxoHistoryEntry
+	err := idx.db.View(func(dbTx database.Tx) error {
+		bucket := dbTx.Metadata().Bucket(utxoHistoryIndexKey)
+		serialized := bucket.Get(utxoHistoryKey(hash, index))
+		if serialized == nil {
+			return nil
+		}
+
+		var err error
+		entry, err = deserializeUtxoHistoryEntry(serialized)
+		return err
+	})
+	return entry, err
+}
+
+// DropUtxoHistoryIndex drops the utxo history index from the provided database
+// if it exists.
+func DropUtxoHistoryIndex(ctx context.Context, db database.DB) error {
+	return dropFlatIndex(ctx, db, utxoHistoryIndexKey, utxoHistoryIndexName)
+}
+
+// DropIndex drops the utxo history index from the provided database if it
+// exists.
+func (*UtxoHistoryIndex) DropIndex(ctx context.Context, db database.DB) error {
+	return DropUtxoHistoryIndex(ctx, db)
+}
+
+// ProcessNotification indexes the provided notification based on its
+// notification type.
+//
+// This is part of the Indexer interface.
+func (idx *UtxoHistoryIndex) ProcessNotification(dbTx database.Tx, ntfn *IndexNtfn) error {
+	switch ntfn.NtfnType {
+	case ConnectNtfn:
+		err := idx.connectBlock(dbTx, ntfn.Block, ntfn.Parent)
+		if err != nil {
+			msg := fmt.Sprintf("%s: unable to connect block: %v",
+				idx.Name(), err)
+			return indexerError(ErrConnectBlock, msg)
+		}
+
+	case DisconnectNtfn:
+		err := idx.disconnectBlock(dbTx, ntfn.Block, ntfn.Parent)
+		if err != nil {
+			msg := fmt.Sprintf("%s: unable to disconnect block: %v",
+				idx.Name(), err)
+			return indexerError(ErrDisconnectBlock, msg)
+		}
+
+	default:
+		msg := fmt.Sprintf("%s: unknown notification type received: %d",
+			idx.Name(), ntfn.NtfnType)
+		return indexerError(ErrInvalidNotificationType, msg)
+	}
+
+	return nil
+}
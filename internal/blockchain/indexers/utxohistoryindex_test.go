@@ -0,0 +1,219 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/decred/dcrd/blockchain/v5/chaingen"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+// TestUtxoHistoryIndexAsync ensures the utxo history index behaves as expected
+// when receiving updates asynchronously.
+func TestUtxoHistoryIndexAsync(t *testing.T) {
+	db := setupDB(t)
+
+	chain, err := newTestChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := chaingen.MakeGenerator(chaincfg.SimNetParams())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// addGenBlock extends the chain with a generated block that optionally
+	// spends the provided output and is modified by the provided mungers.
+	addGenBlock := func(name string, spend *chaingen.SpendableOut, mungers ...func(*wire.MsgBlock)) *dcrutil.Block {
+		t.Helper()
+		blk := dcrutil.NewBlock(g.NextBlock(name, spend, nil, mungers...))
+		g.SaveTipCoinbaseOuts()
+		if err := chain.AddBlock(blk); err != nil {
+			t.Fatal(err)
+		}
+		return blk
+	}
+
+	// Add a block that spends the first coinbase output of an earlier block.
+	addBlock(t, chain, &g, "bk1")
+	bk2 := addBlock(t, chain, &g, "bk2")
+	outs := g.OldestCoinbaseOuts()
+	bk3 := addGenBlock("bk3", &outs[0])
+	spendTx := bk3.MsgBlock().Transactions[1]
+	spendTxHash := spendTx.TxHash()
+	spentOut := outs[0].PrevOut()
+	bk2CoinbaseHash, bk2CoinbaseIdx := spentOut.Hash, spentOut.Index
+	if bk2CoinbaseHash != bk2.MsgBlock().Transactions[0].TxHash() {
+		t.Fatal("spent output is not from the coinbase of bk2")
+	}
+
+	// Initialize the utxo history index.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subber := NewIndexSubscriber(ctx)
+	go subber.Run(ctx)
+
+	idx, err := NewUtxoHistoryIndex(subber, db, chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = subber.CatchUp(ctx, db, chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Ensure the index got synced to bk3 on initialization.
+	tipHeight, tipHash, err := idx.Tip()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tipHeight != bk3.Height() || *tipHash != *bk3.Hash() {
+		t.Fatalf("expected tip to be %s (height %d), got %s (height %d)",
+			bk3.Hash(), bk3.Height(), tipHash, tipHeight)
+	}
+
+	// fetchEntry returns the entry for the provided output and ensures it
+	// exists.
+	fetchEntry := func(hash *chainhash.Hash, index uint32) *UtxoHistoryEntry {
+		t.Helper()
+		entry, err := idx.Entry(hash, index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry == nil {
+			t.Fatalf("no entry for %v:%d", hash, index)
+		}
+		return entry
+	}
+
+	// assertStates ensures the states of the provided output as of the
+	// heights starting at bk1 match the provided states.
+	assertStates := func(name string, hash *chainhash.Hash, index uint32, want ...UtxoState) {
+		t.Helper()
+		entry := fetchEntry(hash, index)
+		for i, wantState := range want {
+			height := int64(i + 1)
+			gotState, _ := entry.StateAt(height)
+			if gotState != wantState {
+				t.Fatalf("%s: unexpected state at height %d -- got %v, "+
+					"want %v", name, height, gotState, wantState)
+			}
+		}
+	}
+
+	// Ensure the details of the spent output are correct.
+	entry := fetchEntry(&bk2CoinbaseHash, bk2CoinbaseIdx)
+	bk2CoinbaseOut := bk2.MsgBlock().Transactions[0].TxOut[bk2CoinbaseIdx]
+	if entry.Height != bk2.Height() || entry.Tree != wire.TxTreeRegular ||
+		!entry.Coinbase || entry.Amount != bk2CoinbaseOut.Value ||
+		entry.ScriptVersion != bk2CoinbaseOut.Version ||
+		string(entry.PkScript) != string(bk2CoinbaseOut.PkScript) {
+
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+	_, event := entry.StateAt(bk3.Height())
+	if event == nil || event.Kind != UtxoEventSpent ||
+		event.SpenderHash != spendTxHash || event.SpenderIndex != 0 {
+
+		t.Fatalf("unexpected spend event %+v", event)
+	}
+	assertStates("spent output", &bk2CoinbaseHash, bk2CoinbaseIdx, UtxoStateNotCreated,
+		UtxoStateUnspent, UtxoStateSpent)
+	assertStates("spending output", &spendTxHash, 0, UtxoStateNotCreated,
+		UtxoStateNotCreated, UtxoStateUnspent)
+
+	// Ensure there is no entry for an unknown output.
+	entry, err = idx.Entry(&chainhash.Hash{0x01}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry != nil {
+		t.Fatalf("unexpected entry for unknown output: %+v", entry)
+	}
+
+	// Connect a block that disapproves the regular tree of bk3 and ensure the
+	// spend is reverted and the outputs it created are removed.
+	bk4 := addGenBlock("bk4", nil, func(b *wire.MsgBlock) {
+		b.Header.VoteBits &^= dcrutil.BlockValid
+	})
+	notifyAndWait(t, subber, &IndexNtfn{
+		NtfnType: ConnectNtfn,
+		Block:    bk4,
+		Parent:   bk3,
+	})
+	assertStates("disapproved spent output", &bk2CoinbaseHash, bk2CoinbaseIdx,
+		UtxoStateNotCreated, UtxoStateUnspent, UtxoStateSpent,
+		UtxoStateUnspent)
+	assertStates("disapproved spending output", &spendTxHash, 0,
+		UtxoStateNotCreated, UtxoStateNotCreated, UtxoStateUnspent,
+		UtxoStateRemoved)
+
+	// Connect a block that includes the disapproved transaction again and
+	// ensure the output is recreated and spent again.
+	bk5 := addGenBlock("bk5", nil, func(b *wire.MsgBlock) {
+		b.AddTransaction(spendTx)
+	})
+	notifyAndWait(t, subber, &IndexNtfn{
+		NtfnType: ConnectNtfn,
+		Block:    bk5,
+		Parent:   bk4,
+	})
+	assertStates("respent output", &bk2CoinbaseHash, bk2CoinbaseIdx,
+		UtxoStateNotCreated, UtxoStateUnspent, UtxoStateSpent,
+		UtxoStateUnspent, UtxoStateSpent)
+	assertStates("recreated output", &spendTxHash, 0, UtxoStateNotCreated,
+		UtxoStateNotCreated, UtxoStateUnspent, UtxoStateRemoved,
+		UtxoStateUnspent)
+	entry = fetchEntry(&spendTxHash, 0)
+	if entry.Height != bk3.Height() || len(entry.Events) != 2 ||
+		entry.Events[1].Kind != UtxoEventRecreated {
+
+		t.Fatalf("unexpected recreated entry %+v", entry)
+	}
+
+	// Disconnect the blocks and ensure their changes are reverted.
+	for _, blocks := range [][2]*dcrutil.Block{{bk5, bk4}, {bk4, bk3}} {
+		block, parent := blocks[0], blocks[1]
+		if err := chain.RemoveBlock(block); err != nil {
+			t.Fatal(err)
+		}
+		notifyAndWait(t, subber, &IndexNtfn{
+			NtfnType: DisconnectNtfn,
+			Block:    block,
+			Parent:   parent,
+		})
+	}
+	entry = fetchEntry(&spendTxHash, 0)
+	if entry.Height != bk3.Height() || len(entry.Events) != 0 {
+		t.Fatalf("unexpected entry after disconnect %+v", entry)
+	}
+	entry = fetchEntry(&bk2CoinbaseHash, bk2CoinbaseIdx)
+	if len(entry.Events) != 1 || entry.Events[0].Kind != UtxoEventSpent {
+		t.Fatalf("unexpected entry after disconnect %+v", entry)
+	}
+	bk5CoinbaseHash := bk5.MsgBlock().Transactions[0].TxHash()
+	entry, err = idx.Entry(&bk5CoinbaseHash, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry != nil {
+		t.Fatalf("unexpected entry for disconnected output: %+v", entry)
+	}
+	tipHeight, tipHash, err = idx.Tip()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tipHeight != bk3.Height() || *tipHash != *bk3.Hash() {
+		t.Fatalf("expected tip to be %s (height %d), got %s (height %d)",
+			bk3.Hash(), bk3.Height(), tipHash, tipHeight)
+	}
+}
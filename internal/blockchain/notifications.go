@@ -69,6 +69,11 @@ const (
 	// NTNewTickets indicates newly maturing tickets from a newly accepted
 	// block.
 	NTNewTickets
+
+	// NTDeepReorgPaused indicates that a chain reorganization was not
+	// performed because it would disconnect more blocks than the maximum
+	// automatic reorganization depth allows.
+	NTDeepReorgPaused
 )
 
 // notificationTypeStrings is a map of notification types back to their constant
@@ -82,6 +87,7 @@ var notificationTypeStrings = map[NotificationType]string{
 	NTChainReorgDone:     "NTChainReorgDone",
 	NTReorganization:     "NTReorganization",
 	NTNewTickets:         "NTNewTickets",
+	NTDeepReorgPaused:    "NTDeepReorgPaused",
 }
 
 // String returns the NotificationType in human-readable form.
@@ -161,13 +167,43 @@ type ReorganizationNtfnsData struct {
 	NewHeight int64
 }
 
+// DeepReorgPausedNtfnsData is the structure for data indicating information
+// about a chain reorganization that was paused due to exceeding the maximum
+// automatic reorganization depth.
+type DeepReorgPausedNtfnsData struct {
+	// TipHash and TipHeight identify the current main chain tip that the
+	// reorganization would have disconnected blocks from.
+	TipHash   chainhash.Hash
+	TipHeight int64
+
+	// TargetHash and TargetHeight identify the tip of the branch with the
+	// most cumulative proof of work that the reorganization would have made
+	// the new main chain tip.
+	TargetHash   chainhash.Hash
+	TargetHeight int64
+
+	// ForkHeight is the height of the final common block between the two
+	// branches.
+	ForkHeight int64
+}
+
 // TicketNotificationsData is the structure for data indicating information
-// about new tickets in a connected block.
+// about new tickets in a connected block along with the tickets that were
+// voted, missed, expired, and revoked by it.
+//
+// Note that TicketsMissed only includes winning tickets that were not voted
+// while TicketsExpired only includes tickets that expired without being
+// selected.  Neither includes tickets that were revoked by the block, which
+// are instead included in TicketsRevoked.
 type TicketNotificationsData struct {
 	Hash            chainhash.Hash
 	Height          int64
 	StakeDifficulty int64
 	TicketsNew      []chainhash.Hash
+	TicketsVoted    []chainhash.Hash
+	TicketsMissed   []chainhash.Hash
+	TicketsExpired  []chainhash.Hash
+	TicketsRevoked  []chainhash.Hash
 }
 
 // Notification defines notification that is sent to the caller via the callback
@@ -181,6 +217,7 @@ type TicketNotificationsData struct {
 //   - NTChainReorgDone:        nil
 //   - NTReorganization:        *ReorganizationNtfnsData
 //   - NTNewTickets:            *TicketNotificationsData
+//   - NTDeepReorgPaused:       *DeepReorgPausedNtfnsData
 type Notification struct {
 	Type NotificationType
 	Data interface{}
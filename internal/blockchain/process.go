@@ -335,6 +335,7 @@ func (b *BlockChain) maybeAcceptBlockData(node *blockNode, block *dcrutil.Block,
 		return nil, err
 	}
 	b.index.SetStatusFlags(node, statusDataStored)
+	b.numSideChainBlocks++
 
 	// Update the block index state to account for the full data for the block
 	// now being available.  This might result in the block, and any others that
@@ -596,6 +597,13 @@ func (b *BlockChain) ProcessBlock(block *dcrutil.Block) (int64, error) {
 		}
 	}
 
+	// Pause the reorganization when it would disconnect more blocks than the
+	// maximum automatic reorganization depth allows and it has not been
+	// approved by the caller.
+	if b.maybePauseDeepReorg(target) {
+		target = b.bestChain.Tip()
+	}
+
 	// Find the best chain candidate and attempt to reorganize the chain to it.
 	// This will have no effect when the target is the same as the current best
 	// chain tip.
@@ -626,6 +634,13 @@ func (b *BlockChain) ProcessBlock(block *dcrutil.Block) (int64, error) {
 		}
 	}
 
+	// Evict side chain blocks starting with the most stale branches when there
+	// are more of them than allowed.  Failure to evict them is not fatal, so
+	// only warn about it.
+	if err := b.maybeEvictSideChainBlocks(); err != nil {
+		log.Warnf("Unable to evict side chain blocks: %v", err)
+	}
+
 	// Notify the caller about any blocks that are now linked and were accepted
 	// to the block chain.  The caller would typically want to react by relaying
 	// the inventory to other peers unless it was already relayed above via
@@ -769,6 +784,102 @@ func (b *BlockChain) InvalidateBlock(hash *chainhash.Hash) error {
 	return err
 }
 
+// maybePauseDeepReorg returns whether or not a chain reorganization to the
+// provided target must be paused because it would disconnect more blocks from
+// the current best chain than the maximum automatic reorganization depth allows
+// and the caller has not approved it via ApproveDeepReorg.
+//
+// A notification is sent the first time a reorganization to a given target is
+// paused.
+//
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) maybePauseDeepReorg(target *blockNode) bool {
+	// Nothing to pause when there is no limit or the target does not cause a
+	// reorganization.
+	tip := b.bestChain.Tip()
+	if b.maxReorgDepth <= 0 || target == nil || tip.IsAncestorOf(target) {
+		return false
+	}
+
+	// Nothing to pause when the reorganization is within the allowed depth.
+	fork := b.bestChain.FindFork(target)
+	if fork == nil || tip.height-fork.height <= b.maxReorgDepth {
+		return false
+	}
+
+	// The reorganization is allowed to proceed when the caller approved a
+	// block on the branch being reorganized to.
+	approved := b.approvedDeepReorg
+	if approved != nil && approved.height > fork.height &&
+		approved.IsAncestorOf(target) {
+
+		return false
+	}
+
+	// Warn and notify the caller the first time a reorganization to the target
+	// is paused.
+	if b.pausedDeepReorg != target {
+		b.pausedDeepReorg = target
+		log.Warnf("Pausing reorganize to block %v (height %d) since it would "+
+			"disconnect %d blocks which exceeds the maximum automatic "+
+			"reorganize depth of %d blocks.  The chain forks at block %v "+
+			"(height %d).  It must be manually approved to proceed.",
+			target.hash, target.height, tip.height-fork.height,
+			b.maxReorgDepth, fork.hash, fork.height)
+
+		// Notice that the chain lock is not released before sending the
+		// notification.  This is intentional and must not be changed without
+		// understanding why!
+		b.sendNotification(NTDeepReorgPaused, &DeepReorgPausedNtfnsData{
+			TipHash:      tip.hash,
+			TipHeight:    tip.height,
+			TargetHash:   target.hash,
+			TargetHeight: target.height,
+			ForkHeight:   fork.height,
+		})
+	}
+	return true
+}
+
+// ApproveDeepReorg approves a chain reorganization to the branch that contains
+// the provided block even when it disconnects more blocks from the current best
+// chain than the maximum automatic reorganization depth allows.  It then
+// reorganizes the chain to the best chain candidate as necessary.
+//
+// An error with the kind ErrDeepReorgNotApproved is returned when the provided
+// block is not part of the branch with the most cumulative proof of work that
+// the chain would reorganize to.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) ApproveDeepReorg(hash *chainhash.Hash) error {
+	b.processLock.Lock()
+	defer b.processLock.Unlock()
+
+	// Unable to approve a block that does not exist.
+	node := b.index.LookupNode(hash)
+	if node == nil {
+		return unknownBlockError(hash)
+	}
+
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+
+	b.approvedDeepReorg = node
+	targetTip := b.index.FindBestChainCandidate()
+	if b.maybePauseDeepReorg(targetTip) {
+		str := fmt.Sprintf("block %s is not part of the branch the chain "+
+			"would reorganize to", hash)
+		return contextError(ErrDeepReorgNotApproved, str)
+	}
+
+	log.Infof("Deep reorganize to block %v (height %d) approved", node.hash,
+		node.height)
+	b.pausedDeepReorg = nil
+	err := b.reorganizeChain(targetTip)
+	b.flushBlockIndexWarnOnly()
+	return err
+}
+
 // blockNodeInSlice return whether a given block node is an element in a slice
 // of them.
 func blockNodeInSlice(node *blockNode, slice []*blockNode) bool {
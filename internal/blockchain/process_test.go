@@ -5,6 +5,7 @@
 package blockchain
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -2061,3 +2062,98 @@ func TestAssumeValid(t *testing.T) {
 			"assumed valid node is nil")
 	}
 }
+
+// TestDeepReorgLimit ensures that chain reorganizations which exceed the
+// maximum automatic reorganization depth are paused until they are approved
+// and that reorganizations within the limit are not affected.
+func TestDeepReorgLimit(t *testing.T) {
+	// Create a test harness initialized with the genesis block as the tip and
+	// limit automatic reorganizations to a depth of 2 blocks.
+	params := chaincfg.RegNetParams()
+	g := newChaingenHarness(t, params)
+	g.chain.maxReorgDepth = 2
+
+	// ---------------------------------------------------------------------
+	// Generate and accept enough blocks to reach stake validation height.
+	// ---------------------------------------------------------------------
+
+	g.AdvanceToStakeValidationHeight()
+	forkName := g.TipName()
+
+	// ---------------------------------------------------------------------
+	// Create a main chain of 3 blocks and a side chain that forks from the
+	// same block.
+	//
+	//   ... -> bdr0  -> bdr1  -> bdr2
+	//      \-> bdra0 -> bdra1 -> bdra2 -> bdra3
+	// ---------------------------------------------------------------------
+
+	for i := 0; i < 3; i++ {
+		outs := g.OldestCoinbaseOuts()
+		g.NextBlock(fmt.Sprintf("bdr%d", i), nil, outs[1:])
+		g.SaveTipCoinbaseOuts()
+		g.AcceptTipBlock()
+	}
+
+	// Accept the side chain blocks up to the same amount of work as the main
+	// chain which must not cause a reorganization.
+	g.SetTip(forkName)
+	for i := 0; i < 3; i++ {
+		g.NextBlock(fmt.Sprintf("bdra%d", i), nil, nil)
+		g.AcceptedToSideChainWithExpectedTip("bdr2")
+	}
+
+	// Accept a side chain block that has more cumulative work than the main
+	// chain and ensure the resulting reorganization, which would disconnect 3
+	// blocks, is paused.
+	g.NextBlock("bdra3", nil, nil)
+	g.AcceptedToSideChainWithExpectedTip("bdr2")
+	pausedHash := g.BlockByName("bdra3").BlockHash()
+	if paused := g.chain.pausedDeepReorg; paused == nil ||
+		paused.hash != pausedHash {
+
+		t.Fatalf("unexpected paused deep reorg target -- got %v, want %v",
+			paused, pausedHash)
+	}
+
+	// Ensure attempting to approve an unknown block and a block that is not
+	// part of the branch the chain would reorganize to are rejected.
+	var unknownHash chainhash.Hash
+	err := g.chain.ApproveDeepReorg(&unknownHash)
+	if !errors.Is(err, ErrUnknownBlock) {
+		t.Fatalf("mismatched err approving unknown block -- got %v, want %v",
+			err, ErrUnknownBlock)
+	}
+	mainHash := g.BlockByName("bdr1").BlockHash()
+	err = g.chain.ApproveDeepReorg(&mainHash)
+	if !errors.Is(err, ErrDeepReorgNotApproved) {
+		t.Fatalf("mismatched err approving main chain block -- got %v, want %v",
+			err, ErrDeepReorgNotApproved)
+	}
+	g.ExpectTip("bdr2")
+
+	// Approve the reorganization via a block on the side chain and ensure the
+	// chain reorganizes to it.
+	sideHash := g.BlockByName("bdra1").BlockHash()
+	if err := g.chain.ApproveDeepReorg(&sideHash); err != nil {
+		t.Fatalf("unexpected err approving side chain block: %v", err)
+	}
+	g.ExpectTip("bdra3")
+
+	// ---------------------------------------------------------------------
+	// Create a side chain that forks 2 blocks back from the current tip and
+	// ensure the reorganization to it is performed automatically since it is
+	// within the limit.
+	//
+	//   ... -> bdra1 -> bdra2  -> bdra3
+	//               \-> bdrb2 -> bdrb3 -> bdrb4
+	// ---------------------------------------------------------------------
+
+	g.SetTip("bdra1")
+	g.NextBlock("bdrb2", nil, nil)
+	g.AcceptedToSideChainWithExpectedTip("bdra3")
+	g.NextBlock("bdrb3", nil, nil)
+	g.AcceptedToSideChainWithExpectedTip("bdra3")
+	g.NextBlock("bdrb4", nil, nil)
+	g.AcceptTipBlock()
+}
@@ -0,0 +1,321 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/database/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/txscript/v4"
+)
+
+// errNoLongerMainChain is used internally to indicate a recorded block is no
+// longer part of the main chain and therefore does not need to be audited.
+var errNoLongerMainChain = errors.New("block is no longer in the main chain")
+
+// ScriptAuditEntry identifies a block that was connected to the main chain
+// without executing its transaction scripts.
+type ScriptAuditEntry struct {
+	Hash   chainhash.Hash
+	Height int64
+}
+
+// ScriptAuditStats houses statistics about the audit of the blocks that were
+// connected to the main chain without executing their transaction scripts.
+type ScriptAuditStats struct {
+	// Pending is the number of recorded blocks that have not been audited
+	// yet.
+	Pending int
+
+	// Verified is the number of recorded blocks that passed the audit.
+	Verified uint64
+
+	// Dropped is the number of recorded blocks that were no longer part of
+	// the main chain by the time they were audited.
+	Dropped uint64
+
+	// Failed houses the recorded blocks that failed the audit.
+	Failed []ScriptAuditEntry
+}
+
+// scriptAuditTrail houses the blocks that were connected to the main chain
+// without executing their transaction scripts in the order they were
+// connected along with the results of auditing them.
+type scriptAuditTrail struct {
+	pending  []ScriptAuditEntry
+	verified uint64
+	dropped  uint64
+	failed   []ScriptAuditEntry
+}
+
+// maybeRecordSkippedScripts records an audit entry for the provided node when
+// auditing skipped scripts is enabled and the transaction scripts of the block
+// were not executed due to it being an ancestor of the assumed valid block.
+//
+// This function MUST be called with the chain lock held (for writes).
+func (b *BlockChain) maybeRecordSkippedScripts(node *blockNode) {
+	if !b.auditSkippedScripts || b.noVerify || b.bulkImportMode ||
+		!b.isAssumeValidAncestor(node) {
+
+		return
+	}
+
+	b.scriptAuditMtx.Lock()
+	b.scriptAudit.pending = append(b.scriptAudit.pending, ScriptAuditEntry{
+		Hash:   node.hash,
+		Height: node.height,
+	})
+	b.scriptAuditMtx.Unlock()
+}
+
+// loadScriptAuditTrail populates the blocks that are pending a script audit
+// with the main chain blocks after the last audited block that are ancestors of
+// the assumed valid block.  The pending blocks are only tracked in memory, so
+// this ensures the audit resumes where it left off after a restart.
+//
+// Note that blocks that were connected prior to the assumed valid block being
+// known had their scripts executed, but they are indistinguishable from the
+// blocks that did not at this point, so they are audited as well.
+//
+// This function MUST be called with the chain lock held (for writes) or
+// during chain initialization.
+func (b *BlockChain) loadScriptAuditTrail() error {
+	var auditedHeight int64
+	err := b.db.View(func(dbTx database.Tx) error {
+		auditedHeight = dbFetchScriptAuditHeight(dbTx)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The ancestors of the assumed valid block in the main chain are a prefix
+	// of it, so stop at the first block that is not one.
+	var pending []ScriptAuditEntry
+	tip := b.bestChain.Tip()
+	for height := auditedHeight + 1; height <= tip.height; height++ {
+		node := b.bestChain.NodeByHeight(height)
+		if !b.isAssumeValidAncestor(node) {
+			break
+		}
+		pending = append(pending, ScriptAuditEntry{
+			Hash:   node.hash,
+			Height: node.height,
+		})
+	}
+	if len(pending) > 0 {
+		log.Infof("Resuming script audit of %d blocks connected via the "+
+			"assumed valid block", len(pending))
+	}
+
+	b.scriptAuditMtx.Lock()
+	b.scriptAudit.pending = pending
+	b.scriptAuditMtx.Unlock()
+	return nil
+}
+
+// ScriptAuditStats returns statistics about the audit of the blocks that were
+// connected to the main chain without executing their transaction scripts.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) ScriptAuditStats() ScriptAuditStats {
+	b.scriptAuditMtx.Lock()
+	defer b.scriptAuditMtx.Unlock()
+
+	trail := &b.scriptAudit
+	stats := ScriptAuditStats{
+		Pending:  len(trail.pending),
+		Verified: trail.verified,
+		Dropped:  trail.dropped,
+	}
+	if len(trail.failed) > 0 {
+		stats.Failed = make([]ScriptAuditEntry, len(trail.failed))
+		copy(stats.Failed, trail.failed)
+	}
+	return stats
+}
+
+// auditBlockScripts executes the transaction scripts of the provided recorded
+// block using the spent outputs from its spend journal entry.
+//
+// An error with the kind ErrScriptAuditFailed is returned when any of the
+// scripts fail validation, while errNoLongerMainChain is returned when the
+// block is no longer part of the main chain.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) auditBlockScripts(entry *ScriptAuditEntry) error {
+	// Load the block along with the data needed to execute its scripts while
+	// the chain lock is held and release it before executing the scripts
+	// since doing so is time consuming.
+	var block *dcrutil.Block
+	var stxos []spentTxOut
+	var scriptFlags txscript.ScriptFlags
+	var isTreasuryEnabled, isAutoRevocationsEnabled bool
+	err := func() error {
+		b.chainLock.RLock()
+		defer b.chainLock.RUnlock()
+
+		node := b.index.LookupNode(&entry.Hash)
+		if node == nil || !b.bestChain.Contains(node) {
+			return errNoLongerMainChain
+		}
+
+		var err error
+		block, err = b.fetchMainChainBlockByNode(node)
+		if err != nil {
+			return err
+		}
+		isTreasuryEnabled, err = b.isTreasuryAgendaActive(node.parent)
+		if err != nil {
+			return err
+		}
+		isAutoRevocationsEnabled, err = b.isAutoRevocationsAgendaActive(
+			node.parent)
+		if err != nil {
+			return err
+		}
+		scriptFlags, err = b.consensusScriptVerifyFlags(node)
+		if err != nil {
+			return err
+		}
+		return b.db.View(func(dbTx database.Tx) error {
+			var err error
+			stxos, err = dbFetchSpendJournalEntry(dbTx, block,
+				isTreasuryEnabled)
+			return err
+		})
+	}()
+	if err != nil {
+		return err
+	}
+
+	// Create a view that contains the scripts of all of the outputs spent by
+	// the block.  Only the scripts and their versions are needed to execute
+	// the scripts.
+	view := NewUtxoViewpoint(nil)
+	source := stxosToScriptSource(block, stxos, isTreasuryEnabled,
+		b.chainParams)
+	for outpoint, prevScript := range source {
+		view.entries[outpoint] = &UtxoEntry{
+			pkScript:      prevScript.script,
+			scriptVersion: prevScript.version,
+		}
+	}
+
+	// Execute the scripts of both transaction trees without making use of the
+	// signature and script caches since the results are not relevant to any
+	// future transactions.
+	for _, txTree := range []bool{false, true} {
+		err := checkBlockScripts(block, view, txTree, scriptFlags, nil, nil,
+			isAutoRevocationsEnabled)
+		if err != nil {
+			str := fmt.Sprintf("script audit of block %s (height %d) "+
+				"failed: %v", entry.Hash, entry.Height, err)
+			return ContextError{Err: ErrScriptAuditFailed, Description: str,
+				RawErr: err}
+		}
+	}
+	return nil
+}
+
+// AuditSkippedScripts executes the transaction scripts of up to the provided
+// number of recorded blocks that were connected to the main chain without
+// executing them, in the order they were connected, and returns the number of
+// blocks that were audited.  Blocks are only recorded when auditing skipped
+// scripts is enabled via the chain configuration.
+//
+// The scripts are executed using the spent outputs from the spend journal, so
+// this allows the validity of blocks that were accepted based on the assumed
+// valid block to be verified after the fact.  Recorded blocks that are no
+// longer part of the main chain are dropped without being audited.
+//
+// An error with the kind ErrScriptAuditFailed is returned as soon as a block
+// fails the audit.  The failed block is tracked in the audit statistics and
+// auditing may be resumed with the next block by calling this function again.
+//
+// The height of the last audited block is stored in the database so that the
+// audit resumes after it when the chain is loaded again.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) AuditSkippedScripts(ctx context.Context, maxBlocks int) (int, error) {
+	var numAudited int
+	var auditedHeight int64
+	for numAudited < maxBlocks {
+		if err := ctx.Err(); err != nil {
+			return numAudited, b.putScriptAuditHeight(auditedHeight, err)
+		}
+
+		b.scriptAuditMtx.Lock()
+		trail := &b.scriptAudit
+		if len(trail.pending) == 0 {
+			b.scriptAuditMtx.Unlock()
+			break
+		}
+		entry := trail.pending[0]
+		trail.pending = trail.pending[1:]
+		if len(trail.pending) == 0 {
+			trail.pending = nil
+		}
+		b.scriptAuditMtx.Unlock()
+
+		// Update the results based on the outcome of the audit.  The entry is
+		// restored so the block is audited again later when it was not
+		// possible to audit it due to an unexpected error.
+		err := b.auditBlockScripts(&entry)
+		b.scriptAuditMtx.Lock()
+		switch {
+		case err == nil:
+			trail.verified++
+		case errors.Is(err, errNoLongerMainChain):
+			trail.dropped++
+		case errors.Is(err, ErrScriptAuditFailed):
+			trail.failed = append(trail.failed, entry)
+		default:
+			trail.pending = append([]ScriptAuditEntry{entry}, trail.pending...)
+			b.scriptAuditMtx.Unlock()
+			return numAudited, b.putScriptAuditHeight(auditedHeight, err)
+		}
+
+		// Never persist progress beyond a block that failed the audit so that
+		// it is audited and reported again after a restart.
+		if entry.Height > auditedHeight {
+			auditedHeight = entry.Height
+		}
+		if len(trail.failed) > 0 && trail.failed[0].Height <= auditedHeight {
+			auditedHeight = trail.failed[0].Height - 1
+		}
+		b.scriptAuditMtx.Unlock()
+		numAudited++
+
+		if errors.Is(err, ErrScriptAuditFailed) {
+			return numAudited, b.putScriptAuditHeight(auditedHeight, err)
+		}
+	}
+
+	return numAudited, b.putScriptAuditHeight(auditedHeight, nil)
+}
+
+// putScriptAuditHeight stores the provided height of the last audited block in
+// the database so the audit resumes after it on the next startup and returns
+// the provided error unless storing the height fails.  Nothing is stored when
+// the height is zero since that means no blocks were audited.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) putScriptAuditHeight(height int64, auditErr error) error {
+	if height == 0 {
+		return auditErr
+	}
+	err := b.db.Update(func(dbTx database.Tx) error {
+		return dbPutScriptAuditHeight(dbTx, height)
+	})
+	if err != nil {
+		return err
+	}
+	return auditErr
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/database/v3"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+// TestAuditSkippedScripts ensures auditing the scripts of recorded blocks
+// verifies valid blocks, drops blocks that are no longer in the main chain,
+// and detects blocks with invalid scripts.
+func TestAuditSkippedScripts(t *testing.T) {
+	t.Parallel()
+
+	// Create a test harness initialized with the genesis block as the tip.
+	params := chaincfg.RegNetParams()
+	g := newChaingenHarness(t, params)
+
+	// Generate and accept enough blocks to reach stake validation height.
+	g.AdvanceToStakeValidationHeight()
+
+	// Create and accept a block with valid scripts.
+	//
+	//   ... -> b1
+	outs := g.OldestCoinbaseOuts()
+	g.NextBlock("b1", &outs[0], outs[1:])
+	g.SaveTipCoinbaseOuts()
+	g.AcceptTipBlock()
+
+	// Create and accept a block with an invalid regular transaction signature
+	// script while script execution is disabled in order to simulate a block
+	// that was accepted without executing its scripts.
+	//
+	//   ... -> b1 -> b2
+	g.chain.noVerify = true
+	outs = g.OldestCoinbaseOuts()
+	g.NextBlock("b2", &outs[0], outs[1:], func(b *wire.MsgBlock) {
+		b.Transactions[1].TxIn[0].SignatureScript = []byte{
+			txscript.OP_DATA_1, txscript.OP_FALSE,
+		}
+	})
+	g.SaveTipCoinbaseOuts()
+	g.AcceptTipBlock()
+	g.chain.noVerify = false
+
+	// Record the blocks along with a block that is not in the main chain.
+	entryFor := func(blockName string) ScriptAuditEntry {
+		block := g.BlockByName(blockName)
+		return ScriptAuditEntry{
+			Hash:   block.BlockHash(),
+			Height: int64(block.Header.Height),
+		}
+	}
+	b1Entry, b2Entry := entryFor("b1"), entryFor("b2")
+	unknownEntry := ScriptAuditEntry{Hash: chainhash.Hash{0x01}, Height: 1}
+	g.chain.scriptAudit.pending = []ScriptAuditEntry{b1Entry, unknownEntry,
+		b2Entry}
+
+	// Ensure the number of audited blocks is limited to the requested number.
+	ctx := context.Background()
+	numAudited, err := g.chain.AuditSkippedScripts(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stats := g.chain.ScriptAuditStats()
+	if numAudited != 1 || stats.Pending != 2 || stats.Verified != 1 {
+		t.Fatalf("unexpected audit result -- audited %d, stats %+v",
+			numAudited, stats)
+	}
+
+	// Ensure the block with invalid scripts fails the audit and the block
+	// that is not in the main chain is dropped.
+	numAudited, err = g.chain.AuditSkippedScripts(ctx, 10)
+	if !errors.Is(err, ErrScriptAuditFailed) {
+		t.Fatalf("unexpected error -- got %v, want %v", err,
+			ErrScriptAuditFailed)
+	}
+	var rerr RuleError
+	if !errors.As(err.(ContextError).RawErr, &rerr) ||
+		!errors.Is(rerr, ErrScriptValidation) {
+
+		t.Fatalf("unexpected raw error -- got %v, want %v",
+			err.(ContextError).RawErr, ErrScriptValidation)
+	}
+	stats = g.chain.ScriptAuditStats()
+	if numAudited != 2 || stats.Pending != 0 || stats.Verified != 1 ||
+		stats.Dropped != 1 || len(stats.Failed) != 1 ||
+		stats.Failed[0] != b2Entry {
+
+		t.Fatalf("unexpected audit result -- audited %d, stats %+v",
+			numAudited, stats)
+	}
+
+	// Ensure there is nothing left to audit.
+	numAudited, err = g.chain.AuditSkippedScripts(ctx, 10)
+	if err != nil || numAudited != 0 {
+		t.Fatalf("unexpected audit result -- audited %d, err %v",
+			numAudited, err)
+	}
+
+	// Ensure the stored audit progress does not extend beyond the block that
+	// failed the audit.
+	var auditedHeight int64
+	g.chain.db.View(func(dbTx database.Tx) error {
+		auditedHeight = dbFetchScriptAuditHeight(dbTx)
+		return nil
+	})
+	if auditedHeight != b1Entry.Height {
+		t.Fatalf("unexpected audited height -- got %d, want %d",
+			auditedHeight, b1Entry.Height)
+	}
+
+	// Ensure the pending audits are rebuilt from the main chain ancestors of
+	// the assumed valid block after the stored audit progress.
+	g.chain.assumeValidNode = g.chain.bestChain.Tip()
+	g.chain.expectedBlocksInTwoWeeks = 0
+	if err := g.chain.loadScriptAuditTrail(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pending := g.chain.scriptAudit.pending
+	if len(pending) != 1 || pending[0] != b2Entry {
+		t.Fatalf("unexpected rebuilt pending audits %+v", pending)
+	}
+	g.chain.scriptAudit.pending = nil
+	g.chain.assumeValidNode = nil
+
+	// Ensure blocks are not recorded when auditing is disabled or the block
+	// is not an ancestor of the assumed valid block.
+	g.chain.maybeRecordSkippedScripts(g.chain.bestChain.Tip())
+	g.chain.auditSkippedScripts = true
+	g.chain.maybeRecordSkippedScripts(g.chain.bestChain.Tip())
+	if stats := g.chain.ScriptAuditStats(); stats.Pending != 0 {
+		t.Fatalf("unexpected pending audits %d", stats.Pending)
+	}
+}
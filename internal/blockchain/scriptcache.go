@@ -0,0 +1,189 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+// scriptCacheRestrictiveFlags defines the script flags that only ever impose
+// additional restrictions on script execution.  A script pair that
+// successfully executes with any of these flags set is guaranteed to also
+// successfully execute without them, so cached results from executions with
+// these flags may be used to satisfy executions without them.
+//
+// This notably allows results cached during mempool acceptance, which makes
+// use of the stricter standard verification flags, to be used when connecting
+// blocks, which only make use of the consensus flags.
+const scriptCacheRestrictiveFlags = txscript.ScriptDiscourageUpgradableNops |
+	txscript.ScriptVerifyCleanStack |
+	txscript.ScriptVerifySigPushOnly
+
+// scriptCacheKey identifies a specific transaction input that has had its
+// scripts successfully executed.
+//
+// The full hash of the transaction, as opposed to the hash of only the
+// prefix, is used because it commits to the signature scripts in addition to
+// the outputs being spent.
+type scriptCacheKey struct {
+	txHash    chainhash.Hash
+	txInIndex uint32
+}
+
+// ScriptCacheStats houses statistics about the usage of a script cache.
+type ScriptCacheStats struct {
+	// Entries is the number of entries currently in the cache.
+	Entries uint
+
+	// Hits is the total number of lookups that found a usable entry.
+	Hits uint64
+
+	// Misses is the total number of lookups that did not find a usable
+	// entry.
+	Misses uint64
+}
+
+// HitRate returns the ratio of lookups that found a usable entry to the total
+// number of lookups as a value in the range [0, 1].  Zero is returned when no
+// lookups have been performed.
+func (s *ScriptCacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// ScriptCache implements a cache of transaction inputs that have had their
+// scripts successfully executed along with the script flags that were in
+// effect at the time with a randomized entry eviction policy.  Only successful
+// executions are added to the cache.
+//
+// The cache is intended to be shared between the mempool and the chain so that
+// transactions which were already validated when they were accepted to the
+// mempool do not need to have their scripts executed again when the block that
+// contains them is connected.
+type ScriptCache struct {
+	// These fields are accessed atomically and must be first in the struct to
+	// ensure 64-bit alignment.
+	hits   uint64
+	misses uint64
+
+	mtx        sync.RWMutex
+	entries    map[scriptCacheKey]txscript.ScriptFlags
+	maxEntries uint
+}
+
+// NewScriptCache creates and initializes a new instance of ScriptCache.  Its
+// sole parameter 'maxEntries' represents the maximum number of entries allowed
+// to exist in the cache at any particular moment.  Random entries are evicted
+// to make room for new entries that would cause the number of entries in the
+// cache to exceed the max.
+func NewScriptCache(maxEntries uint) *ScriptCache {
+	return &ScriptCache{
+		entries:    make(map[scriptCacheKey]txscript.ScriptFlags, maxEntries),
+		maxEntries: maxEntries,
+	}
+}
+
+// Exists returns whether or not the scripts for the input at the provided
+// index of the transaction with the given full hash were previously
+// successfully executed with flags that are compatible with the provided
+// flags.
+//
+// Cached flags are compatible when they include all of the provided flags and
+// any additional flags they include are only ones that impose further
+// restrictions.
+//
+// This function is safe for concurrent access.
+func (c *ScriptCache) Exists(txHash *chainhash.Hash, txInIndex uint32, flags txscript.ScriptFlags) bool {
+	key := scriptCacheKey{txHash: *txHash, txInIndex: txInIndex}
+	c.mtx.RLock()
+	cachedFlags, ok := c.entries[key]
+	c.mtx.RUnlock()
+
+	ok = ok && flags&^cachedFlags == 0 &&
+		cachedFlags&^flags&^scriptCacheRestrictiveFlags == 0
+	if ok {
+		atomic.AddUint64(&c.hits, 1)
+	} else {
+		atomic.AddUint64(&c.misses, 1)
+	}
+	return ok
+}
+
+// Add adds an entry for the input at the provided index of the transaction
+// with the given full hash that had its scripts successfully executed with the
+// provided flags.  In the event the cache is full, an existing entry is
+// randomly chosen to be evicted in order to make space for the new entry.
+//
+// This function is safe for concurrent access.
+func (c *ScriptCache) Add(txHash *chainhash.Hash, txInIndex uint32, flags txscript.ScriptFlags) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.maxEntries == 0 {
+		return
+	}
+
+	// Remove a random entry from the map when adding the new entry would
+	// exceed the max number of allowed entries.  This relies on the random
+	// starting point of Go's map iteration.
+	key := scriptCacheKey{txHash: *txHash, txInIndex: txInIndex}
+	if _, ok := c.entries[key]; !ok && uint(len(c.entries)+1) > c.maxEntries {
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+	c.entries[key] = flags
+}
+
+// EvictEntries removes all entries from the cache that correspond to the
+// transactions in the given block.  The block that is passed should be
+// txscript.ProactiveEvictionDepth blocks deep, which is the depth at which the
+// cached results for the transactions within the block are nearly guaranteed
+// to no longer be useful.
+//
+// This function is safe for concurrent access.
+func (c *ScriptCache) EvictEntries(block *wire.MsgBlock) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if len(c.entries) == 0 {
+		return
+	}
+	evictTxns := func(txns []*wire.MsgTx) {
+		for _, tx := range txns {
+			key := scriptCacheKey{txHash: tx.TxHashFull()}
+			for i := range tx.TxIn {
+				key.txInIndex = uint32(i)
+				delete(c.entries, key)
+			}
+		}
+	}
+	evictTxns(block.Transactions)
+	evictTxns(block.STransactions)
+}
+
+// Stats returns statistics about the usage of the cache.
+//
+// This function is safe for concurrent access.
+func (c *ScriptCache) Stats() ScriptCacheStats {
+	c.mtx.RLock()
+	numEntries := uint(len(c.entries))
+	c.mtx.RUnlock()
+
+	return ScriptCacheStats{
+		Entries: numEntries,
+		Hits:    atomic.LoadUint64(&c.hits),
+		Misses:  atomic.LoadUint64(&c.misses),
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+// TestScriptCache ensures the script cache only reports entries as existing
+// when they were added with compatible flags, respects the max number of
+// entries, evicts entries for block transactions, and tracks hits and misses.
+func TestScriptCache(t *testing.T) {
+	const consensusFlags = txscript.ScriptVerifyCheckLockTimeVerify |
+		txscript.ScriptVerifyCheckSequenceVerify |
+		txscript.ScriptVerifySHA256
+	const standardFlags = consensusFlags |
+		txscript.ScriptDiscourageUpgradableNops |
+		txscript.ScriptVerifyCleanStack
+
+	// Create a transaction with a couple of inputs and add its first input to
+	// the cache with the stricter standard flags.
+	tx := wire.NewMsgTx()
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 0}, 0, []byte{0x51}))
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, 0, []byte{0x51}))
+	txHash := tx.TxHashFull()
+	cache := NewScriptCache(2)
+	cache.Add(&txHash, 0, standardFlags)
+
+	tests := []struct {
+		name      string
+		txHash    chainhash.Hash
+		txInIndex uint32
+		flags     txscript.ScriptFlags
+		want      bool
+	}{{
+		name:      "exact flags",
+		txHash:    txHash,
+		txInIndex: 0,
+		flags:     standardFlags,
+		want:      true,
+	}, {
+		name:      "subset of flags with only restrictive flags removed",
+		txHash:    txHash,
+		txInIndex: 0,
+		flags:     consensusFlags,
+		want:      true,
+	}, {
+		name:      "subset of flags with non-restrictive flag removed",
+		txHash:    txHash,
+		txInIndex: 0,
+		flags:     standardFlags &^ txscript.ScriptVerifySHA256,
+		want:      false,
+	}, {
+		name:      "superset of flags",
+		txHash:    txHash,
+		txInIndex: 0,
+		flags:     standardFlags | txscript.ScriptVerifyTreasury,
+		want:      false,
+	}, {
+		name:      "different input",
+		txHash:    txHash,
+		txInIndex: 1,
+		flags:     standardFlags,
+		want:      false,
+	}, {
+		name:      "different transaction",
+		txHash:    chainhash.Hash{0x01},
+		txInIndex: 0,
+		flags:     standardFlags,
+		want:      false,
+	}}
+
+	var wantHits, wantMisses uint64
+	for _, test := range tests {
+		got := cache.Exists(&test.txHash, test.txInIndex, test.flags)
+		if got != test.want {
+			t.Errorf("%q: mismatched existence -- got %v, want %v", test.name,
+				got, test.want)
+		}
+		if test.want {
+			wantHits++
+		} else {
+			wantMisses++
+		}
+	}
+
+	// Ensure the stats reflect the lookups.
+	stats := cache.Stats()
+	if stats.Entries != 1 || stats.Hits != wantHits ||
+		stats.Misses != wantMisses {
+
+		t.Fatalf("mismatched stats -- got %+v, want entries 1, hits %d, "+
+			"misses %d", stats, wantHits, wantMisses)
+	}
+	wantHitRate := float64(wantHits) / float64(wantHits+wantMisses)
+	if got := stats.HitRate(); got != wantHitRate {
+		t.Fatalf("mismatched hit rate -- got %v, want %v", got, wantHitRate)
+	}
+
+	// Ensure adding more entries than the max evicts entries as needed.
+	cache.Add(&txHash, 1, standardFlags)
+	cache.Add(&chainhash.Hash{0x01}, 0, standardFlags)
+	if got := cache.Stats().Entries; got != 2 {
+		t.Fatalf("mismatched number of entries after exceeding max -- got "+
+			"%d, want 2", got)
+	}
+
+	// Ensure evicting the entries for a block that contains the transaction
+	// removes all of its inputs while leaving unrelated entries.
+	cache = NewScriptCache(10)
+	cache.Add(&txHash, 0, standardFlags)
+	cache.Add(&txHash, 1, standardFlags)
+	cache.Add(&chainhash.Hash{0x01}, 0, standardFlags)
+	cache.EvictEntries(&wire.MsgBlock{Transactions: []*wire.MsgTx{tx}})
+	if cache.Exists(&txHash, 0, standardFlags) ||
+		cache.Exists(&txHash, 1, standardFlags) {
+
+		t.Fatal("entries for block transaction still exist after eviction")
+	}
+	if !cache.Exists(&chainhash.Hash{0x01}, 0, standardFlags) {
+		t.Fatal("unrelated entry does not exist after eviction")
+	}
+
+	// Ensure a cache with a max of zero entries never adds any.
+	cache = NewScriptCache(0)
+	cache.Add(&txHash, 0, standardFlags)
+	if cache.Exists(&txHash, 0, standardFlags) {
+		t.Fatal("entry exists in cache with a max of zero entries")
+	}
+}
@@ -12,16 +12,19 @@ import (
 	"runtime"
 
 	"github.com/decred/dcrd/blockchain/stake/v5"
+	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/dcrutil/v4"
 	"github.com/decred/dcrd/txscript/v4"
 	"github.com/decred/dcrd/wire"
 )
 
-// txValidateItem holds a transaction along with which input to validate.
+// txValidateItem holds a transaction along with which input to validate.  The
+// full hash of the transaction is only set when a script cache is in use.
 type txValidateItem struct {
-	txInIndex int
-	txIn      *wire.TxIn
-	tx        *dcrutil.Tx
+	txInIndex  int
+	txIn       *wire.TxIn
+	tx         *dcrutil.Tx
+	txHashFull *chainhash.Hash
 }
 
 // txValidator provides a type which asynchronously validates transaction
@@ -33,6 +36,7 @@ type txValidator struct {
 	utxoView     *UtxoViewpoint
 	flags        txscript.ScriptFlags
 	sigCache     *txscript.SigCache
+	scriptCache  *ScriptCache
 }
 
 // sendResult sends the result of a script pair validation on the internal
@@ -71,6 +75,16 @@ out:
 				break out
 			}
 
+			// Skip script execution when the scripts for the input were
+			// already successfully executed with compatible flags.
+			txInIdx := uint32(txVI.txInIndex)
+			if v.scriptCache != nil && v.scriptCache.Exists(txVI.txHashFull,
+				txInIdx, v.flags) {
+
+				v.sendResult(ctx, nil)
+				continue
+			}
+
 			// Create a new script engine for the script pair.
 			sigScript := txIn.SignatureScript
 			version := utxo.ScriptVersion()
@@ -104,6 +118,9 @@ out:
 			}
 
 			// Validation succeeded.
+			if v.scriptCache != nil {
+				v.scriptCache.Add(txVI.txHashFull, txInIdx, v.flags)
+			}
 			v.sendResult(ctx, nil)
 		}
 	}
@@ -170,21 +187,39 @@ func (v *txValidator) Validate(items []*txValidateItem) error {
 
 // newTxValidator returns a new instance of txValidator to be used for
 // validating transaction scripts asynchronously.
-func newTxValidator(utxoView *UtxoViewpoint, flags txscript.ScriptFlags, sigCache *txscript.SigCache) *txValidator {
+func newTxValidator(utxoView *UtxoViewpoint, flags txscript.ScriptFlags,
+	sigCache *txscript.SigCache, scriptCache *ScriptCache) *txValidator {
+
 	return &txValidator{
 		validateChan: make(chan *txValidateItem),
 		resultChan:   make(chan error),
 		utxoView:     utxoView,
 		sigCache:     sigCache,
+		scriptCache:  scriptCache,
 		flags:        flags,
 	}
 }
 
+// txHashFullForCache returns the full hash of the passed transaction when the
+// provided script cache is in use and nil otherwise so the potentially
+// expensive hash calculation is avoided when it is not needed.
+func txHashFullForCache(tx *dcrutil.Tx, scriptCache *ScriptCache) *chainhash.Hash {
+	if scriptCache == nil {
+		return nil
+	}
+	txHashFull := tx.MsgTx().TxHashFull()
+	return &txHashFull
+}
+
 // ValidateTransactionScripts validates the scripts for the passed transaction
 // using multiple goroutines.
+//
+// The script cache is optional and may be nil.  When provided, inputs that were
+// previously successfully validated with compatible flags are not executed
+// again and all successfully validated inputs are added to it.
 func ValidateTransactionScripts(tx *dcrutil.Tx, utxoView *UtxoViewpoint,
 	flags txscript.ScriptFlags, sigCache *txscript.SigCache,
-	isAutoRevocationsEnabled bool) error {
+	scriptCache *ScriptCache, isAutoRevocationsEnabled bool) error {
 
 	// Skip revocations if the automatic ticket revocations agenda is active and
 	// the transaction version is greater than or equal to 2.  This is allowed
@@ -202,6 +237,7 @@ func ValidateTransactionScripts(tx *dcrutil.Tx, utxoView *UtxoViewpoint,
 	// validation.
 	txIns := msgTx.TxIn
 	txValItems := make([]*txValidateItem, 0, len(txIns))
+	txHashFull := txHashFullForCache(tx, scriptCache)
 	for txInIdx, txIn := range txIns {
 		// Skip coinbases.
 		if txIn.PreviousOutPoint.Index == math.MaxUint32 {
@@ -209,15 +245,17 @@ func ValidateTransactionScripts(tx *dcrutil.Tx, utxoView *UtxoViewpoint,
 		}
 
 		txVI := &txValidateItem{
-			txInIndex: txInIdx,
-			txIn:      txIn,
-			tx:        tx,
+			txInIndex:  txInIdx,
+			txIn:       txIn,
+			tx:         tx,
+			txHashFull: txHashFull,
 		}
 		txValItems = append(txValItems, txVI)
 	}
 
 	// Validate all of the inputs.
-	return newTxValidator(utxoView, flags, sigCache).Validate(txValItems)
+	validator := newTxValidator(utxoView, flags, sigCache, scriptCache)
+	return validator.Validate(txValItems)
 }
 
 // checkBlockScripts executes and validates the scripts for all transactions in
@@ -225,7 +263,7 @@ func ValidateTransactionScripts(tx *dcrutil.Tx, utxoView *UtxoViewpoint,
 // txTree = true is TxTreeRegular, txTree = false is TxTreeStake.
 func checkBlockScripts(block *dcrutil.Block, utxoView *UtxoViewpoint, txTree bool,
 	scriptFlags txscript.ScriptFlags, sigCache *txscript.SigCache,
-	isAutoRevocationsEnabled bool) error {
+	scriptCache *ScriptCache, isAutoRevocationsEnabled bool) error {
 
 	// Collect all of the transaction inputs and required information for
 	// validation for all transactions in the block into a single slice.
@@ -256,6 +294,7 @@ func checkBlockScripts(block *dcrutil.Block, utxoView *UtxoViewpoint, txTree boo
 			continue
 		}
 
+		txHashFull := txHashFullForCache(tx, scriptCache)
 		for txInIdx, txIn := range msgTx.TxIn {
 			// Skip coinbases.
 			if txIn.PreviousOutPoint.Index == math.MaxUint32 {
@@ -263,14 +302,16 @@ func checkBlockScripts(block *dcrutil.Block, utxoView *UtxoViewpoint, txTree boo
 			}
 
 			txVI := &txValidateItem{
-				txInIndex: txInIdx,
-				txIn:      txIn,
-				tx:        tx,
+				txInIndex:  txInIdx,
+				txIn:       txIn,
+				tx:         tx,
+				txHashFull: txHashFull,
 			}
 			txValItems = append(txValItems, txVI)
 		}
 	}
 
 	// Validate all of the inputs.
-	return newTxValidator(utxoView, scriptFlags, sigCache).Validate(txValItems)
+	validator := newTxValidator(utxoView, scriptFlags, sigCache, scriptCache)
+	return validator.Validate(txValItems)
 }
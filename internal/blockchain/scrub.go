@@ -0,0 +1,312 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/database/v3"
+	"github.com/decred/dcrd/wire"
+)
+
+// ScrubIssueKind identifies a kind of integrity issue found while scrubbing
+// the database.
+type ScrubIssueKind string
+
+// These constants define the kinds of integrity issues that are detected while
+// scrubbing the database.
+const (
+	// ScrubMissingBlockData indicates the block index claims the data for a
+	// block is available, but it is not present in the block files.
+	ScrubMissingBlockData = ScrubIssueKind("missing block data")
+
+	// ScrubCorruptBlockData indicates the data for a block could not be read
+	// from the block files or failed checksum verification.
+	ScrubCorruptBlockData = ScrubIssueKind("corrupt block data")
+
+	// ScrubMismatchedBlockData indicates the data for a block was read
+	// successfully, but it does not match the associated block index entry.
+	ScrubMismatchedBlockData = ScrubIssueKind("mismatched block data")
+
+	// ScrubMissingSpendJournal indicates the spend journal entry for a block
+	// in the main chain is missing.
+	ScrubMissingSpendJournal = ScrubIssueKind("missing spend journal")
+
+	// ScrubCorruptSpendJournal indicates the spend journal entry for a block
+	// in the main chain does not match the transactions in the block.
+	ScrubCorruptSpendJournal = ScrubIssueKind("corrupt spend journal")
+)
+
+// String returns the issue kind as a human-readable string.
+func (k ScrubIssueKind) String() string {
+	return string(k)
+}
+
+// suggestion returns a suggested course of action to repair the issue kind
+// for a block that is either in the main chain or a side chain.
+func (k ScrubIssueKind) suggestion(mainChain bool) string {
+	if !mainChain {
+		return "quarantine the block so it is no longer considered for " +
+			"reorganization and download it again if it becomes relevant"
+	}
+
+	switch k {
+	case ScrubMissingBlockData, ScrubCorruptBlockData,
+		ScrubMismatchedBlockData:
+		return "restore the block files and metadata from a backup taken " +
+			"while the node was shut down or remove the data directory " +
+			"and resync"
+	}
+	return "remove the data directory and resync since the spend journal " +
+		"can only be rebuilt by reconnecting the blocks"
+}
+
+// ScrubIssue describes an integrity issue found while scrubbing the database
+// along with a suggested course of action to repair it.
+type ScrubIssue struct {
+	Hash        chainhash.Hash
+	Height      int64
+	MainChain   bool
+	Kind        ScrubIssueKind
+	Description string
+	Suggestion  string
+	Quarantined bool
+}
+
+// ScrubResult houses the results of scrubbing the database.
+type ScrubResult struct {
+	// MainChainBlocks is the number of main chain blocks that were checked.
+	MainChainBlocks int64
+
+	// SideChainBlocks is the number of side chain blocks that were checked.
+	SideChainBlocks int64
+
+	// Issues houses the integrity issues that were found.
+	Issues []ScrubIssue
+}
+
+// scrubBlock checks the data for the provided block against its block index
+// entry along with its spend journal entry when the block is in the main chain
+// and returns an issue describing the first problem found, if any.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) scrubBlock(node *blockNode, mainChain, isTreasuryEnabled bool) (*ScrubIssue, error) {
+	issue := func(kind ScrubIssueKind, desc string) *ScrubIssue {
+		return &ScrubIssue{
+			Hash:        node.hash,
+			Height:      node.height,
+			MainChain:   mainChain,
+			Kind:        kind,
+			Description: desc,
+			Suggestion:  kind.suggestion(mainChain),
+		}
+	}
+
+	var result *ScrubIssue
+	err := b.db.View(func(dbTx database.Tx) error {
+		// Load the block data which includes verifying its checksum.
+		blockBytes, err := dbTx.FetchBlock(&node.hash)
+		if err != nil {
+			var dbErr database.Error
+			if !errors.As(err, &dbErr) {
+				return err
+			}
+			kind := ScrubCorruptBlockData
+			if errors.Is(err, database.ErrBlockNotFound) {
+				kind = ScrubMissingBlockData
+			}
+			result = issue(kind, dbErr.Description)
+			return nil
+		}
+
+		// Ensure the block data matches the block index entry.
+		var msgBlock wire.MsgBlock
+		if err := msgBlock.Deserialize(bytes.NewReader(blockBytes)); err != nil {
+			str := fmt.Sprintf("unable to deserialize block: %v", err)
+			result = issue(ScrubCorruptBlockData, str)
+			return nil
+		}
+		if hash := msgBlock.BlockHash(); hash != node.hash {
+			str := fmt.Sprintf("block data has hash %v", hash)
+			result = issue(ScrubMismatchedBlockData, str)
+			return nil
+		}
+		if height := int64(msgBlock.Header.Height); height != node.height {
+			str := fmt.Sprintf("block data has height %d", height)
+			result = issue(ScrubMismatchedBlockData, str)
+			return nil
+		}
+
+		// The spend journal is only guaranteed to exist for main chain blocks
+		// that spend outputs.
+		blockTxns := spendJournalTxns(&msgBlock, isTreasuryEnabled)
+		if !mainChain || len(blockTxns) == 0 {
+			return nil
+		}
+		spendBucket := dbTx.Metadata().Bucket(spendJournalBucketName)
+		serialized := spendBucket.Get(node.hash[:])
+		if len(serialized) == 0 {
+			result = issue(ScrubMissingSpendJournal, "no spend journal entry")
+			return nil
+		}
+		_, err = deserializeSpendJournalEntry(serialized, blockTxns)
+		if err != nil {
+			if !isDeserializeErr(err) {
+				return err
+			}
+			result = issue(ScrubCorruptSpendJournal, err.Error())
+		}
+		return nil
+	})
+	return result, err
+}
+
+// sideChainNodes returns all nodes that are not part of the main chain and
+// have their block data available sorted by their height.
+//
+// This function MUST be called with the chain lock held (for reads).
+func (b *BlockChain) sideChainNodes() []*blockNode {
+	seen := make(map[*blockNode]struct{})
+	var nodes []*blockNode
+	b.index.RLock()
+	b.index.forEachChainTip(func(tip *blockNode) error {
+		for n := tip; n != nil && !b.bestChain.Contains(n); n = n.parent {
+			if _, ok := seen[n]; ok {
+				break
+			}
+			seen[n] = struct{}{}
+			if n.status.HaveData() {
+				nodes = append(nodes, n)
+			}
+		}
+		return nil
+	})
+	b.index.RUnlock()
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].height < nodes[j].height
+	})
+	return nodes
+}
+
+// quarantineBlock marks the data for the provided block as no longer being
+// available in the block index so that neither it nor any of its descendants
+// are considered for connection.  Blocks in the main chain are not
+// quarantined.  It returns whether or not the block was quarantined.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) quarantineBlock(node *blockNode) (bool, error) {
+	b.processLock.Lock()
+	defer b.processLock.Unlock()
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+
+	if b.bestChain.Contains(node) {
+		return false, nil
+	}
+	if b.index.NodeStatus(node).HaveData() {
+		b.numSideChainBlocks--
+	}
+	b.index.RemoveBlockData(node)
+	if err := b.flushBlockIndex(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Scrub performs an online integrity check of the database.  The data for all
+// blocks the block index claims are available is loaded from the block files,
+// which verifies their checksums, and cross referenced against their block
+// index entries.  The spend journal entries of all blocks in the main chain are
+// also verified against the transactions in the block.
+//
+// When the quarantine flag is set, side chain blocks with issues are marked as
+// no longer having their data available so they are not considered for
+// reorganization.  Note that the underlying data is retained to allow further
+// analysis of the issues.  Issues with blocks in the main chain can't
+// be repaired while the node is running and are only reported along with a
+// suggested course of action.
+//
+// The chain lock is only held while determining which blocks to check, so the
+// scrub does not prevent the chain from advancing while it is in progress.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) Scrub(ctx context.Context, quarantine bool) (*ScrubResult, error) {
+	var result ScrubResult
+	for height := int64(0); ; height++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		b.chainLock.RLock()
+		node := b.bestChain.NodeByHeight(height)
+		var isTreasuryEnabled bool
+		var err error
+		if node != nil && node.parent != nil {
+			isTreasuryEnabled, err = b.isTreasuryAgendaActive(node.parent)
+		}
+		b.chainLock.RUnlock()
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			break
+		}
+
+		// Blocks in the main chain must always have their data available.
+		result.MainChainBlocks++
+		if !b.index.NodeStatus(node).HaveData() {
+			result.Issues = append(result.Issues, ScrubIssue{
+				Hash:        node.hash,
+				Height:      node.height,
+				MainChain:   true,
+				Kind:        ScrubMissingBlockData,
+				Description: "block index does not have data stored flag",
+				Suggestion:  ScrubMissingBlockData.suggestion(true),
+			})
+			continue
+		}
+
+		issue, err := b.scrubBlock(node, true, isTreasuryEnabled)
+		if err != nil {
+			return nil, err
+		}
+		if issue != nil {
+			result.Issues = append(result.Issues, *issue)
+		}
+	}
+
+	b.chainLock.RLock()
+	sideNodes := b.sideChainNodes()
+	b.chainLock.RUnlock()
+	for _, node := range sideNodes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result.SideChainBlocks++
+		issue, err := b.scrubBlock(node, false, false)
+		if err != nil {
+			return nil, err
+		}
+		if issue == nil {
+			continue
+		}
+		if quarantine {
+			issue.Quarantined, err = b.quarantineBlock(node)
+			if err != nil {
+				return nil, err
+			}
+		}
+		result.Issues = append(result.Issues, *issue)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/database/v3"
+)
+
+// TestScrub ensures scrubbing the database detects integrity issues with both
+// main chain and side chain blocks and quarantines side chain blocks when
+// requested.
+func TestScrub(t *testing.T) {
+	t.Parallel()
+
+	// Create a test harness initialized with the genesis block as the tip.
+	params := chaincfg.RegNetParams()
+	g := newChaingenHarness(t, params)
+
+	// Generate and accept enough blocks to reach stake validation height.
+	g.AdvanceToStakeValidationHeight()
+
+	// Create and accept a couple of blocks in the main chain.
+	//
+	//   ... -> b1 -> b2
+	outs := g.OldestCoinbaseOuts()
+	g.NextBlock("b1", &outs[0], outs[1:])
+	g.SaveTipCoinbaseOuts()
+	g.AcceptTipBlock()
+	outs = g.OldestCoinbaseOuts()
+	g.NextBlock("b2", &outs[0], outs[1:])
+	g.SaveTipCoinbaseOuts()
+	g.AcceptTipBlock()
+
+	// Create a side chain where only the header of the first block and the
+	// data of the second block are accepted.
+	//
+	//   ... -> b1 -> b2
+	//             \-> b2a -> b3a
+	g.SetTip("b1")
+	g.NextBlock("b2a", nil, outs[1:])
+	g.NextBlock("b3a", nil, nil)
+	g.AcceptHeader("b2a")
+	g.AcceptBlockData("b3a")
+	g.ExpectTip("b2")
+
+	// Simulate the block index claiming the data for the first side chain
+	// block is available even though it is not.
+	lookupNode := func(blockName string) *blockNode {
+		hash := g.BlockByName(blockName).BlockHash()
+		return g.chain.index.LookupNode(&hash)
+	}
+	b2aNode, b3aNode := lookupNode("b2a"), lookupNode("b3a")
+	g.chain.index.SetStatusFlags(b2aNode, statusDataStored)
+	g.chain.index.AcceptBlockData(b2aNode, g.chain.bestChain.Tip())
+	if !b3aNode.isFullyLinked {
+		t.Fatal("side chain block is not fully linked")
+	}
+
+	// Ensure the missing side chain block data is reported without being
+	// quarantined.
+	ctx := context.Background()
+	result, err := g.chain.Scrub(ctx, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantMainChain := g.chain.bestChain.Tip().height + 1
+	if result.MainChainBlocks != wantMainChain || result.SideChainBlocks != 2 ||
+		len(result.Issues) != 1 {
+
+		t.Fatalf("unexpected scrub result: %+v", result)
+	}
+	issue := result.Issues[0]
+	if issue.Hash != b2aNode.hash || issue.MainChain ||
+		issue.Kind != ScrubMissingBlockData || issue.Quarantined {
+
+		t.Fatalf("unexpected scrub issue: %+v", issue)
+	}
+
+	// putSpendJournal sets the spend journal entry of the main chain tip to
+	// the provided value, or removes it when the value is nil, and returns the
+	// original value.
+	tipHash := g.chain.bestChain.Tip().hash
+	putSpendJournal := func(serialized []byte) []byte {
+		t.Helper()
+
+		var orig []byte
+		err := g.chain.db.Update(func(dbTx database.Tx) error {
+			spendBucket := dbTx.Metadata().Bucket(spendJournalBucketName)
+			orig = append([]byte(nil), spendBucket.Get(tipHash[:])...)
+			if serialized == nil {
+				return spendBucket.Delete(tipHash[:])
+			}
+			return spendBucket.Put(tipHash[:], serialized)
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return orig
+	}
+
+	// Corrupt the spend journal entry of the main chain tip.
+	origSpendJournal := putSpendJournal([]byte{0xff})
+
+	// Ensure the corrupt spend journal entry is reported and the side chain
+	// block is quarantined.
+	result, err = g.chain.Scrub(ctx, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Issues) != 2 {
+		t.Fatalf("unexpected scrub result: %+v", result)
+	}
+	issue = result.Issues[0]
+	if issue.Hash != tipHash || !issue.MainChain ||
+		issue.Kind != ScrubCorruptSpendJournal || issue.Quarantined {
+
+		t.Fatalf("unexpected scrub issue: %+v", issue)
+	}
+	issue = result.Issues[1]
+	if issue.Hash != b2aNode.hash || issue.MainChain ||
+		issue.Kind != ScrubMissingBlockData || !issue.Quarantined {
+
+		t.Fatalf("unexpected scrub issue: %+v", issue)
+	}
+
+	// Ensure the quarantined block and its descendant are no longer eligible
+	// for connection and the descendant is linked again once the data for the
+	// quarantined block becomes available.
+	if g.chain.index.NodeStatus(b2aNode).HaveData() || b2aNode.isFullyLinked ||
+		b3aNode.isFullyLinked {
+
+		t.Fatal("quarantined side chain is still eligible for connection")
+	}
+	if _, ok := g.chain.index.bestChainCandidates[b3aNode]; ok {
+		t.Fatal("quarantined side chain is still a best chain candidate")
+	}
+	unlinked := g.chain.index.unlinkedChildrenOf[b2aNode]
+	if len(unlinked) != 1 || unlinked[0] != b3aNode {
+		t.Fatalf("unexpected unlinked children: %v", unlinked)
+	}
+
+	// Remove the spend journal entry of the main chain tip and ensure it is
+	// reported as missing while the quarantined block is no longer checked.
+	putSpendJournal(nil)
+	result, err = g.chain.Scrub(ctx, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.SideChainBlocks != 1 || len(result.Issues) != 1 {
+		t.Fatalf("unexpected scrub result: %+v", result)
+	}
+	issue = result.Issues[0]
+	if issue.Hash != tipHash || issue.Kind != ScrubMissingSpendJournal {
+		t.Fatalf("unexpected scrub issue: %+v", issue)
+	}
+
+	// Restore the spend journal entry of the main chain tip and ensure the
+	// side chain is linked again and becomes the main chain once the data for
+	// the quarantined block is accepted.
+	putSpendJournal(origSpendJournal)
+	g.AcceptBlockDataWithExpectedTip("b2a", "b3a")
+}
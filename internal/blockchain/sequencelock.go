@@ -6,6 +6,7 @@ package blockchain
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/decred/dcrd/blockchain/stake/v5"
 	"github.com/decred/dcrd/blockchain/standalone/v2"
@@ -161,6 +162,93 @@ func (b *BlockChain) CalcSequenceLock(tx *dcrutil.Tx, view *UtxoViewpoint) (*Seq
 	return seqLock, err
 }
 
+// TxMiningWindow describes the range of blocks an unconfirmed transaction may
+// be included in while satisfying the relative lock times of all of its input
+// sequence numbers and its expiry.  It is calculated via the CalcTxMiningWindow
+// function.
+type TxMiningWindow struct {
+	// MinHeight is the minimum height of a block that may include the
+	// transaction.
+	MinHeight int64
+
+	// MinMedianTime is the minimum past median time, in seconds since the
+	// unix epoch, of the parent of a block that may include the transaction.
+	// It is zero when the transaction does not require a specific time.
+	MinMedianTime int64
+
+	// MaxHeight is the maximum height of a block that may include the
+	// transaction due to its expiry.  It is -1 when the transaction does not
+	// expire.
+	MaxHeight int64
+}
+
+// NewTxMiningWindow returns the range of blocks the passed transaction may be
+// included in given its sequence lock, as calculated by CalcSequenceLock, and
+// the height of the next block to be mined.
+//
+// Note that the window may be empty, meaning the transaction can never be
+// included in a block, when the transaction expires before its sequence lock
+// is satisfied.
+func NewTxMiningWindow(tx *dcrutil.Tx, seqLock *SequenceLock, nextBlockHeight int64) *TxMiningWindow {
+	// A sequence lock is satisfied by blocks with a height and past median
+	// time greater than the respective values of the lock.  Note that this
+	// results in zero for both values when the lock does not require them.
+	minHeight := seqLock.MinHeight + 1
+	if minHeight < nextBlockHeight {
+		minHeight = nextBlockHeight
+	}
+	window := &TxMiningWindow{
+		MinHeight:     minHeight,
+		MinMedianTime: seqLock.MinTime + 1,
+		MaxHeight:     -1,
+	}
+
+	// Transactions may only be included in blocks prior to their expiry.
+	if expiry := tx.MsgTx().Expiry; expiry != wire.NoExpiryValue {
+		window.MaxHeight = int64(expiry) - 1
+	}
+	return window
+}
+
+// IsEmpty returns whether or not there are no blocks that may include the
+// transaction the window was calculated for.
+func (w *TxMiningWindow) IsEmpty() bool {
+	return w.MaxHeight != -1 && w.MaxHeight < w.MinHeight
+}
+
+// Contains returns whether or not a block with the provided height and parent
+// past median time may include the transaction the window was calculated for.
+func (w *TxMiningWindow) Contains(blockHeight int64, medianTime time.Time) bool {
+	if blockHeight < w.MinHeight || medianTime.Unix() < w.MinMedianTime {
+		return false
+	}
+	return w.MaxHeight == -1 || blockHeight <= w.MaxHeight
+}
+
+// CalcTxMiningWindow computes the range of blocks after the current best chain
+// tip that may include the passed unconfirmed transaction while satisfying the
+// relative lock times of all of its input sequence numbers and its expiry.  The
+// passed view is used to obtain the block heights of the blocks in which the
+// referenced outputs of the inputs to the transaction were included.  Outputs
+// of other unconfirmed transactions are treated as if they will be included in
+// the next block.
+//
+// NOTE: Just as with CalcSequenceLock, this will calculate the sequence locks
+// regardless of the state of the agenda which conditionally activates it.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) CalcTxMiningWindow(tx *dcrutil.Tx, view *UtxoViewpoint) (*TxMiningWindow, error) {
+	b.chainLock.Lock()
+	tip := b.bestChain.Tip()
+	seqLock, err := b.calcSequenceLock(tip, tx, view, true)
+	b.chainLock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTxMiningWindow(tx, seqLock, tip.height+1), nil
+}
+
 // LockTimeToSequence converts the passed relative lock time to a sequence
 // number in accordance with DCP0003.
 //
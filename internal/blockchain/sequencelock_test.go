@@ -394,6 +394,144 @@ func TestCalcSequenceLock(t *testing.T) {
 	}
 }
 
+// TestCalcTxMiningWindow ensures the range of blocks that may include an
+// unconfirmed transaction is calculated as expected for combinations of
+// sequence locks and expiry.
+func TestCalcTxMiningWindow(t *testing.T) {
+	// Generate a synthetic chain with enough nodes to properly test the
+	// mining window functionality.
+	numBlocks := uint32(20)
+	params := chaincfg.RegNetParams()
+	bc := newFakeChain(params)
+	node := bc.bestChain.Tip()
+	blockTime := time.Unix(node.timestamp, 0)
+	for i := uint32(0); i < numBlocks; i++ {
+		blockTime = blockTime.Add(time.Second)
+		node = newFakeNode(node, 1, 1, 0, blockTime)
+		bc.index.AddNode(node)
+		bc.bestChain.SetTip(node)
+	}
+
+	// Create a utxo view with a confirmed utxo that has an age of 4 blocks
+	// and an unconfirmed utxo.
+	confTx := dcrutil.NewTx(&wire.MsgTx{
+		TxOut: []*wire.TxOut{{Value: 10}},
+	})
+	unconfTx := dcrutil.NewTx(&wire.MsgTx{
+		TxOut: []*wire.TxOut{{Value: 5}},
+	})
+	view := NewUtxoViewpoint(nil)
+	view.AddTxOuts(confTx, int64(numBlocks)-4, 0, noTreasury)
+	view.AddTxOuts(unconfTx, 0x7fffffff, wire.NullBlockIndex, noTreasury)
+	view.SetBestHash(&node.hash)
+	confUtxo := wire.OutPoint{Hash: *confTx.Hash(), Tree: wire.TxTreeRegular}
+	unconfUtxo := wire.OutPoint{Hash: *unconfTx.Hash(), Tree: wire.TxTreeRegular}
+
+	// The median time for the confirmed input is the median time from the
+	// PoV of the block prior to the one that included it.
+	confMedianTime := node.RelativeAncestor(5).CalcPastMedianTime().Unix()
+	nextBlockHeight := int64(numBlocks) + 1
+
+	tests := []struct {
+		name      string
+		inputs    []*wire.TxIn
+		expiry    uint32
+		want      TxMiningWindow
+		wantEmpty bool
+	}{{
+		name: "no sequence locks or expiry",
+		inputs: []*wire.TxIn{{
+			PreviousOutPoint: confUtxo,
+			Sequence:         wire.MaxTxInSequenceNum,
+		}},
+		want: TxMiningWindow{MinHeight: nextBlockHeight, MaxHeight: -1},
+	}, {
+		name: "satisfied height lock with expiry",
+		inputs: []*wire.TxIn{{
+			PreviousOutPoint: confUtxo,
+			Sequence:         mustLockTimeToSeq(false, 3),
+		}},
+		expiry: 30,
+		want:   TxMiningWindow{MinHeight: nextBlockHeight, MaxHeight: 29},
+	}, {
+		name: "unsatisfied height lock",
+		inputs: []*wire.TxIn{{
+			PreviousOutPoint: confUtxo,
+			Sequence:         mustLockTimeToSeq(false, 10),
+		}},
+		want: TxMiningWindow{MinHeight: 26, MaxHeight: -1},
+	}, {
+		name: "height lock on unconfirmed input",
+		inputs: []*wire.TxIn{{
+			PreviousOutPoint: unconfUtxo,
+			Sequence:         mustLockTimeToSeq(false, 3),
+		}},
+		want: TxMiningWindow{MinHeight: 24, MaxHeight: -1},
+	}, {
+		name: "time lock",
+		inputs: []*wire.TxIn{{
+			PreviousOutPoint: confUtxo,
+			Sequence:         mustLockTimeToSeq(true, 1024),
+		}},
+		want: TxMiningWindow{
+			MinHeight:     nextBlockHeight,
+			MinMedianTime: confMedianTime + 1024,
+			MaxHeight:     -1,
+		},
+	}, {
+		name: "expires before height lock is satisfied",
+		inputs: []*wire.TxIn{{
+			PreviousOutPoint: confUtxo,
+			Sequence:         mustLockTimeToSeq(false, 10),
+		}},
+		expiry:    25,
+		want:      TxMiningWindow{MinHeight: 26, MaxHeight: 24},
+		wantEmpty: true,
+	}}
+
+	for _, test := range tests {
+		tx := dcrutil.NewTx(&wire.MsgTx{
+			Version: 2,
+			TxIn:    test.inputs,
+			Expiry:  test.expiry,
+		})
+		window, err := bc.CalcTxMiningWindow(tx, view)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if *window != test.want {
+			t.Errorf("%s: mismatched window -- got %+v, want %+v", test.name,
+				*window, test.want)
+			continue
+		}
+		if window.IsEmpty() != test.wantEmpty {
+			t.Errorf("%s: mismatched empty -- got %v, want %v", test.name,
+				window.IsEmpty(), test.wantEmpty)
+			continue
+		}
+
+		// Ensure the window only contains blocks within its bounds.
+		minTime := time.Unix(window.MinMedianTime, 0)
+		if window.Contains(window.MinHeight, minTime) == test.wantEmpty {
+			t.Errorf("%s: mismatched contains for minimum height", test.name)
+		}
+		if window.Contains(window.MinHeight-1, minTime) {
+			t.Errorf("%s: contains height prior to minimum", test.name)
+		}
+		if window.MinMedianTime > 0 &&
+			window.Contains(window.MinHeight, minTime.Add(-time.Second)) {
+
+			t.Errorf("%s: contains time prior to minimum", test.name)
+		}
+		if window.MaxHeight != -1 &&
+			window.Contains(window.MaxHeight+1, minTime) {
+
+			t.Errorf("%s: contains height after maximum", test.name)
+		}
+	}
+}
+
 // TestLockTimeToSequence ensure the convenience function to convert relative
 // lock times to a sequence number works as expected.
 func TestLockTimeToSequence(t *testing.T) {
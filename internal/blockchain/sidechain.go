@@ -0,0 +1,210 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/database/v3"
+)
+
+// SideChainBlock describes a block that is not part of the main chain and has
+// its data available.
+type SideChainBlock struct {
+	// Hash and Height identify the side chain block.
+	Hash   chainhash.Hash
+	Height int64
+
+	// ForkHash and ForkHeight identify the main chain block the branch that
+	// contains the side chain block forks from.
+	ForkHash   chainhash.Hash
+	ForkHeight int64
+
+	// Depth is the number of blocks the main chain tip is past the fork point
+	// of the branch that contains the side chain block.  Branches with a
+	// greater depth are more stale.
+	Depth int64
+}
+
+// staleSideChainNodes returns all nodes that are not part of the main chain and
+// have their data available, along with their fork points, ordered from the
+// most stale branch to the least stale branch as determined by the depth of
+// their fork points.  Nodes that share the same fork point are ordered by
+// descending height so that evicting a prefix of the nodes never leaves a
+// block with its data available without its ancestors back to the fork point
+// also having their data available.
+//
+// Nodes that are ancestors of the best known header are not included since
+// the branch they are part of has more work than the main chain and is
+// therefore expected to become the main chain.
+//
+// This function MUST be called with the chain lock held (for reads).
+func (b *BlockChain) staleSideChainNodes() ([]*blockNode, map[*blockNode]*blockNode) {
+	bestHeader := b.index.BestHeader()
+	nodes := b.sideChainNodes()
+	forks := make(map[*blockNode]*blockNode, len(nodes))
+	filtered := nodes[:0]
+	for _, node := range nodes {
+		if node.IsAncestorOf(bestHeader) {
+			continue
+		}
+		forks[node] = b.bestChain.FindFork(node)
+		filtered = append(filtered, node)
+	}
+	nodes = filtered
+
+	sort.Slice(nodes, func(i, j int) bool {
+		forkI, forkJ := forks[nodes[i]], forks[nodes[j]]
+		if forkI.height != forkJ.height {
+			return forkI.height < forkJ.height
+		}
+		return nodes[i].height > nodes[j].height
+	})
+	return nodes, forks
+}
+
+// sideChainBlock returns a description of the provided side chain node which
+// forks from the main chain at the provided fork node.
+//
+// This function MUST be called with the chain lock held (for reads).
+func (b *BlockChain) sideChainBlock(node, fork *blockNode) SideChainBlock {
+	return SideChainBlock{
+		Hash:       node.hash,
+		Height:     node.height,
+		ForkHash:   fork.hash,
+		ForkHeight: fork.height,
+		Depth:      b.bestChain.Tip().height - fork.height,
+	}
+}
+
+// SideChainBlocks returns all blocks that are not part of the main chain and
+// have their data available ordered from the most stale branch to the least
+// stale branch.  Blocks that are part of a branch that has more work than the
+// main chain are not included since they are expected to become part of the
+// main chain.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) SideChainBlocks() []SideChainBlock {
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+
+	nodes, forks := b.staleSideChainNodes()
+	blocks := make([]SideChainBlock, 0, len(nodes))
+	for _, node := range nodes {
+		blocks = append(blocks, b.sideChainBlock(node, forks[node]))
+	}
+	return blocks
+}
+
+// blockRemover is implemented by database transactions that support removing
+// blocks, such as those provided by the ffldb driver.
+type blockRemover interface {
+	RemoveBlock(hash *chainhash.Hash) error
+}
+
+// evictSideChainNodes removes the data for the provided side chain nodes from
+// the database and marks it as no longer being available in the block index so
+// that neither they nor any of their descendants are considered for connection
+// unless their data is received again.
+//
+// This function MUST be called with the chain lock held (for writes).
+func (b *BlockChain) evictSideChainNodes(nodes []*blockNode) error {
+	for _, node := range nodes {
+		b.index.RemoveBlockData(node)
+		b.numSideChainBlocks--
+	}
+
+	// Flush the block index before removing the data from the database so the
+	// block index never claims data is available when it is not in the event
+	// of an unexpected shutdown.
+	if err := b.flushBlockIndex(); err != nil {
+		return err
+	}
+	return b.db.Update(func(dbTx database.Tx) error {
+		// The data is left in the database when the backend does not support
+		// removing blocks.
+		remover, ok := dbTx.(blockRemover)
+		if !ok {
+			return nil
+		}
+		for _, node := range nodes {
+			err := remover.RemoveBlock(&node.hash)
+			if err != nil && !errors.Is(err, database.ErrBlockNotFound) {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// maybeEvictSideChainBlocks evicts side chain blocks, starting with the most
+// stale branches, when there are more of them with their data available than
+// the configured maximum allows.
+//
+// This function MUST be called with the chain lock held (for writes).
+func (b *BlockChain) maybeEvictSideChainBlocks() error {
+	// Avoid the relatively expensive determination of the stale side chain
+	// blocks when there are not enough side chain blocks for it to matter.
+	if b.maxSideChainBlocks <= 0 || b.numSideChainBlocks <=
+		b.maxSideChainBlocks {
+
+		return nil
+	}
+
+	nodes, _ := b.staleSideChainNodes()
+	numExcess := int64(len(nodes)) - b.maxSideChainBlocks
+	if numExcess <= 0 {
+		return nil
+	}
+
+	log.Debugf("Evicting %d side chain blocks to remain within the maximum "+
+		"of %d", numExcess, b.maxSideChainBlocks)
+	return b.evictSideChainNodes(nodes[:numExcess])
+}
+
+// PurgeSideChainBlocks evicts all blocks that are not part of the main chain,
+// have their data available, and are part of a branch that forks from the main
+// chain at least the provided number of blocks before the main chain tip.  It
+// returns descriptions of the evicted blocks ordered from the most stale branch
+// to the least stale branch.
+//
+// Evicted blocks are removed from the database and marked as no longer having
+// their data available so they are no longer considered for reorganization.
+// They will be considered again if their data is received again.
+//
+// Blocks that are part of a branch that has more work than the main chain are
+// never evicted since they are expected to become part of the main chain.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) PurgeSideChainBlocks(minDepth int64) ([]SideChainBlock, error) {
+	b.processLock.Lock()
+	defer b.processLock.Unlock()
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+
+	nodes, forks := b.staleSideChainNodes()
+	tipHeight := b.bestChain.Tip().height
+	var purged []SideChainBlock
+	var purgedNodes []*blockNode
+	for _, node := range nodes {
+		fork := forks[node]
+		if tipHeight-fork.height < minDepth {
+			continue
+		}
+		purged = append(purged, b.sideChainBlock(node, fork))
+		purgedNodes = append(purgedNodes, node)
+	}
+	if len(purgedNodes) == 0 {
+		return nil, nil
+	}
+
+	if err := b.evictSideChainNodes(purgedNodes); err != nil {
+		return nil, err
+	}
+	log.Infof("Purged %d side chain blocks", len(purgedNodes))
+	return purged, nil
+}
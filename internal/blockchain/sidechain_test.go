@@ -0,0 +1,172 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/database/v3"
+)
+
+// TestSideChainBlocks ensures side chain blocks are listed in order of
+// staleness, are evicted when there are more of them than allowed, and can be
+// purged manually.
+func TestSideChainBlocks(t *testing.T) {
+	t.Parallel()
+
+	// Create a test harness initialized with the genesis block as the tip.
+	params := chaincfg.RegNetParams()
+	g := newChaingenHarness(t, params)
+
+	// Generate and accept enough blocks to reach stake validation height.
+	g.AdvanceToStakeValidationHeight()
+
+	// Create and accept several blocks in the main chain.
+	//
+	//   ... -> b1 -> b2 -> b3 -> b4
+	outs := g.OldestCoinbaseOuts()
+	g.NextBlock("b1", &outs[0], outs[1:])
+	g.SaveTipCoinbaseOuts()
+	g.AcceptTipBlock()
+	b1Outs := g.OldestCoinbaseOuts()
+	g.NextBlock("b2", &b1Outs[0], b1Outs[1:])
+	g.SaveTipCoinbaseOuts()
+	g.AcceptTipBlock()
+	outs = g.OldestCoinbaseOuts()
+	g.NextBlock("b3", &outs[0], outs[1:])
+	g.SaveTipCoinbaseOuts()
+	g.AcceptTipBlock()
+	b3Outs := g.OldestCoinbaseOuts()
+	g.NextBlock("b4", &b3Outs[0], b3Outs[1:])
+	g.SaveTipCoinbaseOuts()
+	g.AcceptTipBlock()
+
+	// Create a couple of side chains that fork from the main chain at
+	// different depths.
+	//
+	//   ... -> b1 -> b2 -> b3 -> b4
+	//            \            \-> b4a
+	//             \-> b2a -> b3a
+	g.SetTip("b1")
+	g.NextBlock("b2a", nil, b1Outs[1:])
+	g.AcceptedToSideChainWithExpectedTip("b4")
+	g.NextBlock("b3a", nil, nil)
+	g.AcceptedToSideChainWithExpectedTip("b4")
+	g.SetTip("b3")
+	g.NextBlock("b4a", nil, b3Outs[1:])
+	g.AcceptedToSideChainWithExpectedTip("b4")
+
+	// assertSideChainBlocks ensures the side chain blocks reported by the
+	// chain are the provided blocks in order with the provided depths.
+	assertSideChainBlocks := func(got []SideChainBlock, names []string,
+		depths []int64) {
+
+		t.Helper()
+
+		if len(got) != len(names) {
+			t.Fatalf("unexpected number of side chain blocks -- got %d, "+
+				"want %d", len(got), len(names))
+		}
+		for i, name := range names {
+			block := g.BlockByName(name)
+			if got[i].Hash != block.BlockHash() ||
+				got[i].Height != int64(block.Header.Height) ||
+				got[i].Depth != depths[i] {
+
+				t.Fatalf("unexpected side chain block %d -- got %+v, want "+
+					"%s (hash %v, depth %d)", i, got[i], name,
+					block.BlockHash(), depths[i])
+			}
+		}
+	}
+	assertSideChainBlocks(g.chain.SideChainBlocks(),
+		[]string{"b3a", "b2a", "b4a"}, []int64{3, 3, 1})
+
+	// assertNumSideChainBlocks ensures the incrementally tracked number of side
+	// chain blocks matches the number of side chain blocks with their data
+	// available.
+	assertNumSideChainBlocks := func(want int64) {
+		t.Helper()
+
+		got := g.chain.numSideChainBlocks
+		if numNodes := int64(len(g.chain.sideChainNodes())); numNodes != got {
+			t.Fatalf("mismatched number of side chain blocks -- tracked %d, "+
+				"actual %d", got, numNodes)
+		}
+		if got != want {
+			t.Fatalf("unexpected number of side chain blocks -- got %d, "+
+				"want %d", got, want)
+		}
+	}
+	assertNumSideChainBlocks(3)
+
+	// Limit the number of side chain blocks and ensure the tip of the most
+	// stale side chain is evicted once another block is processed.
+	//
+	//   ... -> b1 -> b2 -> b3 -> b4 -> b5
+	//            \            \-> b4a
+	//             \-> b2a
+	g.chain.maxSideChainBlocks = 2
+	g.SetTip("b4")
+	outs = g.OldestCoinbaseOuts()
+	g.NextBlock("b5", &outs[0], outs[1:])
+	g.SaveTipCoinbaseOuts()
+	g.AcceptTipBlock()
+	assertSideChainBlocks(g.chain.SideChainBlocks(),
+		[]string{"b2a", "b4a"}, []int64{4, 2})
+	b3aHash := g.BlockByName("b3a").BlockHash()
+	b3aNode := g.chain.index.LookupNode(&b3aHash)
+	if g.chain.index.NodeStatus(b3aNode).HaveData() {
+		t.Fatal("evicted side chain block still has its data available")
+	}
+	g.chain.db.View(func(dbTx database.Tx) error {
+		if hasBlock, _ := dbTx.HasBlock(&b3aHash); hasBlock {
+			t.Fatal("evicted side chain block still exists in the database")
+		}
+		return nil
+	})
+	assertNumSideChainBlocks(2)
+
+	// Ensure purging side chain blocks only purges those that fork from the
+	// main chain at least the provided depth.
+	purged, err := g.chain.PurgeSideChainBlocks(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertSideChainBlocks(purged, []string{"b2a"}, []int64{4})
+	assertSideChainBlocks(g.chain.SideChainBlocks(), []string{"b4a"},
+		[]int64{2})
+	assertNumSideChainBlocks(1)
+
+	// Accept the headers for a side chain that has more work than the main
+	// chain and ensure blocks that are part of it are neither reported nor
+	// purged.
+	//
+	//   ... -> b1 -> b2 -> b3 -> b4 -> b5
+	//                         \-> b4a -> b5a -> b6a
+	g.SetTip("b4a")
+	g.NextBlock("b5a", nil, nil)
+	g.NextBlock("b6a", nil, nil)
+	g.AcceptHeader("b5a")
+	g.AcceptHeader("b6a")
+	g.ExpectBestHeader("b6a")
+	assertSideChainBlocks(g.chain.SideChainBlocks(), nil, nil)
+	purged, err = g.chain.PurgeSideChainBlocks(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertSideChainBlocks(purged, nil, nil)
+
+	// Accept the side chain blocks so the chain reorganizes to them and ensure
+	// the number of side chain blocks accounts for the disconnected blocks.
+	//
+	//   ... -> b1 -> b2 -> b3 -> b4 -> b5
+	//                         \-> b4a -> b5a -> b6a
+	g.AcceptBlockData("b5a")
+	g.AcceptBlockData("b6a")
+	g.ExpectTip("b6a")
+	assertNumSideChainBlocks(2)
+}
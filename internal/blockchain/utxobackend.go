@@ -5,11 +5,13 @@
 package blockchain
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/decred/dcrd/blockchain/standalone/v2"
@@ -183,6 +185,15 @@ type UtxoBackend interface {
 	// both the entry and the error.
 	FetchEntry(outpoint wire.OutPoint) (*UtxoEntry, error)
 
+	// FetchEntries returns the specified transaction outputs from the UTXO
+	// set in the same order as the provided outpoints.  Implementations
+	// should load the outputs as efficiently as possible, such as by
+	// visiting them in sorted key order.
+	//
+	// The returned slice will have a nil entry for each output that does not
+	// exist.
+	FetchEntries(outpoints []wire.OutPoint) ([]*UtxoEntry, error)
+
 	// FetchInfo returns versioning and creation information for the UTXO
 	// backend.
 	FetchInfo() (*UtxoBackendInfo, error)
@@ -452,23 +463,10 @@ func (l *levelDbUtxoBackend) NewIterator(prefix []byte) UtxoBackendIterator {
 	return l.db.NewIterator(slice, nil)
 }
 
-// dbFetchUtxoEntry fetches the specified transaction output from the utxo set.
-//
-// When there is no entry for the provided output, nil will be returned for both
-// the entry and the error.
-func (l *levelDbUtxoBackend) dbFetchUtxoEntry(outpoint wire.OutPoint) (*UtxoEntry, error) {
-	// Fetch the unspent transaction output information for the passed
-	// transaction output.  Return now when there is no entry.
-	key := outpointKey(outpoint)
-	serializedUtxo, err := l.Get(*key)
-	recycleOutpointKey(key)
-	if err != nil {
-		return nil, err
-	}
-	if serializedUtxo == nil {
-		return nil, nil
-	}
-
+// decodeDbUtxoEntry deserializes the passed serialized utxo entry for the
+// specified transaction output as loaded from the database and ensures any
+// errors are returned as UTXO backend corruption errors.
+func decodeDbUtxoEntry(outpoint wire.OutPoint, serializedUtxo []byte) (*UtxoEntry, error) {
 	// A non-nil zero-length entry means there is an entry in the database for a
 	// spent transaction output which should never be the case.
 	if len(serializedUtxo) == 0 {
@@ -492,6 +490,26 @@ func (l *levelDbUtxoBackend) dbFetchUtxoEntry(outpoint wire.OutPoint) (*UtxoEntr
 	return entry, nil
 }
 
+// dbFetchUtxoEntry fetches the specified transaction output from the utxo set.
+//
+// When there is no entry for the provided output, nil will be returned for both
+// the entry and the error.
+func (l *levelDbUtxoBackend) dbFetchUtxoEntry(outpoint wire.OutPoint) (*UtxoEntry, error) {
+	// Fetch the unspent transaction output information for the passed
+	// transaction output.  Return now when there is no entry.
+	key := outpointKey(outpoint)
+	serializedUtxo, err := l.Get(*key)
+	recycleOutpointKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if serializedUtxo == nil {
+		return nil, nil
+	}
+
+	return decodeDbUtxoEntry(outpoint, serializedUtxo)
+}
+
 // FetchEntry returns the specified transaction output from the UTXO set.
 //
 // When there is no entry for the provided output, nil will be returned for both
@@ -506,6 +524,71 @@ func (l *levelDbUtxoBackend) FetchEntry(outpoint wire.OutPoint) (*UtxoEntry, err
 	return l.dbFetchUtxoEntry(outpoint)
 }
 
+// FetchEntries returns the specified transaction outputs from the UTXO set in
+// the same order as the provided outpoints.  The outputs are loaded with a
+// single database iterator that visits the keys in sorted order, which is
+// significantly faster than loading each output individually when there are
+// many outputs that are scattered throughout the database.
+//
+// The returned slice will have a nil entry for each output that does not
+// exist.
+func (l *levelDbUtxoBackend) FetchEntries(outpoints []wire.OutPoint) ([]*UtxoEntry, error) {
+	// Nothing to do if there are no requested outputs.
+	if len(outpoints) == 0 {
+		return nil, nil
+	}
+
+	// Serialize the keys for all of the requested outputs and sort them so
+	// the database is accessed sequentially.
+	type keyedOutpoint struct {
+		key   *[]byte
+		index int
+	}
+	keys := make([]keyedOutpoint, 0, len(outpoints))
+	defer func() {
+		for _, k := range keys {
+			recycleOutpointKey(k.key)
+		}
+	}()
+	for i, outpoint := range outpoints {
+		keys = append(keys, keyedOutpoint{outpointKey(outpoint), i})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(*keys[i].key, *keys[j].key) < 0
+	})
+
+	iter := l.db.NewIterator(util.BytesPrefix(utxoPrefixUtxoSet), nil)
+	defer iter.Release()
+	entries := make([]*UtxoEntry, len(outpoints))
+	for _, k := range keys {
+		// There are no more entries in the database when the seek fails, so
+		// all of the remaining outputs do not exist since the keys are
+		// sorted.
+		if !iter.Seek(*k.key) {
+			if err := iter.Error(); err != nil {
+				str := "failed to iterate utxo entries"
+				return nil, convertLdbErr(err, str)
+			}
+			break
+		}
+		if !bytes.Equal(iter.Key(), *k.key) {
+			continue
+		}
+
+		// Copy the serialized entry since the iterator value is only valid
+		// until the next call to seek.
+		serializedUtxo := make([]byte, len(iter.Value()))
+		copy(serializedUtxo, iter.Value())
+		entry, err := decodeDbUtxoEntry(outpoints[k.index], serializedUtxo)
+		if err != nil {
+			return nil, err
+		}
+		entries[k.index] = entry
+	}
+
+	return entries, nil
+}
+
 // FetchState returns the current state of the UTXO set.
 func (l *levelDbUtxoBackend) FetchState() (*UtxoSetState, error) {
 	// Fetch the utxo set state from the database.
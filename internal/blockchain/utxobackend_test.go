@@ -175,6 +175,84 @@ func TestFetchEntryFromBackend(t *testing.T) {
 	}
 }
 
+// TestFetchEntriesFromBackend validates that fetching multiple entries from the
+// backend returns the correct entries in the requested order.
+func TestFetchEntriesFromBackend(t *testing.T) {
+	t.Parallel()
+
+	// Create a test backend.
+	backend := createTestUtxoBackend(t)
+
+	// Create test entries to be used throughout the tests.  The missing
+	// outpoints have keys that sort both before and after all of the entries
+	// in the backend.
+	outpoint299 := outpoint299()
+	outpoint1100 := outpoint1100()
+	outpoint1200 := outpoint1200()
+	missingFirst := wire.OutPoint{Index: 0}
+	missingLast := wire.OutPoint{Index: 1}
+	for i := range missingLast.Hash {
+		missingLast.Hash[i] = 0xff
+	}
+	modified := func(entry *UtxoEntry) *UtxoEntry {
+		entry.state |= utxoStateModified
+		return entry
+	}
+	err := backend.PutUtxos(map[wire.OutPoint]*UtxoEntry{
+		outpoint299:  modified(entry299()),
+		outpoint1100: modified(entry1100()),
+		outpoint1200: modified(entry1200()),
+	}, &UtxoSetState{})
+	if err != nil {
+		t.Fatalf("unexpected error adding entries to test backend: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		outpoints   []wire.OutPoint
+		wantEntries []*UtxoEntry
+	}{{
+		name: "no outpoints",
+	}, {
+		name:        "all entries are missing",
+		outpoints:   []wire.OutPoint{missingLast, missingFirst},
+		wantEntries: []*UtxoEntry{nil, nil},
+	}, {
+		name: "mix of existing and missing entries",
+		outpoints: []wire.OutPoint{outpoint1200, missingLast, outpoint299,
+			missingFirst, outpoint1100},
+		wantEntries: []*UtxoEntry{entry1200(), nil, entry299(), nil,
+			entry1100()},
+	}}
+
+	for _, test := range tests {
+		entries, err := backend.FetchEntries(test.outpoints)
+		if err != nil {
+			t.Fatalf("%q: unexpected error fetching entries: %v", test.name,
+				err)
+		}
+
+		// Ensure that the fetched entries match the expected entries.
+		if !reflect.DeepEqual(entries, test.wantEntries) {
+			t.Fatalf("%q: mismatched entries:\nwant: %+v\n got: %+v\n",
+				test.name, test.wantEntries, entries)
+		}
+	}
+
+	// Ensure corrupt entries result in an error.
+	err = backend.Update(func(tx UtxoBackendTx) error {
+		key := outpointKey(missingFirst)
+		return tx.Put(*key, hexToBytes("812b"))
+	})
+	if err != nil {
+		t.Fatalf("unexpected error adding entry to test backend: %v", err)
+	}
+	_, err = backend.FetchEntries([]wire.OutPoint{outpoint299, missingFirst})
+	if !errors.Is(err, ErrUtxoBackendCorruption) {
+		t.Fatalf("did not receive expected corruption error -- got %v", err)
+	}
+}
+
 // TestPutUtxos validates that the UTXO set in the backend is updated as
 // expected under a variety of conditions.
 func TestPutUtxos(t *testing.T) {
@@ -99,6 +99,11 @@ type UtxoCacher interface {
 	// FetchStats returns statistics on the current utxo set.
 	FetchStats(bestHash *chainhash.Hash, bestHeight uint32) (*UtxoStats, error)
 
+	// PrefetchEntries loads the requested transaction outputs that are not
+	// already in the cache from the backend into the cache without blocking
+	// concurrent use of the cache while they are loaded.
+	PrefetchEntries(outpoints []wire.OutPoint) error
+
 	// Initialize initializes the utxo cache and underlying utxo backend.  This
 	// entails running any database migrations as well as ensuring that the utxo
 	// set is caught up to the tip of the best chain.
@@ -182,6 +187,11 @@ type UtxoCache struct {
 	hits   uint64
 	misses uint64
 
+	// flushGeneration is incremented every time the cache is flushed to the
+	// backend.  It is used to detect when the backend may have been modified
+	// while entries were being prefetched without the cache lock held.
+	flushGeneration uint64
+
 	// timeNow defines the function to use to get the current local time.  It
 	// defaults to time.Now but an alternative function can be provided for
 	// testing purposes.
@@ -417,18 +427,95 @@ func (c *UtxoCache) FetchEntry(outpoint wire.OutPoint) (*UtxoEntry, error) {
 // This function is safe for concurrent access.
 func (c *UtxoCache) FetchEntries(filteredSet ViewFilteredSet, view *UtxoViewpoint) error {
 	c.cacheLock.Lock()
+	var misses []wire.OutPoint
 	for outpoint := range filteredSet {
-		entry, err := c.fetchEntry(outpoint)
+		// Add the entry to the view immediately when the cache already has
+		// it.  A cloned copy of the entry is added so it can safely be
+		// mutated by the caller without invalidating the cache.
+		if entry, found := c.entries[outpoint]; found {
+			c.hits++
+			view.entries[outpoint] = entry.Clone()
+			continue
+		}
+		misses = append(misses, outpoint)
+	}
+
+	// Fetch all of the entries that are not in the cache from the backend in a
+	// single batch.
+	if len(misses) > 0 {
+		c.misses += uint64(len(misses))
+		entries, err := c.backend.FetchEntries(misses)
 		if err != nil {
 			c.cacheLock.Unlock()
 			return err
 		}
+		for i, outpoint := range misses {
+			entry := entries[i]
+			if entry != nil {
+				c.totalEntrySize += entry.size()
+			}
+			c.entries[outpoint] = entry
+
+			// NOTE: Missing entries are not considered an error here and
+			// instead will result in nil entries in the view.  This is
+			// intentionally done so other code can use the presence of an
+			// entry in the view as a way to unnecessarily avoid attempting to
+			// reload it from the backend.
+			view.entries[outpoint] = entry.Clone()
+		}
+	}
+	c.cacheLock.Unlock()
+
+	return nil
+}
 
-		// NOTE: Missing entries are not considered an error here and instead
-		// will result in nil entries in the view.  This is intentionally done
-		// so other code can use the presence of an entry in the view as a way
-		// to unnecessarily avoid attempting to reload it from the backend.
-		view.entries[outpoint] = entry
+// PrefetchEntries loads the requested transaction outputs that are not already
+// in the cache from the backend into the cache so that subsequent requests for
+// them do not need to access the backend.
+//
+// Unlike FetchEntries, the backend is accessed without holding the cache lock,
+// so the cache may continue to be used concurrently while the entries are
+// loaded.  The loaded entries are discarded when the cache is flushed while
+// they are being loaded since the backend may have been modified.
+//
+// This function is safe for concurrent access.
+func (c *UtxoCache) PrefetchEntries(outpoints []wire.OutPoint) error {
+	// Determine which of the requested entries are not already in the cache
+	// along with the flush generation at the time.
+	c.cacheLock.Lock()
+	misses := make([]wire.OutPoint, 0, len(outpoints))
+	for _, outpoint := range outpoints {
+		if _, found := c.entries[outpoint]; !found {
+			misses = append(misses, outpoint)
+		}
+	}
+	flushGeneration := c.flushGeneration
+	c.cacheLock.Unlock()
+	if len(misses) == 0 {
+		return nil
+	}
+
+	// Load the entries from the backend without holding the cache lock.
+	entries, err := c.backend.FetchEntries(misses)
+	if err != nil {
+		return err
+	}
+
+	// Add the loaded entries to the cache so long as the backend was not
+	// modified while they were being loaded.  Entries that were added to the
+	// cache in the mean time are more recent and therefore are not replaced.
+	c.cacheLock.Lock()
+	if c.flushGeneration == flushGeneration {
+		for i, outpoint := range misses {
+			if _, found := c.entries[outpoint]; found {
+				continue
+			}
+			entry := entries[i]
+			if entry != nil {
+				c.totalEntrySize += entry.size()
+			}
+			c.entries[outpoint] = entry
+		}
 	}
 	c.cacheLock.Unlock()
 
@@ -565,6 +652,10 @@ func (c *UtxoCache) shouldFlush(bestHash *chainhash.Hash) bool {
 //
 // This function MUST be called with the cache lock held.
 func (c *UtxoCache) flush(bestHash *chainhash.Hash, bestHeight uint32, logFlush bool) error {
+	// Invalidate any prefetches that are in progress since the backend is
+	// about to be modified.
+	c.flushGeneration++
+
 	// If the maximum allowed size of the cache has been reached, determine the
 	// eviction height.
 	var evictionHeight uint32
@@ -933,6 +1024,9 @@ func (c *UtxoCache) Initialize(ctx context.Context, b *BlockChain, tip *blockNod
 //
 // This function should only be called during shutdown.
 func (b *BlockChain) ShutdownUtxoCache() {
+	// Wait for any utxo prefetches that are in progress to finish.
+	b.prefetchWg.Wait()
+
 	b.chainLock.RLock()
 	defer b.chainLock.RUnlock()
 
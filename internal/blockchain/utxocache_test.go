@@ -638,6 +638,117 @@ func TestFetchEntries(t *testing.T) {
 	}
 }
 
+// fetchHookUtxoBackend wraps a utxo backend and invokes a hook prior to fetching
+// multiple entries in order to simulate concurrent modifications.
+type fetchHookUtxoBackend struct {
+	UtxoBackend
+	hook func()
+}
+
+// FetchEntries invokes the hook and then fetches the requested entries from the
+// underlying backend.
+func (b *fetchHookUtxoBackend) FetchEntries(outpoints []wire.OutPoint) ([]*UtxoEntry, error) {
+	b.hook()
+	return b.UtxoBackend.FetchEntries(outpoints)
+}
+
+// TestPrefetchEntries validates that prefetching entries adds the entries that
+// are not already cached to the cache and that prefetched entries are discarded
+// when the cache is flushed while they are being loaded.
+func TestPrefetchEntries(t *testing.T) {
+	t.Parallel()
+
+	// Create a test backend.
+	backend := createTestUtxoBackend(t)
+
+	// Create test entries to be used throughout the tests.
+	outpoint299 := outpoint299()
+	outpoint1100 := outpoint1100()
+	entry1100 := entry1100()
+	entry1100Modified := entry1100.Clone()
+	entry1100Modified.amount++
+	entry1100Modified.state |= utxoStateModified
+	outpoint1200 := outpoint1200()
+	entry1200 := entry1200()
+	modified := func(entry *UtxoEntry) *UtxoEntry {
+		entry = entry.Clone()
+		entry.state |= utxoStateModified
+		return entry
+	}
+	err := backend.PutUtxos(map[wire.OutPoint]*UtxoEntry{
+		outpoint1100: modified(entry1100),
+		outpoint1200: modified(entry1200),
+	}, &UtxoSetState{})
+	if err != nil {
+		t.Fatalf("unexpected error adding entries to test backend: %v", err)
+	}
+	outpoints := []wire.OutPoint{outpoint299, outpoint1100, outpoint1200}
+
+	tests := []struct {
+		name          string
+		cachedEntries map[wire.OutPoint]*UtxoEntry
+		flush         bool
+		wantEntries   map[wire.OutPoint]*UtxoEntry
+	}{{
+		name: "entries not in the cache are added to the cache",
+		cachedEntries: map[wire.OutPoint]*UtxoEntry{
+			outpoint1100: entry1100Modified,
+		},
+		wantEntries: map[wire.OutPoint]*UtxoEntry{
+			outpoint299:  nil,
+			outpoint1100: entry1100Modified,
+			outpoint1200: entry1200,
+		},
+	}, {
+		name: "entries are discarded when flushed while loading",
+		cachedEntries: map[wire.OutPoint]*UtxoEntry{
+			outpoint1100: entry1100Modified,
+		},
+		flush: true,
+		wantEntries: map[wire.OutPoint]*UtxoEntry{
+			outpoint1100: entry1100Modified,
+		},
+	}}
+
+	for _, test := range tests {
+		// Create a utxo cache with the cached entries specified by the test
+		// and simulate a flush while the entries are loaded when requested.
+		utxoCache := createTestUtxoCache(t, test.cachedEntries)
+		utxoCache.backend = &fetchHookUtxoBackend{
+			UtxoBackend: backend,
+			hook: func() {
+				if test.flush {
+					utxoCache.cacheLock.Lock()
+					utxoCache.flushGeneration++
+					utxoCache.cacheLock.Unlock()
+				}
+			},
+		}
+
+		// Prefetch the entries and ensure the cache has the expected entries.
+		if err := utxoCache.PrefetchEntries(outpoints); err != nil {
+			t.Fatalf("%q: unexpected error prefetching entries: %v",
+				test.name, err)
+		}
+		if !reflect.DeepEqual(utxoCache.entries, test.wantEntries) {
+			t.Fatalf("%q: mismatched entries:\nwant: %+v\n got: %+v\n",
+				test.name, test.wantEntries, utxoCache.entries)
+		}
+
+		// Ensure the total entry size reflects the cached entries.
+		var wantTotalEntrySize uint64
+		for _, entry := range test.wantEntries {
+			if entry != nil {
+				wantTotalEntrySize += entry.size()
+			}
+		}
+		if utxoCache.totalEntrySize != wantTotalEntrySize {
+			t.Fatalf("%q: unexpected total entry size -- got %v, want %v",
+				test.name, utxoCache.totalEntrySize, wantTotalEntrySize)
+		}
+	}
+}
+
 // TestCommit validates that all entries in both the cache and the provided view
 // are updated appropriately when committing the provided view to the cache.
 func TestCommit(t *testing.T) {
@@ -0,0 +1,597 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/crypto/blake256"
+	"github.com/decred/dcrd/database/v3"
+	"github.com/decred/dcrd/wire"
+)
+
+const (
+	// utxoSnapshotVersion is the current version of the UTXO set snapshot
+	// serialization format.
+	utxoSnapshotVersion = 1
+
+	// utxoSnapshotImportBatchSize is the number of utxos written to the UTXO
+	// backend in each transaction while importing a UTXO set snapshot.
+	utxoSnapshotImportBatchSize = 50000
+
+	// maxUtxoSnapshotRecordLen is the maximum allowed length of the key or
+	// entry of an individual record in a UTXO set snapshot.  It protects
+	// against excessive allocations when reading malformed snapshots.
+	maxUtxoSnapshotRecordLen = wire.MaxBlockPayload
+)
+
+// utxoSnapshotMagic is the magic value that identifies a UTXO set snapshot.
+var utxoSnapshotMagic = [4]byte{'d', 'u', 't', 'x'}
+
+// -----------------------------------------------------------------------------
+// A UTXO set snapshot is a portable serialization of the full UTXO set as of a
+// specific block along with an integrity hash that protects against accidental
+// corruption.
+//
+// The serialized format is:
+//
+//   <header><utxos><terminator><num utxos><integrity hash>
+//
+//   Field                Type              Size
+//   header
+//     magic              [4]byte           4 bytes
+//     version            uint32            4 bytes
+//     network            uint32            4 bytes
+//     utxo set version   uint32            4 bytes
+//     block hash         chainhash.Hash    chainhash.HashSize
+//     block height       uint32            4 bytes
+//   utxos (zero or more)
+//     key len            VLQ               variable
+//     key                []byte            variable
+//     entry len          VLQ               variable
+//     entry              []byte            variable
+//   terminator           VLQ               1 byte (always zero)
+//   num utxos            uint64            8 bytes
+//   integrity hash       chainhash.Hash    chainhash.HashSize
+//
+// All fixed-size integers are encoded in little endian.
+//
+// The key of each utxo is the outpoint key used by the UTXO set without the
+// key set prefix and the entry is the utxo entry serialized according to the
+// UTXO set version in the header.  See the UTXO set serialization format in
+// utxoio.go for details.
+//
+// The integrity hash is the BLAKE-256 hash of all of the preceding bytes.
+//
+// NOTE: The integrity hash only detects corruption.  It does not prove the
+// UTXO set is the one that results from connecting the blocks up to and
+// including the block in the header, so snapshots must only be imported from a
+// trusted source.
+// -----------------------------------------------------------------------------
+
+// UtxoSnapshotInfo describes a UTXO set snapshot.
+type UtxoSnapshotInfo struct {
+	// Hash and Height identify the block the UTXO set snapshot is as of.
+	Hash   chainhash.Hash
+	Height uint32
+
+	// NumUtxos is the number of unspent transaction outputs in the snapshot.
+	NumUtxos uint64
+
+	// IntegrityHash is the hash of the serialized snapshot which is used to
+	// detect corruption.
+	IntegrityHash chainhash.Hash
+}
+
+// utxoSnapshotWriter writes the fields of a UTXO set snapshot to an underlying
+// writer while hashing everything that is written.
+type utxoSnapshotWriter struct {
+	w      io.Writer
+	hasher hash.Hash
+	buf    [binary.MaxVarintLen64]byte
+}
+
+// Write writes the passed bytes to the underlying writer and hasher.
+//
+// This is part of the io.Writer interface.
+func (w *utxoSnapshotWriter) Write(b []byte) (int, error) {
+	w.hasher.Write(b)
+	return w.w.Write(b)
+}
+
+// writeVLQ writes the passed value as a VLQ.
+func (w *utxoSnapshotWriter) writeVLQ(n uint64) error {
+	size := putVLQ(w.buf[:], n)
+	_, err := w.Write(w.buf[:size])
+	return err
+}
+
+// writeRecord writes the passed bytes prefixed by their length as a VLQ.
+func (w *utxoSnapshotWriter) writeRecord(b []byte) error {
+	if err := w.writeVLQ(uint64(len(b))); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+// utxoSnapshotReader reads the fields of a UTXO set snapshot from an
+// underlying reader while hashing everything that is read.
+type utxoSnapshotReader struct {
+	r      *bufio.Reader
+	hasher hash.Hash
+}
+
+// Read reads up to len(b) bytes from the underlying reader and adds them to the
+// hasher.
+//
+// This is part of the io.Reader interface.
+func (r *utxoSnapshotReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	r.hasher.Write(b[:n])
+	return n, err
+}
+
+// ReadByte reads a single byte from the underlying reader and adds it to the
+// hasher.
+//
+// This is part of the io.ByteReader interface.
+func (r *utxoSnapshotReader) ReadByte() (byte, error) {
+	b, err := r.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	r.hasher.Write([]byte{b})
+	return b, nil
+}
+
+// readVLQ reads a VLQ as serialized by putVLQ.
+func (r *utxoSnapshotReader) readVLQ() (uint64, error) {
+	var n uint64
+	for i := 0; ; i++ {
+		// A uint64 never requires more than 10 bytes.
+		if i >= 10 {
+			return 0, errDeserialize("VLQ exceeds maximum size")
+		}
+
+		val, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		n = (n << 7) | uint64(val&0x7f)
+		if val&0x80 != 0x80 {
+			break
+		}
+		n++
+	}
+	return n, nil
+}
+
+// readRecord reads bytes prefixed by their length as a VLQ.  A nil slice is
+// returned when the length is zero.
+func (r *utxoSnapshotReader) readRecord() ([]byte, error) {
+	recordLen, err := r.readVLQ()
+	if err != nil {
+		return nil, err
+	}
+	if recordLen == 0 {
+		return nil, nil
+	}
+	if recordLen > maxUtxoSnapshotRecordLen {
+		str := fmt.Sprintf("record length %d exceeds the maximum allowed "+
+			"length of %d", recordLen, maxUtxoSnapshotRecordLen)
+		return nil, errDeserialize(str)
+	}
+	record := make([]byte, recordLen)
+	if _, err := io.ReadFull(r, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// ExportUtxoSnapshot writes a snapshot of the full UTXO set as of the current
+// tip of the main chain to the passed writer.  The format is described in
+// detail above.
+//
+// The chain is locked while the snapshot is written, so no blocks will be
+// connected or disconnected until it returns.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) ExportUtxoSnapshot(ctx context.Context, w io.Writer) (*UtxoSnapshotInfo, error) {
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+
+	// Flush the UTXO cache so the backend contains the full UTXO set as of the
+	// current tip.
+	tip := b.bestChain.Tip()
+	err := b.utxoCache.MaybeFlush(&tip.hash, uint32(tip.height), true, false)
+	if err != nil {
+		return nil, err
+	}
+	utxoDbInfo, err := b.utxoBackend.FetchInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	// Write the header.
+	bw := bufio.NewWriter(w)
+	sw := &utxoSnapshotWriter{w: bw, hasher: blake256.New()}
+	var header [4 + 4 + 4 + 4 + chainhash.HashSize + 4]byte
+	offset := copy(header[:], utxoSnapshotMagic[:])
+	binary.LittleEndian.PutUint32(header[offset:], utxoSnapshotVersion)
+	offset += 4
+	binary.LittleEndian.PutUint32(header[offset:], uint32(b.chainParams.Net))
+	offset += 4
+	binary.LittleEndian.PutUint32(header[offset:], utxoDbInfo.utxoVer)
+	offset += 4
+	copy(header[offset:], tip.hash[:])
+	offset += chainhash.HashSize
+	binary.LittleEndian.PutUint32(header[offset:], uint32(tip.height))
+	if _, err := sw.Write(header[:]); err != nil {
+		return nil, err
+	}
+
+	// Write all of the utxos in the backend.
+	var numUtxos uint64
+	iter := b.utxoBackend.NewIterator(utxoPrefixUtxoSet)
+	defer iter.Release()
+	for iter.Next() {
+		if numUtxos%utxoSnapshotImportBatchSize == 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+		}
+
+		key, entry := iter.Key(), iter.Value()
+		if len(key) <= utxoSetDbPrefixSize || len(entry) == 0 {
+			str := fmt.Sprintf("corrupt utxo set entry for key %x", key)
+			return nil, contextError(ErrUtxoBackendCorruption, str)
+		}
+		if err := sw.writeRecord(key[utxoSetDbPrefixSize:]); err != nil {
+			return nil, err
+		}
+		if err := sw.writeRecord(entry); err != nil {
+			return nil, err
+		}
+		numUtxos++
+	}
+	if err := iter.Error(); err != nil {
+		return nil, convertLdbErr(err, "failed to iterate utxo set")
+	}
+
+	// Write the terminator and number of utxos followed by the integrity hash
+	// of everything written.
+	if err := sw.writeVLQ(0); err != nil {
+		return nil, err
+	}
+	var numUtxosBytes [8]byte
+	binary.LittleEndian.PutUint64(numUtxosBytes[:], numUtxos)
+	if _, err := sw.Write(numUtxosBytes[:]); err != nil {
+		return nil, err
+	}
+	var integrityHash chainhash.Hash
+	copy(integrityHash[:], sw.hasher.Sum(nil))
+	if _, err := bw.Write(integrityHash[:]); err != nil {
+		return nil, err
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, err
+	}
+
+	return &UtxoSnapshotInfo{
+		Hash:          tip.hash,
+		Height:        uint32(tip.height),
+		NumUtxos:      numUtxos,
+		IntegrityHash: integrityHash,
+	}, nil
+}
+
+// invalidUtxoSnapshotError returns a context error with the kind
+// ErrInvalidUtxoSnapshot and a description that includes the provided details.
+func invalidUtxoSnapshotError(format string, args ...interface{}) error {
+	str := "invalid utxo snapshot: " + fmt.Sprintf(format, args...)
+	return contextError(ErrInvalidUtxoSnapshot, str)
+}
+
+// clearUtxoSet removes all utxos from the passed UTXO backend.
+func clearUtxoSet(backend UtxoBackend) error {
+	for {
+		var keys [][]byte
+		iter := backend.NewIterator(utxoPrefixUtxoSet)
+		for iter.Next() && len(keys) < utxoSnapshotImportBatchSize {
+			keys = append(keys, append([]byte(nil), iter.Key()...))
+		}
+		err := iter.Error()
+		iter.Release()
+		if err != nil {
+			return convertLdbErr(err, "failed to iterate utxo set")
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+
+		err = backend.Update(func(tx UtxoBackendTx) error {
+			for _, key := range keys {
+				if err := tx.Delete(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// dbCheckUtxoSnapshotBlock ensures the block with the provided hash and height
+// is part of the main chain according to the best chain state and block index
+// in the block database.  Only the headers in the block index are consulted, so
+// the block data is not required to be available.
+//
+// An error that wraps ErrUnknownBlock is returned when the block is not part of
+// the main chain.
+func dbCheckUtxoSnapshotBlock(dbTx database.Tx, hash *chainhash.Hash, height uint32) error {
+	if dbTx.Metadata().Get(chainStateKeyName) == nil {
+		str := fmt.Sprintf("snapshot block %s (height %d) is not known since "+
+			"the block database does not contain a chain", hash, height)
+		return contextError(ErrUnknownBlock, str)
+	}
+	state, err := dbFetchBestState(dbTx)
+	if err != nil {
+		return err
+	}
+	if state.height < height {
+		str := fmt.Sprintf("snapshot block %s (height %d) is after the "+
+			"current best block %s (height %d)", hash, height, &state.hash,
+			state.height)
+		return contextError(ErrUnknownBlock, str)
+	}
+
+	// Walk the block index backwards from the best block to the height of the
+	// snapshot block.
+	bucket := dbTx.Metadata().Bucket(blockIndexBucketName)
+	ancestor, ancestorHeight := state.hash, state.height
+	for ancestorHeight > height {
+		serialized := bucket.Get(blockIndexKey(&ancestor, ancestorHeight))
+		if serialized == nil {
+			str := fmt.Sprintf("block index entry for block %s (height %d) "+
+				"does not exist", &ancestor, ancestorHeight)
+			return contextError(ErrUnknownBlock, str)
+		}
+		entry, err := deserializeBlockIndexEntry(serialized)
+		if err != nil {
+			return err
+		}
+		ancestor = entry.header.PrevBlock
+		ancestorHeight--
+	}
+	if ancestor != *hash {
+		str := fmt.Sprintf("snapshot block %s (height %d) is not in the main "+
+			"chain", hash, height)
+		return contextError(ErrUnknownBlock, str)
+	}
+	return nil
+}
+
+// ImportUtxoSnapshot reads a UTXO set snapshot as written by
+// ExportUtxoSnapshot from the passed reader and loads it into the passed UTXO
+// backend, which must not contain a UTXO set.
+//
+// Since the chain is initialized from the UTXO set state as of the block in the
+// snapshot, the block must be part of the main chain in the passed block
+// database.  Only its header is required since the block data is never needed
+// to initialize from it.  The chain will then connect any blocks after it up to
+// the current tip of the main chain when it is created.
+//
+// Note that the snapshot block must not be after the current best block in the
+// block database because the chain also relies on the stake and treasury
+// state as of its best block, which can't be derived from the UTXO set.
+//
+// The UTXO set state is only written once the entire snapshot has been read
+// and its integrity hash has been verified.  Any utxos already written to the
+// backend are removed when the snapshot is rejected.
+//
+// An error that wraps ErrInvalidUtxoSnapshot is returned when the snapshot is
+// malformed, corrupt, or for a different network or UTXO set version and an
+// error that wraps ErrUtxoBackendNotEmpty is returned when the backend already
+// contains a UTXO set.
+func ImportUtxoSnapshot(ctx context.Context, r io.Reader, params *chaincfg.Params, db database.DB, backend UtxoBackend) (*UtxoSnapshotInfo, error) {
+	// Ensure the backend does not already contain a UTXO set.
+	state, err := backend.FetchState()
+	if err != nil {
+		return nil, err
+	}
+	iter := backend.NewIterator(utxoPrefixUtxoSet)
+	haveUtxos := iter.Next()
+	err = iter.Error()
+	iter.Release()
+	if err != nil {
+		return nil, convertLdbErr(err, "failed to iterate utxo set")
+	}
+	if state != nil || haveUtxos {
+		str := "unable to import a utxo snapshot into a utxo database that " +
+			"already contains a utxo set"
+		return nil, contextError(ErrUtxoBackendNotEmpty, str)
+	}
+
+	// Initialize the backend info as needed and ensure the backend uses the
+	// current UTXO set version.
+	if err := backend.InitInfo(currentDatabaseVersion); err != nil {
+		return nil, err
+	}
+	utxoDbInfo, err := backend.FetchInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	// Read and validate the header.
+	sr := &utxoSnapshotReader{r: bufio.NewReader(r), hasher: blake256.New()}
+	var header [4 + 4 + 4 + 4 + chainhash.HashSize + 4]byte
+	if _, err := io.ReadFull(sr, header[:]); err != nil {
+		return nil, invalidUtxoSnapshotError("unable to read header: %v", err)
+	}
+	if !bytes.Equal(header[:4], utxoSnapshotMagic[:]) {
+		return nil, invalidUtxoSnapshotError("unrecognized magic %x",
+			header[:4])
+	}
+	offset := 4
+	version := binary.LittleEndian.Uint32(header[offset:])
+	offset += 4
+	if version != utxoSnapshotVersion {
+		return nil, invalidUtxoSnapshotError("unsupported version %d",
+			version)
+	}
+	net := wire.CurrencyNet(binary.LittleEndian.Uint32(header[offset:]))
+	offset += 4
+	if net != params.Net {
+		return nil, invalidUtxoSnapshotError("snapshot is for network %v "+
+			"instead of %v", net, params.Net)
+	}
+	utxoVer := binary.LittleEndian.Uint32(header[offset:])
+	offset += 4
+	if utxoVer != utxoDbInfo.utxoVer {
+		return nil, invalidUtxoSnapshotError("snapshot utxo set version %d "+
+			"does not match the utxo database version %d", utxoVer,
+			utxoDbInfo.utxoVer)
+	}
+	var info UtxoSnapshotInfo
+	copy(info.Hash[:], header[offset:offset+chainhash.HashSize])
+	offset += chainhash.HashSize
+	info.Height = binary.LittleEndian.Uint32(header[offset:])
+
+	// Ensure the block the snapshot is as of is part of the main chain in the
+	// block database since the chain is unable to initialize from it
+	// otherwise.  Only the header of the block is required to be known since
+	// the chain never needs the data for the block itself when catching the
+	// UTXO set up to the tip.
+	err = db.View(func(dbTx database.Tx) error {
+		return dbCheckUtxoSnapshotBlock(dbTx, &info.Hash, info.Height)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the utxos and write them to the backend in batches.  Remove any
+	// utxos that were written when the snapshot is rejected.
+	var success bool
+	defer func() {
+		if !success {
+			if err := clearUtxoSet(backend); err != nil {
+				log.Errorf("Failed to remove partially imported utxo set: %v",
+					err)
+			}
+		}
+	}()
+	type utxoRecord struct {
+		key, entry []byte
+	}
+	batch := make([]utxoRecord, 0, utxoSnapshotImportBatchSize)
+	writeBatch := func() error {
+		err := backend.Update(func(tx UtxoBackendTx) error {
+			for _, record := range batch {
+				err := tx.Put(prefixedKey(utxoPrefixUtxoSet, record.key),
+					record.entry)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		batch = batch[:0]
+		return err
+	}
+	for {
+		key, err := sr.readRecord()
+		if err != nil {
+			return nil, invalidUtxoSnapshotError("unable to read utxo %d: %v",
+				info.NumUtxos, err)
+		}
+		if key == nil {
+			break
+		}
+		entry, err := sr.readRecord()
+		if err != nil || entry == nil {
+			return nil, invalidUtxoSnapshotError("unable to read entry for "+
+				"utxo %d: %v", info.NumUtxos, err)
+		}
+
+		// Ensure the utxo is well formed.
+		var outpoint wire.OutPoint
+		prefixed := prefixedKey(utxoPrefixUtxoSet, key)
+		if err := decodeOutpointKey(prefixed, &outpoint); err != nil {
+			return nil, invalidUtxoSnapshotError("malformed key for utxo "+
+				"%d: %v", info.NumUtxos, err)
+		}
+		if _, err := deserializeUtxoEntry(entry, outpoint.Index); err != nil {
+			return nil, invalidUtxoSnapshotError("malformed entry for "+
+				"utxo %v: %v", outpoint, err)
+		}
+
+		batch = append(batch, utxoRecord{key: key, entry: entry})
+		info.NumUtxos++
+		if len(batch) == utxoSnapshotImportBatchSize {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+
+			if err := writeBatch(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(batch) > 0 {
+		if err := writeBatch(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Ensure the number of utxos and integrity hash match.
+	var numUtxosBytes [8]byte
+	if _, err := io.ReadFull(sr, numUtxosBytes[:]); err != nil {
+		return nil, invalidUtxoSnapshotError("unable to read number of "+
+			"utxos: %v", err)
+	}
+	if numUtxos := binary.LittleEndian.Uint64(numUtxosBytes[:]); numUtxos !=
+		info.NumUtxos {
+
+		return nil, invalidUtxoSnapshotError("snapshot claims %d utxos, "+
+			"but contains %d", numUtxos, info.NumUtxos)
+	}
+	copy(info.IntegrityHash[:], sr.hasher.Sum(nil))
+	var wantIntegrityHash chainhash.Hash
+	if _, err := io.ReadFull(sr.r, wantIntegrityHash[:]); err != nil {
+		return nil, invalidUtxoSnapshotError("unable to read integrity "+
+			"hash: %v", err)
+	}
+	if info.IntegrityHash != wantIntegrityHash {
+		return nil, invalidUtxoSnapshotError("integrity hash mismatch (got "+
+			"%v, want %v)", info.IntegrityHash, wantIntegrityHash)
+	}
+
+	// Mark the UTXO set as being as of the snapshot block now that it has been
+	// fully imported.
+	state = &UtxoSetState{
+		lastFlushHeight: info.Height,
+		lastFlushHash:   info.Hash,
+	}
+	if err := backend.PutUtxos(nil, state); err != nil {
+		return nil, err
+	}
+	success = true
+	return &info, nil
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/txscript/v4"
+)
+
+// TestUtxoSnapshot ensures exporting a UTXO set snapshot and importing it into
+// a fresh UTXO backend produces an identical UTXO set that a chain instance is
+// able to initialize from and catch up to the current tip.  It also ensures
+// invalid snapshots are rejected without leaving any utxos behind.
+func TestUtxoSnapshot(t *testing.T) {
+	ctx := context.Background()
+	params := chaincfg.RegNetParams()
+	g := newChaingenHarness(t, params)
+	g.AdvanceToStakeValidationHeight()
+
+	// Export a snapshot of the UTXO set as of the current tip and ensure the
+	// reported details match the chain.
+	var snapshot bytes.Buffer
+	info, err := g.chain.ExportUtxoSnapshot(ctx, &snapshot)
+	if err != nil {
+		t.Fatalf("unexpected error exporting utxo snapshot: %v", err)
+	}
+	snapshotStats, err := g.chain.FetchUtxoStats()
+	if err != nil {
+		t.Fatalf("unexpected error fetching utxo stats: %v", err)
+	}
+	best := g.chain.BestSnapshot()
+	if info.Hash != best.Hash || int64(info.Height) != best.Height {
+		t.Fatalf("mismatched snapshot block -- got %v (height %d), want %v "+
+			"(height %d)", info.Hash, info.Height, best.Hash, best.Height)
+	}
+	if info.NumUtxos != uint64(snapshotStats.Utxos) {
+		t.Fatalf("mismatched number of snapshot utxos -- got %d, want %d",
+			info.NumUtxos, snapshotStats.Utxos)
+	}
+
+	// Extend the chain beyond the snapshot so the chain instance created from
+	// the imported snapshot has to catch up.
+	for i := 0; i < 5; i++ {
+		outs := g.OldestCoinbaseOuts()
+		g.NextBlock(fmt.Sprintf("bsnap%d", i), nil, outs[1:])
+		g.SaveTipCoinbaseOuts()
+		g.AcceptTipBlock()
+	}
+	wantStats, err := g.chain.FetchUtxoStats()
+	if err != nil {
+		t.Fatalf("unexpected error fetching utxo stats: %v", err)
+	}
+
+	// Ensure snapshots with modifications, for another network, and for a
+	// block that is not part of the main chain are rejected and leave the UTXO
+	// backend empty.
+	corrupt := append([]byte(nil), snapshot.Bytes()...)
+	corrupt[len(corrupt)/2] ^= 0x01
+	const snapshotHashOffset = 16
+	const snapshotHeightOffset = snapshotHashOffset + chainhash.HashSize
+	sideChain := append([]byte(nil), snapshot.Bytes()...)
+	sideChain[snapshotHashOffset] ^= 0x01
+	future := append([]byte(nil), snapshot.Bytes()...)
+	binary.LittleEndian.PutUint32(future[snapshotHeightOffset:], 1<<31)
+	emptyDb, err := createTestDatabase(t, testDbType, blockDataNet)
+	if err != nil {
+		t.Fatalf("unexpected error creating block database: %v", err)
+	}
+	rejectTests := []struct {
+		name     string
+		snapshot []byte
+		params   *chaincfg.Params
+		unknown  bool
+		wantErr  error
+	}{{
+		name:     "corrupt snapshot",
+		snapshot: corrupt,
+		params:   params,
+		wantErr:  ErrInvalidUtxoSnapshot,
+	}, {
+		name:     "truncated snapshot",
+		snapshot: snapshot.Bytes()[:snapshot.Len()-1],
+		params:   params,
+		wantErr:  ErrInvalidUtxoSnapshot,
+	}, {
+		name:     "wrong network",
+		snapshot: snapshot.Bytes(),
+		params:   chaincfg.SimNetParams(),
+		wantErr:  ErrInvalidUtxoSnapshot,
+	}, {
+		name:     "unknown block",
+		snapshot: snapshot.Bytes(),
+		params:   params,
+		unknown:  true,
+		wantErr:  ErrUnknownBlock,
+	}, {
+		name:     "block not in main chain",
+		snapshot: sideChain,
+		params:   params,
+		wantErr:  ErrUnknownBlock,
+	}, {
+		name:     "block after best block",
+		snapshot: future,
+		params:   params,
+		wantErr:  ErrUnknownBlock,
+	}}
+	for _, test := range rejectTests {
+		db := g.chain.db
+		if test.unknown {
+			db = emptyDb
+		}
+		backend := createTestUtxoBackend(t)
+		_, err := ImportUtxoSnapshot(ctx, bytes.NewReader(test.snapshot),
+			test.params, db, backend)
+		if !errors.Is(err, test.wantErr) {
+			t.Fatalf("%q: mismatched error -- got %v, want %v", test.name,
+				err, test.wantErr)
+		}
+		state, err := backend.FetchState()
+		if err != nil {
+			t.Fatalf("%q: unexpected error fetching state: %v", test.name, err)
+		}
+		stats, err := backend.FetchStats()
+		if err != nil {
+			t.Fatalf("%q: unexpected error fetching stats: %v", test.name, err)
+		}
+		if state != nil || stats.Utxos != 0 {
+			t.Fatalf("%q: utxo backend not empty after rejected import",
+				test.name)
+		}
+	}
+
+	// Import the snapshot into a fresh UTXO backend and ensure the imported
+	// UTXO set is identical to the exported one.
+	backend := createTestUtxoBackend(t)
+	importInfo, err := ImportUtxoSnapshot(ctx, bytes.NewReader(snapshot.Bytes()),
+		params, g.chain.db, backend)
+	if err != nil {
+		t.Fatalf("unexpected error importing utxo snapshot: %v", err)
+	}
+	if *importInfo != *info {
+		t.Fatalf("mismatched imported snapshot info -- got %+v, want %+v",
+			importInfo, info)
+	}
+	importedStats, err := backend.FetchStats()
+	if err != nil {
+		t.Fatalf("unexpected error fetching imported utxo stats: %v", err)
+	}
+	if *importedStats != *snapshotStats {
+		t.Fatalf("mismatched imported utxo stats -- got %+v, want %+v",
+			importedStats, snapshotStats)
+	}
+
+	// Ensure importing into a backend that already contains a UTXO set is
+	// rejected.
+	_, err = ImportUtxoSnapshot(ctx, bytes.NewReader(snapshot.Bytes()), params,
+		g.chain.db, backend)
+	if !errors.Is(err, ErrUtxoBackendNotEmpty) {
+		t.Fatalf("mismatched error -- got %v, want %v", err,
+			ErrUtxoBackendNotEmpty)
+	}
+
+	// Ensure a chain instance initialized from the imported UTXO set catches up
+	// to the current tip and ends up with the same UTXO set.
+	sigCache, err := txscript.NewSigCache(1000)
+	if err != nil {
+		t.Fatalf("unexpected error creating sig cache: %v", err)
+	}
+	chain, err := New(ctx, &Config{
+		DB:          g.chain.db,
+		UtxoBackend: backend,
+		ChainParams: g.chain.chainParams,
+		TimeSource:  NewMedianTime(),
+		SigCache:    sigCache,
+		UtxoCache: NewUtxoCache(&UtxoCacheConfig{
+			Backend:      backend,
+			FlushBlockDB: func() error { return nil },
+			MaxSize:      100 * 1024 * 1024, // 100 MiB
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating chain from imported utxo set: %v",
+			err)
+	}
+	gotStats, err := chain.FetchUtxoStats()
+	if err != nil {
+		t.Fatalf("unexpected error fetching utxo stats: %v", err)
+	}
+	if *gotStats != *wantStats {
+		t.Fatalf("mismatched utxo stats after catching up -- got %+v, want "+
+			"%+v", gotStats, wantStats)
+	}
+}
@@ -724,14 +724,14 @@ func (view *UtxoViewpoint) fetchRegularInputUtxos(block *dcrutil.Block,
 	return view.fetchUtxosMain(filteredSet)
 }
 
-// fetchInputUtxos loads the unspent transaction outputs for the inputs
-// referenced by the transactions in both the regular and stake trees of the
-// given block into the view from the database as needed.  In the case of the
-// regular tree, referenced entries that are earlier in the regular tree of the
-// block are added to the view.  In all cases, entries that are already in the
-// view are not modified.
-func (view *UtxoViewpoint) fetchInputUtxos(block *dcrutil.Block,
-	isTreasuryEnabled bool) error {
+// addInputUtxos adds any outputs of transactions in the regular tree of the
+// provided block that are referenced by inputs of transactions that are located
+// later in the regular tree of the block and returns a set of the outputs
+// referenced by the inputs of the transactions in both the regular and stake
+// trees of the block that are not already in the view and thus need to be
+// fetched from the database.
+func (view *UtxoViewpoint) addInputUtxos(block *dcrutil.Block,
+	isTreasuryEnabled bool) ViewFilteredSet {
 
 	// Add any outputs of transactions in the regular tree of the block that are
 	// referenced by inputs of transactions that are located later in the tree
@@ -760,7 +760,20 @@ func (view *UtxoViewpoint) fetchInputUtxos(block *dcrutil.Block,
 		}
 	}
 
-	// Request the input utxos from the database.
+	return filteredSet
+}
+
+// fetchInputUtxos loads the unspent transaction outputs for the inputs
+// referenced by the transactions in both the regular and stake trees of the
+// given block into the view from the database as needed.  In the case of the
+// regular tree, referenced entries that are earlier in the regular tree of the
+// block are added to the view.  In all cases, entries that are already in the
+// view are not modified.
+func (view *UtxoViewpoint) fetchInputUtxos(block *dcrutil.Block,
+	isTreasuryEnabled bool) error {
+
+	// Request the input utxos that are not already known from the database.
+	filteredSet := view.addInputUtxos(block, isTreasuryEnabled)
 	return view.fetchUtxosMain(filteredSet)
 }
 
@@ -879,3 +892,57 @@ func (b *BlockChain) FetchUtxoView(tx *dcrutil.Tx, includeRegularTxns bool) (*Ut
 	err = view.fetchUtxosMain(filteredSet)
 	return view, err
 }
+
+// FetchUtxoViewForBlock loads the unspent transaction outputs for the inputs
+// referenced by the transactions in both the regular and stake trees of the
+// passed block from the point of view of the main chain tip.  All of the
+// referenced outputs that are not already cached are loaded from the database
+// in a single batch.  Outputs of transactions in the regular tree of the block
+// that are referenced by later transactions in the regular tree are added to
+// the view directly.
+//
+// This function is safe for concurrent access however the returned view is NOT.
+func (b *BlockChain) FetchUtxoViewForBlock(block *dcrutil.Block) (*UtxoViewpoint, error) {
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+
+	tip := b.bestChain.Tip()
+	view := NewUtxoViewpoint(b.utxoCache)
+	view.SetBestHash(&tip.hash)
+
+	// Determine if the treasury agenda is active as of the block after the
+	// current tip.
+	isTreasuryEnabled, err := b.isTreasuryAgendaActive(tip)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := view.fetchInputUtxos(block, isTreasuryEnabled); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// prefetchInputUtxos asynchronously loads the unspent transaction outputs for
+// the inputs referenced by the transactions in the passed block into the utxo
+// cache so they are readily available once the block is validated.
+func (b *BlockChain) prefetchInputUtxos(block *dcrutil.Block, isTreasuryEnabled bool) {
+	view := NewUtxoViewpoint(b.utxoCache)
+	filteredSet := view.addInputUtxos(block, isTreasuryEnabled)
+	if len(filteredSet) == 0 {
+		return
+	}
+	outpoints := make([]wire.OutPoint, 0, len(filteredSet))
+	for outpoint := range filteredSet {
+		outpoints = append(outpoints, outpoint)
+	}
+
+	b.prefetchWg.Add(1)
+	go func() {
+		defer b.prefetchWg.Done()
+		if err := b.utxoCache.PrefetchEntries(outpoints); err != nil {
+			log.Debugf("Failed to prefetch utxos for block %v: %v",
+				block.Hash(), err)
+		}
+	}()
+}
@@ -3938,7 +3938,7 @@ func (b *BlockChain) checkConnectBlock(node *blockNode, block, parent *dcrutil.B
 
 	if runScripts {
 		err = checkBlockScripts(block, view, false, scriptFlags,
-			b.sigCache, isAutoRevocationsEnabled)
+			b.sigCache, b.scriptCache, isAutoRevocationsEnabled)
 		if err != nil {
 			log.Tracef("checkBlockScripts failed; error returned "+
 				"on txtreestake of cur block: %v", err)
@@ -4000,10 +4000,9 @@ func (b *BlockChain) checkConnectBlock(node *blockNode, block, parent *dcrutil.B
 	if err != nil {
 		return ruleError(ErrMissingTxOut, err.Error())
 	}
-	filterHash := filter.Hash()
+	cmtData := headerCommitmentData{filter: filter, filterHash: filter.Hash()}
 	if hdrCommitments != nil {
-		hdrCommitments.filter = filter
-		hdrCommitments.filterHash = filterHash
+		*hdrCommitments = cmtData
 	}
 
 	// The calculated commitment root must match the associated entry in the
@@ -4012,12 +4011,12 @@ func (b *BlockChain) checkConnectBlock(node *blockNode, block, parent *dcrutil.B
 	// The header commitments agenda combines the existing stake tree merkle
 	// root header field with the regular merkle root field and repurposes the
 	// stake root field to house the commitment root instead.
-	hdrCommitmentsActive, err := b.isHeaderCommitmentsAgendaActive(node.parent)
+	hdrCommitmentVersion, err := b.headerCommitmentVersion(node.parent)
 	if err != nil {
 		return err
 	}
-	if hdrCommitmentsActive {
-		wantCommitmentRoot := CalcCommitmentRootV1(filterHash)
+	if hdrCommitmentVersion != HeaderCmtVersionNone {
+		wantCommitmentRoot := calcCommitmentRoot(hdrCommitmentVersion, &cmtData)
 		header := &block.MsgBlock().Header
 		if header.StakeRoot != wantCommitmentRoot {
 			str := fmt.Sprintf("block commitment root is invalid - block "+
@@ -4029,7 +4028,7 @@ func (b *BlockChain) checkConnectBlock(node *blockNode, block, parent *dcrutil.B
 
 	if runScripts {
 		err = checkBlockScripts(block, view, true, scriptFlags,
-			b.sigCache, isAutoRevocationsEnabled)
+			b.sigCache, b.scriptCache, isAutoRevocationsEnabled)
 		if err != nil {
 			log.Tracef("checkBlockScripts failed; error returned "+
 				"on txtreeregular of cur block: %v", err)
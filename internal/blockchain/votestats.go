@@ -0,0 +1,258 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"fmt"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+// BlockVoteStats houses information about how many of the votes that were
+// eligible to be included in a block were actually included versus missed.
+type BlockVoteStats struct {
+	Hash   chainhash.Hash
+	Height int64
+
+	// Eligible is the number of votes that were eligible to be included in
+	// the block.  It is zero for blocks prior to stake validation height
+	// since votes are not required for those blocks.
+	Eligible uint16
+
+	// Included is the number of votes that were included in the block.
+	Included uint16
+
+	// Missed is the number of eligible votes that were not included in the
+	// block.
+	Missed uint16
+}
+
+// VoteStats houses aggregated statistics about the votes included and missed
+// over a range of blocks in the main chain.
+type VoteStats struct {
+	StartHeight int64
+	EndHeight   int64
+
+	// Eligible, Included, and Missed are the total number of votes that were
+	// respectively eligible, included, and missed across all blocks in the
+	// range.
+	Eligible uint64
+	Included uint64
+	Missed   uint64
+
+	// VoteBlocks is the number of blocks in the range for which votes were
+	// eligible, which is to say blocks at or after stake validation height.
+	VoteBlocks int64
+
+	// VoteCounts is the number of blocks in the range that included each
+	// possible number of votes indexed by the number of votes.  It only
+	// accounts for blocks for which votes were eligible.
+	VoteCounts []int64
+
+	// Blocks houses the per-block statistics for every block in the range
+	// ordered by height.  It is only populated when requested.
+	Blocks []BlockVoteStats
+}
+
+// blockVoteStats returns the vote statistics for the passed block node.
+func (b *BlockChain) blockVoteStats(node *blockNode) BlockVoteStats {
+	stats := BlockVoteStats{
+		Hash:     node.hash,
+		Height:   node.height,
+		Included: node.voters,
+	}
+	if node.height >= b.chainParams.StakeValidationHeight {
+		stats.Eligible = b.chainParams.TicketsPerBlock
+		if stats.Included < stats.Eligible {
+			stats.Missed = stats.Eligible - stats.Included
+		}
+	}
+	return stats
+}
+
+// VoteStats returns statistics about the number of eligible votes that were
+// included versus missed in each block of the main chain within the provided
+// inclusive height range.  A negative end height signifies the current best
+// chain tip and the end height is limited to the current best chain tip.
+//
+// The per-block statistics are only included in the result when the
+// includeBlocks flag is set.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) VoteStats(startHeight, endHeight int64, includeBlocks bool) (*VoteStats, error) {
+	// Hold the chain view lock for the duration so the results are
+	// consistent with a single version of the main chain.
+	b.bestChain.mtx.Lock()
+	defer b.bestChain.mtx.Unlock()
+
+	tipHeight := b.bestChain.height()
+	if endHeight < 0 || endHeight > tipHeight {
+		endHeight = tipHeight
+	}
+	if startHeight < 0 || startHeight > endHeight {
+		str := fmt.Sprintf("start height %d is not in the range [0, %d]",
+			startHeight, endHeight)
+		return nil, contextError(ErrInvalidHeightRange, str)
+	}
+
+	stats := &VoteStats{
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+		VoteCounts:  make([]int64, b.chainParams.TicketsPerBlock+1),
+	}
+	if includeBlocks {
+		stats.Blocks = make([]BlockVoteStats, 0, endHeight-startHeight+1)
+	}
+	for height := startHeight; height <= endHeight; height++ {
+		blockStats := b.blockVoteStats(b.bestChain.nodeByHeight(height))
+		if includeBlocks {
+			stats.Blocks = append(stats.Blocks, blockStats)
+		}
+		if blockStats.Eligible == 0 {
+			continue
+		}
+
+		stats.Eligible += uint64(blockStats.Eligible)
+		stats.Included += uint64(blockStats.Included)
+		stats.Missed += uint64(blockStats.Missed)
+		stats.VoteBlocks++
+		if int(blockStats.Included) < len(stats.VoteCounts) {
+			stats.VoteCounts[blockStats.Included]++
+		}
+	}
+
+	return stats, nil
+}
+
+// IntervalAgendaVotes houses the vote tallies for an agenda over a rule change
+// activation interval.
+type IntervalAgendaVotes struct {
+	// Deployment is the consensus deployment that defines the agenda.
+	Deployment *chaincfg.ConsensusDeployment
+
+	// State is the threshold state of the agenda during the interval.  Note
+	// that the vote tallies only have an effect on the agenda when it is
+	// ThresholdStarted.
+	State ThresholdStateTuple
+
+	// Counts houses the tallies of the votes for the agenda with the vote
+	// version that were included in the interval.
+	Counts VoteCounts
+}
+
+// IntervalVoteInfo houses vote statistics for the agendas of a stake version
+// over a rule change activation interval in the main chain.
+type IntervalVoteInfo struct {
+	// StartHeight and EndHeight are the heights of the first and final blocks
+	// of the interval.
+	StartHeight int64
+	EndHeight   int64
+
+	// Hash and Height identify the most recent block of the interval that
+	// was counted.  It is the final block of the interval unless the interval
+	// is still in progress, in which case it is the current best chain tip.
+	Hash   chainhash.Hash
+	Height int64
+
+	// TotalVotes is the total number of votes included in the counted blocks
+	// of the interval regardless of their version while VersionVotes is the
+	// number of those votes with the vote version.
+	TotalVotes   uint32
+	VersionVotes uint32
+
+	// Agendas houses the vote tallies for each agenda of the vote version in
+	// the same order as the deployments of the version.
+	Agendas []IntervalAgendaVotes
+}
+
+// IntervalVoteInfo returns the vote tallies for the agendas of the provided
+// vote version over the rule change activation interval of the main chain
+// that contains the provided height along with the state of the agendas
+// during that interval.  A negative height signifies the interval that
+// contains the current best chain tip.
+//
+// An error with the kind ErrUnknownDeploymentVersion is returned when the vote
+// version does not have any deployments and an error with the kind
+// ErrInvalidHeightRange is returned when the height is after the current best
+// chain tip.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) IntervalVoteInfo(height int64, version uint32) (*IntervalVoteInfo, error) {
+	deployments, ok := b.chainParams.Deployments[version]
+	if !ok {
+		str := fmt.Sprintf("stake version %d does not exist", version)
+		return nil, contextError(ErrUnknownDeploymentVersion, str)
+	}
+
+	// Hold the chain lock for the duration so the results are consistent
+	// with a single version of the main chain.
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+
+	tip := b.bestChain.Tip()
+	if height < 0 {
+		height = tip.height
+	}
+	if height > tip.height {
+		str := fmt.Sprintf("height %d is not in the range [0, %d]", height,
+			tip.height)
+		return nil, contextError(ErrInvalidHeightRange, str)
+	}
+
+	// Determine the bounds of the interval that contains the height along
+	// with the most recent block of the interval to count.
+	svh := b.chainParams.StakeValidationHeight
+	rcai := int64(b.chainParams.RuleChangeActivationInterval)
+	startHeight := calcWantHeight(svh, rcai, height) + 1
+	endHeight := startHeight + rcai - 1
+	node := tip
+	if endHeight < tip.height {
+		node = tip.Ancestor(endHeight)
+	}
+
+	info := &IntervalVoteInfo{
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+		Hash:        node.hash,
+		Height:      node.height,
+		Agendas:     make([]IntervalAgendaVotes, 0, len(deployments)),
+	}
+	for n := node; n != nil && n.height >= startHeight; n = n.parent {
+		for _, vote := range n.votes {
+			info.TotalVotes++
+			if vote.Version == version {
+				info.VersionVotes++
+			}
+		}
+	}
+
+	// The state of the agendas during the interval is the state for the
+	// block after the final block of the previous interval.
+	prevNode := node.Ancestor(startHeight - 1)
+	for k := range deployments {
+		deployment := &deployments[k]
+		state := ThresholdStateTuple{
+			State:  ThresholdDefined,
+			Choice: invalidChoice,
+		}
+		if prevNode != nil {
+			var err error
+			state, err = b.deploymentState(prevNode, version,
+				deployment.Vote.Id)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		info.Agendas = append(info.Agendas, IntervalAgendaVotes{
+			Deployment: deployment,
+			State:      state,
+			Counts:     b.getVoteCounts(node, version, deployment),
+		})
+	}
+
+	return info, nil
+}
@@ -0,0 +1,335 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+// TestVoteStats ensures the vote statistics for ranges of the main chain are
+// calculated as expected.
+func TestVoteStats(t *testing.T) {
+	params := chaincfg.SimNetParams()
+	svh := params.StakeValidationHeight
+	ticketsPerBlock := params.TicketsPerBlock
+
+	// Generate a chain that extends a few blocks past stake validation height
+	// where the blocks starting at stake validation height have the
+	// following number of votes.
+	voters := []uint16{ticketsPerBlock, ticketsPerBlock - 1, ticketsPerBlock,
+		ticketsPerBlock - 2}
+	bc := newFakeChain(params)
+	node := bc.bestChain.Tip()
+	for i := int64(1); i < svh+int64(len(voters)); i++ {
+		node = newFakeNode(node, 0, 0, 0, time.Now())
+		if i >= svh {
+			node.voters = voters[i-svh]
+		}
+		bc.bestChain.SetTip(node)
+	}
+	tipHeight := node.height
+
+	tests := []struct {
+		name          string
+		start, end    int64
+		wantStart     int64
+		wantEnd       int64
+		wantEligible  uint64
+		wantIncluded  uint64
+		wantMissed    uint64
+		wantVoteBlks  int64
+		wantFullVotes int64
+	}{{
+		name:      "prior to stake validation height",
+		start:     0,
+		end:       svh - 1,
+		wantStart: 0,
+		wantEnd:   svh - 1,
+	}, {
+		name:          "entire chain via negative end height",
+		start:         0,
+		end:           -1,
+		wantStart:     0,
+		wantEnd:       tipHeight,
+		wantEligible:  uint64(ticketsPerBlock) * 4,
+		wantIncluded:  uint64(ticketsPerBlock)*4 - 3,
+		wantMissed:    3,
+		wantVoteBlks:  4,
+		wantFullVotes: 2,
+	}, {
+		name:          "end height limited to tip",
+		start:         svh + 1,
+		end:           tipHeight + 100,
+		wantStart:     svh + 1,
+		wantEnd:       tipHeight,
+		wantEligible:  uint64(ticketsPerBlock) * 3,
+		wantIncluded:  uint64(ticketsPerBlock)*3 - 3,
+		wantMissed:    3,
+		wantVoteBlks:  3,
+		wantFullVotes: 1,
+	}, {
+		name:          "single block",
+		start:         svh + 1,
+		end:           svh + 1,
+		wantStart:     svh + 1,
+		wantEnd:       svh + 1,
+		wantEligible:  uint64(ticketsPerBlock),
+		wantIncluded:  uint64(ticketsPerBlock) - 1,
+		wantMissed:    1,
+		wantVoteBlks:  1,
+		wantFullVotes: 0,
+	}}
+	for _, test := range tests {
+		stats, err := bc.VoteStats(test.start, test.end, true)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", test.name, err)
+		}
+		if stats.StartHeight != test.wantStart || stats.EndHeight != test.wantEnd {
+			t.Fatalf("%q: unexpected range -- got [%d, %d], want [%d, %d]",
+				test.name, stats.StartHeight, stats.EndHeight, test.wantStart,
+				test.wantEnd)
+		}
+		if stats.Eligible != test.wantEligible ||
+			stats.Included != test.wantIncluded ||
+			stats.Missed != test.wantMissed {
+
+			t.Fatalf("%q: unexpected totals -- got eligible %d, included %d, "+
+				"missed %d, want eligible %d, included %d, missed %d",
+				test.name, stats.Eligible, stats.Included, stats.Missed,
+				test.wantEligible, test.wantIncluded, test.wantMissed)
+		}
+		if stats.VoteBlocks != test.wantVoteBlks {
+			t.Fatalf("%q: unexpected vote blocks -- got %d, want %d",
+				test.name, stats.VoteBlocks, test.wantVoteBlks)
+		}
+		if stats.VoteCounts[ticketsPerBlock] != test.wantFullVotes {
+			t.Fatalf("%q: unexpected full vote blocks -- got %d, want %d",
+				test.name, stats.VoteCounts[ticketsPerBlock],
+				test.wantFullVotes)
+		}
+
+		// Ensure the per-block stats cover the entire range and agree with
+		// the totals.
+		wantBlocks := test.wantEnd - test.wantStart + 1
+		if int64(len(stats.Blocks)) != wantBlocks {
+			t.Fatalf("%q: unexpected number of blocks -- got %d, want %d",
+				test.name, len(stats.Blocks), wantBlocks)
+		}
+		var missed uint64
+		for i, blk := range stats.Blocks {
+			if blk.Height != test.wantStart+int64(i) {
+				t.Fatalf("%q: unexpected block height -- got %d, want %d",
+					test.name, blk.Height, test.wantStart+int64(i))
+			}
+			if blk.Included+blk.Missed != blk.Eligible {
+				t.Fatalf("%q: inconsistent block stats %+v", test.name, blk)
+			}
+			missed += uint64(blk.Missed)
+		}
+		if missed != stats.Missed {
+			t.Fatalf("%q: per-block missed votes %d do not match total %d",
+				test.name, missed, stats.Missed)
+		}
+	}
+
+	// Ensure the per-block stats are omitted when not requested.
+	stats, err := bc.VoteStats(0, -1, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Blocks != nil {
+		t.Fatalf("unexpected per-block stats: %v", stats.Blocks)
+	}
+
+	// Ensure invalid ranges are rejected.
+	for _, r := range [][2]int64{{-1, 5}, {10, 5}, {tipHeight + 1, -1}} {
+		_, err := bc.VoteStats(r[0], r[1], false)
+		if !errors.Is(err, ErrInvalidHeightRange) {
+			t.Fatalf("range [%d, %d]: unexpected error -- got %v, want %v",
+				r[0], r[1], err, ErrInvalidHeightRange)
+		}
+	}
+}
+
+// TestIntervalVoteInfo ensures the vote tallies for the agendas of a stake
+// version over rule change activation intervals of the main chain are
+// calculated as expected.
+func TestIntervalVoteInfo(t *testing.T) {
+	// Clone the parameters so they can be mutated, find the deployment for
+	// the LN features agenda along with its choices, and remove its time
+	// constraints so it is always available to vote.
+	params := cloneParams(chaincfg.RegNetParams())
+	version, deployment, err := findDeployment(params,
+		chaincfg.VoteIDLNFeatures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	removeDeploymentTimeConstraints(deployment)
+	yesChoice, err := findDeploymentChoice(deployment, "yes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	noChoice, err := findDeploymentChoice(deployment, "no")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var agendaIdx, yesIdx, noIdx int
+	for i := range params.Deployments[version] {
+		if params.Deployments[version][i].Vote.Id == deployment.Vote.Id {
+			agendaIdx = i
+		}
+	}
+	for i := range deployment.Vote.Choices {
+		switch deployment.Vote.Choices[i].Id {
+		case yesChoice.Id:
+			yesIdx = i
+		case noChoice.Id:
+			noIdx = i
+		}
+	}
+	svh := params.StakeValidationHeight
+	rcai := int64(params.RuleChangeActivationInterval)
+	ticketsPerBlock := params.TicketsPerBlock
+
+	// Generate a chain that consists of an entire interval after stake
+	// validation height where every vote is yes followed by a few blocks of
+	// the next interval that each have three yes votes, a no vote, and a
+	// vote for a different version.
+	const numPartial = 10
+	bc := newFakeChain(params)
+	node := bc.bestChain.Tip()
+	curTimestamp := time.Now()
+	for i := int64(1); i < svh+rcai+numPartial; i++ {
+		node = newFakeNode(node, int32(version), version, 0, curTimestamp)
+		switch {
+		case i >= svh+rcai:
+			appendFakeVotes(node, ticketsPerBlock-2, version, yesChoice.Bits|0x01)
+			appendFakeVotes(node, 1, version, noChoice.Bits|0x01)
+			appendFakeVotes(node, 1, version+1, yesChoice.Bits|0x01)
+		case i >= svh:
+			appendFakeVotes(node, ticketsPerBlock, version, yesChoice.Bits|0x01)
+		}
+		bc.index.AddNode(node)
+		bc.bestChain.SetTip(node)
+		curTimestamp = curTimestamp.Add(time.Second)
+	}
+	tip := node
+
+	tests := []struct {
+		name         string
+		height       int64
+		wantStart    int64
+		wantNode     *blockNode
+		wantTotal    uint32
+		wantVersion  uint32
+		wantState    ThresholdState
+		wantYesVotes uint32
+		wantNoVotes  uint32
+	}{{
+		name:         "first interval start",
+		height:       svh,
+		wantStart:    svh,
+		wantNode:     tip.Ancestor(svh + rcai - 1),
+		wantTotal:    uint32(ticketsPerBlock) * uint32(rcai),
+		wantVersion:  uint32(ticketsPerBlock) * uint32(rcai),
+		wantState:    ThresholdDefined,
+		wantYesVotes: uint32(ticketsPerBlock) * uint32(rcai),
+	}, {
+		name:         "first interval end",
+		height:       svh + rcai - 1,
+		wantStart:    svh,
+		wantNode:     tip.Ancestor(svh + rcai - 1),
+		wantTotal:    uint32(ticketsPerBlock) * uint32(rcai),
+		wantVersion:  uint32(ticketsPerBlock) * uint32(rcai),
+		wantState:    ThresholdDefined,
+		wantYesVotes: uint32(ticketsPerBlock) * uint32(rcai),
+	}, {
+		name:         "in progress interval",
+		height:       svh + rcai + 1,
+		wantStart:    svh + rcai,
+		wantNode:     tip,
+		wantTotal:    uint32(ticketsPerBlock) * numPartial,
+		wantVersion:  uint32(ticketsPerBlock-1) * numPartial,
+		wantState:    ThresholdStarted,
+		wantYesVotes: uint32(ticketsPerBlock-2) * numPartial,
+		wantNoVotes:  numPartial,
+	}, {
+		name:         "negative height is current interval",
+		height:       -1,
+		wantStart:    svh + rcai,
+		wantNode:     tip,
+		wantTotal:    uint32(ticketsPerBlock) * numPartial,
+		wantVersion:  uint32(ticketsPerBlock-1) * numPartial,
+		wantState:    ThresholdStarted,
+		wantYesVotes: uint32(ticketsPerBlock-2) * numPartial,
+		wantNoVotes:  numPartial,
+	}}
+	for _, test := range tests {
+		info, err := bc.IntervalVoteInfo(test.height, version)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", test.name, err)
+		}
+		wantEnd := test.wantStart + rcai - 1
+		if info.StartHeight != test.wantStart || info.EndHeight != wantEnd {
+			t.Fatalf("%q: unexpected interval -- got [%d, %d], want [%d, %d]",
+				test.name, info.StartHeight, info.EndHeight, test.wantStart,
+				wantEnd)
+		}
+		if info.Height != test.wantNode.height ||
+			info.Hash != test.wantNode.hash {
+
+			t.Fatalf("%q: unexpected block -- got %s (%d), want %s (%d)",
+				test.name, info.Hash, info.Height, test.wantNode.hash,
+				test.wantNode.height)
+		}
+		if info.TotalVotes != test.wantTotal ||
+			info.VersionVotes != test.wantVersion {
+
+			t.Fatalf("%q: unexpected votes -- got total %d, version %d, "+
+				"want total %d, version %d", test.name, info.TotalVotes,
+				info.VersionVotes, test.wantTotal, test.wantVersion)
+		}
+		if len(info.Agendas) != len(params.Deployments[version]) {
+			t.Fatalf("%q: unexpected number of agendas -- got %d, want %d",
+				test.name, len(info.Agendas),
+				len(params.Deployments[version]))
+		}
+		agenda := info.Agendas[agendaIdx]
+		if agenda.Deployment.Vote.Id != deployment.Vote.Id {
+			t.Fatalf("%q: unexpected agenda -- got %s, want %s", test.name,
+				agenda.Deployment.Vote.Id, deployment.Vote.Id)
+		}
+		if agenda.State.State != test.wantState {
+			t.Fatalf("%q: unexpected state -- got %v, want %v", test.name,
+				agenda.State.State, test.wantState)
+		}
+		counts := agenda.Counts
+		if counts.Total != test.wantVersion ||
+			counts.VoteChoices[yesIdx] != test.wantYesVotes ||
+			counts.VoteChoices[noIdx] != test.wantNoVotes {
+
+			t.Fatalf("%q: unexpected counts -- got total %d, yes %d, no %d, "+
+				"want total %d, yes %d, no %d", test.name, counts.Total,
+				counts.VoteChoices[yesIdx], counts.VoteChoices[noIdx],
+				test.wantVersion, test.wantYesVotes, test.wantNoVotes)
+		}
+	}
+
+	// Ensure heights after the current tip and unknown versions are rejected.
+	_, err = bc.IntervalVoteInfo(tip.height+1, version)
+	if !errors.Is(err, ErrInvalidHeightRange) {
+		t.Fatalf("unexpected error -- got %v, want %v", err,
+			ErrInvalidHeightRange)
+	}
+	_, err = bc.IntervalVoteInfo(-1, 0xffffffff)
+	if !errors.Is(err, ErrUnknownDeploymentVersion) {
+		t.Fatalf("unexpected error -- got %v, want %v", err,
+			ErrUnknownDeploymentVersion)
+	}
+}
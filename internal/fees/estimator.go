@@ -159,6 +159,10 @@ type Estimator struct {
 	// memPoolTxs is the map of transaction hashes and data of known mempool txs.
 	memPoolTxs map[chainhash.Hash]memPoolTxDesc
 
+	// minFee is the minimum fee rate returned by fee estimates.  It starts
+	// out as the minimum bucket fee and may be updated at runtime.
+	minFee feeRate
+
 	maxConfirms int32
 	decay       float64
 	bestHeight  int64
@@ -213,6 +217,7 @@ func NewEstimator(cfg *EstimatorConfig) (*Estimator, error) {
 		maxConfirms:     int32(maxConfirms),
 		decay:           decay,
 		memPoolTxs:      make(map[chainhash.Hash]memPoolTxDesc),
+		minFee:          feeRate(cfg.MinBucketFee),
 		bestHeight:      -1,
 	}
 
@@ -721,6 +726,7 @@ func (stats *Estimator) estimateMedianFee(targetConfs int32, successPct float64)
 func (stats *Estimator) EstimateFee(targetConfs int32) (dcrutil.Amount, error) {
 	stats.lock.RLock()
 	rate, err := stats.estimateMedianFee(targetConfs, 0.95)
+	minFee := stats.minFee
 	stats.lock.RUnlock()
 
 	if err != nil {
@@ -728,15 +734,28 @@ func (stats *Estimator) EstimateFee(targetConfs int32) (dcrutil.Amount, error) {
 	}
 
 	rate = feeRate(math.Round(float64(rate)))
-	if rate < stats.bucketFeeBounds[0] {
+	if rate < minFee {
 		// Prevent our public facing api to ever return something lower than the
 		// minimum fee
-		rate = stats.bucketFeeBounds[0]
+		rate = minFee
 	}
 
 	return dcrutil.Amount(rate), nil
 }
 
+// SetMinFee updates the minimum fee rate returned by fee estimates.
+//
+// Note that the fee buckets are persisted to the database and therefore are
+// not changed, so fee rates below the minimum bucket fee the estimator was
+// created with continue to be tracked by the lowest bucket.
+//
+// This function is safe to be called from multiple goroutines.
+func (stats *Estimator) SetMinFee(fee dcrutil.Amount) {
+	stats.lock.Lock()
+	stats.minFee = feeRate(fee)
+	stats.lock.Unlock()
+}
+
 // Enable establishes the current best height of the blockchain after
 // initializing the chain. All new mempool transactions will be added at this
 // block height.
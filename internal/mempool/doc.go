@@ -56,12 +56,15 @@ be an exhaustive list.
   - Additional metadata tracking for each transaction
   - Manual control of transaction removal
   - Recursive removal of all dependent transactions
+  - Optional journal of recent transaction events (accepted, rejected, evicted,
+    and mined) for post-mortem analysis
 
 # Configurable Transaction Acceptance Policy
 
   - Option to accept or reject standard transactions
   - Option to accept or reject transactions based on priority calculations
   - Minimum fee threshold
+  - Fee rate discounts, surcharges, and exemptions per transaction type
   - Max signature operations per transaction
   - Max orphan transaction size
   - Max number of orphan transactions allowed
@@ -71,6 +74,7 @@ be an exhaustive list.
   - Timestamp when the transaction was added to the pool
   - Most recent block height when the transaction was added to the pool
   - The fee the transaction pays
+  - The minimum fee the transaction was required to pay
   - The starting priority for the transaction
 
 # Errors
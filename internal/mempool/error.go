@@ -91,6 +91,10 @@ const (
 
 	// ErrTSpendInvalidExpiry indicates a treasury spend expiry is invalid.
 	ErrTSpendInvalidExpiry = ErrorKind("ErrTSpendInvalidExpiry")
+
+	// ErrAcceptanceHook indicates a transaction was rejected by one of the
+	// registered acceptance hooks.
+	ErrAcceptanceHook = ErrorKind("ErrAcceptanceHook")
 )
 
 // Error satisfies the error interface and prints human-readable errors.
@@ -147,3 +151,121 @@ func wrapTxRuleError(kind ErrorKind, desc string, err error) error {
 
 	return txRuleError(kind, desc)
 }
+
+// RejectCode identifies a stable, machine-readable category for the reason a
+// transaction was rejected by the mempool.  Unlike error kinds, which are
+// fine-grained and may change as policy evolves, reject codes group rejections
+// into broad categories that are suitable for exposing to external callers
+// such as wallets.
+type RejectCode string
+
+// These constants define the possible reject codes.
+const (
+	// RejectInvalid indicates a transaction violates the consensus rules.
+	RejectInvalid = RejectCode("invalid")
+
+	// RejectDuplicate indicates a transaction, or one with the same effect,
+	// is already known either in the mempool or the main chain.
+	RejectDuplicate = RejectCode("duplicate")
+
+	// RejectDoubleSpend indicates a transaction spends outputs that are
+	// already spent by other transactions in the mempool.
+	RejectDoubleSpend = RejectCode("double-spend")
+
+	// RejectMissingInputs indicates a transaction references outputs that
+	// are unknown or already spent.
+	RejectMissingInputs = RejectCode("missing-inputs")
+
+	// RejectImmature indicates a transaction spends outputs that have not
+	// yet reached the required maturity.
+	RejectImmature = RejectCode("immature")
+
+	// RejectNonFinal indicates a transaction is not yet final due to its
+	// lock time or sequence locks.
+	RejectNonFinal = RejectCode("non-final")
+
+	// RejectExpired indicates a transaction is expired or otherwise too old
+	// to be accepted.
+	RejectExpired = RejectCode("expired")
+
+	// RejectNonStandard indicates a transaction is valid per consensus but
+	// does not conform to the standardness policy.
+	RejectNonStandard = RejectCode("nonstandard")
+
+	// RejectDust indicates a transaction has one or more dust outputs.
+	RejectDust = RejectCode("dust")
+
+	// RejectInsufficientFee indicates a transaction does not pay the minimum
+	// fee required by the active policy.
+	RejectInsufficientFee = RejectCode("insufficient-fee")
+
+	// RejectHighFee indicates a transaction pays a fee above the maximum
+	// allowed by the active policy.
+	RejectHighFee = RejectCode("high-fee")
+
+	// RejectPolicy indicates a transaction was rejected due to mempool
+	// policy limits such as the maximum number of votes or treasury spends
+	// or by a registered acceptance hook.
+	RejectPolicy = RejectCode("policy")
+)
+
+// rejectCodes maps each error kind to the reject code it belongs to.
+var rejectCodes = map[ErrorKind]RejectCode{
+	ErrInvalid:               RejectInvalid,
+	ErrOrphanPolicyViolation: RejectPolicy,
+	ErrMempoolDoubleSpend:    RejectDoubleSpend,
+	ErrAlreadyVoted:          RejectDoubleSpend,
+	ErrDuplicate:             RejectDuplicate,
+	ErrCoinbase:              RejectInvalid,
+	ErrTreasurybase:          RejectInvalid,
+	ErrExpired:               RejectExpired,
+	ErrNonStandard:           RejectNonStandard,
+	ErrDustOutput:            RejectDust,
+	ErrInsufficientFee:       RejectInsufficientFee,
+	ErrTooManyVotes:          RejectPolicy,
+	ErrDuplicateRevocation:   RejectDuplicate,
+	ErrOldVote:               RejectExpired,
+	ErrAlreadyExists:         RejectDuplicate,
+	ErrSeqLockUnmet:          RejectNonFinal,
+	ErrFeeTooHigh:            RejectHighFee,
+	ErrOrphan:                RejectMissingInputs,
+	ErrTooManyTSpends:        RejectPolicy,
+	ErrTSpendMinedOnAncestor: RejectDuplicate,
+	ErrTSpendInvalidExpiry:   RejectInvalid,
+	ErrAcceptanceHook:        RejectPolicy,
+}
+
+// chainRejectCodes maps the blockchain error kinds that warrant a more specific
+// reject code than RejectInvalid to their reject code.
+var chainRejectCodes = map[blockchain.ErrorKind]RejectCode{
+	blockchain.ErrMissingTxOut:  RejectMissingInputs,
+	blockchain.ErrImmatureSpend: RejectImmature,
+	blockchain.ErrUnfinalizedTx: RejectNonFinal,
+	blockchain.ErrExpiredTx:     RejectExpired,
+}
+
+// ErrorRejectCode returns the reject code for the passed error along with
+// whether or not it was able to determine one.  A reject code can only be
+// determined for RuleErrors.  Rule errors that encapsulate a blockchain rule
+// error without a more specific reject code are considered RejectInvalid.
+func ErrorRejectCode(err error) (RejectCode, bool) {
+	var rErr RuleError
+	if !errors.As(err, &rErr) {
+		return "", false
+	}
+
+	var kind ErrorKind
+	if errors.As(err, &kind) {
+		if code, ok := rejectCodes[kind]; ok {
+			return code, true
+		}
+		return RejectInvalid, true
+	}
+	var chainKind blockchain.ErrorKind
+	if errors.As(err, &chainKind) {
+		if code, ok := chainRejectCodes[chainKind]; ok {
+			return code, true
+		}
+	}
+	return RejectInvalid, true
+}
@@ -6,8 +6,11 @@ package mempool
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"testing"
+
+	"github.com/decred/dcrd/internal/blockchain"
 )
 
 // TestErrorKindStringer tests the stringized output for the ErrorKind type.
@@ -37,6 +40,7 @@ func TestErrorKindStringer(t *testing.T) {
 		{ErrTooManyTSpends, "ErrTooManyTSpends"},
 		{ErrTSpendMinedOnAncestor, "ErrTSpendMinedOnAncestor"},
 		{ErrTSpendInvalidExpiry, "ErrTSpendInvalidExpiry"},
+		{ErrAcceptanceHook, "ErrAcceptanceHook"},
 	}
 
 	t.Logf("Running %d tests", len(tests))
@@ -158,3 +162,91 @@ func TestErrorKindIsAs(t *testing.T) {
 		}
 	}
 }
+
+// TestErrorRejectCode ensures the reject codes determined for errors are the
+// expected values and that every error kind maps to a reject code.
+func TestErrorRejectCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode RejectCode
+		wantOk   bool
+	}{{
+		name:     "ErrInsufficientFee",
+		err:      txRuleError(ErrInsufficientFee, ""),
+		wantCode: RejectInsufficientFee,
+		wantOk:   true,
+	}, {
+		name:     "ErrOrphan",
+		err:      txRuleError(ErrOrphan, ""),
+		wantCode: RejectMissingInputs,
+		wantOk:   true,
+	}, {
+		name:     "ErrAlreadyExists",
+		err:      txRuleError(ErrAlreadyExists, ""),
+		wantCode: RejectDuplicate,
+		wantOk:   true,
+	}, {
+		name:     "wrapped ErrMempoolDoubleSpend",
+		err:      fmt.Errorf("rejected: %w", txRuleError(ErrMempoolDoubleSpend, "")),
+		wantCode: RejectDoubleSpend,
+		wantOk:   true,
+	}, {
+		name: "blockchain ErrMissingTxOut",
+		err: chainRuleError(blockchain.RuleError{
+			Err: blockchain.ErrMissingTxOut,
+		}),
+		wantCode: RejectMissingInputs,
+		wantOk:   true,
+	}, {
+		name: "blockchain ErrImmatureSpend",
+		err: chainRuleError(blockchain.RuleError{
+			Err: blockchain.ErrImmatureSpend,
+		}),
+		wantCode: RejectImmature,
+		wantOk:   true,
+	}, {
+		name: "other blockchain error",
+		err: chainRuleError(blockchain.RuleError{
+			Err: blockchain.ErrBadTxInput,
+		}),
+		wantCode: RejectInvalid,
+		wantOk:   true,
+	}, {
+		name:   "bare error kind",
+		err:    ErrDuplicate,
+		wantOk: false,
+	}, {
+		name:   "non-rule error",
+		err:    io.EOF,
+		wantOk: false,
+	}}
+
+	for _, test := range tests {
+		code, ok := ErrorRejectCode(test.err)
+		if ok != test.wantOk {
+			t.Errorf("%s: unexpected ok -- got %v, want %v", test.name, ok,
+				test.wantOk)
+			continue
+		}
+		if code != test.wantCode {
+			t.Errorf("%s: unexpected reject code -- got %q, want %q",
+				test.name, code, test.wantCode)
+			continue
+		}
+	}
+
+	// Ensure every error kind has an explicit reject code.
+	kinds := []ErrorKind{ErrInvalid, ErrOrphanPolicyViolation,
+		ErrMempoolDoubleSpend, ErrAlreadyVoted, ErrDuplicate, ErrCoinbase,
+		ErrTreasurybase, ErrExpired, ErrNonStandard, ErrDustOutput,
+		ErrInsufficientFee, ErrTooManyVotes, ErrDuplicateRevocation,
+		ErrOldVote, ErrAlreadyExists, ErrSeqLockUnmet, ErrFeeTooHigh, ErrOrphan,
+		ErrTooManyTSpends, ErrTSpendMinedOnAncestor, ErrTSpendInvalidExpiry,
+		ErrAcceptanceHook}
+	for _, kind := range kinds {
+		if _, ok := rejectCodes[kind]; !ok {
+			t.Errorf("%v: no reject code defined", kind)
+		}
+	}
+}
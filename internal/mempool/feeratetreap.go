@@ -0,0 +1,220 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mempool
+
+import (
+	"bytes"
+	"math/rand"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+// feeRateKey is the key used to order transactions in a fee rate treap.
+// Transactions are ordered by their fee rate with ties broken by their hash so
+// that every key is unique and the ordering is deterministic.
+type feeRateKey struct {
+	feePerKB float64
+	hash     chainhash.Hash
+}
+
+// less returns whether the key sorts before the provided key.
+func (k *feeRateKey) less(other *feeRateKey) bool {
+	if k.feePerKB != other.feePerKB {
+		return k.feePerKB < other.feePerKB
+	}
+	return bytes.Compare(k.hash[:], other.hash[:]) < 0
+}
+
+// calcTxFeePerKB returns the fee rate in atoms per kilobyte for the provided
+// transaction descriptor.
+func calcTxFeePerKB(txDesc *TxDesc) float64 {
+	if txDesc.TxSize <= 0 {
+		return 0
+	}
+	return float64(txDesc.Fee) * 1000 / float64(txDesc.TxSize)
+}
+
+// feeRateTreapNode represents a node in a fee rate treap.
+type feeRateTreapNode struct {
+	key      feeRateKey
+	txDesc   *TxDesc
+	priority int
+	left     *feeRateTreapNode
+	right    *feeRateTreapNode
+}
+
+// feeRateTreap provides a treap of transaction descriptors ordered by their
+// fee rate, which allows insertion, removal, and ordered iteration starting
+// from either the lowest or highest fee rate in logarithmic time.
+//
+// A treap is a form of binary search tree that maintains balance by assigning a
+// random priority to each node and ensuring the priority of every node is
+// greater than or equal to that of its children (max heap property).
+//
+// The treap is not safe for concurrent access.
+type feeRateTreap struct {
+	root  *feeRateTreapNode
+	count int
+}
+
+// newFeeRateTreap returns a new empty fee rate treap.
+func newFeeRateTreap() *feeRateTreap {
+	return &feeRateTreap{}
+}
+
+// Len returns the number of transactions in the treap.
+func (t *feeRateTreap) Len() int {
+	return t.count
+}
+
+// treapRotateRight performs a right rotation of the subtree rooted at the
+// provided node and returns the new root of the subtree.
+func treapRotateRight(node *feeRateTreapNode) *feeRateTreapNode {
+	left := node.left
+	node.left = left.right
+	left.right = node
+	return left
+}
+
+// treapRotateLeft performs a left rotation of the subtree rooted at the
+// provided node and returns the new root of the subtree.
+func treapRotateLeft(node *feeRateTreapNode) *feeRateTreapNode {
+	right := node.right
+	node.right = right.left
+	right.left = node
+	return right
+}
+
+// treapInsert inserts the provided node into the subtree rooted at the given
+// node and returns the new root of the subtree.  It returns whether or not a
+// new node was added as opposed to an existing node being replaced.
+func treapInsert(root, node *feeRateTreapNode) (*feeRateTreapNode, bool) {
+	if root == nil {
+		return node, true
+	}
+
+	var added bool
+	switch {
+	case node.key.less(&root.key):
+		root.left, added = treapInsert(root.left, node)
+		if root.left.priority > root.priority {
+			root = treapRotateRight(root)
+		}
+	case root.key.less(&node.key):
+		root.right, added = treapInsert(root.right, node)
+		if root.right.priority > root.priority {
+			root = treapRotateLeft(root)
+		}
+	default:
+		root.txDesc = node.txDesc
+	}
+	return root, added
+}
+
+// treapRemove removes the node with the provided key from the subtree rooted
+// at the given node and returns the new root of the subtree.  It returns
+// whether or not a node was removed.
+func treapRemove(root *feeRateTreapNode, key *feeRateKey) (*feeRateTreapNode, bool) {
+	if root == nil {
+		return nil, false
+	}
+
+	var removed bool
+	switch {
+	case key.less(&root.key):
+		root.left, removed = treapRemove(root.left, key)
+	case root.key.less(key):
+		root.right, removed = treapRemove(root.right, key)
+	default:
+		// Rotate the node to remove down until it has at most one child
+		// while maintaining the heap property and then splice it out.
+		switch {
+		case root.left == nil:
+			return root.right, true
+		case root.right == nil:
+			return root.left, true
+		case root.left.priority > root.right.priority:
+			root = treapRotateRight(root)
+			root.right, removed = treapRemove(root.right, key)
+		default:
+			root = treapRotateLeft(root)
+			root.left, removed = treapRemove(root.left, key)
+		}
+	}
+	return root, removed
+}
+
+// Put inserts the provided transaction descriptor into the treap keyed by its
+// fee rate.  It replaces the existing entry when the transaction is already in
+// the treap with the same fee rate.
+func (t *feeRateTreap) Put(txDesc *TxDesc) {
+	node := &feeRateTreapNode{
+		key: feeRateKey{
+			feePerKB: calcTxFeePerKB(txDesc),
+			hash:     *txDesc.Tx.Hash(),
+		},
+		txDesc:   txDesc,
+		priority: rand.Int(),
+	}
+	var added bool
+	t.root, added = treapInsert(t.root, node)
+	if added {
+		t.count++
+	}
+}
+
+// Delete removes the provided transaction descriptor from the treap.  It has
+// no effect when the transaction is not in the treap.
+func (t *feeRateTreap) Delete(txDesc *TxDesc) {
+	key := feeRateKey{
+		feePerKB: calcTxFeePerKB(txDesc),
+		hash:     *txDesc.Tx.Hash(),
+	}
+	var removed bool
+	t.root, removed = treapRemove(t.root, &key)
+	if removed {
+		t.count--
+	}
+}
+
+// ForEachAscending invokes the provided function with each transaction
+// descriptor in the treap in order of increasing fee rate until either all
+// entries have been visited or the function returns false.
+func (t *feeRateTreap) ForEachAscending(f func(txDesc *TxDesc) bool) {
+	var stack []*feeRateTreapNode
+	node := t.root
+	for node != nil || len(stack) > 0 {
+		for node != nil {
+			stack = append(stack, node)
+			node = node.left
+		}
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !f(node.txDesc) {
+			return
+		}
+		node = node.right
+	}
+}
+
+// ForEachDescending invokes the provided function with each transaction
+// descriptor in the treap in order of decreasing fee rate until either all
+// entries have been visited or the function returns false.
+func (t *feeRateTreap) ForEachDescending(f func(txDesc *TxDesc) bool) {
+	var stack []*feeRateTreapNode
+	node := t.root
+	for node != nil || len(stack) > 0 {
+		for node != nil {
+			stack = append(stack, node)
+			node = node.right
+		}
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !f(node.txDesc) {
+			return
+		}
+		node = node.left
+	}
+}
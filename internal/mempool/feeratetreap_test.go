@@ -0,0 +1,116 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mempool
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/internal/mining"
+	"github.com/decred/dcrd/wire"
+)
+
+// TestFeeRateTreap ensures the fee rate treap maintains the expected ordering
+// and count as random transaction descriptors are added and removed.
+func TestFeeRateTreap(t *testing.T) {
+	t.Parallel()
+
+	// Create a bunch of transaction descriptors with random fees and sizes
+	// including some with duplicate fee rates.
+	const numDescs = 500
+	prng := rand.New(rand.NewSource(0))
+	descs := make([]*TxDesc, 0, numDescs)
+	for i := 0; i < numDescs; i++ {
+		tx := wire.NewMsgTx()
+		tx.LockTime = uint32(i)
+		fee := prng.Int63n(100000)
+		if i%10 == 0 {
+			fee = 5000
+		}
+		descs = append(descs, &TxDesc{TxDesc: mining.TxDesc{
+			Tx:     dcrutil.NewTx(tx),
+			Fee:    fee,
+			TxSize: 250 + prng.Int63n(2)*250,
+		}})
+	}
+
+	// checkTreap ensures the treap contains exactly the provided descriptors
+	// in both ascending and descending order of fee rate.
+	checkTreap := func(treap *feeRateTreap, want []*TxDesc) {
+		t.Helper()
+		want = append([]*TxDesc(nil), want...)
+		sort.Slice(want, func(i, j int) bool {
+			ki := feeRateKey{calcTxFeePerKB(want[i]), *want[i].Tx.Hash()}
+			kj := feeRateKey{calcTxFeePerKB(want[j]), *want[j].Tx.Hash()}
+			return ki.less(&kj)
+		})
+		if treap.Len() != len(want) {
+			t.Fatalf("mismatched len -- got %d, want %d", treap.Len(),
+				len(want))
+		}
+		var i int
+		treap.ForEachAscending(func(txDesc *TxDesc) bool {
+			if txDesc != want[i] {
+				t.Fatalf("mismatched ascending entry at index %d", i)
+			}
+			i++
+			return true
+		})
+		if i != len(want) {
+			t.Fatalf("mismatched ascending count -- got %d, want %d", i,
+				len(want))
+		}
+		treap.ForEachDescending(func(txDesc *TxDesc) bool {
+			i--
+			if txDesc != want[i] {
+				t.Fatalf("mismatched descending entry at index %d", i)
+			}
+			return true
+		})
+		if i != 0 {
+			t.Fatalf("mismatched descending count -- %d entries not visited",
+				i)
+		}
+	}
+
+	// Add all of the descriptors, including adding some of them more than
+	// once, and ensure the treap is as expected.
+	treap := newFeeRateTreap()
+	for i, desc := range descs {
+		treap.Put(desc)
+		if i%7 == 0 {
+			treap.Put(desc)
+		}
+	}
+	checkTreap(treap, descs)
+
+	// Ensure iteration stops early when requested.
+	var numVisited int
+	treap.ForEachAscending(func(txDesc *TxDesc) bool {
+		numVisited++
+		return numVisited < 5
+	})
+	if numVisited != 5 {
+		t.Fatalf("mismatched number of visited entries -- got %d, want 5",
+			numVisited)
+	}
+
+	// Remove the descriptors in random order, including some that were
+	// already removed, and ensure the treap is as expected along the way.
+	prng.Shuffle(len(descs), func(i, j int) {
+		descs[i], descs[j] = descs[j], descs[i]
+	})
+	for len(descs) > 0 {
+		treap.Delete(descs[0])
+		treap.Delete(descs[0])
+		descs = descs[1:]
+		if len(descs)%50 == 0 {
+			checkTreap(treap, descs)
+		}
+	}
+	checkTreap(treap, nil)
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mempool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+// EventKind identifies the kind of a mempool event recorded in the event
+// journal.
+type EventKind uint8
+
+// These constants define the kinds of mempool events recorded in the event
+// journal.
+const (
+	// EventAccepted indicates a transaction was added to the main pool.
+	EventAccepted EventKind = iota
+
+	// EventRejected indicates a transaction was rejected when it was
+	// processed.  The reason contains the rejection error.
+	EventRejected
+
+	// EventEvicted indicates a transaction was removed from the main pool
+	// without being mined.  The reason describes why it was removed.
+	EventEvicted
+
+	// EventMined indicates a transaction was removed from the main pool
+	// because it was included in a block connected to the main chain.
+	EventMined
+)
+
+// eventKindStrings is a map of event kinds back to their constant names for
+// pretty printing.
+var eventKindStrings = map[EventKind]string{
+	EventAccepted: "accepted",
+	EventRejected: "rejected",
+	EventEvicted:  "evicted",
+	EventMined:    "mined",
+}
+
+// String returns the EventKind as a human-readable name.
+func (k EventKind) String() string {
+	if s := eventKindStrings[k]; s != "" {
+		return s
+	}
+	return "unknown"
+}
+
+// Event describes a single event recorded in the mempool event journal.
+type Event struct {
+	// Time is when the event occurred.
+	Time time.Time
+
+	// Kind identifies what happened to the transaction.
+	Kind EventKind
+
+	// Hash is the hash of the transaction the event is for.
+	Hash chainhash.Hash
+
+	// Reason provides additional details for rejected and evicted
+	// transactions.  It is empty for other kinds of events.
+	Reason string
+}
+
+// eventJournal houses a fixed-size ring buffer of the most recent mempool
+// events so operators are able to determine what happened to transactions
+// after the fact.
+//
+// It has its own mutex since events are recorded by the mempool with its lock
+// held for writes while callers may query the journal at any time.
+type eventJournal struct {
+	mtx    sync.Mutex
+	events []Event
+	next   int
+	full   bool
+}
+
+// newEventJournal returns a new event journal that retains up to the provided
+// number of events.  It returns nil when the size is not positive, which
+// disables the journal.
+func newEventJournal(size int) *eventJournal {
+	if size <= 0 {
+		return nil
+	}
+	return &eventJournal{events: make([]Event, size)}
+}
+
+// record adds the provided event to the journal, replacing the oldest event
+// when the journal is full.  It is a no-op when the journal is disabled.
+//
+// This function is safe for concurrent access.
+func (j *eventJournal) record(e Event) {
+	if j == nil {
+		return
+	}
+
+	j.mtx.Lock()
+	j.events[j.next] = e
+	j.next++
+	if j.next == len(j.events) {
+		j.next = 0
+		j.full = true
+	}
+	j.mtx.Unlock()
+}
+
+// all returns a copy of the events in the journal ordered from oldest to
+// newest.
+//
+// This function is safe for concurrent access.
+func (j *eventJournal) all() []Event {
+	if j == nil {
+		return nil
+	}
+
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	if !j.full {
+		return append([]Event(nil), j.events[:j.next]...)
+	}
+	events := make([]Event, 0, len(j.events))
+	events = append(events, j.events[j.next:]...)
+	return append(events, j.events[:j.next]...)
+}
+
+// recordEvent adds an event of the provided kind for the provided transaction
+// hash to the event journal when it is enabled.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) recordEvent(kind EventKind, txHash *chainhash.Hash, reason string) {
+	mp.journal.record(Event{
+		Time:   time.Now(),
+		Kind:   kind,
+		Hash:   *txHash,
+		Reason: reason,
+	})
+}
+
+// Events returns the events in the event journal ordered from oldest to
+// newest.  It returns nil when the journal is disabled.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) Events() []Event {
+	return mp.journal.all()
+}
+
+// RestoreEvents adds the provided events, which are expected to be ordered
+// from oldest to newest, to the event journal.  This is typically used to
+// restore the journal from a previous run.  Only the most recent events that
+// fit in the journal are retained.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) RestoreEvents(events []Event) {
+	for _, e := range events {
+		mp.journal.record(e)
+	}
+}
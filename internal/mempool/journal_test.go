@@ -0,0 +1,106 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+// TestEventJournalRingBuffer ensures the event journal retains the most recent
+// events up to its size in order from oldest to newest.
+func TestEventJournalRingBuffer(t *testing.T) {
+	t.Parallel()
+
+	// mockEvent returns a mock event that is unique for the provided index.
+	mockEvent := func(i int) Event {
+		return Event{
+			Time: time.Unix(int64(i), 0),
+			Kind: EventKind(i % 4),
+			Hash: chainhash.Hash{byte(i)},
+		}
+	}
+
+	tests := []struct {
+		name      string // test description
+		size      int    // journal size
+		numEvents int    // number of events to record
+		wantFirst int    // index of the first expected event
+	}{{
+		name:      "disabled",
+		size:      0,
+		numEvents: 5,
+	}, {
+		name:      "empty",
+		size:      5,
+		numEvents: 0,
+	}, {
+		name:      "partially full",
+		size:      5,
+		numEvents: 3,
+	}, {
+		name:      "exactly full",
+		size:      5,
+		numEvents: 5,
+	}, {
+		name:      "wrapped once",
+		size:      5,
+		numEvents: 7,
+		wantFirst: 2,
+	}, {
+		name:      "wrapped multiple times",
+		size:      5,
+		numEvents: 23,
+		wantFirst: 18,
+	}}
+
+	for _, test := range tests {
+		j := newEventJournal(test.size)
+		for i := 0; i < test.numEvents; i++ {
+			j.record(mockEvent(i))
+		}
+
+		events := j.all()
+		wantLen := test.numEvents - test.wantFirst
+		if test.size == 0 {
+			wantLen = 0
+		}
+		if len(events) != wantLen {
+			t.Errorf("%q: mismatched number of events -- got %d, want %d",
+				test.name, len(events), wantLen)
+			continue
+		}
+		for i, event := range events {
+			if want := mockEvent(test.wantFirst + i); event != want {
+				t.Errorf("%q: mismatched event %d -- got %+v, want %+v",
+					test.name, i, event, want)
+			}
+		}
+	}
+}
+
+// TestEventKindStringer tests the stringized output for the EventKind type.
+func TestEventKindStringer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   EventKind
+		want string
+	}{
+		{EventAccepted, "accepted"},
+		{EventRejected, "rejected"},
+		{EventEvicted, "evicted"},
+		{EventMined, "mined"},
+		{0xff, "unknown"},
+	}
+	for i, test := range tests {
+		result := test.in.String()
+		if result != test.want {
+			t.Errorf("String #%d\n got: %s want: %s", i, result, test.want)
+		}
+	}
+}
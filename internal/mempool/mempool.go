@@ -68,6 +68,13 @@ const (
 	MempoolMaxConcurrentTSpends = 7
 )
 
+// AcceptanceHook defines the signature of a function that is invoked to apply
+// an additional, externally defined, acceptance policy to a transaction once it
+// has passed the standardness checks.  The provided utxo view contains the
+// outputs referenced by the inputs of the transaction and must be treated as
+// read only.  A non-nil error rejects the transaction.
+type AcceptanceHook func(tx *dcrutil.Tx, utxoView *blockchain.UtxoViewpoint) error
+
 // Tag represents an identifier to use for tagging orphan transactions.  The
 // caller may choose any scheme it desires, however it is common to use peer IDs
 // so that orphans can be identified by which peer first relayed them.
@@ -126,6 +133,9 @@ type Config struct {
 	// SigCache defines a signature cache to use.
 	SigCache *txscript.SigCache
 
+	// ScriptCache defines a script execution cache to use.
+	ScriptCache *blockchain.ScriptCache
+
 	// ExistsAddrIndex defines the optional exists address index instance
 	// to use for indexing the unconfirmed transactions in the memory pool.
 	// This can be nil if the address index is not enabled.
@@ -145,6 +155,13 @@ type Config struct {
 	// vote in the mempool.
 	OnVoteReceived func(voteTx *dcrutil.Tx)
 
+	// OnVoteLeaderChanged defines an optional function used to signal that
+	// a new vote in the mempool caused a different block than before to
+	// become the most favorable one to build on among the competing blocks
+	// at the provided height.  See mining.TallyVotes for details regarding
+	// what makes a block more favorable.
+	OnVoteLeaderChanged func(height int64, oldLeader, newLeader *chainhash.Hash)
+
 	// IsTreasuryAgendaActive returns if the treasury agenda is active or not.
 	IsTreasuryAgendaActive func() (bool, error)
 
@@ -163,6 +180,17 @@ type Config struct {
 	// TSpendMinedOnAncestor returns an error if the provided tspend has
 	// been mined in an ancestor block.
 	TSpendMinedOnAncestor func(tspend chainhash.Hash) error
+
+	// AcceptanceHooks defines an optional set of hooks that are invoked in
+	// order to apply additional acceptance policies to transactions after the
+	// standardness checks.  Additional hooks may be registered after the pool
+	// is created via RegisterAcceptanceHook.
+	AcceptanceHooks []AcceptanceHook
+
+	// EventJournalSize defines the maximum number of recent events, such as
+	// transactions being accepted, rejected, evicted, or mined, to retain in
+	// the event journal.  The journal is disabled when it is zero.
+	EventJournalSize int
 }
 
 // Policy houses the policy (configuration parameters) which is used to
@@ -209,6 +237,17 @@ type Policy struct {
 	// This function must be safe for concurrent access.
 	StandardVerifyFlags func() (txscript.ScriptFlags, error)
 
+	// FeeRateAdjustment defines an optional function that returns the
+	// percentage of the minimum relay fee rate the provided transaction of
+	// the provided type is required to pay in order to be accepted.  This
+	// allows discounts (less than StandardFeeRatePercent), surcharges
+	// (greater than StandardFeeRatePercent), and exemptions (zero) to be
+	// applied to classes of transactions.  DefaultFeeRateAdjustment is used
+	// when it is nil.
+	//
+	// This function must be safe for concurrent access.
+	FeeRateAdjustment func(tx *dcrutil.Tx, txType stake.TxType) uint32
+
 	// EnableAncestorTracking controls whether the mining view tracks
 	// transaction relationships in the mempool.
 	EnableAncestorTracking bool
@@ -222,6 +261,15 @@ type TxDesc struct {
 	// StartingPriority is the priority of the transaction when it was added
 	// to the pool.
 	StartingPriority float64
+
+	// FeeRatePercent is the percentage of the minimum relay fee rate the
+	// transaction was required to pay when it was added to the pool as
+	// determined by the fee rate adjustment policy.
+	FeeRatePercent uint32
+
+	// RequiredFee is the minimum fee in atoms the transaction was required to
+	// pay when it was added to the pool.
+	RequiredFee int64
 }
 
 // VerboseTxDesc is a descriptor containing a transaction in the mempool along
@@ -265,18 +313,35 @@ type TxPool struct {
 	outpoints     map[wire.OutPoint]*dcrutil.Tx
 	miningView    *mining.TxMiningView
 
+	// feeRates tracks the transactions in the main pool ordered by their
+	// fee rate and totalSize tracks the total serialized size of them.  They
+	// are maintained incrementally as transactions are added and removed.
+	feeRates  *feeRateTreap
+	totalSize int64
+
 	staged          map[chainhash.Hash]*TxDesc
 	stagedOutpoints map[wire.OutPoint]*dcrutil.Tx
 
 	transient map[chainhash.Hash]*dcrutil.Tx
 
-	// Votes on blocks.
-	votesMtx sync.RWMutex
-	votes    map[chainhash.Hash][]mining.VoteDesc
+	// Votes on blocks along with the hashes of the blocks with votes keyed by
+	// their height in the order the first vote for each of them was seen.
+	votesMtx    sync.RWMutex
+	votes       map[chainhash.Hash][]mining.VoteDesc
+	votedBlocks map[int64][]chainhash.Hash
 
 	// TSpends. Access MUST be protected by the mempool mutex.
 	tspends map[chainhash.Hash]*dcrutil.Tx
 
+	// acceptanceHooks houses the hooks that are invoked to apply additional
+	// acceptance policies to transactions.  Access MUST be protected by the
+	// mempool mutex.
+	acceptanceHooks []AcceptanceHook
+
+	// journal houses the most recent events for transactions in the main
+	// pool.  It is nil when the journal is disabled.
+	journal *eventJournal
+
 	// nextExpireScan is the time after which the orphan pool will be
 	// scanned in order to evict orphans.  This is NOT a hard deadline as
 	// the scan will only run when an orphan is added to the pool as opposed
@@ -284,10 +349,38 @@ type TxPool struct {
 	nextExpireScan time.Time
 }
 
-// insertVote inserts a vote into the map of block votes.
+// voteLeaderChange describes a change to the most favorable block to build on
+// among the competing blocks at a given height as a result of a new vote.
+type voteLeaderChange struct {
+	height    int64
+	oldLeader chainhash.Hash
+	newLeader chainhash.Hash
+}
+
+// voteLeaderAtHeight returns the hash of the block at the provided height that
+// is the most favorable to build on according to the votes currently in the
+// mempool.  False is returned when there are no votes for any blocks at the
+// height.
+//
+// This function MUST be called with the vote mutex locked (for reads).
+func (mp *TxPool) voteLeaderAtHeight(height int64) (chainhash.Hash, bool) {
+	blocks := mp.votedBlocks[height]
+	if len(blocks) == 0 {
+		return chainhash.Hash{}, false
+	}
+	votes := make([][]mining.VoteDesc, 0, len(blocks))
+	for _, hash := range blocks {
+		votes = append(votes, mp.votes[hash])
+	}
+	return mining.TallyVotes(blocks, votes)[0].Hash, true
+}
+
+// insertVote inserts a vote into the map of block votes.  The previous and new
+// most favorable blocks to build on at the height voted on are returned when
+// the vote results in a different block becoming the most favorable one.
 //
 // This function MUST be called with the vote mutex locked (for writes).
-func (mp *TxPool) insertVote(ssgen *dcrutil.Tx) {
+func (mp *TxPool) insertVote(ssgen *dcrutil.Tx) *voteLeaderChange {
 	// Get the block it is voting on; here we're agnostic of height.
 	msgTx := ssgen.MsgTx()
 	blockHash, blockHeight := stake.SSGenBlockVotedOn(msgTx)
@@ -303,7 +396,7 @@ func (mp *TxPool) insertVote(ssgen *dcrutil.Tx) {
 	ticketHash := &msgTx.TxIn[1].PreviousOutPoint.Hash
 	for _, vt := range vts {
 		if vt.TicketHash.IsEqual(ticketHash) {
-			return
+			return nil
 		}
 	}
 
@@ -316,12 +409,30 @@ func (mp *TxPool) insertVote(ssgen *dcrutil.Tx) {
 		ApprovesParent: vote,
 	}
 
-	// Append the new vote.
+	// Append the new vote and keep track of the block by its height when it
+	// is the first vote for it.
+	height := int64(blockHeight)
+	oldLeader, hadLeader := mp.voteLeaderAtHeight(height)
 	mp.votes[blockHash] = append(vts, voteTx)
+	if !exists {
+		mp.votedBlocks[height] = append(mp.votedBlocks[height], blockHash)
+	}
 
 	log.Debugf("Accepted vote %v for block hash %v (height %v), voting "+
 		"%v on the transaction tree", voteHash, blockHash, blockHeight,
 		vote)
+
+	// Determine if the vote resulted in a different block becoming the most
+	// favorable one to build on at the height.
+	newLeader, _ := mp.voteLeaderAtHeight(height)
+	if !hadLeader || newLeader == oldLeader {
+		return nil
+	}
+	return &voteLeaderChange{
+		height:    height,
+		oldLeader: oldLeader,
+		newLeader: newLeader,
+	}
 }
 
 // VoteHashesForBlock returns the hashes for all votes on the provided block
@@ -364,6 +475,47 @@ func (mp *TxPool) VotesForBlocks(hashes []chainhash.Hash) [][]mining.VoteDesc {
 	return result
 }
 
+// BlocksVotedOnAtHeight returns the hashes of all blocks at the provided height
+// that have votes currently available in the mempool in the order the first
+// vote for each of them was seen.  This allows competing blocks at the same
+// height, such as those that occur during natural forks, to be compared.  See
+// mining.VoteTalliesAtHeight.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) BlocksVotedOnAtHeight(height int64) []chainhash.Hash {
+	mp.votesMtx.RLock()
+	blocks := mp.votedBlocks[height]
+	if len(blocks) == 0 {
+		mp.votesMtx.RUnlock()
+		return nil
+	}
+	result := make([]chainhash.Hash, len(blocks))
+	copy(result, blocks)
+	mp.votesMtx.RUnlock()
+
+	return result
+}
+
+// PruneVotedBlocks stops tracking the blocks voted on at heights prior to the
+// provided height for the purposes of comparing competing blocks.  It should
+// be called every time a new block is connected to the main chain since votes
+// for blocks that are more than one block behind the main chain tip can no
+// longer influence which block is the most favorable one to build on.
+//
+// Note that the votes themselves remain available via VoteHashesForBlock and
+// VotesForBlocks.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) PruneVotedBlocks(height int64) {
+	mp.votesMtx.Lock()
+	for votedHeight := range mp.votedBlocks {
+		if votedHeight < height {
+			delete(mp.votedBlocks, votedHeight)
+		}
+	}
+	mp.votesMtx.Unlock()
+}
+
 // TODO Pruning of the votes map DECRED
 
 // TSpendHashes returns hashes of all existing tracked tspends. This function
@@ -552,7 +704,7 @@ func (mp *TxPool) maybeAddOrphan(tx *dcrutil.Tx, tag Tag) error {
 	// also limited, so this equates to a maximum memory used of
 	// mp.cfg.Policy.MaxOrphanTxSize * mp.cfg.Policy.MaxOrphanTxs (which is ~5MB
 	// using the default values at the time this comment was written).
-	serializedLen := tx.MsgTx().SerializeSize()
+	serializedLen := tx.SerializeSize()
 	if serializedLen > mp.cfg.Policy.MaxOrphanTxSize {
 		str := fmt.Sprintf("orphan transaction size of %d bytes is "+
 			"larger than max allowed size of %d bytes",
@@ -794,8 +946,12 @@ func (mp *TxPool) HaveAllTransactions(hashes []chainhash.Hash) bool {
 // removeTransaction is the internal function which implements the public
 // RemoveTransaction.  See the comment for RemoveTransaction for more details.
 //
+// The provided event kind and reason are recorded in the event journal when
+// the transaction is in the pool.  Redeemers that are removed are recorded as
+// evicted.
+//
 // This function MUST be called with the mempool lock held (for writes).
-func (mp *TxPool) removeTransaction(tx *dcrutil.Tx, removeRedeemers bool) {
+func (mp *TxPool) removeTransaction(tx *dcrutil.Tx, removeRedeemers bool, kind EventKind, reason string) {
 	txHash := tx.Hash()
 	if removeRedeemers {
 		// Remove any transactions which rely on this one.
@@ -809,7 +965,9 @@ func (mp *TxPool) removeTransaction(tx *dcrutil.Tx, removeRedeemers bool) {
 		for i := uint32(0); i < uint32(len(tx.MsgTx().TxOut)); i++ {
 			outpoint.Index = i
 			if txRedeemer, exists := mp.outpoints[outpoint]; exists {
-				mp.removeTransaction(txRedeemer, true)
+				reason := fmt.Sprintf("spends output of removed "+
+					"transaction %v", txHash)
+				mp.removeTransaction(txRedeemer, true, EventEvicted, reason)
 				continue
 			}
 			if txRedeemer, exists := mp.stagedOutpoints[outpoint]; exists {
@@ -835,6 +993,8 @@ func (mp *TxPool) removeTransaction(tx *dcrutil.Tx, removeRedeemers bool) {
 		mp.miningView.RemoveTransaction(tx.Hash(), updateDescendantStats)
 
 		delete(mp.pool, *txHash)
+		mp.feeRates.Delete(txDesc)
+		mp.totalSize -= txDesc.TxSize
 
 		atomic.StoreInt64(&mp.lastUpdated, time.Now().Unix())
 
@@ -846,6 +1006,8 @@ func (mp *TxPool) removeTransaction(tx *dcrutil.Tx, removeRedeemers bool) {
 
 		// Stop tracking if it's a tspend.
 		delete(mp.tspends, *txHash)
+
+		mp.recordEvent(kind, txHash, reason)
 	}
 }
 
@@ -858,7 +1020,20 @@ func (mp *TxPool) removeTransaction(tx *dcrutil.Tx, removeRedeemers bool) {
 func (mp *TxPool) RemoveTransaction(tx *dcrutil.Tx, removeRedeemers bool) {
 	// Protect concurrent access.
 	mp.mtx.Lock()
-	mp.removeTransaction(tx, removeRedeemers)
+	mp.removeTransaction(tx, removeRedeemers, EventEvicted, "removed")
+	mp.mtx.Unlock()
+}
+
+// RemoveMinedTransaction removes the passed transaction from the mempool
+// because it was included in a block connected to the main chain.  It is the
+// same as RemoveTransaction without removing redeemers, since they remain
+// valid, except the removal is recorded as mined in the event journal.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) RemoveMinedTransaction(tx *dcrutil.Tx) {
+	// Protect concurrent access.
+	mp.mtx.Lock()
+	mp.removeTransaction(tx, false, EventMined, "")
 	mp.mtx.Unlock()
 }
 
@@ -875,7 +1050,9 @@ func (mp *TxPool) RemoveDoubleSpends(tx *dcrutil.Tx) {
 	for _, txIn := range tx.MsgTx().TxIn {
 		if txRedeemer, ok := mp.outpoints[txIn.PreviousOutPoint]; ok {
 			if !txRedeemer.Hash().IsEqual(tx.Hash()) {
-				mp.removeTransaction(txRedeemer, true)
+				reason := fmt.Sprintf("double spends transaction %v",
+					tx.Hash())
+				mp.removeTransaction(txRedeemer, true, EventEvicted, reason)
 			}
 		}
 		if txRedeemer, ok := mp.stagedOutpoints[txIn.PreviousOutPoint]; ok {
@@ -889,6 +1066,84 @@ func (mp *TxPool) RemoveDoubleSpends(tx *dcrutil.Tx) {
 	mp.mtx.Unlock()
 }
 
+// conflictSource provides a view into the main and stage pools that
+// implements the blockchain.UnminedTxSource interface.
+//
+// The mempool lock MUST be held (for reads) while it is in use.
+type conflictSource struct {
+	mp *TxPool
+}
+
+// Ensure conflictSource implements the blockchain.UnminedTxSource interface.
+var _ blockchain.UnminedTxSource = conflictSource{}
+
+// HaveTransaction returns whether or not the passed transaction exists in the
+// main or stage pool.
+//
+// This is part of the blockchain.UnminedTxSource interface.
+func (s conflictSource) HaveTransaction(hash *chainhash.Hash) bool {
+	return s.mp.isTransactionInPool(hash) || s.mp.isTransactionStaged(hash)
+}
+
+// SpendingTx returns the transaction in the main or stage pool that spends the
+// provided outpoint, if any.
+//
+// This is part of the blockchain.UnminedTxSource interface.
+func (s conflictSource) SpendingTx(outpoint wire.OutPoint) *dcrutil.Tx {
+	if tx, ok := s.mp.outpoints[outpoint]; ok {
+		return tx
+	}
+	return s.mp.stagedOutpoints[outpoint]
+}
+
+// CheckBlockConflicts returns which of the transactions in the main and stage
+// pools would be removed as a result of connecting the passed block to the main
+// chain, without modifying the pool.  See blockchain.CheckBlockConflicts for
+// details.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) CheckBlockConflicts(block *dcrutil.Block) *blockchain.BlockConflicts {
+	mp.mtx.RLock()
+	conflicts := blockchain.CheckBlockConflicts(block, conflictSource{mp})
+	mp.mtx.RUnlock()
+	return conflicts
+}
+
+// RemoveBlockConflicts removes all transactions from the main and stage pools
+// that are no longer valid as a result of connecting the passed block to the
+// main chain.  That is, all transactions that are double spent by transactions
+// in the block along with all transactions that depend on them, recursively.
+// It returns the conflicts that were determined for the block.
+//
+// Note that the transactions included in the block are not removed.  See
+// blockchain.CheckBlockConflicts for details.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) RemoveBlockConflicts(block *dcrutil.Block) *blockchain.BlockConflicts {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	// The evicted transactions are ordered such that transactions are always
+	// before the ones that depend on them, so removing each of them along
+	// with its redeemers typically results in the later ones already being
+	// removed.
+	conflicts := blockchain.CheckBlockConflicts(block, conflictSource{mp})
+	for i := range conflicts.Evicted {
+		hash := &conflicts.Evicted[i]
+		if txDesc, ok := mp.pool[*hash]; ok {
+			reason := fmt.Sprintf("conflicts with block %v", block.Hash())
+			mp.removeTransaction(txDesc.Tx, true, EventEvicted, reason)
+			continue
+		}
+		if txDesc, ok := mp.staged[*hash]; ok {
+			log.Debugf("Removing transaction %v that conflicts with block %v "+
+				"from stage pool", hash, block.Hash())
+			mp.removeStagedTransaction(txDesc.Tx)
+		}
+	}
+	return conflicts
+}
+
 // findTx returns a transaction from the mempool by hash.  If it does not exist
 // in the mempool, a nil pointer is returned.
 func (mp *TxPool) findTx(txHash *chainhash.Hash) *mining.TxDesc {
@@ -918,6 +1173,8 @@ func (mp *TxPool) addTransaction(utxoView *blockchain.UtxoViewpoint, txDesc *TxD
 	// Add the transaction to the pool and mark the referenced outpoints
 	// as spent by the pool.
 	mp.pool[*txHash] = txDesc
+	mp.feeRates.Put(txDesc)
+	mp.totalSize += txDesc.TxSize
 	mp.miningView.AddTransaction(&txDesc.TxDesc, mp.findTx)
 
 	msgTx := tx.MsgTx()
@@ -937,6 +1194,8 @@ func (mp *TxPool) addTransaction(utxoView *blockchain.UtxoViewpoint, txDesc *TxD
 	if mp.cfg.AddTxToFeeEstimation != nil {
 		mp.cfg.AddTxToFeeEstimation(txHash, txDesc.Fee, txDesc.TxSize, txType)
 	}
+
+	mp.recordEvent(EventAccepted, txHash, "")
 }
 
 // checkPoolDoubleSpend checks whether or not the passed transaction is
@@ -1191,20 +1450,23 @@ func (mp *TxPool) MaybeAcceptDependents(tx *dcrutil.Tx, isTreasuryEnabled bool)
 	return acceptedTxns
 }
 
-// maybeAcceptTransaction is the internal function which implements the public
-// MaybeAcceptTransaction.  See the comment for MaybeAcceptTransaction for
-// more details.
+// validateTransaction performs all of the checks required for the passed
+// transaction to be accepted into the memory pool without modifying the pool.
+// It returns a description of the transaction along with the view of its
+// inputs when the transaction is acceptable.  When the transaction references
+// inputs that are not available, the hashes of the missing parent transactions
+// are returned instead and the transaction description is nil.
 //
-// This function MUST be called with the mempool lock held (for writes).
+// This function MUST be called with the mempool lock held (for reads).
 //
 // DECRED - TODO
 // We need to make sure thing also assigns the TxType after it evaluates the tx,
 // so that we can easily pick different stake tx types from the mempool later.
 // This should probably be done at the bottom using "IsSStx" etc functions.
 // It should also set the dcrutil tree type for the tx as well.
-func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
-	rejectDupOrphans bool,
-	checkTxFlags blockchain.AgendaFlags) ([]*chainhash.Hash, error) {
+func (mp *TxPool) validateTransaction(tx *dcrutil.Tx, allowHighFees,
+	rejectDupOrphans bool, checkTxFlags blockchain.AgendaFlags) (*TxDesc,
+	*blockchain.UtxoViewpoint, []*chainhash.Hash, error) {
 
 	msgTx := tx.MsgTx()
 	txHash := tx.Hash()
@@ -1215,7 +1477,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 	if mp.isTransactionInPool(txHash) || mp.isTransactionStaged(txHash) ||
 		(rejectDupOrphans && mp.isOrphanInPool(txHash)) {
 		str := fmt.Sprintf("already have transaction %v", txHash)
-		return nil, txRuleError(ErrDuplicate, str)
+		return nil, nil, nil, txRuleError(ErrDuplicate, str)
 	}
 
 	// Perform preliminary validation checks on the transaction.  This makes use
@@ -1225,9 +1487,9 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 	if err != nil {
 		var cerr blockchain.RuleError
 		if errors.As(err, &cerr) {
-			return nil, chainRuleError(cerr)
+			return nil, nil, nil, chainRuleError(cerr)
 		}
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	// Determine active agendas based on flags.
@@ -1250,14 +1512,14 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 	if isTreasurybase {
 		str := fmt.Sprintf("transaction %v is an individual treasurybase",
 			txHash)
-		return nil, txRuleError(ErrTreasurybase, str)
+		return nil, nil, nil, txRuleError(ErrTreasurybase, str)
 	}
 
 	// A standalone transaction must not be a coinbase transaction.
 	if standalone.IsCoinBaseTx(msgTx, isTreasuryEnabled) {
 		str := fmt.Sprintf("transaction %v is an individual coinbase",
 			txHash)
-		return nil, txRuleError(ErrCoinbase, str)
+		return nil, nil, nil, txRuleError(ErrCoinbase, str)
 	}
 
 	// Get the current height of the main chain.  A standalone transaction
@@ -1270,7 +1532,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 	if blockchain.IsExpired(tx, nextBlockHeight) {
 		str := fmt.Sprintf("transaction %v expired at height %d",
 			txHash, msgTx.Expiry)
-		return nil, txRuleError(ErrExpired, str)
+		return nil, nil, nil, txRuleError(ErrExpired, str)
 	}
 
 	// Reject votes and treasury spends before stake validation height.
@@ -1284,7 +1546,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 		}
 		str := fmt.Sprintf("%s are not valid until block height %d (next "+
 			"block height %d)", strType, stakeValidationHeight, nextBlockHeight)
-		return nil, txRuleError(ErrInvalid, str)
+		return nil, nil, nil, txRuleError(ErrInvalid, str)
 	}
 
 	// Reject revocations before they can possibly be valid.  A vote must be
@@ -1295,7 +1557,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 	if isRevocation && nextBlockHeight < stakeValidationHeight+1 {
 		str := fmt.Sprintf("revocations are not valid until block height %d "+
 			"(next block height %d)", stakeValidationHeight+1, nextBlockHeight)
-		return nil, txRuleError(ErrInvalid, str)
+		return nil, nil, nil, txRuleError(ErrInvalid, str)
 	}
 
 	// Don't allow non-standard transactions if the mempool config forbids
@@ -1307,7 +1569,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 		if err != nil {
 			str := fmt.Sprintf("transaction %v is not standard: %v",
 				txHash, err)
-			return nil, wrapTxRuleError(ErrNonStandard, str, err)
+			return nil, nil, nil, wrapTxRuleError(ErrNonStandard, str, err)
 		}
 	}
 
@@ -1319,14 +1581,14 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 		if err != nil {
 			// This is an unexpected error so don't turn it into a
 			// rule error.
-			return nil, err
+			return nil, nil, nil, err
 		}
 
 		if msgTx.TxOut[0].Value < sDiff {
 			str := fmt.Sprintf("transaction %v has not enough funds "+
 				"to meet stake difficulty (ticket diff %v < next diff %v)",
 				txHash, msgTx.TxOut[0].Value, sDiff)
-			return nil, txRuleError(ErrInsufficientFee, str)
+			return nil, nil, nil, txRuleError(ErrInsufficientFee, str)
 		}
 	}
 
@@ -1342,7 +1604,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 	if !isVote && !isRevocation {
 		err = mp.checkPoolDoubleSpend(tx, txType, isTreasuryEnabled)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, err
 		}
 
 	} else if isVote {
@@ -1352,7 +1614,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 		// check to merely reject double spends of tickets is not possible.
 		err := mp.checkVoteDoubleSpend(tx)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, err
 		}
 
 		voteAlreadyFound := 0
@@ -1367,7 +1629,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 				str := fmt.Sprintf("transaction %v in the pool with more than "+
 					"%v votes", msgTx.TxIn[1].PreviousOutPoint,
 					maxVoteDoubleSpends)
-				return nil, txRuleError(ErrTooManyVotes, str)
+				return nil, nil, nil, txRuleError(ErrTooManyVotes, str)
 			}
 		}
 
@@ -1379,7 +1641,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 					str := fmt.Sprintf("transaction %v in the pool as a "+
 						"revocation. Only one revocation is allowed.",
 						msgTx.TxIn[0].PreviousOutPoint)
-					return nil, txRuleError(ErrDuplicateRevocation, str)
+					return nil, nil, nil, txRuleError(ErrDuplicateRevocation, str)
 				}
 			}
 		}
@@ -1394,7 +1656,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 				"block height of %d which is before the "+
 				"current cutoff height of %v", tx.Hash(),
 				voteHeight, nextBlockHeight-int64(mp.cfg.Policy.MaxVoteAge))
-			return nil, txRuleError(ErrOldVote, str)
+			return nil, nil, nil, txRuleError(ErrOldVote, str)
 		}
 	}
 
@@ -1404,7 +1666,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 	// without needing to do a separate lookup.
 	utxoView, err := mp.fetchInputUtxos(tx, isTreasuryEnabled)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	// Don't allow the transaction if it exists in the main chain and is not
@@ -1414,7 +1676,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 		outpoint.Index = uint32(txOutIdx)
 		entry := utxoView.LookupEntry(outpoint)
 		if entry != nil && !entry.IsSpent() {
-			return nil, txRuleError(ErrAlreadyExists, "transaction already exists")
+			return nil, nil, nil, txRuleError(ErrAlreadyExists, "transaction already exists")
 		}
 		utxoView.RemoveEntry(outpoint)
 	}
@@ -1465,7 +1727,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 	}
 
 	if len(missingParents) > 0 {
-		return missingParents, nil
+		return nil, nil, missingParents, nil
 	}
 
 	// Update the fraud proof data on the transaction inputs as necessary.  The
@@ -1514,13 +1776,13 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 		if err != nil {
 			var cerr blockchain.RuleError
 			if errors.As(err, &cerr) {
-				return nil, chainRuleError(cerr)
+				return nil, nil, nil, chainRuleError(cerr)
 			}
-			return nil, err
+			return nil, nil, nil, err
 		}
 		if !blockchain.SequenceLockActive(seqLock, nextBlockHeight, medianTime) {
 			str := "transaction sequence locks on inputs not met"
-			return nil, txRuleError(ErrSeqLockUnmet, str)
+			return nil, nil, nil, txRuleError(ErrSeqLockUnmet, str)
 		}
 	}
 
@@ -1532,7 +1794,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 	bestHash := mp.cfg.BestHash()
 	bestHeader, err := mp.cfg.HeaderByHash(bestHash)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	txFee, err := blockchain.CheckTransactionInputs(mp.cfg.SubsidyCache, tx,
 		nextBlockHeight, utxoView, true, mp.cfg.ChainParams, &bestHeader,
@@ -1540,9 +1802,9 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 	if err != nil {
 		var cerr blockchain.RuleError
 		if errors.As(err, &cerr) {
-			return nil, chainRuleError(cerr)
+			return nil, nil, nil, chainRuleError(cerr)
 		}
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	// Don't allow transactions with non-standard inputs if the mempool config
@@ -1552,7 +1814,17 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 		if err != nil {
 			str := fmt.Sprintf("transaction %v has a non-standard "+
 				"input: %v", txHash, err)
-			return nil, wrapTxRuleError(ErrNonStandard, str, err)
+			return nil, nil, nil, wrapTxRuleError(ErrNonStandard, str, err)
+		}
+	}
+
+	// Don't allow transactions that are rejected by any of the registered
+	// acceptance hooks.
+	for _, hook := range mp.acceptanceHooks {
+		if err := hook(tx, utxoView); err != nil {
+			str := fmt.Sprintf("transaction %v rejected by acceptance "+
+				"policy: %v", txHash, err)
+			return nil, nil, nil, wrapTxRuleError(ErrAcceptanceHook, str, err)
 		}
 	}
 
@@ -1570,9 +1842,9 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 	if err != nil {
 		var cerr blockchain.RuleError
 		if errors.As(err, &cerr) {
-			return nil, chainRuleError(cerr)
+			return nil, nil, nil, chainRuleError(cerr)
 		}
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	numSigOps := blockchain.CountSigOps(tx, false, isVote, isTreasuryEnabled)
@@ -1580,46 +1852,44 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 	if totalSigOps > mp.cfg.Policy.MaxSigOpsPerTx {
 		str := fmt.Sprintf("transaction %v has too many sigops: %d > %d",
 			txHash, totalSigOps, mp.cfg.Policy.MaxSigOpsPerTx)
-		return nil, txRuleError(ErrNonStandard, str)
+		return nil, nil, nil, txRuleError(ErrNonStandard, str)
 	}
 
 	// Don't allow transactions with fees too low to get into a mined block.
 	//
-	// This only applies to transactions of the following types:
-	// - Regular (non-stake) transactions
-	// - Ticket purchases
-	// - Treasury spends
-	// - Treasury adds
-	//
-	// Notice that it intentionally does not apply to the following types since
-	// they are an integral part of the block production process and are
-	// required to be feeless:
-	// - Coinbases (rejected from the mempool anyway)
-	// - Treasurybases (rejected from the mempool anyway)
-	// - Revocations (automatic revocations never in the mempool anyway)
-	// - Votes
-	isTreasuryAdd := isTreasuryEnabled && txType == stake.TxTypeTAdd
-	serializedSize := int64(msgTx.SerializeSize())
-	minFee := calcMinRequiredTxRelayFee(serializedSize,
-		mp.cfg.Policy.MinRelayTxFee)
-	if txFee < minFee && (txType == stake.TxTypeRegular || isTicket ||
-		isTreasuryAdd || isTSpend) {
-
+	// The minimum relay fee rate is adjusted for the transaction according to
+	// the fee rate adjustment policy which, by default, exempts votes and
+	// revocations since they are an integral part of the block production
+	// process and are required to be feeless.
+	feeRateAdjustment := mp.cfg.Policy.FeeRateAdjustment
+	if feeRateAdjustment == nil {
+		feeRateAdjustment = DefaultFeeRateAdjustment
+	}
+	feeRatePercent := feeRateAdjustment(tx, txType)
+	feeRate := calcAdjustedRelayFeeRate(mp.cfg.Policy.MinRelayTxFee,
+		feeRatePercent)
+	serializedSize := int64(tx.SerializeSize())
+	minFee := calcMinRequiredTxRelayFee(serializedSize, feeRate)
+	if txFee < minFee {
 		var txTypeStr string
-		switch {
-		case txType == stake.TxTypeRegular:
+		switch txType {
+		case stake.TxTypeRegular:
 			txTypeStr = "regular "
-		case isTicket:
+		case stake.TxTypeSStx:
 			txTypeStr = "ticket purchase "
-		case isTreasuryAdd:
+		case stake.TxTypeSSGen:
+			txTypeStr = "vote "
+		case stake.TxTypeSSRtx:
+			txTypeStr = "revocation "
+		case stake.TxTypeTAdd:
 			txTypeStr = "treasury add "
-		case isTSpend:
+		case stake.TxTypeTSpend:
 			txTypeStr = "treasury spend "
 		}
 		str := fmt.Sprintf("%stransaction %s pays a fee of %d atoms which is "+
 			"under the required fee of %d atoms for a %d-byte transaction",
 			txTypeStr, txHash, txFee, minFee, serializedSize)
-		return nil, txRuleError(ErrInsufficientFee, str)
+		return nil, nil, nil, txRuleError(ErrInsufficientFee, str)
 	}
 
 	// Check whether allowHighFees is set to false (default), if so, then make
@@ -1632,7 +1902,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 			str := fmt.Sprintf("transaction %v has %v fee which is above the "+
 				"allowHighFee check threshold amount of %v", txHash,
 				txFee, maxFee)
-			return nil, txRuleError(ErrFeeTooHigh, str)
+			return nil, nil, nil, txRuleError(ErrFeeTooHigh, str)
 		}
 	}
 
@@ -1640,16 +1910,16 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 	// any don't verify.
 	flags, err := mp.cfg.Policy.StandardVerifyFlags()
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	err = blockchain.ValidateTransactionScripts(tx, utxoView, flags,
-		mp.cfg.SigCache, isAutoRevocationsEnabled)
+		mp.cfg.SigCache, mp.cfg.ScriptCache, isAutoRevocationsEnabled)
 	if err != nil {
 		var cerr blockchain.RuleError
 		if errors.As(err, &cerr) {
-			return nil, chainRuleError(cerr)
+			return nil, nil, nil, chainRuleError(cerr)
 		}
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	// Only allow TSpends that have a valid Expiry.
@@ -1666,7 +1936,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 		if err != nil {
 			str := fmt.Sprintf("Invalid tspend expiry %d: %v ",
 				msgTx.Expiry, err)
-			return nil, txRuleError(ErrTSpendInvalidExpiry, str)
+			return nil, nil, nil, txRuleError(ErrTSpendInvalidExpiry, str)
 		}
 		voteStartThresh := int64(2 * tvi * mul)
 		blocksToVoteStart := int64(voteStart) - nextBlockHeight
@@ -1677,7 +1947,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 				"future: voting starts in %d blocks while the "+
 				"voting threshold is %d blocks",
 				blocksToVoteStart, voteStartThresh)
-			return nil, txRuleError(ErrTSpendInvalidExpiry, str)
+			return nil, nil, nil, txRuleError(ErrTSpendInvalidExpiry, str)
 		}
 
 		// Only allow up to MempoolMaxConcurrentTSpends TSpends in the
@@ -1687,7 +1957,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 			str := fmt.Sprintf("Mempool can only hold %v "+
 				"concurrent TSpend transactions",
 				MempoolMaxConcurrentTSpends)
-			return nil, txRuleError(ErrTooManyTSpends, str)
+			return nil, nil, nil, txRuleError(ErrTooManyTSpends, str)
 		}
 
 		// Verify that this TSpend uses a well-known Pi key and that
@@ -1695,29 +1965,24 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 		signature, pubKey, err := stake.CheckTSpend(msgTx)
 		if err != nil {
 			str := fmt.Sprintf("Mempool invalid TSpend: %v", err)
-			return nil, txRuleError(ErrInvalid, str)
+			return nil, nil, nil, txRuleError(ErrInvalid, str)
 		}
 		if !mp.cfg.ChainParams.PiKeyExists(pubKey) {
 			str := fmt.Sprintf("Unknown Pi Key: %x", pubKey)
-			return nil, txRuleError(ErrInvalid, str)
+			return nil, nil, nil, txRuleError(ErrInvalid, str)
 		}
 		err = blockchain.VerifyTSpendSignature(msgTx, signature, pubKey)
 		if err != nil {
 			str := fmt.Sprintf("Mempool invalid TSpend signature: "+
 				"%v", err)
-			return nil, txRuleError(ErrInvalid, str)
+			return nil, nil, nil, txRuleError(ErrInvalid, str)
 		}
 
 		// Verify that this tspend hash has not been included in an
 		// ancestor block yet.
 		if err := mp.cfg.TSpendMinedOnAncestor(*txHash); err != nil {
 			// err is descriptive and only needs to be wrapped.
-			return nil, txRuleError(ErrTSpendMinedOnAncestor, err.Error())
-		}
-
-		// Notify that we accepted a TSpend.
-		if mp.cfg.OnTSpendReceived != nil {
-			mp.cfg.OnTSpendReceived(tx)
+			return nil, nil, nil, txRuleError(ErrTSpendMinedOnAncestor, err.Error())
 		}
 
 		log.Tracef("TSpend allowed in mempool: nbh %v expiry %v "+
@@ -1727,6 +1992,39 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 
 	txDesc := mp.newTxDesc(utxoView, tx, txType, bestHeight, txFee, totalSigOps,
 		serializedSize)
+	txDesc.FeeRatePercent = feeRatePercent
+	txDesc.RequiredFee = minFee
+	return txDesc, utxoView, nil, nil
+}
+
+// maybeAcceptTransaction is the internal function which implements the public
+// MaybeAcceptTransaction.  See the comment for MaybeAcceptTransaction for
+// more details.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
+	rejectDupOrphans bool,
+	checkTxFlags blockchain.AgendaFlags) ([]*chainhash.Hash, error) {
+
+	txDesc, utxoView, missingParents, err := mp.validateTransaction(tx,
+		allowHighFees, rejectDupOrphans, checkTxFlags)
+	if err != nil {
+		return nil, err
+	}
+	if len(missingParents) > 0 {
+		return missingParents, nil
+	}
+
+	txHash := tx.Hash()
+	txType := txDesc.Type
+	isVote := txType == stake.TxTypeSSGen
+	isTSpend := checkTxFlags.IsTreasuryEnabled() &&
+		txType == stake.TxTypeTSpend
+
+	// Notify that we accepted a TSpend.
+	if isTSpend && mp.cfg.OnTSpendReceived != nil {
+		mp.cfg.OnTSpendReceived(tx)
+	}
 
 	// Tickets cannot be included in a block until all inputs have
 	// been approved by stakeholders. Consensus rules dictate that stake
@@ -1754,7 +2052,8 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 		mp.forEachRedeemer(tx, func(redeemerTxDesc *TxDesc) {
 			redeemerTx := redeemerTxDesc.Tx
 			if redeemerTxDesc.Type == stake.TxTypeSStx {
-				mp.removeTransaction(redeemerTx, true)
+				mp.removeTransaction(redeemerTx, true, EventEvicted,
+					"moved to the stage pool")
 				mp.stageTransaction(redeemerTxDesc)
 				log.Debugf("Moved ticket %v dependent on %v into stage pool",
 					redeemerTx.Hash(), txHash)
@@ -1765,8 +2064,15 @@ func (mp *TxPool) maybeAcceptTransaction(tx *dcrutil.Tx, isNew, allowHighFees,
 	// Keep track of votes separately.
 	if isVote {
 		mp.votesMtx.Lock()
-		mp.insertVote(tx)
+		leaderChange := mp.insertVote(tx)
 		mp.votesMtx.Unlock()
+
+		// Notify callback about a change to the most favorable block at the
+		// height voted on if requested.
+		if leaderChange != nil && mp.cfg.OnVoteLeaderChanged != nil {
+			mp.cfg.OnVoteLeaderChanged(leaderChange.height,
+				&leaderChange.oldLeader, &leaderChange.newLeader)
+		}
 	}
 
 	// Keep track of tspends separately.
@@ -1839,6 +2145,44 @@ func (mp *TxPool) MaybeAcceptTransaction(tx *dcrutil.Tx, isNew bool) ([]*chainha
 	return hashes, err
 }
 
+// CheckAcceptTransaction performs the same validation as ProcessTransaction
+// without adding the transaction to the memory pool or otherwise modifying
+// it.  It returns a description of the transaction, which includes the fee it
+// pays, when the transaction would be accepted.  Transactions that reference
+// inputs which are not available are rejected as orphans since they would not
+// be accepted into the main pool.
+//
+// Each call is independent of any others, so transactions that spend outputs
+// of other transactions that are only being checked, as opposed to already
+// being in the pool, are also treated as orphans.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) CheckAcceptTransaction(tx *dcrutil.Tx, allowHighFees bool) (*TxDesc, error) {
+	// Create agenda flags for checking transactions based on which ones are
+	// active or should otherwise always be enforced.
+	checkTxFlags, err := mp.determineCheckTxFlags()
+	if err != nil {
+		return nil, err
+	}
+
+	// Protect concurrent access.
+	mp.mtx.RLock()
+	txDesc, _, missingParents, err := mp.validateTransaction(tx,
+		allowHighFees, true, checkTxFlags)
+	mp.mtx.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	if len(missingParents) > 0 {
+		str := fmt.Sprintf("orphan transaction %v references outputs of "+
+			"unknown or fully-spent transaction %v", tx.Hash(),
+			missingParents[0])
+		return nil, txRuleError(ErrOrphan, str)
+	}
+
+	return txDesc, nil
+}
+
 // isDoubleSpendOrDuplicateError returns whether or not the passed error, which
 // is expected to have come from mempool, indicates a transaction was rejected
 // either due to containing a double spend or already existing in the pool.
@@ -1885,7 +2229,8 @@ func (mp *TxPool) MaybeAcceptTransactions(txns []*dcrutil.Tx) error {
 		delete(transientPool, *tx.Hash())
 		_, err := mp.maybeAcceptTransaction(tx, false, true, true, checkTxFlags)
 		if err != nil && !isDoubleSpendOrDuplicateError(err) {
-			mp.removeTransaction(tx, true)
+			mp.recordEvent(EventRejected, tx.Hash(), err.Error())
+			mp.removeTransaction(tx, true, EventEvicted, err.Error())
 			continue
 		}
 		if err != nil {
@@ -2002,17 +2347,20 @@ func (mp *TxPool) pruneStakeTx(requiredStakeDifficulty, height int64, isAutoRevo
 		txType := txDesc.Type
 		if txType == stake.TxTypeSStx &&
 			txDesc.Height+int64(heightDiffToPruneTicket) < height {
-			mp.removeTransaction(txDesc.Tx, true)
+			mp.removeTransaction(txDesc.Tx, true, EventEvicted,
+				"ticket was not mined in time")
 			continue
 		}
 		if txType == stake.TxTypeSStx &&
 			txDesc.Tx.MsgTx().TxOut[0].Value < requiredStakeDifficulty {
-			mp.removeTransaction(txDesc.Tx, true)
+			mp.removeTransaction(txDesc.Tx, true, EventEvicted,
+				"ticket price is below the stake difficulty")
 			continue
 		}
 		if (txType == stake.TxTypeSSRtx || txType == stake.TxTypeSSGen) &&
 			txDesc.Height+int64(heightDiffToPruneVotes) < height {
-			mp.removeTransaction(txDesc.Tx, true)
+			mp.removeTransaction(txDesc.Tx, true, EventEvicted,
+				"vote or revocation is too old")
 			continue
 		}
 		if isAutoRevocationsEnabled && txType == stake.TxTypeSSRtx {
@@ -2021,7 +2369,8 @@ func (mp *TxPool) pruneStakeTx(requiredStakeDifficulty, height int64, isAutoRevo
 			// longer valid and should be removed since they require using the header
 			// of the previous block in order to properly calculate the return
 			// amounts.
-			mp.removeTransaction(txDesc.Tx, true)
+			mp.removeTransaction(txDesc.Tx, true, EventEvicted,
+				"revocation is no longer valid")
 			continue
 		}
 	}
@@ -2047,7 +2396,8 @@ func (mp *TxPool) pruneStakeTx(requiredStakeDifficulty, height int64, isAutoRevo
 			// longer valid and should be removed since they require using the header
 			// of the previous block in order to properly calculate the return
 			// amounts.
-			mp.removeTransaction(txDesc.Tx, true)
+			mp.removeTransaction(txDesc.Tx, true, EventEvicted,
+				"revocation is no longer valid")
 			continue
 		}
 	}
@@ -2083,7 +2433,9 @@ func (mp *TxPool) pruneExpiredTx() {
 		if blockchain.IsExpired(tx, nextBlockHeight) {
 			log.Debugf("Pruning expired transaction %v from the mempool",
 				tx.Hash())
-			mp.removeTransaction(tx, true)
+			reason := fmt.Sprintf("expired at height %d",
+				tx.MsgTx().Expiry)
+			mp.removeTransaction(tx, true, EventEvicted, reason)
 		}
 	}
 
@@ -2108,6 +2460,50 @@ func (mp *TxPool) PruneExpiredTx() {
 	mp.mtx.Unlock()
 }
 
+// CalcTxMiningWindow computes the range of blocks after the current best chain
+// tip that may include the passed transaction while satisfying the relative
+// lock times of all of its input sequence numbers and its expiry.  Inputs that
+// spend outputs of transactions in the pool are treated as if they will be
+// included in the next block.
+//
+// Note that sequence locks do not apply to votes or treasury spend
+// transactions since they do not involve spending normal utxos.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) CalcTxMiningWindow(tx *dcrutil.Tx) (*blockchain.TxMiningWindow, error) {
+	isTreasuryEnabled, err := mp.cfg.IsTreasuryAgendaActive()
+	if err != nil {
+		return nil, err
+	}
+
+	// Protect concurrent access.
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	nextBlockHeight := mp.cfg.BestHeight() + 1
+	txType := stake.DetermineTxType(tx.MsgTx())
+	isVote := txType == stake.TxTypeSSGen
+	isTSpend := isTreasuryEnabled && txType == stake.TxTypeTSpend
+	if isVote || isTSpend {
+		seqLock := &blockchain.SequenceLock{MinHeight: -1, MinTime: -1}
+		return blockchain.NewTxMiningWindow(tx, seqLock, nextBlockHeight), nil
+	}
+
+	utxoView, err := mp.fetchInputUtxos(tx, isTreasuryEnabled)
+	if err != nil {
+		return nil, err
+	}
+	seqLock, err := mp.cfg.CalcSequenceLock(tx, utxoView)
+	if err != nil {
+		var cerr blockchain.RuleError
+		if errors.As(err, &cerr) {
+			return nil, chainRuleError(cerr)
+		}
+		return nil, err
+	}
+	return blockchain.NewTxMiningWindow(tx, seqLock, nextBlockHeight), nil
+}
+
 // ProcessOrphans determines if there are any orphans which depend on the passed
 // transaction hash (it is possible that they are no longer orphans) and
 // potentially accepts them to the memory pool.  It repeats the process for the
@@ -2158,6 +2554,7 @@ func (mp *TxPool) ProcessTransaction(tx *dcrutil.Tx, allowOrphan, allowHighFees
 	missingParents, err := mp.maybeAcceptTransaction(tx, true, allowHighFees,
 		true, checkTxFlags)
 	if err != nil {
+		mp.recordEvent(EventRejected, tx.Hash(), err.Error())
 		return nil, err
 	}
 
@@ -2193,14 +2590,40 @@ func (mp *TxPool) ProcessTransaction(tx *dcrutil.Tx, allowOrphan, allowHighFees
 		str := fmt.Sprintf("orphan transaction %v references "+
 			"outputs of unknown or fully-spent "+
 			"transaction %v", tx.Hash(), missingParents[0])
+		mp.recordEvent(EventRejected, tx.Hash(), str)
 		return nil, txRuleError(ErrOrphan, str)
 	}
 
 	// Potentially add the orphan transaction to the orphan pool.
 	err = mp.maybeAddOrphan(tx, tag)
+	if err != nil {
+		mp.recordEvent(EventRejected, tx.Hash(), err.Error())
+	}
 	return nil, err
 }
 
+// MinRelayTxFee returns the minimum transaction fee in DCR/kB that is
+// considered a non-zero fee by the pool policy.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) MinRelayTxFee() dcrutil.Amount {
+	mp.mtx.RLock()
+	fee := mp.cfg.Policy.MinRelayTxFee
+	mp.mtx.RUnlock()
+	return fee
+}
+
+// SetMinRelayTxFee updates the minimum transaction fee in DCR/kB that is
+// considered a non-zero fee by the pool policy.  It only applies to
+// transactions that are accepted into the pool after the update.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) SetMinRelayTxFee(fee dcrutil.Amount) {
+	mp.mtx.Lock()
+	mp.cfg.Policy.MinRelayTxFee = fee
+	mp.mtx.Unlock()
+}
+
 // Count returns the number of transactions in the main pool.  It does not
 // include the orphan pool.
 //
@@ -2213,6 +2636,76 @@ func (mp *TxPool) Count() int {
 	return count
 }
 
+// TotalSize returns the total serialized size in bytes of all of the
+// transactions in the main pool.  It does not include the orphan pool.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) TotalSize() int64 {
+	mp.mtx.RLock()
+	totalSize := mp.totalSize
+	mp.mtx.RUnlock()
+
+	return totalSize
+}
+
+// LowestFeeRateTxDescs returns a slice of descriptors for up to the provided
+// maximum number of transactions in the main pool with the lowest fee rates
+// ordered by increasing fee rate.  The descriptors must be treated as read
+// only.
+//
+// The transactions are tracked in a data structure ordered by fee rate, so this
+// is considerably more efficient than scanning all of the descriptors in the
+// pool when only a few are needed, such as when choosing transactions to evict.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) LowestFeeRateTxDescs(maxDescs int) []*TxDesc {
+	if maxDescs <= 0 {
+		return nil
+	}
+
+	mp.mtx.RLock()
+	if numTxns := mp.feeRates.Len(); numTxns < maxDescs {
+		maxDescs = numTxns
+	}
+	descs := make([]*TxDesc, 0, maxDescs)
+	mp.feeRates.ForEachAscending(func(txDesc *TxDesc) bool {
+		descs = append(descs, txDesc)
+		return len(descs) < maxDescs
+	})
+	mp.mtx.RUnlock()
+
+	return descs
+}
+
+// HighestFeeRateTxDescs returns a slice of descriptors for up to the provided
+// maximum number of transactions in the main pool with the highest fee rates
+// ordered by decreasing fee rate.  The descriptors must be treated as read
+// only.
+//
+// The transactions are tracked in a data structure ordered by fee rate, so this
+// is considerably more efficient than scanning all of the descriptors in the
+// pool when only a few are needed.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) HighestFeeRateTxDescs(maxDescs int) []*TxDesc {
+	if maxDescs <= 0 {
+		return nil
+	}
+
+	mp.mtx.RLock()
+	if numTxns := mp.feeRates.Len(); numTxns < maxDescs {
+		maxDescs = numTxns
+	}
+	descs := make([]*TxDesc, 0, maxDescs)
+	mp.feeRates.ForEachDescending(func(txDesc *TxDesc) bool {
+		descs = append(descs, txDesc)
+		return len(descs) < maxDescs
+	})
+	mp.mtx.RUnlock()
+
+	return descs
+}
+
 // TxHashes returns a slice of hashes for all of the transactions in the memory
 // pool.
 //
@@ -2333,6 +2826,23 @@ func (mp *TxPool) MiningView() *mining.TxMiningView {
 	return view
 }
 
+// RegisterAcceptanceHook registers the provided hook to be invoked in order to
+// apply an additional acceptance policy to all transactions processed after
+// the call.  Hooks are invoked in the order they were registered after the
+// standardness checks and the first one to return an error rejects the
+// transaction with a RuleError of kind ErrAcceptanceHook whose description
+// includes the error.  Hooks may instead return one of the error kinds defined
+// by this package to reject transactions with that kind.
+//
+// Transactions that were already accepted to the pool are not reevaluated.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) RegisterAcceptanceHook(hook AcceptanceHook) {
+	mp.mtx.Lock()
+	mp.acceptanceHooks = append(mp.acceptanceHooks, hook)
+	mp.mtx.Unlock()
+}
+
 // New returns a new memory pool for validating and storing standalone
 // transactions until they are mined into a block.
 func New(cfg *Config) *TxPool {
@@ -2343,11 +2853,15 @@ func New(cfg *Config) *TxPool {
 		orphansByPrev:   make(map[wire.OutPoint]map[chainhash.Hash]*dcrutil.Tx),
 		outpoints:       make(map[wire.OutPoint]*dcrutil.Tx),
 		votes:           make(map[chainhash.Hash][]mining.VoteDesc),
+		votedBlocks:     make(map[int64][]chainhash.Hash),
 		tspends:         make(map[chainhash.Hash]*dcrutil.Tx),
 		nextExpireScan:  time.Now().Add(orphanExpireScanInterval),
 		staged:          make(map[chainhash.Hash]*TxDesc),
 		stagedOutpoints: make(map[wire.OutPoint]*dcrutil.Tx),
 		transient:       make(map[chainhash.Hash]*dcrutil.Tx),
+		feeRates:        newFeeRateTreap(),
+		acceptanceHooks: append([]AcceptanceHook(nil), cfg.AcceptanceHooks...),
+		journal:         newEventJournal(cfg.EventJournalSize),
 	}
 
 	// for a given transaction, scan the mempool to find which transactions
@@ -11,7 +11,9 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"reflect"
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -847,6 +849,7 @@ func newPoolHarness(chainParams *chaincfg.Params) (*poolHarness, []spendableOutp
 			IsSubsidySplitAgendaActive: func() (bool, error) {
 				return harness.subsidySplitActive, nil
 			},
+			EventJournalSize: 100,
 		}),
 	}
 
@@ -1877,6 +1880,106 @@ func TestSequenceLockAcceptance(t *testing.T) {
 	}
 }
 
+// TestCalcTxMiningWindow ensures the mining window calculated for unconfirmed
+// transactions accounts for sequence locks on inputs that spend outputs of
+// transactions in the pool as well as the transaction expiry.
+func TestCalcTxMiningWindow(t *testing.T) {
+	t.Parallel()
+
+	harness, spendableOuts, err := newPoolHarness(chaincfg.MainNetParams())
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+
+	// Create a transaction from the first spendable output provided by the
+	// harness and add it to the pool.
+	parentTx, err := harness.CreateTx(spendableOuts[0])
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	_, err = harness.txPool.ProcessTransaction(parentTx, false, true, 0)
+	if err != nil {
+		t.Fatalf("unable to process transaction: %v", err)
+	}
+
+	// Outputs of transactions in the pool are treated as if they will be
+	// included in the next block.
+	nextHeight := harness.chain.BestHeight() + 1
+	parentOut := txOutToSpendableOut(parentTx, 0, wire.TxTreeRegular)
+	tests := []struct {
+		name     string                    // test description
+		inputs   []spendableOutput         // inputs to spend
+		sequence uint32                    // sequence number used for inputs
+		expiry   uint32                    // transaction expiry
+		want     blockchain.TxMiningWindow // expected window
+		empty    bool                      // whether the window is expected to be empty
+		err      error                     // expected error
+	}{{
+		name:     "no sequence lock or expiry",
+		inputs:   []spendableOutput{parentOut},
+		sequence: wire.MaxTxInSequenceNum,
+		expiry:   wire.NoExpiryValue,
+		want:     blockchain.TxMiningWindow{MinHeight: nextHeight, MaxHeight: -1},
+	}, {
+		name:     "by-height lock on pool parent with expiry",
+		inputs:   []spendableOutput{parentOut},
+		sequence: mustLockTimeToSeq(false, 3),
+		expiry:   uint32(nextHeight + 10),
+		want: blockchain.TxMiningWindow{
+			MinHeight: nextHeight + 3,
+			MaxHeight: nextHeight + 9,
+		},
+	}, {
+		name:     "expires before sequence lock is satisfied",
+		inputs:   []spendableOutput{parentOut},
+		sequence: mustLockTimeToSeq(false, 5),
+		expiry:   uint32(nextHeight + 2),
+		want: blockchain.TxMiningWindow{
+			MinHeight: nextHeight + 5,
+			MaxHeight: nextHeight + 1,
+		},
+		empty: true,
+	}, {
+		name: "unknown input",
+		inputs: []spendableOutput{{
+			amount:   dcrutil.Amount(5000000000),
+			outPoint: wire.OutPoint{Hash: chainhash.Hash{0x01}, Index: 0},
+		}},
+		sequence: mustLockTimeToSeq(false, 1),
+		expiry:   wire.NoExpiryValue,
+		err:      blockchain.ErrMissingTxOut,
+	}}
+	for _, test := range tests {
+		tx, err := harness.CreateSignedTx(test.inputs, 1, func(tx *wire.MsgTx) {
+			tx.Version = 2
+			tx.Expiry = test.expiry
+			for _, txIn := range tx.TxIn {
+				txIn.Sequence = test.sequence
+			}
+		})
+		if err != nil {
+			t.Fatalf("%s: unable to create tx: %v", test.name, err)
+		}
+
+		window, err := harness.txPool.CalcTxMiningWindow(tx)
+		if !errors.Is(err, test.err) {
+			t.Fatalf("%s: unexpected err -- got %v, want %v", test.name, err,
+				test.err)
+		}
+		if err != nil {
+			continue
+		}
+		if *window != test.want {
+			t.Fatalf("%s: mismatched window -- got %+v, want %+v", test.name,
+				*window, test.want)
+		}
+		if window.IsEmpty() != test.empty {
+			t.Fatalf("%s: mismatched empty -- got %v, want %v", test.name,
+				window.IsEmpty(), test.empty)
+		}
+	}
+}
+
 // TestMaxVoteDoubleSpendRejection ensures that votes that spend the same ticket
 // while voting on different blocks are accepted to the pool until the maximum
 // allowed is reached and rejected afterwards.
@@ -2318,6 +2421,287 @@ func TestRemoveDoubleSpends(t *testing.T) {
 	}
 }
 
+// TestCheckBlockConflicts ensures that checking a block for conflicts with the
+// pool reports the expected transactions without modifying the pool.
+func TestCheckBlockConflicts(t *testing.T) {
+	t.Parallel()
+
+	harness, spendableOuts, err := newPoolHarness(chaincfg.MainNetParams())
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	tc := &testContext{t, harness}
+
+	// Create and add a transaction with two outputs that spends the first
+	// spendable output provided by the harness along with a chain of
+	// transactions rooted with its first output and another transaction that
+	// spends its second output.
+	parentTx, err := harness.CreateSignedTx(spendableOuts[0:1], 2)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	parentOut0 := txOutToSpendableOut(parentTx, 0, wire.TxTreeRegular)
+	parentOut1 := txOutToSpendableOut(parentTx, 1, wire.TxTreeRegular)
+	chainedTxns, err := harness.CreateTxChain(parentOut0, 3)
+	if err != nil {
+		t.Fatalf("unable to create transaction chain: %v", err)
+	}
+	minedTx, err := harness.CreateTx(parentOut1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	poolTxns := append([]*dcrutil.Tx{parentTx, minedTx}, chainedTxns...)
+	for _, tx := range poolTxns {
+		_, err := harness.txPool.ProcessTransaction(tx, false, true, 0)
+		if err != nil {
+			t.Fatalf("ProcessTransaction: failed to accept valid tx: %v",
+				err)
+		}
+	}
+
+	// Create a block that includes the parent transaction, the transaction
+	// that spends its second output, and a transaction that double spends
+	// the root of the chain.
+	doubleSpendTx, err := harness.CreateSignedTx([]spendableOutput{parentOut0},
+		2)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	coinbase := wire.NewMsgTx()
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{},
+		wire.MaxPrevOutIndex, wire.TxTreeRegular), 0, nil))
+	block := dcrutil.NewBlock(&wire.MsgBlock{
+		Transactions: []*wire.MsgTx{coinbase, parentTx.MsgTx(),
+			minedTx.MsgTx(), doubleSpendTx.MsgTx()},
+	})
+
+	// Ensure the expected conflicts are reported.
+	conflicts := harness.txPool.CheckBlockConflicts(block)
+	wantMined := []chainhash.Hash{*parentTx.Hash(), *minedTx.Hash()}
+	if !reflect.DeepEqual(conflicts.Mined, wantMined) {
+		t.Fatalf("unexpected mined txns -- got %v, want %v",
+			conflicts.Mined, wantMined)
+	}
+	wantDoubleSpent := []chainhash.Hash{*chainedTxns[0].Hash()}
+	if !reflect.DeepEqual(conflicts.DoubleSpent, wantDoubleSpent) {
+		t.Fatalf("unexpected double spent txns -- got %v, want %v",
+			conflicts.DoubleSpent, wantDoubleSpent)
+	}
+	wantEvicted := make([]chainhash.Hash, 0, len(chainedTxns))
+	for _, tx := range chainedTxns {
+		wantEvicted = append(wantEvicted, *tx.Hash())
+	}
+	if !reflect.DeepEqual(conflicts.Evicted, wantEvicted) {
+		t.Fatalf("unexpected evicted txns -- got %v, want %v",
+			conflicts.Evicted, wantEvicted)
+	}
+
+	// Ensure the pool was not modified.
+	for _, tx := range poolTxns {
+		testPoolMembership(tc, tx, false, true)
+	}
+
+	// Ensure removing the conflicts reports the same conflicts and only
+	// removes the evicted transactions from the pool.
+	removed := harness.txPool.RemoveBlockConflicts(block)
+	if !reflect.DeepEqual(removed, conflicts) {
+		t.Fatalf("unexpected removed conflicts -- got %+v, want %+v",
+			removed, conflicts)
+	}
+	for _, tx := range chainedTxns {
+		testPoolMembership(tc, tx, false, false)
+	}
+	testPoolMembership(tc, parentTx, false, true)
+	testPoolMembership(tc, minedTx, false, true)
+}
+
+// TestRemoveBlockConflictsNullInputs ensures removing the conflicts for a block
+// does not evict votes from the pool due to their stakebase inputs having the
+// same null outpoint as the coinbase of the block.
+func TestRemoveBlockConflictsNullInputs(t *testing.T) {
+	t.Parallel()
+
+	harness, spendableOuts, err := newPoolHarness(chaincfg.MainNetParams())
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	tc := &testContext{t, harness}
+	harness.chain.SetHeight(harness.chainParams.StakeValidationHeight)
+
+	// Create a mature ticket and add a vote that spends it to the pool.
+	tx, err := harness.CreateSignedTx(spendableOuts[0:1], 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	ticket, err := harness.CreateTicketPurchase(txOutToSpendableOut(tx, 0,
+		wire.TxTreeRegular), 40000)
+	if err != nil {
+		t.Fatalf("unable to create ticket purchase transaction: %v", err)
+	}
+	ticketHeight := harness.chain.BestHeight() -
+		int64(harness.chainParams.TicketMaturity) - 1
+	harness.AddFakeUTXO(ticket, ticketHeight, wire.NullBlockIndex)
+	vote, err := harness.CreateVote(ticket)
+	if err != nil {
+		t.Fatalf("unable to create vote: %v", err)
+	}
+	_, err = harness.txPool.ProcessTransaction(vote, false, true, 0)
+	if err != nil {
+		t.Fatalf("ProcessTransaction: failed to accept valid vote: %v", err)
+	}
+
+	// Ensure connecting a block with a coinbase reports no conflicts and
+	// the vote remains in the pool.
+	coinbase := wire.NewMsgTx()
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{},
+		wire.MaxPrevOutIndex, wire.TxTreeRegular), 0, nil))
+	block := dcrutil.NewBlock(&wire.MsgBlock{
+		Transactions: []*wire.MsgTx{coinbase},
+	})
+	conflicts := harness.txPool.RemoveBlockConflicts(block)
+	if !reflect.DeepEqual(conflicts, &blockchain.BlockConflicts{}) {
+		t.Fatalf("unexpected conflicts -- got %+v, want none", conflicts)
+	}
+	testPoolMembership(tc, vote, false, true)
+}
+
+// TestVoteLeaderChanges ensures votes for competing blocks at the same height
+// are tracked and that changes to the most favorable block to build on are
+// signaled.
+func TestVoteLeaderChanges(t *testing.T) {
+	t.Parallel()
+
+	harness, spendableOuts, err := newPoolHarness(chaincfg.MainNetParams())
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	harness.chain.SetHeight(harness.chainParams.StakeValidationHeight)
+	height := harness.chain.BestHeight()
+
+	// Record any signaled changes to the most favorable block.
+	type leaderChange struct {
+		height               int64
+		oldLeader, newLeader chainhash.Hash
+	}
+	var changes []leaderChange
+	harness.txPool.cfg.OnVoteLeaderChanged = func(height int64, oldLeader, newLeader *chainhash.Hash) {
+		changes = append(changes, leaderChange{height, *oldLeader, *newLeader})
+	}
+
+	// Create mature tickets spending the outputs of a regular transaction that
+	// spends the first spendable output provided by the harness.
+	const numTickets = 3
+	tx, err := harness.CreateSignedTx(spendableOuts[0:1], numTickets)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	ticketHeight := height - int64(harness.chainParams.TicketMaturity) - 1
+	tickets := make([]*dcrutil.Tx, 0, numTickets)
+	for i := uint32(0); i < numTickets; i++ {
+		spend := txOutToSpendableOut(tx, i, wire.TxTreeRegular)
+		ticket, err := harness.CreateTicketPurchase(spend, 40000,
+			func(ticket *wire.MsgTx) {
+				ticket.TxIn[0].PreviousOutPoint.Index = i
+			})
+		if err != nil {
+			t.Fatalf("unable to create ticket purchase transaction: %v",
+				err)
+		}
+		harness.AddFakeUTXO(ticket, ticketHeight, wire.NullBlockIndex)
+		tickets = append(tickets, ticket)
+	}
+
+	// voteOn returns a munger that modifies a vote to vote on the provided
+	// block at the best height of the harness.
+	voteOn := func(blockHash chainhash.Hash) func(*wire.MsgTx) {
+		return func(vote *wire.MsgTx) {
+			script, err := txscript.GenerateSSGenBlockRef(blockHash,
+				uint32(height))
+			if err != nil {
+				t.Fatalf("unable to create block reference script: %v", err)
+			}
+			vote.TxOut[0].PkScript = script
+		}
+	}
+	disapprove := func(vote *wire.MsgTx) {
+		voteBits := stake.VoteBits{Bits: 0xfe, ExtendedBits: []byte{}}
+		script, err := newVoteScript(voteBits)
+		if err != nil {
+			t.Fatalf("unable to create vote script: %v", err)
+		}
+		vote.TxOut[1].PkScript = script
+	}
+	processVote := func(ticket *dcrutil.Tx, mungers ...func(*wire.MsgTx)) {
+		t.Helper()
+		vote, err := harness.CreateVote(ticket, mungers...)
+		if err != nil {
+			t.Fatalf("unable to create vote: %v", err)
+		}
+		_, err = harness.txPool.ProcessTransaction(vote, false, true, 0)
+		if err != nil {
+			t.Fatalf("ProcessTransaction: failed to accept valid vote: %v",
+				err)
+		}
+	}
+
+	// Vote on the current best block and then once on a competing block at
+	// the same height while disapproving its regular transaction tree.
+	// Ensure the best block remains the most favorable since the number of
+	// votes is the same and more of its votes approve its regular tree.
+	blockA := *harness.chain.BestHash()
+	blockB := chainhash.Hash{0x01}
+	processVote(tickets[0], voteOn(blockA))
+	processVote(tickets[1], voteOn(blockB), disapprove)
+	if len(changes) != 0 {
+		t.Fatalf("unexpected leader changes: %+v", changes)
+	}
+	gotBlocks := harness.txPool.BlocksVotedOnAtHeight(height)
+	wantBlocks := []chainhash.Hash{blockA, blockB}
+	if !reflect.DeepEqual(gotBlocks, wantBlocks) {
+		t.Fatalf("unexpected blocks voted on -- got %v, want %v", gotBlocks,
+			wantBlocks)
+	}
+
+	// Vote on the competing block again and ensure it becomes the most
+	// favorable block along with signaling the change.
+	processVote(tickets[2], voteOn(blockB))
+	wantChanges := []leaderChange{{height, blockA, blockB}}
+	if !reflect.DeepEqual(changes, wantChanges) {
+		t.Fatalf("unexpected leader changes -- got %+v, want %+v", changes,
+			wantChanges)
+	}
+	gotTallies := mining.VoteTalliesAtHeight(harness.txPool, height)
+	wantTallies := []mining.BlockVoteTally{
+		{Hash: blockB, NumVotes: 2, NumApprovals: 1},
+		{Hash: blockA, NumVotes: 1, NumApprovals: 1},
+	}
+	if !reflect.DeepEqual(gotTallies, wantTallies) {
+		t.Fatalf("unexpected vote tallies -- got %+v, want %+v", gotTallies,
+			wantTallies)
+	}
+
+	// Ensure pruning the blocks voted on prior to the height keeps them and
+	// pruning those prior to the next height removes them without removing
+	// the votes themselves.
+	harness.txPool.PruneVotedBlocks(height)
+	gotBlocks = harness.txPool.BlocksVotedOnAtHeight(height)
+	if !reflect.DeepEqual(gotBlocks, wantBlocks) {
+		t.Fatalf("unexpected blocks voted on after pruning prior blocks -- "+
+			"got %v, want %v", gotBlocks, wantBlocks)
+	}
+	harness.txPool.PruneVotedBlocks(height + 1)
+	gotBlocks = harness.txPool.BlocksVotedOnAtHeight(height)
+	if len(gotBlocks) != 0 {
+		t.Fatalf("unexpected blocks voted on after pruning -- got %v, want "+
+			"none", gotBlocks)
+	}
+	numVotes := len(harness.txPool.VoteHashesForBlock(&blockB))
+	if numVotes != 2 {
+		t.Fatalf("unexpected number of votes after pruning -- got %d, want %d",
+			numVotes, 2)
+	}
+}
+
 // createTSpend creates a treasury spend transaction given the specified
 // parameters. A single output is created that pays to a test OP_TRUE P2SH
 // script.
@@ -3379,3 +3763,416 @@ func TestMaybeAcceptTransactions(t *testing.T) {
 
 	testExpectedAncestorFee(txC, txAFee+txBFee)
 }
+
+// TestFeeRateTracking ensures the mempool correctly tracks the total size of
+// the transactions in the pool and returns the transactions with the lowest
+// and highest fee rates in the expected order as transactions are added and
+// removed.
+func TestFeeRateTracking(t *testing.T) {
+	t.Parallel()
+
+	harness, spendableOuts, err := newPoolHarness(chaincfg.MainNetParams())
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	txPool := harness.txPool
+
+	// Create a transaction that splits the spendable output into several
+	// outputs and then transactions that spend each of those outputs while
+	// paying increasing fees.
+	const numChildren = 4
+	parentTx, err := harness.CreateSignedTx(spendableOuts, numChildren)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	txns := []*dcrutil.Tx{parentTx}
+	for i := uint32(0); i < numChildren; i++ {
+		extraFee := int64(i+1) * 10000
+		out := txOutToSpendableOut(parentTx, i, wire.TxTreeRegular)
+		tx, err := harness.CreateSignedTx([]spendableOutput{out}, 1,
+			func(tx *wire.MsgTx) {
+				tx.TxOut[0].Value -= extraFee
+			})
+		if err != nil {
+			t.Fatalf("unable to create transaction: %v", err)
+		}
+		txns = append(txns, tx)
+	}
+
+	// Add the transactions to the pool in reverse fee order after the parent
+	// and ensure the total size is updated accordingly.
+	var wantTotalSize int64
+	for i := range txns {
+		tx := txns[0]
+		if i > 0 {
+			tx = txns[len(txns)-i]
+		}
+		_, err := txPool.ProcessTransaction(tx, false, true, 0)
+		if err != nil {
+			t.Fatalf("failed to accept transaction %d: %v", i, err)
+		}
+		wantTotalSize += int64(tx.MsgTx().SerializeSize())
+		if got := txPool.TotalSize(); got != wantTotalSize {
+			t.Fatalf("mismatched total size -- got %d, want %d", got,
+				wantTotalSize)
+		}
+	}
+
+	// checkOrder ensures the provided descriptors are for the provided
+	// transactions in order.
+	checkOrder := func(name string, descs []*TxDesc, want []*dcrutil.Tx) {
+		t.Helper()
+		if len(descs) != len(want) {
+			t.Fatalf("%s: mismatched number of descriptors -- got %d, want %d",
+				name, len(descs), len(want))
+		}
+		for i, desc := range descs {
+			if *desc.Tx.Hash() != *want[i].Hash() {
+				t.Fatalf("%s: mismatched tx at index %d -- got %v, want %v",
+					name, i, desc.Tx.Hash(), want[i].Hash())
+			}
+		}
+	}
+
+	// Ensure the transactions are returned in order of fee rate noting that
+	// the parent transaction pays the lowest fee rate.
+	checkOrder("lowest", txPool.LowestFeeRateTxDescs(3), txns[:3])
+	checkOrder("highest", txPool.HighestFeeRateTxDescs(2),
+		[]*dcrutil.Tx{txns[4], txns[3]})
+	checkOrder("lowest all", txPool.LowestFeeRateTxDescs(100), txns)
+	checkOrder("lowest none", txPool.LowestFeeRateTxDescs(0), nil)
+
+	// Remove the highest fee rate transaction and ensure it is no longer
+	// tracked.
+	txPool.RemoveTransaction(txns[4], false)
+	wantTotalSize -= int64(txns[4].MsgTx().SerializeSize())
+	if got := txPool.TotalSize(); got != wantTotalSize {
+		t.Fatalf("mismatched total size after removal -- got %d, want %d",
+			got, wantTotalSize)
+	}
+	checkOrder("highest after removal", txPool.HighestFeeRateTxDescs(1),
+		[]*dcrutil.Tx{txns[3]})
+
+	// Remove the parent along with all of its redeemers and ensure nothing
+	// remains.
+	txPool.RemoveTransaction(txns[0], true)
+	if got := txPool.TotalSize(); got != 0 {
+		t.Fatalf("mismatched total size after removing all -- got %d, want 0",
+			got)
+	}
+	checkOrder("lowest after removing all", txPool.LowestFeeRateTxDescs(10),
+		nil)
+}
+
+// TestAcceptanceHooks ensures transactions are only accepted to the pool when
+// all of the registered acceptance hooks accept them and that the hooks are
+// provided the outputs the transactions spend.
+func TestAcceptanceHooks(t *testing.T) {
+	t.Parallel()
+
+	harness, spendableOuts, err := newPoolHarness(chaincfg.MainNetParams())
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	tc := &testContext{t, harness}
+
+	// Register a hook that rejects transactions with null data outputs and
+	// ensures the spent outputs are available in the provided view.
+	errNullData := errors.New("null data outputs are not allowed")
+	var numInvoked int
+	harness.txPool.RegisterAcceptanceHook(func(tx *dcrutil.Tx, view *blockchain.UtxoViewpoint) error {
+		numInvoked++
+		for _, txIn := range tx.MsgTx().TxIn {
+			if view.LookupEntry(txIn.PreviousOutPoint) == nil {
+				t.Fatalf("missing utxo entry for %v", txIn.PreviousOutPoint)
+			}
+		}
+		for _, txOut := range tx.MsgTx().TxOut {
+			if stdscript.IsNullDataScript(txOut.Version, txOut.PkScript) {
+				return errNullData
+			}
+		}
+		return nil
+	})
+
+	// Ensure a transaction with a null data output is rejected with the
+	// expected error and is not added to the pool.
+	rejectedTx, err := harness.CreateSignedTx(spendableOuts[0:1], 1,
+		func(tx *wire.MsgTx) {
+			script := []byte{txscript.OP_RETURN, txscript.OP_DATA_2, 0x01, 0x02}
+			tx.AddTxOut(wire.NewTxOut(0, script))
+		})
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	_, err = harness.txPool.ProcessTransaction(rejectedTx, false, true, 0)
+	if !errors.Is(err, ErrAcceptanceHook) {
+		t.Fatalf("ProcessTransaction: did not get expected ErrAcceptanceHook "+
+			"-- got %v", err)
+	}
+	if !strings.Contains(err.Error(), errNullData.Error()) {
+		t.Fatalf("ProcessTransaction: error does not describe hook error -- "+
+			"got %v", err)
+	}
+	testPoolMembership(tc, rejectedTx, false, false)
+
+	// Ensure a transaction without a null data output is accepted.
+	tx, err := harness.CreateTx(spendableOuts[0])
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	_, err = harness.txPool.ProcessTransaction(tx, false, true, 0)
+	if err != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx: %v", err)
+	}
+	testPoolMembership(tc, tx, false, true)
+
+	// Register a second hook that rejects everything with a specific error
+	// kind and ensure it applies to subsequent transactions.
+	harness.txPool.RegisterAcceptanceHook(func(*dcrutil.Tx, *blockchain.UtxoViewpoint) error {
+		return ErrNonStandard
+	})
+	tx2, err := harness.CreateTx(txOutToSpendableOut(tx, 0, 0))
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	_, err = harness.txPool.ProcessTransaction(tx2, false, true, 0)
+	if !errors.Is(err, ErrNonStandard) {
+		t.Fatalf("ProcessTransaction: did not get expected ErrNonStandard "+
+			"-- got %v", err)
+	}
+	testPoolMembership(tc, tx2, false, false)
+
+	if numInvoked != 3 {
+		t.Fatalf("unexpected number of hook invocations -- got %d, want 3",
+			numInvoked)
+	}
+}
+
+// TestCheckAcceptTransaction ensures checking whether or not transactions would
+// be accepted into the pool reports the expected results without modifying the
+// pool.
+func TestCheckAcceptTransaction(t *testing.T) {
+	t.Parallel()
+
+	harness, spendableOuts, err := newPoolHarness(chaincfg.MainNetParams())
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	tc := &testContext{t, harness}
+
+	// Create a chain of transactions rooted with the first spendable output
+	// provided by the harness.
+	chainedTxns, err := harness.CreateTxChain(spendableOuts[0], 2)
+	if err != nil {
+		t.Fatalf("unable to create transaction chain: %v", err)
+	}
+	parent, child := chainedTxns[0], chainedTxns[1]
+
+	// Ensure the parent is reported as acceptable with the expected fee and
+	// size without being added to the pool.
+	txDesc, err := harness.txPool.CheckAcceptTransaction(parent, false)
+	if err != nil {
+		t.Fatalf("CheckAcceptTransaction: failed to check tx: %v", err)
+	}
+	wantFee := int64(spendableOuts[0].amount)
+	for _, txOut := range parent.MsgTx().TxOut {
+		wantFee -= txOut.Value
+	}
+	if txDesc.Fee != wantFee {
+		t.Fatalf("unexpected fee -- got %d, want %d", txDesc.Fee, wantFee)
+	}
+	wantSize := int64(parent.MsgTx().SerializeSize())
+	if txDesc.TxSize != wantSize {
+		t.Fatalf("unexpected size -- got %d, want %d", txDesc.TxSize,
+			wantSize)
+	}
+	testPoolMembership(tc, parent, false, false)
+
+	// Ensure the child is rejected as an orphan since the parent is not in
+	// the pool and that it is not added to the orphan pool.
+	_, err = harness.txPool.CheckAcceptTransaction(child, false)
+	if !errors.Is(err, ErrOrphan) {
+		t.Fatalf("CheckAcceptTransaction: did not get expected ErrOrphan "+
+			"-- got %v", err)
+	}
+	testPoolMembership(tc, child, false, false)
+
+	// Add the parent to the pool and ensure it is now rejected as a
+	// duplicate while the child is reported as acceptable.
+	_, err = harness.txPool.ProcessTransaction(parent, false, false, 0)
+	if err != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx: %v", err)
+	}
+	_, err = harness.txPool.CheckAcceptTransaction(parent, false)
+	if !errors.Is(err, ErrDuplicate) {
+		t.Fatalf("CheckAcceptTransaction: did not get expected ErrDuplicate "+
+			"-- got %v", err)
+	}
+	_, err = harness.txPool.CheckAcceptTransaction(child, false)
+	if err != nil {
+		t.Fatalf("CheckAcceptTransaction: failed to check tx: %v", err)
+	}
+	testPoolMembership(tc, child, false, false)
+}
+
+// TestFeeRateAdjustment ensures the fee rate adjustment policy is applied to
+// the minimum fee transactions are required to pay and that the result is
+// recorded in the descriptors of the accepted transactions.
+func TestFeeRateAdjustment(t *testing.T) {
+	t.Parallel()
+
+	harness, spendableOuts, err := newPoolHarness(chaincfg.MainNetParams())
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	tc := &testContext{t, harness}
+	txPool := harness.txPool
+
+	// fetchTxDesc returns the descriptor for the provided transaction in the
+	// pool.
+	fetchTxDesc := func(tx *dcrutil.Tx) *TxDesc {
+		t.Helper()
+		for _, desc := range txPool.TxDescs() {
+			if *desc.Tx.Hash() == *tx.Hash() {
+				return desc
+			}
+		}
+		t.Fatalf("transaction %v is not in the pool", tx.Hash())
+		return nil
+	}
+
+	// Ensure a regular transaction that pays the minimum relay fee is
+	// accepted with the default policy and its descriptor reflects the
+	// required fee.  The transaction splits the spendable output so the
+	// outputs may be spent by the remaining tests.
+	tx, err := harness.CreateSignedTx(spendableOuts, 2)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	_, err = txPool.ProcessTransaction(tx, false, true, 0)
+	if err != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx: %v", err)
+	}
+	desc := fetchTxDesc(tx)
+	wantFee := calcMinRequiredTxRelayFee(desc.TxSize,
+		txPool.cfg.Policy.MinRelayTxFee)
+	if desc.FeeRatePercent != StandardFeeRatePercent ||
+		desc.RequiredFee != wantFee {
+
+		t.Fatalf("unexpected fee metadata -- got %d%% (%d atoms), want "+
+			"%d%% (%d atoms)", desc.FeeRatePercent, desc.RequiredFee,
+			StandardFeeRatePercent, wantFee)
+	}
+
+	// Ensure a surcharge on regular transactions results in the rejection of
+	// a transaction that only pays the minimum relay fee.
+	txPool.cfg.Policy.FeeRateAdjustment = func(tx *dcrutil.Tx, txType stake.TxType) uint32 {
+		if txType == stake.TxTypeRegular {
+			return 2 * StandardFeeRatePercent
+		}
+		return DefaultFeeRateAdjustment(tx, txType)
+	}
+	tx2, err := harness.CreateTx(txOutToSpendableOut(tx, 0,
+		wire.TxTreeRegular))
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	_, err = txPool.ProcessTransaction(tx2, false, true, 0)
+	if !errors.Is(err, ErrInsufficientFee) {
+		t.Fatalf("ProcessTransaction: did not get expected "+
+			"ErrInsufficientFee -- got %v", err)
+	}
+	testPoolMembership(tc, tx2, false, false)
+
+	// Ensure exempting regular transactions allows a transaction that does
+	// not pay any fee to be accepted.
+	txPool.cfg.Policy.FeeRateAdjustment = func(*dcrutil.Tx, stake.TxType) uint32 {
+		return 0
+	}
+	freeOut := txOutToSpendableOut(tx, 1, wire.TxTreeRegular)
+	freeTx, err := harness.CreateSignedTx([]spendableOutput{freeOut}, 1,
+		func(tx *wire.MsgTx) {
+			tx.TxOut[0].Value = tx.TxIn[0].ValueIn
+		})
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	_, err = txPool.ProcessTransaction(freeTx, false, true, 0)
+	if err != nil {
+		t.Fatalf("ProcessTransaction: failed to accept free tx: %v", err)
+	}
+	desc = fetchTxDesc(freeTx)
+	if desc.Fee != 0 || desc.FeeRatePercent != 0 || desc.RequiredFee != 0 {
+		t.Fatalf("unexpected fee metadata -- got fee %d, %d%% (%d atoms), "+
+			"want fee 0, 0%% (0 atoms)", desc.Fee, desc.FeeRatePercent,
+			desc.RequiredFee)
+	}
+}
+
+// TestEventJournal ensures the event journal records transactions being
+// accepted, rejected, evicted, and mined along with the expected reasons.
+func TestEventJournal(t *testing.T) {
+	t.Parallel()
+
+	harness, spendableOuts, err := newPoolHarness(chaincfg.MainNetParams())
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	txPool := harness.txPool
+
+	// Create a chain of transactions and add them to the pool.
+	chainedTxns, err := harness.CreateTxChain(spendableOuts[0], 2)
+	if err != nil {
+		t.Fatalf("unable to create transaction chain: %v", err)
+	}
+	parent, child := chainedTxns[0], chainedTxns[1]
+	for _, tx := range chainedTxns {
+		_, err := txPool.ProcessTransaction(tx, false, false, 0)
+		if err != nil {
+			t.Fatalf("ProcessTransaction: unexpected error: %v", err)
+		}
+	}
+
+	// Attempt to add the parent again so it is rejected as a duplicate, remove
+	// the parent along with its redeemers, and then add the parent back and
+	// remove it as mined.
+	_, dupErr := txPool.ProcessTransaction(parent, false, false, 0)
+	if !errors.Is(dupErr, ErrDuplicate) {
+		t.Fatalf("ProcessTransaction: mismatched error -- got %v, want %v",
+			dupErr, ErrDuplicate)
+	}
+	txPool.RemoveTransaction(parent, true)
+	_, err = txPool.ProcessTransaction(parent, false, false, 0)
+	if err != nil {
+		t.Fatalf("ProcessTransaction: unexpected error: %v", err)
+	}
+	txPool.RemoveMinedTransaction(parent)
+
+	removedReason := fmt.Sprintf("spends output of removed transaction %v",
+		parent.Hash())
+	want := []Event{
+		{Kind: EventAccepted, Hash: *parent.Hash()},
+		{Kind: EventAccepted, Hash: *child.Hash()},
+		{Kind: EventRejected, Hash: *parent.Hash(), Reason: dupErr.Error()},
+		{Kind: EventEvicted, Hash: *child.Hash(), Reason: removedReason},
+		{Kind: EventEvicted, Hash: *parent.Hash(), Reason: "removed"},
+		{Kind: EventAccepted, Hash: *parent.Hash()},
+		{Kind: EventMined, Hash: *parent.Hash()},
+	}
+	events := txPool.Events()
+	if len(events) != len(want) {
+		t.Fatalf("mismatched number of events -- got %d, want %d",
+			len(events), len(want))
+	}
+	for i, event := range events {
+		if event.Time.IsZero() {
+			t.Fatalf("event %d: time is not set", i)
+		}
+		event.Time = time.Time{}
+		if event != want[i] {
+			t.Fatalf("event %d: mismatched event -- got %+v, want %+v", i,
+				event, want[i])
+		}
+	}
+}
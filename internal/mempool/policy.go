@@ -68,8 +68,40 @@ const (
 		txscript.ScriptVerifyCleanStack |
 		txscript.ScriptVerifyCheckLockTimeVerify |
 		txscript.ScriptVerifyCheckSequenceVerify
+
+	// StandardFeeRatePercent is the fee rate adjustment percentage that
+	// requires transactions to pay the full minimum relay fee rate.  See
+	// Policy.FeeRateAdjustment for more details.
+	StandardFeeRatePercent = 100
 )
 
+// DefaultFeeRateAdjustment returns the default percentage of the minimum relay
+// fee rate that the provided transaction of the provided type is required to
+// pay.
+//
+// Votes and revocations are exempt from fees since they are an integral part
+// of the block production process and are required to be feeless.  All other
+// transactions that are allowed in the mempool, namely regular transactions,
+// ticket purchases, treasury adds, and treasury spends, are required to pay
+// the full minimum relay fee rate.
+func DefaultFeeRateAdjustment(tx *dcrutil.Tx, txType stake.TxType) uint32 {
+	switch txType {
+	case stake.TxTypeSSGen, stake.TxTypeSSRtx:
+		return 0
+	}
+	return StandardFeeRatePercent
+}
+
+// calcAdjustedRelayFeeRate returns the minimum relay fee rate adjusted by the
+// provided percentage.  The result is limited to the maximum possible value
+// for monetary amounts.
+func calcAdjustedRelayFeeRate(minRelayTxFee dcrutil.Amount, percent uint32) dcrutil.Amount {
+	if percent != 0 && int64(minRelayTxFee) > dcrutil.MaxAmount/int64(percent) {
+		return dcrutil.MaxAmount
+	}
+	return minRelayTxFee * dcrutil.Amount(percent) / StandardFeeRatePercent
+}
+
 // calcMinRequiredTxRelayFee returns the minimum transaction fee required for a
 // transaction with the passed serialized size to be accepted into the memory
 // pool and relayed.
@@ -316,7 +348,7 @@ func checkTransactionStandard(tx *dcrutil.Tx, txType stake.TxType, height int64,
 	// almost as much to process as the sender fees, limit the maximum
 	// size of a transaction.  This also helps mitigate CPU exhaustion
 	// attacks.
-	serializedLen := msgTx.SerializeSize()
+	serializedLen := tx.SerializeSize()
 	if serializedLen > MaxStandardTxSize {
 		str := fmt.Sprintf("transaction size of %v is larger than max "+
 			"allowed size of %v", serializedLen, MaxStandardTxSize)
@@ -545,3 +545,47 @@ func TestCheckTransactionStandard(t *testing.T) {
 		}
 	}
 }
+
+// TestCalcAdjustedRelayFeeRate ensures adjusting the minimum relay fee rate by
+// a percentage produces the expected results.
+func TestCalcAdjustedRelayFeeRate(t *testing.T) {
+	tests := []struct {
+		name     string         // test description
+		relayFee dcrutil.Amount // minimum relay transaction fee
+		percent  uint32         // adjustment percentage
+		want     dcrutil.Amount // expected adjusted fee rate
+	}{{
+		name:     "exempt",
+		relayFee: DefaultMinRelayTxFee,
+		percent:  0,
+		want:     0,
+	}, {
+		name:     "discount",
+		relayFee: DefaultMinRelayTxFee,
+		percent:  25,
+		want:     DefaultMinRelayTxFee / 4,
+	}, {
+		name:     "standard",
+		relayFee: DefaultMinRelayTxFee,
+		percent:  StandardFeeRatePercent,
+		want:     DefaultMinRelayTxFee,
+	}, {
+		name:     "surcharge",
+		relayFee: DefaultMinRelayTxFee,
+		percent:  250,
+		want:     DefaultMinRelayTxFee * 5 / 2,
+	}, {
+		name:     "surcharge limited to max amount",
+		relayFee: dcrutil.MaxAmount,
+		percent:  200,
+		want:     dcrutil.MaxAmount,
+	}}
+
+	for _, test := range tests {
+		got := calcAdjustedRelayFeeRate(test.relayFee, test.percent)
+		if got != test.want {
+			t.Errorf("%q: unexpected fee rate -- got %v, want %v", test.name,
+				got, test.want)
+		}
+	}
+}
@@ -426,6 +426,54 @@ func (g *BgBlkTmplGenerator) CurrentTemplate() (*BlockTemplate, error) {
 	return template, err
 }
 
+// NewBlockTemplate generates a new block template that pays the coinbase to the
+// provided address instead of one of the configured mining addresses.
+//
+// Unlike the templates provided via CurrentTemplate and subscriptions, the
+// returned template is not shared with any other callers and it is not tracked
+// by the background template generator.  It is primarily intended for callers
+// that need to mine blocks to a specific address in a discrete fashion.
+//
+// This function is safe for concurrent access.
+func (g *BgBlkTmplGenerator) NewBlockTemplate(payToAddr stdaddr.Address) (*BlockTemplate, error) {
+	return g.tg.NewBlockTemplate(payToAddr)
+}
+
+// NewEmptyBlockTemplate generates a new block template that only contains the
+// transactions required for the block to be valid and pays the coinbase to the
+// provided address, or one of the configured mining addresses chosen at random
+// when it is nil.  See BlkTmplGenerator.NewEmptyBlockTemplate for details.
+//
+// Like NewBlockTemplate, the returned template is not shared with any other
+// callers and it is not tracked by the background template generator.
+//
+// This function is safe for concurrent access.
+func (g *BgBlkTmplGenerator) NewEmptyBlockTemplate(payToAddr stdaddr.Address) (*BlockTemplate, error) {
+	if payToAddr == nil {
+		prng := rand.New(rand.NewSource(time.Now().Unix()))
+		payToAddr = g.cfg.MiningAddrs[prng.Intn(len(g.cfg.MiningAddrs))]
+	}
+	return g.tg.NewEmptyBlockTemplate(payToAddr)
+}
+
+// SimulateBlockTemplate selects the transactions that would be included in the
+// next block template from a snapshot of the current transaction source without
+// modifying any state.  See BlkTmplGenerator.SimulateBlockTemplate for details.
+//
+// This function is safe for concurrent access.
+func (g *BgBlkTmplGenerator) SimulateBlockTemplate() (*TemplateSimulation, error) {
+	return g.tg.SimulateBlockTemplate()
+}
+
+// SetTxMinFreeFee updates the minimum fee in Atoms/1000 bytes that is required
+// for a transaction to be treated as free when generating block templates.  See
+// BlkTmplGenerator.SetTxMinFreeFee for details.
+//
+// This function is safe for concurrent access.
+func (g *BgBlkTmplGenerator) SetTxMinFreeFee(fee dcrutil.Amount) {
+	g.tg.SetTxMinFreeFee(fee)
+}
+
 // TemplateSubscription defines a subscription to receive block template updates
 // from the background block template generator.  The caller must call Stop on
 // the subscription when it is no longer needed to free resources.
@@ -9,6 +9,7 @@ import (
 	"context"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"math"
 	"runtime"
 	"sync"
@@ -21,6 +22,7 @@ import (
 	"github.com/decred/dcrd/internal/blockchain"
 	"github.com/decred/dcrd/internal/mining"
 	"github.com/decred/dcrd/internal/staging/primitives"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
 	"github.com/decred/dcrd/wire"
 )
 
@@ -97,6 +99,11 @@ type Config struct {
 	// not either the provided block is itself known to be invalid or is
 	// known to have an invalid ancestor.
 	IsKnownInvalidBlock func(*chainhash.Hash) bool
+
+	// BestSnapshot defines the function to use to obtain a snapshot of the
+	// current best chain state.  This is used by the turbo mining mode to
+	// determine whether or not a template extends the current tip.
+	BestSnapshot func() *blockchain.BestState
 }
 
 // CPUMiner provides facilities for solving blocks (mining) using the CPU in a
@@ -111,6 +118,10 @@ type Config struct {
 // When the CPU miner is first started via the Run method, it will not have any
 // workers which means it will be idle.  The number of worker goroutines for the
 // normal mining mode can be set via the SetNumWorkers method.
+//
+// The discrete mining mode may additionally be switched to a turbo mode on the
+// simulation and regression test networks via the SetTurboMode method.  See its
+// documentation for more details.
 type CPUMiner struct {
 	numWorkers uint32 // update atomically
 
@@ -119,6 +130,7 @@ type CPUMiner struct {
 	cfg               *Config
 	normalMining      bool
 	discreteMining    bool
+	turboMode         bool
 	submitBlockLock   sync.Mutex
 	wg                sync.WaitGroup
 	workerWg          sync.WaitGroup
@@ -682,6 +694,11 @@ func (m *CPUMiner) SetNumWorkers(numWorkers int32) {
 
 	// Set the normal mining state accordingly.
 	if targetNumWorkers != 0 {
+		if !m.normalMining && !m.cfg.ChainParams.GenerateSupported {
+			log.Warnf("CPU mining is enabled on %s which is discouraged "+
+				"since it is extremely unlikely to ever find a block",
+				m.cfg.ChainParams.Net)
+		}
 		m.normalMining = true
 	} else {
 		m.normalMining = false
@@ -702,6 +719,42 @@ func (m *CPUMiner) NumWorkers() int32 {
 	return int32(atomic.LoadUint32(&m.numWorkers))
 }
 
+// SetTurboMode enables or disables the turbo mode for discrete mining.
+//
+// The turbo mode is intended for tests and optimizes the discrete mining mode
+// for speed by generating empty block templates directly instead of waiting for
+// the background block template generator.  In other words, the blocks only
+// contain the transactions required for them to be valid, so transactions in
+// the mempool are not mined.  Once stake validation height is reached, the
+// miner still waits for enough votes on the current tip before extending it.
+//
+// An error is returned when attempting to enable the turbo mode on any network
+// other than the simulation and regression test networks.
+//
+// This function is safe for concurrent access.
+func (m *CPUMiner) SetTurboMode(enable bool) error {
+	net := m.cfg.ChainParams.Net
+	if enable && net != wire.SimNet && net != wire.RegNet {
+		return fmt.Errorf("turbo mining mode is not supported on %s", net)
+	}
+
+	m.Lock()
+	m.turboMode = enable
+	m.Unlock()
+	return nil
+}
+
+// TurboMode returns whether or not the turbo mode for discrete mining is
+// enabled.
+//
+// This function is safe for concurrent access.
+func (m *CPUMiner) TurboMode() bool {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.turboMode
+}
+
 // GenerateNBlocks generates the requested number of blocks in the discrete
 // mining mode and returns a list of the hashes of generated blocks that were
 // added to the main chain.
@@ -719,6 +772,32 @@ func (m *CPUMiner) NumWorkers() int32 {
 // added to a side chain if it happens to be solved around the same time another
 // one shows up.
 func (m *CPUMiner) GenerateNBlocks(ctx context.Context, n uint32) ([]*chainhash.Hash, error) {
+	return m.generateNBlocks(ctx, n, nil)
+}
+
+// GenerateNBlocksToAddress generates the requested number of blocks in the
+// discrete mining mode with coinbases that pay to the provided address instead
+// of the configured mining addresses and returns a list of the hashes of
+// generated blocks that were added to the main chain.
+//
+// It is otherwise identical to GenerateNBlocks.  See its documentation for more
+// details.
+func (m *CPUMiner) GenerateNBlocksToAddress(ctx context.Context, n uint32, payToAddr stdaddr.Address) ([]*chainhash.Hash, error) {
+	if payToAddr == nil {
+		return nil, errors.New("no address to pay generated blocks to " +
+			"was provided")
+	}
+	return m.generateNBlocks(ctx, n, payToAddr)
+}
+
+// generateNBlocks generates the requested number of blocks in the discrete
+// mining mode and returns a list of the hashes of generated blocks that were
+// added to the main chain.  The coinbases of the generated blocks pay to the
+// provided address when it is not nil or one of the configured mining addresses
+// otherwise.
+//
+// See GenerateNBlocks for more details.
+func (m *CPUMiner) generateNBlocks(ctx context.Context, n uint32, payToAddr stdaddr.Address) ([]*chainhash.Hash, error) {
 	// Nothing to do.
 	if n == 0 {
 		return nil, nil
@@ -738,6 +817,7 @@ func (m *CPUMiner) GenerateNBlocks(ctx context.Context, n uint32) ([]*chainhash.
 	}
 
 	m.discreteMining = true
+	turboMode := m.turboMode
 	m.Unlock()
 
 	log.Tracef("Generating %d blocks", n)
@@ -745,6 +825,16 @@ func (m *CPUMiner) GenerateNBlocks(ctx context.Context, n uint32) ([]*chainhash.
 	templateSub := m.g.Subscribe()
 	defer templateSub.Stop()
 
+	if turboMode {
+		blockHashes, err := m.generateNBlocksTurbo(ctx, n, payToAddr,
+			templateSub)
+		log.Tracef("Generated %d blocks", len(blockHashes))
+		m.Lock()
+		m.discreteMining = false
+		m.Unlock()
+		return blockHashes, err
+	}
+
 	blockHashes := make([]*chainhash.Hash, 0, n)
 	var stats speedStats
 out:
@@ -791,7 +881,27 @@ out:
 		//
 		// The block in the template is shallow copied to avoid mutating the
 		// data of the shared template.
-		shallowBlockCopy := *templateNtfn.Template.Block
+		//
+		// When mining to a specific address, a separate template that pays to
+		// it is generated instead.  The shared template notifications are
+		// still used to determine when a template is ready, such as once all
+		// votes have had a chance to arrive, so the separate template is only
+		// used when it builds on the same parent.
+		templateBlock := templateNtfn.Template.Block
+		if payToAddr != nil {
+			template, err := m.g.NewBlockTemplate(payToAddr)
+			if err != nil {
+				m.Lock()
+				m.discreteMining = false
+				m.Unlock()
+				return blockHashes, err
+			}
+			if template.Block.Header.PrevBlock != templateBlock.Header.PrevBlock {
+				continue
+			}
+			templateBlock = template.Block
+		}
+		shallowBlockCopy := *templateBlock
 		if m.solveBlock(ctx, &shallowBlockCopy.Header, &stats) {
 			block := dcrutil.NewBlock(&shallowBlockCopy)
 			if m.submitBlock(block) {
@@ -817,6 +927,67 @@ out:
 	return blockHashes, nil
 }
 
+// generateNBlocksTurbo generates the requested number of blocks using empty
+// block templates that are generated directly and returns a list of the hashes
+// of generated blocks that were added to the main chain.  The provided template
+// subscription is only used to wait for votes to arrive when the generated
+// template does not extend the current tip.
+//
+// This must only be called from generateNBlocks while in the discrete mining
+// mode.
+func (m *CPUMiner) generateNBlocksTurbo(ctx context.Context, n uint32,
+	payToAddr stdaddr.Address, templateSub *mining.TemplateSubscription) ([]*chainhash.Hash, error) {
+
+	blockHashes := make([]*chainhash.Hash, 0, n)
+	var stats speedStats
+	for uint32(len(blockHashes)) < n {
+		select {
+		case <-ctx.Done():
+			return blockHashes, nil
+		case <-m.quit:
+			return blockHashes, nil
+		default:
+		}
+
+		template, err := m.g.NewEmptyBlockTemplate(payToAddr)
+		if err != nil {
+			return blockHashes, err
+		}
+
+		// The template will not extend the current tip when there are not
+		// enough votes for it yet.  Wait for the background template
+		// generator to signal an updated template, which happens when more
+		// votes arrive, and try again.
+		tipHash := m.cfg.BestSnapshot().Hash
+		if template == nil || template.Block.Header.PrevBlock != tipHash {
+			select {
+			case <-ctx.Done():
+				return blockHashes, nil
+			case <-m.quit:
+				return blockHashes, nil
+			case <-templateSub.C():
+			}
+			continue
+		}
+
+		// Attempt to solve and submit the block.  The template is not shared
+		// with any other callers, so there is no need to copy it.
+		msgBlock := template.Block
+		if m.solveBlock(ctx, &msgBlock.Header, &stats) {
+			block := dcrutil.NewBlock(msgBlock)
+			if m.submitBlock(block) {
+				m.Lock()
+				m.discretePrevHash = msgBlock.Header.PrevBlock
+				m.discreteBlockHash = *block.Hash()
+				m.Unlock()
+				blockHashes = append(blockHashes, block.Hash())
+			}
+		}
+	}
+
+	return blockHashes, nil
+}
+
 // New returns a new instance of a CPU miner for the provided configuration
 // options.
 //
@@ -58,6 +58,20 @@ const (
 
 	// ErrSerializeHeader indicates an attempt to serialize a block header failed.
 	ErrSerializeHeader = ErrorKind("ErrSerializeHeader")
+
+	// ErrUnknownJob indicates a solution was submitted for a job that is not
+	// known to the work provider.  This is typically the case when the job
+	// has been pruned because it builds on a block that is no longer the tip
+	// of the chain.
+	ErrUnknownJob = ErrorKind("ErrUnknownJob")
+
+	// ErrInvalidSolution indicates a solution was submitted that modifies
+	// the header of a job in a way that is not permitted by the job.
+	ErrInvalidSolution = ErrorKind("ErrInvalidSolution")
+
+	// ErrHighHash indicates a solution was submitted with a block hash that
+	// does not satisfy the proof-of-work requirement of the job.
+	ErrHighHash = ErrorKind("ErrHighHash")
 )
 
 // Error satisfies the error interface and prints human-readable errors.
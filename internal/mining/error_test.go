@@ -28,6 +28,9 @@ func TestErrorKindStringer(t *testing.T) {
 		{ErrCalcCommitmentRoot, "ErrCalcCommitmentRoot"},
 		{ErrGetTicketInfo, "ErrGetTicketInfo"},
 		{ErrSerializeHeader, "ErrSerializeHeader"},
+		{ErrUnknownJob, "ErrUnknownJob"},
+		{ErrInvalidSolution, "ErrInvalidSolution"},
+		{ErrHighHash, "ErrHighHash"},
 	}
 
 	for i, test := range tests {
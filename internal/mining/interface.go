@@ -49,6 +49,11 @@ type TxSource interface {
 	// pool.
 	VotesForBlocks(hashes []chainhash.Hash) [][]VoteDesc
 
+	// BlocksVotedOnAtHeight returns the hashes of all blocks at the provided
+	// height that have votes currently available in the source pool in the
+	// order the first vote for each of them was seen.
+	BlocksVotedOnAtHeight(height int64) []chainhash.Hash
+
 	// IsRegTxTreeKnownDisapproved returns whether or not the regular
 	// transaction tree of the block represented by the provided hash is
 	// known to be disapproved according to the votes currently in the
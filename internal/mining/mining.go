@@ -6,11 +6,13 @@
 package mining
 
 import (
+	"bytes"
 	"container/heap"
 	"encoding/binary"
 	"fmt"
 	"math"
 	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/decred/dcrd/blockchain/stake/v5"
@@ -403,6 +405,71 @@ func SortParentsByVotes(txSource TxSource, currentTopBlock chainhash.Hash, block
 	return sortedUsefulBlocks
 }
 
+// BlockVoteTally houses the number of votes available for a block along with
+// how many of them approve its regular transaction tree.
+type BlockVoteTally struct {
+	Hash         chainhash.Hash
+	NumVotes     uint16
+	NumApprovals uint16
+}
+
+// isMoreFavorable returns whether or not the tally is more favorable to build
+// on than the provided tally.  Blocks with more votes are more favorable since
+// they are the only ones that are able to be built on once there are not enough
+// votes for the others, while blocks with more votes that approve their regular
+// transaction tree are preferred otherwise since disapproving the tree
+// invalidates all of its transactions.  Ties are broken by the hash to ensure
+// the result is deterministic.
+func (t *BlockVoteTally) isMoreFavorable(other *BlockVoteTally) bool {
+	if t.NumVotes != other.NumVotes {
+		return t.NumVotes > other.NumVotes
+	}
+	if t.NumApprovals != other.NumApprovals {
+		return t.NumApprovals > other.NumApprovals
+	}
+	return bytes.Compare(t.Hash[:], other.Hash[:]) < 0
+}
+
+// TallyVotes returns the vote tallies for the provided blocks given the
+// associated vote descriptors for each of them, in the same order as returned
+// by TxSource.VotesForBlocks, sorted from most to least favorable to build on.
+// Blocks with more votes are the most favorable followed by blocks with more
+// votes that approve their regular transaction tree.
+func TallyVotes(blocks []chainhash.Hash, votes [][]VoteDesc) []BlockVoteTally {
+	tallies := make([]BlockVoteTally, 0, len(blocks))
+	for i := range blocks {
+		tally := BlockVoteTally{Hash: blocks[i]}
+		if i < len(votes) {
+			tally.NumVotes = uint16(len(votes[i]))
+			for _, vote := range votes[i] {
+				if vote.ApprovesParent {
+					tally.NumApprovals++
+				}
+			}
+		}
+		tallies = append(tallies, tally)
+	}
+	sort.Slice(tallies, func(i, j int) bool {
+		return tallies[i].isMoreFavorable(&tallies[j])
+	})
+	return tallies
+}
+
+// VoteTalliesAtHeight returns the vote tallies for all blocks at the provided
+// height with votes available in the provided source sorted from most to least
+// favorable to build on.  This allows the most favorable block to be chosen
+// when there are competing blocks at the same height, such as during natural
+// forks.  See TallyVotes for details regarding the ordering.
+//
+// This function is safe for concurrent access.
+func VoteTalliesAtHeight(txSource TxSource, height int64) []BlockVoteTally {
+	blocks := txSource.BlocksVotedOnAtHeight(height)
+	if len(blocks) == 0 {
+		return nil
+	}
+	return TallyVotes(blocks, txSource.VotesForBlocks(blocks))
+}
+
 // BlockTemplate houses a block that has yet to be solved along with additional
 // details about the fees and the number of signature operations for each
 // transaction in the block.
@@ -1088,19 +1155,47 @@ func (g *BlkTmplGenerator) addAutoRevocationsToQueue(winningTickets map[chainhas
 // See the NewBlockTemplate method for a detailed description of how the block
 // template is generated.
 type BlkTmplGenerator struct {
+	// txMinFreeFee is the minimum fee in Atoms/1000 bytes that is required
+	// for a transaction to be treated as free.  It is initialized from the
+	// policy and may be updated at runtime, so it must only be accessed
+	// atomically.
+	//
+	// The 64-bit field is first to ensure it is aligned for atomic access.
+	txMinFreeFee int64
+
 	cfg *Config
 }
 
 // NewBlkTmplGenerator returns a new block template generator for the given
 // policy using transactions from the provided transaction source.
 func NewBlkTmplGenerator(cfg *Config) *BlkTmplGenerator {
-	return &BlkTmplGenerator{cfg: cfg}
+	return &BlkTmplGenerator{
+		txMinFreeFee: int64(cfg.Policy.TxMinFreeFee),
+		cfg:          cfg,
+	}
+}
+
+// TxMinFreeFee returns the minimum fee in Atoms/1000 bytes that is required
+// for a transaction to be treated as free when generating block templates.
+//
+// This function is safe for concurrent access.
+func (g *BlkTmplGenerator) TxMinFreeFee() dcrutil.Amount {
+	return dcrutil.Amount(atomic.LoadInt64(&g.txMinFreeFee))
+}
+
+// SetTxMinFreeFee updates the minimum fee in Atoms/1000 bytes that is required
+// for a transaction to be treated as free when generating block templates.  It
+// only applies to templates generated after the update.
+//
+// This function is safe for concurrent access.
+func (g *BlkTmplGenerator) SetTxMinFreeFee(fee dcrutil.Amount) {
+	atomic.StoreInt64(&g.txMinFreeFee, int64(fee))
 }
 
 // calcFeePerKb returns an adjusted fee per kilobyte taking the provided
 // transaction and its ancestors into account.
 func calcFeePerKb(txDesc *TxDesc, ancestorStats *TxAncestorStats) float64 {
-	txSize := txDesc.Tx.MsgTx().SerializeSize()
+	txSize := txDesc.Tx.SerializeSize()
 	if ancestorStats.Fees < 0 || ancestorStats.SizeBytes < 0 {
 		return (float64(txDesc.Fee) * float64(kilobyte)) / float64(txSize)
 	}
@@ -1190,6 +1285,40 @@ func calcFeePerKb(txDesc *TxDesc, ancestorStats *TxAncestorStats) float64 {
 // This function returns nil when there are not enough voters on any of the
 // current top blocks to create a new block template.
 func (g *BlkTmplGenerator) NewBlockTemplate(payToAddress stdaddr.Address) (*BlockTemplate, error) {
+	return g.newBlockTemplate(payToAddress, nil, false, false)
+}
+
+// NewEmptyBlockTemplate returns a new block template that is ready to be solved
+// and only contains the transactions that are required for the block to be
+// valid.  That is to say it only contains the coinbase, the treasurybase when
+// the treasury agenda is active, any votes that are required once stake
+// validation height has been reached, and any automatic revocations.  None of
+// the other transactions in the transaction source are considered.
+//
+// This is primarily intended to allow tests to quickly generate blocks without
+// paying the cost of transaction selection.
+//
+// See NewBlockTemplate for further details.
+func (g *BlkTmplGenerator) NewEmptyBlockTemplate(payToAddress stdaddr.Address) (*BlockTemplate, error) {
+	return g.newBlockTemplate(payToAddress, nil, false, true)
+}
+
+// newBlockTemplate returns a new block template that is ready to be solved
+// using the transactions from the provided snapshot of the transaction source.
+// A new snapshot is obtained, after any forced reorganization to the parent
+// with the most votes, when the provided snapshot is nil.
+//
+// When the dry run flag is set, the chain is never forcibly reorganized, so
+// the template always builds on the current best chain tip.
+//
+// When the votes only flag is set, all transactions other than votes are
+// removed from the snapshot prior to transaction selection.
+//
+// See the NewBlockTemplate method for a detailed description of how the block
+// template is generated.
+func (g *BlkTmplGenerator) newBlockTemplate(payToAddress stdaddr.Address,
+	miningView *TxMiningView, dryRun, votesOnly bool) (*BlockTemplate, error) {
+
 	// All transaction scripts are verified using the more strict standard
 	// flags.
 	scriptFlags, err := g.cfg.Policy.StandardVerifyFlags()
@@ -1272,9 +1401,11 @@ func (g *BlkTmplGenerator) NewBlockTemplate(payToAddress stdaddr.Address) (*Bloc
 			eligibleParents[0])
 
 		// Force a reorganization to the parent with the most votes if needed.
+		// Dry runs must not modify the chain, so they always build on the
+		// current tip.
 		for i := range eligibleParents {
 			newHead := &eligibleParents[i]
-			if *newHead == prevHash {
+			if *newHead == prevHash || dryRun {
 				break
 			}
 
@@ -1314,8 +1445,20 @@ func (g *BlkTmplGenerator) NewBlockTemplate(payToAddress stdaddr.Address) (*Bloc
 	// number of items that are available for the priority queue.  Also,
 	// choose the initial sort order for the priority queue based on whether
 	// or not there is an area allocated for high-priority transactions.
-	miningView := g.cfg.TxSource.MiningView()
+	if miningView == nil {
+		miningView = g.cfg.TxSource.MiningView()
+	}
+	if votesOnly {
+		votes := make([]*TxDesc, 0, g.cfg.ChainParams.TicketsPerBlock)
+		for _, txDesc := range miningView.txDescs {
+			if txDesc.Type == stake.TxTypeSSGen {
+				votes = append(votes, txDesc)
+			}
+		}
+		miningView.txDescs = votes
+	}
 	sourceTxns := miningView.TxDescs()
+	txMinFreeFee := g.TxMinFreeFee()
 	sortedByFee := g.cfg.Policy.BlockPrioritySize == 0
 	lessFunc := txPQByStakeAndFeeAndThenPriority
 	if sortedByFee {
@@ -1701,7 +1844,7 @@ nextPriorityQueueItem:
 		}
 
 		// Enforce maximum block size.  Also check for overflow.
-		txSize := uint32(tx.MsgTx().SerializeSize())
+		txSize := uint32(tx.SerializeSize())
 		blockPlusTxSize := blockSize + txSize + uint32(ancestorStats.SizeBytes)
 		if blockPlusTxSize < blockSize ||
 			blockPlusTxSize >= g.cfg.Policy.BlockMaxSize {
@@ -1749,14 +1892,14 @@ nextPriorityQueueItem:
 		// Skip free transactions once the block is larger than the
 		// minimum block size, except for stake transactions.
 		if sortedByFee &&
-			(prioItem.feePerKB < float64(g.cfg.Policy.TxMinFreeFee)) &&
+			(prioItem.feePerKB < float64(txMinFreeFee)) &&
 			(tx.Tree() != wire.TxTreeStake) &&
 			(blockPlusTxSize >= g.cfg.Policy.BlockMinSize) {
 
 			log.Tracef("Skipping tx %s with feePerKB %.2f "+
 				"< TxMinFreeFee %d and block size %d >= "+
 				"minBlockSize %d", tx.Hash(), prioItem.feePerKB,
-				g.cfg.Policy.TxMinFreeFee, blockPlusTxSize,
+				txMinFreeFee, blockPlusTxSize,
 				g.cfg.Policy.BlockMinSize)
 			logSkippedDeps(tx, deps)
 			miningView.reject(tx.Hash())
@@ -1835,7 +1978,7 @@ nextPriorityQueueItem:
 			// save the fees and signature operation counts to the block
 			// template.
 			blockTxns = append(blockTxns, bundledTx)
-			blockSize += uint32(bundledTx.MsgTx().SerializeSize())
+			blockSize += uint32(bundledTx.SerializeSize())
 			bundledTxSigOps := int64(bundledTxDesc.TotalSigOps)
 			blockSigOps += bundledTxSigOps
 
@@ -2403,6 +2546,126 @@ nextPriorityQueueItem:
 	return blockTemplate, nil
 }
 
+// SimulatedTx describes a transaction from the transaction source that would
+// be included in the next block along with its predicted position and fee.
+type SimulatedTx struct {
+	// Hash is the hash of the transaction.
+	Hash chainhash.Hash
+
+	// Type is the type of the transaction.
+	Type stake.TxType
+
+	// Tree is the transaction tree the transaction would be included in.
+	Tree int8
+
+	// Index is the predicted position of the transaction within its tree.
+	Index int
+
+	// Fee is the total fee the transaction pays in atoms.
+	Fee int64
+
+	// Size is the serialized size of the transaction in bytes.
+	Size int64
+
+	// FeePerKb is the fee rate the transaction pays in atoms per kilobyte.
+	FeePerKb int64
+}
+
+// TemplateSimulation houses the result of simulating the selection of
+// transactions from a snapshot of the transaction source for the next block.
+type TemplateSimulation struct {
+	// Height is the height of the simulated block.
+	Height int64
+
+	// PrevHash is the hash of the block the simulated block builds on.
+	PrevHash chainhash.Hash
+
+	// Selected houses the transactions from the snapshot that would be
+	// included in the next block in the order they would appear in the
+	// regular and stake transaction trees, respectively.
+	Selected []SimulatedTx
+
+	// Excluded houses the hashes of the transactions from the snapshot that
+	// would not be included in the next block.
+	Excluded []chainhash.Hash
+}
+
+// SimulateBlockTemplate selects the transactions that would be included in the
+// next block template from a snapshot of the current transaction source and
+// returns them along with their predicted positions and fees without modifying
+// any state.  This allows callers to determine locally whether or not a given
+// transaction, along with any unconfirmed transactions it depends on, is
+// expected to be included in the next block.
+//
+// The selection is identical to the one performed by NewBlockTemplate with the
+// exception that the chain is never forcibly reorganized to a sibling of the
+// current best chain tip that has more votes.  In other words, the simulated
+// block always builds on the current tip.
+//
+// An error of kind ErrNotEnoughVoters is returned when there are not enough
+// votes available to create a block template.
+func (g *BlkTmplGenerator) SimulateBlockTemplate() (*TemplateSimulation, error) {
+	// Take a snapshot of the transaction source and keep track of the
+	// transactions it contains prior to generating the template since the
+	// view is modified during transaction selection.
+	miningView := g.cfg.TxSource.MiningView()
+	sourceTxns := miningView.TxDescs()
+	sourceTxnsMap := make(map[chainhash.Hash]*TxDesc, len(sourceTxns))
+	sourceHashes := make([]chainhash.Hash, 0, len(sourceTxns))
+	for _, txDesc := range sourceTxns {
+		sourceTxnsMap[*txDesc.Tx.Hash()] = txDesc
+		sourceHashes = append(sourceHashes, *txDesc.Tx.Hash())
+	}
+
+	template, err := g.newBlockTemplate(nil, miningView, true, false)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		str := "not enough votes are available to create a block template"
+		return nil, makeError(ErrNotEnoughVoters, str)
+	}
+
+	// Determine the transactions from the snapshot that were selected along
+	// with their positions in the template.
+	msgBlock := template.Block
+	sim := &TemplateSimulation{
+		Height:   template.Height,
+		PrevHash: msgBlock.Header.PrevBlock,
+	}
+	selected := make(map[chainhash.Hash]struct{})
+	addSelected := func(txns []*wire.MsgTx, tree int8) {
+		for i, tx := range txns {
+			txHash := tx.TxHash()
+			txDesc, ok := sourceTxnsMap[txHash]
+			if !ok {
+				continue
+			}
+			selected[txHash] = struct{}{}
+			size := int64(tx.SerializeSize())
+			sim.Selected = append(sim.Selected, SimulatedTx{
+				Hash:     txHash,
+				Type:     txDesc.Type,
+				Tree:     tree,
+				Index:    i,
+				Fee:      txDesc.Fee,
+				Size:     size,
+				FeePerKb: txDesc.Fee * kilobyte / size,
+			})
+		}
+	}
+	addSelected(msgBlock.Transactions, wire.TxTreeRegular)
+	addSelected(msgBlock.STransactions, wire.TxTreeStake)
+
+	for i := range sourceHashes {
+		if _, ok := selected[sourceHashes[i]]; !ok {
+			sim.Excluded = append(sim.Excluded, sourceHashes[i])
+		}
+	}
+
+	return sim, nil
+}
+
 // UpdateBlockTime updates the timestamp in the passed header to the current
 // time while taking into account the median time of the last several blocks to
 // ensure the new time is after that time per the chain consensus rules.
@@ -261,6 +261,7 @@ type fakeTxSource struct {
 	staged          map[chainhash.Hash]*dcrutil.Tx
 	stagedOutpoints map[wire.OutPoint]*dcrutil.Tx
 	votes           map[chainhash.Hash][]VoteDesc
+	votedBlocks     map[int64][]chainhash.Hash
 	tspends         map[chainhash.Hash]*dcrutil.Tx
 	miningView      *TxMiningView
 	lastUpdated     int64
@@ -345,6 +346,16 @@ func (p *fakeTxSource) VotesForBlocks(hashes []chainhash.Hash) [][]VoteDesc {
 	return result
 }
 
+// BlocksVotedOnAtHeight returns the hashes of all blocks at the provided height
+// that have votes currently available in the fake tx source.
+func (p *fakeTxSource) BlocksVotedOnAtHeight(height int64) []chainhash.Hash {
+	blocks := p.votedBlocks[height]
+	if len(blocks) == 0 {
+		return nil
+	}
+	return append([]chainhash.Hash(nil), blocks...)
+}
+
 // IsRegTxTreeKnownDisapproved returns whether or not the regular transaction
 // tree of the block represented by the provided hash is known to be disapproved
 // according to the votes currently in the fake tx source.
@@ -515,13 +526,15 @@ func (p *fakeTxSource) addTransaction(tx *dcrutil.Tx, txType stake.TxType, heigh
 func (p *fakeTxSource) insertVote(ssgen *dcrutil.Tx) {
 	// Get the block it is voting on; here we're agnostic of height.
 	msgTx := ssgen.MsgTx()
-	blockHash, _ := stake.SSGenBlockVotedOn(msgTx)
+	blockHash, blockHeight := stake.SSGenBlockVotedOn(msgTx)
 
 	// If there are currently no votes for this block,
 	// start a new buffered slice and store it.
 	vts, exists := p.votes[blockHash]
 	if !exists {
 		vts = make([]VoteDesc, 0, p.chainParams.TicketsPerBlock)
+		height := int64(blockHeight)
+		p.votedBlocks[height] = append(p.votedBlocks[height], blockHash)
 	}
 
 	// Nothing to do if a vote for the ticket is already known.
@@ -1387,6 +1400,7 @@ func newMiningHarness(chainParams *chaincfg.Params) (*miningHarness, []spendable
 		staged:          make(map[chainhash.Hash]*dcrutil.Tx),
 		stagedOutpoints: make(map[wire.OutPoint]*dcrutil.Tx),
 		votes:           make(map[chainhash.Hash][]VoteDesc),
+		votedBlocks:     make(map[int64][]chainhash.Hash),
 		tspends:         make(map[chainhash.Hash]*dcrutil.Tx),
 	}
 
@@ -1459,7 +1473,7 @@ func newMiningHarness(chainParams *chaincfg.Params) (*miningHarness, []spendable
 				isAutoRevocationsEnabled bool) error {
 
 				return blockchain.ValidateTransactionScripts(tx, utxoView, flags,
-					sigCache, isAutoRevocationsEnabled)
+					sigCache, nil, isAutoRevocationsEnabled)
 			},
 		}),
 	}
@@ -61,8 +61,91 @@ func TestNewBlockTemplateBasicErrorScenarios(t *testing.T) {
 	harness.chain.isTreasuryAgendaActiveErr = nil
 }
 
+// TestSetTxMinFreeFee ensures updating the minimum fee required for a
+// transaction to not be treated as free applies to block templates generated
+// after the update.
+func TestSetTxMinFreeFee(t *testing.T) {
+	t.Parallel()
+
+	// Create a new mining harness instance with a policy that sorts all
+	// transactions by their fee.
+	harness, spendableOuts, err := newMiningHarness(chaincfg.MainNetParams())
+	if err != nil {
+		t.Fatalf("error creating mining harness: %v", err)
+	}
+	harness.policy.BlockPrioritySize = 0
+	if fee := harness.generator.TxMinFreeFee(); fee != harness.policy.TxMinFreeFee {
+		t.Fatalf("unexpected initial min free fee -- got %v, want %v", fee,
+			harness.policy.TxMinFreeFee)
+	}
+
+	// Create a test address for use in template generation.
+	address, err := stdaddr.DecodeAddress("Dsi8CRt85xYyempXs7ZPL1rBxvDdAGZmgsg",
+		harness.chainParams)
+	if err != nil {
+		t.Fatalf("error decoding address: %v", err)
+	}
+
+	// Add a transaction that pays a fee above the initial minimum to the tx
+	// source.
+	baseTx, err := harness.CreateSignedTx(spendableOuts, 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	harness.AddFakeUTXO(baseTx, harness.chain.bestState.Height, 1,
+		harness.chain.isTreasuryAgendaActive)
+	tx, err := harness.CreateSignedTx([]spendableOutput{
+		txOutToSpendableOut(baseTx, 0, wire.TxTreeRegular)}, 1,
+		func(tx *wire.MsgTx) {
+			tx.TxOut[0].Value -= 5000
+		})
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	if _, err := harness.AddTransactionToTxSource(tx); err != nil {
+		t.Fatalf("unable to add transaction to the tx source: %v", err)
+	}
+
+	tests := []struct {
+		name   string         // test description
+		minFee dcrutil.Amount // min free fee to set
+		wantTx int            // expected number of regular txns
+	}{{
+		name:   "fee above min free fee",
+		minFee: harness.policy.TxMinFreeFee,
+		wantTx: 2,
+	}, {
+		name:   "fee below updated min free fee",
+		minFee: 1e6,
+		wantTx: 1,
+	}, {
+		name:   "fee above restored min free fee",
+		minFee: harness.policy.TxMinFreeFee,
+		wantTx: 2,
+	}}
+
+	for _, test := range tests {
+		harness.generator.SetTxMinFreeFee(test.minFee)
+		if fee := harness.generator.TxMinFreeFee(); fee != test.minFee {
+			t.Fatalf("%q: unexpected min free fee -- got %v, want %v",
+				test.name, fee, test.minFee)
+		}
+		blockTemplate, err := harness.generator.NewBlockTemplate(address)
+		if err != nil {
+			t.Fatalf("%q: unexpected err generating block template: %v",
+				test.name, err)
+		}
+		gotTx := len(blockTemplate.Block.Transactions)
+		if gotTx != test.wantTx {
+			t.Fatalf("%q: unexpected number of transactions in template -- "+
+				"got %v, want %v", test.name, gotTx, test.wantTx)
+		}
+	}
+}
+
 // TestNewBlockTemplate tests the generation of a new block template containing
-// regular and vote transactions.
+// regular and vote transactions along with simulating the selection of the
+// transactions for it.
 func TestNewBlockTemplate(t *testing.T) {
 	t.Parallel()
 
@@ -187,6 +270,88 @@ func TestNewBlockTemplate(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error when checking block sanity: %v", err)
 	}
+
+	// Ensure simulating the template selects the same transactions from the
+	// tx source in the same trees with the expected fees.  Note that the order
+	// of the votes is not deterministic, so only ensure the predicted
+	// positions are unique and valid.
+	sim, err := harness.generator.SimulateBlockTemplate()
+	if err != nil {
+		t.Fatalf("unexpected err simulating block template: %v", err)
+	}
+	if sim.Height != blockTemplate.Height ||
+		sim.PrevHash != blockTemplate.Block.Header.PrevBlock {
+
+		t.Fatalf("unexpected simulated block -- got height %d, prev %v, "+
+			"want height %d, prev %v", sim.Height, sim.PrevHash,
+			blockTemplate.Height, blockTemplate.Block.Header.PrevBlock)
+	}
+	wantSelected := (numTxs - numVotes) + numVotes // regular + votes
+	if len(sim.Selected) != wantSelected || len(sim.Excluded) != 0 {
+		t.Fatalf("unexpected number of simulated transactions -- got %d "+
+			"selected, %d excluded, want %d selected, 0 excluded",
+			len(sim.Selected), len(sim.Excluded), wantSelected)
+	}
+	templateTxns := make(map[chainhash.Hash]int8)
+	for _, tx := range blockTemplate.Block.Transactions {
+		templateTxns[tx.TxHash()] = wire.TxTreeRegular
+	}
+	for _, tx := range blockTemplate.Block.STransactions {
+		templateTxns[tx.TxHash()] = wire.TxTreeStake
+	}
+	type treePos struct {
+		tree  int8
+		index int
+	}
+	seenPositions := make(map[treePos]struct{})
+	for _, simTx := range sim.Selected {
+		tree, ok := templateTxns[simTx.Hash]
+		if !ok || tree != simTx.Tree {
+			t.Fatalf("simulated tx %v is not in tree %d of the template",
+				simTx.Hash, simTx.Tree)
+		}
+		wantFee := int64(5000)
+		numTxns := len(blockTemplate.Block.Transactions)
+		if simTx.Tree == wire.TxTreeStake {
+			wantFee = 0
+			numTxns = len(blockTemplate.Block.STransactions)
+		}
+		pos := treePos{simTx.Tree, simTx.Index}
+		if _, ok := seenPositions[pos]; ok || simTx.Index < 1 ||
+			simTx.Index >= numTxns {
+
+			t.Fatalf("unexpected index %d for simulated tx %v", simTx.Index,
+				simTx.Hash)
+		}
+		seenPositions[pos] = struct{}{}
+		if simTx.Fee != wantFee {
+			t.Fatalf("unexpected fee for simulated tx %v -- got %d, want %d",
+				simTx.Hash, simTx.Fee, wantFee)
+		}
+	}
+
+	// Ensure generating an empty block template only includes the coinbase
+	// and the votes required for the block to be valid.
+	emptyTemplate, err := harness.generator.NewEmptyBlockTemplate(address)
+	if err != nil {
+		t.Fatalf("unexpected err generating empty block template: %v", err)
+	}
+	gotTx = len(emptyTemplate.Block.Transactions)
+	if gotTx != 1 {
+		t.Fatalf("unexpected number of transactions in empty template -- "+
+			"got %v, want 1", gotTx)
+	}
+	gotStx = len(emptyTemplate.Block.STransactions)
+	if gotStx != wantStx {
+		t.Fatalf("unexpected number of stake transactions in empty template "+
+			"-- got %v, want %v", gotStx, wantStx)
+	}
+	block = dcrutil.NewBlock(emptyTemplate.Block)
+	err = blockchain.CheckBlockSanity(block, harness.generator.cfg.TimeSource,
+		harness.chainParams)
+	if err != nil {
+		t.Fatalf("unexpected error when checking empty block sanity: %v", err)
+	}
 }
 
 // TestNewBlockTemplateAutoRevocations tests the generation of a new block with
@@ -671,3 +836,37 @@ func TestSortParentsByVotes(t *testing.T) {
 		}
 	}
 }
+
+// TestTallyVotes ensures tallying the votes for competing blocks orders them
+// from most to least favorable to build on.
+func TestTallyVotes(t *testing.T) {
+	t.Parallel()
+
+	// makeVotes returns the provided number of vote descriptors where the
+	// given number of them approve the parent.
+	makeVotes := func(numVotes, numApprovals int) []VoteDesc {
+		votes := make([]VoteDesc, numVotes)
+		for i := 0; i < numApprovals; i++ {
+			votes[i].ApprovesParent = true
+		}
+		return votes
+	}
+
+	blocks := []chainhash.Hash{{0x04}, {0x03}, {0x02}, {0x01}}
+	votes := [][]VoteDesc{
+		makeVotes(3, 3),
+		makeVotes(4, 1),
+		makeVotes(4, 2),
+		makeVotes(3, 3),
+	}
+	got := TallyVotes(blocks, votes)
+	want := []BlockVoteTally{
+		{Hash: chainhash.Hash{0x02}, NumVotes: 4, NumApprovals: 2},
+		{Hash: chainhash.Hash{0x03}, NumVotes: 4, NumApprovals: 1},
+		{Hash: chainhash.Hash{0x01}, NumVotes: 3, NumApprovals: 3},
+		{Hash: chainhash.Hash{0x04}, NumVotes: 3, NumApprovals: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected tallies -- got %+v, want %+v", got, want)
+	}
+}
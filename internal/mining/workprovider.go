@@ -0,0 +1,388 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mining
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/decred/dcrd/blockchain/standalone/v2"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+const (
+	// defaultMaxJobs is the default maximum number of jobs that build on the
+	// current tip the work provider retains in order to accept solutions.
+	defaultMaxJobs = 32
+
+	// maxJobTimeRoll is the maximum amount of time past the timestamp of the
+	// header of a job that solutions may set the timestamp to.  It is well
+	// below the maximum allowed offset of block timestamps in the future so
+	// that solutions remain valid while they propagate.
+	maxJobTimeRoll = 15 * time.Minute
+
+	// jobSubscriptionBufferSize is the number of jobs that are buffered for
+	// each job subscription before further jobs are dropped.
+	jobSubscriptionBufferSize = 8
+)
+
+// Job describes a unit of work that is suitable for distribution to external
+// miners, such as via a stratum server, without the need to go through the
+// getwork RPC.
+//
+// Jobs are shared between all subscribers and MUST be treated as immutable.
+type Job struct {
+	// ID uniquely identifies the job for the lifetime of the work provider.
+	ID uint64
+
+	// Height is the height of the block the job is for.
+	Height int64
+
+	// Header is the block header to solve.  The previous block hash, merkle
+	// root, and stake root fields are fixed for the job.
+	Header wire.BlockHeader
+
+	// MerkleBranch is the merkle branch that links the coinbase transaction
+	// to the merkle root in the header.  The final entry is the stake tree
+	// merkle root when the merkle root in the header commits to both
+	// transaction trees.
+	//
+	// Decred block headers provide dedicated space for extra nonces in the
+	// extra data field, so the coinbase is never modified by miners and the
+	// branch is only provided so the coinbase can be verified.
+	MerkleBranch []chainhash.Hash
+
+	// Target is the target difficulty that the hash of solutions must not
+	// exceed.
+	Target *big.Int
+
+	// CleanJobs indicates the job builds on a different block than the
+	// previous job and therefore all previous jobs are no longer valid.
+	CleanJobs bool
+
+	// MinTime and MaxTime define the range of timestamps that solutions may
+	// set in the header.  They are the same on networks where the difficulty
+	// depends on the timestamp.
+	MinTime time.Time
+	MaxTime time.Time
+
+	// VersionMask defines the bits of the header version that solutions may
+	// modify.  It is always zero since the block version takes part in the
+	// consensus voting rules.
+	VersionMask uint32
+}
+
+// Solution houses the fields of a job header that miners modify when solving
+// the job.
+type Solution struct {
+	Version   int32
+	Timestamp time.Time
+	Nonce     uint32
+	ExtraData [32]byte
+}
+
+// workJob houses a job along with the block template it was created from.
+type workJob struct {
+	job   *Job
+	block *wire.MsgBlock
+}
+
+// JobSubscription defines a subscription to jobs from the work provider.  The
+// caller must call Stop on the subscription when it is no longer needed to
+// free resources.
+//
+// NOTE: Jobs are dropped to make up for slow receivers to ensure the work
+// provider does not block.  Since the latest job supersedes the previous ones,
+// this should not affect callers in practice.
+type JobSubscription struct {
+	p     *WorkProvider
+	privC chan *Job
+}
+
+// C returns a channel that produces a stream of jobs as each new job is
+// created.  Successive calls to C return the same channel.
+func (s *JobSubscription) C() <-chan *Job {
+	return s.privC
+}
+
+// Stop prevents any future jobs from being delivered and unsubscribes the
+// associated subscription.
+//
+// NOTE: The channel is not closed to prevent a read from the channel succeeding
+// incorrectly.
+func (s *JobSubscription) Stop() {
+	s.p.subscriptionMtx.Lock()
+	delete(s.p.subscriptions, s)
+	s.p.subscriptionMtx.Unlock()
+}
+
+// publishJob sends the provided job on the channel associated with the
+// subscription without blocking.
+func (s *JobSubscription) publishJob(job *Job) {
+	select {
+	case s.privC <- job:
+	default:
+	}
+}
+
+// WorkProviderConfig holds the configuration options related to the work
+// provider.
+type WorkProviderConfig struct {
+	// ChainParams identifies which chain parameters the work provider is
+	// associated with.
+	ChainParams *chaincfg.Params
+
+	// SubscribeTemplates defines the function to use to subscribe for block
+	// template updates.  It is typically the Subscribe method of the
+	// background block template generator.
+	SubscribeTemplates func() *TemplateSubscription
+
+	// UpdateBlockTime defines the function to use to update the timestamp of
+	// the headers of new jobs.  It is typically the UpdateBlockTime method of
+	// the background block template generator.
+	UpdateBlockTime func(header *wire.BlockHeader) error
+
+	// MaxJobs is the maximum number of jobs that build on the current tip to
+	// retain in order to accept solutions.  The default is used when it is
+	// zero.
+	MaxJobs int
+}
+
+// WorkProvider turns block templates into jobs for external miners and turns
+// their solutions back into blocks.  It provides the necessary building blocks
+// to drive external mining protocol implementations such as stratum.
+type WorkProvider struct {
+	cfg WorkProviderConfig
+
+	// These fields track the jobs that are able to accept solutions.  They
+	// are protected by the embedded mutex.
+	mtx       sync.Mutex
+	nextJobID uint64
+	current   *Job
+	jobs      map[uint64]*workJob
+
+	// subscriptions tracks the job subscriptions.  It is protected by
+	// subscriptionMtx.
+	subscriptionMtx sync.Mutex
+	subscriptions   map[*JobSubscription]struct{}
+}
+
+// NewWorkProvider returns a new work provider with the provided configuration.
+// The returned instance must be started with the Run method to create jobs
+// from block templates.
+func NewWorkProvider(cfg *WorkProviderConfig) *WorkProvider {
+	p := &WorkProvider{
+		cfg:           *cfg,
+		nextJobID:     1,
+		jobs:          make(map[uint64]*workJob),
+		subscriptions: make(map[*JobSubscription]struct{}),
+	}
+	if p.cfg.MaxJobs <= 0 {
+		p.cfg.MaxJobs = defaultMaxJobs
+	}
+	return p
+}
+
+// coinbaseMerkleBranch returns the merkle branch that links the coinbase of the
+// provided block to the merkle root in its header.
+func coinbaseMerkleBranch(block *wire.MsgBlock) []chainhash.Hash {
+	if len(block.Transactions) == 0 {
+		return nil
+	}
+	leaves := make([]chainhash.Hash, 0, len(block.Transactions))
+	for _, tx := range block.Transactions {
+		leaves = append(leaves, tx.TxHashFull())
+	}
+	branch := standalone.GenerateInclusionProof(leaves, 0)
+
+	// The merkle root commits to both transaction trees once the header
+	// commitments agenda is active, in which case the stake tree merkle root
+	// is the sibling of the regular tree merkle root.
+	regularRoot := standalone.CalcMerkleRootInPlace(leaves)
+	if regularRoot != block.Header.MerkleRoot {
+		stakeRoot := standalone.CalcTxTreeMerkleRoot(block.STransactions)
+		branch = append(branch, stakeRoot)
+	}
+	return branch
+}
+
+// addTemplate creates a new job from the provided block template and makes it
+// the current job.  All existing jobs are pruned when the new job builds on a
+// different block.
+//
+// This function is safe for concurrent access.
+func (p *WorkProvider) addTemplate(template *BlockTemplate) (*Job, error) {
+	// Update the time of the block template to the current time while
+	// accounting for the median time of the past several blocks per the chain
+	// consensus rules.  Note that the header is copied to avoid mutating the
+	// shared block template.
+	header := template.Block.Header
+	if err := p.cfg.UpdateBlockTime(&header); err != nil {
+		return nil, err
+	}
+
+	// Miners are only permitted to modify the timestamp when the difficulty
+	// does not depend on it.
+	minTime := header.Timestamp
+	maxTime := minTime
+	if !p.cfg.ChainParams.ReduceMinDifficulty {
+		maxTime = minTime.Add(maxJobTimeRoll)
+	}
+
+	job := &Job{
+		Height:       template.Height,
+		Header:       header,
+		MerkleBranch: coinbaseMerkleBranch(template.Block),
+		Target:       standalone.CompactToBig(header.Bits),
+		MinTime:      minTime,
+		MaxTime:      maxTime,
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	// Prune all jobs when the new job builds on a different block since they
+	// can no longer be solved.  Otherwise, prune the oldest jobs that exceed
+	// the maximum allowed.
+	job.ID = p.nextJobID
+	p.nextJobID++
+	if p.current == nil || p.current.Header.PrevBlock != header.PrevBlock {
+		job.CleanJobs = true
+		p.jobs = make(map[uint64]*workJob)
+	} else if job.ID > uint64(p.cfg.MaxJobs) {
+		delete(p.jobs, job.ID-uint64(p.cfg.MaxJobs))
+	}
+	p.jobs[job.ID] = &workJob{job: job, block: template.Block}
+	p.current = job
+	return job, nil
+}
+
+// CurrentJob returns the most recently created job or nil when no jobs have
+// been created yet.
+//
+// This function is safe for concurrent access.
+func (p *WorkProvider) CurrentJob() *Job {
+	p.mtx.Lock()
+	job := p.current
+	p.mtx.Unlock()
+	return job
+}
+
+// SubmitSolution applies the provided solution to the header of the job with
+// the given ID and returns the resulting block when it satisfies the
+// proof-of-work requirement of the job.  The caller is responsible for
+// processing the returned block.
+//
+// An error of kind ErrUnknownJob is returned when the job is not known,
+// ErrInvalidSolution when the solution modifies the header in a way that the
+// job does not permit, and ErrHighHash when the solution does not satisfy the
+// proof-of-work requirement.
+//
+// This function is safe for concurrent access.
+func (p *WorkProvider) SubmitSolution(jobID uint64, solution *Solution) (*dcrutil.Block, error) {
+	p.mtx.Lock()
+	wj, ok := p.jobs[jobID]
+	p.mtx.Unlock()
+	if !ok {
+		str := fmt.Sprintf("job %d does not exist", jobID)
+		return nil, makeError(ErrUnknownJob, str)
+	}
+	job := wj.job
+
+	// Ensure the solution only modifies the permitted fields of the header
+	// within the permitted ranges.
+	versionDiff := uint32(solution.Version ^ job.Header.Version)
+	if versionDiff&^job.VersionMask != 0 {
+		str := fmt.Sprintf("solution version %08x modifies bits outside "+
+			"of the version mask %08x", solution.Version, job.VersionMask)
+		return nil, makeError(ErrInvalidSolution, str)
+	}
+	timestamp := time.Unix(solution.Timestamp.Unix(), 0)
+	if timestamp.Before(job.MinTime) || timestamp.After(job.MaxTime) {
+		str := fmt.Sprintf("solution timestamp %v is outside of the "+
+			"permitted range [%v, %v]", timestamp, job.MinTime, job.MaxTime)
+		return nil, makeError(ErrInvalidSolution, str)
+	}
+
+	header := job.Header
+	header.Version = solution.Version
+	header.Timestamp = timestamp
+	header.Nonce = solution.Nonce
+	header.ExtraData = solution.ExtraData
+
+	blockHash := header.BlockHash()
+	err := standalone.CheckProofOfWork(&blockHash, header.Bits,
+		p.cfg.ChainParams.PowLimit)
+	if err != nil {
+		var rErr standalone.RuleError
+		if !errors.As(err, &rErr) {
+			return nil, err
+		}
+		str := fmt.Sprintf("solution for job %d does not satisfy the "+
+			"proof of work requirement: %v", jobID, err)
+		return nil, makeError(ErrHighHash, str)
+	}
+
+	// Reconstruct the block using the solved header.  Note that the block
+	// template is shallow copied to avoid mutating the header of the shared
+	// block template.
+	msgBlock := *wj.block
+	msgBlock.Header = header
+	return dcrutil.NewBlock(&msgBlock), nil
+}
+
+// Subscribe subscribes a client for new jobs.  The current job, if any, is
+// immediately sent to the returned subscription stream.
+//
+// This function is safe for concurrent access.
+func (p *WorkProvider) Subscribe() *JobSubscription {
+	subscription := &JobSubscription{
+		p:     p,
+		privC: make(chan *Job, jobSubscriptionBufferSize),
+	}
+	p.subscriptionMtx.Lock()
+	p.subscriptions[subscription] = struct{}{}
+	p.subscriptionMtx.Unlock()
+
+	if job := p.CurrentJob(); job != nil {
+		subscription.publishJob(job)
+	}
+	return subscription
+}
+
+// Run creates jobs from block template updates and notifies subscribers of
+// them until the provided context is cancelled.  It blocks until the context
+// is cancelled.
+func (p *WorkProvider) Run(ctx context.Context) {
+	templateSub := p.cfg.SubscribeTemplates()
+	defer templateSub.Stop()
+
+	for {
+		select {
+		case templateNtfn := <-templateSub.C():
+			job, err := p.addTemplate(templateNtfn.Template)
+			if err != nil {
+				log.Errorf("Unable to create mining job: %v", err)
+				continue
+			}
+
+			p.subscriptionMtx.Lock()
+			for subscription := range p.subscriptions {
+				subscription.publishJob(job)
+			}
+			p.subscriptionMtx.Unlock()
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mining
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/blockchain/standalone/v2"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/wire"
+)
+
+// TestWorkProvider ensures the work provider creates jobs from block templates
+// and accepts solutions for them as expected.
+func TestWorkProvider(t *testing.T) {
+	params := chaincfg.RegNetParams()
+	now := time.Unix(time.Now().Unix(), 0)
+	provider := NewWorkProvider(&WorkProviderConfig{
+		ChainParams: params,
+		UpdateBlockTime: func(header *wire.BlockHeader) error {
+			header.Timestamp = now
+			return nil
+		},
+		MaxJobs: 2,
+	})
+
+	// makeTemplate returns a block template that builds on the provided
+	// block and contains the given number of regular transactions.
+	makeTemplate := func(prevBlock chainhash.Hash, numTxns int) *BlockTemplate {
+		block := &wire.MsgBlock{Header: wire.BlockHeader{
+			Version:   9,
+			PrevBlock: prevBlock,
+			Bits:      params.PowLimitBits,
+			Height:    100,
+		}}
+		for i := 0; i < numTxns; i++ {
+			tx := wire.NewMsgTx()
+			tx.LockTime = uint32(i)
+			block.AddTransaction(tx)
+		}
+		stakeTx := wire.NewMsgTx()
+		stakeTx.Expiry = 1
+		block.AddSTransaction(stakeTx)
+		block.Header.MerkleRoot = standalone.CalcCombinedTxTreeMerkleRoot(
+			block.Transactions, block.STransactions)
+		return &BlockTemplate{Block: block, Height: 100}
+	}
+
+	// solve returns a solution for the provided job which either does or
+	// does not satisfy the proof-of-work requirement depending on the flag.
+	solve := func(job *Job, valid bool) *Solution {
+		solution := &Solution{
+			Version:   job.Header.Version,
+			Timestamp: job.MinTime,
+		}
+		for ; ; solution.Nonce++ {
+			header := job.Header
+			header.Nonce = solution.Nonce
+			hash := header.BlockHash()
+			err := standalone.CheckProofOfWork(&hash, header.Bits,
+				params.PowLimit)
+			if (err == nil) == valid {
+				return solution
+			}
+		}
+	}
+
+	// Ensure the first job is a clean job with a merkle branch that links
+	// the coinbase to the merkle root.
+	tmpl := makeTemplate(chainhash.Hash{0x01}, 3)
+	job1, err := provider.addTemplate(tmpl)
+	if err != nil {
+		t.Fatalf("unexpected error adding template: %v", err)
+	}
+	if job1.ID != 1 || !job1.CleanJobs {
+		t.Fatalf("unexpected first job id %d (clean %v)", job1.ID,
+			job1.CleanJobs)
+	}
+	coinbaseHash := tmpl.Block.Transactions[0].TxHashFull()
+	if !standalone.VerifyInclusionProof(&job1.Header.MerkleRoot,
+		&coinbaseHash, 0, job1.MerkleBranch) {
+
+		t.Fatal("merkle branch does not link the coinbase to the merkle root")
+	}
+	if !job1.Header.Timestamp.Equal(now) || !job1.MinTime.Equal(now) ||
+		!job1.MaxTime.Equal(now.Add(maxJobTimeRoll)) {
+
+		t.Fatalf("unexpected job time range [%v, %v]", job1.MinTime,
+			job1.MaxTime)
+	}
+	if job1.VersionMask != 0 {
+		t.Fatalf("unexpected version mask %08x", job1.VersionMask)
+	}
+	if got := provider.CurrentJob(); got != job1 {
+		t.Fatalf("unexpected current job %d", got.ID)
+	}
+
+	// Ensure new subscriptions immediately receive the current job.
+	sub := provider.Subscribe()
+	select {
+	case job := <-sub.C():
+		if job != job1 {
+			t.Fatalf("unexpected subscription job %d", job.ID)
+		}
+	default:
+		t.Fatal("subscription did not receive current job")
+	}
+	sub.Stop()
+
+	// Ensure a valid solution results in the expected block without
+	// modifying the template.
+	solution := solve(job1, true)
+	block, err := provider.SubmitSolution(job1.ID, solution)
+	if err != nil {
+		t.Fatalf("unexpected error submitting solution: %v", err)
+	}
+	if block.MsgBlock().Header.Nonce != solution.Nonce {
+		t.Fatalf("unexpected block nonce %d", block.MsgBlock().Header.Nonce)
+	}
+	if tmpl.Block.Header.Nonce != 0 || !tmpl.Block.Header.Timestamp.IsZero() {
+		t.Fatal("submitting a solution modified the template")
+	}
+
+	// Ensure invalid solutions are rejected with the expected errors.
+	tests := []struct {
+		name    string
+		jobID   uint64
+		modify  func(s *Solution)
+		valid   bool
+		wantErr error
+	}{{
+		name:    "unknown job",
+		jobID:   99,
+		valid:   true,
+		wantErr: ErrUnknownJob,
+	}, {
+		name:    "version outside mask",
+		jobID:   job1.ID,
+		modify:  func(s *Solution) { s.Version |= 0x10000 },
+		valid:   true,
+		wantErr: ErrInvalidSolution,
+	}, {
+		name:    "timestamp before min time",
+		jobID:   job1.ID,
+		modify:  func(s *Solution) { s.Timestamp = now.Add(-time.Second) },
+		valid:   true,
+		wantErr: ErrInvalidSolution,
+	}, {
+		name:  "timestamp after max time",
+		jobID: job1.ID,
+		modify: func(s *Solution) {
+			s.Timestamp = now.Add(maxJobTimeRoll + time.Second)
+		},
+		valid:   true,
+		wantErr: ErrInvalidSolution,
+	}, {
+		name:    "insufficient proof of work",
+		jobID:   job1.ID,
+		valid:   false,
+		wantErr: ErrHighHash,
+	}}
+	for _, test := range tests {
+		solution := solve(job1, test.valid)
+		if test.modify != nil {
+			test.modify(solution)
+		}
+		_, err := provider.SubmitSolution(test.jobID, solution)
+		if !errors.Is(err, test.wantErr) {
+			t.Fatalf("%q: unexpected error - got %v, want %v", test.name,
+				err, test.wantErr)
+		}
+	}
+
+	// Ensure jobs that build on the same block are retained up to the max
+	// and are not marked clean.
+	job2, err := provider.addTemplate(makeTemplate(chainhash.Hash{0x01}, 4))
+	if err != nil {
+		t.Fatalf("unexpected error adding template: %v", err)
+	}
+	if job2.ID != 2 || job2.CleanJobs {
+		t.Fatalf("unexpected second job id %d (clean %v)", job2.ID,
+			job2.CleanJobs)
+	}
+	if _, err := provider.SubmitSolution(job1.ID, solve(job1, true)); err != nil {
+		t.Fatalf("unexpected error submitting solution: %v", err)
+	}
+	job3, err := provider.addTemplate(makeTemplate(chainhash.Hash{0x01}, 5))
+	if err != nil {
+		t.Fatalf("unexpected error adding template: %v", err)
+	}
+	_, err = provider.SubmitSolution(job1.ID, solve(job1, true))
+	if !errors.Is(err, ErrUnknownJob) {
+		t.Fatalf("unexpected error for pruned job - got %v, want %v", err,
+			ErrUnknownJob)
+	}
+
+	// Ensure all jobs are pruned when a job builds on a new block.
+	job4, err := provider.addTemplate(makeTemplate(chainhash.Hash{0x02}, 1))
+	if err != nil {
+		t.Fatalf("unexpected error adding template: %v", err)
+	}
+	if !job4.CleanJobs {
+		t.Fatal("job that builds on a new block is not marked clean")
+	}
+	for _, job := range []*Job{job2, job3} {
+		_, err = provider.SubmitSolution(job.ID, solve(job, true))
+		if !errors.Is(err, ErrUnknownJob) {
+			t.Fatalf("unexpected error for pruned job %d - got %v, want %v",
+				job.ID, err, ErrUnknownJob)
+		}
+	}
+	if _, err := provider.SubmitSolution(job4.ID, solve(job4, true)); err != nil {
+		t.Fatalf("unexpected error submitting solution: %v", err)
+	}
+}
@@ -87,6 +87,35 @@ const (
 	// during the header sync process before stalling the sync and disconnecting
 	// the peer.
 	headerSyncStallTimeoutSecs = (3 + wire.MaxBlockHeadersPerMsg/1000) * 2
+
+	// syncPeerCheckInterval is the interval at which the block download
+	// throughput of peers is measured and the sync peer is potentially
+	// rotated due to poor throughput.
+	syncPeerCheckInterval = time.Second * 30
+
+	// scriptAuditInterval is the interval at which the scripts of blocks that
+	// were connected without executing them are audited once the chain is
+	// current.
+	scriptAuditInterval = time.Second * 10
+
+	// maxScriptAuditBlocks is the maximum number of blocks that are audited
+	// per script audit interval in order to limit the CPU time spent on it.
+	maxScriptAuditBlocks = 100
+
+	// minSyncPeerThroughput is the minimum number of blocks per second the
+	// sync peer must deliver while blocks are being downloaded during the
+	// initial chain sync before it is rotated in favor of another candidate.
+	minSyncPeerThroughput = 0.2
+
+	// syncPeerThroughputDecay is the weight given to the historical
+	// throughput of a peer when combining it with the most recent
+	// measurement.
+	syncPeerThroughputDecay = 0.5
+
+	// syncPeerHeightTolerance is the maximum number of blocks a candidate
+	// sync peer may announce less than the highest announcing candidate and
+	// still be selected based on its historical throughput and stall count.
+	syncPeerHeightTolerance = 3
 )
 
 // zeroHash is the zero value hash (all zeros).  It is defined as a convenience.
@@ -200,6 +229,68 @@ type syncMgrPeer struct {
 	numConsecutiveOrphanHeaders int32
 
 	lastAnnouncedBlock *chainhash.Hash
+
+	// The following fields are used to score the peer when selecting a sync
+	// peer.
+	//
+	// blocksSinceCheck is the number of requested blocks the peer delivered
+	// since the last throughput measurement.
+	//
+	// throughput is the historical block download throughput of the peer in
+	// blocks per second and is only valid when throughputMeasured is set.
+	//
+	// numStalls is the number of times the peer was rotated away from being
+	// the sync peer due to poor throughput.
+	blocksSinceCheck   uint32
+	throughput         float64
+	throughputMeasured bool
+	numStalls          uint32
+}
+
+// syncScore returns a score for the peer as a candidate sync peer based on its
+// historical block download throughput and the number of times it stalled.
+// Higher scores are better.
+//
+// Peers that have not had their throughput measured are treated as having no
+// throughput so that peers known to perform well are preferred while still
+// allowing unknown peers to be chosen over ones that have stalled.
+func (peer *syncMgrPeer) syncScore() float64 {
+	var throughput float64
+	if peer.throughputMeasured {
+		throughput = peer.throughput
+	}
+	return (throughput + 1) / float64(1+peer.numStalls)
+}
+
+// updateThroughput measures the block download throughput of the peer over
+// the provided interval based on the number of requested blocks it delivered
+// since the previous measurement and combines it with its historical
+// throughput.  It returns the measured throughput along with whether or not a
+// measurement was taken, which is only the case when the peer delivered blocks
+// or had blocks in flight during the interval.
+func (peer *syncMgrPeer) updateThroughput(interval time.Duration) (float64, bool) {
+	if peer.blocksSinceCheck == 0 && len(peer.requestedBlocks) == 0 {
+		return 0, false
+	}
+
+	rate := float64(peer.blocksSinceCheck) / interval.Seconds()
+	if peer.throughputMeasured {
+		peer.throughput = peer.throughput*syncPeerThroughputDecay +
+			rate*(1-syncPeerThroughputDecay)
+	} else {
+		peer.throughput = rate
+		peer.throughputMeasured = true
+	}
+	peer.blocksSinceCheck = 0
+	return rate, true
+}
+
+// isStalled returns whether or not the peer is considered stalled given its
+// most recently measured block download throughput.  That is the case when it
+// has blocks in flight, but delivered them at a rate below the minimum
+// required.
+func (peer *syncMgrPeer) isStalled(rate float64) bool {
+	return len(peer.requestedBlocks) != 0 && rate < minSyncPeerThroughput
 }
 
 // headerSyncState houses the state used to track the header sync progress and
@@ -429,21 +520,21 @@ func (m *SyncManager) fetchNextBlocks(peer *syncMgrPeer) {
 	}
 }
 
-// startSync will choose the best peer among the available candidate peers to
-// download/sync the blockchain from.  When syncing is already running, it
-// simply returns.  It also examines the candidates for any which are no longer
-// candidates and removes them as needed.
-func (m *SyncManager) startSync() {
-	// Nothing more to do when already syncing.
-	if m.syncPeer != nil {
-		return
-	}
-
-	chain := m.cfg.Chain
-	best := chain.BestSnapshot()
-	var bestPeer *syncMgrPeer
+// bestSyncCandidate returns the best peer among the available candidate peers
+// to download/sync the blockchain from, excluding the provided peer, if any.
+// It also examines the candidates for any which are no longer candidates and
+// removes them as needed.  Nil is returned when there are no candidates.
+//
+// Candidates are scored by their announced height, historical block download
+// throughput, and the number of times they stalled.  Only candidates that
+// announced a height within a small tolerance of the highest announced height
+// are considered and the one with the best score among them is chosen with
+// ties broken in favor of the higher announced height.
+func (m *SyncManager) bestSyncCandidate(bestHeight int64, exclude *syncMgrPeer) *syncMgrPeer {
+	var candidates []*syncMgrPeer
+	var maxLastBlock int64
 	for _, peer := range m.peers {
-		if !peer.syncCandidate {
+		if !peer.syncCandidate || peer == exclude {
 			continue
 		}
 
@@ -453,19 +544,50 @@ func (m *SyncManager) startSync() {
 		// doesn't have a later block when it's equal, it will likely
 		// have one soon so it is a reasonable choice.  It also allows
 		// the case where both are at 0 such as during regression test.
-		if peer.LastBlock() < best.Height {
+		lastBlock := peer.LastBlock()
+		if lastBlock < bestHeight {
 			peer.syncCandidate = false
 			continue
 		}
 
-		// The best sync candidate is the most updated peer.
-		if bestPeer == nil {
-			bestPeer = peer
+		candidates = append(candidates, peer)
+		if lastBlock > maxLastBlock {
+			maxLastBlock = lastBlock
+		}
+	}
+
+	var bestPeer *syncMgrPeer
+	var bestScore float64
+	for _, peer := range candidates {
+		lastBlock := peer.LastBlock()
+		if lastBlock < maxLastBlock-syncPeerHeightTolerance {
+			continue
 		}
-		if bestPeer.LastBlock() < peer.LastBlock() {
+
+		score := peer.syncScore()
+		if bestPeer == nil || score > bestScore || (score == bestScore &&
+			lastBlock > bestPeer.LastBlock()) {
+
 			bestPeer = peer
+			bestScore = score
 		}
 	}
+	return bestPeer
+}
+
+// startSync will choose the best peer among the available candidate peers to
+// download/sync the blockchain from.  When syncing is already running, it
+// simply returns.  It also examines the candidates for any which are no longer
+// candidates and removes them as needed.
+func (m *SyncManager) startSync() {
+	// Nothing more to do when already syncing.
+	if m.syncPeer != nil {
+		return
+	}
+
+	chain := m.cfg.Chain
+	best := chain.BestSnapshot()
+	bestPeer := m.bestSyncCandidate(best.Height, nil)
 
 	// Update the state of whether or not the manager believes the chain is
 	// fully synced to whatever the chain believes when there is no candidate
@@ -798,6 +920,7 @@ func (m *SyncManager) handleBlockMsg(bmsg *blockMsg) {
 		peer.Disconnect()
 		return
 	}
+	peer.blocksSinceCheck++
 
 	// Save whether or not the chain believes it is current prior to processing
 	// the block for use below in determining logging behavior.
@@ -916,6 +1039,53 @@ func (m *SyncManager) handleBlockMsg(bmsg *blockMsg) {
 	}
 }
 
+// checkSyncPeerThroughput updates the historical block download throughput of
+// all peers that delivered blocks or had blocks in flight since the last check
+// and rotates the sync peer to the best alternative candidate when its
+// throughput dropped below the minimum required while downloading blocks during
+// the initial chain sync.
+//
+// This function MUST be called from the event handler goroutine.
+func (m *SyncManager) checkSyncPeerThroughput() {
+	var syncPeerRate float64
+	for _, peer := range m.peers {
+		rate, measured := peer.updateThroughput(syncPeerCheckInterval)
+		if measured && peer == m.syncPeer {
+			syncPeerRate = rate
+		}
+	}
+
+	// Nothing more to do unless the sync peer is expected to be delivering
+	// blocks during the initial chain sync and is not keeping up.
+	syncPeer := m.syncPeer
+	chain := m.cfg.Chain
+	if syncPeer == nil || !m.hdrSyncState.headersSynced || chain.IsCurrent() ||
+		!syncPeer.isStalled(syncPeerRate) {
+
+		return
+	}
+
+	// Keep the current sync peer when there is no alternative.
+	best := chain.BestSnapshot()
+	if m.bestSyncCandidate(best.Height, syncPeer) == nil {
+		return
+	}
+
+	// Penalize the sync peer for stalling and rotate to the best candidate.
+	//
+	// Note that the blocks that are still in flight remain tracked as
+	// requested from the peer so they are still accepted should they arrive,
+	// however, the list of next needed blocks is forced to be rebuilt so they
+	// are requested from the new sync peer as well.
+	log.Infof("Sync peer %s block download throughput of %.2f blocks/sec is "+
+		"below the minimum of %.2f blocks/sec -- choosing a new sync peer",
+		syncPeer, syncPeerRate, minSyncPeerThroughput)
+	syncPeer.numStalls++
+	m.syncPeer = nil
+	m.nextBlocksHeader = zeroHash
+	m.startSync()
+}
+
 // guessHeaderSyncProgress returns a percentage that is a guess of the progress
 // of the header sync progress for the given currently best known header based
 // on an algorithm that considers the total number of expected headers based on
@@ -1230,7 +1400,7 @@ func (m *SyncManager) handleNotFoundMsg(nfmsg *notFoundMsg) {
 				delete(peer.requestedBlocks, inv.Hash)
 				delete(m.requestedBlocks, inv.Hash)
 			}
-		case wire.InvTypeTx:
+		case wire.InvTypeTx, wire.InvTypeTSpend:
 			if _, exists := peer.requestedTxns[inv.Hash]; exists {
 				delete(peer.requestedTxns, inv.Hash)
 				delete(m.requestedTxns, inv.Hash)
@@ -1298,10 +1468,14 @@ func (m *SyncManager) handleInvMsg(imsg *invMsg) {
 			// Update the last block in the announced inventory.
 			lastBlock = iv
 
-		case wire.InvTypeTx:
+		case wire.InvTypeTx, wire.InvTypeTSpend:
 			// Add the tx to the cache of known inventory for the peer.  This
 			// helps avoid sending transactions to the peer that it is already
 			// known to have.
+			//
+			// Note that treasury spends are announced with a dedicated type by
+			// peers that support it, but are otherwise requested and handled
+			// the same as any other transaction.
 			peer.AddKnownInventory(iv)
 
 			// Ignore transaction announcements before the chain is current or
@@ -1387,6 +1561,9 @@ func limitAdd(m map[chainhash.Hash]struct{}, hash chainhash.Hash, limit int) {
 // because the sync manager controls which blocks are needed and how the
 // fetching should proceed.
 func (m *SyncManager) eventHandler(ctx context.Context) {
+	syncPeerCheckTicker := time.NewTicker(syncPeerCheckInterval)
+	defer syncPeerCheckTicker.Stop()
+
 out:
 	for {
 		select {
@@ -1462,6 +1639,9 @@ out:
 				log.Warnf("Invalid message type in event handler: %T", msg)
 			}
 
+		case <-syncPeerCheckTicker.C:
+			m.checkSyncPeerThroughput()
+
 		case <-m.hdrSyncState.stallTimer.C:
 			// Mark the timer's channel as having been drained so the timer can
 			// safely be reset.
@@ -1738,6 +1918,55 @@ func (m *SyncManager) IsCurrent() bool {
 	return isCurrent
 }
 
+// scriptAuditHandler periodically audits the scripts of the blocks that were
+// connected to the main chain without executing them due to being ancestors of
+// the assumed valid block once the chain is current.  This allows the initial
+// chain sync to skip executing the scripts while still verifying them after
+// the fact.  Any blocks that fail the audit are reported as critical errors.
+//
+// It must be run as a goroutine.
+func (m *SyncManager) scriptAuditHandler(ctx context.Context) {
+	ticker := time.NewTicker(scriptAuditInterval)
+	defer ticker.Stop()
+
+out:
+	for {
+		select {
+		case <-ticker.C:
+			chain := m.cfg.Chain
+			if !m.IsCurrent() || chain.ScriptAuditStats().Pending == 0 {
+				continue
+			}
+
+			numAudited, err := chain.AuditSkippedScripts(ctx,
+				maxScriptAuditBlocks)
+			switch {
+			case errors.Is(err, blockchain.ErrScriptAuditFailed):
+				log.Criticalf("Block accepted via the assumed valid block "+
+					"has invalid scripts: %v", err)
+			case errors.Is(err, context.Canceled):
+				break out
+			case err != nil:
+				log.Errorf("Unable to audit skipped scripts: %v", err)
+				continue
+			}
+
+			stats := chain.ScriptAuditStats()
+			log.Debugf("Audited scripts of %d blocks (%d pending)", numAudited,
+				stats.Pending)
+			if stats.Pending == 0 {
+				log.Infof("Script audit complete (%d verified, %d failed)",
+					stats.Verified, len(stats.Failed))
+			}
+
+		case <-ctx.Done():
+			break out
+		}
+	}
+
+	m.wg.Done()
+}
+
 // Run starts the sync manager and all other goroutines necessary for it to
 // function properly and blocks until the provided context is cancelled.
 func (m *SyncManager) Run(ctx context.Context) {
@@ -1747,6 +1976,11 @@ func (m *SyncManager) Run(ctx context.Context) {
 	m.wg.Add(1)
 	go m.eventHandler(ctx)
 
+	// Start the goroutine that audits the scripts of blocks that were
+	// connected without executing them.
+	m.wg.Add(1)
+	go m.scriptAuditHandler(ctx)
+
 	// Shutdown the sync manager when the context is cancelled.
 	m.wg.Add(1)
 	go func(ctx context.Context) {
@@ -0,0 +1,313 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package netsync
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	peerpkg "github.com/decred/dcrd/peer/v3"
+)
+
+// testSyncPeer describes the state of a sync manager peer used in the tests.
+type testSyncPeer struct {
+	lastBlock          int64
+	notCandidate       bool
+	throughput         float64
+	throughputMeasured bool
+	numStalls          uint32
+	numRequested       int
+}
+
+// newTestSyncMgrPeer returns a sync manager peer with the provided state that
+// is suitable for use in the tests.
+func newTestSyncMgrPeer(state testSyncPeer) *syncMgrPeer {
+	peer := peerpkg.NewInboundPeer(&peerpkg.Config{})
+	peer.UpdateLastBlockHeight(state.lastBlock)
+	requestedBlocks := make(map[chainhash.Hash]struct{}, state.numRequested)
+	for i := 0; i < state.numRequested; i++ {
+		requestedBlocks[chainhash.Hash{byte(i), byte(i >> 8)}] = struct{}{}
+	}
+	return &syncMgrPeer{
+		Peer:               peer,
+		syncCandidate:      !state.notCandidate,
+		requestedTxns:      make(map[chainhash.Hash]struct{}),
+		requestedBlocks:    requestedBlocks,
+		throughput:         state.throughput,
+		throughputMeasured: state.throughputMeasured,
+		numStalls:          state.numStalls,
+	}
+}
+
+// TestSyncScore ensures the score of candidate sync peers is calculated as
+// expected based on their throughput and stall count.
+func TestSyncScore(t *testing.T) {
+	tests := []struct {
+		name  string       // test description
+		peer  testSyncPeer // peer state
+		score float64      // expected score
+	}{{
+		name:  "unmeasured, no stalls",
+		peer:  testSyncPeer{},
+		score: 1,
+	}, {
+		name:  "unmeasured throughput is ignored",
+		peer:  testSyncPeer{throughput: 10},
+		score: 1,
+	}, {
+		name:  "measured zero throughput, no stalls",
+		peer:  testSyncPeer{throughputMeasured: true},
+		score: 1,
+	}, {
+		name:  "measured throughput, no stalls",
+		peer:  testSyncPeer{throughput: 4, throughputMeasured: true},
+		score: 5,
+	}, {
+		name:  "unmeasured, one stall",
+		peer:  testSyncPeer{numStalls: 1},
+		score: 0.5,
+	}, {
+		name: "measured throughput, multiple stalls",
+		peer: testSyncPeer{throughput: 5, throughputMeasured: true,
+			numStalls: 2},
+		score: 2,
+	}}
+
+	for _, test := range tests {
+		peer := newTestSyncMgrPeer(test.peer)
+		if score := peer.syncScore(); score != test.score {
+			t.Errorf("%q: unexpected score -- got %v, want %v", test.name,
+				score, test.score)
+		}
+	}
+}
+
+// TestUpdateThroughput ensures measuring the block download throughput of peers
+// over multiple intervals decays the historical throughput as expected and
+// that peers are only considered stalled when they have blocks in flight that
+// are delivered below the minimum required rate.
+func TestUpdateThroughput(t *testing.T) {
+	const interval = 10 * time.Second
+	tests := []struct {
+		name           string    // test description
+		numRequested   int       // number of blocks in flight
+		delivered      []uint32  // blocks delivered in each interval
+		wantRates      []float64 // expected rate measured in each interval
+		wantMeasured   []bool    // expected measured flag for each interval
+		wantThroughput float64   // expected final historical throughput
+		wantStalled    bool      // expected stalled state after final interval
+	}{{
+		name:           "idle peer is never measured",
+		delivered:      []uint32{0, 0},
+		wantRates:      []float64{0, 0},
+		wantMeasured:   []bool{false, false},
+		wantThroughput: 0,
+		wantStalled:    false,
+	}, {
+		name:           "first measurement is used as is",
+		delivered:      []uint32{20},
+		wantRates:      []float64{2},
+		wantMeasured:   []bool{true},
+		wantThroughput: 2,
+		wantStalled:    false,
+	}, {
+		name:           "later measurements are decayed",
+		delivered:      []uint32{20, 40, 0},
+		wantRates:      []float64{2, 4, 0},
+		wantMeasured:   []bool{true, true, false},
+		wantThroughput: 3,
+		wantStalled:    false,
+	}, {
+		name:           "blocks in flight with no deliveries",
+		numRequested:   16,
+		delivered:      []uint32{10, 0},
+		wantRates:      []float64{1, 0},
+		wantMeasured:   []bool{true, true},
+		wantThroughput: 0.5,
+		wantStalled:    true,
+	}, {
+		name:           "blocks in flight delivered below minimum",
+		numRequested:   16,
+		delivered:      []uint32{1},
+		wantRates:      []float64{0.1},
+		wantMeasured:   []bool{true},
+		wantThroughput: 0.1,
+		wantStalled:    true,
+	}, {
+		name:           "blocks in flight delivered at minimum",
+		numRequested:   16,
+		delivered:      []uint32{2},
+		wantRates:      []float64{0.2},
+		wantMeasured:   []bool{true},
+		wantThroughput: 0.2,
+		wantStalled:    false,
+	}}
+
+	const epsilon = 1e-9
+	for _, test := range tests {
+		peer := newTestSyncMgrPeer(testSyncPeer{
+			numRequested: test.numRequested,
+		})
+		var rate float64
+		for i, delivered := range test.delivered {
+			peer.blocksSinceCheck = delivered
+			var measured bool
+			rate, measured = peer.updateThroughput(interval)
+			if measured != test.wantMeasured[i] {
+				t.Fatalf("%q-%d: unexpected measured flag -- got %v, want %v",
+					test.name, i, measured, test.wantMeasured[i])
+			}
+			if math.Abs(rate-test.wantRates[i]) > epsilon {
+				t.Fatalf("%q-%d: unexpected rate -- got %v, want %v",
+					test.name, i, rate, test.wantRates[i])
+			}
+			if measured && peer.blocksSinceCheck != 0 {
+				t.Fatalf("%q-%d: delivered blocks count was not reset",
+					test.name, i)
+			}
+		}
+		if math.Abs(peer.throughput-test.wantThroughput) > epsilon {
+			t.Errorf("%q: unexpected throughput -- got %v, want %v",
+				test.name, peer.throughput, test.wantThroughput)
+		}
+		if stalled := peer.isStalled(rate); stalled != test.wantStalled {
+			t.Errorf("%q: unexpected stalled state -- got %v, want %v",
+				test.name, stalled, test.wantStalled)
+		}
+	}
+}
+
+// TestBestSyncCandidate ensures the best sync peer candidate is selected as
+// expected based on the announced heights, throughput, and stall counts of the
+// candidates and that peers which are no longer candidates are removed.
+func TestBestSyncCandidate(t *testing.T) {
+	tests := []struct {
+		name           string         // test description
+		peers          []testSyncPeer // peer states
+		bestHeight     int64          // best chain height
+		exclude        int            // index of peer to exclude (-1 for none)
+		want           int            // index of expected peer (-1 for nil)
+		wantCandidates []bool         // expected candidate state of each peer
+	}{{
+		name:           "no peers",
+		bestHeight:     100,
+		exclude:        -1,
+		want:           -1,
+		wantCandidates: nil,
+	}, {
+		name: "no candidates",
+		peers: []testSyncPeer{
+			{lastBlock: 200, notCandidate: true},
+		},
+		bestHeight:     100,
+		exclude:        -1,
+		want:           -1,
+		wantCandidates: []bool{false},
+	}, {
+		name: "candidates behind best height are removed",
+		peers: []testSyncPeer{
+			{lastBlock: 99},
+			{lastBlock: 100},
+		},
+		bestHeight:     100,
+		exclude:        -1,
+		want:           1,
+		wantCandidates: []bool{false, true},
+	}, {
+		name: "highest announced height wins equal scores",
+		peers: []testSyncPeer{
+			{lastBlock: 201},
+			{lastBlock: 203},
+			{lastBlock: 202},
+		},
+		bestHeight:     100,
+		exclude:        -1,
+		want:           1,
+		wantCandidates: []bool{true, true, true},
+	}, {
+		name: "better throughput within height tolerance wins",
+		peers: []testSyncPeer{
+			{lastBlock: 203},
+			{lastBlock: 200, throughput: 5, throughputMeasured: true},
+		},
+		bestHeight:     100,
+		exclude:        -1,
+		want:           1,
+		wantCandidates: []bool{true, true},
+	}, {
+		name: "better throughput beyond height tolerance ignored",
+		peers: []testSyncPeer{
+			{lastBlock: 204},
+			{lastBlock: 200, throughput: 5, throughputMeasured: true},
+		},
+		bestHeight:     100,
+		exclude:        -1,
+		want:           0,
+		wantCandidates: []bool{true, true},
+	}, {
+		name: "unknown peer preferred over stalled peer",
+		peers: []testSyncPeer{
+			{lastBlock: 200, throughput: 0.5, throughputMeasured: true,
+				numStalls: 1},
+			{lastBlock: 200},
+		},
+		bestHeight:     100,
+		exclude:        -1,
+		want:           1,
+		wantCandidates: []bool{true, true},
+	}, {
+		name: "excluded peer is not chosen",
+		peers: []testSyncPeer{
+			{lastBlock: 200, throughput: 5, throughputMeasured: true},
+			{lastBlock: 200},
+		},
+		bestHeight:     100,
+		exclude:        0,
+		want:           1,
+		wantCandidates: []bool{true, true},
+	}, {
+		name: "only excluded peer",
+		peers: []testSyncPeer{
+			{lastBlock: 200},
+		},
+		bestHeight:     100,
+		exclude:        0,
+		want:           -1,
+		wantCandidates: []bool{true},
+	}}
+
+	for _, test := range tests {
+		m := &SyncManager{peers: make(map[*peerpkg.Peer]*syncMgrPeer)}
+		peers := make([]*syncMgrPeer, 0, len(test.peers))
+		for _, state := range test.peers {
+			peer := newTestSyncMgrPeer(state)
+			peers = append(peers, peer)
+			m.peers[peer.Peer] = peer
+		}
+		var exclude, want *syncMgrPeer
+		if test.exclude >= 0 {
+			exclude = peers[test.exclude]
+		}
+		if test.want >= 0 {
+			want = peers[test.want]
+		}
+
+		got := m.bestSyncCandidate(test.bestHeight, exclude)
+		if got != want {
+			t.Errorf("%q: unexpected best candidate -- got %v, want %v",
+				test.name, got, want)
+			continue
+		}
+		for i, peer := range peers {
+			if peer.syncCandidate != test.wantCandidates[i] {
+				t.Errorf("%q: unexpected candidate state for peer %d -- got "+
+					"%v, want %v", test.name, i, peer.syncCandidate,
+					test.wantCandidates[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+)
+
+const (
+	// auditRedactedValue is the value recorded in the audit log in place of
+	// parameters that are redacted.
+	auditRedactedValue = "[redacted]"
+
+	// These constants define the possible statuses recorded in the audit log
+	// for privileged RPC invocations.
+	auditStatusSuccess      = "success"
+	auditStatusError        = "error"
+	auditStatusUnauthorized = "unauthorized"
+)
+
+// auditRecord describes a single entry in the audit log.  Each entry is
+// serialized as a single line of JSON.
+type auditRecord struct {
+	Time       string                 `json:"time"`
+	User       string                 `json:"user"`
+	RemoteAddr string                 `json:"remoteaddr"`
+	Method     string                 `json:"method"`
+	Params     map[string]interface{} `json:"params,omitempty"`
+	Status     string                 `json:"status"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// auditLogger appends a record for every invocation of a privileged RPC, which
+// is any method that is not available to limited users, to an underlying
+// writer as JSON lines.
+//
+// Parameters are recorded by their lowercase field names, which match the
+// names used in the help for each method, and may be redacted by rules of the
+// form "method" to redact all parameters of a method or "method.param" to only
+// redact a single parameter of a method.
+type auditLogger struct {
+	mtx sync.Mutex
+	w   io.WriteCloser
+
+	// redactAll houses the methods for which all parameters are redacted
+	// while redactParams houses the individual parameters that are redacted
+	// keyed by method.
+	redactAll    map[string]struct{}
+	redactParams map[string]map[string]struct{}
+}
+
+// newAuditLogger returns a new audit logger that writes to the provided writer
+// and redacts parameters according to the provided rules.
+func newAuditLogger(w io.WriteCloser, redactions []string) (*auditLogger, error) {
+	logger := &auditLogger{
+		w:            w,
+		redactAll:    make(map[string]struct{}),
+		redactParams: make(map[string]map[string]struct{}),
+	}
+	for _, rule := range redactions {
+		parts := strings.SplitN(strings.ToLower(rule), ".", 2)
+		method := parts[0]
+		if method == "" || (len(parts) == 2 && parts[1] == "") {
+			return nil, fmt.Errorf("malformed audit log redaction rule %q",
+				rule)
+		}
+		if len(parts) == 1 {
+			logger.redactAll[method] = struct{}{}
+			continue
+		}
+		param := parts[1]
+		params, ok := logger.redactParams[method]
+		if !ok {
+			params = make(map[string]struct{})
+			logger.redactParams[method] = params
+		}
+		params[param] = struct{}{}
+	}
+	return logger, nil
+}
+
+// auditParams converts the passed parsed command parameters to a map keyed by
+// the lowercase parameter names while applying any redaction rules for the
+// method.  Optional parameters that were not provided are omitted.
+func (l *auditLogger) auditParams(method string, params interface{}) map[string]interface{} {
+	v := reflect.Indirect(reflect.ValueOf(params))
+	if !v.IsValid() || v.Kind() != reflect.Struct || v.NumField() == 0 {
+		return nil
+	}
+
+	_, redactAll := l.redactAll[method]
+	redactParams := l.redactParams[method]
+	result := make(map[string]interface{}, v.NumField())
+	rt := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Ptr && field.IsNil() {
+			continue
+		}
+		name := strings.ToLower(rt.Field(i).Name)
+		if _, ok := redactParams[name]; ok || redactAll {
+			result[name] = auditRedactedValue
+			continue
+		}
+		result[name] = reflect.Indirect(field).Interface()
+	}
+	return result
+}
+
+// record appends an entry for the passed RPC invocation to the audit log.
+//
+// This function is safe for concurrent access.
+func (l *auditLogger) record(user, remoteAddr, method string, params interface{}, status string, err error) {
+	entry := auditRecord{
+		Time:       time.Now().UTC().Format(time.RFC3339Nano),
+		User:       user,
+		RemoteAddr: remoteAddr,
+		Method:     method,
+		Params:     l.auditParams(method, params),
+		Status:     status,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	line, mErr := json.Marshal(&entry)
+	if mErr != nil {
+		log.Errorf("Failed to marshal audit log entry for %s: %v", method,
+			mErr)
+		return
+	}
+	line = append(line, '\n')
+
+	l.mtx.Lock()
+	_, wErr := l.w.Write(line)
+	l.mtx.Unlock()
+	if wErr != nil {
+		log.Errorf("Failed to write audit log entry for %s: %v", method, wErr)
+	}
+}
+
+// close closes the underlying writer of the audit log.
+func (l *auditLogger) close() error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.w.Close()
+}
+
+// auditRequest records the invocation of the passed method in the audit log
+// when it is enabled and the method is a known privileged method.  Invocations
+// by limited users are recorded as unauthorized since they are not permitted to
+// invoke privileged methods.
+//
+// This function is safe for concurrent access.
+func (s *Server) auditRequest(isAdmin bool, remoteAddr string, method types.Method, params interface{}, err error) {
+	if s.auditLog == nil {
+		return
+	}
+	if _, ok := rpcLimited[string(method)]; ok {
+		return
+	}
+	_, isStandard := rpcHandlers[method]
+	_, isWebsocket := wsHandlers[method]
+	if !isStandard && !isWebsocket {
+		return
+	}
+
+	user, status := s.cfg.RPCUser, auditStatusSuccess
+	switch {
+	case !isAdmin:
+		user, status = s.cfg.RPCLimitUser, auditStatusUnauthorized
+	case err != nil:
+		status = auditStatusError
+	}
+	s.auditLog.record(user, remoteAddr, string(method), params, status, err)
+}
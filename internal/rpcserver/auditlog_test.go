@@ -0,0 +1,147 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcserver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+)
+
+// nopWriteCloser wraps a writer with a Close method that does nothing.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// Close does nothing and always returns nil.
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// TestAuditLog ensures the audit log records privileged RPC invocations with
+// the expected details and redacts parameters according to the configured
+// rules.
+func TestAuditLog(t *testing.T) {
+	t.Parallel()
+
+	// Ensure malformed redaction rules are rejected.
+	for _, rule := range []string{"", ".addr", "addnode."} {
+		_, err := newAuditLogger(nopWriteCloser{io.Discard}, []string{rule})
+		if err == nil {
+			t.Fatalf("did not receive expected error for rule %q", rule)
+		}
+	}
+
+	var buf bytes.Buffer
+	redactions := []string{"AddNode.Addr", "debuglevel"}
+	auditLog, err := newAuditLogger(nopWriteCloser{&buf}, redactions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := &Server{
+		cfg: Config{
+			RPCUser:      "admin",
+			RPCLimitUser: "limited",
+		},
+		auditLog: auditLog,
+	}
+
+	// Invoke a mix of privileged, unprivileged, and unknown methods.
+	const remoteAddr = "127.0.0.1:50000"
+	perm := "perm"
+	s.auditRequest(true, remoteAddr, "addnode", &types.AddNodeCmd{
+		Addr:   "192.168.0.1:9108",
+		SubCmd: types.ANAdd,
+	}, nil)
+	s.auditRequest(true, remoteAddr, "getblockcount", nil, nil)
+	s.auditRequest(true, remoteAddr, "invalidateblock",
+		&types.InvalidateBlockCmd{BlockHash: "deadbeef"},
+		errors.New("block not found"))
+	s.auditRequest(false, remoteAddr, "node", &types.NodeCmd{
+		SubCmd: types.NDisconnect,
+		Target: "192.168.0.2:9108",
+	}, rpcInvalidError("limited user not authorized for this method"))
+	s.auditRequest(true, remoteAddr, "node", &types.NodeCmd{
+		SubCmd:        types.NConnect,
+		Target:        "192.168.0.2:9108",
+		ConnectSubCmd: &perm,
+	}, nil)
+	s.auditRequest(true, remoteAddr, "debuglevel",
+		&types.DebugLevelCmd{LevelSpec: "trace"}, nil)
+	s.auditRequest(true, remoteAddr, "notarealmethod", nil, nil)
+
+	want := []auditRecord{{
+		User:   "admin",
+		Method: "addnode",
+		Params: map[string]interface{}{
+			"addr":   auditRedactedValue,
+			"subcmd": "add",
+		},
+		Status: auditStatusSuccess,
+	}, {
+		User:   "admin",
+		Method: "invalidateblock",
+		Params: map[string]interface{}{"blockhash": "deadbeef"},
+		Status: auditStatusError,
+		Error:  "block not found",
+	}, {
+		User:   "limited",
+		Method: "node",
+		Params: map[string]interface{}{
+			"subcmd": "disconnect",
+			"target": "192.168.0.2:9108",
+		},
+		Status: auditStatusUnauthorized,
+		Error:  "-8: limited user not authorized for this method",
+	}, {
+		User:   "admin",
+		Method: "node",
+		Params: map[string]interface{}{
+			"subcmd":        "connect",
+			"target":        "192.168.0.2:9108",
+			"connectsubcmd": "perm",
+		},
+		Status: auditStatusSuccess,
+	}, {
+		User:   "admin",
+		Method: "debuglevel",
+		Params: map[string]interface{}{"levelspec": auditRedactedValue},
+		Status: auditStatusSuccess,
+	}}
+
+	// Ensure only the privileged invocations were recorded with the expected
+	// details, one per line.
+	var got []auditRecord
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var record auditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("unable to unmarshal audit record %q: %v",
+				scanner.Text(), err)
+		}
+		if record.Time == "" || record.RemoteAddr != remoteAddr {
+			t.Fatalf("unexpected time or remote address in record %q",
+				scanner.Text())
+		}
+		record.Time, record.RemoteAddr = "", ""
+		got = append(got, record)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected number of audit records -- got %d, want %d",
+			len(got), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Fatalf("mismatched audit record %d -- got %+v, want %+v", i,
+				got[i], want[i])
+		}
+	}
+}
@@ -71,6 +71,26 @@ type AddrManager interface {
 	LocalAddresses() []addrmgr.LocalAddr
 }
 
+// RebroadcastTx describes a transaction submitted via the RPC server that is
+// rebroadcast until it is included in a block or expires.
+type RebroadcastTx struct {
+	// Hash is the hash of the transaction.
+	Hash chainhash.Hash
+
+	// Added is the time the transaction was submitted.
+	Added time.Time
+
+	// LastBroadcast is the last time the transaction was rebroadcast.  It is
+	// the zero time when the transaction has not been rebroadcast yet.
+	LastBroadcast time.Time
+
+	// NextBroadcast is the next time the transaction will be rebroadcast.
+	NextBroadcast time.Time
+
+	// Broadcasts is the number of times the transaction was rebroadcast.
+	Broadcasts uint32
+}
+
 // ConnManager represents a connection manager for use with the RPC server.
 //
 // The interface contract requires that all of these methods are safe for
@@ -110,6 +130,10 @@ type ConnManager interface {
 	// network for all peers.
 	NetTotals() (uint64, uint64)
 
+	// AgentFilterTotals returns the total number of peers rejected and evicted
+	// due to their user agent matching the configured patterns.
+	AgentFilterTotals() (uint64, uint64)
+
 	// ConnectedPeers returns an array consisting of all connected peers.
 	ConnectedPeers() []Peer
 
@@ -122,10 +146,24 @@ type ConnManager interface {
 	BroadcastMessage(msg wire.Message)
 
 	// AddRebroadcastInventory adds the provided inventory to the list of
-	// inventories to be rebroadcast at random intervals until they show up
-	// in a block.
+	// inventories to be rebroadcast with exponential backoff until they show
+	// up in a block or expire.
 	AddRebroadcastInventory(iv *wire.InvVect, data interface{})
 
+	// RebroadcastTransactions returns descriptions of all transactions that
+	// are being rebroadcast ordered by the time they were added.
+	RebroadcastTransactions() []RebroadcastTx
+
+	// AbandonRebroadcastTransaction stops rebroadcasting the transaction
+	// with the provided hash and returns whether or not it was being
+	// rebroadcast.
+	AbandonRebroadcastTransaction(hash *chainhash.Hash) bool
+
+	// PruneRebroadcastInventory removes inventory that no longer needs to
+	// be rebroadcast, such as expired transactions, without waiting for the
+	// next block.
+	PruneRebroadcastInventory()
+
 	// RelayTransactions generates and relays inventory vectors for all of
 	// the passed transactions to all connected peers.
 	RelayTransactions(txns []*dcrutil.Tx)
@@ -267,6 +305,15 @@ type Chain interface {
 	// the interval.
 	EstimateNextStakeDifficulty(hash *chainhash.Hash, newTickets int64, useMaxTickets bool) (int64, error)
 
+	// ForecastStakeDifficulty simulates the stake difficulty algorithm forward
+	// from the block with the provided hash for the provided number of retarget
+	// intervals by pretending the provided number of tickets will be purchased
+	// in every interval and returns the projected stake difficulty for the
+	// first block of each interval.  A negative number of tickets signifies the
+	// number of tickets that were purchased in the most recent complete
+	// interval.
+	ForecastStakeDifficulty(hash *chainhash.Hash, numIntervals, ticketsPerInterval int64) (*blockchain.StakeDiffForecast, error)
+
 	// FetchUtxoEntry loads and returns the requested unspent transaction output
 	// from the point of view of the main chain tip.
 	//
@@ -295,6 +342,44 @@ type Chain interface {
 	// deployment version.
 	GetVoteInfo(hash *chainhash.Hash, version uint32) (*blockchain.VoteInfo, error)
 
+	// IntervalVoteInfo returns the vote tallies for the agendas of the
+	// provided vote version over the rule change activation interval of the
+	// main chain that contains the provided height along with the state of
+	// the agendas during that interval.  A negative height signifies the
+	// interval that contains the current best chain tip.
+	//
+	// An error of type blockchain.ErrUnknownDeploymentVersion must be
+	// returned when the vote version does not exist and an error of type
+	// blockchain.ErrInvalidHeightRange must be returned when the height is
+	// after the current best chain tip.
+	IntervalVoteInfo(height int64, version uint32) (*blockchain.IntervalVoteInfo, error)
+
+	// VoteStats returns statistics about the number of eligible votes that
+	// were included versus missed in each block of the main chain within the
+	// provided inclusive height range.  A negative end height signifies the
+	// current best chain tip.  The per-block statistics are only included when
+	// the includeBlocks flag is set.
+	//
+	// An error of type blockchain.ErrInvalidHeightRange must be returned when
+	// the range is not valid for the main chain.
+	VoteStats(startHeight, endHeight int64, includeBlocks bool) (*blockchain.VoteStats, error)
+
+	// HeaderCommitments returns the individual commitments that the commitment
+	// root of the header for the given block hash commits to.  This function
+	// returns the commitments regardless of whether or not their associated
+	// block is part of the main chain.
+	//
+	// An error of type blockchain.ErrNoHeaderCommitment must be returned when
+	// the block does not commit to any data or the commitments are not
+	// available.
+	HeaderCommitments(hash *chainhash.Hash) (*blockchain.HeaderCommitments, error)
+
+	// FilterHeaders returns up to the provided maximum number of headers that
+	// satisfy the provided filter starting with the header of the block
+	// identified by the given hash and iterating in the given direction.  A nil
+	// filter includes all headers and a maximum of zero imposes no limit.
+	FilterHeaders(hash *chainhash.Hash, dir blockchain.HeaderIterDirection, maxHeaders uint32, filter blockchain.HeaderFilterFunc) ([]wire.BlockHeader, error)
+
 	// HeaderByHash returns the block header identified by the given hash or an
 	// error if it doesn't exist.  Note that this will return headers from both the
 	// main chain and any side chains.
@@ -376,6 +461,17 @@ type Chain interface {
 	// TreasuryBalance returns the treasury balance at the provided block.
 	TreasuryBalance(*chainhash.Hash) (*blockchain.TreasuryBalanceInfo, error)
 
+	// VerificationProgress returns an estimate of how far along the chain is
+	// in verifying the entire network's chain as a value in the range [0, 1]
+	// based on the cumulative work of the current best chain tip relative to
+	// the estimated cumulative work of the network.
+	VerificationProgress() float64
+
+	// IsHeaderCommitmentsAgendaActive returns whether or not the header
+	// commitments agenda vote, as defined in DCP0005, has passed and is now
+	// active for the block AFTER the given block.
+	IsHeaderCommitmentsAgendaActive(*chainhash.Hash) (bool, error)
+
 	// IsTreasuryAgendaActive returns whether or not the treasury agenda vote, as
 	// defined in DCP0006, has passed and is now active for the block AFTER the
 	// given block.
@@ -415,6 +511,30 @@ type Chain interface {
 	// block with the most cumulative proof of work that is valid becomes the
 	// tip of the main chain.
 	ReconsiderBlock(*chainhash.Hash) error
+
+	// ApproveDeepReorg approves a chain reorganization to the branch that
+	// contains the provided block even when it disconnects more blocks from
+	// the current best chain than the maximum automatic reorganization depth
+	// allows.  It then reorganizes the chain to the best chain candidate as
+	// necessary.
+	ApproveDeepReorg(*chainhash.Hash) error
+
+	// Scrub performs an online integrity check of the database by verifying
+	// the data of all blocks the block index claims are available along with
+	// the spend journal entries of all blocks in the main chain.  Side chain
+	// blocks with issues are quarantined when the quarantine flag is set.
+	Scrub(ctx context.Context, quarantine bool) (*blockchain.ScrubResult, error)
+
+	// SideChainBlocks returns all blocks that are not part of the main chain
+	// and have their data available ordered from the most stale branch to the
+	// least stale branch.
+	SideChainBlocks() []blockchain.SideChainBlock
+
+	// PurgeSideChainBlocks evicts all blocks that are not part of the main
+	// chain, have their data available, and are part of a branch that forks
+	// from the main chain at least the provided number of blocks before its
+	// tip.  It returns the evicted blocks.
+	PurgeSideChainBlocks(minDepth int64) ([]blockchain.SideChainBlock, error)
 }
 
 // Clock represents a clock for use with the RPC server. The purpose of this
@@ -430,6 +550,17 @@ type Clock interface {
 	Since(t time.Time) time.Duration
 }
 
+// MockTimeSource represents a source of the network-adjusted time used by the
+// node that may be overridden for testing purposes.
+//
+// The interface contract requires that all of these methods are safe for
+// concurrent access.
+type MockTimeSource interface {
+	// SetMockTime overrides the network-adjusted time with the provided
+	// time until it is called again.  The zero time removes the override.
+	SetMockTime(t time.Time)
+}
+
 // FeeEstimator provides an interface that tracks historical data for published
 // and mined transactions in order to estimate fees to be used in new
 // transactions for confirmation within a target block window.
@@ -478,6 +609,10 @@ type CPUMiner interface {
 	// GenerateNBlocks generates the requested number of blocks.
 	GenerateNBlocks(ctx context.Context, n uint32) ([]*chainhash.Hash, error)
 
+	// GenerateNBlocksToAddress generates the requested number of blocks with
+	// coinbases that pay to the provided address.
+	GenerateNBlocksToAddress(ctx context.Context, n uint32, payToAddr stdaddr.Address) ([]*chainhash.Hash, error)
+
 	// IsMining returns whether or not the CPU miner has been started and is
 	// therefore currently mining.
 	IsMining() bool
@@ -605,6 +740,35 @@ type TxMempooler interface {
 	// TSpendHashes returns the hashes of the treasury spend transactions
 	// currently in the mempool.
 	TSpendHashes() []chainhash.Hash
+
+	// MinRelayTxFee returns the minimum transaction fee in Atoms/1000 bytes
+	// that is considered a non-zero fee.
+	MinRelayTxFee() dcrutil.Amount
+
+	// CheckAcceptTransaction performs the same validation as accepting the
+	// passed transaction into the pool without actually adding it.  It
+	// returns a descriptor for the transaction when it would be accepted.
+	CheckAcceptTransaction(tx *dcrutil.Tx, allowHighFees bool) (*mempool.TxDesc, error)
+
+	// PruneExpiredTx removes all transactions from the pool that have
+	// expired as of the current best chain height.
+	PruneExpiredTx()
+
+	// CalcTxMiningWindow returns the range of blocks after the current best
+	// chain tip that may include the passed transaction while satisfying the
+	// relative lock times of its inputs and its expiry.  Inputs that spend
+	// outputs of transactions in the pool are treated as if those
+	// transactions will be included in the next block.
+	CalcTxMiningWindow(tx *dcrutil.Tx) (*blockchain.TxMiningWindow, error)
+
+	// Events returns the events recorded in the mempool event journal
+	// ordered from oldest to newest.
+	Events() []mempool.Event
+
+	// CheckBlockConflicts returns which of the transactions in the pool would
+	// be removed as a result of connecting the passed block to the main
+	// chain without modifying the pool.
+	CheckBlockConflicts(block *dcrutil.Block) *blockchain.BlockConflicts
 }
 
 // TxIndexer provides an interface for retrieving details for a given
@@ -629,6 +793,49 @@ type TxIndexer interface {
 	Entry(hash *chainhash.Hash) (*indexers.TxIndexEntry, error)
 }
 
+// NullDataIndexer provides an interface for retrieving the null data payloads
+// that start with a given prefix.
+//
+// The interface contract requires that all of these methods are safe for
+// concurrent access.
+type NullDataIndexer interface {
+	// Name returns the human-readable name of the index.
+	Name() string
+
+	// Tip returns the current index tip.
+	Tip() (int64, *chainhash.Hash, error)
+
+	// WaitForSync subscribes clients for the next index sync update.
+	WaitForSync() chan bool
+
+	// Entries returns the entries for the provided prefix within the provided
+	// inclusive range of block heights ordered by their location in the chain.
+	// At most maxEntries entries are returned when it is greater than zero.
+	// An error must be returned when the prefix is not indexed.
+	Entries(prefix []byte, startHeight, endHeight int64, maxEntries int) ([]indexers.NullDataEntry, error)
+}
+
+// UtxoHistoryIndexer provides an interface for retrieving the history of
+// transaction outputs in the main chain.
+//
+// The interface contract requires that all of these methods are safe for
+// concurrent access.
+type UtxoHistoryIndexer interface {
+	// Name returns the human-readable name of the index.
+	Name() string
+
+	// Tip returns the current index tip.
+	Tip() (int64, *chainhash.Hash, error)
+
+	// WaitForSync subscribes clients for the next index sync update.
+	WaitForSync() chan bool
+
+	// Entry returns the details and history of the provided transaction
+	// output.  When there is no entry for the provided output, nil must be
+	// returned for both the entry and the error.
+	Entry(hash *chainhash.Hash, index uint32) (*indexers.UtxoHistoryEntry, error)
+}
+
 // NtfnManager provides an interface for processing and sending chain
 // notifications.
 //
@@ -653,6 +860,16 @@ type NtfnManager interface {
 	// the manager for processing.
 	NotifyReorganization(rd *blockchain.ReorganizationNtfnsData)
 
+	// NotifyDeepReorgPaused passes a notification that a blockchain
+	// reorganization was paused due to exceeding the maximum automatic
+	// reorganization depth to the manager for processing.
+	NotifyDeepReorgPaused(pd *blockchain.DeepReorgPausedNtfnsData)
+
+	// NotifyVoteLeaderChanged passes a notification that a new vote in the
+	// mempool caused a different block to become the most favorable one to
+	// build on at a given height to the manager for processing.
+	NotifyVoteLeaderChanged(vd *VoteLeaderChangedNtfnData)
+
 	// NotifyWinningTickets passes newly winning tickets to the manager for
 	// processing.
 	NotifyWinningTickets(wtnd *WinningTicketsNtfnData)
@@ -708,6 +925,14 @@ type NtfnManager interface {
 	// the passed websocket client.
 	UnregisterNewTickets(wsc *wsClient)
 
+	// RegisterStakeEvents requests stake event notifications for the tickets
+	// watched by the passed websocket client.
+	RegisterStakeEvents(wsc *wsClient)
+
+	// UnregisterStakeEvents removes stake event notifications for the passed
+	// websocket client.
+	UnregisterStakeEvents(wsc *wsClient)
+
 	// RegisterNewMempoolTxsUpdates requests notifications to the passed websocket
 	// client when new transactions are added to the memory pool.
 	RegisterNewMempoolTxsUpdates(wsc *wsClient)
@@ -741,3 +966,16 @@ type RPCHelpCacher interface {
 	// RPCUsage returns one-line usage for all supported RPC commands.
 	RPCUsage(includeWebsockets bool) (string, error)
 }
+
+// ConfigReloader represents a source of configuration that can be reloaded
+// while the process is running.
+//
+// The interface contract requires that all of these methods are safe for
+// concurrent access.
+type ConfigReloader interface {
+	// ReloadConfig reloads the configuration options that can safely be
+	// changed at runtime and applies them.  It returns a description of each
+	// option that changed.  An error must be returned without applying any
+	// changes when any of the reloaded options are invalid.
+	ReloadConfig() ([]string, error)
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcserver
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+const (
+	// responseCacheMinConfirmations is the minimum number of confirmations
+	// the block associated with a response must have in the main chain in
+	// order for the response to be cached.  It ensures responses that might
+	// still change due to a reorganization are not cached.
+	responseCacheMinConfirmations = 6
+
+	// responseCacheEntryOverhead is the approximate number of bytes used to
+	// track each cached response in addition to the response itself.
+	responseCacheEntryOverhead = 128
+)
+
+// responseCacheKey identifies a cached response by the RPC method that
+// produced it and the hash of the block or transaction it is for.
+type responseCacheKey struct {
+	method string
+	hash   chainhash.Hash
+}
+
+// responseCacheEntry houses a cached response along with its key and
+// approximate size in bytes.
+type responseCacheEntry struct {
+	key   responseCacheKey
+	value interface{}
+	size  int64
+}
+
+// responseCacheStats houses counters related to the RPC response cache.
+type responseCacheStats struct {
+	// Hits is the total number of lookups that were served from the cache.
+	Hits uint64
+
+	// Misses is the total number of lookups that were not served from the
+	// cache.
+	Misses uint64
+
+	// Entries is the number of responses currently in the cache.
+	Entries int
+
+	// Bytes is the approximate number of bytes currently used by the cache.
+	Bytes int64
+
+	// MaxBytes is the maximum number of bytes the cache may use.
+	MaxBytes int64
+}
+
+// responseCache provides a concurrency safe least-recently-used cache of RPC
+// responses that are immutable, such as those for deeply confirmed blocks and
+// transactions.  It is limited to a maximum approximate number of bytes with
+// eviction of the least recently used responses when the limit is exceeded.
+//
+// The cache is disabled when the limit is zero, however, the counters are
+// still updated.
+type responseCache struct {
+	mtx      sync.Mutex
+	entries  map[responseCacheKey]*list.Element
+	lru      *list.List
+	numBytes int64
+	maxBytes int64
+	hits     uint64
+	misses   uint64
+}
+
+// newResponseCache returns a new RPC response cache that is limited to the
+// provided approximate number of bytes.
+func newResponseCache(maxBytes int64) *responseCache {
+	return &responseCache{
+		entries:  make(map[responseCacheKey]*list.Element),
+		lru:      list.New(),
+		maxBytes: maxBytes,
+	}
+}
+
+// enabled returns whether or not the cache is able to hold any responses.
+func (c *responseCache) enabled() bool {
+	return c.maxBytes > 0
+}
+
+// lookup returns the cached response for the provided method and hash, if
+// any.  Looking up an existing response makes it the most recently used one.
+//
+// This function is safe for concurrent access.
+func (c *responseCache) lookup(method string, hash *chainhash.Hash) (interface{}, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	elem, ok := c.entries[responseCacheKey{method, *hash}]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*responseCacheEntry).value, true
+}
+
+// add caches the provided response for the method and hash along with its
+// approximate size in bytes and evicts the least recently used responses as
+// needed to stay within the limit.  Responses that are larger than the limit
+// on their own are not cached.
+//
+// This function is safe for concurrent access.
+func (c *responseCache) add(method string, hash *chainhash.Hash, value interface{}, size int64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	size += responseCacheEntryOverhead
+	if size > c.maxBytes {
+		return
+	}
+	key := responseCacheKey{method, *hash}
+	if _, ok := c.entries[key]; ok {
+		return
+	}
+	for c.numBytes+size > c.maxBytes {
+		oldest := c.lru.Back()
+		entry := c.lru.Remove(oldest).(*responseCacheEntry)
+		delete(c.entries, entry.key)
+		c.numBytes -= entry.size
+	}
+	entry := &responseCacheEntry{key: key, value: value, size: size}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.numBytes += size
+}
+
+// removeMethod removes all cached responses for the provided method.
+//
+// This function is safe for concurrent access.
+func (c *responseCache) removeMethod(method string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for key, elem := range c.entries {
+		if key.method != method {
+			continue
+		}
+		entry := c.lru.Remove(elem).(*responseCacheEntry)
+		delete(c.entries, key)
+		c.numBytes -= entry.size
+	}
+}
+
+// stats returns the current counters related to the cache.
+//
+// This function is safe for concurrent access.
+func (c *responseCache) stats() responseCacheStats {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return responseCacheStats{
+		Hits:     c.hits,
+		Misses:   c.misses,
+		Entries:  len(c.entries),
+		Bytes:    c.numBytes,
+		MaxBytes: c.maxBytes,
+	}
+}
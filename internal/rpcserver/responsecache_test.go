@@ -0,0 +1,94 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcserver
+
+import (
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+// TestResponseCache ensures the RPC response cache serves cached responses,
+// evicts the least recently used responses to stay within its limit, and
+// tracks the expected counters.
+func TestResponseCache(t *testing.T) {
+	t.Parallel()
+
+	hash1 := chainhash.Hash{0x01}
+	hash2 := chainhash.Hash{0x02}
+	hash3 := chainhash.Hash{0x03}
+
+	// Create a cache that is large enough for exactly two responses of the
+	// size used in the test.
+	const respSize = 100
+	c := newResponseCache(2 * (respSize + responseCacheEntryOverhead))
+	if _, ok := c.lookup("getblock", &hash1); ok {
+		t.Fatal("lookup of response that was never added succeeded")
+	}
+	c.add("getblock", &hash1, "block1", respSize)
+	c.add("getblock", &hash2, "block2", respSize)
+
+	// Ensure cached responses are keyed by both the method and hash.
+	if resp, ok := c.lookup("getblock", &hash1); !ok || resp != "block1" {
+		t.Fatalf("unexpected cached response -- got %v (found %v), want "+
+			"block1", resp, ok)
+	}
+	if _, ok := c.lookup("getrawtransaction", &hash1); ok {
+		t.Fatal("lookup of response for another method succeeded")
+	}
+
+	// Ensure adding another response evicts the least recently used one,
+	// which is the second response since the first one was just looked up.
+	c.add("getblock", &hash3, "block3", respSize)
+	if _, ok := c.lookup("getblock", &hash2); ok {
+		t.Fatal("least recently used response was not evicted")
+	}
+	for _, hash := range []*chainhash.Hash{&hash1, &hash3} {
+		if _, ok := c.lookup("getblock", hash); !ok {
+			t.Fatalf("response for %v was unexpectedly evicted", hash)
+		}
+	}
+
+	// Ensure responses larger than the limit are not cached.
+	c.add("getblock", &hash2, "block2", 5*respSize)
+	if _, ok := c.lookup("getblock", &hash2); ok {
+		t.Fatal("response larger than the limit was cached")
+	}
+
+	stats := c.stats()
+	wantStats := responseCacheStats{
+		Hits:     3,
+		Misses:   4,
+		Entries:  2,
+		Bytes:    2 * (respSize + responseCacheEntryOverhead),
+		MaxBytes: 2 * (respSize + responseCacheEntryOverhead),
+	}
+	if stats != wantStats {
+		t.Fatalf("unexpected stats -- got %+v, want %+v", stats, wantStats)
+	}
+
+	// Ensure removing the responses for a method only removes those for the
+	// method.
+	c.add("getrawtransaction", &hash2, "tx2", respSize)
+	c.removeMethod("getrawtransaction")
+	if _, ok := c.lookup("getrawtransaction", &hash2); ok {
+		t.Fatal("response for removed method is still cached")
+	}
+	if _, ok := c.lookup("getblock", &hash3); !ok {
+		t.Fatal("response for another method was unexpectedly removed")
+	}
+	if stats := c.stats(); stats.Entries != 1 ||
+		stats.Bytes != respSize+responseCacheEntryOverhead {
+
+		t.Fatalf("unexpected stats after removal -- got %+v", stats)
+	}
+
+	// Ensure nothing is cached when the cache is disabled.
+	c = newResponseCache(0)
+	c.add("getblock", &hash1, "block1", respSize)
+	if _, ok := c.lookup("getblock", &hash1); ok {
+		t.Fatal("response was cached with the cache disabled")
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcserver
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// maxAuthLockout is the maximum duration a remote host is locked out
+	// from authenticating after repeated authentication failures regardless
+	// of the number of failures.
+	maxAuthLockout = time.Hour
+
+	// maxTrackedAuthFailureHosts is the maximum number of remote hosts the
+	// authentication failures are tracked for at once.  It prevents clients
+	// that make use of a large number of addresses from exhausting memory.
+	maxTrackedAuthFailureHosts = 10000
+)
+
+// remoteHost returns the host portion of the provided remote address.  The
+// address is returned unmodified when it does not contain a port.
+func remoteHost(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+// authFailureState houses the authentication failure details for a remote
+// host.
+type authFailureState struct {
+	// failures is the number of consecutive authentication failures.
+	failures int
+
+	// lockedUntil is the time the host is locked out from authenticating
+	// until.  It is the zero time when the host is not locked out.
+	lockedUntil time.Time
+}
+
+// clientLimitStats houses counters related to the per remote host limits
+// imposed on RPC clients.
+type clientLimitStats struct {
+	// RejectedWebsockets is the total number of websocket clients that were
+	// disconnected due to exceeding the per remote host limit.
+	RejectedWebsockets uint64
+
+	// AuthFailures is the total number of authentication failures.
+	AuthFailures uint64
+
+	// Lockouts is the total number of times a remote host was locked out
+	// from authenticating due to repeated authentication failures.
+	Lockouts uint64
+
+	// LockedOutHosts is the number of remote hosts that are currently locked
+	// out from authenticating.
+	LockedOutHosts int
+}
+
+// clientLimiter imposes limits on RPC clients on a per remote host basis.
+//
+// It limits the number of concurrent websocket clients per remote host and
+// locks remote hosts out from authenticating for an exponentially increasing
+// duration once they reach a threshold of consecutive authentication failures.
+// A successful authentication resets the number of failures.
+//
+// The limits are disabled when they are zero, however, the counters are still
+// updated.
+type clientLimiter struct {
+	// maxWebsocketsPerHost is the maximum number of concurrent websocket
+	// clients per remote host.
+	maxWebsocketsPerHost int
+
+	// maxAuthFailures is the number of consecutive authentication failures
+	// that cause a remote host to be locked out.  Every additional failure
+	// after that doubles the lockout duration up to maxAuthLockout.
+	maxAuthFailures int
+
+	// authLockout is the duration of the initial lockout.
+	authLockout time.Duration
+
+	// now returns the current time.  It is overridden by the tests.
+	now func() time.Time
+
+	mtx          sync.Mutex
+	websockets   map[string]int
+	authFailures map[string]*authFailureState
+	totals       clientLimitStats
+}
+
+// newClientLimiter returns a new client limiter with the provided limits.
+func newClientLimiter(maxWebsocketsPerHost, maxAuthFailures int, authLockout time.Duration) *clientLimiter {
+	return &clientLimiter{
+		maxWebsocketsPerHost: maxWebsocketsPerHost,
+		maxAuthFailures:      maxAuthFailures,
+		authLockout:          authLockout,
+		now:                  time.Now,
+		websockets:           make(map[string]int),
+		authFailures:         make(map[string]*authFailureState),
+	}
+}
+
+// addWebsocket attempts to add a websocket client for the provided remote
+// address and returns whether or not it is allowed.  Every allowed client must
+// be removed via removeWebsocket once it disconnects.
+//
+// This function is safe for concurrent access.
+func (l *clientLimiter) addWebsocket(remoteAddr string) bool {
+	if l.maxWebsocketsPerHost <= 0 {
+		return true
+	}
+
+	host := remoteHost(remoteAddr)
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.websockets[host] >= l.maxWebsocketsPerHost {
+		l.totals.RejectedWebsockets++
+		return false
+	}
+	l.websockets[host]++
+	return true
+}
+
+// removeWebsocket removes a websocket client for the provided remote address
+// that was previously allowed by addWebsocket.
+//
+// This function is safe for concurrent access.
+func (l *clientLimiter) removeWebsocket(remoteAddr string) {
+	if l.maxWebsocketsPerHost <= 0 {
+		return
+	}
+
+	host := remoteHost(remoteAddr)
+	l.mtx.Lock()
+	if l.websockets[host] <= 1 {
+		delete(l.websockets, host)
+	} else {
+		l.websockets[host]--
+	}
+	l.mtx.Unlock()
+}
+
+// lockedOut returns the remaining duration the provided remote address is
+// locked out from authenticating along with whether or not it is locked out.
+//
+// This function is safe for concurrent access.
+func (l *clientLimiter) lockedOut(remoteAddr string) (time.Duration, bool) {
+	if l.maxAuthFailures <= 0 {
+		return 0, false
+	}
+
+	host := remoteHost(remoteAddr)
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	state, ok := l.authFailures[host]
+	if !ok {
+		return 0, false
+	}
+	remaining := state.lockedUntil.Sub(l.now())
+	return remaining, remaining > 0
+}
+
+// pruneAuthFailures removes tracked authentication failures for remote hosts
+// that are not currently locked out.  When there are still too many tracked
+// hosts afterwards, an arbitrary host is removed to make room.
+//
+// This function MUST be called with the limiter mutex held (for writes).
+func (l *clientLimiter) pruneAuthFailures(now time.Time) {
+	for host, state := range l.authFailures {
+		if !now.Before(state.lockedUntil) {
+			delete(l.authFailures, host)
+		}
+	}
+	for host := range l.authFailures {
+		if len(l.authFailures) < maxTrackedAuthFailureHosts {
+			break
+		}
+		delete(l.authFailures, host)
+	}
+}
+
+// authFailed records an authentication failure for the provided remote
+// address and returns the duration it is locked out for as a result, if any.
+//
+// This function is safe for concurrent access.
+func (l *clientLimiter) authFailed(remoteAddr string) time.Duration {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.totals.AuthFailures++
+	if l.maxAuthFailures <= 0 {
+		return 0
+	}
+
+	now := l.now()
+	host := remoteHost(remoteAddr)
+	state, ok := l.authFailures[host]
+	if !ok {
+		if len(l.authFailures) >= maxTrackedAuthFailureHosts {
+			l.pruneAuthFailures(now)
+		}
+		state = new(authFailureState)
+		l.authFailures[host] = state
+	}
+	state.failures++
+	if state.failures < l.maxAuthFailures {
+		return 0
+	}
+
+	// Double the lockout duration for every failure beyond the threshold
+	// while limiting it to the maximum.
+	lockout := l.authLockout
+	for i := l.maxAuthFailures; i < state.failures && lockout < maxAuthLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > maxAuthLockout {
+		lockout = maxAuthLockout
+	}
+	state.lockedUntil = now.Add(lockout)
+	l.totals.Lockouts++
+	return lockout
+}
+
+// authSucceeded resets the authentication failures for the provided remote
+// address.
+//
+// This function is safe for concurrent access.
+func (l *clientLimiter) authSucceeded(remoteAddr string) {
+	if l.maxAuthFailures <= 0 {
+		return
+	}
+
+	host := remoteHost(remoteAddr)
+	l.mtx.Lock()
+	delete(l.authFailures, host)
+	l.mtx.Unlock()
+}
+
+// stats returns the current counters related to the limits.
+//
+// This function is safe for concurrent access.
+func (l *clientLimiter) stats() clientLimitStats {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	stats := l.totals
+	now := l.now()
+	for _, state := range l.authFailures {
+		if now.Before(state.lockedUntil) {
+			stats.LockedOutHosts++
+		}
+	}
+	return stats
+}
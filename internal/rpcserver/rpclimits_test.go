@@ -0,0 +1,181 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcserver
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestClientLimiterWebsockets ensures the per remote host websocket client
+// limit works as expected.
+func TestClientLimiterWebsockets(t *testing.T) {
+	t.Parallel()
+
+	l := newClientLimiter(2, 0, 0)
+	for i, addr := range []string{"127.0.0.1:1000", "127.0.0.1:1001"} {
+		if !l.addWebsocket(addr) {
+			t.Fatalf("websocket %d was rejected", i)
+		}
+	}
+
+	// Ensure additional clients from the same host are rejected regardless of
+	// their port while clients from other hosts are allowed.
+	if l.addWebsocket("127.0.0.1:1002") {
+		t.Fatal("websocket exceeding the per host limit was allowed")
+	}
+	if !l.addWebsocket("[::1]:1000") {
+		t.Fatal("websocket from another host was rejected")
+	}
+
+	// Ensure a client is allowed again once another one from the same host
+	// disconnects.
+	l.removeWebsocket("127.0.0.1:1000")
+	if !l.addWebsocket("127.0.0.1:1003") {
+		t.Fatal("websocket was rejected after another one disconnected")
+	}
+	if stats := l.stats(); stats.RejectedWebsockets != 1 {
+		t.Fatalf("unexpected rejected websockets -- got %d, want 1",
+			stats.RejectedWebsockets)
+	}
+
+	// Ensure there is no limit when it is disabled.
+	l = newClientLimiter(0, 0, 0)
+	for i := 0; i < 100; i++ {
+		if !l.addWebsocket("127.0.0.1:1000") {
+			t.Fatalf("websocket %d was rejected with the limit disabled", i)
+		}
+	}
+}
+
+// TestClientLimiterAuthLockout ensures remote hosts are locked out from
+// authenticating for exponentially increasing durations after repeated
+// authentication failures.
+func TestClientLimiterAuthLockout(t *testing.T) {
+	t.Parallel()
+
+	const addr = "127.0.0.1:1000"
+	now := time.Unix(1650000000, 0)
+	l := newClientLimiter(0, 3, time.Minute)
+	l.now = func() time.Time { return now }
+
+	// Ensure the host is not locked out until the threshold is reached.
+	for i := 0; i < 2; i++ {
+		if lockout := l.authFailed(addr); lockout != 0 {
+			t.Fatalf("failure %d: unexpected lockout %v", i, lockout)
+		}
+		if _, lockedOut := l.lockedOut(addr); lockedOut {
+			t.Fatalf("failure %d: unexpectedly locked out", i)
+		}
+	}
+
+	// Ensure reaching the threshold locks the host out for the initial
+	// lockout duration while other hosts are not locked out.
+	if lockout := l.authFailed(addr); lockout != time.Minute {
+		t.Fatalf("unexpected lockout -- got %v, want %v", lockout,
+			time.Minute)
+	}
+	if remaining, lockedOut := l.lockedOut(addr); !lockedOut ||
+		remaining != time.Minute {
+
+		t.Fatalf("unexpected lockout state -- got %v (%v), want true (%v)",
+			lockedOut, remaining, time.Minute)
+	}
+	if _, lockedOut := l.lockedOut("127.0.0.2:1000"); lockedOut {
+		t.Fatal("unrelated host is locked out")
+	}
+
+	// Ensure the lockout expires and that every additional failure doubles
+	// the lockout duration up to the maximum.
+	wantLockout := time.Minute
+	for wantLockout < maxAuthLockout {
+		now = now.Add(wantLockout)
+		if _, lockedOut := l.lockedOut(addr); lockedOut {
+			t.Fatalf("still locked out after %v", wantLockout)
+		}
+		wantLockout *= 2
+		if wantLockout > maxAuthLockout {
+			wantLockout = maxAuthLockout
+		}
+		if lockout := l.authFailed(addr); lockout != wantLockout {
+			t.Fatalf("unexpected lockout -- got %v, want %v", lockout,
+				wantLockout)
+		}
+	}
+	if lockout := l.authFailed(addr); lockout != maxAuthLockout {
+		t.Fatalf("unexpected lockout -- got %v, want %v", lockout,
+			maxAuthLockout)
+	}
+
+	stats := l.stats()
+	if stats.AuthFailures != 10 || stats.Lockouts != 8 ||
+		stats.LockedOutHosts != 1 {
+
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+
+	// Ensure a successful authentication resets the failures.
+	l.authSucceeded(addr)
+	if _, lockedOut := l.lockedOut(addr); lockedOut {
+		t.Fatal("still locked out after successful authentication")
+	}
+	if lockout := l.authFailed(addr); lockout != 0 {
+		t.Fatalf("unexpected lockout after reset %v", lockout)
+	}
+	if stats := l.stats(); stats.LockedOutHosts != 0 {
+		t.Fatalf("unexpected locked out hosts -- got %d, want 0",
+			stats.LockedOutHosts)
+	}
+}
+
+// TestCheckAuthLockout ensures the server rejects authentication attempts from
+// clients that are locked out, even with valid credentials.
+func TestCheckAuthLockout(t *testing.T) {
+	t.Parallel()
+
+	s, err := New(&Config{
+		RPCUser:            "user",
+		RPCPass:            "pass",
+		RPCMaxAuthFailures: 2,
+		RPCAuthLockout:     time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("unable to create RPC server: %v", err)
+	}
+	now := time.Unix(1650000000, 0)
+	s.limiter.now = func() time.Time { return now }
+
+	// Fail authentication via both methods to trigger a lockout.
+	const addr = "127.0.0.1:1000"
+	r := &http.Request{RemoteAddr: addr, Header: make(http.Header)}
+	r.Header.Set("Authorization", "Basic Nothing")
+	if _, _, err := s.checkAuth(r, true); err == nil ||
+		errors.Is(err, errAuthLockedOut) {
+
+		t.Fatalf("unexpected err -- got %v, want auth failure", err)
+	}
+	if authed, _ := s.checkAuthUserPass("user", "wrong", addr); authed {
+		t.Fatal("invalid credentials were accepted")
+	}
+
+	// Ensure valid credentials are rejected while locked out.
+	if authed, _ := s.checkAuthUserPass("user", "pass", addr); authed {
+		t.Fatal("valid credentials were accepted while locked out")
+	}
+	r.SetBasicAuth("user", "pass")
+	if _, _, err := s.checkAuth(r, true); !errors.Is(err, errAuthLockedOut) {
+		t.Fatalf("unexpected err -- got %v, want %v", err, errAuthLockedOut)
+	}
+
+	// Ensure valid credentials are accepted once the lockout expires.
+	now = now.Add(time.Minute)
+	authed, isAdmin, err := s.checkAuth(r, true)
+	if err != nil || !authed || !isAdmin {
+		t.Fatalf("unexpected result -- got %v, %v, %v, want true, true, <nil>",
+			authed, isAdmin, err)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"runtime"
 	"sort"
 	"strconv"
@@ -47,6 +48,7 @@ import (
 	"github.com/decred/dcrd/dcrjson/v4"
 	"github.com/decred/dcrd/dcrutil/v4"
 	"github.com/decred/dcrd/internal/blockchain"
+	"github.com/decred/dcrd/internal/blockchain/indexers"
 	"github.com/decred/dcrd/internal/mempool"
 	"github.com/decred/dcrd/internal/mining"
 	"github.com/decred/dcrd/internal/version"
@@ -105,9 +107,25 @@ const (
 	// of a block.
 	merkleRootPairSize = 64
 
+	// maxTestMempoolAcceptTxns is the maximum number of transactions that
+	// may be checked by a single testmempoolaccept request.
+	maxTestMempoolAcceptTxns = 25
+
 	// syncWait is the maximum time in seconds to wait for an index
 	// to sync with the main chain.
 	syncWait = time.Second * 3
+
+	// maxNullDataResults is the maximum number of entries returned by the
+	// getnulldata RPC.
+	maxNullDataResults = 1000
+
+	// maxStakeDiffForecastIntervals is the maximum number of stake difficulty
+	// retarget intervals that may be simulated by the forecaststakediff RPC.
+	maxStakeDiffForecastIntervals = 100
+
+	// maxGetRawTransactionsTxns is the maximum number of transactions that may
+	// be requested by a single getrawtransactions request.
+	maxGetRawTransactionsTxns = 2000
 )
 
 var (
@@ -146,6 +164,19 @@ var (
 		Code:    dcrjson.ErrRPCNoWallet,
 		Message: "This implementation does not implement wallet commands",
 	}
+
+	// ErrRPCDebugDisabled is an error returned to RPC clients when the
+	// provided command is only intended for testing and the server was not
+	// started with the debug RPCs enabled.
+	ErrRPCDebugDisabled = &dcrjson.RPCError{
+		Code:    dcrjson.ErrRPCMethodNotFound.Code,
+		Message: "Command is only available when the --debugrpc option is set",
+	}
+
+	// errAuthLockedOut is returned when checking the authentication of a
+	// client that is locked out from authenticating due to repeated
+	// authentication failures.
+	errAuthLockedOut = errors.New("auth locked out")
 )
 
 type commandHandler func(context.Context, *Server, interface{}) (interface{}, error)
@@ -155,7 +186,10 @@ type commandHandler func(context.Context, *Server, interface{}) (interface{}, er
 // a dependency loop.
 var rpcHandlers map[types.Method]commandHandler
 var rpcHandlersBeforeInit = map[types.Method]commandHandler{
+	"abandonrebroadcasttx":  handleAbandonRebroadcastTx,
 	"addnode":               handleAddNode,
+	"approvedeepreorg":      handleApproveDeepReorg,
+	"checkblockconflicts":   handleCheckBlockConflicts,
 	"createrawsstx":         handleCreateRawSStx,
 	"createrawssrtx":        handleCreateRawSSRtx,
 	"createrawtransaction":  handleCreateRawTransaction,
@@ -170,7 +204,10 @@ var rpcHandlersBeforeInit = map[types.Method]commandHandler{
 	"existsliveticket":      handleExistsLiveTicket,
 	"existslivetickets":     handleExistsLiveTickets,
 	"existsmempooltxs":      handleExistsMempoolTxs,
+	"forceprune":            handleForcePrune,
+	"forecaststakediff":     handleForecastStakeDiff,
 	"generate":              handleGenerate,
+	"generatetoaddress":     handleGenerateToAddress,
 	"getaddednodeinfo":      handleGetAddedNodeInfo,
 	"getbestblock":          handleGetBestBlock,
 	"getbestblockhash":      handleGetBestBlockHash,
@@ -179,6 +216,8 @@ var rpcHandlersBeforeInit = map[types.Method]commandHandler{
 	"getblockcount":         handleGetBlockCount,
 	"getblockhash":          handleGetBlockHash,
 	"getblockheader":        handleGetBlockHeader,
+	"getblockheaderproof":   handleGetBlockHeaderProof,
+	"getblockheaders":       handleGetBlockHeaders,
 	"getblocksubsidy":       handleGetBlockSubsidy,
 	"getcfilterv2":          handleGetCFilterV2,
 	"getchaintips":          handleGetChainTips,
@@ -188,16 +227,23 @@ var rpcHandlersBeforeInit = map[types.Method]commandHandler{
 	"getdifficulty":         handleGetDifficulty,
 	"getgenerate":           handleGetGenerate,
 	"gethashespersec":       handleGetHashesPerSec,
+	"getheadercommitments":  handleGetHeaderCommitments,
 	"getheaders":            handleGetHeaders,
 	"getinfo":               handleGetInfo,
 	"getmempoolinfo":        handleGetMempoolInfo,
+	"getmempooljournal":     handleGetMempoolJournal,
 	"getmininginfo":         handleGetMiningInfo,
 	"getnettotals":          handleGetNetTotals,
 	"getnetworkhashps":      handleGetNetworkHashPS,
+	"getnulldata":           handleGetNullData,
 	"getnetworkinfo":        handleGetNetworkInfo,
+	"getnodeinfo":           handleGetNodeInfo,
 	"getpeerinfo":           handleGetPeerInfo,
 	"getrawmempool":         handleGetRawMempool,
 	"getrawtransaction":     handleGetRawTransaction,
+	"getrawtransactions":    handleGetRawTransactions,
+	"getrebroadcasttxs":     handleGetRebroadcastTxs,
+	"getsidechainblocks":    handleGetSideChainBlocks,
 	"getstakedifficulty":    handleGetStakeDifficulty,
 	"getstakeversioninfo":   handleGetStakeVersionInfo,
 	"getstakeversions":      handleGetStakeVersions,
@@ -205,7 +251,10 @@ var rpcHandlersBeforeInit = map[types.Method]commandHandler{
 	"gettreasurybalance":    handleGetTreasuryBalance,
 	"gettreasuryspendvotes": handleGetTreasurySpendVotes,
 	"getvoteinfo":           handleGetVoteInfo,
+	"getvotestats":          handleGetVoteStats,
+	"gettxminingwindow":     handleGetTxMiningWindow,
 	"gettxout":              handleGetTxOut,
+	"gettxouthistory":       handleGetTxOutHistory,
 	"gettxoutsetinfo":       handleGetTxOutSetInfo,
 	"getwork":               handleGetWork,
 	"help":                  handleHelp,
@@ -213,12 +262,17 @@ var rpcHandlersBeforeInit = map[types.Method]commandHandler{
 	"livetickets":           handleLiveTickets,
 	"node":                  handleNode,
 	"ping":                  handlePing,
+	"purgesidechainblocks":  handlePurgeSideChainBlocks,
 	"reconsiderblock":       handleReconsiderBlock,
 	"regentemplate":         handleRegenTemplate,
+	"reloadconfig":          handleReloadConfig,
+	"scrubdatabase":         handleScrubDatabase,
 	"sendrawtransaction":    handleSendRawTransaction,
 	"setgenerate":           handleSetGenerate,
+	"setmocktime":           handleSetMockTime,
 	"stop":                  handleStop,
 	"submitblock":           handleSubmitBlock,
+	"testmempoolaccept":     handleTestMempoolAccept,
 	"ticketfeeinfo":         handleTicketFeeInfo,
 	"ticketsforaddress":     handleTicketsForAddress,
 	"ticketvwap":            handleTicketVWAP,
@@ -318,6 +372,13 @@ var rpcUnimplemented = map[string]struct{}{
 	"estimatepriority": {},
 }
 
+// Commands that are only intended for testing and are therefore only available
+// when the server is started with the debug RPCs enabled.
+var rpcDebugOnly = map[string]struct{}{
+	"forceprune":  {},
+	"setmocktime": {},
+}
+
 // Commands that are available to a limited user
 var rpcLimited = map[string]struct{}{
 	// Websockets commands
@@ -326,6 +387,7 @@ var rpcLimited = map[string]struct{}{
 	"notifyreceived":        {},
 	"notifyspent":           {},
 	"rescan":                {},
+	"rescanfilters":         {},
 	"session":               {},
 	"rebroadcastwinners":    {},
 
@@ -346,6 +408,7 @@ var rpcLimited = map[string]struct{}{
 	"existsliveticket":      {},
 	"existslivetickets":     {},
 	"existsmempooltxs":      {},
+	"forecaststakediff":     {},
 	"getbestblock":          {},
 	"getbestblockhash":      {},
 	"getblock":              {},
@@ -353,30 +416,41 @@ var rpcLimited = map[string]struct{}{
 	"getblockcount":         {},
 	"getblockhash":          {},
 	"getblockheader":        {},
+	"getblockheaderproof":   {},
+	"getblockheaders":       {},
 	"getblocksubsidy":       {},
 	"getcfilterv2":          {},
 	"getchaintips":          {},
 	"getcoinsupply":         {},
 	"getcurrentnet":         {},
 	"getdifficulty":         {},
+	"getheadercommitments":  {},
 	"getheaders":            {},
 	"getinfo":               {},
 	"getnettotals":          {},
 	"getnetworkhashps":      {},
 	"getnetworkinfo":        {},
+	"getnodeinfo":           {},
+	"getnulldata":           {},
 	"getrawmempool":         {},
+	"getsidechainblocks":    {},
 	"getstakedifficulty":    {},
 	"getstakeversioninfo":   {},
 	"getstakeversions":      {},
 	"getrawtransaction":     {},
+	"getrawtransactions":    {},
 	"gettreasurybalance":    {},
+	"gettxminingwindow":     {},
 	"gettxout":              {},
+	"gettxouthistory":       {},
 	"getvoteinfo":           {},
+	"getvotestats":          {},
 	"livetickets":           {},
 	"regentemplate":         {},
 	"searchrawtransactions": {},
 	"sendrawtransaction":    {},
 	"submitblock":           {},
+	"testmempoolaccept":     {},
 	"ticketfeeinfo":         {},
 	"ticketsforaddress":     {},
 	"ticketvwap":            {},
@@ -428,6 +502,15 @@ func rpcDuplicateTxError(fmtStr string, args ...interface{}) *dcrjson.RPCError {
 		fmt.Sprintf(fmtStr, args...))
 }
 
+// rpcTxRejectError is a convenience function to attach the passed reject code
+// to an RPC error for a rejected transaction as machine-readable data so
+// clients are able to determine the reason the transaction was rejected without
+// inspecting the error message.
+func rpcTxRejectError(rpcErr *dcrjson.RPCError, code mempool.RejectCode) *dcrjson.RPCError {
+	rpcErr.Data = &types.TxRejectErrorData{RejectCode: string(code)}
+	return rpcErr
+}
+
 // rpcAddressKeyError is a convenience function to convert an address/key error to
 // an RPC error with the appropriate code set.  It also logs the error to the
 // RPC server subsystem since internal errors really should not occur.  The
@@ -534,6 +617,21 @@ func newWorkState() *workState {
 	}
 }
 
+// handleAbandonRebroadcastTx implements the abandonrebroadcasttx command.
+func handleAbandonRebroadcastTx(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.AbandonRebroadcastTxCmd)
+	txHash, err := chainhash.NewHashFromStr(c.TxHash)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.TxHash)
+	}
+
+	if !s.cfg.ConnMgr.AbandonRebroadcastTransaction(txHash) {
+		return nil, dcrjson.NewRPCError(dcrjson.ErrRPCNoTxInfo,
+			fmt.Sprintf("Transaction %v is not being rebroadcast", txHash))
+	}
+	return nil, nil
+}
+
 // handleAddNode handles addnode commands.
 func handleAddNode(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
 	c := cmd.(*types.AddNodeCmd)
@@ -775,6 +873,69 @@ func handleCreateRawTransaction(_ context.Context, s *Server, cmd interface{}) (
 	return mtxHex, nil
 }
 
+// handleApproveDeepReorg implements the approvedeepreorg command.
+func handleApproveDeepReorg(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.ApproveDeepReorgCmd)
+	hash, err := chainhash.NewHashFromStr(c.BlockHash)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.BlockHash)
+	}
+
+	chain := s.cfg.Chain
+	err = chain.ApproveDeepReorg(hash)
+	if err != nil {
+		if errors.Is(err, blockchain.ErrUnknownBlock) {
+			return nil, &dcrjson.RPCError{
+				Code:    dcrjson.ErrRPCBlockNotFound,
+				Message: fmt.Sprintf("Block not found: %v", hash),
+			}
+		}
+
+		if errors.Is(err, blockchain.ErrDeepReorgNotApproved) {
+			return nil, rpcInvalidError("%v", err)
+		}
+
+		context := fmt.Sprintf("Failed to approve reorganize to block %s",
+			hash)
+		return nil, rpcInternalError(err.Error(), context)
+	}
+
+	return nil, nil
+}
+
+// handleCheckBlockConflicts implements the checkblockconflicts command.
+func handleCheckBlockConflicts(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.CheckBlockConflictsCmd)
+
+	// Deserialize the provided block.
+	hexStr := c.HexBlock
+	if len(hexStr)%2 != 0 {
+		hexStr = "0" + c.HexBlock
+	}
+	serializedBlock, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.HexBlock)
+	}
+	block, err := dcrutil.NewBlockFromBytes(serializedBlock)
+	if err != nil {
+		return nil, rpcDeserializationError("Could not decode block: %v", err)
+	}
+
+	hashStrings := func(hashes []chainhash.Hash) []string {
+		strs := make([]string, 0, len(hashes))
+		for i := range hashes {
+			strs = append(strs, hashes[i].String())
+		}
+		return strs
+	}
+	conflicts := s.cfg.TxMempooler.CheckBlockConflicts(block)
+	return &types.CheckBlockConflictsResult{
+		Mined:       hashStrings(conflicts.Mined),
+		DoubleSpent: hashStrings(conflicts.DoubleSpent),
+		Evicted:     hashStrings(conflicts.Evicted),
+	}, nil
+}
+
 // handleCreateRawSStx handles createrawsstx commands.
 func handleCreateRawSStx(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
 	c := cmd.(*types.CreateRawSStxCmd)
@@ -1391,7 +1552,7 @@ func handleDecodeScript(_ context.Context, s *Server, cmd interface{}) (interfac
 // TODO this is a very basic implementation.  It should be
 // modified to match the bitcoin-core one.
 func handleEstimateFee(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
-	return s.cfg.MinRelayTxFee.ToCoin(), nil
+	return s.cfg.TxMempooler.MinRelayTxFee().ToCoin(), nil
 }
 
 // handleEstimateSmartFee implements the estimatesmartfee command.
@@ -1710,6 +1871,76 @@ func handleExistsMempoolTxs(_ context.Context, s *Server, cmd interface{}) (inte
 	return hex.EncodeToString([]byte(set)), nil
 }
 
+// handleForcePrune implements the forceprune command.
+func handleForcePrune(_ context.Context, s *Server, _ interface{}) (interface{}, error) {
+	s.cfg.TxMempooler.PruneExpiredTx()
+	s.cfg.ConnMgr.PruneRebroadcastInventory()
+	return nil, nil
+}
+
+// handleForecastStakeDiff implements the forecaststakediff command.
+func handleForecastStakeDiff(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.ForecastStakeDiffCmd)
+
+	numIntervals := int64(10)
+	if c.Intervals != nil {
+		numIntervals = *c.Intervals
+	}
+	if numIntervals <= 0 || numIntervals > maxStakeDiffForecastIntervals {
+		return nil, rpcInvalidError("Invalid parameter, intervals must be "+
+			"in the range [1, %d]", maxStakeDiffForecastIntervals)
+	}
+
+	// Ensure the number of tickets per interval is possible.  A negative
+	// value signifies the number of tickets purchased in the most recent
+	// complete interval.
+	params := s.cfg.ChainParams
+	ticketsPerInterval := int64(-1)
+	if c.TicketsPerInterval != nil {
+		ticketsPerInterval = *c.TicketsPerInterval
+	}
+	maxTicketsPerInterval := params.StakeDiffWindowSize *
+		int64(params.MaxFreshStakePerBlock)
+	if ticketsPerInterval > maxTicketsPerInterval {
+		return nil, rpcInvalidError("Invalid parameter, ticketsperinterval "+
+			"must not be more than %d", maxTicketsPerInterval)
+	}
+
+	chain := s.cfg.Chain
+	best := chain.BestSnapshot()
+	forecast, err := chain.ForecastStakeDifficulty(&best.Hash, numIntervals,
+		ticketsPerInterval)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(), "Could not forecast "+
+			"stake difficulty")
+	}
+
+	poolValue, err := chain.TicketPoolValue()
+	if err != nil {
+		return nil, rpcInternalError(err.Error(), "Could not obtain ticket "+
+			"pool value")
+	}
+
+	result := types.ForecastStakeDiffResult{
+		Height:             best.Height,
+		PoolValue:          poolValue.ToCoin(),
+		NextStakeDiff:      dcrutil.Amount(best.NextStakeDiff).ToCoin(),
+		TicketsPerInterval: forecast.TicketsPerInterval,
+		Projections: make([]types.StakeDiffProjection, 0,
+			len(forecast.Projections)),
+	}
+	for _, projection := range forecast.Projections {
+		result.Projections = append(result.Projections,
+			types.StakeDiffProjection{
+				Height:    projection.Height,
+				StakeDiff: dcrutil.Amount(projection.StakeDiff).ToCoin(),
+				PoolSize:  projection.PoolSize,
+			})
+	}
+
+	return result, nil
+}
+
 // handleGenerate handles generate commands.
 func handleGenerate(ctx context.Context, s *Server, cmd interface{}) (interface{}, error) {
 	// Respond with an error if there are no addresses to pay the
@@ -1751,6 +1982,49 @@ func handleGenerate(ctx context.Context, s *Server, cmd interface{}) (interface{
 	return reply, nil
 }
 
+// handleGenerateToAddress handles generatetoaddress commands.
+func handleGenerateToAddress(ctx context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.GenerateToAddressCmd)
+
+	// Decode the provided address.  This also ensures the network encoded with
+	// the address matches the network the server is currently on.
+	addr, err := stdaddr.DecodeAddress(c.Address, s.cfg.ChainParams)
+	if err != nil {
+		return nil, rpcAddressKeyError("Could not decode address: %v", err)
+	}
+
+	// Respond with an error if there's virtually 0 chance of CPU-mining a block.
+	params := s.cfg.ChainParams
+	if !params.GenerateSupported {
+		return nil, &dcrjson.RPCError{
+			Code: dcrjson.ErrRPCDifficulty,
+			Message: fmt.Sprintf("No support for `generatetoaddress` on the "+
+				"current network, %s, as it's unlikely to be possible to "+
+				"mine a block with the CPU.", params.Net),
+		}
+	}
+
+	// Respond with an error if the client is requesting 0 blocks to be generated.
+	if c.NumBlocks == 0 {
+		return nil, rpcInternalError("Invalid number of blocks",
+			"Configuration")
+	}
+
+	// Mine the correct number of blocks paying to the provided address,
+	// assigning the hex representation of the hash of each one to its place in
+	// the reply.
+	blockHashes, err := s.cfg.CPUMiner.GenerateNBlocksToAddress(ctx,
+		c.NumBlocks, addr)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(), "Could not generate blocks")
+	}
+	reply := make([]string, 0, len(blockHashes))
+	for _, hash := range blockHashes {
+		reply = append(reply, hash.String())
+	}
+	return reply, nil
+}
+
 // handleGetAddedNodeInfo handles getaddednodeinfo commands.
 func handleGetAddedNodeInfo(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
 	c := cmd.(*types.GetAddedNodeInfoCmd)
@@ -1879,6 +2153,15 @@ func handleGetBlock(_ context.Context, s *Server, cmd interface{}) (interface{},
 		return nil, rpcDecodeHexError(c.Hash)
 	}
 
+	// The network-serialized block for deeply confirmed blocks is immutable,
+	// so serve it from the response cache when possible.
+	verbose := c.Verbose == nil || *c.Verbose
+	if !verbose {
+		if blkHex, ok := s.responseCache.lookup("getblock", hash); ok {
+			return blkHex, nil
+		}
+	}
+
 	chain := s.cfg.Chain
 	blk, err := chain.BlockByHash(hash)
 	if err != nil {
@@ -1890,14 +2173,17 @@ func handleGetBlock(_ context.Context, s *Server, cmd interface{}) (interface{},
 
 	// When the verbose flag isn't set, simply return the
 	// network-serialized block as a hex-encoded string.
-	if c.Verbose != nil && !*c.Verbose {
+	if !verbose {
 		blkBytes, err := blk.Bytes()
 		if err != nil {
 			return nil, rpcInternalError(err.Error(),
 				"Could not serialize block")
 		}
 
-		return hex.EncodeToString(blkBytes), nil
+		blkHex := hex.EncodeToString(blkBytes)
+		s.maybeCacheResponse("getblock", hash, hash, blkHex,
+			int64(len(blkHex)))
+		return blkHex, nil
 	}
 
 	chainWork, err := chain.ChainWork(hash)
@@ -2049,13 +2335,6 @@ func handleGetBlockchainInfo(_ context.Context, s *Server, _ interface{}) (inter
 		return nil, rpcInternalError(err.Error(), "Could not fetch chain work.")
 	}
 
-	// Estimate the verification progress of the node.
-	var verifyProgress float64
-	if bestHeaderHeight > 0 {
-		progress := float64(best.Height) / float64(bestHeaderHeight)
-		verifyProgress = math.Min(progress, 1.0)
-	}
-
 	// Fetch the maximum allowed block size for all blocks other than the
 	// genesis block.
 	params := s.cfg.ChainParams
@@ -2117,7 +2396,7 @@ func handleGetBlockchainInfo(_ context.Context, s *Server, _ interface{}) (inter
 		SyncHeight:           s.cfg.SyncMgr.SyncHeight(),
 		ChainWork:            fmt.Sprintf("%064x", chainWork),
 		InitialBlockDownload: !chain.IsCurrent(),
-		VerificationProgress: verifyProgress,
+		VerificationProgress: chain.VerificationProgress(),
 		BestBlockHash:        best.Hash.String(),
 		Difficulty:           best.Bits,
 		DifficultyRatio:      getDifficultyRatio(best.Bits, params),
@@ -2181,12 +2460,6 @@ func handleGetBlockHeader(_ context.Context, s *Server, cmd interface{}) (interf
 	}
 
 	// The verbose flag is set, so generate the JSON object and return it.
-
-	chainWork, err := chain.ChainWork(hash)
-	if err != nil {
-		return nil, rpcInternalError(err.Error(), "Failed to retrieve work")
-	}
-
 	best := chain.BestSnapshot()
 
 	// Get next block hash unless there are none.
@@ -2205,13 +2478,28 @@ func handleGetBlockHeader(_ context.Context, s *Server, cmd interface{}) (interf
 		confirmations = 1 + best.Height - height
 	}
 
+	return s.blockHeaderVerboseResult(&blockHeader, hash, confirmations,
+		nextHashString)
+}
+
+// blockHeaderVerboseResult returns the verbose result for the provided block
+// header that is shared by the getblockheader and getblockheaders commands.
+func (s *Server) blockHeaderVerboseResult(blockHeader *wire.BlockHeader, hash *chainhash.Hash, confirmations int64, nextHash string) (types.GetBlockHeaderVerboseResult, error) {
+	chain := s.cfg.Chain
+	chainWork, err := chain.ChainWork(hash)
+	if err != nil {
+		return types.GetBlockHeaderVerboseResult{},
+			rpcInternalError(err.Error(), "Failed to retrieve work")
+	}
+
 	medianTime, err := chain.MedianTimeByHash(hash)
 	if err != nil {
-		return nil, rpcInternalError(err.Error(), "Unable to retrieve median block time")
+		return types.GetBlockHeaderVerboseResult{},
+			rpcInternalError(err.Error(), "Unable to retrieve median block time")
 	}
 
-	blockHeaderReply := types.GetBlockHeaderVerboseResult{
-		Hash:          c.Hash,
+	return types.GetBlockHeaderVerboseResult{
+		Hash:          hash.String(),
 		Confirmations: confirmations,
 		Version:       blockHeader.Version,
 		MerkleRoot:    blockHeader.MerkleRoot.String(),
@@ -2224,7 +2512,7 @@ func handleGetBlockHeader(_ context.Context, s *Server, cmd interface{}) (interf
 		PoolSize:      blockHeader.PoolSize,
 		Bits:          strconv.FormatInt(int64(blockHeader.Bits), 16),
 		SBits:         dcrutil.Amount(blockHeader.SBits).ToCoin(),
-		Height:        uint32(height),
+		Height:        blockHeader.Height,
 		Size:          blockHeader.Size,
 		Time:          blockHeader.Timestamp.Unix(),
 		MedianTime:    medianTime.Unix(),
@@ -2234,10 +2522,238 @@ func handleGetBlockHeader(_ context.Context, s *Server, cmd interface{}) (interf
 		Difficulty:    getDifficultyRatio(blockHeader.Bits, s.cfg.ChainParams),
 		ChainWork:     fmt.Sprintf("%064x", chainWork),
 		PreviousHash:  blockHeader.PrevBlock.String(),
-		NextHash:      nextHashString,
+		NextHash:      nextHash,
+	}, nil
+}
+
+// handleGetBlockHeaders implements the getblockheaders command.
+func handleGetBlockHeaders(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.GetBlockHeadersCmd)
+
+	count := uint32(wire.MaxBlockHeadersPerMsg)
+	if c.Count != nil {
+		count = *c.Count
+	}
+	if count == 0 || count > wire.MaxBlockHeadersPerMsg {
+		return nil, rpcInvalidError("Count must be between 1 and %d",
+			wire.MaxBlockHeadersPerMsg)
+	}
+
+	// Load the requested headers from the main chain starting at the provided
+	// height.
+	chain := s.cfg.Chain
+	startHash, err := chain.BlockHashByHeight(c.StartHeight)
+	if err != nil {
+		return nil, &dcrjson.RPCError{
+			Code: dcrjson.ErrRPCOutOfRange,
+			Message: fmt.Sprintf("Block number out of range: %v",
+				c.StartHeight),
+		}
+	}
+	headers, err := chain.FilterHeaders(startHash,
+		blockchain.HeaderIterForward, count, nil)
+	if err != nil {
+		context := "Failed to load block headers"
+		return nil, rpcInternalError(err.Error(), context)
+	}
+
+	// When the verbose flag isn't set, simply return the serialized block
+	// headers as hex-encoded strings.
+	if c.Verbose == nil || !*c.Verbose {
+		hexBlockHeaders := make([]string, len(headers))
+		var buf bytes.Buffer
+		buf.Grow(wire.MaxBlockHeaderPayload)
+		for i := range headers {
+			err := headers[i].Serialize(&buf)
+			if err != nil {
+				return nil, rpcInternalError(err.Error(),
+					"Failed to serialize block header")
+			}
+			hexBlockHeaders[i] = hex.EncodeToString(buf.Bytes())
+			buf.Reset()
+		}
+		return &types.GetHeadersResult{Headers: hexBlockHeaders}, nil
+	}
+
+	// The verbose flag is set, so generate the JSON objects and return them.
+	hashes := make([]chainhash.Hash, len(headers))
+	for i := range headers {
+		hashes[i] = headers[i].BlockHash()
+	}
+	best := chain.BestSnapshot()
+	results := make([]types.GetBlockHeaderVerboseResult, 0, len(headers))
+	for i := range headers {
+		header := &headers[i]
+		height := int64(header.Height)
+
+		// Get next block hash unless there are none.  It is only necessary
+		// to query the chain for the final header.
+		var nextHashString string
+		if i+1 < len(headers) {
+			nextHashString = hashes[i+1].String()
+		} else if height < best.Height {
+			nextHash, err := chain.BlockHashByHeight(height + 1)
+			if err != nil {
+				context := "No next block"
+				return nil, rpcInternalError(err.Error(), context)
+			}
+			nextHashString = nextHash.String()
+		}
+
+		confirmations := 1 + best.Height - height
+		result, err := s.blockHeaderVerboseResult(header, &hashes[i],
+			confirmations, nextHashString)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// handleGetBlockHeaderProof implements the getblockheaderproof command.
+func handleGetBlockHeaderProof(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.GetBlockHeaderProofCmd)
+	txHash, err := chainhash.NewHashFromStr(c.TxHash)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.TxHash)
+	}
+	blockHash, err := chainhash.NewHashFromStr(c.BlockHash)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.BlockHash)
+	}
+
+	// Only blocks in the main chain are supported since the header chain is
+	// built from the main chain.
+	chain := s.cfg.Chain
+	block, err := chain.BlockByHash(blockHash)
+	if err != nil || !chain.MainChainHasBlock(blockHash) {
+		return nil, rpcBlockNotFoundError(*blockHash)
+	}
+	msgBlock := block.MsgBlock()
+	header := &msgBlock.Header
+	height := block.Height()
+
+	// Locate the transaction in the block while collecting the leaves of the
+	// merkle tree for the transaction tree it belongs to.
+	treeTxns := [2][]*wire.MsgTx{msgBlock.Transactions, msgBlock.STransactions}
+	var leaves []chainhash.Hash
+	var tree int8
+	var leafIndex uint32
+	var found bool
+	for i := range treeTxns {
+		for j, tx := range treeTxns[i] {
+			if tx.TxHash() == *txHash {
+				tree, leafIndex, found = int8(i), uint32(j), true
+				break
+			}
+		}
+		if found {
+			leaves = make([]chainhash.Hash, 0, len(treeTxns[i]))
+			for _, tx := range treeTxns[i] {
+				leaves = append(leaves, tx.TxHashFull())
+			}
+			break
+		}
+	}
+	if !found {
+		return nil, rpcNoTxInfoError(txHash)
+	}
+	leaf := leaves[leafIndex]
+	proof := standalone.GenerateInclusionProof(leaves, leafIndex)
+
+	// The merkle root in the header commits to the roots of both transaction
+	// trees once the header commitments agenda is active, so extend the proof
+	// with the root of the other tree in that case.  Otherwise, each tree root
+	// is individually committed to by the header.
+	var hdrCommitmentsActive bool
+	if height > 0 {
+		hdrCommitmentsActive, err = chain.IsHeaderCommitmentsAgendaActive(
+			&header.PrevBlock)
+		if err != nil {
+			context := "Failed to obtain header commitments agenda status"
+			return nil, rpcInternalError(err.Error(), context)
+		}
+	}
+	root := header.MerkleRoot
+	switch {
+	case hdrCommitmentsActive:
+		otherTree := treeTxns[tree^1]
+		leafIndex |= uint32(tree) << uint32(len(proof))
+		proof = append(proof, standalone.CalcTxTreeMerkleRoot(otherTree))
+	case tree == wire.TxTreeStake:
+		root = header.StakeRoot
+	}
+
+	// Determine the anchor block the header chain starts from.  It defaults
+	// to the assumed valid block when it is an ancestor of the block and the
+	// genesis block otherwise.
+	anchorHash := s.cfg.ChainParams.GenesisHash
+	var anchorHeight int64
+	if c.AnchorHash != nil {
+		hash, err := chainhash.NewHashFromStr(*c.AnchorHash)
+		if err != nil {
+			return nil, rpcDecodeHexError(*c.AnchorHash)
+		}
+		if !chain.MainChainHasBlock(hash) {
+			return nil, rpcBlockNotFoundError(*hash)
+		}
+		anchorHeight, err = chain.BlockHeightByHash(hash)
+		if err != nil {
+			return nil, rpcBlockNotFoundError(*hash)
+		}
+		if anchorHeight > height {
+			return nil, rpcInvalidError("Anchor block %v is not an ancestor "+
+				"of block %v", hash, blockHash)
+		}
+		anchorHash = *hash
+	} else if assumeValid := s.cfg.ChainParams.AssumeValid; assumeValid !=
+		zeroHash && chain.MainChainHasBlock(&assumeValid) {
+
+		assumeValidHeight, err := chain.BlockHeightByHash(&assumeValid)
+		if err == nil && assumeValidHeight <= height {
+			anchorHash, anchorHeight = assumeValid, assumeValidHeight
+		}
+	}
+	if height-anchorHeight > wire.MaxBlockHeadersPerMsg {
+		return nil, rpcInvalidError("The header chain from anchor block %v "+
+			"to block %v exceeds the max of %d headers -- specify a more "+
+			"recent anchor", anchorHash, blockHash, wire.MaxBlockHeadersPerMsg)
+	}
+
+	// Return the serialized block headers from the block after the anchor
+	// through the block as hex-encoded strings.
+	hexBlockHeaders := make([]string, 0, height-anchorHeight)
+	var buf bytes.Buffer
+	buf.Grow(wire.MaxBlockHeaderPayload)
+	for h := anchorHeight + 1; h <= height; h++ {
+		hdr, err := chain.HeaderByHeight(h)
+		if err != nil {
+			context := "Failed to fetch block header"
+			return nil, rpcInternalError(err.Error(), context)
+		}
+		if err := hdr.Serialize(&buf); err != nil {
+			context := "Failed to serialize block header"
+			return nil, rpcInternalError(err.Error(), context)
+		}
+		hexBlockHeaders = append(hexBlockHeaders,
+			hex.EncodeToString(buf.Bytes()))
+		buf.Reset()
 	}
 
-	return blockHeaderReply, nil
+	return &types.GetBlockHeaderProofResult{
+		TxHash:       txHash.String(),
+		LeafHash:     leaf.String(),
+		Tree:         tree,
+		BlockHash:    blockHash.String(),
+		Height:       height,
+		Root:         root.String(),
+		ProofIndex:   leafIndex,
+		ProofHashes:  proofHashStrs(proof),
+		AnchorHash:   anchorHash.String(),
+		AnchorHeight: anchorHeight,
+		Headers:      hexBlockHeaders,
+	}, nil
 }
 
 // handleGetBlockSubsidy implements the getblocksubsidy command.
@@ -2303,9 +2819,77 @@ func handleGetChainTips(_ context.Context, s *Server, _ interface{}) (interface{
 	return result, nil
 }
 
+// subsidySplitActivationHeight returns the height of the first block in the
+// main chain up to and including the provided height for which the modified
+// subsidy split defined in DCP0010 is active or -1 when it is not active.
+func (s *Server) subsidySplitActivationHeight(height int64) (int64, error) {
+	// isActive returns whether or not the modified subsidy split is active for
+	// the block after the main chain block at the provided height.
+	chain := s.cfg.Chain
+	isActive := func(prevHeight int64) (bool, error) {
+		prevHash, err := chain.BlockHashByHeight(prevHeight)
+		if err != nil {
+			context := fmt.Sprintf("Failed to retrieve hash for height %d",
+				prevHeight)
+			return false, rpcInternalError(err.Error(), context)
+		}
+		return s.isSubsidySplitAgendaActive(prevHash)
+	}
+
+	// Nothing more to do when the agenda is not active as of the provided
+	// height.
+	if height < 1 {
+		return -1, nil
+	}
+	active, err := isActive(height - 1)
+	if err != nil {
+		return 0, err
+	}
+	if !active {
+		return -1, nil
+	}
+
+	// Agendas never become inactive once they are active, so perform a binary
+	// search for the first block for which it is active.
+	var searchErr error
+	prevHeight := sort.Search(int(height-1), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		active, err := isActive(int64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return active
+	})
+	if searchErr != nil {
+		return 0, searchErr
+	}
+	return int64(prevHeight) + 1, nil
+}
+
 // handleGetCoinSupply implements the getcoinsupply command.
 func handleGetCoinSupply(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
-	return s.cfg.Chain.BestSnapshot().TotalSubsidy, nil
+	c := cmd.(*types.GetCoinSupplyCmd)
+	best := s.cfg.Chain.BestSnapshot()
+	if c.Verbose == nil || !*c.Verbose {
+		return best.TotalSubsidy, nil
+	}
+
+	// Cross check the coin supply against the max supply permitted by the
+	// subsidy schedule as of the current best block.
+	subsidySplitHeight, err := s.subsidySplitActivationHeight(best.Height)
+	if err != nil {
+		return nil, err
+	}
+	params := s.cfg.ChainParams
+	return &types.GetCoinSupplyVerboseResult{
+		Height:             best.Height,
+		Supply:             best.TotalSubsidy,
+		ScheduledSupply:    params.TotalSupply(best.Height, subsidySplitHeight),
+		SubsidySplitHeight: subsidySplitHeight,
+	}, nil
 }
 
 // handleGetConnectionCount implements the getconnectioncount command.
@@ -2387,6 +2971,12 @@ func handleGetCFilterV2(_ context.Context, s *Server, cmd interface{}) (interfac
 		return nil, rpcDecodeHexError(c.BlockHash)
 	}
 
+	// The filter for deeply confirmed blocks is immutable, so serve it from
+	// the response cache when possible.
+	if result, ok := s.responseCache.lookup("getcfilterv2", hash); ok {
+		return result, nil
+	}
+
 	filter, proof, err := s.cfg.FiltererV2.FilterByBlockHash(hash)
 	if err != nil {
 		if errors.Is(err, blockchain.ErrNoFilter) {
@@ -2400,19 +2990,83 @@ func handleGetCFilterV2(_ context.Context, s *Server, cmd interface{}) (interfac
 		return nil, rpcInternalError(err.Error(), context)
 	}
 
-	var proofHashes []string
-	if len(proof.ProofHashes) > 0 {
-		proofHashes = make([]string, 0, len(proof.ProofHashes))
-		for i := range proof.ProofHashes {
-			proofHashes[i] = proof.ProofHashes[i].String()
-		}
-	}
-
 	result := &types.GetCFilterV2Result{
 		BlockHash:   c.BlockHash,
 		Data:        hex.EncodeToString(filter.Bytes()),
 		ProofIndex:  proof.ProofIndex,
-		ProofHashes: proofHashes,
+		ProofHashes: proofHashStrs(proof.ProofHashes),
+	}
+	size := int64(len(result.BlockHash) + len(result.Data) +
+		len(result.ProofHashes)*chainhash.MaxHashStringSize)
+	s.maybeCacheResponse("getcfilterv2", hash, hash, result, size)
+	return result, nil
+}
+
+// proofHashStrs returns the provided inclusion proof hashes as strings.  Nil
+// is returned when there are no proof hashes.
+func proofHashStrs(proofHashes []chainhash.Hash) []string {
+	if len(proofHashes) == 0 {
+		return nil
+	}
+	strs := make([]string, 0, len(proofHashes))
+	for i := range proofHashes {
+		strs = append(strs, proofHashes[i].String())
+	}
+	return strs
+}
+
+// handleGetHeaderCommitments implements the getheadercommitments command.
+func handleGetHeaderCommitments(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.GetHeaderCommitmentsCmd)
+	hash, err := chainhash.NewHashFromStr(c.BlockHash)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.BlockHash)
+	}
+
+	hdrCommitments, err := s.cfg.Chain.HeaderCommitments(hash)
+	if err != nil {
+		switch {
+		case errors.Is(err, blockchain.ErrUnknownBlock):
+			return nil, &dcrjson.RPCError{
+				Code:    dcrjson.ErrRPCBlockNotFound,
+				Message: fmt.Sprintf("Block not found: %v", hash),
+			}
+
+		case errors.Is(err, blockchain.ErrNoHeaderCommitment):
+			return nil, &dcrjson.RPCError{
+				Code: dcrjson.ErrRPCMisc,
+				Message: fmt.Sprintf("No header commitments for block %v",
+					hash),
+			}
+		}
+
+		context := fmt.Sprintf("Failed to load header commitments for "+
+			"block %s", hash)
+		return nil, rpcInternalError(err.Error(), context)
+	}
+
+	commitments := make([]types.HeaderCommitmentResult, 0,
+		len(hdrCommitments.Commitments))
+	for i := range hdrCommitments.Commitments {
+		commitment := &hdrCommitments.Commitments[i]
+		proof, err := hdrCommitments.InclusionProof(uint32(i))
+		if err != nil {
+			context := "Failed to generate header commitment proof"
+			return nil, rpcInternalError(err.Error(), context)
+		}
+		commitments = append(commitments, types.HeaderCommitmentResult{
+			Name:        commitment.Name,
+			Hash:        commitment.Hash.String(),
+			ProofIndex:  proof.ProofIndex,
+			ProofHashes: proofHashStrs(proof.ProofHashes),
+		})
+	}
+
+	result := &types.GetHeaderCommitmentsResult{
+		BlockHash:      c.BlockHash,
+		Version:        hdrCommitments.Version,
+		CommitmentRoot: hdrCommitments.Root.String(),
+		Commitments:    commitments,
 	}
 	return result, nil
 }
@@ -2431,7 +3085,7 @@ func handleGetInfo(_ context.Context, s *Server, _ interface{}) (interface{}, er
 		Proxy:           s.cfg.Proxy,
 		Difficulty:      getDifficultyRatio(best.Bits, s.cfg.ChainParams),
 		TestNet:         s.cfg.TestNet,
-		RelayFee:        s.cfg.MinRelayTxFee.ToCoin(),
+		RelayFee:        s.cfg.TxMempooler.MinRelayTxFee().ToCoin(),
 		TxIndex:         s.cfg.TxIndexer != nil,
 	}
 
@@ -2444,7 +3098,7 @@ func handleGetMempoolInfo(_ context.Context, s *Server, _ interface{}) (interfac
 
 	var numBytes int64
 	for _, txD := range mempoolTxns {
-		numBytes += int64(txD.Tx.MsgTx().SerializeSize())
+		numBytes += int64(txD.Tx.SerializeSize())
 	}
 
 	ret := &types.GetMempoolInfoResult{
@@ -2455,6 +3109,38 @@ func handleGetMempoolInfo(_ context.Context, s *Server, _ interface{}) (interfac
 	return ret, nil
 }
 
+// handleGetMempoolJournal implements the getmempooljournal command.
+func handleGetMempoolJournal(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.GetMempoolJournalCmd)
+
+	// Only return events for the requested transaction when specified.
+	var txHash *chainhash.Hash
+	if c.TxID != nil {
+		var err error
+		txHash, err = chainhash.NewHashFromStr(*c.TxID)
+		if err != nil {
+			return nil, rpcDecodeHexError(*c.TxID)
+		}
+	}
+
+	events := s.cfg.TxMempooler.Events()
+	results := make([]types.MempoolEventResult, 0, len(events))
+	for i := range events {
+		event := &events[i]
+		if txHash != nil && event.Hash != *txHash {
+			continue
+		}
+		results = append(results, types.MempoolEventResult{
+			Time:   event.Time.Unix(),
+			Event:  event.Kind.String(),
+			TxID:   event.Hash.String(),
+			Reason: event.Reason,
+		})
+	}
+
+	return results, nil
+}
+
 // handleGetMiningInfo implements the getmininginfo command. We only return the
 // fields that are not related to wallet functionality.
 func handleGetMiningInfo(ctx context.Context, s *Server, _ interface{}) (interface{}, error) {
@@ -2616,15 +3302,174 @@ func handleGetNetworkInfo(_ context.Context, s *Server, _ interface{}) (interfac
 		ProtocolVersion: int32(s.cfg.MaxProtocolVersion),
 		TimeOffset:      int64(s.cfg.TimeSource.Offset().Seconds()),
 		Connections:     s.cfg.ConnMgr.ConnectedCount(),
-		RelayFee:        s.cfg.MinRelayTxFee.ToCoin(),
+		RelayFee:        s.cfg.TxMempooler.MinRelayTxFee().ToCoin(),
 		Networks:        s.cfg.NetInfo,
 		LocalAddresses:  localAddrs,
 		LocalServices:   fmt.Sprintf("%016x", uint64(s.cfg.Services)),
 	}
+	info.AgentRejected, info.AgentEvicted = s.cfg.ConnMgr.AgentFilterTotals()
 
 	return info, nil
 }
 
+// handleGetNodeInfo implements the getnodeinfo command.
+func handleGetNodeInfo(_ context.Context, s *Server, _ interface{}) (interface{}, error) {
+	indexes := make([]string, 0, 4)
+	if s.cfg.TxIndexer != nil {
+		indexes = append(indexes, "txindex")
+	}
+	if s.cfg.ExistsAddresser != nil {
+		indexes = append(indexes, "existsaddrindex")
+	}
+	if s.cfg.NullDataIndexer != nil {
+		indexes = append(indexes, "nulldataindex")
+	}
+	if s.cfg.UtxoHistoryIndexer != nil {
+		indexes = append(indexes, "utxohistoryindex")
+	}
+
+	features := make([]string, 0, 3)
+	if s.cfg.Services&wire.SFNodeCF == wire.SFNodeCF {
+		features = append(features, "cfilters")
+	}
+	if len(s.cfg.MiningAddrs) > 0 && s.cfg.BlockTemplater != nil {
+		features = append(features, "mining")
+	}
+	if s.cfg.AuditLogFile != "" {
+		features = append(features, "rpcauditlog")
+	}
+
+	limitStats := s.limiter.stats()
+	cacheStats := s.responseCache.stats()
+	startTime := time.Unix(s.cfg.StartupTime, 0)
+	apiVer, dcrdVer := versionResults()
+	result := &types.GetNodeInfoResult{
+		Version:         dcrdVer,
+		RPCAPIVersion:   apiVer,
+		Commit:          version.BuildMetadata,
+		GoVersion:       runtime.Version(),
+		UserAgent:       s.cfg.UserAgentVersion,
+		ProtocolVersion: s.cfg.MaxProtocolVersion,
+		Network:         s.cfg.ChainParams.Name,
+		StartTime:       s.cfg.StartupTime,
+		Uptime:          int64(s.cfg.Clock.Since(startTime).Seconds()),
+		Indexes:         indexes,
+		Features:        features,
+
+		// Pruning is not supported, so the full block data is always
+		// available.
+		Pruned: false,
+
+		Policy: types.NodePolicyResult{
+			RelayFee:          s.cfg.TxMempooler.MinRelayTxFee().ToCoin(),
+			AcceptNonStd:      s.cfg.AcceptNonStd,
+			MaxOrphanTxs:      s.cfg.MaxOrphanTxs,
+			MaxStandardTxSize: mempool.MaxStandardTxSize,
+		},
+
+		RPCServer: types.NodeRPCServerResult{
+			Clients:            int(atomic.LoadInt32(&s.numClients)),
+			Websockets:         s.ntfnMgr.NumClients(),
+			RejectedWebsockets: limitStats.RejectedWebsockets,
+			SlowWebsockets:     atomic.LoadUint64(&s.slowWebsockets),
+			AuthFailures:       limitStats.AuthFailures,
+			AuthLockouts:       limitStats.Lockouts,
+			LockedOut:          limitStats.LockedOutHosts,
+			ResponseCache: types.NodeRPCResponseCacheResult{
+				Hits:     cacheStats.Hits,
+				Misses:   cacheStats.Misses,
+				Entries:  cacheStats.Entries,
+				Bytes:    cacheStats.Bytes,
+				MaxBytes: cacheStats.MaxBytes,
+			},
+		},
+	}
+	return result, nil
+}
+
+// handleGetNullData implements the getnulldata command.
+func handleGetNullData(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	nullDataIndex := s.cfg.NullDataIndexer
+	if nullDataIndex == nil {
+		return nil, rpcInternalError("The null data index must be enabled "+
+			"to query null data payloads (specify --nulldataindex)",
+			"Configuration")
+	}
+
+	c := cmd.(*types.GetNullDataCmd)
+	prefix, err := hex.DecodeString(c.Prefix)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.Prefix)
+	}
+
+	// Ensure the null data index is synced.
+	tHeight, tHash, err := nullDataIndex.Tip()
+	if err != nil {
+		return nil, rpcInternalError(err.Error(), "Tip")
+	}
+
+	chain := s.cfg.Chain
+
+	// Return an out-of-sync error if index is lagging a
+	// maximum reorg depth (6) blocks or more from the chain tip.
+	if chain.BestSnapshot().Height > (tHeight + 5) {
+		msg := fmt.Sprintf("%s: index not synced", nullDataIndex.Name())
+		return nil, rpcInternalError(msg, "Sync")
+	}
+
+sync:
+	for !chain.BestSnapshot().Hash.IsEqual(tHash) {
+		select {
+		case <-time.After(syncWait):
+			msg := fmt.Sprintf("%s: index not synced", nullDataIndex.Name())
+			return nil, rpcInternalError(msg, "Sync")
+		case <-nullDataIndex.WaitForSync():
+			break sync
+		}
+	}
+
+	// Limit the range of heights to the current best chain block height
+	// and use it when the end height is negative.
+	bestHeight := chain.BestSnapshot().Height
+	startHeight, endHeight := *c.StartHeight, *c.EndHeight
+	if endHeight < 0 || endHeight > bestHeight {
+		endHeight = bestHeight
+	}
+	if startHeight < 0 || startHeight > endHeight {
+		return nil, rpcInvalidError("Invalid height range [%d, %d]",
+			startHeight, endHeight)
+	}
+
+	entries, err := nullDataIndex.Entries(prefix, startHeight, endHeight,
+		maxNullDataResults)
+	if err != nil {
+		return nil, rpcInvalidError("Could not query null data: %v", err)
+	}
+
+	results := make([]types.GetNullDataResult, 0, len(entries))
+	var blockHash *chainhash.Hash
+	for i := range entries {
+		entry := &entries[i]
+		if i == 0 || entry.Height != entries[i-1].Height {
+			blockHash, err = chain.BlockHashByHeight(entry.Height)
+			if err != nil {
+				context := "Failed to get block hash"
+				return nil, rpcInternalError(err.Error(), context)
+			}
+		}
+		results = append(results, types.GetNullDataResult{
+			BlockHash: blockHash.String(),
+			Height:    entry.Height,
+			TxHash:    entry.TxHash.String(),
+			Tree:      entry.Tree,
+			Vout:      entry.OutputIndex,
+			Payload:   hex.EncodeToString(entry.Payload),
+		})
+	}
+
+	return results, nil
+}
+
 // handleGetPeerInfo implements the getpeerinfo command.
 func handleGetPeerInfo(_ context.Context, s *Server, _ interface{}) (interface{}, error) {
 	peers := s.cfg.ConnMgr.ConnectedPeers()
@@ -2637,25 +3482,30 @@ func handleGetPeerInfo(_ context.Context, s *Server, _ interface{}) (interface{}
 			addrLocalStr = addrLocal.String()
 		}
 		info := &types.GetPeerInfoResult{
-			ID:             statsSnap.ID,
-			Addr:           statsSnap.Addr,
-			AddrLocal:      addrLocalStr,
-			Services:       fmt.Sprintf("%08d", uint64(statsSnap.Services)),
-			RelayTxes:      !p.IsTxRelayDisabled(),
-			LastSend:       statsSnap.LastSend.Unix(),
-			LastRecv:       statsSnap.LastRecv.Unix(),
-			BytesSent:      statsSnap.BytesSent,
-			BytesRecv:      statsSnap.BytesRecv,
-			ConnTime:       statsSnap.ConnTime.Unix(),
-			PingTime:       float64(statsSnap.LastPingMicros),
-			TimeOffset:     statsSnap.TimeOffset,
-			Version:        statsSnap.Version,
-			SubVer:         statsSnap.UserAgent,
-			Inbound:        statsSnap.Inbound,
-			StartingHeight: statsSnap.StartingHeight,
-			CurrentHeight:  statsSnap.LastBlock,
-			BanScore:       int32(p.BanScore()),
-			SyncNode:       p.ID() == syncPeerID,
+			ID:              statsSnap.ID,
+			Addr:            statsSnap.Addr,
+			AddrLocal:       addrLocalStr,
+			Services:        fmt.Sprintf("%08d", uint64(statsSnap.Services)),
+			RelayTxes:       !p.IsTxRelayDisabled(),
+			LastSend:        statsSnap.LastSend.Unix(),
+			LastRecv:        statsSnap.LastRecv.Unix(),
+			BytesSent:       statsSnap.BytesSent,
+			BytesRecv:       statsSnap.BytesRecv,
+			BytesSentPerMsg: statsSnap.BytesSentPerMsg,
+			BytesRecvPerMsg: statsSnap.BytesRecvPerMsg,
+			ConnTime:        statsSnap.ConnTime.Unix(),
+			PingTime:        float64(statsSnap.LastPingMicros),
+			PingP50:         float64(statsSnap.PingP50Micros),
+			PingP90:         float64(statsSnap.PingP90Micros),
+			PingP99:         float64(statsSnap.PingP99Micros),
+			TimeOffset:      statsSnap.TimeOffset,
+			Version:         statsSnap.Version,
+			SubVer:          statsSnap.UserAgent,
+			Inbound:         statsSnap.Inbound,
+			StartingHeight:  statsSnap.StartingHeight,
+			CurrentHeight:   statsSnap.LastBlock,
+			BanScore:        int32(p.BanScore()),
+			SyncNode:        p.ID() == syncPeerID,
 		}
 		if p.LastPingNonce() != 0 {
 			wait := float64(s.cfg.Clock.Since(statsSnap.LastPingTime).Nanoseconds())
@@ -2708,19 +3558,94 @@ func handleGetRawMempool(_ context.Context, s *Server, cmd interface{}) (interfa
 		}
 	}
 
+	// Convert the minimum fee rate filter from DCR/kB to atoms/kB.
+	var minFeeRate int64
+	if c.MinFeeRate != nil {
+		amt, err := dcrutil.NewAmount(*c.MinFeeRate)
+		if err != nil || amt < 0 {
+			return nil, rpcInvalidError("Invalid minimum fee rate: %v",
+				*c.MinFeeRate)
+		}
+		minFeeRate = int64(amt)
+	}
+
+	// Ensure the pagination parameters are sane.
+	var offset, limit int
+	if c.Offset != nil {
+		offset = *c.Offset
+	}
+	if c.Limit != nil {
+		limit = *c.Limit
+	}
+	if offset < 0 {
+		return nil, rpcInvalidError("Offset must not be negative: %d", offset)
+	}
+	if limit < 0 {
+		return nil, rpcInvalidError("Limit must not be negative: %d", limit)
+	}
+	paginate := offset > 0 || limit > 0
+
+	// includeTx returns whether or not the transaction associated with the
+	// provided descriptor passes the type and fee rate filters.
+	includeTx := func(desc *mining.TxDesc) bool {
+		if filterType != nil && desc.Type != *filterType {
+			return false
+		}
+		if minFeeRate > 0 {
+			size := int64(desc.Tx.MsgTx().SerializeSize())
+			if desc.Fee*1000/size < minFeeRate {
+				return false
+			}
+		}
+		return true
+	}
+
+	// Return a summary of the matching transactions if requested.  Pagination
+	// does not apply to summaries since they are already bounded in size.
+	if c.Summary != nil && *c.Summary {
+		result := &types.GetRawMempoolSummaryResult{
+			Types: make(map[string]types.GetRawMempoolSummaryTypeResult),
+		}
+		for _, desc := range s.cfg.TxMempooler.TxDescs() {
+			if !includeTx(&desc.TxDesc) {
+				continue
+			}
+			size := int64(desc.Tx.MsgTx().SerializeSize())
+			result.Count++
+			result.Bytes += size
+			typeName := string(rawMempoolTxTypeName(desc.Type))
+			typeResult := result.Types[typeName]
+			typeResult.Count++
+			typeResult.Bytes += size
+			result.Types[typeName] = typeResult
+		}
+		return result, nil
+	}
+
 	// Return verbose results if requested.
 	if c.Verbose != nil && *c.Verbose {
-		descs := s.cfg.TxMempooler.VerboseTxDescs()
+		allDescs := s.cfg.TxMempooler.VerboseTxDescs()
+		descs := make([]*mempool.VerboseTxDesc, 0, len(allDescs))
+		for _, desc := range allDescs {
+			if includeTx(&desc.TxDesc.TxDesc) {
+				descs = append(descs, desc)
+			}
+		}
+		if paginate {
+			sort.Slice(descs, func(i, j int) bool {
+				return mempoolTxDescLess(&descs[i].TxDesc.TxDesc,
+					&descs[j].TxDesc.TxDesc)
+			})
+			start, end := paginationBounds(len(descs), offset, limit)
+			descs = descs[start:end]
+		}
+
 		result := make(map[string]*types.GetRawMempoolVerboseResult, len(descs))
 		for i := range descs {
 			desc := descs[i]
-			if filterType != nil && desc.Type != *filterType {
-				continue
-			}
-
 			tx := desc.Tx
 			mpd := &types.GetRawMempoolVerboseResult{
-				Size:             int32(tx.MsgTx().SerializeSize()),
+				Size:             int32(tx.SerializeSize()),
 				Fee:              dcrutil.Amount(desc.Fee).ToCoin(),
 				Time:             desc.Added.Unix(),
 				Height:           desc.Height,
@@ -2740,42 +3665,147 @@ func handleGetRawMempool(_ context.Context, s *Server, cmd interface{}) (interfa
 
 	// The response is simply an array of the transaction hashes if the
 	// verbose flag is not set.
-	descs := s.cfg.TxMempooler.TxDescs()
+	allDescs := s.cfg.TxMempooler.TxDescs()
+	descs := make([]*mempool.TxDesc, 0, len(allDescs))
+	for _, desc := range allDescs {
+		if includeTx(&desc.TxDesc) {
+			descs = append(descs, desc)
+		}
+	}
+	if paginate {
+		sort.Slice(descs, func(i, j int) bool {
+			return mempoolTxDescLess(&descs[i].TxDesc, &descs[j].TxDesc)
+		})
+		start, end := paginationBounds(len(descs), offset, limit)
+		descs = descs[start:end]
+	}
 	hashStrings := make([]string, 0, len(descs))
 	for i := range descs {
-		if filterType != nil && descs[i].Type != *filterType {
-			continue
-		}
 		hashStrings = append(hashStrings, descs[i].Tx.Hash().String())
 	}
 	return hashStrings, nil
 }
 
-// handleGetRawTransaction implements the getrawtransaction command.
-func handleGetRawTransaction(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
-	c := cmd.(*types.GetRawTransactionCmd)
+// rawMempoolTxTypeName returns the getrawmempool transaction type name that
+// corresponds to the provided stake transaction type.
+func rawMempoolTxTypeName(txType stake.TxType) types.GetRawMempoolTxTypeCmd {
+	switch txType {
+	case stake.TxTypeSStx:
+		return types.GRMTickets
+	case stake.TxTypeSSGen:
+		return types.GRMVotes
+	case stake.TxTypeSSRtx:
+		return types.GRMRevocations
+	case stake.TxTypeTSpend:
+		return types.GRMTSpend
+	case stake.TxTypeTAdd:
+		return types.GRMTAdd
+	}
+	return types.GRMRegular
+}
 
-	// Convert the provided transaction hash hex to a Hash.
-	txHash, err := chainhash.NewHashFromStr(c.Txid)
-	if err != nil {
-		return nil, rpcDecodeHexError(c.Txid)
+// mempoolTxDescLess returns whether the first provided mempool transaction
+// descriptor sorts before the second one.  Transactions are sorted by the time
+// they were added to the pool followed by their hash so that paginated results
+// are stable as new transactions are added to the pool.
+func mempoolTxDescLess(a, b *mining.TxDesc) bool {
+	if !a.Added.Equal(b.Added) {
+		return a.Added.Before(b.Added)
 	}
+	return bytes.Compare(a.Tx.Hash()[:], b.Tx.Hash()[:]) < 0
+}
 
-	verbose := false
-	if c.Verbose != nil {
-		verbose = *c.Verbose != 0
+// paginationBounds returns the start and end slice bounds for the page of a
+// list with the provided number of items that starts at the provided offset
+// and has at most limit items.  A limit of zero means there is no limit.
+func paginationBounds(numItems, offset, limit int) (int, int) {
+	if offset > numItems {
+		offset = numItems
+	}
+	end := numItems
+	if limit > 0 && limit < numItems-offset {
+		end = offset + limit
+	}
+	return offset, end
+}
+
+// rawTxHintBlock returns the block identified by the provided hash which is
+// used as a hint to look up transactions without requiring the transaction
+// index.  It returns nil when no hash is provided.
+func (s *Server) rawTxHintBlock(blockHashStr *string) (*dcrutil.Block, error) {
+	if blockHashStr == nil {
+		return nil, nil
 	}
 
-	// Try to fetch the transaction from the memory pool and if that fails,
-	// try the block database.
+	blockHash, err := chainhash.NewHashFromStr(*blockHashStr)
+	if err != nil {
+		return nil, rpcDecodeHexError(*blockHashStr)
+	}
+	block, err := s.cfg.Chain.BlockByHash(blockHash)
+	if err != nil {
+		return nil, rpcBlockNotFoundError(*blockHash)
+	}
+	return block, nil
+}
+
+// rawTransaction returns either the hex-encoded serialized transaction with the
+// provided hash or a verbose result that describes it depending on the
+// provided verbose flag.
+//
+// When a hint block is provided, the transaction is only looked up in that
+// block which does not require the transaction index.  Otherwise, it is looked
+// up in the memory pool and then the transaction index.
+func (s *Server) rawTransaction(txHash *chainhash.Hash, hintBlock *dcrutil.Block, verbose bool) (interface{}, error) {
+	// Look up the transaction in the hint block when one is provided.
+	// Otherwise, try to fetch the transaction from the memory pool and if that
+	// fails, try the block database.
 	var mtx *wire.MsgTx
 	var blkHash *chainhash.Hash
 	var blkHeight int64
 	var blkIndex uint32
+	inMainChain := true
 	chain := s.cfg.Chain
 	txIndex := s.cfg.TxIndexer
-	tx, err := s.cfg.TxMempooler.FetchTransaction(txHash)
-	if err != nil {
+
+	// The network-serialized transaction for deeply confirmed transactions
+	// is immutable, so serve it from the response cache when possible.
+	if !verbose && hintBlock == nil {
+		if mtxHex, ok := s.responseCache.lookup("getrawtransaction", txHash); ok {
+			return mtxHex, nil
+		}
+	}
+
+	if hintBlock != nil {
+		txTrees := [2][]*dcrutil.Tx{hintBlock.Transactions(),
+			hintBlock.STransactions()}
+	search:
+		for _, txns := range txTrees {
+			for _, tx := range txns {
+				if *tx.Hash() == *txHash {
+					mtx = tx.MsgTx()
+					blkIndex = uint32(tx.Index())
+					break search
+				}
+			}
+		}
+		if mtx == nil {
+			return nil, rpcNoTxInfoError(txHash)
+		}
+
+		// When the verbose flag isn't set, simply return the
+		// network-serialized transaction as a hex-encoded string.
+		if !verbose {
+			mtxHex, err := s.messageToHex(mtx)
+			if err != nil {
+				return nil, err
+			}
+			return mtxHex, nil
+		}
+
+		blkHash = hintBlock.Hash()
+		blkHeight = hintBlock.Height()
+		inMainChain = chain.MainChainHasBlock(blkHash)
+	} else if tx, err := s.cfg.TxMempooler.FetchTransaction(txHash); err != nil {
 		if txIndex == nil {
 			return nil, rpcInternalError("The transaction index "+
 				"must be enabled to query the blockchain "+
@@ -2834,7 +3864,10 @@ func handleGetRawTransaction(_ context.Context, s *Server, cmd interface{}) (int
 		// transaction as a hex-encoded string.  This is done here to
 		// avoid deserializing it only to reserialize it again later.
 		if !verbose {
-			return hex.EncodeToString(txBytes), nil
+			mtxHex := hex.EncodeToString(txBytes)
+			s.maybeCacheResponse("getrawtransaction", txHash,
+				blockRegion.Hash, mtxHex, int64(len(mtxHex)))
+			return mtxHex, nil
 		}
 
 		// Grab the block details.
@@ -2891,7 +3924,9 @@ func handleGetRawTransaction(_ context.Context, s *Server, cmd interface{}) (int
 		blkHeader = &header
 		prevBlkHash = header.PrevBlock
 		blkHashStr = blkHash.String()
-		confirmations = 1 + chain.BestSnapshot().Height - blkHeight
+		if inMainChain {
+			confirmations = 1 + chain.BestSnapshot().Height - blkHeight
+		}
 	} else {
 		// The transaction was obtained from the mempool when there is no block
 		// hash set, so the previous block hash is the current best chain tip in
@@ -2916,6 +3951,131 @@ func handleGetRawTransaction(_ context.Context, s *Server, cmd interface{}) (int
 	return *rawTxn, nil
 }
 
+// handleGetRawTransaction implements the getrawtransaction command.
+func handleGetRawTransaction(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.GetRawTransactionCmd)
+
+	// Convert the provided transaction hash hex to a Hash.
+	txHash, err := chainhash.NewHashFromStr(c.Txid)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.Txid)
+	}
+
+	verbose := false
+	if c.Verbose != nil {
+		verbose = *c.Verbose != 0
+	}
+
+	hintBlock, err := s.rawTxHintBlock(c.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	return s.rawTransaction(txHash, hintBlock, verbose)
+}
+
+// handleGetRawTransactions implements the getrawtransactions command.
+func handleGetRawTransactions(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.GetRawTransactionsCmd)
+	if len(c.Txids) == 0 {
+		return nil, rpcInvalidError("No transaction hashes provided")
+	}
+	if len(c.Txids) > maxGetRawTransactionsTxns {
+		return nil, rpcInvalidError("Too many transaction hashes provided "+
+			"-- got %d, max %d", len(c.Txids), maxGetRawTransactionsTxns)
+	}
+
+	// Convert all of the provided transaction hashes before looking up any of
+	// them so malformed requests are rejected as a whole.
+	txHashes := make([]*chainhash.Hash, 0, len(c.Txids))
+	for _, txid := range c.Txids {
+		txHash, err := chainhash.NewHashFromStr(txid)
+		if err != nil {
+			return nil, rpcDecodeHexError(txid)
+		}
+		txHashes = append(txHashes, txHash)
+	}
+
+	verbose := false
+	if c.Verbose != nil {
+		verbose = *c.Verbose != 0
+	}
+
+	hintBlock, err := s.rawTxHintBlock(c.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Look up the transactions in the same order they were requested.
+	if !verbose {
+		hexTxns := make([]string, 0, len(txHashes))
+		for _, txHash := range txHashes {
+			result, err := s.rawTransaction(txHash, hintBlock, verbose)
+			if err != nil {
+				return nil, err
+			}
+			hexTxns = append(hexTxns, result.(string))
+		}
+		return hexTxns, nil
+	}
+	verboseTxns := make([]types.TxRawResult, 0, len(txHashes))
+	for _, txHash := range txHashes {
+		result, err := s.rawTransaction(txHash, hintBlock, verbose)
+		if err != nil {
+			return nil, err
+		}
+		verboseTxns = append(verboseTxns, result.(types.TxRawResult))
+	}
+	return verboseTxns, nil
+}
+
+// handleGetRebroadcastTxs implements the getrebroadcasttxs command.
+func handleGetRebroadcastTxs(_ context.Context, s *Server, _ interface{}) (interface{}, error) {
+	// unixOrZero returns the unix time of the provided time or zero when it
+	// is not set.
+	unixOrZero := func(t time.Time) int64 {
+		if t.IsZero() {
+			return 0
+		}
+		return t.Unix()
+	}
+
+	txns := s.cfg.ConnMgr.RebroadcastTransactions()
+	results := make([]types.RebroadcastTxResult, 0, len(txns))
+	for i := range txns {
+		tx := &txns[i]
+		results = append(results, types.RebroadcastTxResult{
+			TxID:          tx.Hash.String(),
+			Added:         tx.Added.Unix(),
+			LastBroadcast: unixOrZero(tx.LastBroadcast),
+			NextBroadcast: tx.NextBroadcast.Unix(),
+			Broadcasts:    tx.Broadcasts,
+		})
+	}
+	return results, nil
+}
+
+// sideChainBlockResults converts the provided side chain blocks to their
+// JSON-RPC representation.
+func sideChainBlockResults(blocks []blockchain.SideChainBlock) []types.SideChainBlockResult {
+	results := make([]types.SideChainBlockResult, 0, len(blocks))
+	for i := range blocks {
+		block := &blocks[i]
+		results = append(results, types.SideChainBlockResult{
+			Hash:       block.Hash.String(),
+			Height:     block.Height,
+			ForkHash:   block.ForkHash.String(),
+			ForkHeight: block.ForkHeight,
+			Depth:      block.Depth,
+		})
+	}
+	return results
+}
+
+// handleGetSideChainBlocks implements the getsidechainblocks command.
+func handleGetSideChainBlocks(_ context.Context, s *Server, _ interface{}) (interface{}, error) {
+	return sideChainBlockResults(s.cfg.Chain.SideChainBlocks()), nil
+}
+
 // handleGetStakeDifficulty implements the getstakedifficulty command.
 func handleGetStakeDifficulty(_ context.Context, s *Server, _ interface{}) (interface{}, error) {
 	chain := s.cfg.Chain
@@ -3350,9 +4510,68 @@ func handleGetTreasurySpendVotes(_ context.Context, s *Server, cmd interface{})
 	}, nil
 }
 
+// voteInfoAgenda returns the getvoteinfo result for the provided agenda with
+// the provided status and without any progress.
+func voteInfoAgenda(agenda *chaincfg.ConsensusDeployment, status string) types.Agenda {
+	a := types.Agenda{
+		ID:          agenda.Vote.Id,
+		Description: agenda.Vote.Description,
+		Mask:        agenda.Vote.Mask,
+		Choices:     make([]types.Choice, 0, len(agenda.Vote.Choices)),
+		StartTime:   agenda.StartTime,
+		ExpireTime:  agenda.ExpireTime,
+		Status:      status,
+	}
+
+	// Handle choices.
+	for _, choice := range agenda.Vote.Choices {
+		a.Choices = append(a.Choices, types.Choice{
+			ID:          choice.Id,
+			Description: choice.Description,
+			Bits:        choice.Bits,
+			IsAbstain:   choice.IsAbstain,
+			IsNo:        choice.IsNo,
+		})
+	}
+
+	return a
+}
+
+// setVoteInfoProgress sets the quorum progress, participation, and choice
+// progress of the provided getvoteinfo agenda result from the provided vote
+// counts.
+func setVoteInfoProgress(a *types.Agenda, counts *blockchain.VoteCounts, quorum uint32) {
+	// Calculate quorum.
+	qmin := quorum
+	totalNonAbstain := counts.Total - counts.TotalAbstain
+	if totalNonAbstain < quorum {
+		qmin = totalNonAbstain
+	}
+	a.QuorumProgress = float64(qmin) / float64(quorum)
+
+	// There is no participation or choice progress without any votes.
+	if counts.Total == 0 {
+		for k := range a.Choices {
+			a.Choices[k].Count = counts.VoteChoices[k]
+		}
+		return
+	}
+
+	// Calculate participation and choice progress.
+	a.Participation = float64(totalNonAbstain) / float64(counts.Total)
+	for k := range a.Choices {
+		a.Choices[k].Count = counts.VoteChoices[k]
+		a.Choices[k].Progress = float64(counts.VoteChoices[k]) /
+			float64(counts.Total)
+	}
+}
+
 // handleGetVoteInfo implements the getvoteinfo command.
-func handleGetVoteInfo(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+func handleGetVoteInfo(ctx context.Context, s *Server, cmd interface{}) (interface{}, error) {
 	c := cmd.(*types.GetVoteInfoCmd)
+	if c.Height != nil && *c.Height >= 0 {
+		return handleGetVoteInfoAtHeight(ctx, s, c)
+	}
 
 	// Shorter versions of some parameters for convenience.
 	interval := int64(s.cfg.ChainParams.RuleChangeActivationInterval)
@@ -3387,7 +4606,9 @@ func handleGetVoteInfo(_ context.Context, s *Server, cmd interface{}) (interface
 	}
 
 	result.Agendas = make([]types.Agenda, 0, len(vi.Agendas))
-	for _, agenda := range vi.Agendas {
+	for i := range vi.Agendas {
+		agenda := &vi.Agendas[i]
+
 		// Obtain status of agenda.
 		state, err := chain.NextThresholdState(&snapshot.Hash, c.Version,
 			agenda.Vote.Id)
@@ -3396,56 +4617,106 @@ func handleGetVoteInfo(_ context.Context, s *Server, cmd interface{}) (interface
 				"could not fetch next threshold state")
 		}
 
-		a := types.Agenda{
-			ID:          agenda.Vote.Id,
-			Description: agenda.Vote.Description,
-			Mask:        agenda.Vote.Mask,
-			Choices:     make([]types.Choice, 0, len(agenda.Vote.Choices)),
-			StartTime:   agenda.StartTime,
-			ExpireTime:  agenda.ExpireTime,
-			Status:      state.String(),
-		}
-
-		// Handle choices.
-		for _, choice := range agenda.Vote.Choices {
-			a.Choices = append(a.Choices, types.Choice{
-				ID:          choice.Id,
-				Description: choice.Description,
-				Bits:        choice.Bits,
-				IsAbstain:   choice.IsAbstain,
-				IsNo:        choice.IsNo,
-			})
-		}
-
+		a := voteInfoAgenda(agenda, state.String())
 		if state.State != blockchain.ThresholdStarted {
 			// Append transformed agenda without progress.
 			result.Agendas = append(result.Agendas, a)
 			continue
 		}
 
-		counts, err := s.cfg.Chain.GetVoteCounts(c.Version, agenda.Vote.Id)
-		if err != nil {
-			return nil, rpcInternalError(err.Error(),
-				"could not obtain vote count")
-		}
+		counts, err := s.cfg.Chain.GetVoteCounts(c.Version, agenda.Vote.Id)
+		if err != nil {
+			return nil, rpcInternalError(err.Error(),
+				"could not obtain vote count")
+		}
+		setVoteInfoProgress(&a, &counts, quorum)
+
+		// Append transformed agenda.
+		result.Agendas = append(result.Agendas, a)
+	}
+
+	return result, nil
+}
+
+// handleGetVoteInfoAtHeight implements the getvoteinfo command for the rule
+// change activation interval that contains a specific height.
+func handleGetVoteInfoAtHeight(_ context.Context, s *Server, c *types.GetVoteInfoCmd) (interface{}, error) {
+	info, err := s.cfg.Chain.IntervalVoteInfo(*c.Height, c.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, blockchain.ErrUnknownDeploymentVersion):
+			return nil, rpcInvalidError("%d: unrecognized vote version",
+				c.Version)
+		case errors.Is(err, blockchain.ErrInvalidHeightRange):
+			return nil, rpcInvalidError("%v", err)
+		}
+		return nil, rpcInternalError(err.Error(), "could not obtain vote info")
+	}
+
+	// Assemble JSON result.  The current height and hash identify the most
+	// recent block of the interval that was counted and the status of the
+	// agendas is their status during the interval.
+	quorum := s.cfg.ChainParams.RuleChangeActivationQuorum
+	result := types.GetVoteInfoResult{
+		CurrentHeight: info.Height,
+		StartHeight:   info.StartHeight,
+		EndHeight:     info.EndHeight,
+		Hash:          info.Hash.String(),
+		VoteVersion:   c.Version,
+		Quorum:        quorum,
+		TotalVotes:    info.VersionVotes,
+		Agendas:       make([]types.Agenda, 0, len(info.Agendas)),
+	}
+	for i := range info.Agendas {
+		agenda := &info.Agendas[i]
+		a := voteInfoAgenda(agenda.Deployment, agenda.State.String())
+		if agenda.State.State == blockchain.ThresholdStarted {
+			setVoteInfoProgress(&a, &agenda.Counts, quorum)
+		}
+		result.Agendas = append(result.Agendas, a)
+	}
+
+	return result, nil
+}
+
+// handleGetVoteStats implements the getvotestats command.
+func handleGetVoteStats(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.GetVoteStatsCmd)
+
+	endHeight := int64(-1)
+	if c.EndHeight != nil {
+		endHeight = *c.EndHeight
+	}
+	verbose := c.Verbose != nil && *c.Verbose
 
-		// Calculate quorum.
-		qmin := quorum
-		totalNonAbstain := counts.Total - counts.TotalAbstain
-		if totalNonAbstain < quorum {
-			qmin = totalNonAbstain
+	stats, err := s.cfg.Chain.VoteStats(c.StartHeight, endHeight, verbose)
+	if err != nil {
+		if errors.Is(err, blockchain.ErrInvalidHeightRange) {
+			return nil, rpcInvalidError("%v", err)
 		}
-		a.QuorumProgress = float64(qmin) / float64(quorum)
-
-		// Calculate choice progress.
-		for k := range a.Choices {
-			a.Choices[k].Count = counts.VoteChoices[k]
-			a.Choices[k].Progress = float64(counts.VoteChoices[k]) /
-				float64(counts.Total)
+		return nil, rpcInternalError(err.Error(), "Could not obtain vote stats")
+	}
+
+	result := types.GetVoteStatsResult{
+		StartHeight: stats.StartHeight,
+		EndHeight:   stats.EndHeight,
+		VoteBlocks:  stats.VoteBlocks,
+		Eligible:    stats.Eligible,
+		Included:    stats.Included,
+		Missed:      stats.Missed,
+		VoteCounts:  stats.VoteCounts,
+	}
+	if len(stats.Blocks) > 0 {
+		result.Blocks = make([]types.VoteStatsBlock, 0, len(stats.Blocks))
+		for _, blk := range stats.Blocks {
+			result.Blocks = append(result.Blocks, types.VoteStatsBlock{
+				Hash:     blk.Hash.String(),
+				Height:   blk.Height,
+				Eligible: blk.Eligible,
+				Included: blk.Included,
+				Missed:   blk.Missed,
+			})
 		}
-
-		// Append transformed agenda.
-		result.Agendas = append(result.Agendas, a)
 	}
 
 	return result, nil
@@ -3475,6 +4746,48 @@ func bigToLEUint256(n *big.Int) [uint256Size]byte {
 	return buf
 }
 
+// handleGetTxMiningWindow implements the gettxminingwindow command.
+func handleGetTxMiningWindow(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.GetTxMiningWindowCmd)
+
+	// Deserialize the transaction.
+	hexStr := c.HexTx
+	if len(hexStr)%2 != 0 {
+		hexStr = "0" + hexStr
+	}
+	serializedTx, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return nil, rpcDecodeHexError(hexStr)
+	}
+	msgTx := wire.NewMsgTx()
+	err = msgTx.Deserialize(bytes.NewReader(serializedTx))
+	if err != nil {
+		return nil, rpcDeserializationError("Could not decode Tx: %v", err)
+	}
+
+	// Calculate the range of blocks the transaction may be included in.
+	// Rule violations, such as spending outputs that do not exist in the
+	// main chain or the mempool, are reported as such.
+	tx := dcrutil.NewTx(msgTx)
+	window, err := s.cfg.TxMempooler.CalcTxMiningWindow(tx)
+	if err != nil {
+		var rErr mempool.RuleError
+		if errors.As(err, &rErr) {
+			return nil, rpcRuleError("%v", err)
+		}
+		context := fmt.Sprintf("Failed to calculate mining window for "+
+			"transaction %v", tx.Hash())
+		return nil, rpcInternalError(err.Error(), context)
+	}
+
+	return &types.GetTxMiningWindowResult{
+		MinHeight:     window.MinHeight,
+		MinMedianTime: window.MinMedianTime,
+		MaxHeight:     window.MaxHeight,
+		Minable:       !window.IsEmpty(),
+	}, nil
+}
+
 // handleGetTxOut handles gettxout commands.
 func handleGetTxOut(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
 	c := cmd.(*types.GetTxOutCmd)
@@ -3608,6 +4921,131 @@ func handleGetTxOut(_ context.Context, s *Server, cmd interface{}) (interface{},
 	return txOutReply, nil
 }
 
+// handleGetTxOutHistory implements the gettxouthistory command.
+func handleGetTxOutHistory(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	utxoHistoryIndex := s.cfg.UtxoHistoryIndexer
+	if utxoHistoryIndex == nil {
+		return nil, rpcInternalError("The utxo history index must be "+
+			"enabled to query the history of transaction outputs (specify "+
+			"--utxohistoryindex)", "Configuration")
+	}
+
+	c := cmd.(*types.GetTxOutHistoryCmd)
+	txHash, err := chainhash.NewHashFromStr(c.Txid)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.Txid)
+	}
+
+	// Ensure the utxo history index is synced.
+	tHeight, tHash, err := utxoHistoryIndex.Tip()
+	if err != nil {
+		return nil, rpcInternalError(err.Error(), "Tip")
+	}
+
+	chain := s.cfg.Chain
+
+	// Return an out-of-sync error if index is lagging a
+	// maximum reorg depth (6) blocks or more from the chain tip.
+	if chain.BestSnapshot().Height > (tHeight + 5) {
+		msg := fmt.Sprintf("%s: index not synced", utxoHistoryIndex.Name())
+		return nil, rpcInternalError(msg, "Sync")
+	}
+
+sync:
+	for !chain.BestSnapshot().Hash.IsEqual(tHash) {
+		select {
+		case <-time.After(syncWait):
+			msg := fmt.Sprintf("%s: index not synced",
+				utxoHistoryIndex.Name())
+			return nil, rpcInternalError(msg, "Sync")
+		case <-utxoHistoryIndex.WaitForSync():
+			break sync
+		}
+	}
+
+	// Limit the height to the current best chain block height and use it when
+	// the height is negative.
+	bestHeight := chain.BestSnapshot().Height
+	height := *c.Height
+	if height < 0 || height > bestHeight {
+		height = bestHeight
+	}
+
+	entry, err := utxoHistoryIndex.Entry(txHash, c.Vout)
+	if err != nil {
+		context := "Failed to query utxo history"
+		return nil, rpcInternalError(err.Error(), context)
+	}
+	if entry == nil || entry.Height > height {
+		return nil, dcrjson.NewRPCError(dcrjson.ErrRPCNoTxInfo,
+			fmt.Sprintf("No history available about output %v:%d as of "+
+				"height %d", txHash, c.Vout, height))
+	}
+
+	// Only include the events that happened as of the requested height.
+	state, _ := entry.StateAt(height)
+	events := make([]types.TxOutHistoryEventResult, 0, len(entry.Events))
+	for i := range entry.Events {
+		event := &entry.Events[i]
+		if event.Height > height {
+			break
+		}
+		blockHash, err := chain.BlockHashByHeight(event.Height)
+		if err != nil {
+			context := "Failed to get block hash"
+			return nil, rpcInternalError(err.Error(), context)
+		}
+		result := types.TxOutHistoryEventResult{
+			Height:    event.Height,
+			BlockHash: blockHash.String(),
+			Event:     event.Kind.String(),
+		}
+		if event.Kind == indexers.UtxoEventSpent ||
+			event.Kind == indexers.UtxoEventUnspent {
+
+			result.SpendTxID = event.SpenderHash.String()
+			result.SpendVin = event.SpenderIndex
+		}
+		events = append(events, result)
+	}
+
+	// Disassemble script into single line printable format.  The
+	// disassembled string will contain [error] inline if the script
+	// doesn't fully parse, so ignore the error here.
+	script := entry.PkScript
+	disbuf, _ := txscript.DisasmString(script)
+
+	// Attempt to extract known addresses associated with the script.
+	scriptVersion := entry.ScriptVersion
+	scriptType, addrs := stdscript.ExtractAddrs(scriptVersion, script,
+		s.cfg.ChainParams)
+	addresses := make([]string, len(addrs))
+	for i, addr := range addrs {
+		addresses[i] = addr.String()
+	}
+
+	// Determine the number of required signatures for known standard types.
+	reqSigs := stdscript.DetermineRequiredSigs(scriptVersion, script)
+
+	return &types.GetTxOutHistoryResult{
+		Height:        height,
+		State:         state.String(),
+		CreatedHeight: entry.Height,
+		Tree:          entry.Tree,
+		Value:         dcrutil.Amount(entry.Amount).ToUnit(dcrutil.AmountCoin),
+		ScriptPubKey: types.ScriptPubKeyResult{
+			Asm:       disbuf,
+			Hex:       hex.EncodeToString(script),
+			ReqSigs:   int32(reqSigs),
+			Type:      scriptType.String(),
+			Addresses: addresses,
+			Version:   scriptVersion,
+		},
+		Coinbase: entry.Coinbase,
+		Events:   events,
+	}, nil
+}
+
 // handleGetTxOutSetInfo returns statistics on the current unspent transaction output set.
 func handleGetTxOutSetInfo(_ context.Context, s *Server, _ interface{}) (interface{}, error) {
 	best := s.cfg.Chain.BestSnapshot()
@@ -4010,6 +5448,21 @@ func handlePing(_ context.Context, s *Server, _ interface{}) (interface{}, error
 	return nil, nil
 }
 
+// handlePurgeSideChainBlocks implements the purgesidechainblocks command.
+func handlePurgeSideChainBlocks(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.PurgeSideChainBlocksCmd)
+	if *c.MinDepth < 0 {
+		return nil, rpcInvalidError("Minimum depth must not be negative")
+	}
+
+	purged, err := s.cfg.Chain.PurgeSideChainBlocks(*c.MinDepth)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Unable to purge side chain blocks")
+	}
+	return sideChainBlockResults(purged), nil
+}
+
 // handleReconsiderBlock implements the reconsiderblock command.
 func handleReconsiderBlock(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
 	c := cmd.(*types.ReconsiderBlockCmd)
@@ -4072,6 +5525,46 @@ func handleRegenTemplate(_ context.Context, s *Server, _ interface{}) (interface
 	return nil, nil
 }
 
+// handleReloadConfig implements the reloadconfig command.
+func handleReloadConfig(_ context.Context, s *Server, _ interface{}) (interface{}, error) {
+	changes, err := s.cfg.ConfigReloader.ReloadConfig()
+	if err != nil {
+		return nil, rpcInvalidError("Unable to reload config: %v", err)
+	}
+	if changes == nil {
+		changes = []string{}
+	}
+	return &types.ReloadConfigResult{Changes: changes}, nil
+}
+
+// handleScrubDatabase implements the scrubdatabase command.
+func handleScrubDatabase(ctx context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.ScrubDatabaseCmd)
+	result, err := s.cfg.Chain.Scrub(ctx, *c.Quarantine)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(), "Unable to scrub database")
+	}
+
+	issues := make([]types.ScrubIssue, 0, len(result.Issues))
+	for i := range result.Issues {
+		issue := &result.Issues[i]
+		issues = append(issues, types.ScrubIssue{
+			Hash:        issue.Hash.String(),
+			Height:      issue.Height,
+			MainChain:   issue.MainChain,
+			Kind:        issue.Kind.String(),
+			Description: issue.Description,
+			Suggestion:  issue.Suggestion,
+			Quarantined: issue.Quarantined,
+		})
+	}
+	return &types.ScrubDatabaseResult{
+		MainChainBlocks: result.MainChainBlocks,
+		SideChainBlocks: result.SideChainBlocks,
+		Issues:          issues,
+	}, nil
+}
+
 // handleSendRawTransaction implements the sendrawtransaction command.
 func handleSendRawTransaction(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
 	c := cmd.(*types.SendRawTransactionCmd)
@@ -4115,17 +5608,20 @@ func handleSendRawTransaction(_ context.Context, s *Server, cmd interface{}) (in
 			// is known to already be submitted to the mempool, as
 			// well as whenever there is a high certainty that the
 			// transaction has been confirmed in a recent block.
+			rejectCode, _ := mempool.ErrorRejectCode(rErr)
 			switch {
 			case errors.Is(rErr, mempool.ErrDuplicate):
 				fallthrough
 			case errors.Is(rErr, mempool.ErrAlreadyExists):
 				fallthrough
 			case s.cfg.SyncMgr.RecentlyConfirmedTxn(hash):
-				return nil, rpcDuplicateTxError("%v", err)
+				return nil, rpcTxRejectError(rpcDuplicateTxError("%v",
+					err), mempool.RejectDuplicate)
 			}
 
 			// return a generic rule error
-			return nil, rpcRuleError("%v", err)
+			return nil, rpcTxRejectError(rpcRuleError("%v", err),
+				rejectCode)
 		}
 
 		err = fmt.Errorf("failed to process transaction %v: %w",
@@ -4182,11 +5678,44 @@ func handleSetGenerate(_ context.Context, s *Server, cmd interface{}) (interface
 				"specified via --miningaddr", "Configuration")
 		}
 
+		// Respond with an error if there's virtually 0 chance of CPU-mining
+		// a block.
+		params := s.cfg.ChainParams
+		if !params.GenerateSupported {
+			return nil, &dcrjson.RPCError{
+				Code: dcrjson.ErrRPCDifficulty,
+				Message: fmt.Sprintf("No support for `setgenerate` on the "+
+					"current network, %s, as it's unlikely to be possible to "+
+					"mine a block with the CPU.", params.Net),
+			}
+		}
+
 		s.cfg.CPUMiner.SetNumWorkers(int32(genProcLimit))
 	}
 	return nil, nil
 }
 
+// handleSetMockTime implements the setmocktime command.
+func handleSetMockTime(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.SetMockTimeCmd)
+
+	if c.Timestamp < 0 {
+		return nil, rpcInvalidError("Timestamp must not be negative")
+	}
+	if s.cfg.MockTimeSource == nil {
+		return nil, rpcInternalError("Mock time is not supported",
+			"Configuration")
+	}
+
+	// A timestamp of zero removes the mock time.
+	var mockTime time.Time
+	if c.Timestamp != 0 {
+		mockTime = time.Unix(c.Timestamp, 0)
+	}
+	s.cfg.MockTimeSource.SetMockTime(mockTime)
+	return nil, nil
+}
+
 // handleStop implements the stop command.
 func handleStop(_ context.Context, s *Server, _ interface{}) (interface{}, error) {
 	select {
@@ -4312,7 +5841,7 @@ func feeInfoForMempool(s *Server, txType stake.TxType) *types.FeeInfoMempool {
 	for _, txD := range txDs {
 		if txD.Type == txType {
 			feePerKb := (dcrutil.Amount(txD.Fee)) * 1000 /
-				dcrutil.Amount(txD.Tx.MsgTx().SerializeSize())
+				dcrutil.Amount(txD.Tx.SerializeSize())
 			ticketFees = append(ticketFees, feePerKb)
 		}
 	}
@@ -4339,7 +5868,7 @@ func calcFeePerKb(tx *dcrutil.Tx) dcrutil.Amount {
 		out += dcrutil.Amount(txOut.Value)
 	}
 
-	return ((in - out) * 1000) / dcrutil.Amount(tx.MsgTx().SerializeSize())
+	return ((in - out) * 1000) / dcrutil.Amount(tx.SerializeSize())
 }
 
 // ticketFeeInfoForBlock fetches the ticket fee information for a given tx type
@@ -4442,6 +5971,69 @@ func ticketFeeInfoForRange(s *Server, start int64, end int64, txType stake.TxTyp
 	}, nil
 }
 
+// handleTestMempoolAccept implements the testmempoolaccept command.
+func handleTestMempoolAccept(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
+	c := cmd.(*types.TestMempoolAcceptCmd)
+	if len(c.RawTxs) == 0 {
+		return nil, rpcInvalidError("No transactions provided")
+	}
+	if len(c.RawTxs) > maxTestMempoolAcceptTxns {
+		return nil, rpcInvalidError("Too many transactions provided -- "+
+			"got %d, max %d", len(c.RawTxs), maxTestMempoolAcceptTxns)
+	}
+
+	// Deserialize all of the transactions before checking any of them so
+	// malformed requests are rejected as a whole.
+	txns := make([]*dcrutil.Tx, 0, len(c.RawTxs))
+	for _, hexStr := range c.RawTxs {
+		if len(hexStr)%2 != 0 {
+			hexStr = "0" + hexStr
+		}
+		serializedTx, err := hex.DecodeString(hexStr)
+		if err != nil {
+			return nil, rpcDecodeHexError(hexStr)
+		}
+		msgTx := wire.NewMsgTx()
+		err = msgTx.Deserialize(bytes.NewReader(serializedTx))
+		if err != nil {
+			return nil, rpcDeserializationError("Could not decode Tx: %v",
+				err)
+		}
+		txns = append(txns, dcrutil.NewTx(msgTx))
+	}
+
+	// Check whether or not each transaction would be accepted into the
+	// mempool without adding it.  Rule violations are reported as part of
+	// the result for the transaction as opposed to failing the request.
+	allowHighFees := *c.AllowHighFees
+	mp := s.cfg.TxMempooler
+	results := make([]types.TestMempoolAcceptResult, 0, len(txns))
+	for _, tx := range txns {
+		result := types.TestMempoolAcceptResult{TxID: tx.Hash().String()}
+		txDesc, err := mp.CheckAcceptTransaction(tx, allowHighFees)
+		if err != nil {
+			var rErr mempool.RuleError
+			if !errors.As(err, &rErr) {
+				context := fmt.Sprintf("Failed to check transaction %v",
+					tx.Hash())
+				return nil, rpcInternalError(err.Error(), context)
+			}
+			rejectCode, _ := mempool.ErrorRejectCode(rErr)
+			result.RejectReason = err.Error()
+			result.RejectCode = string(rejectCode)
+			results = append(results, result)
+			continue
+		}
+
+		result.Allowed = true
+		result.Size = txDesc.TxSize
+		result.Fee = dcrutil.Amount(txDesc.Fee).ToCoin()
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
 // handleTicketFeeInfo implements the ticketfeeinfo command.
 func handleTicketFeeInfo(_ context.Context, s *Server, cmd interface{}) (interface{}, error) {
 	c := cmd.(*types.TicketFeeInfoCmd)
@@ -4826,29 +6418,38 @@ func handleVerifyMessage(_ context.Context, s *Server, cmd interface{}) (interfa
 	return address.String() == c.Address, nil
 }
 
-// handleVersion implements the version command.
-func handleVersion(_ context.Context, _ *Server, _ interface{}) (interface{}, error) {
+// versionResults returns the version details of the RPC server API and the
+// software.
+func versionResults() (apiVer, dcrdVer types.VersionResult) {
 	runtimeVer := strings.ReplaceAll(runtime.Version(), ".", "-")
 	buildMeta := version.NormalizeString(runtimeVer)
 	build := version.NormalizeString(version.BuildMetadata)
 	if build != "" {
 		buildMeta = fmt.Sprintf("%s.%s", build, buildMeta)
 	}
+	apiVer = types.VersionResult{
+		VersionString: jsonrpcSemverString,
+		Major:         jsonrpcSemverMajor,
+		Minor:         jsonrpcSemverMinor,
+		Patch:         jsonrpcSemverPatch,
+	}
+	dcrdVer = types.VersionResult{
+		VersionString: version.String(),
+		Major:         version.Major,
+		Minor:         version.Minor,
+		Patch:         version.Patch,
+		Prerelease:    version.NormalizeString(version.PreRelease),
+		BuildMetadata: buildMeta,
+	}
+	return apiVer, dcrdVer
+}
+
+// handleVersion implements the version command.
+func handleVersion(_ context.Context, _ *Server, _ interface{}) (interface{}, error) {
+	apiVer, dcrdVer := versionResults()
 	result := map[string]types.VersionResult{
-		"dcrdjsonrpcapi": {
-			VersionString: jsonrpcSemverString,
-			Major:         jsonrpcSemverMajor,
-			Minor:         jsonrpcSemverMinor,
-			Patch:         jsonrpcSemverPatch,
-		},
-		"dcrd": {
-			VersionString: version.String(),
-			Major:         version.Major,
-			Minor:         version.Minor,
-			Patch:         version.Patch,
-			Prerelease:    version.NormalizeString(version.PreRelease),
-			BuildMetadata: buildMeta,
-		},
+		"dcrdjsonrpcapi": apiVer,
+		"dcrd":           dcrdVer,
 	}
 	return result, nil
 }
@@ -4856,7 +6457,8 @@ func handleVersion(_ context.Context, _ *Server, _ interface{}) (interface{}, er
 // Server provides a concurrent safe RPC server to a chain server.
 type Server struct {
 	// atomic
-	numClients int32
+	slowWebsockets uint64
+	numClients     int32
 
 	cfg                    Config
 	hmac                   hash.Hash
@@ -4870,6 +6472,9 @@ type Server struct {
 	workState              *workState
 	helpCacher             RPCHelpCacher
 	requestProcessShutdown chan struct{}
+	auditLog               *auditLogger
+	limiter                *clientLimiter
+	responseCache          *responseCache
 }
 
 // isTreasuryAgendaActive returns if the treasury agenda is active or not for
@@ -4885,6 +6490,26 @@ func (s *Server) isTreasuryAgendaActive(prevBlkHash *chainhash.Hash) (bool, erro
 	return isTreasuryEnabled, nil
 }
 
+// maybeCacheResponse adds the provided response for the method and hash to the
+// response cache when the provided block it is associated with has at least
+// responseCacheMinConfirmations confirmations in the main chain.
+func (s *Server) maybeCacheResponse(method string, hash, blockHash *chainhash.Hash, value interface{}, size int64) {
+	if !s.responseCache.enabled() {
+		return
+	}
+
+	chain := s.cfg.Chain
+	height, err := chain.BlockHeightByHash(blockHash)
+	if err != nil {
+		return
+	}
+	confirmations := 1 + chain.BestSnapshot().Height - height
+	if confirmations < responseCacheMinConfirmations {
+		return
+	}
+	s.responseCache.add(method, hash, value, size)
+}
+
 // isAutoRevocationsAgendaActive returns if the automatic ticket revocations
 // agenda is active or not for the block AFTER the provided block hash.
 func (s *Server) isAutoRevocationsAgendaActive(prevBlkHash *chainhash.Hash) (bool, error) {
@@ -4976,7 +6601,19 @@ func (s *Server) shutdown() error {
 			return err
 		}
 	}
+	for _, listener := range s.cfg.TrustedListeners {
+		err := listener.Close()
+		if err != nil {
+			log.Errorf("Problem shutting down rpc: %v", err)
+			return err
+		}
+	}
 	s.wg.Wait()
+	if s.auditLog != nil {
+		if err := s.auditLog.close(); err != nil {
+			log.Errorf("Problem closing RPC audit log: %v", err)
+		}
+	}
 	log.Infof("RPC server shutdown complete")
 	return nil
 }
@@ -5019,6 +6656,12 @@ func (s *Server) NotifyBlockConnected(block *dcrutil.Block) {
 // NotifyBlockDisconnected notifies websocket clients that have registered for
 // block updates when a block is disconnected from the main chain.
 func (s *Server) NotifyBlockDisconnected(block *dcrutil.Block) {
+	// Transaction hashes do not commit to the signature scripts, so a cached
+	// transaction might be mined again with different ones after a
+	// reorganization.  Remove all cached transactions since it is not known
+	// which of them might be affected.
+	s.responseCache.removeMethod("getrawtransaction")
+
 	s.ntfnMgr.NotifyBlockDisconnected(block)
 }
 
@@ -5028,6 +6671,20 @@ func (s *Server) NotifyReorganization(rd *blockchain.ReorganizationNtfnsData) {
 	s.ntfnMgr.NotifyReorganization(rd)
 }
 
+// NotifyDeepReorgPaused notifies websocket clients that have registered for
+// block updates when a blockchain reorganization was paused due to exceeding
+// the maximum automatic reorganization depth.
+func (s *Server) NotifyDeepReorgPaused(pd *blockchain.DeepReorgPausedNtfnsData) {
+	s.ntfnMgr.NotifyDeepReorgPaused(pd)
+}
+
+// NotifyVoteLeaderChanged notifies websocket clients that have registered for
+// work updates when a new vote in the mempool caused a different block to
+// become the most favorable one to build on at a given height.
+func (s *Server) NotifyVoteLeaderChanged(vd *VoteLeaderChangedNtfnData) {
+	s.ntfnMgr.NotifyVoteLeaderChanged(vd)
+}
+
 // NotifyWinningTickets notifies websocket clients that have registered for
 // winning ticket updates.
 func (s *Server) NotifyWinningTickets(wtnd *WinningTicketsNtfnData) {
@@ -5095,11 +6752,39 @@ func (s *Server) checkAuthMAC(auth, remoteAddr string) (bool, bool) {
 	if cmp|limitcmp == 0 {
 		// Request's auth doesn't match either user
 		log.Warnf("RPC authentication failure from %s", remoteAddr)
+		s.authFailed(remoteAddr)
 		return false, false
 	}
+	s.limiter.authSucceeded(remoteAddr)
 	return true, cmp == 1
 }
 
+// authFailed records an authentication failure for the provided remote address
+// and logs when it results in the remote host being locked out from
+// authenticating.
+//
+// This function is safe for concurrent access.
+func (s *Server) authFailed(remoteAddr string) {
+	if lockout := s.limiter.authFailed(remoteAddr); lockout > 0 {
+		log.Warnf("Too many RPC authentication failures from %s - locking "+
+			"out for %v", remoteAddr, lockout)
+	}
+}
+
+// authLockedOut returns whether or not the provided remote address is
+// currently locked out from authenticating due to repeated authentication
+// failures and logs when it is.
+//
+// This function is safe for concurrent access.
+func (s *Server) authLockedOut(remoteAddr string) bool {
+	remaining, lockedOut := s.limiter.lockedOut(remoteAddr)
+	if lockedOut {
+		log.Warnf("RPC authentication attempt from %s rejected - locked out "+
+			"for another %v", remoteAddr, remaining.Round(time.Second))
+	}
+	return lockedOut
+}
+
 // checkAuthUserPass checks the correctness of username and password by
 // generating the corresponding HTTP Basic authentication string then
 // compare the string with the already generated hash.
@@ -5108,6 +6793,10 @@ func (s *Server) checkAuthMAC(auth, remoteAddr string) (bool, bool) {
 // the second bool return value specifies whether the user can change the state
 // of the server (true) or whether the user is limited (false).
 func (s *Server) checkAuthUserPass(user, pass, remoteAddr string) (bool, bool) {
+	if s.authLockedOut(remoteAddr) {
+		return false, false
+	}
+
 	login := user + ":" + pass
 	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(login))
 	return s.checkAuthMAC(auth, remoteAddr)
@@ -5115,7 +6804,9 @@ func (s *Server) checkAuthUserPass(user, pass, remoteAddr string) (bool, bool) {
 
 // checkAuth checks the HTTP Basic authentication supplied by a wallet or RPC
 // client in the HTTP request r.  If the supplied authentication does not match
-// the username and password expected, a non-nil error is returned.
+// the username and password expected, a non-nil error is returned.  The error
+// is errAuthLockedOut when the client is locked out from authenticating due to
+// repeated authentication failures.
 //
 // This check is time-constant.
 //
@@ -5124,6 +6815,12 @@ func (s *Server) checkAuthUserPass(user, pass, remoteAddr string) (bool, bool) {
 // of the server (true) or whether the user is limited (false). The second is
 // always false if the first is.
 func (s *Server) checkAuth(r *http.Request, require bool) (bool, bool, error) {
+	// Requests over trusted connections are always authenticated with full
+	// administrative access.
+	if isTrustedConn(r.Context()) {
+		return true, true, nil
+	}
+
 	// If admin-level RPC user and pass options are not set, this always
 	// succeeds.  This will be the case when TLS client certificates are
 	// being used for authentication.
@@ -5131,11 +6828,16 @@ func (s *Server) checkAuth(r *http.Request, require bool) (bool, bool, error) {
 		return true, true, nil
 	}
 
+	if s.authLockedOut(r.RemoteAddr) {
+		return false, false, errAuthLockedOut
+	}
+
 	authhdr := r.Header["Authorization"]
 	if len(authhdr) == 0 {
 		if require {
 			log.Warnf("RPC authentication failure from %s",
 				r.RemoteAddr)
+			s.authFailed(r.RemoteAddr)
 			return false, false, errors.New("auth failure")
 		}
 
@@ -5169,6 +6871,9 @@ func (s *Server) standardCmdResult(ctx context.Context, cmd *parsedRPCCmd) (inte
 	if !ok {
 		return nil, dcrjson.ErrRPCMethodNotFound
 	}
+	if _, ok := rpcDebugOnly[string(cmd.method)]; ok && !s.cfg.DebugRPC {
+		return nil, ErrRPCDebugDisabled
+	}
 
 	return handler(ctx, s, cmd.params)
 }
@@ -5213,19 +6918,36 @@ func parseCmd(request *dcrjson.Request) *parsedRPCCmd {
 // createMarshalledReply returns a new marshalled JSON-RPC response given the
 // passed parameters.  It will automatically convert errors that are not of the
 // type *dcrjson.RPCError to the appropriate type as needed.
+//
+// Errors are always mapped to one of the stable error codes defined by the
+// dcrjson package so clients are able to reliably switch on them.  Errors that
+// are not RPC errors or that carry an unknown code are reported as internal
+// errors.
 func createMarshalledReply(rpcVersion string, id interface{}, result interface{}, replyErr error) ([]byte, error) {
 	var jsonErr *dcrjson.RPCError
-	if replyErr != nil && !errors.As(replyErr, &jsonErr) {
+	var jsonErrVal dcrjson.RPCError
+	switch {
+	case replyErr == nil:
+	case errors.As(replyErr, &jsonErr):
+	case errors.As(replyErr, &jsonErrVal):
+		jsonErr = &jsonErrVal
+	default:
 		jsonErr = rpcInternalError(replyErr.Error(), "")
 	}
+	if jsonErr != nil && !jsonErr.Code.IsKnown() {
+		context := fmt.Sprintf("Unknown RPC error code %d", int(jsonErr.Code))
+		internalErr := rpcInternalError(jsonErr.Message, context)
+		internalErr.Data = jsonErr.Data
+		jsonErr = internalErr
+	}
 
 	return dcrjson.MarshalResponse(rpcVersion, id, result, jsonErr)
 }
 
 // processRequest determines the incoming request type (single or batched),
 // parses it and returns a marshalled response.
-func (s *Server) processRequest(ctx context.Context, request *dcrjson.Request, isAdmin bool) []byte {
-	var result interface{}
+func (s *Server) processRequest(ctx context.Context, request *dcrjson.Request, remoteAddr string, isAdmin bool) []byte {
+	var result, params interface{}
 	var jsonErr error
 
 	if !isAdmin {
@@ -5261,10 +6983,15 @@ func (s *Server) processRequest(ctx context.Context, request *dcrjson.Request, i
 		if parsedCmd.err != nil {
 			jsonErr = parsedCmd.err
 		} else {
+			params = parsedCmd.params
 			result, jsonErr = s.standardCmdResult(ctx, parsedCmd)
 		}
 	}
 
+	// Record the invocation in the audit log when it is privileged.
+	s.auditRequest(isAdmin, remoteAddr, types.Method(request.Method), params,
+		jsonErr)
+
 	// Marshal the response.
 	msg, err := createMarshalledReply(request.Jsonrpc, request.ID, result, jsonErr)
 	if err != nil {
@@ -5358,7 +7085,7 @@ func (s *Server) jsonRPCRead(sCtx context.Context, w http.ResponseWriter, r *htt
 				log.Errorf("Failed to create reply: %v", err)
 			}
 		} else {
-			resp = s.processRequest(ctx, &req, isAdmin)
+			resp = s.processRequest(ctx, &req, r.RemoteAddr, isAdmin)
 		}
 
 		if resp != nil {
@@ -5427,7 +7154,7 @@ func (s *Server) jsonRPCRead(sCtx context.Context, w http.ResponseWriter, r *htt
 						continue
 					}
 
-					resp = s.processRequest(ctx, &req, isAdmin)
+					resp = s.processRequest(ctx, &req, r.RemoteAddr, isAdmin)
 					if resp != nil {
 						results = append(results, resp)
 					}
@@ -5484,6 +7211,13 @@ func jsonAuthFail(w http.ResponseWriter) {
 	http.Error(w, "401 Unauthorized.", http.StatusUnauthorized)
 }
 
+// jsonAuthLockedOut sends a message back to the client that it is locked out
+// from authenticating due to repeated authentication failures.
+func jsonAuthLockedOut(w http.ResponseWriter) {
+	http.Error(w, "429 Too many authentication failures.  Try again later.",
+		http.StatusTooManyRequests)
+}
+
 // logForwarder provides logic to forward log messages writing to an io.Writer
 // to the rpcserver logger.
 type logForwarder struct{}
@@ -5533,6 +7267,10 @@ func (s *Server) route(ctx context.Context) *http.Server {
 		// Reroute http server error logging through the rpcserver
 		// logger.
 		ErrorLog: stdlog.New(logForwarder{}, "", 0),
+
+		// Mark connections accepted by trusted listeners as such so they
+		// are treated as authenticated.
+		ConnContext: trustedConnContext,
 	}
 	rpcServeMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Connection", "close")
@@ -5548,6 +7286,10 @@ func (s *Server) route(ctx context.Context) *http.Server {
 		s.incrementClients()
 		defer s.decrementClients()
 		_, isAdmin, err := s.checkAuth(r, true)
+		if errors.Is(err, errAuthLockedOut) {
+			jsonAuthLockedOut(w)
+			return
+		}
 		if err != nil {
 			jsonAuthFail(w)
 			return
@@ -5560,6 +7302,10 @@ func (s *Server) route(ctx context.Context) *http.Server {
 	// Websocket endpoint.
 	rpcServeMux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
 		authenticated, isAdmin, err := s.checkAuth(r, false)
+		if errors.Is(err, errAuthLockedOut) {
+			jsonAuthLockedOut(w)
+			return
+		}
 		if err != nil {
 			jsonAuthFail(w)
 			return
@@ -5656,6 +7402,16 @@ func (s *Server) Run(ctx context.Context) {
 			s.wg.Done()
 		}(listener)
 	}
+	for _, listener := range s.cfg.TrustedListeners {
+		s.wg.Add(1)
+		go func(listener net.Listener) {
+			log.Infof("RPC server listening on %s (authentication not "+
+				"required)", listener.Addr())
+			server.Serve(trustedListener{listener})
+			log.Tracef("RPC listener done for %s", listener.Addr())
+			s.wg.Done()
+		}(listener)
+	}
 
 	// Subscribe for async work notifications when background template
 	// generation is enabled.
@@ -5694,6 +7450,13 @@ type Config struct {
 	// is stopped.
 	Listeners []net.Listener
 
+	// TrustedListeners defines a slice of additional listeners for which the
+	// RPC server will take ownership of and accept connections that are
+	// treated as authenticated with full administrative access.  They are
+	// intended for listeners where access is controlled by other means, such
+	// as Unix domain sockets which are protected by file permissions.
+	TrustedListeners []net.Listener
+
 	// StartupTime is the unix timestamp for when the server that is hosting
 	// the RPC server started.
 	StartupTime int64
@@ -5747,13 +7510,17 @@ type Config struct {
 	// use.
 	TxIndexer TxIndexer
 
+	// NullDataIndexer defines the optional null data indexer for the RPC
+	// server to use.
+	NullDataIndexer NullDataIndexer
+
+	// UtxoHistoryIndexer defines the optional utxo history indexer for the RPC
+	// server to use.
+	UtxoHistoryIndexer UtxoHistoryIndexer
+
 	// NetInfo defines a slice of the available networks.
 	NetInfo []types.NetworksResult
 
-	// MinRelayTxFee defines the minimum transaction fee in Atoms/1000 bytes to be
-	// considered a non-zero fee.
-	MinRelayTxFee dcrutil.Amount
-
 	// Proxy defines the proxy that is being used for connections.
 	Proxy string
 
@@ -5775,9 +7542,51 @@ type Config struct {
 	// RPCMaxWebsockets defines the max number of RPC websocket connections.
 	RPCMaxWebsockets int
 
+	// RPCMaxWebsocketsPerIP defines the max number of RPC websocket
+	// connections per remote IP address.  There is no per IP limit when it
+	// is zero.
+	RPCMaxWebsocketsPerIP int
+
+	// RPCMaxWebsocketQueueBytes defines the max number of bytes of
+	// notifications that may be queued for a websocket client.  Clients that
+	// exceed it have their notifications dropped and are disconnected.
+	// There is no limit when it is zero.
+	RPCMaxWebsocketQueueBytes int64
+
+	// RPCResponseCacheBytes defines the max approximate number of bytes of
+	// immutable responses, such as those for deeply confirmed blocks and
+	// transactions, that are cached in memory.  Responses are not cached
+	// when it is zero.
+	RPCResponseCacheBytes int64
+
+	// RPCMaxAuthFailures defines the number of consecutive authentication
+	// failures from a remote IP address that cause it to be locked out from
+	// authenticating for RPCAuthLockout.  Every additional failure after that
+	// doubles the lockout duration up to a maximum of one hour.  Lockouts are
+	// disabled when it is zero.
+	RPCMaxAuthFailures int
+
+	// RPCAuthLockout defines the initial duration a remote IP address is
+	// locked out from authenticating after repeated authentication failures.
+	RPCAuthLockout time.Duration
+
 	// TestNet represents whether or not the server is using testnet.
 	TestNet bool
 
+	// DebugRPC enables the RPCs that are only intended for testing, such as
+	// setmocktime and forceprune.  It must only be set on networks intended
+	// for testing.
+	DebugRPC bool
+
+	// MockTimeSource defines the source of the network-adjusted time that
+	// the setmocktime RPC overrides.  It must be set when DebugRPC is set.
+	MockTimeSource MockTimeSource
+
+	// AcceptNonStd and MaxOrphanTxs define the transaction acceptance policy
+	// of the transaction memory pool.
+	AcceptNonStd bool
+	MaxOrphanTxs int
+
 	// MiningAddrs is a list of payment addresses to use for the generated blocks.
 	MiningAddrs []stdaddr.Address
 
@@ -5797,6 +7606,22 @@ type Config struct {
 
 	// FiltererV2 defines the V2 filterer for the RPC server to use.
 	FiltererV2 FiltererV2
+
+	// ConfigReloader defines the source of configuration for the RPC server
+	// to reload.
+	ConfigReloader ConfigReloader
+
+	// AuditLogFile defines the optional path to an append-only file that
+	// records every invocation of a privileged RPC, which is any method that
+	// is not available to limited users, as a line of JSON.  The audit log is
+	// disabled when it is empty.
+	AuditLogFile string
+
+	// AuditLogRedactions defines rules for parameters that are redacted from
+	// the audit log.  Each rule is either of the form "method" to redact all
+	// parameters of the method or "method.param" to only redact the named
+	// parameter of the method.
+	AuditLogRedactions []string
 }
 
 // New returns a new instance of the Server struct.
@@ -5826,6 +7651,21 @@ func New(config *Config) (*Server, error) {
 			base64.StdEncoding.EncodeToString([]byte(login))
 		rpc.authMAC(rpc.limitauthsha[:0], []byte(auth))
 	}
+	if config.AuditLogFile != "" {
+		const flags = os.O_APPEND | os.O_CREATE | os.O_WRONLY
+		f, err := os.OpenFile(config.AuditLogFile, flags, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open RPC audit log: %w", err)
+		}
+		rpc.auditLog, err = newAuditLogger(f, config.AuditLogRedactions)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	rpc.limiter = newClientLimiter(config.RPCMaxWebsocketsPerIP,
+		config.RPCMaxAuthFailures, config.RPCAuthLockout)
+	rpc.responseCache = newResponseCache(config.RPCResponseCacheBytes)
 	rpc.ntfnMgr = newWsNotificationManager(&rpc)
 
 	return &rpc, nil
@@ -295,5 +295,22 @@ func TestCheckAuth(t *testing.T) {
 				t.Errorf("unexpected err -- got %v, want auth failure", err)
 			}
 		}
+
+		// Requests over trusted connections are always authenticated with
+		// full administrative access.
+		ctx := trustedConnContext(context.Background(), trustedConn{})
+		for i := 0; i <= 1; i++ {
+			r := (&http.Request{}).WithContext(ctx)
+			authed, isAdmin, err := s.checkAuth(r, i == 0)
+			if !authed {
+				t.Errorf(" unexpected authed -- got %v, want %v", authed, true)
+			}
+			if !isAdmin {
+				t.Errorf("unexpected isAdmin -- got %v, want %v", isAdmin, true)
+			}
+			if err != nil {
+				t.Errorf("unexpected err -- got %v, want %v", err, nil)
+			}
+		}
 	}
 }
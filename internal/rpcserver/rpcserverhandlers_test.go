@@ -21,6 +21,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -130,6 +131,7 @@ type tspendVotes struct {
 
 // testRPCChain provides a mock block chain by implementing the Chain interface.
 type testRPCChain struct {
+	approveDeepReorgErr           error
 	autoRevocationsActive         bool
 	autoRevocationsActiveErr      error
 	bestSnapshot                  *blockchain.BestState
@@ -155,14 +157,26 @@ type testRPCChain struct {
 	fetchUtxoEntry                UtxoEntry
 	fetchUtxoEntryErr             error
 	fetchUtxoStats                *blockchain.UtxoStats
+	filterHeaders                 []wire.BlockHeader
+	filterHeadersErr              error
+	forecastStakeDifficulty       *blockchain.StakeDiffForecast
+	forecastStakeDifficultyErr    error
 	getStakeVersions              []blockchain.StakeVersions
 	getStakeVersionsErr           error
 	getVoteCounts                 blockchain.VoteCounts
 	getVoteCountsErr              error
 	getVoteInfo                   *blockchain.VoteInfo
 	getVoteInfoErr                error
+	intervalVoteInfo              *blockchain.IntervalVoteInfo
+	intervalVoteInfoErr           error
+	voteStats                     *blockchain.VoteStats
+	voteStatsErr                  error
 	headerByHashFn                func() wire.BlockHeader
 	headerByHashErr               error
+	headerCommitments             *blockchain.HeaderCommitments
+	headerCommitmentsErr          error
+	headerCommitmentsActive       bool
+	headerCommitmentsActiveErr    error
 	headerByHeight                wire.BlockHeader
 	headerByHeightErr             error
 	heightRangeFn                 func(startHeight, endHeight int64) ([]chainhash.Hash, error)
@@ -182,7 +196,12 @@ type testRPCChain struct {
 	missedTicketsErr              error
 	nextThresholdState            blockchain.ThresholdStateTuple
 	nextThresholdStateErr         error
+	purgedSideChainBlocks         []blockchain.SideChainBlock
+	purgeSideChainBlocksErr       error
 	reconsiderBlockErr            error
+	scrubResult                   *blockchain.ScrubResult
+	scrubErr                      error
+	sideChainBlocks               []blockchain.SideChainBlock
 	stateLastChangedHeight        int64
 	stateLastChangedHeightErr     error
 	ticketPoolValue               dcrutil.Amount
@@ -197,6 +216,13 @@ type testRPCChain struct {
 	treasuryActiveErr             error
 	subsidySplitActive            bool
 	subsidySplitActiveErr         error
+	verificationProgress          float64
+}
+
+// ApproveDeepReorg returns a mocked error from approving a chain
+// reorganization that exceeds the maximum automatic reorganization depth.
+func (c *testRPCChain) ApproveDeepReorg(hash *chainhash.Hash) error {
+	return c.approveDeepReorgErr
 }
 
 // BestSnapshot returns a mocked blockchain.BestState.
@@ -269,6 +295,11 @@ func (c *testRPCChain) EstimateNextStakeDifficulty(hash *chainhash.Hash, newTick
 	return c.estimateNextStakeDifficultyFn(hash, newTickets, useMaxTickets)
 }
 
+// ForecastStakeDifficulty returns a mocked stake difficulty forecast.
+func (c *testRPCChain) ForecastStakeDifficulty(hash *chainhash.Hash, numIntervals, ticketsPerInterval int64) (*blockchain.StakeDiffForecast, error) {
+	return c.forecastStakeDifficulty, c.forecastStakeDifficultyErr
+}
+
 // FetchUtxoEntry returns a mocked UtxoEntry.
 func (c *testRPCChain) FetchUtxoEntry(outpoint wire.OutPoint) (UtxoEntry, error) {
 	return c.fetchUtxoEntry, c.fetchUtxoEntryErr
@@ -297,6 +328,30 @@ func (c *testRPCChain) GetVoteInfo(hash *chainhash.Hash, version uint32) (*block
 	return c.getVoteInfo, c.getVoteInfoErr
 }
 
+// IntervalVoteInfo returns mocked vote tallies for the agendas of the provided
+// vote version over the rule change activation interval that contains the
+// provided height.
+func (c *testRPCChain) IntervalVoteInfo(height int64, version uint32) (*blockchain.IntervalVoteInfo, error) {
+	return c.intervalVoteInfo, c.intervalVoteInfoErr
+}
+
+// VoteStats returns mocked statistics about the number of eligible votes that
+// were included versus missed within the provided height range.
+func (c *testRPCChain) VoteStats(startHeight, endHeight int64, includeBlocks bool) (*blockchain.VoteStats, error) {
+	return c.voteStats, c.voteStatsErr
+}
+
+// HeaderCommitments returns mocked header commitments for the given block hash.
+func (c *testRPCChain) HeaderCommitments(hash *chainhash.Hash) (*blockchain.HeaderCommitments, error) {
+	return c.headerCommitments, c.headerCommitmentsErr
+}
+
+// FilterHeaders returns mocked headers starting with the header of the block
+// identified by the given hash.
+func (c *testRPCChain) FilterHeaders(hash *chainhash.Hash, dir blockchain.HeaderIterDirection, maxHeaders uint32, filter blockchain.HeaderFilterFunc) ([]wire.BlockHeader, error) {
+	return c.filterHeaders, c.filterHeadersErr
+}
+
 // HeaderByHash returns a mocked block header identified by the given hash.
 func (c *testRPCChain) HeaderByHash(hash *chainhash.Hash) (wire.BlockHeader, error) {
 	return c.headerByHashFn(), c.headerByHashErr
@@ -372,12 +427,28 @@ func (c *testRPCChain) NextThresholdState(hash *chainhash.Hash, version uint32,
 	return c.nextThresholdState, c.nextThresholdStateErr
 }
 
+// PurgeSideChainBlocks returns mocked side chain blocks that were purged.
+func (c *testRPCChain) PurgeSideChainBlocks(minDepth int64) ([]blockchain.SideChainBlock, error) {
+	return c.purgedSideChainBlocks, c.purgeSideChainBlocksErr
+}
+
 // ReconsiderBlock returns a mocked error from manually reconsidering a given
 // block.
 func (c *testRPCChain) ReconsiderBlock(hash *chainhash.Hash) error {
 	return c.reconsiderBlockErr
 }
 
+// Scrub returns a mocked result from scrubbing the database.
+func (c *testRPCChain) Scrub(ctx context.Context, quarantine bool) (*blockchain.ScrubResult, error) {
+	return c.scrubResult, c.scrubErr
+}
+
+// SideChainBlocks returns mocked side chain blocks that have their data
+// available.
+func (c *testRPCChain) SideChainBlocks() []blockchain.SideChainBlock {
+	return c.sideChainBlocks
+}
+
 // StateLastChangedHeight returns a mocked height at which the provided
 // consensus deployment agenda last changed state.
 func (c *testRPCChain) StateLastChangedHeight(hash *chainhash.Hash, version uint32, deploymentID string) (int64, error) {
@@ -407,6 +478,18 @@ func (c *testRPCChain) TreasuryBalance(*chainhash.Hash) (*blockchain.TreasuryBal
 	return c.treasuryBalance, c.treasuryBalanceErr
 }
 
+// VerificationProgress returns a mocked estimate of the chain verification
+// progress.
+func (c *testRPCChain) VerificationProgress() float64 {
+	return c.verificationProgress
+}
+
+// IsHeaderCommitmentsAgendaActive returns a mocked bool representing whether
+// or not the header commitments agenda is active.
+func (c *testRPCChain) IsHeaderCommitmentsAgendaActive(*chainhash.Hash) (bool, error) {
+	return c.headerCommitmentsActive, c.headerCommitmentsActiveErr
+}
+
 // IsTreasuryAgendaActive returns a mocked bool representing whether or not the
 // treasury agenda is active.
 func (c *testRPCChain) IsTreasuryAgendaActive(*chainhash.Hash) (bool, error) {
@@ -636,6 +719,77 @@ func (t *testTxIndexer) Entry(hash *chainhash.Hash) (*indexers.TxIndexEntry, err
 	return t.entry(hash)
 }
 
+// testNullDataIndexer provides a mock null data indexer by implementing the
+// NullDataIndexer interface.
+type testNullDataIndexer struct {
+	entries      []indexers.NullDataEntry
+	entriesErr   error
+	tipHeight    int64
+	tipHash      *chainhash.Hash
+	tipErr       error
+	signalOnWait bool
+}
+
+// Name returns the human-readable name of the index.
+func (t *testNullDataIndexer) Name() string {
+	return "testNullDataIndexer"
+}
+
+// Tip returns the current index tip.
+func (t *testNullDataIndexer) Tip() (int64, *chainhash.Hash, error) {
+	return t.tipHeight, t.tipHash, t.tipErr
+}
+
+// WaitForSync subscribes clients for the next index sync update.
+func (t *testNullDataIndexer) WaitForSync() chan bool {
+	c := make(chan bool)
+	if t.signalOnWait {
+		close(c)
+	}
+	return c
+}
+
+// Entries returns the mocked entries for the provided prefix within the
+// provided range of block heights.
+func (t *testNullDataIndexer) Entries(prefix []byte, startHeight, endHeight int64, maxEntries int) ([]indexers.NullDataEntry, error) {
+	return t.entries, t.entriesErr
+}
+
+// testUtxoHistoryIndexer provides a mock utxo history indexer by implementing
+// the UtxoHistoryIndexer interface.
+type testUtxoHistoryIndexer struct {
+	entry        *indexers.UtxoHistoryEntry
+	entryErr     error
+	tipHeight    int64
+	tipHash      *chainhash.Hash
+	tipErr       error
+	signalOnWait bool
+}
+
+// Name returns the human-readable name of the index.
+func (t *testUtxoHistoryIndexer) Name() string {
+	return "testUtxoHistoryIndexer"
+}
+
+// Tip returns the current index tip.
+func (t *testUtxoHistoryIndexer) Tip() (int64, *chainhash.Hash, error) {
+	return t.tipHeight, t.tipHash, t.tipErr
+}
+
+// WaitForSync subscribes clients for the next index sync update.
+func (t *testUtxoHistoryIndexer) WaitForSync() chan bool {
+	c := make(chan bool)
+	if t.signalOnWait {
+		close(c)
+	}
+	return c
+}
+
+// Entry returns the mocked entry for the provided transaction output.
+func (t *testUtxoHistoryIndexer) Entry(hash *chainhash.Hash, index uint32) (*indexers.UtxoHistoryEntry, error) {
+	return t.entry, t.entryErr
+}
+
 // testDB provides a mock database by implementing the database.DB interface.
 type testDB struct {
 	dbType   string
@@ -784,9 +938,12 @@ type testConnManager struct {
 	connectedCount      int32
 	netTotalReceived    uint64
 	netTotalSent        uint64
+	agentRejected       uint64
+	agentEvicted        uint64
 	connectedPeers      []Peer
 	persistentPeers     []Peer
 	addedNodeInfo       []Peer
+	rebroadcastTxns     []RebroadcastTx
 	lookup              func(host string) ([]net.IP, error)
 }
 
@@ -831,6 +988,12 @@ func (c *testConnManager) NetTotals() (uint64, uint64) {
 	return c.netTotalReceived, c.netTotalSent
 }
 
+// AgentFilterTotals returns a mocked total number of peers rejected and evicted
+// due to their user agent.
+func (c *testConnManager) AgentFilterTotals() (uint64, uint64) {
+	return c.agentRejected, c.agentEvicted
+}
+
 // ConnectedPeers returns a mocked slice of all connected peers.
 func (c *testConnManager) ConnectedPeers() []Peer {
 	return c.connectedPeers
@@ -850,6 +1013,27 @@ func (c *testConnManager) BroadcastMessage(msg wire.Message) {}
 // intervals until they show up in a block.
 func (c *testConnManager) AddRebroadcastInventory(iv *wire.InvVect, data interface{}) {}
 
+// RebroadcastTransactions returns a mocked list of transactions that are being
+// rebroadcast.
+func (c *testConnManager) RebroadcastTransactions() []RebroadcastTx {
+	return c.rebroadcastTxns
+}
+
+// AbandonRebroadcastTransaction returns a mocked result for whether or not the
+// transaction with the provided hash was being rebroadcast.
+func (c *testConnManager) AbandonRebroadcastTransaction(hash *chainhash.Hash) bool {
+	for _, tx := range c.rebroadcastTxns {
+		if tx.Hash == *hash {
+			return true
+		}
+	}
+	return false
+}
+
+// PruneRebroadcastInventory provides a mock implementation for removing
+// inventory that no longer needs to be rebroadcast.
+func (c *testConnManager) PruneRebroadcastInventory() {}
+
 // RelayTransactions provides a mock implementation for generating and relaying
 // inventory vectors for all of the passed transactions to all connected peers.
 func (c *testConnManager) RelayTransactions(txns []*dcrutil.Tx) {}
@@ -880,6 +1064,12 @@ func (c *testCPUMiner) GenerateNBlocks(ctx context.Context, n uint32) ([]*chainh
 	return c.generatedBlocks, c.generateNBlocksErr
 }
 
+// GenerateNBlocksToAddress returns a mock implementatation of generating a
+// requested number of blocks that pay to a provided address.
+func (c *testCPUMiner) GenerateNBlocksToAddress(ctx context.Context, n uint32, payToAddr stdaddr.Address) ([]*chainhash.Hash, error) {
+	return c.generatedBlocks, c.generateNBlocksErr
+}
+
 // IsMining returns a mocked mining state of the CPU miner.
 func (c *testCPUMiner) IsMining() bool {
 	return c.isMining
@@ -963,6 +1153,18 @@ func (l *testLogManager) ParseAndSetDebugLevels(debugLevel string) error {
 	return l.parseAndSetDebugLevelsErr
 }
 
+// testConfigReloader provides a mock config reloader by implementing the
+// ConfigReloader interface.
+type testConfigReloader struct {
+	changes []string
+	err     error
+}
+
+// ReloadConfig returns the mocked changes and error.
+func (r *testConfigReloader) ReloadConfig() ([]string, error) {
+	return r.changes, r.err
+}
+
 // testSanityChecker provides a mock implementation that checks the sanity
 // state of a block.
 type testSanityChecker struct {
@@ -1080,6 +1282,12 @@ type testTxMempooler struct {
 	fetchTransaction    *dcrutil.Tx
 	fetchTransactionErr error
 	tspendHashes        []chainhash.Hash
+	minRelayTxFee       dcrutil.Amount
+	checkAcceptTx       func(tx *dcrutil.Tx) (*mempool.TxDesc, error)
+	txMiningWindow      *blockchain.TxMiningWindow
+	txMiningWindowErr   error
+	events              []mempool.Event
+	blockConflicts      *blockchain.BlockConflicts
 }
 
 // HaveTransactions returns a mocked bool slice representing whether or not the
@@ -1117,6 +1325,59 @@ func (mp *testTxMempooler) TSpendHashes() []chainhash.Hash {
 	return mp.tspendHashes
 }
 
+// MinRelayTxFee returns the mocked minimum transaction fee that is considered
+// a non-zero fee.
+func (mp *testTxMempooler) MinRelayTxFee() dcrutil.Amount {
+	return mp.minRelayTxFee
+}
+
+// CheckAcceptTransaction returns a mocked result for whether or not the passed
+// transaction would be accepted into the pool.
+func (mp *testTxMempooler) CheckAcceptTransaction(tx *dcrutil.Tx, allowHighFees bool) (*mempool.TxDesc, error) {
+	return mp.checkAcceptTx(tx)
+}
+
+// PruneExpiredTx provides a mock implementation for removing all expired
+// transactions from the pool.
+func (mp *testTxMempooler) PruneExpiredTx() {}
+
+// Events returns the mocked events recorded in the mempool event journal.
+func (mp *testTxMempooler) Events() []mempool.Event {
+	return mp.events
+}
+
+// CalcTxMiningWindow returns the mocked range of blocks that may include the
+// passed transaction.
+func (mp *testTxMempooler) CalcTxMiningWindow(tx *dcrutil.Tx) (*blockchain.TxMiningWindow, error) {
+	return mp.txMiningWindow, mp.txMiningWindowErr
+}
+
+// CheckBlockConflicts returns the mocked conflicts for the passed block.
+func (mp *testTxMempooler) CheckBlockConflicts(block *dcrutil.Block) *blockchain.BlockConflicts {
+	return mp.blockConflicts
+}
+
+// testMockTimeSource provides a mock source of the network-adjusted time that
+// may be overridden by implementing the MockTimeSource interface.
+type testMockTimeSource struct {
+	mtx      sync.Mutex
+	mockTime time.Time
+}
+
+// SetMockTime records the provided mock time.
+func (m *testMockTimeSource) SetMockTime(t time.Time) {
+	m.mtx.Lock()
+	m.mockTime = t
+	m.mtx.Unlock()
+}
+
+// MockTime returns the most recently recorded mock time.
+func (m *testMockTimeSource) MockTime() time.Time {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.mockTime
+}
+
 // testNtfnManager provides a mock notification manager by implementing the
 // NtfnManager interface.
 type testNtfnManager struct {
@@ -1142,6 +1403,16 @@ func (mgr *testNtfnManager) NotifyTSpend(tx *dcrutil.Tx) {}
 // the manager for processing.
 func (mgr *testNtfnManager) NotifyReorganization(rd *blockchain.ReorganizationNtfnsData) {}
 
+// NotifyDeepReorgPaused passes a notification that a blockchain
+// reorganization was paused due to exceeding the maximum automatic
+// reorganization depth to the manager for processing.
+func (mgr *testNtfnManager) NotifyDeepReorgPaused(pd *blockchain.DeepReorgPausedNtfnsData) {}
+
+// NotifyVoteLeaderChanged passes a notification that a new vote in the mempool
+// caused a different block to become the most favorable one to build on at a
+// given height to the manager for processing.
+func (mgr *testNtfnManager) NotifyVoteLeaderChanged(vd *VoteLeaderChangedNtfnData) {}
+
 // NotifyWinningTickets passes newly winning tickets to the manager for
 // processing.
 func (mgr *testNtfnManager) NotifyWinningTickets(wtnd *WinningTicketsNtfnData) {}
@@ -1199,6 +1470,14 @@ func (mgr *testNtfnManager) RegisterNewTickets(wsc *wsClient) {}
 // the passed websocket client.
 func (mgr *testNtfnManager) UnregisterNewTickets(wsc *wsClient) {}
 
+// RegisterStakeEvents requests stake event notifications for the tickets
+// watched by the passed websocket client.
+func (mgr *testNtfnManager) RegisterStakeEvents(wsc *wsClient) {}
+
+// UnregisterStakeEvents removes stake event notifications for the passed
+// websocket client.
+func (mgr *testNtfnManager) UnregisterStakeEvents(wsc *wsClient) {}
+
 // RegisterStakeDifficulty requests stake difficulty notifications
 // to the passed websocket client.
 func (mgr *testNtfnManager) RegisterStakeDifficulty(wsc *wsClient) {}
@@ -1361,10 +1640,14 @@ type rpcTest struct {
 	setExistsAddresserNil bool
 	mockTxIndexer         *testTxIndexer
 	setTxIndexerNil       bool
+	mockNullDataIndexer   *testNullDataIndexer
+	mockUtxoHistIndexer   *testUtxoHistoryIndexer
 	mockDB                *testDB
 	mockConnManager       *testConnManager
 	mockClock             *testClock
+	setMockTimeSourceNil  bool
 	mockLogManager        *testLogManager
+	mockConfigReloader    *testConfigReloader
 	mockFiltererV2        *testFiltererV2
 	mockTxMempooler       *testTxMempooler
 	mockMiningAddrs       []stdaddr.Address
@@ -1372,6 +1655,7 @@ type rpcTest struct {
 	result                interface{}
 	wantErr               bool
 	errCode               dcrjson.RPCErrorCode
+	errData               interface{}
 }
 
 // defaultChainParams provides a default chaincfg.Params to be used throughout
@@ -1503,12 +1787,21 @@ func defaultMockRPCChain() *testRPCChain {
 				Choice: uint32(0xffffffff),
 			}},
 		},
-		headerByHashFn:    headerByHashFn,
-		headerByHeight:    blkHeader,
-		isCurrent:         true,
-		mainChainHasBlock: true,
-		maxBlockSize:      int64(393216),
-		medianTimeByHash:  time.Time{},
+		headerByHashFn: headerByHashFn,
+		headerByHeight: blkHeader,
+		headerCommitments: &blockchain.HeaderCommitments{
+			Version: blockchain.HeaderCmtVersion1,
+			Root:    blkHeader.StakeRoot,
+			Commitments: []blockchain.HeaderCommitment{{
+				Name: blockchain.HeaderCmtFilterName,
+				Hash: blkHeader.StakeRoot,
+			}},
+		},
+		headerCommitmentsActive: true,
+		isCurrent:               true,
+		mainChainHasBlock:       true,
+		maxBlockSize:            int64(393216),
+		medianTimeByHash:        time.Time{},
 		nextThresholdState: blockchain.ThresholdStateTuple{
 			State:  blockchain.ThresholdStarted,
 			Choice: uint32(0xffffffff),
@@ -1608,6 +1901,30 @@ func defaultMockTxIndexer() *testTxIndexer {
 	}
 }
 
+// defaultMockNullDataIndexer provides a default mock null data indexer to be
+// used throughout the tests.  Since the null data index is disabled by default,
+// tests must explicitly set rpcTest.mockNullDataIndexer to enable it.
+func defaultMockNullDataIndexer() *testNullDataIndexer {
+	bestHash := block432100.Header.BlockHash()
+	return &testNullDataIndexer{
+		tipHeight:    int64(block432100.Header.Height),
+		tipHash:      &bestHash,
+		signalOnWait: true,
+	}
+}
+
+// defaultMockUtxoHistoryIndexer provides a default mock utxo history indexer to
+// be used throughout the tests.  Since the utxo history index is disabled by
+// default, tests must explicitly set rpcTest.mockUtxoHistIndexer to enable it.
+func defaultMockUtxoHistoryIndexer() *testUtxoHistoryIndexer {
+	bestHash := block432100.Header.BlockHash()
+	return &testUtxoHistoryIndexer{
+		tipHeight:    int64(block432100.Header.Height),
+		tipHash:      &bestHash,
+		signalOnWait: true,
+	}
+}
+
 // defaultMockDB provides a default mock database to be used throughout the
 // tests. Tests can override these defaults by calling defaultMockDB, updating
 // fields as necessary on the returned *testDB, and then setting rpcTest.mockDB
@@ -1673,6 +1990,8 @@ func defaultMockConnManager() *testConnManager {
 		connectedCount:   4,
 		netTotalReceived: 9598159,
 		netTotalSent:     4783802,
+		agentRejected:    3,
+		agentEvicted:     1,
 		connectedPeers: []Peer{
 			testPeer1,
 			testPeer2,
@@ -1761,6 +2080,7 @@ func defaultMockCPUMiner() *testCPUMiner {
 func defaultMockTxMempooler() *testTxMempooler {
 	return &testTxMempooler{
 		fetchTransactionErr: errors.New("transaction is not in the pool"),
+		minRelayTxFee:       dcrutil.Amount(10000),
 	}
 }
 
@@ -1782,7 +2102,9 @@ func defaultMockConfig(chainParams *chaincfg.Params) *Config {
 		CPUMiner:        defaultMockCPUMiner(),
 		TxMempooler:     defaultMockTxMempooler(),
 		Clock:           &testClock{},
+		MockTimeSource:  &testMockTimeSource{},
 		LogManager:      defaultMockLogManager(),
+		ConfigReloader:  &testConfigReloader{},
 		FiltererV2:      defaultMockFiltererV2(),
 		TimeSource:      blockchain.NewMedianTime(),
 		Services:        wire.SFNodeNetwork | wire.SFNodeCF,
@@ -1806,13 +2128,52 @@ func defaultMockConfig(chainParams *chaincfg.Params) *Config {
 			Proxy:                     "",
 			ProxyRandomizeCredentials: false,
 		}},
-		MinRelayTxFee:      dcrutil.Amount(10000),
 		MaxProtocolVersion: wire.CFilterV2Version,
 		UserAgentVersion: fmt.Sprintf("%d.%d.%d", version.Major, version.Minor,
 			version.Patch),
 	}
 }
 
+func TestHandleAbandonRebroadcastTx(t *testing.T) {
+	t.Parallel()
+
+	txHash := block432100.Transactions[0].TxHash()
+	connManagerWithTxns := func(txns []RebroadcastTx) *testConnManager {
+		connManager := defaultMockConnManager()
+		connManager.rebroadcastTxns = txns
+		return connManager
+	}
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleAbandonRebroadcastTx: ok",
+		handler: handleAbandonRebroadcastTx,
+		cmd: &types.AbandonRebroadcastTxCmd{
+			TxHash: txHash.String(),
+		},
+		mockConnManager: connManagerWithTxns([]RebroadcastTx{{
+			Hash: txHash,
+		}}),
+		result: nil,
+	}, {
+		name:    "handleAbandonRebroadcastTx: invalid hash",
+		handler: handleAbandonRebroadcastTx,
+		cmd: &types.AbandonRebroadcastTxCmd{
+			TxHash: "invalid",
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:    "handleAbandonRebroadcastTx: not being rebroadcast",
+		handler: handleAbandonRebroadcastTx,
+		cmd: &types.AbandonRebroadcastTxCmd{
+			TxHash: txHash.String(),
+		},
+		mockConnManager: connManagerWithTxns(nil),
+		wantErr:         true,
+		errCode:         dcrjson.ErrRPCNoTxInfo,
+	}})
+}
+
 func TestHandleAddNode(t *testing.T) {
 	t.Parallel()
 
@@ -1878,6 +2239,102 @@ func TestHandleAddNode(t *testing.T) {
 	}})
 }
 
+func TestHandleApproveDeepReorg(t *testing.T) {
+	t.Parallel()
+
+	chainWithErr := func(err error) *testRPCChain {
+		chain := defaultMockRPCChain()
+		chain.approveDeepReorgErr = err
+		return chain
+	}
+
+	validApproveDeepReorgCmd := &types.ApproveDeepReorgCmd{
+		BlockHash: block432100.BlockHash().String(),
+	}
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleApproveDeepReorg: ok",
+		handler: handleApproveDeepReorg,
+		cmd:     validApproveDeepReorgCmd,
+	}, {
+		name:    "handleApproveDeepReorg: bad hash",
+		handler: handleApproveDeepReorg,
+		cmd: &types.ApproveDeepReorgCmd{
+			BlockHash: "bad hash",
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:      "handleApproveDeepReorg: block not found",
+		handler:   handleApproveDeepReorg,
+		cmd:       validApproveDeepReorgCmd,
+		mockChain: chainWithErr(blockchain.ErrUnknownBlock),
+		wantErr:   true,
+		errCode:   dcrjson.ErrRPCBlockNotFound,
+	}, {
+		name:      "handleApproveDeepReorg: block not on reorg branch",
+		handler:   handleApproveDeepReorg,
+		cmd:       validApproveDeepReorgCmd,
+		mockChain: chainWithErr(blockchain.ErrDeepReorgNotApproved),
+		wantErr:   true,
+		errCode:   dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:      "handleApproveDeepReorg: other error",
+		handler:   handleApproveDeepReorg,
+		cmd:       validApproveDeepReorgCmd,
+		mockChain: chainWithErr(errors.New("")),
+		wantErr:   true,
+		errCode:   dcrjson.ErrRPCInternal.Code,
+	}})
+}
+
+func TestHandleCheckBlockConflicts(t *testing.T) {
+	t.Parallel()
+
+	blkBytes, err := block432100.Bytes()
+	if err != nil {
+		t.Fatalf("error serializing block: %+v", err)
+	}
+	blkHexString := hex.EncodeToString(blkBytes)
+	mined := block432100.Transactions[1].TxHash()
+	doubleSpent := block432100.STransactions[0].TxHash()
+	mp := defaultMockTxMempooler()
+	mp.blockConflicts = &blockchain.BlockConflicts{
+		Mined:       []chainhash.Hash{mined},
+		DoubleSpent: []chainhash.Hash{doubleSpent},
+		Evicted:     []chainhash.Hash{doubleSpent},
+	}
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleCheckBlockConflicts: ok",
+		handler: handleCheckBlockConflicts,
+		cmd: &types.CheckBlockConflictsCmd{
+			HexBlock: blkHexString,
+		},
+		mockTxMempooler: mp,
+		result: &types.CheckBlockConflictsResult{
+			Mined:       []string{mined.String()},
+			DoubleSpent: []string{doubleSpent.String()},
+			Evicted:     []string{doubleSpent.String()},
+		},
+	}, {
+		name:    "handleCheckBlockConflicts: invalid hex",
+		handler: handleCheckBlockConflicts,
+		cmd: &types.CheckBlockConflictsCmd{
+			HexBlock: "invalid",
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:    "handleCheckBlockConflicts: block decode error",
+		handler: handleCheckBlockConflicts,
+		cmd: &types.CheckBlockConflictsCmd{
+			HexBlock: "ffffffff",
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDeserialization,
+	}})
+}
+
 func TestHandleCreateRawSStx(t *testing.T) {
 	t.Parallel()
 
@@ -3026,6 +3483,110 @@ func TestHandleEstimateStakeDiff(t *testing.T) {
 	}})
 }
 
+func TestHandleForcePrune(t *testing.T) {
+	t.Parallel()
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleForcePrune: ok",
+		handler: handleForcePrune,
+		cmd:     &types.ForcePruneCmd{},
+		result:  nil,
+	}})
+}
+
+func TestHandleForecastStakeDiff(t *testing.T) {
+	t.Parallel()
+
+	forecast := &blockchain.StakeDiffForecast{
+		TicketsPerInterval: 2880,
+		Projections: []blockchain.StakeDiffProjection{{
+			Height:    432144,
+			StakeDiff: 14428162590,
+			PoolSize:  41135,
+		}, {
+			Height:    432288,
+			StakeDiff: 14689310201,
+			PoolSize:  41295,
+		}},
+	}
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleForecastStakeDiff: ok",
+		handler: handleForecastStakeDiff,
+		cmd: &types.ForecastStakeDiffCmd{
+			Intervals:          dcrjson.Int64(2),
+			TicketsPerInterval: dcrjson.Int64(-1),
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.forecastStakeDifficulty = forecast
+			chain.ticketPoolValue = 570678603367920
+			return chain
+		}(),
+		result: types.ForecastStakeDiffResult{
+			Height:             432100,
+			PoolValue:          5706786.0336792,
+			NextStakeDiff:      144.2816259,
+			TicketsPerInterval: 2880,
+			Projections: []types.StakeDiffProjection{{
+				Height:    432144,
+				StakeDiff: 144.2816259,
+				PoolSize:  41135,
+			}, {
+				Height:    432288,
+				StakeDiff: 146.89310201,
+				PoolSize:  41295,
+			}},
+		},
+	}, {
+		name:    "handleForecastStakeDiff: invalid number of intervals",
+		handler: handleForecastStakeDiff,
+		cmd: &types.ForecastStakeDiffCmd{
+			Intervals:          dcrjson.Int64(maxStakeDiffForecastIntervals + 1),
+			TicketsPerInterval: dcrjson.Int64(-1),
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleForecastStakeDiff: too many tickets per interval",
+		handler: handleForecastStakeDiff,
+		cmd: &types.ForecastStakeDiffCmd{
+			Intervals:          dcrjson.Int64(2),
+			TicketsPerInterval: dcrjson.Int64(2881),
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleForecastStakeDiff: unable to forecast stake difficulty",
+		handler: handleForecastStakeDiff,
+		cmd: &types.ForecastStakeDiffCmd{
+			Intervals:          dcrjson.Int64(2),
+			TicketsPerInterval: dcrjson.Int64(-1),
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.forecastStakeDifficultyErr = errors.New("unable to forecast")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleForecastStakeDiff: unable to obtain ticket pool value",
+		handler: handleForecastStakeDiff,
+		cmd: &types.ForecastStakeDiffCmd{
+			Intervals:          dcrjson.Int64(2),
+			TicketsPerInterval: dcrjson.Int64(-1),
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.forecastStakeDifficulty = forecast
+			chain.ticketPoolValueErr = errors.New("unable to obtain value")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}})
+}
+
 func TestHandleExistsAddress(t *testing.T) {
 	t.Parallel()
 
@@ -3466,14 +4027,85 @@ func TestHandleGenerate(t *testing.T) {
 	}})
 }
 
-func TestHandleGetAddedNodeInfo(t *testing.T) {
+func TestHandleGenerateToAddress(t *testing.T) {
 	t.Parallel()
 
+	hashStrOne := "00000000000000001e6ec1501c858506de1de4703d1be8bab4061126e8f61480"
+	hashStrTwo := "00000000000000001a1ec2becd0dd90bfbd0c65f42fdaf608dd9ceac2a3aee1d"
+	generatedBlocks := []*chainhash.Hash{mustParseHash(hashStrOne), mustParseHash(hashStrTwo)}
+	res := []string{hashStrOne, hashStrTwo}
+	payToAddr := "DcurAwesomeAddressmqDctW5wJCW1Cn2MF"
+	chainParams := cloneParams(defaultChainParams)
+	chainParams.GenerateSupported = true
+	cpu := defaultMockCPUMiner()
+	cpu.generatedBlocks = generatedBlocks
 	testRPCServerHandler(t, []rpcTest{{
-		name:    "handleGetAddedNodeInfo: ok without DNS and without address filter",
-		handler: handleGetAddedNodeInfo,
-		cmd: &types.GetAddedNodeInfoCmd{
-			DNS: false,
+		name:    "handleGenerateToAddress: ok",
+		handler: handleGenerateToAddress,
+		cmd: &types.GenerateToAddressCmd{
+			NumBlocks: 2,
+			Address:   payToAddr,
+		},
+		mockChainParams: chainParams,
+		mockCPUMiner:    cpu,
+		result:          res,
+	}, {
+		name:    "handleGenerateToAddress: invalid address",
+		handler: handleGenerateToAddress,
+		cmd: &types.GenerateToAddressCmd{
+			NumBlocks: 2,
+			Address:   "invalid",
+		},
+		mockChainParams: chainParams,
+		mockCPUMiner:    cpu,
+		wantErr:         true,
+		errCode:         dcrjson.ErrRPCInvalidAddressOrKey,
+	}, {
+		name:    "handleGenerateToAddress: generate not supported for network",
+		handler: handleGenerateToAddress,
+		cmd: &types.GenerateToAddressCmd{
+			NumBlocks: 2,
+			Address:   payToAddr,
+		},
+		mockCPUMiner: cpu,
+		wantErr:      true,
+		errCode:      dcrjson.ErrRPCDifficulty,
+	}, {
+		name:    "handleGenerateToAddress: generate 0 blocks",
+		handler: handleGenerateToAddress,
+		cmd: &types.GenerateToAddressCmd{
+			Address: payToAddr,
+		},
+		mockChainParams: chainParams,
+		mockCPUMiner:    cpu,
+		wantErr:         true,
+		errCode:         dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleGenerateToAddress: generate n blocks error",
+		handler: handleGenerateToAddress,
+		cmd: &types.GenerateToAddressCmd{
+			NumBlocks: 2,
+			Address:   payToAddr,
+		},
+		mockChainParams: chainParams,
+		mockCPUMiner: func() *testCPUMiner {
+			cpu := defaultMockCPUMiner()
+			cpu.generateNBlocksErr = errors.New("")
+			return cpu
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}})
+}
+
+func TestHandleGetAddedNodeInfo(t *testing.T) {
+	t.Parallel()
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleGetAddedNodeInfo: ok without DNS and without address filter",
+		handler: handleGetAddedNodeInfo,
+		cmd: &types.GetAddedNodeInfoCmd{
+			DNS: false,
 		},
 		result: []string{"127.0.0.210:9108", "127.0.0.211:9108",
 			"mydomain.org:9108", "nonexistentdomain.org:9108"},
@@ -3597,6 +4229,7 @@ func TestHandleGetBlockchainInfo(t *testing.T) {
 			chain.isCurrent = false
 			chain.maxBlockSize = 393216
 			chain.stateLastChangedHeight = int64(149248)
+			chain.verificationProgress = 0.9999
 			return chain
 		}(),
 		result: types.GetBlockChainInfoResult{
@@ -3606,7 +4239,7 @@ func TestHandleGetBlockchainInfo(t *testing.T) {
 			SyncHeight:           int64(463074),
 			ChainWork:            "000000000000000000000000000000000000000000115d2833849090b0026506",
 			InitialBlockDownload: true,
-			VerificationProgress: float64(1),
+			VerificationProgress: float64(0.9999),
 			BestBlockHash:        "00000000000000001e6ec1501c858506de1de4703d1be8bab4061126e8f61480",
 			Difficulty:           uint32(404696953),
 			DifficultyRatio:      float64(35256672611.3862),
@@ -4146,6 +4779,151 @@ func TestHandleGetBlockHeader(t *testing.T) {
 	}})
 }
 
+func TestHandleGetBlockHeaders(t *testing.T) {
+	t.Parallel()
+
+	// Define a couple of connected headers based on block432100 to be used
+	// throughout the handleGetBlockHeaders tests.
+	header1 := block432100.Header
+	hash1 := header1.BlockHash()
+	header2 := header1
+	header2.PrevBlock = hash1
+	header2.Height++
+	hash2 := header2.BlockHash()
+	headers := []wire.BlockHeader{header1, header2}
+	hexHeaders := make([]string, 0, len(headers))
+	for i := range headers {
+		headerBytes, err := headers[i].Bytes()
+		if err != nil {
+			t.Fatalf("error serializing block header: %+v", err)
+		}
+		hexHeaders = append(hexHeaders, hex.EncodeToString(headerBytes))
+	}
+	bestHeight := int64(header2.Height) + 10
+	nextHash := mustParseHash("000000000000000002e63055e402c823cb86c8258806508d84d6dc2a0790bd49")
+	chainWork, _ := new(big.Int).SetString("0e805fb85284503581c57c", 16)
+	verboseResult := func(header *wire.BlockHeader, hash *chainhash.Hash, next string) types.GetBlockHeaderVerboseResult {
+		return types.GetBlockHeaderVerboseResult{
+			Hash:          hash.String(),
+			Confirmations: bestHeight - int64(header.Height) + 1,
+			Version:       header.Version,
+			MerkleRoot:    header.MerkleRoot.String(),
+			StakeRoot:     header.StakeRoot.String(),
+			VoteBits:      header.VoteBits,
+			FinalState:    hex.EncodeToString(header.FinalState[:]),
+			Voters:        header.Voters,
+			FreshStake:    header.FreshStake,
+			Revocations:   header.Revocations,
+			PoolSize:      header.PoolSize,
+			Bits:          strconv.FormatInt(int64(header.Bits), 16),
+			SBits:         dcrutil.Amount(header.SBits).ToCoin(),
+			Height:        header.Height,
+			Size:          header.Size,
+			Time:          header.Timestamp.Unix(),
+			MedianTime:    time.Time{}.Unix(),
+			Nonce:         header.Nonce,
+			ExtraData:     hex.EncodeToString(header.ExtraData[:]),
+			StakeVersion:  header.StakeVersion,
+			Difficulty:    float64(28147398026.656624),
+			ChainWork:     fmt.Sprintf("%064x", chainWork),
+			PreviousHash:  header.PrevBlock.String(),
+			NextHash:      next,
+		}
+	}
+	chainWithHeaders := func() *testRPCChain {
+		chain := defaultMockRPCChain()
+		chain.bestSnapshot = &blockchain.BestState{
+			Height: bestHeight,
+		}
+		chain.blockHashByHeight = nextHash
+		chain.filterHeaders = headers
+		return chain
+	}
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleGetBlockHeaders: ok",
+		handler: handleGetBlockHeaders,
+		cmd: &types.GetBlockHeadersCmd{
+			StartHeight: int64(header1.Height),
+		},
+		mockChain: chainWithHeaders(),
+		result: &types.GetHeadersResult{
+			Headers: hexHeaders,
+		},
+	}, {
+		name:    "handleGetBlockHeaders: ok verbose",
+		handler: handleGetBlockHeaders,
+		cmd: &types.GetBlockHeadersCmd{
+			StartHeight: int64(header1.Height),
+			Count:       dcrjson.Uint32(2),
+			Verbose:     dcrjson.Bool(true),
+		},
+		mockChain: chainWithHeaders(),
+		result: []types.GetBlockHeaderVerboseResult{
+			verboseResult(&header1, &hash1, hash2.String()),
+			verboseResult(&header2, &hash2, nextHash.String()),
+		},
+	}, {
+		name:    "handleGetBlockHeaders: count zero",
+		handler: handleGetBlockHeaders,
+		cmd: &types.GetBlockHeadersCmd{
+			StartHeight: int64(header1.Height),
+			Count:       dcrjson.Uint32(0),
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleGetBlockHeaders: count too high",
+		handler: handleGetBlockHeaders,
+		cmd: &types.GetBlockHeadersCmd{
+			StartHeight: int64(header1.Height),
+			Count:       dcrjson.Uint32(wire.MaxBlockHeadersPerMsg + 1),
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleGetBlockHeaders: start height out of range",
+		handler: handleGetBlockHeaders,
+		cmd: &types.GetBlockHeadersCmd{
+			StartHeight: bestHeight + 1,
+		},
+		mockChain: func() *testRPCChain {
+			chain := chainWithHeaders()
+			chain.blockHashByHeightErr = errors.New("out of range")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCOutOfRange,
+	}, {
+		name:    "handleGetBlockHeaders: failed to load headers",
+		handler: handleGetBlockHeaders,
+		cmd: &types.GetBlockHeadersCmd{
+			StartHeight: int64(header1.Height),
+		},
+		mockChain: func() *testRPCChain {
+			chain := chainWithHeaders()
+			chain.filterHeadersErr = errors.New("not in main chain")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleGetBlockHeaders: could not fetch median time",
+		handler: handleGetBlockHeaders,
+		cmd: &types.GetBlockHeadersCmd{
+			StartHeight: int64(header1.Height),
+			Verbose:     dcrjson.Bool(true),
+		},
+		mockChain: func() *testRPCChain {
+			chain := chainWithHeaders()
+			chain.medianTimeByHashErr = errors.New("could not fetch median time")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}})
+}
+
 func TestHandleGetBlockSubsidy(t *testing.T) {
 	t.Parallel()
 
@@ -4275,6 +5053,53 @@ func TestHandleGetCoinSupply(t *testing.T) {
 		handler: handleGetCoinSupply,
 		cmd:     &types.GetCoinSupplyCmd{},
 		result:  int64(1122503888072909),
+	}, {
+		name:    "handleGetCoinSupply: verbose, subsidy split not active",
+		handler: handleGetCoinSupply,
+		cmd:     &types.GetCoinSupplyCmd{Verbose: dcrjson.Bool(true)},
+		result: &types.GetCoinSupplyVerboseResult{
+			Height:             432100,
+			Supply:             1122503888072909,
+			ScheduledSupply:    defaultChainParams.TotalSupply(432100, -1),
+			SubsidySplitHeight: -1,
+		},
+	}, {
+		name:    "handleGetCoinSupply: verbose, subsidy split active",
+		handler: handleGetCoinSupply,
+		cmd:     &types.GetCoinSupplyCmd{Verbose: dcrjson.Bool(true)},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.subsidySplitActive = true
+			return chain
+		}(),
+		result: &types.GetCoinSupplyVerboseResult{
+			Height:             432100,
+			Supply:             1122503888072909,
+			ScheduledSupply:    defaultChainParams.TotalSupply(432100, 1),
+			SubsidySplitHeight: 1,
+		},
+	}, {
+		name:    "handleGetCoinSupply: verbose, failed to retrieve hash",
+		handler: handleGetCoinSupply,
+		cmd:     &types.GetCoinSupplyCmd{Verbose: dcrjson.Bool(true)},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.blockHashByHeightErr = errors.New("block number out of range")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleGetCoinSupply: verbose, failed to get agenda status",
+		handler: handleGetCoinSupply,
+		cmd:     &types.GetCoinSupplyCmd{Verbose: dcrjson.Bool(true)},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.subsidySplitActiveErr = errors.New("unknown block")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
 	}})
 }
 
@@ -4336,6 +5161,322 @@ func TestHandleGetHashesPerSec(t *testing.T) {
 	}})
 }
 
+func TestHandleGetBlockHeaderProof(t *testing.T) {
+	t.Parallel()
+
+	blk := &block432100
+	blkHashString := blk.BlockHash().String()
+	blkHeight := int64(blk.Header.Height)
+	headerBytes, err := blk.Header.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := hex.EncodeToString(headerBytes)
+	anchorHash := "349b3e23b64cb4b71d09b9be4652c9e02e73430daee1285ea03d92aa437dcf37"
+
+	// calcProof returns the expected proof index and hashes for the provided
+	// transaction in the test block.  The proof is extended with the root of
+	// the other transaction tree when the merkle roots are combined and it is
+	// also ensured to commit to the merkle root in the header in that case.
+	calcProof := func(txns, otherTxns []*wire.MsgTx, txIdx uint32, tree int8,
+		combined bool) (uint32, []string) {
+
+		leaves := make([]chainhash.Hash, 0, len(txns))
+		for _, tx := range txns {
+			leaves = append(leaves, tx.TxHashFull())
+		}
+		proof := standalone.GenerateInclusionProof(leaves, txIdx)
+		if !combined {
+			return txIdx, proofHashStrs(proof)
+		}
+		proofIdx := txIdx | uint32(tree)<<uint32(len(proof))
+		proof = append(proof, standalone.CalcTxTreeMerkleRoot(otherTxns))
+		if !standalone.VerifyInclusionProof(&blk.Header.MerkleRoot,
+			&leaves[txIdx], proofIdx, proof) {
+
+			t.Fatalf("proof for tx %d in tree %d does not verify", txIdx, tree)
+		}
+		return proofIdx, proofHashStrs(proof)
+	}
+	regularTx := blk.Transactions[1]
+	regularIdx, regularProof := calcProof(blk.Transactions, blk.STransactions,
+		1, wire.TxTreeRegular, true)
+	stakeTx := blk.STransactions[2]
+	stakeIdx, stakeProof := calcProof(blk.STransactions, blk.Transactions, 2,
+		wire.TxTreeStake, true)
+	legacyStakeIdx, legacyStakeProof := calcProof(blk.STransactions, nil, 2,
+		wire.TxTreeStake, false)
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleGetBlockHeaderProof: ok regular tree",
+		handler: handleGetBlockHeaderProof,
+		cmd: &types.GetBlockHeaderProofCmd{
+			TxHash:     regularTx.TxHash().String(),
+			BlockHash:  blkHashString,
+			AnchorHash: dcrjson.String(anchorHash),
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.blockHeightByHash = blkHeight - 2
+			return chain
+		}(),
+		result: &types.GetBlockHeaderProofResult{
+			TxHash:       regularTx.TxHash().String(),
+			LeafHash:     regularTx.TxHashFull().String(),
+			Tree:         wire.TxTreeRegular,
+			BlockHash:    blkHashString,
+			Height:       blkHeight,
+			Root:         blk.Header.MerkleRoot.String(),
+			ProofIndex:   regularIdx,
+			ProofHashes:  regularProof,
+			AnchorHash:   anchorHash,
+			AnchorHeight: blkHeight - 2,
+			Headers:      []string{header, header},
+		},
+	}, {
+		name:    "handleGetBlockHeaderProof: ok stake tree",
+		handler: handleGetBlockHeaderProof,
+		cmd: &types.GetBlockHeaderProofCmd{
+			TxHash:     stakeTx.TxHash().String(),
+			BlockHash:  blkHashString,
+			AnchorHash: dcrjson.String(anchorHash),
+		},
+		result: &types.GetBlockHeaderProofResult{
+			TxHash:       stakeTx.TxHash().String(),
+			LeafHash:     stakeTx.TxHashFull().String(),
+			Tree:         wire.TxTreeStake,
+			BlockHash:    blkHashString,
+			Height:       blkHeight,
+			Root:         blk.Header.MerkleRoot.String(),
+			ProofIndex:   stakeIdx,
+			ProofHashes:  stakeProof,
+			AnchorHash:   anchorHash,
+			AnchorHeight: blkHeight,
+			Headers:      []string{},
+		},
+	}, {
+		name:    "handleGetBlockHeaderProof: ok stake tree prior to header commitments",
+		handler: handleGetBlockHeaderProof,
+		cmd: &types.GetBlockHeaderProofCmd{
+			TxHash:     stakeTx.TxHash().String(),
+			BlockHash:  blkHashString,
+			AnchorHash: dcrjson.String(anchorHash),
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.headerCommitmentsActive = false
+			return chain
+		}(),
+		result: &types.GetBlockHeaderProofResult{
+			TxHash:       stakeTx.TxHash().String(),
+			LeafHash:     stakeTx.TxHashFull().String(),
+			Tree:         wire.TxTreeStake,
+			BlockHash:    blkHashString,
+			Height:       blkHeight,
+			Root:         blk.Header.StakeRoot.String(),
+			ProofIndex:   legacyStakeIdx,
+			ProofHashes:  legacyStakeProof,
+			AnchorHash:   anchorHash,
+			AnchorHeight: blkHeight,
+			Headers:      []string{},
+		},
+	}, {
+		name:    "handleGetBlockHeaderProof: invalid tx hash",
+		handler: handleGetBlockHeaderProof,
+		cmd: &types.GetBlockHeaderProofCmd{
+			TxHash:    "invalid",
+			BlockHash: blkHashString,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:    "handleGetBlockHeaderProof: invalid block hash",
+		handler: handleGetBlockHeaderProof,
+		cmd: &types.GetBlockHeaderProofCmd{
+			TxHash:    regularTx.TxHash().String(),
+			BlockHash: "invalid",
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:    "handleGetBlockHeaderProof: block not in main chain",
+		handler: handleGetBlockHeaderProof,
+		cmd: &types.GetBlockHeaderProofCmd{
+			TxHash:    regularTx.TxHash().String(),
+			BlockHash: blkHashString,
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.mainChainHasBlock = false
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCBlockNotFound,
+	}, {
+		name:    "handleGetBlockHeaderProof: tx not in block",
+		handler: handleGetBlockHeaderProof,
+		cmd: &types.GetBlockHeaderProofCmd{
+			TxHash:    chainhash.HashH([]byte("missing")).String(),
+			BlockHash: blkHashString,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCNoTxInfo,
+	}, {
+		name:    "handleGetBlockHeaderProof: agenda status error",
+		handler: handleGetBlockHeaderProof,
+		cmd: &types.GetBlockHeaderProofCmd{
+			TxHash:    regularTx.TxHash().String(),
+			BlockHash: blkHashString,
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.headerCommitmentsActiveErr = errors.New("agenda status")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleGetBlockHeaderProof: anchor not an ancestor",
+		handler: handleGetBlockHeaderProof,
+		cmd: &types.GetBlockHeaderProofCmd{
+			TxHash:     regularTx.TxHash().String(),
+			BlockHash:  blkHashString,
+			AnchorHash: dcrjson.String(anchorHash),
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.blockHeightByHash = blkHeight + 1
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleGetBlockHeaderProof: too many headers",
+		handler: handleGetBlockHeaderProof,
+		cmd: &types.GetBlockHeaderProofCmd{
+			TxHash:     regularTx.TxHash().String(),
+			BlockHash:  blkHashString,
+			AnchorHash: dcrjson.String(anchorHash),
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.blockHeightByHash = blkHeight - wire.MaxBlockHeadersPerMsg - 1
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}})
+}
+
+func TestHandleGetHeaderCommitments(t *testing.T) {
+	t.Parallel()
+
+	blkHashString := block432100.BlockHash().String()
+	cmtRoot := block432100.Header.StakeRoot
+	leafA := chainhash.HashH([]byte("a"))
+	leafB := chainhash.HashH([]byte("b"))
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleGetHeaderCommitments: ok",
+		handler: handleGetHeaderCommitments,
+		cmd: &types.GetHeaderCommitmentsCmd{
+			BlockHash: blkHashString,
+		},
+		result: &types.GetHeaderCommitmentsResult{
+			BlockHash:      blkHashString,
+			Version:        blockchain.HeaderCmtVersion1,
+			CommitmentRoot: cmtRoot.String(),
+			Commitments: []types.HeaderCommitmentResult{{
+				Name:        blockchain.HeaderCmtFilterName,
+				Hash:        cmtRoot.String(),
+				ProofIndex:  blockchain.HeaderCmtFilterIndex,
+				ProofHashes: nil,
+			}},
+		},
+	}, {
+		name:    "handleGetHeaderCommitments: ok with multiple commitments",
+		handler: handleGetHeaderCommitments,
+		cmd: &types.GetHeaderCommitmentsCmd{
+			BlockHash: blkHashString,
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.headerCommitments = &blockchain.HeaderCommitments{
+				Version: 2,
+				Root:    standalone.CalcMerkleRoot([]chainhash.Hash{leafA, leafB}),
+				Commitments: []blockchain.HeaderCommitment{
+					{Name: "a", Hash: leafA},
+					{Name: "b", Hash: leafB},
+				},
+			}
+			return chain
+		}(),
+		result: &types.GetHeaderCommitmentsResult{
+			BlockHash: blkHashString,
+			Version:   2,
+			CommitmentRoot: standalone.CalcMerkleRoot([]chainhash.Hash{
+				leafA, leafB}).String(),
+			Commitments: []types.HeaderCommitmentResult{{
+				Name:        "a",
+				Hash:        leafA.String(),
+				ProofIndex:  0,
+				ProofHashes: []string{leafB.String()},
+			}, {
+				Name:        "b",
+				Hash:        leafB.String(),
+				ProofIndex:  1,
+				ProofHashes: []string{leafA.String()},
+			}},
+		},
+	}, {
+		name:    "handleGetHeaderCommitments: invalid hash",
+		handler: handleGetHeaderCommitments,
+		cmd: &types.GetHeaderCommitmentsCmd{
+			BlockHash: "invalid",
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:    "handleGetHeaderCommitments: block not found",
+		handler: handleGetHeaderCommitments,
+		cmd: &types.GetHeaderCommitmentsCmd{
+			BlockHash: blkHashString,
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.headerCommitmentsErr = blockchain.ErrUnknownBlock
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCBlockNotFound,
+	}, {
+		name:    "handleGetHeaderCommitments: no header commitments",
+		handler: handleGetHeaderCommitments,
+		cmd: &types.GetHeaderCommitmentsCmd{
+			BlockHash: blkHashString,
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.headerCommitmentsErr = blockchain.ErrNoHeaderCommitment
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCMisc,
+	}, {
+		name:    "handleGetHeaderCommitments: failed to load commitments",
+		handler: handleGetHeaderCommitments,
+		cmd: &types.GetHeaderCommitmentsCmd{
+			BlockHash: blkHashString,
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.headerCommitmentsErr = errors.New("failed to load")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}})
+}
+
 func TestHandleGetHeaders(t *testing.T) {
 	t.Parallel()
 
@@ -4414,6 +5555,51 @@ func TestHandleGetInfo(t *testing.T) {
 	}})
 }
 
+func TestHandleGetNodeInfo(t *testing.T) {
+	// This test is intentionally not run in parallel since other tests modify
+	// the global version information.
+
+	apiVer, dcrdVer := versionResults()
+	result := &types.GetNodeInfoResult{
+		Version:       dcrdVer,
+		RPCAPIVersion: apiVer,
+		Commit:        version.BuildMetadata,
+		GoVersion:     runtime.Version(),
+		UserAgent: fmt.Sprintf("%d.%d.%d", version.Major, version.Minor,
+			version.Patch),
+		ProtocolVersion: wire.CFilterV2Version,
+		Network:         "mainnet",
+		StartTime:       0,
+		Uptime:          90,
+		Indexes:         []string{"txindex", "existsaddrindex"},
+		Features:        []string{"cfilters"},
+		Pruned:          false,
+		Policy: types.NodePolicyResult{
+			RelayFee:          0.0001,
+			MaxStandardTxSize: mempool.MaxStandardTxSize,
+		},
+	}
+
+	withNullData := *result
+	withNullData.Indexes = []string{"nulldataindex"}
+	testRPCServerHandler(t, []rpcTest{{
+		name:      "handleGetNodeInfo: ok",
+		handler:   handleGetNodeInfo,
+		cmd:       &types.GetNodeInfoCmd{},
+		mockClock: &testClock{since: 90 * time.Second},
+		result:    result,
+	}, {
+		name:                  "handleGetNodeInfo: ok with only null data index",
+		handler:               handleGetNodeInfo,
+		cmd:                   &types.GetNodeInfoCmd{},
+		mockClock:             &testClock{since: 90 * time.Second},
+		setTxIndexerNil:       true,
+		setExistsAddresserNil: true,
+		mockNullDataIndexer:   &testNullDataIndexer{},
+		result:                &withNullData,
+	}})
+}
+
 func TestHandleGetMempoolInfo(t *testing.T) {
 	t.Parallel()
 
@@ -4450,6 +5636,82 @@ func TestHandleGetMempoolInfo(t *testing.T) {
 	}})
 }
 
+func TestHandleGetMempoolJournal(t *testing.T) {
+	t.Parallel()
+
+	tx1 := block432100.Transactions[1].TxHash()
+	tx2 := block432100.STransactions[0].TxHash()
+	events := []mempool.Event{{
+		Time: time.Unix(1583426560, 0),
+		Kind: mempool.EventAccepted,
+		Hash: tx1,
+	}, {
+		Time:   time.Unix(1583426561, 0),
+		Kind:   mempool.EventRejected,
+		Hash:   tx2,
+		Reason: "transaction already exists",
+	}, {
+		Time: time.Unix(1583426562, 0),
+		Kind: mempool.EventMined,
+		Hash: tx1,
+	}}
+	mempoolerWithEvents := func() *testTxMempooler {
+		mp := defaultMockTxMempooler()
+		mp.events = events
+		return mp
+	}
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleGetMempoolJournal: journal disabled",
+		handler: handleGetMempoolJournal,
+		cmd:     &types.GetMempoolJournalCmd{},
+		result:  []types.MempoolEventResult{},
+	}, {
+		name:    "handleGetMempoolJournal: invalid txid",
+		handler: handleGetMempoolJournal,
+		cmd: &types.GetMempoolJournalCmd{
+			TxID: dcrjson.String("invalid"),
+		},
+		mockTxMempooler: mempoolerWithEvents(),
+		wantErr:         true,
+		errCode:         dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:            "handleGetMempoolJournal: ok",
+		handler:         handleGetMempoolJournal,
+		cmd:             &types.GetMempoolJournalCmd{},
+		mockTxMempooler: mempoolerWithEvents(),
+		result: []types.MempoolEventResult{{
+			Time:  1583426560,
+			Event: "accepted",
+			TxID:  tx1.String(),
+		}, {
+			Time:   1583426561,
+			Event:  "rejected",
+			TxID:   tx2.String(),
+			Reason: "transaction already exists",
+		}, {
+			Time:  1583426562,
+			Event: "mined",
+			TxID:  tx1.String(),
+		}},
+	}, {
+		name:    "handleGetMempoolJournal: ok with txid",
+		handler: handleGetMempoolJournal,
+		cmd: &types.GetMempoolJournalCmd{
+			TxID: dcrjson.String(tx1.String()),
+		},
+		mockTxMempooler: mempoolerWithEvents(),
+		result: []types.MempoolEventResult{{
+			Time:  1583426560,
+			Event: "accepted",
+			TxID:  tx1.String(),
+		}, {
+			Time:  1583426562,
+			Event: "mined",
+			TxID:  tx1.String(),
+		}},
+	}})
+}
+
 func TestHandleGetMiningInfo(t *testing.T) {
 	t.Parallel()
 
@@ -4619,7 +5881,180 @@ func TestHandleGetNetworkInfo(t *testing.T) {
 				Score:   int32(0),
 			}},
 			LocalServices: "0000000000000005",
+			AgentRejected: 3,
+			AgentEvicted:  1,
+		},
+	}})
+}
+
+func TestHandleGetNullData(t *testing.T) {
+	t.Parallel()
+
+	blkHash := block432100.BlockHash()
+	blkHeight := int64(block432100.Header.Height)
+	txHash := block432100.Transactions[0].TxHash()
+	entries := []indexers.NullDataEntry{{
+		Height:      blkHeight,
+		TxHash:      txHash,
+		Tree:        wire.TxTreeRegular,
+		OutputIndex: 1,
+		Payload:     []byte("DCRAhello"),
+	}, {
+		Height:      blkHeight,
+		TxHash:      txHash,
+		Tree:        wire.TxTreeRegular,
+		OutputIndex: 2,
+		Payload:     []byte("DCRAworld"),
+	}}
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleGetNullData: ok",
+		handler: handleGetNullData,
+		cmd: &types.GetNullDataCmd{
+			Prefix:      "44435241",
+			StartHeight: dcrjson.Int64(0),
+			EndHeight:   dcrjson.Int64(-1),
+		},
+		mockNullDataIndexer: func() *testNullDataIndexer {
+			idx := defaultMockNullDataIndexer()
+			idx.entries = entries
+			return idx
+		}(),
+		result: []types.GetNullDataResult{{
+			BlockHash: blkHash.String(),
+			Height:    blkHeight,
+			TxHash:    txHash.String(),
+			Tree:      wire.TxTreeRegular,
+			Vout:      1,
+			Payload:   hex.EncodeToString([]byte("DCRAhello")),
+		}, {
+			BlockHash: blkHash.String(),
+			Height:    blkHeight,
+			TxHash:    txHash.String(),
+			Tree:      wire.TxTreeRegular,
+			Vout:      2,
+			Payload:   hex.EncodeToString([]byte("DCRAworld")),
+		}},
+	}, {
+		name:    "handleGetNullData: ok, no entries",
+		handler: handleGetNullData,
+		cmd: &types.GetNullDataCmd{
+			Prefix:      "44435241",
+			StartHeight: dcrjson.Int64(0),
+			EndHeight:   dcrjson.Int64(-1),
+		},
+		mockNullDataIndexer: defaultMockNullDataIndexer(),
+		result:              []types.GetNullDataResult{},
+	}, {
+		name:    "handleGetNullData: ok, wait for sync",
+		handler: handleGetNullData,
+		cmd: &types.GetNullDataCmd{
+			Prefix:      "44435241",
+			StartHeight: dcrjson.Int64(0),
+			EndHeight:   dcrjson.Int64(-1),
+		},
+		mockNullDataIndexer: func() *testNullDataIndexer {
+			idx := defaultMockNullDataIndexer()
+			idx.tipHash = &zeroHash
+			return idx
+		}(),
+		result: []types.GetNullDataResult{},
+	}, {
+		name:    "handleGetNullData: null data index not enabled",
+		handler: handleGetNullData,
+		cmd: &types.GetNullDataCmd{
+			Prefix:      "44435241",
+			StartHeight: dcrjson.Int64(0),
+			EndHeight:   dcrjson.Int64(-1),
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleGetNullData: invalid prefix hex",
+		handler: handleGetNullData,
+		cmd: &types.GetNullDataCmd{
+			Prefix:      "zz",
+			StartHeight: dcrjson.Int64(0),
+			EndHeight:   dcrjson.Int64(-1),
+		},
+		mockNullDataIndexer: defaultMockNullDataIndexer(),
+		wantErr:             true,
+		errCode:             dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:    "handleGetNullData: unable to fetch index tip",
+		handler: handleGetNullData,
+		cmd: &types.GetNullDataCmd{
+			Prefix:      "44435241",
+			StartHeight: dcrjson.Int64(0),
+			EndHeight:   dcrjson.Int64(-1),
+		},
+		mockNullDataIndexer: func() *testNullDataIndexer {
+			idx := defaultMockNullDataIndexer()
+			idx.tipErr = errors.New("unable to fetch index tip")
+			return idx
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleGetNullData: index is not synced",
+		handler: handleGetNullData,
+		cmd: &types.GetNullDataCmd{
+			Prefix:      "44435241",
+			StartHeight: dcrjson.Int64(0),
+			EndHeight:   dcrjson.Int64(-1),
+		},
+		mockNullDataIndexer: func() *testNullDataIndexer {
+			idx := defaultMockNullDataIndexer()
+			idx.tipHeight = blkHeight - 6
+			return idx
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleGetNullData: invalid height range",
+		handler: handleGetNullData,
+		cmd: &types.GetNullDataCmd{
+			Prefix:      "44435241",
+			StartHeight: dcrjson.Int64(blkHeight + 1),
+			EndHeight:   dcrjson.Int64(-1),
+		},
+		mockNullDataIndexer: defaultMockNullDataIndexer(),
+		wantErr:             true,
+		errCode:             dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleGetNullData: unindexed prefix",
+		handler: handleGetNullData,
+		cmd: &types.GetNullDataCmd{
+			Prefix:      "44435242",
+			StartHeight: dcrjson.Int64(0),
+			EndHeight:   dcrjson.Int64(-1),
+		},
+		mockNullDataIndexer: func() *testNullDataIndexer {
+			idx := defaultMockNullDataIndexer()
+			idx.entriesErr = errors.New("prefix is not indexed")
+			return idx
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleGetNullData: unable to fetch block hash",
+		handler: handleGetNullData,
+		cmd: &types.GetNullDataCmd{
+			Prefix:      "44435241",
+			StartHeight: dcrjson.Int64(0),
+			EndHeight:   dcrjson.Int64(-1),
 		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.blockHashByHeightErr = errors.New("no block at height")
+			return chain
+		}(),
+		mockNullDataIndexer: func() *testNullDataIndexer {
+			idx := defaultMockNullDataIndexer()
+			idx.entries = entries
+			return idx
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
 	}})
 }
 
@@ -4661,6 +6096,17 @@ func TestHandleGetPeerInfo(t *testing.T) {
 						LastPingNonce:  uint64(10),
 						LastPingTime:   time.Unix(1592918788, 0),
 						LastPingMicros: int64(0),
+						BytesSentPerMsg: map[string]uint64{
+							wire.CmdVersion: 134,
+							wire.CmdGetData: 3272,
+						},
+						BytesRecvPerMsg: map[string]uint64{
+							wire.CmdVersion: 134,
+							wire.CmdBlock:   2364,
+						},
+						PingP50Micros: int64(1500),
+						PingP90Micros: int64(2500),
+						PingP99Micros: int64(4000),
 					},
 				},
 			}
@@ -4670,19 +6116,30 @@ func TestHandleGetPeerInfo(t *testing.T) {
 			since: time.Duration(2000),
 		},
 		result: []*types.GetPeerInfoResult{{
-			ID:             int32(5),
-			Addr:           "106.14.238.184:19108",
-			AddrLocal:      "172.17.0.2:51060",
-			Services:       "00000005",
-			RelayTxes:      true,
-			LastSend:       int64(1592918788),
-			LastRecv:       int64(1592918788),
-			BytesSent:      uint64(3406),
-			BytesRecv:      uint64(2498),
+			ID:        int32(5),
+			Addr:      "106.14.238.184:19108",
+			AddrLocal: "172.17.0.2:51060",
+			Services:  "00000005",
+			RelayTxes: true,
+			LastSend:  int64(1592918788),
+			LastRecv:  int64(1592918788),
+			BytesSent: uint64(3406),
+			BytesRecv: uint64(2498),
+			BytesSentPerMsg: map[string]uint64{
+				wire.CmdVersion: 134,
+				wire.CmdGetData: 3272,
+			},
+			BytesRecvPerMsg: map[string]uint64{
+				wire.CmdVersion: 134,
+				wire.CmdBlock:   2364,
+			},
 			ConnTime:       int64(1592918784),
 			TimeOffset:     int64(-75),
 			PingTime:       float64(0),
 			PingWait:       float64(2),
+			PingP50:        float64(1500),
+			PingP90:        float64(2500),
+			PingP99:        float64(4000),
 			Version:        uint32(6),
 			SubVer:         "/dcrwire:0.3.0/dcrd:1.5.0(pre)/",
 			Inbound:        false,
@@ -4991,6 +6448,102 @@ func TestHandleGetTreasuryBalance(t *testing.T) {
 	}})
 }
 
+func TestHandleGetTxMiningWindow(t *testing.T) {
+	t.Parallel()
+
+	txB, err := block432100.Transactions[1].Bytes()
+	if err != nil {
+		t.Fatalf("unexpected tx serialization error: %v", err)
+	}
+	hexTx := hex.EncodeToString(txB)
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleGetTxMiningWindow: invalid tx hex",
+		handler: handleGetTxMiningWindow,
+		cmd: &types.GetTxMiningWindowCmd{
+			HexTx: "invalid",
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:    "handleGetTxMiningWindow: invalid tx",
+		handler: handleGetTxMiningWindow,
+		cmd: &types.GetTxMiningWindowCmd{
+			HexTx: "fefefefefefe",
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDeserialization,
+	}, {
+		name:    "handleGetTxMiningWindow: rule error",
+		handler: handleGetTxMiningWindow,
+		cmd: &types.GetTxMiningWindowCmd{
+			HexTx: hexTx,
+		},
+		mockTxMempooler: func() *testTxMempooler {
+			mp := defaultMockTxMempooler()
+			mp.txMiningWindowErr = mempool.RuleError{
+				Err:         blockchain.ErrMissingTxOut,
+				Description: "missing input",
+			}
+			return mp
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCMisc,
+	}, {
+		name:    "handleGetTxMiningWindow: unable to calculate window",
+		handler: handleGetTxMiningWindow,
+		cmd: &types.GetTxMiningWindowCmd{
+			HexTx: hexTx,
+		},
+		mockTxMempooler: func() *testTxMempooler {
+			mp := defaultMockTxMempooler()
+			mp.txMiningWindowErr = errors.New("unable to calculate window")
+			return mp
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleGetTxMiningWindow: ok",
+		handler: handleGetTxMiningWindow,
+		cmd: &types.GetTxMiningWindowCmd{
+			HexTx: hexTx,
+		},
+		mockTxMempooler: func() *testTxMempooler {
+			mp := defaultMockTxMempooler()
+			mp.txMiningWindow = &blockchain.TxMiningWindow{
+				MinHeight:     432105,
+				MinMedianTime: 1583426563,
+				MaxHeight:     432200,
+			}
+			return mp
+		}(),
+		result: &types.GetTxMiningWindowResult{
+			MinHeight:     432105,
+			MinMedianTime: 1583426563,
+			MaxHeight:     432200,
+			Minable:       true,
+		},
+	}, {
+		name:    "handleGetTxMiningWindow: ok, expires before minable",
+		handler: handleGetTxMiningWindow,
+		cmd: &types.GetTxMiningWindowCmd{
+			HexTx: hexTx,
+		},
+		mockTxMempooler: func() *testTxMempooler {
+			mp := defaultMockTxMempooler()
+			mp.txMiningWindow = &blockchain.TxMiningWindow{
+				MinHeight: 432105,
+				MaxHeight: 432101,
+			}
+			return mp
+		}(),
+		result: &types.GetTxMiningWindowResult{
+			MinHeight: 432105,
+			MaxHeight: 432101,
+			Minable:   false,
+		},
+	}})
+}
+
 func TestHandleGetTxOut(t *testing.T) {
 	t.Parallel()
 
@@ -5170,6 +6723,187 @@ func TestHandleGetTxOut(t *testing.T) {
 	}})
 }
 
+func TestHandleGetTxOutHistory(t *testing.T) {
+	t.Parallel()
+
+	// Define the test tx and output.
+	msgTx := hexToMsgTx(hexFromFile("tx432100-1.hex"))
+	txHash := msgTx.TxHash()
+	txOut := msgTx.TxOut[0]
+	blkHash := block432100.BlockHash()
+	blkHeight := int64(block432100.Header.Height)
+	spenderHash := block432100.Transactions[0].TxHash()
+
+	// Define the default command and mock entry.  The output is created two
+	// blocks before the best block and spent in the best block.
+	cmd := func(height int64) *types.GetTxOutHistoryCmd {
+		return &types.GetTxOutHistoryCmd{
+			Txid:   txHash.String(),
+			Vout:   0,
+			Height: dcrjson.Int64(height),
+		}
+	}
+	entry := &indexers.UtxoHistoryEntry{
+		Height:        blkHeight - 2,
+		Tree:          wire.TxTreeRegular,
+		Amount:        txOut.Value,
+		ScriptVersion: txOut.Version,
+		PkScript:      txOut.PkScript,
+		Events: []indexers.UtxoEvent{{
+			Height:       blkHeight,
+			Kind:         indexers.UtxoEventSpent,
+			SpenderHash:  spenderHash,
+			SpenderIndex: 1,
+		}},
+	}
+	indexerWithEntry := func() *testUtxoHistoryIndexer {
+		idx := defaultMockUtxoHistoryIndexer()
+		idx.entry = entry
+		return idx
+	}
+
+	// Define the expected results.
+	script := txOut.PkScript
+	disbuf, _ := txscript.DisasmString(script)
+	scriptType, addrs := stdscript.ExtractAddrs(txOut.Version, script,
+		defaultChainParams)
+	addresses := make([]string, len(addrs))
+	for i, addr := range addrs {
+		addresses[i] = addr.String()
+	}
+	reqSigs := stdscript.DetermineRequiredSigs(txOut.Version, script)
+	unspentResult := types.GetTxOutHistoryResult{
+		Height:        blkHeight - 1,
+		State:         "unspent",
+		CreatedHeight: blkHeight - 2,
+		Tree:          wire.TxTreeRegular,
+		Value:         dcrutil.Amount(txOut.Value).ToUnit(dcrutil.AmountCoin),
+		ScriptPubKey: types.ScriptPubKeyResult{
+			Asm:       disbuf,
+			Hex:       hex.EncodeToString(script),
+			ReqSigs:   int32(reqSigs),
+			Type:      scriptType.String(),
+			Addresses: addresses,
+		},
+		Events: []types.TxOutHistoryEventResult{},
+	}
+	spentResult := unspentResult
+	spentResult.Height = blkHeight
+	spentResult.State = "spent"
+	spentResult.Events = []types.TxOutHistoryEventResult{{
+		Height:    blkHeight,
+		BlockHash: blkHash.String(),
+		Event:     "spent",
+		SpendTxID: spenderHash.String(),
+		SpendVin:  1,
+	}}
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:                "handleGetTxOutHistory: ok, best height",
+		handler:             handleGetTxOutHistory,
+		cmd:                 cmd(-1),
+		mockUtxoHistIndexer: indexerWithEntry(),
+		result:              &spentResult,
+	}, {
+		name:                "handleGetTxOutHistory: ok, height after best",
+		handler:             handleGetTxOutHistory,
+		cmd:                 cmd(blkHeight + 1),
+		mockUtxoHistIndexer: indexerWithEntry(),
+		result:              &spentResult,
+	}, {
+		name:                "handleGetTxOutHistory: ok, historical height",
+		handler:             handleGetTxOutHistory,
+		cmd:                 cmd(blkHeight - 1),
+		mockUtxoHistIndexer: indexerWithEntry(),
+		result:              &unspentResult,
+	}, {
+		name:    "handleGetTxOutHistory: ok, wait for sync",
+		handler: handleGetTxOutHistory,
+		cmd:     cmd(-1),
+		mockUtxoHistIndexer: func() *testUtxoHistoryIndexer {
+			idx := indexerWithEntry()
+			idx.tipHash = &zeroHash
+			return idx
+		}(),
+		result: &spentResult,
+	}, {
+		name:    "handleGetTxOutHistory: utxo history index not enabled",
+		handler: handleGetTxOutHistory,
+		cmd:     cmd(-1),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleGetTxOutHistory: invalid txid",
+		handler: handleGetTxOutHistory,
+		cmd: &types.GetTxOutHistoryCmd{
+			Txid:   "zz",
+			Height: dcrjson.Int64(-1),
+		},
+		mockUtxoHistIndexer: indexerWithEntry(),
+		wantErr:             true,
+		errCode:             dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:    "handleGetTxOutHistory: unable to fetch index tip",
+		handler: handleGetTxOutHistory,
+		cmd:     cmd(-1),
+		mockUtxoHistIndexer: func() *testUtxoHistoryIndexer {
+			idx := indexerWithEntry()
+			idx.tipErr = errors.New("unable to fetch index tip")
+			return idx
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleGetTxOutHistory: index is not synced",
+		handler: handleGetTxOutHistory,
+		cmd:     cmd(-1),
+		mockUtxoHistIndexer: func() *testUtxoHistoryIndexer {
+			idx := indexerWithEntry()
+			idx.tipHeight = blkHeight - 6
+			return idx
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleGetTxOutHistory: unable to fetch entry",
+		handler: handleGetTxOutHistory,
+		cmd:     cmd(-1),
+		mockUtxoHistIndexer: func() *testUtxoHistoryIndexer {
+			idx := indexerWithEntry()
+			idx.entryErr = errors.New("corrupt entry")
+			return idx
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:                "handleGetTxOutHistory: no entry",
+		handler:             handleGetTxOutHistory,
+		cmd:                 cmd(-1),
+		mockUtxoHistIndexer: defaultMockUtxoHistoryIndexer(),
+		wantErr:             true,
+		errCode:             dcrjson.ErrRPCNoTxInfo,
+	}, {
+		name:                "handleGetTxOutHistory: output not created at height",
+		handler:             handleGetTxOutHistory,
+		cmd:                 cmd(blkHeight - 3),
+		mockUtxoHistIndexer: indexerWithEntry(),
+		wantErr:             true,
+		errCode:             dcrjson.ErrRPCNoTxInfo,
+	}, {
+		name:    "handleGetTxOutHistory: unable to fetch block hash",
+		handler: handleGetTxOutHistory,
+		cmd:     cmd(-1),
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.blockHashByHeightErr = errors.New("no block at height")
+			return chain
+		}(),
+		mockUtxoHistIndexer: indexerWithEntry(),
+		wantErr:             true,
+		errCode:             dcrjson.ErrRPCInternal.Code,
+	}})
+}
+
 func TestHandleGetTxOutSetInfo(t *testing.T) {
 	t.Parallel()
 
@@ -5891,6 +7625,8 @@ func TestHandleSetGenerate(t *testing.T) {
 	if err != nil {
 		t.Fatalf("[DecodeAddress] unexpected error: %v", err)
 	}
+	chainParams := cloneParams(defaultChainParams)
+	chainParams.GenerateSupported = true
 
 	testRPCServerHandler(t, []rpcTest{{
 		name:    "handleSetGenerate: no payment addresses",
@@ -5901,6 +7637,20 @@ func TestHandleSetGenerate(t *testing.T) {
 		},
 		wantErr: true,
 		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleSetGenerate: generate not supported for network",
+		handler: handleSetGenerate,
+		cmd: &types.SetGenerateCmd{
+			Generate:     true,
+			GenProcLimit: &procLimit,
+		},
+		mockMiningState: func() *testMiningState {
+			ms := defaultMockMiningState()
+			ms.miningAddrs = []stdaddr.Address{miningaddr}
+			return ms
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDifficulty,
 	}, {
 		name:    "handleSetGenerate: ok",
 		handler: handleSetGenerate,
@@ -5913,6 +7663,7 @@ func TestHandleSetGenerate(t *testing.T) {
 			ms.miningAddrs = []stdaddr.Address{miningaddr}
 			return ms
 		}(),
+		mockChainParams: chainParams,
 	}, {
 		name:    "handleSetGenerate: ok, generate=false",
 		handler: handleSetGenerate,
@@ -5928,6 +7679,89 @@ func TestHandleSetGenerate(t *testing.T) {
 	}})
 }
 
+func TestHandleSetMockTime(t *testing.T) {
+	t.Parallel()
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleSetMockTime: ok",
+		handler: handleSetMockTime,
+		cmd: &types.SetMockTimeCmd{
+			Timestamp: 1592931200,
+		},
+		result: nil,
+	}, {
+		name:    "handleSetMockTime: remove mock time",
+		handler: handleSetMockTime,
+		cmd: &types.SetMockTimeCmd{
+			Timestamp: 0,
+		},
+		result: nil,
+	}, {
+		name:    "handleSetMockTime: negative timestamp",
+		handler: handleSetMockTime,
+		cmd: &types.SetMockTimeCmd{
+			Timestamp: -1,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleSetMockTime: no mock time source",
+		handler: handleSetMockTime,
+		cmd: &types.SetMockTimeCmd{
+			Timestamp: 1592931200,
+		},
+		setMockTimeSourceNil: true,
+		wantErr:              true,
+		errCode:              dcrjson.ErrRPCInternal.Code,
+	}})
+}
+
+// TestDebugRPCs ensures the RPCs that are only intended for testing are only
+// available when the debug RPCs are enabled.
+func TestDebugRPCs(t *testing.T) {
+	t.Parallel()
+
+	mockTimeSource := &testMockTimeSource{}
+	cfg := defaultMockConfig(defaultChainParams)
+	cfg.MockTimeSource = mockTimeSource
+	s := &Server{cfg: *cfg}
+	setMockTime := func(timestamp int64) error {
+		_, err := s.standardCmdResult(context.Background(), &parsedRPCCmd{
+			method: "setmocktime",
+			params: &types.SetMockTimeCmd{Timestamp: timestamp},
+		})
+		return err
+	}
+
+	// Ensure the debug RPCs are rejected when they are not enabled.
+	for _, method := range []types.Method{"setmocktime", "forceprune"} {
+		_, err := s.standardCmdResult(context.Background(), &parsedRPCCmd{
+			method: method,
+			params: &types.ForcePruneCmd{},
+		})
+		if !errors.Is(err, ErrRPCDebugDisabled) {
+			t.Fatalf("%s: mismatched error -- got %v, want %v", method, err,
+				ErrRPCDebugDisabled)
+		}
+	}
+
+	// Ensure the mock time is set and removed when the debug RPCs are
+	// enabled.
+	s.cfg.DebugRPC = true
+	if err := setMockTime(1592931200); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := mockTimeSource.MockTime(), time.Unix(1592931200, 0); !got.Equal(want) {
+		t.Fatalf("mismatched mock time -- got %v, want %v", got, want)
+	}
+	if err := setMockTime(0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := mockTimeSource.MockTime(); !got.IsZero() {
+		t.Fatalf("mock time was not removed -- got %v", got)
+	}
+}
+
 func TestHandleReconsiderBlock(t *testing.T) {
 	t.Parallel()
 
@@ -5982,29 +7816,217 @@ func TestHandleReconsiderBlock(t *testing.T) {
 		wantErr:   true,
 		errCode:   dcrjson.ErrRPCInternal.Code,
 	}, {
-		name:      "handleReconsiderBlock: reconsider other error",
-		handler:   handleReconsiderBlock,
-		cmd:       validReconsiderBlockCmd,
-		mockChain: chainWithErr(errors.New("")),
+		name:      "handleReconsiderBlock: reconsider other error",
+		handler:   handleReconsiderBlock,
+		cmd:       validReconsiderBlockCmd,
+		mockChain: chainWithErr(errors.New("")),
+		wantErr:   true,
+		errCode:   dcrjson.ErrRPCInternal.Code,
+	}})
+}
+
+func TestHandleRegenTemplate(t *testing.T) {
+	t.Parallel()
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:                 "handleRegenTemplate: node is not configured for mining",
+		handler:              handleRegenTemplate,
+		cmd:                  &types.RegenTemplateCmd{},
+		setBlockTemplaterNil: true,
+		wantErr:              true,
+		errCode:              dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleRegenTemplate: ok",
+		handler: handleRegenTemplate,
+		cmd:     &types.RegenTemplateCmd{},
+	}})
+}
+
+func TestHandleReloadConfig(t *testing.T) {
+	t.Parallel()
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleReloadConfig: invalid config",
+		handler: handleReloadConfig,
+		cmd:     &types.ReloadConfigCmd{},
+		mockConfigReloader: &testConfigReloader{
+			err: errors.New("invalid minrelaytxfee"),
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleReloadConfig: no changes",
+		handler: handleReloadConfig,
+		cmd:     &types.ReloadConfigCmd{},
+		result:  &types.ReloadConfigResult{Changes: []string{}},
+	}, {
+		name:    "handleReloadConfig: ok",
+		handler: handleReloadConfig,
+		cmd:     &types.ReloadConfigCmd{},
+		mockConfigReloader: &testConfigReloader{
+			changes: []string{"banthreshold: 100 -> 50"},
+		},
+		result: &types.ReloadConfigResult{
+			Changes: []string{"banthreshold: 100 -> 50"},
+		},
+	}})
+}
+
+func TestHandleScrubDatabase(t *testing.T) {
+	t.Parallel()
+
+	chainWithScrub := func(result *blockchain.ScrubResult, err error) *testRPCChain {
+		chain := defaultMockRPCChain()
+		chain.scrubResult = result
+		chain.scrubErr = err
+		return chain
+	}
+
+	blkHash := block432100.BlockHash()
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleScrubDatabase: no issues",
+		handler: handleScrubDatabase,
+		cmd: &types.ScrubDatabaseCmd{
+			Quarantine: dcrjson.Bool(false),
+		},
+		mockChain: chainWithScrub(&blockchain.ScrubResult{
+			MainChainBlocks: 432101,
+			SideChainBlocks: 2,
+		}, nil),
+		result: &types.ScrubDatabaseResult{
+			MainChainBlocks: 432101,
+			SideChainBlocks: 2,
+			Issues:          []types.ScrubIssue{},
+		},
+	}, {
+		name:    "handleScrubDatabase: issues",
+		handler: handleScrubDatabase,
+		cmd: &types.ScrubDatabaseCmd{
+			Quarantine: dcrjson.Bool(true),
+		},
+		mockChain: chainWithScrub(&blockchain.ScrubResult{
+			MainChainBlocks: 432101,
+			SideChainBlocks: 1,
+			Issues: []blockchain.ScrubIssue{{
+				Hash:        blkHash,
+				Height:      432100,
+				Kind:        blockchain.ScrubCorruptBlockData,
+				Description: "checksum does not match",
+				Suggestion:  "quarantine the block",
+				Quarantined: true,
+			}},
+		}, nil),
+		result: &types.ScrubDatabaseResult{
+			MainChainBlocks: 432101,
+			SideChainBlocks: 1,
+			Issues: []types.ScrubIssue{{
+				Hash:        blkHash.String(),
+				Height:      432100,
+				Kind:        "corrupt block data",
+				Description: "checksum does not match",
+				Suggestion:  "quarantine the block",
+				Quarantined: true,
+			}},
+		},
+	}, {
+		name:    "handleScrubDatabase: scrub error",
+		handler: handleScrubDatabase,
+		cmd: &types.ScrubDatabaseCmd{
+			Quarantine: dcrjson.Bool(false),
+		},
+		mockChain: chainWithScrub(nil, errors.New("scrub error")),
 		wantErr:   true,
 		errCode:   dcrjson.ErrRPCInternal.Code,
 	}})
 }
 
-func TestHandleRegenTemplate(t *testing.T) {
+func TestHandleGetSideChainBlocks(t *testing.T) {
 	t.Parallel()
 
+	chainWithSideChainBlocks := func(blocks []blockchain.SideChainBlock) *testRPCChain {
+		chain := defaultMockRPCChain()
+		chain.sideChainBlocks = blocks
+		return chain
+	}
+
+	blkHash := block432100.BlockHash()
+	forkHash := block432100.Header.PrevBlock
 	testRPCServerHandler(t, []rpcTest{{
-		name:                 "handleRegenTemplate: node is not configured for mining",
-		handler:              handleRegenTemplate,
-		cmd:                  &types.RegenTemplateCmd{},
-		setBlockTemplaterNil: true,
-		wantErr:              true,
-		errCode:              dcrjson.ErrRPCInternal.Code,
+		name:      "handleGetSideChainBlocks: no side chain blocks",
+		handler:   handleGetSideChainBlocks,
+		cmd:       &types.GetSideChainBlocksCmd{},
+		mockChain: chainWithSideChainBlocks(nil),
+		result:    []types.SideChainBlockResult{},
+	}, {
+		name:    "handleGetSideChainBlocks: ok",
+		handler: handleGetSideChainBlocks,
+		cmd:     &types.GetSideChainBlocksCmd{},
+		mockChain: chainWithSideChainBlocks([]blockchain.SideChainBlock{{
+			Hash:       blkHash,
+			Height:     432100,
+			ForkHash:   forkHash,
+			ForkHeight: 432099,
+			Depth:      2,
+		}}),
+		result: []types.SideChainBlockResult{{
+			Hash:       blkHash.String(),
+			Height:     432100,
+			ForkHash:   forkHash.String(),
+			ForkHeight: 432099,
+			Depth:      2,
+		}},
+	}})
+}
+
+func TestHandlePurgeSideChainBlocks(t *testing.T) {
+	t.Parallel()
+
+	chainWithPurge := func(blocks []blockchain.SideChainBlock, err error) *testRPCChain {
+		chain := defaultMockRPCChain()
+		chain.purgedSideChainBlocks = blocks
+		chain.purgeSideChainBlocksErr = err
+		return chain
+	}
+
+	blkHash := block432100.BlockHash()
+	forkHash := block432100.Header.PrevBlock
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handlePurgeSideChainBlocks: ok",
+		handler: handlePurgeSideChainBlocks,
+		cmd: &types.PurgeSideChainBlocksCmd{
+			MinDepth: dcrjson.Int64(0),
+		},
+		mockChain: chainWithPurge([]blockchain.SideChainBlock{{
+			Hash:       blkHash,
+			Height:     432100,
+			ForkHash:   forkHash,
+			ForkHeight: 432099,
+			Depth:      2,
+		}}, nil),
+		result: []types.SideChainBlockResult{{
+			Hash:       blkHash.String(),
+			Height:     432100,
+			ForkHash:   forkHash.String(),
+			ForkHeight: 432099,
+			Depth:      2,
+		}},
 	}, {
-		name:    "handleRegenTemplate: ok",
-		handler: handleRegenTemplate,
-		cmd:     &types.RegenTemplateCmd{},
+		name:    "handlePurgeSideChainBlocks: negative min depth",
+		handler: handlePurgeSideChainBlocks,
+		cmd: &types.PurgeSideChainBlocksCmd{
+			MinDepth: dcrjson.Int64(-1),
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handlePurgeSideChainBlocks: purge error",
+		handler: handlePurgeSideChainBlocks,
+		cmd: &types.PurgeSideChainBlocksCmd{
+			MinDepth: dcrjson.Int64(0),
+		},
+		mockChain: chainWithPurge(nil, errors.New("purge error")),
+		wantErr:   true,
+		errCode:   dcrjson.ErrRPCInternal.Code,
 	}})
 }
 
@@ -6787,6 +8809,7 @@ func TestHandleSendRawTransaction(t *testing.T) {
 		}(),
 		wantErr: true,
 		errCode: dcrjson.ErrRPCDuplicateTx,
+		errData: &types.TxRejectErrorData{RejectCode: "duplicate"},
 	}, {
 		name:    "handleSendRawTransaction: duplicate unspent mined transaction",
 		handler: handleSendRawTransaction,
@@ -6806,6 +8829,7 @@ func TestHandleSendRawTransaction(t *testing.T) {
 		}(),
 		wantErr: true,
 		errCode: dcrjson.ErrRPCDuplicateTx,
+		errData: &types.TxRejectErrorData{RejectCode: "duplicate"},
 	}, {
 		name:    "handleSendRawTransaction: recently mined transaction",
 		handler: handleSendRawTransaction,
@@ -6826,6 +8850,7 @@ func TestHandleSendRawTransaction(t *testing.T) {
 		}(),
 		wantErr: true,
 		errCode: dcrjson.ErrRPCDuplicateTx,
+		errData: &types.TxRejectErrorData{RejectCode: "duplicate"},
 	}, {
 		name:    "handleSendRawTransaction: expired transaction",
 		handler: handleSendRawTransaction,
@@ -6845,6 +8870,7 @@ func TestHandleSendRawTransaction(t *testing.T) {
 		}(),
 		wantErr: true,
 		errCode: dcrjson.ErrRPCMisc,
+		errData: &types.TxRejectErrorData{RejectCode: "expired"},
 	}, {
 		name:    "handleSendRawTransaction: ok",
 		handler: handleSendRawTransaction,
@@ -6861,6 +8887,119 @@ func TestHandleSendRawTransaction(t *testing.T) {
 	}})
 }
 
+func TestHandleTestMempoolAccept(t *testing.T) {
+	t.Parallel()
+
+	allowHighFees := false
+	tx1 := dcrutil.NewTx(block432100.Transactions[1])
+	tx2 := dcrutil.NewTx(block432100.STransactions[0])
+	tx1B, err := tx1.MsgTx().Bytes()
+	if err != nil {
+		t.Fatalf("unexpected tx serialization error: %v", err)
+	}
+	tx2B, err := tx2.MsgTx().Bytes()
+	if err != nil {
+		t.Fatalf("unexpected tx serialization error: %v", err)
+	}
+	hexTx1, hexTx2 := hex.EncodeToString(tx1B), hex.EncodeToString(tx2B)
+	tooManyTxns := make([]string, maxTestMempoolAcceptTxns+1)
+	for i := range tooManyTxns {
+		tooManyTxns[i] = hexTx1
+	}
+
+	// mempoolerWithResults returns a mock mempool that accepts the first
+	// transaction and rejects the second one with a rule error.
+	mempoolerWithResults := func() *testTxMempooler {
+		mp := defaultMockTxMempooler()
+		mp.checkAcceptTx = func(tx *dcrutil.Tx) (*mempool.TxDesc, error) {
+			if *tx.Hash() == *tx1.Hash() {
+				return &mempool.TxDesc{TxDesc: mining.TxDesc{
+					Tx:     tx,
+					Fee:    2520,
+					TxSize: int64(len(tx1B)),
+				}}, nil
+			}
+			return nil, mempool.RuleError{
+				Err:         mempool.ErrInsufficientFee,
+				Description: "insufficient fee",
+			}
+		}
+		return mp
+	}
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleTestMempoolAccept: no transactions",
+		handler: handleTestMempoolAccept,
+		cmd: &types.TestMempoolAcceptCmd{
+			RawTxs:        nil,
+			AllowHighFees: &allowHighFees,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleTestMempoolAccept: too many transactions",
+		handler: handleTestMempoolAccept,
+		cmd: &types.TestMempoolAcceptCmd{
+			RawTxs:        tooManyTxns,
+			AllowHighFees: &allowHighFees,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleTestMempoolAccept: invalid tx hex",
+		handler: handleTestMempoolAccept,
+		cmd: &types.TestMempoolAcceptCmd{
+			RawTxs:        []string{hexTx1, "invalid"},
+			AllowHighFees: &allowHighFees,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:    "handleTestMempoolAccept: invalid tx",
+		handler: handleTestMempoolAccept,
+		cmd: &types.TestMempoolAcceptCmd{
+			RawTxs:        []string{"fefefefefefe"},
+			AllowHighFees: &allowHighFees,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDeserialization,
+	}, {
+		name:    "handleTestMempoolAccept: unable to check transaction",
+		handler: handleTestMempoolAccept,
+		cmd: &types.TestMempoolAcceptCmd{
+			RawTxs:        []string{hexTx1},
+			AllowHighFees: &allowHighFees,
+		},
+		mockTxMempooler: func() *testTxMempooler {
+			mp := defaultMockTxMempooler()
+			mp.checkAcceptTx = func(*dcrutil.Tx) (*mempool.TxDesc, error) {
+				return nil, errors.New("unable to check transaction")
+			}
+			return mp
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleTestMempoolAccept: ok",
+		handler: handleTestMempoolAccept,
+		cmd: &types.TestMempoolAcceptCmd{
+			RawTxs:        []string{hexTx1, hexTx2},
+			AllowHighFees: &allowHighFees,
+		},
+		mockTxMempooler: mempoolerWithResults(),
+		result: []types.TestMempoolAcceptResult{{
+			TxID:    tx1.Hash().String(),
+			Allowed: true,
+			Size:    int64(len(tx1B)),
+			Fee:     0.0000252,
+		}, {
+			TxID:         tx2.Hash().String(),
+			RejectReason: "insufficient fee",
+			RejectCode:   "insufficient-fee",
+		}},
+	}})
+}
+
 func TestHandleGetVoteInfo(t *testing.T) {
 	t.Parallel()
 
@@ -6875,6 +9014,7 @@ func TestHandleGetVoteInfo(t *testing.T) {
 		ExpireTime:     1599264000,
 		Status:         "started",
 		QuorumProgress: 0.01984126984126984,
+		Participation:  0.8,
 		Choices: []types.Choice{{
 			ID:          "abstain",
 			Description: "abstain voting for change",
@@ -6959,6 +9099,49 @@ func TestHandleGetVoteInfo(t *testing.T) {
 		Agendas:       thresholdDefinedAgendas,
 	}
 
+	// The historical results identify the final block of the interval and
+	// only include the votes of the version.
+	intervalHash := mustParseHash("00000000000000000ee3f7ad06d8d3f97fad3d8af5ae21a73aa07a4b3cbb2e2a")
+	okIntervalResult := okResult
+	okIntervalResult.CurrentHeight = 439551
+	okIntervalResult.Hash = intervalHash.String()
+	okIntervalResult.TotalVotes = 100
+	okIntervalVoteInfo := &blockchain.IntervalVoteInfo{
+		StartHeight:  431488,
+		EndHeight:    439551,
+		Hash:         *intervalHash,
+		Height:       439551,
+		TotalVotes:   110,
+		VersionVotes: 100,
+		Agendas: []blockchain.IntervalAgendaVotes{{
+			Deployment: &defaultChainParams.Deployments[v7][0],
+			State: blockchain.ThresholdStateTuple{
+				State:  blockchain.ThresholdStarted,
+				Choice: uint32(0xffffffff),
+			},
+			Counts: blockchain.VoteCounts{
+				Total:        100,
+				TotalAbstain: 20,
+				VoteChoices:  []uint32{20, 10, 70},
+			},
+		}},
+	}
+	definedIntervalVoteInfo := *okIntervalVoteInfo
+	definedIntervalVoteInfo.VersionVotes = 0
+	definedIntervalVoteInfo.Agendas = []blockchain.IntervalAgendaVotes{{
+		Deployment: &defaultChainParams.Deployments[v7][0],
+		State: blockchain.ThresholdStateTuple{
+			State:  blockchain.ThresholdDefined,
+			Choice: uint32(0xffffffff),
+		},
+		Counts: blockchain.VoteCounts{
+			VoteChoices: []uint32{0, 0, 0},
+		},
+	}}
+	definedIntervalResult := thresholdDefinedResult
+	definedIntervalResult.CurrentHeight = 439551
+	definedIntervalResult.Hash = intervalHash.String()
+
 	testRPCServerHandler(t, []rpcTest{{
 		name:    "handleGetVoteInfo: unable to fetch vote info",
 		handler: handleGetVoteInfo,
@@ -6967,122 +9150,327 @@ func TestHandleGetVoteInfo(t *testing.T) {
 		},
 		mockChain: func() *testRPCChain {
 			chain := defaultMockRPCChain()
-			chain.getVoteInfoErr = errors.New("unable to fetch vote info")
+			chain.getVoteInfoErr = errors.New("unable to fetch vote info")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleGetVoteInfo: invalid version info",
+		handler: handleGetVoteInfo,
+		cmd: &types.GetVoteInfoCmd{
+			Version: v7,
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.getVoteInfoErr = blockchain.ErrUnknownDeploymentVersion
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleGetVoteInfo: unable to count vote version",
+		handler: handleGetVoteInfo,
+		cmd: &types.GetVoteInfoCmd{
+			Version: v7,
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.countVoteVersionErr = errors.New("unable to count vote version")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleGetVoteInfo: unable to fetch next threshold state",
+		handler: handleGetVoteInfo,
+		cmd: &types.GetVoteInfoCmd{
+			Version: v7,
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.getVoteInfo = &blockchain.VoteInfo{
+				Agendas: defaultChainParams.Deployments[v7],
+				AgendaStatus: []blockchain.ThresholdStateTuple{{
+					State:  blockchain.ThresholdStarted,
+					Choice: uint32(0xffffffff),
+				}},
+			}
+			chain.nextThresholdStateErr = errors.New("unable to fetch next threshold state")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleGetVoteInfo: unable to get vote counts",
+		handler: handleGetVoteInfo,
+		cmd: &types.GetVoteInfoCmd{
+			Version: v7,
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.getVoteInfo = &blockchain.VoteInfo{
+				Agendas: defaultChainParams.Deployments[v7],
+				AgendaStatus: []blockchain.ThresholdStateTuple{{
+					State:  blockchain.ThresholdStarted,
+					Choice: uint32(0xffffffff),
+				}},
+			}
+			chain.getVoteCountsErr = errors.New("unable to get vote counts")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
+	}, {
+		name:    "handleGetVoteInfo: ok, threshold state != started",
+		handler: handleGetVoteInfo,
+		cmd: &types.GetVoteInfoCmd{
+			Version: v7,
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.getVoteInfo = &blockchain.VoteInfo{
+				Agendas: defaultChainParams.Deployments[v7],
+				AgendaStatus: []blockchain.ThresholdStateTuple{{
+					State:  blockchain.ThresholdDefined,
+					Choice: uint32(0xffffffff),
+				}},
+			}
+			chain.nextThresholdState = blockchain.ThresholdStateTuple{
+				State:  blockchain.ThresholdDefined,
+				Choice: uint32(0xffffffff),
+			}
+			return chain
+		}(),
+		result: thresholdDefinedResult,
+	}, {
+		name:    "handleGetVoteInfo: ok",
+		handler: handleGetVoteInfo,
+		cmd: &types.GetVoteInfoCmd{
+			Version: v7,
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.getVoteCounts = blockchain.VoteCounts{
+				Total:        100,
+				TotalAbstain: 20,
+				VoteChoices:  []uint32{20, 10, 70},
+			}
+			chain.getVoteInfo = &blockchain.VoteInfo{
+				Agendas: defaultChainParams.Deployments[v7],
+				AgendaStatus: []blockchain.ThresholdStateTuple{{
+					State:  blockchain.ThresholdStarted,
+					Choice: uint32(0xffffffff),
+				}},
+			}
+			return chain
+		}(),
+		result: okResult,
+	}, {
+		name:    "handleGetVoteInfo: ok, negative height",
+		handler: handleGetVoteInfo,
+		cmd: &types.GetVoteInfoCmd{
+			Version: v7,
+			Height:  dcrjson.Int64(-1),
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.getVoteCounts = blockchain.VoteCounts{
+				Total:        100,
+				TotalAbstain: 20,
+				VoteChoices:  []uint32{20, 10, 70},
+			}
+			chain.getVoteInfo = &blockchain.VoteInfo{
+				Agendas: defaultChainParams.Deployments[v7],
+				AgendaStatus: []blockchain.ThresholdStateTuple{{
+					State:  blockchain.ThresholdStarted,
+					Choice: uint32(0xffffffff),
+				}},
+			}
+			chain.intervalVoteInfoErr = errors.New("unexpected call")
+			return chain
+		}(),
+		result: okResult,
+	}, {
+		name:    "handleGetVoteInfo: at height, invalid version",
+		handler: handleGetVoteInfo,
+		cmd: &types.GetVoteInfoCmd{
+			Version: v0,
+			Height:  dcrjson.Int64(439551),
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.intervalVoteInfoErr = blockchain.ErrUnknownDeploymentVersion
 			return chain
 		}(),
 		wantErr: true,
-		errCode: dcrjson.ErrRPCInternal.Code,
+		errCode: dcrjson.ErrRPCInvalidParameter,
 	}, {
-		name:    "handleGetVoteInfo: invalid version info",
+		name:    "handleGetVoteInfo: at height, height after tip",
 		handler: handleGetVoteInfo,
 		cmd: &types.GetVoteInfoCmd{
 			Version: v7,
+			Height:  dcrjson.Int64(1000000),
 		},
 		mockChain: func() *testRPCChain {
 			chain := defaultMockRPCChain()
-			chain.getVoteInfoErr = blockchain.ErrUnknownDeploymentVersion
+			chain.intervalVoteInfoErr = blockchain.ErrInvalidHeightRange
 			return chain
 		}(),
 		wantErr: true,
 		errCode: dcrjson.ErrRPCInvalidParameter,
 	}, {
-		name:    "handleGetVoteInfo: unable to count vote version",
+		name:    "handleGetVoteInfo: at height, unable to fetch vote info",
 		handler: handleGetVoteInfo,
 		cmd: &types.GetVoteInfoCmd{
 			Version: v7,
+			Height:  dcrjson.Int64(439551),
 		},
 		mockChain: func() *testRPCChain {
 			chain := defaultMockRPCChain()
-			chain.countVoteVersionErr = errors.New("unable to count vote version")
+			chain.intervalVoteInfoErr = errors.New("unable to fetch vote info")
 			return chain
 		}(),
 		wantErr: true,
 		errCode: dcrjson.ErrRPCInternal.Code,
 	}, {
-		name:    "handleGetVoteInfo: unable to fetch next threshold state",
+		name:    "handleGetVoteInfo: at height, ok, threshold state != started",
 		handler: handleGetVoteInfo,
 		cmd: &types.GetVoteInfoCmd{
 			Version: v7,
+			Height:  dcrjson.Int64(431488),
 		},
 		mockChain: func() *testRPCChain {
 			chain := defaultMockRPCChain()
-			chain.getVoteInfo = &blockchain.VoteInfo{
-				Agendas: defaultChainParams.Deployments[v7],
-				AgendaStatus: []blockchain.ThresholdStateTuple{{
-					State:  blockchain.ThresholdStarted,
-					Choice: uint32(0xffffffff),
-				}},
-			}
-			chain.nextThresholdStateErr = errors.New("unable to fetch next threshold state")
+			chain.intervalVoteInfo = &definedIntervalVoteInfo
 			return chain
 		}(),
-		wantErr: true,
-		errCode: dcrjson.ErrRPCInternal.Code,
+		result: definedIntervalResult,
 	}, {
-		name:    "handleGetVoteInfo: unable to get vote counts",
+		name:    "handleGetVoteInfo: at height, ok",
 		handler: handleGetVoteInfo,
 		cmd: &types.GetVoteInfoCmd{
 			Version: v7,
+			Height:  dcrjson.Int64(431488),
 		},
 		mockChain: func() *testRPCChain {
 			chain := defaultMockRPCChain()
-			chain.getVoteInfo = &blockchain.VoteInfo{
-				Agendas: defaultChainParams.Deployments[v7],
-				AgendaStatus: []blockchain.ThresholdStateTuple{{
-					State:  blockchain.ThresholdStarted,
-					Choice: uint32(0xffffffff),
-				}},
-			}
-			chain.getVoteCountsErr = errors.New("unable to get vote counts")
+			chain.intervalVoteInfo = okIntervalVoteInfo
 			return chain
 		}(),
-		wantErr: true,
-		errCode: dcrjson.ErrRPCInternal.Code,
+		result: okIntervalResult,
+	}})
+}
+
+func TestHandleGetVoteStats(t *testing.T) {
+	t.Parallel()
+
+	blkHash := mustParseHash("000000000000000023455b4328635d8e014dbeea99c6140aa715836cc7e55981")
+	voteStats := &blockchain.VoteStats{
+		StartHeight: 432099,
+		EndHeight:   432100,
+		VoteBlocks:  2,
+		Eligible:    10,
+		Included:    9,
+		Missed:      1,
+		VoteCounts:  []int64{0, 0, 0, 0, 1, 1},
+	}
+	verboseVoteStats := *voteStats
+	verboseVoteStats.Blocks = []blockchain.BlockVoteStats{{
+		Hash:     *blkHash,
+		Height:   432099,
+		Eligible: 5,
+		Included: 4,
+		Missed:   1,
+	}, {
+		Hash:     *blkHash,
+		Height:   432100,
+		Eligible: 5,
+		Included: 5,
+		Missed:   0,
+	}}
+	result := types.GetVoteStatsResult{
+		StartHeight: 432099,
+		EndHeight:   432100,
+		VoteBlocks:  2,
+		Eligible:    10,
+		Included:    9,
+		Missed:      1,
+		VoteCounts:  []int64{0, 0, 0, 0, 1, 1},
+	}
+	verboseResult := result
+	verboseResult.Blocks = []types.VoteStatsBlock{{
+		Hash:     blkHash.String(),
+		Height:   432099,
+		Eligible: 5,
+		Included: 4,
+		Missed:   1,
+	}, {
+		Hash:     blkHash.String(),
+		Height:   432100,
+		Eligible: 5,
+		Included: 5,
+		Missed:   0,
+	}}
+
+	testRPCServerHandler(t, []rpcTest{{
+		name:    "handleGetVoteStats: ok",
+		handler: handleGetVoteStats,
+		cmd: &types.GetVoteStatsCmd{
+			StartHeight: 432099,
+			EndHeight:   dcrjson.Int64(-1),
+			Verbose:     dcrjson.Bool(false),
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.voteStats = voteStats
+			return chain
+		}(),
+		result: result,
 	}, {
-		name:    "handleGetVoteInfo: ok, threshold state != started",
-		handler: handleGetVoteInfo,
-		cmd: &types.GetVoteInfoCmd{
-			Version: v7,
+		name:    "handleGetVoteStats: ok verbose",
+		handler: handleGetVoteStats,
+		cmd: &types.GetVoteStatsCmd{
+			StartHeight: 432099,
+			EndHeight:   dcrjson.Int64(432100),
+			Verbose:     dcrjson.Bool(true),
 		},
 		mockChain: func() *testRPCChain {
 			chain := defaultMockRPCChain()
-			chain.getVoteInfo = &blockchain.VoteInfo{
-				Agendas: defaultChainParams.Deployments[v7],
-				AgendaStatus: []blockchain.ThresholdStateTuple{{
-					State:  blockchain.ThresholdDefined,
-					Choice: uint32(0xffffffff),
-				}},
-			}
-			chain.nextThresholdState = blockchain.ThresholdStateTuple{
-				State:  blockchain.ThresholdDefined,
-				Choice: uint32(0xffffffff),
-			}
+			chain.voteStats = &verboseVoteStats
 			return chain
 		}(),
-		result: thresholdDefinedResult,
+		result: verboseResult,
 	}, {
-		name:    "handleGetVoteInfo: ok",
-		handler: handleGetVoteInfo,
-		cmd: &types.GetVoteInfoCmd{
-			Version: v7,
+		name:    "handleGetVoteStats: invalid height range",
+		handler: handleGetVoteStats,
+		cmd: &types.GetVoteStatsCmd{
+			StartHeight: 432101,
+			EndHeight:   dcrjson.Int64(432100),
+			Verbose:     dcrjson.Bool(false),
 		},
 		mockChain: func() *testRPCChain {
 			chain := defaultMockRPCChain()
-			chain.getVoteCounts = blockchain.VoteCounts{
-				Total:        100,
-				TotalAbstain: 20,
-				VoteChoices:  []uint32{20, 10, 70},
-			}
-			chain.getVoteInfo = &blockchain.VoteInfo{
-				Agendas: defaultChainParams.Deployments[v7],
-				AgendaStatus: []blockchain.ThresholdStateTuple{{
-					State:  blockchain.ThresholdStarted,
-					Choice: uint32(0xffffffff),
-				}},
-			}
+			chain.voteStatsErr = blockchain.ErrInvalidHeightRange
 			return chain
 		}(),
-		result: okResult,
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleGetVoteStats: unable to obtain vote stats",
+		handler: handleGetVoteStats,
+		cmd: &types.GetVoteStatsCmd{
+			StartHeight: 432099,
+			EndHeight:   dcrjson.Int64(-1),
+			Verbose:     dcrjson.Bool(false),
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.voteStatsErr = errors.New("unable to obtain vote stats")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInternal.Code,
 	}})
 }
 
@@ -7176,6 +9564,23 @@ func TestHandleGetRawMempool(t *testing.T) {
 	mockTxMempooler.txDescs = descs
 	mockTxMempooler.verboseTxDescs = verboseDescs
 
+	// Create a mock mempool with transactions that were added at different
+	// times and pay different fee rates to test filtering and pagination.
+	feeRegular, feeTicket, feeVote := *regular, *ticket, *vote
+	feeRegular.Added, feeRegular.Fee = time.Unix(3, 0), 1500
+	feeTicket.Added, feeTicket.Fee = time.Unix(1, 0), 150
+	feeVote.Added = time.Unix(2, 0)
+	pagedTxMempooler := defaultMockTxMempooler()
+	pagedTxMempooler.txDescs = []*mempool.TxDesc{&feeRegular, &feeTicket,
+		&feeVote}
+	pagedTxMempooler.verboseTxDescs = []*mempool.VerboseTxDesc{{
+		TxDesc: feeRegular,
+	}, {
+		TxDesc: feeTicket,
+	}, {
+		TxDesc: feeVote,
+	}}
+
 	getRawMempoolVerboseResult := &types.GetRawMempoolVerboseResult{
 		Size:    15,
 		Time:    time.Time{}.Unix(),
@@ -7263,6 +9668,94 @@ func TestHandleGetRawMempool(t *testing.T) {
 		cmd:             &types.GetRawMempoolCmd{TxType: dcrjson.String("not a type")},
 		wantErr:         true,
 		errCode:         dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:            "handleGetRawMempool: ok paginated",
+		handler:         handleGetRawMempool,
+		mockTxMempooler: pagedTxMempooler,
+		cmd:             &types.GetRawMempoolCmd{Offset: dcrjson.Int(1), Limit: dcrjson.Int(1)},
+		result:          []string{voteHash},
+	}, {
+		name:            "handleGetRawMempool: ok offset past end",
+		handler:         handleGetRawMempool,
+		mockTxMempooler: pagedTxMempooler,
+		cmd:             &types.GetRawMempoolCmd{Offset: dcrjson.Int(5)},
+		result:          []string{},
+	}, {
+		name:            "handleGetRawMempool: ok min fee rate",
+		handler:         handleGetRawMempool,
+		mockTxMempooler: pagedTxMempooler,
+		cmd:             &types.GetRawMempoolCmd{MinFeeRate: dcrjson.Float64(0.0001)},
+		result:          []string{regularHash, ticketHash},
+	}, {
+		name:            "handleGetRawMempool: ok verbose paginated",
+		handler:         handleGetRawMempool,
+		mockTxMempooler: pagedTxMempooler,
+		cmd: &types.GetRawMempoolCmd{
+			Verbose: dcrjson.Bool(true),
+			Limit:   dcrjson.Int(1),
+		},
+		result: map[string]*types.GetRawMempoolVerboseResult{
+			ticketHash: {
+				Size:    15,
+				Fee:     0.0000015,
+				Time:    1,
+				Depends: []string{},
+			},
+		},
+	}, {
+		name:            "handleGetRawMempool: ok summary",
+		handler:         handleGetRawMempool,
+		mockTxMempooler: mockTxMempooler,
+		cmd:             &types.GetRawMempoolCmd{Summary: dcrjson.Bool(true)},
+		result: &types.GetRawMempoolSummaryResult{
+			Count: 6,
+			Bytes: 90,
+			Types: map[string]types.GetRawMempoolSummaryTypeResult{
+				"regular":     {Count: 1, Bytes: 15},
+				"tickets":     {Count: 1, Bytes: 15},
+				"votes":       {Count: 1, Bytes: 15},
+				"revocations": {Count: 1, Bytes: 15},
+				"tspend":      {Count: 1, Bytes: 15},
+				"tadd":        {Count: 1, Bytes: 15},
+			},
+		},
+	}, {
+		name:            "handleGetRawMempool: ok summary with min fee rate",
+		handler:         handleGetRawMempool,
+		mockTxMempooler: pagedTxMempooler,
+		cmd: &types.GetRawMempoolCmd{
+			MinFeeRate: dcrjson.Float64(0.0001),
+			Summary:    dcrjson.Bool(true),
+		},
+		result: &types.GetRawMempoolSummaryResult{
+			Count: 2,
+			Bytes: 30,
+			Types: map[string]types.GetRawMempoolSummaryTypeResult{
+				"regular": {Count: 1, Bytes: 15},
+				"tickets": {Count: 1, Bytes: 15},
+			},
+		},
+	}, {
+		name:            "handleGetRawMempool: invalid min fee rate",
+		handler:         handleGetRawMempool,
+		mockTxMempooler: mockTxMempooler,
+		cmd:             &types.GetRawMempoolCmd{MinFeeRate: dcrjson.Float64(-1)},
+		wantErr:         true,
+		errCode:         dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:            "handleGetRawMempool: negative offset",
+		handler:         handleGetRawMempool,
+		mockTxMempooler: mockTxMempooler,
+		cmd:             &types.GetRawMempoolCmd{Offset: dcrjson.Int(-1)},
+		wantErr:         true,
+		errCode:         dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:            "handleGetRawMempool: negative limit",
+		handler:         handleGetRawMempool,
+		mockTxMempooler: mockTxMempooler,
+		cmd:             &types.GetRawMempoolCmd{Limit: dcrjson.Int(-1)},
+		wantErr:         true,
+		errCode:         dcrjson.ErrRPCInvalidParameter,
 	}})
 }
 
@@ -7386,6 +9879,19 @@ func TestHandleGetRawTransaction(t *testing.T) {
 		Blocktime:     1584248018,
 	}
 
+	verboseBlockResult := verboseResult
+	verboseBlockResult.BlockHash = block432100.BlockHash().String()
+	verboseSideChainResult := verboseBlockResult
+	verboseSideChainResult.Confirmations = 0
+
+	coinbaseTx := block432100.Transactions[0]
+	coinbaseTxid := coinbaseTx.TxHash().String()
+	coinbaseBytes, err := coinbaseTx.Bytes()
+	if err != nil {
+		t.Fatalf("unable to serialize coinbase tx: %v", err)
+	}
+	coinbaseHex := hex.EncodeToString(coinbaseBytes)
+
 	verboseMempoolResult := verboseResult
 	verboseMempoolResult.BlockHash = ""
 	verboseMempoolResult.BlockHeight = 0
@@ -7408,7 +9914,7 @@ func TestHandleGetRawTransaction(t *testing.T) {
 	}
 
 	var tx wire.MsgTx
-	err := tx.FromBytes(hexToBytes(tx0TestTx.hex))
+	err = tx.FromBytes(hexToBytes(tx0TestTx.hex))
 	if err != nil {
 		t.Fatalf("unable to create tx from bytes: %v", err)
 	}
@@ -7427,6 +9933,25 @@ func TestHandleGetRawTransaction(t *testing.T) {
 		return idx
 	}()
 
+	// hintBlockChain returns a mock chain that returns a block which houses the
+	// test transaction at the expected index when it is used as a hint along
+	// with the provided main chain status.
+	hintMsgBlock := block432100
+	hintMsgBlock.Transactions = append([]*wire.MsgTx(nil),
+		block432100.Transactions...)
+	for len(hintMsgBlock.Transactions) < 11 {
+		hintMsgBlock.Transactions = append(hintMsgBlock.Transactions,
+			block432100.Transactions[1])
+	}
+	hintMsgBlock.Transactions = append(hintMsgBlock.Transactions, &tx)
+	hintBlockChain := func(mainChain bool) *testRPCChain {
+		chain := defaultMockRPCChain()
+		chain.blockByHash = dcrutil.NewBlock(&hintMsgBlock)
+		chain.mainChainHasBlock = mainChain
+		chain.treasuryActive = true
+		return chain
+	}
+
 	testRPCServerHandler(t, []rpcTest{{
 		name:    "handleGetRawTransaction: invalid txid",
 		handler: handleGetRawTransaction,
@@ -7707,6 +10232,194 @@ func TestHandleGetRawTransaction(t *testing.T) {
 		}(),
 		wantErr: true,
 		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleGetRawTransaction: invalid block hash",
+		handler: handleGetRawTransaction,
+		cmd: &types.GetRawTransactionCmd{
+			Txid:      txid,
+			Verbose:   &nonVerboseTx,
+			BlockHash: dcrjson.String("invalid"),
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:    "handleGetRawTransaction: block not found",
+		handler: handleGetRawTransaction,
+		cmd: &types.GetRawTransactionCmd{
+			Txid:      txid,
+			Verbose:   &nonVerboseTx,
+			BlockHash: dcrjson.String(block432100.BlockHash().String()),
+		},
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.blockByHashErr = errors.New("block not found")
+			return chain
+		}(),
+		wantErr: true,
+		errCode: dcrjson.ErrRPCBlockNotFound,
+	}, {
+		name:    "handleGetRawTransaction: tx not in block",
+		handler: handleGetRawTransaction,
+		cmd: &types.GetRawTransactionCmd{
+			Txid:      zeroHash.String(),
+			Verbose:   &nonVerboseTx,
+			BlockHash: dcrjson.String(block432100.BlockHash().String()),
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCNoTxInfo,
+	}, {
+		name:    "handleGetRawTransaction: ok, not verbose, tx from block",
+		handler: handleGetRawTransaction,
+		cmd: &types.GetRawTransactionCmd{
+			Txid:      txid,
+			Verbose:   &nonVerboseTx,
+			BlockHash: dcrjson.String(block432100.BlockHash().String()),
+		},
+		setTxIndexerNil: true,
+		mockTxMempooler: txPool,
+		mockChain:       hintBlockChain(true),
+		result:          nonVerboseResult,
+	}, {
+		name:    "handleGetRawTransaction: ok, verbose, tx from block",
+		handler: handleGetRawTransaction,
+		cmd: &types.GetRawTransactionCmd{
+			Txid:      txid,
+			Verbose:   &verboseTx,
+			BlockHash: dcrjson.String(block432100.BlockHash().String()),
+		},
+		setTxIndexerNil: true,
+		mockTxMempooler: txPool,
+		mockChain:       hintBlockChain(true),
+		result:          verboseBlockResult,
+	}, {
+		name:    "handleGetRawTransaction: ok, verbose, tx from side chain block",
+		handler: handleGetRawTransaction,
+		cmd: &types.GetRawTransactionCmd{
+			Txid:      txid,
+			Verbose:   &verboseTx,
+			BlockHash: dcrjson.String(block432100.BlockHash().String()),
+		},
+		setTxIndexerNil: true,
+		mockTxMempooler: txPool,
+		mockChain:       hintBlockChain(false),
+		result:          verboseSideChainResult,
+	}, {
+		name:    "handleGetRawTransactions: no txids",
+		handler: handleGetRawTransactions,
+		cmd: &types.GetRawTransactionsCmd{
+			Verbose: &nonVerboseTx,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleGetRawTransactions: too many txids",
+		handler: handleGetRawTransactions,
+		cmd: &types.GetRawTransactionsCmd{
+			Txids:   make([]string, maxGetRawTransactionsTxns+1),
+			Verbose: &nonVerboseTx,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCInvalidParameter,
+	}, {
+		name:    "handleGetRawTransactions: invalid txid",
+		handler: handleGetRawTransactions,
+		cmd: &types.GetRawTransactionsCmd{
+			Txids:   []string{txid, "invalid"},
+			Verbose: &nonVerboseTx,
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCDecodeHexString,
+	}, {
+		name:    "handleGetRawTransactions: one tx not in block",
+		handler: handleGetRawTransactions,
+		cmd: &types.GetRawTransactionsCmd{
+			Txids:     []string{txid, zeroHash.String()},
+			Verbose:   &nonVerboseTx,
+			BlockHash: dcrjson.String(block432100.BlockHash().String()),
+		},
+		wantErr: true,
+		errCode: dcrjson.ErrRPCNoTxInfo,
+	}, {
+		name:    "handleGetRawTransactions: ok, not verbose, txns from block",
+		handler: handleGetRawTransactions,
+		cmd: &types.GetRawTransactionsCmd{
+			Txids:     []string{txid, coinbaseTxid, txid},
+			Verbose:   &nonVerboseTx,
+			BlockHash: dcrjson.String(block432100.BlockHash().String()),
+		},
+		setTxIndexerNil: true,
+		mockTxMempooler: txPool,
+		mockChain:       hintBlockChain(true),
+		result:          []string{nonVerboseResult, coinbaseHex, nonVerboseResult},
+	}, {
+		name:    "handleGetRawTransactions: ok, verbose, txns from mempool",
+		handler: handleGetRawTransactions,
+		cmd: &types.GetRawTransactionsCmd{
+			Txids:   []string{txid, txid},
+			Verbose: &verboseTx,
+		},
+		mockTxMempooler: func() *testTxMempooler {
+			mp := defaultMockTxMempooler()
+			mp.fetchTransaction = dcrutil.NewTx(&tx)
+			mp.fetchTransactionErr = nil
+			return mp
+		}(),
+		mockChain: func() *testRPCChain {
+			chain := defaultMockRPCChain()
+			chain.treasuryActive = true
+			return chain
+		}(),
+		result: []types.TxRawResult{verboseMempoolResult,
+			verboseMempoolResult},
+	}})
+}
+
+func TestHandleGetRebroadcastTxs(t *testing.T) {
+	t.Parallel()
+
+	connManagerWithTxns := func(txns []RebroadcastTx) *testConnManager {
+		connManager := defaultMockConnManager()
+		connManager.rebroadcastTxns = txns
+		return connManager
+	}
+
+	txHash := block432100.Transactions[0].TxHash()
+	txHash2 := block432100.Transactions[1].TxHash()
+	added := time.Unix(1592931200, 0)
+	testRPCServerHandler(t, []rpcTest{{
+		name:            "handleGetRebroadcastTxs: no transactions",
+		handler:         handleGetRebroadcastTxs,
+		cmd:             &types.GetRebroadcastTxsCmd{},
+		mockConnManager: connManagerWithTxns(nil),
+		result:          []types.RebroadcastTxResult{},
+	}, {
+		name:    "handleGetRebroadcastTxs: ok",
+		handler: handleGetRebroadcastTxs,
+		cmd:     &types.GetRebroadcastTxsCmd{},
+		mockConnManager: connManagerWithTxns([]RebroadcastTx{{
+			Hash:          txHash,
+			Added:         added,
+			NextBroadcast: added.Add(5 * time.Minute),
+		}, {
+			Hash:          txHash2,
+			Added:         added.Add(time.Minute),
+			LastBroadcast: added.Add(11 * time.Minute),
+			NextBroadcast: added.Add(21 * time.Minute),
+			Broadcasts:    2,
+		}}),
+		result: []types.RebroadcastTxResult{{
+			TxID:          txHash.String(),
+			Added:         1592931200,
+			LastBroadcast: 0,
+			NextBroadcast: 1592931500,
+			Broadcasts:    0,
+		}, {
+			TxID:          txHash2.String(),
+			Added:         1592931260,
+			LastBroadcast: 1592931860,
+			NextBroadcast: 1592932460,
+			Broadcasts:    2,
+		}},
 	}})
 }
 
@@ -7890,6 +10603,12 @@ func testRPCServerHandler(t *testing.T, tests []rpcTest) {
 			if test.setTxIndexerNil {
 				rpcserverConfig.TxIndexer = nil
 			}
+			if test.mockNullDataIndexer != nil {
+				rpcserverConfig.NullDataIndexer = test.mockNullDataIndexer
+			}
+			if test.mockUtxoHistIndexer != nil {
+				rpcserverConfig.UtxoHistoryIndexer = test.mockUtxoHistIndexer
+			}
 			if test.mockDB != nil {
 				rpcserverConfig.DB = test.mockDB
 			}
@@ -7899,12 +10618,18 @@ func testRPCServerHandler(t *testing.T, tests []rpcTest) {
 			if test.mockClock != nil {
 				rpcserverConfig.Clock = test.mockClock
 			}
+			if test.setMockTimeSourceNil {
+				rpcserverConfig.MockTimeSource = nil
+			}
 			if test.mockFeeEstimator != nil {
 				rpcserverConfig.FeeEstimator = test.mockFeeEstimator
 			}
 			if test.mockLogManager != nil {
 				rpcserverConfig.LogManager = test.mockLogManager
 			}
+			if test.mockConfigReloader != nil {
+				rpcserverConfig.ConfigReloader = test.mockConfigReloader
+			}
 			if test.mockSanityChecker != nil {
 				rpcserverConfig.SanityChecker = test.mockSanityChecker
 			}
@@ -7939,10 +10664,12 @@ func testRPCServerHandler(t *testing.T, tests []rpcTest) {
 			}
 
 			testServer := &Server{
-				cfg:        *rpcserverConfig,
-				ntfnMgr:    new(testNtfnManager),
-				workState:  workState,
-				helpCacher: helpCacher,
+				cfg:           *rpcserverConfig,
+				ntfnMgr:       new(testNtfnManager),
+				workState:     workState,
+				helpCacher:    helpCacher,
+				limiter:       newClientLimiter(0, 0, 0),
+				responseCache: newResponseCache(0),
 			}
 			result, err := test.handler(nil, testServer, test.cmd)
 			if test.wantErr {
@@ -7953,6 +10680,11 @@ func testRPCServerHandler(t *testing.T, tests []rpcTest) {
 					} else {
 						t.Errorf("%s\nwant: %+v\n got: nil\n", test.name, test.errCode)
 					}
+					return
+				}
+				if test.errData != nil && !reflect.DeepEqual(rpcErr.Data, test.errData) {
+					t.Errorf("%s\nwant data: %+v\n got data: %+v\n", test.name,
+						spew.Sdump(test.errData), spew.Sdump(rpcErr.Data))
 				}
 				return
 			}
@@ -7966,3 +10698,53 @@ func testRPCServerHandler(t *testing.T, tests []rpcTest) {
 		})
 	}
 }
+
+// TestCreateMarshalledReply ensures errors in replies are always mapped to one
+// of the stable RPC error codes.
+func TestCreateMarshalledReply(t *testing.T) {
+	tests := []struct {
+		name     string
+		replyErr error
+		want     string
+	}{{
+		name:     "no error",
+		replyErr: nil,
+		want:     `{"jsonrpc":"1.0","result":"ok","error":null,"id":1}`,
+	}, {
+		name:     "rpc error",
+		replyErr: rpcDecodeHexError("zz"),
+		want:     `{"jsonrpc":"1.0","result":null,"error":{"code":-22,"message":"Argument must be hexadecimal string (not \"zz\")"},"id":1}`,
+	}, {
+		name:     "wrapped rpc error",
+		replyErr: fmt.Errorf("context: %w", rpcMiscError("misc")),
+		want:     `{"jsonrpc":"1.0","result":null,"error":{"code":-1,"message":"misc"},"id":1}`,
+	}, {
+		name:     "rpc error value",
+		replyErr: dcrjson.RPCError{Code: dcrjson.ErrRPCDatabase, Message: "db"},
+		want:     `{"jsonrpc":"1.0","result":null,"error":{"code":-20,"message":"db"},"id":1}`,
+	}, {
+		name:     "unknown code",
+		replyErr: dcrjson.NewRPCError(-12345, "unknown"),
+		want:     `{"jsonrpc":"1.0","result":null,"error":{"code":-32603,"message":"unknown"},"id":1}`,
+	}, {
+		name:     "non-rpc error",
+		replyErr: errors.New("some error"),
+		want:     `{"jsonrpc":"1.0","result":null,"error":{"code":-32603,"message":"some error"},"id":1}`,
+	}}
+
+	for _, test := range tests {
+		var result interface{}
+		if test.replyErr == nil {
+			result = "ok"
+		}
+		reply, err := createMarshalledReply("1.0", 1, result, test.replyErr)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.name, err)
+			continue
+		}
+		if string(reply) != test.want {
+			t.Errorf("%q: mismatched reply -- got %s, want %s", test.name,
+				reply, test.want)
+		}
+	}
+}
@@ -30,11 +30,30 @@ var helpDescsEnUS = map[string]string{
 	"debuglevel--result0":    "The string 'Done.'",
 	"debuglevel--result1":    "The list of subsystems",
 
+	// AbandonRebroadcastTxCmd help.
+	"abandonrebroadcasttx--synopsis": "Stops automatically rebroadcasting a transaction that was previously sent via sendrawtransaction.",
+	"abandonrebroadcasttx-txhash":    "The hash of the transaction to stop rebroadcasting",
+
 	// AddNodeCmd help.
 	"addnode--synopsis": "Attempts to add or remove a persistent peer.",
 	"addnode-addr":      "IP address and port of the peer to operate on",
 	"addnode-subcmd":    "'add' to add a persistent peer, 'remove' to remove a persistent peer, or 'onetry' to try a single connection to a peer",
 
+	// ApproveDeepReorgCmd help.
+	"approvedeepreorg--synopsis": "Approves a chain reorganization to the branch that contains the provided block even though it disconnects more blocks than the maximum automatic reorganization depth allows.\n" +
+		"Reorganizations deeper than the depth configured via the --maxreorgdepth option are paused until approved.",
+	"approvedeepreorg-blockhash": "The hash of a block on the branch to reorganize to",
+
+	// CheckBlockConflictsCmd help.
+	"checkblockconflicts--synopsis": "Returns which transactions in the mempool would be removed as a result of connecting the provided block to the main chain without modifying the mempool.\n" +
+		"This is primarily intended for miners to determine the effect a block would have on the mempool prior to working on it.",
+	"checkblockconflicts-hexblock": "Serialized, hex-encoded block",
+
+	// CheckBlockConflictsResult help.
+	"checkblockconflictsresult-mined":       "The hashes of the mempool transactions that are also included in the block",
+	"checkblockconflictsresult-doublespent": "The hashes of the mempool transactions that spend an output also spent by a different transaction in the block",
+	"checkblockconflictsresult-evicted":     "The hashes of all mempool transactions that would be evicted, including the double spent transactions and all transactions that depend on them",
+
 	// NodeCmd help.
 	"node--synopsis":     "Attempts to add or remove a peer.",
 	"node-subcmd":        "'disconnect' to remove all matching non-persistent peers, 'remove' to remove a persistent peer, or 'connect' to connect to a peer",
@@ -189,12 +208,22 @@ var helpDescsEnUS = map[string]string{
 	"existsmempooltxs-txhashes":  "Array of hashes to check",
 	"existsmempooltxs--result0":  "Bool blob showing if txs exist in the mempool or not",
 
+	// ForcePruneCmd help.
+	"forceprune--synopsis": "Immediately removes expired transactions from the mempool and from the transactions being automatically rebroadcast instead of waiting for the next block (requires --debugrpc, simnet or regnet only).",
+
 	// GenerateCmd help
 	"generate--synopsis": "Generates a set number of blocks (simnet or regtest only) and returns a JSON\n" +
 		" array of their hashes.",
 	"generate-numblocks": "Number of blocks to generate",
 	"generate--result0":  "The hashes, in order, of blocks generated by the call",
 
+	// GenerateToAddressCmd help
+	"generatetoaddress--synopsis": "Generates a set number of blocks that pay to the provided address (simnet or regtest only)\n" +
+		" and returns a JSON array of their hashes.",
+	"generatetoaddress-numblocks": "Number of blocks to generate",
+	"generatetoaddress-address":   "The address the coinbase of each generated block pays to",
+	"generatetoaddress--result0":  "The hashes, in order, of blocks generated by the call",
+
 	// GetAddedNodeInfoResultAddr help.
 	"getaddednodeinforesultaddr-address":   "The ip address for this DNS entry",
 	"getaddednodeinforesultaddr-connected": "The connection 'direction' (inbound/outbound/false)",
@@ -244,7 +273,7 @@ var helpDescsEnUS = map[string]string{
 	"getblockchaininforesult-bestblockhash":        "The block hash of the current best chain tip.",
 	"getblockchaininforesult-difficulty":           "(DEPRECATED) The current network difficulty.",
 	"getblockchaininforesult-difficultyratio":      "The current proof-of-work difficulty as a multiple of the minimum difficulty.",
-	"getblockchaininforesult-verificationprogress": "The chain verification progress estimate.",
+	"getblockchaininforesult-verificationprogress": "The chain verification progress estimate in the range [0, 1] based on the cumulative work of the best chain relative to the estimated cumulative work of the network.",
 	"getblockchaininforesult-chainwork":            "Hex encoded total work done for the chain.",
 	"getblockchaininforesult-initialblockdownload": "Best guess of whether this node is in the initial block download mode used to catch up the chain when it is far behind",
 	"getblockchaininforesult-maxblocksize":         "The maximum allowed block size.",
@@ -347,6 +376,34 @@ var helpDescsEnUS = map[string]string{
 	"getblockheaderverboseresult-extradata":         "Extra data field for the requested block",
 	"getblockheaderverboseresult-stakeversion":      "The stake version of the block",
 
+	// GetBlockHeaderProofCmd help.
+	"getblockheaderproof--synopsis": "Returns a merkle inclusion proof for a transaction in a main chain block along with the chain of block headers that connects the block to an anchor block.\n" +
+		"The proof commits to the header field specified by the root in the result and the headers allow the proof of work of the block to be verified starting from the anchor.",
+	"getblockheaderproof-txhash":     "The hash of the transaction to prove",
+	"getblockheaderproof-blockhash":  "The hash of the main chain block that contains the transaction",
+	"getblockheaderproof-anchorhash": "The hash of an ancestor of the block in the main chain to build the header chain from (default: the assumed valid block when it is an ancestor of the block, otherwise the genesis block)",
+
+	// GetBlockHeaderProofResult help.
+	"getblockheaderproofresult-txid":         "The hash of the transaction",
+	"getblockheaderproofresult-leafhash":     "The full hash of the transaction (including witness data) which is the leaf committed to by the merkle tree",
+	"getblockheaderproofresult-tree":         "The transaction tree the transaction is in (0 = regular, 1 = stake)",
+	"getblockheaderproofresult-blockhash":    "The hash of the block that contains the transaction",
+	"getblockheaderproofresult-height":       "The height of the block that contains the transaction",
+	"getblockheaderproofresult-root":         "The merkle root from the block header the proof commits to",
+	"getblockheaderproofresult-proofindex":   "The index of the leaf in the merkle tree that the proof commits to",
+	"getblockheaderproofresult-proofhashes":  "The hashes needed to prove the leaf is committed to by the merkle root",
+	"getblockheaderproofresult-anchorhash":   "The hash of the anchor block the header chain builds from",
+	"getblockheaderproofresult-anchorheight": "The height of the anchor block",
+	"getblockheaderproofresult-headers":      "The serialized block headers from the block after the anchor through the block that contains the transaction in ascending order",
+
+	// GetBlockHeadersCmd help.
+	"getblockheaders--synopsis":   "Returns a contiguous range of main chain block headers starting at the provided height.",
+	"getblockheaders-startheight": "The height of the first block header to return",
+	"getblockheaders-count":       "The maximum number of block headers to return (max: 2000)",
+	"getblockheaders-verbose":     "Specifies the block headers are returned as JSON objects instead of hex-encoded strings",
+	"getblockheaders--condition0": "verbose=false",
+	"getblockheaders--condition1": "verbose=true",
+
 	// GetBlockSubsidyCmd help.
 	"getblocksubsidy--synopsis": "Returns information regarding subsidy amounts.",
 	"getblocksubsidy-height":    "The block height",
@@ -396,6 +453,17 @@ var helpDescsEnUS = map[string]string{
 	"getdifficulty--synopsis": "Returns the proof-of-work difficulty as a multiple of the minimum difficulty.",
 	"getdifficulty--result0":  "The difficulty",
 
+	// GetSideChainBlocksCmd help.
+	"getsidechainblocks--synopsis": "Returns all blocks that are not part of the main chain and have their data available ordered from the most stale side chain to the least stale side chain.\n" +
+		"Blocks that are part of a side chain with more work than the main chain are not included.",
+
+	// SideChainBlockResult help.
+	"sidechainblockresult-hash":       "The hash of the side chain block",
+	"sidechainblockresult-height":     "The height of the side chain block",
+	"sidechainblockresult-forkhash":   "The hash of the main chain block the side chain forks from",
+	"sidechainblockresult-forkheight": "The height of the main chain block the side chain forks from",
+	"sidechainblockresult-depth":      "The number of blocks the main chain tip is past the fork point of the side chain",
+
 	// GetStakeDifficultyCmd help.
 	"getstakedifficulty--synopsis":     "Returns the proof-of-stake difficulty.",
 	"getstakedifficultyresult-current": "The current top block's stake difficulty",
@@ -428,8 +496,9 @@ var helpDescsEnUS = map[string]string{
 	"versionbits-bits":                     "The bits assigned by the vote.",
 
 	// GetVoteInfo
-	"getvoteinfo--synopsis":           "Returns the vote info statistics.",
+	"getvoteinfo--synopsis":           "Returns the vote info statistics for the current voting window or the voting window of the main chain that contains the provided height.",
 	"getvoteinfo-version":             "The stake version.",
+	"getvoteinfo-height":              "A height within the voting window to return the statistics for or -1 for the current voting window.",
 	"getvoteinforesult-currentheight": "Top of the chain height.",
 	"getvoteinforesult-startheight":   "The start height of this voting window.",
 	"getvoteinforesult-endheight":     "The end height of this voting window.",
@@ -445,6 +514,7 @@ var helpDescsEnUS = map[string]string{
 	"agenda-expiretime":               "Time agenda becomes invalid.",
 	"agenda-status":                   "Agenda status.",
 	"agenda-quorumprogress":           "Progress of quorum reached.",
+	"agenda-participation":            "Portion of the votes that did not abstain.",
 	"agenda-choices":                  "All choices in this agenda.",
 	"choice-id":                       "Unique identifier of this choice.",
 	"choice-description":              "Description of this choice.",
@@ -454,6 +524,25 @@ var helpDescsEnUS = map[string]string{
 	"choice-count":                    "How many votes received.",
 	"choice-progress":                 "Progress of the overall count.",
 
+	// GetVoteStatsCmd help.
+	"getvotestats--synopsis":         "Returns statistics about the number of eligible votes that were included versus missed in the main chain blocks within the provided inclusive height range.",
+	"getvotestats-startheight":       "The height of the first block to include",
+	"getvotestats-endheight":         "The height of the last block to include (-1 for the current best chain tip)",
+	"getvotestats-verbose":           "Include the statistics for each individual block",
+	"getvotestatsresult-startheight": "The height of the first block included",
+	"getvotestatsresult-endheight":   "The height of the last block included",
+	"getvotestatsresult-voteblocks":  "The number of blocks in the range for which votes were eligible",
+	"getvotestatsresult-eligible":    "The total number of votes eligible to be included",
+	"getvotestatsresult-included":    "The total number of votes included",
+	"getvotestatsresult-missed":      "The total number of eligible votes that were missed",
+	"getvotestatsresult-votecounts":  "The number of blocks for which votes were eligible that included each possible number of votes indexed by the number of votes",
+	"getvotestatsresult-blocks":      "The statistics for each block in the range (only when verbose)",
+	"votestatsblock-hash":            "The hash of the block",
+	"votestatsblock-height":          "The height of the block",
+	"votestatsblock-eligible":        "The number of votes eligible to be included in the block",
+	"votestatsblock-included":        "The number of votes included in the block",
+	"votestatsblock-missed":          "The number of eligible votes missed by the block",
+
 	// GetGenerateCmd help.
 	"getgenerate--synopsis": "Returns if the server is set to generate coins (mine) or not.",
 	"getgenerate--result0":  "True if mining, false if not",
@@ -493,6 +582,22 @@ var helpDescsEnUS = map[string]string{
 	"infowalletresult-relayfee":        "The minimum relay fee for non-free transactions in DCR/KB",
 	"infowalletresult-errors":          "Any current errors",
 
+	// GetHeaderCommitmentsCmd help.
+	"getheadercommitments--synopsis": "Returns the individual commitments that the commitment root of the header for the given block commits to along with proofs that can be used to prove each commitment is committed to by the block header",
+	"getheadercommitments-blockhash": "The block hash of the header commitments to retrieve",
+
+	// GetHeaderCommitmentsResult help.
+	"getheadercommitmentsresult-blockhash":      "The block hash associated with the header commitments",
+	"getheadercommitmentsresult-version":        "The header commitment version that defines the data the header commits to",
+	"getheadercommitmentsresult-commitmentroot": "The commitment root from the block header",
+	"getheadercommitmentsresult-commitments":    "The individual header commitments in proof index order",
+
+	// HeaderCommitmentResult help.
+	"headercommitmentresult-name":        "The name of the data the commitment commits to",
+	"headercommitmentresult-hash":        "The commitment hash of the data",
+	"headercommitmentresult-proofindex":  "The index of the leaf that represents the commitment hash in the header commitment",
+	"headercommitmentresult-proofhashes": "The hashes needed to prove the commitment is committed to by the header commitment",
+
 	// GetHeadersCmd help.
 	"getheaders--synopsis":     "Returns block headers starting with the first known block hash from the request",
 	"getheaders-blocklocators": "Array of block locator hashes.  Headers are returned starting from the first known hash in this list",
@@ -509,6 +614,18 @@ var helpDescsEnUS = map[string]string{
 	"getmempoolinforesult-bytes": "Size in bytes of the mempool",
 	"getmempoolinforesult-size":  "Number of transactions in the mempool",
 
+	// GetMempoolJournalCmd help.
+	"getmempooljournal--synopsis": "Returns the most recent events recorded in the mempool event journal ordered from oldest to newest.\n" +
+		"The events describe transactions being accepted to the mempool, rejected, evicted from the mempool without being mined, or mined.\n" +
+		"The result is empty when the journal is disabled.",
+	"getmempooljournal-txid": "Only return the events for the transaction with this hash",
+
+	// MempoolEventResult help.
+	"mempooleventresult-time":   "The time the event occurred in seconds since 1 Jan 1970 GMT",
+	"mempooleventresult-event":  "The kind of event (accepted, rejected, evicted, or mined)",
+	"mempooleventresult-txid":   "The hash of the transaction the event is for",
+	"mempooleventresult-reason": "The reason the transaction was rejected or evicted (omitted for other events)",
+
 	// GetMiningInfoResult help.
 	"getmininginforesult-blocks":           "Height of the latest best block",
 	"getmininginforesult-currentblocksize": "Size of the latest best block",
@@ -532,6 +649,20 @@ var helpDescsEnUS = map[string]string{
 	"getnetworkhashps-height":    "Perform estimate ending with this height or -1 for current best chain block height",
 	"getnetworkhashps--result0":  "Estimated hashes per second",
 
+	// GetNullDataCmd help.
+	"getnulldata--synopsis":   "Returns the null data (OP_RETURN) payloads in the main chain that start with the provided prefix within a range of block heights in order of their location in the chain.  At most 1000 entries are returned.  This requires the null data index to be enabled with the provided prefix.",
+	"getnulldata-prefix":      "The hex-encoded prefix the payloads start with",
+	"getnulldata-startheight": "The height of the first block to return payloads for",
+	"getnulldata-endheight":   "The height of the final block to return payloads for or -1 for the current best chain block height",
+
+	// GetNullDataResult help.
+	"getnulldataresult-blockhash": "The hash of the block that contains the transaction",
+	"getnulldataresult-height":    "The height of the block that contains the transaction",
+	"getnulldataresult-txid":      "The hash of the transaction that contains the null data output",
+	"getnulldataresult-tree":      "The transaction tree the transaction is in (0 = regular, 1 = stake)",
+	"getnulldataresult-vout":      "The index of the null data output in the transaction",
+	"getnulldataresult-payload":   "The hex-encoded data pushed by the null data output",
+
 	// GetNetworkInfoCmd help.
 	"getnetworkinfo--synopsis": "Returns a JSON object containing network-related information.",
 
@@ -557,6 +688,58 @@ var helpDescsEnUS = map[string]string{
 	"getnetworkinforesult-relayfee":        "The minimum required transaction fee for the node.",
 	"getnetworkinforesult-localaddresses":  "An array of objects describing local addresses being listened on by the node",
 	"getnetworkinforesult-localservices":   "The services supported by the node, as advertised in its version message",
+	"getnetworkinforesult-agentrejected":   "The total number of peers rejected since start due to their user agent matching a --rejectagent pattern",
+	"getnetworkinforesult-agentevicted":    "The total number of inbound peers evicted since start due to their user agent matching a --deprioritizeagent pattern",
+
+	// GetNodeInfoCmd help.
+	"getnodeinfo--synopsis": "Returns a JSON object containing the version, build, uptime, and configuration details of the node.",
+
+	// GetNodeInfoResult help.
+	"getnodeinforesult-version":         "The version of the node software",
+	"getnodeinforesult-rpcapiversion":   "The version of the JSON-RPC API supported by the node",
+	"getnodeinforesult-commit":          "The build metadata of the node software, which is typically the commit it was built from",
+	"getnodeinforesult-goversion":       "The version of Go the node software was built with",
+	"getnodeinforesult-useragent":       "The user agent version of the node, as advertised to peers",
+	"getnodeinforesult-protocolversion": "The protocol version of the node",
+	"getnodeinforesult-network":         "The name of the network the node is running on",
+	"getnodeinforesult-starttime":       "The time the node started in seconds since 1 Jan 1970 GMT",
+	"getnodeinforesult-uptime":          "The number of seconds the node has been running",
+	"getnodeinforesult-indexes":         "The names of the optional indexes that are enabled",
+	"getnodeinforesult-features":        "The names of the optional features that are enabled (cfilters, mining, rpcauditlog)",
+	"getnodeinforesult-pruned":          "Whether or not the node has pruned block data (always false since pruning is not supported)",
+	"getnodeinforesult-policy":          "The transaction relay and acceptance policy of the node",
+	"getnodeinforesult-rpcserver":       "The client details and counters of the RPC server",
+
+	// NodePolicyResult help.
+	"nodepolicyresult-relayfee":          "The minimum required transaction fee for the node",
+	"nodepolicyresult-acceptnonstd":      "Whether or not non-standard transactions are accepted",
+	"nodepolicyresult-maxorphantxs":      "The maximum number of orphan transactions kept in memory",
+	"nodepolicyresult-maxstandardtxsize": "The maximum size of a standard transaction in bytes",
+
+	// NodeRPCServerResult help.
+	"noderpcserverresult-clients":            "The number of standard RPC clients currently connected",
+	"noderpcserverresult-websockets":         "The number of websocket RPC clients currently connected",
+	"noderpcserverresult-rejectedwebsockets": "The total number of websocket clients disconnected since start due to exceeding the per IP limit",
+	"noderpcserverresult-slowwebsockets":     "The total number of websocket clients disconnected since start due to exceeding the queued notification limit",
+	"noderpcserverresult-authfailures":       "The total number of authentication failures since start",
+	"noderpcserverresult-authlockouts":       "The total number of times an IP address was locked out from authenticating since start due to repeated authentication failures",
+	"noderpcserverresult-lockedout":          "The number of IP addresses currently locked out from authenticating",
+	"noderpcserverresult-responsecache":      "The counters of the cache for immutable responses such as those for deeply confirmed blocks and transactions",
+
+	// NodeRPCResponseCacheResult help.
+	"noderpcresponsecacheresult-hits":     "The total number of cacheable requests served from the cache since start",
+	"noderpcresponsecacheresult-misses":   "The total number of cacheable requests not served from the cache since start",
+	"noderpcresponsecacheresult-entries":  "The number of responses currently in the cache",
+	"noderpcresponsecacheresult-bytes":    "The approximate number of bytes currently used by the cache",
+	"noderpcresponsecacheresult-maxbytes": "The maximum approximate number of bytes the cache may use (0 when disabled)",
+
+	// VersionResult help.
+	"versionresult-versionstring": "The semantic version string",
+	"versionresult-major":         "The major component of the version",
+	"versionresult-minor":         "The minor component of the version",
+	"versionresult-patch":         "The patch component of the version",
+	"versionresult-prerelease":    "The pre-release component of the version, if any",
+	"versionresult-buildmetadata": "The build metadata component of the version, if any",
 
 	// GetNetTotalsCmd help.
 	"getnettotals--synopsis": "Returns a JSON object containing network traffic statistics.",
@@ -567,26 +750,37 @@ var helpDescsEnUS = map[string]string{
 	"getnettotalsresult-timemillis":     "Number of milliseconds since 1 Jan 1970 GMT",
 
 	// GetPeerInfoResult help.
-	"getpeerinforesult-id":             "A unique node ID",
-	"getpeerinforesult-addr":           "The ip address and port of the peer",
-	"getpeerinforesult-addrlocal":      "Local address",
-	"getpeerinforesult-services":       "Services bitmask which represents the services supported by the peer",
-	"getpeerinforesult-relaytxes":      "Peer has requested transactions be relayed to it",
-	"getpeerinforesult-lastsend":       "Time the last message was received in seconds since 1 Jan 1970 GMT",
-	"getpeerinforesult-lastrecv":       "Time the last message was sent in seconds since 1 Jan 1970 GMT",
-	"getpeerinforesult-bytessent":      "Total bytes sent",
-	"getpeerinforesult-bytesrecv":      "Total bytes received",
-	"getpeerinforesult-conntime":       "Time the connection was made in seconds since 1 Jan 1970 GMT",
-	"getpeerinforesult-timeoffset":     "The time offset of the peer",
-	"getpeerinforesult-pingtime":       "Number of microseconds the last ping took",
-	"getpeerinforesult-pingwait":       "Number of microseconds a queued ping has been waiting for a response",
-	"getpeerinforesult-version":        "The protocol version of the peer",
-	"getpeerinforesult-subver":         "The user agent of the peer",
-	"getpeerinforesult-inbound":        "Whether or not the peer is an inbound connection",
-	"getpeerinforesult-startingheight": "The latest block height the peer knew about when the connection was established",
-	"getpeerinforesult-currentheight":  "The current height of the peer",
-	"getpeerinforesult-banscore":       "The ban score",
-	"getpeerinforesult-syncnode":       "Whether or not the peer is the sync peer",
+	"getpeerinforesult-id":                       "A unique node ID",
+	"getpeerinforesult-addr":                     "The ip address and port of the peer",
+	"getpeerinforesult-addrlocal":                "Local address",
+	"getpeerinforesult-services":                 "Services bitmask which represents the services supported by the peer",
+	"getpeerinforesult-relaytxes":                "Peer has requested transactions be relayed to it",
+	"getpeerinforesult-lastsend":                 "Time the last message was received in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-lastrecv":                 "Time the last message was sent in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-bytessent":                "Total bytes sent",
+	"getpeerinforesult-bytesrecv":                "Total bytes received",
+	"getpeerinforesult-bytessent_per_msg":        "Total bytes sent by message type",
+	"getpeerinforesult-bytessent_per_msg--desc":  "Total bytes sent broken down by message type",
+	"getpeerinforesult-bytessent_per_msg--key":   "command",
+	"getpeerinforesult-bytessent_per_msg--value": "n",
+	"getpeerinforesult-bytesrecv_per_msg":        "Total bytes received by message type",
+	"getpeerinforesult-bytesrecv_per_msg--desc":  "Total bytes received broken down by message type",
+	"getpeerinforesult-bytesrecv_per_msg--key":   "command",
+	"getpeerinforesult-bytesrecv_per_msg--value": "n",
+	"getpeerinforesult-conntime":                 "Time the connection was made in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-timeoffset":               "The time offset of the peer",
+	"getpeerinforesult-pingtime":                 "Number of microseconds the last ping took",
+	"getpeerinforesult-pingwait":                 "Number of microseconds a queued ping has been waiting for a response",
+	"getpeerinforesult-pingp50":                  "Median number of microseconds of the most recent pings",
+	"getpeerinforesult-pingp90":                  "90th percentile number of microseconds of the most recent pings",
+	"getpeerinforesult-pingp99":                  "99th percentile number of microseconds of the most recent pings",
+	"getpeerinforesult-version":                  "The protocol version of the peer",
+	"getpeerinforesult-subver":                   "The user agent of the peer",
+	"getpeerinforesult-inbound":                  "Whether or not the peer is an inbound connection",
+	"getpeerinforesult-startingheight":           "The latest block height the peer knew about when the connection was established",
+	"getpeerinforesult-currentheight":            "The current height of the peer",
+	"getpeerinforesult-banscore":                 "The ban score",
+	"getpeerinforesult-syncnode":                 "Whether or not the peer is the sync peer",
 
 	// GetPeerInfoCmd help.
 	"getpeerinfo--synopsis": "Returns data about each connected network peer as an array of json objects.",
@@ -600,12 +794,30 @@ var helpDescsEnUS = map[string]string{
 	"getrawmempoolverboseresult-currentpriority":  "Current priority",
 	"getrawmempoolverboseresult-depends":          "Unconfirmed transactions used as inputs for this transaction",
 
+	// GetRawMempoolSummaryResult help.
+	"getrawmempoolsummaryresult-count":        "Number of matching transactions",
+	"getrawmempoolsummaryresult-bytes":        "Total serialized size of the matching transactions in bytes",
+	"getrawmempoolsummaryresult-types":        "Matching transactions by type",
+	"getrawmempoolsummaryresult-types--desc":  "Number and size of the matching transactions for each type",
+	"getrawmempoolsummaryresult-types--key":   "The transaction type (regular/tickets/votes/revocations/tspend/tadd)",
+	"getrawmempoolsummaryresult-types--value": "The number and size of the matching transactions of the type",
+
+	// GetRawMempoolSummaryTypeResult help.
+	"getrawmempoolsummarytyperesult-count": "Number of matching transactions of the type",
+	"getrawmempoolsummarytyperesult-bytes": "Total serialized size of the matching transactions of the type in bytes",
+
 	// GetRawMempoolCmd help.
-	"getrawmempool--synopsis":   "Returns information about all of the transactions currently in the memory pool.",
+	"getrawmempool--synopsis": "Returns information about all of the transactions currently in the memory pool.\n" +
+		"Paginated results are ordered by the time the transactions entered the pool followed by their hashes.",
 	"getrawmempool-verbose":     "Returns JSON object when true or an array of transaction hashes when false",
 	"getrawmempool-txtype":      "Type of tx to return (regular/tickets/votes/revocations/tspend/tadd/all)",
+	"getrawmempool-minfeerate":  "Only return transactions that pay at least this fee rate in DCR/kB",
+	"getrawmempool-offset":      "Number of matching transactions to skip",
+	"getrawmempool-limit":       "Maximum number of transactions to return (0 for no limit)",
+	"getrawmempool-summary":     "Returns the number and size of the matching transactions by type instead of the transactions when true",
 	"getrawmempool--condition0": "verbose=false",
 	"getrawmempool--condition1": "verbose=true",
+	"getrawmempool--condition2": "summary=true",
 	"getrawmempool--result0":    "Array of transaction hashes",
 
 	// GetRawTransactionCmd help.
@@ -615,6 +827,26 @@ var helpDescsEnUS = map[string]string{
 	"getrawtransaction--condition0": "verbose=false",
 	"getrawtransaction--condition1": "verbose=true",
 	"getrawtransaction--result0":    "Hex-encoded bytes of the serialized transaction",
+	"getrawtransaction-blockhash":   "The hash of the block that contains the transaction which avoids the need for the transaction index",
+
+	// GetRawTransactionsCmd help.
+	"getrawtransactions--synopsis":   "Returns information about multiple transactions given their hashes in the same order they were requested.",
+	"getrawtransactions-txids":       "The hashes of the transactions (max: 2000)",
+	"getrawtransactions-verbose":     "Specifies the transactions are returned as JSON objects instead of hex-encoded strings",
+	"getrawtransactions-blockhash":   "The hash of the block that contains all of the transactions which avoids the need for the transaction index",
+	"getrawtransactions--condition0": "verbose=false",
+	"getrawtransactions--condition1": "verbose=true",
+	"getrawtransactions--result0":    "Hex-encoded bytes of the serialized transactions",
+
+	// GetRebroadcastTxsCmd help.
+	"getrebroadcasttxs--synopsis": "Returns all transactions that are being automatically rebroadcast with exponential backoff until they are included in a block or expire.",
+
+	// RebroadcastTxResult help.
+	"rebroadcasttxresult-txid":          "The hash of the transaction",
+	"rebroadcasttxresult-added":         "The time the transaction was added in seconds since 1 Jan 1970 GMT",
+	"rebroadcasttxresult-lastbroadcast": "The time the transaction was last rebroadcast in seconds since 1 Jan 1970 GMT (0 if never)",
+	"rebroadcasttxresult-nextbroadcast": "The time the transaction will next be rebroadcast in seconds since 1 Jan 1970 GMT",
+	"rebroadcasttxresult-broadcasts":    "The number of times the transaction has been rebroadcast",
 
 	// GetTicketPoolValue help.
 	"getticketpoolvalue--synopsis": "Return the current value of all locked funds in the ticket pool",
@@ -653,6 +885,17 @@ var helpDescsEnUS = map[string]string{
 	"gettreasuryspendvotes-tspends": "Count votes for the specified tspends.\n" +
 		"If empty, count votes for all tspends currently in the mempool.",
 
+	// GetTxMiningWindowCmd help.
+	"gettxminingwindow--synopsis": "Returns the range of blocks after the current best block that may include the provided transaction while satisfying the relative lock times of its inputs and its expiry.\n" +
+		"Inputs that spend outputs of transactions in the mempool are treated as if those transactions will be included in the next block.",
+	"gettxminingwindow-hextx": "Serialized, hex-encoded transaction",
+
+	// GetTxMiningWindowResult help.
+	"gettxminingwindowresult-minheight":     "The minimum height of a block that may include the transaction",
+	"gettxminingwindowresult-minmediantime": "The minimum past median time, in seconds since 1 Jan 1970 GMT, of the parent of a block that may include the transaction (0 when no specific time is required)",
+	"gettxminingwindowresult-maxheight":     "The maximum height of a block that may include the transaction due to its expiry (-1 when the transaction does not expire)",
+	"gettxminingwindowresult-minable":       "Whether or not any block may include the transaction (false when it expires before its relative lock times are satisfied)",
+
 	// GetTxOutResult help.
 	"gettxoutresult-bestblock":     "The block hash that contains the transaction output",
 	"gettxoutresult-confirmations": "The number of confirmations",
@@ -667,6 +910,29 @@ var helpDescsEnUS = map[string]string{
 	"gettxout-tree":           "The tree of the transaction",
 	"gettxout-includemempool": "Include the mempool when true",
 
+	// GetTxOutHistoryCmd help.
+	"gettxouthistory--synopsis": "Returns the details of a transaction output along with its state and the history of events that changed its state in the main chain as of a given block height.  This requires the utxo history index to be enabled.",
+	"gettxouthistory-txid":      "The hash of the transaction",
+	"gettxouthistory-vout":      "The index of the output",
+	"gettxouthistory-height":    "The height of the block to return the state as of or -1 for the current best chain block height",
+
+	// GetTxOutHistoryResult help.
+	"gettxouthistoryresult-height":        "The height of the block the state is as of",
+	"gettxouthistoryresult-state":         "The state of the output as of the height (unspent, spent, or removed)",
+	"gettxouthistoryresult-createdheight": "The height of the block that first created the output",
+	"gettxouthistoryresult-tree":          "The transaction tree of the transaction that created the output (0 = regular, 1 = stake)",
+	"gettxouthistoryresult-value":         "The output amount in DCR",
+	"gettxouthistoryresult-scriptPubKey":  "The public key script used to pay coins as a JSON object",
+	"gettxouthistoryresult-coinbase":      "Whether or not the output was created by a coinbase transaction",
+	"gettxouthistoryresult-events":        "The events that changed the state of the output as of the height ordered by height",
+
+	// TxOutHistoryEventResult help.
+	"txouthistoryeventresult-height":    "The height of the block that caused the event",
+	"txouthistoryeventresult-blockhash": "The hash of the block that caused the event",
+	"txouthistoryeventresult-event":     "The kind of event (spent, unspent, removed, or recreated)",
+	"txouthistoryeventresult-spendtxid": "The hash of the transaction that spent the output or had its spend reverted",
+	"txouthistoryeventresult-spendvin":  "The index of the input that spent the output or had its spend reverted",
+
 	// GetTxOutSetInfoCmd help.
 	"gettxoutsetinfo--synopsis": "Returns statistics on current unspent transaction output set.",
 
@@ -709,7 +975,12 @@ var helpDescsEnUS = map[string]string{
 	"ping--synopsis": "Queues a ping to be sent to each connected peer.\n" +
 		"Ping times are provided by getpeerinfo via the pingtime and pingwait fields.",
 
-	// RebroadcastWinnersCmd help.
+	// PurgeSideChainBlocksCmd help.
+	"purgesidechainblocks--synopsis": "Evicts all blocks that are not part of the main chain, have their data available, and are part of a side chain that forks from the main chain at least the provided number of blocks before its tip.\n" +
+		"Evicted blocks are no longer considered for reorganization unless their data is received again.\n" +
+		"Blocks that are part of a side chain with more work than the main chain are never evicted.",
+	"purgesidechainblocks-mindepth": "The minimum number of blocks the main chain tip must be past the fork point of a side chain for its blocks to be evicted",
+
 	"rebroadcastwinners--synopsis": "Asks the daemon to rebroadcast the winners of the voting lottery.",
 
 	// ReconsiderBlockCmd help.
@@ -717,6 +988,26 @@ var helpDescsEnUS = map[string]string{
 		"Any descendants that are neither themselves marked as having failed validation, nor descendants of another such block, are also made eligibile for best chain selection.",
 	"reconsiderblock-blockhash": "The hash of the block to reconsider",
 
+	// ScrubDatabaseCmd help.
+	"scrubdatabase--synopsis": "Performs an online integrity check of the database that verifies the checksums of all stored blocks and cross references them against the block index along with the spend journal entries of all blocks in the main chain.\n" +
+		"Issues with side chain blocks may optionally be quarantined so the blocks are no longer considered for reorganization, while issues with main chain blocks are only reported along with a suggested repair.\n" +
+		"The check may take a long time to complete on large databases.",
+	"scrubdatabase-quarantine": "Quarantine side chain blocks with issues",
+
+	// ScrubDatabaseResult help.
+	"scrubdatabaseresult-mainchainblocks": "The number of main chain blocks that were checked",
+	"scrubdatabaseresult-sidechainblocks": "The number of side chain blocks that were checked",
+	"scrubdatabaseresult-issues":          "The integrity issues that were found",
+
+	// ScrubIssue help.
+	"scrubissue-hash":        "The hash of the affected block",
+	"scrubissue-height":      "The height of the affected block",
+	"scrubissue-mainchain":   "Whether or not the block is part of the main chain",
+	"scrubissue-kind":        "The kind of issue (missing block data, corrupt block data, mismatched block data, missing spend journal, corrupt spend journal)",
+	"scrubissue-description": "A description of the issue",
+	"scrubissue-suggestion":  "A suggested course of action to repair the issue",
+	"scrubissue-quarantined": "Whether or not the block was quarantined",
+
 	// SendRawTransactionCmd help.
 	"sendrawtransaction--synopsis":     "Submits the serialized, hex-encoded transaction to the local peer and relays it to the network.",
 	"sendrawtransaction-hextx":         "Serialized, hex-encoded signed transaction",
@@ -728,6 +1019,10 @@ var helpDescsEnUS = map[string]string{
 	"setgenerate-generate":     "Use true to enable generation, false to disable it",
 	"setgenerate-genproclimit": "The number of processors (cores) to limit generation to or -1 for default",
 
+	// SetMockTimeCmd help.
+	"setmocktime--synopsis": "Overrides the network-adjusted time used by the node, such as when validating block timestamps and creating block templates, with a fixed time (requires --debugrpc, simnet or regnet only).",
+	"setmocktime-timestamp": "The time to use in seconds since 1 Jan 1970 GMT or 0 to remove the override",
+
 	// StopCmd help.
 	"stop--synopsis": "Shutdown dcrd.",
 	"stop--result0":  "The string 'dcrd stopping.'",
@@ -743,6 +1038,19 @@ var helpDescsEnUS = map[string]string{
 	"submitblock--condition1": "Block rejected",
 	"submitblock--result1":    "The reason the block was rejected",
 
+	// TestMempoolAcceptCmd help.
+	"testmempoolaccept--synopsis":     "Checks whether or not the provided serialized, hex-encoded transactions would be accepted into the mempool without submitting them.\nEach transaction is checked independently against the current mempool, so transactions that spend outputs of other provided transactions are rejected unless those transactions are already in the mempool.",
+	"testmempoolaccept-rawtxs":        "Serialized, hex-encoded signed transactions to check",
+	"testmempoolaccept-allowhighfees": "Whether or not to allow transactions that pay insanely high fees",
+
+	// TestMempoolAcceptResult help.
+	"testmempoolacceptresult-txid":         "The hash of the transaction",
+	"testmempoolacceptresult-allowed":      "Whether or not the transaction would be accepted into the mempool",
+	"testmempoolacceptresult-rejectreason": "The reason the transaction would be rejected (only when allowed is false)",
+	"testmempoolacceptresult-rejectcode":   "A stable, machine-readable category for the reason the transaction would be rejected (only when allowed is false)",
+	"testmempoolacceptresult-size":         "The serialized size of the transaction in bytes (only when allowed is true)",
+	"testmempoolacceptresult-fee":          "The fee paid by the transaction in DCR (only when allowed is true)",
+
 	// ValidateAddressResult help.
 	"validateaddresschainresult-isvalid": "Whether or not the address is valid",
 	"validateaddresschainresult-address": "The Decred address (only when isvalid is true)",
@@ -784,13 +1092,13 @@ var helpDescsEnUS = map[string]string{
 	"notifyblocks--synopsis": "Request notifications for whenever a block is connected or disconnected from the main (best) chain.",
 
 	// NotifyWorkCmd help.
-	"notifywork--synopsis": "Request notifications for whenever a new block template is generated.",
+	"notifywork--synopsis": "Request notifications for whenever a new block template is generated and whenever votes in the mempool cause a different block to become the most favorable one to build on.",
 
 	// StopNotifyBlocksCmd help.
 	"stopnotifyblocks--synopsis": "Cancel registered notifications for whenever a block is connected or disconnected from the main (best) chain.",
 
 	// StopNotifyWorkCmd help.
-	"stopnotifywork--synopsis": "Cancel registered notifications for whenever a new block template is generated.",
+	"stopnotifywork--synopsis": "Cancel registered notifications for whenever a new block template is generated and whenever votes in the mempool cause a different block to become the most favorable one to build on.",
 
 	// NotifyTSpendCmd help.
 	"notifytspend--synopsis": "Request notifications for whenever a new tspend arrives in the mempool.",
@@ -805,21 +1113,48 @@ var helpDescsEnUS = map[string]string{
 	// StopNotifyNewTransactionsCmd help.
 	"stopnotifynewtransactions--synopsis": "Stop sending either a txaccepted or a txacceptedverbose notification when a new transaction is accepted into the mempool.",
 
+	// NotifyStakeEventsCmd help.
+	"notifystakeevents--synopsis": "Send a stakeevents notification whenever any of the provided tickets matures, votes, is missed, expires, or is revoked in a block connected to the main chain.\n" +
+		"The tickets are added to any that were previously provided and tickets that vote or are revoked are no longer watched once the notification is sent.",
+	"notifystakeevents-tickets": "The hashes of the tickets to watch",
+
+	// StopNotifyStakeEventsCmd help.
+	"stopnotifystakeevents--synopsis": "Stop sending stakeevents notifications for the provided tickets or for all watched tickets when none are provided.",
+	"stopnotifystakeevents-tickets":   "The hashes of the tickets to stop watching",
+
 	// OutPoint help.
 	"outpoint-hash":  "The hex-encoded bytes of the outpoint hash",
 	"outpoint-index": "The index of the outpoint",
 	"outpoint-tree":  "The tree of the outpoint",
 
 	// LoadTxFilterCmd help.
-	"loadtxfilter--synopsis": "Load, add to, or reload a websocket client's transaction filter for mempool transactions, new blocks and rescans.",
-	"loadtxfilter-reload":    "Load a new filter instead of adding data to an existing one",
-	"loadtxfilter-addresses": "Array of addresses to add to the transaction filter",
-	"loadtxfilter-outpoints": "Array of outpoints to add to the transaction filter",
+	"loadtxfilter--synopsis":          "Load, add to, or reload a websocket client's transaction filter for mempool transactions, new blocks and rescans.",
+	"loadtxfilter-reload":             "Load a new filter instead of adding data to an existing one",
+	"loadtxfilter-addresses":          "Array of addresses to add to the transaction filter",
+	"loadtxfilter-outpoints":          "Array of outpoints to add to the transaction filter",
+	"loadtxfilter-scripts":            "Array of hex-encoded raw output scripts to add to the transaction filter",
+	"loadtxfilter-scripthashprefixes": "Array of hex-encoded prefixes of the BLAKE-256 hash of output scripts to add to the transaction filter",
+
+	// GetTxFilterCmd help.
+	"gettxfilter--synopsis": "Returns the contents of a websocket client's transaction filter.",
+
+	// GetTxFilterResult help.
+	"gettxfilterresult-addresses":          "The addresses in the transaction filter",
+	"gettxfilterresult-outpoints":          "The outpoints in the transaction filter including those added due to matching outputs",
+	"gettxfilterresult-scripts":            "The hex-encoded raw output scripts in the transaction filter",
+	"gettxfilterresult-scripthashprefixes": "The hex-encoded prefixes of the BLAKE-256 hash of output scripts in the transaction filter",
 
 	// Rescan help.
 	"rescan--synopsis":   "Rescan blocks for transactions matching the loaded transaction filter.",
 	"rescan-blockhashes": "Array of block hashes to rescan.  Each next block must be a child of the previous.",
 
+	// RescanFilters help.
+	"rescanfilters--synopsis": "Rescan the main chain for transactions matching the loaded transaction filter using the version 2 committed filters to skip blocks that do not contain any relevant transactions.\n" +
+		"Progress is periodically reported via rescanprogress notifications.\n" +
+		"Every block in the range is inspected when the transaction filter contains script hash prefixes since committed filters can not be matched against them.",
+	"rescanfilters-beginblock": "Hash of the first main chain block to rescan",
+	"rescanfilters-endblock":   "Hash of the last main chain block to rescan (default: the current best block)",
+
 	// -------- Decred-specific help --------
 
 	// EstimateFee help.
@@ -843,9 +1178,31 @@ var helpDescsEnUS = map[string]string{
 	"estimatestakediffresult-expected": "Expected estimate for stake difficulty",
 	"estimatestakediffresult-user":     "Estimate for stake difficulty with the passed user amount of tickets",
 
+	// ForecastStakeDiffCmd help.
+	"forecaststakediff--synopsis":                "Simulates the stake difficulty algorithm forward from the current best chain tip and returns the projected stake difficulty for the first block of each future retarget interval.  The simulation assumes every block includes the maximum number of votes and does not account for expired or missed tickets.",
+	"forecaststakediff-intervals":                "The number of retarget intervals to simulate (max 100)",
+	"forecaststakediff-ticketsperinterval":       "The number of tickets assumed to be purchased in each interval or -1 to use the number purchased in the most recent complete interval",
+	"forecaststakediffresult-height":             "The height of the current best chain tip the simulation starts from",
+	"forecaststakediffresult-poolvalue":          "The current value of all locked funds in the ticket pool",
+	"forecaststakediffresult-nextstakediff":      "The stake difficulty required for the next block",
+	"forecaststakediffresult-ticketsperinterval": "The number of tickets assumed to be purchased in each interval",
+	"forecaststakediffresult-projections":        "The projected stake difficulty for each simulated retarget interval",
+	"stakediffprojection-height":                 "The height of the first block in the retarget interval",
+	"stakediffprojection-stakediff":              "The projected stake difficulty for the interval",
+	"stakediffprojection-poolsize":               "The projected number of live tickets at the start of the interval",
+
 	// GetCoinSupply help
-	"getcoinsupply--synopsis": "Returns current total coin supply in atoms",
-	"getcoinsupply--result0":  "Current coin supply in atoms",
+	"getcoinsupply--synopsis":   "Returns current total coin supply in atoms",
+	"getcoinsupply-verbose":     "Specifies the coin supply is returned as a JSON object that also includes the max supply permitted by the subsidy schedule",
+	"getcoinsupply--condition0": "verbose=false",
+	"getcoinsupply--condition1": "verbose=true",
+	"getcoinsupply--result0":    "Current coin supply in atoms",
+
+	// GetCoinSupplyVerboseResult help.
+	"getcoinsupplyverboseresult-height":             "The height of the current best block",
+	"getcoinsupplyverboseresult-supply":             "The current coin supply in atoms",
+	"getcoinsupplyverboseresult-scheduledsupply":    "The max supply in atoms permitted by the subsidy schedule as of the current best block assuming every block contains the max number of votes and is approved",
+	"getcoinsupplyverboseresult-subsidysplitheight": "The height at which the modified subsidy split defined in DCP0010 became active or -1 when it is not active",
 
 	// LiveTickets help.
 	"livetickets--synopsis":     "Returns live ticket hashes from the ticket database",
@@ -930,13 +1287,25 @@ var helpDescsEnUS = map[string]string{
 
 	// regentemplate help
 	"regentemplate--synopsis": "Asks the node to regenerate its block mining template.",
+
+	// ReloadConfigCmd help.
+	"reloadconfig--synopsis": "Reloads the configuration options that can safely be changed while the node is running from the config file and command line.\n" +
+		"The reloadable options are debuglevel, nobanning, banduration, banthreshold, whitelist, minrelaytxfee, rejectagent, deprioritizeagent, and either addpeer or connect.\n" +
+		"Switching between the addpeer and connect options requires a restart.\n" +
+		"No changes are made when any of the options are invalid.",
+
+	// ReloadConfigResult help.
+	"reloadconfigresult-changes": "Descriptions of the options that changed",
 }
 
 // rpcResultTypes specifies the result types that each RPC command can return.
 // This information is used to generate the help.  Each result type must be a
 // pointer to the type (or nil to indicate no return value).
 var rpcResultTypes = map[types.Method][]interface{}{
+	"abandonrebroadcasttx":  nil,
 	"addnode":               nil,
+	"approvedeepreorg":      nil,
+	"checkblockconflicts":   {(*types.CheckBlockConflictsResult)(nil)},
 	"createrawsstx":         {(*string)(nil)},
 	"createrawssrtx":        {(*string)(nil)},
 	"createrawtransaction":  {(*string)(nil)},
@@ -951,55 +1320,75 @@ var rpcResultTypes = map[types.Method][]interface{}{
 	"existsliveticket":      {(*bool)(nil)},
 	"existslivetickets":     {(*string)(nil)},
 	"existsmempooltxs":      {(*string)(nil)},
+	"forceprune":            nil,
+	"forecaststakediff":     {(*types.ForecastStakeDiffResult)(nil)},
 	"getaddednodeinfo":      {(*[]string)(nil), (*[]types.GetAddedNodeInfoResult)(nil)},
 	"getbestblock":          {(*types.GetBestBlockResult)(nil)},
 	"generate":              {(*[]string)(nil)},
+	"generatetoaddress":     {(*[]string)(nil)},
 	"getbestblockhash":      {(*string)(nil)},
 	"getblock":              {(*string)(nil), (*types.GetBlockVerboseResult)(nil)},
 	"getblockchaininfo":     {(*types.GetBlockChainInfoResult)(nil)},
 	"getblockcount":         {(*int64)(nil)},
 	"getblockhash":          {(*string)(nil)},
 	"getblockheader":        {(*string)(nil), (*types.GetBlockHeaderVerboseResult)(nil)},
+	"getblockheaderproof":   {(*types.GetBlockHeaderProofResult)(nil)},
+	"getblockheaders":       {(*types.GetHeadersResult)(nil), (*[]types.GetBlockHeaderVerboseResult)(nil)},
 	"getblocksubsidy":       {(*types.GetBlockSubsidyResult)(nil)},
 	"getcfilterv2":          {(*types.GetCFilterV2Result)(nil)},
 	"getchaintips":          {(*[]types.GetChainTipsResult)(nil)},
 	"getconnectioncount":    {(*int32)(nil)},
 	"getcurrentnet":         {(*uint32)(nil)},
 	"getdifficulty":         {(*float64)(nil)},
+	"getsidechainblocks":    {(*[]types.SideChainBlockResult)(nil)},
 	"getstakedifficulty":    {(*types.GetStakeDifficultyResult)(nil)},
 	"getstakeversioninfo":   {(*types.GetStakeVersionInfoResult)(nil)},
 	"getstakeversions":      {(*types.GetStakeVersionsResult)(nil)},
 	"getgenerate":           {(*bool)(nil)},
 	"gethashespersec":       {(*float64)(nil)},
+	"getheadercommitments":  {(*types.GetHeaderCommitmentsResult)(nil)},
 	"getheaders":            {(*types.GetHeadersResult)(nil)},
 	"getinfo":               {(*types.InfoChainResult)(nil)},
 	"getmempoolinfo":        {(*types.GetMempoolInfoResult)(nil)},
+	"getmempooljournal":     {(*[]types.MempoolEventResult)(nil)},
 	"getmininginfo":         {(*types.GetMiningInfoResult)(nil)},
 	"getnettotals":          {(*types.GetNetTotalsResult)(nil)},
 	"getnetworkhashps":      {(*int64)(nil)},
+	"getnulldata":           {(*[]types.GetNullDataResult)(nil)},
 	"getnetworkinfo":        {(*[]types.GetNetworkInfoResult)(nil)},
+	"getnodeinfo":           {(*types.GetNodeInfoResult)(nil)},
 	"getpeerinfo":           {(*[]types.GetPeerInfoResult)(nil)},
-	"getrawmempool":         {(*[]string)(nil), (*types.GetRawMempoolVerboseResult)(nil)},
+	"getrawmempool":         {(*[]string)(nil), (*types.GetRawMempoolVerboseResult)(nil), (*types.GetRawMempoolSummaryResult)(nil)},
 	"getrawtransaction":     {(*string)(nil), (*types.TxRawResult)(nil)},
+	"getrawtransactions":    {(*[]string)(nil), (*[]types.TxRawResult)(nil)},
+	"getrebroadcasttxs":     {(*[]types.RebroadcastTxResult)(nil)},
 	"getticketpoolvalue":    {(*float64)(nil)},
 	"gettreasurybalance":    {(*types.GetTreasuryBalanceResult)(nil)},
 	"gettreasuryspendvotes": {(*types.GetTreasurySpendVotesResult)(nil)},
+	"gettxminingwindow":     {(*types.GetTxMiningWindowResult)(nil)},
 	"gettxout":              {(*types.GetTxOutResult)(nil)},
+	"gettxouthistory":       {(*types.GetTxOutHistoryResult)(nil)},
 	"gettxoutsetinfo":       {(*types.GetTxOutSetInfoResult)(nil)},
 	"getvoteinfo":           {(*types.GetVoteInfoResult)(nil)},
+	"getvotestats":          {(*types.GetVoteStatsResult)(nil)},
 	"getwork":               {(*types.GetWorkResult)(nil), (*bool)(nil)},
-	"getcoinsupply":         {(*int64)(nil)},
+	"getcoinsupply":         {(*int64)(nil), (*types.GetCoinSupplyVerboseResult)(nil)},
 	"help":                  {(*string)(nil), (*string)(nil)},
 	"invalidateblock":       nil,
 	"livetickets":           {(*types.LiveTicketsResult)(nil)},
 	"node":                  nil,
 	"ping":                  nil,
+	"purgesidechainblocks":  {(*[]types.SideChainBlockResult)(nil)},
 	"reconsiderblock":       nil,
 	"regentemplate":         nil,
+	"reloadconfig":          {(*types.ReloadConfigResult)(nil)},
+	"scrubdatabase":         {(*types.ScrubDatabaseResult)(nil)},
 	"sendrawtransaction":    {(*string)(nil)},
 	"setgenerate":           nil,
+	"setmocktime":           nil,
 	"stop":                  {(*string)(nil)},
 	"submitblock":           {nil, (*string)(nil)},
+	"testmempoolaccept":     {(*[]types.TestMempoolAcceptResult)(nil)},
 	"ticketfeeinfo":         {(*types.TicketFeeInfoResult)(nil)},
 	"ticketsforaddress":     {(*types.TicketsForAddressResult)(nil)},
 	"ticketvwap":            {(*float64)(nil)},
@@ -1010,6 +1399,7 @@ var rpcResultTypes = map[types.Method][]interface{}{
 	"version":               {(*map[string]types.VersionResult)(nil)},
 
 	// Websocket commands.
+	"gettxfilter":               {(*types.GetTxFilterResult)(nil)},
 	"loadtxfilter":              nil,
 	"notifywinningtickets":      nil,
 	"notifynewtickets":          nil,
@@ -1017,15 +1407,18 @@ var rpcResultTypes = map[types.Method][]interface{}{
 	"notifywork":                nil,
 	"notifytspend":              nil,
 	"notifynewtransactions":     nil,
+	"notifystakeevents":         nil,
 	"notifyreceived":            nil,
 	"notifyspent":               nil,
 	"rebroadcastwinners":        nil,
 	"rescan":                    nil,
+	"rescanfilters":             nil,
 	"session":                   {(*types.SessionResult)(nil)},
 	"stopnotifyblocks":          nil,
 	"stopnotifywork":            nil,
 	"stopnotifytspend":          nil,
 	"stopnotifynewtransactions": nil,
+	"stopnotifystakeevents":     nil,
 	"stopnotifyreceived":        nil,
 	"stopnotifyspent":           nil,
 }
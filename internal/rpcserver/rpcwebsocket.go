@@ -13,8 +13,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -26,6 +28,7 @@ import (
 	"github.com/decred/dcrd/crypto/ripemd160"
 	"github.com/decred/dcrd/dcrjson/v4"
 	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/gcs/v4/blockcf2"
 	"github.com/decred/dcrd/internal/blockchain"
 	"github.com/decred/dcrd/internal/mining"
 	"github.com/decred/dcrd/rpc/jsonrpc/types/v4"
@@ -53,6 +56,16 @@ const (
 	// websocketPongTimeout is the maximum amount of time attempts to respond to
 	// websocket ping messages with a pong will wait before giving up.
 	websocketPongTimeout = time.Second * 5
+
+	// rescanProgressInterval is the number of blocks between the progress
+	// notifications sent to websocket clients during a filter-based rescan.
+	rescanProgressInterval = 2000
+
+	// websocketSlowClientTimeout is the maximum amount of time a websocket
+	// client that exceeded its queued notification budget is given to
+	// receive the notification that its notifications were dropped before it
+	// is disconnected.
+	websocketSlowClientTimeout = time.Second * 10
 )
 
 type semaphore chan struct{}
@@ -78,6 +91,7 @@ type wsCommandHandler func(*wsClient, interface{}) (interface{}, error)
 var wsHandlers map[types.Method]wsCommandHandler
 var wsHandlersBeforeInit = map[types.Method]wsCommandHandler{
 	"help":                      handleWebsocketHelp,
+	"gettxfilter":               handleGetTxFilter,
 	"loadtxfilter":              handleLoadTxFilter,
 	"notifyblocks":              handleNotifyBlocks,
 	"notifywork":                handleNotifyWork,
@@ -85,13 +99,16 @@ var wsHandlersBeforeInit = map[types.Method]wsCommandHandler{
 	"notifywinningtickets":      handleWinningTickets,
 	"notifynewtickets":          handleNewTickets,
 	"notifynewtransactions":     handleNotifyNewTransactions,
+	"notifystakeevents":         handleNotifyStakeEvents,
 	"rebroadcastwinners":        handleRebroadcastWinners,
 	"rescan":                    handleRescan,
+	"rescanfilters":             handleRescanFilters,
 	"session":                   handleSession,
 	"stopnotifyblocks":          handleStopNotifyBlocks,
 	"stopnotifywork":            handleStopNotifyWork,
 	"stopnotifytspend":          handleStopNotifyTSpend,
 	"stopnotifynewtransactions": handleStopNotifyNewTransactions,
+	"stopnotifystakeevents":     handleStopNotifyStakeEvents,
 }
 
 // WebsocketHandler handles a new websocket client by creating a new wsClient,
@@ -114,6 +131,16 @@ func (s *Server) WebsocketHandler(conn *websocket.Conn, remoteAddr string, authe
 		return
 	}
 
+	// Limit max number of websocket clients per remote IP address.
+	if !s.limiter.addWebsocket(remoteAddr) {
+		log.Infof("Max websocket clients per IP exceeded [%d] - "+
+			"disconnecting client %s", s.cfg.RPCMaxWebsocketsPerIP,
+			remoteAddr)
+		conn.Close()
+		return
+	}
+	defer s.limiter.removeWebsocket(remoteAddr)
+
 	// Create a new websocket client to handle the new websocket connection
 	// and wait for it to shutdown.  Once it has shutdown (and hence
 	// disconnected), remove it and any notifications it registered for.
@@ -247,6 +274,26 @@ func (m *wsNotificationManager) NotifyReorganization(rd *blockchain.Reorganizati
 	}
 }
 
+// NotifyDeepReorgPaused passes a notification that a blockchain
+// reorganization was paused due to exceeding the maximum automatic
+// reorganization depth for notification processing.
+func (m *wsNotificationManager) NotifyDeepReorgPaused(pd *blockchain.DeepReorgPausedNtfnsData) {
+	select {
+	case m.queueNotification <- (*notificationDeepReorgPaused)(pd):
+	case <-m.quit:
+	}
+}
+
+// NotifyVoteLeaderChanged passes a notification that a new vote in the
+// mempool caused a different block to become the most favorable one to build
+// on at a given height to the notification manager for further processing.
+func (m *wsNotificationManager) NotifyVoteLeaderChanged(vd *VoteLeaderChangedNtfnData) {
+	select {
+	case m.queueNotification <- (*notificationVoteLeaderChanged)(vd):
+	case <-m.quit:
+	}
+}
+
 // NotifyWinningTickets passes newly winning tickets for an incoming block
 // to the notification manager for further processing.
 func (m *wsNotificationManager) NotifyWinningTickets(wtnd *WinningTicketsNtfnData) {
@@ -290,6 +337,16 @@ type WinningTicketsNtfnData struct {
 	Tickets     []chainhash.Hash
 }
 
+// VoteLeaderChangedNtfnData is the data that is used to generate vote leader
+// changed notifications (which indicate a different block became the most
+// favorable one to build on among the competing blocks at a height according
+// to the votes in the mempool).
+type VoteLeaderChangedNtfnData struct {
+	Height    int64
+	OldLeader chainhash.Hash
+	NewLeader chainhash.Hash
+}
+
 type wsClientFilter struct {
 	mu sync.Mutex
 
@@ -308,16 +365,27 @@ type wsClientFilter struct {
 
 	// Outpoints of unspent outputs.
 	unspent map[wire.OutPoint]struct{}
+
+	// Raw output scripts and prefixes of the BLAKE-256 hash of output
+	// scripts.  These allow matching nonstandard scripts that do not have an
+	// address.  The lengths of all prefixes are tracked so that only the
+	// relevant prefixes of a hash need to be looked up.
+	scripts              map[string]struct{}
+	scriptHashPrefixes   map[string]struct{}
+	scriptHashPrefixLens map[int]struct{}
 }
 
-func makeWSClientFilter(addresses []string, unspentOutPoints []*wire.OutPoint, params stdaddr.AddressParams) *wsClientFilter {
+func makeWSClientFilter(addresses []string, unspentOutPoints []*wire.OutPoint, scripts, scriptHashPrefixes [][]byte, params stdaddr.AddressParams) *wsClientFilter {
 	filter := &wsClientFilter{
-		params:            params,
-		pubKeyHashes:      map[[ripemd160.Size]byte]struct{}{},
-		scriptHashes:      map[[ripemd160.Size]byte]struct{}{},
-		compressedPubKeys: map[[33]byte]struct{}{},
-		otherAddresses:    map[string]struct{}{},
-		unspent:           make(map[wire.OutPoint]struct{}, len(unspentOutPoints)),
+		params:               params,
+		pubKeyHashes:         map[[ripemd160.Size]byte]struct{}{},
+		scriptHashes:         map[[ripemd160.Size]byte]struct{}{},
+		compressedPubKeys:    map[[33]byte]struct{}{},
+		otherAddresses:       map[string]struct{}{},
+		unspent:              make(map[wire.OutPoint]struct{}, len(unspentOutPoints)),
+		scripts:              make(map[string]struct{}, len(scripts)),
+		scriptHashPrefixes:   make(map[string]struct{}, len(scriptHashPrefixes)),
+		scriptHashPrefixLens: map[int]struct{}{},
 	}
 
 	for _, s := range addresses {
@@ -326,6 +394,12 @@ func makeWSClientFilter(addresses []string, unspentOutPoints []*wire.OutPoint, p
 	for _, op := range unspentOutPoints {
 		filter.addUnspentOutPoint(op)
 	}
+	for _, script := range scripts {
+		filter.addScript(script)
+	}
+	for _, prefix := range scriptHashPrefixes {
+		filter.addScriptHashPrefix(prefix)
+	}
 
 	return filter
 }
@@ -399,12 +473,159 @@ func (f *wsClientFilter) existsUnspentOutPoint(op *wire.OutPoint) bool {
 	return ok
 }
 
+func (f *wsClientFilter) addScript(script []byte) {
+	f.scripts[string(script)] = struct{}{}
+}
+
+func (f *wsClientFilter) addScriptHashPrefix(prefix []byte) {
+	f.scriptHashPrefixes[string(prefix)] = struct{}{}
+	f.scriptHashPrefixLens[len(prefix)] = struct{}{}
+}
+
+// existsScript returns whether the provided output script is either one of the
+// raw scripts in the filter or its BLAKE-256 hash starts with one of the
+// script hash prefixes in the filter.
+func (f *wsClientFilter) existsScript(script []byte) bool {
+	if _, ok := f.scripts[string(script)]; ok {
+		return true
+	}
+	if len(f.scriptHashPrefixes) == 0 {
+		return false
+	}
+	hash := chainhash.HashB(script)
+	for prefixLen := range f.scriptHashPrefixLens {
+		if _, ok := f.scriptHashPrefixes[string(hash[:prefixLen])]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// watchScripts returns the output scripts to match against version 2 committed
+// filters in order to determine if a block might contain transactions relevant
+// to the filter.
+//
+// Note that it is not possible to match committed filters against script hash
+// prefixes or outpoints whose output scripts are not known.
+func (f *wsClientFilter) watchScripts() [][]byte {
+	scripts := make([][]byte, 0, len(f.pubKeyHashes)+len(f.scriptHashes)+
+		len(f.compressedPubKeys)*2+len(f.otherAddresses)+len(f.scripts))
+	addScript := func(addr stdaddr.Address) {
+		_, script := addr.PaymentScript()
+		scripts = append(scripts, script)
+	}
+	for h := range f.pubKeyHashes {
+		a, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(h[:], f.params)
+		if err == nil {
+			addScript(a)
+		}
+	}
+	for h := range f.scriptHashes {
+		a, err := stdaddr.NewAddressScriptHashV0FromHash(h[:], f.params)
+		if err == nil {
+			addScript(a)
+		}
+	}
+	for pk := range f.compressedPubKeys {
+		// Outputs that pay to the public key hash of public keys are also
+		// relevant to the filter.
+		a, err := stdaddr.NewAddressPubKeyEcdsaSecp256k1V0Raw(pk[:], f.params)
+		if err == nil {
+			addScript(a)
+			addScript(a.AddressPubKeyHash())
+		}
+	}
+	for addrStr := range f.otherAddresses {
+		a, err := stdaddr.DecodeAddress(addrStr, f.params)
+		if err == nil {
+			addScript(a)
+		}
+	}
+	for script := range f.scripts {
+		scripts = append(scripts, []byte(script))
+	}
+	return scripts
+}
+
+// unspentOutPointScripts returns the scripts of the outputs referenced by the
+// unspent outpoints in the filter that are looked up with the provided function
+// along with whether or not the scripts for all of them were found.
+//
+// This function MUST be called with the filter lock held.
+func (f *wsClientFilter) unspentOutPointScripts(fetchUtxoEntry func(wire.OutPoint) (UtxoEntry, error)) ([][]byte, bool) {
+	scripts := make([][]byte, 0, len(f.unspent))
+	allFound := true
+	for op := range f.unspent {
+		entry, err := fetchUtxoEntry(op)
+		if err != nil || entry == nil || entry.IsSpent() {
+			allFound = false
+			continue
+		}
+		scripts = append(scripts, entry.PkScript())
+	}
+	return scripts, allFound
+}
+
+// unspentOutputScripts returns the scripts of the outputs in the provided block
+// that are unspent outpoints in the filter.
+//
+// This function MUST be called with the filter lock held.
+func (f *wsClientFilter) unspentOutputScripts(block *dcrutil.Block) [][]byte {
+	var scripts [][]byte
+	addScripts := func(txns []*dcrutil.Tx, tree int8) {
+		for _, tx := range txns {
+			op := wire.OutPoint{Hash: *tx.Hash(), Tree: tree}
+			for i, output := range tx.MsgTx().TxOut {
+				op.Index = uint32(i)
+				if _, ok := f.unspent[op]; ok {
+					scripts = append(scripts, output.PkScript)
+				}
+			}
+		}
+	}
+	addScripts(block.STransactions(), wire.TxTreeStake)
+	addScripts(block.Transactions(), wire.TxTreeRegular)
+	return scripts
+}
+
+// addresses returns the encoded addresses in the filter sorted
+// lexicographically.
+func (f *wsClientFilter) addresses() []string {
+	addrs := make([]string, 0, len(f.pubKeyHashes)+len(f.scriptHashes)+
+		len(f.compressedPubKeys)+len(f.otherAddresses))
+	for h := range f.pubKeyHashes {
+		a, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(h[:], f.params)
+		if err == nil {
+			addrs = append(addrs, a.String())
+		}
+	}
+	for h := range f.scriptHashes {
+		a, err := stdaddr.NewAddressScriptHashV0FromHash(h[:], f.params)
+		if err == nil {
+			addrs = append(addrs, a.String())
+		}
+	}
+	for pk := range f.compressedPubKeys {
+		a, err := stdaddr.NewAddressPubKeyEcdsaSecp256k1V0Raw(pk[:], f.params)
+		if err == nil {
+			addrs = append(addrs, a.String())
+		}
+	}
+	for a := range f.otherAddresses {
+		addrs = append(addrs, a)
+	}
+	sort.Strings(addrs)
+	return addrs
+}
+
 // Notification types
 type notificationBlockConnected dcrutil.Block
 type notificationBlockDisconnected dcrutil.Block
 type notificationWork mining.TemplateNtfn
 type notificationTSpend dcrutil.Tx
 type notificationReorganization blockchain.ReorganizationNtfnsData
+type notificationDeepReorgPaused blockchain.DeepReorgPausedNtfnsData
+type notificationVoteLeaderChanged VoteLeaderChangedNtfnData
 type notificationWinningTickets WinningTicketsNtfnData
 type notificationNewTickets blockchain.TicketNotificationsData
 type notificationTxAcceptedByMempool struct {
@@ -425,6 +646,8 @@ type notificationRegisterWinningTickets wsClient
 type notificationUnregisterWinningTickets wsClient
 type notificationRegisterNewTickets wsClient
 type notificationUnregisterNewTickets wsClient
+type notificationRegisterStakeEvents wsClient
+type notificationUnregisterStakeEvents wsClient
 type notificationRegisterNewMempoolTxs wsClient
 type notificationUnregisterNewMempoolTxs wsClient
 
@@ -446,6 +669,7 @@ func (m *wsNotificationManager) notificationHandler(ctx context.Context) {
 	tspendNotifications := make(map[chan struct{}]*wsClient)
 	winningTicketNotifications := make(map[chan struct{}]*wsClient)
 	ticketNewNotifications := make(map[chan struct{}]*wsClient)
+	stakeEventNotifications := make(map[chan struct{}]*wsClient)
 	txNotifications := make(map[chan struct{}]*wsClient)
 
 out:
@@ -479,6 +703,10 @@ out:
 			case *notificationWork:
 				m.notifyWork(workNotifications, (*mining.TemplateNtfn)(n))
 
+			case *notificationVoteLeaderChanged:
+				m.notifyVoteLeaderChanged(workNotifications,
+					(*VoteLeaderChangedNtfnData)(n))
+
 			case *notificationTSpend:
 				m.notifyTSpend(tspendNotifications, (*dcrutil.Tx)(n))
 
@@ -486,13 +714,18 @@ out:
 				m.notifyReorganization(blockNotifications,
 					(*blockchain.ReorganizationNtfnsData)(n))
 
+			case *notificationDeepReorgPaused:
+				m.notifyDeepReorgPaused(blockNotifications,
+					(*blockchain.DeepReorgPausedNtfnsData)(n))
+
 			case *notificationWinningTickets:
 				m.notifyWinningTickets(winningTicketNotifications,
 					(*WinningTicketsNtfnData)(n))
 
 			case *notificationNewTickets:
-				m.notifyNewTickets(ticketNewNotifications,
-					(*blockchain.TicketNotificationsData)(n))
+				tnd := (*blockchain.TicketNotificationsData)(n)
+				m.notifyNewTickets(ticketNewNotifications, tnd)
+				m.notifyStakeEvents(stakeEventNotifications, tnd)
 
 			case *notificationTxAcceptedByMempool:
 				if n.isNew && len(txNotifications) != 0 {
@@ -540,6 +773,14 @@ out:
 				wsc := (*wsClient)(n)
 				delete(ticketNewNotifications, wsc.quit)
 
+			case *notificationRegisterStakeEvents:
+				wsc := (*wsClient)(n)
+				stakeEventNotifications[wsc.quit] = wsc
+
+			case *notificationUnregisterStakeEvents:
+				wsc := (*wsClient)(n)
+				delete(stakeEventNotifications, wsc.quit)
+
 			case *notificationRegisterClient:
 				wsc := (*wsClient)(n)
 				clients[wsc.quit] = wsc
@@ -554,6 +795,7 @@ out:
 				delete(txNotifications, wsc.quit)
 				delete(winningTicketNotifications, wsc.quit)
 				delete(ticketNewNotifications, wsc.quit)
+				delete(stakeEventNotifications, wsc.quit)
 				delete(clients, wsc.quit)
 
 			case *notificationRegisterNewMempoolTxs:
@@ -658,6 +900,17 @@ func (m *wsNotificationManager) subscribedClients(tx *dcrutil.Tx, clients map[ch
 		}
 
 		for i, output := range msgTx.TxOut {
+			if f.existsScript(output.PkScript) {
+				subscribed[q] = struct{}{}
+				op := wire.OutPoint{
+					Hash:  *tx.Hash(),
+					Index: uint32(i),
+					Tree:  tx.Tree(),
+				}
+				f.addUnspentOutPoint(&op)
+				continue
+			}
+
 			watchOutput := true
 			scriptType, addrs := stdscript.ExtractAddrs(output.Version,
 				output.PkScript, params)
@@ -942,6 +1195,53 @@ func (m *wsNotificationManager) notifyReorganization(clients map[chan struct{}]*
 	}
 }
 
+// notifyDeepReorgPaused notifies websocket clients that have registered for
+// block updates when a blockchain reorganization was paused due to exceeding
+// the maximum automatic reorganization depth.
+func (m *wsNotificationManager) notifyDeepReorgPaused(clients map[chan struct{}]*wsClient, pd *blockchain.DeepReorgPausedNtfnsData) {
+	// Skip notification creation if no clients have requested block
+	// notifications.
+	if len(clients) == 0 {
+		return
+	}
+
+	ntfn := types.NewDeepReorgPausedNtfn(pd.TipHash.String(),
+		int32(pd.TipHeight), pd.TargetHash.String(), int32(pd.TargetHeight),
+		int32(pd.ForkHeight))
+	marshalledJSON, err := dcrjson.MarshalCmd("1.0", nil, ntfn)
+	if err != nil {
+		log.Errorf("Failed to marshal deep reorg paused notification: %v",
+			err)
+		return
+	}
+	for _, wsc := range clients {
+		wsc.QueueNotification(marshalledJSON)
+	}
+}
+
+// notifyVoteLeaderChanged notifies websocket clients that have registered for
+// work updates when a new vote in the mempool caused a different block to
+// become the most favorable one to build on at a given height.
+func (m *wsNotificationManager) notifyVoteLeaderChanged(clients map[chan struct{}]*wsClient, vd *VoteLeaderChangedNtfnData) {
+	// Skip notification creation if no clients have requested work
+	// notifications.
+	if len(clients) == 0 {
+		return
+	}
+
+	ntfn := types.NewVoteLeaderChangedNtfn(vd.Height, vd.OldLeader.String(),
+		vd.NewLeader.String())
+	marshalledJSON, err := dcrjson.MarshalCmd("1.0", nil, ntfn)
+	if err != nil {
+		log.Errorf("Failed to marshal vote leader changed notification: %v",
+			err)
+		return
+	}
+	for _, wsc := range clients {
+		wsc.QueueNotification(marshalledJSON)
+	}
+}
+
 // RegisterWinningTickets requests winning tickets update notifications
 // to the passed websocket client.
 func (m *wsNotificationManager) RegisterWinningTickets(wsc *wsClient) {
@@ -993,6 +1293,18 @@ func (m *wsNotificationManager) UnregisterNewTickets(wsc *wsClient) {
 	m.queueNotification <- (*notificationUnregisterNewTickets)(wsc)
 }
 
+// RegisterStakeEvents requests stake event notifications for the tickets
+// watched by the passed websocket client.
+func (m *wsNotificationManager) RegisterStakeEvents(wsc *wsClient) {
+	m.queueNotification <- (*notificationRegisterStakeEvents)(wsc)
+}
+
+// UnregisterStakeEvents removes stake event notifications for the passed
+// websocket client.
+func (m *wsNotificationManager) UnregisterStakeEvents(wsc *wsClient) {
+	m.queueNotification <- (*notificationUnregisterStakeEvents)(wsc)
+}
+
 // notifyNewTickets notifies websocket clients that have registered for
 // maturing ticket updates.
 func (*wsNotificationManager) notifyNewTickets(clients map[chan struct{}]*wsClient, tnd *blockchain.TicketNotificationsData) {
@@ -1017,6 +1329,26 @@ func (*wsNotificationManager) notifyNewTickets(clients map[chan struct{}]*wsClie
 	}
 }
 
+// notifyStakeEvents notifies websocket clients that have registered for stake
+// event notifications about the tickets they watch that matured, voted, were
+// missed, expired, or were revoked in a newly connected block.
+func (*wsNotificationManager) notifyStakeEvents(clients map[chan struct{}]*wsClient, tnd *blockchain.TicketNotificationsData) {
+	for _, wsc := range clients {
+		ntfn := wsc.filterStakeEvents(tnd)
+		if ntfn == nil {
+			continue
+		}
+
+		marshalledJSON, err := dcrjson.MarshalCmd("1.0", nil, ntfn)
+		if err != nil {
+			log.Errorf("Failed to marshal stake events notification: "+
+				"%v", err)
+			continue
+		}
+		wsc.QueueNotification(marshalledJSON)
+	}
+}
+
 // RegisterNewMempoolTxsUpdates requests notifications to the passed websocket
 // client when new transactions are added to the memory pool.
 func (m *wsNotificationManager) RegisterNewMempoolTxsUpdates(wsc *wsClient) {
@@ -1126,26 +1458,35 @@ func (m *wsNotificationManager) notifyRelevantTxAccepted(tx *dcrutil.Tx,
 		}
 
 		for i, output := range msgTx.TxOut {
-			scriptType, addrs := stdscript.ExtractAddrs(output.Version,
-				output.PkScript, m.server.cfg.ChainParams)
-			if scriptType == stdscript.STNonStandard {
+			matched := f.existsScript(output.PkScript)
+			if !matched {
+				scriptType, addrs := stdscript.ExtractAddrs(output.Version,
+					output.PkScript, m.server.cfg.ChainParams)
+				if scriptType == stdscript.STNonStandard {
+					continue
+				}
+				for _, a := range addrs {
+					if f.existsAddress(a) {
+						matched = true
+						break
+					}
+				}
+			}
+			if !matched {
 				continue
 			}
-			for _, a := range addrs {
-				if f.existsAddress(a) {
-					if clientsToNotify == nil {
-						clientsToNotify = make(map[chan struct{}]*wsClient)
-					}
-					clientsToNotify[q] = c
 
-					op := wire.OutPoint{
-						Hash:  *tx.Hash(),
-						Index: uint32(i),
-						Tree:  tx.Tree(),
-					}
-					f.addUnspentOutPoint(&op)
-				}
+			if clientsToNotify == nil {
+				clientsToNotify = make(map[chan struct{}]*wsClient)
+			}
+			clientsToNotify[q] = c
+
+			op := wire.OutPoint{
+				Hash:  *tx.Hash(),
+				Index: uint32(i),
+				Tree:  tx.Tree(),
 			}
+			f.addUnspentOutPoint(&op)
 		}
 
 		f.mu.Unlock()
@@ -1259,6 +1600,10 @@ type wsClient struct {
 
 	filterData *wsClientFilter
 
+	// stakeEventTickets houses the tickets the client has requested stake
+	// event notifications for.  It is protected by the embedded mutex.
+	stakeEventTickets map[chainhash.Hash]struct{}
+
 	// Networking infrastructure.
 	serviceRequestSem semaphore
 	ntfnChan          chan []byte
@@ -1267,6 +1612,46 @@ type wsClient struct {
 	wg                sync.WaitGroup
 }
 
+// filterStakeEvents returns a stakeevents notification for the provided ticket
+// notification data that only includes the tickets watched by the client, or
+// nil when none of them are involved.  Tickets that were voted or revoked are
+// removed from the watch list since they can not be involved in any further
+// stake events.
+//
+// This function is safe for concurrent access.
+func (c *wsClient) filterStakeEvents(tnd *blockchain.TicketNotificationsData) *types.StakeEventsNtfn {
+	c.Lock()
+	defer c.Unlock()
+
+	var found bool
+	filter := func(tickets []chainhash.Hash, unwatch bool) []string {
+		matches := make([]string, 0)
+		for i := range tickets {
+			hash := &tickets[i]
+			if _, ok := c.stakeEventTickets[*hash]; !ok {
+				continue
+			}
+			matches = append(matches, hash.String())
+			if unwatch {
+				delete(c.stakeEventTickets, *hash)
+			}
+			found = true
+		}
+		return matches
+	}
+	matured := filter(tnd.TicketsNew, false)
+	voted := filter(tnd.TicketsVoted, true)
+	missed := filter(tnd.TicketsMissed, false)
+	expired := filter(tnd.TicketsExpired, false)
+	revoked := filter(tnd.TicketsRevoked, true)
+	if !found {
+		return nil
+	}
+
+	return types.NewStakeEventsNtfn(tnd.Hash.String(), tnd.Height, matured,
+		voted, missed, expired, revoked)
+}
+
 // inHandler handles all incoming messages for the websocket connection.  It
 // must be run as a goroutine.
 func (c *wsClient) inHandler(ctx context.Context) {
@@ -1406,6 +1791,8 @@ out:
 						Code:    dcrjson.ErrRPCInvalidParams.Code,
 						Message: "limited user not authorized for this method",
 					}
+					c.rpcServer.auditRequest(c.isAdmin, c.addr, cmd.method,
+						cmd.params, jsonErr)
 					// Marshal and send response.
 					reply, err = createMarshalledReply("", req.ID, nil, jsonErr)
 					if err != nil {
@@ -1615,6 +2002,8 @@ out:
 									Code:    dcrjson.ErrRPCInvalidParams.Code,
 									Message: "limited user not authorized for this method",
 								}
+								c.rpcServer.auditRequest(c.isAdmin, c.addr,
+									cmd.method, cmd.params, jsonErr)
 								// Marshal and send response.
 								reply, err = createMarshalledReply(req.Jsonrpc, req.ID, nil, jsonErr)
 								if err != nil {
@@ -1640,6 +2029,8 @@ out:
 							resp, err = c.rpcServer.standardCmdResult(ctx,
 								cmd)
 						}
+						c.rpcServer.auditRequest(c.isAdmin, c.addr, cmd.method,
+							cmd.params, err)
 
 						// Marshal request output.
 						reply, err := createMarshalledReply(cmd.jsonrpc, cmd.id, resp, err)
@@ -1711,6 +2102,7 @@ func (c *wsClient) serviceRequest(ctx context.Context, r *parsedRPCCmd) {
 	} else {
 		result, err = c.rpcServer.standardCmdResult(ctx, r)
 	}
+	c.rpcServer.auditRequest(c.isAdmin, c.addr, r.method, r.params, err)
 	reply, err := createMarshalledReply(r.jsonrpc, r.id, result, err)
 	if err != nil {
 		log.Errorf("Failed to marshal reply for <%s> "+
@@ -1741,6 +2133,15 @@ func (c *wsClient) notificationQueueHandler() {
 	// problematic without using this approach.
 	var pendingNtfns [][]byte
 	waiting := false
+
+	// pendingBytes is the total size of the pending notifications.  Once it
+	// exceeds the configured budget, the pending notifications are dropped
+	// in favor of a single notification informing the client, any further
+	// notifications are discarded, and the client is disconnected once it
+	// has been sent.
+	var pendingBytes int64
+	maxPendingBytes := c.rpcServer.cfg.RPCMaxWebsocketQueueBytes
+	dropping := false
 out:
 	for {
 		select {
@@ -1750,20 +2151,35 @@ out:
 		// queue the message to be sent once the other pending messages
 		// are sent.
 		case msg := <-c.ntfnChan:
+			if dropping {
+				continue
+			}
 			if !waiting {
 				c.SendMessage(msg, ntfnSentChan)
-			} else {
-				pendingNtfns = append(pendingNtfns, msg)
+				waiting = true
+				continue
+			}
+			pendingNtfns = append(pendingNtfns, msg)
+			pendingBytes += int64(len(msg))
+			if maxPendingBytes > 0 && pendingBytes > maxPendingBytes {
+				pendingNtfns = c.dropNotifications(pendingBytes,
+					maxPendingBytes)
+				pendingBytes = 0
+				dropping = true
 			}
-			waiting = true
 
 		// This channel is notified when a notification has been sent
 		// across the network socket.
 		case <-ntfnSentChan:
 			// No longer waiting if there are no more messages in
-			// the pending messages queue.
+			// the pending messages queue.  Disconnect clients that
+			// had their notifications dropped once they have been
+			// informed.
 			if len(pendingNtfns) == 0 {
 				waiting = false
+				if dropping {
+					c.Disconnect()
+				}
 				continue
 			}
 			// Notify the outHandler about the next item to
@@ -1771,6 +2187,7 @@ out:
 			msg := pendingNtfns[0]
 			pendingNtfns[0] = nil
 			pendingNtfns = pendingNtfns[1:]
+			pendingBytes -= int64(len(msg))
 			c.SendMessage(msg, ntfnSentChan)
 
 		case <-c.quit:
@@ -1794,6 +2211,33 @@ cleanup:
 		"for %s", c.addr)
 }
 
+// dropNotifications logs a warning about the websocket client exceeding the
+// provided budget of queued notification bytes, schedules the client to be
+// disconnected, and returns the pending notifications to use in place of the
+// dropped ones.  The returned notifications only consist of a notification that
+// informs the client its notifications were dropped.
+//
+// This must only be called from notificationQueueHandler.
+func (c *wsClient) dropNotifications(queuedBytes, maxQueuedBytes int64) [][]byte {
+	log.Warnf("Dropping notifications for slow websocket client %s: %d "+
+		"bytes queued exceeds the limit of %d bytes", c.addr, queuedBytes,
+		maxQueuedBytes)
+	atomic.AddUint64(&c.rpcServer.slowWebsockets, 1)
+
+	// Ensure the client is disconnected even when it never reads the
+	// notification informing it the notifications were dropped.
+	time.AfterFunc(websocketSlowClientTimeout, c.Disconnect)
+
+	ntfn := types.NewNotificationsDroppedNtfn(queuedBytes, maxQueuedBytes)
+	marshalled, err := dcrjson.MarshalCmd("1.0", nil, ntfn)
+	if err != nil {
+		log.Errorf("Failed to marshal notificationsdropped notification: %v",
+			err)
+		return nil
+	}
+	return [][]byte{marshalled}
+}
+
 // outHandler handles all outgoing messages for the websocket connection.  It
 // must be run as a goroutine.  It uses a buffered channel to serialize output
 // messages while allowing the sender to continue running asynchronously.  It
@@ -2029,10 +2473,38 @@ func handleLoadTxFilter(wsc *wsClient, icmd interface{}) (interface{}, error) {
 		}
 	}
 
+	var scripts [][]byte
+	if cmd.Scripts != nil {
+		scripts = make([][]byte, len(*cmd.Scripts))
+		for i, scriptHex := range *cmd.Scripts {
+			script, err := hex.DecodeString(scriptHex)
+			if err != nil {
+				return nil, rpcDecodeHexError(scriptHex)
+			}
+			scripts[i] = script
+		}
+	}
+
+	var scriptHashPrefixes [][]byte
+	if cmd.ScriptHashPrefixes != nil {
+		scriptHashPrefixes = make([][]byte, len(*cmd.ScriptHashPrefixes))
+		for i, prefixHex := range *cmd.ScriptHashPrefixes {
+			prefix, err := hex.DecodeString(prefixHex)
+			if err != nil {
+				return nil, rpcDecodeHexError(prefixHex)
+			}
+			if len(prefix) == 0 || len(prefix) > chainhash.HashSize {
+				return nil, rpcInvalidError("script hash prefix %q must be "+
+					"between 1 and %d bytes", prefixHex, chainhash.HashSize)
+			}
+			scriptHashPrefixes[i] = prefix
+		}
+	}
+
 	wsc.Lock()
 	if cmd.Reload || wsc.filterData == nil {
 		wsc.filterData = makeWSClientFilter(cmd.Addresses, outPoints,
-			wsc.rpcServer.cfg.ChainParams)
+			scripts, scriptHashPrefixes, wsc.rpcServer.cfg.ChainParams)
 		wsc.Unlock()
 	} else {
 		filter := wsc.filterData
@@ -2045,12 +2517,70 @@ func handleLoadTxFilter(wsc *wsClient, icmd interface{}) (interface{}, error) {
 		for _, op := range outPoints {
 			filter.addUnspentOutPoint(op)
 		}
+		for _, script := range scripts {
+			filter.addScript(script)
+		}
+		for _, prefix := range scriptHashPrefixes {
+			filter.addScriptHashPrefix(prefix)
+		}
 		filter.mu.Unlock()
 	}
 
 	return nil, nil
 }
 
+// handleGetTxFilter implements the gettxfilter command extension for
+// websocket connections.
+func handleGetTxFilter(wsc *wsClient, _ interface{}) (interface{}, error) {
+	result := &types.GetTxFilterResult{
+		Addresses:          []string{},
+		OutPoints:          []types.OutPoint{},
+		Scripts:            []string{},
+		ScriptHashPrefixes: []string{},
+	}
+
+	wsc.Lock()
+	filter := wsc.filterData
+	wsc.Unlock()
+	if filter == nil {
+		return result, nil
+	}
+
+	filter.mu.Lock()
+	result.Addresses = filter.addresses()
+	for op := range filter.unspent {
+		result.OutPoints = append(result.OutPoints, types.OutPoint{
+			Hash:  op.Hash.String(),
+			Tree:  op.Tree,
+			Index: op.Index,
+		})
+	}
+	for script := range filter.scripts {
+		result.Scripts = append(result.Scripts, hex.EncodeToString(
+			[]byte(script)))
+	}
+	for prefix := range filter.scriptHashPrefixes {
+		result.ScriptHashPrefixes = append(result.ScriptHashPrefixes,
+			hex.EncodeToString([]byte(prefix)))
+	}
+	filter.mu.Unlock()
+
+	// Sort the results so they are deterministic.
+	sort.Slice(result.OutPoints, func(i, j int) bool {
+		a, b := &result.OutPoints[i], &result.OutPoints[j]
+		if a.Hash != b.Hash {
+			return a.Hash < b.Hash
+		}
+		if a.Index != b.Index {
+			return a.Index < b.Index
+		}
+		return a.Tree < b.Tree
+	})
+	sort.Strings(result.Scripts)
+	sort.Strings(result.ScriptHashPrefixes)
+	return result, nil
+}
+
 // handleNotifyBlocks implements the notifyblocks command extension for
 // websocket connections.
 func handleNotifyBlocks(wsc *wsClient, _ interface{}) (interface{}, error) {
@@ -2164,6 +2694,68 @@ func handleStopNotifyNewTransactions(wsc *wsClient, _ interface{}) (interface{},
 	return nil, nil
 }
 
+// handleNotifyStakeEvents implements the notifystakeevents command extension
+// for websocket connections.
+func handleNotifyStakeEvents(wsc *wsClient, icmd interface{}) (interface{}, error) {
+	cmd, ok := icmd.(*types.NotifyStakeEventsCmd)
+	if !ok {
+		return nil, dcrjson.ErrRPCInternal
+	}
+
+	tickets, err := decodeHashes(cmd.Tickets)
+	if err != nil {
+		return nil, err
+	}
+
+	wsc.Lock()
+	if wsc.stakeEventTickets == nil {
+		wsc.stakeEventTickets = make(map[chainhash.Hash]struct{},
+			len(tickets))
+	}
+	for _, ticket := range tickets {
+		wsc.stakeEventTickets[ticket] = struct{}{}
+	}
+	wsc.Unlock()
+
+	wsc.rpcServer.ntfnMgr.RegisterStakeEvents(wsc)
+	return nil, nil
+}
+
+// handleStopNotifyStakeEvents implements the stopnotifystakeevents command
+// extension for websocket connections.
+func handleStopNotifyStakeEvents(wsc *wsClient, icmd interface{}) (interface{}, error) {
+	cmd, ok := icmd.(*types.StopNotifyStakeEventsCmd)
+	if !ok {
+		return nil, dcrjson.ErrRPCInternal
+	}
+
+	// Stop watching all tickets when none are specified.
+	if cmd.Tickets == nil {
+		wsc.Lock()
+		wsc.stakeEventTickets = nil
+		wsc.Unlock()
+		wsc.rpcServer.ntfnMgr.UnregisterStakeEvents(wsc)
+		return nil, nil
+	}
+
+	tickets, err := decodeHashes(*cmd.Tickets)
+	if err != nil {
+		return nil, err
+	}
+
+	wsc.Lock()
+	for i := range tickets {
+		delete(wsc.stakeEventTickets, tickets[i])
+	}
+	numWatched := len(wsc.stakeEventTickets)
+	wsc.Unlock()
+
+	if numWatched == 0 {
+		wsc.rpcServer.ntfnMgr.UnregisterStakeEvents(wsc)
+	}
+	return nil, nil
+}
+
 // rescanBlock rescans a block for any relevant transactions for the passed
 // lookup keys.  Any discovered transactions are returned hex encoded as a
 // string slice.
@@ -2208,27 +2800,34 @@ func rescanBlock(filter *wsClientFilter, block *dcrutil.Block, params *chaincfg.
 
 	LoopOutputs:
 		for i, output := range tx.TxOut {
-			scriptType, addrs := stdscript.ExtractAddrs(output.Version,
-				output.PkScript, params)
-			if scriptType == stdscript.STNonStandard {
-				continue
-			}
-			for _, a := range addrs {
-				if !filter.existsAddress(a) {
+			matched := filter.existsScript(output.PkScript)
+			if !matched {
+				scriptType, addrs := stdscript.ExtractAddrs(output.Version,
+					output.PkScript, params)
+				if scriptType == stdscript.STNonStandard {
 					continue
 				}
-
-				op := wire.OutPoint{
-					Hash:  tx.TxHash(),
-					Index: uint32(i),
-					Tree:  tree,
+				for _, a := range addrs {
+					if filter.existsAddress(a) {
+						matched = true
+						break
+					}
 				}
-				filter.addUnspentOutPoint(&op)
+			}
+			if !matched {
+				continue
+			}
 
-				if !added {
-					transactions = append(transactions, txHexString(tx))
-					added = true
-				}
+			op := wire.OutPoint{
+				Hash:  tx.TxHash(),
+				Index: uint32(i),
+				Tree:  tree,
+			}
+			filter.addUnspentOutPoint(&op)
+
+			if !added {
+				transactions = append(transactions, txHexString(tx))
+				added = true
 			}
 		}
 	}
@@ -2315,6 +2914,194 @@ func handleRescan(wsc *wsClient, icmd interface{}) (interface{}, error) {
 	return &types.RescanResult{DiscoveredData: discoveredData}, nil
 }
 
+// handleRescanFilters implements the rescanfilters command extension for
+// websocket connections.
+//
+// It walks the main chain from the begin block through the end block, which
+// defaults to the current best block, and uses the version 2 committed filters
+// to only inspect the blocks that might contain transactions relevant to the
+// client's loaded transaction filter.  This avoids the need for clients to
+// download and match the filters themselves when rescanning against a trusted
+// node.
+func handleRescanFilters(wsc *wsClient, icmd interface{}) (interface{}, error) {
+	cmd, ok := icmd.(*types.RescanFiltersCmd)
+	if !ok {
+		return nil, dcrjson.ErrRPCInternal
+	}
+
+	// Load client's transaction filter.  Must exist in order to continue.
+	wsc.Lock()
+	filter := wsc.filterData
+	wsc.Unlock()
+	if filter == nil {
+		return nil, &dcrjson.RPCError{
+			Code:    dcrjson.ErrRPCMisc,
+			Message: "Transaction filter must be loaded before rescanning",
+		}
+	}
+
+	// Determine the range of main chain blocks to rescan.
+	rpcServer := wsc.rpcServer
+	cfg := rpcServer.cfg
+	bc := cfg.Chain
+	mainChainHeight := func(hashStr string) (int64, error) {
+		hash, err := chainhash.NewHashFromStr(hashStr)
+		if err != nil {
+			return 0, rpcDecodeHexError(hashStr)
+		}
+		height, err := bc.BlockHeightByHash(hash)
+		if err != nil {
+			return 0, &dcrjson.RPCError{
+				Code:    dcrjson.ErrRPCBlockNotFound,
+				Message: fmt.Sprintf("Block %s is not in the main chain", hash),
+			}
+		}
+		return height, nil
+	}
+	beginHeight, err := mainChainHeight(cmd.BeginBlock)
+	if err != nil {
+		return nil, err
+	}
+	endHeight := bc.BestSnapshot().Height
+	if cmd.EndBlock != nil {
+		endHeight, err = mainChainHeight(*cmd.EndBlock)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if endHeight < beginHeight {
+		return nil, rpcInvalidError("End block height %d is before begin "+
+			"block height %d", endHeight, beginHeight)
+	}
+
+	// Committed filters can't be matched against script hash prefixes, so
+	// every block must be inspected in that case.
+	//
+	// Also, committed filters commit to the scripts of the outputs spent by a
+	// block as opposed to their outpoints, so the scripts of the outputs the
+	// unspent outpoints in the filter reference must be watched as well in
+	// order to match the blocks that spend them.  Every block must be
+	// inspected when the script for any of them is not known since the output
+	// might already be spent.
+	filter.mu.Lock()
+	watchScripts := filter.watchScripts()
+	matchAll := len(filter.scriptHashPrefixes) != 0
+	if !matchAll {
+		outPointScripts, ok := filter.unspentOutPointScripts(bc.FetchUtxoEntry)
+		watchScripts = append(watchScripts, outPointScripts...)
+		matchAll = !ok
+	}
+	filter.mu.Unlock()
+
+	sendProgress := func(hash *chainhash.Hash, height int64) {
+		header, err := bc.HeaderByHash(hash)
+		if err != nil {
+			log.Errorf("Failed to load header for block %s: %v", hash, err)
+			return
+		}
+		ntfn := types.NewRescanProgressNtfn(hash.String(), height,
+			header.Timestamp.Unix())
+		marshalledJSON, err := dcrjson.MarshalCmd("1.0", nil, ntfn)
+		if err != nil {
+			log.Errorf("Failed to marshal rescan progress notification: %v",
+				err)
+			return
+		}
+		if err := wsc.QueueNotification(marshalledJSON); err != nil {
+			log.Debugf("Failed to queue rescan progress notification: %v",
+				err)
+		}
+	}
+
+	var discoveredData []types.RescannedBlock
+	for height := beginHeight; height <= endHeight; height++ {
+		// Stop the rescan early when the client disconnects.
+		if wsc.Disconnected() {
+			return nil, &dcrjson.RPCError{
+				Code:    dcrjson.ErrRPCMisc,
+				Message: "Client disconnected during rescan",
+			}
+		}
+
+		hash, err := bc.BlockHashByHeight(height)
+		if err != nil {
+			return nil, &dcrjson.RPCError{
+				Code: dcrjson.ErrRPCBlockNotFound,
+				Message: fmt.Sprintf("Failed to fetch block at height "+
+					"%d: %v", height, err),
+			}
+		}
+		if height != beginHeight && height%rescanProgressInterval == 0 {
+			sendProgress(hash, height)
+		}
+
+		// Skip blocks whose committed filter does not match any of the
+		// scripts being watched.
+		if !matchAll {
+			if len(watchScripts) == 0 {
+				continue
+			}
+			header, err := bc.HeaderByHash(hash)
+			if err != nil {
+				context := fmt.Sprintf("Failed to load header for block %s",
+					hash)
+				return nil, rpcInternalError(err.Error(), context)
+			}
+			cf, _, err := cfg.FiltererV2.FilterByBlockHash(hash)
+			if err != nil {
+				context := fmt.Sprintf("Failed to load filter for block %s",
+					hash)
+				return nil, rpcInternalError(err.Error(), context)
+			}
+			if !cf.MatchAny(blockcf2.Key(&header.MerkleRoot), watchScripts) {
+				continue
+			}
+		}
+
+		block, err := bc.BlockByHash(hash)
+		if err != nil {
+			return nil, &dcrjson.RPCError{
+				Code:    dcrjson.ErrRPCBlockNotFound,
+				Message: "Failed to fetch block: " + err.Error(),
+			}
+		}
+
+		// Determine if the treasury rules are active as of the block.
+		prevBlkHash := block.MsgBlock().Header.PrevBlock
+		isTreasuryEnabled, err := rpcServer.isTreasuryAgendaActive(&prevBlkHash)
+		if err != nil {
+			return nil, err
+		}
+
+		transactions := rescanBlock(filter, block, cfg.ChainParams,
+			isTreasuryEnabled)
+		if len(transactions) == 0 {
+			continue
+		}
+		discoveredData = append(discoveredData, types.RescannedBlock{
+			Hash:         hash.String(),
+			Transactions: transactions,
+		})
+
+		// Outputs that match the filter are added to it as unspent outpoints,
+		// so watch their scripts in order to also match the blocks that spend
+		// them.  This is necessary because outputs may match by address
+		// without their scripts being one of the scripts already watched, such
+		// as stake outputs.
+		if !matchAll {
+			filter.mu.Lock()
+			watchScripts = append(watchScripts,
+				filter.unspentOutputScripts(block)...)
+			filter.mu.Unlock()
+		}
+	}
+
+	if discoveredData == nil {
+		discoveredData = []types.RescannedBlock{}
+	}
+	return &types.RescanResult{DiscoveredData: discoveredData}, nil
+}
+
 func init() {
 	wsHandlers = wsHandlersBeforeInit
 }
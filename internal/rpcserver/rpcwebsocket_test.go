@@ -0,0 +1,373 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcserver
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/internal/blockchain"
+	"github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
+)
+
+// TestWSClientFilterScripts ensures the websocket client transaction filter
+// matches outputs by raw script and script hash prefix, including nonstandard
+// scripts, and that rescanning a block adds the matching outputs to the
+// filter.
+func TestWSClientFilterScripts(t *testing.T) {
+	params := chaincfg.MainNetParams()
+
+	// Create nonstandard scripts to match by raw script and by script hash
+	// prefix along with one that is not in the filter.
+	rawScript := []byte{0x51, 0x52, 0x93, 0x53, 0x87}    // 1 2 ADD 3 EQUAL
+	prefixScript := []byte{0x52, 0x53, 0x93, 0x55, 0x87} // 2 3 ADD 5 EQUAL
+	otherScript := []byte{0x53, 0x54, 0x93, 0x57, 0x87}  // 3 4 ADD 7 EQUAL
+	prefix := chainhash.HashB(prefixScript)[:4]
+	filter := makeWSClientFilter(nil, nil, [][]byte{rawScript},
+		[][]byte{prefix}, params)
+
+	tests := []struct {
+		name   string
+		script []byte
+		want   bool
+	}{
+		{name: "raw script", script: rawScript, want: true},
+		{name: "script hash prefix", script: prefixScript, want: true},
+		{name: "unrelated script", script: otherScript, want: false},
+		{name: "empty script", script: nil, want: false},
+	}
+	for _, test := range tests {
+		if got := filter.existsScript(test.script); got != test.want {
+			t.Errorf("%q: mismatched result -- got %v, want %v", test.name,
+				got, test.want)
+		}
+	}
+
+	// Ensure rescanning a block that contains a transaction with outputs that
+	// pay to the scripts returns the transaction and adds the outpoints of the
+	// matching outputs to the filter.
+	coinbase := wire.NewMsgTx()
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{},
+		wire.MaxPrevOutIndex, wire.TxTreeRegular), 0, nil))
+	coinbase.AddTxOut(wire.NewTxOut(0, otherScript))
+	tx := wire.NewMsgTx()
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{0x01}, 0,
+		wire.TxTreeRegular), 0, nil))
+	tx.AddTxOut(wire.NewTxOut(1, otherScript))
+	tx.AddTxOut(wire.NewTxOut(2, rawScript))
+	tx.AddTxOut(wire.NewTxOut(3, prefixScript))
+	block := dcrutil.NewBlock(&wire.MsgBlock{
+		Transactions: []*wire.MsgTx{coinbase, tx},
+	})
+	txns := rescanBlock(filter, block, params, false)
+	if len(txns) != 1 || txns[0] != txHexString(tx) {
+		t.Fatalf("mismatched rescan results -- got %d txns, want 1", len(txns))
+	}
+	txHash := tx.TxHash()
+	for i, want := range []bool{false, true, true} {
+		op := wire.OutPoint{Hash: txHash, Index: uint32(i)}
+		if got := filter.existsUnspentOutPoint(&op); got != want {
+			t.Errorf("output %d: mismatched outpoint existence -- got %v, "+
+				"want %v", i, got, want)
+		}
+	}
+}
+
+// TestWSClientFilterWatchScripts ensures the scripts used to match committed
+// filters during filter-based rescans include the payment scripts for the
+// addresses in the filter as well as its raw scripts.
+func TestWSClientFilterWatchScripts(t *testing.T) {
+	params := chaincfg.MainNetParams()
+
+	const pubKeyHex = "028f53838b7639563f27c94845549a41e5146bcd52e7fef0ea6da" +
+		"143a02b0fe2ed"
+	pubKey, err := hex.DecodeString(pubKeyHex)
+	if err != nil {
+		t.Fatalf("unable to decode pubkey: %v", err)
+	}
+	pkAddr, err := stdaddr.NewAddressPubKeyEcdsaSecp256k1V0Raw(pubKey, params)
+	if err != nil {
+		t.Fatalf("unable to create pubkey address: %v", err)
+	}
+	shAddr, err := stdaddr.NewAddressScriptHashV0([]byte{0x51}, params)
+	if err != nil {
+		t.Fatalf("unable to create script hash address: %v", err)
+	}
+	rawScript := []byte{0x51, 0x52, 0x93, 0x53, 0x87} // 1 2 ADD 3 EQUAL
+	filter := makeWSClientFilter([]string{pkAddr.String(), shAddr.String()}, nil,
+		[][]byte{rawScript}, nil, params)
+
+	// The public key address must match both pay-to-pubkey and
+	// pay-to-pubkey-hash outputs.
+	_, pkScript := pkAddr.PaymentScript()
+	_, pkhScript := pkAddr.AddressPubKeyHash().PaymentScript()
+	_, shScript := shAddr.PaymentScript()
+	want := map[string]struct{}{
+		string(pkScript):  {},
+		string(pkhScript): {},
+		string(shScript):  {},
+		string(rawScript): {},
+	}
+	got := filter.watchScripts()
+	if len(got) != len(want) {
+		t.Fatalf("mismatched number of watch scripts -- got %d, want %d",
+			len(got), len(want))
+	}
+	for _, script := range got {
+		if _, ok := want[string(script)]; !ok {
+			t.Errorf("unexpected watch script %x", script)
+		}
+	}
+}
+
+// TestWSClientFilterOutPointScripts ensures the scripts used to match committed
+// filters during filter-based rescans include the scripts of the outputs
+// referenced by the unspent outpoints in the filter and that the caller is
+// informed when any of them are unknown.
+func TestWSClientFilterOutPointScripts(t *testing.T) {
+	params := chaincfg.MainNetParams()
+
+	unspentScript := []byte{0x51, 0x52, 0x93, 0x53, 0x87} // 1 2 ADD 3 EQUAL
+	unspentOp := wire.OutPoint{Hash: chainhash.Hash{0x01}}
+	spentOp := wire.OutPoint{Hash: chainhash.Hash{0x02}}
+	unknownOp := wire.OutPoint{Hash: chainhash.Hash{0x03}}
+	entries := map[wire.OutPoint]*testRPCUtxoEntry{
+		unspentOp: {pkScript: unspentScript},
+		spentOp:   {pkScript: []byte{0x52}, isSpent: true},
+	}
+	fetchUtxoEntry := func(op wire.OutPoint) (UtxoEntry, error) {
+		entry, ok := entries[op]
+		if !ok {
+			return nil, nil
+		}
+		return entry, nil
+	}
+
+	tests := []struct {
+		name      string
+		outPoints []*wire.OutPoint
+		want      [][]byte
+		wantAll   bool
+	}{{
+		name:    "no outpoints",
+		want:    [][]byte{},
+		wantAll: true,
+	}, {
+		name:      "unspent outpoint",
+		outPoints: []*wire.OutPoint{&unspentOp},
+		want:      [][]byte{unspentScript},
+		wantAll:   true,
+	}, {
+		name:      "spent outpoint",
+		outPoints: []*wire.OutPoint{&unspentOp, &spentOp},
+		want:      [][]byte{unspentScript},
+		wantAll:   false,
+	}, {
+		name:      "unknown outpoint",
+		outPoints: []*wire.OutPoint{&unknownOp},
+		want:      [][]byte{},
+		wantAll:   false,
+	}}
+	for _, test := range tests {
+		filter := makeWSClientFilter(nil, test.outPoints, nil, nil, params)
+		got, gotAll := filter.unspentOutPointScripts(fetchUtxoEntry)
+		if !reflect.DeepEqual(got, test.want) || gotAll != test.wantAll {
+			t.Errorf("%q: mismatched result -- got %x (%v), want %x (%v)",
+				test.name, got, gotAll, test.want, test.wantAll)
+		}
+	}
+
+	// Ensure the scripts of the outputs in a block that are unspent outpoints
+	// in the filter are returned.
+	ticketScript := []byte{0xba, 0x76, 0xa9} // SSTX DUP HASH160
+	regularScript := []byte{0x51}            // 1
+	stakeTx := wire.NewMsgTx()
+	stakeTx.AddTxOut(wire.NewTxOut(1, ticketScript))
+	regularTx := wire.NewMsgTx()
+	regularTx.AddTxOut(wire.NewTxOut(1, []byte{0x52}))
+	regularTx.AddTxOut(wire.NewTxOut(2, regularScript))
+	block := dcrutil.NewBlock(&wire.MsgBlock{
+		Transactions:  []*wire.MsgTx{regularTx},
+		STransactions: []*wire.MsgTx{stakeTx},
+	})
+	filter := makeWSClientFilter(nil, []*wire.OutPoint{
+		wire.NewOutPoint(&chainhash.Hash{}, 0, wire.TxTreeStake),
+		wire.NewOutPoint(&chainhash.Hash{}, 0, wire.TxTreeRegular),
+	}, nil, nil, params)
+	stakeHash, regularHash := stakeTx.TxHash(), regularTx.TxHash()
+	filter.addUnspentOutPoint(wire.NewOutPoint(&stakeHash, 0,
+		wire.TxTreeStake))
+	filter.addUnspentOutPoint(wire.NewOutPoint(&regularHash, 1,
+		wire.TxTreeRegular))
+	got := filter.unspentOutputScripts(block)
+	want := [][]byte{ticketScript, regularScript}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("mismatched output scripts -- got %x, want %x", got, want)
+	}
+}
+
+// TestWSClientFilterStakeEvents ensures stake event notifications only include
+// the tickets watched by the websocket client and that tickets which voted or
+// were revoked are no longer watched afterwards.
+func TestWSClientFilterStakeEvents(t *testing.T) {
+	matured := chainhash.Hash{0x01}
+	voted := chainhash.Hash{0x02}
+	missed := chainhash.Hash{0x03}
+	revoked := chainhash.Hash{0x04}
+	unwatched := chainhash.Hash{0x05}
+	wsc := &wsClient{stakeEventTickets: map[chainhash.Hash]struct{}{
+		matured: {},
+		voted:   {},
+		missed:  {},
+		revoked: {},
+	}}
+
+	// Ensure no notification is created when none of the watched tickets are
+	// involved.
+	tnd := &blockchain.TicketNotificationsData{
+		Hash:          chainhash.Hash{0xff},
+		Height:        100,
+		TicketsNew:    []chainhash.Hash{unwatched},
+		TicketsMissed: []chainhash.Hash{unwatched},
+	}
+	if ntfn := wsc.filterStakeEvents(tnd); ntfn != nil {
+		t.Fatalf("unexpected notification for unwatched tickets: %+v", ntfn)
+	}
+
+	// Ensure only the watched tickets are included in the notification.
+	tnd = &blockchain.TicketNotificationsData{
+		Hash:           chainhash.Hash{0xff},
+		Height:         101,
+		TicketsNew:     []chainhash.Hash{matured, unwatched},
+		TicketsVoted:   []chainhash.Hash{voted},
+		TicketsMissed:  []chainhash.Hash{missed},
+		TicketsRevoked: []chainhash.Hash{unwatched, revoked},
+	}
+	ntfn := wsc.filterStakeEvents(tnd)
+	if ntfn == nil {
+		t.Fatal("missing notification for watched tickets")
+	}
+	want := types.NewStakeEventsNtfn(tnd.Hash.String(), 101,
+		[]string{matured.String()}, []string{voted.String()},
+		[]string{missed.String()}, []string{}, []string{revoked.String()})
+	if !reflect.DeepEqual(ntfn, want) {
+		t.Fatalf("mismatched notification -- got %+v, want %+v", ntfn, want)
+	}
+
+	// Ensure the tickets that voted or were revoked are no longer watched.
+	for _, ticket := range []chainhash.Hash{voted, revoked} {
+		if _, ok := wsc.stakeEventTickets[ticket]; ok {
+			t.Errorf("ticket %v is still watched", ticket)
+		}
+	}
+	for _, ticket := range []chainhash.Hash{matured, missed} {
+		if _, ok := wsc.stakeEventTickets[ticket]; !ok {
+			t.Errorf("ticket %v is no longer watched", ticket)
+		}
+	}
+}
+
+// TestWSClientNotificationBudget ensures websocket clients that exceed the
+// budget of queued notification bytes have their notifications dropped in favor
+// of a notificationsdropped notification and are disconnected once it is sent.
+func TestWSClientNotificationBudget(t *testing.T) {
+	// Create a websocket connection for the client.
+	connChan := make(chan *websocket.Conn, 1)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Upgrade(w, r, nil, 0, 0)
+		if err != nil {
+			t.Errorf("unable to upgrade connection: %v", err)
+			return
+		}
+		connChan <- conn
+	}))
+	defer httpServer.Close()
+	wsURL := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	remoteConn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("unable to dial websocket: %v", err)
+	}
+	defer remoteConn.Close()
+
+	// Only run the notification queue handler so the test is able to
+	// control when the notifications are considered sent.
+	server := &Server{cfg: Config{RPCMaxWebsocketQueueBytes: 100}}
+	wsc := &wsClient{
+		rpcServer: server,
+		conn:      <-connChan,
+		ntfnChan:  make(chan []byte, 1),
+		sendChan:  make(chan wsResponse, websocketSendBufferSize),
+		quit:      make(chan struct{}),
+	}
+	wsc.wg.Add(1)
+	go wsc.notificationQueueHandler()
+	defer wsc.WaitForShutdown()
+	defer wsc.Disconnect()
+
+	// recvSent waits for the next message to be sent and returns it.
+	recvSent := func() wsResponse {
+		t.Helper()
+		select {
+		case r := <-wsc.sendChan:
+			return r
+		case <-time.After(time.Second * 5):
+			t.Fatal("timeout waiting for sent message")
+		}
+		return wsResponse{}
+	}
+
+	// Queue notifications such that the pending ones exceed the budget while
+	// the first one is still being sent.
+	ntfn := bytes.Repeat([]byte{'a'}, 60)
+	for i := 0; i < 3; i++ {
+		if err := wsc.QueueNotification(ntfn); err != nil {
+			t.Fatalf("unexpected error queueing notification: %v", err)
+		}
+	}
+	r := recvSent()
+	if !bytes.Equal(r.msg, ntfn) {
+		t.Fatalf("mismatched first notification -- got %s, want %s", r.msg,
+			ntfn)
+	}
+
+	// Ensure notifications queued after the budget was exceeded are
+	// discarded.
+	if err := wsc.QueueNotification(ntfn); err != nil {
+		t.Fatalf("unexpected error queueing notification: %v", err)
+	}
+	r.doneChan <- true
+
+	// Ensure the next message informs the client its notifications were
+	// dropped and the client is disconnected once it is sent.
+	r = recvSent()
+	want := `{"jsonrpc":"1.0","method":"notificationsdropped",` +
+		`"params":[120,100],"id":null}`
+	if string(r.msg) != want {
+		t.Fatalf("mismatched notification -- got %s, want %s", r.msg, want)
+	}
+	r.doneChan <- true
+	select {
+	case <-wsc.quit:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timeout waiting for client to be disconnected")
+	}
+	if got := atomic.LoadUint64(&server.slowWebsockets); got != 1 {
+		t.Fatalf("mismatched slow websockets -- got %d, want 1", got)
+	}
+}
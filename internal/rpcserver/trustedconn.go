@@ -0,0 +1,55 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcserver
+
+import (
+	"context"
+	"net"
+)
+
+// trustedListener wraps a listener such that all connections it accepts are
+// identifiable as trusted.
+type trustedListener struct {
+	net.Listener
+}
+
+// trustedConn wraps a connection accepted by a trusted listener.
+type trustedConn struct {
+	net.Conn
+}
+
+// Accept waits for and returns the next connection to the listener wrapped as
+// a trusted connection.
+//
+// This is part of the net.Listener interface.
+func (l trustedListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return trustedConn{conn}, nil
+}
+
+// trustedConnCtxKey is the context key used to mark contexts associated with
+// trusted connections.
+type trustedConnCtxKey struct{}
+
+// trustedConnContext returns a context derived from the provided one that is
+// marked as associated with a trusted connection when the provided connection
+// was accepted by a trusted listener.  It is intended to be used as the
+// ConnContext function of an HTTP server.
+func trustedConnContext(ctx context.Context, conn net.Conn) context.Context {
+	if _, ok := conn.(trustedConn); ok {
+		return context.WithValue(ctx, trustedConnCtxKey{}, true)
+	}
+	return ctx
+}
+
+// isTrustedConn returns whether or not the provided context, which is
+// typically that of an HTTP request, is associated with a trusted connection.
+func isTrustedConn(ctx context.Context) bool {
+	trusted, _ := ctx.Value(trustedConnCtxKey{}).(bool)
+	return trusted
+}
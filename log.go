@@ -58,24 +58,24 @@ var (
 	// application shutdown.
 	logRotator *rotator.Rotator
 
-	adxrLog = backendLog.Logger("ADXR")
-	amgrLog = backendLog.Logger("AMGR")
-	bcdbLog = backendLog.Logger("BCDB")
-	chanLog = backendLog.Logger("CHAN")
-	cmgrLog = backendLog.Logger("CMGR")
-	dcrdLog = backendLog.Logger("DCRD")
-	discLog = backendLog.Logger("DISC")
-	feesLog = backendLog.Logger("FEES")
-	indxLog = backendLog.Logger("INDX")
-	minrLog = backendLog.Logger("MINR")
-	peerLog = backendLog.Logger("PEER")
-	rpcsLog = backendLog.Logger("RPCS")
-	scrpLog = backendLog.Logger("SCRP")
-	srvrLog = backendLog.Logger("SRVR")
-	stkeLog = backendLog.Logger("STKE")
-	syncLog = backendLog.Logger("SYNC")
-	txmpLog = backendLog.Logger("TXMP")
-	trsyLog = backendLog.Logger("TRSY")
+	adxrLog = newSubsystemLogger("ADXR")
+	amgrLog = newSubsystemLogger("AMGR")
+	bcdbLog = newSubsystemLogger("BCDB")
+	chanLog = newSubsystemLogger("CHAN")
+	cmgrLog = newSubsystemLogger("CMGR")
+	dcrdLog = newSubsystemLogger("DCRD")
+	discLog = newSubsystemLogger("DISC")
+	feesLog = newSubsystemLogger("FEES")
+	indxLog = newSubsystemLogger("INDX")
+	minrLog = newSubsystemLogger("MINR")
+	peerLog = newSubsystemLogger("PEER")
+	rpcsLog = newSubsystemLogger("RPCS")
+	scrpLog = newSubsystemLogger("SCRP")
+	srvrLog = newSubsystemLogger("SRVR")
+	stkeLog = newSubsystemLogger("STKE")
+	syncLog = newSubsystemLogger("SYNC")
+	txmpLog = newSubsystemLogger("TXMP")
+	trsyLog = newSubsystemLogger("TRSY")
 )
 
 // Initialize package-global logger variables.
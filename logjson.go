@@ -0,0 +1,307 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/wire"
+	"github.com/decred/slog"
+)
+
+const (
+	// logFormatText is the log format that writes human-readable log lines.
+	logFormatText = "text"
+
+	// logFormatJSON is the log format that writes each log entry as a JSON
+	// object on a single line.
+	logFormatJSON = "json"
+)
+
+var (
+	// jsonLogging indicates whether or not log entries are written as JSON
+	// objects instead of human-readable lines.  It is set while loading the
+	// config before any logging takes place and is not modified afterwards.
+	jsonLogging bool
+
+	// jsonLogMtx serializes writes of JSON log entries so they are not
+	// interleaved.
+	jsonLogMtx sync.Mutex
+)
+
+// jsonLevelStrs defines the names for each logging level in JSON log entries.
+var jsonLevelStrs = map[slog.Level]string{
+	slog.LevelTrace:    "trace",
+	slog.LevelDebug:    "debug",
+	slog.LevelInfo:     "info",
+	slog.LevelWarn:     "warn",
+	slog.LevelError:    "error",
+	slog.LevelCritical: "critical",
+}
+
+// jsonLogEntry describes a log entry written in the JSON log format.
+//
+// In addition to the time, level, subsystem, and message, the entry contains
+// context fields derived from the types of the arguments provided to the
+// logging call so that the relevant blocks, transactions, and peers can be
+// queried without parsing the message.
+type jsonLogEntry struct {
+	Time      string   `json:"time"`
+	Level     string   `json:"level"`
+	Subsystem string   `json:"subsystem"`
+	Message   string   `json:"msg"`
+	Block     string   `json:"block,omitempty"`
+	Tx        string   `json:"tx,omitempty"`
+	Hashes    []string `json:"hashes,omitempty"`
+	Peer      string   `json:"peer,omitempty"`
+	Addr      string   `json:"addr,omitempty"`
+}
+
+// addContext adds the context fields that can be derived from the provided
+// logging argument to the entry.
+func (e *jsonLogEntry) addContext(param interface{}) {
+	switch p := param.(type) {
+	case *dcrutil.Block:
+		if p != nil {
+			e.Block = p.Hash().String()
+		}
+	case *wire.MsgBlock:
+		if p != nil {
+			e.Block = p.BlockHash().String()
+		}
+	case *wire.BlockHeader:
+		if p != nil {
+			e.Block = p.BlockHash().String()
+		}
+	case *dcrutil.Tx:
+		if p != nil {
+			e.Tx = p.Hash().String()
+		}
+	case *wire.MsgTx:
+		if p != nil {
+			e.Tx = p.TxHash().String()
+		}
+	case chainhash.Hash:
+		e.Hashes = append(e.Hashes, p.String())
+	case *chainhash.Hash:
+		if p != nil {
+			e.Hashes = append(e.Hashes, p.String())
+		}
+	case interface{ Addr() string }:
+		// Peers.
+		e.Peer = p.Addr()
+	case net.Addr:
+		e.Addr = p.String()
+	}
+}
+
+// subsystemLogger is a slog.Logger for a specific subsystem that writes to the
+// backend logger in the human-readable format by default and writes JSON
+// objects instead when JSON logging is enabled.
+type subsystemLogger struct {
+	slog.Logger
+	subsystem string
+}
+
+// newSubsystemLogger returns a new logger for the provided subsystem that
+// writes to the package-global backend logger.
+func newSubsystemLogger(subsystem string) *subsystemLogger {
+	return &subsystemLogger{
+		Logger:    backendLog.Logger(subsystem),
+		subsystem: subsystem,
+	}
+}
+
+// writeJSON writes a JSON log entry with the provided level and message along
+// with the context derived from the provided arguments.
+func (l *subsystemLogger) writeJSON(level slog.Level, msg string, params []interface{}) {
+	entry := jsonLogEntry{
+		Time:      time.Now().Format(time.RFC3339Nano),
+		Level:     jsonLevelStrs[level],
+		Subsystem: l.subsystem,
+		Message:   msg,
+	}
+	for _, param := range params {
+		entry.addContext(param)
+	}
+	line, err := json.Marshal(&entry)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	jsonLogMtx.Lock()
+	logWriter{}.Write(line)
+	jsonLogMtx.Unlock()
+}
+
+// logf writes a formatted log entry with the provided level.
+func (l *subsystemLogger) logf(level slog.Level, format string, params []interface{}) {
+	if level < l.Level() {
+		return
+	}
+	l.writeJSON(level, fmt.Sprintf(format, params...), params)
+}
+
+// log writes a log entry with the provided level that is formatted using the
+// default formats for its arguments.
+func (l *subsystemLogger) log(level slog.Level, v []interface{}) {
+	if level < l.Level() {
+		return
+	}
+	msg := strings.TrimSuffix(fmt.Sprintln(v...), "\n")
+	l.writeJSON(level, msg, v)
+}
+
+// Tracef formats message according to format specifier and writes to log with
+// LevelTrace.
+//
+// This is part of the slog.Logger interface implementation.
+func (l *subsystemLogger) Tracef(format string, params ...interface{}) {
+	if jsonLogging {
+		l.logf(slog.LevelTrace, format, params)
+		return
+	}
+	l.Logger.Tracef(format, params...)
+}
+
+// Debugf formats message according to format specifier and writes to log with
+// LevelDebug.
+//
+// This is part of the slog.Logger interface implementation.
+func (l *subsystemLogger) Debugf(format string, params ...interface{}) {
+	if jsonLogging {
+		l.logf(slog.LevelDebug, format, params)
+		return
+	}
+	l.Logger.Debugf(format, params...)
+}
+
+// Infof formats message according to format specifier and writes to log with
+// LevelInfo.
+//
+// This is part of the slog.Logger interface implementation.
+func (l *subsystemLogger) Infof(format string, params ...interface{}) {
+	if jsonLogging {
+		l.logf(slog.LevelInfo, format, params)
+		return
+	}
+	l.Logger.Infof(format, params...)
+}
+
+// Warnf formats message according to format specifier and writes to log with
+// LevelWarn.
+//
+// This is part of the slog.Logger interface implementation.
+func (l *subsystemLogger) Warnf(format string, params ...interface{}) {
+	if jsonLogging {
+		l.logf(slog.LevelWarn, format, params)
+		return
+	}
+	l.Logger.Warnf(format, params...)
+}
+
+// Errorf formats message according to format specifier and writes to log with
+// LevelError.
+//
+// This is part of the slog.Logger interface implementation.
+func (l *subsystemLogger) Errorf(format string, params ...interface{}) {
+	if jsonLogging {
+		l.logf(slog.LevelError, format, params)
+		return
+	}
+	l.Logger.Errorf(format, params...)
+}
+
+// Criticalf formats message according to format specifier and writes to log
+// with LevelCritical.
+//
+// This is part of the slog.Logger interface implementation.
+func (l *subsystemLogger) Criticalf(format string, params ...interface{}) {
+	if jsonLogging {
+		l.logf(slog.LevelCritical, format, params)
+		return
+	}
+	l.Logger.Criticalf(format, params...)
+}
+
+// Trace formats message using the default formats for its operands and writes
+// to log with LevelTrace.
+//
+// This is part of the slog.Logger interface implementation.
+func (l *subsystemLogger) Trace(v ...interface{}) {
+	if jsonLogging {
+		l.log(slog.LevelTrace, v)
+		return
+	}
+	l.Logger.Trace(v...)
+}
+
+// Debug formats message using the default formats for its operands and writes
+// to log with LevelDebug.
+//
+// This is part of the slog.Logger interface implementation.
+func (l *subsystemLogger) Debug(v ...interface{}) {
+	if jsonLogging {
+		l.log(slog.LevelDebug, v)
+		return
+	}
+	l.Logger.Debug(v...)
+}
+
+// Info formats message using the default formats for its operands and writes
+// to log with LevelInfo.
+//
+// This is part of the slog.Logger interface implementation.
+func (l *subsystemLogger) Info(v ...interface{}) {
+	if jsonLogging {
+		l.log(slog.LevelInfo, v)
+		return
+	}
+	l.Logger.Info(v...)
+}
+
+// Warn formats message using the default formats for its operands and writes
+// to log with LevelWarn.
+//
+// This is part of the slog.Logger interface implementation.
+func (l *subsystemLogger) Warn(v ...interface{}) {
+	if jsonLogging {
+		l.log(slog.LevelWarn, v)
+		return
+	}
+	l.Logger.Warn(v...)
+}
+
+// Error formats message using the default formats for its operands and writes
+// to log with LevelError.
+//
+// This is part of the slog.Logger interface implementation.
+func (l *subsystemLogger) Error(v ...interface{}) {
+	if jsonLogging {
+		l.log(slog.LevelError, v)
+		return
+	}
+	l.Logger.Error(v...)
+}
+
+// Critical formats message using the default formats for its operands and
+// writes to log with LevelCritical.
+//
+// This is part of the slog.Logger interface implementation.
+func (l *subsystemLogger) Critical(v ...interface{}) {
+	if jsonLogging {
+		l.log(slog.LevelCritical, v)
+		return
+	}
+	l.Logger.Critical(v...)
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+// testLogPeer is a mock peer that provides its address for the purposes of
+// testing the context of JSON log entries.
+type testLogPeer string
+
+// Addr returns the address of the mock peer.
+func (p testLogPeer) Addr() string {
+	return string(p)
+}
+
+// TestJSONLogEntryContext ensures the context of JSON log entries is derived
+// from the types of the logging arguments as expected.
+func TestJSONLogEntryContext(t *testing.T) {
+	hash := chainhash.Hash{0x01}
+	tx := wire.NewMsgTx()
+	block := &wire.MsgBlock{Header: wire.BlockHeader{Height: 1}}
+	tcpAddr := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 9108}
+
+	tests := []struct {
+		name   string
+		params []interface{}
+		want   jsonLogEntry
+	}{{
+		name:   "no context",
+		params: []interface{}{"str", 1, nil},
+		want:   jsonLogEntry{},
+	}, {
+		name:   "hashes",
+		params: []interface{}{hash, &hash, (*chainhash.Hash)(nil)},
+		want:   jsonLogEntry{Hashes: []string{hash.String(), hash.String()}},
+	}, {
+		name:   "block and transaction",
+		params: []interface{}{dcrutil.NewBlock(block), dcrutil.NewTx(tx)},
+		want: jsonLogEntry{
+			Block: block.BlockHash().String(),
+			Tx:    tx.TxHash().String(),
+		},
+	}, {
+		name:   "wire block header and transaction",
+		params: []interface{}{&block.Header, tx},
+		want: jsonLogEntry{
+			Block: block.BlockHash().String(),
+			Tx:    tx.TxHash().String(),
+		},
+	}, {
+		name:   "peer and network address",
+		params: []interface{}{testLogPeer("127.0.0.1:19108"), tcpAddr},
+		want: jsonLogEntry{
+			Peer: "127.0.0.1:19108",
+			Addr: "127.0.0.1:9108",
+		},
+	}}
+
+	for _, test := range tests {
+		var entry jsonLogEntry
+		for _, param := range test.params {
+			entry.addContext(param)
+		}
+		if !reflect.DeepEqual(entry, test.want) {
+			t.Errorf("%q: mismatched entry -- got %+v, want %+v", test.name,
+				entry, test.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package uint256
+
+import (
+	"io"
+)
+
+// SetRandom sets the uint256 to a uniformly distributed random value in the
+// range [0, 2^256) read from the passed source of randomness.
+//
+// The passed reader must be a source of uniformly distributed random bytes,
+// such as crypto/rand.Reader or a seeded math/rand.Rand, in order for the
+// result to also be uniformly distributed.
+//
+// The uint256 is not modified when an error is returned.
+func (n *Uint256) SetRandom(rand io.Reader) error {
+	var buf [32]byte
+	if _, err := io.ReadFull(rand, buf[:]); err != nil {
+		return err
+	}
+	n.SetBytes(&buf)
+	return nil
+}
+
+// SetRandomRange sets the uint256 to a uniformly distributed random value in
+// the range [0, max) read from the passed source of randomness.  It will panic
+// if max is 0.
+//
+// Rejection sampling is used to ensure the result is unbiased.  Concretely,
+// random values that consist of the same number of bits as the maximum
+// possible result are repeatedly generated until one that is in the range is
+// found.  Since the range is always more than half of the values that can be
+// represented by that number of bits, the expected number of attempts is less
+// than two.
+//
+// The passed reader must be a source of uniformly distributed random bytes,
+// such as crypto/rand.Reader or a seeded math/rand.Rand, in order for the
+// result to also be uniformly distributed.
+//
+// The uint256 is not modified when an error is returned.  It is safe to alias
+// the max argument.
+func (n *Uint256) SetRandomRange(rand io.Reader, max *Uint256) error {
+	if max.IsZero() {
+		panic("empty random range")
+	}
+
+	// Determine the number of bits needed to represent the maximum possible
+	// result so that random values with any larger bits can be masked off
+	// prior to testing them against the range.
+	var maxResult Uint256
+	maxResult.Set(max).SubUint64(1)
+	shift := uint32(256 - maxResult.BitLen())
+
+	var candidate Uint256
+	for {
+		if err := candidate.SetRandom(rand); err != nil {
+			return err
+		}
+		candidate.Rsh(shift)
+		if candidate.Lt(max) {
+			n.Set(&candidate)
+			return nil
+		}
+	}
+}
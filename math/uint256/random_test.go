@@ -0,0 +1,131 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package uint256
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// TestUint256SetRandom ensures that setting a uint256 to a random value reads
+// the expected bytes from the source of randomness and leaves the value
+// unmodified on errors.
+func TestUint256SetRandom(t *testing.T) {
+	t.Parallel()
+
+	// Ensure the value is set to the big-endian interpretation of the bytes
+	// read from the source.
+	buf := hexToBytes("0102030405060708090a0b0c0d0e0f10111213141516171819" +
+		"1a1b1c1d1e1f20")
+	var n Uint256
+	if err := n.SetRandom(bytes.NewReader(buf)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := new(Uint256).SetByteSlice(buf)
+	if !n.Eq(want) {
+		t.Fatalf("wrong result -- got: %x, want: %x", n, want)
+	}
+
+	// Ensure a short read results in an error without modifying the value.
+	err := n.SetRandom(bytes.NewReader(buf[:31]))
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("mismatched error -- got %v, want %v", err,
+			io.ErrUnexpectedEOF)
+	}
+	if !n.Eq(want) {
+		t.Fatalf("value modified on error -- got: %x, want: %x", n, want)
+	}
+}
+
+// TestUint256SetRandomRange ensures that setting a uint256 to a random value in
+// a range works as expected for edge cases as well as randomly-generated
+// ranges.
+func TestUint256SetRandomRange(t *testing.T) {
+	t.Parallel()
+
+	// Use a unique random seed each test instance and log it if the tests fail.
+	seed := time.Now().Unix()
+	rng := rand.New(rand.NewSource(seed))
+	defer func(t *testing.T, seed int64) {
+		if t.Failed() {
+			t.Logf("random seed: %d", seed)
+		}
+	}(t, seed)
+
+	// Ensure a range of a single value always produces zero.
+	var n Uint256
+	n.SetUint64(1)
+	one := new(Uint256).SetUint64(1)
+	if err := n.SetRandomRange(rng, one); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !n.IsZero() {
+		t.Fatalf("wrong result for range [0, 1) -- got: %x, want: 0", n)
+	}
+
+	// Ensure values are rejected until one that is in the range is found.
+	// The range [0, 5) requires 3 bits, so the candidates are the 3 most
+	// significant bits of each random value read and thus 7 and 5 must be
+	// rejected.
+	five := new(Uint256).SetUint64(5)
+	var src bytes.Buffer
+	for _, firstByte := range []byte{0xff, 0xa0, 0x7f} {
+		src.WriteByte(firstByte)
+		src.Write(make([]byte, 31))
+	}
+	if err := n.SetRandomRange(&src, five); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !n.EqUint64(3) {
+		t.Fatalf("wrong result for range [0, 5) -- got: %x, want: 3", n)
+	}
+
+	// Ensure a source that runs out of data results in an error.
+	var buf [64]byte
+	err := n.SetRandomRange(bytes.NewReader(buf[:16]), five)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("mismatched error -- got %v, want %v", err,
+			io.ErrUnexpectedEOF)
+	}
+
+	// Ensure random values are always in random ranges of varying sizes,
+	// including when aliased with the max.
+	for i := 0; i < 1000; i++ {
+		var max Uint256
+		if err := max.SetRandom(rng); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		max.Rsh(uint32(rng.Intn(256)))
+		if max.IsZero() {
+			max.SetUint64(1)
+		}
+		if err := n.SetRandomRange(rng, &max); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !n.Lt(&max) {
+			t.Fatalf("random value %x is not less than %x", n, max)
+		}
+		origMax := max
+		if err := max.SetRandomRange(rng, &max); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !max.Lt(&origMax) {
+			t.Fatalf("aliased random value %x is not less than %x", max,
+				origMax)
+		}
+	}
+
+	// Ensure an empty range panics.
+	defer func() {
+		if err := recover(); err == nil {
+			t.Fatal("SetRandomRange did not panic on an empty range")
+		}
+	}()
+	_ = n.SetRandomRange(rng, new(Uint256))
+}
@@ -1118,6 +1118,164 @@ func (n *Uint256) DivUint64(divisor uint64) *Uint256 {
 	return n.Set(&quotient)
 }
 
+// uint512 is an unsigned 512-bit integer stored as eight base 2^64 digits in
+// little-endian order.  It is only used internally to house the intermediate
+// results of operations such as MulDiv that must not overflow.
+type uint512 [8]uint64
+
+// mul512 returns the full 512-bit product of the passed uint256s.
+func mul512(n1, n2 *Uint256) uint512 {
+	// This uses standard schoolbook multiplication, however, unlike Mul2, all
+	// of the intermediate terms are needed since the result is not reduced.
+	var r uint512
+	for i := 0; i < 4; i++ {
+		var c uint64
+		for j := 0; j < 4; j++ {
+			c, r[i+j] = mulAdd64Carry(n2.n[i], n1.n[j], r[i+j], c)
+		}
+		r[i+4] = c
+	}
+	return r
+}
+
+// numDigits returns the number of base 2^64 digits required to represent the
+// uint512.  The result is 0 when the value is 0.
+func (n *uint512) numDigits() int {
+	for i := 7; i >= 0; i-- {
+		if n[i] != 0 {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// MulDiv multiplies the first two passed uint256s together and divides the
+// full 512-bit product by the passed uint256 divisor, then stores the result
+// modulo 2^256 in n.  It will panic if the divisor is 0.
+//
+// Unlike n.Mul2(n1, n2).Div(divisor), the product is not reduced modulo 2^256
+// prior to the division, so the result is correct so long as the final
+// quotient fits in a uint256.  This makes it suitable for calculating scaled
+// ratios such as those involved in difficulty and work calculations without
+// needing to resort to big integers.
+//
+// This implements truncated division like native Go integers and it is safe to
+// alias the arguments.
+//
+// The uint256 is returned to support chaining.  This enables syntax like:
+// n.MulDiv(n1, n2, n3).AddUint64(1) so that n = ((n1 * n2) / n3) + 1.
+func (n *Uint256) MulDiv(n1, n2, divisor *Uint256) *Uint256 {
+	if divisor.IsZero() {
+		panic("division by zero")
+	}
+
+	// Calculate the full product and use the more efficient uint256 division
+	// when the product fits in a uint256.
+	product := mul512(n1, n2)
+	if product[4]|product[5]|product[6]|product[7] == 0 {
+		quotient := Uint256{n: [4]uint64{product[0], product[1], product[2],
+			product[3]}}
+		return n.Div2(&quotient, divisor)
+	}
+
+	// When the divisor can be fully represented by a uint64, the divisor only
+	// consists of a single base 2^64 digit, so use that fact to avoid extra
+	// work.  It is important to note that the algorithm below also requires the
+	// divisor to be at least two digits, so this is not solely a performance
+	// optimization.
+	//
+	// Note that any quotient digits beyond the first four are ≡ 0 (mod 2^256)
+	// and are therefore discarded.
+	numProductDigits := product.numDigits()
+	if divisor.IsUint64() {
+		var quotient Uint256
+		var r, q uint64
+		for d := numProductDigits - 1; d >= 0; d-- {
+			q, r = bits.Div64(r, product[d], divisor.n[0])
+			if d < 4 {
+				quotient.n[d] = q
+			}
+		}
+		return n.Set(&quotient)
+	}
+
+	// The remaining code is the same long division algorithm used by Div2
+	// generalized to a dividend of up to eight digits.  See the comments there
+	// for a detailed explanation.  The primary differences are that the full
+	// active part of the remainder is updated since it spans up to one digit
+	// more than the divisor and that quotient digits that are ≡ 0 (mod 2^256)
+	// are discarded.
+	//
+	// Start by normalizing the arguments such that the leading digit of the
+	// divisor has its most significant bit set.
+	numDivisorDigits := divisor.numDigits()
+	sf := uint8(bits.LeadingZeros64(divisor.n[numDivisorDigits-1]))
+	var divisorN [4]uint64
+	var dividendN [9]uint64
+	if sf > 0 {
+		for i := numDivisorDigits - 1; i > 0; i-- {
+			divisorN[i] = divisor.n[i]<<sf | divisor.n[i-1]>>(64-sf)
+		}
+		divisorN[0] = divisor.n[0] << sf
+
+		dividendN[numProductDigits] = product[numProductDigits-1] >> (64 - sf)
+		for i := numProductDigits - 1; i > 0; i-- {
+			dividendN[i] = product[i]<<sf | product[i-1]>>(64-sf)
+		}
+		dividendN[0] = product[0] << sf
+	} else {
+		copy(divisorN[:], divisor.n[:])
+		copy(dividendN[:], product[:])
+	}
+
+	var quotient Uint256
+	var p [5]uint64
+	var qhat, c, borrow uint64
+	for d := numProductDigits - numDivisorDigits; d >= 0; d-- {
+		// Estimate the quotient digit by dividing the 2 leading digits of the
+		// active part of the remainder by the leading digit of the normalized
+		// divisor while avoiding overflow.
+		if dividendN[d+numDivisorDigits] == divisorN[numDivisorDigits-1] {
+			qhat = ^uint64(0)
+		} else {
+			qhat, _ = bits.Div64(dividendN[d+numDivisorDigits],
+				dividendN[d+numDivisorDigits-1], divisorN[numDivisorDigits-1])
+		}
+
+		// Calculate the product of the estimated quotient digit and divisor.
+		c, p[0] = bits.Mul64(qhat, divisorN[0])
+		c, p[1] = mulAdd64(qhat, divisorN[1], c)
+		c, p[2] = mulAdd64(qhat, divisorN[2], c)
+		p[4], p[3] = mulAdd64(qhat, divisorN[3], c)
+
+		// Adjust the estimate (and associated product) downwards when they are
+		// too high for the active part of the partial remainder.
+		for prefixLt(dividendN[d:d+numDivisorDigits+1], p[:]) {
+			qhat--
+			p[0], borrow = bits.Sub64(p[0], divisorN[0], 0)
+			p[1], borrow = bits.Sub64(p[1], divisorN[1], borrow)
+			p[2], borrow = bits.Sub64(p[2], divisorN[2], borrow)
+			p[3], borrow = bits.Sub64(p[3], divisorN[3], borrow)
+			p[4] -= borrow
+		}
+
+		// Set the quotient digit in the result when it is not ≡ 0 (mod 2^256).
+		if d < 4 {
+			quotient.n[d] = qhat
+		}
+
+		// Update the active part of the dividend by subtracting the resulting
+		// product from it so that it becomes the new remainder to use for
+		// calculating the next quotient digit.
+		borrow = 0
+		for i := 0; i <= numDivisorDigits; i++ {
+			dividendN[d+i], borrow = bits.Sub64(dividendN[d+i], p[i], borrow)
+		}
+	}
+
+	return n.Set(&quotient)
+}
+
 // NegateVal negates the passed uint256 modulo 2^256 and stores the result in
 // n.  In other words, n will be set to the two's complement of the passed
 // uint256.
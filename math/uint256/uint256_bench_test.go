@@ -881,6 +881,43 @@ func BenchmarkBigIntDivRandom(b *testing.B) {
 	}
 }
 
+// BenchmarkUint256MulDivRandom benchmarks computing the quotient of the full
+// product of random large unsigned 256-bit integers and a random divisor with
+// the specialized type.
+func BenchmarkUint256MulDivRandom(b *testing.B) {
+	n := new(Uint256)
+	vals := randBenchVals
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i += len(vals) {
+		for j := 0; j < len(vals); j++ {
+			val := &vals[j]
+			n.MulDiv(val.n1, val.n2, val.n2Low64)
+		}
+	}
+}
+
+// BenchmarkBigIntMulDivRandom benchmarks computing the quotient of the full
+// product of random large unsigned 256-bit integers and a random divisor with
+// stdlib big integers.
+func BenchmarkBigIntMulDivRandom(b *testing.B) {
+	n := new(big.Int)
+	two256 := new(big.Int).Lsh(big.NewInt(1), 256)
+	vals := randBenchVals
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i += len(vals) {
+		for j := 0; j < len(vals); j++ {
+			val := &vals[j]
+			n.Mul(val.bigN1, val.bigN2)
+			n.Div(n, val.bigN2Low64)
+			n.Mod(n, two256)
+		}
+	}
+}
+
 // BenchmarkUint256DivUint64 benchmarks computing the quotient of an unsigned
 // 256-bit integer and unsigned 64-bit integer with the specialized type.
 func BenchmarkUint256DivUint64(b *testing.B) {
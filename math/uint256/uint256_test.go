@@ -2389,6 +2389,139 @@ func TestUint256DivRandom(t *testing.T) {
 	}
 }
 
+// TestUint256MulDiv ensures that multiplying two uint256s and dividing the full
+// product by a third works as expected for edge cases.
+func TestUint256MulDiv(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string // test description
+		n1      string // hex encoded first factor
+		n2      string // hex encoded second factor
+		divisor string // hex encoded divisor
+		want    string // hex encoded expected result
+	}{{
+		name:    "zero product",
+		n1:      "0",
+		n2:      "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		divisor: "1",
+		want:    "0",
+	}, {
+		name:    "product fits in uint256",
+		n1:      "100000000",
+		n2:      "7",
+		divisor: "3",
+		want:    "255555555",
+	}, {
+		name:    "max * max / max",
+		n1:      "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		n2:      "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		divisor: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		want:    "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	}, {
+		name:    "2^255 * 4 / 8 (1 digit divisor)",
+		n1:      "8000000000000000000000000000000000000000000000000000000000000000",
+		n2:      "4",
+		divisor: "8",
+		want:    "4000000000000000000000000000000000000000000000000000000000000000",
+	}, {
+		name:    "2^255 * 2^64 / 2^65 (2 digit divisor)",
+		n1:      "8000000000000000000000000000000000000000000000000000000000000000",
+		n2:      "10000000000000000",
+		divisor: "20000000000000000",
+		want:    "4000000000000000000000000000000000000000000000000000000000000000",
+	}, {
+		name:    "max * max / (max - 1) (normalized divisor)",
+		n1:      "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		n2:      "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		divisor: "fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe",
+		want:    "0000000000000000000000000000000000000000000000000000000000000000",
+	}, {
+		name:    "quotient mod 2^256",
+		n1:      "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		n2:      "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		divisor: "3",
+		want:    "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaab",
+	}, {
+		name:    "work ratio scaled",
+		n1:      "000000000000000000000000000000000000000001a36e2eb1c432ca57a786c",
+		n2:      "de0b6b3a7640000",
+		divisor: "000000000000000000000000000000000000000003446dc5d38865948b4f0d8",
+		want:    "6f58592fc7ecdaf",
+	}}
+
+	for _, test := range tests {
+		n1 := hexToUint256(test.n1)
+		n2 := hexToUint256(test.n2)
+		divisor := hexToUint256(test.divisor)
+		want := hexToUint256(test.want)
+
+		got := new(Uint256).MulDiv(n1, n2, divisor)
+		if !got.Eq(want) {
+			t.Errorf("%q: wrong result -- got: %x, want: %x", test.name, got,
+				want)
+			continue
+		}
+
+		// Ensure aliasing the result with the arguments works as expected.
+		got.Set(n1).MulDiv(got, n2, divisor)
+		if !got.Eq(want) {
+			t.Errorf("%q: wrong aliased result -- got: %x, want: %x",
+				test.name, got, want)
+			continue
+		}
+	}
+}
+
+// TestUint256MulDivRandom ensures that multiplying two uint256s and dividing
+// the full product by a third, created from random values, works as expected
+// by also performing the same operation with big ints and comparing the
+// results.
+func TestUint256MulDivRandom(t *testing.T) {
+	t.Parallel()
+
+	// Use a unique random seed each test instance and log it if the tests fail.
+	seed := time.Now().Unix()
+	rng := rand.New(rand.NewSource(seed))
+	defer func(t *testing.T, seed int64) {
+		if t.Failed() {
+			t.Logf("random seed: %d", seed)
+		}
+	}(t, seed)
+
+	two256 := new(big.Int).Lsh(big.NewInt(1), 256)
+	for i := 0; i < 1000; i++ {
+		// Generate three big integer and uint256 pairs with divisors of varying
+		// sizes to exercise all code paths.
+		bigN1, n1 := randBigIntAndUint256(t, rng)
+		bigN2, n2 := randBigIntAndUint256(t, rng)
+		bigN3, n3 := randBigIntAndUint256(t, rng)
+		shift := uint32(rng.Intn(256))
+		bigN3.Rsh(bigN3, uint(shift))
+		n3.Rsh(shift)
+		if n3.IsZero() {
+			bigN3.SetUint64(1)
+			n3.SetUint64(1)
+		}
+
+		// Calculate the result using big ints.
+		bigIntResult := new(big.Int).Mul(bigN1, bigN2)
+		bigIntResult.Div(bigIntResult, bigN3)
+		bigIntResult.Mod(bigIntResult, two256)
+
+		// Calculate the result using uint256s.
+		uint256Result := new(Uint256).MulDiv(n1, n2, n3)
+
+		// Ensure they match.
+		bigIntResultHex := fmt.Sprintf("%064x", bigIntResult.Bytes())
+		uint256ResultHex := fmt.Sprintf("%064x", uint256Result.Bytes())
+		if bigIntResultHex != uint256ResultHex {
+			t.Fatalf("mismatched muldiv n1: %x, n2: %x, n3: %x -- got %x, "+
+				"want %x", n1, n2, n3, uint256Result, bigIntResult)
+		}
+	}
+}
+
 // TestUint256DivUint64 ensures that dividing a uint256 by a uint64 works as
 // expected for edge cases.
 func TestUint256DivUint64(t *testing.T) {
@@ -2553,6 +2686,18 @@ func TestUint256DivByZeroPanic(t *testing.T) {
 	if !paniced {
 		t.Fatal("DivUint64 did not panic on division by zero")
 	}
+
+	// Ensure attempting to divide by zero via the fused multiply and divide
+	// variant panics.
+	paniced = testPanic(func() {
+		var n1, n2, n3 Uint256
+		n1.SetUint64(1)
+		n2.SetUint64(1)
+		_ = new(Uint256).MulDiv(&n1, &n2, &n3)
+	})
+	if !paniced {
+		t.Fatal("MulDiv did not panic on division by zero")
+	}
 }
 
 // TestUint256Negate ensures that negating uint256s mod 2^256 works as expected
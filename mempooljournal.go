@@ -0,0 +1,98 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/internal/mempool"
+)
+
+// mempoolJournalFilename is the name of the file in the data directory the
+// mempool event journal is persisted to when requested.
+const mempoolJournalFilename = "mempooljournal.json"
+
+// persistedMempoolEvent is the JSON representation of a mempool event that is
+// persisted to the mempool journal file.  Each event is stored on its own line.
+type persistedMempoolEvent struct {
+	Time   int64  `json:"time"`
+	Kind   uint8  `json:"kind"`
+	TxID   string `json:"txid"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// saveMempoolJournal writes the events in the event journal of the provided
+// mempool to the file at the provided path, replacing any existing file.
+func saveMempoolJournal(path string, txMemPool *mempool.TxPool) error {
+	// Write the events to a temporary file and then rename it over the
+	// existing one so a partially-written journal never replaces it.
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, event := range txMemPool.Events() {
+		err := enc.Encode(&persistedMempoolEvent{
+			Time:   event.Time.UnixNano(),
+			Kind:   uint8(event.Kind),
+			TxID:   event.Hash.String(),
+			Reason: event.Reason,
+		})
+		if err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// loadMempoolJournal restores the events persisted to the file at the provided
+// path to the event journal of the provided mempool.  It is not an error for
+// the file to not exist.
+func loadMempoolJournal(path string, txMemPool *mempool.TxPool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	var events []mempool.Event
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for dec.More() {
+		var event persistedMempoolEvent
+		if err := dec.Decode(&event); err != nil {
+			return err
+		}
+		hash, err := chainhash.NewHashFromStr(event.TxID)
+		if err != nil {
+			return err
+		}
+		events = append(events, mempool.Event{
+			Time:   time.Unix(0, event.Time),
+			Kind:   mempool.EventKind(event.Kind),
+			Hash:   *hash,
+			Reason: event.Reason,
+		})
+	}
+	txMemPool.RestoreEvents(events)
+	return nil
+}
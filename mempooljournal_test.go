@@ -0,0 +1,66 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/internal/mempool"
+)
+
+// TestMempoolJournalPersistence ensures the mempool event journal round trips
+// through the persisted file and that a missing file is not an error.
+func TestMempoolJournalPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), mempoolJournalFilename)
+	events := []mempool.Event{{
+		Time: time.Unix(1583426560, 123),
+		Kind: mempool.EventAccepted,
+		Hash: chainhash.Hash{0x01},
+	}, {
+		Time:   time.Unix(1583426561, 0),
+		Kind:   mempool.EventEvicted,
+		Hash:   chainhash.Hash{0x01},
+		Reason: "expired at height 432290",
+	}}
+	txMemPool := mempool.New(&mempool.Config{EventJournalSize: 10})
+	txMemPool.RestoreEvents(events)
+
+	// Ensure loading a journal that does not exist does not add any events.
+	restored := mempool.New(&mempool.Config{EventJournalSize: 10})
+	if err := loadMempoolJournal(path, restored); err != nil {
+		t.Fatalf("loadMempoolJournal: unexpected error: %v", err)
+	}
+	if got := restored.Events(); len(got) != 0 {
+		t.Fatalf("loadMempoolJournal: unexpected events: %+v", got)
+	}
+
+	// Ensure the saved events are restored.
+	if err := saveMempoolJournal(path, txMemPool); err != nil {
+		t.Fatalf("saveMempoolJournal: unexpected error: %v", err)
+	}
+	if err := loadMempoolJournal(path, restored); err != nil {
+		t.Fatalf("loadMempoolJournal: unexpected error: %v", err)
+	}
+	got := restored.Events()
+	if len(got) != len(events) {
+		t.Fatalf("mismatched number of events -- got %d, want %d", len(got),
+			len(events))
+	}
+	for i := range got {
+		if !got[i].Time.Equal(events[i].Time) {
+			t.Fatalf("event %d: mismatched time -- got %v, want %v", i,
+				got[i].Time, events[i].Time)
+		}
+		got[i].Time = events[i].Time
+		if !reflect.DeepEqual(got[i], events[i]) {
+			t.Fatalf("event %d: mismatched event -- got %+v, want %+v", i,
+				got[i], events[i])
+		}
+	}
+}
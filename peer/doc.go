@@ -95,6 +95,13 @@ channel which will be notified when the message is actually sent can optionally
 be specified.  There are certain message types which are better sent using other
 functions which provide additional functionality.
 
+Queued messages are assigned a priority class so that time-sensitive messages
+such as pings, block announcements, and blocks are sent ahead of bulk data such
+as transactions and committed filters.  QueueMessage uses the class returned by
+DefaultMessagePriority, while QueueMessageWithPriority allows the caller to
+specify it, for example, to prioritize votes over other transactions.  Messages
+within the same class are always sent in the order they were queued.
+
 Of special interest are inventory messages.  Rather than manually sending MsgInv
 messages via Queuemessage, the inventory vectors should be queued using the
 QueueInventory function.  It employs batching and trickling along with
@@ -1,5 +1,5 @@
 // Copyright (c) 2015-2016 The btcsuite developers
-// Copyright (c) 2016-2021 The Decred developers
+// Copyright (c) 2016-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -68,6 +68,8 @@ func invSummary(invList []*wire.InvVect) string {
 			return fmt.Sprintf("tx %s", iv.Hash)
 		case wire.InvTypeFilteredBlock:
 			return fmt.Sprintf("filtered block %s", iv.Hash)
+		case wire.InvTypeTSpend:
+			return fmt.Sprintf("tspend %s", iv.Hash)
 		}
 
 		return fmt.Sprintf("unknown (%d) %s", uint32(iv.Type), iv.Hash)
@@ -77,7 +79,7 @@ func invSummary(invList []*wire.InvVect) string {
 	var numTxns, numBlocks uint64
 	for _, iv := range invList {
 		switch iv.Type {
-		case wire.InvTypeTx:
+		case wire.InvTypeTx, wire.InvTypeTSpend:
 			numTxns++
 		case wire.InvTypeBlock:
 			numBlocks++
@@ -12,6 +12,7 @@ import (
 	"io"
 	"math/rand"
 	"net"
+	"sort"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -27,7 +28,7 @@ import (
 
 const (
 	// MaxProtocolVersion is the max protocol version the peer supports.
-	MaxProtocolVersion = wire.InitStateVersion
+	MaxProtocolVersion = wire.TSpendInvVersion
 
 	// outputBufferSize is the number of elements the output channels use.
 	outputBufferSize = 5000
@@ -66,6 +67,30 @@ const (
 	// pingInterval is the interval of time to wait in between sending ping
 	// messages.
 	pingInterval = defaultIdleTimeout - 13*time.Second
+
+	// maxPingSamples is the maximum number of the most recent ping round trip
+	// times to keep for calculating ping time percentiles.
+	maxPingSamples = 100
+
+	// otherMsgCommand is the command used to account for bytes of messages
+	// that could not be decoded and therefore have no known command.
+	otherMsgCommand = "*other*"
+
+	// DefaultCompressionThreshold is the default minimum payload size in
+	// bytes of the blocks and committed filters that are compressed when
+	// compression is enabled.  Smaller payloads typically do not compress
+	// well enough to justify the CPU cost.
+	DefaultCompressionThreshold = 16 * 1024
+
+	// compressionSampleMsgs is the number of messages that are compressed for
+	// a peer before the achieved compression ratio is evaluated.
+	compressionSampleMsgs = 16
+
+	// minCompressionSavings is the minimum percentage of bytes compression
+	// must save after compressionSampleMsgs messages in order to continue
+	// compressing messages sent to a peer.  This prevents spending CPU time
+	// compressing payloads that are mostly incompressible.
+	minCompressionSavings = 10
 )
 
 var (
@@ -189,6 +214,9 @@ type MessageListeners struct {
 	// message.
 	OnSendHeaders func(p *Peer, msg *wire.MsgSendHeaders)
 
+	// OnSendCmpr is invoked when a peer receives a sendcmpr wire message.
+	OnSendCmpr func(p *Peer, msg *wire.MsgSendCmpr)
+
 	// OnGetInitState is invoked when a peer receives a getinitstate wire
 	// message.
 	OnGetInitState func(p *Peer, msg *wire.MsgGetInitState)
@@ -264,6 +292,23 @@ type Config struct {
 	// not send inv messages for transactions.
 	DisableRelayTx bool
 
+	// EnableCompression specifies whether or not to negotiate compression
+	// of block and committed filter payloads with the remote peer.  When
+	// enabled, the remote peer is informed via a sendcmpr message that it
+	// may send compressed payloads and payloads are sent compressed to
+	// remote peers that sent one in turn.
+	//
+	// NOTE: Compression is only negotiated with protocol versions starting
+	// with wire.CompressionVersion, so ProtocolVersion must also be set
+	// accordingly.
+	EnableCompression bool
+
+	// CompressionThreshold specifies the minimum uncompressed payload size
+	// in bytes of the blocks and committed filters to compress when
+	// compression is enabled.  This field can be omitted in which case
+	// DefaultCompressionThreshold will be used.
+	CompressionThreshold uint32
+
 	// Listeners houses callback functions to be invoked on receiving peer
 	// messages.
 	Listeners MessageListeners
@@ -271,6 +316,13 @@ type Config struct {
 	// IdleTimeout is the duration of inactivity before a peer is timed
 	// out in seconds.
 	IdleTimeout time.Duration
+
+	// TrickleInterval specifies a function that returns the duration to wait
+	// before trickling the next batch of queued inventory to the peer.  This
+	// allows callers to adapt the batching to the type of peer and current
+	// conditions.  This field can be omitted in which case queued inventory
+	// is trickled at a fixed interval.
+	TrickleInterval TrickleIntervalFunc
 }
 
 // minUint32 is a helper function to return the minimum of two uint32s.
@@ -282,6 +334,16 @@ func minUint32(a, b uint32) uint32 {
 	return b
 }
 
+// trickleInterval returns the duration to wait before trickling the next batch
+// of queued inventory to the peer.  It uses the function provided by the
+// configuration when one is specified.
+func (p *Peer) trickleInterval() time.Duration {
+	if p.cfg.TrickleInterval != nil {
+		return p.cfg.TrickleInterval(p)
+	}
+	return trickleTimeout
+}
+
 // newNetAddress attempts to extract the IP address and port from the passed
 // net.Addr interface and create a NetAddress structure using that information.
 func newNetAddress(addr net.Addr, services wire.ServiceFlag) (*wire.NetAddress, error) {
@@ -320,12 +382,105 @@ func newNetAddress(addr net.Addr, services wire.ServiceFlag) (*wire.NetAddress,
 	return na, nil
 }
 
+// MessagePriority identifies the priority class of a message queued to be sent
+// to a peer.  Queued messages with a higher priority are sent before those with
+// a lower priority, while messages with the same priority are sent in the order
+// they were queued.
+type MessagePriority uint8
+
+// These constants define the supported message priority classes.
+const (
+	// MessagePriorityLow is the priority for bulk data such as transactions
+	// and committed filters.
+	MessagePriorityLow MessagePriority = iota
+
+	// MessagePriorityNormal is the priority for all messages that are
+	// neither time sensitive nor bulk data.
+	MessagePriorityNormal
+
+	// MessagePriorityHigh is the priority for time-sensitive messages such as
+	// pings, votes, and block announcements.
+	MessagePriorityHigh
+
+	// numMessagePriorities is the total number of message priority classes.
+	numMessagePriorities
+)
+
+// String returns the message priority as a human-readable string.
+func (p MessagePriority) String() string {
+	switch p {
+	case MessagePriorityLow:
+		return "low"
+	case MessagePriorityNormal:
+		return "normal"
+	case MessagePriorityHigh:
+		return "high"
+	}
+	return fmt.Sprintf("unknown priority (%d)", uint8(p))
+}
+
+// DefaultMessagePriority returns the priority class used for the passed message
+// when it is queued without an explicit priority.
+//
+// Pings, pongs, blocks, headers, and the messages that convey vote and mining
+// state are time sensitive and therefore high priority, while transactions and
+// committed filters are bulk data and therefore low priority.  All other
+// messages are normal priority.
+//
+// Note that votes are transactions, so callers that wish to prioritize them
+// must queue them with an explicit priority.
+func DefaultMessagePriority(msg wire.Message) MessagePriority {
+	switch msg.(type) {
+	case *wire.MsgPing, *wire.MsgPong, *wire.MsgBlock, *wire.MsgHeaders,
+		*wire.MsgMiningState, *wire.MsgInitState:
+
+		return MessagePriorityHigh
+
+	case *wire.MsgTx, *wire.MsgCFilter, *wire.MsgCFilterV2,
+		*wire.MsgCFHeaders:
+
+		return MessagePriorityLow
+	}
+	return MessagePriorityNormal
+}
+
 // outMsg is used to house a message to be sent along with a channel to signal
 // when the message has been sent (or won't be sent due to things such as
-// shutdown).
+// shutdown) and the priority class of the message.
 type outMsg struct {
 	msg      wire.Message
 	doneChan chan<- struct{}
+	priority MessagePriority
+}
+
+// outMsgQueue houses messages waiting to be sent in a separate first-in
+// first-out queue for each priority class.
+type outMsgQueue [numMessagePriorities][]outMsg
+
+// push adds the passed message to the end of the queue for its priority class.
+func (q *outMsgQueue) push(msg outMsg) {
+	priority := msg.priority
+	if priority >= numMessagePriorities {
+		priority = MessagePriorityHigh
+	}
+	q[priority] = append(q[priority], msg)
+}
+
+// pop removes and returns the oldest message from the highest priority class
+// that has any queued messages.  The returned flag is false when there are no
+// queued messages.
+func (q *outMsgQueue) pop() (outMsg, bool) {
+	for priority := int(numMessagePriorities) - 1; priority >= 0; priority-- {
+		queue := q[priority]
+		if len(queue) == 0 {
+			continue
+		}
+		msg := queue[0]
+		queue[0] = outMsg{}
+		q[priority] = queue[1:]
+		return msg, true
+	}
+	return outMsg{}, false
 }
 
 // stallControlCmd represents the command of a stall control message.
@@ -373,6 +528,18 @@ type StatsSnap struct {
 	LastPingNonce  uint64
 	LastPingTime   time.Time
 	LastPingMicros int64
+
+	// BytesSentPerMsg and BytesRecvPerMsg are the number of bytes sent to
+	// and received from the peer keyed by message command.
+	BytesSentPerMsg map[string]uint64
+	BytesRecvPerMsg map[string]uint64
+
+	// PingP50Micros, PingP90Micros, and PingP99Micros are the percentiles of
+	// the most recent ping round trip times in microseconds.  They are zero
+	// when no pings have been answered.
+	PingP50Micros int64
+	PingP90Micros int64
+	PingP99Micros int64
 }
 
 // HashFunc is a function which returns a block hash, height and error
@@ -387,6 +554,10 @@ type AddrFunc func(remoteAddr *wire.NetAddress) *wire.NetAddress
 type HostToNetAddrFunc func(host string, port uint16,
 	services wire.ServiceFlag) (*wire.NetAddress, error)
 
+// TrickleIntervalFunc is a function which returns the duration to wait before
+// trickling the next batch of queued inventory to a peer.
+type TrickleIntervalFunc func(p *Peer) time.Duration
+
 // NOTE: The overall data flow of a peer is split into 3 goroutines.  Inbound
 // messages are read via the inHandler goroutine and generally dispatched to
 // their own handler.  For inbound data-related messages such as blocks,
@@ -441,6 +612,10 @@ type Peer struct {
 	sendHeadersPreferred bool   // peer sent a sendheaders message
 	versionSent          bool
 	verAckReceived       bool
+	cmprSent             bool // sent a sendcmpr message to the peer
+	cmprRemote           bool // peer sent a known sendcmpr message
+	cmprAlgorithm        wire.CompressionAlgorithm
+	cmprMinSize          uint32 // min payload size the peer wants compressed
 
 	knownInventory     lru.Cache
 	prevGetBlocksMtx   sync.Mutex
@@ -460,6 +635,19 @@ type Peer struct {
 	lastPingNonce  uint64    // Set to nonce if we have a pending ping.
 	lastPingTime   time.Time // Time we sent last ping.
 	lastPingMicros int64     // Time for last ping to return.
+	pingSamples    []int64   // Recent ping round trip times in usec.
+	nextPingSample int       // Index in pingSamples to overwrite next.
+
+	// These fields track the number of bytes sent and received per message
+	// command and are protected by the msgStatsMtx mutex.
+	msgStatsMtx     sync.Mutex
+	bytesSentPerMsg map[string]uint64
+	bytesRecvPerMsg map[string]uint64
+
+	// These fields track the results of compressing messages sent to the
+	// peer and are protected by the msgStatsMtx mutex.
+	cmprStats    CompressionStats
+	cmprDisabled bool // compression stopped due to poor savings
 
 	stallControl  chan stallControlMsg
 	outputQueue   chan outMsg
@@ -545,11 +733,48 @@ func (p *Peer) StatsSnapshot() *StatsSnap {
 		LastPingMicros: p.lastPingMicros,
 		LastPingTime:   p.lastPingTime,
 	}
+	statsSnap.PingP50Micros, statsSnap.PingP90Micros,
+		statsSnap.PingP99Micros = pingPercentiles(p.pingSamples)
 
 	p.statsMtx.RUnlock()
+
+	p.msgStatsMtx.Lock()
+	statsSnap.BytesSentPerMsg = copyMsgStats(p.bytesSentPerMsg)
+	statsSnap.BytesRecvPerMsg = copyMsgStats(p.bytesRecvPerMsg)
+	p.msgStatsMtx.Unlock()
+
 	return statsSnap
 }
 
+// copyMsgStats returns a copy of the provided per-message byte counts.
+func copyMsgStats(stats map[string]uint64) map[string]uint64 {
+	statsCopy := make(map[string]uint64, len(stats))
+	for command, bytes := range stats {
+		statsCopy[command] = bytes
+	}
+	return statsCopy
+}
+
+// pingPercentiles returns the 50th, 90th, and 99th percentiles of the provided
+// ping round trip times using the nearest-rank method.  Zero is returned for
+// all percentiles when there are no samples.
+func pingPercentiles(samples []int64) (int64, int64, int64) {
+	if len(samples) == 0 {
+		return 0, 0, 0
+	}
+	sorted := make([]int64, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	percentile := func(pct int) int64 {
+		rank := (pct*len(sorted) + 99) / 100
+		if rank < 1 {
+			rank = 1
+		}
+		return sorted[rank-1]
+	}
+	return percentile(50), percentile(90), percentile(99)
+}
+
 // ID returns the peer id.
 //
 // This function is safe for concurrent access.
@@ -781,6 +1006,189 @@ func (p *Peer) WantsHeaders() bool {
 	return sendHeadersPreferred
 }
 
+// CompressionStats houses statistics about the compression of messages sent
+// to a peer.
+type CompressionStats struct {
+	// MsgsCompressed is the number of messages sent compressed.
+	MsgsCompressed uint64
+
+	// BytesUncompressed is the total number of bytes of the payloads of the
+	// messages that were sent compressed prior to compressing them.
+	BytesUncompressed uint64
+
+	// BytesCompressed is the total number of bytes of the payloads of the
+	// messages that were sent compressed.
+	BytesCompressed uint64
+
+	// Disabled indicates whether or not compression was stopped for the peer
+	// due to not achieving the minimum savings.
+	Disabled bool
+}
+
+// WantsCompression returns whether or not the remote peer consented to
+// receiving compressed payloads via a sendcmpr message.
+//
+// This function is safe for concurrent access.
+func (p *Peer) WantsCompression() bool {
+	p.flagsMtx.Lock()
+	cmprRemote := p.cmprRemote
+	p.flagsMtx.Unlock()
+
+	return cmprRemote
+}
+
+// CompressionStats returns statistics about the compression of messages sent
+// to the peer.
+//
+// This function is safe for concurrent access.
+func (p *Peer) CompressionStats() CompressionStats {
+	p.msgStatsMtx.Lock()
+	stats := p.cmprStats
+	stats.Disabled = p.cmprDisabled
+	p.msgStatsMtx.Unlock()
+
+	return stats
+}
+
+// compressionThreshold returns the minimum uncompressed payload size of the
+// messages to compress as configured.
+func (p *Peer) compressionThreshold() uint32 {
+	if p.cfg.CompressionThreshold == 0 {
+		return DefaultCompressionThreshold
+	}
+	return p.cfg.CompressionThreshold
+}
+
+// handleSendCmprMsg records the compression algorithm and minimum payload
+// size the remote peer consented to receiving compressed payloads with.
+// Messages that specify an unknown algorithm are ignored.
+func (p *Peer) handleSendCmprMsg(msg *wire.MsgSendCmpr) {
+	if !msg.Algorithm.IsKnown() {
+		log.Debugf("Ignoring sendcmpr with unknown algorithm %v from %s",
+			msg.Algorithm, p)
+		return
+	}
+
+	p.flagsMtx.Lock()
+	p.cmprRemote = true
+	p.cmprAlgorithm = msg.Algorithm
+	p.cmprMinSize = msg.MinSize
+	p.flagsMtx.Unlock()
+}
+
+// maybeCompress returns a compressed message that wraps the passed message
+// when compression is enabled, the remote peer consented to it, and the
+// message is a block or committed filter with a payload that is at least the
+// larger of the local and remote minimum sizes.  Otherwise, the passed message
+// is returned unmodified.
+func (p *Peer) maybeCompress(msg wire.Message) wire.Message {
+	switch msg.(type) {
+	case *wire.MsgBlock, *wire.MsgCFilter, *wire.MsgCFilterV2:
+	default:
+		return msg
+	}
+	if !p.cfg.EnableCompression {
+		return msg
+	}
+
+	p.flagsMtx.Lock()
+	cmprRemote := p.cmprRemote
+	algorithm := p.cmprAlgorithm
+	minSize := p.cmprMinSize
+	p.flagsMtx.Unlock()
+	if !cmprRemote {
+		return msg
+	}
+
+	p.msgStatsMtx.Lock()
+	disabled := p.cmprDisabled
+	p.msgStatsMtx.Unlock()
+	if disabled {
+		return msg
+	}
+
+	// Determine the payload size prior to encoding the message so small
+	// messages that are not worth compressing are not encoded twice.
+	threshold := p.compressionThreshold()
+	if minSize > threshold {
+		threshold = minSize
+	}
+	if uint32(compressiblePayloadSize(msg)) < threshold {
+		return msg
+	}
+
+	cmsg, err := wire.NewMsgCompressed(algorithm, msg, p.ProtocolVersion())
+	if err != nil {
+		log.Errorf("Unable to compress %s message for %s: %v",
+			msg.Command(), p, err)
+		return msg
+	}
+	return cmsg
+}
+
+// compressiblePayloadSize returns the number of bytes the payload of the
+// passed compressible message occupies when encoded without actually encoding
+// it.  It returns zero for messages that are not compressible.
+func compressiblePayloadSize(msg wire.Message) int {
+	switch msg := msg.(type) {
+	case *wire.MsgBlock:
+		return msg.SerializeSize()
+
+	case *wire.MsgCFilter:
+		// Block hash + filter type + filter data.
+		return chainhash.HashSize + 1 +
+			wire.VarIntSerializeSize(uint64(len(msg.Data))) + len(msg.Data)
+
+	case *wire.MsgCFilterV2:
+		// Block hash + filter data + proof index + proof hashes.
+		numHashes := len(msg.ProofHashes)
+		return chainhash.HashSize +
+			wire.VarIntSerializeSize(uint64(len(msg.Data))) + len(msg.Data) +
+			4 + wire.VarIntSerializeSize(uint64(numHashes)) +
+			numHashes*chainhash.HashSize
+	}
+	return 0
+}
+
+// recordCompression updates the compression statistics with a message that
+// was sent compressed and stops compressing messages sent to the peer when
+// the compression does not achieve the minimum savings after enough messages
+// have been compressed.
+func (p *Peer) recordCompression(uncompressed, compressed int) {
+	p.msgStatsMtx.Lock()
+	defer p.msgStatsMtx.Unlock()
+
+	stats := &p.cmprStats
+	stats.MsgsCompressed++
+	stats.BytesUncompressed += uint64(uncompressed)
+	stats.BytesCompressed += uint64(compressed)
+	if stats.MsgsCompressed < compressionSampleMsgs || p.cmprDisabled {
+		return
+	}
+	maxCompressed := stats.BytesUncompressed * (100 - minCompressionSavings) / 100
+	if stats.BytesCompressed > maxCompressed {
+		log.Debugf("Disabling compression for %s due to insufficient "+
+			"savings (%d of %d bytes)", p, stats.BytesCompressed,
+			stats.BytesUncompressed)
+		p.cmprDisabled = true
+	}
+}
+
+// decompress returns the message wrapped by the passed compressed message.  An
+// error is returned when the remote peer was not informed it may send
+// compressed messages or the wrapped message is malformed.
+func (p *Peer) decompress(msg *wire.MsgCompressed) (wire.Message, error) {
+	p.flagsMtx.Lock()
+	cmprSent := p.cmprSent
+	p.flagsMtx.Unlock()
+	if !cmprSent {
+		return nil, fmt.Errorf("received compressed %s message without "+
+			"sending sendcmpr", msg.MsgCommand)
+	}
+
+	return msg.Message(p.ProtocolVersion())
+}
+
 // PushAddrMsg sends an addr message to the connected peer using the provided
 // addresses.  This function is useful over manually sending the message via
 // QueueMessage since it automatically limits the addresses to the maximum
@@ -930,6 +1338,14 @@ func (p *Peer) handlePongMsg(msg *wire.MsgPong) {
 		p.lastPingMicros = time.Since(p.lastPingTime).Nanoseconds()
 		p.lastPingMicros /= 1000 // convert to usec.
 		p.lastPingNonce = 0
+
+		// Keep the most recent round trip times for percentiles.
+		if len(p.pingSamples) < maxPingSamples {
+			p.pingSamples = append(p.pingSamples, p.lastPingMicros)
+		} else {
+			p.pingSamples[p.nextPingSample] = p.lastPingMicros
+			p.nextPingSample = (p.nextPingSample + 1) % maxPingSamples
+		}
 	}
 	p.statsMtx.Unlock()
 }
@@ -943,6 +1359,13 @@ func (p *Peer) readMessage() (wire.Message, []byte, error) {
 	n, msg, buf, err := wire.ReadMessageN(p.conn, p.ProtocolVersion(),
 		p.cfg.Net)
 	atomic.AddUint64(&p.bytesReceived, uint64(n))
+	command := otherMsgCommand
+	if msg != nil {
+		command = msg.Command()
+	}
+	p.msgStatsMtx.Lock()
+	p.bytesRecvPerMsg[command] += uint64(n)
+	p.msgStatsMtx.Unlock()
 	if p.cfg.Listeners.OnRead != nil {
 		p.cfg.Listeners.OnRead(p, n, msg, err)
 	}
@@ -950,6 +1373,15 @@ func (p *Peer) readMessage() (wire.Message, []byte, error) {
 		return nil, nil, err
 	}
 
+	// Decompress the wrapped message of compressed messages.  They are only
+	// allowed when the remote peer was informed it may send them.
+	if cmsg, ok := msg.(*wire.MsgCompressed); ok {
+		msg, err = p.decompress(cmsg)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// Only construct expensive log strings when the logging level requires it.
 	if log.Level() <= slog.LevelDebug {
 		// Debug summary of message.
@@ -993,9 +1425,18 @@ func (p *Peer) writeMessage(msg wire.Message) error {
 		}
 	}
 
+	// Compress the message payload when the remote peer consented to it.
+	msg = p.maybeCompress(msg)
+
 	// Write the message to the peer.
 	n, err := wire.WriteMessageN(p.conn, msg, p.ProtocolVersion(), p.cfg.Net)
 	atomic.AddUint64(&p.bytesSent, uint64(n))
+	p.msgStatsMtx.Lock()
+	p.bytesSentPerMsg[msg.Command()] += uint64(n)
+	p.msgStatsMtx.Unlock()
+	if cmsg, ok := msg.(*wire.MsgCompressed); ok && err == nil {
+		p.recordCompression(len(cmsg.Payload), n-wire.MessageHeaderSize)
+	}
 	if p.cfg.Listeners.OnWrite != nil {
 		p.cfg.Listeners.OnWrite(p, n, msg, err)
 	}
@@ -1408,6 +1849,13 @@ out:
 				p.cfg.Listeners.OnSendHeaders(p, msg)
 			}
 
+		case *wire.MsgSendCmpr:
+			p.handleSendCmprMsg(msg)
+
+			if p.cfg.Listeners.OnSendCmpr != nil {
+				p.cfg.Listeners.OnSendCmpr(p, msg)
+			}
+
 		case *wire.MsgGetCFilterV2:
 			if p.cfg.Listeners.OnGetCFilterV2 != nil {
 				p.cfg.Listeners.OnGetCFilterV2(p, msg)
@@ -1447,10 +1895,10 @@ out:
 // handlers will not block on us sending a message.  That data is then passed on
 // to outHandler to be actually written.
 func (p *Peer) queueHandler() {
-	var pendingMsgs []outMsg
+	var pendingMsgs outMsgQueue
 	var invSendQueue []*wire.InvVect
-	trickleTicker := time.NewTicker(trickleTimeout)
-	defer trickleTicker.Stop()
+	trickleTimer := time.NewTimer(p.trickleInterval())
+	defer trickleTimer.Stop()
 
 	// We keep the waiting flag so that we know if we have a message queued
 	// to the outHandler or not.  We could use the presence of a head of
@@ -1462,11 +1910,11 @@ func (p *Peer) queueHandler() {
 	waiting := false
 
 	// To avoid duplication below.
-	queuePacket := func(msg outMsg, list *[]outMsg, waiting bool) bool {
+	queuePacket := func(msg outMsg, list *outMsgQueue, waiting bool) bool {
 		if !waiting {
 			p.sendQueue <- msg
 		} else {
-			*list = append(*list, msg)
+			list.push(msg)
 		}
 		// we are always waiting now.
 		return true
@@ -1482,15 +1930,15 @@ out:
 		case <-p.sendDoneQueue:
 			// No longer waiting if there are no more messages
 			// in the pending messages queue.
-			if len(pendingMsgs) == 0 {
+			next, ok := pendingMsgs.pop()
+			if !ok {
 				waiting = false
 				continue
 			}
 
 			// Notify the outHandler about the next item to
-			// asynchronously send.
-			next := pendingMsgs[0]
-			pendingMsgs = pendingMsgs[1:]
+			// asynchronously send.  Higher priority messages
+			// are sent before lower priority ones.
 			p.sendQueue <- next
 
 		case iv := <-p.outputInvChan:
@@ -1499,7 +1947,10 @@ out:
 				invSendQueue = append(invSendQueue, iv)
 			}
 
-		case <-trickleTicker.C:
+		case <-trickleTimer.C:
+			// Schedule the next batch.
+			trickleTimer.Reset(p.trickleInterval())
+
 			// Don't send anything if we're disconnecting or there
 			// is no queued inventory.
 			// version is known if send queue has any entries.
@@ -1521,8 +1972,8 @@ out:
 
 				invMsg.AddInvVect(iv)
 				if len(invMsg.InvList) >= maxInvTrickleSize {
-					waiting = queuePacket(
-						outMsg{msg: invMsg},
+					waiting = queuePacket(outMsg{msg: invMsg,
+						priority: MessagePriorityNormal},
 						&pendingMsgs, waiting)
 					invMsg = wire.NewMsgInvSizeHint(uint(len(invSendQueue)))
 				}
@@ -1532,7 +1983,8 @@ out:
 				p.AddKnownInventory(iv)
 			}
 			if len(invMsg.InvList) > 0 {
-				waiting = queuePacket(outMsg{msg: invMsg},
+				waiting = queuePacket(outMsg{msg: invMsg,
+					priority: MessagePriorityNormal},
 					&pendingMsgs, waiting)
 			}
 			invSendQueue = nil
@@ -1544,7 +1996,7 @@ out:
 
 	// Drain any wait channels before we go away so we don't leave something
 	// waiting for us.
-	for _, msg := range pendingMsgs {
+	for msg, ok := pendingMsgs.pop(); ok; msg, ok = pendingMsgs.pop() {
 		if msg.doneChan != nil {
 			msg.doneChan <- struct{}{}
 		}
@@ -1668,10 +2120,20 @@ cleanup:
 	log.Tracef("Peer output handler done for %s", p)
 }
 
-// QueueMessage adds the passed wire message to the peer send queue.
+// QueueMessage adds the passed wire message to the peer send queue with the
+// priority class returned by DefaultMessagePriority.
 //
 // This function is safe for concurrent access.
 func (p *Peer) QueueMessage(msg wire.Message, doneChan chan<- struct{}) {
+	p.QueueMessageWithPriority(msg, doneChan, DefaultMessagePriority(msg))
+}
+
+// QueueMessageWithPriority adds the passed wire message to the peer send queue
+// with the provided priority class.  Queued messages with a higher priority are
+// sent before any queued messages with a lower priority.
+//
+// This function is safe for concurrent access.
+func (p *Peer) QueueMessageWithPriority(msg wire.Message, doneChan chan<- struct{}, priority MessagePriority) {
 	// Avoid risk of deadlock if goroutine already exited.  The goroutine
 	// we will be sending to hangs around until it knows for a fact that
 	// it is marked as disconnected and *then* it drains the channels.
@@ -1683,7 +2145,7 @@ func (p *Peer) QueueMessage(msg wire.Message, doneChan chan<- struct{}) {
 		}
 		return
 	}
-	p.outputQueue <- outMsg{msg: msg, doneChan: doneChan}
+	p.outputQueue <- outMsg{msg: msg, doneChan: doneChan, priority: priority}
 }
 
 // QueueInventory adds the passed inventory to the inventory send queue which
@@ -1732,7 +2194,8 @@ func (p *Peer) QueueInventoryImmediate(invVect *wire.InvVect) {
 	invMsg := wire.NewMsgInvSizeHint(1)
 	invMsg.AddInvVect(invVect)
 	p.AddKnownInventory(invVect)
-	p.outputQueue <- outMsg{msg: invMsg, doneChan: nil}
+	p.outputQueue <- outMsg{msg: invMsg, doneChan: nil,
+		priority: MessagePriorityHigh}
 }
 
 // Connected returns whether or not the peer is currently connected.
@@ -1959,6 +2422,16 @@ func (p *Peer) start() error {
 
 	// Send our verack message now that the IO processing machinery has started.
 	p.QueueMessage(wire.NewMsgVerAck(), nil)
+
+	// Inform the remote peer it may send compressed payloads when compression
+	// is enabled and supported by the negotiated protocol version.
+	if p.cfg.EnableCompression && p.ProtocolVersion() >= wire.CompressionVersion {
+		p.flagsMtx.Lock()
+		p.cmprSent = true
+		p.flagsMtx.Unlock()
+		p.QueueMessage(wire.NewMsgSendCmpr(wire.CompressionDeflate,
+			p.compressionThreshold()), nil)
+	}
 	return nil
 }
 
@@ -2033,6 +2506,8 @@ func newPeerBase(cfgOrig *Config, inbound bool) *Peer {
 	p := Peer{
 		inbound:         inbound,
 		knownInventory:  lru.NewCache(maxKnownInventory),
+		bytesSentPerMsg: make(map[string]uint64),
+		bytesRecvPerMsg: make(map[string]uint64),
 		stallControl:    make(chan stallControlMsg, 1), // nonblocking sync
 		outputQueue:     make(chan outMsg, outputBufferSize),
 		sendQueue:       make(chan outMsg, 1),   // nonblocking sync
@@ -6,12 +6,15 @@
 package peer
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"io"
 	"net"
+	"reflect"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -104,6 +107,8 @@ type peerStats struct {
 	wantTimeOffset      int64
 	wantBytesSent       uint64
 	wantBytesReceived   uint64
+	wantBytesSentPerMsg map[string]uint64
+	wantBytesRecvPerMsg map[string]uint64
 }
 
 // testPeer tests the given peer's flags and stats.
@@ -202,6 +207,18 @@ func testPeer(t *testing.T, p *Peer, s peerStats) {
 		t.Errorf("testPeer: wrong LastRecv - got %v, want %v", p.LastRecv(), stats.LastRecv)
 		return
 	}
+
+	if !reflect.DeepEqual(stats.BytesSentPerMsg, s.wantBytesSentPerMsg) {
+		t.Errorf("testPeer: wrong BytesSentPerMsg - got %v, want %v",
+			stats.BytesSentPerMsg, s.wantBytesSentPerMsg)
+		return
+	}
+
+	if !reflect.DeepEqual(stats.BytesRecvPerMsg, s.wantBytesRecvPerMsg) {
+		t.Errorf("testPeer: wrong BytesRecvPerMsg - got %v, want %v",
+			stats.BytesRecvPerMsg, s.wantBytesRecvPerMsg)
+		return
+	}
 }
 
 // TestPeerConnection tests connection between inbound and outbound peers.
@@ -242,6 +259,14 @@ func TestPeerConnection(t *testing.T) {
 		wantTimeOffset:      int64(0),
 		wantBytesSent:       158, // 134 version + 24 verack
 		wantBytesReceived:   158,
+		wantBytesSentPerMsg: map[string]uint64{
+			wire.CmdVersion: 134,
+			wire.CmdVerAck:  24,
+		},
+		wantBytesRecvPerMsg: map[string]uint64{
+			wire.CmdVersion: 134,
+			wire.CmdVerAck:  24,
+		},
 	}
 	tests := []struct {
 		name  string
@@ -415,6 +440,10 @@ func TestPeerListeners(t *testing.T) {
 		UserAgentVersion: "1.0",
 		Net:              wire.MainNet,
 		Services:         wire.SFNodeBloom,
+
+		// Use the last protocol version that supports reject messages so
+		// their listener is exercised as well.
+		ProtocolVersion: wire.RemoveRejectVersion - 1,
 	}
 	inConn, outConn := pipe(
 		&conn{raddr: "10.0.0.1:8333"},
@@ -862,6 +891,489 @@ func TestUpdateLastBlockHeight(t *testing.T) {
 	}
 }
 
+// TestPingPercentiles ensures the ping time percentiles are calculated as
+// expected.
+func TestPingPercentiles(t *testing.T) {
+	hundredSamples := make([]int64, 0, 100)
+	for i := int64(100); i > 0; i-- {
+		hundredSamples = append(hundredSamples, i*1000)
+	}
+
+	tests := []struct {
+		name    string
+		samples []int64
+		wantP50 int64
+		wantP90 int64
+		wantP99 int64
+	}{{
+		name:    "no samples",
+		samples: nil,
+	}, {
+		name:    "single sample",
+		samples: []int64{5000},
+		wantP50: 5000,
+		wantP90: 5000,
+		wantP99: 5000,
+	}, {
+		name:    "unsorted samples",
+		samples: []int64{300, 100, 500, 200, 400},
+		wantP50: 300,
+		wantP90: 500,
+		wantP99: 500,
+	}, {
+		name:    "max samples",
+		samples: hundredSamples,
+		wantP50: 50000,
+		wantP90: 90000,
+		wantP99: 99000,
+	}}
+
+	for _, test := range tests {
+		p50, p90, p99 := pingPercentiles(test.samples)
+		if p50 != test.wantP50 || p90 != test.wantP90 || p99 != test.wantP99 {
+			t.Errorf("%q: wrong percentiles - got (%d, %d, %d), want "+
+				"(%d, %d, %d)", test.name, p50, p90, p99, test.wantP50,
+				test.wantP90, test.wantP99)
+		}
+	}
+}
+
+// TestMessagePriority ensures messages are assigned the expected default
+// priority classes and that the output message queue returns messages in
+// priority order while preserving the queued order within each class.
+func TestMessagePriority(t *testing.T) {
+	t.Parallel()
+
+	priorityTests := []struct {
+		name string
+		msg  wire.Message
+		want MessagePriority
+	}{
+		{"ping", wire.NewMsgPing(1), MessagePriorityHigh},
+		{"pong", wire.NewMsgPong(1), MessagePriorityHigh},
+		{"block", &wire.MsgBlock{}, MessagePriorityHigh},
+		{"headers", wire.NewMsgHeaders(), MessagePriorityHigh},
+		{"mining state", wire.NewMsgMiningState(), MessagePriorityHigh},
+		{"init state", wire.NewMsgInitState(), MessagePriorityHigh},
+		{"inv", wire.NewMsgInv(), MessagePriorityNormal},
+		{"getdata", wire.NewMsgGetData(), MessagePriorityNormal},
+		{"tx", wire.NewMsgTx(), MessagePriorityLow},
+		{"cfilterv2", &wire.MsgCFilterV2{}, MessagePriorityLow},
+		{"cfheaders", wire.NewMsgCFHeaders(), MessagePriorityLow},
+	}
+	for _, test := range priorityTests {
+		got := DefaultMessagePriority(test.msg)
+		if got != test.want {
+			t.Errorf("%q: wrong priority - got %v, want %v", test.name, got,
+				test.want)
+		}
+	}
+
+	// Queue messages with mixed priorities and ensure they are returned with
+	// the higher priorities first and in the queued order otherwise.
+	var queue outMsgQueue
+	queued := []outMsg{
+		{msg: wire.NewMsgTx(), priority: MessagePriorityLow},
+		{msg: wire.NewMsgInv(), priority: MessagePriorityNormal},
+		{msg: wire.NewMsgPing(1), priority: MessagePriorityHigh},
+		{msg: wire.NewMsgTx(), priority: MessagePriorityLow},
+		{msg: wire.NewMsgPing(2), priority: MessagePriorityHigh},
+		{msg: wire.NewMsgInv(), priority: MessagePriorityNormal},
+	}
+	for _, msg := range queued {
+		queue.push(msg)
+	}
+	wantOrder := []int{2, 4, 1, 5, 0, 3}
+	for i, want := range wantOrder {
+		got, ok := queue.pop()
+		if !ok {
+			t.Fatalf("pop #%d: unexpected empty queue", i)
+		}
+		if got.msg != queued[want].msg {
+			t.Fatalf("pop #%d: wrong message - got %v (%v), want %v (%v)",
+				i, got.msg.Command(), got.priority,
+				queued[want].msg.Command(), queued[want].priority)
+		}
+	}
+	if _, ok := queue.pop(); ok {
+		t.Fatal("expected empty queue after popping all messages")
+	}
+}
+
+// TestQueueHandlerPriority ensures the queue handler sends messages that are
+// queued while another message is being sent in order of their priority and in
+// the order they were queued otherwise.
+func TestQueueHandlerPriority(t *testing.T) {
+	// Create a peer that is marked connected without an underlying connection
+	// and only run the queue handler so the messages it hands off to be sent
+	// can be inspected.
+	p := newPeerBase(&Config{
+		TrickleInterval: func(*Peer) time.Duration { return time.Hour },
+	}, false)
+	atomic.StoreInt32(&p.connected, 1)
+	go p.queueHandler()
+	defer func() {
+		close(p.quit)
+		<-p.queueQuit
+	}()
+
+	// Queue an initial message which is immediately handed off to be sent
+	// followed by several messages with mixed priorities, such as votes that
+	// are queued with high priority, while it is still being sent.
+	vote := wire.NewMsgTx()
+	queued := []struct {
+		msg      wire.Message
+		priority MessagePriority
+	}{
+		{wire.NewMsgTx(), MessagePriorityLow},
+		{wire.NewMsgInv(), MessagePriorityNormal},
+		{vote, MessagePriorityHigh},
+		{wire.NewMsgTx(), MessagePriorityLow},
+		{wire.NewMsgPing(1), MessagePriorityHigh},
+		{wire.NewMsgInv(), MessagePriorityNormal},
+	}
+	first := wire.NewMsgPing(0)
+	p.QueueMessage(first, nil)
+	for _, q := range queued {
+		p.QueueMessageWithPriority(q.msg, nil, q.priority)
+	}
+
+	// Wait for the queue handler to consume all of the queued messages.
+	for deadline := time.Now().Add(time.Second); len(p.outputQueue) != 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout waiting for queue handler to consume messages")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Ensure the initial message is handed off first and the remaining ones
+	// are handed off in priority order as each prior one is sent.
+	select {
+	case got := <-p.sendQueue:
+		if got.msg != first {
+			t.Fatalf("wrong initial message - got %v", got.msg.Command())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for initial message")
+	}
+	wantOrder := []int{2, 4, 1, 5, 0, 3}
+	for i, want := range wantOrder {
+		p.sendDoneQueue <- struct{}{}
+		select {
+		case got := <-p.sendQueue:
+			if got.msg != queued[want].msg {
+				t.Fatalf("send #%d: wrong message - got %v (%v), want %v "+
+					"(%v)", i, got.msg.Command(), got.priority,
+					queued[want].msg.Command(), queued[want].priority)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("send #%d: timeout waiting for message", i)
+		}
+	}
+}
+
+// TestTrickleInterval ensures queued inventory is trickled to the remote peer
+// using the interval returned by the configured trickle interval function.
+func TestTrickleInterval(t *testing.T) {
+	t.Parallel()
+
+	verack := make(chan struct{}, 2)
+	invs := make(chan *wire.MsgInv, 1)
+	inPeerCfg := &Config{
+		Listeners: MessageListeners{
+			OnVerAck: func(p *Peer, msg *wire.MsgVerAck) {
+				verack <- struct{}{}
+			},
+			OnInv: func(p *Peer, msg *wire.MsgInv) {
+				invs <- msg
+			},
+		},
+		UserAgentName:    "peer",
+		UserAgentVersion: "1.0",
+		Net:              wire.MainNet,
+		Services:         wire.SFNodeNetwork,
+	}
+	inConn, outConn := pipe(
+		&conn{raddr: "10.0.0.1:8333"},
+		&conn{raddr: "10.0.0.2:8333"},
+	)
+	inPeer := NewInboundPeer(inPeerCfg)
+	inPeer.AssociateConnection(inConn)
+
+	// Use a trickle interval that is much shorter than the default for the
+	// outbound peer and keep track of the peer it is invoked for.
+	var trickleCalls int32
+	const interval = 10 * time.Millisecond
+	outPeerCfg := *inPeerCfg
+	outPeerCfg.Listeners = MessageListeners{
+		OnVerAck: func(p *Peer, msg *wire.MsgVerAck) {
+			verack <- struct{}{}
+		},
+	}
+	var outPeer *Peer
+	outPeerCfg.TrickleInterval = func(p *Peer) time.Duration {
+		if p != outPeer {
+			t.Errorf("trickle interval invoked for unexpected peer %v", p)
+		}
+		atomic.AddInt32(&trickleCalls, 1)
+		return interval
+	}
+	outPeer, err := NewOutboundPeer(&outPeerCfg, "10.0.0.1:8333")
+	if err != nil {
+		t.Fatalf("NewOutboundPeer: unexpected err %v", err)
+	}
+	outPeer.AssociateConnection(outConn)
+	defer func() {
+		outPeer.Disconnect()
+		inPeer.Disconnect()
+		outPeer.WaitForDisconnect()
+		inPeer.WaitForDisconnect()
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-verack:
+		case <-time.After(time.Second):
+			t.Fatal("verack timeout")
+		}
+	}
+
+	// Queue inventory and ensure it is received well before the default fixed
+	// trickle interval would have elapsed.
+	fakeInv := wire.NewInvVect(wire.InvTypeBlock, &chainhash.Hash{0x01})
+	outPeer.QueueInventory(fakeInv)
+	select {
+	case msg := <-invs:
+		if len(msg.InvList) != 1 || *msg.InvList[0] != *fakeInv {
+			t.Fatalf("unexpected inventory %v", msg.InvList)
+		}
+	case <-time.After(trickleTimeout / 2):
+		t.Fatal("inventory was not trickled using the configured interval")
+	}
+	if calls := atomic.LoadInt32(&trickleCalls); calls < 2 {
+		t.Fatalf("trickle interval was invoked %d times", calls)
+	}
+}
+
+// TestCompression ensures compression of block and committed filter payloads
+// is negotiated between peers that enable it and that payloads are only
+// compressed when they meet the size thresholds and achieve enough savings.
+func TestCompression(t *testing.T) {
+	t.Parallel()
+
+	verack := make(chan struct{}, 2)
+	sendCmpr := make(chan *wire.MsgSendCmpr, 2)
+	blocks := make(chan *wire.MsgBlock, 1)
+	inPeerCfg := &Config{
+		Listeners: MessageListeners{
+			OnVerAck: func(p *Peer, msg *wire.MsgVerAck) {
+				verack <- struct{}{}
+			},
+			OnSendCmpr: func(p *Peer, msg *wire.MsgSendCmpr) {
+				sendCmpr <- msg
+			},
+			OnBlock: func(p *Peer, msg *wire.MsgBlock, buf []byte) {
+				blocks <- msg
+			},
+		},
+		UserAgentName:        "peer",
+		UserAgentVersion:     "1.0",
+		Net:                  wire.MainNet,
+		Services:             wire.SFNodeNetwork,
+		ProtocolVersion:      wire.CompressionVersion,
+		EnableCompression:    true,
+		CompressionThreshold: 1000,
+	}
+	inConn, outConn := pipe(
+		&conn{raddr: "10.0.0.1:8333"},
+		&conn{raddr: "10.0.0.2:8333"},
+	)
+	inPeer := NewInboundPeer(inPeerCfg)
+	inPeer.AssociateConnection(inConn)
+
+	outPeerCfg := *inPeerCfg
+	outPeerCfg.CompressionThreshold = 100
+	outPeer, err := NewOutboundPeer(&outPeerCfg, "10.0.0.1:8333")
+	if err != nil {
+		t.Fatalf("NewOutboundPeer: unexpected err %v", err)
+	}
+	outPeer.AssociateConnection(outConn)
+	defer func() {
+		outPeer.Disconnect()
+		inPeer.Disconnect()
+		outPeer.WaitForDisconnect()
+		inPeer.WaitForDisconnect()
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-verack:
+		case <-time.After(time.Second):
+			t.Fatal("verack timeout")
+		}
+	}
+
+	// Ensure both peers inform each other of their thresholds.
+	wantMinSizes := map[uint32]bool{1000: true, 100: true}
+	for i := 0; i < 2; i++ {
+		select {
+		case msg := <-sendCmpr:
+			if msg.Algorithm != wire.CompressionDeflate ||
+				!wantMinSizes[msg.MinSize] {
+
+				t.Fatalf("unexpected sendcmpr %v", msg)
+			}
+			delete(wantMinSizes, msg.MinSize)
+		case <-time.After(time.Second):
+			t.Fatal("sendcmpr timeout")
+		}
+	}
+	if !inPeer.WantsCompression() || !outPeer.WantsCompression() {
+		t.Fatal("compression was not negotiated")
+	}
+
+	// sendBlock queues a block with a transaction that has a public key
+	// script of the provided size from the inbound peer and ensures the
+	// outbound peer receives it intact.
+	sendBlock := func(scriptSize int) {
+		t.Helper()
+
+		block := wire.NewMsgBlock(&wire.BlockHeader{Height: 1})
+		tx := wire.NewMsgTx()
+		tx.AddTxOut(wire.NewTxOut(0, make([]byte, scriptSize)))
+		block.AddTransaction(tx)
+		done := make(chan struct{}, 1)
+		outPeer.QueueMessage(block, done)
+		select {
+		case msg := <-blocks:
+			if msg.BlockHash() != block.BlockHash() {
+				t.Fatalf("unexpected block %v", msg.BlockHash())
+			}
+		case <-time.After(time.Second):
+			t.Fatal("block timeout")
+		}
+		<-done
+	}
+
+	// Ensure a block that is smaller than the threshold of the remote peer is
+	// not compressed even though it is larger than the local threshold.
+	sendBlock(500)
+	if stats := outPeer.CompressionStats(); stats.MsgsCompressed != 0 {
+		t.Fatalf("unexpected compressed messages %d", stats.MsgsCompressed)
+	}
+
+	// Ensure a block that meets both thresholds is compressed.
+	sendBlock(2000)
+	stats := outPeer.CompressionStats()
+	if stats.MsgsCompressed != 1 || stats.BytesUncompressed < 2000 ||
+		stats.BytesCompressed >= stats.BytesUncompressed {
+
+		t.Fatalf("unexpected compression stats %+v", stats)
+	}
+}
+
+// TestCompressionSafeguards ensures compression is stopped when it does not
+// achieve the minimum savings and that compressed messages are rejected when
+// the peer was not informed it may send them.
+func TestCompressionSafeguards(t *testing.T) {
+	t.Parallel()
+
+	p := newPeerBase(&Config{
+		ProtocolVersion:   wire.CompressionVersion,
+		EnableCompression: true,
+	}, false)
+	p.handleSendCmprMsg(wire.NewMsgSendCmpr(wire.CompressionDeflate, 0))
+	block := wire.NewMsgBlock(&wire.BlockHeader{})
+	tx := wire.NewMsgTx()
+	tx.AddTxOut(wire.NewTxOut(0, make([]byte, DefaultCompressionThreshold)))
+	block.AddTransaction(tx)
+	if _, ok := p.maybeCompress(block).(*wire.MsgCompressed); !ok {
+		t.Fatal("block was not compressed")
+	}
+
+	// Ensure other messages are never compressed.
+	if _, ok := p.maybeCompress(wire.NewMsgPing(0)).(*wire.MsgPing); !ok {
+		t.Fatal("ping was compressed")
+	}
+
+	// Ensure compression is stopped once enough messages are compressed
+	// without achieving the minimum savings.
+	for i := 0; i < compressionSampleMsgs; i++ {
+		if p.CompressionStats().Disabled {
+			t.Fatalf("compression disabled after %d messages", i)
+		}
+		p.recordCompression(1000, 950)
+	}
+	if !p.CompressionStats().Disabled {
+		t.Fatal("compression was not disabled")
+	}
+	if _, ok := p.maybeCompress(block).(*wire.MsgBlock); !ok {
+		t.Fatal("block was compressed after compression was disabled")
+	}
+
+	// Ensure compressed messages are rejected until the remote peer is
+	// informed it may send them.
+	cmsg, err := wire.NewMsgCompressed(wire.CompressionDeflate, block,
+		wire.CompressionVersion)
+	if err != nil {
+		t.Fatalf("NewMsgCompressed: unexpected err %v", err)
+	}
+	if _, err := p.decompress(cmsg); err == nil {
+		t.Fatal("compressed message was accepted without sending sendcmpr")
+	}
+	p.cmprSent = true
+	msg, err := p.decompress(cmsg)
+	if err != nil {
+		t.Fatalf("decompress: unexpected err %v", err)
+	}
+	if got, ok := msg.(*wire.MsgBlock); !ok || got.BlockHash() != block.BlockHash() {
+		t.Fatalf("unexpected decompressed message %v", msg)
+	}
+}
+
+// TestCompressiblePayloadSize ensures the payload size of compressible messages
+// calculated without encoding them matches the size of their encoded payloads.
+func TestCompressiblePayloadSize(t *testing.T) {
+	t.Parallel()
+
+	block := wire.NewMsgBlock(&wire.BlockHeader{})
+	tx := wire.NewMsgTx()
+	tx.AddTxOut(wire.NewTxOut(0, make([]byte, 300)))
+	block.AddTransaction(tx)
+	block.AddSTransaction(tx)
+	proofHashes := []chainhash.Hash{{0x01}, {0x02}, {0x03}}
+	tests := []struct {
+		name string
+		msg  wire.Message
+	}{
+		{"empty block", wire.NewMsgBlock(&wire.BlockHeader{})},
+		{"block", block},
+		{"empty cfilter", wire.NewMsgCFilter(&chainhash.Hash{},
+			wire.GCSFilterRegular, nil)},
+		{"cfilter", wire.NewMsgCFilter(&chainhash.Hash{},
+			wire.GCSFilterExtended, make([]byte, 300))},
+		{"empty cfilterv2", wire.NewMsgCFilterV2(&chainhash.Hash{}, nil, 0,
+			nil)},
+		{"cfilterv2", wire.NewMsgCFilterV2(&chainhash.Hash{},
+			make([]byte, 300), 2, proofHashes)},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := test.msg.BtcEncode(&buf, wire.ProtocolVersion); err != nil {
+			t.Fatalf("%q: unexpected encode err: %v", test.name, err)
+		}
+		if got := compressiblePayloadSize(test.msg); got != buf.Len() {
+			t.Errorf("%q: mismatched size -- got %d, want %d", test.name, got,
+				buf.Len())
+		}
+	}
+
+	// Ensure messages that are not compressible have a size of zero.
+	if got := compressiblePayloadSize(wire.NewMsgPing(0)); got != 0 {
+		t.Errorf("unexpected size for ping -- got %d, want 0", got)
+	}
+}
+
 func init() {
 	// Allow self connection when running the tests.
 	allowSelfConns = true
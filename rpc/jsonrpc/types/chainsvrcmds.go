@@ -12,6 +12,19 @@ import (
 	"github.com/decred/dcrd/dcrjson/v4"
 )
 
+// AbandonRebroadcastTxCmd defines the abandonrebroadcasttx JSON-RPC command.
+type AbandonRebroadcastTxCmd struct {
+	TxHash string
+}
+
+// NewAbandonRebroadcastTxCmd returns a new instance which can be used to issue
+// an abandonrebroadcasttx JSON-RPC command.
+func NewAbandonRebroadcastTxCmd(txHash string) *AbandonRebroadcastTxCmd {
+	return &AbandonRebroadcastTxCmd{
+		TxHash: txHash,
+	}
+}
+
 // AddNodeSubCmd defines the type used in the addnode JSON-RPC command for the
 // sub command field.
 type AddNodeSubCmd string
@@ -60,6 +73,32 @@ func NewAddNodeCmd(addr string, subCmd AddNodeSubCmd) *AddNodeCmd {
 	}
 }
 
+// ApproveDeepReorgCmd defines the approvedeepreorg JSON-RPC command.
+type ApproveDeepReorgCmd struct {
+	BlockHash string
+}
+
+// NewApproveDeepReorgCmd returns a new instance which can be used to issue an
+// approvedeepreorg JSON-RPC command.
+func NewApproveDeepReorgCmd(hash string) *ApproveDeepReorgCmd {
+	return &ApproveDeepReorgCmd{
+		BlockHash: hash,
+	}
+}
+
+// CheckBlockConflictsCmd defines the checkblockconflicts JSON-RPC command.
+type CheckBlockConflictsCmd struct {
+	HexBlock string
+}
+
+// NewCheckBlockConflictsCmd returns a new instance which can be used to issue
+// a checkblockconflicts JSON-RPC command.
+func NewCheckBlockConflictsCmd(hexBlock string) *CheckBlockConflictsCmd {
+	return &CheckBlockConflictsCmd{
+		HexBlock: hexBlock,
+	}
+}
+
 // SStxInput represents the inputs to an SStx transaction. Specifically a
 // transactionsha and output number pair, along with the output amounts.
 type SStxInput struct {
@@ -304,6 +343,33 @@ func NewExistsMempoolTxsCmd(txHashes []string) *ExistsMempoolTxsCmd {
 	}
 }
 
+// ForcePruneCmd defines the forceprune JSON-RPC command.
+type ForcePruneCmd struct{}
+
+// NewForcePruneCmd returns a new instance which can be used to issue a
+// forceprune JSON-RPC command.
+func NewForcePruneCmd() *ForcePruneCmd {
+	return &ForcePruneCmd{}
+}
+
+// ForecastStakeDiffCmd defines the forecaststakediff JSON-RPC command.
+type ForecastStakeDiffCmd struct {
+	Intervals          *int64 `jsonrpcdefault:"10"`
+	TicketsPerInterval *int64 `jsonrpcdefault:"-1"`
+}
+
+// NewForecastStakeDiffCmd returns a new instance which can be used to issue a
+// forecaststakediff JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewForecastStakeDiffCmd(intervals, ticketsPerInterval *int64) *ForecastStakeDiffCmd {
+	return &ForecastStakeDiffCmd{
+		Intervals:          intervals,
+		TicketsPerInterval: ticketsPerInterval,
+	}
+}
+
 // GenerateCmd defines the generate JSON-RPC command.
 type GenerateCmd struct {
 	NumBlocks uint32
@@ -317,6 +383,21 @@ func NewGenerateCmd(numBlocks uint32) *GenerateCmd {
 	}
 }
 
+// GenerateToAddressCmd defines the generatetoaddress JSON-RPC command.
+type GenerateToAddressCmd struct {
+	NumBlocks uint32
+	Address   string
+}
+
+// NewGenerateToAddressCmd returns a new instance which can be used to issue a
+// generatetoaddress JSON-RPC command.
+func NewGenerateToAddressCmd(numBlocks uint32, address string) *GenerateToAddressCmd {
+	return &GenerateToAddressCmd{
+		NumBlocks: numBlocks,
+		Address:   address,
+	}
+}
+
 // GetAddedNodeInfoCmd defines the getaddednodeinfo JSON-RPC command.
 type GetAddedNodeInfoCmd struct {
 	DNS  bool
@@ -419,6 +500,40 @@ func NewGetBlockHeaderCmd(hash string, verbose *bool) *GetBlockHeaderCmd {
 	}
 }
 
+// GetBlockHeaderProofCmd defines the getblockheaderproof JSON-RPC command.
+type GetBlockHeaderProofCmd struct {
+	TxHash     string
+	BlockHash  string
+	AnchorHash *string
+}
+
+// NewGetBlockHeaderProofCmd returns a new instance which can be used to issue
+// a getblockheaderproof JSON-RPC command.
+func NewGetBlockHeaderProofCmd(txHash, blockHash string, anchorHash *string) *GetBlockHeaderProofCmd {
+	return &GetBlockHeaderProofCmd{
+		TxHash:     txHash,
+		BlockHash:  blockHash,
+		AnchorHash: anchorHash,
+	}
+}
+
+// GetBlockHeadersCmd defines the getblockheaders JSON-RPC command.
+type GetBlockHeadersCmd struct {
+	StartHeight int64
+	Count       *uint32 `jsonrpcdefault:"2000"`
+	Verbose     *bool   `jsonrpcdefault:"false"`
+}
+
+// NewGetBlockHeadersCmd returns a new instance which can be used to issue a
+// getblockheaders JSON-RPC command.
+func NewGetBlockHeadersCmd(startHeight int64, count *uint32, verbose *bool) *GetBlockHeadersCmd {
+	return &GetBlockHeadersCmd{
+		StartHeight: startHeight,
+		Count:       count,
+		Verbose:     verbose,
+	}
+}
+
 // GetBlockSubsidyCmd defines the getblocksubsidy JSON-RPC command.
 type GetBlockSubsidyCmd struct {
 	Height int64
@@ -457,12 +572,19 @@ func NewGetChainTipsCmd() *GetChainTipsCmd {
 }
 
 // GetCoinSupplyCmd defines the getcoinsupply JSON-RPC command.
-type GetCoinSupplyCmd struct{}
+type GetCoinSupplyCmd struct {
+	Verbose *bool `jsonrpcdefault:"false"`
+}
 
 // NewGetCoinSupplyCmd returns a new instance which can be used to issue a
 // getcoinsupply JSON-RPC command.
-func NewGetCoinSupplyCmd() *GetCoinSupplyCmd {
-	return &GetCoinSupplyCmd{}
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGetCoinSupplyCmd(verbose *bool) *GetCoinSupplyCmd {
+	return &GetCoinSupplyCmd{
+		Verbose: verbose,
+	}
 }
 
 // GetConnectionCountCmd defines the getconnectioncount JSON-RPC command.
@@ -519,6 +641,19 @@ func NewGetInfoCmd() *GetInfoCmd {
 	return &GetInfoCmd{}
 }
 
+// GetHeaderCommitmentsCmd defines the getheadercommitments JSON-RPC command.
+type GetHeaderCommitmentsCmd struct {
+	BlockHash string
+}
+
+// NewGetHeaderCommitmentsCmd returns a new instance which can be used to issue
+// a getheadercommitments JSON-RPC command.
+func NewGetHeaderCommitmentsCmd(hash string) *GetHeaderCommitmentsCmd {
+	return &GetHeaderCommitmentsCmd{
+		BlockHash: hash,
+	}
+}
+
 // GetHeadersCmd defines the getheaders JSON-RPC command.
 type GetHeadersCmd struct {
 	BlockLocators []string `json:"blocklocators"`
@@ -543,6 +678,22 @@ func NewGetMempoolInfoCmd() *GetMempoolInfoCmd {
 	return &GetMempoolInfoCmd{}
 }
 
+// GetMempoolJournalCmd defines the getmempooljournal JSON-RPC command.
+type GetMempoolJournalCmd struct {
+	TxID *string
+}
+
+// NewGetMempoolJournalCmd returns a new instance which can be used to issue a
+// getmempooljournal JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGetMempoolJournalCmd(txID *string) *GetMempoolJournalCmd {
+	return &GetMempoolJournalCmd{
+		TxID: txID,
+	}
+}
+
 // GetMiningInfoCmd defines the getmininginfo JSON-RPC command.
 type GetMiningInfoCmd struct{}
 
@@ -570,6 +721,15 @@ func NewGetNetTotalsCmd() *GetNetTotalsCmd {
 	return &GetNetTotalsCmd{}
 }
 
+// GetNodeInfoCmd defines the getnodeinfo JSON-RPC command.
+type GetNodeInfoCmd struct{}
+
+// NewGetNodeInfoCmd returns a new instance which can be used to issue a
+// getnodeinfo JSON-RPC command.
+func NewGetNodeInfoCmd() *GetNodeInfoCmd {
+	return &GetNodeInfoCmd{}
+}
+
 // GetNetworkHashPSCmd defines the getnetworkhashps JSON-RPC command.
 type GetNetworkHashPSCmd struct {
 	Blocks *int `jsonrpcdefault:"120"`
@@ -588,6 +748,26 @@ func NewGetNetworkHashPSCmd(numBlocks, height *int) *GetNetworkHashPSCmd {
 	}
 }
 
+// GetNullDataCmd defines the getnulldata JSON-RPC command.
+type GetNullDataCmd struct {
+	Prefix      string
+	StartHeight *int64 `jsonrpcdefault:"0"`
+	EndHeight   *int64 `jsonrpcdefault:"-1"`
+}
+
+// NewGetNullDataCmd returns a new instance which can be used to issue a
+// getnulldata JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGetNullDataCmd(prefix string, startHeight, endHeight *int64) *GetNullDataCmd {
+	return &GetNullDataCmd{
+		Prefix:      prefix,
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+	}
+}
+
 // GetPeerInfoCmd defines the getpeerinfo JSON-RPC command.
 type GetPeerInfoCmd struct{}
 
@@ -625,9 +805,15 @@ const (
 )
 
 // GetRawMempoolCmd defines the getmempool JSON-RPC command.
+//
+// The MinFeeRate field is in DCR/kB and a Limit of 0 means there is no limit.
 type GetRawMempoolCmd struct {
-	Verbose *bool `jsonrpcdefault:"false"`
-	TxType  *string
+	Verbose    *bool `jsonrpcdefault:"false"`
+	TxType     *string
+	MinFeeRate *float64
+	Offset     *int  `jsonrpcdefault:"0"`
+	Limit      *int  `jsonrpcdefault:"0"`
+	Summary    *bool `jsonrpcdefault:"false"`
 }
 
 // NewGetRawMempoolCmd returns a new instance which can be used to issue a
@@ -635,10 +821,16 @@ type GetRawMempoolCmd struct {
 //
 // The parameters which are pointers indicate they are optional.  Passing nil
 // for optional parameters will use the default value.
-func NewGetRawMempoolCmd(verbose *bool, txType *string) *GetRawMempoolCmd {
+func NewGetRawMempoolCmd(verbose *bool, txType *string, minFeeRate *float64,
+	offset, limit *int, summary *bool) *GetRawMempoolCmd {
+
 	return &GetRawMempoolCmd{
-		Verbose: verbose,
-		TxType:  txType,
+		Verbose:    verbose,
+		TxType:     txType,
+		MinFeeRate: minFeeRate,
+		Offset:     offset,
+		Limit:      limit,
+		Summary:    summary,
 	}
 }
 
@@ -647,8 +839,9 @@ func NewGetRawMempoolCmd(verbose *bool, txType *string) *GetRawMempoolCmd {
 // NOTE: This field is an int versus a bool to remain compatible with Bitcoin
 // Core even though it really should be a bool.
 type GetRawTransactionCmd struct {
-	Txid    string
-	Verbose *int `jsonrpcdefault:"0"`
+	Txid      string
+	Verbose   *int `jsonrpcdefault:"0"`
+	BlockHash *string
 }
 
 // NewGetRawTransactionCmd returns a new instance which can be used to issue a
@@ -656,13 +849,52 @@ type GetRawTransactionCmd struct {
 //
 // The parameters which are pointers indicate they are optional.  Passing nil
 // for optional parameters will use the default value.
-func NewGetRawTransactionCmd(txHash string, verbose *int) *GetRawTransactionCmd {
+func NewGetRawTransactionCmd(txHash string, verbose *int, blockHash *string) *GetRawTransactionCmd {
 	return &GetRawTransactionCmd{
-		Txid:    txHash,
-		Verbose: verbose,
+		Txid:      txHash,
+		Verbose:   verbose,
+		BlockHash: blockHash,
 	}
 }
 
+// GetRawTransactionsCmd defines the getrawtransactions JSON-RPC command.
+type GetRawTransactionsCmd struct {
+	Txids     []string
+	Verbose   *int `jsonrpcdefault:"0"`
+	BlockHash *string
+}
+
+// NewGetRawTransactionsCmd returns a new instance which can be used to issue a
+// getrawtransactions JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGetRawTransactionsCmd(txHashes []string, verbose *int, blockHash *string) *GetRawTransactionsCmd {
+	return &GetRawTransactionsCmd{
+		Txids:     txHashes,
+		Verbose:   verbose,
+		BlockHash: blockHash,
+	}
+}
+
+// GetRebroadcastTxsCmd defines the getrebroadcasttxs JSON-RPC command.
+type GetRebroadcastTxsCmd struct{}
+
+// NewGetRebroadcastTxsCmd returns a new instance which can be used to issue a
+// getrebroadcasttxs JSON-RPC command.
+func NewGetRebroadcastTxsCmd() *GetRebroadcastTxsCmd {
+	return &GetRebroadcastTxsCmd{}
+}
+
+// GetSideChainBlocksCmd defines the getsidechainblocks JSON-RPC command.
+type GetSideChainBlocksCmd struct{}
+
+// NewGetSideChainBlocksCmd returns a new instance which can be used to issue a
+// getsidechainblocks JSON-RPC command.
+func NewGetSideChainBlocksCmd() *GetSideChainBlocksCmd {
+	return &GetSideChainBlocksCmd{}
+}
+
 // GetStakeDifficultyCmd is a type handling custom marshaling and
 // unmarshaling of getstakedifficulty JSON RPC commands.
 type GetStakeDifficultyCmd struct{}
@@ -712,6 +944,19 @@ func NewGetTicketPoolValueCmd() *GetTicketPoolValueCmd {
 	return &GetTicketPoolValueCmd{}
 }
 
+// GetTxMiningWindowCmd defines the gettxminingwindow JSON-RPC command.
+type GetTxMiningWindowCmd struct {
+	HexTx string
+}
+
+// NewGetTxMiningWindowCmd returns a new instance which can be used to issue a
+// gettxminingwindow JSON-RPC command.
+func NewGetTxMiningWindowCmd(hexTx string) *GetTxMiningWindowCmd {
+	return &GetTxMiningWindowCmd{
+		HexTx: hexTx,
+	}
+}
+
 // GetTxOutCmd defines the gettxout JSON-RPC command.
 type GetTxOutCmd struct {
 	Txid           string
@@ -734,6 +979,26 @@ func NewGetTxOutCmd(txHash string, vout uint32, tree int8, includeMempool *bool)
 	}
 }
 
+// GetTxOutHistoryCmd defines the gettxouthistory JSON-RPC command.
+type GetTxOutHistoryCmd struct {
+	Txid   string
+	Vout   uint32
+	Height *int64 `jsonrpcdefault:"-1"`
+}
+
+// NewGetTxOutHistoryCmd returns a new instance which can be used to issue a
+// gettxouthistory JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGetTxOutHistoryCmd(txHash string, vout uint32, height *int64) *GetTxOutHistoryCmd {
+	return &GetTxOutHistoryCmd{
+		Txid:   txHash,
+		Vout:   vout,
+		Height: height,
+	}
+}
+
 // GetTxOutSetInfoCmd defines the gettxoutsetinfo JSON-RPC command.
 type GetTxOutSetInfoCmd struct{}
 
@@ -743,17 +1008,46 @@ func NewGetTxOutSetInfoCmd() *GetTxOutSetInfoCmd {
 	return &GetTxOutSetInfoCmd{}
 }
 
-// GetVoteInfoCmd returns voting results over a range of blocks.  Count
-// indicates how many blocks are walked backwards.
+// GetVoteInfoCmd returns voting results for the agendas of a stake version
+// over the rule change activation interval that contains the provided height.
+// A height of -1 signifies the interval that contains the current best chain
+// block.
 type GetVoteInfoCmd struct {
 	Version uint32
+	Height  *int64 `jsonrpcdefault:"-1"`
 }
 
 // NewGetVoteInfoCmd returns a new instance which can be used to
 // issue a JSON-RPC getvoteinfo command.
-func NewGetVoteInfoCmd(version uint32) *GetVoteInfoCmd {
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGetVoteInfoCmd(version uint32, height *int64) *GetVoteInfoCmd {
 	return &GetVoteInfoCmd{
 		Version: version,
+		Height:  height,
+	}
+}
+
+// GetVoteStatsCmd defines the getvotestats JSON-RPC command.  It returns
+// statistics about the votes included and missed over the provided inclusive
+// range of main chain block heights.
+type GetVoteStatsCmd struct {
+	StartHeight int64
+	EndHeight   *int64 `jsonrpcdefault:"-1"`
+	Verbose     *bool  `jsonrpcdefault:"false"`
+}
+
+// NewGetVoteStatsCmd returns a new instance which can be used to issue a
+// getvotestats JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGetVoteStatsCmd(startHeight int64, endHeight *int64, verbose *bool) *GetVoteStatsCmd {
+	return &GetVoteStatsCmd{
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+		Verbose:     verbose,
 	}
 }
 
@@ -817,6 +1111,15 @@ func NewRegenTemplateCmd() *RegenTemplateCmd {
 	return &RegenTemplateCmd{}
 }
 
+// ReloadConfigCmd defines the reloadconfig JSON-RPC command.
+type ReloadConfigCmd struct{}
+
+// NewReloadConfigCmd returns a new instance which can be used to issue a
+// reloadconfig JSON-RPC command.
+func NewReloadConfigCmd() *ReloadConfigCmd {
+	return &ReloadConfigCmd{}
+}
+
 // HelpCmd defines the help JSON-RPC command.
 type HelpCmd struct {
 	Command *string
@@ -885,6 +1188,22 @@ func NewPingCmd() *PingCmd {
 	return &PingCmd{}
 }
 
+// PurgeSideChainBlocksCmd defines the purgesidechainblocks JSON-RPC command.
+type PurgeSideChainBlocksCmd struct {
+	MinDepth *int64 `jsonrpcdefault:"0"`
+}
+
+// NewPurgeSideChainBlocksCmd returns a new instance which can be used to issue
+// a purgesidechainblocks JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewPurgeSideChainBlocksCmd(minDepth *int64) *PurgeSideChainBlocksCmd {
+	return &PurgeSideChainBlocksCmd{
+		MinDepth: minDepth,
+	}
+}
+
 // ReconsiderBlockCmd defines the reconsiderblock JSON-RPC command.
 type ReconsiderBlockCmd struct {
 	BlockHash string
@@ -898,6 +1217,22 @@ func NewReconsiderBlockCmd(hash string) *ReconsiderBlockCmd {
 	}
 }
 
+// ScrubDatabaseCmd defines the scrubdatabase JSON-RPC command.
+type ScrubDatabaseCmd struct {
+	Quarantine *bool `jsonrpcdefault:"false"`
+}
+
+// NewScrubDatabaseCmd returns a new instance which can be used to issue a
+// scrubdatabase JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewScrubDatabaseCmd(quarantine *bool) *ScrubDatabaseCmd {
+	return &ScrubDatabaseCmd{
+		Quarantine: quarantine,
+	}
+}
+
 // SendRawTransactionCmd defines the sendrawtransaction JSON-RPC command.
 type SendRawTransactionCmd struct {
 	HexTx         string
@@ -934,6 +1269,19 @@ func NewSetGenerateCmd(generate bool, genProcLimit *int) *SetGenerateCmd {
 	}
 }
 
+// SetMockTimeCmd defines the setmocktime JSON-RPC command.
+type SetMockTimeCmd struct {
+	Timestamp int64
+}
+
+// NewSetMockTimeCmd returns a new instance which can be used to issue a
+// setmocktime JSON-RPC command.  A timestamp of zero removes the mock time.
+func NewSetMockTimeCmd(timestamp int64) *SetMockTimeCmd {
+	return &SetMockTimeCmd{
+		Timestamp: timestamp,
+	}
+}
+
 // StopCmd defines the stop JSON-RPC command.
 type StopCmd struct{}
 
@@ -968,6 +1316,24 @@ func NewSubmitBlockCmd(hexBlock string, options *SubmitBlockOptions) *SubmitBloc
 	}
 }
 
+// TestMempoolAcceptCmd defines the testmempoolaccept JSON-RPC command.
+type TestMempoolAcceptCmd struct {
+	RawTxs        []string
+	AllowHighFees *bool `jsonrpcdefault:"false"`
+}
+
+// NewTestMempoolAcceptCmd returns a new instance which can be used to issue a
+// testmempoolaccept JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewTestMempoolAcceptCmd(rawTxs []string, allowHighFees *bool) *TestMempoolAcceptCmd {
+	return &TestMempoolAcceptCmd{
+		RawTxs:        rawTxs,
+		AllowHighFees: allowHighFees,
+	}
+}
+
 // TicketFeeInfoCmd defines the ticketfeeinfo JSON-RPC command.
 type TicketFeeInfoCmd struct {
 	Blocks  *uint32
@@ -1085,7 +1451,10 @@ func init() {
 	// No special flags for commands in this file.
 	flags := dcrjson.UsageFlag(0)
 
+	dcrjson.MustRegister(Method("abandonrebroadcasttx"), (*AbandonRebroadcastTxCmd)(nil), flags)
 	dcrjson.MustRegister(Method("addnode"), (*AddNodeCmd)(nil), flags)
+	dcrjson.MustRegister(Method("approvedeepreorg"), (*ApproveDeepReorgCmd)(nil), flags)
+	dcrjson.MustRegister(Method("checkblockconflicts"), (*CheckBlockConflictsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("createrawssrtx"), (*CreateRawSSRtxCmd)(nil), flags)
 	dcrjson.MustRegister(Method("createrawsstx"), (*CreateRawSStxCmd)(nil), flags)
 	dcrjson.MustRegister(Method("createrawtransaction"), (*CreateRawTransactionCmd)(nil), flags)
@@ -1100,7 +1469,10 @@ func init() {
 	dcrjson.MustRegister(Method("existsliveticket"), (*ExistsLiveTicketCmd)(nil), flags)
 	dcrjson.MustRegister(Method("existslivetickets"), (*ExistsLiveTicketsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("existsmempooltxs"), (*ExistsMempoolTxsCmd)(nil), flags)
+	dcrjson.MustRegister(Method("forceprune"), (*ForcePruneCmd)(nil), flags)
+	dcrjson.MustRegister(Method("forecaststakediff"), (*ForecastStakeDiffCmd)(nil), flags)
 	dcrjson.MustRegister(Method("generate"), (*GenerateCmd)(nil), flags)
+	dcrjson.MustRegister(Method("generatetoaddress"), (*GenerateToAddressCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getaddednodeinfo"), (*GetAddedNodeInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getbestblock"), (*GetBestBlockCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getbestblockhash"), (*GetBestBlockHashCmd)(nil), flags)
@@ -1109,6 +1481,8 @@ func init() {
 	dcrjson.MustRegister(Method("getblockcount"), (*GetBlockCountCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getblockhash"), (*GetBlockHashCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getblockheader"), (*GetBlockHeaderCmd)(nil), flags)
+	dcrjson.MustRegister(Method("getblockheaderproof"), (*GetBlockHeaderProofCmd)(nil), flags)
+	dcrjson.MustRegister(Method("getblockheaders"), (*GetBlockHeadersCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getblocksubsidy"), (*GetBlockSubsidyCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getcfilterv2"), (*GetCFilterV2Cmd)(nil), flags)
 	dcrjson.MustRegister(Method("getchaintips"), (*GetChainTipsCmd)(nil), flags)
@@ -1118,37 +1492,52 @@ func init() {
 	dcrjson.MustRegister(Method("getdifficulty"), (*GetDifficultyCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getgenerate"), (*GetGenerateCmd)(nil), flags)
 	dcrjson.MustRegister(Method("gethashespersec"), (*GetHashesPerSecCmd)(nil), flags)
+	dcrjson.MustRegister(Method("getheadercommitments"), (*GetHeaderCommitmentsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getheaders"), (*GetHeadersCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getinfo"), (*GetInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getmempoolinfo"), (*GetMempoolInfoCmd)(nil), flags)
+	dcrjson.MustRegister(Method("getmempooljournal"), (*GetMempoolJournalCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getmininginfo"), (*GetMiningInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getnetworkinfo"), (*GetNetworkInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getnettotals"), (*GetNetTotalsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getnetworkhashps"), (*GetNetworkHashPSCmd)(nil), flags)
+	dcrjson.MustRegister(Method("getnodeinfo"), (*GetNodeInfoCmd)(nil), flags)
+	dcrjson.MustRegister(Method("getnulldata"), (*GetNullDataCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getpeerinfo"), (*GetPeerInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getrawmempool"), (*GetRawMempoolCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getrawtransaction"), (*GetRawTransactionCmd)(nil), flags)
+	dcrjson.MustRegister(Method("getrawtransactions"), (*GetRawTransactionsCmd)(nil), flags)
+	dcrjson.MustRegister(Method("getrebroadcasttxs"), (*GetRebroadcastTxsCmd)(nil), flags)
+	dcrjson.MustRegister(Method("getsidechainblocks"), (*GetSideChainBlocksCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getstakedifficulty"), (*GetStakeDifficultyCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getstakeversioninfo"), (*GetStakeVersionInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getstakeversions"), (*GetStakeVersionsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getticketpoolvalue"), (*GetTicketPoolValueCmd)(nil), flags)
 	dcrjson.MustRegister(Method("gettreasurybalance"), (*GetTreasuryBalanceCmd)(nil), flags)
 	dcrjson.MustRegister(Method("gettreasuryspendvotes"), (*GetTreasurySpendVotesCmd)(nil), flags)
+	dcrjson.MustRegister(Method("gettxminingwindow"), (*GetTxMiningWindowCmd)(nil), flags)
 	dcrjson.MustRegister(Method("gettxout"), (*GetTxOutCmd)(nil), flags)
+	dcrjson.MustRegister(Method("gettxouthistory"), (*GetTxOutHistoryCmd)(nil), flags)
 	dcrjson.MustRegister(Method("gettxoutsetinfo"), (*GetTxOutSetInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getvoteinfo"), (*GetVoteInfoCmd)(nil), flags)
+	dcrjson.MustRegister(Method("getvotestats"), (*GetVoteStatsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("getwork"), (*GetWorkCmd)(nil), flags)
 	dcrjson.MustRegister(Method("help"), (*HelpCmd)(nil), flags)
 	dcrjson.MustRegister(Method("invalidateblock"), (*InvalidateBlockCmd)(nil), flags)
 	dcrjson.MustRegister(Method("livetickets"), (*LiveTicketsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("node"), (*NodeCmd)(nil), flags)
 	dcrjson.MustRegister(Method("ping"), (*PingCmd)(nil), flags)
+	dcrjson.MustRegister(Method("purgesidechainblocks"), (*PurgeSideChainBlocksCmd)(nil), flags)
 	dcrjson.MustRegister(Method("reconsiderblock"), (*ReconsiderBlockCmd)(nil), flags)
 	dcrjson.MustRegister(Method("regentemplate"), (*RegenTemplateCmd)(nil), flags)
+	dcrjson.MustRegister(Method("reloadconfig"), (*ReloadConfigCmd)(nil), flags)
+	dcrjson.MustRegister(Method("scrubdatabase"), (*ScrubDatabaseCmd)(nil), flags)
 	dcrjson.MustRegister(Method("sendrawtransaction"), (*SendRawTransactionCmd)(nil), flags)
 	dcrjson.MustRegister(Method("setgenerate"), (*SetGenerateCmd)(nil), flags)
+	dcrjson.MustRegister(Method("setmocktime"), (*SetMockTimeCmd)(nil), flags)
 	dcrjson.MustRegister(Method("stop"), (*StopCmd)(nil), flags)
 	dcrjson.MustRegister(Method("submitblock"), (*SubmitBlockCmd)(nil), flags)
+	dcrjson.MustRegister(Method("testmempoolaccept"), (*TestMempoolAcceptCmd)(nil), flags)
 	dcrjson.MustRegister(Method("ticketfeeinfo"), (*TicketFeeInfoCmd)(nil), flags)
 	dcrjson.MustRegister(Method("ticketsforaddress"), (*TicketsForAddressCmd)(nil), flags)
 	dcrjson.MustRegister(Method("ticketvwap"), (*TicketVWAPCmd)(nil), flags)
@@ -30,6 +30,17 @@ func TestChainSvrCmds(t *testing.T) {
 		marshalled   string
 		unmarshalled interface{}
 	}{
+		{
+			name: "abandonrebroadcasttx",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("abandonrebroadcasttx"), "123")
+			},
+			staticCmd: func() interface{} {
+				return NewAbandonRebroadcastTxCmd("123")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"abandonrebroadcasttx","params":["123"],"id":1}`,
+			unmarshalled: &AbandonRebroadcastTxCmd{TxHash: "123"},
+		},
 		{
 			name: "addnode",
 			newCmd: func() (interface{}, error) {
@@ -41,6 +52,17 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"addnode","params":["127.0.0.1","remove"],"id":1}`,
 			unmarshalled: &AddNodeCmd{Addr: "127.0.0.1", SubCmd: ANRemove},
 		},
+		{
+			name: "checkblockconflicts",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("checkblockconflicts"), "00")
+			},
+			staticCmd: func() interface{} {
+				return NewCheckBlockConflictsCmd("00")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"checkblockconflicts","params":["00"],"id":1}`,
+			unmarshalled: &CheckBlockConflictsCmd{HexBlock: "00"},
+		},
 		{
 			name: "createrawtransaction",
 			newCmd: func() (interface{}, error) {
@@ -171,6 +193,46 @@ func TestChainSvrCmds(t *testing.T) {
 				Mode:          EstimateSmartFeeModeAddr(EstimateSmartFeeConservative),
 			},
 		},
+		{
+			name: "forceprune",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("forceprune"))
+			},
+			staticCmd: func() interface{} {
+				return NewForcePruneCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"forceprune","params":[],"id":1}`,
+			unmarshalled: &ForcePruneCmd{},
+		},
+		{
+			name: "forecaststakediff",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("forecaststakediff"))
+			},
+			staticCmd: func() interface{} {
+				return NewForecastStakeDiffCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"forecaststakediff","params":[],"id":1}`,
+			unmarshalled: &ForecastStakeDiffCmd{
+				Intervals:          dcrjson.Int64(10),
+				TicketsPerInterval: dcrjson.Int64(-1),
+			},
+		},
+		{
+			name: "forecaststakediff optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("forecaststakediff"), 20, 2880)
+			},
+			staticCmd: func() interface{} {
+				return NewForecastStakeDiffCmd(dcrjson.Int64(20),
+					dcrjson.Int64(2880))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"forecaststakediff","params":[20,2880],"id":1}`,
+			unmarshalled: &ForecastStakeDiffCmd{
+				Intervals:          dcrjson.Int64(20),
+				TicketsPerInterval: dcrjson.Int64(2880),
+			},
+		},
 		{
 			name: "generate",
 			newCmd: func() (interface{}, error) {
@@ -184,6 +246,22 @@ func TestChainSvrCmds(t *testing.T) {
 				NumBlocks: 1,
 			},
 		},
+		{
+			name: "generatetoaddress",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("generatetoaddress"), 1,
+					"SsWKp7wtdTZYabYFYSc9cnxhwFEjA5g4pFc")
+			},
+			staticCmd: func() interface{} {
+				return NewGenerateToAddressCmd(1,
+					"SsWKp7wtdTZYabYFYSc9cnxhwFEjA5g4pFc")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"generatetoaddress","params":[1,"SsWKp7wtdTZYabYFYSc9cnxhwFEjA5g4pFc"],"id":1}`,
+			unmarshalled: &GenerateToAddressCmd{
+				NumBlocks: 1,
+				Address:   "SsWKp7wtdTZYabYFYSc9cnxhwFEjA5g4pFc",
+			},
+		},
 		{
 			name: "getaddednodeinfo",
 			newCmd: func() (interface{}, error) {
@@ -327,6 +405,68 @@ func TestChainSvrCmds(t *testing.T) {
 				Verbose: dcrjson.Bool(true),
 			},
 		},
+		{
+			name: "getblockheaderproof",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getblockheaderproof"), "123", "456")
+			},
+			staticCmd: func() interface{} {
+				return NewGetBlockHeaderProofCmd("123", "456", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblockheaderproof","params":["123","456"],"id":1}`,
+			unmarshalled: &GetBlockHeaderProofCmd{
+				TxHash:    "123",
+				BlockHash: "456",
+			},
+		},
+		{
+			name: "getblockheaderproof optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getblockheaderproof"), "123", "456",
+					"789")
+			},
+			staticCmd: func() interface{} {
+				return NewGetBlockHeaderProofCmd("123", "456",
+					dcrjson.String("789"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblockheaderproof","params":["123","456","789"],"id":1}`,
+			unmarshalled: &GetBlockHeaderProofCmd{
+				TxHash:     "123",
+				BlockHash:  "456",
+				AnchorHash: dcrjson.String("789"),
+			},
+		},
+		{
+			name: "getblockheaders",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getblockheaders"), 123)
+			},
+			staticCmd: func() interface{} {
+				return NewGetBlockHeadersCmd(123, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblockheaders","params":[123],"id":1}`,
+			unmarshalled: &GetBlockHeadersCmd{
+				StartHeight: 123,
+				Count:       dcrjson.Uint32(2000),
+				Verbose:     dcrjson.Bool(false),
+			},
+		},
+		{
+			name: "getblockheaders optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getblockheaders"), 123, 10, true)
+			},
+			staticCmd: func() interface{} {
+				return NewGetBlockHeadersCmd(123, dcrjson.Uint32(10),
+					dcrjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblockheaders","params":[123,10,true],"id":1}`,
+			unmarshalled: &GetBlockHeadersCmd{
+				StartHeight: 123,
+				Count:       dcrjson.Uint32(10),
+				Verbose:     dcrjson.Bool(true),
+			},
+		},
 		{
 			name: "getblocksubsidy",
 			newCmd: func() (interface{}, error) {
@@ -365,6 +505,32 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"getchaintips","params":[],"id":1}`,
 			unmarshalled: &GetChainTipsCmd{},
 		},
+		{
+			name: "getcoinsupply",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getcoinsupply"))
+			},
+			staticCmd: func() interface{} {
+				return NewGetCoinSupplyCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getcoinsupply","params":[],"id":1}`,
+			unmarshalled: &GetCoinSupplyCmd{
+				Verbose: dcrjson.Bool(false),
+			},
+		},
+		{
+			name: "getcoinsupply optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getcoinsupply"), true)
+			},
+			staticCmd: func() interface{} {
+				return NewGetCoinSupplyCmd(dcrjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getcoinsupply","params":[true],"id":1}`,
+			unmarshalled: &GetCoinSupplyCmd{
+				Verbose: dcrjson.Bool(true),
+			},
+		},
 		{
 			name: "getconnectioncount",
 			newCmd: func() (interface{}, error) {
@@ -420,6 +586,19 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"gethashespersec","params":[],"id":1}`,
 			unmarshalled: &GetHashesPerSecCmd{},
 		},
+		{
+			name: "getheadercommitments",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getheadercommitments"), "123")
+			},
+			staticCmd: func() interface{} {
+				return NewGetHeaderCommitmentsCmd("123")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getheadercommitments","params":["123"],"id":1}`,
+			unmarshalled: &GetHeaderCommitmentsCmd{
+				BlockHash: "123",
+			},
+		},
 		{
 			name: "getinfo",
 			newCmd: func() (interface{}, error) {
@@ -442,6 +621,30 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"getmempoolinfo","params":[],"id":1}`,
 			unmarshalled: &GetMempoolInfoCmd{},
 		},
+		{
+			name: "getmempooljournal",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getmempooljournal"))
+			},
+			staticCmd: func() interface{} {
+				return NewGetMempoolJournalCmd(nil)
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getmempooljournal","params":[],"id":1}`,
+			unmarshalled: &GetMempoolJournalCmd{},
+		},
+		{
+			name: "getmempooljournal optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getmempooljournal"), "123")
+			},
+			staticCmd: func() interface{} {
+				return NewGetMempoolJournalCmd(dcrjson.String("123"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getmempooljournal","params":["123"],"id":1}`,
+			unmarshalled: &GetMempoolJournalCmd{
+				TxID: dcrjson.String("123"),
+			},
+		},
 		{
 			name: "getmininginfo",
 			newCmd: func() (interface{}, error) {
@@ -517,6 +720,48 @@ func TestChainSvrCmds(t *testing.T) {
 				Height: dcrjson.Int(123),
 			},
 		},
+		{
+			name: "getnodeinfo",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getnodeinfo"))
+			},
+			staticCmd: func() interface{} {
+				return NewGetNodeInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getnodeinfo","params":[],"id":1}`,
+			unmarshalled: &GetNodeInfoCmd{},
+		},
+		{
+			name: "getnulldata",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getnulldata"), "444352")
+			},
+			staticCmd: func() interface{} {
+				return NewGetNullDataCmd("444352", nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getnulldata","params":["444352"],"id":1}`,
+			unmarshalled: &GetNullDataCmd{
+				Prefix:      "444352",
+				StartHeight: dcrjson.Int64(0),
+				EndHeight:   dcrjson.Int64(-1),
+			},
+		},
+		{
+			name: "getnulldata optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getnulldata"), "444352", 100, 200)
+			},
+			staticCmd: func() interface{} {
+				return NewGetNullDataCmd("444352", dcrjson.Int64(100),
+					dcrjson.Int64(200))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getnulldata","params":["444352",100,200],"id":1}`,
+			unmarshalled: &GetNullDataCmd{
+				Prefix:      "444352",
+				StartHeight: dcrjson.Int64(100),
+				EndHeight:   dcrjson.Int64(200),
+			},
+		},
 		{
 			name: "getpeerinfo",
 			newCmd: func() (interface{}, error) {
@@ -534,11 +779,14 @@ func TestChainSvrCmds(t *testing.T) {
 				return dcrjson.NewCmd(Method("getrawmempool"))
 			},
 			staticCmd: func() interface{} {
-				return NewGetRawMempoolCmd(nil, nil)
+				return NewGetRawMempoolCmd(nil, nil, nil, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"getrawmempool","params":[],"id":1}`,
 			unmarshalled: &GetRawMempoolCmd{
 				Verbose: dcrjson.Bool(false),
+				Offset:  dcrjson.Int(0),
+				Limit:   dcrjson.Int(0),
+				Summary: dcrjson.Bool(false),
 			},
 		},
 		{
@@ -547,11 +795,15 @@ func TestChainSvrCmds(t *testing.T) {
 				return dcrjson.NewCmd(Method("getrawmempool"), false)
 			},
 			staticCmd: func() interface{} {
-				return NewGetRawMempoolCmd(dcrjson.Bool(false), nil)
+				return NewGetRawMempoolCmd(dcrjson.Bool(false), nil, nil, nil,
+					nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"getrawmempool","params":[false],"id":1}`,
 			unmarshalled: &GetRawMempoolCmd{
 				Verbose: dcrjson.Bool(false),
+				Offset:  dcrjson.Int(0),
+				Limit:   dcrjson.Int(0),
+				Summary: dcrjson.Bool(false),
 			},
 		},
 		{
@@ -560,12 +812,37 @@ func TestChainSvrCmds(t *testing.T) {
 				return dcrjson.NewCmd(Method("getrawmempool"), false, "all")
 			},
 			staticCmd: func() interface{} {
-				return NewGetRawMempoolCmd(dcrjson.Bool(false), dcrjson.String("all"))
+				return NewGetRawMempoolCmd(dcrjson.Bool(false),
+					dcrjson.String("all"), nil, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"getrawmempool","params":[false,"all"],"id":1}`,
 			unmarshalled: &GetRawMempoolCmd{
 				Verbose: dcrjson.Bool(false),
 				TxType:  dcrjson.String("all"),
+				Offset:  dcrjson.Int(0),
+				Limit:   dcrjson.Int(0),
+				Summary: dcrjson.Bool(false),
+			},
+		},
+		{
+			name: "getrawmempool optional 3",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getrawmempool"), false, "tickets",
+					0.0001, 10, 20, true)
+			},
+			staticCmd: func() interface{} {
+				return NewGetRawMempoolCmd(dcrjson.Bool(false),
+					dcrjson.String("tickets"), dcrjson.Float64(0.0001),
+					dcrjson.Int(10), dcrjson.Int(20), dcrjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getrawmempool","params":[false,"tickets",0.0001,10,20,true],"id":1}`,
+			unmarshalled: &GetRawMempoolCmd{
+				Verbose:    dcrjson.Bool(false),
+				TxType:     dcrjson.String("tickets"),
+				MinFeeRate: dcrjson.Float64(0.0001),
+				Offset:     dcrjson.Int(10),
+				Limit:      dcrjson.Int(20),
+				Summary:    dcrjson.Bool(true),
 			},
 		},
 		{
@@ -574,7 +851,7 @@ func TestChainSvrCmds(t *testing.T) {
 				return dcrjson.NewCmd(Method("getrawtransaction"), "123")
 			},
 			staticCmd: func() interface{} {
-				return NewGetRawTransactionCmd("123", nil)
+				return NewGetRawTransactionCmd("123", nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"getrawtransaction","params":["123"],"id":1}`,
 			unmarshalled: &GetRawTransactionCmd{
@@ -588,7 +865,7 @@ func TestChainSvrCmds(t *testing.T) {
 				return dcrjson.NewCmd(Method("getrawtransaction"), "123", 1)
 			},
 			staticCmd: func() interface{} {
-				return NewGetRawTransactionCmd("123", dcrjson.Int(1))
+				return NewGetRawTransactionCmd("123", dcrjson.Int(1), nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"getrawtransaction","params":["123",1],"id":1}`,
 			unmarshalled: &GetRawTransactionCmd{
@@ -596,6 +873,75 @@ func TestChainSvrCmds(t *testing.T) {
 				Verbose: dcrjson.Int(1),
 			},
 		},
+		{
+			name: "getrawtransaction optional blockhash",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getrawtransaction"), "123", 1, "456")
+			},
+			staticCmd: func() interface{} {
+				return NewGetRawTransactionCmd("123", dcrjson.Int(1),
+					dcrjson.String("456"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getrawtransaction","params":["123",1,"456"],"id":1}`,
+			unmarshalled: &GetRawTransactionCmd{
+				Txid:      "123",
+				Verbose:   dcrjson.Int(1),
+				BlockHash: dcrjson.String("456"),
+			},
+		},
+		{
+			name: "getrawtransactions",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getrawtransactions"), `["123","456"]`)
+			},
+			staticCmd: func() interface{} {
+				return NewGetRawTransactionsCmd([]string{"123", "456"}, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getrawtransactions","params":[["123","456"]],"id":1}`,
+			unmarshalled: &GetRawTransactionsCmd{
+				Txids:   []string{"123", "456"},
+				Verbose: dcrjson.Int(0),
+			},
+		},
+		{
+			name: "getrawtransactions optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getrawtransactions"), `["123"]`, 1,
+					"456")
+			},
+			staticCmd: func() interface{} {
+				return NewGetRawTransactionsCmd([]string{"123"}, dcrjson.Int(1),
+					dcrjson.String("456"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getrawtransactions","params":[["123"],1,"456"],"id":1}`,
+			unmarshalled: &GetRawTransactionsCmd{
+				Txids:     []string{"123"},
+				Verbose:   dcrjson.Int(1),
+				BlockHash: dcrjson.String("456"),
+			},
+		},
+		{
+			name: "getrebroadcasttxs",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getrebroadcasttxs"))
+			},
+			staticCmd: func() interface{} {
+				return NewGetRebroadcastTxsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getrebroadcasttxs","params":[],"id":1}`,
+			unmarshalled: &GetRebroadcastTxsCmd{},
+		},
+		{
+			name: "getsidechainblocks",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getsidechainblocks"))
+			},
+			staticCmd: func() interface{} {
+				return NewGetSideChainBlocksCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getsidechainblocks","params":[],"id":1}`,
+			unmarshalled: &GetSideChainBlocksCmd{},
+		},
 		{
 			name: "getstakeversions",
 			newCmd: func() (interface{}, error) {
@@ -610,6 +956,19 @@ func TestChainSvrCmds(t *testing.T) {
 				Count: 1,
 			},
 		},
+		{
+			name: "gettxminingwindow",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("gettxminingwindow"), "001122")
+			},
+			staticCmd: func() interface{} {
+				return NewGetTxMiningWindowCmd("001122")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gettxminingwindow","params":["001122"],"id":1}`,
+			unmarshalled: &GetTxMiningWindowCmd{
+				HexTx: "001122",
+			},
+		},
 		{
 			name: "gettxout",
 			newCmd: func() (interface{}, error) {
@@ -642,6 +1001,36 @@ func TestChainSvrCmds(t *testing.T) {
 				IncludeMempool: dcrjson.Bool(true),
 			},
 		},
+		{
+			name: "gettxouthistory",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("gettxouthistory"), "123", 1)
+			},
+			staticCmd: func() interface{} {
+				return NewGetTxOutHistoryCmd("123", 1, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gettxouthistory","params":["123",1],"id":1}`,
+			unmarshalled: &GetTxOutHistoryCmd{
+				Txid:   "123",
+				Vout:   1,
+				Height: dcrjson.Int64(-1),
+			},
+		},
+		{
+			name: "gettxouthistory optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("gettxouthistory"), "123", 1, 100)
+			},
+			staticCmd: func() interface{} {
+				return NewGetTxOutHistoryCmd("123", 1, dcrjson.Int64(100))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gettxouthistory","params":["123",1,100],"id":1}`,
+			unmarshalled: &GetTxOutHistoryCmd{
+				Txid:   "123",
+				Vout:   1,
+				Height: dcrjson.Int64(100),
+			},
+		},
 		{
 			name: "gettxoutsetinfo",
 			newCmd: func() (interface{}, error) {
@@ -659,11 +1048,57 @@ func TestChainSvrCmds(t *testing.T) {
 				return dcrjson.NewCmd(Method("getvoteinfo"), 1)
 			},
 			staticCmd: func() interface{} {
-				return NewGetVoteInfoCmd(1)
+				return NewGetVoteInfoCmd(1, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"getvoteinfo","params":[1],"id":1}`,
 			unmarshalled: &GetVoteInfoCmd{
 				Version: 1,
+				Height:  dcrjson.Int64(-1),
+			},
+		},
+		{
+			name: "getvoteinfo optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getvoteinfo"), 1, 4096)
+			},
+			staticCmd: func() interface{} {
+				return NewGetVoteInfoCmd(1, dcrjson.Int64(4096))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getvoteinfo","params":[1,4096],"id":1}`,
+			unmarshalled: &GetVoteInfoCmd{
+				Version: 1,
+				Height:  dcrjson.Int64(4096),
+			},
+		},
+		{
+			name: "getvotestats",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getvotestats"), 4096)
+			},
+			staticCmd: func() interface{} {
+				return NewGetVoteStatsCmd(4096, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getvotestats","params":[4096],"id":1}`,
+			unmarshalled: &GetVoteStatsCmd{
+				StartHeight: 4096,
+				EndHeight:   dcrjson.Int64(-1),
+				Verbose:     dcrjson.Bool(false),
+			},
+		},
+		{
+			name: "getvotestats optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("getvotestats"), 4096, 8192, true)
+			},
+			staticCmd: func() interface{} {
+				return NewGetVoteStatsCmd(4096, dcrjson.Int64(8192),
+					dcrjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getvotestats","params":[4096,8192,true],"id":1}`,
+			unmarshalled: &GetVoteStatsCmd{
+				StartHeight: 4096,
+				EndHeight:   dcrjson.Int64(8192),
+				Verbose:     dcrjson.Bool(true),
 			},
 		},
 		{
@@ -814,6 +1249,69 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"ping","params":[],"id":1}`,
 			unmarshalled: &PingCmd{},
 		},
+		{
+			name: "purgesidechainblocks",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("purgesidechainblocks"))
+			},
+			staticCmd: func() interface{} {
+				return NewPurgeSideChainBlocksCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"purgesidechainblocks","params":[],"id":1}`,
+			unmarshalled: &PurgeSideChainBlocksCmd{
+				MinDepth: dcrjson.Int64(0),
+			},
+		},
+		{
+			name: "purgesidechainblocks optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("purgesidechainblocks"), 288)
+			},
+			staticCmd: func() interface{} {
+				return NewPurgeSideChainBlocksCmd(dcrjson.Int64(288))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"purgesidechainblocks","params":[288],"id":1}`,
+			unmarshalled: &PurgeSideChainBlocksCmd{
+				MinDepth: dcrjson.Int64(288),
+			},
+		},
+		{
+			name: "reloadconfig",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("reloadconfig"))
+			},
+			staticCmd: func() interface{} {
+				return NewReloadConfigCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"reloadconfig","params":[],"id":1}`,
+			unmarshalled: &ReloadConfigCmd{},
+		},
+		{
+			name: "scrubdatabase",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("scrubdatabase"))
+			},
+			staticCmd: func() interface{} {
+				return NewScrubDatabaseCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"scrubdatabase","params":[],"id":1}`,
+			unmarshalled: &ScrubDatabaseCmd{
+				Quarantine: dcrjson.Bool(false),
+			},
+		},
+		{
+			name: "scrubdatabase optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("scrubdatabase"), true)
+			},
+			staticCmd: func() interface{} {
+				return NewScrubDatabaseCmd(dcrjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"scrubdatabase","params":[true],"id":1}`,
+			unmarshalled: &ScrubDatabaseCmd{
+				Quarantine: dcrjson.Bool(true),
+			},
+		},
 		{
 			name: "sendrawtransaction",
 			newCmd: func() (interface{}, error) {
@@ -870,6 +1368,19 @@ func TestChainSvrCmds(t *testing.T) {
 				GenProcLimit: dcrjson.Int(6),
 			},
 		},
+		{
+			name: "setmocktime",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("setmocktime"), 1592931200)
+			},
+			staticCmd: func() interface{} {
+				return NewSetMockTimeCmd(1592931200)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setmocktime","params":[1592931200],"id":1}`,
+			unmarshalled: &SetMockTimeCmd{
+				Timestamp: 1592931200,
+			},
+		},
 		{
 			name: "stop",
 			newCmd: func() (interface{}, error) {
@@ -914,6 +1425,34 @@ func TestChainSvrCmds(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "testmempoolaccept",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("testmempoolaccept"), []string{"1122", "3344"})
+			},
+			staticCmd: func() interface{} {
+				return NewTestMempoolAcceptCmd([]string{"1122", "3344"}, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"testmempoolaccept","params":[["1122","3344"]],"id":1}`,
+			unmarshalled: &TestMempoolAcceptCmd{
+				RawTxs:        []string{"1122", "3344"},
+				AllowHighFees: dcrjson.Bool(false),
+			},
+		},
+		{
+			name: "testmempoolaccept optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("testmempoolaccept"), []string{"1122"}, true)
+			},
+			staticCmd: func() interface{} {
+				return NewTestMempoolAcceptCmd([]string{"1122"}, dcrjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"testmempoolaccept","params":[["1122"],true],"id":1}`,
+			unmarshalled: &TestMempoolAcceptCmd{
+				RawTxs:        []string{"1122"},
+				AllowHighFees: dcrjson.Bool(true),
+			},
+		},
 		{
 			name: "validateaddress",
 			newCmd: func() (interface{}, error) {
@@ -17,6 +17,14 @@ type TxRawDecodeResult struct {
 	Vout     []Vout `json:"vout"`
 }
 
+// CheckBlockConflictsResult models the data returned from the
+// checkblockconflicts command.
+type CheckBlockConflictsResult struct {
+	Mined       []string `json:"mined"`
+	DoubleSpent []string `json:"doublespent"`
+	Evicted     []string `json:"evicted"`
+}
+
 // DecodeScriptResult models the data returned from the decodescript command.
 type DecodeScriptResult struct {
 	Asm       string   `json:"asm"`
@@ -43,6 +51,24 @@ type EstimateStakeDiffResult struct {
 	User     *float64 `json:"user,omitempty"`
 }
 
+// StakeDiffProjection models the data for each projected retarget interval of
+// ForecastStakeDiffResult.
+type StakeDiffProjection struct {
+	Height    int64   `json:"height"`
+	StakeDiff float64 `json:"stakediff"`
+	PoolSize  uint32  `json:"poolsize"`
+}
+
+// ForecastStakeDiffResult models the data returned from the forecaststakediff
+// command.
+type ForecastStakeDiffResult struct {
+	Height             int64                 `json:"height"`
+	PoolValue          float64               `json:"poolvalue"`
+	NextStakeDiff      float64               `json:"nextstakediff"`
+	TicketsPerInterval int64                 `json:"ticketsperinterval"`
+	Projections        []StakeDiffProjection `json:"projections"`
+}
+
 // GetAddedNodeInfoResultAddr models the data of the addresses portion of the
 // getaddednodeinfo command.
 type GetAddedNodeInfoResultAddr struct {
@@ -162,6 +188,22 @@ type GetBlockHeaderVerboseResult struct {
 	NextHash      string  `json:"nextblockhash,omitempty"`
 }
 
+// GetBlockHeaderProofResult models the data returned from the
+// getblockheaderproof command.
+type GetBlockHeaderProofResult struct {
+	TxHash       string   `json:"txid"`
+	LeafHash     string   `json:"leafhash"`
+	Tree         int8     `json:"tree"`
+	BlockHash    string   `json:"blockhash"`
+	Height       int64    `json:"height"`
+	Root         string   `json:"root"`
+	ProofIndex   uint32   `json:"proofindex"`
+	ProofHashes  []string `json:"proofhashes"`
+	AnchorHash   string   `json:"anchorhash"`
+	AnchorHeight int64    `json:"anchorheight"`
+	Headers      []string `json:"headers"`
+}
+
 // GetBlockSubsidyResult models the data returned from the getblocksubsidy
 // command.
 type GetBlockSubsidyResult struct {
@@ -171,6 +213,15 @@ type GetBlockSubsidyResult struct {
 	Total     int64 `json:"total"`
 }
 
+// GetCoinSupplyVerboseResult models the data returned from the getcoinsupply
+// command when the verbose flag is set.
+type GetCoinSupplyVerboseResult struct {
+	Height             int64 `json:"height"`
+	Supply             int64 `json:"supply"`
+	ScheduledSupply    int64 `json:"scheduledsupply"`
+	SubsidySplitHeight int64 `json:"subsidysplitheight"`
+}
+
 // GetChainTipsResult models the data returns from the getchaintips command.
 type GetChainTipsResult struct {
 	Height    int64  `json:"height"`
@@ -187,6 +238,24 @@ type GetCFilterV2Result struct {
 	ProofHashes []string `json:"proofhashes"`
 }
 
+// HeaderCommitmentResult models an individual header commitment along with the
+// inclusion proof returned from the getheadercommitments command.
+type HeaderCommitmentResult struct {
+	Name        string   `json:"name"`
+	Hash        string   `json:"hash"`
+	ProofIndex  uint32   `json:"proofindex"`
+	ProofHashes []string `json:"proofhashes"`
+}
+
+// GetHeaderCommitmentsResult models the data returned from the
+// getheadercommitments command.
+type GetHeaderCommitmentsResult struct {
+	BlockHash      string                   `json:"blockhash"`
+	Version        uint32                   `json:"version"`
+	CommitmentRoot string                   `json:"commitmentroot"`
+	Commitments    []HeaderCommitmentResult `json:"commitments"`
+}
+
 // GetHeadersResult models the data returned by the chain server getheaders
 // command.
 type GetHeadersResult struct {
@@ -215,6 +284,15 @@ type GetMempoolInfoResult struct {
 	Bytes int64 `json:"bytes"`
 }
 
+// MempoolEventResult models the data returned from the getmempooljournal
+// command for each recorded mempool event.
+type MempoolEventResult struct {
+	Time   int64  `json:"time"`
+	Event  string `json:"event"`
+	TxID   string `json:"txid"`
+	Reason string `json:"reason,omitempty"`
+}
+
 // GetMiningInfoResult models the data from the getmininginfo command.
 // Contains Decred additions.
 type GetMiningInfoResult struct {
@@ -261,6 +339,8 @@ type GetNetworkInfoResult struct {
 	RelayFee        float64                `json:"relayfee"`
 	LocalAddresses  []LocalAddressesResult `json:"localaddresses"`
 	LocalServices   string                 `json:"localservices"`
+	AgentRejected   uint64                 `json:"agentrejected"`
+	AgentEvicted    uint64                 `json:"agentevicted"`
 }
 
 // GetNetTotalsResult models the data returned from the getnettotals command.
@@ -270,28 +350,93 @@ type GetNetTotalsResult struct {
 	TimeMillis     int64  `json:"timemillis"`
 }
 
+// NodePolicyResult models the transaction relay and acceptance policy data
+// returned from the getnodeinfo command.
+type NodePolicyResult struct {
+	RelayFee          float64 `json:"relayfee"`
+	AcceptNonStd      bool    `json:"acceptnonstd"`
+	MaxOrphanTxs      int     `json:"maxorphantxs"`
+	MaxStandardTxSize int     `json:"maxstandardtxsize"`
+}
+
+// NodeRPCResponseCacheResult models the RPC server response cache data
+// returned from the getnodeinfo command.
+type NodeRPCResponseCacheResult struct {
+	Hits     uint64 `json:"hits"`
+	Misses   uint64 `json:"misses"`
+	Entries  int    `json:"entries"`
+	Bytes    int64  `json:"bytes"`
+	MaxBytes int64  `json:"maxbytes"`
+}
+
+// NodeRPCServerResult models the RPC server client data returned from the
+// getnodeinfo command.
+type NodeRPCServerResult struct {
+	Clients            int                        `json:"clients"`
+	Websockets         int                        `json:"websockets"`
+	RejectedWebsockets uint64                     `json:"rejectedwebsockets"`
+	SlowWebsockets     uint64                     `json:"slowwebsockets"`
+	AuthFailures       uint64                     `json:"authfailures"`
+	AuthLockouts       uint64                     `json:"authlockouts"`
+	LockedOut          int                        `json:"lockedout"`
+	ResponseCache      NodeRPCResponseCacheResult `json:"responsecache"`
+}
+
+// GetNodeInfoResult models the data returned from the getnodeinfo command.
+type GetNodeInfoResult struct {
+	Version         VersionResult       `json:"version"`
+	RPCAPIVersion   VersionResult       `json:"rpcapiversion"`
+	Commit          string              `json:"commit"`
+	GoVersion       string              `json:"goversion"`
+	UserAgent       string              `json:"useragent"`
+	ProtocolVersion uint32              `json:"protocolversion"`
+	Network         string              `json:"network"`
+	StartTime       int64               `json:"starttime"`
+	Uptime          int64               `json:"uptime"`
+	Indexes         []string            `json:"indexes"`
+	Features        []string            `json:"features"`
+	Pruned          bool                `json:"pruned"`
+	Policy          NodePolicyResult    `json:"policy"`
+	RPCServer       NodeRPCServerResult `json:"rpcserver"`
+}
+
+// GetNullDataResult models the data returned from the getnulldata command.
+type GetNullDataResult struct {
+	BlockHash string `json:"blockhash"`
+	Height    int64  `json:"height"`
+	TxHash    string `json:"txid"`
+	Tree      int8   `json:"tree"`
+	Vout      uint32 `json:"vout"`
+	Payload   string `json:"payload"`
+}
+
 // GetPeerInfoResult models the data returned from the getpeerinfo command.
 type GetPeerInfoResult struct {
-	ID             int32   `json:"id"`
-	Addr           string  `json:"addr"`
-	AddrLocal      string  `json:"addrlocal,omitempty"`
-	Services       string  `json:"services"`
-	RelayTxes      bool    `json:"relaytxes"`
-	LastSend       int64   `json:"lastsend"`
-	LastRecv       int64   `json:"lastrecv"`
-	BytesSent      uint64  `json:"bytessent"`
-	BytesRecv      uint64  `json:"bytesrecv"`
-	ConnTime       int64   `json:"conntime"`
-	TimeOffset     int64   `json:"timeoffset"`
-	PingTime       float64 `json:"pingtime"`
-	PingWait       float64 `json:"pingwait,omitempty"`
-	Version        uint32  `json:"version"`
-	SubVer         string  `json:"subver"`
-	Inbound        bool    `json:"inbound"`
-	StartingHeight int64   `json:"startingheight"`
-	CurrentHeight  int64   `json:"currentheight,omitempty"`
-	BanScore       int32   `json:"banscore"`
-	SyncNode       bool    `json:"syncnode"`
+	ID              int32             `json:"id"`
+	Addr            string            `json:"addr"`
+	AddrLocal       string            `json:"addrlocal,omitempty"`
+	Services        string            `json:"services"`
+	RelayTxes       bool              `json:"relaytxes"`
+	LastSend        int64             `json:"lastsend"`
+	LastRecv        int64             `json:"lastrecv"`
+	BytesSent       uint64            `json:"bytessent"`
+	BytesRecv       uint64            `json:"bytesrecv"`
+	BytesSentPerMsg map[string]uint64 `json:"bytessent_per_msg,omitempty"`
+	BytesRecvPerMsg map[string]uint64 `json:"bytesrecv_per_msg,omitempty"`
+	ConnTime        int64             `json:"conntime"`
+	TimeOffset      int64             `json:"timeoffset"`
+	PingTime        float64           `json:"pingtime"`
+	PingWait        float64           `json:"pingwait,omitempty"`
+	PingP50         float64           `json:"pingp50,omitempty"`
+	PingP90         float64           `json:"pingp90,omitempty"`
+	PingP99         float64           `json:"pingp99,omitempty"`
+	Version         uint32            `json:"version"`
+	SubVer          string            `json:"subver"`
+	Inbound         bool              `json:"inbound"`
+	StartingHeight  int64             `json:"startingheight"`
+	CurrentHeight   int64             `json:"currentheight,omitempty"`
+	BanScore        int32             `json:"banscore"`
+	SyncNode        bool              `json:"syncnode"`
 }
 
 // GetRawMempoolVerboseResult models the data returned from the getrawmempool
@@ -307,6 +452,21 @@ type GetRawMempoolVerboseResult struct {
 	Depends          []string `json:"depends"`
 }
 
+// GetRawMempoolSummaryTypeResult models the per transaction type data returned
+// from the getrawmempool command when the summary flag is set.
+type GetRawMempoolSummaryTypeResult struct {
+	Count int   `json:"count"`
+	Bytes int64 `json:"bytes"`
+}
+
+// GetRawMempoolSummaryResult models the data returned from the getrawmempool
+// command when the summary flag is set.
+type GetRawMempoolSummaryResult struct {
+	Count int                                       `json:"count"`
+	Bytes int64                                     `json:"bytes"`
+	Types map[string]GetRawMempoolSummaryTypeResult `json:"types"`
+}
+
 // TxRawResult models the data from the getrawtransaction command.
 type TxRawResult struct {
 	Hex           string `json:"hex"`
@@ -383,6 +543,36 @@ type GetTxOutResult struct {
 	Coinbase      bool               `json:"coinbase"`
 }
 
+// TxOutHistoryEventResult models an event that changed the state of an output
+// in the main chain as returned by the gettxouthistory command.
+type TxOutHistoryEventResult struct {
+	Height    int64  `json:"height"`
+	BlockHash string `json:"blockhash"`
+	Event     string `json:"event"`
+	SpendTxID string `json:"spendtxid,omitempty"`
+	SpendVin  uint32 `json:"spendvin,omitempty"`
+}
+
+// GetTxOutHistoryResult models the data from the gettxouthistory command.
+type GetTxOutHistoryResult struct {
+	Height        int64                     `json:"height"`
+	State         string                    `json:"state"`
+	CreatedHeight int64                     `json:"createdheight"`
+	Tree          int8                      `json:"tree"`
+	Value         float64                   `json:"value"`
+	ScriptPubKey  ScriptPubKeyResult        `json:"scriptPubKey"`
+	Coinbase      bool                      `json:"coinbase"`
+	Events        []TxOutHistoryEventResult `json:"events"`
+}
+
+// GetTxMiningWindowResult models the data from the gettxminingwindow command.
+type GetTxMiningWindowResult struct {
+	MinHeight     int64 `json:"minheight"`
+	MinMedianTime int64 `json:"minmediantime"`
+	MaxHeight     int64 `json:"maxheight"`
+	Minable       bool  `json:"minable"`
+}
+
 // GetTxOutSetInfoResult models the data from the gettxoutsetinfo command.
 type GetTxOutSetInfoResult struct {
 	Height         int64  `json:"height"`
@@ -414,6 +604,7 @@ type Agenda struct {
 	ExpireTime     uint64   `json:"expiretime"`
 	Status         string   `json:"status"`
 	QuorumProgress float64  `json:"quorumprogress"`
+	Participation  float64  `json:"participation"`
 	Choices        []Choice `json:"choices"`
 }
 
@@ -429,6 +620,27 @@ type GetVoteInfoResult struct {
 	Agendas       []Agenda `json:"agendas,omitempty"`
 }
 
+// VoteStatsBlock models the per-block data for GetVoteStatsResult.
+type VoteStatsBlock struct {
+	Hash     string `json:"hash"`
+	Height   int64  `json:"height"`
+	Eligible uint16 `json:"eligible"`
+	Included uint16 `json:"included"`
+	Missed   uint16 `json:"missed"`
+}
+
+// GetVoteStatsResult models the data returned from the getvotestats command.
+type GetVoteStatsResult struct {
+	StartHeight int64            `json:"startheight"`
+	EndHeight   int64            `json:"endheight"`
+	VoteBlocks  int64            `json:"voteblocks"`
+	Eligible    uint64           `json:"eligible"`
+	Included    uint64           `json:"included"`
+	Missed      uint64           `json:"missed"`
+	VoteCounts  []int64          `json:"votecounts"`
+	Blocks      []VoteStatsBlock `json:"blocks,omitempty"`
+}
+
 // GetTreasuryBalanceResult models the data returned from the
 // gettreasurybalance command.
 type GetTreasuryBalanceResult struct {
@@ -475,6 +687,49 @@ type LiveTicketsResult struct {
 	Tickets []string `json:"tickets"`
 }
 
+// ReloadConfigResult models the data returned from the reloadconfig command.
+type ReloadConfigResult struct {
+	Changes []string `json:"changes"`
+}
+
+// RebroadcastTxResult models a transaction tracked for rebroadcast returned by
+// the getrebroadcasttxs command.
+type RebroadcastTxResult struct {
+	TxID          string `json:"txid"`
+	Added         int64  `json:"added"`
+	LastBroadcast int64  `json:"lastbroadcast"`
+	NextBroadcast int64  `json:"nextbroadcast"`
+	Broadcasts    uint32 `json:"broadcasts"`
+}
+
+// SideChainBlockResult models a side chain block returned by the
+// getsidechainblocks and purgesidechainblocks commands.
+type SideChainBlockResult struct {
+	Hash       string `json:"hash"`
+	Height     int64  `json:"height"`
+	ForkHash   string `json:"forkhash"`
+	ForkHeight int64  `json:"forkheight"`
+	Depth      int64  `json:"depth"`
+}
+
+// ScrubIssue models an integrity issue returned by the scrubdatabase command.
+type ScrubIssue struct {
+	Hash        string `json:"hash"`
+	Height      int64  `json:"height"`
+	MainChain   bool   `json:"mainchain"`
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+	Suggestion  string `json:"suggestion"`
+	Quarantined bool   `json:"quarantined"`
+}
+
+// ScrubDatabaseResult models the data returned from the scrubdatabase command.
+type ScrubDatabaseResult struct {
+	MainChainBlocks int64        `json:"mainchainblocks"`
+	SideChainBlocks int64        `json:"sidechainblocks"`
+	Issues          []ScrubIssue `json:"issues"`
+}
+
 // FeeInfoBlock is ticket fee information about a block.
 type FeeInfoBlock struct {
 	Height uint32  `json:"height"`
@@ -518,6 +773,25 @@ type FeeInfoWindow struct {
 	StdDev      float64 `json:"stddev"`
 }
 
+// TestMempoolAcceptResult models the data returned from the testmempoolaccept
+// command for each of the provided transactions.
+type TestMempoolAcceptResult struct {
+	TxID         string  `json:"txid"`
+	Allowed      bool    `json:"allowed"`
+	RejectReason string  `json:"rejectreason,omitempty"`
+	RejectCode   string  `json:"rejectcode,omitempty"`
+	Size         int64   `json:"size,omitempty"`
+	Fee          float64 `json:"fee,omitempty"`
+}
+
+// TxRejectErrorData models the additional data included in the errors returned
+// by commands that submit transactions, such as sendrawtransaction, when the
+// transaction is rejected.  The reject code is a stable, machine-readable
+// category for the reason the transaction was rejected.
+type TxRejectErrorData struct {
+	RejectCode string `json:"rejectcode"`
+}
+
 // TicketFeeInfoResult models the data returned from the ticketfeeinfo command.
 // command.
 type TicketFeeInfoResult struct {
@@ -1,5 +1,5 @@
 // Copyright (c) 2014-2015 The btcsuite developers
-// Copyright (c) 2015-2021 The Decred developers
+// Copyright (c) 2015-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -35,10 +35,18 @@ type OutPoint struct {
 
 // LoadTxFilterCmd defines the loadtxfilter request parameters to load or
 // reload a transaction filter.
+//
+// In addition to addresses and outpoints, the filter optionally matches
+// outputs that pay to any of the provided hex-encoded raw output scripts or
+// whose BLAKE-256 hash of the output script starts with any of the provided
+// hex-encoded prefixes.  These allow watching nonstandard scripts that do not
+// have an address.
 type LoadTxFilterCmd struct {
-	Reload    bool
-	Addresses []string
-	OutPoints []OutPoint
+	Reload             bool
+	Addresses          []string
+	OutPoints          []OutPoint
+	Scripts            *[]string
+	ScriptHashPrefixes *[]string
 }
 
 // NewLoadTxFilterCmd returns a new instance which can be used to issue a
@@ -51,6 +59,15 @@ func NewLoadTxFilterCmd(reload bool, addresses []string, outPoints []OutPoint) *
 	}
 }
 
+// GetTxFilterCmd defines the gettxfilter JSON-RPC command.
+type GetTxFilterCmd struct{}
+
+// NewGetTxFilterCmd returns a new instance which can be used to issue a
+// gettxfilter JSON-RPC command.
+func NewGetTxFilterCmd() *GetTxFilterCmd {
+	return &GetTxFilterCmd{}
+}
+
 // NotifyBlocksCmd defines the notifyblocks JSON-RPC command.
 type NotifyBlocksCmd struct{}
 
@@ -137,6 +154,35 @@ func NewStopNotifyTSpendCmd() *StopNotifyTSpendCmd {
 	return &StopNotifyTSpendCmd{}
 }
 
+// NotifyStakeEventsCmd defines the notifystakeevents JSON-RPC command.
+type NotifyStakeEventsCmd struct {
+	Tickets []string
+}
+
+// NewNotifyStakeEventsCmd returns a new instance which can be used to issue a
+// notifystakeevents JSON-RPC command.
+func NewNotifyStakeEventsCmd(tickets []string) *NotifyStakeEventsCmd {
+	return &NotifyStakeEventsCmd{
+		Tickets: tickets,
+	}
+}
+
+// StopNotifyStakeEventsCmd defines the stopnotifystakeevents JSON-RPC command.
+type StopNotifyStakeEventsCmd struct {
+	Tickets *[]string
+}
+
+// NewStopNotifyStakeEventsCmd returns a new instance which can be used to issue
+// a stopnotifystakeevents JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewStopNotifyStakeEventsCmd(tickets *[]string) *StopNotifyStakeEventsCmd {
+	return &StopNotifyStakeEventsCmd{
+		Tickets: tickets,
+	}
+}
+
 // NotifyNewTransactionsCmd defines the notifynewtransactions JSON-RPC command.
 type NotifyNewTransactionsCmd struct {
 	Verbose *bool `jsonrpcdefault:"false"`
@@ -185,16 +231,36 @@ func NewRescanCmd(blockHashes []string) *RescanCmd {
 	return &RescanCmd{BlockHashes: blockHashes}
 }
 
+// RescanFiltersCmd defines the rescanfilters JSON-RPC command.
+type RescanFiltersCmd struct {
+	BeginBlock string
+	EndBlock   *string
+}
+
+// NewRescanFiltersCmd returns a new instance which can be used to issue a
+// rescanfilters JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewRescanFiltersCmd(beginBlock string, endBlock *string) *RescanFiltersCmd {
+	return &RescanFiltersCmd{
+		BeginBlock: beginBlock,
+		EndBlock:   endBlock,
+	}
+}
+
 func init() {
 	// The commands in this file are only usable by websockets.
 	flags := dcrjson.UFWebsocketOnly
 
 	dcrjson.MustRegister(Method("authenticate"), (*AuthenticateCmd)(nil), flags)
+	dcrjson.MustRegister(Method("gettxfilter"), (*GetTxFilterCmd)(nil), flags)
 	dcrjson.MustRegister(Method("loadtxfilter"), (*LoadTxFilterCmd)(nil), flags)
 	dcrjson.MustRegister(Method("notifyblocks"), (*NotifyBlocksCmd)(nil), flags)
 	dcrjson.MustRegister(Method("notifywork"), (*NotifyWorkCmd)(nil), flags)
 	dcrjson.MustRegister(Method("notifytspend"), (*NotifyTSpendCmd)(nil), flags)
 	dcrjson.MustRegister(Method("notifynewtransactions"), (*NotifyNewTransactionsCmd)(nil), flags)
+	dcrjson.MustRegister(Method("notifystakeevents"), (*NotifyStakeEventsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("notifynewtickets"), (*NotifyNewTicketsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("notifywinningtickets"), (*NotifyWinningTicketsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("rebroadcastwinners"), (*RebroadcastWinnersCmd)(nil), flags)
@@ -203,5 +269,7 @@ func init() {
 	dcrjson.MustRegister(Method("stopnotifywork"), (*StopNotifyWorkCmd)(nil), flags)
 	dcrjson.MustRegister(Method("stopnotifytspend"), (*StopNotifyTSpendCmd)(nil), flags)
 	dcrjson.MustRegister(Method("stopnotifynewtransactions"), (*StopNotifyNewTransactionsCmd)(nil), flags)
+	dcrjson.MustRegister(Method("stopnotifystakeevents"), (*StopNotifyStakeEventsCmd)(nil), flags)
 	dcrjson.MustRegister(Method("rescan"), (*RescanCmd)(nil), flags)
+	dcrjson.MustRegister(Method("rescanfilters"), (*RescanFiltersCmd)(nil), flags)
 }
@@ -1,5 +1,5 @@
 // Copyright (c) 2014 The btcsuite developers
-// Copyright (c) 2015-2021 The Decred developers
+// Copyright (c) 2015-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -166,6 +166,96 @@ func TestChainSvrWsCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifynewtransactions","params":[],"id":1}`,
 			unmarshalled: &StopNotifyNewTransactionsCmd{},
 		},
+		{
+			name: "notifystakeevents",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("notifystakeevents"), []string{"123", "456"})
+			},
+			staticCmd: func() interface{} {
+				return NewNotifyStakeEventsCmd([]string{"123", "456"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"notifystakeevents","params":[["123","456"]],"id":1}`,
+			unmarshalled: &NotifyStakeEventsCmd{
+				Tickets: []string{"123", "456"},
+			},
+		},
+		{
+			name: "stopnotifystakeevents",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("stopnotifystakeevents"))
+			},
+			staticCmd: func() interface{} {
+				return NewStopNotifyStakeEventsCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"stopnotifystakeevents","params":[],"id":1}`,
+			unmarshalled: &StopNotifyStakeEventsCmd{
+				Tickets: nil,
+			},
+		},
+		{
+			name: "stopnotifystakeevents optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("stopnotifystakeevents"), []string{"123"})
+			},
+			staticCmd: func() interface{} {
+				return NewStopNotifyStakeEventsCmd(&[]string{"123"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"stopnotifystakeevents","params":[["123"]],"id":1}`,
+			unmarshalled: &StopNotifyStakeEventsCmd{
+				Tickets: &[]string{"123"},
+			},
+		},
+		{
+			name: "gettxfilter",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("gettxfilter"))
+			},
+			staticCmd: func() interface{} {
+				return NewGetTxFilterCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"gettxfilter","params":[],"id":1}`,
+			unmarshalled: &GetTxFilterCmd{},
+		},
+		{
+			name: "loadtxfilter",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("loadtxfilter"), false,
+					[]string{"DsYAXxmBs2SX2mmeNXdcTaK5gxpqFtEBrWj"},
+					[]OutPoint{{Hash: "0000000000000000000000000000000000000000000000000000000000000123", Index: 1}})
+			},
+			staticCmd: func() interface{} {
+				return NewLoadTxFilterCmd(false,
+					[]string{"DsYAXxmBs2SX2mmeNXdcTaK5gxpqFtEBrWj"},
+					[]OutPoint{{Hash: "0000000000000000000000000000000000000000000000000000000000000123", Index: 1}})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"loadtxfilter","params":[false,["DsYAXxmBs2SX2mmeNXdcTaK5gxpqFtEBrWj"],[{"hash":"0000000000000000000000000000000000000000000000000000000000000123","tree":0,"index":1}]],"id":1}`,
+			unmarshalled: &LoadTxFilterCmd{
+				Reload:    false,
+				Addresses: []string{"DsYAXxmBs2SX2mmeNXdcTaK5gxpqFtEBrWj"},
+				OutPoints: []OutPoint{{Hash: "0000000000000000000000000000000000000000000000000000000000000123", Index: 1}},
+			},
+		},
+		{
+			name: "loadtxfilter optional scripts",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("loadtxfilter"), true, []string{},
+					[]OutPoint{}, &[]string{"51"}, &[]string{"abcd"})
+			},
+			staticCmd: func() interface{} {
+				cmd := NewLoadTxFilterCmd(true, []string{}, []OutPoint{})
+				cmd.Scripts = &[]string{"51"}
+				cmd.ScriptHashPrefixes = &[]string{"abcd"}
+				return cmd
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"loadtxfilter","params":[true,[],[],["51"],["abcd"]],"id":1}`,
+			unmarshalled: &LoadTxFilterCmd{
+				Reload:             true,
+				Addresses:          []string{},
+				OutPoints:          []OutPoint{},
+				Scripts:            &[]string{"51"},
+				ScriptHashPrefixes: &[]string{"abcd"},
+			},
+		},
 		{
 			name: "rescan",
 			newCmd: func() (interface{}, error) {
@@ -179,6 +269,33 @@ func TestChainSvrWsCmds(t *testing.T) {
 				BlockHashes: []string{"0000000000000000000000000000000000000000000000000000000000000123"},
 			},
 		},
+		{
+			name: "rescanfilters",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("rescanfilters"), "123")
+			},
+			staticCmd: func() interface{} {
+				return NewRescanFiltersCmd("123", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"rescanfilters","params":["123"],"id":1}`,
+			unmarshalled: &RescanFiltersCmd{
+				BeginBlock: "123",
+			},
+		},
+		{
+			name: "rescanfilters optional",
+			newCmd: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("rescanfilters"), "123", "456")
+			},
+			staticCmd: func() interface{} {
+				return NewRescanFiltersCmd("123", dcrjson.String("456"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"rescanfilters","params":["123","456"],"id":1}`,
+			unmarshalled: &RescanFiltersCmd{
+				BeginBlock: "123",
+				EndBlock:   dcrjson.String("456"),
+			},
+		},
 	}
 
 	t.Logf("Running %d tests", len(tests))
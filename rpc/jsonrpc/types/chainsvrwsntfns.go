@@ -1,5 +1,5 @@
 // Copyright (c) 2014 The btcsuite developers
-// Copyright (c) 2015-2021 The Decred developers
+// Copyright (c) 2015-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -34,6 +34,17 @@ const (
 	// block chain is in the process of a reorganization.
 	ReorganizationNtfnMethod Method = "reorganization"
 
+	// DeepReorgPausedNtfnMethod is the method used for notifications that a
+	// block chain reorganization was paused because it exceeds the maximum
+	// automatic reorganization depth.
+	DeepReorgPausedNtfnMethod Method = "deepreorgpaused"
+
+	// VoteLeaderChangedNtfnMethod is the method used for notifications from
+	// the chain server that a new vote in the mempool caused a different
+	// block to become the most favorable one to build on among the competing
+	// blocks at a given height.
+	VoteLeaderChangedNtfnMethod Method = "voteleaderchanged"
+
 	// TxAcceptedNtfnMethod is the method used for notifications from the
 	// chain server that a transaction has been accepted into the mempool.
 	TxAcceptedNtfnMethod Method = "txaccepted"
@@ -52,6 +63,21 @@ const (
 	// WinningTicketsNtfnMethod is the method of the daemon winningtickets
 	// notification.
 	WinningTicketsNtfnMethod Method = "winningtickets"
+
+	// RescanProgressNtfnMethod is the method used for notifications from the
+	// chain server that report the progress of a rescan.
+	RescanProgressNtfnMethod Method = "rescanprogress"
+
+	// StakeEventsNtfnMethod is the method used for notifications from the
+	// chain server that watched tickets matured, voted, were missed, expired,
+	// or were revoked in a block connected to the main chain.
+	StakeEventsNtfnMethod Method = "stakeevents"
+
+	// NotificationsDroppedNtfnMethod is the method used for notifications
+	// from the chain server that the queued notifications for the client
+	// exceeded the allowed budget and were dropped prior to the client being
+	// disconnected.
+	NotificationsDroppedNtfnMethod Method = "notificationsdropped"
 )
 
 // BlockConnectedNtfn defines the blockconnected JSON-RPC notification.
@@ -150,6 +176,45 @@ func NewReorganizationNtfn(oldHash string, oldHeight int32, newHash string,
 	}
 }
 
+// DeepReorgPausedNtfn defines the deepreorgpaused JSON-RPC notification.
+type DeepReorgPausedNtfn struct {
+	TipHash      string `json:"tiphash"`
+	TipHeight    int32  `json:"tipheight"`
+	TargetHash   string `json:"targethash"`
+	TargetHeight int32  `json:"targetheight"`
+	ForkHeight   int32  `json:"forkheight"`
+}
+
+// NewDeepReorgPausedNtfn returns a new instance which can be used to issue a
+// deepreorgpaused JSON-RPC notification.
+func NewDeepReorgPausedNtfn(tipHash string, tipHeight int32, targetHash string,
+	targetHeight, forkHeight int32) *DeepReorgPausedNtfn {
+	return &DeepReorgPausedNtfn{
+		TipHash:      tipHash,
+		TipHeight:    tipHeight,
+		TargetHash:   targetHash,
+		TargetHeight: targetHeight,
+		ForkHeight:   forkHeight,
+	}
+}
+
+// VoteLeaderChangedNtfn defines the voteleaderchanged JSON-RPC notification.
+type VoteLeaderChangedNtfn struct {
+	Height    int64  `json:"height"`
+	OldLeader string `json:"oldleader"`
+	NewLeader string `json:"newleader"`
+}
+
+// NewVoteLeaderChangedNtfn returns a new instance which can be used to issue a
+// voteleaderchanged JSON-RPC notification.
+func NewVoteLeaderChangedNtfn(height int64, oldLeader, newLeader string) *VoteLeaderChangedNtfn {
+	return &VoteLeaderChangedNtfn{
+		Height:    height,
+		OldLeader: oldLeader,
+		NewLeader: newLeader,
+	}
+}
+
 // TxAcceptedNtfn defines the txaccepted JSON-RPC notification.
 type TxAcceptedNtfn struct {
 	TxID   string  `json:"txid"`
@@ -207,6 +272,66 @@ func NewWinningTicketsNtfn(hash string, height int32, tickets map[string]string)
 	}
 }
 
+// RescanProgressNtfn defines the rescanprogress JSON-RPC notification.
+type RescanProgressNtfn struct {
+	Hash   string
+	Height int64
+	Time   int64
+}
+
+// NewRescanProgressNtfn returns a new instance which can be used to issue a
+// rescanprogress JSON-RPC notification.
+func NewRescanProgressNtfn(hash string, height int64, time int64) *RescanProgressNtfn {
+	return &RescanProgressNtfn{
+		Hash:   hash,
+		Height: height,
+		Time:   time,
+	}
+}
+
+// StakeEventsNtfn defines the stakeevents JSON-RPC notification.
+type StakeEventsNtfn struct {
+	BlockHash   string
+	BlockHeight int64
+	Matured     []string
+	Voted       []string
+	Missed      []string
+	Expired     []string
+	Revoked     []string
+}
+
+// NewStakeEventsNtfn returns a new instance which can be used to issue a
+// stakeevents JSON-RPC notification.
+func NewStakeEventsNtfn(hash string, height int64, matured, voted, missed,
+	expired, revoked []string) *StakeEventsNtfn {
+
+	return &StakeEventsNtfn{
+		BlockHash:   hash,
+		BlockHeight: height,
+		Matured:     matured,
+		Voted:       voted,
+		Missed:      missed,
+		Expired:     expired,
+		Revoked:     revoked,
+	}
+}
+
+// NotificationsDroppedNtfn defines the notificationsdropped JSON-RPC
+// notification.
+type NotificationsDroppedNtfn struct {
+	QueuedBytes    int64
+	MaxQueuedBytes int64
+}
+
+// NewNotificationsDroppedNtfn returns a new instance which can be used to
+// issue a notificationsdropped JSON-RPC notification.
+func NewNotificationsDroppedNtfn(queuedBytes, maxQueuedBytes int64) *NotificationsDroppedNtfn {
+	return &NotificationsDroppedNtfn{
+		QueuedBytes:    queuedBytes,
+		MaxQueuedBytes: maxQueuedBytes,
+	}
+}
+
 func init() {
 	// The commands in this file are only usable by websockets and are
 	// notifications.
@@ -218,8 +343,13 @@ func init() {
 	dcrjson.MustRegister(TSpendNtfnMethod, (*TSpendNtfn)(nil), flags)
 	dcrjson.MustRegister(NewTicketsNtfnMethod, (*NewTicketsNtfn)(nil), flags)
 	dcrjson.MustRegister(ReorganizationNtfnMethod, (*ReorganizationNtfn)(nil), flags)
+	dcrjson.MustRegister(DeepReorgPausedNtfnMethod, (*DeepReorgPausedNtfn)(nil), flags)
+	dcrjson.MustRegister(VoteLeaderChangedNtfnMethod, (*VoteLeaderChangedNtfn)(nil), flags)
 	dcrjson.MustRegister(TxAcceptedNtfnMethod, (*TxAcceptedNtfn)(nil), flags)
 	dcrjson.MustRegister(TxAcceptedVerboseNtfnMethod, (*TxAcceptedVerboseNtfn)(nil), flags)
 	dcrjson.MustRegister(RelevantTxAcceptedNtfnMethod, (*RelevantTxAcceptedNtfn)(nil), flags)
 	dcrjson.MustRegister(WinningTicketsNtfnMethod, (*WinningTicketsNtfn)(nil), flags)
+	dcrjson.MustRegister(RescanProgressNtfnMethod, (*RescanProgressNtfn)(nil), flags)
+	dcrjson.MustRegister(StakeEventsNtfnMethod, (*StakeEventsNtfn)(nil), flags)
+	dcrjson.MustRegister(NotificationsDroppedNtfnMethod, (*NotificationsDroppedNtfn)(nil), flags)
 }
@@ -1,5 +1,5 @@
 // Copyright (c) 2014 The btcsuite developers
-// Copyright (c) 2015-2021 The Decred developers
+// Copyright (c) 2015-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -56,6 +56,23 @@ func TestChainSvrWsNtfns(t *testing.T) {
 				Header: "header",
 			},
 		},
+		{
+			name: "deepreorgpaused",
+			newNtfn: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("deepreorgpaused"), "123", 100, "456", 98, 90)
+			},
+			staticNtfn: func() interface{} {
+				return NewDeepReorgPausedNtfn("123", 100, "456", 98, 90)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"deepreorgpaused","params":["123",100,"456",98,90],"id":null}`,
+			unmarshalled: &DeepReorgPausedNtfn{
+				TipHash:      "123",
+				TipHeight:    100,
+				TargetHash:   "456",
+				TargetHeight: 98,
+				ForkHeight:   90,
+			},
+		},
 		{
 			name: "newtickets",
 			newNtfn: func() (interface{}, error) {
@@ -72,6 +89,21 @@ func TestChainSvrWsNtfns(t *testing.T) {
 				Tickets:   []string{"a", "b"},
 			},
 		},
+		{
+			name: "notificationsdropped",
+			newNtfn: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("notificationsdropped"), 1048577,
+					1048576)
+			},
+			staticNtfn: func() interface{} {
+				return NewNotificationsDroppedNtfn(1048577, 1048576)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"notificationsdropped","params":[1048577,1048576],"id":null}`,
+			unmarshalled: &NotificationsDroppedNtfn{
+				QueuedBytes:    1048577,
+				MaxQueuedBytes: 1048576,
+			},
+		},
 		{
 			name: "relevanttxaccepted",
 			newNtfn: func() (interface{}, error) {
@@ -85,6 +117,43 @@ func TestChainSvrWsNtfns(t *testing.T) {
 				Transaction: "001122",
 			},
 		},
+		{
+			name: "rescanprogress",
+			newNtfn: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("rescanprogress"), "123", 100, 1306533807)
+			},
+			staticNtfn: func() interface{} {
+				return NewRescanProgressNtfn("123", 100, 1306533807)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"rescanprogress","params":["123",100,1306533807],"id":null}`,
+			unmarshalled: &RescanProgressNtfn{
+				Hash:   "123",
+				Height: 100,
+				Time:   1306533807,
+			},
+		},
+		{
+			name: "stakeevents",
+			newNtfn: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("stakeevents"), "123", 100,
+					[]string{"a"}, []string{"b"}, []string{}, []string{},
+					[]string{"c"})
+			},
+			staticNtfn: func() interface{} {
+				return NewStakeEventsNtfn("123", 100, []string{"a"},
+					[]string{"b"}, []string{}, []string{}, []string{"c"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"stakeevents","params":["123",100,["a"],["b"],[],[],["c"]],"id":null}`,
+			unmarshalled: &StakeEventsNtfn{
+				BlockHash:   "123",
+				BlockHeight: 100,
+				Matured:     []string{"a"},
+				Voted:       []string{"b"},
+				Missed:      []string{},
+				Expired:     []string{},
+				Revoked:     []string{"c"},
+			},
+		},
 		{
 			name: "txaccepted",
 			newNtfn: func() (interface{}, error) {
@@ -129,6 +198,21 @@ func TestChainSvrWsNtfns(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "voteleaderchanged",
+			newNtfn: func() (interface{}, error) {
+				return dcrjson.NewCmd(Method("voteleaderchanged"), 100, "123", "456")
+			},
+			staticNtfn: func() interface{} {
+				return NewVoteLeaderChangedNtfn(100, "123", "456")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"voteleaderchanged","params":[100,"123","456"],"id":null}`,
+			unmarshalled: &VoteLeaderChangedNtfn{
+				Height:    100,
+				OldLeader: "123",
+				NewLeader: "456",
+			},
+		},
 		{
 			name: "winningtickets",
 			newNtfn: func() (interface{}, error) {
@@ -10,6 +10,14 @@ type SessionResult struct {
 	SessionID uint64 `json:"sessionid"`
 }
 
+// GetTxFilterResult models the data from the gettxfilter command.
+type GetTxFilterResult struct {
+	Addresses          []string   `json:"addresses"`
+	OutPoints          []OutPoint `json:"outpoints"`
+	Scripts            []string   `json:"scripts"`
+	ScriptHashPrefixes []string   `json:"scripthashprefixes"`
+}
+
 // RescanResult models the result object returned by the rescan RPC.
 type RescanResult struct {
 	DiscoveredData []RescannedBlock `json:"discovereddata"`
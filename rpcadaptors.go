@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"net"
+	"sync/atomic"
 	"time"
 
 	"github.com/decred/dcrd/chaincfg/chainhash"
@@ -21,6 +22,7 @@ import (
 	"github.com/decred/dcrd/internal/netsync"
 	"github.com/decred/dcrd/internal/rpcserver"
 	"github.com/decred/dcrd/peer/v3"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
 	"github.com/decred/dcrd/wire"
 )
 
@@ -220,6 +222,15 @@ func (cm *rpcConnManager) NetTotals() (uint64, uint64) {
 	return cm.server.NetTotals()
 }
 
+// AgentFilterTotals returns the total number of peers rejected and evicted due
+// to their user agent matching the configured patterns.
+//
+// This function is safe for concurrent access and is part of the
+// rpcserver.ConnManager interface implementation.
+func (cm *rpcConnManager) AgentFilterTotals() (uint64, uint64) {
+	return cm.server.AgentFilterTotals()
+}
+
 // ConnectedPeers returns an array consisting of all connected peers.
 //
 // This function is safe for concurrent access and is part of the
@@ -264,8 +275,8 @@ func (cm *rpcConnManager) BroadcastMessage(msg wire.Message) {
 }
 
 // AddRebroadcastInventory adds the provided inventory to the list of
-// inventories to be rebroadcast at random intervals until they show up in a
-// block.
+// inventories to be rebroadcast with exponential backoff until they show up in
+// a block or expire.
 //
 // This function is safe for concurrent access and is part of the
 // rpcserver.ConnManager interface implementation.
@@ -273,6 +284,50 @@ func (cm *rpcConnManager) AddRebroadcastInventory(iv *wire.InvVect, data interfa
 	cm.server.AddRebroadcastInventory(iv, data)
 }
 
+// RebroadcastTransactions returns descriptions of all transactions that are
+// being rebroadcast ordered by the time they were added.
+//
+// This function is safe for concurrent access and is part of the
+// rpcserver.ConnManager interface implementation.
+func (cm *rpcConnManager) RebroadcastTransactions() []rpcserver.RebroadcastTx {
+	infos := cm.server.RebroadcastInventory()
+	txns := make([]rpcserver.RebroadcastTx, 0, len(infos))
+	for i := range infos {
+		info := &infos[i]
+		if info.invVect.Type != wire.InvTypeTx {
+			continue
+		}
+		txns = append(txns, rpcserver.RebroadcastTx{
+			Hash:          info.invVect.Hash,
+			Added:         info.added,
+			LastBroadcast: info.lastBroadcast,
+			NextBroadcast: info.nextBroadcast,
+			Broadcasts:    info.broadcasts,
+		})
+	}
+	return txns
+}
+
+// AbandonRebroadcastTransaction stops rebroadcasting the transaction with the
+// provided hash and returns whether or not it was being rebroadcast.
+//
+// This function is safe for concurrent access and is part of the
+// rpcserver.ConnManager interface implementation.
+func (cm *rpcConnManager) AbandonRebroadcastTransaction(hash *chainhash.Hash) bool {
+	iv := wire.NewInvVect(wire.InvTypeTx, hash)
+	return cm.server.AbandonRebroadcastInventory(iv)
+}
+
+// PruneRebroadcastInventory removes inventory that no longer needs to be
+// rebroadcast, such as expired transactions, without waiting for the next
+// block.
+//
+// This function is safe for concurrent access and is part of the
+// rpcserver.ConnManager interface implementation.
+func (cm *rpcConnManager) PruneRebroadcastInventory() {
+	cm.server.PruneRebroadcastInventory()
+}
+
 // RelayTransactions generates and relays inventory vectors for all of the
 // passed transactions to all connected peers.
 //
@@ -428,6 +483,63 @@ func (*rpcClock) Since(t time.Time) time.Duration {
 	return time.Since(t)
 }
 
+// rpcMockTimeSource wraps a median time source to allow the network-adjusted
+// time to be overridden via the RPC server for testing purposes and implements
+// the rpcserver.MockTimeSource interface.  It also implements the
+// blockchain.MedianTimeSource interface so that it may be used in place of the
+// wrapped time source.
+type rpcMockTimeSource struct {
+	// mockTime is the overridden time in seconds since the unix epoch or zero
+	// when the time is not overridden.  It must be accessed atomically.
+	mockTime int64
+
+	blockchain.MedianTimeSource
+}
+
+// Ensure rpcMockTimeSource implements the rpcserver.MockTimeSource and
+// blockchain.MedianTimeSource interfaces.
+var _ rpcserver.MockTimeSource = (*rpcMockTimeSource)(nil)
+var _ blockchain.MedianTimeSource = (*rpcMockTimeSource)(nil)
+
+// SetMockTime overrides the network-adjusted time with the provided time until
+// it is called again.  The zero time removes the override.
+//
+// This function is safe for concurrent access and is part of the
+// rpcserver.MockTimeSource interface implementation.
+func (m *rpcMockTimeSource) SetMockTime(t time.Time) {
+	var mockTime int64
+	if !t.IsZero() {
+		mockTime = t.Unix()
+	}
+	atomic.StoreInt64(&m.mockTime, mockTime)
+}
+
+// AdjustedTime returns the overridden time when it is set or the current time
+// adjusted by the median time offset of the wrapped time source otherwise.
+//
+// This function is safe for concurrent access and is part of the
+// blockchain.MedianTimeSource interface implementation.
+func (m *rpcMockTimeSource) AdjustedTime() time.Time {
+	if mockTime := atomic.LoadInt64(&m.mockTime); mockTime != 0 {
+		return time.Unix(mockTime, 0)
+	}
+	return m.MedianTimeSource.AdjustedTime()
+}
+
+// Offset returns the difference between the overridden time and the local
+// clock when it is set or the median time offset of the wrapped time source
+// otherwise.
+//
+// This function is safe for concurrent access and is part of the
+// blockchain.MedianTimeSource interface implementation.
+func (m *rpcMockTimeSource) Offset() time.Duration {
+	if mockTime := atomic.LoadInt64(&m.mockTime); mockTime != 0 {
+		offset := time.Until(time.Unix(mockTime, 0))
+		return offset.Truncate(time.Second)
+	}
+	return m.MedianTimeSource.Offset()
+}
+
 // rpcLogManager provides a log manager for use with the RPC server and
 // implements the rpcserver.LogManager interface.
 type rpcLogManager struct{}
@@ -452,6 +564,25 @@ func (*rpcLogManager) ParseAndSetDebugLevels(debugLevel string) error {
 	return parseAndSetDebugLevels(debugLevel)
 }
 
+// rpcConfigReloader provides a config reloader for use with the RPC server and
+// implements the rpcserver.ConfigReloader interface.
+type rpcConfigReloader struct {
+	server *server
+}
+
+// Ensure rpcConfigReloader implements the rpcserver.ConfigReloader interface.
+var _ rpcserver.ConfigReloader = (*rpcConfigReloader)(nil)
+
+// ReloadConfig reloads the configuration options that can safely be changed at
+// runtime and applies them.  It returns a description of each option that
+// changed.
+//
+// This function is safe for concurrent access and is part of the
+// rpcserver.ConfigReloader interface implementation.
+func (r *rpcConfigReloader) ReloadConfig() ([]string, error) {
+	return r.server.reloadConfig()
+}
+
 // rpcSanityChecker provides a block sanity checker for use with the RPC and
 // implements the rpcserver.SanityChecker interface.
 type rpcSanityChecker struct {
@@ -507,6 +638,17 @@ func (c *rpcCPUMiner) GenerateNBlocks(ctx context.Context, n uint32) ([]*chainha
 	return c.miner.GenerateNBlocks(ctx, n)
 }
 
+// GenerateNBlocksToAddress generates the requested number of blocks with
+// coinbases that pay to the provided address.
+func (c *rpcCPUMiner) GenerateNBlocksToAddress(ctx context.Context, n uint32, payToAddr stdaddr.Address) ([]*chainhash.Hash, error) {
+	if c.miner == nil {
+		return nil, errors.New("Block generation is disallowed without a " +
+			"CPU miner.")
+	}
+
+	return c.miner.GenerateNBlocksToAddress(ctx, n, payToAddr)
+}
+
 // IsMining returns whether or not the CPU miner has been started and is
 // therefore currently mining.
 func (c *rpcCPUMiner) IsMining() bool {
@@ -0,0 +1,219 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/wire"
+)
+
+// DefaultBlockRangeLookahead is the maximum number of blocks a BlockRange
+// iterator requests ahead of the block most recently returned to the caller.
+const DefaultBlockRangeLookahead = 16
+
+// ErrBlockRangeReorg describes the condition where the blocks returned by the
+// server for a block range no longer connect to each other, which typically
+// means the main chain was reorganized while iterating the range.
+var ErrBlockRangeReorg = errors.New("block range is no longer part of the " +
+	"main chain")
+
+// blockRangeFetch houses the in-flight requests for a single block of a block
+// range.  The block is requested once the hash of the block at the height is
+// known.
+type blockRangeFetch struct {
+	height  int64
+	hashFut *FutureGetBlockHashResult
+	hash    *chainhash.Hash
+	block   *FutureGetBlockResult
+}
+
+// BlockRangeIterator iterates the raw blocks in a range of main chain heights
+// in order of increasing height.  It pipelines the requests for the blocks so
+// that up to a bounded number of blocks are requested ahead of the block most
+// recently returned to the caller.  No further requests are made until the
+// caller advances the iterator, which provides natural backpressure.  Note
+// that clients running in HTTP POST mode issue requests one at a time, so the
+// requests are only processed concurrently by the server when connected via
+// websockets.
+//
+// Typical usage:
+//
+//	iter := client.BlockRange(ctx, start, end)
+//	defer iter.Close()
+//	for iter.Next() {
+//		block := iter.Block()
+//		...
+//	}
+//	if err := iter.Err(); err != nil {
+//		...
+//	}
+//
+// The iterator is not safe for concurrent access.
+type BlockRangeIterator struct {
+	c         *Client
+	ctx       context.Context
+	nextReq   int64
+	end       int64
+	lookahead int
+	pending   []*blockRangeFetch
+
+	height   int64
+	hash     *chainhash.Hash
+	block    *wire.MsgBlock
+	prevHash *chainhash.Hash
+	err      error
+}
+
+// BlockRange returns an iterator over the raw blocks in the main chain from
+// the start height through the end height, inclusive, that requests up to
+// DefaultBlockRangeLookahead blocks ahead of the caller.
+//
+// See BlockRangeIterator for more details.
+func (c *Client) BlockRange(ctx context.Context, start, end int64) *BlockRangeIterator {
+	return c.BlockRangeWithLookahead(ctx, start, end,
+		DefaultBlockRangeLookahead)
+}
+
+// BlockRangeWithLookahead returns an iterator over the raw blocks in the main
+// chain from the start height through the end height, inclusive, that
+// requests up to the provided number of blocks ahead of the caller.  A
+// lookahead less than one is treated as one.
+//
+// See BlockRangeIterator for more details.
+func (c *Client) BlockRangeWithLookahead(ctx context.Context, start, end int64, lookahead int) *BlockRangeIterator {
+	if lookahead < 1 {
+		lookahead = 1
+	}
+	iter := &BlockRangeIterator{
+		c:         c,
+		ctx:       ctx,
+		nextReq:   start,
+		end:       end,
+		lookahead: lookahead,
+		pending:   make([]*blockRangeFetch, 0, lookahead),
+		height:    start - 1,
+	}
+	if start < 0 {
+		iter.err = fmt.Errorf("invalid block range start height %d", start)
+	}
+	return iter
+}
+
+// fill requests the hashes of the blocks following the most recently
+// requested one until the lookahead is reached and then requests the blocks
+// for all pending hashes.  The hash requests are all issued before waiting on
+// any of them so the blocks are requested after roughly a single round trip.
+func (it *BlockRangeIterator) fill() error {
+	for len(it.pending) < it.lookahead && it.nextReq <= it.end {
+		it.pending = append(it.pending, &blockRangeFetch{
+			height:  it.nextReq,
+			hashFut: it.c.GetBlockHashAsync(it.ctx, it.nextReq),
+		})
+		it.nextReq++
+	}
+	for _, fetch := range it.pending {
+		if fetch.block != nil {
+			continue
+		}
+		hash, err := fetch.hashFut.Receive()
+		if err != nil {
+			return fmt.Errorf("unable to get hash of block %d: %w",
+				fetch.height, err)
+		}
+		fetch.hash = hash
+		fetch.block = it.c.GetBlockAsync(it.ctx, hash)
+	}
+	return nil
+}
+
+// Next advances the iterator to the next block in the range and returns
+// whether or not there is one.  It returns false once all blocks in the range
+// have been returned or an error is encountered, in which case Err returns the
+// error.
+//
+// ErrBlockRangeReorg is returned via Err when a block does not connect to the
+// previous block returned by the iterator.
+func (it *BlockRangeIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if err := it.fill(); err != nil {
+		it.fail(err)
+		return false
+	}
+	if len(it.pending) == 0 {
+		it.block = nil
+		return false
+	}
+
+	fetch := it.pending[0]
+	it.pending[0] = nil
+	it.pending = it.pending[1:]
+	block, err := fetch.block.Receive()
+	if err != nil {
+		it.fail(fmt.Errorf("unable to get block %v (height %d): %w",
+			fetch.hash, fetch.height, err))
+		return false
+	}
+
+	// Ensure the block connects to the previous one since the main chain
+	// might have been reorganized in between the requests.
+	header := &block.Header
+	if int64(header.Height) != fetch.height ||
+		(it.prevHash != nil && header.PrevBlock != *it.prevHash) {
+
+		it.fail(fmt.Errorf("%w: block %v at height %d does not connect to "+
+			"block %v", ErrBlockRangeReorg, fetch.hash, fetch.height,
+			it.prevHash))
+		return false
+	}
+
+	it.height = fetch.height
+	it.hash = fetch.hash
+	it.block = block
+	it.prevHash = fetch.hash
+	return true
+}
+
+// fail records the provided error and discards any pending requests.
+func (it *BlockRangeIterator) fail(err error) {
+	it.err = err
+	it.block = nil
+	it.pending = nil
+}
+
+// Height returns the height of the block most recently returned by Next.
+func (it *BlockRangeIterator) Height() int64 {
+	return it.height
+}
+
+// Hash returns the hash of the block most recently returned by Next.
+func (it *BlockRangeIterator) Hash() *chainhash.Hash {
+	return it.hash
+}
+
+// Block returns the block most recently returned by Next.  It is nil before
+// the first call to Next and once Next returns false.
+func (it *BlockRangeIterator) Block() *wire.MsgBlock {
+	return it.block
+}
+
+// Err returns the first error encountered by the iterator, if any.
+func (it *BlockRangeIterator) Err() error {
+	return it.err
+}
+
+// Close stops the iterator and discards any pending requests.  Responses to
+// requests that are already in flight are ignored.  Calling Next after Close
+// returns false.  Close does not modify the error returned by Err.
+func (it *BlockRangeIterator) Close() {
+	it.pending = nil
+	it.nextReq = it.end + 1
+	it.block = nil
+}
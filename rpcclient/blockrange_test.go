@@ -0,0 +1,207 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/wire"
+)
+
+// mockBlockServer provides a mock RPC server that serves the getblockhash and
+// getblock methods for a main chain that may be replaced by tests.
+type mockBlockServer struct {
+	mtx    sync.Mutex
+	chain  []*wire.MsgBlock
+	onHash func(height int64)
+}
+
+// mockChain returns a chain of blocks with the provided number of blocks where
+// the nonce of each block is set to the provided value.
+func mockChain(numBlocks int, nonce uint32) []*wire.MsgBlock {
+	chain := make([]*wire.MsgBlock, 0, numBlocks)
+	var prevHash chainhash.Hash
+	for i := 0; i < numBlocks; i++ {
+		block := &wire.MsgBlock{Header: wire.BlockHeader{
+			PrevBlock: prevHash,
+			Height:    uint32(i),
+			Nonce:     nonce,
+		}}
+		chain = append(chain, block)
+		prevHash = block.BlockHash()
+	}
+	return chain
+}
+
+// ServeHTTP responds to the getblockhash and getblock methods from the
+// current mock chain.
+func (s *mockBlockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result interface{}
+	var rpcErr interface{}
+	switch req.Method {
+	case "getblockhash":
+		var height int64
+		json.Unmarshal(req.Params[0], &height)
+		s.mtx.Lock()
+		onHash := s.onHash
+		s.mtx.Unlock()
+		if onHash != nil {
+			onHash(height)
+		}
+		s.mtx.Lock()
+		if height < int64(len(s.chain)) {
+			result = s.chain[height].BlockHash().String()
+		} else {
+			rpcErr = map[string]interface{}{
+				"code":    -1,
+				"message": "block number out of range",
+			}
+		}
+		s.mtx.Unlock()
+
+	case "getblock":
+		var hashStr string
+		json.Unmarshal(req.Params[0], &hashStr)
+		s.mtx.Lock()
+		for _, block := range s.chain {
+			if block.BlockHash().String() != hashStr {
+				continue
+			}
+			var buf strings.Builder
+			if err := block.Serialize(hex.NewEncoder(&buf)); err != nil {
+				s.mtx.Unlock()
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			result = buf.String()
+		}
+		s.mtx.Unlock()
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"id":     req.ID,
+		"result": result,
+		"error":  rpcErr,
+	})
+}
+
+// TestBlockRange ensures the block range iterator returns the expected blocks
+// in order and detects when the blocks no longer connect.
+func TestBlockRange(t *testing.T) {
+	mockServer := &mockBlockServer{chain: mockChain(20, 0)}
+	server := httptest.NewServer(mockServer)
+	defer server.Close()
+
+	client, err := New(&ConnConfig{
+		Host:         strings.TrimPrefix(server.URL, "http://"),
+		HTTPPostMode: true,
+		DisableTLS:   true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+	defer client.Shutdown()
+
+	// Ensure all blocks in the range are returned in order for several
+	// lookahead values.
+	ctx := context.Background()
+	for _, lookahead := range []int{0, 1, 3, 16, 100} {
+		iter := client.BlockRangeWithLookahead(ctx, 5, 14, lookahead)
+		wantHeight := int64(5)
+		for iter.Next() {
+			want := mockServer.chain[wantHeight]
+			if iter.Height() != wantHeight {
+				t.Fatalf("lookahead %d: mismatched height -- got %d, want %d",
+					lookahead, iter.Height(), wantHeight)
+			}
+			if *iter.Hash() != want.BlockHash() {
+				t.Fatalf("lookahead %d: mismatched hash -- got %v, want %v",
+					lookahead, iter.Hash(), want.BlockHash())
+			}
+			if iter.Block().BlockHash() != want.BlockHash() {
+				t.Fatalf("lookahead %d: mismatched block -- got %v, want %v",
+					lookahead, iter.Block().BlockHash(), want.BlockHash())
+			}
+			wantHeight++
+		}
+		if err := iter.Err(); err != nil {
+			t.Fatalf("lookahead %d: unexpected error: %v", lookahead, err)
+		}
+		if wantHeight != 15 {
+			t.Fatalf("lookahead %d: iterated through height %d, want 14",
+				lookahead, wantHeight-1)
+		}
+		iter.Close()
+	}
+
+	// Ensure an empty range does not return any blocks.
+	iter := client.BlockRange(ctx, 5, 4)
+	if iter.Next() || iter.Err() != nil {
+		t.Fatalf("empty range: unexpected block or error %v", iter.Err())
+	}
+
+	// Ensure a negative start height is rejected.
+	iter = client.BlockRange(ctx, -1, 4)
+	if iter.Next() || iter.Err() == nil {
+		t.Fatal("negative start height: expected error")
+	}
+
+	// Ensure heights beyond the chain result in an error.
+	iter = client.BlockRange(ctx, 18, 25)
+	for iter.Next() {
+	}
+	if iter.Err() == nil {
+		t.Fatal("range beyond chain: expected error")
+	}
+
+	// Ensure closing the iterator stops it.
+	iter = client.BlockRange(ctx, 0, 19)
+	if !iter.Next() {
+		t.Fatalf("unexpected error: %v", iter.Err())
+	}
+	iter.Close()
+	if iter.Next() || iter.Err() != nil {
+		t.Fatalf("closed iterator: unexpected block or error %v", iter.Err())
+	}
+
+	// Ensure replacing the chain while iterating is detected.  The chain is
+	// replaced once the hash for a block beyond the lookahead of the first
+	// block is requested.
+	iter = client.BlockRangeWithLookahead(ctx, 0, 19, 4)
+	mockServer.mtx.Lock()
+	mockServer.onHash = func(height int64) {
+		if height == 8 {
+			mockServer.mtx.Lock()
+			mockServer.chain = mockChain(20, 1)
+			mockServer.mtx.Unlock()
+		}
+	}
+	mockServer.mtx.Unlock()
+	for iter.Next() {
+	}
+	if !errors.Is(iter.Err(), ErrBlockRangeReorg) {
+		t.Fatalf("replaced chain: mismatched error -- got %v, want %v",
+			iter.Err(), ErrBlockRangeReorg)
+	}
+}
@@ -433,6 +433,102 @@ func (c *Client) GetBlockHeaderVerbose(ctx context.Context, hash *chainhash.Hash
 	return c.GetBlockHeaderVerboseAsync(ctx, hash).Receive()
 }
 
+// FutureGetBlockHeadersResult is a future promise to deliver the result of a
+// GetBlockHeadersAsync RPC invocation (or an applicable error).
+type FutureGetBlockHeadersResult cmdRes
+
+// Receive waits for the response promised by the future and returns the
+// block headers requested from the server.
+func (r *FutureGetBlockHeadersResult) Receive() ([]*wire.BlockHeader, error) {
+	res, err := receiveFuture(r.ctx, r.c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal the result as a getheaders result object.
+	var hr chainjson.GetHeadersResult
+	err = json.Unmarshal(res, &hr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deserialize the block headers and return them.
+	headers := make([]*wire.BlockHeader, 0, len(hr.Headers))
+	for _, bhHex := range hr.Headers {
+		serializedBH, err := hex.DecodeString(bhHex)
+		if err != nil {
+			return nil, err
+		}
+		var bh wire.BlockHeader
+		err = bh.Deserialize(bytes.NewReader(serializedBH))
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, &bh)
+	}
+	return headers, nil
+}
+
+// GetBlockHeadersAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See GetBlockHeaders for the blocking version and more details.
+func (c *Client) GetBlockHeadersAsync(ctx context.Context, startHeight int64, count uint32) *FutureGetBlockHeadersResult {
+	cmd := chainjson.NewGetBlockHeadersCmd(startHeight, &count,
+		dcrjson.Bool(false))
+	return (*FutureGetBlockHeadersResult)(c.sendCmd(ctx, cmd))
+}
+
+// GetBlockHeaders returns up to the given number of contiguous block headers
+// in the main chain starting at the given height.
+//
+// See GetBlockHeadersVerbose to retrieve data structures of the block headers
+// instead.
+func (c *Client) GetBlockHeaders(ctx context.Context, startHeight int64, count uint32) ([]*wire.BlockHeader, error) {
+	return c.GetBlockHeadersAsync(ctx, startHeight, count).Receive()
+}
+
+// FutureGetBlockHeadersVerboseResult is a future promise to deliver the result
+// of a GetBlockHeadersVerboseAsync RPC invocation (or an applicable error).
+type FutureGetBlockHeadersVerboseResult cmdRes
+
+// Receive waits for the response promised by the future and returns data
+// structures of the block headers requested from the server.
+func (r *FutureGetBlockHeadersVerboseResult) Receive() ([]chainjson.GetBlockHeaderVerboseResult, error) {
+	res, err := receiveFuture(r.ctx, r.c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal the result
+	var bhs []chainjson.GetBlockHeaderVerboseResult
+	err = json.Unmarshal(res, &bhs)
+	if err != nil {
+		return nil, err
+	}
+	return bhs, nil
+}
+
+// GetBlockHeadersVerboseAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetBlockHeadersVerbose for the blocking version and more details.
+func (c *Client) GetBlockHeadersVerboseAsync(ctx context.Context, startHeight int64, count uint32) *FutureGetBlockHeadersVerboseResult {
+	cmd := chainjson.NewGetBlockHeadersCmd(startHeight, &count,
+		dcrjson.Bool(true))
+	return (*FutureGetBlockHeadersVerboseResult)(c.sendCmd(ctx, cmd))
+}
+
+// GetBlockHeadersVerbose returns data structures of up to the given number of
+// contiguous block headers in the main chain starting at the given height.
+//
+// See GetBlockHeaders to retrieve the raw block headers instead.
+func (c *Client) GetBlockHeadersVerbose(ctx context.Context, startHeight int64, count uint32) ([]chainjson.GetBlockHeaderVerboseResult, error) {
+	return c.GetBlockHeadersVerboseAsync(ctx, startHeight, count).Receive()
+}
+
 // FutureGetBlockSubsidyResult is a future promise to deliver the result of a
 // GetBlockSubsidyAsync RPC invocation (or an applicable error).
 type FutureGetBlockSubsidyResult cmdRes
@@ -498,7 +594,7 @@ func (r *FutureGetCoinSupplyResult) Receive() (dcrutil.Amount, error) {
 //
 // See GetCoinSupply for the blocking version and more details.
 func (c *Client) GetCoinSupplyAsync(ctx context.Context) *FutureGetCoinSupplyResult {
-	cmd := chainjson.NewGetCoinSupplyCmd()
+	cmd := chainjson.NewGetCoinSupplyCmd(nil)
 	return (*FutureGetCoinSupplyResult)(c.sendCmd(ctx, cmd))
 }
 
@@ -507,6 +603,44 @@ func (c *Client) GetCoinSupply(ctx context.Context) (dcrutil.Amount, error) {
 	return c.GetCoinSupplyAsync(ctx).Receive()
 }
 
+// FutureGetCoinSupplyVerboseResult is a future promise to deliver the result
+// of a GetCoinSupplyVerboseAsync RPC invocation (or an applicable error).
+type FutureGetCoinSupplyVerboseResult cmdRes
+
+// Receive waits for the response promised by the future and returns the
+// current coin supply along with the max supply permitted by the subsidy
+// schedule.
+func (r *FutureGetCoinSupplyVerboseResult) Receive() (*chainjson.GetCoinSupplyVerboseResult, error) {
+	res, err := receiveFuture(r.ctx, r.c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal the result
+	var result chainjson.GetCoinSupplyVerboseResult
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetCoinSupplyVerboseAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetCoinSupplyVerbose for the blocking version and more details.
+func (c *Client) GetCoinSupplyVerboseAsync(ctx context.Context) *FutureGetCoinSupplyVerboseResult {
+	cmd := chainjson.NewGetCoinSupplyCmd(dcrjson.Bool(true))
+	return (*FutureGetCoinSupplyVerboseResult)(c.sendCmd(ctx, cmd))
+}
+
+// GetCoinSupplyVerbose returns the current coin supply along with the max
+// supply permitted by the subsidy schedule as of the current best block.
+func (c *Client) GetCoinSupplyVerbose(ctx context.Context) (*chainjson.GetCoinSupplyVerboseResult, error) {
+	return c.GetCoinSupplyVerboseAsync(ctx).Receive()
+}
+
 // FutureGetRawMempoolResult is a future promise to deliver the result of a
 // GetRawMempoolAsync RPC invocation (or an applicable error).
 type FutureGetRawMempoolResult cmdRes
@@ -546,7 +680,7 @@ func (r *FutureGetRawMempoolResult) Receive() ([]*chainhash.Hash, error) {
 // See GetRawMempool for the blocking version and more details.
 func (c *Client) GetRawMempoolAsync(ctx context.Context, txType chainjson.GetRawMempoolTxTypeCmd) *FutureGetRawMempoolResult {
 	cmd := chainjson.NewGetRawMempoolCmd(dcrjson.Bool(false),
-		dcrjson.String(string(txType)))
+		dcrjson.String(string(txType)), nil, nil, nil, nil)
 	return (*FutureGetRawMempoolResult)(c.sendCmd(ctx, cmd))
 }
 
@@ -589,7 +723,7 @@ func (r *FutureGetRawMempoolVerboseResult) Receive() (map[string]chainjson.GetRa
 // See GetRawMempoolVerbose for the blocking version and more details.
 func (c *Client) GetRawMempoolVerboseAsync(ctx context.Context, txType chainjson.GetRawMempoolTxTypeCmd) *FutureGetRawMempoolVerboseResult {
 	cmd := chainjson.NewGetRawMempoolCmd(dcrjson.Bool(true),
-		dcrjson.String(string(txType)))
+		dcrjson.String(string(txType)), nil, nil, nil, nil)
 	return (*FutureGetRawMempoolVerboseResult)(c.sendCmd(ctx, cmd))
 }
 
@@ -602,6 +736,45 @@ func (c *Client) GetRawMempoolVerbose(ctx context.Context, txType chainjson.GetR
 	return c.GetRawMempoolVerboseAsync(ctx, txType).Receive()
 }
 
+// FutureGetRawMempoolSummaryResult is a future promise to deliver the result of
+// a GetRawMempoolSummaryAsync RPC invocation (or an applicable error).
+type FutureGetRawMempoolSummaryResult cmdRes
+
+// Receive waits for the response promised by the future and returns the
+// transaction counts and sizes for the transactions in the memory pool.
+func (r *FutureGetRawMempoolSummaryResult) Receive() (*chainjson.GetRawMempoolSummaryResult, error) {
+	res, err := receiveFuture(r.ctx, r.c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal the result as a getrawmempool summary result object.
+	var summary chainjson.GetRawMempoolSummaryResult
+	err = json.Unmarshal(res, &summary)
+	if err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// GetRawMempoolSummaryAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetRawMempoolSummary for the blocking version and more details.
+func (c *Client) GetRawMempoolSummaryAsync(ctx context.Context, txType chainjson.GetRawMempoolTxTypeCmd) *FutureGetRawMempoolSummaryResult {
+	cmd := chainjson.NewGetRawMempoolCmd(nil, dcrjson.String(string(txType)),
+		nil, nil, nil, dcrjson.Bool(true))
+	return (*FutureGetRawMempoolSummaryResult)(c.sendCmd(ctx, cmd))
+}
+
+// GetRawMempoolSummary returns the total number and size of the transactions
+// in the memory pool for the given txType along with a breakdown by
+// transaction type.
+func (c *Client) GetRawMempoolSummary(ctx context.Context, txType chainjson.GetRawMempoolTxTypeCmd) (*chainjson.GetRawMempoolSummaryResult, error) {
+	return c.GetRawMempoolSummaryAsync(ctx, txType).Receive()
+}
+
 // FutureValidateAddressResult is a future promise to deliver the result of a
 // ValidateAddressAsync RPC invocation (or an applicable error).
 type FutureValidateAddressResult cmdRes
@@ -855,6 +1028,53 @@ func (c *Client) GetTxOut(ctx context.Context, txHash *chainhash.Hash, index uin
 	return c.GetTxOutAsync(ctx, txHash, index, tree, mempool).Receive()
 }
 
+// FutureGetTxOutHistoryResult is a future promise to deliver the result of a
+// GetTxOutHistoryAsync RPC invocation (or an applicable error).
+type FutureGetTxOutHistoryResult cmdRes
+
+// Receive waits for the response promised by the future and returns the
+// details and history of the transaction output as of the requested height.
+func (r *FutureGetTxOutHistoryResult) Receive() (*chainjson.GetTxOutHistoryResult, error) {
+	res, err := receiveFuture(r.ctx, r.c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a gettxouthistory result object.
+	var history chainjson.GetTxOutHistoryResult
+	err = json.Unmarshal(res, &history)
+	if err != nil {
+		return nil, err
+	}
+
+	return &history, nil
+}
+
+// GetTxOutHistoryAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See GetTxOutHistory for the blocking version and more details.
+func (c *Client) GetTxOutHistoryAsync(ctx context.Context, txHash *chainhash.Hash, index uint32, height int64) *FutureGetTxOutHistoryResult {
+	hash := ""
+	if txHash != nil {
+		hash = txHash.String()
+	}
+
+	cmd := chainjson.NewGetTxOutHistoryCmd(hash, index, &height)
+	return (*FutureGetTxOutHistoryResult)(c.sendCmd(ctx, cmd))
+}
+
+// GetTxOutHistory returns the details of the provided transaction output along
+// with its state and the history of events that changed its state in the main
+// chain as of the provided height.  A negative height uses the current best
+// chain block height.
+//
+// NOTE: This requires the server to have the utxo history index enabled.
+func (c *Client) GetTxOutHistory(ctx context.Context, txHash *chainhash.Hash, index uint32, height int64) (*chainjson.GetTxOutHistoryResult, error) {
+	return c.GetTxOutHistoryAsync(ctx, txHash, index, height).Receive()
+}
+
 // FutureRescanResult is a future promise to deliver the result of a
 // RescanAsynnc RPC invocation (or an applicable error).
 type FutureRescanResult cmdRes
@@ -56,6 +56,11 @@ the Receive method on the returned instance will either return the result
 immediately if it has already arrived, or block until it has.  This is useful
 since it provides the caller with greater control over concurrency.
 
+The BlockRange method builds on the asynchronous API to provide an iterator
+over a range of main chain blocks that requests a bounded number of blocks
+ahead of the caller.  This is useful for applications that need to process
+large numbers of blocks in order, such as when backfilling a block explorer.
+
 # Notifications
 
 The first important part of notifications is to realize that they will only
@@ -174,6 +179,9 @@ detect if a command is unimplemented by the remote RPC server:
 		// from the remote RPC server.
 	}
 
+The error codes defined by the dcrjson package are guaranteed to remain stable
+across releases, so it is safe to switch on them as shown above.
+
 # Example Usage
 
 The following full-blown client examples are in the examples directory:
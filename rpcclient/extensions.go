@@ -651,7 +651,7 @@ func (r *FutureGetVoteInfoResult) Receive() (*chainjson.GetVoteInfoResult, error
 //
 // NOTE: This is a dcrd extension.
 func (c *Client) GetVoteInfoAsync(ctx context.Context, version uint32) *FutureGetVoteInfoResult {
-	cmd := chainjson.NewGetVoteInfoCmd(version)
+	cmd := chainjson.NewGetVoteInfoCmd(version, nil)
 	return (*FutureGetVoteInfoResult)(c.sendCmd(ctx, cmd))
 }
 
@@ -663,6 +663,28 @@ func (c *Client) GetVoteInfo(ctx context.Context, version uint32) (*chainjson.Ge
 	return c.GetVoteInfoAsync(ctx, version).Receive()
 }
 
+// GetVoteInfoAtHeightAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetVoteInfoAtHeight for the blocking version and more details.
+//
+// NOTE: This is a dcrd extension.
+func (c *Client) GetVoteInfoAtHeightAsync(ctx context.Context, version uint32, height int64) *FutureGetVoteInfoResult {
+	cmd := chainjson.NewGetVoteInfoCmd(version, &height)
+	return (*FutureGetVoteInfoResult)(c.sendCmd(ctx, cmd))
+}
+
+// GetVoteInfoAtHeight returns voting information for the specified stake
+// version over the voting window that contains the provided main chain block
+// height.  This includes the voting window, quorum, total votes and the
+// agendas along with their status and vote tallies during that window.
+//
+// NOTE: This is a dcrd extension.
+func (c *Client) GetVoteInfoAtHeight(ctx context.Context, version uint32, height int64) (*chainjson.GetVoteInfoResult, error) {
+	return c.GetVoteInfoAtHeightAsync(ctx, version, height).Receive()
+}
+
 // FutureLiveTicketsResult is a future promise to deliver the result
 // of a FutureLiveTicketsResultAsync RPC invocation (or an applicable error).
 type FutureLiveTicketsResult cmdRes
@@ -14,6 +14,7 @@ import (
 	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/dcrutil/v4"
 	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
 )
 
 // FutureGenerateResult is a future promise to deliver the result of a
@@ -63,6 +64,22 @@ func (c *Client) Generate(ctx context.Context, numBlocks uint32) ([]*chainhash.H
 	return c.GenerateAsync(ctx, numBlocks).Receive()
 }
 
+// GenerateToAddressAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See GenerateToAddress for the blocking version and more details.
+func (c *Client) GenerateToAddressAsync(ctx context.Context, numBlocks uint32, address stdaddr.Address) *FutureGenerateResult {
+	cmd := chainjson.NewGenerateToAddressCmd(numBlocks, address.String())
+	return (*FutureGenerateResult)(c.sendCmd(ctx, cmd))
+}
+
+// GenerateToAddress generates numBlocks blocks with coinbases that pay to the
+// provided address and returns their hashes.
+func (c *Client) GenerateToAddress(ctx context.Context, numBlocks uint32, address stdaddr.Address) ([]*chainhash.Hash, error) {
+	return c.GenerateToAddressAsync(ctx, numBlocks, address).Receive()
+}
+
 // FutureGetGenerateResult is a future promise to deliver the result of a
 // GetGenerateAsync RPC invocation (or an applicable error).
 type FutureGetGenerateResult cmdRes
@@ -322,3 +322,42 @@ func (c *Client) GetNetworkInfoAsync(ctx context.Context) *FutureGetNetworkInfoR
 func (c *Client) GetNetworkInfo(ctx context.Context) (*chainjson.GetNetworkInfoResult, error) {
 	return c.GetNetworkInfoAsync(ctx).Receive()
 }
+
+// FutureGetNodeInfoResult is a future promise to deliver the result of a
+// GetNodeInfo RPC invocation (or an applicable error).
+type FutureGetNodeInfoResult cmdRes
+
+// Receive waits for the response promised by the future and returns the
+// version, build, uptime, and configuration details of the underlying node
+// instance.
+func (r *FutureGetNodeInfoResult) Receive() (*chainjson.GetNodeInfoResult, error) {
+	res, err := receiveFuture(r.ctx, r.c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a getnodeinfo result object.
+	var nodeInfo chainjson.GetNodeInfoResult
+	err = json.Unmarshal(res, &nodeInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nodeInfo, nil
+}
+
+// GetNodeInfoAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetNodeInfo for the blocking version and more details.
+func (c *Client) GetNodeInfoAsync(ctx context.Context) *FutureGetNodeInfoResult {
+	cmd := chainjson.NewGetNodeInfoCmd()
+	return (*FutureGetNodeInfoResult)(c.sendCmd(ctx, cmd))
+}
+
+// GetNodeInfo returns the version, build, uptime, and configuration details of
+// the underlying node.
+func (c *Client) GetNodeInfo(ctx context.Context) (*chainjson.GetNodeInfoResult, error) {
+	return c.GetNodeInfoAsync(ctx).Receive()
+}
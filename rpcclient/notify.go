@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/decred/dcrd/blockchain/stake/v5"
 	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/dcrutil/v4"
 	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
@@ -118,6 +119,20 @@ type NotificationHandlers struct {
 	OnReorganization func(oldHash *chainhash.Hash, oldHeight int32,
 		newHash *chainhash.Hash, newHeight int32)
 
+	// OnDeepReorgPaused is invoked when a blockchain reorganization is paused
+	// because it exceeds the maximum automatic reorganization depth of the
+	// server.  It will only be invoked if a preceding call to NotifyBlocks
+	// has been made to register for the notification and the function is
+	// non-nil.
+	OnDeepReorgPaused func(ntfn *chainjson.DeepReorgPausedNtfn)
+
+	// OnVoteLeaderChanged is invoked when a new vote in the mempool of the
+	// server causes a different block to become the most favorable one to
+	// build on among the competing blocks at a given height.  It will only be
+	// invoked if a preceding call to NotifyWork has been made to register for
+	// the notification and the function is non-nil.
+	OnVoteLeaderChanged func(ntfn *chainjson.VoteLeaderChangedNtfn)
+
 	// OnWinningTickets is invoked when a block is connected and eligible tickets
 	// to be voted on for this chain are given.  It will only be invoked if a
 	// preceding call to NotifyWinningTickets has been made to register for the
@@ -147,6 +162,20 @@ type NotificationHandlers struct {
 	// made to register for the notification and the function is non-nil.
 	OnTxAcceptedVerbose func(txDetails *chainjson.TxRawResult)
 
+	// OnTicketAccepted is invoked when a ticket purchase transaction is
+	// accepted into the memory pool.  It delivers the decoded details of the
+	// ticket.  It will only be invoked if a preceding call to
+	// NotifyNewTransactions with the verbose flag set to true has been made
+	// to register for the notification and the function is non-nil.
+	OnTicketAccepted func(ticket *TicketInfo)
+
+	// OnVoteAccepted is invoked when a vote transaction is accepted into the
+	// memory pool.  It delivers the decoded details of the vote.  It will
+	// only be invoked if a preceding call to NotifyNewTransactions with the
+	// verbose flag set to true has been made to register for the
+	// notification and the function is non-nil.
+	OnVoteAccepted func(vote *VoteInfo)
+
 	// OnUnknownNotification is invoked when an unrecognized notification
 	// is received.  This typically means the notification handling code
 	// for this package needs to be updated for a new notification type or
@@ -269,6 +298,40 @@ func (c *Client) handleNotification(ntfn *rawNotification) {
 
 		c.ntfnHandlers.OnReorganization(oldHash, oldHeight, newHash, newHeight)
 
+	// OnDeepReorgPaused
+	case chainjson.DeepReorgPausedNtfnMethod:
+		// Ignore the notification if the client is not interested in
+		// it.
+		if c.ntfnHandlers.OnDeepReorgPaused == nil {
+			return
+		}
+
+		ntfn, err := parseDeepReorgPausedNtfnParams(ntfn.Params)
+		if err != nil {
+			log.Warnf("Received invalid deep reorg paused "+
+				"notification: %v", err)
+			return
+		}
+
+		c.ntfnHandlers.OnDeepReorgPaused(ntfn)
+
+	// OnVoteLeaderChanged
+	case chainjson.VoteLeaderChangedNtfnMethod:
+		// Ignore the notification if the client is not interested in
+		// it.
+		if c.ntfnHandlers.OnVoteLeaderChanged == nil {
+			return
+		}
+
+		ntfn, err := parseVoteLeaderChangedNtfnParams(ntfn.Params)
+		if err != nil {
+			log.Warnf("Received invalid vote leader changed "+
+				"notification: %v", err)
+			return
+		}
+
+		c.ntfnHandlers.OnVoteLeaderChanged(ntfn)
+
 	// OnWinningTickets
 	case chainjson.WinningTicketsNtfnMethod:
 		// Ignore the notification if the client is not interested in
@@ -329,7 +392,10 @@ func (c *Client) handleNotification(ntfn *rawNotification) {
 	case chainjson.TxAcceptedVerboseNtfnMethod:
 		// Ignore the notification if the client is not interested in
 		// it.
-		if c.ntfnHandlers.OnTxAcceptedVerbose == nil {
+		if c.ntfnHandlers.OnTxAcceptedVerbose == nil &&
+			c.ntfnHandlers.OnTicketAccepted == nil &&
+			c.ntfnHandlers.OnVoteAccepted == nil {
+
 			return
 		}
 
@@ -340,7 +406,10 @@ func (c *Client) handleNotification(ntfn *rawNotification) {
 			return
 		}
 
-		c.ntfnHandlers.OnTxAcceptedVerbose(rawTx)
+		if c.ntfnHandlers.OnTxAcceptedVerbose != nil {
+			c.ntfnHandlers.OnTxAcceptedVerbose(rawTx)
+		}
+		c.handleStakeTxAccepted(rawTx)
 
 	default:
 		if c.ntfnHandlers.OnUnknownNotification == nil {
@@ -460,6 +529,42 @@ func parseRelevantTxAcceptedParams(params []json.RawMessage) (transaction []byte
 	return parseHexParam(params[0])
 }
 
+// parseDeepReorgPausedNtfnParams parses out the details of a paused deep
+// reorganization from the parameters of a deepreorgpaused notification.
+func parseDeepReorgPausedNtfnParams(params []json.RawMessage) (*chainjson.DeepReorgPausedNtfn, error) {
+	if len(params) != 5 {
+		return nil, wrongNumParams(len(params))
+	}
+
+	var ntfn chainjson.DeepReorgPausedNtfn
+	fields := []interface{}{&ntfn.TipHash, &ntfn.TipHeight, &ntfn.TargetHash,
+		&ntfn.TargetHeight, &ntfn.ForkHeight}
+	for i, field := range fields {
+		if err := json.Unmarshal(params[i], field); err != nil {
+			return nil, err
+		}
+	}
+	return &ntfn, nil
+}
+
+// parseVoteLeaderChangedNtfnParams parses out the height along with the
+// previous and new most favorable blocks to build on from the parameters of a
+// voteleaderchanged notification.
+func parseVoteLeaderChangedNtfnParams(params []json.RawMessage) (*chainjson.VoteLeaderChangedNtfn, error) {
+	if len(params) != 3 {
+		return nil, wrongNumParams(len(params))
+	}
+
+	var ntfn chainjson.VoteLeaderChangedNtfn
+	fields := []interface{}{&ntfn.Height, &ntfn.OldLeader, &ntfn.NewLeader}
+	for i, field := range fields {
+		if err := json.Unmarshal(params[i], field); err != nil {
+			return nil, err
+		}
+	}
+	return &ntfn, nil
+}
+
 func parseReorganizationNtfnParams(params []json.RawMessage) (*chainhash.Hash,
 	int32, *chainhash.Hash, int32, error) {
 	errorOut := func(err error) (*chainhash.Hash, int32, *chainhash.Hash,
@@ -689,6 +794,51 @@ func parseTxAcceptedVerboseNtfnParams(params []json.RawMessage) (*chainjson.TxRa
 	return &rawTx, nil
 }
 
+// handleStakeTxAccepted decodes the transaction from the provided verbose tx
+// accepted notification details and invokes the OnTicketAccepted or
+// OnVoteAccepted handler when it is a ticket purchase or vote, respectively, and
+// the associated handler is non-nil.
+func (c *Client) handleStakeTxAccepted(rawTx *chainjson.TxRawResult) {
+	if c.ntfnHandlers.OnTicketAccepted == nil &&
+		c.ntfnHandlers.OnVoteAccepted == nil {
+
+		return
+	}
+
+	serializedTx, err := hex.DecodeString(rawTx.Hex)
+	if err != nil {
+		log.Warnf("Received invalid tx accepted verbose notification: %v",
+			err)
+		return
+	}
+	var tx wire.MsgTx
+	if err := tx.FromBytes(serializedTx); err != nil {
+		log.Warnf("Received invalid tx accepted verbose notification: %v",
+			err)
+		return
+	}
+
+	switch {
+	case c.ntfnHandlers.OnTicketAccepted != nil && stake.IsSStx(&tx):
+		ticket, err := DecodeTicket(&tx)
+		if err != nil {
+			log.Warnf("Unable to decode accepted ticket %s: %v",
+				rawTx.Txid, err)
+			return
+		}
+		c.ntfnHandlers.OnTicketAccepted(ticket)
+
+	case c.ntfnHandlers.OnVoteAccepted != nil && stake.IsSSGen(&tx):
+		vote, err := DecodeVote(&tx)
+		if err != nil {
+			log.Warnf("Unable to decode accepted vote %s: %v",
+				rawTx.Txid, err)
+			return
+		}
+		c.ntfnHandlers.OnVoteAccepted(vote)
+	}
+}
+
 // FutureNotifyBlocksResult is a future promise to deliver the result of a
 // NotifyBlocksAsync RPC invocation (or an applicable error).
 type FutureNotifyBlocksResult cmdRes
@@ -966,7 +1116,8 @@ func (c *Client) NotifyNewTransactionsAsync(ctx context.Context, verbose bool) *
 //
 // The notifications delivered as a result of this call will be via one of
 // OnTxAccepted (when verbose is false) or OnTxAcceptedVerbose (when verbose is
-// true).
+// true).  Ticket purchases and votes are additionally delivered as decoded
+// details via OnTicketAccepted and OnVoteAccepted when verbose is true.
 //
 // NOTE: This is a dcrd extension and requires a websocket connection.
 func (c *Client) NotifyNewTransactions(ctx context.Context, verbose bool) error {
@@ -63,7 +63,7 @@ func (c *Client) GetRawTransactionAsync(ctx context.Context, txHash *chainhash.H
 		hash = txHash.String()
 	}
 
-	cmd := chainjson.NewGetRawTransactionCmd(hash, dcrjson.Int(0))
+	cmd := chainjson.NewGetRawTransactionCmd(hash, dcrjson.Int(0), nil)
 	return (*FutureGetRawTransactionResult)(c.sendCmd(ctx, cmd))
 }
 
@@ -109,7 +109,7 @@ func (c *Client) GetRawTransactionVerboseAsync(ctx context.Context, txHash *chai
 		hash = txHash.String()
 	}
 
-	cmd := chainjson.NewGetRawTransactionCmd(hash, dcrjson.Int(1))
+	cmd := chainjson.NewGetRawTransactionCmd(hash, dcrjson.Int(1), nil)
 	return (*FutureGetRawTransactionVerboseResult)(c.sendCmd(ctx, cmd))
 }
 
@@ -121,6 +121,124 @@ func (c *Client) GetRawTransactionVerbose(ctx context.Context, txHash *chainhash
 	return c.GetRawTransactionVerboseAsync(ctx, txHash).Receive()
 }
 
+// FutureGetRawTransactionsResult is a future promise to deliver the result of
+// a GetRawTransactionsAsync RPC invocation (or an applicable error).
+type FutureGetRawTransactionsResult cmdRes
+
+// Receive waits for the response promised by the future and returns the
+// transactions given their hashes in the same order they were requested.
+func (r *FutureGetRawTransactionsResult) Receive() ([]*dcrutil.Tx, error) {
+	res, err := receiveFuture(r.ctx, r.c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of strings.
+	var txHexes []string
+	err = json.Unmarshal(res, &txHexes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode and deserialize the transactions.
+	txns := make([]*dcrutil.Tx, 0, len(txHexes))
+	for _, txHex := range txHexes {
+		serializedTx, err := hex.DecodeString(txHex)
+		if err != nil {
+			return nil, err
+		}
+		var msgTx wire.MsgTx
+		if err := msgTx.Deserialize(bytes.NewReader(serializedTx)); err != nil {
+			return nil, err
+		}
+		txns = append(txns, dcrutil.NewTx(&msgTx))
+	}
+	return txns, nil
+}
+
+// rawTransactionsCmdArgs returns the string forms of the provided transaction
+// hashes and optional block hash.
+func rawTransactionsCmdArgs(txHashes []*chainhash.Hash, blockHash *chainhash.Hash) ([]string, *string) {
+	hashes := make([]string, 0, len(txHashes))
+	for _, txHash := range txHashes {
+		hashes = append(hashes, txHash.String())
+	}
+	var blockHashStr *string
+	if blockHash != nil {
+		blockHashStr = dcrjson.String(blockHash.String())
+	}
+	return hashes, blockHashStr
+}
+
+// GetRawTransactionsAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See GetRawTransactions for the blocking version and more details.
+func (c *Client) GetRawTransactionsAsync(ctx context.Context, txHashes []*chainhash.Hash, blockHash *chainhash.Hash) *FutureGetRawTransactionsResult {
+	hashes, blockHashStr := rawTransactionsCmdArgs(txHashes, blockHash)
+	cmd := chainjson.NewGetRawTransactionsCmd(hashes, dcrjson.Int(0),
+		blockHashStr)
+	return (*FutureGetRawTransactionsResult)(c.sendCmd(ctx, cmd))
+}
+
+// GetRawTransactions returns multiple transactions given their hashes in the
+// same order they were requested.  The block hash is optional and, when
+// provided, the transactions are looked up in that block which does not
+// require the server to have the transaction index enabled.
+//
+// See GetRawTransactionsVerbose to obtain additional information about the
+// transactions.
+func (c *Client) GetRawTransactions(ctx context.Context, txHashes []*chainhash.Hash, blockHash *chainhash.Hash) ([]*dcrutil.Tx, error) {
+	return c.GetRawTransactionsAsync(ctx, txHashes, blockHash).Receive()
+}
+
+// FutureGetRawTransactionsVerboseResult is a future promise to deliver the
+// result of a GetRawTransactionsVerboseAsync RPC invocation (or an applicable
+// error).
+type FutureGetRawTransactionsVerboseResult cmdRes
+
+// Receive waits for the response promised by the future and returns
+// information about multiple transactions given their hashes in the same order
+// they were requested.
+func (r *FutureGetRawTransactionsVerboseResult) Receive() ([]chainjson.TxRawResult, error) {
+	res, err := receiveFuture(r.ctx, r.c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of getrawtransaction result objects.
+	var rawTxResults []chainjson.TxRawResult
+	err = json.Unmarshal(res, &rawTxResults)
+	if err != nil {
+		return nil, err
+	}
+
+	return rawTxResults, nil
+}
+
+// GetRawTransactionsVerboseAsync returns an instance of a type that can be used
+// to get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetRawTransactionsVerbose for the blocking version and more details.
+func (c *Client) GetRawTransactionsVerboseAsync(ctx context.Context, txHashes []*chainhash.Hash, blockHash *chainhash.Hash) *FutureGetRawTransactionsVerboseResult {
+	hashes, blockHashStr := rawTransactionsCmdArgs(txHashes, blockHash)
+	cmd := chainjson.NewGetRawTransactionsCmd(hashes, dcrjson.Int(1),
+		blockHashStr)
+	return (*FutureGetRawTransactionsVerboseResult)(c.sendCmd(ctx, cmd))
+}
+
+// GetRawTransactionsVerbose returns information about multiple transactions
+// given their hashes in the same order they were requested.  The block hash is
+// optional and, when provided, the transactions are looked up in that block
+// which does not require the server to have the transaction index enabled.
+//
+// See GetRawTransactions to obtain only the transactions already deserialized.
+func (c *Client) GetRawTransactionsVerbose(ctx context.Context, txHashes []*chainhash.Hash, blockHash *chainhash.Hash) ([]chainjson.TxRawResult, error) {
+	return c.GetRawTransactionsVerboseAsync(ctx, txHashes, blockHash).Receive()
+}
+
 // FutureDecodeRawTransactionResult is a future promise to deliver the result
 // of a DecodeRawTransactionAsync RPC invocation (or an applicable error).
 type FutureDecodeRawTransactionResult cmdRes
@@ -394,3 +512,53 @@ func (c *Client) SendRawTransactionAsync(ctx context.Context, tx *wire.MsgTx, al
 func (c *Client) SendRawTransaction(ctx context.Context, tx *wire.MsgTx, allowHighFees bool) (*chainhash.Hash, error) {
 	return c.SendRawTransactionAsync(ctx, tx, allowHighFees).Receive()
 }
+
+// FutureTestMempoolAcceptResult is a future promise to deliver the result of a
+// TestMempoolAcceptAsync RPC invocation (or an applicable error).
+type FutureTestMempoolAcceptResult cmdRes
+
+// Receive waits for the response promised by the future and returns whether or
+// not each of the transactions would be accepted into the mempool of the
+// server.
+func (r *FutureTestMempoolAcceptResult) Receive() ([]chainjson.TestMempoolAcceptResult, error) {
+	res, err := receiveFuture(r.ctx, r.c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of test mempool accept result objects.
+	var results []chainjson.TestMempoolAcceptResult
+	err = json.Unmarshal(res, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// TestMempoolAcceptAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See TestMempoolAccept for the blocking version and more details.
+func (c *Client) TestMempoolAcceptAsync(ctx context.Context, txns []*wire.MsgTx, allowHighFees bool) *FutureTestMempoolAcceptResult {
+	txHexes := make([]string, 0, len(txns))
+	for _, tx := range txns {
+		// Serialize the transaction and convert to hex string.
+		buf := bytes.NewBuffer(make([]byte, 0, tx.SerializeSize()))
+		if err := tx.Serialize(buf); err != nil {
+			return (*FutureTestMempoolAcceptResult)(newFutureError(ctx, err))
+		}
+		txHexes = append(txHexes, hex.EncodeToString(buf.Bytes()))
+	}
+
+	cmd := chainjson.NewTestMempoolAcceptCmd(txHexes, &allowHighFees)
+	return (*FutureTestMempoolAcceptResult)(c.sendCmd(ctx, cmd))
+}
+
+// TestMempoolAccept returns whether or not each of the passed transactions
+// would be accepted into the mempool of the server without actually submitting
+// them.  The results are in the same order as the passed transactions.
+func (c *Client) TestMempoolAccept(ctx context.Context, txns []*wire.MsgTx, allowHighFees bool) ([]chainjson.TestMempoolAcceptResult, error) {
+	return c.TestMempoolAcceptAsync(ctx, txns, allowHighFees).Receive()
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpcclient
+
+import (
+	"github.com/decred/dcrd/blockchain/stake/v5"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+// TicketInfo houses decoded details about a ticket purchase transaction.
+type TicketInfo struct {
+	// Hash is the hash of the ticket purchase transaction.
+	Hash chainhash.Hash
+
+	// Price is the amount paid for the ticket, which is the value of the
+	// voting rights output.
+	Price dcrutil.Amount
+
+	// Expiry is the expiry height of the transaction.
+	Expiry uint32
+
+	// Commitments houses the reward commitments of the ticket in output
+	// order.
+	Commitments []stake.TicketCommitment
+}
+
+// VoteInfo houses decoded details about a vote transaction.
+type VoteInfo struct {
+	// Hash is the hash of the vote transaction.
+	Hash chainhash.Hash
+
+	// TicketHash is the hash of the ticket spent by the vote.
+	TicketHash chainhash.Hash
+
+	// BlockHash and BlockHeight identify the block the vote is voting on.
+	BlockHash   chainhash.Hash
+	BlockHeight uint32
+
+	// VoteBits are the bits that specify the choices of the vote, including
+	// whether or not the regular transaction tree of the block voted on is
+	// approved.
+	VoteBits uint16
+
+	// Version is the stake version of the vote.
+	Version uint32
+
+	// TreasuryVotes houses the votes on treasury spend transactions, if
+	// any.
+	TreasuryVotes []stake.TreasuryVoteTuple
+}
+
+// ApprovesParent returns whether or not the vote approves the regular
+// transaction tree of the block it is voting on.
+func (v *VoteInfo) ApprovesParent() bool {
+	return v.VoteBits&0x0001 != 0
+}
+
+// Choices returns the choices of the vote keyed by the identifier of the agenda
+// they apply to for all agendas defined for the vote version by the provided
+// network parameters.  Agendas for which the vote bits do not match any of the
+// defined choices are not included.
+func (v *VoteInfo) Choices(params *chaincfg.Params) map[string]string {
+	deployments := params.Deployments[v.Version]
+	choices := make(map[string]string, len(deployments))
+	for i := range deployments {
+		vote := &deployments[i].Vote
+		bits := v.VoteBits & vote.Mask
+		for j := range vote.Choices {
+			if vote.Choices[j].Bits == bits {
+				choices[vote.Id] = vote.Choices[j].Id
+				break
+			}
+		}
+	}
+	return choices
+}
+
+// DecodeTicket decodes the details of the provided ticket purchase transaction.
+// An error is returned when the transaction is not a valid ticket purchase.
+func DecodeTicket(tx *wire.MsgTx) (*TicketInfo, error) {
+	commitments, err := stake.ExtractTicketCommitments(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TicketInfo{
+		Hash:        tx.TxHash(),
+		Price:       dcrutil.Amount(tx.TxOut[0].Value),
+		Expiry:      tx.Expiry,
+		Commitments: commitments,
+	}, nil
+}
+
+// DecodeVote decodes the details of the provided vote transaction.  An error is
+// returned when the transaction is not a valid vote.
+func DecodeVote(tx *wire.MsgTx) (*VoteInfo, error) {
+	treasuryVotes, err := stake.CheckSSGenVotes(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockHash, blockHeight := stake.SSGenBlockVotedOn(tx)
+	return &VoteInfo{
+		Hash:          tx.TxHash(),
+		TicketHash:    tx.TxIn[1].PreviousOutPoint.Hash,
+		BlockHash:     blockHash,
+		BlockHeight:   blockHeight,
+		VoteBits:      stake.SSGenVoteBits(tx),
+		Version:       stake.SSGenVersion(tx),
+		TreasuryVotes: treasuryVotes,
+	}, nil
+}
@@ -3,7 +3,9 @@
 // interface. Each instance of an active harness comes equipped with a simple
 // in-memory HD wallet capable of properly syncing to the generated chain,
 // creating new addresses, and crafting fully signed transactions paying to an
-// arbitrary set of outputs.
+// arbitrary set of outputs.  Alternative wallet implementations may be used
+// instead by providing an implementation of the WalletController interface to
+// NewWithWallet.
 //
 // This package was designed specifically to act as an RPC testing harness for
 // `dcrd`. However, the constructs presented are general enough to be adapted to
@@ -0,0 +1,201 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpctest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/decred/dcrd/peer/v3"
+	"github.com/decred/dcrd/wire"
+)
+
+// FakePeer is a scriptable P2P peer that is connected to a harness node and is
+// intended for use in tests that exercise the P2P protocol handling of the
+// node, such as tests for protocol violations and DoS handling.
+//
+// It is built on the peer package, so it automatically performs the version
+// handshake and responds to pings, however, it does not otherwise react to
+// any messages it receives.  Instead, all received messages are recorded so
+// tests are able to send arbitrary messages and assert on the responses.
+type FakePeer struct {
+	*peer.Peer
+
+	conn      net.Conn
+	verAckCh  chan struct{}
+	quit      chan struct{}
+	mtx       sync.Mutex
+	received  []wire.Message
+	newMsgsCh chan struct{}
+}
+
+// onRead is invoked whenever the fake peer reads a message from the harness
+// node.  It records the message and notifies any waiters.
+func (p *FakePeer) onRead(_ *peer.Peer, _ int, msg wire.Message, err error) {
+	if err != nil || msg == nil {
+		return
+	}
+
+	p.mtx.Lock()
+	p.received = append(p.received, msg)
+	newMsgsCh := p.newMsgsCh
+	p.newMsgsCh = make(chan struct{})
+	p.mtx.Unlock()
+	close(newMsgsCh)
+}
+
+// ConnectFakePeer connects a new fake peer to the P2P address of the harness
+// node and blocks until the version handshake completes or the provided
+// context is done.
+//
+// The fake peer advertises the provided services and the latest protocol
+// version supported by the wire package.
+func (h *Harness) ConnectFakePeer(ctx context.Context, services wire.ServiceFlag) (*FakePeer, error) {
+	fp := &FakePeer{
+		verAckCh:  make(chan struct{}),
+		quit:      make(chan struct{}),
+		newMsgsCh: make(chan struct{}),
+	}
+	var verAckOnce sync.Once
+	cfg := &peer.Config{
+		Listeners: peer.MessageListeners{
+			OnVerAck: func(*peer.Peer, *wire.MsgVerAck) {
+				verAckOnce.Do(func() { close(fp.verAckCh) })
+			},
+			OnRead: fp.onRead,
+		},
+		UserAgentName:    "rpctest-fakepeer",
+		UserAgentVersion: "1.0.0",
+		Net:              h.ActiveNet.Net,
+		Services:         services,
+		ProtocolVersion:  wire.ProtocolVersion,
+	}
+	addr := h.P2PAddress()
+	p, err := peer.NewOutboundPeer(cfg, addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create fake peer: %w", err)
+	}
+	fp.Peer = p
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect fake peer to %s: %w", addr,
+			err)
+	}
+	fp.conn = conn
+	p.AssociateConnection(conn)
+	go func() {
+		p.WaitForDisconnect()
+		close(fp.quit)
+	}()
+
+	select {
+	case <-fp.verAckCh:
+	case <-fp.quit:
+		return nil, errors.New("fake peer disconnected during handshake")
+	case <-ctx.Done():
+		p.Disconnect()
+		return nil, ctx.Err()
+	}
+	return fp, nil
+}
+
+// SendMessage sends the provided message to the harness node and blocks until
+// it has been written or the provided context is done.
+func (p *FakePeer) SendMessage(ctx context.Context, msg wire.Message) error {
+	done := make(chan struct{}, 1)
+	p.QueueMessage(msg, done)
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SendRaw writes the provided raw bytes directly to the underlying connection
+// with the harness node.  This is useful for testing the handling of malformed
+// messages that can't otherwise be created via the wire package.
+//
+// NOTE: Since the bytes bypass the peer, callers must ensure no other messages
+// are in the process of being sent concurrently or the data on the connection
+// will be interleaved.
+func (p *FakePeer) SendRaw(b []byte) error {
+	_, err := p.conn.Write(b)
+	return err
+}
+
+// ReceivedMessages returns all of the messages the fake peer has received
+// from the harness node so far in the order they were received.
+func (p *FakePeer) ReceivedMessages() []wire.Message {
+	p.mtx.Lock()
+	msgs := make([]wire.Message, len(p.received))
+	copy(msgs, p.received)
+	p.mtx.Unlock()
+	return msgs
+}
+
+// WaitForMessage blocks until the fake peer receives a message from the
+// harness node for which the provided match function returns true and returns
+// it.  Messages received prior to calling this function are also considered.
+//
+// An error is returned when the provided context is done or the harness node
+// disconnects the fake peer before a matching message is received.
+func (p *FakePeer) WaitForMessage(ctx context.Context, match func(wire.Message) bool) (wire.Message, error) {
+	var numChecked int
+	var isDisconnected bool
+	for {
+		p.mtx.Lock()
+		for ; numChecked < len(p.received); numChecked++ {
+			msg := p.received[numChecked]
+			if match(msg) {
+				p.mtx.Unlock()
+				return msg, nil
+			}
+		}
+		newMsgsCh := p.newMsgsCh
+		p.mtx.Unlock()
+
+		// No more messages will be received once the peer is disconnected.
+		// Note that the messages are checked once more after noticing the
+		// disconnect to avoid missing any received in the mean time.
+		if isDisconnected {
+			return nil, errors.New("fake peer disconnected")
+		}
+
+		select {
+		case <-newMsgsCh:
+		case <-p.quit:
+			isDisconnected = true
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// WaitForCommand blocks until the fake peer receives a message with the
+// provided command from the harness node and returns it.  See WaitForMessage
+// for details.
+func (p *FakePeer) WaitForCommand(ctx context.Context, cmd string) (wire.Message, error) {
+	return p.WaitForMessage(ctx, func(msg wire.Message) bool {
+		return msg.Command() == cmd
+	})
+}
+
+// WaitForDisconnect blocks until the fake peer is disconnected, which is
+// typically the result of the harness node disconnecting it, or the provided
+// context is done.  It returns an error if the context is done first.
+func (p *FakePeer) WaitForDisconnect(ctx context.Context) error {
+	select {
+	case <-p.quit:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
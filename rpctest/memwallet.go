@@ -134,7 +134,7 @@ func newMemWallet(t *testing.T, net *chaincfg.Params, harnessID uint32) (*memWal
 
 	hdRoot, err := hdkeychain.NewMaster(harnessHDSeed[:], net)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 
 	// The first child key from the hd root is reserved as the coinbase
@@ -176,6 +176,14 @@ func (m *memWallet) Start() {
 	go m.chainSyncer()
 }
 
+// CoinbaseAddress returns the address reserved for receiving newly generated
+// coins.
+//
+// This function is safe for concurrent access.
+func (m *memWallet) CoinbaseAddress() stdaddr.Address {
+	return m.coinbaseAddr
+}
+
 // SyncedHeight returns the height the wallet is known to be synced to.
 //
 // This function is safe for concurrent access.
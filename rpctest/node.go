@@ -35,6 +35,8 @@ type nodeConfig struct {
 	dataDir    string
 	logDir     string
 	profile    string
+	cpuProfile string
+	memProfile string
 	debugLevel string
 	extra      []string
 	prefix     string
@@ -122,6 +124,14 @@ func (n *nodeConfig) arguments() []string {
 		// --profile
 		args = append(args, fmt.Sprintf("--profile=%s", n.profile))
 	}
+	if n.cpuProfile != "" {
+		// --cpuprofile
+		args = append(args, fmt.Sprintf("--cpuprofile=%s", n.cpuProfile))
+	}
+	if n.memProfile != "" {
+		// --memprofile
+		args = append(args, fmt.Sprintf("--memprofile=%s", n.memProfile))
+	}
 	if n.debugLevel != "" {
 		// --debuglevel
 		args = append(args, fmt.Sprintf("--debuglevel=%s", n.debugLevel))
@@ -0,0 +1,318 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpctest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// These constants define the minimum and maximum port numbers used by
+	// the profiling server of a test harness node.  The min port is inclusive
+	// while the max port is exclusive.
+	minProfilePort = maxRPCPort
+	maxProfilePort = 65000
+
+	// cpuProfileName and heapProfileName are the names of the files the node
+	// writes its CPU and heap profiles to when it shuts down.
+	cpuProfileName  = "cpu.pprof"
+	heapProfileName = "heap.pprof"
+)
+
+// ProfilingConfig houses the configuration used to capture profiling data from
+// the dcrd process managed by a Harness.
+type ProfilingConfig struct {
+	// ProfileDir is the directory the node writes its CPU profile, which
+	// covers its entire lifetime, and its heap profile to when it shuts down.
+	// Since the temporary directories of the harness are removed when it is
+	// torn down, it must not be one of them.  Profiles are not written when
+	// it is empty.
+	ProfileDir string
+
+	// Limits are the resource ceilings the runtime metrics of the node are
+	// checked against when the harness is torn down.  No limits are checked
+	// when it is nil.
+	Limits *ResourceLimits
+}
+
+// ResourceLimits defines resource ceilings for the dcrd process managed by a
+// Harness.  A limit of zero is not checked.
+type ResourceLimits struct {
+	// MaxHeapAlloc is the maximum number of bytes of allocated heap objects.
+	MaxHeapAlloc uint64
+
+	// MaxSys is the maximum number of bytes obtained from the OS.
+	MaxSys uint64
+
+	// MaxGoroutines is the maximum number of goroutines.
+	MaxGoroutines int
+}
+
+// RuntimeMetrics houses runtime metrics of the dcrd process managed by a
+// Harness.
+type RuntimeMetrics struct {
+	// HeapAlloc is the number of bytes of allocated heap objects.
+	HeapAlloc uint64
+
+	// HeapInuse is the number of bytes in in-use heap spans.
+	HeapInuse uint64
+
+	// Sys is the total number of bytes obtained from the OS.
+	Sys uint64
+
+	// NumGC is the number of completed GC cycles.
+	NumGC uint64
+
+	// NumGoroutines is the number of goroutines that currently exist.
+	NumGoroutines int
+}
+
+// String returns a human-readable summary of the runtime metrics.
+func (m *RuntimeMetrics) String() string {
+	return fmt.Sprintf("heapalloc=%d heapinuse=%d sys=%d numgc=%d "+
+		"goroutines=%d", m.HeapAlloc, m.HeapInuse, m.Sys, m.NumGC,
+		m.NumGoroutines)
+}
+
+// Check returns an error that describes every provided limit the runtime
+// metrics exceed, if any.
+func (m *RuntimeMetrics) Check(limits *ResourceLimits) error {
+	var exceeded []string
+	if limits.MaxHeapAlloc != 0 && m.HeapAlloc > limits.MaxHeapAlloc {
+		exceeded = append(exceeded, fmt.Sprintf("heap alloc %d > %d",
+			m.HeapAlloc, limits.MaxHeapAlloc))
+	}
+	if limits.MaxSys != 0 && m.Sys > limits.MaxSys {
+		exceeded = append(exceeded, fmt.Sprintf("sys %d > %d", m.Sys,
+			limits.MaxSys))
+	}
+	if limits.MaxGoroutines != 0 && m.NumGoroutines > limits.MaxGoroutines {
+		exceeded = append(exceeded, fmt.Sprintf("goroutines %d > %d",
+			m.NumGoroutines, limits.MaxGoroutines))
+	}
+	if len(exceeded) > 0 {
+		return fmt.Errorf("resource limits exceeded: %s",
+			strings.Join(exceeded, ", "))
+	}
+	return nil
+}
+
+// generateProfileAddress returns the profiling server listening address for
+// the test instance with the provided number in the same manner as
+// generateListeningAddresses.
+func generateProfileAddress(nodeNum int) string {
+	port := minProfilePort + nodeNum + ((20 * processID) %
+		(maxProfilePort - minProfilePort))
+	return net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+}
+
+// EnableProfiling configures the dcrd process managed by the harness to serve
+// profiling data over HTTP so that profiles and runtime metrics may be fetched
+// while it is running and, depending on the provided configuration, to write
+// CPU and heap profiles when it shuts down and to have its runtime metrics
+// checked against resource ceilings when the harness is torn down.
+//
+// The runtime metrics of the node are logged when the harness is torn down.
+//
+// NOTE: This must be called before SetUp.  Also, the node is unable to write
+// its profiles on Windows since it is killed instead of interrupted there.
+func (h *Harness) EnableProfiling(cfg *ProfilingConfig) error {
+	if h.external != nil {
+		return errors.New("profiling is not supported for externally " +
+			"managed nodes")
+	}
+	if h.node.pid != 0 {
+		return errors.New("profiling must be enabled before the harness " +
+			"is set up")
+	}
+
+	if cfg.ProfileDir != "" {
+		if err := os.MkdirAll(cfg.ProfileDir, 0700); err != nil {
+			return err
+		}
+		h.node.config.cpuProfile = filepath.Join(cfg.ProfileDir,
+			cpuProfileName)
+		h.node.config.memProfile = filepath.Join(cfg.ProfileDir,
+			heapProfileName)
+	}
+	h.node.config.profile = generateProfileAddress(h.nodeNum)
+	h.node.cmd = h.node.config.command()
+	h.profiling = cfg
+	return nil
+}
+
+// ProfileAddress returns the address of the profiling server of the harness
+// node or an empty string when profiling is not enabled.
+func (h *Harness) ProfileAddress() string {
+	if h.external != nil {
+		return ""
+	}
+	return h.node.config.profile
+}
+
+// fetchProfile returns the data served by the profiling server of the harness
+// node at the provided path.
+func (h *Harness) fetchProfile(ctx context.Context, path string) ([]byte, error) {
+	profileAddr := h.ProfileAddress()
+	if profileAddr == "" {
+		return nil, errors.New("profiling is not enabled")
+	}
+
+	url := fmt.Sprintf("http://%s/debug/pprof/%s", profileAddr, path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s: %s", path,
+			resp.Status, bytes.TrimSpace(body))
+	}
+	return body, nil
+}
+
+// FetchCPUProfile collects a CPU profile of the harness node for the provided
+// duration, which is rounded up to the nearest second, and returns it in the
+// format expected by the pprof tool.
+//
+// NOTE: Profiling must be enabled via EnableProfiling without a profile
+// directory since the node is only able to collect a single CPU profile at a
+// time and it collects one for its entire lifetime in that case.
+func (h *Harness) FetchCPUProfile(ctx context.Context, duration time.Duration) ([]byte, error) {
+	if h.external == nil && h.node.config.cpuProfile != "" {
+		return nil, errors.New("the node is already writing a CPU profile " +
+			"to the profile directory")
+	}
+
+	seconds := int64((duration + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return h.fetchProfile(ctx, fmt.Sprintf("profile?seconds=%d", seconds))
+}
+
+// FetchHeapProfile returns a heap profile of the harness node in the format
+// expected by the pprof tool.
+//
+// NOTE: Profiling must be enabled via EnableProfiling.
+func (h *Harness) FetchHeapProfile(ctx context.Context) ([]byte, error) {
+	return h.fetchProfile(ctx, "heap")
+}
+
+// FetchRuntimeMetrics returns the current runtime metrics of the harness node.
+//
+// NOTE: Profiling must be enabled via EnableProfiling.
+func (h *Harness) FetchRuntimeMetrics(ctx context.Context) (*RuntimeMetrics, error) {
+	// The debug form of the heap profile ends with the memory statistics of
+	// the runtime in the form "# Name = Value".
+	heap, err := h.fetchProfile(ctx, "heap?debug=1")
+	if err != nil {
+		return nil, err
+	}
+	var metrics RuntimeMetrics
+	fields := map[string]*uint64{
+		"HeapAlloc": &metrics.HeapAlloc,
+		"HeapInuse": &metrics.HeapInuse,
+		"Sys":       &metrics.Sys,
+		"NumGC":     &metrics.NumGC,
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(heap))
+	for scanner.Scan() {
+		line := strings.TrimPrefix(scanner.Text(), "# ")
+		parts := strings.SplitN(line, " = ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		field, ok := fields[parts[0]]
+		if !ok {
+			continue
+		}
+		*field, err = strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed runtime metric %q: %w",
+				parts[0], err)
+		}
+		delete(fields, parts[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(fields) != 0 {
+		return nil, errors.New("heap profile is missing runtime metrics")
+	}
+
+	// The debug form of the goroutine profile starts with a line in the form
+	// "goroutine profile: total N".
+	goroutines, err := h.fetchProfile(ctx, "goroutine?debug=1")
+	if err != nil {
+		return nil, err
+	}
+	const goroutinePrefix = "goroutine profile: total "
+	firstLine := string(goroutines)
+	if i := strings.IndexByte(firstLine, '\n'); i >= 0 {
+		firstLine = firstLine[:i]
+	}
+	if !strings.HasPrefix(firstLine, goroutinePrefix) {
+		return nil, errors.New("goroutine profile is missing total")
+	}
+	total := strings.TrimPrefix(firstLine, goroutinePrefix)
+	metrics.NumGoroutines, err = strconv.Atoi(total)
+	if err != nil {
+		return nil, fmt.Errorf("malformed goroutine total: %w", err)
+	}
+
+	return &metrics, nil
+}
+
+// CheckResourceLimits fetches the current runtime metrics of the harness node
+// and returns an error that describes every provided limit they exceed, if
+// any.
+//
+// NOTE: Profiling must be enabled via EnableProfiling.
+func (h *Harness) CheckResourceLimits(ctx context.Context, limits *ResourceLimits) error {
+	metrics, err := h.FetchRuntimeMetrics(ctx)
+	if err != nil {
+		return err
+	}
+	return metrics.Check(limits)
+}
+
+// captureProfilingData logs the runtime metrics of the harness node and checks
+// them against the configured resource ceilings, if any.  It is called when
+// the harness is torn down while the node is still running.
+func (h *Harness) captureProfilingData() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	metrics, err := h.FetchRuntimeMetrics(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to fetch runtime metrics: %w", err)
+	}
+	logf(h.t, "%d runtime metrics: %v", h.node.pid, metrics)
+	if h.profiling.Limits != nil {
+		return metrics.Check(h.profiling.Limits)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@
 package rpctest
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net"
@@ -79,7 +80,9 @@ type HarnessTestCase func(ctx context.Context, r *Harness, t *testing.T)
 // of the process along with any temporary directories created as a result.
 // Multiple Harness instances may be run concurrently, in order to allow for
 // testing complex scenarios involving multiple nodes. The harness also
-// includes an in-memory wallet to streamline various classes of tests.
+// includes a wallet to streamline various classes of tests.  It defaults to an
+// in-memory wallet, but any implementation of WalletController may be provided
+// via NewWithWallet.
 type Harness struct {
 	// ActiveNet is the parameters of the blockchain the Harness belongs
 	// to.
@@ -89,7 +92,16 @@ type Harness struct {
 	node     *node
 	handlers *rpcclient.NotificationHandlers
 
-	wallet *memWallet
+	wallet WalletController
+
+	// profiling is the profiling configuration of the harness node.  It is
+	// nil when profiling is not enabled.
+	profiling *ProfilingConfig
+
+	// external houses the details of the externally managed node the
+	// harness is attached to.  It is nil when the harness manages its own
+	// node.
+	external *ExistingNodeConfig
 
 	testNodeDir    string
 	maxConnRetries int
@@ -127,6 +139,28 @@ func SetPathToDCRD(fnScopePathToDCRD string) {
 // when calling New with different dcrd executables, as whatever is at
 // pathToDCRD at the time will be identified with that node.
 func New(t *testing.T, activeNet *chaincfg.Params, handlers *rpcclient.NotificationHandlers, extraArgs []string) (*Harness, error) {
+	return newHarness(t, activeNet, handlers, extraArgs, nil)
+}
+
+// NewWithWallet creates and initializes a new instance of the rpc test harness
+// that uses the provided wallet instead of the default in-memory wallet.  It is
+// otherwise identical to New.
+//
+// The harness node is configured to pay all newly generated coins to the
+// coinbase address of the provided wallet and the wallet is notified of all
+// connected and disconnected blocks.
+func NewWithWallet(t *testing.T, activeNet *chaincfg.Params, handlers *rpcclient.NotificationHandlers, extraArgs []string, wallet WalletController) (*Harness, error) {
+	if wallet == nil {
+		return nil, fmt.Errorf("rpctest.NewWithWallet must be called with " +
+			"a non-nil wallet")
+	}
+	return newHarness(t, activeNet, handlers, extraArgs, wallet)
+}
+
+// newHarness creates and initializes a new instance of the rpc test harness
+// that uses the provided wallet.  An in-memory wallet is created when the
+// provided wallet is nil.
+func newHarness(t *testing.T, activeNet *chaincfg.Params, handlers *rpcclient.NotificationHandlers, extraArgs []string, wallet WalletController) (*Harness, error) {
 	harnessStateMtx.Lock()
 	defer harnessStateMtx.Unlock()
 
@@ -159,12 +193,14 @@ func New(t *testing.T, activeNet *chaincfg.Params, handlers *rpcclient.Notificat
 		return nil, err
 	}
 
-	wallet, err := newMemWallet(t, activeNet, uint32(numTestInstances))
-	if err != nil {
-		return nil, err
+	if wallet == nil {
+		wallet, err = newMemWallet(t, activeNet, uint32(numTestInstances))
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	miningAddr := fmt.Sprintf("--miningaddr=%s", wallet.coinbaseAddr)
+	miningAddr := fmt.Sprintf("--miningaddr=%s", wallet.CoinbaseAddress())
 	extraArgs = append(extraArgs, miningAddr)
 
 	config, err := newConfig(nodeTestData, certFile, keyFile, extraArgs)
@@ -186,6 +222,93 @@ func New(t *testing.T, activeNet *chaincfg.Params, handlers *rpcclient.Notificat
 	nodeNum := numTestInstances
 	numTestInstances++ // XXX this really should be the length of the harness map.
 
+	h := &Harness{
+		handlers:       walletNotificationHandlers(handlers, wallet),
+		node:           node,
+		maxConnRetries: 20,
+		testNodeDir:    nodeTestData,
+		ActiveNet:      activeNet,
+		nodeNum:        nodeNum,
+		wallet:         wallet,
+		t:              t,
+	}
+
+	// Track this newly created test instance within the package level
+	// global map of all active test instances.
+	testInstances[h.testNodeDir] = h
+
+	return h, nil
+}
+
+// ExistingNodeConfig houses the details needed to attach a harness to an
+// externally managed dcrd instance via ConnectExisting.
+type ExistingNodeConfig struct {
+	// RPC is the configuration used to connect to the RPC server of the
+	// node.  It must not use HTTP POST mode since the harness relies on
+	// websocket notifications.
+	RPC rpcclient.ConnConfig
+
+	// P2PAddress is the address the node listens on for P2P connections.  It
+	// is optional and only required when the harness is used with functions
+	// that connect nodes, such as ConnectNode.
+	P2PAddress string
+}
+
+// ConnectExisting creates and initializes a new instance of the rpc test
+// harness that is attached to the externally managed dcrd instance described
+// by the provided configuration instead of launching its own node.  This
+// allows the wallet, notification plumbing, and helpers provided by the harness
+// to be reused in environments such as staging where the node is managed
+// separately.
+//
+// The provided wallet is used when it is non-nil.  Otherwise, the harness uses
+// the default in-memory wallet.  Since the harness does not control the mining
+// address of the node, any test chain created by SetUp is generated with
+// coinbases that pay to the wallet via the generatetoaddress RPC.  This means
+// the node must support generating blocks in order to create a test chain.
+//
+// The node is never stopped by the harness and it is not tracked as an active
+// harness, so TearDown only disconnects from it.
+func ConnectExisting(t *testing.T, activeNet *chaincfg.Params, handlers *rpcclient.NotificationHandlers, nodeCfg *ExistingNodeConfig, wallet WalletController) (*Harness, error) {
+	if nodeCfg == nil {
+		return nil, fmt.Errorf("rpctest.ConnectExisting must be called " +
+			"with a non-nil node configuration")
+	}
+	if nodeCfg.RPC.HTTPPostMode {
+		return nil, fmt.Errorf("rpctest.ConnectExisting requires a " +
+			"websocket RPC connection")
+	}
+
+	harnessStateMtx.Lock()
+	defer harnessStateMtx.Unlock()
+
+	if wallet == nil {
+		var err error
+		wallet, err = newMemWallet(t, activeNet, uint32(numTestInstances))
+		if err != nil {
+			return nil, err
+		}
+	}
+	nodeNum := numTestInstances
+	numTestInstances++
+
+	external := *nodeCfg
+	h := &Harness{
+		handlers:       walletNotificationHandlers(handlers, wallet),
+		external:       &external,
+		maxConnRetries: 20,
+		ActiveNet:      activeNet,
+		nodeNum:        nodeNum,
+		wallet:         wallet,
+		t:              t,
+	}
+	return h, nil
+}
+
+// walletNotificationHandlers returns the provided notification handlers
+// modified to also notify the provided wallet of connected and disconnected
+// blocks.  New handlers are created when the provided handlers are nil.
+func walletNotificationHandlers(handlers *rpcclient.NotificationHandlers, wallet WalletController) *rpcclient.NotificationHandlers {
 	if handlers == nil {
 		handlers = &rpcclient.NotificationHandlers{}
 	}
@@ -213,23 +336,7 @@ func New(t *testing.T, activeNet *chaincfg.Params, handlers *rpcclient.Notificat
 	} else {
 		handlers.OnBlockDisconnected = wallet.UnwindBlock
 	}
-
-	h := &Harness{
-		handlers:       handlers,
-		node:           node,
-		maxConnRetries: 20,
-		testNodeDir:    nodeTestData,
-		ActiveNet:      activeNet,
-		nodeNum:        nodeNum,
-		wallet:         wallet,
-		t:              t,
-	}
-
-	// Track this newly created test instance within the package level
-	// global map of all active test instances.
-	testInstances[h.testNodeDir] = h
-
-	return h, nil
+	return handlers
 }
 
 // SetUp initializes the rpc test state. Initialization includes: starting up a
@@ -240,10 +347,13 @@ func New(t *testing.T, activeNet *chaincfg.Params, handlers *rpcclient.Notificat
 // NOTE: This method and TearDown should always be called from the same
 // goroutine as they are not concurrent safe.
 func (h *Harness) SetUp(createTestChain bool, numMatureOutputs uint32) error {
-	// Start the dcrd node itself. This spawns a new process which will be
+	// Start the dcrd node itself unless the harness is attached to an
+	// externally managed node. This spawns a new process which will be
 	// managed
-	if err := h.node.start(); err != nil {
-		return err
+	if h.external == nil {
+		if err := h.node.start(); err != nil {
+			return err
+		}
 	}
 	if err := h.connectRPCClient(); err != nil {
 		return err
@@ -253,13 +363,13 @@ func (h *Harness) SetUp(createTestChain bool, numMatureOutputs uint32) error {
 
 	// Filter transactions that pay to the coinbase associated with the
 	// wallet.
-	filterAddrs := []stdaddr.Address{h.wallet.coinbaseAddr}
+	filterAddrs := []stdaddr.Address{h.wallet.CoinbaseAddress()}
 	if err := h.Node.LoadTxFilter(ctx, true, filterAddrs, nil); err != nil {
 		return err
 	}
 
 	// Ensure dcrd properly dispatches our registered call-back for each new
-	// block. Otherwise, the memWallet won't function properly.
+	// block. Otherwise, the wallet won't function properly.
 	if err := h.Node.NotifyBlocks(ctx); err != nil {
 		return err
 	}
@@ -273,7 +383,17 @@ func (h *Harness) SetUp(createTestChain bool, numMatureOutputs uint32) error {
 		numToGenerate := (uint32(h.ActiveNet.CoinbaseMaturity) +
 			numMatureOutputs) + 1
 		tracef(h.t, "Generate: %v", numToGenerate)
-		_, err := h.Node.Generate(ctx, numToGenerate)
+		var err error
+		if h.external != nil {
+			// The mining address of an external node is not
+			// controlled by the harness, so explicitly pay the
+			// coinbases to the wallet.
+			coinbaseAddr := h.wallet.CoinbaseAddress()
+			_, err = h.Node.GenerateToAddress(ctx, numToGenerate,
+				coinbaseAddr)
+		} else {
+			_, err = h.Node.Generate(ctx, numToGenerate)
+		}
 		if err != nil {
 			return err
 		}
@@ -299,7 +419,8 @@ func (h *Harness) SetUp(createTestChain bool, numMatureOutputs uint32) error {
 }
 
 // TearDown stops the running rpc test instance. All created processes are
-// killed, and temporary directories removed.
+// killed, and temporary directories removed.  Harnesses that are attached to an
+// externally managed node via ConnectExisting only disconnect from it.
 //
 // NOTE: This method and SetUp should always be called from the same goroutine
 // as they are not concurrent safe.
@@ -307,6 +428,22 @@ func (h *Harness) TearDown() error {
 	tracef(h.t, "TearDown %p %p", h.Node, h.node)
 	defer tracef(h.t, "TearDown done")
 
+	if h.external != nil {
+		if h.Node != nil {
+			tracef(h.t, "TearDown: Node")
+			h.Node.Shutdown()
+		}
+		return nil
+	}
+
+	// Capture the profiling data while the node is still running.  Any
+	// resulting error is returned after the harness is fully torn down.
+	var profilingErr error
+	if h.profiling != nil && h.node.pid != 0 {
+		tracef(h.t, "TearDown: profiling")
+		profilingErr = h.captureProfilingData()
+	}
+
 	if h.Node != nil {
 		tracef(h.t, "TearDown: Node")
 		h.Node.Shutdown()
@@ -326,7 +463,7 @@ func (h *Harness) TearDown() error {
 	tracef(h.t, "TearDown deleting %v", h.node.pid)
 	delete(testInstances, h.testNodeDir)
 
-	return nil
+	return profilingErr
 }
 
 // connectRPCClient attempts to establish an RPC connection to the created dcrd
@@ -339,7 +476,7 @@ func (h *Harness) connectRPCClient() error {
 	var client *rpcclient.Client
 	var err error
 
-	rpcConf := h.node.config.rpcConnConfig()
+	rpcConf := h.RPCConfig()
 	for i := 0; i < h.maxConnRetries; i++ {
 		if client, err = rpcclient.New(&rpcConf, h.handlers); err != nil {
 			time.Sleep(time.Duration(i) * 50 * time.Millisecond)
@@ -357,6 +494,12 @@ func (h *Harness) connectRPCClient() error {
 	return nil
 }
 
+// Wallet returns the wallet used by the Harness.  This is the in-memory wallet
+// unless a different wallet was provided via NewWithWallet.
+func (h *Harness) Wallet() WalletController {
+	return h.wallet
+}
+
 // NewAddress returns a fresh address spendable by the Harness' internal
 // wallet.
 //
@@ -405,10 +548,86 @@ func (h *Harness) UnlockOutputs(inputs []*wire.TxIn) {
 	h.wallet.UnlockOutputs(inputs)
 }
 
+// GenerateToAddress generates the requested number of blocks with coinbases
+// that pay to the provided address instead of the harness wallet and returns
+// their hashes.
+//
+// This function is safe for concurrent access.
+func (h *Harness) GenerateToAddress(ctx context.Context, numBlocks uint32, addr stdaddr.Address) ([]*chainhash.Hash, error) {
+	return h.Node.GenerateToAddress(ctx, numBlocks, addr)
+}
+
+// MatureCoinbases generates the requested number of blocks with coinbases that
+// pay to the provided address followed by enough additional blocks for their
+// coinbase outputs to mature and returns the outpoints of the matured outputs
+// that pay to the address in the order the blocks were generated.  The returned
+// outputs may be spent by transactions included in the next block.
+//
+// The premine block is generated first when the harness node is at the genesis
+// block since its coinbase does not pay to the mining address.
+//
+// This function is safe for concurrent access.
+func (h *Harness) MatureCoinbases(ctx context.Context, numOutputs uint32, addr stdaddr.Address) ([]wire.OutPoint, error) {
+	if numOutputs == 0 {
+		return nil, nil
+	}
+
+	_, height, err := h.Node.GetBestBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if height == 0 {
+		if _, err := h.Node.Generate(ctx, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	// Generate the blocks that pay to the address and record the coinbase
+	// outputs that pay to it.
+	blockHashes, err := h.GenerateToAddress(ctx, numOutputs, addr)
+	if err != nil {
+		return nil, err
+	}
+	_, payScript := addr.PaymentScript()
+	outPoints := make([]wire.OutPoint, 0, len(blockHashes))
+	for _, blockHash := range blockHashes {
+		block, err := h.Node.GetBlock(ctx, blockHash)
+		if err != nil {
+			return nil, err
+		}
+		coinbase := block.Transactions[0]
+		coinbaseHash := coinbase.TxHash()
+		for i, txOut := range coinbase.TxOut {
+			if bytes.Equal(txOut.PkScript, payScript) {
+				outPoints = append(outPoints, wire.OutPoint{
+					Hash:  coinbaseHash,
+					Index: uint32(i),
+					Tree:  wire.TxTreeRegular,
+				})
+			}
+		}
+	}
+
+	// Coinbase outputs may be spent once the block that spends them is at
+	// least the coinbase maturity number of blocks after the block that
+	// contains them, so generate enough blocks for the outputs from the final
+	// block above to be spendable in the next block.
+	if maturity := uint32(h.ActiveNet.CoinbaseMaturity); maturity > 1 {
+		if _, err := h.Node.Generate(ctx, maturity-1); err != nil {
+			return nil, err
+		}
+	}
+
+	return outPoints, nil
+}
+
 // RPCConfig returns the harnesses current rpc configuration. This allows other
 // potential RPC clients created within tests to connect to a given test
 // harness instance.
 func (h *Harness) RPCConfig() rpcclient.ConnConfig {
+	if h.external != nil {
+		return h.external.RPC
+	}
 	return h.node.config.rpcConnConfig()
 }
 
@@ -419,6 +638,9 @@ func (h *Harness) RPCConfig() rpcclient.ConnConfig {
 // ConnectNode() function, which handles cases like already connected peers and
 // ensures the connection actually takes place.
 func (h *Harness) P2PAddress() string {
+	if h.external != nil {
+		return h.external.P2PAddress
+	}
 	return h.node.config.listen
 }
 
@@ -13,6 +13,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/decred/dcrd/chaincfg/v3"
 	"github.com/decred/dcrd/dcrutil/v4"
 	dcrdtypes "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
 	"github.com/decred/dcrd/wire"
 )
 
@@ -352,6 +354,45 @@ func testActiveHarnesses(_ context.Context, r *Harness, t *testing.T) {
 	}
 }
 
+func testFakePeer(ctx context.Context, r *Harness, t *testing.T) {
+	tracef(t, "testFakePeer start")
+	defer tracef(t, "testFakePeer end")
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Connect a fake peer to the harness node.
+	fp, err := r.ConnectFakePeer(ctx, wire.SFNodeNetwork)
+	if err != nil {
+		t.Fatalf("unable to connect fake peer: %v", err)
+	}
+	defer fp.Disconnect()
+
+	// Ensure the node responds to a ping with a pong with the same nonce.
+	const nonce = 0x1234567890
+	if err := fp.SendMessage(ctx, wire.NewMsgPing(nonce)); err != nil {
+		t.Fatalf("unable to send ping: %v", err)
+	}
+	_, err = fp.WaitForMessage(ctx, func(msg wire.Message) bool {
+		pong, ok := msg.(*wire.MsgPong)
+		return ok && pong.Nonce == nonce
+	})
+	if err != nil {
+		t.Fatalf("did not receive pong: %v", err)
+	}
+
+	// Ensure the node disconnects the fake peer when it sends a block that
+	// was not requested.
+	genesis := r.ActiveNet.GenesisBlock
+	if err := fp.SendMessage(ctx, genesis); err != nil {
+		t.Fatalf("unable to send block: %v", err)
+	}
+	if err := fp.WaitForDisconnect(ctx); err != nil {
+		t.Fatalf("fake peer was not disconnected after sending an "+
+			"unrequested block: %v", err)
+	}
+}
+
 func testJoinMempools(ctx context.Context, r *Harness, t *testing.T) {
 	tracef(t, "testJoinMempools start")
 	defer tracef(t, "testJoinMempools end")
@@ -620,6 +661,201 @@ func testMemWalletLockedOutputs(_ context.Context, r *Harness, t *testing.T) {
 	}
 }
 
+func testMatureCoinbases(ctx context.Context, r *Harness, t *testing.T) {
+	tracef(t, "testMatureCoinbases start")
+	defer tracef(t, "testMatureCoinbases end")
+
+	// Use an address that does not belong to the harness wallet.
+	addr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(
+		make([]byte, 20), r.ActiveNet)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+
+	_, startHeight, err := r.Node.GetBestBlock(ctx)
+	if err != nil {
+		t.Fatalf("unable to get best block: %v", err)
+	}
+
+	// Mature a few coinbases that pay to the address.
+	const numOutputs = 3
+	outPoints, err := r.MatureCoinbases(ctx, numOutputs, addr)
+	if err != nil {
+		t.Fatalf("unable to mature coinbases: %v", err)
+	}
+	if len(outPoints) != numOutputs {
+		t.Fatalf("unexpected number of matured outputs -- got %d, want %d",
+			len(outPoints), numOutputs)
+	}
+
+	// Ensure the expected number of blocks were generated and that all of
+	// the outputs are unspent and pay to the address.
+	_, height, err := r.Node.GetBestBlock(ctx)
+	if err != nil {
+		t.Fatalf("unable to get best block: %v", err)
+	}
+	coinbaseMaturity := int64(r.ActiveNet.CoinbaseMaturity)
+	if wantHeight := startHeight + numOutputs + coinbaseMaturity - 1; height != wantHeight {
+		t.Fatalf("unexpected height -- got %d, want %d", height, wantHeight)
+	}
+	for _, outPoint := range outPoints {
+		txOut, err := r.Node.GetTxOut(ctx, &outPoint.Hash, outPoint.Index,
+			outPoint.Tree, false)
+		if err != nil {
+			t.Fatalf("unable to get txout %v: %v", outPoint, err)
+		}
+		if txOut == nil || !txOut.Coinbase {
+			t.Fatalf("matured output %v is not an unspent coinbase output",
+				outPoint)
+		}
+		if len(txOut.ScriptPubKey.Addresses) != 1 ||
+			txOut.ScriptPubKey.Addresses[0] != addr.String() {
+
+			t.Fatalf("matured output %v does not pay to %v", outPoint, addr)
+		}
+	}
+
+}
+
+func testProfiling(ctx context.Context, r *Harness, t *testing.T) {
+	tracef(t, "testProfiling start")
+	defer tracef(t, "testProfiling end")
+
+	// Create a harness that writes its profiles to a temp dir and has a
+	// limit on the number of goroutines that it is certain to exceed.
+	profileDir := t.TempDir()
+	harness, err := New(t, r.ActiveNet, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = harness.EnableProfiling(&ProfilingConfig{
+		ProfileDir: profileDir,
+		Limits:     &ResourceLimits{MaxGoroutines: 1},
+	})
+	if err != nil {
+		t.Fatalf("unable to enable profiling: %v", err)
+	}
+	if err := harness.SetUp(false, 0); err != nil {
+		_ = harness.TearDown()
+		t.Fatalf("unable to setup harness: %v", err)
+	}
+
+	// Ensure profiling can not be enabled once the harness is set up.
+	if err := harness.EnableProfiling(&ProfilingConfig{}); err == nil {
+		_ = harness.TearDown()
+		t.Fatal("enabled profiling after the harness was set up")
+	}
+
+	// Ensure the runtime metrics and profiles can be fetched and the
+	// resource limits are checked as expected.
+	metrics, err := harness.FetchRuntimeMetrics(ctx)
+	if err != nil {
+		_ = harness.TearDown()
+		t.Fatalf("unable to fetch runtime metrics: %v", err)
+	}
+	if metrics.HeapAlloc == 0 || metrics.Sys == 0 || metrics.NumGoroutines == 0 {
+		_ = harness.TearDown()
+		t.Fatalf("unexpected runtime metrics: %v", metrics)
+	}
+	err = harness.CheckResourceLimits(ctx, &ResourceLimits{
+		MaxHeapAlloc: 1 << 40,
+		MaxSys:       1 << 40,
+	})
+	if err != nil {
+		_ = harness.TearDown()
+		t.Fatalf("unexpected error checking resource limits: %v", err)
+	}
+	if err := metrics.Check(&ResourceLimits{MaxSys: 1}); err == nil {
+		_ = harness.TearDown()
+		t.Fatal("resource limits were not exceeded")
+	}
+	profile, err := harness.FetchHeapProfile(ctx)
+	if err != nil {
+		_ = harness.TearDown()
+		t.Fatalf("unable to fetch heap profile: %v", err)
+	}
+	if len(profile) == 0 {
+		_ = harness.TearDown()
+		t.Fatal("empty heap profile")
+	}
+
+	// Ensure a CPU profile can not be fetched while the node is writing one
+	// to the profile directory.
+	if _, err := harness.FetchCPUProfile(ctx, time.Second); err == nil {
+		_ = harness.TearDown()
+		t.Fatal("fetched CPU profile while the node is writing one")
+	}
+
+	// Ensure tearing down the harness reports the exceeded goroutine limit
+	// and the node wrote its profiles.
+	if err := harness.TearDown(); err == nil {
+		t.Fatal("teardown did not report exceeded resource limits")
+	}
+	for _, name := range []string{cpuProfileName, heapProfileName} {
+		fi, err := os.Stat(filepath.Join(profileDir, name))
+		if err != nil {
+			t.Fatalf("node did not write %s: %v", name, err)
+		}
+		if fi.Size() == 0 {
+			t.Fatalf("node wrote empty %s", name)
+		}
+	}
+}
+
+func testConnectExisting(ctx context.Context, r *Harness, t *testing.T) {
+	tracef(t, "testConnectExisting start")
+	defer tracef(t, "testConnectExisting end")
+
+	// Create a harness to act as the externally managed node.
+	harness, err := New(t, r.ActiveNet, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer harness.TearDown()
+	if err := harness.SetUp(false, 0); err != nil {
+		t.Fatalf("unable to setup harness: %v", err)
+	}
+
+	// Attach a harness to the node and ensure it is able to create a test
+	// chain that pays to its own wallet.
+	const numOutputs = 2
+	external, err := ConnectExisting(t, r.ActiveNet, nil, &ExistingNodeConfig{
+		RPC:        harness.RPCConfig(),
+		P2PAddress: harness.P2PAddress(),
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to attach harness: %v", err)
+	}
+	if err := external.SetUp(true, numOutputs); err != nil {
+		_ = external.TearDown()
+		t.Fatalf("unable to setup attached harness: %v", err)
+	}
+	if external.ConfirmedBalance() == 0 {
+		_ = external.TearDown()
+		t.Fatal("attached harness wallet does not have any mature outputs")
+	}
+	if external.P2PAddress() != harness.P2PAddress() {
+		_ = external.TearDown()
+		t.Fatalf("mismatched P2P address -- got %s, want %s",
+			external.P2PAddress(), harness.P2PAddress())
+	}
+
+	// Ensure the attached harness is not tracked as an active harness and
+	// tearing it down leaves the node running.
+	for _, active := range ActiveHarnesses() {
+		if active == external {
+			t.Fatal("attached harness is tracked as an active harness")
+		}
+	}
+	if err := external.TearDown(); err != nil {
+		t.Fatalf("unable to tear down attached harness: %v", err)
+	}
+	if _, _, err := harness.Node.GetBestBlock(ctx); err != nil {
+		t.Fatalf("node is not running after tearing down attached "+
+			"harness: %v", err)
+	}
+}
+
 func TestHarness(t *testing.T) {
 	var err error
 	mainHarness, err := New(t, chaincfg.RegNetParams(), nil, nil)
@@ -699,6 +935,10 @@ func TestHarness(t *testing.T) {
 				f:    testActiveHarnesses,
 				name: "testActiveHarnesses",
 			},
+			{
+				f:    testFakePeer,
+				name: "testFakePeer",
+			},
 			{
 				f:    testJoinBlocks,
 				name: "testJoinBlocks",
@@ -715,6 +955,18 @@ func TestHarness(t *testing.T) {
 				f:    testMemWalletLockedOutputs,
 				name: "testMemWalletLockedOutputs",
 			},
+			{
+				f:    testMatureCoinbases,
+				name: "testMatureCoinbases",
+			},
+			{
+				f:    testProfiling,
+				name: "testProfiling",
+			},
+			{
+				f:    testConnectExisting,
+				name: "testConnectExisting",
+			},
 		}
 
 		for _, testCase := range tests {
@@ -0,0 +1,377 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpctest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/blockchain/v5/chaingen"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+// scenarioStep is a single step of a scenario along with a description that
+// identifies it in any errors.
+type scenarioStep struct {
+	desc string
+	run  func(ctx context.Context, h *Harness) error
+}
+
+// Scenario is a declarative description of a consensus test that is executed
+// against the node of a live harness via its RPC interface.
+//
+// Scenarios are described with a builder API in the same manner as the full
+// block tests.  The blocks are created with a chaingen generator which is made
+// available via Generator for creating custom transactions and blocks, and
+// each call to Accepted, AcceptedToSideChain, Rejected, ExpectTip, AcceptTx,
+// and RejectTx appends a step that submits a block or transaction to the node
+// and asserts the result.  Reorgs are described by using SetTip to build on an
+// earlier block and then extending the new branch until it has the most work.
+//
+// For example:
+//
+//	s, err := NewScenario(chaincfg.SimNetParams())
+//	...
+//	s.AdvanceToStakeValidationHeight()
+//	outs := s.Generator().OldestCoinbaseOuts()
+//	s.NextBlock("b1", &outs[0], outs[1:]).Accepted()
+//	s.SetTip("bsv0").NextBlock("b1a", nil, nil).AcceptedToSideChain()
+//	s.NextBlock("b2a", nil, nil, badMunger).Rejected("merkle root")
+//	s.ExpectTip("b1")
+//	err = s.Run(ctx, harness)
+//
+// Any failures while describing the scenario, such as referencing an unknown
+// block, are deferred and returned from Run.
+type Scenario struct {
+	g     chaingen.Generator
+	steps []scenarioStep
+	err   error
+}
+
+// NewScenario returns a new scenario for the provided network parameters with
+// the genesis block as the tip of its generator.  The parameters must match
+// the network of the harness the scenario is run against.
+func NewScenario(params *chaincfg.Params) (*Scenario, error) {
+	g, err := chaingen.MakeGenerator(params)
+	if err != nil {
+		return nil, err
+	}
+	return &Scenario{g: g}, nil
+}
+
+// Generator returns the underlying generator used to create the blocks of the
+// scenario.  It may be used to create custom transactions and spendable
+// outputs.
+func (s *Scenario) Generator() *chaingen.Generator {
+	return &s.g
+}
+
+// build invokes the provided function unless a previous error occurred while
+// describing the scenario and converts any panics from the generator into an
+// error that is returned from Run.
+func (s *Scenario) build(f func()) *Scenario {
+	if s.err != nil {
+		return s
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch rt := r.(type) {
+			case error:
+				s.err = rt
+			default:
+				s.err = fmt.Errorf("%v", rt)
+			}
+		}
+	}()
+	f()
+	return s
+}
+
+// addStep appends a step with the provided description to the scenario.
+func (s *Scenario) addStep(desc string, run func(ctx context.Context, h *Harness) error) {
+	s.steps = append(s.steps, scenarioStep{desc: desc, run: run})
+}
+
+// NextBlock creates a new block that builds on the current tip of the scenario
+// generator via chaingen.Generator.NextBlock and saves its coinbase outputs so
+// they are available for spending by later blocks.  The block becomes the new
+// tip of the generator, however, it is not submitted to the node until one of
+// Accepted, AcceptedToSideChain, or Rejected is invoked.
+func (s *Scenario) NextBlock(blockName string, spend *chaingen.SpendableOut, ticketSpends []chaingen.SpendableOut, mungers ...func(*wire.MsgBlock)) *Scenario {
+	return s.build(func() {
+		s.nextBlock(blockName, spend, ticketSpends, mungers...)
+	})
+}
+
+// nextBlock is the internal implementation of NextBlock that does not convert
+// generator panics into errors.
+func (s *Scenario) nextBlock(blockName string, spend *chaingen.SpendableOut, ticketSpends []chaingen.SpendableOut, mungers ...func(*wire.MsgBlock)) {
+	s.g.NextBlock(blockName, spend, ticketSpends, mungers...)
+	s.g.SaveTipCoinbaseOuts()
+}
+
+// SetTip changes the tip of the scenario generator to the block with the
+// provided name so subsequent blocks build on it.  This is typically used to
+// create side chains and reorgs.
+func (s *Scenario) SetTip(blockName string) *Scenario {
+	return s.build(func() {
+		s.g.SetTip(blockName)
+	})
+}
+
+// submitBlock submits the provided block to the node associated with the
+// harness.
+func submitBlock(ctx context.Context, h *Harness, block *wire.MsgBlock) error {
+	return h.Node.SubmitBlock(ctx, dcrutil.NewBlock(block), nil)
+}
+
+// bestBlockName returns the name of the current best block of the node
+// associated with the harness as known by the scenario generator.
+func (s *Scenario) bestBlockName(ctx context.Context, h *Harness) (string, error) {
+	hash, _, err := h.Node.GetBestBlock(ctx)
+	if err != nil {
+		return "", err
+	}
+	if name := s.g.BlockName(hash); name != "" {
+		return name, nil
+	}
+	return hash.String(), nil
+}
+
+// Accepted appends a step that submits the current tip of the scenario
+// generator to the node and expects it to be accepted to the main chain.
+func (s *Scenario) Accepted() *Scenario {
+	return s.build(s.accepted)
+}
+
+// accepted is the internal implementation of Accepted that does not convert
+// generator panics into errors.
+func (s *Scenario) accepted() {
+	blockName, block := s.g.TipName(), s.g.Tip()
+	desc := fmt.Sprintf("accept block %q", blockName)
+	s.addStep(desc, func(ctx context.Context, h *Harness) error {
+		if err := submitBlock(ctx, h, block); err != nil {
+			return fmt.Errorf("block was rejected: %w", err)
+		}
+		bestName, err := s.bestBlockName(ctx, h)
+		if err != nil {
+			return err
+		}
+		if bestName != blockName {
+			return fmt.Errorf("block is not the best block -- got best %q",
+				bestName)
+		}
+		return nil
+	})
+}
+
+// AcceptedToSideChain appends a step that submits the current tip of the
+// scenario generator to the node and expects it to be accepted to a side chain
+// such that the best block of the node does not change.
+func (s *Scenario) AcceptedToSideChain() *Scenario {
+	return s.build(func() {
+		blockName, block := s.g.TipName(), s.g.Tip()
+		desc := fmt.Sprintf("accept block %q to side chain", blockName)
+		s.addStep(desc, func(ctx context.Context, h *Harness) error {
+			prevBestName, err := s.bestBlockName(ctx, h)
+			if err != nil {
+				return err
+			}
+			if err := submitBlock(ctx, h, block); err != nil {
+				return fmt.Errorf("block was rejected: %w", err)
+			}
+			bestName, err := s.bestBlockName(ctx, h)
+			if err != nil {
+				return err
+			}
+			if bestName != prevBestName {
+				return fmt.Errorf("block unexpectedly changed the best "+
+					"block from %q to %q", prevBestName, bestName)
+			}
+			return nil
+		})
+	})
+}
+
+// Rejected appends a step that submits the current tip of the scenario
+// generator to the node and expects it to be rejected.  The rejection reason
+// reported by the node must contain the provided reason when it is not empty.
+func (s *Scenario) Rejected(reason string) *Scenario {
+	return s.build(func() {
+		blockName, block := s.g.TipName(), s.g.Tip()
+		desc := fmt.Sprintf("reject block %q", blockName)
+		s.addStep(desc, func(ctx context.Context, h *Harness) error {
+			err := submitBlock(ctx, h, block)
+			if err == nil {
+				return errors.New("block was accepted")
+			}
+			if !strings.Contains(err.Error(), reason) {
+				return fmt.Errorf("mismatched rejection reason -- got %q, "+
+					"want %q", err, reason)
+			}
+			return nil
+		})
+	})
+}
+
+// ExpectTip appends a step that expects the best block of the node to be the
+// block with the provided name.
+func (s *Scenario) ExpectTip(blockName string) *Scenario {
+	return s.build(func() {
+		block := s.g.BlockByName(blockName)
+		desc := fmt.Sprintf("expect tip %q", blockName)
+		s.addStep(desc, func(ctx context.Context, h *Harness) error {
+			hash, height, err := h.Node.GetBestBlock(ctx)
+			if err != nil {
+				return err
+			}
+			wantHash := block.BlockHash()
+			if *hash != wantHash {
+				bestName, _ := s.bestBlockName(ctx, h)
+				return fmt.Errorf("mismatched best block -- got %q "+
+					"(height %d), want %q", bestName, height, blockName)
+			}
+			return nil
+		})
+	})
+}
+
+// AcceptTx appends a step that submits the provided transaction to the node
+// and expects it to be accepted to the mempool.
+func (s *Scenario) AcceptTx(txName string, tx *wire.MsgTx) *Scenario {
+	return s.build(func() {
+		desc := fmt.Sprintf("accept tx %q", txName)
+		s.addStep(desc, func(ctx context.Context, h *Harness) error {
+			_, err := h.Node.SendRawTransaction(ctx, tx, true)
+			if err != nil {
+				return fmt.Errorf("transaction was rejected: %w", err)
+			}
+			return nil
+		})
+	})
+}
+
+// RejectTx appends a step that submits the provided transaction to the node
+// and expects it to be rejected.  The rejection reason reported by the node
+// must contain the provided reason when it is not empty.
+func (s *Scenario) RejectTx(txName string, tx *wire.MsgTx, reason string) *Scenario {
+	return s.build(func() {
+		desc := fmt.Sprintf("reject tx %q", txName)
+		s.addStep(desc, func(ctx context.Context, h *Harness) error {
+			_, err := h.Node.SendRawTransaction(ctx, tx, true)
+			if err == nil {
+				return errors.New("transaction was accepted")
+			}
+			if !strings.Contains(err.Error(), reason) {
+				return fmt.Errorf("mismatched rejection reason -- got %q, "+
+					"want %q", err, reason)
+			}
+			return nil
+		})
+	})
+}
+
+// AdvanceToStakeValidationHeight creates the required first block followed by
+// enough blocks to reach stake validation height, purchasing the maximum
+// number of tickets per block once coinbases are mature until the target
+// ticket pool size is reached, and appends steps that expect each of them to
+// be accepted to the main chain.
+//
+// It must only be used when the tip of the scenario generator is the genesis
+// block.
+func (s *Scenario) AdvanceToStakeValidationHeight() *Scenario {
+	return s.build(func() {
+		if s.g.Tip().Header.Height != 0 {
+			panic("advancing to stake validation height requires the " +
+				"scenario to be at the genesis block")
+		}
+
+		// Shorter versions of useful params for convenience.
+		params := s.g.Params()
+		coinbaseMaturity := uint32(params.CoinbaseMaturity)
+		stakeEnabledHeight := uint32(params.StakeEnabledHeight)
+		stakeValidationHeight := uint32(params.StakeValidationHeight)
+		ticketsPerBlock := uint32(params.TicketsPerBlock)
+		targetPoolSize := uint32(params.TicketPoolSize) * ticketsPerBlock
+
+		// Add the required first block.
+		//
+		//   genesis -> bfb
+		s.g.CreateBlockOne("bfb", 0)
+		s.accepted()
+
+		// Generate enough blocks to have mature coinbase outputs to work
+		// with.
+		//
+		//   genesis -> bfb -> bm0 -> bm1 -> ... -> bm#
+		for i := uint32(0); i < coinbaseMaturity; i++ {
+			s.nextBlock(fmt.Sprintf("bm%d", i), nil, nil)
+			s.accepted()
+		}
+
+		// Generate enough blocks to reach stake validation height while
+		// purchasing tickets that spend from the coinbases matured above
+		// until the target ticket pool size is reached.
+		//
+		//   ... -> bm# -> bse0 -> ... -> bse# -> bsv0 -> ... -> bsv#
+		var ticketsPurchased uint32
+		firstHeight := coinbaseMaturity + 2
+		for height := firstHeight; height <= stakeValidationHeight; height++ {
+			ticketsNeeded := targetPoolSize - ticketsPurchased
+			if ticketsNeeded > ticketsPerBlock {
+				ticketsNeeded = ticketsPerBlock
+			}
+			outs := s.g.OldestCoinbaseOuts()
+			ticketsPurchased += ticketsNeeded
+
+			blockName := fmt.Sprintf("bse%d", height-firstHeight)
+			if height > stakeEnabledHeight {
+				blockName = fmt.Sprintf("bsv%d", height-stakeEnabledHeight-1)
+			}
+			s.nextBlock(blockName, nil, outs[1:ticketsNeeded+1])
+			s.accepted()
+		}
+		s.g.AssertTipHeight(stakeValidationHeight)
+	})
+}
+
+// Run executes the steps of the scenario in order against the node associated
+// with the provided harness and returns an error that identifies the first
+// step that fails.
+//
+// The node must be on the same network as the scenario and its best block must
+// be the genesis block, which is the case for a harness that was set up
+// without creating a test chain.
+func (s *Scenario) Run(ctx context.Context, h *Harness) error {
+	if s.err != nil {
+		return fmt.Errorf("invalid scenario: %w", s.err)
+	}
+
+	if h.ActiveNet.Net != s.g.Params().Net {
+		return fmt.Errorf("scenario for network %v can not be run on a "+
+			"harness for network %v", s.g.Params().Net, h.ActiveNet.Net)
+	}
+	_, height, err := h.Node.GetBestBlock(ctx)
+	if err != nil {
+		return err
+	}
+	if height != 0 {
+		return fmt.Errorf("scenario requires the harness node to be at the "+
+			"genesis block -- current height %d", height)
+	}
+
+	for i, step := range s.steps {
+		if err := step.run(ctx, h); err != nil {
+			return fmt.Errorf("step %d (%s): %w", i, step.desc, err)
+		}
+		debugf(h.t, "scenario step %d (%s) passed", i, step.desc)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// This file is ignored during the regular tests due to the following build tag.
+//go:build rpctest
+// +build rpctest
+
+package rpctest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/wire"
+)
+
+// TestScenario ensures a scenario that advances to stake validation height,
+// creates a side chain that triggers a reorg, and submits an invalid block
+// runs successfully against a live node.
+func TestScenario(t *testing.T) {
+	// Skip tests when running with -short
+	if testing.Short() {
+		t.Skip("Skipping scenario test in short mode")
+	}
+
+	net := chaincfg.SimNetParams()
+	hn, err := New(t, net, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hn.SetUp(false, 0); err != nil {
+		t.Fatal(err)
+	}
+	defer hn.TearDown()
+
+	s, err := NewScenario(net)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.AdvanceToStakeValidationHeight()
+	svhTip := s.Generator().TipName()
+
+	// Create a block that spends a coinbase and purchases tickets followed by
+	// a side chain that triggers a reorg once it has more work.
+	//
+	//   ... -> bsv# -> b1
+	//              \-> b1a -> b2a
+	outs := s.Generator().OldestCoinbaseOuts()
+	s.NextBlock("b1", &outs[0], outs[1:]).Accepted()
+	s.SetTip(svhTip)
+	s.NextBlock("b1a", nil, nil).AcceptedToSideChain()
+	s.NextBlock("b2a", nil, nil).Accepted()
+	s.ExpectTip("b2a")
+
+	// Create a block with an invalid merkle root and ensure it is rejected
+	// without changing the tip.
+	//
+	//   ... -> b2a -> b3(bad)
+	s.NextBlock("b3", nil, nil, func(b *wire.MsgBlock) {
+		b.Header.MerkleRoot[0] ^= 0x01
+	}).Rejected("merkle root")
+	s.ExpectTip("b2a")
+
+	if err := s.Run(context.Background(), hn); err != nil {
+		t.Fatal(err)
+	}
+}
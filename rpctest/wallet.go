@@ -0,0 +1,72 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package rpctest
+
+import (
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/rpcclient/v8"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
+)
+
+// WalletController defines the functionality a wallet must provide in order to
+// be used by the Harness.  This allows tests to make use of alternative wallet
+// implementations, such as wallets backed by a full wallet process or mocks,
+// instead of the default in-memory wallet.
+//
+// Implementations must be safe for concurrent access since the block
+// notification callbacks are invoked from the RPC client's notification
+// handler while tests may concurrently invoke the remaining methods.
+type WalletController interface {
+	// Start launches any goroutines required for the wallet to function
+	// properly.  It is called once the harness node has been started and
+	// the RPC client has been set.
+	Start()
+
+	// SetRPCClient provides the wallet with the RPC connection to the
+	// harness node.  It is called prior to Start.
+	SetRPCClient(rpcClient *rpcclient.Client)
+
+	// CoinbaseAddress returns the address the harness node pays all newly
+	// generated coins to when mining blocks.
+	CoinbaseAddress() stdaddr.Address
+
+	// SyncedHeight returns the height the wallet is known to be synced to.
+	SyncedHeight() int64
+
+	// IngestBlock is invoked each time a new block is connected to the main
+	// chain with the serialized block header and the serialized transactions
+	// that match the transaction filter loaded for the wallet.
+	IngestBlock(header []byte, filteredTxns [][]byte)
+
+	// UnwindBlock is invoked each time a block is disconnected from the main
+	// chain with the serialized block header.
+	UnwindBlock(header []byte)
+
+	// NewAddress returns a fresh address spendable by the wallet.
+	NewAddress() (stdaddr.Address, error)
+
+	// ConfirmedBalance returns the confirmed balance of the wallet.
+	ConfirmedBalance() dcrutil.Amount
+
+	// SendOutputs creates, signs, and broadcasts a transaction paying to the
+	// specified outputs while observing the provided fee rate expressed in
+	// atoms-per-byte.
+	SendOutputs(outputs []*wire.TxOut, feeRate dcrutil.Amount) (*chainhash.Hash, error)
+
+	// CreateTransaction returns a fully signed transaction paying to the
+	// specified outputs while observing the provided fee rate expressed in
+	// atoms-per-byte.  The selected inputs must not be selected again until
+	// they are unlocked via UnlockOutputs.
+	CreateTransaction(outputs []*wire.TxOut, feeRate dcrutil.Amount) (*wire.MsgTx, error)
+
+	// UnlockOutputs unlocks any outputs which were previously selected to
+	// fund a transaction via CreateTransaction.
+	UnlockOutputs(inputs []*wire.TxIn)
+}
+
+// Ensure the in-memory wallet implements the WalletController interface.
+var _ WalletController = (*memWallet)(nil)
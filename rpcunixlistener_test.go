@@ -0,0 +1,59 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestSetupRPCUnixListener ensures the Unix domain socket for the RPC server
+// is only accessible by the user running the server and that stale sockets are
+// replaced while other files are not.
+func TestSetupRPCUnixListener(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix socket permissions are not supported on windows")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rpc.sock")
+	listener, err := setupRPCUnixListener(path)
+	if err != nil {
+		t.Fatalf("unexpected error creating listener: %v", err)
+	}
+	fi, err := os.Lstat(path)
+	if err != nil {
+		t.Fatalf("unexpected error getting socket info: %v", err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0600 {
+		t.Fatalf("unexpected socket permissions -- got %o, want %o", perm,
+			0600)
+	}
+
+	// Leave a stale socket behind by disabling removal on close and ensure it
+	// is replaced.
+	type unlinker interface{ SetUnlinkOnClose(bool) }
+	listener.(unlinker).SetUnlinkOnClose(false)
+	listener.Close()
+	listener, err = setupRPCUnixListener(path)
+	if err != nil {
+		t.Fatalf("unexpected error replacing stale socket: %v", err)
+	}
+	listener.Close()
+
+	// Ensure an existing file that is not a socket is not removed.
+	filePath := filepath.Join(dir, "file")
+	if err := os.WriteFile(filePath, nil, 0600); err != nil {
+		t.Fatalf("unexpected error creating file: %v", err)
+	}
+	if _, err := setupRPCUnixListener(filePath); err == nil {
+		t.Fatal("did not receive expected error for existing file")
+	}
+	if _, err := os.Stat(filePath); err != nil {
+		t.Fatalf("existing file was removed: %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+//
+//go:build aix || android || darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build aix android darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package main
+
+import (
+	"net"
+	"syscall"
+)
+
+// listenUnixPrivate listens on a new Unix domain socket at the provided path
+// that is only accessible by the user running the server.
+//
+// The socket is created while a restrictive umask is in effect so that it is
+// never accessible by other users, as opposed to restricting its permissions
+// after it has been created.  Note that the umask is process wide, so this
+// must only be called during startup.
+func listenUnixPrivate(path string) (net.Listener, error) {
+	oldMask := syscall.Umask(0177)
+	listener, err := net.Listen("unix", path)
+	syscall.Umask(oldMask)
+	return listener, err
+}
@@ -137,6 +137,29 @@ const fileContents = `[Application Options]
 ; whitelist=192.168.0.0/24
 ; whitelist=fd00::/16
 
+; The average duration between inventory announcements to outbound and inbound
+; peers, respectively.  The intervals are randomized for each announcement so
+; the timing does not reveal when the inventory was received and they are
+; doubled while the mempool is under pressure.  Whitelisted peers are sent
+; announcements at a quarter of the outbound interval.  Valid time units are
+; {ms,s,m}.  Minimum 10 milliseconds.
+; trickleinterval=500ms
+; inboundtrickleinterval=1s
+
+; Add comments to the user agent advertised to peers.  Comments may not contain
+; the '/', ':', '(', or ')' characters.
+; uacomment=mycomment
+
+; Reject peers with a user agent that matches a regular expression.  Permanent
+; peers and whitelisted inbound peers are exempt.  This may be specified
+; multiple times.
+; rejectagent=^/dcrd:1\.[0-6]\.
+
+; Evict inbound peers with a user agent that matches a regular expression to
+; make room for other peers once the max number of peers is reached.  This may
+; be specified multiple times.
+; deprioritizeagent=^/dcrwallet:
+
 ; Disable seeding for peer discovery.  By default, when dcrd starts, it will use
 ; HTTPS to query for available peers to connect with.
 ; noseeders=1
@@ -170,9 +193,25 @@ const fileContents = `[Application Options]
 ; All ipv6 interfaces on non-standard port 8336:
 ;   listen=[::]:8336
 
+; Apply policies to the peers accepted via a specific listen interface/port.
+; The interface/port must also be specified via listen.  Multiple policies may
+; be specified per address separated by commas and multiple addresses may be
+; configured by specifying the option more than once.  The supported policies
+; are:
+;   onion:   Connections are from a local Tor hidden service, so the peers are
+;            never whitelisted and the address is not advertised
+;   norelay: Do not relay transactions to or from the peers
+; Treat connections to localhost on non-standard port 8336 as onion peers that
+; do not relay transactions:
+;   listenpolicy=127.0.0.1:8336=onion,norelay
+
 ; Disable listening for incoming connections.  This will override all listeners.
 ; nolisten=1
 
+; Disable negotiating compression of large block and filter messages with
+; peers.
+; nocompression=1
+
 
 ; ------------------------------------------------------------------------------
 ; RPC server options - The following options control the built-in RPC server
@@ -224,6 +263,46 @@ const fileContents = `[Application Options]
 ; Specify the maximum number of concurrent RPC websocket clients.
 ; rpcmaxwebsockets=25
 
+; Specify the maximum number of concurrent RPC websocket clients per IP address.
+; There is no per IP limit by default.
+; rpcmaxwebsocketsperip=5
+
+; Specify the maximum number of bytes of notifications that may be queued for
+; an RPC websocket client that is not reading them quickly enough.  Clients that
+; exceed it have their queued notifications dropped and are disconnected.  A
+; value of 0 disables the limit.  The default is 64 MiB.
+; rpcmaxwsqueuebytes=67108864
+
+; Specify the maximum number of bytes of immutable RPC responses to cache in
+; memory.  This includes the serialized blocks and transactions as well as the
+; committed filters for blocks that are deeply confirmed in the main chain,
+; which offloads repeated queries for them from the database.  A value of 0
+; disables the cache.  The default is 32 MiB.
+; rpccachebytes=33554432
+
+; Specify the number of consecutive RPC authentication failures from an IP
+; address that cause it to be locked out from authenticating for the specified
+; lockout duration.  Every additional failure after that doubles the lockout
+; duration up to a maximum of one hour.  Lockouts are disabled by default.
+; rpcmaxauthfailures=5
+; rpcauthlockout=1m
+
+; Specify a file to append a line of JSON to for every invocation of a
+; privileged RPC, which is any RPC that is not available to limited users.  Each
+; line records the authenticated user, remote address, method, parameters, and
+; result status.  Parameters may be redacted from the log by method, or by
+; individual parameter of a method, and the setting may be specified multiple
+; times.
+; rpcauditlog=/path/to/rpcaudit.log
+; rpcauditredact=addnode.addr
+; rpcauditredact=debuglevel
+
+; Enable the RPCs that are only intended for testing, such as setmocktime to
+; override the network-adjusted time used by the node and forceprune to remove
+; expired transactions without waiting for the next block.  This is only
+; allowed on simnet and regnet.
+; debugrpc=1
+
 ; Use the following setting to disable the RPC server even if the rpcuser and
 ; rpcpass are specified above.  This allows one to quickly disable the RPC
 ; server without having to remove credentials from the config file.
@@ -257,6 +336,15 @@ const fileContents = `[Application Options]
 ; Reject non-standard transactions regardless of default network settings.
 ; rejectnonstd=1
 
+; Limit the mempool event journal, which records recent transactions being
+; accepted, rejected, evicted, or mined for the getmempooljournal RPC, to 1000
+; events.  Set to 0 to disable the journal.
+; mempooljournalsize=1000
+
+; Save the mempool event journal to the data directory on shutdown and restore
+; it on startup.
+; persistmempooljournal=1
+
 
 ; ------------------------------------------------------------------------------
 ; Optional Indexes
@@ -266,6 +354,19 @@ const fileContents = `[Application Options]
 ; transactions available via the getrawtransaction RPC.
 ; txindex=1
 
+; Build and maintain an index of null data (OP_RETURN) payloads that start with
+; one of the specified hex-encoded prefixes which makes them available via the
+; getnulldata RPC.  The nulldataprefix option may be specified multiple times
+; and changing the set of prefixes rebuilds the index.
+; nulldataindex=1
+; nulldataprefix=44435241
+
+; Build and maintain an archival index of all transaction outputs and the
+; history of their spends in the main chain which makes their state as of any
+; height available via the gettxouthistory RPC.  Note that this index requires
+; a significant amount of additional disk space.
+; utxohistoryindex=1
+
 
 ; ------------------------------------------------------------------------------
 ; Signature Verification Cache
@@ -281,6 +382,10 @@ const fileContents = `[Application Options]
 ; Limit the utxo cache to a max of 100 MiB.
 ; utxocachemaxsize=150
 
+; Prefetch the utxos spent by the next block into the utxo cache while
+; connecting blocks during reorganizations.
+; utxoprefetch=1
+
 ; ------------------------------------------------------------------------------
 ; Coin Generation (Mining) Settings - The following options control the
 ; generation of block templates used by external mining applications through RPC
@@ -343,6 +448,13 @@ const fileContents = `[Application Options]
 ; output.
 ; nofilelogging=false
 
+; The format of log entries.  Valid formats are {text, json}.  The json format
+; writes each log entry as a JSON object on a single line with the time, level,
+; subsystem, and message along with context such as block hashes, transaction
+; hashes, and peer addresses when available, which is useful for ingesting the
+; logs into log aggregation systems.
+; logformat=text
+
 ; Log verbosity.
 ; Valid levels are {trace, debug, info, warn, error, critical}
 ; You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set
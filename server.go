@@ -15,10 +15,13 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	mrand "math/rand"
 	"net"
 	"os"
 	"path"
+	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -72,8 +75,26 @@ const (
 	// number of retries such that there is a retry backoff.
 	connectionRetryInterval = time.Second * 5
 
+	// rebroadcastInitialInterval is the amount of time to wait before first
+	// rebroadcasting inventory submitted via the RPC server that has not made
+	// it into a block.  The interval doubles after each rebroadcast up to
+	// rebroadcastMaxInterval.
+	rebroadcastInitialInterval = 5 * time.Minute
+
+	// rebroadcastMaxInterval is the maximum amount of time to wait in between
+	// rebroadcasts of inventory submitted via the RPC server.
+	rebroadcastMaxInterval = 2 * time.Hour
+
+	// rebroadcastMaxAge is the maximum amount of time inventory submitted via
+	// the RPC server is rebroadcast before it is no longer tracked.
+	rebroadcastMaxAge = 24 * time.Hour
+
+	// rebroadcastCheckInterval is the interval at which inventory submitted
+	// via the RPC server is checked for rebroadcasts that are due.
+	rebroadcastCheckInterval = time.Minute
+
 	// maxProtocolVersion is the max protocol version the server supports.
-	maxProtocolVersion = wire.InitStateVersion
+	maxProtocolVersion = wire.TSpendInvVersion
 
 	// These fields are used to track known addresses on a per-peer basis.
 	//
@@ -123,6 +144,41 @@ const (
 	// These values result in about 183 KiB memory usage including overhead.
 	maxRecentlyConfirmedTxns    = 23000
 	recentlyConfirmedTxnsFPRate = 0.000001
+
+	// trickleMempoolPressureTxns is the number of transactions in the mempool
+	// at which it is considered to be under pressure such that the average
+	// interval between inventory announcements to peers is doubled in order to
+	// batch more inventory into each announcement.
+	trickleMempoolPressureTxns = 5000
+
+	// maxTrickleMeanMultiple is the maximum multiple of the average interval
+	// between inventory announcements that the randomized interval for a given
+	// announcement is allowed to reach.
+	maxTrickleMeanMultiple = 4
+
+	// localAddrAdvertiseInterval is the average interval between periodic
+	// advertisements of the local address to outbound peers after the initial
+	// advertisement when the connection is established.  The actual interval
+	// is randomized so the advertisements are not easily correlated.
+	localAddrAdvertiseInterval = time.Hour * 24
+
+	// maxLocalAddrAdvertiseMultiple is the maximum multiple of the average
+	// interval between local address advertisements that the randomized
+	// interval for a given advertisement is allowed to reach.
+	maxLocalAddrAdvertiseMultiple = 3
+
+	// These fields control how long the addresses sent in response to getaddr
+	// requests are reused.
+	//
+	// addrResponseCacheLifetime is the minimum amount of time the same set of
+	// addresses is provided to all peers that request them.  Reusing the
+	// same response prevents peers from learning the entire contents of the
+	// address manager by repeatedly reconnecting and requesting addresses.
+	//
+	// addrResponseCacheJitter is the maximum random amount of additional time
+	// added to the lifetime so the refresh time is not predictable.
+	addrResponseCacheLifetime = time.Hour * 21
+	addrResponseCacheJitter   = time.Hour * 6
 )
 
 var (
@@ -158,6 +214,41 @@ func (a simpleAddr) Network() string {
 // Ensure simpleAddr implements the net.Addr interface.
 var _ net.Addr = simpleAddr{}
 
+// policyListener wraps a net.Listener to associate the policies configured for
+// the address it is listening on with all of the connections it accepts.
+type policyListener struct {
+	net.Listener
+	policy listenPolicy
+}
+
+// policyConn houses a connection accepted via a listener with policies along
+// with the policies.
+type policyConn struct {
+	net.Conn
+	policy listenPolicy
+}
+
+// Accept waits for and returns the next connection to the listener with the
+// policies for the listener attached.
+//
+// This is part of the net.Listener interface.
+func (l *policyListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &policyConn{Conn: conn, policy: l.policy}, nil
+}
+
+// connListenPolicy returns the policies that apply to the provided connection
+// based on the listener that accepted it.
+func connListenPolicy(conn net.Conn) listenPolicy {
+	if pc, ok := conn.(*policyConn); ok {
+		return pc.policy
+	}
+	return listenPolicy{}
+}
+
 // broadcastMsg provides the ability to house a Decred message to be broadcast
 // to all connected peers except specified excluded peers.
 type broadcastMsg struct {
@@ -177,6 +268,49 @@ type broadcastInventoryDel *wire.InvVect
 // inventory entries need to be filtered and removed where necessary
 type broadcastPruneInventory struct{}
 
+// broadcastInventoryQuery is a type used to request descriptions of all of
+// the inventory in the rebroadcast map.
+type broadcastInventoryQuery struct {
+	reply chan []rebroadcastInfo
+}
+
+// broadcastInventoryAbandon is a type used to declare that the InvVect it
+// contains needs to be removed from the rebroadcast map and to reply with
+// whether or not it was present.
+type broadcastInventoryAbandon struct {
+	invVect *wire.InvVect
+	reply   chan bool
+}
+
+// rebroadcastEntry houses inventory in the rebroadcast map along with the
+// state used to schedule its rebroadcasts with exponential backoff.
+type rebroadcastEntry struct {
+	data          interface{}
+	added         time.Time
+	lastBroadcast time.Time
+	nextBroadcast time.Time
+	interval      time.Duration
+	broadcasts    uint32
+}
+
+// scheduleNext sets the next time the entry is to be rebroadcast to the
+// current interval after the provided time plus a random delay of up to half
+// the interval so the rebroadcasts are harder to correlate.
+func (e *rebroadcastEntry) scheduleNext(now time.Time) {
+	maxJitter := uint16(e.interval / 2 / time.Second)
+	jitter := time.Duration(randomUint16Number(maxJitter)) * time.Second
+	e.nextBroadcast = now.Add(e.interval + jitter)
+}
+
+// rebroadcastInfo describes inventory in the rebroadcast map.
+type rebroadcastInfo struct {
+	invVect       wire.InvVect
+	added         time.Time
+	lastBroadcast time.Time
+	nextBroadcast time.Time
+	broadcasts    uint32
+}
+
 // relayMsg packages an inventory vector along with the newly discovered
 // inventory and a flag that determines if the relay should happen immediately
 // (it will be put into a trickle queue if false) so the relay has access to
@@ -469,12 +603,15 @@ type server struct {
 	// Putting the uint64s first makes them 64-bit aligned for 32-bit systems.
 	bytesReceived uint64 // Total bytes received from all peers since start.
 	bytesSent     uint64 // Total bytes sent by all peers since start.
+	agentRejected uint64 // Total peers rejected due to their user agent.
+	agentEvicted  uint64 // Total peers evicted due to their user agent.
 	shutdown      int32
 
 	chainParams          *chaincfg.Params
 	addrManager          *addrmgr.AddrManager
 	connManager          *connmgr.ConnManager
 	sigCache             *txscript.SigCache
+	scriptCache          *blockchain.ScriptCache
 	subsidyCache         *standalone.SubsidyCache
 	rpcServer            *rpcserver.Server
 	syncManager          *netsync.SyncManager
@@ -494,9 +631,21 @@ type server struct {
 	nat                  *upnpNAT
 	db                   database.DB
 	timeSource           blockchain.MedianTimeSource
+	mockTimeSource       *rpcMockTimeSource
 	services             wire.ServiceFlag
 	quit                 chan struct{}
 
+	// mempoolJournalPath is the path the mempool event journal is persisted
+	// to on shutdown.  It is empty when the journal is not persisted.
+	mempoolJournalPath string
+
+	// addrResponseMtx protects addrResponse and addrResponseExpiry which
+	// house the cached addresses provided in response to getaddr requests
+	// and the time they must be refreshed.
+	addrResponseMtx    sync.Mutex
+	addrResponse       []*addrmgr.NetAddress
+	addrResponseExpiry time.Time
+
 	// The following fields are used for optional indexes.  They will be nil
 	// if the associated index is not enabled.  These fields are set during
 	// initial creation of the server and never changed afterwards, so they
@@ -504,6 +653,8 @@ type server struct {
 	indexSubscriber *indexers.IndexSubscriber
 	txIndex         *indexers.TxIndex
 	existsAddrIndex *indexers.ExistsAddrIndex
+	nullDataIndex   *indexers.NullDataIndex
+	utxoHistIndex   *indexers.UtxoHistoryIndex
 
 	// These following fields are used to filter duplicate block lottery data
 	// anouncements.
@@ -513,6 +664,12 @@ type server struct {
 	// recentlyConfirmedTxns tracks transactions that have been confirmed in the
 	// most recent blocks.
 	recentlyConfirmedTxns *apbf.Filter
+
+	// reloadMtx serializes config reloads and liveCfg houses the current
+	// *reloadableConfig with the options that can be reloaded at runtime.
+	// The stored config must be treated as immutable.
+	reloadMtx sync.Mutex
+	liveCfg   atomic.Value
 }
 
 // serverPeer extends the peer to maintain state shared by the server.
@@ -526,6 +683,7 @@ type serverPeer struct {
 	relayMtx       sync.Mutex
 	disableRelayTx bool
 	isWhitelisted  bool
+	listenPolicy   listenPolicy
 	knownAddresses *apbf.Filter
 	banScore       connmgr.DynamicBanScore
 	quit           chan struct{}
@@ -566,6 +724,13 @@ func newServerPeer(s *server, isPersistent bool) *serverPeer {
 	}
 }
 
+// blocksOnly returns whether or not transactions from the peer are to be
+// ignored either due to the global blocks only mode or due to the policies of
+// the listener the peer was accepted via.
+func (sp *serverPeer) blocksOnly() bool {
+	return cfg.BlocksOnly || sp.listenPolicy.noRelay
+}
+
 // newestBlock returns the current best block hash and height using the format
 // required by the configuration for the peer package.
 func (sp *serverPeer) newestBlock() (*chainhash.Hash, int64, error) {
@@ -665,7 +830,8 @@ func (sp *serverPeer) pushAddrMsg(addresses []*addrmgr.NetAddress) {
 // disconnected.
 func (sp *serverPeer) addBanScore(persistent, transient uint32, reason string) bool {
 	// No warning is logged and no score is calculated if banning is disabled.
-	if cfg.DisableBanning {
+	liveCfg := sp.server.reloadableCfg()
+	if liveCfg.disableBanning {
 		return false
 	}
 	if sp.isWhitelisted {
@@ -673,7 +839,7 @@ func (sp *serverPeer) addBanScore(persistent, transient uint32, reason string) b
 		return false
 	}
 
-	warnThreshold := cfg.BanThreshold >> 1
+	warnThreshold := liveCfg.banThreshold >> 1
 	if transient == 0 && persistent == 0 {
 		// The score is not being increased, but a warning message is still
 		// logged if the score is above the warn threshold.
@@ -688,7 +854,7 @@ func (sp *serverPeer) addBanScore(persistent, transient uint32, reason string) b
 	if score > warnThreshold {
 		peerLog.Warnf("Misbehaving peer %s: %s -- ban score increased to %d",
 			sp, reason, score)
-		if score > cfg.BanThreshold {
+		if score > liveCfg.banThreshold {
 			peerLog.Warnf("Misbehaving peer %s -- banning and disconnecting",
 				sp)
 			sp.server.BanPeer(sp)
@@ -738,6 +904,21 @@ func (sp *serverPeer) OnVersion(_ *peer.Peer, msg *wire.MsgVersion) {
 		return
 	}
 
+	// Reject peers with a user agent that matches any of the configured
+	// patterns.  Permanent peers and whitelisted inbound peers are exempt
+	// since they are explicitly trusted.
+	isInboundWhitelisted := sp.isWhitelisted && isInbound
+	if !sp.persistent && !isInboundWhitelisted {
+		rejectAgents := sp.server.reloadableCfg().rejectAgents
+		if re := matchUserAgent(rejectAgents, msg.UserAgent); re != nil {
+			srvrLog.Debugf("Rejecting peer %s with user agent %q due to "+
+				"matching reject pattern %q", sp.Peer, msg.UserAgent, re)
+			atomic.AddUint64(&sp.server.agentRejected, 1)
+			sp.Disconnect()
+			return
+		}
+	}
+
 	// Reject outbound peers that are not full nodes.
 	wantServices := wire.SFNodeNetwork
 	if !isInbound && !hasServices(msg.Services, wantServices) {
@@ -768,6 +949,12 @@ func (sp *serverPeer) OnVersion(_ *peer.Peer, msg *wire.MsgVersion) {
 			}
 		}
 
+		// Periodically advertise the local address to the peer at
+		// randomized intervals for as long as it remains connected.
+		if !cfg.DisableListen {
+			go sp.localAddrAdvertiseHandler(remoteAddr)
+		}
+
 		// Request known addresses if the server address manager needs
 		// more.
 		if addrManager.NeedMoreAddresses() {
@@ -786,7 +973,7 @@ func (sp *serverPeer) OnVersion(_ *peer.Peer, msg *wire.MsgVersion) {
 	sp.peerNaMtx.Unlock()
 
 	// Choose whether or not to relay transactions.
-	sp.setDisableRelayTx(msg.DisableRelayTx)
+	sp.setDisableRelayTx(msg.DisableRelayTx || sp.listenPolicy.noRelay)
 
 	// Add the remote peer time as a sample for creating an offset against
 	// the local clock to keep the network time in sync.
@@ -824,9 +1011,9 @@ func (sp *serverPeer) OnMemPool(_ *peer.Peer, msg *wire.MsgMemPool) {
 	txDescs := txMemPool.TxDescs()
 
 	// Send the inventory message if there is anything to send.
+	pver := sp.ProtocolVersion()
 	for _, txDesc := range txDescs {
-		iv := wire.NewInvVect(wire.InvTypeTx, txDesc.Tx.Hash())
-		sp.QueueInventory(iv)
+		sp.QueueInventory(txInvVect(txDesc.Tx, pver))
 	}
 }
 
@@ -1051,7 +1238,7 @@ func (sp *serverPeer) OnInitState(_ *peer.Peer, msg *wire.MsgInitState) {
 // serialize all transactions through a single thread transactions don't rely on
 // the previous one in a linear fashion like blocks.
 func (sp *serverPeer) OnTx(_ *peer.Peer, msg *wire.MsgTx) {
-	if cfg.BlocksOnly {
+	if sp.blocksOnly() {
 		peerLog.Tracef("Ignoring tx %v from %v - blocksonly enabled",
 			msg.TxHash(), sp)
 		return
@@ -1061,7 +1248,7 @@ func (sp *serverPeer) OnTx(_ *peer.Peer, msg *wire.MsgTx) {
 	// Convert the raw MsgTx to a dcrutil.Tx which provides some convenience
 	// methods and things such as hash caching.
 	tx := dcrutil.NewTx(msg)
-	iv := wire.NewInvVect(wire.InvTypeTx, tx.Hash())
+	iv := txInvVect(tx, sp.ProtocolVersion())
 	sp.AddKnownInventory(iv)
 
 	// Queue the transaction up to be handled by the net sync manager and
@@ -1107,14 +1294,14 @@ func (sp *serverPeer) OnInv(_ *peer.Peer, msg *wire.MsgInv) {
 		return
 	}
 
-	if !cfg.BlocksOnly {
+	if !sp.blocksOnly() {
 		sp.server.syncManager.QueueInv(msg, sp.Peer)
 		return
 	}
 
 	newInv := wire.NewMsgInvSizeHint(uint(len(msg.InvList)))
 	for _, invVect := range msg.InvList {
-		if invVect.Type == wire.InvTypeTx {
+		if invVect.Type == wire.InvTypeTx || invVect.Type == wire.InvTypeTSpend {
 			peerLog.Infof("Peer %v is announcing transactions -- disconnecting",
 				sp)
 			sp.Disconnect()
@@ -1178,7 +1365,7 @@ func (sp *serverPeer) OnGetData(_ *peer.Peer, msg *wire.MsgGetData) {
 		}
 		var err error
 		switch iv.Type {
-		case wire.InvTypeTx:
+		case wire.InvTypeTx, wire.InvTypeTSpend:
 			err = sp.server.pushTxMsg(sp, &iv.Hash, c, waitChan)
 		case wire.InvTypeBlock:
 			err = sp.server.pushBlockMsg(sp, &iv.Hash, c, waitChan)
@@ -1300,7 +1487,9 @@ func (sp *serverPeer) enforceNodeCFFlag(cmd string) {
 	// NOTE: Even though the addBanScore function already examines whether
 	// or not banning is enabled, it is checked here as well to ensure the
 	// violation is logged and the peer is disconnected regardless.
-	if sp.ProtocolVersion() >= wire.NodeCFVersion && !cfg.DisableBanning {
+	if sp.ProtocolVersion() >= wire.NodeCFVersion &&
+		!sp.server.reloadableCfg().disableBanning {
+
 		// Disconnect the peer regardless of whether it was banned.
 		sp.addBanScore(100, 0, cmd)
 		sp.Disconnect()
@@ -1380,11 +1569,71 @@ func (sp *serverPeer) OnGetAddr(_ *peer.Peer, msg *wire.MsgGetAddr) {
 	}
 	sp.addrsSent = true
 
-	// Get the current known addresses from the address manager.
-	addrCache := sp.server.addrManager.AddressCache()
+	// Push the addresses to provide in response to getaddr requests.
+	sp.pushAddrMsg(sp.server.getAddrResponse())
+}
+
+// getAddrResponse returns the addresses to provide in response to getaddr
+// requests.  The same addresses are provided to all peers until the cached
+// response expires after a randomized lifetime in order to avoid leaking the
+// full contents of the address manager to peers that repeatedly connect and
+// request addresses.
+//
+// This function is safe for concurrent access.
+func (s *server) getAddrResponse() []*addrmgr.NetAddress {
+	s.addrResponseMtx.Lock()
+	defer s.addrResponseMtx.Unlock()
+
+	now := time.Now()
+	if s.addrResponse == nil || !now.Before(s.addrResponseExpiry) {
+		jitter := time.Duration(mrand.Int63n(int64(addrResponseCacheJitter)))
+		s.addrResponse = s.addrManager.AddressCache()
+		s.addrResponseExpiry = now.Add(addrResponseCacheLifetime + jitter)
+	}
+	return s.addrResponse
+}
+
+// localAddrAdvertiseHandler periodically advertises the local address that best
+// matches the provided remote address to the peer at randomized intervals until
+// the peer disconnects.  Advertisements are skipped when the server does not
+// believe itself to be close to the best known tip.
+//
+// This must be run as a goroutine.
+func (sp *serverPeer) localAddrAdvertiseHandler(remoteAddr *addrmgr.NetAddress) {
+	for {
+		interval := randomizedInterval(localAddrAdvertiseInterval,
+			maxLocalAddrAdvertiseMultiple)
+		timer := time.NewTimer(interval)
+		select {
+		case <-sp.quit:
+			timer.Stop()
+			return
+
+		case <-timer.C:
+		}
 
-	// Push the addresses.
-	sp.pushAddrMsg(addrCache)
+		if !sp.server.syncManager.IsCurrent() {
+			continue
+		}
+		lna := sp.server.addrManager.GetBestLocalAddress(remoteAddr)
+		if !lna.IsRoutable() {
+			continue
+		}
+
+		// Intentionally bypass the filter for addresses the peer already
+		// knows about and use the current time since the purpose is to
+		// refresh the address.
+		wireNetAddr := wire.NewNetAddressTimestamp(time.Now(), lna.Services,
+			lna.IP, lna.Port)
+		_, err := sp.PushAddrMsg([]*wire.NetAddress{wireNetAddr})
+		if err != nil {
+			peerLog.Errorf("Can't push address message to %s: %v", sp.Peer,
+				err)
+			sp.Disconnect()
+			return
+		}
+		sp.addKnownAddress(lna)
+	}
 }
 
 // OnAddr is invoked when a peer receives an addr wire message and is used to
@@ -1464,7 +1713,7 @@ func (sp *serverPeer) OnNotFound(_ *peer.Peer, msg *wire.MsgNotFound) {
 		switch inv.Type {
 		case wire.InvTypeBlock:
 			numBlocks++
-		case wire.InvTypeTx:
+		case wire.InvTypeTx, wire.InvTypeTSpend:
 			numTxns++
 		default:
 			peerLog.Debugf("Invalid inv type '%d' in notfound message from %s",
@@ -1509,7 +1758,8 @@ func randomUint16Number(max uint16) uint16 {
 }
 
 // AddRebroadcastInventory adds 'iv' to the list of inventories to be
-// rebroadcasted at random intervals until they show up in a block.
+// rebroadcasted with exponential backoff until they show up in a block or
+// expire.
 func (s *server) AddRebroadcastInventory(iv *wire.InvVect, data interface{}) {
 	select {
 	case <-s.quit:
@@ -1535,12 +1785,58 @@ func (s *server) PruneRebroadcastInventory() {
 	}
 }
 
+// RebroadcastInventory returns descriptions of all inventory that is being
+// rebroadcast ordered by the time it was added.
+func (s *server) RebroadcastInventory() []rebroadcastInfo {
+	reply := make(chan []rebroadcastInfo, 1)
+	select {
+	case <-s.quit:
+		return nil
+	case s.modifyRebroadcastInv <- broadcastInventoryQuery{reply: reply}:
+	}
+	return <-reply
+}
+
+// AbandonRebroadcastInventory removes 'iv' from the list of items to be
+// rebroadcasted and returns whether or not it was present.
+func (s *server) AbandonRebroadcastInventory(iv *wire.InvVect) bool {
+	reply := make(chan bool, 1)
+	select {
+	case <-s.quit:
+		return false
+	case s.modifyRebroadcastInv <- broadcastInventoryAbandon{iv, reply}:
+	}
+	return <-reply
+}
+
+// txInvVect returns the inventory vector to use when announcing the provided
+// transaction to a peer that negotiated the provided protocol version.
+// Treasury spends are announced with their dedicated inventory vector type to
+// peers that support it so they are not conflated with other transactions.
+func txInvVect(tx *dcrutil.Tx, pver uint32) *wire.InvVect {
+	invType := wire.InvTypeTx
+	if pver >= wire.TSpendInvVersion && stake.IsTSpend(tx.MsgTx()) {
+		invType = wire.InvTypeTSpend
+	}
+	return wire.NewInvVect(invType, tx.Hash())
+}
+
+// isTimeSensitiveTx returns whether or not the provided transaction is time
+// sensitive and therefore should be relayed and sent to peers ahead of other
+// transactions.  Votes are required in order for new blocks to be built and
+// treasury spends must be voted on within a specific window, so both are time
+// sensitive.
+func isTimeSensitiveTx(tx *wire.MsgTx) bool {
+	return stake.IsSSGen(tx) || stake.IsTSpend(tx)
+}
+
 // relayTransactions generates and relays inventory vectors for all of the
-// passed transactions to all connected peers.
+// passed transactions to all connected peers.  Time sensitive transactions
+// are announced immediately instead of being trickled.
 func (s *server) relayTransactions(txns []*dcrutil.Tx) {
 	for _, tx := range txns {
 		iv := wire.NewInvVect(wire.InvTypeTx, tx.Hash())
-		s.RelayInventory(iv, tx, false)
+		s.RelayInventory(iv, tx, isTimeSensitiveTx(tx.MsgTx()))
 	}
 }
 
@@ -1597,7 +1893,13 @@ func (s *server) pushTxMsg(sp *serverPeer, hash *chainhash.Hash, doneChan chan<-
 		<-waitChan
 	}
 
-	sp.QueueMessage(tx.MsgTx(), doneChan)
+	// Send time sensitive transactions such as votes and treasury spends ahead
+	// of other transactions.
+	priority := peer.DefaultMessagePriority(tx.MsgTx())
+	if isTimeSensitiveTx(tx.MsgTx()) {
+		priority = peer.MessagePriorityHigh
+	}
+	sp.QueueMessageWithPriority(tx.MsgTx(), doneChan, priority)
 
 	return nil
 }
@@ -1647,6 +1949,54 @@ func (s *server) pushBlockMsg(sp *serverPeer, hash *chainhash.Hash, doneChan cha
 	return nil
 }
 
+// matchUserAgent returns the first of the provided patterns that matches the
+// user agent or nil when none of them match.
+func matchUserAgent(patterns []*regexp.Regexp, userAgent string) *regexp.Regexp {
+	for _, re := range patterns {
+		if re.MatchString(userAgent) {
+			return re
+		}
+	}
+	return nil
+}
+
+// evictDeprioritizedPeer attempts to disconnect a connected inbound peer with a
+// user agent that matches any of the configured deprioritize patterns in order
+// to make room for the provided new peer.  No peers are evicted when the new
+// peer is itself deprioritized.  Whitelisted and permanent peers are never
+// evicted.
+//
+// It returns whether or not a peer was evicted.
+//
+// This function MUST be called from the peerHandler goroutine.
+func (s *server) evictDeprioritizedPeer(state *peerState, newPeer *serverPeer) bool {
+	deprioAgents := s.reloadableCfg().deprioAgents
+	if len(deprioAgents) == 0 {
+		return false
+	}
+	if matchUserAgent(deprioAgents, newPeer.UserAgent()) != nil {
+		return false
+	}
+
+	for _, sp := range state.inboundPeers {
+		if sp.isWhitelisted || sp.persistent || !sp.Connected() {
+			continue
+		}
+		re := matchUserAgent(deprioAgents, sp.UserAgent())
+		if re == nil {
+			continue
+		}
+
+		srvrLog.Debugf("Evicting peer %s with user agent %q due to matching "+
+			"deprioritize pattern %q to make room for peer %s", sp,
+			sp.UserAgent(), re, newPeer)
+		atomic.AddUint64(&s.agentEvicted, 1)
+		sp.Disconnect()
+		return true
+	}
+	return false
+}
+
 // handleAddPeerMsg deals with adding new peers.  It is invoked from the
 // peerHandler goroutine.
 func (s *server) handleAddPeerMsg(state *peerState, sp *serverPeer) bool {
@@ -1693,8 +2043,11 @@ func (s *server) handleAddPeerMsg(state *peerState, sp *serverPeer) bool {
 	}
 
 	// Limit max number of total peers.  However, allow whitelisted inbound
-	// peers regardless.
-	if state.Count()+1 > cfg.MaxPeers && !isInboundWhitelisted {
+	// peers regardless.  Inbound peers with deprioritized user agents are
+	// evicted to make room for new peers that are not deprioritized.
+	if state.Count()+1 > cfg.MaxPeers && !isInboundWhitelisted &&
+		!s.evictDeprioritizedPeer(state, sp) {
+
 		srvrLog.Infof("Max peers reached [%d] - disconnecting peer %s",
 			cfg.MaxPeers, sp)
 		sp.Disconnect()
@@ -1849,10 +2202,10 @@ func (s *server) handleBanPeerMsg(state *peerState, sp *serverPeer) {
 		srvrLog.Debugf("can't split ban peer %s %v", sp.Addr(), err)
 		return
 	}
+	banDuration := s.reloadableCfg().banDuration
 	direction := directionString(sp.Inbound())
-	srvrLog.Infof("Banned peer %s (%s) for %v", host, direction,
-		cfg.BanDuration)
-	state.banned[host] = time.Now().Add(cfg.BanDuration)
+	srvrLog.Infof("Banned peer %s (%s) for %v", host, direction, banDuration)
+	state.banned[host] = time.Now().Add(banDuration)
 }
 
 // handleRelayInvMsg deals with relaying inventory to peers that are not already
@@ -1904,6 +2257,12 @@ func (s *server) handleRelayInvMsg(state *peerState, msg relayMsg) {
 			if sp.relayTxDisabled() {
 				return
 			}
+
+			// Announce the transaction with the inventory vector type
+			// that is most specific for it that the peer supports.
+			if tx, ok := msg.data.(*dcrutil.Tx); ok {
+				iv = txInvVect(tx, sp.ProtocolVersion())
+			}
 		}
 
 		// Either queue the inventory to be relayed immediately or with
@@ -2145,12 +2504,56 @@ func disconnectPeer(peerList map[int32]*serverPeer, compareFunc func(*serverPeer
 	return false
 }
 
+// trickleInterval returns the duration to wait before trickling the next batch
+// of queued inventory to the peer.
+//
+// Inventory is announced more frequently to trusted whitelisted peers and less
+// frequently to inbound peers since they are more likely to be attempting to
+// deduce the origin of transactions from the timing of announcements.  The
+// intervals for peers that are not whitelisted are randomized with an
+// exponential distribution so the announcement times do not reveal when the
+// inventory was received.  They are also lengthened while the mempool is under
+// pressure in order to batch more inventory into each announcement.
+//
+// This function is safe for concurrent access and is invoked by the peer
+// package via the peer configuration.
+func (sp *serverPeer) trickleInterval(_ *peer.Peer) time.Duration {
+	if sp.isWhitelisted {
+		return cfg.TrickleInterval / 4
+	}
+
+	mean := cfg.TrickleInterval
+	if sp.Inbound() {
+		mean = cfg.InboundTrickleInterval
+	}
+	if sp.server.txMemPool.Count() >= trickleMempoolPressureTxns {
+		mean *= 2
+	}
+
+	// Choose the interval from an exponential distribution with the mean
+	// determined above while limiting it to a multiple of the mean to avoid
+	// excessive delays.
+	return randomizedInterval(mean, maxTrickleMeanMultiple)
+}
+
+// randomizedInterval returns an interval chosen from an exponential
+// distribution with the provided mean while limiting it to the provided
+// multiple of the mean.
+func randomizedInterval(mean time.Duration, maxMeanMultiple int64) time.Duration {
+	interval := time.Duration(mrand.ExpFloat64() * float64(mean))
+	if maxInterval := mean * time.Duration(maxMeanMultiple); interval > maxInterval {
+		interval = maxInterval
+	}
+	return interval
+}
+
 // newPeerConfig returns the configuration for the given serverPeer.
 func newPeerConfig(sp *serverPeer) *peer.Config {
 	var userAgentComments []string
 	if version.PreRelease != "" {
 		userAgentComments = append(userAgentComments, version.PreRelease)
 	}
+	userAgentComments = append(userAgentComments, cfg.UserAgentComments...)
 
 	return &peer.Config{
 		Listeners: peer.MessageListeners{
@@ -2192,9 +2595,11 @@ func newPeerConfig(sp *serverPeer) *peer.Config {
 		UserAgentComments: userAgentComments,
 		Net:               sp.server.chainParams.Net,
 		Services:          sp.server.services,
-		DisableRelayTx:    cfg.BlocksOnly,
+		DisableRelayTx:    sp.blocksOnly(),
 		ProtocolVersion:   maxProtocolVersion,
 		IdleTimeout:       cfg.PeerIdleTimeout,
+		TrickleInterval:   sp.trickleInterval,
+		EnableCompression: !cfg.NoCompression,
 	}
 }
 
@@ -2203,8 +2608,12 @@ func newPeerConfig(sp *serverPeer) *peer.Config {
 // instance, associates it with the connection, and starts a goroutine to wait
 // for disconnection.
 func (s *server) inboundPeerConnected(conn net.Conn) {
+	// Peers accepted via an onion listener are never whitelisted since the
+	// connections are always from the local Tor hidden service.
 	sp := newServerPeer(s, false)
-	sp.isWhitelisted = isWhitelisted(conn.RemoteAddr())
+	sp.listenPolicy = connListenPolicy(conn)
+	sp.isWhitelisted = !sp.listenPolicy.onion &&
+		s.isWhitelisted(conn.RemoteAddr())
 	sp.Peer = peer.NewInboundPeer(newPeerConfig(sp))
 	sp.AssociateConnection(conn)
 	go s.peerDoneHandler(sp)
@@ -2225,7 +2634,7 @@ func (s *server) outboundPeerConnected(c *connmgr.ConnReq, conn net.Conn) {
 	}
 	sp.Peer = p
 	sp.connReq = c
-	sp.isWhitelisted = isWhitelisted(conn.RemoteAddr())
+	sp.isWhitelisted = s.isWhitelisted(conn.RemoteAddr())
 	sp.AssociateConnection(conn)
 	go s.peerDoneHandler(sp)
 
@@ -2236,6 +2645,28 @@ func (s *server) outboundPeerConnected(c *connmgr.ConnReq, conn net.Conn) {
 	}
 }
 
+// outboundPeerFailed is invoked by the connection manager when an attempt to
+// establish an outbound connection fails.  It notifies the address manager of
+// the attempt unless the reason for the failure is not attributable to the
+// remote address, such as when the attempt was canceled or there is no route
+// configured for the address.
+func (s *server) outboundPeerFailed(c *connmgr.ConnReq, err error) {
+	switch connmgr.DialErrorKind(err) {
+	case connmgr.ErrDialCanceled, connmgr.ErrDialNoRoute:
+		return
+	}
+
+	tcpAddr, ok := c.Addr.(*net.TCPAddr)
+	if !ok {
+		return
+	}
+	remoteAddr := addrmgr.NewNetAddressIPPort(tcpAddr.IP,
+		uint16(tcpAddr.Port), 0)
+	if err := s.addrManager.Attempt(remoteAddr); err != nil {
+		srvrLog.Tracef("Marking address as attempted failed: %v", err)
+	}
+}
+
 // peerDoneHandler handles peer disconnects by notifying the server that it's
 // done along with other performing other desirable cleanup.
 func (s *server) peerDoneHandler(sp *serverPeer) {
@@ -2358,7 +2789,7 @@ func (s *server) DonePeer(sp *serverPeer) {
 // BanPeer bans a peer that has already been connected to the server by ip
 // unless banning is disabled or the peer has been whitelisted.
 func (s *server) BanPeer(sp *serverPeer) {
-	if cfg.DisableBanning || sp.isWhitelisted {
+	if s.reloadableCfg().disableBanning || sp.isWhitelisted {
 		return
 	}
 	sp.Disconnect()
@@ -2369,6 +2800,175 @@ func (s *server) BanPeer(sp *serverPeer) {
 	}
 }
 
+// reloadableCfg returns the current values of the config options that can be
+// reloaded at runtime.
+//
+// This function is safe for concurrent access.
+func (s *server) reloadableCfg() *reloadableConfig {
+	return s.liveCfg.Load().(*reloadableConfig)
+}
+
+// connectPermanentPeer requests a persistent connection to the peer with the
+// provided address.
+func (s *server) connectPermanentPeer(addr string) error {
+	reply := make(chan error)
+	select {
+	case <-s.quit:
+		return errors.New("server is shutting down")
+	case s.query <- connectNodeMsg{addr: addr, permanent: true, reply: reply}:
+	}
+	return <-reply
+}
+
+// removePermanentPeer removes the persistent peer with the provided address,
+// including any pending connection to it.
+func (s *server) removePermanentPeer(addr string) error {
+	reply := make(chan error)
+	select {
+	case <-s.quit:
+		return errors.New("server is shutting down")
+	case s.query <- removeNodeMsg{
+		cmp:   func(sp *serverPeer) bool { return sp.Addr() == addr },
+		reply: reply,
+	}:
+	}
+	if err := <-reply; err == nil {
+		return nil
+	}
+
+	// Cancel the connection if it could still be pending.
+	select {
+	case <-s.quit:
+		return errors.New("server is shutting down")
+	case s.query <- cancelPendingMsg{addr: addr, reply: reply}:
+	}
+	return <-reply
+}
+
+// diffPeers returns the addresses that are in the new set of peers but not the
+// old one and vice versa.
+func diffPeers(oldPeers, newPeers []string) (added, removed []string) {
+	oldSet := make(map[string]struct{}, len(oldPeers))
+	for _, addr := range oldPeers {
+		oldSet[addr] = struct{}{}
+	}
+	newSet := make(map[string]struct{}, len(newPeers))
+	for _, addr := range newPeers {
+		newSet[addr] = struct{}{}
+		if _, ok := oldSet[addr]; !ok {
+			added = append(added, addr)
+		}
+	}
+	for _, addr := range oldPeers {
+		if _, ok := newSet[addr]; !ok {
+			removed = append(removed, addr)
+		}
+	}
+	return added, removed
+}
+
+// reloadConfig parses the config file and command line options again and
+// applies the options that can safely be changed at runtime, which are the
+// debug log levels, banning options, whitelists, minimum relay transaction
+// fee, user agent patterns, and the peers specified via --connect or
+// --addpeer.  All other options
+// are ignored.
+//
+// No changes are made when any of the reloadable options are invalid.  Peers
+// that are added or removed are reported as changes even when connecting to or
+// removing them fails since those failures are only logged.
+//
+// It returns a description of each option that changed.
+//
+// This function is safe for concurrent access.
+func (s *server) reloadConfig() ([]string, error) {
+	s.reloadMtx.Lock()
+	defer s.reloadMtx.Unlock()
+
+	newCfg, err := loadReloadableConfig()
+	if err != nil {
+		return nil, err
+	}
+	oldCfg := s.reloadableCfg()
+
+	// Update the log levels first since it is the only remaining operation
+	// that can fail.
+	var changes []string
+	if newCfg.debugLevel != oldCfg.debugLevel {
+		if err := parseAndSetDebugLevels(newCfg.debugLevel); err != nil {
+			return nil, err
+		}
+		changes = append(changes, fmt.Sprintf("debuglevel: %s -> %s",
+			oldCfg.debugLevel, newCfg.debugLevel))
+	}
+	if newCfg.disableBanning != oldCfg.disableBanning {
+		changes = append(changes, fmt.Sprintf("nobanning: %v -> %v",
+			oldCfg.disableBanning, newCfg.disableBanning))
+	}
+	if newCfg.banDuration != oldCfg.banDuration {
+		changes = append(changes, fmt.Sprintf("banduration: %v -> %v",
+			oldCfg.banDuration, newCfg.banDuration))
+	}
+	if newCfg.banThreshold != oldCfg.banThreshold {
+		changes = append(changes, fmt.Sprintf("banthreshold: %d -> %d",
+			oldCfg.banThreshold, newCfg.banThreshold))
+	}
+	oldWhitelists := fmt.Sprint(oldCfg.whitelists)
+	newWhitelists := fmt.Sprint(newCfg.whitelists)
+	if newWhitelists != oldWhitelists {
+		changes = append(changes, fmt.Sprintf("whitelist: %s -> %s",
+			oldWhitelists, newWhitelists))
+	}
+	oldRejectAgents := fmt.Sprint(oldCfg.rejectAgents)
+	newRejectAgents := fmt.Sprint(newCfg.rejectAgents)
+	if newRejectAgents != oldRejectAgents {
+		changes = append(changes, fmt.Sprintf("rejectagent: %s -> %s",
+			oldRejectAgents, newRejectAgents))
+	}
+	oldDeprioAgents := fmt.Sprint(oldCfg.deprioAgents)
+	newDeprioAgents := fmt.Sprint(newCfg.deprioAgents)
+	if newDeprioAgents != oldDeprioAgents {
+		changes = append(changes, fmt.Sprintf("deprioritizeagent: %s -> %s",
+			oldDeprioAgents, newDeprioAgents))
+	}
+	if newCfg.minRelayTxFee != oldCfg.minRelayTxFee {
+		s.txMemPool.SetMinRelayTxFee(newCfg.minRelayTxFee)
+		s.feeEstimator.SetMinFee(newCfg.minRelayTxFee)
+		if s.bg != nil {
+			s.bg.SetTxMinFreeFee(newCfg.minRelayTxFee)
+		}
+		changes = append(changes, fmt.Sprintf("minrelaytxfee: %v -> %v",
+			oldCfg.minRelayTxFee, newCfg.minRelayTxFee))
+	}
+	s.liveCfg.Store(newCfg)
+
+	// Update the persistent peers.
+	peersOpt := "addpeer"
+	if newCfg.connectOnly {
+		peersOpt = "connect"
+	}
+	added, removed := diffPeers(oldCfg.permanentPeers, newCfg.permanentPeers)
+	for _, addr := range removed {
+		if err := s.removePermanentPeer(addr); err != nil {
+			srvrLog.Warnf("Unable to remove persistent peer %s: %v", addr,
+				err)
+		}
+		changes = append(changes, fmt.Sprintf("%s: removed %s", peersOpt,
+			addr))
+	}
+	for _, addr := range added {
+		if err := s.connectPermanentPeer(addr); err != nil {
+			srvrLog.Warnf("Unable to add persistent peer %s: %v", addr, err)
+		}
+		changes = append(changes, fmt.Sprintf("%s: added %s", peersOpt, addr))
+	}
+
+	for _, change := range changes {
+		srvrLog.Infof("Reloaded config option %s", change)
+	}
+	return changes, nil
+}
+
 // RelayInventory relays the passed inventory vector to all connected peers
 // that are not already known to have it.
 func (s *server) RelayInventory(invVect *wire.InvVect, data interface{}, immediate bool) {
@@ -2450,6 +3050,14 @@ func (s *server) AddBytesReceived(bytesReceived uint64) {
 	atomic.AddUint64(&s.bytesReceived, bytesReceived)
 }
 
+// AgentFilterTotals returns the total number of peers rejected and evicted due
+// to their user agent matching the configured patterns since start.  It is safe
+// for concurrent access.
+func (s *server) AgentFilterTotals() (uint64, uint64) {
+	return atomic.LoadUint64(&s.agentRejected),
+		atomic.LoadUint64(&s.agentEvicted)
+}
+
 // NetTotals returns the sum of all bytes received and sent across the network
 // for all peers.  It is safe for concurrent access.
 func (s *server) NetTotals() (uint64, uint64) {
@@ -2473,10 +3081,11 @@ func headerApprovesParent(header *wire.BlockHeader) bool {
 	return dcrutil.IsFlagSet16(header.VoteBits, dcrutil.BlockValid)
 }
 
-// proactivelyEvictSigCacheEntries fetches the block that is
+// proactivelyEvictCacheEntries fetches the block that is
 // txscript.ProactiveEvictionDepth levels deep from bestHeight and passes it to
-// SigCache to evict the entries associated with the transactions in that block.
-func (s *server) proactivelyEvictSigCacheEntries(bestHeight int64) {
+// the signature and script caches to evict the entries associated with the
+// transactions in that block.
+func (s *server) proactivelyEvictCacheEntries(bestHeight int64) {
 	// Nothing to do before the eviction depth is reached.
 	if bestHeight <= txscript.ProactiveEvictionDepth {
 		return
@@ -2491,6 +3100,11 @@ func (s *server) proactivelyEvictSigCacheEntries(bestHeight int64) {
 	}
 
 	s.sigCache.EvictEntries(block.MsgBlock())
+	s.scriptCache.EvictEntries(block.MsgBlock())
+
+	stats := s.scriptCache.Stats()
+	srvrLog.Debugf("Script cache: %d entries, %d hits, %d misses (%.2f%% hit "+
+		"rate)", stats.Entries, stats.Hits, stats.Misses, stats.HitRate()*100)
 }
 
 // handleBlockchainNotification handles notifications from blockchain.  It does
@@ -2661,11 +3275,17 @@ func (s *server) handleBlockchainNotification(notification *blockchain.Notificat
 		// Also, in the case the RPC server is enabled, stop rebroadcasting any
 		// transactions in the block that were setup to be rebroadcast.
 		txMemPool := s.txMemPool
+		conflicts := txMemPool.RemoveBlockConflicts(block)
+		if len(conflicts.Evicted) > 0 {
+			srvrLog.Debugf("Block %v double spends %d transaction(s) in the "+
+				"mempool which resulted in evicting %d transaction(s)",
+				block.Hash(), len(conflicts.DoubleSpent),
+				len(conflicts.Evicted))
+		}
 		handleConnectedBlockTxns := func(txns []*dcrutil.Tx) {
 			for _, tx := range txns {
-				txMemPool.RemoveTransaction(tx, false)
+				txMemPool.RemoveMinedTransaction(tx)
 				txMemPool.MaybeAcceptDependents(tx, isTreasuryEnabled)
-				txMemPool.RemoveDoubleSpends(tx)
 				txMemPool.RemoveOrphan(tx)
 				acceptedTxs := txMemPool.ProcessOrphans(tx, ntfn.CheckTxFlags)
 				s.AnnounceNewTransactions(acceptedTxs)
@@ -2712,6 +3332,12 @@ func (s *server) handleBlockchainNotification(notification *blockchain.Notificat
 			txns := parentBlock.Transactions()[1:]
 			txMemPool.MaybeAcceptTransactions(txns)
 		}
+
+		// Stop tracking the blocks voted on prior to the parent of the block
+		// since votes for them can no longer influence which block is the
+		// most favorable one to build on.
+		txMemPool.PruneVotedBlocks(block.Height() - 1)
+
 		if r := s.rpcServer; r != nil {
 			// Filter and update the rebroadcast inventory.
 			s.PruneRebroadcastInventory()
@@ -2737,7 +3363,7 @@ func (s *server) handleBlockchainNotification(notification *blockchain.Notificat
 
 		// Proactively evict signature cache entries that are virtually
 		// guaranteed to no longer be useful.
-		s.proactivelyEvictSigCacheEntries(block.Height())
+		s.proactivelyEvictCacheEntries(block.Height())
 
 	// Stake tickets are matured from the most recently connected block.
 	case blockchain.NTNewTickets:
@@ -2779,7 +3405,7 @@ func (s *server) handleBlockchainNotification(notification *blockchain.Notificat
 		txMemPool := s.txMemPool
 		if !headerApprovesParent(&block.MsgBlock().Header) {
 			for _, tx := range parentBlock.Transactions()[1:] {
-				txMemPool.RemoveTransaction(tx, false)
+				txMemPool.RemoveMinedTransaction(tx)
 				txMemPool.MaybeAcceptDependents(tx, isTreasuryEnabled)
 				txMemPool.RemoveDoubleSpends(tx)
 				txMemPool.RemoveOrphan(tx)
@@ -2878,16 +3504,40 @@ func (s *server) handleBlockchainNotification(notification *blockchain.Notificat
 		if r := s.rpcServer; r != nil {
 			r.NotifyReorganization(rd)
 		}
+
+	// A chain reorganization was paused because it exceeds the maximum
+	// automatic reorganization depth and must be manually approved.
+	case blockchain.NTDeepReorgPaused:
+		// WARNING: The chain lock is not released before sending this
+		// notification, so care must be taken to avoid calling chain functions
+		// which could result in a deadlock.
+		pd, ok := notification.Data.(*blockchain.DeepReorgPausedNtfnsData)
+		if !ok {
+			syncLog.Warnf("Deep reorg paused notification is malformed")
+			break
+		}
+
+		srvrLog.Warnf("Chain reorganization to block %v (height %d) paused "+
+			"at tip %v (height %d) -- use the approvedeepreorg RPC to allow "+
+			"it to proceed", pd.TargetHash, pd.TargetHeight, pd.TipHash,
+			pd.TipHeight)
+
+		// Notify registered websocket clients.
+		if r := s.rpcServer; r != nil {
+			r.NotifyDeepReorgPaused(pd)
+		}
 	}
 }
 
 // rebroadcastHandler keeps track of user submitted inventories that we have
 // sent out but have not yet made it into a block. We periodically rebroadcast
-// them in case our peers restarted or otherwise lost track of them.
+// them in case our peers restarted or otherwise lost track of them.  The
+// interval between rebroadcasts of each inventory doubles after every
+// rebroadcast up to a maximum and inventory that is not included in a block
+// within a maximum age is no longer tracked.
 func (s *server) rebroadcastHandler(ctx context.Context) {
-	// Wait 5 min before first tx rebroadcast.
-	timer := time.NewTimer(5 * time.Minute)
-	pendingInvs := make(map[wire.InvVect]interface{})
+	ticker := time.NewTicker(rebroadcastCheckInterval)
+	pendingInvs := make(map[wire.InvVect]*rebroadcastEntry)
 
 	for {
 		select {
@@ -2896,21 +3546,61 @@ func (s *server) rebroadcastHandler(ctx context.Context) {
 
 			// Incoming InvVects are added to our map of RPC txs.
 			case broadcastInventoryAdd:
-				pendingInvs[*msg.invVect] = msg.data
+				if entry, ok := pendingInvs[*msg.invVect]; ok {
+					entry.data = msg.data
+					continue
+				}
+				now := time.Now()
+				entry := &rebroadcastEntry{
+					data:     msg.data,
+					added:    now,
+					interval: rebroadcastInitialInterval,
+				}
+				entry.scheduleNext(now)
+				pendingInvs[*msg.invVect] = entry
 
 			// When an InvVect has been added to a block, we can
 			// now remove it, if it was present.
 			case broadcastInventoryDel:
 				delete(pendingInvs, *msg)
 
+			case broadcastInventoryAbandon:
+				_, ok := pendingInvs[*msg.invVect]
+				delete(pendingInvs, *msg.invVect)
+				msg.reply <- ok
+
+			case broadcastInventoryQuery:
+				infos := make([]rebroadcastInfo, 0, len(pendingInvs))
+				for iv, entry := range pendingInvs {
+					infos = append(infos, rebroadcastInfo{
+						invVect:       iv,
+						added:         entry.added,
+						lastBroadcast: entry.lastBroadcast,
+						nextBroadcast: entry.nextBroadcast,
+						broadcasts:    entry.broadcasts,
+					})
+				}
+				sort.Slice(infos, func(i, j int) bool {
+					return infos[i].added.Before(infos[j].added)
+				})
+				msg.reply <- infos
+
 			case broadcastPruneInventory:
 				best := s.chain.BestSnapshot()
-				for iv, data := range pendingInvs {
-					tx, ok := data.(*dcrutil.Tx)
+				for iv, entry := range pendingInvs {
+					tx, ok := entry.data.(*dcrutil.Tx)
 					if !ok {
 						continue
 					}
 
+					// Remove the rebroadcast if the transaction has expired.
+					if blockchain.IsExpired(tx, best.Height) {
+						delete(pendingInvs, iv)
+						srvrLog.Debugf("Pending broadcast inventory for tx "+
+							"%v removed. Transaction expired.", tx.Hash())
+						continue
+					}
+
 					txType := stake.DetermineTxType(tx.MsgTx())
 
 					// Remove the ticket rebroadcast if the amount not equal to
@@ -2924,16 +3614,6 @@ func (s *server) rebroadcastHandler(ctx context.Context) {
 						continue
 					}
 
-					// Remove the ticket rebroadcast if it has already expired.
-					if txType == stake.TxTypeSStx &&
-						blockchain.IsExpired(tx, best.Height) {
-						delete(pendingInvs, iv)
-						srvrLog.Debugf("Pending ticket purchase broadcast "+
-							"inventory for tx %v removed. Transaction "+
-							"expired.", tx.Hash())
-						continue
-					}
-
 					// Remove the revocation rebroadcast if the associated
 					// ticket has been revived.
 					if txType == stake.TxTypeSSRtx {
@@ -2949,21 +3629,36 @@ func (s *server) rebroadcastHandler(ctx context.Context) {
 				}
 			}
 
-		case <-timer.C:
-			// Any inventory we have has not made it into a block
-			// yet. We periodically resubmit them until they have.
-			for iv, data := range pendingInvs {
+		case <-ticker.C:
+			// Any inventory we have has not made it into a block yet.  We
+			// periodically resubmit them until they have or they are too
+			// old to reasonably expect them to.
+			now := time.Now()
+			for iv, entry := range pendingInvs {
+				if now.Sub(entry.added) >= rebroadcastMaxAge {
+					delete(pendingInvs, iv)
+					srvrLog.Debugf("Pending broadcast inventory for %v "+
+						"removed. Not included in a block within %v.",
+						iv.Hash, rebroadcastMaxAge)
+					continue
+				}
+				if now.Before(entry.nextBroadcast) {
+					continue
+				}
+
 				ivCopy := iv
-				s.RelayInventory(&ivCopy, data, false)
+				s.RelayInventory(&ivCopy, entry.data, false)
+				entry.broadcasts++
+				entry.lastBroadcast = now
+				entry.interval *= 2
+				if entry.interval > rebroadcastMaxInterval {
+					entry.interval = rebroadcastMaxInterval
+				}
+				entry.scheduleNext(now)
 			}
 
-			// Process at a random time up to 30mins (in seconds)
-			// in the future.
-			timer.Reset(time.Second *
-				time.Duration(randomUint16Number(1800)))
-
 		case <-ctx.Done():
-			timer.Stop()
+			ticker.Stop()
 			s.wg.Done()
 			return
 		}
@@ -3088,6 +3783,13 @@ func (s *server) Run(ctx context.Context) {
 
 	s.feeEstimator.Close()
 
+	if s.mempoolJournalPath != "" {
+		err := saveMempoolJournal(s.mempoolJournalPath, s.txMemPool)
+		if err != nil {
+			srvrLog.Warnf("Unable to save mempool event journal: %v", err)
+		}
+	}
+
 	s.chain.ShutdownUtxoCache()
 
 	s.wg.Wait()
@@ -3259,7 +3961,7 @@ func genCertPair(certFile, keyFile string, altDNSNames []string, tlsCurve ellipt
 func setupRPCListeners() ([]net.Listener, error) {
 	// Setup TLS if not disabled.
 	listenFunc := net.Listen
-	if !cfg.DisableRPC && !cfg.DisableTLS {
+	if !cfg.DisableRPC && !cfg.DisableTLS && len(cfg.RPCListeners) > 0 {
 		// Generate the TLS cert and key file if both don't already exist.
 		keyFileExists := fileExists(cfg.RPCKey)
 		certFileExists := fileExists(cfg.RPCCert)
@@ -3327,6 +4029,24 @@ func setupRPCListeners() ([]net.Listener, error) {
 	return listeners, nil
 }
 
+// setupRPCUnixListener returns a listener for the Unix domain socket at the
+// provided path that is configured for use with the RPC server.  The socket is
+// only accessible by the user running the server.  Any stale socket left behind
+// at the path, such as from an unclean shutdown, is removed first.
+func setupRPCUnixListener(path string) (net.Listener, error) {
+	if fi, err := os.Lstat(path); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("unable to listen for RPC connections on "+
+				"%q: file exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return listenUnixPrivate(path)
+}
+
 // newServer returns a new dcrd server configured to listen on addr for the
 // decred network type specified by chainParams.  Use start to begin accepting
 // connections from peers.
@@ -3357,6 +4077,15 @@ func newServer(ctx context.Context, listenAddrs []string, db database.DB,
 		return nil, err
 	}
 
+	// Allow the network-adjusted time to be overridden via the RPC server when
+	// the debug RPCs are enabled.
+	var timeSource blockchain.MedianTimeSource = blockchain.NewMedianTime()
+	var mockTimeSource *rpcMockTimeSource
+	if cfg.DebugRPC {
+		mockTimeSource = &rpcMockTimeSource{MedianTimeSource: timeSource}
+		timeSource = mockTimeSource
+	}
+
 	s := server{
 		chainParams:          chainParams,
 		addrManager:          amgr,
@@ -3369,9 +4098,11 @@ func newServer(ctx context.Context, listenAddrs []string, db database.DB,
 		modifyRebroadcastInv: make(chan interface{}),
 		nat:                  nat,
 		db:                   db,
-		timeSource:           blockchain.NewMedianTime(),
+		timeSource:           timeSource,
+		mockTimeSource:       mockTimeSource,
 		services:             services,
 		sigCache:             sigCache,
+		scriptCache:          blockchain.NewScriptCache(cfg.ScriptCacheMaxSize),
 		subsidyCache:         standalone.NewSubsidyCache(chainParams),
 		lotteryDataBroadcast: make(map[chainhash.Hash]struct{}),
 		recentlyConfirmedTxns: apbf.NewFilter(maxRecentlyConfirmedTxns,
@@ -3379,6 +4110,7 @@ func newServer(ctx context.Context, listenAddrs []string, db database.DB,
 		indexSubscriber: indexers.NewIndexSubscriber(ctx),
 		quit:            make(chan struct{}),
 	}
+	s.liveCfg.Store(cfg.reloadable())
 
 	feC := fees.EstimatorConfig{
 		MinBucketFee: cfg.minRelayTxFee,
@@ -3403,6 +4135,17 @@ func newServer(ctx context.Context, listenAddrs []string, db database.DB,
 	if cfg.AllowOldForks {
 		srvrLog.Info("Processing forks deep in history is enabled")
 	}
+	if cfg.MaxReorgDepth > 0 {
+		srvrLog.Infof("Automatic chain reorganizations limited to a depth "+
+			"of %d blocks", cfg.MaxReorgDepth)
+	}
+	if cfg.MaxSideChainBlocks > 0 {
+		srvrLog.Infof("Side chain blocks limited to a maximum of %d",
+			cfg.MaxSideChainBlocks)
+	}
+	if cfg.AuditAssumeValid {
+		srvrLog.Info("Auditing scripts skipped due to assume valid is enabled")
+	}
 
 	// Set assume valid when enabled.
 	var assumeValid chainhash.Hash
@@ -3433,16 +4176,21 @@ func newServer(ctx context.Context, listenAddrs []string, db database.DB,
 	})
 	s.chain, err = blockchain.New(ctx,
 		&blockchain.Config{
-			DB:              s.db,
-			UtxoBackend:     utxoBackend,
-			ChainParams:     s.chainParams,
-			AssumeValid:     assumeValid,
-			TimeSource:      s.timeSource,
-			Notifications:   s.handleBlockchainNotification,
-			SigCache:        s.sigCache,
-			SubsidyCache:    s.subsidyCache,
-			IndexSubscriber: s.indexSubscriber,
-			UtxoCache:       utxoCache,
+			DB:                  s.db,
+			UtxoBackend:         utxoBackend,
+			ChainParams:         s.chainParams,
+			AssumeValid:         assumeValid,
+			MaxReorgDepth:       int64(cfg.MaxReorgDepth),
+			MaxSideChainBlocks:  int64(cfg.MaxSideChainBlocks),
+			AuditSkippedScripts: cfg.AuditAssumeValid,
+			TimeSource:          s.timeSource,
+			Notifications:       s.handleBlockchainNotification,
+			SigCache:            s.sigCache,
+			ScriptCache:         s.scriptCache,
+			SubsidyCache:        s.subsidyCache,
+			IndexSubscriber:     s.indexSubscriber,
+			UtxoCache:           utxoCache,
+			PrefetchUtxos:       cfg.UtxoPrefetch,
 		})
 	if err != nil {
 		return nil, err
@@ -3459,6 +4207,22 @@ func newServer(ctx context.Context, listenAddrs []string, db database.DB,
 	if !cfg.NoExistsAddrIndex {
 		indxLog.Info("Exists address index is enabled")
 		s.existsAddrIndex, err = indexers.NewExistsAddrIndex(s.indexSubscriber,
+			db, queryer, cfg.ExistsAddrFPRate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.NullDataIndex {
+		indxLog.Info("Null data index is enabled")
+		s.nullDataIndex, err = indexers.NewNullDataIndex(s.indexSubscriber,
+			db, queryer, cfg.nullDataPrefixes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.UtxoHistoryIndex {
+		indxLog.Info("UTXO history index is enabled")
+		s.utxoHistIndex, err = indexers.NewUtxoHistoryIndex(s.indexSubscriber,
 			db, queryer)
 		if err != nil {
 			return nil, err
@@ -3506,6 +4270,7 @@ func newServer(ctx context.Context, listenAddrs []string, db database.DB,
 		CalcSequenceLock: s.chain.CalcSequenceLock,
 		SubsidyCache:     s.subsidyCache,
 		SigCache:         s.sigCache,
+		ScriptCache:      s.scriptCache,
 		PastMedianTime: func() time.Time {
 			return s.chain.BestSnapshot().MedianTime
 		},
@@ -3517,6 +4282,17 @@ func newServer(ctx context.Context, listenAddrs []string, db database.DB,
 				s.bg.VoteReceived(voteTx)
 			}
 		},
+		OnVoteLeaderChanged: func(height int64, oldLeader, newLeader *chainhash.Hash) {
+			srvrLog.Debugf("Votes now favor block %v over block %v at "+
+				"height %d", newLeader, oldLeader, height)
+			if s.rpcServer != nil {
+				s.rpcServer.NotifyVoteLeaderChanged(&rpcserver.VoteLeaderChangedNtfnData{
+					Height:    height,
+					OldLeader: *oldLeader,
+					NewLeader: *newLeader,
+				})
+			}
+		},
 		OnTSpendReceived: func(tx *dcrutil.Tx) {
 			if s.rpcServer != nil {
 				s.rpcServer.NotifyTSpend(tx)
@@ -3538,9 +4314,20 @@ func newServer(ctx context.Context, listenAddrs []string, db database.DB,
 			tipHash := s.chain.BestSnapshot().Hash
 			return s.chain.CheckTSpendExists(tipHash, tspend)
 		},
+		EventJournalSize: cfg.MempoolJournalSize,
 	}
 	s.txMemPool = mempool.New(&txC)
 
+	// Restore the mempool event journal from the previous run when it is
+	// persisted.
+	if cfg.PersistMempoolJournal && cfg.MempoolJournalSize > 0 {
+		s.mempoolJournalPath = path.Join(dataDir, mempoolJournalFilename)
+		err := loadMempoolJournal(s.mempoolJournalPath, s.txMemPool)
+		if err != nil {
+			srvrLog.Warnf("Unable to load mempool event journal: %v", err)
+		}
+	}
+
 	s.syncManager = netsync.New(&netsync.Config{
 		PeerNotifier:          &s,
 		Chain:                 s.chain,
@@ -3625,7 +4412,7 @@ func newServer(ctx context.Context, listenAddrs []string, db database.DB,
 				isAutoRevocationsEnabled bool) error {
 
 				return blockchain.ValidateTransactionScripts(tx, utxoView, flags,
-					s.sigCache, isAutoRevocationsEnabled)
+					s.sigCache, s.scriptCache, isAutoRevocationsEnabled)
 			},
 		})
 
@@ -3644,6 +4431,7 @@ func newServer(ctx context.Context, listenAddrs []string, db database.DB,
 			ConnectedCount:             s.ConnectedCount,
 			IsCurrent:                  s.syncManager.IsCurrent,
 			IsKnownInvalidBlock:        s.chain.IsKnownInvalidBlock,
+			BestSnapshot:               s.chain.BestSnapshot,
 		})
 	}
 
@@ -3698,14 +4486,15 @@ func newServer(ctx context.Context, listenAddrs []string, db database.DB,
 		targetOutbound = cfg.MaxPeers
 	}
 	cmgr, err := connmgr.New(&connmgr.Config{
-		Listeners:      listeners,
-		OnAccept:       s.inboundPeerConnected,
-		RetryDuration:  connectionRetryInterval,
-		TargetOutbound: uint32(targetOutbound),
-		Dial:           dcrdDial,
-		Timeout:        cfg.DialTimeout,
-		OnConnection:   s.outboundPeerConnected,
-		GetNewAddress:  newAddressFunc,
+		Listeners:          listeners,
+		OnAccept:           s.inboundPeerConnected,
+		RetryDuration:      connectionRetryInterval,
+		TargetOutbound:     uint32(targetOutbound),
+		Dial:               dcrdDial,
+		Timeout:            cfg.DialTimeout,
+		OnConnection:       s.outboundPeerConnected,
+		OnConnectionFailed: s.outboundPeerFailed,
+		GetNewAddress:      newAddressFunc,
 	})
 	if err != nil {
 		return nil, err
@@ -3738,12 +4527,29 @@ func newServer(ctx context.Context, listenAddrs []string, db database.DB,
 			return nil, err
 		}
 
-		if len(rpcListeners) == 0 {
+		// Setup the listener for the Unix domain socket when configured.
+		// Connections over it are trusted when authentication is not
+		// required for it since access is controlled by its permissions.
+		var rpcTrustedListeners []net.Listener
+		if cfg.RPCListenUnix != "" {
+			listener, err := setupRPCUnixListener(cfg.RPCListenUnix)
+			if err != nil {
+				return nil, err
+			}
+			if cfg.RPCUnixNoAuth {
+				rpcTrustedListeners = append(rpcTrustedListeners, listener)
+			} else {
+				rpcListeners = append(rpcListeners, listener)
+			}
+		}
+
+		if len(rpcListeners) == 0 && len(rpcTrustedListeners) == 0 {
 			return nil, errors.New("no usable rpc listen addresses")
 		}
 
 		rpcsConfig := rpcserver.Config{
 			Listeners:    rpcListeners,
+			StartupTime:  time.Now().Unix(),
 			ConnMgr:      &rpcConnManager{&s},
 			SyncMgr:      &rpcSyncMgr{server: &s, syncMgr: s.syncManager},
 			FeeEstimator: s.feeEstimator,
@@ -3759,26 +4565,37 @@ func newServer(ctx context.Context, listenAddrs []string, db database.DB,
 				timeSource:  s.timeSource,
 				chainParams: chainParams,
 			},
-			DB:                   db,
-			TxMempooler:          s.txMemPool,
-			CPUMiner:             &rpcCPUMiner{s.cpuMiner},
-			NetInfo:              cfg.generateNetworkInfo(),
-			MinRelayTxFee:        cfg.minRelayTxFee,
-			Proxy:                cfg.Proxy,
-			RPCUser:              cfg.RPCUser,
-			RPCPass:              cfg.RPCPass,
-			RPCLimitUser:         cfg.RPCLimitUser,
-			RPCLimitPass:         cfg.RPCLimitPass,
-			RPCMaxClients:        cfg.RPCMaxClients,
-			RPCMaxConcurrentReqs: cfg.RPCMaxConcurrentReqs,
-			RPCMaxWebsockets:     cfg.RPCMaxWebsockets,
-			TestNet:              cfg.TestNet,
-			MiningAddrs:          cfg.miningAddrs,
-			AllowUnsyncedMining:  cfg.AllowUnsyncedMining,
-			MaxProtocolVersion:   maxProtocolVersion,
-			UserAgentVersion:     userAgentVersion,
-			LogManager:           &rpcLogManager{},
-			FiltererV2:           s.chain,
+			DB:                        db,
+			TxMempooler:               s.txMemPool,
+			CPUMiner:                  &rpcCPUMiner{s.cpuMiner},
+			NetInfo:                   cfg.generateNetworkInfo(),
+			Proxy:                     cfg.Proxy,
+			TrustedListeners:          rpcTrustedListeners,
+			RPCUser:                   cfg.RPCUser,
+			RPCPass:                   cfg.RPCPass,
+			RPCLimitUser:              cfg.RPCLimitUser,
+			RPCLimitPass:              cfg.RPCLimitPass,
+			RPCMaxClients:             cfg.RPCMaxClients,
+			RPCMaxConcurrentReqs:      cfg.RPCMaxConcurrentReqs,
+			RPCMaxWebsockets:          cfg.RPCMaxWebsockets,
+			RPCMaxWebsocketsPerIP:     cfg.RPCMaxWebsocketsPerIP,
+			RPCMaxWebsocketQueueBytes: cfg.RPCMaxWSQueueBytes,
+			RPCResponseCacheBytes:     cfg.RPCCacheBytes,
+			RPCMaxAuthFailures:        cfg.RPCMaxAuthFailures,
+			RPCAuthLockout:            cfg.RPCAuthLockout,
+			TestNet:                   cfg.TestNet,
+			DebugRPC:                  cfg.DebugRPC,
+			AcceptNonStd:              cfg.AcceptNonStd,
+			MaxOrphanTxs:              cfg.MaxOrphanTxs,
+			MiningAddrs:               cfg.miningAddrs,
+			AllowUnsyncedMining:       cfg.AllowUnsyncedMining,
+			MaxProtocolVersion:        maxProtocolVersion,
+			UserAgentVersion:          userAgentVersion,
+			LogManager:                &rpcLogManager{},
+			ConfigReloader:            &rpcConfigReloader{server: &s},
+			FiltererV2:                s.chain,
+			AuditLogFile:              cfg.RPCAuditLog,
+			AuditLogRedactions:        cfg.RPCAuditRedact,
 		}
 		if s.existsAddrIndex != nil {
 			rpcsConfig.ExistsAddresser = s.existsAddrIndex
@@ -3789,6 +4606,15 @@ func newServer(ctx context.Context, listenAddrs []string, db database.DB,
 		if s.txIndex != nil {
 			rpcsConfig.TxIndexer = s.txIndex
 		}
+		if s.nullDataIndex != nil {
+			rpcsConfig.NullDataIndexer = s.nullDataIndex
+		}
+		if s.utxoHistIndex != nil {
+			rpcsConfig.UtxoHistoryIndexer = s.utxoHistIndex
+		}
+		if s.mockTimeSource != nil {
+			rpcsConfig.MockTimeSource = s.mockTimeSource
+		}
 
 		s.rpcServer, err = rpcserver.New(&rpcsConfig)
 		if err != nil {
@@ -3823,6 +4649,14 @@ func initListeners(ctx context.Context, params *chaincfg.Params, amgr *addrmgr.A
 			srvrLog.Warnf("Can't listen on %s: %v", addr, err)
 			continue
 		}
+
+		// Associate any policies configured for the address with the
+		// connections accepted via the listener.
+		if policy, ok := cfg.listenPolicies[addr.String()]; ok {
+			srvrLog.Infof("Listening on %s with policies (onion: %v, "+
+				"norelay: %v)", addr, policy.onion, policy.noRelay)
+			listener = &policyListener{Listener: listener, policy: policy}
+		}
 		listeners = append(listeners, listener)
 	}
 
@@ -3872,8 +4706,13 @@ func initListeners(ctx context.Context, params *chaincfg.Params, amgr *addrmgr.A
 			// nil nat here is fine, just means no upnp on network.
 		}
 
-		// Add bound addresses to address manager to be advertised to peers.
+		// Add bound addresses to address manager to be advertised to peers
+		// excluding those for onion listeners since they are only reachable
+		// via the Tor hidden service.
 		for _, listener := range listeners {
+			if pl, ok := listener.(*policyListener); ok && pl.policy.onion {
+				continue
+			}
 			addr := listener.Addr().String()
 			err := addLocalAddress(amgr, addr, services)
 			if err != nil {
@@ -3965,8 +4804,9 @@ func addLocalAddress(addrMgr *addrmgr.AddrManager, addr string, services wire.Se
 
 // isWhitelisted returns whether the IP address is included in the whitelisted
 // networks and IPs.
-func isWhitelisted(addr net.Addr) bool {
-	if len(cfg.whitelists) == 0 {
+func (s *server) isWhitelisted(addr net.Addr) bool {
+	whitelists := s.reloadableCfg().whitelists
+	if len(whitelists) == 0 {
 		return false
 	}
 
@@ -3981,7 +4821,7 @@ func isWhitelisted(addr net.Addr) bool {
 		return false
 	}
 
-	for _, ipnet := range cfg.whitelists {
+	for _, ipnet := range whitelists {
 		if ipnet.Contains(ip) {
 			return true
 		}
@@ -1,5 +1,5 @@
 // Copyright (c) 2013-2016 The btcsuite developers
-// Copyright (c) 2015-2016 The Decred developers
+// Copyright (c) 2015-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -19,6 +19,11 @@ var shutdownRequestChannel = make(chan struct{})
 // shutdown.  This may be modified during init depending on the platform.
 var interruptSignals = []os.Signal{os.Interrupt}
 
+// reloadSignals defines the signals to catch in order to reload the config
+// options that can safely be changed at runtime.  This may be modified during
+// init depending on the platform.
+var reloadSignals []os.Signal
+
 // shutdownListener listens for OS Signals such as SIGINT (Ctrl+C) and shutdown
 // requests from shutdownRequestChannel.  It returns a context that is canceled
 // when either signal is received.
@@ -69,3 +74,33 @@ func shutdownRequested(ctx context.Context) bool {
 
 	return false
 }
+
+// reloadListener listens for OS signals such as SIGHUP and reloads the config
+// options that can safely be changed at runtime via the provided server each
+// time one is received.  It blocks until the provided context is canceled.
+func reloadListener(ctx context.Context, s *server) {
+	if len(reloadSignals) == 0 {
+		return
+	}
+
+	reloadChannel := make(chan os.Signal, 1)
+	signal.Notify(reloadChannel, reloadSignals...)
+	defer signal.Stop(reloadChannel)
+	for {
+		select {
+		case sig := <-reloadChannel:
+			dcrdLog.Infof("Received signal (%s).  Reloading config...", sig)
+			changes, err := s.reloadConfig()
+			if err != nil {
+				dcrdLog.Errorf("Unable to reload config: %v", err)
+				continue
+			}
+			if len(changes) == 0 {
+				dcrdLog.Info("Reloaded config without any changes")
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
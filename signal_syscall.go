@@ -12,5 +12,6 @@ import (
 )
 
 func init() {
-	interruptSignals = append(interruptSignals, syscall.SIGTERM, syscall.SIGHUP)
+	interruptSignals = append(interruptSignals, syscall.SIGTERM)
+	reloadSignals = append(reloadSignals, syscall.SIGHUP)
 }
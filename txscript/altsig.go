@@ -0,0 +1,160 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package txscript
+
+import (
+	"github.com/decred/dcrd/dcrec"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/schnorr"
+)
+
+// AltSigSuite defines the hooks and parameters required to support a specific
+// signature type via OP_CHECKSIGALT and the related standard script forms.
+//
+// Signature types are consensus critical, so the set of suites is fixed at
+// package initialization and new suites must only ever be introduced behind a
+// consensus vote.  This is accomplished by specifying script flags that must be
+// set in order for the suite to be active.  Since OP_CHECKSIGALT treats all
+// unknown signature types as successful, an inactive suite is treated exactly
+// the same as an unknown signature type, which means activating a new suite is
+// a soft fork.
+//
+// The address derivation hooks for each signature type are provided by the
+// stdaddr package keyed by the same signature type.
+type AltSigSuite struct {
+	// Name is a human-readable name for the signature type.
+	Name string
+
+	// RequiredFlags are the script flags that must all be set for the suite
+	// to be active.  Only the suites that predate the registry have a value of
+	// zero, which means the suite is always active.  All new suites must
+	// require a flag that is only set once the agenda that introduces them is
+	// active.
+	RequiredFlags ScriptFlags
+
+	// PubKeyLen is the required length of serialized public keys.
+	PubKeyLen int
+
+	// SigLen is the required length of serialized signatures excluding the
+	// trailing signature hash type byte.
+	SigLen int
+
+	// Verify returns whether or not the provided serialized signature is a
+	// valid signature of the given hash for the provided serialized public
+	// key.  It must return false when either the public key or signature fail
+	// to parse.
+	Verify func(pubKey, sig, hash []byte) bool
+
+	// IsStandardPubKey returns whether or not the provided serialized public
+	// key is in the encoding required by standard scripts.  It may be nil
+	// when every public key of the required length is standard.
+	IsStandardPubKey func(pubKey []byte) bool
+}
+
+// altSigSuites houses the supported alt signature suites keyed by their
+// signature type.  It must not be modified after package initialization.
+var altSigSuites = map[dcrec.SignatureType]*AltSigSuite{
+	dcrec.STEd25519: {
+		Name:      "ed25519",
+		PubKeyLen: 32,
+		SigLen:    64,
+		Verify: func(pubKey, sig, hash []byte) bool {
+			pubKeyEd, err := edwards.ParsePubKey(pubKey)
+			if err != nil {
+				return false
+			}
+			sigEd, err := edwards.ParseSignature(sig)
+			if err != nil {
+				return false
+			}
+			return edwards.Verify(pubKeyEd, hash, sigEd.GetR(), sigEd.GetS())
+		},
+	},
+	dcrec.STSchnorrSecp256k1: {
+		Name:      "schnorr-secp256k1",
+		PubKeyLen: 33,
+		SigLen:    64,
+		Verify: func(pubKey, sig, hash []byte) bool {
+			pubKeySec, err := schnorr.ParsePubKey(pubKey)
+			if err != nil {
+				return false
+			}
+			sigSec, err := schnorr.ParseSignature(sig)
+			if err != nil {
+				return false
+			}
+			return sigSec.Verify(hash, pubKeySec)
+		},
+		IsStandardPubKey: IsStrictCompressedPubKeyEncoding,
+	},
+}
+
+// LookupAltSigSuite returns a copy of the suite for the provided signature type
+// along with whether or not one exists.  Note that the returned suite might not
+// be active depending on the script flags.
+func LookupAltSigSuite(sigType dcrec.SignatureType) (AltSigSuite, bool) {
+	suite, ok := altSigSuites[sigType]
+	if !ok {
+		return AltSigSuite{}, false
+	}
+	return *suite, true
+}
+
+// IsStrictPubKeyEncodingForSuite returns whether or not the passed public key
+// adheres to the strict encoding requirements of the provided signature type
+// and is a valid public key for it.
+//
+// ECDSA secp256k1 public keys must be in either the compressed or uncompressed
+// format, Schnorr secp256k1 public keys must be in the compressed format, and
+// Ed25519 public keys must be exactly 32 bytes.  Public keys for any other
+// registered alt signature suites must be of the length required by the suite
+// and satisfy its standard encoding requirements, if any.  Unknown signature
+// types are not supported and always return false.
+//
+// Unlike the checks performed by the script engine, this also ensures the
+// public key actually parses, so callers such as wallets may use it to reject
+// invalid keys before they are used to build scripts.
+func IsStrictPubKeyEncodingForSuite(sigType dcrec.SignatureType, pubKey []byte) bool {
+	switch sigType {
+	case dcrec.STEcdsaSecp256k1:
+		if !isStrictPubKeyEncoding(pubKey) {
+			return false
+		}
+		_, err := secp256k1.ParsePubKey(pubKey)
+		return err == nil
+
+	case dcrec.STSchnorrSecp256k1:
+		if !IsStrictCompressedPubKeyEncoding(pubKey) {
+			return false
+		}
+		_, err := schnorr.ParsePubKey(pubKey)
+		return err == nil
+
+	case dcrec.STEd25519:
+		if len(pubKey) != 32 {
+			return false
+		}
+		_, err := edwards.ParsePubKey(pubKey)
+		return err == nil
+	}
+
+	suite, ok := altSigSuites[sigType]
+	if !ok || len(pubKey) != suite.PubKeyLen {
+		return false
+	}
+	return suite.IsStandardPubKey == nil || suite.IsStandardPubKey(pubKey)
+}
+
+// activeAltSigSuite returns the suite registered for the provided signature
+// type when it is active with the given script flags.  It returns nil when
+// there is no registered suite or it is not active.
+func activeAltSigSuite(sigType dcrec.SignatureType, flags ScriptFlags) *AltSigSuite {
+	suite, ok := altSigSuites[sigType]
+	if !ok || flags&suite.RequiredFlags != suite.RequiredFlags {
+		return nil
+	}
+	return suite
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package txscript
+
+import (
+	"testing"
+
+	"github.com/decred/dcrd/dcrec"
+)
+
+// TestAltSigSuiteRegistry ensures the fixed alt signature suites are well
+// formed, that only the suites that predate the registry are active without any
+// script flags, and that lookups do not allow the registry to be modified.
+func TestAltSigSuiteRegistry(t *testing.T) {
+	t.Parallel()
+
+	// alwaysActive houses the signature types that predate the registry and
+	// therefore are not gated behind any script flags.
+	alwaysActive := map[dcrec.SignatureType]struct{}{
+		dcrec.STEd25519:          {},
+		dcrec.STSchnorrSecp256k1: {},
+	}
+	for sigType := range alwaysActive {
+		if _, ok := LookupAltSigSuite(sigType); !ok {
+			t.Fatalf("suite for signature type %d not found", sigType)
+		}
+	}
+
+	for sigType, suite := range altSigSuites {
+		// The signature type must be representable by the maximum allowed
+		// script number length and can't be zero since that is reserved for
+		// ECDSA.
+		if sigType <= 0 || sigType > 127 {
+			t.Errorf("signature type %d is out of range", sigType)
+		}
+		if suite.Name == "" || suite.PubKeyLen <= 0 || suite.SigLen <= 0 ||
+			suite.Verify == nil {
+
+			t.Errorf("suite for signature type %d is malformed", sigType)
+		}
+
+		// All suites aside from the ones that predate the registry must be
+		// gated behind script flags that are only set by an agenda.
+		_, ok := alwaysActive[sigType]
+		if ok != (suite.RequiredFlags == 0) {
+			t.Errorf("suite for signature type %d has unexpected required "+
+				"flags %#x", sigType, suite.RequiredFlags)
+		}
+		if activeAltSigSuite(sigType, suite.RequiredFlags) != suite {
+			t.Errorf("suite for signature type %d is not active with the "+
+				"required flags", sigType)
+		}
+		if suite.RequiredFlags != 0 && activeAltSigSuite(sigType, 0) != nil {
+			t.Errorf("suite for signature type %d is active without the "+
+				"required flags", sigType)
+		}
+
+		// Ensure modifying a looked up suite does not modify the registry.
+		lookedUp, ok := LookupAltSigSuite(sigType)
+		if !ok || lookedUp.Name != suite.Name {
+			t.Errorf("lookup for signature type %d returned unexpected suite",
+				sigType)
+		}
+		lookedUp.RequiredFlags = 0
+		lookedUp.PubKeyLen = 0
+		if again, _ := LookupAltSigSuite(sigType); again.PubKeyLen == 0 ||
+			again.RequiredFlags != suite.RequiredFlags {
+
+			t.Errorf("lookup for signature type %d allowed modifying the "+
+				"registry", sigType)
+		}
+	}
+
+	// Ensure unknown signature types are neither found nor active.
+	const unknownSigType = dcrec.SignatureType(127)
+	if _, ok := LookupAltSigSuite(unknownSigType); ok {
+		t.Fatalf("suite for unknown signature type %d found", unknownSigType)
+	}
+	if activeAltSigSuite(unknownSigType, ^ScriptFlags(0)) != nil {
+		t.Fatalf("suite for unknown signature type %d is active",
+			unknownSigType)
+	}
+}
+
+// TestIsStrictPubKeyEncodingForSuite ensures checking strict public key
+// encoding for each of the supported signature types works as expected.
+func TestIsStrictPubKeyEncodingForSuite(t *testing.T) {
+	t.Parallel()
+
+	const (
+		compressedEven = "02" +
+			"ce0b14fb842b1ba549fdd675c98075f12e9c510f8ef52bd021a9a1f4809d3b4d"
+		compressedOdd = "03" +
+			"2689c7c2dab13309fb143e0e8fe396342521887e976690b6b47f5b2a4b7d448e"
+		compressedNotOnCurve = "02" +
+			"0000000000000000000000000000000000000000000000000000000000000005"
+		uncompressed = "04" +
+			"11db93e1dcdb8a016b49840f8c53bc1eb68a382e97b1482ecad7b148a6909a5c" +
+			"b2e0eaddfb84ccf9744464f82e160bfa9b8b64f9d4c03f999b8643f656b412a3"
+		uncompressedNotOnCurve = "04" +
+			"15db93e1dcdb8a016b49840f8c53bc1eb68a382e97b1482ecad7b148a6909a5c" +
+			"b2e0eaddfb84ccf9744464f82e160bfa9b8b64f9d4c03f999b8643f656b412a3"
+		hybrid = "06" +
+			"79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+			"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"
+		ed25519           = "3b6a27bcceb6a42d62a3a8d02a6f0d73653215771de243a63ac048a18b59da29"
+		ed25519NotOnCurve = "02000000000000000000000000000000" +
+			"00000000000000000000000000000000"
+	)
+
+	tests := []struct {
+		name    string
+		sigType dcrec.SignatureType
+		key     string
+		want    bool
+	}{{
+		name:    "ecdsa compressed ok (ybit = 0)",
+		sigType: dcrec.STEcdsaSecp256k1,
+		key:     compressedEven,
+		want:    true,
+	}, {
+		name:    "ecdsa compressed ok (ybit = 1)",
+		sigType: dcrec.STEcdsaSecp256k1,
+		key:     compressedOdd,
+		want:    true,
+	}, {
+		name:    "ecdsa uncompressed ok",
+		sigType: dcrec.STEcdsaSecp256k1,
+		key:     uncompressed,
+		want:    true,
+	}, {
+		name:    "ecdsa empty rejected",
+		sigType: dcrec.STEcdsaSecp256k1,
+		key:     "",
+		want:    false,
+	}, {
+		name:    "ecdsa hybrid rejected",
+		sigType: dcrec.STEcdsaSecp256k1,
+		key:     hybrid,
+		want:    false,
+	}, {
+		name:    "ecdsa compressed not on curve rejected",
+		sigType: dcrec.STEcdsaSecp256k1,
+		key:     compressedNotOnCurve,
+		want:    false,
+	}, {
+		name:    "ecdsa uncompressed not on curve rejected",
+		sigType: dcrec.STEcdsaSecp256k1,
+		key:     uncompressedNotOnCurve,
+		want:    false,
+	}, {
+		name:    "ecdsa ed25519 key rejected",
+		sigType: dcrec.STEcdsaSecp256k1,
+		key:     ed25519,
+		want:    false,
+	}, {
+		name:    "schnorr compressed ok (ybit = 0)",
+		sigType: dcrec.STSchnorrSecp256k1,
+		key:     compressedEven,
+		want:    true,
+	}, {
+		name:    "schnorr compressed ok (ybit = 1)",
+		sigType: dcrec.STSchnorrSecp256k1,
+		key:     compressedOdd,
+		want:    true,
+	}, {
+		name:    "schnorr uncompressed rejected",
+		sigType: dcrec.STSchnorrSecp256k1,
+		key:     uncompressed,
+		want:    false,
+	}, {
+		name:    "schnorr compressed not on curve rejected",
+		sigType: dcrec.STSchnorrSecp256k1,
+		key:     compressedNotOnCurve,
+		want:    false,
+	}, {
+		name:    "ed25519 ok",
+		sigType: dcrec.STEd25519,
+		key:     ed25519,
+		want:    true,
+	}, {
+		name:    "ed25519 not on curve rejected",
+		sigType: dcrec.STEd25519,
+		key:     ed25519NotOnCurve,
+		want:    false,
+	}, {
+		name:    "ed25519 short rejected",
+		sigType: dcrec.STEd25519,
+		key:     ed25519[:62],
+		want:    false,
+	}, {
+		name:    "ed25519 secp256k1 key rejected",
+		sigType: dcrec.STEd25519,
+		key:     compressedEven,
+		want:    false,
+	}, {
+		name:    "unknown signature type rejected",
+		sigType: 100,
+		key:     compressedEven,
+		want:    false,
+	}}
+
+	for _, test := range tests {
+		got := IsStrictPubKeyEncodingForSuite(test.sigType, hexToBytes(test.key))
+		if got != test.want {
+			t.Errorf("%s: unexpected result -- got %v, want %v", test.name,
+				got, test.want)
+		}
+	}
+}
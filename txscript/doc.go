@@ -35,5 +35,10 @@ errors.As functions.  This allows the caller to programmatically determine the
 specific error while still providing rich error messages with contextual
 information.  See the constants defined with ErrorKind in the package
 documentation for a full list.
+
+Errors that result from executing a specific opcode also include an
+ErrorLocation which identifies the script, opcode index, and byte offset of the
+failing opcode along with the depth of the data stack at the time of the
+failure.
 */
 package txscript
@@ -468,6 +468,24 @@ func (vm *Engine) CheckErrorCondition(finalScript bool) error {
 	return nil
 }
 
+// withErrorLocation returns the passed error with the location of the opcode
+// at the provided byte offset within the current script attached when it is a
+// script error that does not already have a location.  All other errors are
+// returned unmodified.
+func (vm *Engine) withErrorLocation(err error, byteOffset int32) error {
+	serr, ok := err.(Error)
+	if !ok || serr.Location != nil {
+		return err
+	}
+	serr.Location = &ErrorLocation{
+		ScriptIndex: vm.scriptIdx,
+		OpcodeIndex: vm.opcodeIdx,
+		ByteOffset:  byteOffset,
+		StackDepth:  vm.dstack.Depth(),
+	}
+	return serr
+}
+
 // Step executes the next instruction and moves the program counter to the next
 // opcode in the script, or the next script if the current has ended.  Step will
 // return true in the case that the last opcode was successfully executed.
@@ -480,7 +498,9 @@ func (vm *Engine) Step() (done bool, err error) {
 		return true, err
 	}
 
-	// Attempt to parse the next opcode from the current script.
+	// Attempt to parse the next opcode from the current script while keeping
+	// track of its offset for the purposes of reporting failures.
+	opcodeOffset := vm.tokenizer.ByteIndex()
 	if !vm.tokenizer.Next() {
 		// Note that due to the fact that all scripts are checked for parse
 		// failures before this code ever runs, there should never be an error
@@ -501,7 +521,7 @@ func (vm *Engine) Step() (done bool, err error) {
 	// maximum script element sizes, and conditionals.
 	err = vm.executeOpcode(vm.tokenizer.op, vm.tokenizer.Data())
 	if err != nil {
-		return true, err
+		return true, vm.withErrorLocation(err, opcodeOffset)
 	}
 
 	// The number of elements in the combination of the data and alt stacks
@@ -510,7 +530,8 @@ func (vm *Engine) Step() (done bool, err error) {
 	if combinedStackSize > MaxStackSize {
 		str := fmt.Sprintf("combined stack size %d > max allowed %d",
 			combinedStackSize, MaxStackSize)
-		return false, scriptError(ErrStackOverflow, str)
+		err := scriptError(ErrStackOverflow, str)
+		return false, vm.withErrorLocation(err, opcodeOffset)
 	}
 
 	// Prepare for next instruction.
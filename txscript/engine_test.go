@@ -149,3 +149,82 @@ func TestCheckErrorCondition(t *testing.T) {
 		t.Errorf("unexpected error %v on final check", err)
 	}
 }
+
+// TestErrorLocation ensures script errors that result from executing an opcode
+// carry the location of the failing opcode while other errors do not.
+func TestErrorLocation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		sigScript string
+		pkScript  string
+		wantErr   ErrorKind
+		wantLoc   *ErrorLocation
+	}{{
+		name:      "early return in public key script",
+		sigScript: "1",
+		pkScript:  "2 DATA_1 0x20 DROP RETURN",
+		wantErr:   ErrEarlyReturn,
+		wantLoc: &ErrorLocation{
+			ScriptIndex: 1,
+			OpcodeIndex: 3,
+			ByteOffset:  4,
+			StackDepth:  2,
+		},
+	}, {
+		name:      "failed verify in signature script",
+		sigScript: "1 0 VERIFY",
+		pkScript:  "TRUE",
+		wantErr:   ErrVerify,
+		wantLoc: &ErrorLocation{
+			ScriptIndex: 0,
+			OpcodeIndex: 2,
+			ByteOffset:  2,
+			StackDepth:  1,
+		},
+	}, {
+		name:      "false result at end of execution",
+		sigScript: "1",
+		pkScript:  "DROP 0",
+		wantErr:   ErrEvalFalse,
+	}}
+
+	for _, test := range tests {
+		tx := &wire.MsgTx{
+			SerType: wire.TxSerializeFull,
+			Version: 1,
+			TxIn: []*wire.TxIn{{
+				SignatureScript: mustParseShortFormV0(test.sigScript),
+				Sequence:        wire.MaxTxInSequenceNum,
+			}},
+			TxOut: []*wire.TxOut{{Value: 1000000000}},
+		}
+		pkScript := mustParseShortFormV0(test.pkScript)
+		vm, err := NewEngine(pkScript, tx, 0, 0, 0, nil)
+		if err != nil {
+			t.Fatalf("%q: failed to create engine: %v", test.name, err)
+		}
+
+		err = vm.Execute()
+		var serr Error
+		if !errors.As(err, &serr) || !errors.Is(err, test.wantErr) {
+			t.Fatalf("%q: unexpected error -- got %v, want %v", test.name, err,
+				test.wantErr)
+		}
+		if test.wantLoc == nil {
+			if serr.Location != nil {
+				t.Fatalf("%q: unexpected error location %+v", test.name,
+					*serr.Location)
+			}
+			continue
+		}
+		if serr.Location == nil {
+			t.Fatalf("%q: missing error location", test.name)
+		}
+		if *serr.Location != *test.wantLoc {
+			t.Fatalf("%q: unexpected error location -- got %+v, want %+v",
+				test.name, *serr.Location, *test.wantLoc)
+		}
+	}
+}
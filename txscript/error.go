@@ -7,6 +7,7 @@ package txscript
 
 import (
 	"errors"
+	"fmt"
 )
 
 // ErrorKind identifies a kind of script error.
@@ -312,6 +313,28 @@ func (e ErrorKind) Error() string {
 	return string(e)
 }
 
+// ErrorLocation identifies the location within the scripts executed by the
+// script engine at which a script execution failure occurred.
+type ErrorLocation struct {
+	// ScriptIndex is the index of the script that was executing at the time
+	// of the failure.  The signature script is index 0, the public key script
+	// is index 1, and the redeem script, when executing a pay-to-script-hash
+	// script, is index 2.
+	ScriptIndex int
+
+	// OpcodeIndex is the zero-based index of the failing opcode within the
+	// script.
+	OpcodeIndex int
+
+	// ByteOffset is the offset of the failing opcode in bytes from the start
+	// of the script.
+	ByteOffset int32
+
+	// StackDepth is the number of items on the data stack at the time of the
+	// failure.
+	StackDepth int32
+}
+
 // Error identifies a script-related error.  It is used to indicate three
 // classes of errors:
 //  1. Script execution failures due to violating one of the many requirements
@@ -321,14 +344,28 @@ func (e ErrorKind) Error() string {
 //
 // It has full support for errors.Is and errors.As, so the caller can ascertain
 // the specific reason for the error by checking the underlying error.
+//
+// Errors that result from executing a specific opcode additionally carry the
+// location of the failure so callers such as RPC servers and debuggers are able
+// to point at the exact opcode that caused it.
 type Error struct {
 	Err         error
 	Description string
+
+	// Location is the location of the failing opcode in the executed scripts.
+	// It is nil for errors that are not the result of executing an opcode.
+	Location *ErrorLocation
 }
 
 // Error satisfies the error interface and prints human-readable errors.
 func (e Error) Error() string {
-	return e.Description
+	if e.Location == nil {
+		return e.Description
+	}
+	loc := e.Location
+	return fmt.Sprintf("%s (script %d, opcode %d, byte offset %d, stack "+
+		"depth %d)", e.Description, loc.ScriptIndex, loc.OpcodeIndex,
+		loc.ByteOffset, loc.StackDepth)
 }
 
 // Unwrap returns the underlying wrapped error.
@@ -103,6 +103,19 @@ func TestError(t *testing.T) {
 			Error{Description: "human-readable error"},
 			"human-readable error",
 		},
+		{
+			Error{
+				Description: "error with location",
+				Location: &ErrorLocation{
+					ScriptIndex: 1,
+					OpcodeIndex: 3,
+					ByteOffset:  4,
+					StackDepth:  2,
+				},
+			},
+			"error with location (script 1, opcode 3, byte offset 4, " +
+				"stack depth 2)",
+		},
 	}
 
 	t.Logf("Running %d tests", len(tests))
@@ -17,3 +17,5 @@ require (
 )
 
 require github.com/agl/ed25519 v0.0.0-20170116200512-5312a6153412 // indirect
+
+replace github.com/decred/dcrd/dcrec/secp256k1/v4 => ../dcrec/secp256k1
@@ -17,10 +17,8 @@ import (
 	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/crypto/ripemd160"
 	"github.com/decred/dcrd/dcrec"
-	"github.com/decred/dcrd/dcrec/edwards/v2"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
-	"github.com/decred/dcrd/dcrec/secp256k1/v4/schnorr"
 	"github.com/decred/dcrd/wire"
 )
 
@@ -2479,8 +2477,8 @@ func opcodeCheckSig(op *opcode, data []byte, vm *Engine) error {
 	if hashType&sigHashMask == SigHashAll && optimizeSigVerification {
 		prefixHash = vm.tx.CachedTxHash()
 	}
-	hash, err := calcSignatureHash(subScript, hashType, &vm.tx, vm.txIdx,
-		prefixHash)
+	hash, err := calcSignatureHash(vm.version, subScript, hashType, &vm.tx,
+		vm.txIdx, prefixHash)
 	if err != nil {
 		vm.dstack.PushBool(false)
 		return nil
@@ -2712,8 +2710,8 @@ func opcodeCheckMultiSig(op *opcode, data []byte, vm *Engine) error {
 		if hashType&sigHashMask == SigHashAll && optimizeSigVerification {
 			prefixHash = vm.tx.CachedTxHash()
 		}
-		hash, err := calcSignatureHash(script, hashType, &vm.tx, vm.txIdx,
-			prefixHash)
+		hash, err := calcSignatureHash(vm.version, script, hashType, &vm.tx,
+			vm.txIdx, prefixHash)
 		if err != nil {
 			return err
 		}
@@ -2759,32 +2757,34 @@ func opcodeCheckMultiSigVerify(op *opcode, data []byte, vm *Engine) error {
 
 // opcodeCheckSigAlt accepts a three item stack and pops off the first three
 // items. The first item is a signature type (1-255, can not be zero or the
-// soft fork will fail). Any unused signature types return true, so that future
-// alternative signature methods may be added. The second item popped off the
-// stack is the public key; wrong size pubkeys return false. The third item to
-// be popped off the stack is the signature along with the hash type at the
-// end; wrong sized signatures also return false.
+// soft fork will fail). Any unused or inactive signature types return true, so
+// that future alternative signature methods may be added. The second item
+// popped off the stack is the public key; wrong size pubkeys return false. The
+// third item to be popped off the stack is the signature along with the hash
+// type at the end; wrong sized signatures also return false.
 // Failing to parse a pubkey or signature results in false.
 // After parsing, the signature and pubkey are verified against the message
 // (the hash of this transaction and its input).
+//
+// The supported signature types along with their required key and signature
+// sizes and verification are defined by the registered alt signature suites.
+// See AltSigSuite for details.
 func opcodeCheckSigAlt(op *opcode, data []byte, vm *Engine) error {
 	sigType, err := vm.dstack.PopInt(altSigSuitesMaxscriptNumLen)
 	if err != nil {
 		return err
 	}
 
-	switch sigType {
-	case 0:
-		// Zero case; pre-softfork clients will return 0 in this case as well.
+	// Zero case; pre-softfork clients will return 0 in this case as well.
+	if sigType == 0 {
 		vm.dstack.PushBool(false)
 		return nil
-	case dcrec.STEd25519:
-		break
-	case dcrec.STSchnorrSecp256k1:
-		break
-	default:
-		// Caveat: All unknown signature types return true, allowing for future
-		// softforks with other new signature types.
+	}
+
+	// Caveat: All unknown and inactive signature types return true, allowing
+	// for future softforks with other new signature types.
+	suite := activeAltSigSuite(dcrec.SignatureType(sigType), vm.flags)
+	if suite == nil {
 		vm.dstack.PushBool(true)
 		return nil
 	}
@@ -2794,20 +2794,12 @@ func opcodeCheckSigAlt(op *opcode, data []byte, vm *Engine) error {
 		return err
 	}
 
-	// Check the public key lengths. Only 33-byte compressed secp256k1 keys
-	// are allowed for secp256k1 Schnorr signatures, while 32 byte keys
-	// are used for Curve25519.
-	switch sigType {
-	case dcrec.STEd25519:
-		if len(pkBytes) != 32 {
-			vm.dstack.PushBool(false)
-			return nil
-		}
-	case dcrec.STSchnorrSecp256k1:
-		if len(pkBytes) != 33 {
-			vm.dstack.PushBool(false)
-			return nil
-		}
+	// Check the public key length.  For example, only 33-byte compressed
+	// secp256k1 keys are allowed for secp256k1 Schnorr signatures, while 32
+	// byte keys are used for Curve25519.
+	if len(pkBytes) != suite.PubKeyLen {
+		vm.dstack.PushBool(false)
+		return nil
 	}
 
 	fullSigBytes, err := vm.dstack.PopByteArray()
@@ -2815,19 +2807,12 @@ func opcodeCheckSigAlt(op *opcode, data []byte, vm *Engine) error {
 		return err
 	}
 
-	// Schnorr signatures are 65 bytes in length (64 bytes for [r,s] and
-	// 1 byte appended to the end for hashType).
-	switch sigType {
-	case dcrec.STEd25519:
-		if len(fullSigBytes) != 65 {
-			vm.dstack.PushBool(false)
-			return nil
-		}
-	case dcrec.STSchnorrSecp256k1:
-		if len(fullSigBytes) != 65 {
-			vm.dstack.PushBool(false)
-			return nil
-		}
+	// Check the signature length including the hash type byte appended to
+	// the end.  For example, Schnorr signatures are 65 bytes in length (64
+	// bytes for [r,s] and 1 byte for hashType).
+	if len(fullSigBytes) != suite.SigLen+1 {
+		vm.dstack.PushBool(false)
+		return nil
 	}
 
 	// Trim off hashtype from the signature string and check if the
@@ -2863,48 +2848,16 @@ func opcodeCheckSigAlt(op *opcode, data []byte, vm *Engine) error {
 			prefixHash = ph
 		}
 	}
-	hash, err := calcSignatureHash(subScript, hashType, &vm.tx, vm.txIdx,
-		prefixHash)
+	hash, err := calcSignatureHash(vm.version, subScript, hashType, &vm.tx,
+		vm.txIdx, prefixHash)
 	if err != nil {
 		vm.dstack.PushBool(false)
 		return nil
 	}
 
-	// Get the public key from bytes.
-	switch sigType {
-	case dcrec.STEd25519:
-		pubKeyEd, err := edwards.ParsePubKey(pkBytes)
-		if err != nil {
-			vm.dstack.PushBool(false)
-			return nil
-		}
-		sigEd, err := edwards.ParseSignature(sigBytes)
-		if err != nil {
-			vm.dstack.PushBool(false)
-			return nil
-		}
-		ok := edwards.Verify(pubKeyEd, hash, sigEd.GetR(), sigEd.GetS())
-		vm.dstack.PushBool(ok)
-		return nil
-	case dcrec.STSchnorrSecp256k1:
-		pubKeySec, err := schnorr.ParsePubKey(pkBytes)
-		if err != nil {
-			vm.dstack.PushBool(false)
-			return nil
-		}
-		sigSec, err := schnorr.ParseSignature(sigBytes)
-		if err != nil {
-			vm.dstack.PushBool(false)
-			return nil
-		}
-		ok := sigSec.Verify(hash, pubKeySec)
-		vm.dstack.PushBool(ok)
-		return nil
-	}
-
-	// Fallthrough of some kind automatically results in false, but
-	// this should never be hit.
-	vm.dstack.PushBool(false)
+	// Parse the public key and signature and verify the signature.  Failing
+	// to parse either of them results in false.
+	vm.dstack.PushBool(suite.Verify(pkBytes, sigBytes, hash))
 	return nil
 }
 
@@ -639,10 +639,46 @@ func parseSigHashExpectedResult(expected string) (error, error) {
 		expected)
 }
 
+// sigHashReferenceTests houses the paths to the reference signature hash
+// calculation test vectors keyed by the script version they apply to.  New
+// script versions that define a signature hash algorithm should add their
+// vectors here.
+var sigHashReferenceTests = map[uint16]string{
+	0: "data/sighash.json",
+}
+
 // TestCalcSignatureHashReference runs the reference signature hash calculation
-// tests in sighash.json.
+// tests for every script version with defined test vectors and ensures every
+// script version with a signature hash algorithm has test vectors.
 func TestCalcSignatureHashReference(t *testing.T) {
-	file, err := os.ReadFile("data/sighash.json")
+	for scriptVersion := range sigHashers {
+		if _, ok := sigHashReferenceTests[scriptVersion]; !ok {
+			t.Errorf("no signature hash test vectors for script version %d",
+				scriptVersion)
+		}
+	}
+	for scriptVersion, path := range sigHashReferenceTests {
+		scriptVersion, path := scriptVersion, path
+		t.Run(path, func(t *testing.T) {
+			testCalcSignatureHashReference(t, scriptVersion, path)
+		})
+	}
+
+	// Ensure attempting to calculate a signature hash for a script version
+	// without a defined signature hash algorithm is rejected.
+	const unsupportedVersion = 0xffff
+	_, err := CalcScriptVersionSignatureHash(unsupportedVersion, nil,
+		SigHashAll, wire.NewMsgTx(), 0, nil)
+	if !errors.Is(err, ErrUnsupportedScriptVersion) {
+		t.Errorf("mismatched err for unsupported script version -- got %v, "+
+			"want %v", err, ErrUnsupportedScriptVersion)
+	}
+}
+
+// testCalcSignatureHashReference runs the reference signature hash calculation
+// tests in the file at the provided path for the given script version.
+func testCalcSignatureHashReference(t *testing.T, scriptVersion uint16, path string) {
+	file, err := os.ReadFile(path)
 	if err != nil {
 		t.Fatalf("TestCalcSignatureHash: %v\n", err)
 	}
@@ -653,7 +689,6 @@ func TestCalcSignatureHashReference(t *testing.T) {
 		t.Fatalf("TestCalcSignatureHash couldn't Unmarshal: %v\n", err)
 	}
 
-	const scriptVersion = 0
 	for i, test := range tests {
 		// Skip comment lines.
 		if len(test) == 1 {
@@ -740,8 +775,8 @@ func TestCalcSignatureHashReference(t *testing.T) {
 		}
 
 		// Calculate the signature hash and verify expected result.
-		hash, err := CalcSignatureHash(subScript, hashType, &tx,
-			int(inputIdxF64), nil)
+		hash, err := CalcScriptVersionSignatureHash(scriptVersion, subScript,
+			hashType, &tx, int(inputIdxF64), nil)
 		if !errors.Is(err, expectedErr) {
 			t.Errorf("Test #%d: want error kind %v, got err: %v (%T)", i,
 				expectedErr, err, err)
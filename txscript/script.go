@@ -8,6 +8,7 @@ package txscript
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 	"strings"
 
 	"github.com/decred/dcrd/chaincfg/chainhash"
@@ -20,6 +21,44 @@ const (
 	MaxScriptElementSize  = 2048 // Max bytes pushable to the stack.
 )
 
+// MultiSigScriptLimits houses the limits that apply to multi-signature scripts
+// for a given script version.
+type MultiSigScriptLimits struct {
+	// MaxPubKeys is the maximum number of public keys a multi-signature
+	// script may contain.
+	MaxPubKeys int
+
+	// MaxScriptSize is the maximum allowed length of a multi-signature
+	// script.
+	MaxScriptSize int
+}
+
+// multiSigLimits houses the multi-signature script limits keyed by the script
+// version they apply to.
+var multiSigLimits = map[uint16]MultiSigScriptLimits{
+	0: {
+		MaxPubKeys:    MaxPubKeysPerMultiSig,
+		MaxScriptSize: MaxScriptSize,
+	},
+}
+
+// MultiSigLimits returns the limits that apply to multi-signature scripts for
+// the provided script version.  An error with the ErrUnsupportedScriptVersion
+// kind is returned when the script version does not have defined limits.
+//
+// Callers should prefer this over the MaxPubKeysPerMultiSig and MaxScriptSize
+// constants since future script versions may define different limits.
+func MultiSigLimits(scriptVersion uint16) (MultiSigScriptLimits, error) {
+	limits, ok := multiSigLimits[scriptVersion]
+	if !ok {
+		str := fmt.Sprintf("no multi-signature script limits are defined "+
+			"for script version %d", scriptVersion)
+		return MultiSigScriptLimits{}, scriptError(ErrUnsupportedScriptVersion,
+			str)
+	}
+	return limits, nil
+}
+
 // IsSmallInt returns whether or not the opcode is considered a small integer,
 // which is an OP_0, or OP_1 through OP_16.
 //
@@ -733,3 +733,49 @@ func TestGenerateSSGenVotes(t *testing.T) {
 		}
 	}
 }
+
+// TestMultiSigLimits ensures the multi-signature script limits are returned as
+// expected for supported script versions and that unsupported script versions
+// are rejected.
+func TestMultiSigLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    uint16
+		maxPubKeys int
+		maxSize    int
+		err        error
+	}{{
+		name:       "version 0",
+		version:    0,
+		maxPubKeys: 20,
+		maxSize:    16384,
+	}, {
+		name:    "unsupported version 1",
+		version: 1,
+		err:     ErrUnsupportedScriptVersion,
+	}, {
+		name:    "unsupported max version",
+		version: 65535,
+		err:     ErrUnsupportedScriptVersion,
+	}}
+
+	for _, test := range tests {
+		limits, err := MultiSigLimits(test.version)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%q: unexpected error -- got %v, want %v", test.name,
+				err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if limits.MaxPubKeys != test.maxPubKeys {
+			t.Errorf("%q: unexpected max pubkeys -- got %d, want %d",
+				test.name, limits.MaxPubKeys, test.maxPubKeys)
+		}
+		if limits.MaxScriptSize != test.maxSize {
+			t.Errorf("%q: unexpected max script size -- got %d, want %d",
+				test.name, limits.MaxScriptSize, test.maxSize)
+		}
+	}
+}
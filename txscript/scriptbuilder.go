@@ -292,3 +292,27 @@ func NewScriptBuilder() *ScriptBuilder {
 		script: make([]byte, 0, defaultScriptAlloc),
 	}
 }
+
+// ComposeSigScript returns a signature script that consists of canonical data
+// pushes of each of the provided items followed by a canonical data push of the
+// provided redeem script.  This is the form required to redeem a
+// pay-to-script-hash output since the redeem script must be the final data
+// push of the signature script.
+//
+// An error of type ErrScriptNotCanonical is returned when any of the items or
+// the redeem script is larger than MaxScriptElementSize or the resulting script
+// would exceed MaxScriptSize since such scripts can never be executed.
+//
+// NOTE: This function is only valid for version 0 scripts.
+func ComposeSigScript(pushes [][]byte, redeemScript []byte) ([]byte, error) {
+	builder := NewScriptBuilder()
+	for _, data := range pushes {
+		builder.AddData(data)
+	}
+	builder.AddData(redeemScript)
+	script, err := builder.Script()
+	if err != nil {
+		return nil, err
+	}
+	return script, nil
+}
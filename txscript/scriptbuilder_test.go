@@ -8,6 +8,7 @@ package txscript
 import (
 	"bytes"
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -412,3 +413,85 @@ func TestErroredScript(t *testing.T) {
 		t.Fatal("ErrScriptNotCanonical.Error does not have any text")
 	}
 }
+
+// TestComposeSigScript ensures composing pay-to-script-hash signature scripts
+// produces the expected canonical pushes and rejects items that can't be
+// pushed.
+func TestComposeSigScript(t *testing.T) {
+	t.Parallel()
+
+	redeemScript := mustParseShortFormV0("1 DATA_33 0x02" +
+		strings.Repeat("01", 32) + " 1 CHECKMULTISIG")
+	tests := []struct {
+		name         string
+		pushes       [][]byte
+		redeemScript []byte
+		expected     []byte
+		canonical    bool
+	}{{
+		name:         "redeem script only",
+		redeemScript: redeemScript,
+		expected:     append([]byte{OP_DATA_37}, redeemScript...),
+		canonical:    true,
+	}, {
+		name:         "small int and empty pushes",
+		pushes:       [][]byte{nil, {0x05}},
+		redeemScript: []byte{OP_TRUE},
+		expected:     []byte{OP_0, OP_5, OP_DATA_1, OP_TRUE},
+		canonical:    true,
+	}, {
+		name:         "signature and redeem script",
+		pushes:       [][]byte{bytes.Repeat([]byte{0x30}, 72)},
+		redeemScript: redeemScript,
+		expected: append(append(append([]byte{OP_DATA_72},
+			bytes.Repeat([]byte{0x30}, 72)...), OP_DATA_37),
+			redeemScript...),
+		canonical: true,
+	}, {
+		name:         "oversized push",
+		pushes:       [][]byte{make([]byte, MaxScriptElementSize+1)},
+		redeemScript: redeemScript,
+		canonical:    false,
+	}, {
+		name:         "oversized redeem script",
+		redeemScript: make([]byte, MaxScriptElementSize+1),
+		canonical:    false,
+	}, {
+		name: "exceeds max script size",
+		pushes: [][]byte{
+			make([]byte, MaxScriptElementSize),
+			make([]byte, MaxScriptElementSize),
+			make([]byte, MaxScriptElementSize),
+			make([]byte, MaxScriptElementSize),
+			make([]byte, MaxScriptElementSize),
+			make([]byte, MaxScriptElementSize),
+			make([]byte, MaxScriptElementSize),
+		},
+		redeemScript: make([]byte, MaxScriptElementSize),
+		canonical:    false,
+	}}
+
+	for _, test := range tests {
+		script, err := ComposeSigScript(test.pushes, test.redeemScript)
+		if !test.canonical {
+			var e ErrScriptNotCanonical
+			if !errors.As(err, &e) {
+				t.Errorf("%q: expected ErrScriptNotCanonical, got %v",
+					test.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !bytes.Equal(script, test.expected) {
+			t.Errorf("%q: unexpected script -- got %x, want %x", test.name,
+				script, test.expected)
+			continue
+		}
+		if !IsPushOnlyScript(script) {
+			t.Errorf("%q: composed script is not push only", test.name)
+		}
+	}
+}
@@ -221,12 +221,74 @@ func sigHashWitnessSerializeSize(txIns []*wire.TxIn, signScript []byte) int {
 		len(signScript)
 }
 
+// sigHasher describes a signature hash algorithm for a specific script version.
+// This allows new script versions to make use of different signature hash
+// algorithms without affecting the consensus rules for existing versions.
+type sigHasher interface {
+	// calcSignatureHash computes the signature hash for the specified input
+	// of the target transaction observing the desired signature hash type.
+	// The cached prefix parameter allows the caller to optimize the
+	// calculation by providing the prefix hash to be reused in the case of
+	// SigHashAll without the SigHashAnyOneCanPay flag set when the algorithm
+	// supports it.
+	calcSignatureHash(signScript []byte, hashType SigHashType, tx *wire.MsgTx, idx int, cachedPrefix *chainhash.Hash) ([]byte, error)
+}
+
+// sigHasherV0 implements the sigHasher interface for version 0 scripts.
+type sigHasherV0 struct{}
+
+// Ensure sigHasherV0 implements the sigHasher interface.
+var _ sigHasher = sigHasherV0{}
+
+// calcSignatureHash computes the signature hash for the specified input of the
+// target transaction according to the version 0 signature hash algorithm.
+//
+// This is part of the sigHasher interface.
+func (sigHasherV0) calcSignatureHash(signScript []byte, hashType SigHashType, tx *wire.MsgTx, idx int, cachedPrefix *chainhash.Hash) ([]byte, error) {
+	return calcSignatureHashV0(signScript, hashType, tx, idx, cachedPrefix)
+}
+
+// sigHashers houses the signature hash algorithms keyed by the script version
+// they apply to.
+var sigHashers = map[uint16]sigHasher{
+	0: sigHasherV0{},
+}
+
+// sigHasherForVersion returns the signature hash algorithm for the provided
+// script version.  An error is returned when the script version does not have
+// a defined signature hash algorithm.
+func sigHasherForVersion(scriptVersion uint16) (sigHasher, error) {
+	hasher, ok := sigHashers[scriptVersion]
+	if !ok {
+		str := fmt.Sprintf("no signature hash algorithm is defined for "+
+			"script version %d", scriptVersion)
+		return nil, scriptError(ErrUnsupportedScriptVersion, str)
+	}
+	return hasher, nil
+}
+
 // calcSignatureHash computes the signature hash for the specified input of the
-// target transaction observing the desired signature hash type.  The cached
+// target transaction observing the desired signature hash type according to
+// the signature hash algorithm for the provided script version.  The cached
 // prefix parameter allows the caller to optimize the calculation by providing
 // the prefix hash to be reused in the case of SigHashAll without the
 // SigHashAnyOneCanPay flag set.
-func calcSignatureHash(signScript []byte, hashType SigHashType, tx *wire.MsgTx, idx int, cachedPrefix *chainhash.Hash) ([]byte, error) {
+func calcSignatureHash(scriptVersion uint16, signScript []byte, hashType SigHashType, tx *wire.MsgTx, idx int, cachedPrefix *chainhash.Hash) ([]byte, error) {
+	hasher, err := sigHasherForVersion(scriptVersion)
+	if err != nil {
+		return nil, err
+	}
+	return hasher.calcSignatureHash(signScript, hashType, tx, idx,
+		cachedPrefix)
+}
+
+// calcSignatureHashV0 computes the signature hash for the specified input of
+// the target transaction observing the desired signature hash type according
+// to the version 0 signature hash algorithm.  The cached prefix parameter
+// allows the caller to optimize the calculation by providing the prefix hash
+// to be reused in the case of SigHashAll without the SigHashAnyOneCanPay flag
+// set.
+func calcSignatureHashV0(signScript []byte, hashType SigHashType, tx *wire.MsgTx, idx int, cachedPrefix *chainhash.Hash) ([]byte, error) {
 	// The SigHashSingle signature type signs only the corresponding input
 	// and output (the output with the same index number as the input).
 	//
@@ -450,9 +512,27 @@ func calcSignatureHash(signScript []byte, hashType SigHashType, tx *wire.MsgTx,
 // versions.
 func CalcSignatureHash(script []byte, hashType SigHashType, tx *wire.MsgTx, idx int, cachedPrefix *chainhash.Hash) ([]byte, error) {
 	const scriptVersion = 0
+	return CalcScriptVersionSignatureHash(scriptVersion, script, hashType, tx,
+		idx, cachedPrefix)
+}
+
+// CalcScriptVersionSignatureHash computes the signature hash for the specified
+// input of the target transaction observing the desired signature hash type
+// according to the signature hash algorithm defined for the provided script
+// version.  The cached prefix parameter allows the caller to optimize the
+// calculation by providing the prefix hash to be reused in the case of
+// SigHashAll without the SigHashAnyOneCanPay flag set.
+//
+// An error with kind ErrUnsupportedScriptVersion is returned for script
+// versions that do not have a defined signature hash algorithm.
+func CalcScriptVersionSignatureHash(scriptVersion uint16, script []byte, hashType SigHashType, tx *wire.MsgTx, idx int, cachedPrefix *chainhash.Hash) ([]byte, error) {
+	hasher, err := sigHasherForVersion(scriptVersion)
+	if err != nil {
+		return nil, err
+	}
 	if err := checkScriptParses(scriptVersion, script); err != nil {
 		return nil, err
 	}
 
-	return calcSignatureHash(script, hashType, tx, idx, cachedPrefix)
+	return hasher.calcSignatureHash(script, hashType, tx, idx, cachedPrefix)
 }
@@ -1,11 +1,13 @@
 // Copyright (c) 2013-2015 The btcsuite developers
-// Copyright (c) 2015-2021 The Decred developers
+// Copyright (c) 2015-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
 package sign
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 
@@ -20,6 +22,10 @@ import (
 	"github.com/decred/dcrd/wire"
 )
 
+// extraEntropySize is the required size of the additional entropy that may be
+// supplied to RFC6979 when producing ECDSA signatures.
+const extraEntropySize = 32
+
 // RawTxInSignature returns the serialized ECDSA signature for the input idx of
 // the given transaction, with hashType appended to it.
 //
@@ -30,6 +36,39 @@ func RawTxInSignature(tx *wire.MsgTx, idx int, subScript []byte,
 	hashType txscript.SigHashType, key []byte,
 	sigType dcrec.SignatureType) ([]byte, error) {
 
+	return RawTxInSignatureWithEntropy(tx, idx, subScript, hashType, key,
+		sigType, nil)
+}
+
+// RawTxInSignatureWithEntropy returns the serialized signature for the input
+// idx of the given transaction, with hashType appended to it, in the same way
+// as RawTxInSignature with the addition of allowing the caller to supply
+// additional entropy to the RFC6979 nonce generation.
+//
+// The additional entropy, when not nil, must be 32 bytes and is only supported
+// for ECDSA signatures over the secp256k1 curve.  Signatures produced with it
+// are still deterministic for a given key, hash, and entropy, but differ from
+// those produced without it.  Passing nil results in the same signatures as
+// RawTxInSignature.
+//
+// NOTE: This function is only valid for version 0 scripts.  Since the function
+// does not accept a script version, the results are undefined for other script
+// versions.
+func RawTxInSignatureWithEntropy(tx *wire.MsgTx, idx int, subScript []byte,
+	hashType txscript.SigHashType, key []byte, sigType dcrec.SignatureType,
+	extraEntropy []byte) ([]byte, error) {
+
+	if extraEntropy != nil {
+		if len(extraEntropy) != extraEntropySize {
+			return nil, fmt.Errorf("extra entropy must be %d bytes instead "+
+				"of %d", extraEntropySize, len(extraEntropy))
+		}
+		if sigType != dcrec.STEcdsaSecp256k1 {
+			return nil, fmt.Errorf("extra entropy is not supported for "+
+				"signature type '%v'", sigType)
+		}
+	}
+
 	hash, err := txscript.CalcSignatureHash(subScript, hashType, tx, idx, nil)
 	if err != nil {
 		return nil, err
@@ -39,7 +78,7 @@ func RawTxInSignature(tx *wire.MsgTx, idx int, subScript []byte,
 	switch sigType {
 	case dcrec.STEcdsaSecp256k1:
 		priv := secp256k1.PrivKeyFromBytes(key)
-		sig := ecdsa.Sign(priv, hash)
+		sig := ecdsa.SignWithExtraEntropy(priv, hash, extraEntropy)
 		sigBytes = sig.Serialize()
 	case dcrec.STEd25519:
 		priv, _ := edwards.PrivKeyFromBytes(key)
@@ -65,6 +104,36 @@ func RawTxInSignature(tx *wire.MsgTx, idx int, subScript []byte,
 	return append(sigBytes, byte(hashType)), nil
 }
 
+// IsDeterministicSignature returns whether the provided serialized signature,
+// with the hash type appended to it, for the input idx of the given transaction
+// is the one produced by the provided key when its nonce is generated
+// deterministically according to RFC6979 with the provided additional entropy,
+// if any.  It is intended to allow auditing signing infrastructure for
+// compliance with deterministic nonce generation.
+//
+// Note that a signature that is otherwise valid, such as one that was produced
+// with a random nonce or with different additional entropy, is reported as not
+// being deterministic.
+//
+// NOTE: This function is only valid for version 0 scripts.  Since the function
+// does not accept a script version, the results are undefined for other script
+// versions.
+func IsDeterministicSignature(tx *wire.MsgTx, idx int, subScript []byte,
+	sig []byte, key []byte, sigType dcrec.SignatureType,
+	extraEntropy []byte) (bool, error) {
+
+	if len(sig) == 0 {
+		return false, errors.New("signature is empty")
+	}
+	hashType := txscript.SigHashType(sig[len(sig)-1])
+	want, err := RawTxInSignatureWithEntropy(tx, idx, subScript, hashType, key,
+		sigType, extraEntropy)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(sig, want), nil
+}
+
 // SignatureScript creates an input signature script for tx to spend coins sent
 // from a previous output to the owner of privKey. tx must include all
 // transaction inputs and outputs, however txin scripts are allowed to be filled
@@ -77,7 +146,21 @@ func SignatureScript(tx *wire.MsgTx, idx int, subscript []byte,
 	hashType txscript.SigHashType, privKey []byte,
 	sigType dcrec.SignatureType, compress bool) ([]byte, error) {
 
-	sig, err := RawTxInSignature(tx, idx, subscript, hashType, privKey, sigType)
+	return SignatureScriptWithEntropy(tx, idx, subscript, hashType, privKey,
+		sigType, compress, nil)
+}
+
+// SignatureScriptWithEntropy creates an input signature script in the same way
+// as SignatureScript with the addition of allowing the caller to supply
+// additional entropy to the RFC6979 nonce generation.  See
+// RawTxInSignatureWithEntropy for the requirements of the additional entropy.
+func SignatureScriptWithEntropy(tx *wire.MsgTx, idx int, subscript []byte,
+	hashType txscript.SigHashType, privKey []byte,
+	sigType dcrec.SignatureType, compress bool,
+	extraEntropy []byte) ([]byte, error) {
+
+	sig, err := RawTxInSignatureWithEntropy(tx, idx, subscript, hashType,
+		privKey, sigType, extraEntropy)
 	if err != nil {
 		return nil, err
 	}
@@ -653,3 +736,43 @@ func TSpendSignatureScript(msgTx *wire.MsgTx, privKey []byte) ([]byte, error) {
 	return txscript.NewScriptBuilder().AddData(sigBytes).AddData(pkBytes).
 		AddOp(txscript.OP_TSPEND).Script()
 }
+
+// HashPuzzleSignatureScript returns a signature script that redeems the
+// provided version 0 hash puzzle script, as created by
+// stdscript.PayToSha256PuzzleScriptV0 or stdscript.PayToHash160PuzzleScriptV0,
+// with the given preimage.  The puzzle script is also pushed as the redeem
+// script when isP2SH is true, which must be the case when the puzzle script is
+// the redeem script of a pay-to-script-hash output.
+//
+// An error is returned when the provided script is not a hash puzzle script or
+// the preimage does not satisfy it.
+//
+// NOTE: This function is only valid for version 0 scripts.
+func HashPuzzleSignatureScript(puzzleScript, preimage []byte, isP2SH bool) ([]byte, error) {
+	puzzle := stdscript.ExtractHashPuzzleDataV0(puzzleScript)
+	if puzzle == nil {
+		return nil, errors.New("script is not a hash puzzle script")
+	}
+	if int64(len(preimage)) != puzzle.PreimageSize {
+		return nil, fmt.Errorf("preimage is %d bytes instead of the %d bytes "+
+			"required by the hash puzzle", len(preimage), puzzle.PreimageSize)
+	}
+	var hash []byte
+	switch puzzle.Type {
+	case stdscript.HashPuzzleSha256V0:
+		h := sha256.Sum256(preimage)
+		hash = h[:]
+	case stdscript.HashPuzzleHash160V0:
+		hash = stdaddr.Hash160(preimage)
+	}
+	if !bytes.Equal(hash, puzzle.Hash) {
+		return nil, errors.New("preimage does not hash to the hash required " +
+			"by the hash puzzle")
+	}
+
+	builder := txscript.NewScriptBuilder().AddData(preimage)
+	if isP2SH {
+		builder.AddData(puzzleScript)
+	}
+	return builder.Script()
+}
@@ -1,12 +1,14 @@
 // Copyright (c) 2013-2016 The btcsuite developers
-// Copyright (c) 2015-2021 The Decred developers
+// Copyright (c) 2015-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
 package sign
 
 import (
+	"bytes"
 	"crypto/rand"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	mrand "math/rand"
@@ -2749,3 +2751,201 @@ nexttest:
 		}
 	}
 }
+
+// TestRawTxInSignatureWithEntropy ensures signatures produced with additional
+// RFC6979 entropy are valid, deterministic for the same entropy, distinct from
+// those produced without it, and are correctly identified by the deterministic
+// signature audit helper.
+func TestRawTxInSignatureWithEntropy(t *testing.T) {
+	t.Parallel()
+
+	// Create a transaction that spends a pay-to-pubkey-hash output.
+	pkh := stdaddr.Hash160(thisPubKey.SerializeCompressed())
+	addr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(pkh,
+		testingParams)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	_, pkScript := addr.PaymentScript()
+	tx := wire.NewMsgTx()
+	tx.AddTxIn(wire.NewTxIn(coinbaseOutPoint, 500, nil))
+	tx.AddTxOut(wire.NewTxOut(500, []byte{txscript.OP_RETURN}))
+
+	var entropy, otherEntropy [32]byte
+	entropy[0], otherEntropy[0] = 0x01, 0x02
+	const hashType = txscript.SigHashAll
+	ecdsaType := dcrec.STEcdsaSecp256k1
+
+	// Ensure the signatures with entropy are deterministic and differ from
+	// the ones without it.
+	sigNoEntropy, err := RawTxInSignature(tx, 0, pkScript, hashType, privKeyD,
+		ecdsaType)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sig, err := RawTxInSignatureWithEntropy(tx, 0, pkScript, hashType,
+		privKeyD, ecdsaType, entropy[:])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sig2, err := RawTxInSignatureWithEntropy(tx, 0, pkScript, hashType,
+		privKeyD, ecdsaType, entropy[:])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(sig, sig2) {
+		t.Fatalf("signatures with the same entropy differ: %x != %x", sig,
+			sig2)
+	}
+	if bytes.Equal(sig, sigNoEntropy) {
+		t.Fatal("signature with entropy matches signature without it")
+	}
+
+	// Ensure a signature script with the signature produced with entropy
+	// validates.
+	sigScript, err := SignatureScriptWithEntropy(tx, 0, pkScript, hashType,
+		privKeyD, ecdsaType, true, entropy[:])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := checkScripts("entropy", tx, 0, sigScript, pkScript); err != nil {
+		t.Fatalf("signature script with entropy failed to validate: %v", err)
+	}
+
+	// Ensure the audit helper only reports the signatures as deterministic
+	// for the entropy they were produced with.
+	tests := []struct {
+		name    string
+		sig     []byte
+		entropy []byte
+		want    bool
+	}{
+		{name: "no entropy", sig: sigNoEntropy, entropy: nil, want: true},
+		{name: "entropy", sig: sig, entropy: entropy[:], want: true},
+		{name: "missing entropy", sig: sig, entropy: nil, want: false},
+		{name: "other entropy", sig: sig, entropy: otherEntropy[:],
+			want: false},
+		{name: "unexpected entropy", sig: sigNoEntropy, entropy: entropy[:],
+			want: false},
+	}
+	for _, test := range tests {
+		got, err := IsDeterministicSignature(tx, 0, pkScript, test.sig,
+			privKeyD, ecdsaType, test.entropy)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.name, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%q: mismatched result -- got %v, want %v", test.name,
+				got, test.want)
+		}
+	}
+
+	// Ensure entropy of the wrong size and entropy for signature types that do
+	// not support it are rejected.
+	_, err = RawTxInSignatureWithEntropy(tx, 0, pkScript, hashType, privKeyD,
+		ecdsaType, entropy[:31])
+	if err == nil {
+		t.Fatal("did not receive error for short entropy")
+	}
+	_, err = RawTxInSignatureWithEntropy(tx, 0, pkScript, hashType, privKeyD,
+		dcrec.STSchnorrSecp256k1, entropy[:])
+	if err == nil {
+		t.Fatal("did not receive error for schnorr signature with entropy")
+	}
+	_, err = IsDeterministicSignature(tx, 0, pkScript, nil, privKeyD,
+		ecdsaType, nil)
+	if err == nil {
+		t.Fatal("did not receive error for empty signature")
+	}
+}
+
+// TestHashPuzzleSignatureScript ensures signature scripts that redeem hash
+// puzzle scripts are created as expected and are accepted by the script engine
+// when spending both bare and pay-to-script-hash outputs.
+func TestHashPuzzleSignatureScript(t *testing.T) {
+	t.Parallel()
+
+	params := chaincfg.MainNetParams()
+	preimage := bytes.Repeat([]byte{0x01}, 32)
+	sha256Puzzle, err := stdscript.PayToSha256PuzzleScriptV0(
+		sha256.Sum256(preimage), int64(len(preimage)))
+	if err != nil {
+		t.Fatalf("unexpected error creating sha256 puzzle: %v", err)
+	}
+	var hash160 [20]byte
+	copy(hash160[:], stdaddr.Hash160(preimage))
+	hash160Puzzle, err := stdscript.PayToHash160PuzzleScriptV0(hash160,
+		int64(len(preimage)))
+	if err != nil {
+		t.Fatalf("unexpected error creating hash160 puzzle: %v", err)
+	}
+
+	tx := &wire.MsgTx{
+		Version: 1,
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{0x01}},
+			Sequence:         wire.MaxTxInSequenceNum,
+		}},
+		TxOut: []*wire.TxOut{{Value: 1}},
+	}
+
+	// checkPuzzle executes the provided scripts with the flag that enables
+	// OP_SHA256 set since it is otherwise treated as a no-op.
+	checkPuzzle := func(msg string, sigScript, pkScript []byte) error {
+		tx.TxIn[0].SignatureScript = sigScript
+		const flags = txscript.ScriptVerifySHA256
+		vm, err := txscript.NewEngine(pkScript, tx, 0, flags, 0, nil)
+		if err != nil {
+			return fmt.Errorf("failed to make script engine for %s: %v",
+				msg, err)
+		}
+		if err := vm.Execute(); err != nil {
+			return fmt.Errorf("invalid script signature for %s: %v", msg,
+				err)
+		}
+		return nil
+	}
+	for _, puzzle := range [][]byte{sha256Puzzle, hash160Puzzle} {
+		// Ensure the puzzle is redeemable when used directly.
+		sigScript, err := HashPuzzleSignatureScript(puzzle, preimage, false)
+		if err != nil {
+			t.Fatalf("unexpected error creating signature script: %v", err)
+		}
+		if err := checkPuzzle("bare hash puzzle", sigScript, puzzle); err != nil {
+			t.Fatal(err)
+		}
+
+		// Ensure the puzzle is redeemable when used as a P2SH redeem script.
+		addr, err := stdaddr.NewAddressScriptHashV0(puzzle, params)
+		if err != nil {
+			t.Fatalf("unexpected error creating p2sh address: %v", err)
+		}
+		_, pkScript := addr.PaymentScript()
+		sigScript, err = HashPuzzleSignatureScript(puzzle, preimage, true)
+		if err != nil {
+			t.Fatalf("unexpected error creating signature script: %v", err)
+		}
+		if err := checkPuzzle("p2sh hash puzzle", sigScript, pkScript); err != nil {
+			t.Fatal(err)
+		}
+
+		// Ensure preimages that do not satisfy the puzzle are rejected.
+		badPreimage := bytes.Repeat([]byte{0x02}, len(preimage))
+		_, err = HashPuzzleSignatureScript(puzzle, badPreimage, false)
+		if err == nil {
+			t.Fatal("did not receive error for preimage with wrong hash")
+		}
+		_, err = HashPuzzleSignatureScript(puzzle, preimage[1:], false)
+		if err == nil {
+			t.Fatal("did not receive error for preimage with wrong size")
+		}
+	}
+
+	// Ensure scripts that are not hash puzzles are rejected.
+	_, err = HashPuzzleSignatureScript([]byte{txscript.OP_TRUE}, preimage,
+		false)
+	if err == nil {
+		t.Fatal("did not receive error for script that is not a hash puzzle")
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2021 The Decred developers
+// Copyright (c) 2021-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -7,7 +7,11 @@
 package stdaddr
 
 import (
+	"crypto/subtle"
+	"errors"
 	"fmt"
+	"strings"
+	"unicode"
 
 	"github.com/decred/dcrd/crypto/ripemd160"
 )
@@ -415,6 +419,44 @@ func NewAddressScriptHash(scriptVersion uint16, redeemScript []byte,
 	return nil, makeError(ErrUnsupportedScriptVersion, str)
 }
 
+// RedeemScriptHash returns the hash of the provided redeem script as it is
+// committed to by pay-to-script-hash scripts and addresses for the provided
+// script version.
+//
+// The hash function used to commit to redeem scripts is a property of the
+// script version, so callers should make use of this function rather than
+// hard coding a specific hash function in order to seamlessly support future
+// script versions that might make use of a different one.
+//
+// NOTE: Version 0 scripts are the only currently supported version.  They
+// commit to redeem scripts via Hash160.
+func RedeemScriptHash(scriptVersion uint16, redeemScript []byte) ([]byte, error) {
+	switch scriptVersion {
+	case 0:
+		return Hash160(redeemScript), nil
+	}
+
+	str := fmt.Sprintf("script hashes for version %d are not supported",
+		scriptVersion)
+	return nil, makeError(ErrUnsupportedScriptVersion, str)
+}
+
+// RedeemScriptHashSize returns the size, in bytes, of the hash of a redeem
+// script as it is committed to by pay-to-script-hash scripts and addresses for
+// the provided script version.
+//
+// NOTE: Version 0 scripts are the only currently supported version.
+func RedeemScriptHashSize(scriptVersion uint16) (int, error) {
+	switch scriptVersion {
+	case 0:
+		return ripemd160.Size, nil
+	}
+
+	str := fmt.Sprintf("script hashes for version %d are not supported",
+		scriptVersion)
+	return 0, makeError(ErrUnsupportedScriptVersion, str)
+}
+
 // probablyV0Base58Addr returns true when the provided string looks like a
 // version 0 base58 address as determined by their length and only containing
 // runes in the base58 alphabet used by Decred for version 0 addresses.
@@ -455,3 +497,72 @@ func DecodeAddress(addr string, params AddressParams) (Address, error) {
 	str := fmt.Sprintf("address %q is not a supported type", addr)
 	return nil, makeError(ErrUnsupportedAddress, str)
 }
+
+// NormalizeAddress returns the provided user-supplied address string with any
+// characters that are commonly introduced when addresses are copied and pasted
+// removed.  This includes all whitespace, such as leading and trailing spaces
+// and line breaks from wrapped text, along with invisible zero-width
+// characters and byte order marks.
+//
+// Note that the case of the address is intentionally preserved because the
+// base58 encoding used by version 0 addresses is case sensitive.  This means
+// addresses with an altered case will fail to decode due to an invalid
+// checksum as opposed to silently decoding to a different address.
+func NormalizeAddress(addr string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r):
+			return -1
+
+		// Zero width space, non-joiner, joiner, and byte order mark.
+		case r == '\u200b', r == '\u200c', r == '\u200d', r == '\ufeff':
+			return -1
+		}
+		return r
+	}, addr)
+}
+
+// AddressesEqual returns whether or not the two provided addresses are the
+// same address for the same network.
+//
+// The comparison is performed in constant time with respect to the contents
+// of the addresses in order to avoid leaking information about them via
+// timing side channels.  This is useful for services that compare
+// user-supplied addresses against known addresses, for example, to detect
+// vanity addresses that attempt to imitate them by sharing common prefixes
+// and suffixes.  Note that the lengths of the encoded addresses are not
+// protected.
+func AddressesEqual(a, b Address) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return subtle.ConstantTimeCompare([]byte(a.String()),
+		[]byte(b.String())) == 1
+}
+
+// DetectNetwork decodes the provided address under each of the provided
+// network parameters in order and returns the first parameters the address is
+// valid for along with the decoded address.
+//
+// An error with the kind ErrUnsupportedAddress is returned when the address
+// is not valid for any of the provided networks.  Errors that indicate the
+// address itself is malformed, such as an invalid checksum, are returned
+// as-is since they are not specific to any network.
+//
+// Callers will typically want to use NormalizeAddress on user-supplied input
+// before calling this function.
+func DetectNetwork(addr string, networks ...AddressParams) (AddressParams, Address, error) {
+	for _, params := range networks {
+		decoded, err := DecodeAddress(addr, params)
+		if err == nil {
+			return params, decoded, nil
+		}
+		if !errors.Is(err, ErrUnsupportedAddress) {
+			return nil, nil, err
+		}
+	}
+
+	str := fmt.Sprintf("address %q is not valid for any of the provided "+
+		"networks", addr)
+	return nil, nil, makeError(ErrUnsupportedAddress, str)
+}
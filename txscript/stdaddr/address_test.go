@@ -1,4 +1,4 @@
-// Copyright (c) 2021 The Decred developers
+// Copyright (c) 2021-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -12,8 +12,10 @@ import (
 
 	"github.com/decred/base58"
 	"github.com/decred/dcrd/crypto/ripemd160"
+	"github.com/decred/dcrd/dcrec"
 	"github.com/decred/dcrd/dcrec/edwards/v2"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/txscript/v4"
 )
 
 // mockAddrParams implements the AddressParams interface and is used throughout
@@ -1417,3 +1419,352 @@ func TestProbablyV0Base58Addr(t *testing.T) {
 		}
 	}
 }
+
+// TestWrapP2SH ensures wrapping redeem scripts in a pay-to-script-hash
+// produces the same payment script and address as creating the address
+// directly and rejects redeem scripts that are too large to redeem.
+func TestWrapP2SH(t *testing.T) {
+	mainNetParams := mockMainNetParams()
+	redeemScript := hexToBytes("512103e925aafc1edd44e7c7f1ea4fb7d265dc672f204" +
+		"c3d0c81930389c10b81fb75de51ae")
+	pkScript, addr, err := WrapP2SH(redeemScript, mainNetParams)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantAddr, err := NewAddressScriptHashV0(redeemScript, mainNetParams)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if addr.String() != wantAddr.String() {
+		t.Fatalf("mismatched address -- got %s, want %s", addr, wantAddr)
+	}
+	_, wantScript := wantAddr.PaymentScript()
+	if !bytes.Equal(pkScript, wantScript) {
+		t.Fatalf("mismatched payment script -- got %x, want %x", pkScript,
+			wantScript)
+	}
+
+	// Ensure redeem scripts that can't be pushed are rejected.
+	_, _, err = WrapP2SH(make([]byte, txscript.MaxScriptElementSize+1),
+		mainNetParams)
+	if !errors.Is(err, ErrRedeemScriptTooLarge) {
+		t.Fatalf("mismatched error -- got %v, want %v", err,
+			ErrRedeemScriptTooLarge)
+	}
+}
+
+// TestRedeemScriptHash ensures hashing redeem scripts for the supported script
+// versions produces the hash committed to by the associated script hash
+// address and rejects unsupported script versions.
+func TestRedeemScriptHash(t *testing.T) {
+	t.Parallel()
+
+	mainNetParams := mockMainNetParams()
+	redeemScript := hexToBytes("512103e925aafc1edd44e7c7f1ea4fb7d265dc672f204" +
+		"c3d0c81930389c10b81fb75de51ae")
+	scriptHash, err := RedeemScriptHash(0, redeemScript)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	size, err := RedeemScriptHashSize(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scriptHash) != size {
+		t.Fatalf("mismatched hash size -- got %d, want %d", len(scriptHash),
+			size)
+	}
+	addr, err := NewAddressScriptHash(0, redeemScript, mainNetParams)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantHash := addr.(Hash160er).Hash160()
+	if !bytes.Equal(scriptHash, wantHash[:]) {
+		t.Fatalf("mismatched script hash -- got %x, want %x", scriptHash,
+			wantHash[:])
+	}
+	addrFromHash, err := NewAddressScriptHashFromHash(0, scriptHash,
+		mainNetParams)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if addrFromHash.String() != addr.String() {
+		t.Fatalf("mismatched address -- got %s, want %s", addrFromHash, addr)
+	}
+
+	// Ensure unsupported script versions are rejected.
+	const unsupportedVersion = 1
+	_, err = RedeemScriptHash(unsupportedVersion, redeemScript)
+	if !errors.Is(err, ErrUnsupportedScriptVersion) {
+		t.Fatalf("mismatched error -- got %v, want %v", err,
+			ErrUnsupportedScriptVersion)
+	}
+	_, err = RedeemScriptHashSize(unsupportedVersion)
+	if !errors.Is(err, ErrUnsupportedScriptVersion) {
+		t.Fatalf("mismatched error -- got %v, want %v", err,
+			ErrUnsupportedScriptVersion)
+	}
+}
+
+// TestNormalizeAddress ensures normalizing user-supplied addresses removes
+// whitespace and invisible characters while preserving the case.
+func TestNormalizeAddress(t *testing.T) {
+	t.Parallel()
+
+	const addr = "DsUZxxoHJSty8DCfwfartwTYbuhmVct7tJu"
+	tests := []struct {
+		name string // test description
+		in   string // address to normalize
+		want string // expected normalized address
+	}{{
+		name: "already normalized",
+		in:   addr,
+		want: addr,
+	}, {
+		name: "leading and trailing whitespace",
+		in:   " \t" + addr + "\r\n",
+		want: addr,
+	}, {
+		name: "wrapped across lines",
+		in:   addr[:20] + "\n" + addr[20:],
+		want: addr,
+	}, {
+		name: "zero width characters and byte order mark",
+		in:   "\ufeff" + addr[:10] + "\u200b" + addr[10:] + "\u200d",
+		want: addr,
+	}, {
+		name: "case is preserved",
+		in:   " dsuzxxohjsty8dcfwfartwtybuhmvct7tju ",
+		want: "dsuzxxohjsty8dcfwfartwtybuhmvct7tju",
+	}, {
+		name: "empty",
+		in:   " \n ",
+		want: "",
+	}}
+
+	for _, test := range tests {
+		got := NormalizeAddress(test.in)
+		if got != test.want {
+			t.Errorf("%s: mismatched result -- got %q, want %q", test.name,
+				got, test.want)
+		}
+	}
+}
+
+// TestAddressesEqual ensures comparing addresses works as expected.
+func TestAddressesEqual(t *testing.T) {
+	t.Parallel()
+
+	mainNetParams := mockMainNetParams()
+	testNetParams := mockTestNetParams()
+	hash := hexToBytes("2789d58cfa0957d206f025c2af056fc8a77cebb0")
+	addr1, err := NewAddressPubKeyHashEcdsaSecp256k1V0(hash, mainNetParams)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	addr2, err := DecodeAddress(addr1.String(), mainNetParams)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	otherNet, err := NewAddressPubKeyHashEcdsaSecp256k1V0(hash, testNetParams)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	otherType, err := NewAddressPubKeyHashSchnorrSecp256k1V0(hash,
+		mainNetParams)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string  // test description
+		a    Address // first address to compare
+		b    Address // second address to compare
+		want bool    // expected result
+	}{{
+		name: "same address",
+		a:    addr1,
+		b:    addr2,
+		want: true,
+	}, {
+		name: "different networks",
+		a:    addr1,
+		b:    otherNet,
+		want: false,
+	}, {
+		name: "different types",
+		a:    addr1,
+		b:    otherType,
+		want: false,
+	}, {
+		name: "one nil",
+		a:    addr1,
+		b:    nil,
+		want: false,
+	}, {
+		name: "both nil",
+		a:    nil,
+		b:    nil,
+		want: true,
+	}}
+
+	for _, test := range tests {
+		got := AddressesEqual(test.a, test.b)
+		if got != test.want {
+			t.Errorf("%s: mismatched result -- got %v, want %v", test.name,
+				got, test.want)
+		}
+	}
+}
+
+// TestDetectNetwork ensures detecting the network an address is valid for
+// works as expected.
+func TestDetectNetwork(t *testing.T) {
+	t.Parallel()
+
+	mainNetParams := mockMainNetParams()
+	testNetParams := mockTestNetParams()
+	regNetParams := mockRegNetParams()
+	allNets := []AddressParams{mainNetParams, testNetParams, regNetParams}
+
+	tests := []struct {
+		name    string          // test description
+		addr    string          // address to detect the network of
+		nets    []AddressParams // candidate networks
+		want    AddressParams   // expected network
+		wantErr error           // expected error
+	}{{
+		name: "mainnet",
+		addr: "DsUZxxoHJSty8DCfwfartwTYbuhmVct7tJu",
+		nets: allNets,
+		want: mainNetParams,
+	}, {
+		name: "testnet",
+		addr: "Tso2MVTUeVrjHTBFedFhiyM7yVTbieqp91h",
+		nets: allNets,
+		want: testNetParams,
+	}, {
+		name: "regnet",
+		addr: "RsWM2w5LPJip56uxcZ1Scq7Tcbg97EfiwPA",
+		nets: allNets,
+		want: regNetParams,
+	}, {
+		name:    "network not provided",
+		addr:    "RsWM2w5LPJip56uxcZ1Scq7Tcbg97EfiwPA",
+		nets:    []AddressParams{mainNetParams, testNetParams},
+		wantErr: ErrUnsupportedAddress,
+	}, {
+		name:    "no networks",
+		addr:    "DsUZxxoHJSty8DCfwfartwTYbuhmVct7tJu",
+		wantErr: ErrUnsupportedAddress,
+	}, {
+		name:    "bad checksum",
+		addr:    "TsmWaPM77WSyA3aiQ2Q1KnwGDVWvEkhip23",
+		nets:    allNets,
+		wantErr: ErrBadAddressChecksum,
+	}}
+
+	for _, test := range tests {
+		net, addr, err := DetectNetwork(test.addr, test.nets...)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%s: mismatched error -- got %v, want %v", test.name, err,
+				test.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if net != test.want {
+			t.Errorf("%s: mismatched network -- got %v, want %v", test.name,
+				net, test.want)
+			continue
+		}
+		if addr.String() != test.addr {
+			t.Errorf("%s: mismatched address -- got %s, want %s", test.name,
+				addr, test.addr)
+		}
+	}
+}
+
+// TestAltSigAddrHooks ensures every alternative signature type supported by the
+// txscript alt signature suites has address hooks and that the addresses they
+// produce round trip through decoding as expected.
+func TestAltSigAddrHooks(t *testing.T) {
+	t.Parallel()
+
+	// Ensure the address hooks are consistent with the alt signature suites.
+	for sigType := dcrec.SignatureType(0); sigType <= 127; sigType++ {
+		_, hasSuite := txscript.LookupAltSigSuite(sigType)
+		_, hasHooks := altSigAddrHooks[sigType]
+		if hasSuite != hasHooks {
+			t.Errorf("signature type %d: mismatched suite (%v) and address "+
+				"hooks (%v)", sigType, hasSuite, hasHooks)
+		}
+	}
+
+	mainNetParams := mockMainNetParams()
+	tests := []struct {
+		name    string              // test description
+		sigType dcrec.SignatureType // signature type
+		pubKey  string              // hex encoded serialized public key
+		err     error               // expected error
+	}{{
+		name:    "ed25519",
+		sigType: dcrec.STEd25519,
+		pubKey:  "cecc1507dc1ddd7295951c290888f095adb9044d1b73d696e6df065d683bd4fc",
+	}, {
+		name:    "schnorr-secp256k1",
+		sigType: dcrec.STSchnorrSecp256k1,
+		pubKey:  "02ce0b14fb842b1ba549fdd675c98075f12e9c510f8ef52bd021a9a1f4809d3b4d",
+	}, {
+		name:    "ecdsa-secp256k1 is not an alt signature type",
+		sigType: dcrec.STEcdsaSecp256k1,
+		pubKey:  "02ce0b14fb842b1ba549fdd675c98075f12e9c510f8ef52bd021a9a1f4809d3b4d",
+		err:     ErrUnsupportedAddress,
+	}, {
+		name:    "unknown signature type",
+		sigType: 100,
+		pubKey:  "02ce0b14fb842b1ba549fdd675c98075f12e9c510f8ef52bd021a9a1f4809d3b4d",
+		err:     ErrUnsupportedAddress,
+	}}
+
+	for _, test := range tests {
+		pubKey := hexToBytes(test.pubKey)
+		pkHash := Hash160(pubKey)
+		p2pk, err := NewAddressPubKeyAltV0(test.sigType, pubKey, mainNetParams)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: mismatched p2pk err -- got %v, want %v", test.name,
+				err, test.err)
+			continue
+		}
+		p2pkh, err := NewAddressPubKeyHashAltV0(test.sigType, pkHash,
+			mainNetParams)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: mismatched p2pkh err -- got %v, want %v", test.name,
+				err, test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+
+		// Ensure both addresses round trip through decoding and that the
+		// public key hash address is the one for the public key.
+		for _, addr := range []Address{p2pk, p2pkh} {
+			decoded, err := DecodeAddressV0(addr.String(), mainNetParams)
+			if err != nil {
+				t.Errorf("%s: unexpected decode err: %v", test.name, err)
+				continue
+			}
+			if decoded.String() != addr.String() {
+				t.Errorf("%s: mismatched decoded address -- got %s, want %s",
+					test.name, decoded, addr)
+			}
+		}
+		gotP2PKH := p2pk.(AddressPubKeyHasher).AddressPubKeyHash()
+		if gotP2PKH.String() != p2pkh.String() {
+			t.Errorf("%s: mismatched p2pkh address -- got %s, want %s",
+				test.name, gotP2PKH, p2pkh)
+		}
+	}
+}
@@ -951,6 +951,32 @@ func NewAddressScriptHashV0(redeemScript []byte,
 	return NewAddressScriptHashV0FromHash(scriptHash, params)
 }
 
+// WrapP2SH returns the version 0 pay-to-script-hash payment script that
+// commits to the provided redeem script along with the associated address.
+// This is a convenience function that is equivalent to calling
+// NewAddressScriptHashV0 followed by PaymentScript on the result, however, it
+// additionally ensures the redeem script is able to be pushed by the signature
+// script that is ultimately required to redeem the output.
+//
+// The provided redeem script must not be larger than the maximum allowed
+// script element size since outputs that commit to such a script are not
+// spendable.
+func WrapP2SH(redeemScript []byte, params AddressParamsV0) ([]byte, *AddressScriptHashV0, error) {
+	if len(redeemScript) > txscript.MaxScriptElementSize {
+		str := fmt.Sprintf("redeem script size of %d bytes exceeds the max "+
+			"allowed script element size of %d", len(redeemScript),
+			txscript.MaxScriptElementSize)
+		return nil, nil, makeError(ErrRedeemScriptTooLarge, str)
+	}
+
+	addr, err := NewAddressScriptHashV0(redeemScript, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, pkScript := addr.PaymentScript()
+	return pkScript, addr, nil
+}
+
 // String returns the string encoding of the payment address for the associated
 // script version and payment script.
 //
@@ -1147,12 +1173,6 @@ func DecodeAddressV0(addr string, params AddressParamsV0) (Address, error) {
 	case params.AddrIDPubKeyHashECDSAV0():
 		return NewAddressPubKeyHashEcdsaSecp256k1(0, decoded, params)
 
-	case params.AddrIDPubKeyHashSchnorrV0():
-		return NewAddressPubKeyHashSchnorrSecp256k1(0, decoded, params)
-
-	case params.AddrIDPubKeyHashEd25519V0():
-		return NewAddressPubKeyHashEd25519(0, decoded, params)
-
 	case params.AddrIDPubKeyV0():
 		// Ensure the decoded data has the expected signature type identifier
 		// byte.
@@ -1187,45 +1207,20 @@ func DecodeAddressV0(addr string, params AddressParamsV0) (Address, error) {
 			}
 			decoded[0] = prefix
 			return NewAddressPubKeyEcdsaSecp256k1Raw(0, decoded, params)
+		}
 
-		case dcrec.STEd25519:
-			const reqPubKeyLen = 32
-			pubKey := decoded[1:]
-			if len(pubKey) != reqPubKeyLen {
-				str := fmt.Sprintf("public key is %d bytes vs required %d bytes",
-					len(pubKey), reqPubKeyLen)
-				return nil, makeError(ErrMalformedAddressData, str)
-			}
-
-			// The encoded data for this case is the actual Ed25519 public key,
-			// so just pass it along unaltered to the constructor of the
-			// appropriate type to validate and return the relevant address
-			// instance.
-			return NewAddressPubKeyEd25519Raw(0, pubKey, params)
+		// Decode alternative signature types via their address hooks.
+		if hooks, ok := altSigAddrHooks[dcrec.SignatureType(sigType)]; ok {
+			return hooks.decodePubKeyAddr(decoded, params)
+		}
 
-		case dcrec.STSchnorrSecp256k1:
-			// The encoded data for this case is the 32-byte X coordinate for a
-			// secp256k1 public key along with the oddness of the Y coordinate
-			// encoded via the high bit of the first byte.
-			//
-			// Reconstruct the standard compressed serialized public key format
-			// by choosing the correct prefix byte depending on the encoded
-			// Y-coordinate oddness pass it along to the constructor of the
-			// appropriate type to validate and return the relevant address
-			// instance.
-			const reqPubKeyLen = 33
-			if len(decoded) != reqPubKeyLen {
-				str := fmt.Sprintf("public key is %d bytes vs required %d bytes",
-					len(decoded), reqPubKeyLen)
-				return nil, makeError(ErrMalformedAddressData, str)
-			}
-			isOddY := decoded[0]&sigTypeSecp256k1PubKeyCompOddFlag != 0
-			prefix := secp256k1.PubKeyFormatCompressedEven
-			if isOddY {
-				prefix = secp256k1.PubKeyFormatCompressedOdd
+	default:
+		// Decode pay-to-pubkey-hash addresses for alternative signature
+		// types via their address hooks.
+		for _, hooks := range altSigAddrHooks {
+			if addrID == hooks.addrIDPubKeyHash(params) {
+				return hooks.newPubKeyHashAddr(decoded, params)
 			}
-			decoded[0] = prefix
-			return NewAddressPubKeySchnorrSecp256k1Raw(0, decoded, params)
 		}
 	}
 
@@ -0,0 +1,141 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package stdaddr
+
+import (
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// altSigAddrHooksV0 defines the hooks required to derive and decode version 0
+// addresses for an alternative signature type supported by OP_CHECKSIGALT.
+type altSigAddrHooksV0 struct {
+	// newPubKeyAddr returns an address that represents a payment destination
+	// which imposes an encumbrance that requires a valid signature for the
+	// provided serialized public key.
+	newPubKeyAddr func(serializedPubKey []byte, params AddressParamsV0) (Address, error)
+
+	// newPubKeyHashAddr returns an address that represents a payment
+	// destination which imposes an encumbrance that requires a public key that
+	// hashes to the provided public key hash along with a valid signature for
+	// it.
+	newPubKeyHashAddr func(pkHash []byte, params AddressParamsV0) (Address, error)
+
+	// addrIDPubKeyHash returns the magic prefix bytes for pay-to-pubkey-hash
+	// addresses of the signature type.
+	addrIDPubKeyHash func(params AddressParamsV0) [2]byte
+
+	// decodePubKeyAddr returns the pay-to-pubkey address for the provided
+	// decoded address data which includes the leading signature type byte.
+	decodePubKeyAddr func(decoded []byte, params AddressParamsV0) (Address, error)
+}
+
+// altSigAddrHooks houses the address hooks for the alternative signature types
+// keyed by their signature type.  Every signature type supported by the
+// txscript alt signature suites must have an entry.  It must not be modified
+// after package initialization.
+var altSigAddrHooks = map[dcrec.SignatureType]*altSigAddrHooksV0{
+	dcrec.STEd25519: {
+		newPubKeyAddr: func(pk []byte, params AddressParamsV0) (Address, error) {
+			return NewAddressPubKeyEd25519V0Raw(pk, params)
+		},
+		newPubKeyHashAddr: func(pkHash []byte, params AddressParamsV0) (Address, error) {
+			return NewAddressPubKeyHashEd25519V0(pkHash, params)
+		},
+		addrIDPubKeyHash: func(params AddressParamsV0) [2]byte {
+			return params.AddrIDPubKeyHashEd25519V0()
+		},
+		decodePubKeyAddr: func(decoded []byte, params AddressParamsV0) (Address, error) {
+			const reqPubKeyLen = 32
+			pubKey := decoded[1:]
+			if len(pubKey) != reqPubKeyLen {
+				str := fmt.Sprintf("public key is %d bytes vs required %d bytes",
+					len(pubKey), reqPubKeyLen)
+				return nil, makeError(ErrMalformedAddressData, str)
+			}
+
+			// The encoded data for this case is the actual Ed25519 public key,
+			// so just pass it along unaltered to the constructor of the
+			// appropriate type to validate and return the relevant address
+			// instance.
+			return NewAddressPubKeyEd25519V0Raw(pubKey, params)
+		},
+	},
+	dcrec.STSchnorrSecp256k1: {
+		newPubKeyAddr: func(pk []byte, params AddressParamsV0) (Address, error) {
+			return NewAddressPubKeySchnorrSecp256k1V0Raw(pk, params)
+		},
+		newPubKeyHashAddr: func(pkHash []byte, params AddressParamsV0) (Address, error) {
+			return NewAddressPubKeyHashSchnorrSecp256k1V0(pkHash, params)
+		},
+		addrIDPubKeyHash: func(params AddressParamsV0) [2]byte {
+			return params.AddrIDPubKeyHashSchnorrV0()
+		},
+		decodePubKeyAddr: func(decoded []byte, params AddressParamsV0) (Address, error) {
+			// The encoded data for this case is the 32-byte X coordinate for a
+			// secp256k1 public key along with the oddness of the Y coordinate
+			// encoded via the high bit of the first byte.
+			//
+			// Reconstruct the standard compressed serialized public key format
+			// by choosing the correct prefix byte depending on the encoded
+			// Y-coordinate oddness pass it along to the constructor of the
+			// appropriate type to validate and return the relevant address
+			// instance.
+			const reqPubKeyLen = 33
+			if len(decoded) != reqPubKeyLen {
+				str := fmt.Sprintf("public key is %d bytes vs required %d bytes",
+					len(decoded), reqPubKeyLen)
+				return nil, makeError(ErrMalformedAddressData, str)
+			}
+			isOddY := decoded[0]&sigTypeSecp256k1PubKeyCompOddFlag != 0
+			prefix := secp256k1.PubKeyFormatCompressedEven
+			if isOddY {
+				prefix = secp256k1.PubKeyFormatCompressedOdd
+			}
+			decoded[0] = prefix
+			return NewAddressPubKeySchnorrSecp256k1V0Raw(decoded, params)
+		},
+	},
+}
+
+// NewAddressPubKeyAltV0 returns an address that represents a payment
+// destination which imposes an encumbrance that requires a valid signature of
+// the provided alternative signature type for the given serialized public key
+// using version 0 scripts.
+//
+// ErrUnsupportedAddress will be returned for signature types that are not
+// alternative signature types supported by OP_CHECKSIGALT.
+func NewAddressPubKeyAltV0(sigType dcrec.SignatureType, serializedPubKey []byte,
+	params AddressParamsV0) (Address, error) {
+
+	hooks, ok := altSigAddrHooks[sigType]
+	if !ok {
+		str := fmt.Sprintf("signature type %d is not a supported alternative "+
+			"signature type", sigType)
+		return nil, makeError(ErrUnsupportedAddress, str)
+	}
+	return hooks.newPubKeyAddr(serializedPubKey, params)
+}
+
+// NewAddressPubKeyHashAltV0 returns an address that represents a payment
+// destination which imposes an encumbrance that requires a public key that
+// hashes to the provided public key hash along with a valid signature of the
+// provided alternative signature type for it using version 0 scripts.
+//
+// ErrUnsupportedAddress will be returned for signature types that are not
+// alternative signature types supported by OP_CHECKSIGALT.
+func NewAddressPubKeyHashAltV0(sigType dcrec.SignatureType, pkHash []byte,
+	params AddressParamsV0) (Address, error) {
+
+	hooks, ok := altSigAddrHooks[sigType]
+	if !ok {
+		str := fmt.Sprintf("signature type %d is not a supported alternative "+
+			"signature type", sigType)
+		return nil, makeError(ErrUnsupportedAddress, str)
+	}
+	return hooks.newPubKeyHashAddr(pkHash, params)
+}
@@ -0,0 +1,312 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package stdaddr
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/txscript/v4"
+)
+
+// maxDescriptorMultiSigKeys is the maximum number of public keys allowed in a
+// multisig descriptor.  It is limited to the maximum value that can be
+// represented by a small integer so the resulting scripts are standard.
+const maxDescriptorMultiSigKeys = 16
+
+// Descriptor houses the result of parsing an output descriptor.  See
+// ParseDescriptorV0 for details regarding the supported syntax.
+type Descriptor struct {
+	// ScriptVersion is the version of the output script.
+	ScriptVersion uint16
+
+	// Script is the output script described by the descriptor.
+	Script []byte
+
+	// Addresses is the set of addresses involved with the output script,
+	// which is suitable for use when watching the chain for outputs that pay
+	// to the script, such as when performing rescans.
+	Addresses []Address
+}
+
+// descriptorStakeScripts houses the functions used to create the scripts for
+// the supported stake wrapper expressions keyed by their name.
+var descriptorStakeScripts = map[string]func(StakeAddress) (uint16, []byte){
+	"sstx":       StakeAddress.VotingRightsScript,
+	"ssgen":      StakeAddress.PayVoteCommitmentScript,
+	"ssrtx":      StakeAddress.PayRevokeCommitmentScript,
+	"sstxchange": StakeAddress.StakeChangeScript,
+	"tgen":       StakeAddress.PayFromTreasuryScript,
+}
+
+// descriptorNode is an intermediate result of parsing a portion of an output
+// descriptor.
+type descriptorNode struct {
+	// script is the script described by the node.
+	script []byte
+
+	// addr is the address that pays to the script described by the node
+	// when there is one.
+	addr Address
+
+	// watchAddrs is the set of addresses to watch for the node.
+	watchAddrs []Address
+}
+
+// descriptorError creates an Error given a set of arguments for a malformed
+// output descriptor.
+func descriptorError(format string, a ...interface{}) Error {
+	return makeError(ErrMalformedDescriptor, fmt.Sprintf(format, a...))
+}
+
+// splitDescriptorFunc splits the provided descriptor expression of the form
+// name(arg1,arg2,...) into the function name and its top-level arguments.
+func splitDescriptorFunc(expr string) (string, []string, error) {
+	open := strings.IndexByte(expr, '(')
+	if open <= 0 || !strings.HasSuffix(expr, ")") {
+		return "", nil, descriptorError("descriptor expression %q is not of "+
+			"the form name(args)", expr)
+	}
+	name := expr[:open]
+	inner := expr[open+1 : len(expr)-1]
+
+	// Split the arguments on the commas that are not nested within any
+	// parenthesis.
+	var args []string
+	var depth, start int
+	for i := 0; i < len(inner); i++ {
+		switch inner[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return "", nil, descriptorError("descriptor expression %q "+
+					"has unbalanced parenthesis", expr)
+			}
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(inner[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return "", nil, descriptorError("descriptor expression %q has "+
+			"unbalanced parenthesis", expr)
+	}
+	args = append(args, strings.TrimSpace(inner[start:]))
+	return name, args, nil
+}
+
+// parseDescriptorPubKeyV0 parses the provided hex-encoded secp256k1 public key
+// in the compressed format into a version 0 pay-to-pubkey address.
+func parseDescriptorPubKeyV0(pubKeyHex string, params AddressParamsV0) (*AddressPubKeyEcdsaSecp256k1V0, error) {
+	pubKey, err := hex.DecodeString(pubKeyHex)
+	if err != nil {
+		return nil, descriptorError("public key %q is not valid hex",
+			pubKeyHex)
+	}
+	return NewAddressPubKeyEcdsaSecp256k1V0Raw(pubKey, params)
+}
+
+// parseDescriptorNodeV0 parses the provided descriptor expression.  The parent
+// parameter is the name of the immediately enclosing expression or an empty
+// string for the top level.
+func parseDescriptorNodeV0(expr string, params AddressParamsV0, parent string) (*descriptorNode, error) {
+	name, args, err := splitDescriptorFunc(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	requireArgs := func(num int) error {
+		if len(args) != num {
+			return descriptorError("%s requires %d argument(s) but %d were "+
+				"provided", name, num, len(args))
+		}
+		return nil
+	}
+
+	switch name {
+	case "addr":
+		if err := requireArgs(1); err != nil {
+			return nil, err
+		}
+		addr, err := DecodeAddressV0(args[0], params)
+		if err != nil {
+			return nil, err
+		}
+		_, script := addr.PaymentScript()
+		return &descriptorNode{
+			script:     script,
+			addr:       addr,
+			watchAddrs: []Address{addr},
+		}, nil
+
+	case "pk":
+		if err := requireArgs(1); err != nil {
+			return nil, err
+		}
+		addr, err := parseDescriptorPubKeyV0(args[0], params)
+		if err != nil {
+			return nil, err
+		}
+		_, script := addr.PaymentScript()
+		return &descriptorNode{
+			script:     script,
+			addr:       addr,
+			watchAddrs: []Address{addr},
+		}, nil
+
+	case "pkh":
+		if err := requireArgs(1); err != nil {
+			return nil, err
+		}
+		pkAddr, err := parseDescriptorPubKeyV0(args[0], params)
+		if err != nil {
+			return nil, err
+		}
+		addr := pkAddr.AddressPubKeyHash()
+		_, script := addr.PaymentScript()
+		return &descriptorNode{
+			script:     script,
+			addr:       addr,
+			watchAddrs: []Address{addr},
+		}, nil
+
+	case "multi":
+		if len(args) < 2 {
+			return nil, descriptorError("multi requires a threshold and at " +
+				"least one public key")
+		}
+		pubKeys := args[1:]
+		if len(pubKeys) > maxDescriptorMultiSigKeys {
+			return nil, descriptorError("multi has %d public keys which is "+
+				"more than the max allowed of %d", len(pubKeys),
+				maxDescriptorMultiSigKeys)
+		}
+		threshold, err := strconv.Atoi(args[0])
+		if err != nil || threshold < 1 || threshold > len(pubKeys) {
+			return nil, descriptorError("multi threshold %q must be an "+
+				"integer in the range [1, %d]", args[0], len(pubKeys))
+		}
+
+		// A multisig script is of the form:
+		//  NUM_SIGS PUBKEY PUBKEY PUBKEY ... NUM_PUBKEYS OP_CHECKMULTISIG
+		const pubKeyLen = 33
+		script := make([]byte, 0, 3+len(pubKeys)*(1+pubKeyLen))
+		script = append(script, txscript.OP_1+byte(threshold-1))
+		watchAddrs := make([]Address, 0, len(pubKeys))
+		for _, pubKeyHex := range pubKeys {
+			addr, err := parseDescriptorPubKeyV0(pubKeyHex, params)
+			if err != nil {
+				return nil, err
+			}
+			script = append(script, txscript.OP_DATA_33)
+			script = append(script, addr.SerializedPubKey()...)
+			watchAddrs = append(watchAddrs, addr)
+		}
+		script = append(script, txscript.OP_1+byte(len(pubKeys)-1))
+		script = append(script, txscript.OP_CHECKMULTISIG)
+		return &descriptorNode{script: script, watchAddrs: watchAddrs}, nil
+
+	case "sh":
+		if err := requireArgs(1); err != nil {
+			return nil, err
+		}
+		_, isStakeWrapper := descriptorStakeScripts[parent]
+		if parent != "" && !isStakeWrapper {
+			return nil, descriptorError("sh is only allowed at the top level "+
+				"or within a stake wrapper, not within %s", parent)
+		}
+		inner, err := parseDescriptorNodeV0(args[0], params, name)
+		if err != nil {
+			return nil, err
+		}
+		script, addr, err := WrapP2SH(inner.script, params)
+		if err != nil {
+			return nil, err
+		}
+		return &descriptorNode{
+			script:     script,
+			addr:       addr,
+			watchAddrs: []Address{addr},
+		}, nil
+	}
+
+	// The remaining expressions are stake wrappers that require a stake
+	// address.
+	stakeScript, ok := descriptorStakeScripts[name]
+	if !ok {
+		return nil, descriptorError("unsupported descriptor expression %q",
+			name)
+	}
+	if err := requireArgs(1); err != nil {
+		return nil, err
+	}
+	if parent != "" {
+		return nil, descriptorError("%s is only allowed at the top level, "+
+			"not within %s", name, parent)
+	}
+
+	inner, err := parseDescriptorNodeV0(args[0], params, name)
+	if err != nil {
+		return nil, err
+	}
+	stakeAddr, ok := inner.addr.(StakeAddress)
+	if !ok {
+		return nil, descriptorError("%s requires a pay-to-pubkey-hash or "+
+			"pay-to-script-hash expression", name)
+	}
+	_, script := stakeScript(stakeAddr)
+	return &descriptorNode{
+		script:     script,
+		addr:       inner.addr,
+		watchAddrs: inner.watchAddrs,
+	}, nil
+}
+
+// ParseDescriptorV0 parses the provided output descriptor that concisely
+// describes a version 0 output script and returns the resulting script along
+// with the set of addresses to watch for outputs that pay to it.
+//
+// The following expressions are supported, where KEY is a hex-encoded
+// secp256k1 public key in the compressed format:
+//
+//	addr(ADDRESS)       - the payment script for the encoded address
+//	pk(KEY)             - pay-to-pubkey
+//	pkh(KEY)            - pay-to-pubkey-hash
+//	multi(M,KEY,...)    - bare M-of-N multisig with up to 16 keys
+//	sh(EXPR)            - pay-to-script-hash of the script for the expression
+//
+// The following stake wrappers are also supported at the top level and require
+// a pay-to-pubkey-hash or pay-to-script-hash expression:
+//
+//	sstx(EXPR)          - ticket purchase voting rights
+//	ssgen(EXPR)         - vote payment to a committed address
+//	ssrtx(EXPR)         - revocation payment to a committed address
+//	sstxchange(EXPR)    - ticket purchase and treasury add change
+//	tgen(EXPR)          - treasury spend payment
+//
+// For example, sh(multi(2,KEY1,KEY2,KEY3)) describes a pay-to-script-hash
+// output for a 2-of-3 multisig redeem script and sstx(pkh(KEY)) describes the
+// voting rights output of a ticket purchase.
+//
+// An error with kind ErrMalformedDescriptor is returned when the descriptor
+// is malformed.  Errors related to the keys and addresses within the
+// descriptor are also returned as is.
+func ParseDescriptorV0(desc string, params AddressParamsV0) (*Descriptor, error) {
+	node, err := parseDescriptorNodeV0(strings.TrimSpace(desc), params, "")
+	if err != nil {
+		return nil, err
+	}
+	return &Descriptor{
+		ScriptVersion: 0,
+		Script:        node.script,
+		Addresses:     node.watchAddrs,
+	}, nil
+}
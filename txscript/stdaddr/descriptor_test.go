@@ -0,0 +1,192 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package stdaddr
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// TestParseDescriptorV0 ensures parsing output descriptors produces the
+// expected scripts and watch addresses and rejects malformed descriptors.
+func TestParseDescriptorV0(t *testing.T) {
+	const (
+		pk1             = "028f53838b7639563f27c94845549a41e5146bcd52e7fef0ea6da143a02b0fe2ed"
+		pk2             = "03e925aafc1edd44e7c7f1ea4fb7d265dc672f204c3d0c81930389c10b81fb75de"
+		pk3             = "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+		pk3Uncompressed = "0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d9" +
+			"59f2815b16f81798483ada7726a3c4655da4fbfc0e1108a8fd17b448a6855419" +
+			"9c47d08ffb10d4b8"
+	)
+	params := mockMainNetParams()
+
+	// Create the addresses that are used to produce the expected results.
+	mustPubKeyAddr := func(pkHex string) *AddressPubKeyEcdsaSecp256k1V0 {
+		t.Helper()
+		addr, err := NewAddressPubKeyEcdsaSecp256k1V0Raw(hexToBytes(pkHex),
+			params)
+		if err != nil {
+			t.Fatalf("unable to create pubkey address: %v", err)
+		}
+		return addr
+	}
+	pkAddr1 := mustPubKeyAddr(pk1)
+	pkAddr2 := mustPubKeyAddr(pk2)
+	pkAddr3 := mustPubKeyAddr(pk3)
+	pkhAddr1 := pkAddr1.AddressPubKeyHash().(*AddressPubKeyHashEcdsaSecp256k1V0)
+	multiScript := hexToBytes("52" + "21" + pk1 + "21" + pk2 + "21" + pk3 +
+		"53ae")
+	shAddr, err := NewAddressScriptHashV0(multiScript, params)
+	if err != nil {
+		t.Fatalf("unable to create script hash address: %v", err)
+	}
+	script := func(f func() (uint16, []byte)) []byte {
+		_, script := f()
+		return script
+	}
+
+	tests := []struct {
+		name       string
+		desc       string
+		wantScript []byte
+		wantAddrs  []Address
+		wantErr    error
+	}{{
+		name:       "address",
+		desc:       "addr(" + pkhAddr1.String() + ")",
+		wantScript: script(pkhAddr1.PaymentScript),
+		wantAddrs:  []Address{pkhAddr1},
+	}, {
+		name:       "pay-to-pubkey",
+		desc:       "pk(" + pk1 + ")",
+		wantScript: script(pkAddr1.PaymentScript),
+		wantAddrs:  []Address{pkAddr1},
+	}, {
+		name:       "pay-to-pubkey-hash with whitespace",
+		desc:       " pkh( " + pk1 + " ) ",
+		wantScript: script(pkhAddr1.PaymentScript),
+		wantAddrs:  []Address{pkhAddr1},
+	}, {
+		name:       "bare multisig",
+		desc:       "multi(2," + pk1 + "," + pk2 + "," + pk3 + ")",
+		wantScript: multiScript,
+		wantAddrs:  []Address{pkAddr1, pkAddr2, pkAddr3},
+	}, {
+		name:       "pay-to-script-hash multisig",
+		desc:       "sh(multi(2," + pk1 + ", " + pk2 + ", " + pk3 + "))",
+		wantScript: script(shAddr.PaymentScript),
+		wantAddrs:  []Address{shAddr},
+	}, {
+		name:       "ticket voting rights pay-to-pubkey-hash",
+		desc:       "sstx(pkh(" + pk1 + "))",
+		wantScript: script(pkhAddr1.VotingRightsScript),
+		wantAddrs:  []Address{pkhAddr1},
+	}, {
+		name:       "vote payment pay-to-script-hash",
+		desc:       "ssgen(sh(multi(2," + pk1 + "," + pk2 + "," + pk3 + ")))",
+		wantScript: script(shAddr.PayVoteCommitmentScript),
+		wantAddrs:  []Address{shAddr},
+	}, {
+		name:       "revocation payment",
+		desc:       "ssrtx(pkh(" + pk1 + "))",
+		wantScript: script(pkhAddr1.PayRevokeCommitmentScript),
+		wantAddrs:  []Address{pkhAddr1},
+	}, {
+		name:       "stake change",
+		desc:       "sstxchange(addr(" + pkhAddr1.String() + "))",
+		wantScript: script(pkhAddr1.StakeChangeScript),
+		wantAddrs:  []Address{pkhAddr1},
+	}, {
+		name:       "treasury spend payment",
+		desc:       "tgen(pkh(" + pk1 + "))",
+		wantScript: script(pkhAddr1.PayFromTreasuryScript),
+		wantAddrs:  []Address{pkhAddr1},
+	}, {
+		name:    "empty descriptor",
+		desc:    "",
+		wantErr: ErrMalformedDescriptor,
+	}, {
+		name:    "unsupported expression",
+		desc:    "wpkh(" + pk1 + ")",
+		wantErr: ErrMalformedDescriptor,
+	}, {
+		name:    "unbalanced parenthesis",
+		desc:    "sh(multi(1," + pk1 + ")",
+		wantErr: ErrMalformedDescriptor,
+	}, {
+		name:    "too many arguments",
+		desc:    "pkh(" + pk1 + "," + pk2 + ")",
+		wantErr: ErrMalformedDescriptor,
+	}, {
+		name:    "invalid hex public key",
+		desc:    "pk(zz)",
+		wantErr: ErrMalformedDescriptor,
+	}, {
+		name:    "uncompressed public key",
+		desc:    "pk(" + pk3Uncompressed + ")",
+		wantErr: ErrInvalidPubKeyFormat,
+	}, {
+		name:    "multisig threshold too high",
+		desc:    "multi(3," + pk1 + "," + pk2 + ")",
+		wantErr: ErrMalformedDescriptor,
+	}, {
+		name:    "multisig threshold zero",
+		desc:    "multi(0," + pk1 + ")",
+		wantErr: ErrMalformedDescriptor,
+	}, {
+		name:    "multisig without keys",
+		desc:    "multi(1)",
+		wantErr: ErrMalformedDescriptor,
+	}, {
+		name:    "nested pay-to-script-hash",
+		desc:    "sh(sh(pkh(" + pk1 + ")))",
+		wantErr: ErrMalformedDescriptor,
+	}, {
+		name:    "nested stake wrapper",
+		desc:    "sstx(ssgen(pkh(" + pk1 + ")))",
+		wantErr: ErrMalformedDescriptor,
+	}, {
+		name:    "stake wrapper within pay-to-script-hash",
+		desc:    "sh(sstx(pkh(" + pk1 + ")))",
+		wantErr: ErrMalformedDescriptor,
+	}, {
+		name:    "stake wrapper with pay-to-pubkey",
+		desc:    "sstx(pk(" + pk1 + "))",
+		wantErr: ErrMalformedDescriptor,
+	}}
+
+	for _, test := range tests {
+		desc, err := ParseDescriptorV0(test.desc, params)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%q: mismatched err -- got %v, want %v", test.name, err,
+				test.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		if desc.ScriptVersion != 0 {
+			t.Errorf("%q: unexpected script version -- got %d, want 0",
+				test.name, desc.ScriptVersion)
+		}
+		if !bytes.Equal(desc.Script, test.wantScript) {
+			t.Errorf("%q: mismatched script -- got %x, want %x", test.name,
+				desc.Script, test.wantScript)
+		}
+		if len(desc.Addresses) != len(test.wantAddrs) {
+			t.Errorf("%q: mismatched number of addresses -- got %d, want %d",
+				test.name, len(desc.Addresses), len(test.wantAddrs))
+			continue
+		}
+		for i, addr := range desc.Addresses {
+			if addr.String() != test.wantAddrs[i].String() {
+				t.Errorf("%q: mismatched address %d -- got %v, want %v",
+					test.name, i, addr, test.wantAddrs[i])
+			}
+		}
+	}
+}
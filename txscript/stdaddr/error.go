@@ -39,6 +39,14 @@ const (
 	// ErrInvalidHashLen indicates that either a public key hash or a script
 	// hash is not an allowed length.
 	ErrInvalidHashLen = ErrorKind("ErrInvalidHashLen")
+
+	// ErrMalformedDescriptor indicates an output descriptor is not
+	// well formed or makes use of unsupported expressions.
+	ErrMalformedDescriptor = ErrorKind("ErrMalformedDescriptor")
+
+	// ErrRedeemScriptTooLarge indicates a redeem script is larger than the
+	// maximum allowed script element size and therefore can't be redeemed.
+	ErrRedeemScriptTooLarge = ErrorKind("ErrRedeemScriptTooLarge")
 )
 
 // Error satisfies the error interface and prints human-readable errors.
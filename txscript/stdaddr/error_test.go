@@ -24,6 +24,8 @@ func TestErrorKindStringer(t *testing.T) {
 		{ErrInvalidPubKey, "ErrInvalidPubKey"},
 		{ErrInvalidPubKeyFormat, "ErrInvalidPubKeyFormat"},
 		{ErrInvalidHashLen, "ErrInvalidHashLen"},
+		{ErrMalformedDescriptor, "ErrMalformedDescriptor"},
+		{ErrRedeemScriptTooLarge, "ErrRedeemScriptTooLarge"},
 	}
 
 	for i, test := range tests {
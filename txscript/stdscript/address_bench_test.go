@@ -0,0 +1,38 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package stdscript
+
+import (
+	"testing"
+)
+
+// BenchmarkExtractAddrs benchmarks the performance of extracting the script
+// type and addresses from various public key scripts.
+func BenchmarkExtractAddrs(b *testing.B) {
+	// Limit to one of each script type.
+	counts := make(map[ScriptType]int)
+	benches := make([]addressTest, 0, len(addressV0Tests))
+	for _, test := range addressV0Tests {
+		counts[test.wantType]++
+		if counts[test.wantType] == 1 {
+			benches = append(benches, test)
+		}
+	}
+
+	for _, bench := range benches {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				gotType, _ := ExtractAddrs(bench.version, bench.script,
+					bench.params)
+				if gotType != bench.wantType {
+					b.Fatalf("%q: unexpected result -- got %v, want %v",
+						bench.name, gotType, bench.wantType)
+				}
+			}
+		})
+	}
+}
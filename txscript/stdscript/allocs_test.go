@@ -0,0 +1,184 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package stdscript
+
+import (
+	"testing"
+
+	"github.com/decred/dcrd/txscript/v4"
+)
+
+// allocsTestRuns is the number of runs used to average the number of
+// allocations performed by the functions under test.
+const allocsTestRuns = 100
+
+// makeAllocsTests returns the version 0 test scripts along with the complex
+// non standard script used in the benchmarks that successfully parse.  Scripts
+// that fail to parse are excluded since the resulting parse errors necessarily
+// allocate in order to provide a descriptive error.
+func makeAllocsTests() []scriptTest {
+	return makeBenchmarks(func(test scriptTest) bool {
+		tokenizer := txscript.MakeScriptTokenizer(test.version, test.script)
+		for tokenizer.Next() {
+		}
+		return tokenizer.Err() == nil
+	})
+}
+
+// TestScriptTypeDetectionAllocs ensures the functions that determine the type
+// of scripts and the number of required signatures do not allocate for any of
+// the version 0 test scripts that parse.  This helps prevent regressions in
+// refactors of the standard script detection code since it is called extremely
+// frequently.
+func TestScriptTypeDetectionAllocs(t *testing.T) {
+	isXFuncs := []struct {
+		name string
+		fn   func(scriptVersion uint16, script []byte) bool
+	}{
+		{"IsPubKeyScript", IsPubKeyScript},
+		{"IsPubKeyEd25519Script", IsPubKeyEd25519Script},
+		{"IsPubKeySchnorrSecp256k1Script", IsPubKeySchnorrSecp256k1Script},
+		{"IsPubKeyHashScript", IsPubKeyHashScript},
+		{"IsPubKeyHashEd25519Script", IsPubKeyHashEd25519Script},
+		{"IsPubKeyHashSchnorrSecp256k1Script", IsPubKeyHashSchnorrSecp256k1Script},
+		{"IsScriptHashScript", IsScriptHashScript},
+		{"IsMultiSigScript", IsMultiSigScript},
+		{"IsMultiSigSigScript", IsMultiSigSigScript},
+		{"IsNullDataScript", IsNullDataScript},
+		{"IsStakeSubmissionPubKeyHashScript", IsStakeSubmissionPubKeyHashScript},
+		{"IsStakeSubmissionScriptHashScript", IsStakeSubmissionScriptHashScript},
+		{"IsStakeGenPubKeyHashScript", IsStakeGenPubKeyHashScript},
+		{"IsStakeGenScriptHashScript", IsStakeGenScriptHashScript},
+		{"IsStakeRevocationPubKeyHashScript", IsStakeRevocationPubKeyHashScript},
+		{"IsStakeRevocationScriptHashScript", IsStakeRevocationScriptHashScript},
+		{"IsStakeChangePubKeyHashScript", IsStakeChangePubKeyHashScript},
+		{"IsStakeChangeScriptHashScript", IsStakeChangeScriptHashScript},
+		{"IsTreasuryAddScript", IsTreasuryAddScript},
+		{"IsTreasuryGenPubKeyHashScript", IsTreasuryGenPubKeyHashScript},
+		{"IsTreasuryGenScriptHashScript", IsTreasuryGenScriptHashScript},
+	}
+
+	tests := makeAllocsTests()
+	for _, test := range tests {
+		allocs := testing.AllocsPerRun(allocsTestRuns, func() {
+			DetermineScriptType(test.version, test.script)
+		})
+		if allocs != 0 {
+			t.Errorf("%q: DetermineScriptType unexpected allocs -- got %v, "+
+				"want 0", test.name, allocs)
+		}
+
+		allocs = testing.AllocsPerRun(allocsTestRuns, func() {
+			DetermineRequiredSigs(test.version, test.script)
+		})
+		if allocs != 0 {
+			t.Errorf("%q: DetermineRequiredSigs unexpected allocs -- got %v, "+
+				"want 0", test.name, allocs)
+		}
+
+		for _, isX := range isXFuncs {
+			allocs := testing.AllocsPerRun(allocsTestRuns, func() {
+				isX.fn(test.version, test.script)
+			})
+			if allocs != 0 {
+				t.Errorf("%q: %s unexpected allocs -- got %v, want 0",
+					test.name, isX.name, allocs)
+			}
+		}
+	}
+}
+
+// TestExtractV0Allocs ensures the version 0 helper functions that extract data
+// from scripts do not allocate for any of the version 0 test scripts that parse
+// since the data they return refers directly to the provided script.
+func TestExtractV0Allocs(t *testing.T) {
+	extractFuncs := []struct {
+		name string
+		fn   func(script []byte) []byte
+	}{
+		{"ExtractCompressedPubKeyV0", ExtractCompressedPubKeyV0},
+		{"ExtractUncompressedPubKeyV0", ExtractUncompressedPubKeyV0},
+		{"ExtractPubKeyV0", ExtractPubKeyV0},
+		{"ExtractPubKeyEd25519V0", ExtractPubKeyEd25519V0},
+		{"ExtractPubKeySchnorrSecp256k1V0", ExtractPubKeySchnorrSecp256k1V0},
+		{"ExtractPubKeyHashV0", ExtractPubKeyHashV0},
+		{"ExtractPubKeyHashEd25519V0", ExtractPubKeyHashEd25519V0},
+		{"ExtractPubKeyHashSchnorrSecp256k1V0", ExtractPubKeyHashSchnorrSecp256k1V0},
+		{"ExtractScriptHashV0", ExtractScriptHashV0},
+		{"ExtractStakePubKeyHashV0", ExtractStakePubKeyHashV0},
+		{"ExtractStakeScriptHashV0", ExtractStakeScriptHashV0},
+		{"ExtractStakeSubmissionPubKeyHashV0", ExtractStakeSubmissionPubKeyHashV0},
+		{"ExtractStakeSubmissionScriptHashV0", ExtractStakeSubmissionScriptHashV0},
+		{"ExtractStakeGenPubKeyHashV0", ExtractStakeGenPubKeyHashV0},
+		{"ExtractStakeGenScriptHashV0", ExtractStakeGenScriptHashV0},
+		{"ExtractStakeRevocationPubKeyHashV0", ExtractStakeRevocationPubKeyHashV0},
+		{"ExtractStakeRevocationScriptHashV0", ExtractStakeRevocationScriptHashV0},
+		{"ExtractStakeChangePubKeyHashV0", ExtractStakeChangePubKeyHashV0},
+		{"ExtractStakeChangeScriptHashV0", ExtractStakeChangeScriptHashV0},
+		{"ExtractTreasuryGenPubKeyHashV0", ExtractTreasuryGenPubKeyHashV0},
+		{"ExtractTreasuryGenScriptHashV0", ExtractTreasuryGenScriptHashV0},
+	}
+
+	tests := makeAllocsTests()
+	for _, test := range tests {
+		for _, extract := range extractFuncs {
+			allocs := testing.AllocsPerRun(allocsTestRuns, func() {
+				extract.fn(test.script)
+			})
+			if allocs != 0 {
+				t.Errorf("%q: %s unexpected allocs -- got %v, want 0",
+					test.name, extract.name, allocs)
+			}
+		}
+
+		allocs := testing.AllocsPerRun(allocsTestRuns, func() {
+			ExtractMultiSigScriptDetailsV0(test.script, false)
+		})
+		if allocs != 0 {
+			t.Errorf("%q: ExtractMultiSigScriptDetailsV0 unexpected allocs "+
+				"-- got %v, want 0", test.name, allocs)
+		}
+	}
+}
+
+// TestExtractAddrsAllocs ensures extracting addresses from the version 0 test
+// scripts for which the address is created directly from a hash does not
+// exceed the allocation budget of one allocation for the returned slice and
+// one for the address itself.  It also ensures scripts that never produce
+// addresses do not allocate.
+func TestExtractAddrsAllocs(t *testing.T) {
+	budgets := map[ScriptType]float64{
+		STPubKeyHashEcdsaSecp256k1:   2,
+		STPubKeyHashEd25519:          2,
+		STPubKeyHashSchnorrSecp256k1: 2,
+		STScriptHash:                 2,
+		STStakeSubmissionPubKeyHash:  2,
+		STStakeSubmissionScriptHash:  2,
+		STStakeGenPubKeyHash:         2,
+		STStakeGenScriptHash:         2,
+		STStakeRevocationPubKeyHash:  2,
+		STStakeRevocationScriptHash:  2,
+		STStakeChangePubKeyHash:      2,
+		STStakeChangeScriptHash:      2,
+		STTreasuryGenPubKeyHash:      2,
+		STTreasuryGenScriptHash:      2,
+		STNullData:                   0,
+		STTreasuryAdd:                0,
+	}
+
+	for _, test := range addressV0Tests {
+		budget, ok := budgets[test.wantType]
+		if !ok {
+			continue
+		}
+		allocs := testing.AllocsPerRun(allocsTestRuns, func() {
+			ExtractAddrs(test.version, test.script, test.params)
+		})
+		if allocs > budget {
+			t.Errorf("%q: ExtractAddrs exceeded allocation budget -- got %v, "+
+				"want <= %v", test.name, allocs, budget)
+		}
+	}
+}
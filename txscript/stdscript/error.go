@@ -29,6 +29,22 @@ const (
 	// provably-pruneable script with data that exceeds the maximum allowed
 	// length.
 	ErrTooMuchNullData = ErrorKind("ErrTooMuchNullData")
+
+	// ErrUnknownScriptType is returned when attempting to encode or decode a
+	// script type that is not known.
+	ErrUnknownScriptType = ErrorKind("ErrUnknownScriptType")
+
+	// ErrInvalidScriptTypeDetector is returned when attempting to register a
+	// script type detector that is invalid or conflicts with an existing one.
+	ErrInvalidScriptTypeDetector = ErrorKind("ErrInvalidScriptTypeDetector")
+
+	// ErrMissingPrevOut is returned from AnalyzeTransactionScripts when a
+	// previous output referenced by a transaction input is not available.
+	ErrMissingPrevOut = ErrorKind("ErrMissingPrevOut")
+
+	// ErrInvalidPreimageSize is returned when attempting to generate a hash
+	// puzzle script with a preimage size that is not possible to satisfy.
+	ErrInvalidPreimageSize = ErrorKind("ErrInvalidPreimageSize")
 )
 
 // Error satisfies the error interface and prints human-readable errors.
@@ -21,6 +21,10 @@ func TestErrorKindStringer(t *testing.T) {
 		{ErrTooManyRequiredSigs, "ErrTooManyRequiredSigs"},
 		{ErrPubKeyType, "ErrPubKeyType"},
 		{ErrTooMuchNullData, "ErrTooMuchNullData"},
+		{ErrUnknownScriptType, "ErrUnknownScriptType"},
+		{ErrInvalidScriptTypeDetector, "ErrInvalidScriptTypeDetector"},
+		{ErrMissingPrevOut, "ErrMissingPrevOut"},
+		{ErrInvalidPreimageSize, "ErrInvalidPreimageSize"},
 	}
 
 	for i, test := range tests {
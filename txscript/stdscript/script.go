@@ -5,6 +5,11 @@
 // Package stdscript provides facilities for working with standard scripts.
 package stdscript
 
+import (
+	"fmt"
+	"sync"
+)
+
 // ScriptType identifies the type of known scripts in the blockchain that are
 // typically considered standard by the default policy of most nodes.  All other
 // scripts are considered non-standard.
@@ -177,6 +182,9 @@ const (
 
 // scriptTypeToName houses the human-readable strings which describe each script
 // type.
+//
+// NOTE: These names are used for the text and JSON representations of script
+// types and therefore MUST NOT be changed once they are defined.
 var scriptTypeToName = []string{
 	STNonStandard:                "nonstandard",
 	STPubKeyEcdsaSecp256k1:       "pubkey",
@@ -209,6 +217,74 @@ func (t ScriptType) String() string {
 	return scriptTypeToName[t]
 }
 
+// MarshalText satisfies the encoding.TextMarshaler interface which results in
+// script types being encoded as their stable human-readable names in formats
+// such as JSON.  An error is returned for unknown script types.
+func (t ScriptType) MarshalText() ([]byte, error) {
+	if t >= numScriptTypes {
+		str := fmt.Sprintf("unknown script type %d", t)
+		return nil, makeError(ErrUnknownScriptType, str)
+	}
+	return []byte(scriptTypeToName[t]), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface by decoding
+// the stable human-readable name of a script type.  An error is returned for
+// unknown names.
+func (t *ScriptType) UnmarshalText(text []byte) error {
+	for st, name := range scriptTypeToName {
+		if name == string(text) {
+			*t = ScriptType(st)
+			return nil
+		}
+	}
+	str := fmt.Sprintf("unknown script type %q", text)
+	return makeError(ErrUnknownScriptType, str)
+}
+
+// ScriptTypeDetector defines the function signature used to determine the type
+// of a script for a specific script version.  Detectors must return
+// STNonStandard for scripts that are not one of the standard forms for the
+// version, including scripts that do not parse.
+type ScriptTypeDetector func(script []byte) ScriptType
+
+var (
+	// scriptTypeDetectorsMtx protects the registered script type detectors.
+	scriptTypeDetectorsMtx sync.RWMutex
+
+	// scriptTypeDetectors houses the registered script type detectors for
+	// script versions other than version 0 keyed by the script version.
+	// Version 0 is handled directly since it is by far the most common.
+	scriptTypeDetectors = make(map[uint16]ScriptTypeDetector)
+)
+
+// RegisterScriptTypeDetector registers the provided detector for determining
+// the type of scripts with the given script version so that DetermineScriptType
+// recognizes standard scripts of that version.
+//
+// An error is returned when the detector is nil or a detector is already
+// registered for the script version, which is always the case for version 0.
+//
+// This is typically only called during package initialization.
+func RegisterScriptTypeDetector(scriptVersion uint16, detector ScriptTypeDetector) error {
+	if detector == nil {
+		str := fmt.Sprintf("script type detector for script version %d must "+
+			"not be nil", scriptVersion)
+		return makeError(ErrInvalidScriptTypeDetector, str)
+	}
+
+	scriptTypeDetectorsMtx.Lock()
+	defer scriptTypeDetectorsMtx.Unlock()
+	_, ok := scriptTypeDetectors[scriptVersion]
+	if ok || scriptVersion == 0 {
+		str := fmt.Sprintf("a script type detector for script version %d is "+
+			"already registered", scriptVersion)
+		return makeError(ErrInvalidScriptTypeDetector, str)
+	}
+	scriptTypeDetectors[scriptVersion] = detector
+	return nil
+}
+
 // IsPubKeyScript returns whether or not the passed script is either a standard
 // pay-to-compressed-secp256k1-pubkey or pay-to-uncompressed-secp256k1-pubkey
 // script.
@@ -512,18 +588,26 @@ func IsTreasuryGenScriptHashScript(scriptVersion uint16, script []byte) bool {
 
 // DetermineScriptType returns the type of the script passed.
 //
-// NOTE: Version 0 scripts are the only currently supported version.  It will
-// always return STNonStandard for other script versions.
+// Version 0 scripts are always supported while other script versions are only
+// supported when a detector has been registered for them via
+// RegisterScriptTypeDetector.  It will always return STNonStandard for script
+// versions without a registered detector.
 //
 // Similarly, STNonStandard is returned when the script does not parse.
 func DetermineScriptType(scriptVersion uint16, script []byte) ScriptType {
-	switch scriptVersion {
-	case 0:
+	if scriptVersion == 0 {
 		return DetermineScriptTypeV0(script)
 	}
 
-	// All scripts with newer versions are considered non standard.
-	return STNonStandard
+	scriptTypeDetectorsMtx.RLock()
+	detector, ok := scriptTypeDetectors[scriptVersion]
+	scriptTypeDetectorsMtx.RUnlock()
+	if !ok {
+		// All scripts with versions that do not have a registered detector
+		// are considered non standard.
+		return STNonStandard
+	}
+	return detector(script)
 }
 
 // DetermineRequiredSigs attempts to identify the number of signatures required
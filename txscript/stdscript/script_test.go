@@ -5,6 +5,8 @@
 package stdscript
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 )
 
@@ -55,6 +57,107 @@ func TestScriptTypeStringer(t *testing.T) {
 	}
 }
 
+// TestScriptTypeText ensures script types round trip through their stable text
+// and JSON representations and that unknown script types are rejected.
+func TestScriptTypeText(t *testing.T) {
+	t.Parallel()
+
+	for st := STNonStandard; st < numScriptTypes; st++ {
+		text, err := st.MarshalText()
+		if err != nil {
+			t.Fatalf("%v: unexpected marshal error: %v", st, err)
+		}
+		if string(text) != st.String() {
+			t.Fatalf("%v: unexpected text -- got %s, want %s", st, text,
+				st.String())
+		}
+
+		// Ensure the JSON encoding uses the name and decodes to the same
+		// script type.
+		encoded, err := json.Marshal(st)
+		if err != nil {
+			t.Fatalf("%v: unexpected JSON marshal error: %v", st, err)
+		}
+		if want := `"` + st.String() + `"`; string(encoded) != want {
+			t.Fatalf("%v: unexpected JSON -- got %s, want %s", st, encoded,
+				want)
+		}
+		var decoded ScriptType
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("%v: unexpected JSON unmarshal error: %v", st, err)
+		}
+		if decoded != st {
+			t.Fatalf("%v: unexpected decoded script type -- got %v", st,
+				decoded)
+		}
+	}
+
+	// Ensure unknown script types and names are rejected.
+	if _, err := numScriptTypes.MarshalText(); !errors.Is(err, ErrUnknownScriptType) {
+		t.Fatalf("unexpected marshal error -- got %v, want %v", err,
+			ErrUnknownScriptType)
+	}
+	var st ScriptType
+	for _, name := range []string{"", "invalid", "PubKey"} {
+		err := st.UnmarshalText([]byte(name))
+		if !errors.Is(err, ErrUnknownScriptType) {
+			t.Fatalf("%q: unexpected unmarshal error -- got %v, want %v",
+				name, err, ErrUnknownScriptType)
+		}
+	}
+}
+
+// TestRegisterScriptTypeDetector ensures registering script type detectors for
+// new script versions works as intended.
+func TestRegisterScriptTypeDetector(t *testing.T) {
+	t.Parallel()
+
+	// Ensure nil detectors and detectors for version 0 are rejected.
+	err := RegisterScriptTypeDetector(0xfffe, nil)
+	if !errors.Is(err, ErrInvalidScriptTypeDetector) {
+		t.Fatalf("unexpected error for nil detector -- got %v, want %v", err,
+			ErrInvalidScriptTypeDetector)
+	}
+	err = RegisterScriptTypeDetector(0, DetermineScriptTypeV0)
+	if !errors.Is(err, ErrInvalidScriptTypeDetector) {
+		t.Fatalf("unexpected error for version 0 detector -- got %v, want %v",
+			err, ErrInvalidScriptTypeDetector)
+	}
+
+	// Register a detector for a new script version that considers all scripts
+	// that consist of a single OP_TRUE as null data scripts.
+	const scriptVersion = 0xfffe
+	script := []byte{0x51}
+	if got := DetermineScriptType(scriptVersion, script); got != STNonStandard {
+		t.Fatalf("unexpected type prior to registration -- got %v, want %v",
+			got, STNonStandard)
+	}
+	detector := func(script []byte) ScriptType {
+		if len(script) == 1 && script[0] == 0x51 {
+			return STNullData
+		}
+		return STNonStandard
+	}
+	if err := RegisterScriptTypeDetector(scriptVersion, detector); err != nil {
+		t.Fatalf("unexpected registration error: %v", err)
+	}
+	if got := DetermineScriptType(scriptVersion, script); got != STNullData {
+		t.Fatalf("unexpected type after registration -- got %v, want %v",
+			got, STNullData)
+	}
+	if got := DetermineScriptType(scriptVersion, nil); got != STNonStandard {
+		t.Fatalf("unexpected type for empty script -- got %v, want %v", got,
+			STNonStandard)
+	}
+
+	// Ensure registering a second detector for the same version is rejected.
+	err = RegisterScriptTypeDetector(scriptVersion, detector)
+	if !errors.Is(err, ErrInvalidScriptTypeDetector) {
+		t.Fatalf("unexpected error for duplicate detector -- got %v, want %v",
+			err, ErrInvalidScriptTypeDetector)
+	}
+}
+
 // scriptTest describes tests for scripts that are used to ensure various script
 // types and data extraction is working as expected.  It's defined separately
 // since it is intended for use in multiple shared per-version tests.
@@ -75,12 +75,17 @@ func IsPubKeyScriptV0(script []byte) bool {
 // ExtractPubKeyAltDetailsV0 extracts the public key and signature type from the
 // passed script if it is a standard version 0 pay-to-alt-pubkey script.  It
 // will return nil otherwise.
+//
+// The supported signature types along with their public key requirements are
+// defined by the alt signature suites registered with txscript.  Note that
+// suites which are registered, but not yet active according to the consensus
+// rules, are also recognized.
 func ExtractPubKeyAltDetailsV0(script []byte) ([]byte, dcrec.SignatureType) {
 	// A pay-to-alt-pubkey script is of the form:
 	//  PUBKEY SIGTYPE OP_CHECKSIGALT
 	//
-	// The only two currently supported alternative signature types are ed25519
-	// and schnorr + secp256k1 (with a compressed pubkey).
+	// The built-in alternative signature types are ed25519 and schnorr +
+	// secp256k1 (with a compressed pubkey).
 	//
 	//  OP_DATA_32 <32-byte pubkey> <1-byte ed25519 sigtype> OP_CHECKSIGALT
 	//  OP_DATA_33 <33-byte pubkey> <1-byte schnorr+secp sigtype> OP_CHECKSIGALT
@@ -93,22 +98,33 @@ func ExtractPubKeyAltDetailsV0(script []byte) ([]byte, dcrec.SignatureType) {
 		return nil, 0
 	}
 
-	if len(script) == 35 && script[0] == txscript.OP_DATA_32 &&
-		txscript.IsSmallInt(script[33]) &&
-		txscript.AsSmallInt(script[33]) == dcrec.STEd25519 {
-
-		return script[1:33], dcrec.STEd25519
+	// The signature type must be a standard alt signature type.
+	sigTypeOp := script[len(script)-2]
+	if !IsStandardAltSignatureTypeV0(sigTypeOp) {
+		return nil, 0
 	}
+	sigType := dcrec.SignatureType(txscript.AsSmallInt(sigTypeOp))
+	suite, _ := txscript.LookupAltSigSuite(sigType)
 
-	if len(script) == 36 && script[0] == txscript.OP_DATA_33 &&
-		txscript.IsSmallInt(script[34]) &&
-		txscript.AsSmallInt(script[34]) == dcrec.STSchnorrSecp256k1 &&
-		txscript.IsStrictCompressedPubKeyEncoding(script[1:34]) {
+	// The signature type must be preceded by a single canonical push of a
+	// public key with the length and encoding required by the suite.
+	const scriptVersion = 0
+	tokenizer := txscript.MakeScriptTokenizer(scriptVersion,
+		script[:len(script)-2])
+	if !tokenizer.Next() || !tokenizer.Done() {
+		return nil, 0
+	}
+	pubKey := tokenizer.Data()
+	if len(pubKey) != suite.PubKeyLen ||
+		!isCanonicalPushV0(tokenizer.Opcode(), pubKey) {
 
-		return script[1:34], dcrec.STSchnorrSecp256k1
+		return nil, 0
+	}
+	if suite.IsStandardPubKey != nil && !suite.IsStandardPubKey(pubKey) {
+		return nil, 0
 	}
 
-	return nil, 0
+	return pubKey, sigType
 }
 
 // ExtractPubKeyEd25519V0 extracts a public key from the passed script if it is
@@ -171,14 +187,20 @@ func IsPubKeyHashScriptV0(script []byte) bool {
 }
 
 // IsStandardAltSignatureTypeV0 returns whether or not the provided version 0
-// script opcode represents a push of a standard alt signature type.
+// script opcode represents a push of a standard alt signature type.  The
+// standard alt signature types are those with an alt signature suite
+// registered with txscript.
 func IsStandardAltSignatureTypeV0(op byte) bool {
 	if !txscript.IsSmallInt(op) {
 		return false
 	}
 
 	sigType := txscript.AsSmallInt(op)
-	return sigType == dcrec.STEd25519 || sigType == dcrec.STSchnorrSecp256k1
+	if sigType == 0 {
+		return false
+	}
+	_, ok := txscript.LookupAltSigSuite(dcrec.SignatureType(sigType))
+	return ok
 }
 
 // ExtractPubKeyHashAltDetailsV0 extracts the public key hash and signature type
@@ -188,8 +210,8 @@ func ExtractPubKeyHashAltDetailsV0(script []byte) ([]byte, dcrec.SignatureType)
 	// A pay-to-alt-pubkey-hash script is of the form:
 	//  DUP HASH160 <20-byte hash> EQUALVERIFY SIGTYPE CHECKSIG
 	//
-	// The only two currently supported alternative signature types are ed25519
-	// and schnorr + secp256k1 (with a compressed pubkey).
+	// The built-in alternative signature types are ed25519 and schnorr +
+	// secp256k1 (with a compressed pubkey).
 	//
 	//  DUP HASH160 <20-byte hash> EQUALVERIFY <1-byte ed25519 sigtype> CHECKSIGALT
 	//  DUP HASH160 <20-byte hash> EQUALVERIFY <1-byte schnorr+secp sigtype> CHECKSIGALT
@@ -980,3 +1002,161 @@ func ExtractAtomicSwapDataPushesV0(redeemScript []byte) *AtomicSwapDataPushesV0
 	copy(pushes.RefundHash160[:], template[16].extractedData)
 	return &pushes
 }
+
+// HashPuzzleTypeV0 identifies the hash function used by a version 0 hash
+// puzzle script.
+type HashPuzzleTypeV0 byte
+
+const (
+	// HashPuzzleSha256V0 identifies a hash puzzle that requires a preimage
+	// that hashes to a specific value via SHA-256 (OP_SHA256).
+	HashPuzzleSha256V0 HashPuzzleTypeV0 = iota
+
+	// HashPuzzleHash160V0 identifies a hash puzzle that requires a preimage
+	// that hashes to a specific value via RIPEMD-160(BLAKE-256) (OP_HASH160).
+	HashPuzzleHash160V0
+)
+
+// HashPuzzleDataV0 houses the details extracted from a version 0 hash puzzle
+// script.
+type HashPuzzleDataV0 struct {
+	// Type identifies the hash function the preimage must hash to Hash with.
+	Type HashPuzzleTypeV0
+
+	// Hash is the hash the preimage must hash to.  It is 32 bytes for
+	// HashPuzzleSha256V0 and 20 bytes for HashPuzzleHash160V0.
+	Hash []byte
+
+	// PreimageSize is the required size of the preimage in bytes.
+	PreimageSize int64
+}
+
+// hashPuzzleScriptV0 returns a valid version 0 hash puzzle script that
+// requires a preimage of the provided size which hashes to the provided hash
+// with the hash function associated with the given hash opcode.
+func hashPuzzleScriptV0(hashOp byte, hash []byte, preimageSize int64) ([]byte, error) {
+	if preimageSize < 1 || preimageSize > txscript.MaxScriptElementSize {
+		str := fmt.Sprintf("unable to generate hash puzzle script with a "+
+			"preimage size of %d bytes (must be between 1 and %d)",
+			preimageSize, txscript.MaxScriptElementSize)
+		return nil, makeError(ErrInvalidPreimageSize, str)
+	}
+
+	return txscript.NewScriptBuilder().
+		AddOp(txscript.OP_SIZE).AddInt64(preimageSize).
+		AddOp(txscript.OP_EQUALVERIFY).AddOp(hashOp).AddData(hash).
+		AddOp(txscript.OP_EQUAL).Script()
+}
+
+// PayToSha256PuzzleScriptV0 returns a valid version 0 hash puzzle script which
+// may only be redeemed by providing a preimage of the given size that hashes to
+// the provided hash via SHA-256.  This is commonly referred to as a hash lock
+// and is used by some swap and payment protocols.  The size of the preimage is
+// committed to in order to prevent issues in protocols where the same preimage
+// is used across chains with different limits on the size of data pushes.
+//
+// An Error with kind ErrInvalidPreimageSize will be returned if the preimage
+// size is not between 1 and txscript.MaxScriptElementSize.
+//
+// NOTE: Hash puzzles are not considered standard script types by the dcrd
+// mempool policy and should be used with P2SH.  Also, anyone that observes the
+// preimage, such as in the mempool, is able to redeem the output, so they are
+// typically used as a part of more complex scripts.
+func PayToSha256PuzzleScriptV0(hash [32]byte, preimageSize int64) ([]byte, error) {
+	return hashPuzzleScriptV0(txscript.OP_SHA256, hash[:], preimageSize)
+}
+
+// PayToHash160PuzzleScriptV0 returns a valid version 0 hash puzzle script
+// which may only be redeemed by providing a preimage of the given size that
+// hashes to the provided hash via RIPEMD-160(BLAKE-256).
+//
+// See PayToSha256PuzzleScriptV0 for more details.
+func PayToHash160PuzzleScriptV0(hash [20]byte, preimageSize int64) ([]byte, error) {
+	return hashPuzzleScriptV0(txscript.OP_HASH160, hash[:], preimageSize)
+}
+
+// ExtractHashPuzzleDataV0 returns the details of a version 0 hash puzzle script
+// as created by PayToSha256PuzzleScriptV0 or PayToHash160PuzzleScriptV0 if it
+// is one.  It will return nil otherwise.
+func ExtractHashPuzzleDataV0(script []byte) *HashPuzzleDataV0 {
+	// A hash puzzle is of the form:
+	//  SIZE <preimage size> EQUALVERIFY <SHA256 or HASH160> <hash> EQUAL
+	//
+	// Notice that the preimage size is required to be a canonically-encoded
+	// push of the size so there is only a single valid script for any given
+	// set of details.
+	const scriptVersion = 0
+	tokenizer := txscript.MakeScriptTokenizer(scriptVersion, script)
+	if !tokenizer.Next() || tokenizer.Opcode() != txscript.OP_SIZE {
+		return nil
+	}
+
+	// The preimage size must be a canonical push of a valid size.
+	if !tokenizer.Next() {
+		return nil
+	}
+	var preimageSize int64
+	op, data := tokenizer.Opcode(), tokenizer.Data()
+	switch {
+	case txscript.IsSmallInt(op):
+		preimageSize = int64(txscript.AsSmallInt(op))
+
+	case data != nil && isCanonicalPushV0(op, data):
+		const maxIntBytes = txscript.MathOpCodeMaxScriptNumLen
+		val, err := txscript.MakeScriptNum(data, maxIntBytes)
+		if err != nil || int64(val) <= 16 {
+			return nil
+		}
+		preimageSize = int64(val)
+
+	default:
+		return nil
+	}
+	if preimageSize < 1 || preimageSize > txscript.MaxScriptElementSize {
+		return nil
+	}
+
+	if !tokenizer.Next() || tokenizer.Opcode() != txscript.OP_EQUALVERIFY {
+		return nil
+	}
+
+	// The hash opcode determines the type of the puzzle and the required size
+	// of the hash.
+	if !tokenizer.Next() {
+		return nil
+	}
+	var puzzleType HashPuzzleTypeV0
+	var hashPushOp byte
+	switch tokenizer.Opcode() {
+	case txscript.OP_SHA256:
+		puzzleType, hashPushOp = HashPuzzleSha256V0, txscript.OP_DATA_32
+	case txscript.OP_HASH160:
+		puzzleType, hashPushOp = HashPuzzleHash160V0, txscript.OP_DATA_20
+	default:
+		return nil
+	}
+	if !tokenizer.Next() || tokenizer.Opcode() != hashPushOp {
+		return nil
+	}
+	hash := tokenizer.Data()
+
+	if !tokenizer.Next() || tokenizer.Opcode() != txscript.OP_EQUAL {
+		return nil
+	}
+	if tokenizer.Next() || tokenizer.Err() != nil {
+		return nil
+	}
+
+	return &HashPuzzleDataV0{
+		Type:         puzzleType,
+		Hash:         append([]byte(nil), hash...),
+		PreimageSize: preimageSize,
+	}
+}
+
+// IsHashPuzzleScriptV0 returns whether or not the passed script is a version 0
+// hash puzzle script as created by PayToSha256PuzzleScriptV0 or
+// PayToHash160PuzzleScriptV0.
+func IsHashPuzzleScriptV0(script []byte) bool {
+	return ExtractHashPuzzleDataV0(script) != nil
+}
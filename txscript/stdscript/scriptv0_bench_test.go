@@ -60,3 +60,85 @@ func BenchmarkExtractStakeScriptHashV0(b *testing.B) {
 		})
 	}
 }
+
+// benchExtractV0 is a convenience function that runs benchmarks for one of each
+// script type that matches the provided filter function using the given version
+// 0 data extraction function.
+func benchExtractV0(b *testing.B, filterFn func(test scriptTest) bool, extractFn func(script []byte) []byte) {
+	b.Helper()
+
+	counts := make(map[ScriptType]int)
+	benches := makeBenchmarks(func(test scriptTest) bool {
+		// Limit to one of each script type.
+		counts[test.wantType]++
+		return counts[test.wantType] == 1 && filterFn(test)
+	})
+
+	for _, bench := range benches {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				extractFn(bench.script)
+			}
+		})
+	}
+}
+
+// BenchmarkExtractPubKeyV0 benchmarks the performance of attempting to extract
+// public keys from various version 0 public key scripts.
+func BenchmarkExtractPubKeyV0(b *testing.B) {
+	filterFn := func(test scriptTest) bool {
+		return test.wantType == STPubKeyEcdsaSecp256k1 ||
+			test.wantType == STPubKeyHashEcdsaSecp256k1
+	}
+	benchExtractV0(b, filterFn, ExtractPubKeyV0)
+}
+
+// BenchmarkExtractPubKeyHashV0 benchmarks the performance of attempting to
+// extract public key hashes from various version 0 public key scripts.
+func BenchmarkExtractPubKeyHashV0(b *testing.B) {
+	filterFn := func(test scriptTest) bool {
+		return test.wantType == STPubKeyHashEcdsaSecp256k1 ||
+			test.wantType == STScriptHash
+	}
+	benchExtractV0(b, filterFn, ExtractPubKeyHashV0)
+}
+
+// BenchmarkExtractScriptHashV0 benchmarks the performance of attempting to
+// extract script hashes from various version 0 public key scripts.
+func BenchmarkExtractScriptHashV0(b *testing.B) {
+	filterFn := func(test scriptTest) bool {
+		return test.wantType == STScriptHash ||
+			test.wantType == STPubKeyHashEcdsaSecp256k1
+	}
+	benchExtractV0(b, filterFn, ExtractScriptHashV0)
+}
+
+// BenchmarkExtractMultiSigScriptDetailsV0 benchmarks the performance of
+// attempting to extract the details of various version 0 multisig scripts both
+// with and without the public keys.
+func BenchmarkExtractMultiSigScriptDetailsV0(b *testing.B) {
+	counts := make(map[ScriptType]int)
+	benches := makeBenchmarks(func(test scriptTest) bool {
+		// Limit to one of each script type.
+		counts[test.wantType]++
+		return counts[test.wantType] == 1 && test.wantType == STMultiSig
+	})
+
+	for _, bench := range benches {
+		for _, extractPubKeys := range []bool{false, true} {
+			name := bench.name
+			if extractPubKeys {
+				name += " with pubkeys"
+			}
+			b.Run(name, func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					ExtractMultiSigScriptDetailsV0(bench.script, extractPubKeys)
+				}
+			})
+		}
+	}
+}
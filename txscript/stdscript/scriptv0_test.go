@@ -1643,3 +1643,139 @@ func TestExtractAtomicSwapDataPushesV0(t *testing.T) {
 		}
 	}
 }
+
+// TestHashPuzzleScriptsV0 ensures version 0 hash puzzle scripts are created as
+// expected and recognized properly along with the correct information being
+// extracted from them.
+func TestHashPuzzleScriptsV0(t *testing.T) {
+	t.Parallel()
+
+	// Define some values shared in the tests for convenience.
+	sha256Hash := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	hash160 := "0000000000000000000000000000000000000001"
+
+	tests := []struct {
+		name   string            // test description
+		script string            // script to analyze
+		data   *HashPuzzleDataV0 // expected extracted data
+	}{{
+		name: "sha256 puzzle with 32-byte preimage",
+		script: fmt.Sprintf("SIZE 32 EQUALVERIFY SHA256 DATA_32 0x%s EQUAL",
+			sha256Hash),
+		data: &HashPuzzleDataV0{
+			Type:         HashPuzzleSha256V0,
+			Hash:         hexToBytes(sha256Hash),
+			PreimageSize: 32,
+		},
+	}, {
+		name: "hash160 puzzle with smallint preimage size",
+		script: fmt.Sprintf("SIZE 16 EQUALVERIFY HASH160 DATA_20 0x%s EQUAL",
+			hash160),
+		data: &HashPuzzleDataV0{
+			Type:         HashPuzzleHash160V0,
+			Hash:         hexToBytes(hash160),
+			PreimageSize: 16,
+		},
+	}, {
+		name: "sha256 puzzle with max preimage size",
+		script: fmt.Sprintf("SIZE 2048 EQUALVERIFY SHA256 DATA_32 0x%s EQUAL",
+			sha256Hash),
+		data: &HashPuzzleDataV0{
+			Type:         HashPuzzleSha256V0,
+			Hash:         hexToBytes(sha256Hash),
+			PreimageSize: 2048,
+		},
+	}, {
+		name: "preimage size larger than max allowed",
+		script: fmt.Sprintf("SIZE 2049 EQUALVERIFY SHA256 DATA_32 0x%s EQUAL",
+			sha256Hash),
+	}, {
+		name: "zero preimage size",
+		script: fmt.Sprintf("SIZE 0 EQUALVERIFY SHA256 DATA_32 0x%s EQUAL",
+			sha256Hash),
+	}, {
+		name: "non-canonical preimage size",
+		script: fmt.Sprintf("SIZE DATA_1 0x10 EQUALVERIFY SHA256 DATA_32 0x%s "+
+			"EQUAL", sha256Hash),
+	}, {
+		name: "sha256 with hash160-sized hash",
+		script: fmt.Sprintf("SIZE 32 EQUALVERIFY SHA256 DATA_20 0x%s EQUAL",
+			hash160),
+	}, {
+		name: "hash160 with sha256-sized hash",
+		script: fmt.Sprintf("SIZE 32 EQUALVERIFY HASH160 DATA_32 0x%s EQUAL",
+			sha256Hash),
+	}, {
+		name: "unsupported hash opcode",
+		script: fmt.Sprintf("SIZE 32 EQUALVERIFY BLAKE256 DATA_32 0x%s EQUAL",
+			sha256Hash),
+	}, {
+		name:   "missing size check",
+		script: fmt.Sprintf("SHA256 DATA_32 0x%s EQUAL", sha256Hash),
+	}, {
+		name: "trailing opcode",
+		script: fmt.Sprintf("SIZE 32 EQUALVERIFY SHA256 DATA_32 0x%s EQUAL "+
+			"NOP", sha256Hash),
+	}, {
+		name: "parse error",
+		script: fmt.Sprintf("SIZE 32 EQUALVERIFY SHA256 DATA_33 0x%s EQUAL",
+			sha256Hash),
+	}}
+
+	const scriptVersion = 0
+	for _, test := range tests {
+		script := mustParseShortForm(scriptVersion, test.script)
+
+		// Ensure the script is either detected as a hash puzzle with the
+		// expected details or not as expected.
+		data := ExtractHashPuzzleDataV0(script)
+		if !reflect.DeepEqual(data, test.data) {
+			t.Errorf("%q: unexpected extracted data -- got %+v, want %+v",
+				test.name, data, test.data)
+			continue
+		}
+		if got := IsHashPuzzleScriptV0(script); got != (test.data != nil) {
+			t.Errorf("%q: unexpected IsHashPuzzleScriptV0 result -- got %v",
+				test.name, got)
+			continue
+		}
+		if test.data == nil {
+			continue
+		}
+
+		// Ensure building a script from the extracted details produces the
+		// same script.
+		var built []byte
+		var err error
+		switch test.data.Type {
+		case HashPuzzleSha256V0:
+			var hash [32]byte
+			copy(hash[:], test.data.Hash)
+			built, err = PayToSha256PuzzleScriptV0(hash, test.data.PreimageSize)
+		case HashPuzzleHash160V0:
+			var hash [20]byte
+			copy(hash[:], test.data.Hash)
+			built, err = PayToHash160PuzzleScriptV0(hash,
+				test.data.PreimageSize)
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error building script: %v", test.name,
+				err)
+			continue
+		}
+		if !bytes.Equal(built, script) {
+			t.Errorf("%q: unexpected built script -- got %x, want %x",
+				test.name, built, script)
+			continue
+		}
+	}
+
+	// Ensure building scripts with invalid preimage sizes is rejected.
+	for _, size := range []int64{-1, 0, 2049} {
+		_, err := PayToSha256PuzzleScriptV0([32]byte{}, size)
+		if !errors.Is(err, ErrInvalidPreimageSize) {
+			t.Errorf("unexpected error for preimage size %d -- got %v, want "+
+				"%v", size, err, ErrInvalidPreimageSize)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package stdscript
+
+import (
+	"fmt"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+// PrevOutFetcher provides access to the public key scripts of the previous
+// outputs referenced by transaction inputs.
+type PrevOutFetcher interface {
+	// FetchPrevOutScript returns the script version and public key script of
+	// the output referenced by the provided outpoint along with whether or not
+	// the output exists.
+	FetchPrevOutScript(outpoint *wire.OutPoint) (uint16, []byte, bool)
+}
+
+// PrevOutFetcherFunc is an adapter that allows ordinary functions to be used
+// as a PrevOutFetcher.
+type PrevOutFetcherFunc func(outpoint *wire.OutPoint) (uint16, []byte, bool)
+
+// FetchPrevOutScript calls f(outpoint).
+func (f PrevOutFetcherFunc) FetchPrevOutScript(outpoint *wire.OutPoint) (uint16, []byte, bool) {
+	return f(outpoint)
+}
+
+// InputScriptInfo houses the results of analyzing the scripts involved in
+// redeeming a transaction input.
+type InputScriptInfo struct {
+	// HasPrevOut indicates whether or not the input references a previous
+	// output.  It is false for inputs that create new coins such as the
+	// inputs of coinbase, treasurybase, and treasury spend transactions and
+	// the stakebase input of votes, in which case the remaining fields are
+	// their zero values.
+	HasPrevOut bool
+
+	// PkScriptVersion and PkScriptType are the script version and type of the
+	// public key script of the referenced previous output.
+	PkScriptVersion uint16
+	PkScriptType    ScriptType
+
+	// RequiredSigs is the number of signatures required to redeem the
+	// referenced previous output.  It is determined from the redeem script
+	// for the pay-to-script-hash types and it is zero for non-standard
+	// scripts.
+	RequiredSigs uint16
+
+	// RedeemScript is the redeem script provided by the signature script when
+	// the referenced previous output is one of the pay-to-script-hash types,
+	// including the stake-tagged variants.  It is nil otherwise or when the
+	// signature script does not provide one.
+	RedeemScript []byte
+
+	// RedeemScriptType is the type of the redeem script when there is one and
+	// STNonStandard otherwise.
+	RedeemScriptType ScriptType
+
+	// SigOps is the number of signature operations the input contributes to
+	// the transaction as counted by the consensus rules.  It consists of the
+	// quick count of the signature script along with the precise count of the
+	// redeem script for regular pay-to-script-hash outputs.
+	SigOps int
+}
+
+// TxScriptInfo houses the results of analyzing the scripts of an entire
+// transaction.
+type TxScriptInfo struct {
+	// Inputs houses the results for each transaction input in order.
+	Inputs []InputScriptInfo
+
+	// OutputSigOps is the number of signature operations in the public key
+	// scripts of the transaction outputs.
+	OutputSigOps int
+
+	// TotalSigOps is the total number of signature operations of the
+	// transaction, which is the sum of the signature operations of all of
+	// the inputs and the outputs.
+	TotalSigOps int
+}
+
+// isNullOutPoint returns whether or not the provided outpoint is the null
+// outpoint referenced by inputs that do not spend a previous output.
+func isNullOutPoint(outpoint *wire.OutPoint) bool {
+	return outpoint.Index == wire.MaxPrevOutIndex &&
+		outpoint.Hash == chainhash.Hash{}
+}
+
+// isAnyKindOfScriptHashType returns whether or not the provided script type is
+// either a regular pay-to-script-hash script or one of the stake-tagged
+// variants.
+func isAnyKindOfScriptHashType(scriptType ScriptType) bool {
+	switch scriptType {
+	case STScriptHash, STStakeSubmissionScriptHash, STStakeGenScriptHash,
+		STStakeRevocationScriptHash, STStakeChangeScriptHash,
+		STTreasuryGenScriptHash:
+
+		return true
+	}
+	return false
+}
+
+// extractRedeemScript returns the redeem script provided by the passed
+// signature script, which is the data pushed by its final opcode.  It returns
+// nil when the signature script does not only push data or fails to parse.
+func extractRedeemScript(scriptVersion uint16, sigScript []byte) []byte {
+	if len(sigScript) == 0 || !txscript.IsPushOnlyScript(sigScript) {
+		return nil
+	}
+
+	var data []byte
+	tokenizer := txscript.MakeScriptTokenizer(scriptVersion, sigScript)
+	for tokenizer.Next() {
+		data = tokenizer.Data()
+	}
+	if tokenizer.Err() != nil {
+		return nil
+	}
+	return data
+}
+
+// AnalyzeTransactionScripts analyzes the scripts of the provided transaction
+// in a single pass and returns, for each input, the type of the public key
+// script of the referenced previous output, the number of signatures required
+// to redeem it, the redeem script and its type for the pay-to-script-hash
+// types, and the number of signature operations, along with the number of
+// signature operations of the outputs and the entire transaction.
+//
+// The provided fetcher is used to look up the public key scripts of the
+// previous outputs referenced by the inputs.  An error with the kind
+// ErrMissingPrevOut is returned when any of them are not available.
+//
+// The signature operations are counted in the same manner as the consensus
+// rules which means the results are suitable for enforcing the signature
+// operation limits of blocks and transactions.  Note that inputs that do not
+// reference a previous output, such as the inputs of coinbase transactions,
+// do not contribute any signature operations.
+func AnalyzeTransactionScripts(tx *wire.MsgTx, prevOuts PrevOutFetcher, isTreasuryEnabled bool) (*TxScriptInfo, error) {
+	info := &TxScriptInfo{
+		Inputs: make([]InputScriptInfo, len(tx.TxIn)),
+	}
+	for i, txIn := range tx.TxIn {
+		prevOut := &txIn.PreviousOutPoint
+		if isNullOutPoint(prevOut) {
+			continue
+		}
+
+		scriptVersion, pkScript, ok := prevOuts.FetchPrevOutScript(prevOut)
+		if !ok {
+			str := fmt.Sprintf("output %v referenced by input %d is not "+
+				"available", prevOut, i)
+			return nil, makeError(ErrMissingPrevOut, str)
+		}
+
+		// Determine the type of the referenced public key script and the
+		// number of signatures it requires.  The redeem script determines
+		// the number of required signatures for the pay-to-script-hash
+		// types.
+		input := &info.Inputs[i]
+		input.HasPrevOut = true
+		input.PkScriptVersion = scriptVersion
+		input.PkScriptType = DetermineScriptType(scriptVersion, pkScript)
+		input.RequiredSigs = DetermineRequiredSigs(scriptVersion, pkScript)
+		sigScript := txIn.SignatureScript
+		if isAnyKindOfScriptHashType(input.PkScriptType) {
+			input.RequiredSigs = 0
+			input.RedeemScript = extractRedeemScript(scriptVersion, sigScript)
+			if input.RedeemScript != nil {
+				input.RedeemScriptType = DetermineScriptType(scriptVersion,
+					input.RedeemScript)
+				input.RequiredSigs = DetermineRequiredSigs(scriptVersion,
+					input.RedeemScript)
+			}
+		}
+
+		// Count the signature operations in the same manner as consensus
+		// which always counts the signature script and additionally
+		// precisely counts the redeem script of regular pay-to-script-hash
+		// outputs.  Note that consensus does not consider the script version
+		// when doing so.
+		input.SigOps = txscript.GetSigOpCount(sigScript, isTreasuryEnabled)
+		if txscript.IsPayToScriptHash(pkScript) {
+			input.SigOps += txscript.GetPreciseSigOpCount(sigScript,
+				pkScript, isTreasuryEnabled)
+		}
+		info.TotalSigOps += input.SigOps
+	}
+
+	for _, txOut := range tx.TxOut {
+		info.OutputSigOps += txscript.GetSigOpCount(txOut.PkScript,
+			isTreasuryEnabled)
+	}
+	info.TotalSigOps += info.OutputSigOps
+
+	return info, nil
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package stdscript
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/wire"
+)
+
+// TestAnalyzeTransactionScripts ensures AnalyzeTransactionScripts produces the
+// expected per-input and transaction-wide results.
+func TestAnalyzeTransactionScripts(t *testing.T) {
+	t.Parallel()
+
+	// Convenience function that combines fmt.Sprintf with mustParseShortForm
+	// to create more compact tests.
+	p := func(format string, a ...interface{}) []byte {
+		const scriptVersion = 0
+		return mustParseShortForm(scriptVersion, fmt.Sprintf(format, a...))
+	}
+
+	// Define the scripts used throughout the tests.
+	const (
+		h160 = "433ec2ac1ffa1b7b7d027f564529c57197f9ae88"
+		pk1  = "02f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9"
+		pk2  = "03e38d2d8f5f4ed4c6f1d4d7f3b4f9f0e1c1d3b2a6f5e4d3c2b1a0f9e8d7c6b5a4"
+		pk3  = "022f8bde4d1a07209355b4a7250a5c5128e88b84bddc619ab7cba8d569b240efe4"
+		sig  = "304402201c0c1b8a0c0f2fd2b8e5b5b2aa2d9d3a1c3b8a6e0d4e1b7c2f9a1d3e4b" +
+			"5c6d7e022037a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d" +
+			"6e7f8001"
+	)
+	p2pkh := p("DUP HASH160 DATA_20 0x%s EQUALVERIFY CHECKSIG", h160)
+	p2sh := p("HASH160 DATA_20 0x%s EQUAL", h160)
+	stakeP2SH := p("SSTX HASH160 DATA_20 0x%s EQUAL", h160)
+	redeemScript := p("2 DATA_33 0x%s DATA_33 0x%s DATA_33 0x%s 3 CHECKMULTISIG",
+		pk1, pk2, pk3)
+	p2shSigScript := p("DATA_71 0x%s DATA_71 0x%s PUSHDATA1 0x%02x 0x%x", sig,
+		sig, len(redeemScript), redeemScript)
+	nonStandard := p("DROP TRUE")
+
+	// Create a transaction that spends a pay-to-pubkey-hash output, a
+	// pay-to-script-hash output with a multisig redeem script, a stake-tagged
+	// pay-to-script-hash output with the same redeem script, and a
+	// non-standard output with a signature script that contains a signature
+	// operation, along with outputs that contain signature operations.
+	prevOuts := map[wire.OutPoint][]byte{
+		{Hash: chainhash.Hash{0x01}, Index: 0}:                         p2pkh,
+		{Hash: chainhash.Hash{0x01}, Index: 1}:                         p2sh,
+		{Hash: chainhash.Hash{0x02}, Index: 0, Tree: wire.TxTreeStake}: stakeP2SH,
+		{Hash: chainhash.Hash{0x03}, Index: 5}:                         nonStandard,
+	}
+	tx := wire.NewMsgTx()
+	sigScripts := [][]byte{
+		p("DATA_71 0x%s DATA_33 0x%s", sig, pk1),
+		p2shSigScript,
+		p2shSigScript,
+		p("CHECKSIG"),
+	}
+	for i, outPoint := range []wire.OutPoint{
+		{Hash: chainhash.Hash{0x01}, Index: 0},
+		{Hash: chainhash.Hash{0x01}, Index: 1},
+		{Hash: chainhash.Hash{0x02}, Index: 0, Tree: wire.TxTreeStake},
+		{Hash: chainhash.Hash{0x03}, Index: 5},
+	} {
+		outPoint := outPoint
+		tx.AddTxIn(wire.NewTxIn(&outPoint, 0, sigScripts[i]))
+	}
+	tx.AddTxOut(wire.NewTxOut(1, p2pkh))
+	tx.AddTxOut(wire.NewTxOut(1, redeemScript))
+	fetcher := PrevOutFetcherFunc(func(outpoint *wire.OutPoint) (uint16, []byte, bool) {
+		pkScript, ok := prevOuts[*outpoint]
+		return 0, pkScript, ok
+	})
+
+	info, err := AnalyzeTransactionScripts(tx, fetcher, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantInputs := []InputScriptInfo{{
+		HasPrevOut:   true,
+		PkScriptType: STPubKeyHashEcdsaSecp256k1,
+		RequiredSigs: 1,
+	}, {
+		HasPrevOut:       true,
+		PkScriptType:     STScriptHash,
+		RequiredSigs:     2,
+		RedeemScript:     redeemScript,
+		RedeemScriptType: STMultiSig,
+		SigOps:           3,
+	}, {
+		HasPrevOut:       true,
+		PkScriptType:     STStakeSubmissionScriptHash,
+		RequiredSigs:     2,
+		RedeemScript:     redeemScript,
+		RedeemScriptType: STMultiSig,
+	}, {
+		HasPrevOut:   true,
+		PkScriptType: STNonStandard,
+		SigOps:       1,
+	}}
+	if len(info.Inputs) != len(wantInputs) {
+		t.Fatalf("unexpected number of inputs -- got %d, want %d",
+			len(info.Inputs), len(wantInputs))
+	}
+	for i, want := range wantInputs {
+		got := info.Inputs[i]
+		if got.HasPrevOut != want.HasPrevOut ||
+			got.PkScriptVersion != want.PkScriptVersion ||
+			got.PkScriptType != want.PkScriptType ||
+			got.RequiredSigs != want.RequiredSigs ||
+			!bytes.Equal(got.RedeemScript, want.RedeemScript) ||
+			got.RedeemScriptType != want.RedeemScriptType ||
+			got.SigOps != want.SigOps {
+
+			t.Fatalf("input %d: unexpected result -- got %+v, want %+v", i,
+				got, want)
+		}
+	}
+
+	// The outputs contain a signature operation for the pay-to-pubkey-hash
+	// script and 20 for the multisig script since the quick count does not
+	// consider the number of public keys.
+	const wantOutputSigOps = 1 + 20
+	if info.OutputSigOps != wantOutputSigOps {
+		t.Fatalf("unexpected output sigops -- got %d, want %d",
+			info.OutputSigOps, wantOutputSigOps)
+	}
+	if wantTotal := wantOutputSigOps + 3 + 1; info.TotalSigOps != wantTotal {
+		t.Fatalf("unexpected total sigops -- got %d, want %d",
+			info.TotalSigOps, wantTotal)
+	}
+
+	// Ensure inputs that do not reference a previous output are not analyzed
+	// and do not contribute any signature operations.
+	coinbase := wire.NewMsgTx()
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{},
+		wire.MaxPrevOutIndex, wire.TxTreeRegular), 0, p("CHECKSIG")))
+	coinbase.AddTxOut(wire.NewTxOut(1, p2pkh))
+	info, err = AnalyzeTransactionScripts(coinbase, fetcher, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Inputs[0].HasPrevOut || info.Inputs[0].SigOps != 0 ||
+		info.TotalSigOps != 1 {
+
+		t.Fatalf("unexpected coinbase result %+v", info)
+	}
+
+	// Ensure a missing previous output results in the expected error.
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{0x04}, 0,
+		wire.TxTreeRegular), 0, nil))
+	_, err = AnalyzeTransactionScripts(tx, fetcher, true)
+	if !errors.Is(err, ErrMissingPrevOut) {
+		t.Fatalf("unexpected error -- got %v, want %v", err,
+			ErrMissingPrevOut)
+	}
+}
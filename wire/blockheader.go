@@ -96,7 +96,9 @@ func (h *BlockHeader) BlockHash() chainhash.Hash {
 	buf := bytes.NewBuffer(make([]byte, 0, MaxBlockHeaderPayload))
 	_ = writeBlockHeader(buf, 0, h)
 
-	return chainhash.HashH(buf.Bytes())
+	// The hash function is selected by the header version so that future
+	// header versions are able to use a different algorithm.
+	return chainhash.HeaderHash(h.Version, buf.Bytes())
 }
 
 // BtcDecode decodes r using the bitcoin protocol encoding into the receiver.
@@ -0,0 +1,197 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package corpus
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/decred/dcrd/wire"
+)
+
+// Vector describes a single test vector in the corpus which consists of the
+// full wire encoding, including the message header, of a message for a given
+// protocol version and network.
+type Vector struct {
+	// Name uniquely identifies the test vector within the corpus.
+	Name string
+
+	// Command is the protocol command string of the encoded message.
+	Command string
+
+	// ProtocolVersion is the protocol version the message is encoded with.
+	ProtocolVersion uint32
+
+	// Net is the network the message is encoded for.
+	Net wire.CurrencyNet
+
+	// Encoding is the full wire encoding of the message including the
+	// message header.
+	Encoding []byte
+}
+
+// jsonVector is the JSON representation of a test vector.
+type jsonVector struct {
+	Name            string `json:"name"`
+	Command         string `json:"command"`
+	ProtocolVersion uint32 `json:"pver"`
+	Net             uint32 `json:"net"`
+	Encoding        string `json:"encoding"`
+}
+
+// vectorName returns the name of the test vector for the given command and
+// protocol version.
+func vectorName(command string, pver uint32) string {
+	return fmt.Sprintf("%s-pver%d", command, pver)
+}
+
+// Generate returns test vectors for a deterministic sample of every message
+// type defined by the wire package encoded for the provided network at every
+// protocol version from the initial protocol version through the current one.
+// Protocol versions a message is not valid for are skipped.
+func Generate(net wire.CurrencyNet) ([]Vector, error) {
+	msgs, err := sampleMessages()
+	if err != nil {
+		return nil, err
+	}
+
+	var vectors []Vector
+	var buf bytes.Buffer
+	for _, msg := range msgs {
+		for pver := wire.InitialProcotolVersion; pver <= wire.ProtocolVersion; pver++ {
+			buf.Reset()
+			_, err := wire.WriteMessageN(&buf, msg, pver, net)
+			if errors.Is(err, wire.ErrMsgInvalidForPVer) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("unable to encode %s message for "+
+					"protocol version %d: %w", msg.Command(), pver, err)
+			}
+
+			encoding := make([]byte, buf.Len())
+			copy(encoding, buf.Bytes())
+			vectors = append(vectors, Vector{
+				Name:            vectorName(msg.Command(), pver),
+				Command:         msg.Command(),
+				ProtocolVersion: pver,
+				Net:             net,
+				Encoding:        encoding,
+			})
+		}
+	}
+	return vectors, nil
+}
+
+// Write serializes the provided test vectors to the writer as JSON.
+func Write(w io.Writer, vectors []Vector) error {
+	jsonVectors := make([]jsonVector, 0, len(vectors))
+	for i := range vectors {
+		v := &vectors[i]
+		jsonVectors = append(jsonVectors, jsonVector{
+			Name:            v.Name,
+			Command:         v.Command,
+			ProtocolVersion: v.ProtocolVersion,
+			Net:             uint32(v.Net),
+			Encoding:        hex.EncodeToString(v.Encoding),
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jsonVectors)
+}
+
+// Read deserializes test vectors previously serialized with Write from the
+// reader.
+func Read(r io.Reader) ([]Vector, error) {
+	var jsonVectors []jsonVector
+	if err := json.NewDecoder(r).Decode(&jsonVectors); err != nil {
+		return nil, err
+	}
+
+	vectors := make([]Vector, 0, len(jsonVectors))
+	for i := range jsonVectors {
+		jv := &jsonVectors[i]
+		encoding, err := hex.DecodeString(jv.Encoding)
+		if err != nil {
+			return nil, fmt.Errorf("test vector %q: invalid encoding: %w",
+				jv.Name, err)
+		}
+		vectors = append(vectors, Vector{
+			Name:            jv.Name,
+			Command:         jv.Command,
+			ProtocolVersion: jv.ProtocolVersion,
+			Net:             wire.CurrencyNet(jv.Net),
+			Encoding:        encoding,
+		})
+	}
+	return vectors, nil
+}
+
+// Verify ensures each of the provided test vectors decodes to a message with
+// the expected command using the wire package and that the decoded message
+// encodes back to the exact same bytes.  An error that identifies the first
+// test vector that fails is returned.
+func Verify(vectors []Vector) error {
+	var buf bytes.Buffer
+	for i := range vectors {
+		v := &vectors[i]
+		n, msg, _, err := wire.ReadMessageN(bytes.NewReader(v.Encoding),
+			v.ProtocolVersion, v.Net)
+		if err != nil {
+			return fmt.Errorf("test vector %q: unable to decode: %w", v.Name,
+				err)
+		}
+		if n != len(v.Encoding) {
+			return fmt.Errorf("test vector %q: decoded %d bytes of %d",
+				v.Name, n, len(v.Encoding))
+		}
+		if msg.Command() != v.Command {
+			return fmt.Errorf("test vector %q: decoded %s message instead "+
+				"of %s", v.Name, msg.Command(), v.Command)
+		}
+
+		buf.Reset()
+		_, err = wire.WriteMessageN(&buf, msg, v.ProtocolVersion, v.Net)
+		if err != nil {
+			return fmt.Errorf("test vector %q: unable to encode: %w", v.Name,
+				err)
+		}
+		if !bytes.Equal(buf.Bytes(), v.Encoding) {
+			return fmt.Errorf("test vector %q: re-encoded message %x does "+
+				"not match %x", v.Name, buf.Bytes(), v.Encoding)
+		}
+	}
+	return nil
+}
+
+// WriteFuzzSeeds writes the raw encoding of each of the provided test vectors
+// to a separate file named after the test vector in the given directory, which
+// is created when it does not already exist.  An error is returned for test
+// vectors with names that are not valid file names.
+func WriteFuzzSeeds(dir string, vectors []Vector) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	for i := range vectors {
+		v := &vectors[i]
+		if v.Name == "" || v.Name != filepath.Base(v.Name) {
+			return fmt.Errorf("test vector %q: name is not a valid file "+
+				"name", v.Name)
+		}
+		path := filepath.Join(dir, v.Name)
+		if err := os.WriteFile(path, v.Encoding, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
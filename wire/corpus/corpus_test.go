@@ -0,0 +1,250 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package corpus
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/decred/dcrd/wire"
+)
+
+// hexToBytes converts the passed hex string into bytes and will panic if there
+// is an error.  This is only provided for the hard-coded constants so errors in
+// the source code can be detected.  It will only (and must only) be called with
+// hard-coded values.
+func hexToBytes(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic("invalid hex in source file: " + s)
+	}
+	return b
+}
+
+// TestGenerate ensures the generated corpus covers every message type defined
+// by the wire package, only contains uniquely named test vectors that pass
+// verification, and produces the expected encodings for some known messages.
+func TestGenerate(t *testing.T) {
+	vectors, err := Generate(wire.MainNet)
+	if err != nil {
+		t.Fatalf("unexpected error generating corpus: %v", err)
+	}
+	if err := Verify(vectors); err != nil {
+		t.Fatalf("unexpected error verifying corpus: %v", err)
+	}
+
+	// Ensure there is at least one test vector for every message type and
+	// that every test vector is uniquely named.
+	allCommands := []string{wire.CmdVersion, wire.CmdVerAck, wire.CmdGetAddr,
+		wire.CmdAddr, wire.CmdGetBlocks, wire.CmdInv, wire.CmdGetData,
+		wire.CmdNotFound, wire.CmdBlock, wire.CmdTx, wire.CmdGetHeaders,
+		wire.CmdHeaders, wire.CmdPing, wire.CmdPong, wire.CmdMemPool,
+		wire.CmdMiningState, wire.CmdGetMiningState, wire.CmdReject,
+		wire.CmdSendHeaders, wire.CmdFeeFilter, wire.CmdGetCFilter,
+		wire.CmdGetCFHeaders, wire.CmdGetCFTypes, wire.CmdCFilter,
+		wire.CmdCFHeaders, wire.CmdCFTypes, wire.CmdGetCFilterV2,
+		wire.CmdCFilterV2, wire.CmdGetInitState, wire.CmdInitState,
+		wire.CmdSendCmpr, wire.CmdCompressed}
+	byName := make(map[string]*Vector, len(vectors))
+	byCommand := make(map[string]int)
+	for i := range vectors {
+		v := &vectors[i]
+		if _, ok := byName[v.Name]; ok {
+			t.Fatalf("duplicate test vector name %q", v.Name)
+		}
+		byName[v.Name] = v
+		byCommand[v.Command]++
+	}
+	for _, cmd := range allCommands {
+		if byCommand[cmd] == 0 {
+			t.Errorf("no test vectors for %s message", cmd)
+		}
+	}
+	if len(byCommand) != len(allCommands) {
+		t.Errorf("unexpected number of message types -- got %d, want %d",
+			len(byCommand), len(allCommands))
+	}
+
+	// Ensure messages are only included for the protocol versions they are
+	// valid for.
+	tests := []struct {
+		name     string // test vector name
+		exists   bool   // whether or not the test vector should exist
+		encoding []byte // expected encoding when it exists
+	}{{
+		name:     "verack-pver9",
+		exists:   true,
+		encoding: hexToBytes("f900b4d976657261636b00000000000000000000716f6e86"),
+	}, {
+		name:   "ping-pver9",
+		exists: true,
+		encoding: hexToBytes("f900b4d970696e67000000000000000008000000744193" +
+			"911032547698badcfe"),
+	}, {
+		name:   "feefilter-pver5",
+		exists: true,
+		encoding: hexToBytes("f900b4d966656566696c74657200000008000000923099" +
+			"3b1027000000000000"),
+	}, {
+		name:   "feefilter-pver4",
+		exists: false,
+	}, {
+		name:   "reject-pver9",
+		exists: false,
+	}, {
+		name:   "cfilterv2-pver6",
+		exists: false,
+	}}
+	for _, test := range tests {
+		v, ok := byName[test.name]
+		if ok != test.exists {
+			t.Errorf("%s: unexpected existence -- got %v, want %v", test.name,
+				ok, test.exists)
+			continue
+		}
+		if ok && !bytes.Equal(v.Encoding, test.encoding) {
+			t.Errorf("%s: unexpected encoding -- got %x, want %x", test.name,
+				v.Encoding, test.encoding)
+		}
+	}
+}
+
+// TestWriteRead ensures a corpus serialized with Write deserializes with Read
+// to the same test vectors.
+func TestWriteRead(t *testing.T) {
+	vectors, err := Generate(wire.TestNet3)
+	if err != nil {
+		t.Fatalf("unexpected error generating corpus: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, vectors); err != nil {
+		t.Fatalf("unexpected error writing corpus: %v", err)
+	}
+	gotVectors, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error reading corpus: %v", err)
+	}
+	if !reflect.DeepEqual(gotVectors, vectors) {
+		t.Fatal("read corpus does not match written corpus")
+	}
+	if err := Verify(gotVectors); err != nil {
+		t.Fatalf("unexpected error verifying corpus: %v", err)
+	}
+
+	// Ensure invalid encodings are rejected.
+	badCorpus := `[{"name":"x","command":"verack","pver":9,"net":0,"encoding":"zz"}]`
+	if _, err := Read(bytes.NewBufferString(badCorpus)); err == nil {
+		t.Fatal("read corpus with invalid encoding without error")
+	}
+}
+
+// TestVerifyErrors ensures Verify detects test vectors that do not match the
+// wire package encodings.
+func TestVerifyErrors(t *testing.T) {
+	vectors, err := Generate(wire.MainNet)
+	if err != nil {
+		t.Fatalf("unexpected error generating corpus: %v", err)
+	}
+	var ping Vector
+	for _, v := range vectors {
+		if v.Name == vectorName(wire.CmdPing, wire.ProtocolVersion) {
+			ping = v
+			break
+		}
+	}
+
+	// withEncoding returns a copy of the ping test vector with the provided
+	// encoding.
+	withEncoding := func(encoding []byte) Vector {
+		v := ping
+		v.Encoding = encoding
+		return v
+	}
+	corruptPayload := append([]byte(nil), ping.Encoding...)
+	corruptPayload[len(corruptPayload)-1] ^= 0x01
+
+	tests := []struct {
+		name   string
+		vector Vector
+	}{{
+		name:   "truncated encoding",
+		vector: withEncoding(ping.Encoding[:len(ping.Encoding)-1]),
+	}, {
+		name:   "trailing data",
+		vector: withEncoding(append(append([]byte(nil), ping.Encoding...), 0x00)),
+	}, {
+		name:   "corrupt payload",
+		vector: withEncoding(corruptPayload),
+	}, {
+		name: "wrong command",
+		vector: func() Vector {
+			v := ping
+			v.Command = wire.CmdPong
+			return v
+		}(),
+	}, {
+		name: "wrong network",
+		vector: func() Vector {
+			v := ping
+			v.Net = wire.TestNet3
+			return v
+		}(),
+	}, {
+		name: "invalid protocol version",
+		vector: func() Vector {
+			v := vectors[0]
+			for _, vec := range vectors {
+				if vec.Command == wire.CmdFeeFilter {
+					v = vec
+					break
+				}
+			}
+			v.ProtocolVersion = wire.FeeFilterVersion - 1
+			return v
+		}(),
+	}}
+
+	for _, test := range tests {
+		if err := Verify([]Vector{test.vector}); err == nil {
+			t.Errorf("%s: verified without error", test.name)
+		}
+	}
+}
+
+// TestWriteFuzzSeeds ensures the raw encodings of the test vectors are written
+// to the expected files.
+func TestWriteFuzzSeeds(t *testing.T) {
+	vectors, err := Generate(wire.MainNet)
+	if err != nil {
+		t.Fatalf("unexpected error generating corpus: %v", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "seeds")
+	if err := WriteFuzzSeeds(dir, vectors); err != nil {
+		t.Fatalf("unexpected error writing fuzz seeds: %v", err)
+	}
+	for _, v := range vectors {
+		seed, err := os.ReadFile(filepath.Join(dir, v.Name))
+		if err != nil {
+			t.Fatalf("unexpected error reading fuzz seed: %v", err)
+		}
+		if !bytes.Equal(seed, v.Encoding) {
+			t.Fatalf("%s: unexpected fuzz seed -- got %x, want %x", v.Name,
+				seed, v.Encoding)
+		}
+	}
+
+	// Ensure names that are not valid file names are rejected.
+	badVector := vectors[0]
+	badVector.Name = filepath.Join("..", "escape")
+	if err := WriteFuzzSeeds(dir, []Vector{badVector}); err == nil {
+		t.Fatal("wrote fuzz seed with invalid name without error")
+	}
+}
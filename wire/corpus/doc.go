@@ -0,0 +1,38 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+/*
+Package corpus provides a canonical corpus of test vectors for the Decred wire
+protocol messages.
+
+The corpus consists of the full wire encodings, including the message header,
+of a deterministic sample of every message type defined by the wire package at
+every protocol version the message is valid for.  It is intended to aid
+compatibility testing between independent implementations of the wire protocol
+and to seed fuzzers with well-formed inputs.
+
+# Generating a Corpus
+
+Generate produces the test vectors for a given network and Write serializes them
+as JSON so they can be stored or shared:
+
+	vectors, err := corpus.Generate(wire.MainNet)
+	if err != nil {
+		// Handle error.
+	}
+	if err := corpus.Write(w, vectors); err != nil {
+		// Handle error.
+	}
+
+WriteFuzzSeeds additionally writes the raw encoding of each test vector to a
+separate file in a directory which is the format most fuzzers expect for their
+seed corpus.
+
+# Consuming a Corpus
+
+Read deserializes a corpus previously written by Write, potentially by another
+implementation, and Verify ensures each of its test vectors decodes with this
+implementation and encodes back to the exact same bytes.
+*/
+package corpus
@@ -0,0 +1,253 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package corpus
+
+import (
+	"net"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/wire"
+)
+
+// sampleTime is the fixed timestamp used throughout the sample messages so the
+// generated encodings are deterministic.
+var sampleTime = time.Unix(0x62a5f9c0, 0) // 2022-06-12 14:35:44 +0000 UTC
+
+// sampleHash returns a deterministic hash for use in the sample messages where
+// every byte is derived from the provided seed.
+func sampleHash(seed byte) chainhash.Hash {
+	var hash chainhash.Hash
+	for i := range hash {
+		hash[i] = seed + byte(i)
+	}
+	return hash
+}
+
+// sampleHashPtr returns a pointer to a deterministic hash derived from the
+// provided seed.  See sampleHash for more details.
+func sampleHashPtr(seed byte) *chainhash.Hash {
+	hash := sampleHash(seed)
+	return &hash
+}
+
+// sampleNetAddress returns a deterministic network address for use in the
+// sample messages.
+func sampleNetAddress(ip string, port uint16) *wire.NetAddress {
+	return wire.NewNetAddressTimestamp(sampleTime, wire.SFNodeNetwork,
+		net.ParseIP(ip), port)
+}
+
+// sampleBlockHeader returns a deterministic block header with all fields
+// populated for use in the sample messages.
+func sampleBlockHeader(height uint32) *wire.BlockHeader {
+	var finalState [6]byte
+	copy(finalState[:], []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06})
+	var extraData [32]byte
+	copy(extraData[:], []byte{0xde, 0xad, 0xbe, 0xef})
+	header := wire.NewBlockHeader(9, sampleHashPtr(0x10), sampleHashPtr(0x20),
+		sampleHashPtr(0x30), 0x0001, finalState, 5, 3, 1, 41250, 0x1b01ffff,
+		197000000, height, 12345, 0xa5a5a5a5, extraData, 9)
+	header.Timestamp = sampleTime
+	return header
+}
+
+// sampleTx returns a deterministic transaction with all fields populated for
+// use in the sample messages.
+func sampleTx() *wire.MsgTx {
+	tx := wire.NewMsgTx()
+	tx.Version = wire.TxVersion
+	prevOut := wire.NewOutPoint(sampleHashPtr(0x40), 1, wire.TxTreeRegular)
+	txIn := wire.NewTxIn(prevOut, 5000000000, []byte{0x51})
+	txIn.BlockHeight = 400000
+	txIn.BlockIndex = 2
+	tx.AddTxIn(txIn)
+	tx.AddTxOut(wire.NewTxOut(4999990000, []byte{
+		0x76, 0xa9, 0x14, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13,
+		0x14, 0x88, 0xac,
+	}))
+	tx.LockTime = 400100
+	tx.Expiry = 400200
+	return tx
+}
+
+// sampleStakeTx returns a deterministic transaction that spends from the stake
+// tree for use in the sample messages.
+func sampleStakeTx() *wire.MsgTx {
+	tx := wire.NewMsgTx()
+	tx.Version = wire.TxVersion
+	prevOut := wire.NewOutPoint(sampleHashPtr(0x50), 0, wire.TxTreeStake)
+	tx.AddTxIn(wire.NewTxIn(prevOut, 20000000000, []byte{0x00, 0x51}))
+	tx.AddTxOut(wire.NewTxOut(20000000000, []byte{0xba, 0x51}))
+	return tx
+}
+
+// sampleMessages returns a deterministic sample message for every message type
+// defined by the wire package.  The messages populate as many fields as
+// possible so their encodings exercise all of the relevant serialization code.
+func sampleMessages() ([]wire.Message, error) {
+	version := &wire.MsgVersion{
+		ProtocolVersion: int32(wire.ProtocolVersion),
+		Services:        wire.SFNodeNetwork | wire.SFNodeCF,
+		Timestamp:       sampleTime,
+		AddrYou:         *sampleNetAddress("192.168.0.1", 9108),
+		AddrMe:          *sampleNetAddress("10.0.0.1", 9108),
+		Nonce:           0x0123456789abcdef,
+		UserAgent:       "/dcrwire:0.5.0/corpus:1.0.0/",
+		LastBlock:       675000,
+		DisableRelayTx:  true,
+	}
+
+	addr := wire.NewMsgAddr()
+	err := addr.AddAddresses(sampleNetAddress("127.0.0.1", 9108),
+		sampleNetAddress("2001:db8::1", 19108))
+	if err != nil {
+		return nil, err
+	}
+
+	getBlocks := wire.NewMsgGetBlocks(sampleHashPtr(0x60))
+	if err := getBlocks.AddBlockLocatorHash(sampleHashPtr(0x61)); err != nil {
+		return nil, err
+	}
+	if err := getBlocks.AddBlockLocatorHash(sampleHashPtr(0x62)); err != nil {
+		return nil, err
+	}
+
+	block := wire.NewMsgBlock(sampleBlockHeader(675000))
+	if err := block.AddTransaction(sampleTx()); err != nil {
+		return nil, err
+	}
+	if err := block.AddSTransaction(sampleStakeTx()); err != nil {
+		return nil, err
+	}
+
+	inv := wire.NewMsgInv()
+	getData := wire.NewMsgGetData()
+	notFound := wire.NewMsgNotFound()
+	invVects := []*wire.InvVect{
+		wire.NewInvVect(wire.InvTypeTx, sampleHashPtr(0x70)),
+		wire.NewInvVect(wire.InvTypeBlock, sampleHashPtr(0x71)),
+		wire.NewInvVect(wire.InvTypeFilteredBlock, sampleHashPtr(0x72)),
+	}
+	for _, iv := range invVects {
+		if err := inv.AddInvVect(iv); err != nil {
+			return nil, err
+		}
+		if err := getData.AddInvVect(iv); err != nil {
+			return nil, err
+		}
+		if err := notFound.AddInvVect(iv); err != nil {
+			return nil, err
+		}
+	}
+
+	getHeaders := wire.NewMsgGetHeaders()
+	getHeaders.HashStop = sampleHash(0x80)
+	if err := getHeaders.AddBlockLocatorHash(sampleHashPtr(0x81)); err != nil {
+		return nil, err
+	}
+
+	headers := wire.NewMsgHeaders()
+	if err := headers.AddBlockHeader(sampleBlockHeader(675000)); err != nil {
+		return nil, err
+	}
+	if err := headers.AddBlockHeader(sampleBlockHeader(675001)); err != nil {
+		return nil, err
+	}
+
+	miningState := wire.NewMsgMiningState()
+	miningState.Version = 1
+	miningState.Height = 675000
+	if err := miningState.AddBlockHash(sampleHashPtr(0x90)); err != nil {
+		return nil, err
+	}
+	for i := byte(0); i < 5; i++ {
+		if err := miningState.AddVoteHash(sampleHashPtr(0x91 + i)); err != nil {
+			return nil, err
+		}
+	}
+
+	reject := wire.NewMsgReject(wire.CmdTx, wire.RejectDuplicate,
+		"transaction already exists")
+	reject.Hash = sampleHash(0xa0)
+
+	getCFHeaders := wire.NewMsgGetCFHeaders()
+	getCFHeaders.HashStop = sampleHash(0xb0)
+	getCFHeaders.FilterType = wire.GCSFilterExtended
+	if err := getCFHeaders.AddBlockLocatorHash(sampleHashPtr(0xb1)); err != nil {
+		return nil, err
+	}
+
+	cfHeaders := wire.NewMsgCFHeaders()
+	cfHeaders.StopHash = sampleHash(0xb2)
+	cfHeaders.FilterType = wire.GCSFilterRegular
+	if err := cfHeaders.AddCFHeader(sampleHashPtr(0xb3)); err != nil {
+		return nil, err
+	}
+	if err := cfHeaders.AddCFHeader(sampleHashPtr(0xb4)); err != nil {
+		return nil, err
+	}
+
+	getInitState := wire.NewMsgGetInitState()
+	err = getInitState.AddTypes(wire.InitStateHeadBlocks,
+		wire.InitStateHeadBlockVotes, wire.InitStateTSpends)
+	if err != nil {
+		return nil, err
+	}
+
+	initState, err := wire.NewMsgInitStateFilled(
+		[]chainhash.Hash{sampleHash(0xc0), sampleHash(0xc1)},
+		[]chainhash.Hash{sampleHash(0xc2), sampleHash(0xc3), sampleHash(0xc4)},
+		[]chainhash.Hash{sampleHash(0xc5)})
+	if err != nil {
+		return nil, err
+	}
+
+	filterData := []byte{0x00, 0x00, 0x00, 0x03, 0x9e, 0x5a, 0x1c, 0x40}
+	compressed, err := wire.NewMsgCompressed(wire.CompressionDeflate, block,
+		wire.ProtocolVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return []wire.Message{
+		version,
+		wire.NewMsgVerAck(),
+		wire.NewMsgGetAddr(),
+		addr,
+		getBlocks,
+		block,
+		inv,
+		getData,
+		notFound,
+		sampleTx(),
+		wire.NewMsgPing(0xfedcba9876543210),
+		wire.NewMsgPong(0xfedcba9876543210),
+		getHeaders,
+		headers,
+		wire.NewMsgMemPool(),
+		miningState,
+		wire.NewMsgGetMiningState(),
+		reject,
+		wire.NewMsgSendHeaders(),
+		wire.NewMsgFeeFilter(10000),
+		wire.NewMsgGetCFilter(sampleHashPtr(0xd0), wire.GCSFilterRegular),
+		getCFHeaders,
+		wire.NewMsgGetCFTypes(),
+		wire.NewMsgCFilter(sampleHashPtr(0xd1), wire.GCSFilterExtended,
+			filterData),
+		cfHeaders,
+		wire.NewMsgCFTypes([]wire.FilterType{wire.GCSFilterRegular,
+			wire.GCSFilterExtended}),
+		wire.NewMsgGetCFilterV2(sampleHashPtr(0xd2)),
+		wire.NewMsgCFilterV2(sampleHashPtr(0xd3), filterData, 2,
+			[]chainhash.Hash{sampleHash(0xd4), sampleHash(0xd5)}),
+		getInitState,
+		initState,
+		wire.NewMsgSendCmpr(wire.CompressionDeflate, 1024),
+		compressed,
+	}, nil
+}
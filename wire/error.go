@@ -133,6 +133,18 @@ const (
 	// ErrTooManyTSpends is returned when the number of tspend hashes
 	// exceeds the maximum allowed.
 	ErrTooManyTSpends
+
+	// ErrUnknownCompression is returned when a compression algorithm is
+	// unknown.
+	ErrUnknownCompression
+
+	// ErrNotCompressible is returned when attempting to compress a message
+	// that is not allowed to be compressed.
+	ErrNotCompressible
+
+	// ErrMalformedCompression is returned when compressed data does not
+	// decompress to the expected size.
+	ErrMalformedCompression
 )
 
 // Map of ErrorCode values back to their constant names for pretty printing.
@@ -168,6 +180,9 @@ var errorCodeStrings = map[ErrorCode]string{
 	ErrTooManyInitStateTypes:         "ErrTooManyInitStateTypes",
 	ErrInitStateTypeTooLong:          "ErrInitStateTypeTooLong",
 	ErrTooManyTSpends:                "ErrTooManyTSpends",
+	ErrUnknownCompression:            "ErrUnknownCompression",
+	ErrNotCompressible:               "ErrNotCompressible",
+	ErrMalformedCompression:          "ErrMalformedCompression",
 }
 
 // String returns the ErrorCode as a human-readable name.
@@ -50,6 +50,9 @@ func TestMessageErrorCodeStringer(t *testing.T) {
 		{ErrTooManyInitStateTypes, "ErrTooManyInitStateTypes"},
 		{ErrInitStateTypeTooLong, "ErrInitStateTypeTooLong"},
 		{ErrTooManyTSpends, "ErrTooManyTSpends"},
+		{ErrUnknownCompression, "ErrUnknownCompression"},
+		{ErrNotCompressible, "ErrNotCompressible"},
+		{ErrMalformedCompression, "ErrMalformedCompression"},
 
 		{0xffff, "Unknown ErrorCode (65535)"},
 	}
@@ -8,3 +8,5 @@ require (
 )
 
 require github.com/decred/dcrd/crypto/blake256 v1.0.0 // indirect
+
+replace github.com/decred/dcrd/chaincfg/chainhash => ../chaincfg/chainhash
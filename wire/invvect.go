@@ -1,5 +1,5 @@
 // Copyright (c) 2013-2016 The btcsuite developers
-// Copyright (c) 2015-2016 The Decred developers
+// Copyright (c) 2015-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -30,6 +30,10 @@ const (
 	InvTypeTx            InvType = 1
 	InvTypeBlock         InvType = 2
 	InvTypeFilteredBlock InvType = 3
+
+	// InvTypeTSpend is the inventory vector type for treasury spend
+	// transactions.  It is only valid starting with TSpendInvVersion.
+	InvTypeTSpend InvType = 4
 )
 
 // Map of service flags back to their constant names for pretty printing.
@@ -38,6 +42,7 @@ var ivStrings = map[InvType]string{
 	InvTypeTx:            "MSG_TX",
 	InvTypeBlock:         "MSG_BLOCK",
 	InvTypeFilteredBlock: "MSG_FILTERED_BLOCK",
+	InvTypeTSpend:        "MSG_TSPEND",
 }
 
 // String returns the InvType in human-readable form.
@@ -49,6 +54,18 @@ func (invtype InvType) String() string {
 	return fmt.Sprintf("Unknown InvType (%d)", uint32(invtype))
 }
 
+// IsValidForPVer returns whether or not the inventory vector type is valid for
+// the provided protocol version.  Inventory vector types that were added after
+// the initial protocol version must not be sent to or accepted from peers that
+// negotiated an older protocol version.
+func (invtype InvType) IsValidForPVer(pver uint32) bool {
+	switch invtype {
+	case InvTypeTSpend:
+		return pver >= TSpendInvVersion
+	}
+	return true
+}
+
 // InvVect defines a Decred inventory vector which is used to describe data,
 // as specified by the Type field, that a peer wants, has, or does not have to
 // another peer.
@@ -68,10 +85,25 @@ func NewInvVect(typ InvType, hash *chainhash.Hash) *InvVect {
 // readInvVect reads an encoded InvVect from r depending on the protocol
 // version.
 func readInvVect(r io.Reader, pver uint32, iv *InvVect) error {
-	return readElements(r, &iv.Type, &iv.Hash)
+	const op = "readInvVect"
+	if err := readElements(r, &iv.Type, &iv.Hash); err != nil {
+		return err
+	}
+	if !iv.Type.IsValidForPVer(pver) {
+		str := fmt.Sprintf("inventory vector type %v invalid for protocol "+
+			"version %d", iv.Type, pver)
+		return messageError(op, ErrMsgInvalidForPVer, str)
+	}
+	return nil
 }
 
 // writeInvVect serializes an InvVect to w depending on the protocol version.
 func writeInvVect(w io.Writer, pver uint32, iv *InvVect) error {
+	const op = "writeInvVect"
+	if !iv.Type.IsValidForPVer(pver) {
+		str := fmt.Sprintf("inventory vector type %v invalid for protocol "+
+			"version %d", iv.Type, pver)
+		return messageError(op, ErrMsgInvalidForPVer, str)
+	}
 	return writeElements(w, iv.Type, &iv.Hash)
 }
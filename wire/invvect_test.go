@@ -1,5 +1,5 @@
 // Copyright (c) 2013-2016 The btcsuite developers
-// Copyright (c) 2015-2016 The Decred developers
+// Copyright (c) 2015-2022 The Decred developers
 // Use of this source code is governed by an ISC
 // license that can be found in the LICENSE file.
 
@@ -7,6 +7,7 @@ package wire
 
 import (
 	"bytes"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -23,6 +24,8 @@ func TestInvTypeStringer(t *testing.T) {
 		{InvTypeError, "ERROR"},
 		{InvTypeTx, "MSG_TX"},
 		{InvTypeBlock, "MSG_BLOCK"},
+		{InvTypeFilteredBlock, "MSG_FILTERED_BLOCK"},
+		{InvTypeTSpend, "MSG_TSPEND"},
 		{0xffffffff, "Unknown InvType (4294967295)"},
 	}
 
@@ -109,6 +112,21 @@ func TestInvVectWire(t *testing.T) {
 		0x26, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Block 203707 hash
 	}
 
+	// tspendInvVect is an inventory vector representing a treasury spend.
+	tspendInvVect := InvVect{
+		Type: InvTypeTSpend,
+		Hash: *baseHash,
+	}
+
+	// tspendInvVectEncoded is the wire encoded bytes of tspendInvVect.
+	tspendInvVectEncoded := []byte{
+		0x04, 0x00, 0x00, 0x00, // InvTypeTSpend
+		0xdc, 0xe9, 0x69, 0x10, 0x94, 0xda, 0x23, 0xc7,
+		0xe7, 0x67, 0x13, 0xd0, 0x75, 0xd4, 0xa1, 0x0b,
+		0x79, 0x40, 0x08, 0xa6, 0x36, 0xac, 0xc2, 0x4b,
+		0x26, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Block 203707 hash
+	}
+
 	tests := []struct {
 		in   InvVect // NetAddress to encode
 		out  InvVect // Expected decoded NetAddress
@@ -138,6 +156,14 @@ func TestInvVectWire(t *testing.T) {
 			blockInvVectEncoded,
 			ProtocolVersion,
 		},
+
+		// Protocol version TSpendInvVersion tspend inventory vector.
+		{
+			tspendInvVect,
+			tspendInvVect,
+			tspendInvVectEncoded,
+			TSpendInvVersion,
+		},
 	}
 
 	t.Logf("Running %d tests", len(tests))
@@ -170,3 +196,45 @@ func TestInvVectWire(t *testing.T) {
 		}
 	}
 }
+
+// TestInvVectWirePVer ensures inventory vector types that were added after the
+// initial protocol version are rejected when encoding and decoding them for
+// protocol versions prior to the one that added them.
+func TestInvVectWirePVer(t *testing.T) {
+	iv := InvVect{Type: InvTypeTSpend, Hash: chainhash.Hash{0x01}}
+	pver := TSpendInvVersion - 1
+	if iv.Type.IsValidForPVer(pver) {
+		t.Fatalf("tspend inventory vector type is valid for protocol version "+
+			"%d", pver)
+	}
+
+	var buf bytes.Buffer
+	err := writeInvVect(&buf, pver, &iv)
+	if !errors.Is(err, ErrMsgInvalidForPVer) {
+		t.Fatalf("writeInvVect: unexpected error -- got %v, want %v", err,
+			ErrMsgInvalidForPVer)
+	}
+
+	buf.Reset()
+	if err := writeInvVect(&buf, TSpendInvVersion, &iv); err != nil {
+		t.Fatalf("writeInvVect: unexpected error: %v", err)
+	}
+	var decoded InvVect
+	err = readInvVect(bytes.NewReader(buf.Bytes()), pver, &decoded)
+	if !errors.Is(err, ErrMsgInvalidForPVer) {
+		t.Fatalf("readInvVect: unexpected error -- got %v, want %v", err,
+			ErrMsgInvalidForPVer)
+	}
+
+	// Ensure messages that contain the inventory vector are rejected as well.
+	msg := NewMsgInv()
+	if err := msg.AddInvVect(&iv); err != nil {
+		t.Fatalf("AddInvVect: unexpected error: %v", err)
+	}
+	buf.Reset()
+	err = msg.BtcEncode(&buf, pver)
+	if !errors.Is(err, ErrMsgInvalidForPVer) {
+		t.Fatalf("BtcEncode: unexpected error -- got %v, want %v", err,
+			ErrMsgInvalidForPVer)
+	}
+}
@@ -7,6 +7,7 @@ package wire
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 
@@ -58,6 +59,8 @@ const (
 	CmdCFilterV2      = "cfilterv2"
 	CmdGetInitState   = "getinitstate"
 	CmdInitState      = "initstate"
+	CmdSendCmpr       = "sendcmpr"
+	CmdCompressed     = "compressed"
 )
 
 // Message is an interface that describes a Decred message.  A type that
@@ -168,6 +171,12 @@ func makeEmptyMessage(command string) (Message, error) {
 	case CmdInitState:
 		msg = &MsgInitState{}
 
+	case CmdSendCmpr:
+		msg = &MsgSendCmpr{}
+
+	case CmdCompressed:
+		msg = &MsgCompressed{}
+
 	default:
 		str := fmt.Sprintf("unhandled command [%s]", command)
 		return nil, messageError(op, ErrUnknownCmd, str)
@@ -227,6 +236,56 @@ func discardInput(r io.Reader, n uint32) {
 	}
 }
 
+// readBlockPayload reads and decodes the block payload described by the
+// provided message header from r into the provided block and returns the
+// number of bytes read along with the raw payload bytes.
+//
+// The block is decoded incrementally as the payload is read with the limits
+// for the protocol version enforced via MsgBlock.BtcDecodeBounded, and the
+// payload buffer only grows as data is actually received.  Any remaining
+// input for the message is discarded when the block is malformed so the reader
+// is left positioned at the next message.
+func readBlockPayload(r io.Reader, hdr *messageHeader, pver uint32, msg *MsgBlock) (int, []byte, error) {
+	const op = "ReadMessage"
+
+	// Tee everything read from the payload into a buffer since the raw bytes
+	// are needed to verify the checksum and are returned to the caller.
+	var payload bytes.Buffer
+	lr := &io.LimitedReader{R: r, N: int64(hdr.length)}
+	tr := io.TeeReader(lr, &payload)
+
+	limits := DefaultBlockDecodeLimits(pver)
+	if hdr.length < limits.MaxBlockSize {
+		limits.MaxBlockSize = hdr.length
+	}
+	if err := msg.BtcDecodeBounded(tr, pver, limits); err != nil {
+		var mErr *MessageError
+		if errors.As(err, &mErr) {
+			discardInput(r, uint32(lr.N))
+		}
+		return payload.Len(), nil, err
+	}
+
+	// Read any remaining bytes in the payload that were not part of the
+	// block so the checksum covers the entire payload.
+	if _, err := io.Copy(&payload, lr); err != nil {
+		return payload.Len(), nil, err
+	}
+	if payload.Len() != int(hdr.length) {
+		return payload.Len(), nil, io.ErrUnexpectedEOF
+	}
+
+	// Test checksum.
+	checksum := chainhash.HashB(payload.Bytes())[0:4]
+	if !bytes.Equal(checksum, hdr.checksum[:]) {
+		msg := fmt.Sprintf("payload checksum failed - header indicates %v, "+
+			"but actual checksum is %v.", hdr.checksum, checksum)
+		return payload.Len(), nil, messageError(op, ErrPayloadChecksum, msg)
+	}
+
+	return payload.Len(), payload.Bytes(), nil
+}
+
 // WriteMessageN writes a Decred Message to w including the necessary header
 // information and returns the number of bytes written.    This function is the
 // same as WriteMessage except it also returns the number of bytes written.
@@ -356,6 +415,18 @@ func ReadMessageN(r io.Reader, pver uint32, dcrnet CurrencyNet) (int, Message, [
 		return totalBytes, nil, nil, messageError(op, ErrPayloadTooLarge, msg)
 	}
 
+	// Blocks are streamed and decoded while they are read so that invalid
+	// blocks are detected as early as possible without first needing to
+	// buffer the entire announced payload.
+	if blockMsg, ok := msg.(*MsgBlock); ok {
+		n, payload, err := readBlockPayload(r, hdr, pver, blockMsg)
+		totalBytes += n
+		if err != nil {
+			return totalBytes, nil, nil, err
+		}
+		return totalBytes, msg, payload, nil
+	}
+
 	// Read payload.
 	payload := make([]byte, hdr.length)
 	n, err = io.ReadFull(r, payload)
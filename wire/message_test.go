@@ -80,6 +80,7 @@ func TestMessage(t *testing.T) {
 	msgReject := NewMsgReject("block", RejectDuplicate, "duplicate block")
 	msgGetInitState := NewMsgGetInitState()
 	msgInitState := NewMsgInitState()
+	msgSendCmpr := NewMsgSendCmpr(CompressionDeflate, 1024)
 
 	tests := []struct {
 		in     Message     // Value to encode
@@ -112,6 +113,7 @@ func TestMessage(t *testing.T) {
 		{msgCFTypes, msgCFTypes, pver, MainNet, 26},
 		{msgGetInitState, msgGetInitState, pver, MainNet, 25},
 		{msgInitState, msgInitState, pver, MainNet, 27},
+		{msgSendCmpr, msgSendCmpr, pver, MainNet, 29},
 	}
 
 	t.Logf("Running %d tests", len(tests))
@@ -366,6 +368,54 @@ func TestReadMessageWireErrors(t *testing.T) {
 	}
 }
 
+// TestReadMessageBlockStreaming ensures block messages that are read while
+// being incrementally decoded are rejected as expected and that the remaining
+// payload of malformed blocks is discarded so the following message can still
+// be read.
+func TestReadMessageBlockStreaming(t *testing.T) {
+	pver := ProtocolVersion
+	dcrnet := MainNet
+
+	// Create a block message that claims to have more transactions than can
+	// possibly fit into the payload along with a valid ping message after it.
+	badBlock := make([]byte, len(testBlockBytes))
+	copy(badBlock, testBlockBytes)
+	badBlock[MaxBlockHeaderPayload] = 0xfc
+	checksum := chainhash.HashB(badBlock)[0:4]
+	var buf bytes.Buffer
+	buf.Write(makeHeader(dcrnet, CmdBlock, uint32(len(badBlock)),
+		binary.LittleEndian.Uint32(checksum)))
+	buf.Write(badBlock)
+	if err := WriteMessage(&buf, NewMsgPing(123123), pver, dcrnet); err != nil {
+		t.Fatalf("unexpected error writing ping: %v", err)
+	}
+
+	_, _, err := ReadMessage(&buf, pver, dcrnet)
+	if !errors.Is(err, ErrTooManyTxs) {
+		t.Fatalf("unexpected error reading malformed block -- got %v, "+
+			"want %v", err, ErrTooManyTxs)
+	}
+	msg, _, err := ReadMessage(&buf, pver, dcrnet)
+	if err != nil {
+		t.Fatalf("unexpected error reading message after malformed "+
+			"block: %v", err)
+	}
+	if _, ok := msg.(*MsgPing); !ok {
+		t.Fatalf("unexpected message after malformed block: %T", msg)
+	}
+
+	// Ensure a valid block with an invalid checksum is rejected.
+	buf.Reset()
+	buf.Write(makeHeader(dcrnet, CmdBlock, uint32(len(testBlockBytes)),
+		0xbeef))
+	buf.Write(testBlockBytes)
+	_, _, err = ReadMessage(&buf, pver, dcrnet)
+	if !errors.Is(err, ErrPayloadChecksum) {
+		t.Fatalf("unexpected error reading block with bad checksum -- got "+
+			"%v, want %v", err, ErrPayloadChecksum)
+	}
+}
+
 // TestWriteMessageWireErrors performs negative tests against wire encoding from
 // concrete messages to confirm error paths work correctly.
 func TestWriteMessageWireErrors(t *testing.T) {
@@ -142,6 +142,130 @@ func (msg *MsgBlock) BtcDecode(r io.Reader, pver uint32) error {
 	return nil
 }
 
+// BlockDecodeLimits defines the limits that are enforced incrementally while
+// decoding a block with BtcDecodeBounded.
+type BlockDecodeLimits struct {
+	// MaxBlockSize is the maximum number of bytes the entire serialized
+	// block may be.
+	MaxBlockSize uint32
+
+	// MaxTxPerTree is the maximum number of transactions each of the regular
+	// and stake transaction trees may have.
+	MaxTxPerTree uint64
+
+	// MaxTxSize is the maximum number of bytes any individual serialized
+	// transaction may be.
+	MaxTxSize uint32
+}
+
+// DefaultBlockDecodeLimits returns the block decoding limits that apply to
+// blocks received from the network for the provided protocol version.
+func DefaultBlockDecodeLimits(pver uint32) BlockDecodeLimits {
+	var msg MsgBlock
+	maxBlockSize := msg.MaxPayloadLength(pver)
+	return BlockDecodeLimits{
+		MaxBlockSize: maxBlockSize,
+		MaxTxPerTree: MaxTxPerTxTree(pver),
+		MaxTxSize:    maxBlockSize - MaxBlockHeaderPayload,
+	}
+}
+
+// boundedReader is an io.Reader that returns a message error as soon as a
+// read would consume more than the remaining number of allowed bytes as
+// opposed to silently truncating the data like io.LimitedReader.  This allows
+// decoding to be aborted as early as possible when data is too large without
+// first needing to read all of it.
+type boundedReader struct {
+	r         io.Reader
+	remaining int64
+	op        string
+	what      string
+	max       int64
+}
+
+// newBoundedReader returns a reader that reads from r and errors once more
+// than max bytes are read.  The op and what params are used to construct a
+// meaningful error.
+func newBoundedReader(r io.Reader, max int64, op, what string) *boundedReader {
+	return &boundedReader{r: r, remaining: max, op: op, what: what, max: max}
+}
+
+// Read reads from the underlying reader while ensuring the maximum number of
+// allowed bytes is not exceeded.  This is part of the io.Reader interface.
+func (br *boundedReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > br.remaining {
+		msg := fmt.Sprintf("%s exceeds the max allowed size of %d bytes",
+			br.what, br.max)
+		return 0, messageError(br.op, ErrPayloadTooLarge, msg)
+	}
+	n, err := br.r.Read(p)
+	br.remaining -= int64(n)
+	return n, err
+}
+
+// readTxTreeBounded reads a transaction count followed by that many
+// transactions from the provided bounded reader while enforcing the provided
+// limits.  The count is rejected up front when it exceeds the limit or when
+// there are not enough remaining bytes for that many transactions to exist so
+// no memory is allocated for transactions that can't possibly be valid.
+func readTxTreeBounded(br *boundedReader, pver uint32, limits *BlockDecodeLimits, treeDesc string) ([]*MsgTx, error) {
+	const op = "MsgBlock.BtcDecodeBounded"
+	txCount, err := ReadVarInt(br, pver)
+	if err != nil {
+		return nil, err
+	}
+	if txCount > limits.MaxTxPerTree {
+		msg := fmt.Sprintf("too many %s to fit into a block [count %d, "+
+			"max %d]", treeDesc, txCount, limits.MaxTxPerTree)
+		return nil, messageError(op, ErrTooManyTxs, msg)
+	}
+	maxRemainingTxns := uint64(br.remaining / minTxPayload)
+	if txCount > maxRemainingTxns {
+		msg := fmt.Sprintf("too many %s to fit into the remaining block "+
+			"size [count %d, max %d]", treeDesc, txCount, maxRemainingTxns)
+		return nil, messageError(op, ErrTooManyTxs, msg)
+	}
+
+	txns := make([]*MsgTx, 0, txCount)
+	for i := uint64(0); i < txCount; i++ {
+		var tx MsgTx
+		maxTxSize := int64(limits.MaxTxSize)
+		if br.remaining < maxTxSize {
+			maxTxSize = br.remaining
+		}
+		txr := newBoundedReader(br, maxTxSize, op, "transaction")
+		if err := tx.BtcDecode(txr, pver); err != nil {
+			return nil, err
+		}
+		txns = append(txns, &tx)
+	}
+	return txns, nil
+}
+
+// BtcDecodeBounded decodes r using the Decred protocol encoding into the
+// receiver while incrementally enforcing the provided limits.  It differs from
+// BtcDecode in that it streams the block from r and aborts with an error as
+// soon as any of the limits is violated, which means data that is too large
+// is detected without first needing to read or allocate memory for all of it.
+// This makes it well suited for decoding blocks from untrusted sources.
+func (msg *MsgBlock) BtcDecodeBounded(r io.Reader, pver uint32, limits BlockDecodeLimits) error {
+	br := newBoundedReader(r, int64(limits.MaxBlockSize),
+		"MsgBlock.BtcDecodeBounded", "block")
+	err := readBlockHeader(br, pver, &msg.Header)
+	if err != nil {
+		return err
+	}
+
+	msg.Transactions, err = readTxTreeBounded(br, pver, &limits,
+		"transactions")
+	if err != nil {
+		return err
+	}
+	msg.STransactions, err = readTxTreeBounded(br, pver, &limits,
+		"stransactions")
+	return err
+}
+
 // Deserialize decodes a block from r into the receiver using a format that is
 // suitable for long-term storage such as a database while respecting the
 // Version field in the block.  This function differs from BtcDecode in that
@@ -538,6 +538,77 @@ func TestBlockOverflowErrors(t *testing.T) {
 	}
 }
 
+// TestBlockDecodeBounded ensures decoding blocks with limits enforces the
+// limits and otherwise decodes the same as BtcDecode.
+func TestBlockDecodeBounded(t *testing.T) {
+	pver := ProtocolVersion
+	defaultLimits := DefaultBlockDecodeLimits(pver)
+	txLen := uint32(testBlockTxLocs[0].TxLen)
+
+	// withLimits returns the default limits modified by the provided func.
+	withLimits := func(modify func(*BlockDecodeLimits)) BlockDecodeLimits {
+		limits := defaultLimits
+		modify(&limits)
+		return limits
+	}
+
+	tests := []struct {
+		name   string            // test description
+		limits BlockDecodeLimits // limits to enforce
+		err    error             // expected error
+	}{{
+		name:   "default limits",
+		limits: defaultLimits,
+	}, {
+		name: "block exactly max size",
+		limits: withLimits(func(l *BlockDecodeLimits) {
+			l.MaxBlockSize = uint32(len(testBlockBytes))
+		}),
+	}, {
+		name: "block larger than max size",
+		limits: withLimits(func(l *BlockDecodeLimits) {
+			l.MaxBlockSize = uint32(len(testBlockBytes) - 1)
+		}),
+		err: ErrPayloadTooLarge,
+	}, {
+		name: "transaction larger than max size",
+		limits: withLimits(func(l *BlockDecodeLimits) {
+			l.MaxTxSize = txLen - 1
+		}),
+		err: ErrPayloadTooLarge,
+	}, {
+		name: "too many transactions per tree",
+		limits: withLimits(func(l *BlockDecodeLimits) {
+			l.MaxTxPerTree = 0
+		}),
+		err: ErrTooManyTxs,
+	}, {
+		name: "transactions can't fit into remaining size",
+		limits: withLimits(func(l *BlockDecodeLimits) {
+			l.MaxBlockSize = MaxBlockHeaderPayload + 1 + minTxPayload - 1
+		}),
+		err: ErrTooManyTxs,
+	}}
+
+	for _, test := range tests {
+		var msg MsgBlock
+		r := bytes.NewReader(testBlockBytes)
+		err := msg.BtcDecodeBounded(r, pver, test.limits)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%q: wrong error -- got: %v, want: %v", test.name, err,
+				test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(&msg, &testBlock) {
+			t.Errorf("%q: mismatched block -- got: %s want: %s", test.name,
+				spew.Sdump(&msg), spew.Sdump(&testBlock))
+		}
+	}
+}
+
 // TestBlockSerializeSize performs tests to ensure the serialize size for
 // various blocks is accurate.
 func TestBlockSerializeSize(t *testing.T) {
@@ -0,0 +1,251 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"compress/flate"
+	"fmt"
+	"io"
+)
+
+// isCompressibleCommand returns whether or not messages with the provided
+// command are allowed to be compressed.  Only messages that carry large
+// payloads that are commonly requested in bulk, namely blocks and committed
+// filters, are compressible.
+func isCompressibleCommand(command string) bool {
+	switch command {
+	case CmdBlock, CmdCFilter, CmdCFilterV2:
+		return true
+	}
+	return false
+}
+
+// MsgCompressed implements the Message interface and represents a Decred
+// compressed message.  It is used to deliver the payload of another message
+// compressed with an algorithm the receiving peer consented to via a sendcmpr
+// message.
+//
+// Only the payloads of block, cfilter, and cfilterv2 messages may be
+// compressed.  The payload is held uncompressed and is only compressed when
+// the message is encoded.  Decoding enforces that the payload decompresses to
+// exactly the declared size, which is limited by the maximum payload length of
+// the wrapped message, in order to prevent excessive memory usage.
+//
+// This message was not added until protocol versions starting with
+// CompressionVersion.
+type MsgCompressed struct {
+	// Algorithm is the algorithm the payload is compressed with.
+	Algorithm CompressionAlgorithm
+
+	// MsgCommand is the protocol command of the wrapped message.
+	MsgCommand string
+
+	// Payload is the uncompressed protocol encoding of the wrapped message.
+	Payload []byte
+}
+
+// BtcDecode decodes r using the Decred protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgCompressed) BtcDecode(r io.Reader, pver uint32) error {
+	const op = "MsgCompressed.BtcDecode"
+	if pver < CompressionVersion {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+
+	var algorithm uint8
+	if err := readElement(r, &algorithm); err != nil {
+		return err
+	}
+	msg.Algorithm = CompressionAlgorithm(algorithm)
+	if !msg.Algorithm.IsKnown() {
+		str := fmt.Sprintf("unknown compression algorithm %d", algorithm)
+		return messageError(op, ErrUnknownCompression, str)
+	}
+
+	command, err := ReadAsciiVarString(r, pver, CommandSize)
+	if err != nil {
+		return err
+	}
+	if !isCompressibleCommand(command) {
+		str := fmt.Sprintf("%s messages are not compressible", command)
+		return messageError(op, ErrNotCompressible, str)
+	}
+	msg.MsgCommand = command
+
+	// Limit the declared uncompressed size to the maximum payload length of
+	// the wrapped message.
+	var size uint32
+	if err := readElement(r, &size); err != nil {
+		return err
+	}
+	wrapped, err := makeEmptyMessage(command)
+	if err != nil {
+		return err
+	}
+	maxSize := wrapped.MaxPayloadLength(pver)
+	if maxSize > MaxMessagePayload {
+		maxSize = MaxMessagePayload
+	}
+	if size > maxSize {
+		str := fmt.Sprintf("uncompressed %s payload size %d exceeds the "+
+			"maximum allowed size of %d", command, size, maxSize)
+		return messageError(op, ErrPayloadTooLarge, str)
+	}
+
+	compressed, err := ReadVarBytes(r, pver, MaxMessagePayload,
+		"compressed payload")
+	if err != nil {
+		return err
+	}
+
+	// Decompress the payload while ensuring it is exactly the declared size.
+	decompressor := flate.NewReader(bytes.NewReader(compressed))
+	defer decompressor.Close()
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(decompressor, payload); err != nil {
+		str := fmt.Sprintf("unable to decompress %s payload: %v", command,
+			err)
+		return messageError(op, ErrMalformedCompression, str)
+	}
+	var extra [1]byte
+	if n, err := decompressor.Read(extra[:]); n != 0 || err != io.EOF {
+		str := fmt.Sprintf("compressed %s payload does not decompress to "+
+			"the declared size of %d bytes", command, size)
+		return messageError(op, ErrMalformedCompression, str)
+	}
+	msg.Payload = payload
+
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the Decred protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgCompressed) BtcEncode(w io.Writer, pver uint32) error {
+	const op = "MsgCompressed.BtcEncode"
+	if pver < CompressionVersion {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+	if !msg.Algorithm.IsKnown() {
+		str := fmt.Sprintf("unknown compression algorithm %d",
+			uint8(msg.Algorithm))
+		return messageError(op, ErrUnknownCompression, str)
+	}
+	if !isCompressibleCommand(msg.MsgCommand) {
+		str := fmt.Sprintf("%s messages are not compressible",
+			msg.MsgCommand)
+		return messageError(op, ErrNotCompressible, str)
+	}
+
+	// Compress the payload favoring speed over the compression ratio since
+	// the messages are compressed on demand.
+	var compressed bytes.Buffer
+	compressor, err := flate.NewWriter(&compressed, flate.BestSpeed)
+	if err != nil {
+		return err
+	}
+	if _, err := compressor.Write(msg.Payload); err != nil {
+		return err
+	}
+	if err := compressor.Close(); err != nil {
+		return err
+	}
+
+	err = writeElement(w, uint8(msg.Algorithm))
+	if err != nil {
+		return err
+	}
+	err = WriteVarString(w, pver, msg.MsgCommand)
+	if err != nil {
+		return err
+	}
+	err = writeElement(w, uint32(len(msg.Payload)))
+	if err != nil {
+		return err
+	}
+	return WriteVarBytes(w, pver, compressed.Bytes())
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgCompressed) Command() string {
+	return CmdCompressed
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgCompressed) MaxPayloadLength(pver uint32) uint32 {
+	if pver < CompressionVersion {
+		return 0
+	}
+
+	return MaxMessagePayload
+}
+
+// Message decodes and returns the wrapped message from the uncompressed
+// payload.
+func (msg *MsgCompressed) Message(pver uint32) (Message, error) {
+	const op = "MsgCompressed.Message"
+	if !isCompressibleCommand(msg.MsgCommand) {
+		str := fmt.Sprintf("%s messages are not compressible",
+			msg.MsgCommand)
+		return nil, messageError(op, ErrNotCompressible, str)
+	}
+	wrapped, err := makeEmptyMessage(msg.MsgCommand)
+	if err != nil {
+		return nil, err
+	}
+	if uint32(len(msg.Payload)) > wrapped.MaxPayloadLength(pver) {
+		str := fmt.Sprintf("%s payload size %d exceeds the maximum allowed "+
+			"size of %d", msg.MsgCommand, len(msg.Payload),
+			wrapped.MaxPayloadLength(pver))
+		return nil, messageError(op, ErrPayloadTooLarge, str)
+	}
+	r := bytes.NewReader(msg.Payload)
+	if err := wrapped.BtcDecode(r, pver); err != nil {
+		return nil, err
+	}
+	if r.Len() != 0 {
+		str := fmt.Sprintf("%s payload has %d unused bytes", msg.MsgCommand,
+			r.Len())
+		return nil, messageError(op, ErrMalformedCompression, str)
+	}
+	return wrapped, nil
+}
+
+// NewMsgCompressed returns a new Decred compressed message that conforms to
+// the Message interface and wraps the provided message which will be
+// compressed with the provided algorithm when the returned message is
+// encoded.  See MsgCompressed for details.
+//
+// An error is returned when the algorithm is unknown or the provided message
+// is not allowed to be compressed.
+func NewMsgCompressed(algorithm CompressionAlgorithm, msg Message, pver uint32) (*MsgCompressed, error) {
+	const op = "NewMsgCompressed"
+	if !algorithm.IsKnown() {
+		str := fmt.Sprintf("unknown compression algorithm %d",
+			uint8(algorithm))
+		return nil, messageError(op, ErrUnknownCompression, str)
+	}
+	command := msg.Command()
+	if !isCompressibleCommand(command) {
+		str := fmt.Sprintf("%s messages are not compressible", command)
+		return nil, messageError(op, ErrNotCompressible, str)
+	}
+
+	var payload bytes.Buffer
+	if err := msg.BtcEncode(&payload, pver); err != nil {
+		return nil, err
+	}
+	return &MsgCompressed{
+		Algorithm:  algorithm,
+		MsgCommand: command,
+		Payload:    payload.Bytes(),
+	}, nil
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestCompressed tests the MsgCompressed API against the latest protocol
+// version.
+func TestCompressed(t *testing.T) {
+	pver := ProtocolVersion
+
+	// Ensure the command is expected value.
+	wantCmd := "compressed"
+	msg, err := NewMsgCompressed(CompressionDeflate, &testBlock, pver)
+	if err != nil {
+		t.Fatalf("NewMsgCompressed: unexpected error: %v", err)
+	}
+	if cmd := msg.Command(); cmd != wantCmd {
+		t.Errorf("NewMsgCompressed: wrong command - got %v want %v", cmd,
+			wantCmd)
+	}
+	if msg.MsgCommand != CmdBlock {
+		t.Errorf("NewMsgCompressed: wrong wrapped command - got %v want %v",
+			msg.MsgCommand, CmdBlock)
+	}
+	if !bytes.Equal(msg.Payload, testBlockBytes) {
+		t.Errorf("NewMsgCompressed: wrong payload\n got: %s want: %s",
+			spew.Sdump(msg.Payload), spew.Sdump(testBlockBytes))
+	}
+
+	// Ensure max payload is expected value for latest protocol version.
+	wantPayload := uint32(MaxMessagePayload)
+	maxPayload := msg.MaxPayloadLength(pver)
+	if maxPayload != wantPayload {
+		t.Errorf("MaxPayloadLength: wrong max payload length for protocol "+
+			"version %d - got %v, want %v", pver, maxPayload, wantPayload)
+	}
+
+	// Ensure max payload is zero for protocol versions before the message was
+	// introduced.
+	if maxPayload := msg.MaxPayloadLength(CompressionVersion - 1); maxPayload != 0 {
+		t.Errorf("MaxPayloadLength: wrong max payload length for protocol "+
+			"version %d - got %v, want 0", CompressionVersion-1, maxPayload)
+	}
+
+	// Ensure messages that are not compressible and unknown algorithms are
+	// rejected.
+	_, err = NewMsgCompressed(CompressionDeflate, NewMsgPing(0), pver)
+	if !errors.Is(err, ErrNotCompressible) {
+		t.Errorf("NewMsgCompressed: wrong error - got %v, want %v", err,
+			ErrNotCompressible)
+	}
+	_, err = NewMsgCompressed(CompressionAlgorithm(0xff), &testBlock, pver)
+	if !errors.Is(err, ErrUnknownCompression) {
+		t.Errorf("NewMsgCompressed: wrong error - got %v, want %v", err,
+			ErrUnknownCompression)
+	}
+}
+
+// TestCompressedWire tests the MsgCompressed wire encode and decode along with
+// decoding the wrapped messages.
+func TestCompressedWire(t *testing.T) {
+	pver := ProtocolVersion
+	tests := []Message{
+		&testBlock,
+		baseMsgCFilterV2(t),
+		NewMsgCFilter(&testBlock.Header.PrevBlock, GCSFilterRegular,
+			bytes.Repeat([]byte{0x5a}, 1000)),
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		msg, err := NewMsgCompressed(CompressionDeflate, test, pver)
+		if err != nil {
+			t.Errorf("NewMsgCompressed #%d error %v", i, err)
+			continue
+		}
+
+		// Encode the message to wire format.
+		var buf bytes.Buffer
+		if err := msg.BtcEncode(&buf, pver); err != nil {
+			t.Errorf("BtcEncode #%d error %v", i, err)
+			continue
+		}
+
+		// Decode the message from wire format.
+		var decoded MsgCompressed
+		if err := decoded.BtcDecode(&buf, pver); err != nil {
+			t.Errorf("BtcDecode #%d error %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(&decoded, msg) {
+			t.Errorf("BtcDecode #%d\n got: %s want: %s", i,
+				spew.Sdump(decoded), spew.Sdump(msg))
+			continue
+		}
+
+		// Ensure the wrapped message decodes to the original message.
+		wrapped, err := decoded.Message(pver)
+		if err != nil {
+			t.Errorf("Message #%d error %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(wrapped, test) {
+			t.Errorf("Message #%d\n got: %s want: %s", i,
+				spew.Sdump(wrapped), spew.Sdump(test))
+			continue
+		}
+	}
+}
+
+// TestCompressedWireErrors performs negative tests against wire encode and
+// decode of MsgCompressed to confirm error paths work correctly.
+func TestCompressedWireErrors(t *testing.T) {
+	pver := ProtocolVersion
+
+	// deflate returns the provided data compressed with DEFLATE.
+	deflate := func(data []byte) []byte {
+		var buf bytes.Buffer
+		w, err := flate.NewWriter(&buf, flate.BestSpeed)
+		if err != nil {
+			t.Fatalf("unable to create compressor: %v", err)
+		}
+		w.Write(data)
+		w.Close()
+		return buf.Bytes()
+	}
+
+	// encode returns a compressed message encoding with the provided fields
+	// which allows creating encodings that are otherwise not possible.
+	encode := func(algorithm uint8, command string, size uint32, compressed []byte) []byte {
+		var buf bytes.Buffer
+		writeElement(&buf, algorithm)
+		WriteVarString(&buf, pver, command)
+		writeElement(&buf, size)
+		WriteVarBytes(&buf, pver, compressed)
+		return buf.Bytes()
+	}
+
+	payload := bytes.Repeat([]byte{0x01}, 100)
+	tests := []struct {
+		name string // test description
+		buf  []byte // wire encoding
+		pver uint32 // protocol version for wire encoding
+		err  error  // expected read error
+	}{{
+		name: "unsupported protocol version",
+		buf:  encode(1, CmdBlock, 100, deflate(payload)),
+		pver: CompressionVersion - 1,
+		err:  ErrMsgInvalidForPVer,
+	}, {
+		name: "unknown algorithm",
+		buf:  encode(0xff, CmdBlock, 100, deflate(payload)),
+		pver: pver,
+		err:  ErrUnknownCompression,
+	}, {
+		name: "not compressible command",
+		buf:  encode(1, CmdTx, 100, deflate(payload)),
+		pver: pver,
+		err:  ErrNotCompressible,
+	}, {
+		name: "declared size exceeds max wrapped payload",
+		buf:  encode(1, CmdCFilter, MaxMessagePayload+1, deflate(payload)),
+		pver: pver,
+		err:  ErrPayloadTooLarge,
+	}, {
+		name: "decompresses to less than declared size",
+		buf:  encode(1, CmdBlock, 101, deflate(payload)),
+		pver: pver,
+		err:  ErrMalformedCompression,
+	}, {
+		name: "decompresses to more than declared size",
+		buf:  encode(1, CmdBlock, 99, deflate(payload)),
+		pver: pver,
+		err:  ErrMalformedCompression,
+	}, {
+		name: "invalid compressed data",
+		buf:  encode(1, CmdBlock, 100, payload),
+		pver: pver,
+		err:  ErrMalformedCompression,
+	}}
+
+	for _, test := range tests {
+		var msg MsgCompressed
+		err := msg.BtcDecode(bytes.NewReader(test.buf), test.pver)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%q: wrong error - got %v, want %v", test.name, err,
+				test.err)
+		}
+	}
+
+	// Ensure encoding fails for unsupported protocol versions, unknown
+	// algorithms, and commands that are not compressible.
+	encodeTests := []struct {
+		name string         // test description
+		msg  *MsgCompressed // message to encode
+		pver uint32         // protocol version for wire encoding
+		err  error          // expected write error
+	}{{
+		name: "unsupported protocol version",
+		msg:  &MsgCompressed{CompressionDeflate, CmdBlock, payload},
+		pver: CompressionVersion - 1,
+		err:  ErrMsgInvalidForPVer,
+	}, {
+		name: "unknown algorithm",
+		msg:  &MsgCompressed{0xff, CmdBlock, payload},
+		pver: pver,
+		err:  ErrUnknownCompression,
+	}, {
+		name: "not compressible command",
+		msg:  &MsgCompressed{CompressionDeflate, CmdTx, payload},
+		pver: pver,
+		err:  ErrNotCompressible,
+	}}
+	for _, test := range encodeTests {
+		var buf bytes.Buffer
+		err := test.msg.BtcEncode(&buf, test.pver)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%q: wrong error - got %v, want %v", test.name, err,
+				test.err)
+		}
+	}
+
+	// Ensure decoding the wrapped message fails when the payload has unused
+	// bytes.
+	msg, err := NewMsgCompressed(CompressionDeflate, &testBlock, pver)
+	if err != nil {
+		t.Fatalf("NewMsgCompressed: unexpected error: %v", err)
+	}
+	msg.Payload = append(msg.Payload, 0x00)
+	if _, err := msg.Message(pver); !errors.Is(err, ErrMalformedCompression) {
+		t.Errorf("Message: wrong error - got %v, want %v", err,
+			ErrMalformedCompression)
+	}
+}
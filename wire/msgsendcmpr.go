@@ -0,0 +1,110 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"fmt"
+	"io"
+)
+
+// CompressionAlgorithm identifies an algorithm used to compress the payload
+// of a compressed message.
+type CompressionAlgorithm uint8
+
+const (
+	// CompressionDeflate identifies the DEFLATE compression algorithm as
+	// defined by RFC 1951.
+	CompressionDeflate CompressionAlgorithm = 1
+)
+
+// String returns the CompressionAlgorithm as a human-readable name.
+func (a CompressionAlgorithm) String() string {
+	switch a {
+	case CompressionDeflate:
+		return "deflate"
+	}
+	return fmt.Sprintf("Unknown CompressionAlgorithm (%d)", uint8(a))
+}
+
+// IsKnown returns whether or not the compression algorithm is known.
+func (a CompressionAlgorithm) IsKnown() bool {
+	return a == CompressionDeflate
+}
+
+// MsgSendCmpr implements the Message interface and represents a Decred
+// sendcmpr message.  It is used to inform the receiving peer that the sender
+// consents to receiving the payloads of blocks and committed filters that are
+// at least the specified minimum size compressed with the specified algorithm
+// via compressed messages.
+//
+// This message was not added until protocol versions starting with
+// CompressionVersion.
+type MsgSendCmpr struct {
+	// Algorithm is the compression algorithm the sender is able to
+	// decompress.
+	Algorithm CompressionAlgorithm
+
+	// MinSize is the minimum uncompressed payload size in bytes of the
+	// messages the sender wishes to receive compressed.
+	MinSize uint32
+}
+
+// BtcDecode decodes r using the Decred protocol encoding into the receiver.
+// This is part of the Message interface implementation.
+func (msg *MsgSendCmpr) BtcDecode(r io.Reader, pver uint32) error {
+	const op = "MsgSendCmpr.BtcDecode"
+	if pver < CompressionVersion {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+
+	var algorithm uint8
+	err := readElements(r, &algorithm, &msg.MinSize)
+	if err != nil {
+		return err
+	}
+	msg.Algorithm = CompressionAlgorithm(algorithm)
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the Decred protocol encoding.
+// This is part of the Message interface implementation.
+func (msg *MsgSendCmpr) BtcEncode(w io.Writer, pver uint32) error {
+	const op = "MsgSendCmpr.BtcEncode"
+	if pver < CompressionVersion {
+		msg := fmt.Sprintf("%s message invalid for protocol version %d",
+			msg.Command(), pver)
+		return messageError(op, ErrMsgInvalidForPVer, msg)
+	}
+
+	return writeElements(w, uint8(msg.Algorithm), msg.MinSize)
+}
+
+// Command returns the protocol command string for the message.  This is part
+// of the Message interface implementation.
+func (msg *MsgSendCmpr) Command() string {
+	return CmdSendCmpr
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the
+// receiver.  This is part of the Message interface implementation.
+func (msg *MsgSendCmpr) MaxPayloadLength(pver uint32) uint32 {
+	if pver < CompressionVersion {
+		return 0
+	}
+
+	// 1 byte algorithm + 4 bytes min size.
+	return 5
+}
+
+// NewMsgSendCmpr returns a new Decred sendcmpr message that conforms to the
+// Message interface using the passed parameters.  See MsgSendCmpr for details.
+func NewMsgSendCmpr(algorithm CompressionAlgorithm, minSize uint32) *MsgSendCmpr {
+	return &MsgSendCmpr{
+		Algorithm: algorithm,
+		MinSize:   minSize,
+	}
+}
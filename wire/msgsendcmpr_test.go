@@ -0,0 +1,166 @@
+// Copyright (c) 2022 The Decred developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wire
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestSendCmpr tests the MsgSendCmpr API against the latest protocol version.
+func TestSendCmpr(t *testing.T) {
+	pver := ProtocolVersion
+
+	// Ensure the command is expected value.
+	wantCmd := "sendcmpr"
+	msg := NewMsgSendCmpr(CompressionDeflate, 1024)
+	if cmd := msg.Command(); cmd != wantCmd {
+		t.Errorf("NewMsgSendCmpr: wrong command - got %v want %v", cmd,
+			wantCmd)
+	}
+
+	// Ensure max payload is expected value for latest protocol version.
+	wantPayload := uint32(5)
+	maxPayload := msg.MaxPayloadLength(pver)
+	if maxPayload != wantPayload {
+		t.Errorf("MaxPayloadLength: wrong max payload length for protocol "+
+			"version %d - got %v, want %v", pver, maxPayload, wantPayload)
+	}
+
+	// Ensure max payload is zero for protocol versions before the message was
+	// introduced.
+	if maxPayload := msg.MaxPayloadLength(CompressionVersion - 1); maxPayload != 0 {
+		t.Errorf("MaxPayloadLength: wrong max payload length for protocol "+
+			"version %d - got %v, want 0", CompressionVersion-1, maxPayload)
+	}
+
+	// Ensure the algorithm strings are the expected values.
+	if s := CompressionDeflate.String(); s != "deflate" {
+		t.Errorf("String: wrong string - got %q, want %q", s, "deflate")
+	}
+	if s := CompressionAlgorithm(0xff).String(); s != "Unknown CompressionAlgorithm (255)" {
+		t.Errorf("String: wrong string - got %q", s)
+	}
+}
+
+// TestSendCmprWire tests the MsgSendCmpr wire encode and decode for various
+// protocol versions.
+func TestSendCmprWire(t *testing.T) {
+	msgSendCmpr := NewMsgSendCmpr(CompressionDeflate, 0x01020304)
+	msgSendCmprEncoded := []byte{
+		0x01,                   // Algorithm
+		0x04, 0x03, 0x02, 0x01, // Min size
+	}
+
+	tests := []struct {
+		in   *MsgSendCmpr // Message to encode
+		out  *MsgSendCmpr // Expected decoded message
+		buf  []byte       // Wire encoding
+		pver uint32       // Protocol version for wire encoding
+	}{
+		// Latest protocol version.
+		{
+			msgSendCmpr,
+			msgSendCmpr,
+			msgSendCmprEncoded,
+			ProtocolVersion,
+		},
+
+		// Protocol version CompressionVersion.
+		{
+			msgSendCmpr,
+			msgSendCmpr,
+			msgSendCmprEncoded,
+			CompressionVersion,
+		},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		// Encode the message to wire format.
+		var buf bytes.Buffer
+		err := test.in.BtcEncode(&buf, test.pver)
+		if err != nil {
+			t.Errorf("BtcEncode #%d error %v", i, err)
+			continue
+		}
+		if !bytes.Equal(buf.Bytes(), test.buf) {
+			t.Errorf("BtcEncode #%d\n got: %s want: %s", i,
+				spew.Sdump(buf.Bytes()), spew.Sdump(test.buf))
+			continue
+		}
+
+		// Decode the message from wire format.
+		var msg MsgSendCmpr
+		rbuf := bytes.NewReader(test.buf)
+		err = msg.BtcDecode(rbuf, test.pver)
+		if err != nil {
+			t.Errorf("BtcDecode #%d error %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(&msg, test.out) {
+			t.Errorf("BtcDecode #%d\n got: %s want: %s", i,
+				spew.Sdump(msg), spew.Sdump(test.out))
+			continue
+		}
+	}
+}
+
+// TestSendCmprWireErrors performs negative tests against wire encode and
+// decode of MsgSendCmpr to confirm error paths work correctly.
+func TestSendCmprWireErrors(t *testing.T) {
+	pver := ProtocolVersion
+	pverNoSendCmpr := CompressionVersion - 1
+
+	baseSendCmpr := NewMsgSendCmpr(CompressionDeflate, 1024)
+	baseSendCmprEncoded := []byte{
+		0x01,                   // Algorithm
+		0x00, 0x04, 0x00, 0x00, // Min size
+	}
+
+	tests := []struct {
+		in       *MsgSendCmpr // Value to encode
+		buf      []byte       // Wire encoding
+		pver     uint32       // Protocol version for wire encoding
+		max      int          // Max size of fixed buffer to induce errors
+		writeErr error        // Expected write error
+		readErr  error        // Expected read error
+	}{
+		// Force error in algorithm.
+		{baseSendCmpr, baseSendCmprEncoded, pver, 0, io.ErrShortWrite, io.EOF},
+		// Force error in min size.
+		{baseSendCmpr, baseSendCmprEncoded, pver, 1, io.ErrShortWrite, io.EOF},
+		// Force error due to unsupported protocol version.
+		{baseSendCmpr, baseSendCmprEncoded, pverNoSendCmpr, 5,
+			ErrMsgInvalidForPVer, ErrMsgInvalidForPVer},
+	}
+
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		// Encode to wire format.
+		w := newFixedWriter(test.max)
+		err := test.in.BtcEncode(w, test.pver)
+		if !errors.Is(err, test.writeErr) {
+			t.Errorf("BtcEncode #%d wrong error got: %v, want: %v", i, err,
+				test.writeErr)
+			continue
+		}
+
+		// Decode from wire format.
+		var msg MsgSendCmpr
+		r := newFixedReader(test.max, test.buf)
+		err = msg.BtcDecode(r, test.pver)
+		if !errors.Is(err, test.readErr) {
+			t.Errorf("BtcDecode #%d wrong error got: %v, want: %v", i, err,
+				test.readErr)
+			continue
+		}
+	}
+}
@@ -17,7 +17,7 @@ const (
 	InitialProcotolVersion uint32 = 1
 
 	// ProtocolVersion is the latest protocol version this package supports.
-	ProtocolVersion uint32 = 9
+	ProtocolVersion uint32 = 11
 
 	// NodeBloomVersion is the protocol version which added the SFNodeBloom
 	// service flag (unused).
@@ -51,6 +51,15 @@ const (
 	// RemoveRejectVersion is the protocol version which removes support for the
 	// reject message.
 	RemoveRejectVersion uint32 = 9
+
+	// CompressionVersion is the protocol version which adds the sendcmpr and
+	// compressed messages.
+	CompressionVersion uint32 = 10
+
+	// TSpendInvVersion is the protocol version which adds the tspend inventory
+	// vector type so treasury spends are announced separately from other
+	// transactions.
+	TSpendInvVersion uint32 = 11
 )
 
 // ServiceFlag identifies services supported by a Decred peer.